@@ -0,0 +1,99 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aware
+
+import (
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+// AdminResultType the type of the applied admin command
+type AdminResultType int
+
+const (
+	// AdminSplit the shard was splited into the new shards
+	AdminSplit AdminResultType = iota
+	// AdminConfigChange the membership of the shard was changed
+	AdminConfigChange
+	// AdminCompactLog the raft log of the shard was compacted
+	AdminCompactLog
+	// AdminUpdateMetadata the metadata of the shard was updated
+	AdminUpdateMetadata
+	// AdminUpdateLabels the labels of the shard was updated
+	AdminUpdateLabels
+	// AdminUpdateGate the gate of the shard was updated
+	AdminUpdateGate
+	// AdminUpdateAppLease the application lease of the shard was acquired,
+	// renewed or released
+	AdminUpdateAppLease
+	// AdminPurge the data of the shard was purged
+	AdminPurge
+)
+
+// String returns the name of the admin result type
+func (t AdminResultType) String() string {
+	switch t {
+	case AdminSplit:
+		return "split"
+	case AdminConfigChange:
+		return "config-change"
+	case AdminCompactLog:
+		return "compact-log"
+	case AdminUpdateMetadata:
+		return "update-metadata"
+	case AdminUpdateLabels:
+		return "update-labels"
+	case AdminUpdateGate:
+		return "update-gate"
+	case AdminUpdateAppLease:
+		return "update-app-lease"
+	case AdminPurge:
+		return "purge"
+	}
+	return "unknown"
+}
+
+// AdminResult is the result of an admin command applied on the current store
+type AdminResult struct {
+	// Type the type of the admin command
+	Type AdminResultType
+	// Shard the shard metadata after the admin command applied. For AdminSplit
+	// it's the metadata of the splited shard.
+	Shard metapb.Shard
+	// Index the raft log index of the admin command
+	Index uint64
+	// NewShards the new shards created by the split, only for AdminSplit
+	NewShards []metapb.Shard
+	// ConfigChanges the membership changes, only for AdminConfigChange
+	ConfigChanges []rpcpb.ConfigChangeRequest
+	// CompactIndex the raft logs before the index are compacted, only for
+	// AdminCompactLog
+	CompactIndex uint64
+	// Purge the marker of the purged data, only for AdminPurge. The backup
+	// tooling can record it to purge the data again after a restore.
+	Purge *metapb.PurgeMarker
+	// CommitTime the wall-clock time in nanoseconds stamped by the leader when
+	// the admin command was proposed, it's the same on all replicas and never
+	// goes backwards in the log of the shard, see storage.Batch.CommitTime. Zero
+	// if the command was proposed by a version without the stamp.
+	CommitTime int64
+}
+
+// AdminResultAware is notified after the admin commands are applied, so the
+// embedders can maintain their own metadata. The methods are called in the
+// event worker of the shard, and should not be blocked.
+type AdminResultAware interface {
+	// AdminApplied the admin command was applied on the current store
+	AdminApplied(AdminResult)
+}
@@ -16,10 +16,12 @@ package client
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/fagongzi/util/hack"
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/pb/txnpb"
@@ -52,7 +54,8 @@ func releaseFuture(f *Future) {
 // Option client option
 type Option func(*rpcpb.Request)
 
-// WithShardGroup set shard group to execute the request
+// WithShardGroup set shard group to execute the request. If the client has a
+// key router, the group must be the routed group of the route key.
 func WithShardGroup(group uint64) Option {
 	return func(req *rpcpb.Request) {
 		req.Group = group
@@ -90,6 +93,54 @@ func WithReplicaSelectPolicy(policy rpcpb.ReplicaSelectPolicy) Option {
 	}
 }
 
+// WithStaleReadAllowed allows the read to be served as a stale read if the
+// replica's apply lag exceeds the threshold of the read load shedding, the lag
+// is returned by Future.ApplyLag. Without it, such reads fail fast with
+// raftstore.ApplyLagTooLargeErr. The stale reads are also served by the
+// surviving replicas of a shard which has lost the write quorum, the other
+// requests of the shard fail fast with raftstore.QuorumLostErr.
+func WithStaleReadAllowed() Option {
+	return func(req *rpcpb.Request) {
+		req.AllowStaleRead = true
+	}
+}
+
+// WithFollowerRead allows the read to be served by a follower replica. The
+// follower confirms the read index with the leader before reading its local
+// state, so the read is still linearizable. With the default SelectLeader
+// policy, the follower reads are spread over all replicas of the shard.
+func WithFollowerRead() Option {
+	return func(req *rpcpb.Request) {
+		req.FollowerRead = true
+	}
+}
+
+// WithAppLeaseToken tags the write with the fencing token of the shard's
+// application lease, the write fails with raftstore.AppLeaseMismatchErr if the
+// token is not the token of the current lease.
+func WithAppLeaseToken(token uint64) Option {
+	return func(req *rpcpb.Request) {
+		req.AppLeaseToken = token
+	}
+}
+
+// WithColumnFamily set the column family of the request, the column family
+// must be defined by the data storage of the shard group.
+func WithColumnFamily(cf string) Option {
+	return func(req *rpcpb.Request) {
+		req.CF = cf
+	}
+}
+
+// WithPriority set the priority of the request, the replica proposes the
+// requests of higher priority first. The requests of different priorities are
+// not batched together, and may be reordered.
+func WithPriority(priority rpcpb.RequestPriority) Option {
+	return func(req *rpcpb.Request) {
+		req.Priority = priority
+	}
+}
+
 // WithLease set the Lease for request
 func WithLease(lease *metapb.EpochLease) Option {
 	return func(req *rpcpb.Request) {
@@ -119,6 +170,53 @@ type Client interface {
 
 	// AddLabelToShard add lable to shard, and use the `Future` to get the response
 	AddLabelToShard(ctx context.Context, name, value string, shard uint64) *Future
+	// UpdateShardGate update the gate of the shard to block the reads or writes of
+	// the shard, and use the `Future` to get the response. Use an empty gate to
+	// unblock the shard.
+	UpdateShardGate(ctx context.Context, gate metapb.ShardGate, shard uint64) *Future
+	// AcquireAppLease acquires or renews the application lease of the shard for
+	// the holder, and use the `Future.GetAcquireAppLeaseResponse` to get the lease
+	// and its fencing token. The writes should be tagged with the token by
+	// `WithAppLeaseToken`.
+	AcquireAppLease(ctx context.Context, holder string, ttl time.Duration, shard uint64) *Future
+	// ReleaseAppLease releases the application lease of the shard held with the
+	// token, and use the `Future` to get the response.
+	ReleaseAppLease(ctx context.Context, token uint64, shard uint64) *Future
+	// SplitShard splits the shard by the split keys, e.g. to pre-split the shard
+	// before the bulk load, and use the `Future` to get the response. The keys
+	// must be in ascending order and inside the shard range. The response only
+	// means that the split is submitted by the shard leader, the new shards can
+	// be found in the router once the split is done.
+	SplitShard(ctx context.Context, splitKeys [][]byte, shard uint64) *Future
+	// Barrier proposes a no-op barrier to the shard, and use the
+	// `Future.GetBarrierResponse` to get the index of the applied barrier. All
+	// the requests proposed to the shard before the barrier are applied at the
+	// index.
+	Barrier(ctx context.Context, shard uint64) *Future
+	// ConsistentWatermark proposes the barriers to the shards and returns the
+	// indexes of the applied barriers. An embedder can take an externally
+	// consistent snapshot of its own indexes maintained on top of the shards by
+	// the returned watermarks.
+	ConsistentWatermark(ctx context.Context, shards ...uint64) ([]ShardWatermark, error)
+	// ComputeHash proposes a request to compute the data checksum on every
+	// replica of the shard, and use the `Future.GetComputeHashResponse` to get
+	// the applied index and the checksum of the leader.
+	ComputeHash(ctx context.Context, shard uint64) *Future
+	// VerifyHash proposes a request to compare the data checksum computed by
+	// every replica at the index with the hash, the replicas diverging from the
+	// hash are reported to prophet.
+	VerifyHash(ctx context.Context, index, hash uint64, shard uint64) *Future
+	// CheckConsistency computes the data checksum of the shard on every replica
+	// and verifies them with the checksum of the leader, returns the applied
+	// index of the computed checksums. The divergence is found by the replicas
+	// asynchronously, and reported to prophet.
+	CheckConsistency(ctx context.Context, shard uint64) (uint64, error)
+	// Purge proposes a request to delete the data of the [start, end) range and
+	// the keys on every replica of the shard, the range is not purged if both
+	// start and end are empty. Every replica records a purge marker proving the
+	// deletion at the applied index, and use the `Future.GetPurgeResponse` to
+	// get the marker.
+	Purge(ctx context.Context, start, end []byte, keys [][]byte, shard uint64) *Future
 }
 
 var _ Client = (*client)(nil)
@@ -127,6 +225,7 @@ var _ Client = (*client)(nil)
 type client struct {
 	logger      *zap.Logger
 	shardsProxy raftstore.ShardsProxy
+	keyRouter   config.KeyRouter
 
 	mu struct {
 		sync.RWMutex
@@ -137,7 +236,8 @@ type client struct {
 // NewClient creates and return a cube client
 func NewClient(cfg Cfg) Client {
 	return NewClientWithOptions(CreateWithLogger(cfg.Store.GetConfig().Logger.Named("cube-client")),
-		CreateWithShardsProxy(cfg.Store.GetShardsProxy()))
+		CreateWithShardsProxy(cfg.Store.GetShardsProxy()),
+		CreateWithKeyRouter(cfg.Store.GetConfig().Customize.CustomKeyRouter))
 }
 
 // NewClientWithOptions create client with options
@@ -202,6 +302,46 @@ func (s *client) AddLabelToShard(ctx context.Context, name, value string, shard
 	return s.exec(ctx, uint64(rpcpb.CmdUpdateLabels), payload, rpcpb.Admin, nil, WithShard(shard))
 }
 
+func (s *client) UpdateShardGate(ctx context.Context, gate metapb.ShardGate, shard uint64) *Future {
+	payload := protoc.MustMarshal(&rpcpb.UpdateGateRequest{Gate: gate})
+	return s.exec(ctx, uint64(rpcpb.CmdUpdateGate), payload, rpcpb.Admin, nil, WithShard(shard))
+}
+
+func (s *client) AcquireAppLease(ctx context.Context, holder string, ttl time.Duration, shard uint64) *Future {
+	payload := protoc.MustMarshal(&rpcpb.AcquireAppLeaseRequest{Holder: holder, TTL: int64(ttl)})
+	return s.exec(ctx, uint64(rpcpb.CmdAcquireAppLease), payload, rpcpb.Admin, nil, WithShard(shard))
+}
+
+func (s *client) ReleaseAppLease(ctx context.Context, token uint64, shard uint64) *Future {
+	payload := protoc.MustMarshal(&rpcpb.ReleaseAppLeaseRequest{Token: token})
+	return s.exec(ctx, uint64(rpcpb.CmdReleaseAppLease), payload, rpcpb.Admin, nil, WithShard(shard))
+}
+
+func (s *client) SplitShard(ctx context.Context, splitKeys [][]byte, shard uint64) *Future {
+	payload := protoc.MustMarshal(&rpcpb.SplitShardRequest{SplitKeys: splitKeys})
+	return s.exec(ctx, uint64(rpcpb.CmdSplitShard), payload, rpcpb.Admin, nil, WithShard(shard))
+}
+
+func (s *client) Barrier(ctx context.Context, shard uint64) *Future {
+	payload := protoc.MustMarshal(&rpcpb.BarrierRequest{})
+	return s.exec(ctx, uint64(rpcpb.CmdBarrier), payload, rpcpb.Admin, nil, WithShard(shard))
+}
+
+func (s *client) ComputeHash(ctx context.Context, shard uint64) *Future {
+	payload := protoc.MustMarshal(&rpcpb.ComputeHashRequest{})
+	return s.exec(ctx, uint64(rpcpb.CmdComputeHash), payload, rpcpb.Admin, nil, WithShard(shard))
+}
+
+func (s *client) VerifyHash(ctx context.Context, index, hash uint64, shard uint64) *Future {
+	payload := protoc.MustMarshal(&rpcpb.VerifyHashRequest{Index: index, Hash: hash})
+	return s.exec(ctx, uint64(rpcpb.CmdVerifyHash), payload, rpcpb.Admin, nil, WithShard(shard))
+}
+
+func (s *client) Purge(ctx context.Context, start, end []byte, keys [][]byte, shard uint64) *Future {
+	payload := protoc.MustMarshal(&rpcpb.PurgeRequest{Start: start, End: end, Keys: keys})
+	return s.exec(ctx, uint64(rpcpb.CmdPurge), payload, rpcpb.Admin, nil, WithShard(shard))
+}
+
 func (s *client) exec(ctx context.Context, requestType uint64, payload []byte, cmdType rpcpb.CmdType, txnRequest *txnpb.TxnBatchRequest, opts ...Option) *Future {
 	f := newFuture(ctx)
 	f.req.ID = uuid.NewV4().Bytes()
@@ -225,6 +365,13 @@ func (s *client) exec(ctx context.Context, requestType uint64, payload []byte, c
 	if _, ok := ctx.Deadline(); !ok {
 		s.logger.Fatal("cube client must use timeout context")
 	}
+	// the stores give up the request once the timeout elapses, instead of
+	// proposing the request no one is waiting for
+	f.req.Timeout = f.timeout()
+	if err := s.routeGroup(&f.req); err != nil {
+		f.done(nil, nil, err)
+		return f
+	}
 
 	if ce := s.logger.Check(zap.DebugLevel, "begin to send request"); ce != nil {
 		ce.Write(log.RequestIDField(f.req.ID))
@@ -236,10 +383,31 @@ func (s *client) exec(ctx context.Context, requestType uint64, payload []byte, c
 	return f
 }
 
+// routeGroup sets the group of the request to the group routed by the key
+// router. The request group set by WithShardGroup is kept only if it's the
+// default group or the routed group.
+func (s *client) routeGroup(req *rpcpb.Request) error {
+	if s.keyRouter == nil || len(req.Key) == 0 {
+		return nil
+	}
+
+	group, ok := s.keyRouter.Route(req.Key)
+	if !ok {
+		return nil
+	}
+	if req.Group != 0 && req.Group != group {
+		return raftstore.NewGroupMismatchErr(req.Key, req.Group, group)
+	}
+	req.Group = group
+	return nil
+}
+
 func (s *client) Retry(requestID []byte) (rpcpb.Request, bool) {
 	if f, ok := s.getInfight(hack.SliceToString(requestID)); ok {
 		if f.canRetry() {
-			return f.req, true
+			req := f.req
+			req.Timeout = f.timeout()
+			return req, true
 		}
 	}
 
@@ -254,6 +422,7 @@ func (s *client) done(resp rpcpb.Response) {
 	id := hack.SliceToString(resp.ID)
 	if f, ok := s.getInfight(id); ok {
 		s.deleteInfight(id)
+		f.setApplyLag(resp.ApplyLag)
 		f.done(resp.Value, resp.TxnBatchResponse, nil)
 	} else {
 		if ce := s.logger.Check(zap.DebugLevel, "response skipped"); ce != nil {
@@ -14,12 +14,15 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"testing"
 	"time"
 
+	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/raftstore"
 	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/storage/executor"
@@ -46,6 +49,8 @@ func TestExec(t *testing.T) {
 	req := newTestWriteCustomRequest("k", "v")
 	f := s.Write(ctx, req.CmdType, req.Cmd, WithRouteKey(req.Key))
 	defer f.Close()
+	// the relative timeout is sent, not the deadline
+	assert.True(t, f.req.Timeout > 0 && f.req.Timeout <= int64(time.Minute))
 	_, err := f.Get()
 	assert.NoError(t, err)
 }
@@ -105,6 +110,192 @@ func TestAddShardLabel(t *testing.T) {
 	c.WaitShardByLabel(sid, "l1", "v1", time.Minute)
 }
 
+func TestUpdateShardGate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	c.WaitShardByCount(1, time.Minute)
+	sid := c.GetShardByIndex(0, 0).ID
+
+	update := func(gate metapb.ShardGate) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		f := s.UpdateShardGate(ctx, gate, sid)
+		defer f.Close()
+		_, err := f.Get()
+		assert.NoError(t, err)
+	}
+	write := func(timeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		req := newTestWriteCustomRequest("k", "v")
+		f := s.Write(ctx, req.CmdType, req.Cmd, WithRouteKey(req.Key))
+		defer f.Close()
+		_, err := f.Get()
+		return err
+	}
+
+	update(metapb.ShardGate{DisableWrite: true, Redirect: "new-cluster"})
+	assert.Error(t, write(time.Millisecond*200))
+
+	update(metapb.ShardGate{})
+	assert.NoError(t, write(time.Minute))
+}
+
+func TestAppLease(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	c.WaitShardByCount(1, time.Minute)
+	sid := c.GetShardByIndex(0, 0).ID
+
+	acquire := func(holder string) rpcpb.AcquireAppLeaseResponse {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		f := s.AcquireAppLease(ctx, holder, time.Minute, sid)
+		defer f.Close()
+		resp, err := f.GetAcquireAppLeaseResponse()
+		assert.NoError(t, err)
+		return resp
+	}
+	write := func(token uint64) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		req := newTestWriteCustomRequest("k", "v")
+		f := s.Write(ctx, req.CmdType, req.Cmd, WithRouteKey(req.Key), WithAppLeaseToken(token))
+		defer f.Close()
+		_, err := f.Get()
+		return err
+	}
+
+	resp := acquire("a")
+	assert.True(t, resp.Acquired)
+	assert.False(t, acquire("b").Acquired)
+	assert.NoError(t, write(resp.Lease.Token))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	f := s.ReleaseAppLease(ctx, resp.Lease.Token, sid)
+	defer f.Close()
+	_, err := f.Get()
+	assert.NoError(t, err)
+
+	assert.True(t, acquire("b").Acquired)
+	assert.True(t, raftstore.IsAppLeaseMismatchErr(write(resp.Lease.Token)))
+}
+
+func TestSplitShard(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	c.WaitShardByCount(1, time.Minute)
+	sid := c.GetShardByIndex(0, 0).ID
+
+	split := func(keys ...string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		var splitKeys [][]byte
+		for _, key := range keys {
+			splitKeys = append(splitKeys, []byte(key))
+		}
+		f := s.SplitShard(ctx, splitKeys, sid)
+		defer f.Close()
+		_, err := f.Get()
+		return err
+	}
+
+	assert.Error(t, split())
+	assert.Error(t, split("k2", "k1"))
+	assert.NoError(t, split("k1", "k2"))
+	c.WaitShardSplitByCount(sid, 1, time.Minute)
+	c.WaitShardByCount(3, time.Minute)
+}
+
+func TestPurge(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	c.WaitShardByCount(1, time.Minute)
+	sid := c.GetShardByIndex(0, 0).ID
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	get := func(key string) []byte {
+		req := executor.NewReadRequest([]byte(key))
+		f := s.Read(ctx, req.CmdType, req.Cmd, WithRouteKey(req.Key))
+		defer f.Close()
+		v, err := f.Get()
+		assert.NoError(t, err)
+		var resp rpcpb.KVGetResponse
+		protoc.MustUnmarshal(&resp, v)
+		return resp.Value
+	}
+
+	for _, key := range []string{"k1", "k2", "k3"} {
+		req := newTestWriteCustomRequest(key, "v")
+		f := s.Write(ctx, req.CmdType, req.Cmd, WithRouteKey(req.Key))
+		_, err := f.Get()
+		f.Close()
+		assert.NoError(t, err)
+	}
+
+	f := s.Purge(ctx, []byte("k3"), nil, [][]byte{[]byte("k1")}, sid)
+	defer f.Close()
+	resp, err := f.GetPurgeResponse()
+	assert.NoError(t, err)
+	assert.True(t, resp.Purged)
+	assert.True(t, resp.Index > 0)
+
+	assert.Empty(t, get("k1"))
+	assert.Equal(t, []byte("v"), get("k2"))
+	assert.Empty(t, get("k3"))
+
+	markers, err := c.GetStore(0).GetPurgeMarkers(sid)
+	assert.NoError(t, err)
+	assert.Equal(t, []metapb.PurgeMarker{{
+		Index: resp.Index,
+		Start: []byte("k3"),
+		Keys:  [][]byte{[]byte("k1")},
+	}}, markers)
+}
+
 func TestKeysRangeNotInShard(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -134,6 +325,56 @@ func TestKeysRangeNotInShard(t *testing.T) {
 	assert.Empty(t, v)
 }
 
+func TestKeyRouter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	router := config.KeyRouterFunc(func(key []byte) (uint64, bool) {
+		if bytes.HasPrefix(key, []byte("m")) {
+			return 1, true
+		}
+		return 0, false
+	})
+	c := raftstore.NewSingleTestClusterStore(t, raftstore.WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Customize.CustomInitShardsFactory = func() []metapb.Shard {
+			return []metapb.Shard{{}, {Group: 1}}
+		}
+		cfg.Customize.CustomKeyRouter = router
+	}))
+	c.Start()
+	defer c.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	write := func(s Client, key string, opts ...Option) error {
+		req := newTestWriteCustomRequest(key, "v")
+		f := s.Write(ctx, req.CmdType, req.Cmd, append(opts, WithRouteKey(req.Key))...)
+		defer f.Close()
+		_, err := f.Get()
+		return err
+	}
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	assert.NoError(t, write(s, "k1"))
+	assert.NoError(t, write(s, "m1"))
+	assert.NoError(t, write(s, "m1", WithShardGroup(1)))
+	assert.True(t, raftstore.IsGroupMismatchErr(write(s, "m1", WithShardGroup(2))))
+	assert.NoError(t, s.Stop())
+
+	// the store rejects the requests sent to another group by the clients
+	// without the key router
+	s = NewClientWithOptions(CreateWithShardsProxy(c.GetStore(0).GetShardsProxy()))
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+	assert.NoError(t, write(s, "k1"))
+	err := write(s, "m1")
+	assert.True(t, raftstore.IsGroupMismatchErr(err))
+	assert.Equal(t, raftstore.NewGroupMismatchErr([]byte("m1"), 0, 1), err)
+}
+
 func newTestWriteCustomRequest(k, v string) storage.Request {
 	return executor.NewWriteRequest([]byte(k), []byte(v))
 }
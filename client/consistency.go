@@ -0,0 +1,43 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+func (s *client) CheckConsistency(ctx context.Context, shard uint64) (uint64, error) {
+	f := s.ComputeHash(ctx, shard)
+	defer f.Close()
+	resp, err := f.GetComputeHashResponse()
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute the checksum of shard %d: %w",
+			shard, err)
+	}
+	if !resp.Computed {
+		return 0, fmt.Errorf("the checksum of shard %d is not computed by the leader",
+			shard)
+	}
+
+	// the leader may be changed after the checksum computed, it's fine as the
+	// replicas compare their own checksums at the same index
+	vf := s.VerifyHash(ctx, resp.Index, resp.Hash, shard)
+	defer vf.Close()
+	if _, err := vf.Get(); err != nil {
+		return 0, fmt.Errorf("failed to verify the checksum of shard %d: %w",
+			shard, err)
+	}
+	return resp.Index, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConsistency(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	c.WaitShardByCount(1, time.Minute)
+	shard := c.GetShardByIndex(0, 0).ID
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	f := s.Write(ctx, uint64(rpcpb.CmdKVSet), protoc.MustMarshal(&rpcpb.KVSetRequest{
+		Key:   []byte("k1"),
+		Value: []byte("v1"),
+	}), WithRouteKey([]byte("k1")))
+	_, err := f.Get()
+	f.Close()
+	require.NoError(t, err)
+
+	f = s.ComputeHash(ctx, shard)
+	resp, err := f.GetComputeHashResponse()
+	f.Close()
+	require.NoError(t, err)
+	assert.True(t, resp.Computed)
+	assert.True(t, resp.Index > 0)
+
+	index, err := s.CheckConsistency(ctx, shard)
+	require.NoError(t, err)
+	assert.True(t, index > resp.Index)
+}
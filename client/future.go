@@ -16,6 +16,7 @@ package client
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
@@ -28,6 +29,7 @@ type Future struct {
 	req              rpcpb.Request
 	txnResponse      txnpb.TxnBatchResponse
 	batchGetResponse rpcpb.KVBatchGetResponse
+	applyLag         uint64
 	err              error
 	ctx              context.Context
 	c                chan struct{}
@@ -50,6 +52,7 @@ func (f *Future) reset() {
 	f.value = nil
 	f.txnResponse.Reset()
 	f.batchGetResponse.Reset()
+	f.applyLag = 0
 	f.err = nil
 	f.ctx = nil
 	f.cancel = nil
@@ -105,6 +108,78 @@ func (f *Future) GetKVGetResponse() (rpcpb.KVGetResponse, error) {
 	return resp, nil
 }
 
+// GetAcquireAppLeaseResponse get the acquire app lease response
+func (f *Future) GetAcquireAppLeaseResponse() (rpcpb.AcquireAppLeaseResponse, error) {
+	v, err := f.Get()
+	if err != nil {
+		return rpcpb.AcquireAppLeaseResponse{}, err
+	}
+
+	var resp rpcpb.AcquireAppLeaseResponse
+	protoc.MustUnmarshal(&resp, v)
+	return resp, nil
+}
+
+// GetBarrierResponse get the barrier response
+func (f *Future) GetBarrierResponse() (rpcpb.BarrierResponse, error) {
+	v, err := f.Get()
+	if err != nil {
+		return rpcpb.BarrierResponse{}, err
+	}
+
+	var resp rpcpb.BarrierResponse
+	protoc.MustUnmarshal(&resp, v)
+	return resp, nil
+}
+
+// GetComputeHashResponse get the compute hash response
+func (f *Future) GetComputeHashResponse() (rpcpb.ComputeHashResponse, error) {
+	v, err := f.Get()
+	if err != nil {
+		return rpcpb.ComputeHashResponse{}, err
+	}
+
+	var resp rpcpb.ComputeHashResponse
+	protoc.MustUnmarshal(&resp, v)
+	return resp, nil
+}
+
+// GetPurgeResponse get the purge response
+func (f *Future) GetPurgeResponse() (rpcpb.PurgeResponse, error) {
+	v, err := f.Get()
+	if err != nil {
+		return rpcpb.PurgeResponse{}, err
+	}
+
+	var resp rpcpb.PurgeResponse
+	protoc.MustUnmarshal(&resp, v)
+	return resp, nil
+}
+
+// GetKVGetDelResponse get the kv get-del response
+func (f *Future) GetKVGetDelResponse() (rpcpb.KVGetDelResponse, error) {
+	v, err := f.Get()
+	if err != nil {
+		return rpcpb.KVGetDelResponse{}, err
+	}
+
+	var resp rpcpb.KVGetDelResponse
+	protoc.MustUnmarshal(&resp, v)
+	return resp, nil
+}
+
+// GetKVDeleteIfResponse get the kv delete-if response
+func (f *Future) GetKVDeleteIfResponse() (rpcpb.KVDeleteIfResponse, error) {
+	v, err := f.Get()
+	if err != nil {
+		return rpcpb.KVDeleteIfResponse{}, err
+	}
+
+	var resp rpcpb.KVDeleteIfResponse
+	protoc.MustUnmarshal(&resp, v)
+	return resp, nil
+}
+
 // GetKVBatchGetResponse get the kv batch get response
 func (f *Future) GetKVBatchGetResponse() (rpcpb.KVBatchGetResponse, error) {
 	v, err := f.Get()
@@ -133,6 +208,15 @@ func (f *Future) GetKVScanResponse() (rpcpb.KVScanResponse, error) {
 	return resp, nil
 }
 
+// ApplyLag returns the apply lag of the replica if the read is served as a
+// stale read, 0 means the read is linearizable. It must be called after the
+// response is received.
+func (f *Future) ApplyLag() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.applyLag
+}
+
 // Close close the future.
 func (f *Future) Close() {
 	f.mu.Lock()
@@ -145,6 +229,21 @@ func (f *Future) Close() {
 	releaseFuture(f)
 }
 
+// timeout returns the time left before the deadline of the context in
+// nanoseconds. The stores use the relative timeout rather than the deadline, as
+// their clocks are not synchronized with the client. It's never 0 if the
+// context has a deadline, as 0 means no timeout.
+func (f *Future) timeout() int64 {
+	deadline, ok := f.ctx.Deadline()
+	if !ok {
+		return 0
+	}
+	if d := time.Until(deadline); d > 0 {
+		return int64(d)
+	}
+	return 1
+}
+
 func (f *Future) canRetry() bool {
 	select {
 	case <-f.ctx.Done():
@@ -172,6 +271,12 @@ func (f *Future) done(value []byte, txnRespopnse *txnpb.TxnBatchResponse, err er
 	}
 }
 
+func (f *Future) setApplyLag(applyLag uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.applyLag = applyLag
+}
+
 func (f *Future) kvBatchGetDone(values [][]byte) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
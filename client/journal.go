@@ -0,0 +1,260 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/fagongzi/util/hack"
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+const (
+	journalRecordPut  byte = 1
+	journalRecordDone byte = 2
+
+	// type + payload length
+	journalHeaderSize = 5
+	journalCRCSize    = 4
+	// the journal file is truncated if there is no pending request and the size
+	// exceeds the value
+	journalCompactSize = 64 * 1024 * 1024
+)
+
+var (
+	// ErrJournalClosed the journal is closed
+	ErrJournalClosed = errors.New("journal closed")
+)
+
+// Journal is a file-backed write-ahead journal of the write requests. A request
+// is appended to the journal and synced to the disk before it is sent, and
+// marked as done after the response is received. The requests not marked as
+// done survive the client crashes, and can be resubmitted with the same request
+// ID after the restart.
+type Journal struct {
+	fs   vfs.FS
+	path string
+
+	mu struct {
+		sync.Mutex
+		closed  bool
+		file    vfs.File
+		size    int64
+		order   *list.List // appending order of the pending requests
+		pending map[string]*list.Element
+	}
+}
+
+// OpenJournal opens the journal file, the file is created if not exists. The
+// pending requests are loaded, and the file is rewritten to only keep the
+// pending requests.
+func OpenJournal(fs vfs.FS, path string) (*Journal, error) {
+	if fs == nil {
+		fs = vfs.Default
+	}
+	j := &Journal{fs: fs, path: path}
+	j.mu.order = list.New()
+	j.mu.pending = make(map[string]*list.Element)
+	if err := j.load(); err != nil {
+		return nil, err
+	}
+	if err := j.rewrite(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// load loads the pending requests. A record with bad checksum or partially
+// written means the client crashed while writing the record, the record and
+// the records after it are ignored.
+func (j *Journal) load() error {
+	f, err := j.fs.Open(j.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	for len(data) >= journalHeaderSize+journalCRCSize {
+		tp := data[0]
+		n := int(binary.BigEndian.Uint32(data[1:journalHeaderSize]))
+		end := journalHeaderSize + n
+		if end+journalCRCSize > len(data) ||
+			crc32.ChecksumIEEE(data[:end]) != binary.BigEndian.Uint32(data[end:]) {
+			break
+		}
+
+		payload := data[journalHeaderSize:end]
+		switch tp {
+		case journalRecordPut:
+			var req rpcpb.Request
+			protoc.MustUnmarshal(&req, payload)
+			j.addLocked(req)
+		case journalRecordDone:
+			j.removeLocked(string(payload))
+		}
+		data = data[end+journalCRCSize:]
+	}
+	return nil
+}
+
+// rewrite writes the pending requests into a new journal file, and replaces
+// the old one.
+func (j *Journal) rewrite() error {
+	tmp := j.path + ".tmp"
+	f, err := j.fs.Create(tmp)
+	if err != nil {
+		return err
+	}
+	var size int64
+	for e := j.mu.order.Front(); e != nil; e = e.Next() {
+		req := e.Value.(rpcpb.Request)
+		n, err := writeJournalRecord(f, journalRecordPut, protoc.MustMarshal(&req))
+		if err != nil {
+			f.Close()
+			return err
+		}
+		size += int64(n)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := j.fs.Rename(tmp, j.path); err != nil {
+		return err
+	}
+
+	f, err = j.fs.OpenForAppend(j.path)
+	if err != nil {
+		return err
+	}
+	j.mu.file = f
+	j.mu.size = size
+	return nil
+}
+
+// Append appends the request to the journal, and syncs the journal to the
+// disk.
+func (j *Journal) Append(req rpcpb.Request) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.mu.closed {
+		return ErrJournalClosed
+	}
+
+	n, err := writeJournalRecord(j.mu.file, journalRecordPut, protoc.MustMarshal(&req))
+	if err != nil {
+		return err
+	}
+	j.mu.size += int64(n)
+	if err := j.mu.file.Sync(); err != nil {
+		return err
+	}
+	j.addLocked(req)
+	return nil
+}
+
+// Done marks the request as done. The record is not synced, if it's lost, the
+// request will be resubmitted after the restart.
+func (j *Journal) Done(id []byte) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.mu.closed {
+		return ErrJournalClosed
+	}
+	if _, ok := j.mu.pending[hack.SliceToString(id)]; !ok {
+		return nil
+	}
+
+	n, err := writeJournalRecord(j.mu.file, journalRecordDone, id)
+	if err != nil {
+		return err
+	}
+	j.mu.size += int64(n)
+	j.removeLocked(string(id))
+
+	if len(j.mu.pending) == 0 && j.mu.size > journalCompactSize {
+		if err := j.mu.file.Close(); err != nil {
+			return err
+		}
+		return j.rewrite()
+	}
+	return nil
+}
+
+// Pending returns the pending requests in the order they were appended
+func (j *Journal) Pending() []rpcpb.Request {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	requests := make([]rpcpb.Request, 0, j.mu.order.Len())
+	for e := j.mu.order.Front(); e != nil; e = e.Next() {
+		requests = append(requests, e.Value.(rpcpb.Request))
+	}
+	return requests
+}
+
+// Close closes the journal
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.mu.closed {
+		return nil
+	}
+	j.mu.closed = true
+	return j.mu.file.Close()
+}
+
+func (j *Journal) addLocked(req rpcpb.Request) {
+	id := string(req.ID)
+	if e, ok := j.mu.pending[id]; ok {
+		e.Value = req
+		return
+	}
+	j.mu.pending[id] = j.mu.order.PushBack(req)
+}
+
+func (j *Journal) removeLocked(id string) {
+	if e, ok := j.mu.pending[id]; ok {
+		j.mu.order.Remove(e)
+		delete(j.mu.pending, id)
+	}
+}
+
+func writeJournalRecord(w io.Writer, tp byte, payload []byte) (int, error) {
+	data := make([]byte, journalHeaderSize+len(payload)+journalCRCSize)
+	data[0] = tp
+	binary.BigEndian.PutUint32(data[1:journalHeaderSize], uint32(len(payload)))
+	copy(data[journalHeaderSize:], payload)
+	end := journalHeaderSize + len(payload)
+	binary.BigEndian.PutUint32(data[end:], crc32.ChecksumIEEE(data[:end]))
+	return w.Write(data)
+}
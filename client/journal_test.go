@@ -0,0 +1,168 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/vfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalAppendAndDone(t *testing.T) {
+	fs := vfs.NewMemFS()
+	j, err := OpenJournal(fs, "journal")
+	require.NoError(t, err)
+	assert.Empty(t, j.Pending())
+
+	for _, id := range []string{"1", "2", "3"} {
+		require.NoError(t, j.Append(rpcpb.Request{ID: []byte(id), Cmd: []byte(id)}))
+	}
+	require.NoError(t, j.Done([]byte("2")))
+	// unknown request
+	require.NoError(t, j.Done([]byte("4")))
+	require.NoError(t, j.Close())
+	assert.Equal(t, ErrJournalClosed, j.Append(rpcpb.Request{ID: []byte("5")}))
+
+	j, err = OpenJournal(fs, "journal")
+	require.NoError(t, err)
+	defer j.Close()
+	pending := j.Pending()
+	require.Equal(t, 2, len(pending))
+	assert.Equal(t, []byte("1"), pending[0].ID)
+	assert.Equal(t, []byte("1"), pending[0].Cmd)
+	assert.Equal(t, []byte("3"), pending[1].ID)
+}
+
+func TestJournalIgnoreCorruptedTail(t *testing.T) {
+	fs := vfs.NewMemFS()
+	j, err := OpenJournal(fs, "journal")
+	require.NoError(t, err)
+	require.NoError(t, j.Append(rpcpb.Request{ID: []byte("1")}))
+	require.NoError(t, j.Close())
+
+	// a partially written record
+	f, err := fs.OpenForAppend("journal")
+	require.NoError(t, err)
+	_, err = f.Write([]byte{journalRecordPut, 0, 0, 0, 10, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	j, err = OpenJournal(fs, "journal")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(j.Pending()))
+	require.NoError(t, j.Append(rpcpb.Request{ID: []byte("2")}))
+	require.NoError(t, j.Close())
+
+	j, err = OpenJournal(fs, "journal")
+	require.NoError(t, err)
+	defer j.Close()
+	assert.Equal(t, 2, len(j.Pending()))
+}
+
+func TestJournalRewrite(t *testing.T) {
+	fs := vfs.NewMemFS()
+	j, err := OpenJournal(fs, "journal")
+	require.NoError(t, err)
+	defer j.Close()
+
+	require.NoError(t, j.Append(rpcpb.Request{ID: []byte("1")}))
+	j.mu.size = journalCompactSize
+	require.NoError(t, j.Done([]byte("1")))
+	assert.Equal(t, int64(0), j.mu.size)
+	stat, err := fs.Stat("journal")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), stat.Size())
+}
+
+func TestJournaledWriterResubmit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	fs := vfs.NewMemFS()
+	j, err := OpenJournal(fs, "journal")
+	require.NoError(t, err)
+	defer j.Close()
+
+	// the request was journaled before the client crashed
+	require.NoError(t, j.Append(rpcpb.Request{
+		ID:         []byte("id1"),
+		Type:       rpcpb.Write,
+		CustomType: uint64(rpcpb.CmdKVSet),
+		Cmd:        protoc.MustMarshal(&rpcpb.KVSetRequest{Key: []byte("k1"), Value: []byte("v1")}),
+		Key:        []byte("k1"),
+	}))
+	// the request is not idempotent, it's dropped without resubmitted
+	require.NoError(t, j.Append(rpcpb.Request{
+		ID:         []byte("id2"),
+		Type:       rpcpb.Write,
+		CustomType: uint64(rpcpb.CmdKVGetDel),
+		Cmd:        protoc.MustMarshal(&rpcpb.KVGetDelRequest{Key: []byte("k1")}),
+		Key:        []byte("k1"),
+	}))
+
+	var mu sync.Mutex
+	var ids []string
+	w := NewJournaledWriter(s, j, time.Minute, func(id []byte, err error) {
+		if string(id) == "id2" {
+			assert.Equal(t, ErrNotIdempotent, err)
+			return
+		}
+		assert.NoError(t, err)
+		mu.Lock()
+		defer mu.Unlock()
+		ids = append(ids, string(id))
+	})
+	assert.Equal(t, 1, w.Resubmit(context.Background()))
+	id, err := w.Write(context.Background(), uint64(rpcpb.CmdKVSet),
+		protoc.MustMarshal(&rpcpb.KVSetRequest{Key: []byte("k2"), Value: []byte("v2")}),
+		WithRouteKey([]byte("k2")))
+	require.NoError(t, err)
+	_, err = w.Write(context.Background(), uint64(rpcpb.CmdKVGetDel),
+		protoc.MustMarshal(&rpcpb.KVGetDelRequest{Key: []byte("k2")}),
+		WithRouteKey([]byte("k2")))
+	assert.Equal(t, ErrNotIdempotent, err)
+	w.Close()
+
+	assert.ElementsMatch(t, []string{"id1", string(id)}, ids)
+	assert.Empty(t, j.Pending())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	kv := NewKVClient(s, 0, rpcpb.SelectLeader)
+	for k, v := range map[string]string{"k1": "v1", "k2": "v2"} {
+		f := kv.Get(ctx, []byte(k))
+		resp, err := f.GetKVGetResponse()
+		f.Close()
+		require.NoError(t, err)
+		assert.Equal(t, []byte(v), resp.Value)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/uuid"
+)
+
+var (
+	// ErrNotIdempotent the request type is not idempotent, so it cannot be
+	// journaled and resubmitted
+	ErrNotIdempotent = errors.New("request type is not idempotent")
+)
+
+// JournaledWriter is an async writer which appends the write requests to the
+// journal before sending them. The requests are resubmitted with the same
+// request ID by Resubmit if the client crashed before the responses received,
+// so the writes are at-least-once.
+//
+// The server does not dedup the resubmitted requests, a request may be applied
+// more than once, so only the idempotent request types are accepted: the kv
+// set, delete and range delete commands by default, and the custom types added
+// by AddIdempotentTypes. The request ID is passed to the storage as
+// storage.Request.ID, a storage can use it to dedup other request types.
+type JournaledWriter struct {
+	cli        Client
+	journal    *Journal
+	timeout    time.Duration
+	callback   func(id []byte, err error)
+	idempotent map[uint64]struct{}
+	wg         sync.WaitGroup
+}
+
+// NewJournaledWriter returns a JournaledWriter. The callback is called with the
+// request ID and the result after each request completed, the request is kept
+// in the journal if it failed.
+func NewJournaledWriter(cli Client, journal *Journal, timeout time.Duration,
+	callback func(id []byte, err error)) *JournaledWriter {
+	w := &JournaledWriter{
+		cli:        cli,
+		journal:    journal,
+		timeout:    timeout,
+		callback:   callback,
+		idempotent: make(map[uint64]struct{}),
+	}
+	// CmdKVGetDel and CmdKVDeleteIf are excluded, the response or the result
+	// depends on the value written by other requests
+	w.AddIdempotentTypes(uint64(rpcpb.CmdKVSet),
+		uint64(rpcpb.CmdKVBatchSet),
+		uint64(rpcpb.CmdKVDelete),
+		uint64(rpcpb.CmdKVBatchDelete),
+		uint64(rpcpb.CmdKVRangeDelete),
+		uint64(rpcpb.CmdKVBatchMixedWrite))
+	return w
+}
+
+// AddIdempotentTypes adds the custom request types which are idempotent, so
+// they can be written by the JournaledWriter. It must be called before any
+// request written or resubmitted.
+func (w *JournaledWriter) AddIdempotentTypes(requestTypes ...uint64) {
+	for _, requestType := range requestTypes {
+		w.idempotent[requestType] = struct{}{}
+	}
+}
+
+func (w *JournaledWriter) isIdempotent(requestType uint64) bool {
+	_, ok := w.idempotent[requestType]
+	return ok
+}
+
+// Write appends the write request to the journal and sends it asynchronously.
+// The request ID is returned after the request is persisted in the journal. The
+// request is sent with a context derived from ctx, so the request is given up
+// once ctx is canceled, but it's kept in the journal to be resubmitted.
+func (w *JournaledWriter) Write(ctx context.Context, requestType uint64, payload []byte, opts ...Option) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !w.isIdempotent(requestType) {
+		return nil, ErrNotIdempotent
+	}
+
+	req := rpcpb.Request{
+		ID:         uuid.NewV4().Bytes(),
+		Type:       rpcpb.Write,
+		CustomType: requestType,
+		Cmd:        payload,
+	}
+	for _, opt := range opts {
+		opt(&req)
+	}
+	if err := w.journal.Append(req); err != nil {
+		return nil, err
+	}
+	w.submit(ctx, req)
+	return req.ID, nil
+}
+
+// Resubmit resubmits all pending requests in the journal with the contexts
+// derived from ctx, and returns the count of the resubmitted requests. The
+// pending requests of the types not idempotent are removed from the journal
+// without resubmitted, the callback is called with ErrNotIdempotent for them.
+func (w *JournaledWriter) Resubmit(ctx context.Context) int {
+	n := 0
+	for _, req := range w.journal.Pending() {
+		if !w.isIdempotent(req.CustomType) {
+			err := w.journal.Done(req.ID)
+			if err == nil {
+				err = ErrNotIdempotent
+			}
+			if w.callback != nil {
+				w.callback(req.ID, err)
+			}
+			continue
+		}
+		w.submit(ctx, req)
+		n++
+	}
+	return n
+}
+
+// Close waits for all sent requests completed. The journal is not closed.
+func (w *JournaledWriter) Close() {
+	w.wg.Wait()
+}
+
+func (w *JournaledWriter) submit(ctx context.Context, req rpcpb.Request) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	f := w.cli.Write(ctx, req.CustomType, req.Cmd, withJournaledRequest(req))
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer cancel()
+		defer f.Close()
+
+		err := f.GetError()
+		if err == nil {
+			err = w.journal.Done(req.ID)
+		}
+		if w.callback != nil {
+			w.callback(req.ID, err)
+		}
+	}()
+}
+
+// withJournaledRequest restores the request loaded from the journal, the ID is
+// kept to make the resubmitted request identifiable.
+func withJournaledRequest(req rpcpb.Request) Option {
+	return func(r *rpcpb.Request) {
+		r.ID = req.ID
+		r.Group = req.Group
+		r.Key = req.Key
+		r.ToShard = req.ToShard
+		r.KeysRange = req.KeysRange
+		r.ReplicaSelectPolicy = req.ReplicaSelectPolicy
+		r.Lease = req.Lease
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+)
+
+var (
+	// ErrEmptyWriteBatch the write batch has no commands
+	ErrEmptyWriteBatch = errors.New("empty write batch")
+)
+
+// KVWriteBatch is a group of write commands. The commands are applied in the
+// order they are added.
+type KVWriteBatch struct {
+	requests []rpcpb.KVMixedWriteRequest
+}
+
+// NewKVWriteBatch returns an empty write batch
+func NewKVWriteBatch() *KVWriteBatch {
+	return &KVWriteBatch{}
+}
+
+// Set adds a set command
+func (b *KVWriteBatch) Set(key, value []byte) *KVWriteBatch {
+	b.requests = append(b.requests, rpcpb.KVMixedWriteRequest{
+		CmdType: uint64(rpcpb.CmdKVSet),
+		Set:     rpcpb.KVSetRequest{Key: key, Value: value},
+	})
+	return b
+}
+
+// Delete adds a delete command
+func (b *KVWriteBatch) Delete(key []byte) *KVWriteBatch {
+	b.requests = append(b.requests, rpcpb.KVMixedWriteRequest{
+		CmdType: uint64(rpcpb.CmdKVDelete),
+		Delete:  rpcpb.KVDeleteRequest{Key: key},
+	})
+	return b
+}
+
+// RangeDelete adds a command to delete keys in range [start, end)
+func (b *KVWriteBatch) RangeDelete(start, end []byte) *KVWriteBatch {
+	b.requests = append(b.requests, rpcpb.KVMixedWriteRequest{
+		CmdType:     uint64(rpcpb.CmdKVRangeDelete),
+		RangeDelete: rpcpb.KVRangeDeleteRequest{Start: start, End: end},
+	})
+	return b
+}
+
+// Len returns the count of the commands
+func (b *KVWriteBatch) Len() int {
+	return len(b.requests)
+}
+
+// Reset removes all the commands
+func (b *KVWriteBatch) Reset() {
+	b.requests = b.requests[:0]
+}
+
+// keysRange returns the range [from, to) of the keys in the requests, the empty
+// to means the range is unbounded as a range delete with the empty end deletes
+// all the keys after its start.
+func keysRange(requests []rpcpb.KVMixedWriteRequest) ([]byte, []byte) {
+	var from, to []byte
+	unbounded := false
+	update := func(idx int, start, end []byte) {
+		if idx == 0 || bytes.Compare(start, from) < 0 {
+			from = start
+		}
+		if len(end) == 0 {
+			unbounded = true
+		}
+		if idx == 0 || bytes.Compare(end, to) > 0 {
+			to = end
+		}
+	}
+	for idx, req := range requests {
+		switch rpcpb.InternalCmd(req.CmdType) {
+		case rpcpb.CmdKVSet:
+			update(idx, req.Set.Key, keysutil.NextKey(req.Set.Key, nil))
+		case rpcpb.CmdKVDelete:
+			update(idx, req.Delete.Key, keysutil.NextKey(req.Delete.Key, nil))
+		case rpcpb.CmdKVRangeDelete:
+			update(idx, req.RangeDelete.Start, req.RangeDelete.End)
+		}
+	}
+	if unbounded {
+		return from, nil
+	}
+	return from, to
+}
+
+// routeKey returns the key used to route the request
+func routeKey(req rpcpb.KVMixedWriteRequest) []byte {
+	switch rpcpb.InternalCmd(req.CmdType) {
+	case rpcpb.CmdKVSet:
+		return req.Set.Key
+	case rpcpb.CmdKVDelete:
+		return req.Delete.Key
+	default:
+		return req.RangeDelete.Start
+	}
+}
+
+func (c *kvClient) Multi(ctx context.Context, batch *KVWriteBatch) *Future {
+	if batch.Len() == 0 {
+		f := newFuture(ctx)
+		f.done(nil, nil, ErrEmptyWriteBatch)
+		return f
+	}
+	return c.writeMixed(ctx, batch.requests)
+}
+
+func (c *kvClient) writeMixed(ctx context.Context, requests []rpcpb.KVMixedWriteRequest) *Future {
+	from, to := keysRange(requests)
+	return c.cli.Write(ctx,
+		uint64(rpcpb.CmdKVBatchMixedWrite),
+		protoc.MustMarshal(&rpcpb.KVBatchMixedWriteRequest{Requests: requests}),
+		WithReplicaSelectPolicy(c.policy),
+		WithRouteKey(from),
+		WithKeysRange(from, to),
+		WithShardGroup(c.shardGroup))
+}
+
+func (c *kvClient) Pipeline(ctx context.Context, batch *KVWriteBatch) []error {
+	errs := make([]error, batch.Len())
+	if batch.Len() == 0 {
+		return errs
+	}
+
+	// group the commands by shard, the commands in a group are written by one
+	// proposal. The range delete is split into the sub-ranges of the shards, it
+	// fails if any of its sub-ranges fails.
+	type group struct {
+		indexes  []int
+		requests []rpcpb.KVMixedWriteRequest
+	}
+	var shards []uint64
+	groups := make(map[uint64]*group)
+	add := func(idx int, req rpcpb.KVMixedWriteRequest) {
+		id := c.cli.Router().SelectShardIDByKey(c.shardGroup, routeKey(req))
+		g, ok := groups[id]
+		if !ok {
+			g = &group{}
+			groups[id] = g
+			shards = append(shards, id)
+		}
+		g.indexes = append(g.indexes, idx)
+		g.requests = append(g.requests, req)
+	}
+	for idx, req := range batch.requests {
+		if rpcpb.InternalCmd(req.CmdType) != rpcpb.CmdKVRangeDelete {
+			add(idx, req)
+			continue
+		}
+		for _, r := range c.splitRangeByShards(req.RangeDelete.Start, req.RangeDelete.End) {
+			req.RangeDelete.Start, req.RangeDelete.End = r.start, r.end
+			add(idx, req)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, id := range shards {
+		g := groups[id]
+		wg.Add(1)
+		f := c.writeMixed(ctx, g.requests)
+		go func() {
+			defer wg.Done()
+			defer f.Close()
+			err := f.GetError()
+			if err == nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, idx := range g.indexes {
+				if errs[idx] == nil {
+					errs[idx] = err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
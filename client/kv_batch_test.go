@@ -0,0 +1,44 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysRange(t *testing.T) {
+	from, to := keysRange(NewKVWriteBatch().
+		Set([]byte("k2"), []byte("v2")).
+		Delete([]byte("k1")).
+		RangeDelete([]byte("k3"), []byte("k4")).requests)
+	assert.Equal(t, []byte("k1"), from)
+	assert.Equal(t, []byte("k4"), to)
+
+	// the range delete with the empty end is unbounded
+	from, to = keysRange(NewKVWriteBatch().
+		RangeDelete([]byte("k3"), nil).
+		Set([]byte("k5"), []byte("v5")).requests)
+	assert.Equal(t, []byte("k3"), from)
+	assert.Empty(t, to)
+
+	// the range delete with the empty start is from the first key
+	from, to = keysRange(NewKVWriteBatch().
+		Set([]byte("k5"), []byte("v5")).
+		RangeDelete(nil, []byte("k3")).requests)
+	assert.Empty(t, from)
+	assert.Equal(t, keysutil.NextKey([]byte("k5"), nil), to)
+}
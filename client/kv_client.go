@@ -65,18 +65,41 @@ type KVClient interface {
 	// Delete delete the key from the underlying storage engine. Use Future.GetError to check
 	// result.
 	Delete(ctx context.Context, key []byte) *Future
+	// GetDel get the value of the key and delete the key atomically, use
+	// Future.GetKVGetDelResponse to get response
+	GetDel(ctx context.Context, key []byte) *Future
+	// DeleteIf delete the key only if its current value equals the expected value
+	// atomically, use Future.GetKVDeleteIfResponse to check whether the key is
+	// deleted
+	DeleteIf(ctx context.Context, key, value []byte) *Future
 	// BatchDelete delete the keys from the underlying storage engine, these Keys must belong
 	// to the same ShardUse Future.GetError to check result.
 	BatchDelete(ctx context.Context, keys [][]byte) *Future
 	// RangeDelete delete keys in range [start, end) from the underlying storage engine, these
 	// Keys must belong to the same ShardUse Future.GetError to check result.
 	RangeDelete(ctx context.Context, start, end []byte) *Future
+	// Multi writes the commands in the batch atomically, all the keys of the
+	// commands must belong to the same Shard. Use Future.GetError to check
+	// result.
+	Multi(ctx context.Context, batch *KVWriteBatch) *Future
+	// Pipeline writes the commands in the batch, the commands belong to the same
+	// Shard are written atomically by one proposal, but the commands in different
+	// Shards are not. Returns the error of each command in the order of the batch.
+	Pipeline(ctx context.Context, batch *KVWriteBatch) []error
 	// Get get the value of the key, use Future.GetKVGetResponse to get response
 	Get(ctx context.Context, key []byte) *Future
 	// BatchGet silimlar to Get, but perform with multi-keys
 	BatchGet(ctx context.Context, keys [][]byte) *Future
 	// Scan scan the keys in the range [start, end)
 	Scan(ctx context.Context, start, end []byte, handler ScanHandler, options ...ScanOption) error
+	// ScanStream scan the keys in the range [start, end) in bounded-size frames,
+	// ScanWithLimitBytes sets the bytes limit of a frame. The stream must be
+	// closed after use.
+	ScanStream(ctx context.Context, start, end []byte, options ...ScanOption) *ScanStream
+	// MergeScan scan the keys in the range [start, end) of multiple shards, at
+	// most parallel shards are scanned concurrently in bounded-size frames, and
+	// the keys are returned in order. The iterator must be closed after use.
+	MergeScan(ctx context.Context, start, end []byte, parallel int, options ...ScanOption) *MergeScanIterator
 	// ScanCount returns the count of keys in the range [start, end)
 	ScanCount(ctx context.Context, start, end []byte) (uint64, error)
 	// ParallelScan similar to Scan, but perform scan in shards parallelly. Since scan is parallel,
@@ -143,6 +166,24 @@ func (c *kvClient) Delete(ctx context.Context, key []byte) *Future {
 		WithShardGroup(c.shardGroup))
 }
 
+func (c *kvClient) GetDel(ctx context.Context, key []byte) *Future {
+	return c.cli.Write(ctx,
+		uint64(rpcpb.CmdKVGetDel),
+		protoc.MustMarshal(&rpcpb.KVGetDelRequest{Key: key}),
+		WithReplicaSelectPolicy(c.policy),
+		WithRouteKey(key),
+		WithShardGroup(c.shardGroup))
+}
+
+func (c *kvClient) DeleteIf(ctx context.Context, key, value []byte) *Future {
+	return c.cli.Write(ctx,
+		uint64(rpcpb.CmdKVDeleteIf),
+		protoc.MustMarshal(&rpcpb.KVDeleteIfRequest{Key: key, Value: value}),
+		WithReplicaSelectPolicy(c.policy),
+		WithRouteKey(key),
+		WithShardGroup(c.shardGroup))
+}
+
 func (c *kvClient) BatchDelete(ctx context.Context, keys [][]byte) *Future {
 	sort.Slice(keys, func(i, j int) bool {
 		return bytes.Compare(keys[i], keys[j]) < 0
@@ -152,7 +193,7 @@ func (c *kvClient) BatchDelete(ctx context.Context, keys [][]byte) *Future {
 	})
 
 	return c.cli.Write(ctx,
-		uint64(rpcpb.CmdKVDelete),
+		uint64(rpcpb.CmdKVBatchDelete),
 		cmd,
 		WithReplicaSelectPolicy(c.policy),
 		WithRouteKey(keys[0]),
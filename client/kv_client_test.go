@@ -16,6 +16,7 @@ package client
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"sort"
 	"sync"
 	"testing"
@@ -72,6 +73,98 @@ func TestKVSetAndGetAndDelete(t *testing.T) {
 	assert.Empty(t, resp.Value)
 }
 
+func TestKVGetDel(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	kv := NewKVClient(s, 0, rpcpb.SelectLeader)
+	defer kv.Close()
+
+	k1 := []byte("k1")
+	v1 := []byte("v1")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	f := kv.Set(ctx, k1, v1)
+	defer f.Close()
+	assert.NoError(t, f.GetError())
+
+	f2 := kv.GetDel(ctx, k1)
+	defer f2.Close()
+	resp, err := f2.GetKVGetDelResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, v1, resp.Value)
+
+	f3 := kv.GetDel(ctx, k1)
+	defer f3.Close()
+	resp, err = f3.GetKVGetDelResponse()
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Value)
+
+	f4 := kv.Get(ctx, k1)
+	defer f4.Close()
+	getResp, err := f4.GetKVGetResponse()
+	assert.NoError(t, err)
+	assert.Empty(t, getResp.Value)
+}
+
+func TestKVDeleteIfAndBatchDelete(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	kv := NewKVClient(s, 0, rpcpb.SelectLeader)
+	defer kv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	keys := [][]byte{[]byte("k1"), []byte("k2"), []byte("k3")}
+	f := kv.BatchSet(ctx, keys, [][]byte{[]byte("v1"), []byte("v2"), []byte("v3")})
+	defer f.Close()
+	assert.NoError(t, f.GetError())
+
+	f2 := kv.DeleteIf(ctx, keys[0], []byte("v2"))
+	defer f2.Close()
+	resp, err := f2.GetKVDeleteIfResponse()
+	assert.NoError(t, err)
+	assert.False(t, resp.Deleted)
+
+	f3 := kv.DeleteIf(ctx, keys[0], []byte("v1"))
+	defer f3.Close()
+	resp, err = f3.GetKVDeleteIfResponse()
+	assert.NoError(t, err)
+	assert.True(t, resp.Deleted)
+
+	f4 := kv.BatchDelete(ctx, [][]byte{keys[2], keys[1]})
+	defer f4.Close()
+	assert.NoError(t, f4.GetError())
+
+	f5 := kv.BatchGet(ctx, keys)
+	defer f5.Close()
+	getResp, err := f5.GetKVBatchGetResponse()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(getResp.Values))
+	for _, v := range getResp.Values {
+		assert.Empty(t, v)
+	}
+}
+
 func TestKVBatchSetAndBatchGet(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -439,3 +532,206 @@ func newTestRaftstoreClient(router raftstore.Router, handler func(rpcpb.Request)
 	}
 	return c
 }
+
+func TestKVMultiAndPipeline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t, raftstore.WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Customize.CustomInitShardsFactory = func() []metapb.Shard {
+			return []metapb.Shard{
+				{Start: []byte("k1"), End: []byte("k2")},
+				{Start: []byte("k2"), End: []byte("k3")},
+			}
+		}
+	}))
+	c.Start()
+	defer c.Stop()
+	c.WaitShardByCountPerNode(2, time.Minute)
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	kv := NewKVClient(s, 0, rpcpb.SelectLeader)
+	defer kv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	get := func(key []byte) []byte {
+		f := kv.Get(ctx, key)
+		defer f.Close()
+		resp, err := f.GetKVGetResponse()
+		assert.NoError(t, err)
+		return resp.Value
+	}
+
+	f := kv.Multi(ctx, NewKVWriteBatch())
+	assert.Equal(t, ErrEmptyWriteBatch, f.GetError())
+	f.Close()
+
+	f = kv.Multi(ctx, NewKVWriteBatch().Set([]byte("k1"), []byte("v1")).Set([]byte("k11"), []byte("v11")).Delete([]byte("k1")))
+	assert.NoError(t, f.GetError())
+	f.Close()
+	assert.Empty(t, get([]byte("k1")))
+	assert.Equal(t, []byte("v11"), get([]byte("k11")))
+
+	// keys in multiple shards
+	f = kv.Multi(ctx, NewKVWriteBatch().Set([]byte("k1"), []byte("v1")).Set([]byte("k2"), []byte("v2")))
+	assert.Error(t, f.GetError())
+	f.Close()
+	assert.Empty(t, get([]byte("k1")))
+	assert.Empty(t, get([]byte("k2")))
+
+	// the range delete with the empty end is not in the shard with an end
+	f = kv.Multi(ctx, NewKVWriteBatch().Set([]byte("k2"), []byte("v2")).RangeDelete([]byte("k21"), nil))
+	assert.Error(t, f.GetError())
+	f.Close()
+	assert.Empty(t, get([]byte("k2")))
+
+	errs := kv.Pipeline(ctx, NewKVWriteBatch().
+		Set([]byte("k1"), []byte("v1")).
+		Set([]byte("k2"), []byte("v2")).
+		Set([]byte("k21"), []byte("v21")).
+		RangeDelete([]byte("k11"), []byte("k12")))
+	assert.Equal(t, []error{nil, nil, nil, nil}, errs)
+	assert.Equal(t, []byte("v1"), get([]byte("k1")))
+	assert.Empty(t, get([]byte("k11")))
+	assert.Equal(t, []byte("v2"), get([]byte("k2")))
+	assert.Equal(t, []byte("v21"), get([]byte("k21")))
+
+	// the range delete across the shards is split by shard
+	errs = kv.Pipeline(ctx, NewKVWriteBatch().
+		Set([]byte("k11"), []byte("v11")).
+		RangeDelete([]byte("k1"), []byte("k3")).
+		Set([]byte("k22"), []byte("v22")))
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+	assert.Empty(t, get([]byte("k1")))
+	assert.Empty(t, get([]byte("k11")))
+	assert.Empty(t, get([]byte("k2")))
+	assert.Empty(t, get([]byte("k21")))
+	assert.Equal(t, []byte("v22"), get([]byte("k22")))
+}
+
+func TestKVScanStream(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t, raftstore.WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Customize.CustomInitShardsFactory = func() []metapb.Shard {
+			return []metapb.Shard{
+				{Start: []byte("k1"), End: []byte("k3")},
+				{Start: []byte("k3"), End: nil},
+			}
+		}
+	}))
+	c.Start()
+	defer c.Stop()
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	kv := NewKVClient(s, 0, rpcpb.SelectLeader)
+	defer kv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var keys, values [][]byte
+	for i := 1; i <= 5; i++ {
+		keys = append(keys, []byte(fmt.Sprintf("k%d", i)))
+		values = append(values, []byte(fmt.Sprintf("v%d", i)))
+		f := kv.Set(ctx, keys[i-1], values[i-1])
+		assert.NoError(t, f.GetError())
+		f.Close()
+	}
+
+	// every frame contains one key-value pair
+	stream := kv.ScanStream(ctx, []byte("k1"), []byte("k6"), ScanWithValue(), ScanWithLimitBytes(4))
+	var scanKeys, scanValues [][]byte
+	for stream.Next() {
+		scanKeys = append(scanKeys, stream.Key())
+		scanValues = append(scanValues, stream.Value())
+	}
+	assert.NoError(t, stream.Err())
+	stream.Close()
+	assert.Equal(t, keys, scanKeys)
+	assert.Equal(t, values, scanValues)
+
+	// close before all frames consumed
+	stream = kv.ScanStream(ctx, []byte("k1"), []byte("k6"), ScanWithLimitBytes(2))
+	assert.True(t, stream.Next())
+	assert.Equal(t, keys[0], stream.Key())
+	assert.Nil(t, stream.Value())
+	stream.Close()
+	assert.False(t, stream.Next())
+}
+
+func TestKVMergeScan(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t, raftstore.WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Customize.CustomInitShardsFactory = func() []metapb.Shard {
+			return []metapb.Shard{
+				{Start: []byte("k1"), End: []byte("k3")},
+				{Start: []byte("k3"), End: []byte("k5")},
+				{Start: []byte("k5"), End: nil},
+			}
+		}
+	}))
+	c.Start()
+	defer c.Stop()
+	c.WaitShardByCountPerNode(3, time.Minute)
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	kv := NewKVClient(s, 0, rpcpb.SelectLeader)
+	defer kv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var keys, values [][]byte
+	for i := 1; i <= 6; i++ {
+		keys = append(keys, []byte(fmt.Sprintf("k%d", i)))
+		values = append(values, []byte(fmt.Sprintf("v%d", i)))
+		f := kv.Set(ctx, keys[i-1], values[i-1])
+		assert.NoError(t, f.GetError())
+		f.Close()
+	}
+
+	assert.Equal(t, []keyRange{
+		{start: []byte("k2"), end: []byte("k3")},
+		{start: []byte("k3"), end: []byte("k5")},
+		{start: []byte("k5"), end: []byte("k7")},
+	}, kv.(*kvClient).splitRangeByShards([]byte("k2"), []byte("k7")))
+
+	for _, parallel := range []int{0, 2, 3} {
+		it := kv.MergeScan(ctx, []byte("k1"), []byte("k7"), parallel, ScanWithValue(), ScanWithLimitBytes(4))
+		var scanKeys, scanValues [][]byte
+		for it.Next() {
+			scanKeys = append(scanKeys, it.Key())
+			scanValues = append(scanValues, it.Value())
+		}
+		assert.NoError(t, it.Err())
+		it.Close()
+		assert.Equal(t, keys, scanKeys)
+		assert.Equal(t, values, scanValues)
+	}
+
+	// close before all keys consumed
+	it := kv.MergeScan(ctx, []byte("k2"), []byte("k7"), 3, ScanWithLimitBytes(2))
+	assert.True(t, it.Next())
+	assert.Equal(t, keys[1], it.Key())
+	assert.Nil(t, it.Value())
+	it.Close()
+	assert.False(t, it.Next())
+}
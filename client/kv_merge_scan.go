@@ -0,0 +1,134 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/matrixorigin/matrixcube/raftstore"
+)
+
+// MergeScanIterator is an iterator of the results of the parallel scans over
+// multiple shards. The range is split into sub-ranges by the shards covering
+// it, at most `parallel` sub-ranges are scanned concurrently by ScanStreams,
+// and the results are returned in key order. Each ScanStream buffers a bounded
+// number of frames, so the memory used is bounded by the parallelism.
+//
+// The sub-ranges are fixed once the iterator is created. If a shard is split
+// or merged during the iteration, the ScanStream of the sub-range re-routes the
+// remaining keys by the scan start key, so no key is lost or duplicated.
+type MergeScanIterator struct {
+	ctx      context.Context
+	c        *kvClient
+	options  []ScanOption
+	parallel int
+	// ranges the sub-ranges not scanned yet, in key order
+	ranges []keyRange
+	// streams the sub-ranges being scanned, in key order
+	streams []*ScanStream
+	err     error
+}
+
+func (c *kvClient) MergeScan(ctx context.Context, start, end []byte, parallel int, options ...ScanOption) *MergeScanIterator {
+	if parallel <= 0 {
+		parallel = 1
+	}
+	it := &MergeScanIterator{
+		ctx:      ctx,
+		c:        c,
+		options:  options,
+		parallel: parallel,
+		ranges:   c.splitRangeByShards(start, end),
+	}
+	it.fill()
+	return it
+}
+
+// splitRangeByShards splits the range [start, end) into the sub-ranges of the
+// shards known by the router, the empty end means no upper bound. The range is
+// not split if the router has no shard in the range.
+func (c *kvClient) splitRangeByShards(start, end []byte) []keyRange {
+	var ranges []keyRange
+	from := start
+	c.cli.Router().AscendRangeWithoutSelectReplica(c.shardGroup,
+		start, end,
+		func(shard raftstore.Shard) bool {
+			if len(shard.End) == 0 || (len(end) > 0 && bytes.Compare(shard.End, end) >= 0) {
+				return false
+			}
+			if bytes.Compare(shard.End, from) > 0 {
+				ranges = append(ranges, keyRange{start: from, end: shard.End})
+				from = shard.End
+			}
+			return true
+		})
+	return append(ranges, keyRange{start: from, end: end})
+}
+
+// fill starts the scans of the pending sub-ranges until the parallelism is
+// reached
+func (it *MergeScanIterator) fill() {
+	for len(it.streams) < it.parallel && len(it.ranges) > 0 {
+		r := it.ranges[0]
+		it.ranges = it.ranges[1:]
+		it.streams = append(it.streams, it.c.ScanStream(it.ctx, r.start, r.end, it.options...))
+	}
+}
+
+// Next moves to the next key, returns false if no more key or any error
+// occurred
+func (it *MergeScanIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	for len(it.streams) > 0 {
+		s := it.streams[0]
+		if s.Next() {
+			return true
+		}
+		if err := s.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		s.Close()
+		it.streams = it.streams[1:]
+		it.fill()
+	}
+	return false
+}
+
+// Key returns the current key
+func (it *MergeScanIterator) Key() []byte {
+	return it.streams[0].Key()
+}
+
+// Value returns the current value, nil if ScanWithValue is not set
+func (it *MergeScanIterator) Value() []byte {
+	return it.streams[0].Value()
+}
+
+// Err returns the error occurred during the scan
+func (it *MergeScanIterator) Err() error {
+	return it.err
+}
+
+// Close stops all the scans
+func (it *MergeScanIterator) Close() {
+	for _, s := range it.streams {
+		s.Close()
+	}
+	it.streams = nil
+	it.ranges = nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+)
+
+var (
+	// defaultScanFrameBytes the bytes limit of a frame if ScanWithLimitBytes is
+	// not set
+	defaultScanFrameBytes = uint64(1024 * 1024)
+	// scanStreamWindow the maximum count of the frames fetched but not consumed
+	scanStreamWindow = 2
+)
+
+type scanFrame struct {
+	resp rpcpb.KVScanResponse
+	err  error
+}
+
+// ScanStream is an iterator of the scan results. The results are fetched in
+// bounded-size frames in background as the iterator progresses, and the
+// fetching is paused if the frames are not consumed, so the memory used by a
+// big scan is bounded.
+type ScanStream struct {
+	cancel context.CancelFunc
+	frames chan scanFrame
+
+	frame rpcpb.KVScanResponse
+	idx   int
+	err   error
+}
+
+func (c *kvClient) ScanStream(ctx context.Context, start, end []byte, options ...ScanOption) *ScanStream {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &ScanStream{
+		cancel: cancel,
+		frames: make(chan scanFrame, scanStreamWindow),
+		idx:    -1,
+	}
+	if err := c.stopper.RunTask(ctx, func(ctx context.Context) {
+		c.fetchScanFrames(ctx, start, end, s.frames, options...)
+	}); err != nil {
+		s.frames <- scanFrame{err: err}
+		close(s.frames)
+	}
+	return s
+}
+
+func (c *kvClient) fetchScanFrames(ctx context.Context, start, end []byte,
+	frames chan scanFrame, options ...ScanOption) {
+	defer close(frames)
+	for {
+		req := rpcpb.KVScanRequest{
+			Start:      start,
+			End:        end,
+			LimitBytes: defaultScanFrameBytes,
+		}
+		for _, opt := range options {
+			opt(&req)
+		}
+		req.OnlyCount = false
+
+		f := c.cli.Read(ctx, uint64(rpcpb.CmdKVScan), protoc.MustMarshal(&req),
+			WithReplicaSelectPolicy(c.policy),
+			WithRouteKey(start),
+			WithShardGroup(c.shardGroup))
+		resp, err := f.GetKVScanResponse()
+		f.Close()
+
+		select {
+		case frames <- scanFrame{resp: resp, err: err}:
+		case <-ctx.Done():
+			return
+		}
+		if err != nil {
+			return
+		}
+
+		if !resp.Completed {
+			start = keysutil.NextKey(resp.Keys[resp.Count-1], nil)
+		} else {
+			start = resp.ShardEnd
+		}
+
+		// start >= end, completed
+		if len(start) == 0 ||
+			bytes.Compare(start, end) >= 0 {
+			return
+		}
+	}
+}
+
+// Next moves to the next key, returns false if no more key or any error
+// occurred
+func (s *ScanStream) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	for {
+		if s.idx+1 < int(s.frame.Count) {
+			s.idx++
+			return true
+		}
+
+		frame, ok := <-s.frames
+		if !ok {
+			return false
+		}
+		if frame.err != nil {
+			s.err = frame.err
+			return false
+		}
+		s.frame = frame.resp
+		s.idx = -1
+	}
+}
+
+// Key returns the current key
+func (s *ScanStream) Key() []byte {
+	return s.frame.Keys[s.idx]
+}
+
+// Value returns the current value, nil if ScanWithValue is not set
+func (s *ScanStream) Value() []byte {
+	if len(s.frame.Values) == 0 {
+		return nil
+	}
+	return s.frame.Values[s.idx]
+}
+
+// Err returns the error occurred during the scan
+func (s *ScanStream) Err() error {
+	return s.err
+}
+
+// Close stops the fetching of the remaining frames
+func (s *ScanStream) Close() {
+	s.cancel()
+	for range s.frames {
+	}
+}
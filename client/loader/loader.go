@@ -0,0 +1,570 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loader loads a big sorted key-value stream into an empty range of a
+// shard group. The range is pre-split into the shards of the configured size
+// and scattered across the stores before the data is written, so the load is
+// not concentrated on the stores of a single shard, and the loaded data is
+// verified by the count and the checksum at the end.
+//
+// The data is written through the raft log by KVClient.BatchSet. Ingesting SST
+// files into the data storage of the replicas is not supported by the loader:
+// a replicated ingest needs the files shipped to every replica of the shard
+// outside of the raft log and an admin command to ingest them on apply, which
+// the store doesn't have. So the load costs the same write amplification as
+// the normal writes, the pre-split and the parallelism only spread it across
+// the stores.
+package loader
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc64"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/client"
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrUnsorted the keys of the source are not in strictly ascending order
+	ErrUnsorted = errors.New("keys not in ascending order")
+	// ErrVerifyFailed the loaded data doesn't match the source
+	ErrVerifyFailed = errors.New("verify loaded data failed")
+
+	checksumTable = crc64.MakeTable(crc64.ECMA)
+)
+
+var (
+	defaultShardSize      = uint64(64 * 1024 * 1024)
+	defaultBatchSize      = uint64(1024 * 1024)
+	defaultParallelism    = 8
+	defaultRetries        = 3
+	defaultRequestTimeout = time.Minute
+	defaultSplitTimeout   = time.Minute
+	retryInterval         = 100 * time.Millisecond
+	resubmitInterval      = 5 * time.Second
+)
+
+// Iterator iterates the key-value pairs to load, the keys must be in strictly
+// ascending order. client.ScanStream and client.MergeScanIterator are
+// Iterators.
+type Iterator interface {
+	// Next moves to the next key-value pair, returns false if no more pair or
+	// any error occurred
+	Next() bool
+	// Key returns the current key
+	Key() []byte
+	// Value returns the current value
+	Value() []byte
+	// Err returns the error occurred during the iteration
+	Err() error
+	// Close closes the iterator
+	Close()
+}
+
+// Source opens an Iterator from the first key-value pair. The source is
+// iterated twice, first to compute the split points and the checksum, then to
+// write the data, so it must return the same key-value pairs every time.
+type Source func() (Iterator, error)
+
+// Scatterer scatters the shards of the range across the stores, it's
+// implemented by prophet.Client.
+type Scatterer interface {
+	ScatterShards(group uint64, start, end []byte) (rpcpb.ScatterShardsRsp, error)
+}
+
+// Options the options of the bulk load
+type Options struct {
+	// Logger the logger
+	Logger *zap.Logger
+	// Group the shard group to load into
+	Group uint64
+	// ShardSize the bytes of the key-value pairs in a pre-split shard
+	ShardSize uint64
+	// BatchSize the max bytes of the key-value pairs written by a request
+	BatchSize uint64
+	// Parallelism the max number of the concurrent write requests
+	Parallelism int
+	// Retries the max retries of a failed scatter or write request
+	Retries int
+	// RequestTimeout the timeout of a request
+	RequestTimeout time.Duration
+	// SplitTimeout the timeout to wait for the pre-split shards to be found in
+	// the router
+	SplitTimeout time.Duration
+	// DisableSplit disables the pre-split and the scatter
+	DisableSplit bool
+	// DisableScatter disables the scatter after the pre-split
+	DisableScatter bool
+	// DisableVerify disables the verification of the loaded data
+	DisableVerify bool
+}
+
+func (opts *Options) adjust() {
+	opts.Logger = log.Adjust(opts.Logger).Named("loader")
+	if opts.ShardSize == 0 {
+		opts.ShardSize = defaultShardSize
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = defaultParallelism
+	}
+	if opts.Retries <= 0 {
+		opts.Retries = defaultRetries
+	}
+	if opts.RequestTimeout == 0 {
+		opts.RequestTimeout = defaultRequestTimeout
+	}
+	if opts.SplitTimeout == 0 {
+		opts.SplitTimeout = defaultSplitTimeout
+	}
+}
+
+// Result the result of a bulk load
+type Result struct {
+	// Count the number of the loaded key-value pairs
+	Count uint64
+	// Bytes the bytes of the loaded key-value pairs
+	Bytes uint64
+	// Checksum the checksum of the loaded key-value pairs
+	Checksum uint64
+	// Start the first loaded key
+	Start []byte
+	// End the end of the loaded range [Start, End), the next key of the last
+	// loaded key
+	End []byte
+	// SplitKeys the split points of the pre-split shards in the range
+	SplitKeys [][]byte
+}
+
+// Loader loads the sorted key-value pairs into a shard group
+type Loader struct {
+	opts      Options
+	cli       client.Client
+	kv        client.KVClient
+	scatterer Scatterer
+}
+
+// NewLoader returns a Loader writing by the client, the range is scattered by
+// the scatterer, no scatter if it's nil.
+func NewLoader(cli client.Client, scatterer Scatterer, opts Options) *Loader {
+	opts.adjust()
+	return &Loader{
+		opts:      opts,
+		cli:       cli,
+		kv:        client.NewKVClient(cli, opts.Group, rpcpb.SelectLeader),
+		scatterer: scatterer,
+	}
+}
+
+// Close closes the loader
+func (l *Loader) Close() error {
+	return l.kv.Close()
+}
+
+// Load loads the key-value pairs of the source. The range of the source must
+// be empty before the load, otherwise the existing data fails the
+// verification.
+func (l *Loader) Load(ctx context.Context, src Source) (Result, error) {
+	r, err := l.analyze(src)
+	if err != nil {
+		return Result{}, err
+	}
+	if r.Count == 0 {
+		return r, nil
+	}
+	l.opts.Logger.Info("bulk load started",
+		log.HexField("start", r.Start),
+		log.HexField("end", r.End),
+		zap.Uint64("count", r.Count),
+		zap.Uint64("bytes", r.Bytes),
+		zap.Int("split-keys", len(r.SplitKeys)))
+
+	if !l.opts.DisableSplit {
+		if err := l.preSplit(ctx, r); err != nil {
+			return Result{}, err
+		}
+		if l.scatterer != nil && !l.opts.DisableScatter {
+			l.scatter(ctx, r)
+		}
+	}
+	if err := l.ingest(ctx, src); err != nil {
+		return Result{}, err
+	}
+	if !l.opts.DisableVerify {
+		if err := l.verify(ctx, r); err != nil {
+			return Result{}, err
+		}
+	}
+
+	l.opts.Logger.Info("bulk load completed",
+		log.HexField("start", r.Start),
+		log.HexField("end", r.End),
+		zap.Uint64("count", r.Count),
+		zap.Uint64("checksum", r.Checksum))
+	return r, nil
+}
+
+// analyze iterates the source to compute the count, the checksum and the split
+// points, a split point is chosen every ShardSize bytes
+func (l *Loader) analyze(src Source) (Result, error) {
+	it, err := src()
+	if err != nil {
+		return Result{}, err
+	}
+	defer it.Close()
+
+	var r Result
+	var size uint64
+	var last []byte
+	h := newChecksum()
+	for it.Next() {
+		key, value := it.Key(), it.Value()
+		if r.Count > 0 && bytes.Compare(key, last) <= 0 {
+			return Result{}, fmt.Errorf("%w: %x after %x", ErrUnsorted, key, last)
+		}
+		if r.Count == 0 {
+			r.Start = keysutil.Clone(key)
+		} else if size >= l.opts.ShardSize {
+			r.SplitKeys = append(r.SplitKeys, keysutil.Clone(key))
+			size = 0
+		}
+
+		h.add(key, value)
+		n := uint64(len(key) + len(value))
+		size += n
+		r.Count++
+		r.Bytes += n
+		last = append(last[:0], key...)
+	}
+	if err := it.Err(); err != nil {
+		return Result{}, err
+	}
+
+	if r.Count > 0 {
+		r.End = keysutil.NextKey(last, nil)
+	}
+	r.Checksum = h.sum()
+	return r, nil
+}
+
+// submittedSplit the split submitted to a shard
+type submittedSplit struct {
+	generation uint64
+	at         time.Time
+}
+
+// preSplit splits the shards at the start and the end of the range and at the
+// split points, and waits for the new shards to be found in the router. The
+// router is updated asynchronously, so the split of a shard is submitted once
+// for a shard epoch, and submitted again if the epoch is not changed after
+// resubmitInterval, e.g. the split is dropped by a busy split checker.
+func (l *Loader) preSplit(ctx context.Context, r Result) error {
+	keys := make([][]byte, 0, len(r.SplitKeys)+2)
+	keys = append(keys, r.Start)
+	keys = append(keys, r.SplitKeys...)
+	keys = append(keys, r.End)
+
+	router := l.cli.Router()
+	submitted := make(map[uint64]submittedSplit)
+	timeout := time.Now().Add(l.opts.SplitTimeout)
+	for {
+		if l.isSplit(keys) {
+			return nil
+		}
+
+		now := time.Now()
+		if now.After(timeout) {
+			return fmt.Errorf("pre-split range [%x, %x) timeout", r.Start, r.End)
+		}
+		router.AscendRangeWithoutSelectReplica(l.opts.Group, r.Start, r.End, func(shard raftstore.Shard) bool {
+			splitKeys := splitKeysInShard(shard, keys)
+			if len(splitKeys) == 0 {
+				return true
+			}
+			if s, ok := submitted[shard.ID]; ok &&
+				s.generation == shard.Epoch.Generation &&
+				now.Sub(s.at) < resubmitInterval {
+				return true
+			}
+			if err := l.splitShard(ctx, shard.ID, splitKeys); err != nil {
+				l.opts.Logger.Warn("failed to split shard, retry later",
+					log.ShardIDField(shard.ID),
+					zap.Error(err))
+				return true
+			}
+			submitted[shard.ID] = submittedSplit{generation: shard.Epoch.Generation, at: now}
+			return true
+		})
+		if err := sleep(ctx, retryInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// isSplit returns true if a shard starts at every split key in the router
+func (l *Loader) isSplit(keys [][]byte) bool {
+	router := l.cli.Router()
+	for _, key := range keys {
+		if shard := router.SelectShardByKey(l.opts.Group, key); shard.ID == 0 ||
+			!bytes.Equal(shard.Start, key) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitKeysInShard returns the keys inside the shard, not including the start
+// of the shard
+func splitKeysInShard(shard raftstore.Shard, keys [][]byte) [][]byte {
+	var splitKeys [][]byte
+	for _, key := range keys {
+		if bytes.Compare(key, shard.Start) > 0 &&
+			(len(shard.End) == 0 || bytes.Compare(key, shard.End) < 0) {
+			splitKeys = append(splitKeys, key)
+		}
+	}
+	return splitKeys
+}
+
+func (l *Loader) splitShard(ctx context.Context, shardID uint64, splitKeys [][]byte) error {
+	ctx, cancel := context.WithTimeout(ctx, l.opts.RequestTimeout)
+	defer cancel()
+	f := l.cli.SplitShard(ctx, splitKeys, shardID)
+	defer f.Close()
+	return f.GetError()
+}
+
+// scatter asks prophet to scatter the pre-split shards. The scatter is best
+// effort, the shards not scattered are still balanced by the schedulers later.
+func (l *Loader) scatter(ctx context.Context, r Result) {
+	for retry := 0; ; retry++ {
+		rsp, err := l.scatterer.ScatterShards(l.opts.Group, r.Start, r.End)
+		if err == nil && rsp.Failed == 0 {
+			l.opts.Logger.Info("shards scattered",
+				zap.Uint64("scattered", rsp.Scattered))
+			return
+		}
+		if retry >= l.opts.Retries {
+			l.opts.Logger.Warn("failed to scatter shards",
+				zap.Uint64("failed", rsp.Failed),
+				zap.Error(err))
+			return
+		}
+		if err := sleep(ctx, retryInterval*time.Duration(retry+1)); err != nil {
+			return
+		}
+	}
+}
+
+type writeBatch struct {
+	keys   [][]byte
+	values [][]byte
+	bytes  uint64
+}
+
+// ingest writes the key-value pairs of the source in batches by BatchSet, at
+// most Parallelism batches are written concurrently. Every batch is written to
+// a single shard, so it's proposed by the leader of the shard as one entry. The
+// SST files are never ingested, see the package doc.
+func (l *Loader) ingest(ctx context.Context, src Source) error {
+	it, err := src()
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var writeErr error
+	batches := make(chan writeBatch, l.opts.Parallelism)
+	for i := 0; i < l.opts.Parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				if err := l.write(ctx, batch); err != nil {
+					errOnce.Do(func() {
+						writeErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	err = func() error {
+		defer close(batches)
+		var batch writeBatch
+		var shardEnd []byte
+		push := func() error {
+			select {
+			case batches <- batch:
+				batch = writeBatch{}
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		for it.Next() {
+			key, value := it.Key(), it.Value()
+			// a batch is written to a single shard
+			if len(batch.keys) > 0 &&
+				(batch.bytes >= l.opts.BatchSize ||
+					(len(shardEnd) > 0 && bytes.Compare(key, shardEnd) >= 0)) {
+				if err := push(); err != nil {
+					return err
+				}
+			}
+			if len(batch.keys) == 0 {
+				shardEnd = l.cli.Router().SelectShardByKey(l.opts.Group, key).End
+			}
+			batch.keys = append(batch.keys, keysutil.Clone(key))
+			batch.values = append(batch.values, keysutil.Clone(value))
+			batch.bytes += uint64(len(key) + len(value))
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+		if len(batch.keys) > 0 {
+			return push()
+		}
+		return nil
+	}()
+	wg.Wait()
+	if writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+// write writes the batch, the batch is re-partitioned by the current shards on
+// retry, since the shards may be split after the batch was built
+func (l *Loader) write(ctx context.Context, batch writeBatch) error {
+	for retry := 0; ; retry++ {
+		err := l.writeByShards(ctx, batch)
+		if err == nil || retry >= l.opts.Retries || ctx.Err() != nil {
+			return err
+		}
+		l.opts.Logger.Warn("failed to write batch, retry later",
+			log.HexField("start", batch.keys[0]),
+			zap.Int("retry", retry),
+			zap.Error(err))
+		if err := sleep(ctx, retryInterval); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *Loader) writeByShards(ctx context.Context, batch writeBatch) error {
+	router := l.cli.Router()
+	for start := 0; start < len(batch.keys); {
+		end := start + 1
+		shardEnd := router.SelectShardByKey(l.opts.Group, batch.keys[start]).End
+		for end < len(batch.keys) &&
+			(len(shardEnd) == 0 || bytes.Compare(batch.keys[end], shardEnd) < 0) {
+			end++
+		}
+		if err := l.batchSet(ctx, batch.keys[start:end], batch.values[start:end]); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+func (l *Loader) batchSet(ctx context.Context, keys, values [][]byte) error {
+	ctx, cancel := context.WithTimeout(ctx, l.opts.RequestTimeout)
+	defer cancel()
+	f := l.kv.BatchSet(ctx, keys, values)
+	defer f.Close()
+	return f.GetError()
+}
+
+// verify scans the loaded range, and compares the count and the checksum with
+// the source
+func (l *Loader) verify(ctx context.Context, r Result) error {
+	count, err := l.kv.ScanCount(ctx, r.Start, r.End)
+	if err != nil {
+		return err
+	}
+	if count != r.Count {
+		return fmt.Errorf("%w: count %d, expect %d", ErrVerifyFailed, count, r.Count)
+	}
+
+	h := newChecksum()
+	stream := l.kv.ScanStream(ctx, r.Start, r.End, client.ScanWithValue())
+	defer stream.Close()
+	for stream.Next() {
+		h.add(stream.Key(), stream.Value())
+	}
+	if err := stream.Err(); err != nil {
+		return err
+	}
+	if checksum := h.sum(); checksum != r.Checksum {
+		return fmt.Errorf("%w: checksum %d, expect %d", ErrVerifyFailed, checksum, r.Checksum)
+	}
+	return nil
+}
+
+// checksum hashes the key-value pairs with their lengths, so the boundaries of
+// the pairs are covered
+type checksum struct {
+	h    hash.Hash64
+	size [binary.MaxVarintLen64]byte
+}
+
+func newChecksum() *checksum {
+	return &checksum{h: crc64.New(checksumTable)}
+}
+
+func (c *checksum) add(key, value []byte) {
+	c.write(key)
+	c.write(value)
+}
+
+func (c *checksum) write(v []byte) {
+	n := binary.PutUvarint(c.size[:], uint64(len(v)))
+	c.h.Write(c.size[:n])
+	c.h.Write(v)
+}
+
+func (c *checksum) sum() uint64 {
+	return c.h.Sum64()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
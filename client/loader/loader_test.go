@@ -0,0 +1,143 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/client"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sliceIterator struct {
+	keys   [][]byte
+	values [][]byte
+	idx    int
+}
+
+func (it *sliceIterator) Next() bool    { it.idx++; return it.idx < len(it.keys) }
+func (it *sliceIterator) Key() []byte   { return it.keys[it.idx] }
+func (it *sliceIterator) Value() []byte { return it.values[it.idx] }
+func (it *sliceIterator) Err() error    { return nil }
+func (it *sliceIterator) Close()        {}
+
+func newTestSource(n int) Source {
+	var keys, values [][]byte
+	for i := 0; i < n; i++ {
+		keys = append(keys, []byte(fmt.Sprintf("k%04d", i)))
+		values = append(values, []byte(fmt.Sprintf("v%04d", i)))
+	}
+	return func() (Iterator, error) {
+		return &sliceIterator{keys: keys, values: values, idx: -1}, nil
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	l := &Loader{opts: Options{ShardSize: 30}}
+	l.opts.adjust()
+	r, err := l.analyze(newTestSource(10))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), r.Count)
+	assert.Equal(t, uint64(100), r.Bytes)
+	assert.Equal(t, []byte("k0000"), r.Start)
+	assert.Equal(t, []byte("k0009\x00"), r.End)
+	assert.Equal(t, [][]byte{[]byte("k0003"), []byte("k0006"), []byte("k0009")}, r.SplitKeys)
+
+	r2, err := l.analyze(newTestSource(10))
+	require.NoError(t, err)
+	assert.Equal(t, r.Checksum, r2.Checksum)
+
+	r, err = l.analyze(newTestSource(0))
+	require.NoError(t, err)
+	assert.Equal(t, Result{Checksum: newChecksum().sum()}, r)
+
+	_, err = l.analyze(func() (Iterator, error) {
+		return &sliceIterator{
+			keys:   [][]byte{[]byte("b"), []byte("a")},
+			values: [][]byte{nil, nil},
+			idx:    -1,
+		}, nil
+	})
+	assert.True(t, errors.Is(err, ErrUnsorted))
+}
+
+func TestSplitKeysInShard(t *testing.T) {
+	keys := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")}
+	assert.Equal(t, keys[1:3], splitKeysInShard(raftstore.Shard{Start: []byte("a"), End: []byte("d")}, keys))
+	assert.Equal(t, keys[2:], splitKeysInShard(raftstore.Shard{Start: []byte("b")}, keys))
+	assert.Empty(t, splitKeysInShard(raftstore.Shard{Start: []byte("d")}, keys))
+}
+
+func TestLoad(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	s := client.NewClient(client.Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+	c.WaitShardByCount(1, time.Minute)
+
+	l := NewLoader(s, c.GetProphet().GetClient(), Options{
+		ShardSize:   300,
+		BatchSize:   50,
+		Parallelism: 4,
+	})
+	defer func() {
+		assert.NoError(t, l.Close())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	r, err := l.Load(ctx, newTestSource(100))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), r.Count)
+	assert.Equal(t, 3, len(r.SplitKeys))
+	// split at the start and the end of the range too
+	c.WaitShardByCount(6, time.Minute)
+
+	kv := client.NewKVClient(s, 0, rpcpb.SelectLeader)
+	defer kv.Close()
+	f := kv.Get(ctx, []byte("k0042"))
+	defer f.Close()
+	v, err := f.Get()
+	require.NoError(t, err)
+	var resp rpcpb.KVGetResponse
+	protoc.MustUnmarshal(&resp, v)
+	assert.Equal(t, []byte("v0042"), resp.Value)
+
+	// the existing data in the range fails the verification
+	l.opts.DisableSplit = true
+	_, err = l.Load(ctx, func() (Iterator, error) {
+		return &sliceIterator{
+			keys:   [][]byte{[]byte("k0000"), []byte("k0099")},
+			values: [][]byte{[]byte("v0000"), []byte("v0099")},
+			idx:    -1,
+		}, nil
+	})
+	assert.True(t, errors.Is(err, ErrVerifyFailed))
+}
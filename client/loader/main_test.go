@@ -0,0 +1,36 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/util"
+)
+
+func TestMain(m *testing.M) {
+	// The goroutines of the default timeout wheel are started at init, they may
+	// not be running yet when the leak check of the first test takes its
+	// snapshot. Wait for a timeout to expire, so they are never reported as
+	// leaked.
+	expired := make(chan struct{})
+	if _, err := util.DefaultTimeoutWheel().Schedule(time.Millisecond,
+		func(interface{}) { close(expired) }, nil); err != nil {
+		panic(err)
+	}
+	<-expired
+	os.Exit(m.Run())
+}
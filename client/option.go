@@ -1,6 +1,7 @@
 package client
 
 import (
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/raftstore"
 	"go.uber.org/zap"
 )
@@ -21,3 +22,11 @@ func CreateWithShardsProxy(shardsProxy raftstore.ShardsProxy) CreateOption {
 		c.shardsProxy = shardsProxy
 	}
 }
+
+// CreateWithKeyRouter set the key router for client, the requests with route
+// key are sent to the shard group routed by the router
+func CreateWithKeyRouter(router config.KeyRouter) CreateOption {
+	return func(c *client) {
+		c.keyRouter = router
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// ShardWatermark is the applied index of the barrier proposed to the shard
+type ShardWatermark struct {
+	// ShardID the id of the shard
+	ShardID uint64
+	// Index the raft log index of the barrier, all the requests proposed to the
+	// shard before the barrier are applied at the index
+	Index uint64
+}
+
+func (s *client) ConsistentWatermark(ctx context.Context, shards ...uint64) ([]ShardWatermark, error) {
+	// all the barriers are proposed before waiting any of them, so the
+	// watermarks are taken after all the requests finished before the call
+	futures := make([]*Future, 0, len(shards))
+	defer func() {
+		for _, f := range futures {
+			f.Close()
+		}
+	}()
+	for _, shard := range shards {
+		futures = append(futures, s.Barrier(ctx, shard))
+	}
+
+	watermarks := make([]ShardWatermark, 0, len(shards))
+	for i, f := range futures {
+		resp, err := f.GetBarrierResponse()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the watermark of shard %d: %w",
+				shards[i], err)
+		}
+		watermarks = append(watermarks, ShardWatermark{
+			ShardID: shards[i],
+			Index:   resp.Index,
+		})
+	}
+	return watermarks, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/raftstore"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsistentWatermark(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := raftstore.NewSingleTestClusterStore(t, raftstore.WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Customize.CustomInitShardsFactory = func() []metapb.Shard {
+			return []metapb.Shard{{End: []byte("k5")}, {Start: []byte("k5")}}
+		}
+	}))
+	c.Start()
+	defer c.Stop()
+
+	s := NewClient(Cfg{Store: c.GetStore(0)})
+	assert.NoError(t, s.Start())
+	defer func() {
+		assert.NoError(t, s.Stop())
+	}()
+
+	c.WaitShardByCount(2, time.Minute)
+	shards := []uint64{c.GetShardByIndex(0, 0).ID, c.GetShardByIndex(0, 1).ID}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	w1, err := s.ConsistentWatermark(ctx, shards...)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(w1))
+	for i, w := range w1 {
+		assert.Equal(t, shards[i], w.ShardID)
+		assert.True(t, w.Index > 0)
+	}
+
+	f := s.Write(ctx, uint64(rpcpb.CmdKVSet), protoc.MustMarshal(&rpcpb.KVSetRequest{
+		Key:   []byte("k1"),
+		Value: []byte("v1"),
+	}), WithRouteKey([]byte("k1")))
+	_, err = f.Get()
+	f.Close()
+	require.NoError(t, err)
+
+	w2, err := s.ConsistentWatermark(ctx, shards...)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(w2))
+	for i := range w2 {
+		assert.True(t, w2[i].Index > w1[i].Index)
+	}
+}
@@ -71,6 +71,9 @@ type Client interface {
 	AsyncRemoveShards(ids ...uint64) error
 	// CheckShardState returns resources state
 	CheckShardState(resources *roaring64.Bitmap) (rpcpb.CheckShardStateRsp, error)
+	// CheckTombstoneReplicas returns the shards whose tombstone replicas are safe
+	// to delete
+	CheckTombstoneReplicas(replicas []rpcpb.TombstoneReplica) (*roaring64.Bitmap, error)
 
 	// PutPlacementRule put placement rule
 	PutPlacementRule(rule rpcpb.PlacementRule) error
@@ -84,6 +87,18 @@ type Client interface {
 	// GetSchedulingRules get all schedule group rules
 	GetSchedulingRules() ([]metapb.ScheduleGroupRule, error)
 
+	// PutClusterSetting puts the cluster-wide setting and returns it with the new version.
+	// The setting is persisted by the prophet and notified to all stores by the watcher.
+	PutClusterSetting(key, value string) (metapb.ClusterSetting, error)
+	// GetClusterSettings get all cluster-wide settings
+	GetClusterSettings() ([]metapb.ClusterSetting, error)
+
+	// ScatterShards scatters the replicas and the leaders of the shards in the
+	// range [start, end) of the group across the stores, e.g. after the range
+	// is pre-split for a bulk load. The shards not fully replicated yet are
+	// returned as failed, and can be scattered by a retry.
+	ScatterShards(group uint64, start, end []byte) (rpcpb.ScatterShardsRsp, error)
+
 	// CreateJob create job
 	CreateJob(metapb.Job) error
 	// RemoveJob remove job
@@ -423,6 +438,23 @@ func (c *asyncClient) CheckShardState(resources *roaring64.Bitmap) (rpcpb.CheckS
 	return rsp.CheckShardState, nil
 }
 
+func (c *asyncClient) CheckTombstoneReplicas(replicas []rpcpb.TombstoneReplica) (*roaring64.Bitmap, error) {
+	if !c.running() {
+		return nil, ErrClosed
+	}
+
+	req := &rpcpb.ProphetRequest{}
+	req.Type = rpcpb.TypeCheckTombstoneReplicasReq
+	req.CheckTombstoneReplicas.Replicas = replicas
+
+	rsp, err := c.syncDo(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.MustUnmarshalBM64(rsp.CheckTombstoneReplicas.Removable), nil
+}
+
 func (c *asyncClient) PutPlacementRule(rule rpcpb.PlacementRule) error {
 	if !c.running() {
 		return ErrClosed
@@ -491,6 +523,57 @@ func (c *asyncClient) GetSchedulingRules() ([]metapb.ScheduleGroupRule, error) {
 	return rsp.GetScheduleGroupRule.Rules, nil
 }
 
+func (c *asyncClient) PutClusterSetting(key, value string) (metapb.ClusterSetting, error) {
+	if !c.running() {
+		return metapb.ClusterSetting{}, ErrClosed
+	}
+
+	req := &rpcpb.ProphetRequest{}
+	req.Type = rpcpb.TypePutClusterSettingReq
+	req.PutClusterSetting.Setting.Key = key
+	req.PutClusterSetting.Setting.Value = value
+	rsp, err := c.syncDo(req)
+	if err != nil {
+		return metapb.ClusterSetting{}, err
+	}
+
+	return rsp.PutClusterSetting.Setting, nil
+}
+
+func (c *asyncClient) GetClusterSettings() ([]metapb.ClusterSetting, error) {
+	if !c.running() {
+		return nil, ErrClosed
+	}
+
+	req := &rpcpb.ProphetRequest{}
+	req.Type = rpcpb.TypeGetClusterSettingsReq
+	rsp, err := c.syncDo(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.GetClusterSettings.Settings, nil
+}
+
+func (c *asyncClient) ScatterShards(group uint64, start, end []byte) (rpcpb.ScatterShardsRsp, error) {
+	if !c.running() {
+		return rpcpb.ScatterShardsRsp{}, ErrClosed
+	}
+
+	req := &rpcpb.ProphetRequest{}
+	req.Type = rpcpb.TypeScatterShardsReq
+	req.ScatterShards.Group = group
+	req.ScatterShards.Start = start
+	req.ScatterShards.End = end
+
+	rsp, err := c.syncDo(req)
+	if err != nil {
+		return rpcpb.ScatterShardsRsp{}, err
+	}
+
+	return rsp.ScatterShards, nil
+}
+
 func (c *asyncClient) CreateJob(job metapb.Job) error {
 	if !c.running() {
 		return ErrClosed
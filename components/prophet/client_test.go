@@ -154,6 +154,53 @@ func TestScheduleGroupRule(t *testing.T) {
 	assert.Equal(t, 10, len(rules))
 }
 
+func TestClusterSetting(t *testing.T) {
+	p := newTestSingleProphet(t, nil)
+	defer p.Stop()
+
+	c := p.GetClient()
+	w, err := c.NewWatcher(event.InitEvent | event.SettingEvent)
+	assert.NoError(t, err)
+	defer w.Close()
+	select {
+	case e := <-w.GetNotify():
+		assert.Equal(t, event.InitEvent, e.Type)
+	case <-time.After(time.Second * 10):
+		assert.FailNow(t, "timeout")
+	}
+
+	setting, err := c.PutClusterSetting("schedule.leader-schedule-limit", "8")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), setting.Version)
+	setting, err = c.PutClusterSetting("schedule.leader-schedule-limit", "16")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), setting.Version)
+
+	_, err = c.PutClusterSetting("schedule.unknown", "1")
+	assert.Error(t, err)
+	_, err = c.PutClusterSetting("schedule.leader-schedule-limit", "abc")
+	assert.Error(t, err)
+
+	_, err = c.PutClusterSetting("feature.k1", "v1")
+	assert.NoError(t, err)
+	settings, err := c.GetClusterSettings()
+	assert.NoError(t, err)
+	assert.Equal(t, []metapb.ClusterSetting{
+		{Key: "feature.k1", Value: "v1", Version: 1},
+		{Key: "schedule.leader-schedule-limit", Value: "16", Version: 2},
+	}, settings)
+
+	for _, version := range []uint64{1, 2, 1} {
+		select {
+		case e := <-w.GetNotify():
+			assert.Equal(t, event.SettingEvent, e.Type)
+			assert.Equal(t, version, e.SettingEvent.Version)
+		case <-time.After(time.Second * 10):
+			assert.FailNow(t, "timeout")
+		}
+	}
+}
+
 func TestPutPlacementRule(t *testing.T) {
 	p := newTestSingleProphet(t, nil)
 	defer p.Stop()
@@ -0,0 +1,117 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"math"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"go.uber.org/zap"
+)
+
+const (
+	// adaptiveLimitStoreLimitMode the store limit mode tuning the store limits
+	// by the apply backlogs and latencies of the stores
+	adaptiveLimitStoreLimitMode = "adaptive"
+	// adaptiveLimitIncreaseStep the ratio is increased by this step when the
+	// cluster is idle, and halved when the cluster is busy
+	adaptiveLimitIncreaseStep = 0.1
+	// adaptiveLimitIdleFactor the cluster is considered as idle if the apply
+	// backlogs and latencies of all stores are below the thresholds multiplied
+	// by this factor
+	adaptiveLimitIdleFactor = 0.25
+)
+
+// adaptiveLimitController tunes the ratio of the add peer and remove peer
+// limits by the load of the stores. The data movement is slowed down quickly
+// when any store is busy, and speeded up slowly when all stores are idle.
+type adaptiveLimitController struct {
+	cluster *RaftCluster
+}
+
+func newAdaptiveLimitController(cluster *RaftCluster) *adaptiveLimitController {
+	return &adaptiveLimitController{cluster: cluster}
+}
+
+// check updates the ratio of the store limits, it is called periodically by
+// the background jobs.
+func (a *adaptiveLimitController) check() {
+	opt := a.cluster.GetOpts()
+	current := opt.GetStoreLimitRatio()
+	if opt.GetStoreLimitMode() != adaptiveLimitStoreLimitMode {
+		if current != 1 {
+			opt.SetStoreLimitRatio(1)
+			adaptiveLimitRatioGauge.Set(1)
+			a.cluster.logger.Info("store limit ratio restored, adaptive limit disabled")
+		}
+		return
+	}
+
+	backlog, latency := getMaxApplyLoad(a.cluster.GetStores())
+	minRatio, maxRatio := opt.GetAdaptiveLimitRatioRange()
+	ratio := nextAdaptiveLimitRatio(current, backlog, latency,
+		opt.GetAdaptiveLimitMaxApplyBacklog(),
+		opt.GetAdaptiveLimitMaxApplyLatency(),
+		minRatio, maxRatio)
+	adaptiveLimitRatioGauge.Set(ratio)
+	if ratio != current {
+		opt.SetStoreLimitRatio(ratio)
+		a.cluster.logger.Info("store limit ratio changed by adaptive limit",
+			zap.Float64("from", current),
+			zap.Float64("to", ratio),
+			zap.Uint64("max-apply-backlog", backlog),
+			zap.Duration("max-apply-latency", latency))
+	}
+}
+
+// getMaxApplyLoad returns the max apply backlog and latency of the up stores,
+// the outdated stats of the disconnected stores are ignored.
+func getMaxApplyLoad(stores []*core.CachedStore) (uint64, time.Duration) {
+	var backlog uint64
+	var latency time.Duration
+	for _, s := range stores {
+		if !s.IsUp() || s.IsDisconnected() {
+			continue
+		}
+		stats := s.GetStoreStats()
+		if stats == nil {
+			continue
+		}
+		if stats.ApplyBacklog > backlog {
+			backlog = stats.ApplyBacklog
+		}
+		if v := time.Duration(stats.ApplyLatency); v > latency {
+			latency = v
+		}
+	}
+	return backlog, latency
+}
+
+// nextAdaptiveLimitRatio returns the next ratio of the store limits, the ratio
+// is halved when the cluster is busy, increased by a step when the cluster is
+// idle and kept otherwise.
+func nextAdaptiveLimitRatio(current float64, backlog uint64, latency time.Duration,
+	maxBacklog uint64, maxLatency time.Duration, minRatio, maxRatio float64) float64 {
+	next := current
+	if backlog > maxBacklog || latency > maxLatency {
+		next = current / 2
+	} else if float64(backlog) <= float64(maxBacklog)*adaptiveLimitIdleFactor &&
+		float64(latency) <= float64(maxLatency)*adaptiveLimitIdleFactor {
+		next = current + adaptiveLimitIncreaseStep
+	}
+	// avoid the accumulated float errors of the steps
+	next = math.Round(next*1000) / 1000
+	return math.Min(math.Max(next, minRatio), maxRatio)
+}
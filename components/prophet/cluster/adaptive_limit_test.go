@@ -0,0 +1,106 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/limit"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextAdaptiveLimitRatio(t *testing.T) {
+	maxLatency := 10 * time.Millisecond
+	tests := []struct {
+		current float64
+		backlog uint64
+		latency time.Duration
+		next    float64
+	}{
+		// idle
+		{1, 0, 0, 1.1},
+		{1.1, 25, 2 * time.Millisecond, 1.2},
+		{1.95, 0, 0, 2},
+		// busy
+		{1, 101, 0, 0.5},
+		{1, 0, 11 * time.Millisecond, 0.5},
+		{0.15, 1000, 0, 0.1},
+		// normal
+		{1, 50, 0, 1},
+		{0.5, 0, 5 * time.Millisecond, 0.5},
+	}
+
+	for idx, tt := range tests {
+		assert.Equal(t, tt.next,
+			nextAdaptiveLimitRatio(tt.current, tt.backlog, tt.latency, 100, maxLatency, 0.1, 2),
+			"index %d", idx)
+	}
+}
+
+func TestAdaptiveLimitController(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	cfg := opt.GetScheduleConfig().Clone()
+	cfg.StoreLimitMode = adaptiveLimitStoreLimitMode
+	opt.SetScheduleConfig(cfg)
+	tc := newTestCluster(opt)
+	for id := uint64(1); id <= 3; id++ {
+		require.NoError(t, tc.addShardStore(id, 0))
+	}
+	addPeerLimit := opt.GetStoreLimitByType(1, limit.AddPeer)
+	removePeerLimit := opt.GetStoreLimitByType(1, limit.RemovePeer)
+
+	tc.adaptiveLimit.check()
+	assert.Equal(t, 1.1, opt.GetStoreLimitRatio())
+	assert.Equal(t, addPeerLimit*1.1, opt.GetStoreLimitByType(1, limit.AddPeer))
+	assert.Equal(t, removePeerLimit*1.1, opt.GetStoreLimitByType(2, limit.RemovePeer))
+
+	store := tc.GetStore(2)
+	stats := *store.GetStoreStats()
+	stats.ApplyBacklog = opt.GetAdaptiveLimitMaxApplyBacklog() + 1
+	tc.Lock()
+	require.NoError(t, tc.putStoreLocked(store.Clone(core.SetStoreStats(&stats))))
+	tc.Unlock()
+	tc.adaptiveLimit.check()
+	assert.Equal(t, 0.55, opt.GetStoreLimitRatio())
+	assert.Equal(t, addPeerLimit*0.55, opt.GetStoreLimitByType(1, limit.AddPeer))
+
+	cfg = opt.GetScheduleConfig().Clone()
+	cfg.StoreLimitMode = "manual"
+	opt.SetScheduleConfig(cfg)
+	tc.adaptiveLimit.check()
+	assert.Equal(t, float64(1), opt.GetStoreLimitRatio())
+	assert.Equal(t, addPeerLimit, opt.GetStoreLimitByType(1, limit.AddPeer))
+}
+
+func TestGetMaxApplyLoad(t *testing.T) {
+	now := time.Now()
+	newStore := func(id uint64, lastHeartbeat time.Time, backlog uint64, latency time.Duration) *core.CachedStore {
+		return core.NewCachedStore(metapb.Store{ID: id},
+			core.SetLastHeartbeatTS(lastHeartbeat),
+			core.SetStoreStats(&metapb.StoreStats{StoreID: id, ApplyBacklog: backlog, ApplyLatency: uint64(latency)}))
+	}
+	backlog, latency := getMaxApplyLoad([]*core.CachedStore{
+		newStore(1, now, 10, time.Millisecond),
+		newStore(2, now, 5, 2*time.Millisecond),
+		// the disconnected store is ignored
+		newStore(3, now.Add(-time.Hour), 100, time.Second),
+	})
+	assert.Equal(t, uint64(10), backlog)
+	assert.Equal(t, 2*time.Millisecond, latency)
+}
@@ -0,0 +1,236 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/notify"
+	"go.uber.org/zap"
+)
+
+// alertStartGracePeriod the stores are not considered as disconnected within
+// the period after the tracker is created, waiting for their first heartbeats
+var alertStartGracePeriod = time.Minute
+
+// alertTracker finds the stores and shards turning into the unhealthy states,
+// each of them is notified once until it recovers.
+type alertTracker struct {
+	// start the time when the tracker is created, the stores without
+	// heartbeats are considered as down since the time, so the stores loaded
+	// by a new prophet leader are not reported as down at once
+	start       time.Time
+	downStores  map[uint64]struct{}
+	unavailable map[uint64]struct{}
+	quorumLost  map[uint64]struct{}
+	// inconsistent the inconsistent replicas keyed by the shard and the store
+	inconsistent map[[2]uint64]struct{}
+}
+
+func newAlertTracker(now time.Time) *alertTracker {
+	return &alertTracker{
+		start:        now,
+		downStores:   make(map[uint64]struct{}),
+		unavailable:  make(map[uint64]struct{}),
+		quorumLost:   make(map[uint64]struct{}),
+		inconsistent: make(map[[2]uint64]struct{}),
+	}
+}
+
+// NotifyEvent sends the event to the configured notification sinks, it's used
+// by the components outside the cluster, e.g. the unsafe recovery and backup
+// jobs, to report their significant events.
+func (c *RaftCluster) NotifyEvent(e notify.Event) {
+	c.RLock()
+	n := c.notifier
+	c.RUnlock()
+	n.Notify(e)
+}
+
+// checkAlerts notifies the stores down and the shards unavailable
+func (c *RaftCluster) checkAlerts(now time.Time) {
+	if c.alerts == nil || !c.isPrepared() {
+		return
+	}
+
+	events := c.alerts.check(now, c.GetStores(), c.GetShards(),
+		c.opt.GetMaxStoreDownTime())
+	for _, e := range events {
+		c.NotifyEvent(e)
+	}
+	c.recordAlertEvents(events)
+}
+
+func (t *alertTracker) check(now time.Time, stores []*core.CachedStore,
+	shards []*core.CachedShard, maxDownTime time.Duration) []notify.Event {
+	var events []notify.Event
+	down := make(map[uint64]struct{})
+	healthy := make(map[uint64]bool, len(stores))
+	for _, s := range stores {
+		if s.IsTombstone() {
+			continue
+		}
+		id := s.Meta.GetID()
+		healthy[id] = !s.IsDisconnected() || now.Sub(t.start) < alertStartGracePeriod
+		last := s.GetLastHeartbeatTS()
+		if last.Before(t.start) {
+			last = t.start
+		}
+		if now.Sub(last) < maxDownTime {
+			continue
+		}
+		down[id] = struct{}{}
+		if _, ok := t.downStores[id]; !ok {
+			events = append(events, notify.Event{
+				Type:    notify.StoreDown,
+				Time:    now,
+				StoreID: id,
+				Message: fmt.Sprintf("no heartbeat since %s", s.GetLastHeartbeatTS().Format(time.RFC3339)),
+				Details: map[string]string{
+					"address":        s.Meta.GetClientAddress(),
+					"last-heartbeat": s.GetLastHeartbeatTS().Format(time.RFC3339),
+				},
+			})
+		}
+	}
+	t.downStores = down
+
+	unavailable := make(map[uint64]struct{})
+	for _, res := range shards {
+		voters := res.GetVoters()
+		if len(voters) == 0 {
+			continue
+		}
+		downVoters := 0
+		for _, v := range voters {
+			if _, ok := res.GetDownVoter(v.ID); ok || !healthy[v.StoreID] {
+				downVoters++
+			}
+		}
+		if downVoters <= len(voters)/2 {
+			continue
+		}
+
+		id := res.Meta.GetID()
+		unavailable[id] = struct{}{}
+		if _, ok := t.unavailable[id]; !ok {
+			events = append(events, notify.Event{
+				Type:    notify.ShardUnavailable,
+				Time:    now,
+				ShardID: id,
+				Message: fmt.Sprintf("%d of %d voters are down", downVoters, len(voters)),
+				Details: map[string]string{
+					"group":       strconv.FormatUint(res.Meta.GetGroup(), 10),
+					"voters":      strconv.Itoa(len(voters)),
+					"down-voters": strconv.Itoa(downVoters),
+				},
+			})
+		}
+	}
+	t.unavailable = unavailable
+	events = append(events, t.checkQuorumLost(now, stores, down)...)
+	return append(events, t.checkInconsistent(now, stores, down)...)
+}
+
+// checkQuorumLost notifies the shards reported as quorum lost by the surviving
+// replicas, the reports of the down stores are ignored
+func (t *alertTracker) checkQuorumLost(now time.Time, stores []*core.CachedStore,
+	down map[uint64]struct{}) []notify.Event {
+	reporters := make(map[uint64][]string)
+	for _, s := range stores {
+		if _, ok := down[s.Meta.GetID()]; ok || s.IsTombstone() {
+			continue
+		}
+		stats := s.GetStoreStats()
+		if stats == nil {
+			continue
+		}
+		for _, id := range stats.QuorumLostShards {
+			reporters[id] = append(reporters[id], strconv.FormatUint(s.Meta.GetID(), 10))
+		}
+	}
+
+	ids := make([]uint64, 0, len(reporters))
+	quorumLost := make(map[uint64]struct{}, len(reporters))
+	for id := range reporters {
+		quorumLost[id] = struct{}{}
+		if _, ok := t.quorumLost[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var events []notify.Event
+	for _, id := range ids {
+		events = append(events, notify.Event{
+			Type:    notify.ShardQuorumLost,
+			Time:    now,
+			ShardID: id,
+			Message: fmt.Sprintf("no leader and write quorum lost, reported by %d stores", len(reporters[id])),
+			Details: map[string]string{
+				"stores": strings.Join(reporters[id], ","),
+			},
+		})
+	}
+	t.quorumLost = quorumLost
+	return events
+}
+
+// checkInconsistent notifies the replicas whose data checksums diverge from the
+// leaders', each replica is notified once until it's consistent again
+func (t *alertTracker) checkInconsistent(now time.Time, stores []*core.CachedStore,
+	down map[uint64]struct{}) []notify.Event {
+	var events []notify.Event
+	inconsistent := make(map[[2]uint64]struct{})
+	for _, r := range collectInconsistentReplicas(stores, down) {
+		key := [2]uint64{r.ShardID, r.StoreID}
+		inconsistent[key] = struct{}{}
+		if _, ok := t.inconsistent[key]; ok {
+			continue
+		}
+		events = append(events, notify.Event{
+			Type:    notify.ShardInconsistent,
+			Time:    now,
+			StoreID: r.StoreID,
+			ShardID: r.ShardID,
+			Message: fmt.Sprintf("data checksum at index %d diverges from the leader", r.Index),
+			Details: map[string]string{
+				"index":       strconv.FormatUint(r.Index, 10),
+				"hash":        strconv.FormatUint(r.Hash, 10),
+				"expect-hash": strconv.FormatUint(r.ExpectHash, 10),
+			},
+		})
+	}
+	t.inconsistent = inconsistent
+	return events
+}
+
+func newNotifier(clusterID uint64, cfg *config.NotifyConfig, logger *zap.Logger) *notify.Notifier {
+	sinks := cfg.GetSinks()
+	if len(sinks) == 0 {
+		return nil
+	}
+	return notify.NewNotifier(notify.Options{
+		ClusterID:     clusterID,
+		QueueSize:     cfg.QueueSize,
+		MaxRetries:    cfg.MaxRetries,
+		RetryInterval: cfg.RetryInterval.Duration,
+	}, sinks, logger)
+}
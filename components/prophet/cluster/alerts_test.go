@@ -0,0 +1,167 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/notify"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertTrackerCheck(t *testing.T) {
+	now := time.Now()
+	tr := newAlertTracker(now.Add(-time.Hour))
+	maxDownTime := 30 * time.Minute
+
+	newStore := func(id uint64, lastHeartbeat time.Time) *core.CachedStore {
+		return core.NewCachedStore(metapb.Store{ID: id}, core.SetLastHeartbeatTS(lastHeartbeat))
+	}
+	stores := []*core.CachedStore{
+		newStore(1, now),
+		newStore(2, now.Add(-2*maxDownTime)),
+		newStore(3, now.Add(-2*maxDownTime)),
+	}
+	shards := []*core.CachedShard{
+		// 2 of 3 voters are down
+		core.NewCachedShard(metapb.Shard{ID: 1, Replicas: []metapb.Replica{
+			{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3}}}, nil),
+		// 1 of 3 voters is down
+		core.NewCachedShard(metapb.Shard{ID: 2, Replicas: []metapb.Replica{
+			{ID: 4, StoreID: 1}, {ID: 5, StoreID: 2}, {ID: 6, StoreID: 4}}}, nil),
+	}
+	stores = append(stores, newStore(4, now))
+
+	events := tr.check(now, stores, shards, maxDownTime)
+	require.Equal(t, 3, len(events))
+	assert.Equal(t, notify.StoreDown, events[0].Type)
+	assert.Equal(t, uint64(2), events[0].StoreID)
+	assert.Equal(t, notify.StoreDown, events[1].Type)
+	assert.Equal(t, uint64(3), events[1].StoreID)
+	assert.Equal(t, notify.ShardUnavailable, events[2].Type)
+	assert.Equal(t, uint64(1), events[2].ShardID)
+
+	// notified only once
+	assert.Empty(t, tr.check(now, stores, shards, maxDownTime))
+
+	// recovered and down again
+	stores[2] = newStore(3, now)
+	assert.Empty(t, tr.check(now, stores, shards, maxDownTime))
+	stores[2] = newStore(3, now.Add(-2*maxDownTime))
+	events = tr.check(now, stores, shards, maxDownTime)
+	require.Equal(t, 2, len(events))
+	assert.Equal(t, uint64(3), events[0].StoreID)
+	assert.Equal(t, uint64(1), events[1].ShardID)
+}
+
+func TestAlertTrackerWithNewLeader(t *testing.T) {
+	now := time.Now()
+	tr := newAlertTracker(now)
+	// loaded by the new prophet leader, no heartbeat received yet
+	stores := []*core.CachedStore{core.NewCachedStore(metapb.Store{ID: 1})}
+	shards := []*core.CachedShard{
+		core.NewCachedShard(metapb.Shard{ID: 1, Replicas: []metapb.Replica{{ID: 1, StoreID: 1}}}, nil),
+	}
+	assert.Empty(t, tr.check(now, stores, shards, time.Minute*30))
+
+	events := tr.check(now.Add(time.Minute*30), stores, shards, time.Minute*30)
+	require.Equal(t, 2, len(events))
+	assert.Equal(t, notify.StoreDown, events[0].Type)
+	assert.Equal(t, notify.ShardUnavailable, events[1].Type)
+}
+
+func TestAlertTrackerQuorumLost(t *testing.T) {
+	now := time.Now()
+	tr := newAlertTracker(now.Add(-time.Hour))
+	maxDownTime := 30 * time.Minute
+
+	newStore := func(id uint64, lastHeartbeat time.Time, quorumLost ...uint64) *core.CachedStore {
+		return core.NewCachedStore(metapb.Store{ID: id},
+			core.SetLastHeartbeatTS(lastHeartbeat),
+			core.SetStoreStats(&metapb.StoreStats{StoreID: id, QuorumLostShards: quorumLost}))
+	}
+	stores := []*core.CachedStore{
+		newStore(1, now, 2, 1),
+		newStore(2, now, 2),
+		// the reports of the down store are ignored
+		newStore(3, now.Add(-2*maxDownTime), 3),
+	}
+
+	events := tr.check(now, stores, nil, maxDownTime)
+	require.Equal(t, 3, len(events))
+	assert.Equal(t, notify.StoreDown, events[0].Type)
+	assert.Equal(t, notify.ShardQuorumLost, events[1].Type)
+	assert.Equal(t, uint64(1), events[1].ShardID)
+	assert.Equal(t, "1", events[1].Details["stores"])
+	assert.Equal(t, notify.ShardQuorumLost, events[2].Type)
+	assert.Equal(t, uint64(2), events[2].ShardID)
+	assert.Equal(t, "1,2", events[2].Details["stores"])
+
+	// notified only once
+	assert.Empty(t, tr.check(now, stores, nil, maxDownTime))
+
+	// recovered and lost again
+	stores[0], stores[1] = newStore(1, now, 1), newStore(2, now)
+	assert.Empty(t, tr.check(now, stores, nil, maxDownTime))
+	stores[1] = newStore(2, now, 2)
+	events = tr.check(now, stores, nil, maxDownTime)
+	require.Equal(t, 1, len(events))
+	assert.Equal(t, uint64(2), events[0].ShardID)
+}
+
+func TestAlertTrackerInconsistent(t *testing.T) {
+	now := time.Now()
+	tr := newAlertTracker(now.Add(-time.Hour))
+	maxDownTime := 30 * time.Minute
+
+	newStore := func(id uint64, lastHeartbeat time.Time, inconsistent ...metapb.InconsistentShard) *core.CachedStore {
+		return core.NewCachedStore(metapb.Store{ID: id},
+			core.SetLastHeartbeatTS(lastHeartbeat),
+			core.SetStoreStats(&metapb.StoreStats{StoreID: id, InconsistentShards: inconsistent}))
+	}
+	stores := []*core.CachedStore{
+		newStore(1, now, metapb.InconsistentShard{ShardID: 2, Index: 10, Hash: 1, ExpectHash: 2}),
+		newStore(2, now),
+		// the reports of the down store are ignored
+		newStore(3, now.Add(-2*maxDownTime), metapb.InconsistentShard{ShardID: 2}),
+	}
+
+	events := tr.check(now, stores, nil, maxDownTime)
+	require.Equal(t, 2, len(events))
+	assert.Equal(t, notify.StoreDown, events[0].Type)
+	assert.Equal(t, notify.ShardInconsistent, events[1].Type)
+	assert.Equal(t, uint64(1), events[1].StoreID)
+	assert.Equal(t, uint64(2), events[1].ShardID)
+	assert.Equal(t, map[string]string{"index": "10", "hash": "1", "expect-hash": "2"}, events[1].Details)
+
+	// notified only once
+	assert.Empty(t, tr.check(now, stores, nil, maxDownTime))
+
+	// consistent and inconsistent again
+	stores[0] = newStore(1, now)
+	assert.Empty(t, tr.check(now, stores, nil, maxDownTime))
+	stores[0] = newStore(1, now, metapb.InconsistentShard{ShardID: 2, Index: 20})
+	events = tr.check(now, stores, nil, maxDownTime)
+	require.Equal(t, 1, len(events))
+	assert.Equal(t, uint64(2), events[0].ShardID)
+
+	assert.Equal(t, []InconsistentReplica{
+		{StoreID: 1, ShardID: 2, Index: 20},
+		{StoreID: 3, ShardID: 2},
+	}, collectInconsistentReplicas(stores, nil))
+}
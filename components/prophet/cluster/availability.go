@@ -0,0 +1,260 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+)
+
+// UnavailableReason is the reason why a shard is unavailable
+type UnavailableReason string
+
+const (
+	// QuorumLost more than half of the voters are down, or the shard is
+	// reported as quorum lost by the surviving replicas
+	QuorumLost = UnavailableReason("quorum-lost")
+	// NoLeader no leader is reported by the heartbeats of the shard
+	NoLeader = UnavailableReason("no-leader")
+	// WriteBlocked the writes are blocked by the shard gate
+	WriteBlocked = UnavailableReason("write-blocked")
+)
+
+// maxClosedWindows the max number of the recovered unavailability windows kept
+// in the memory, the oldest ones are dropped first
+var maxClosedWindows = 1024
+
+// AvailabilityReport is the availability of the shards since the tracker is
+// started, i.e. since the current prophet becomes the leader.
+type AvailabilityReport struct {
+	Since  time.Time           `json:"since"`
+	Time   time.Time           `json:"time"`
+	Groups []GroupAvailability `json:"groups"`
+	// Windows the current and the latest recovered unavailability windows of
+	// the shards, ordered by the start time
+	Windows []UnavailableWindow `json:"windows"`
+}
+
+// GroupAvailability is the availability of the shards of a schedule group. The
+// durations are the sums of the durations of all the shards, so the
+// availability is 1 - unavailable duration / total duration.
+type GroupAvailability struct {
+	Group        uint64                              `json:"group"`
+	Shards       int                                 `json:"shards"`
+	Total        time.Duration                       `json:"total"`
+	Unavailable  map[UnavailableReason]time.Duration `json:"unavailable"`
+	Availability float64                             `json:"availability"`
+}
+
+// UnavailableWindow is a period during which a shard is unavailable, the End is
+// zero if the shard is not recovered yet.
+type UnavailableWindow struct {
+	ShardID uint64            `json:"shard-id"`
+	Group   uint64            `json:"group"`
+	Reason  UnavailableReason `json:"reason"`
+	Start   time.Time         `json:"start"`
+	End     time.Time         `json:"end,omitempty"`
+}
+
+// Duration returns the duration of the window until now if it's not closed
+func (w UnavailableWindow) Duration(now time.Time) time.Duration {
+	if w.End.IsZero() {
+		return now.Sub(w.Start)
+	}
+	return w.End.Sub(w.Start)
+}
+
+// availabilityTracker accumulates the unavailable durations of the shards on
+// each check, so the precision is the check interval.
+type availabilityTracker struct {
+	sync.RWMutex
+	start  time.Time
+	last   time.Time
+	groups map[uint64]*GroupAvailability
+	open   map[uint64]*UnavailableWindow
+	closed []UnavailableWindow
+}
+
+func newAvailabilityTracker(now time.Time) *availabilityTracker {
+	return &availabilityTracker{
+		start:  now,
+		last:   now,
+		groups: make(map[uint64]*GroupAvailability),
+		open:   make(map[uint64]*UnavailableWindow),
+	}
+}
+
+// checkAvailability updates the availability by the shards and the shard
+// alerts found in the same check
+func (c *RaftCluster) checkAvailability(now time.Time) {
+	if c.availability == nil || c.alerts == nil || !c.isPrepared() {
+		return
+	}
+	c.availability.check(now, c.GetShards(), c.alerts.unavailable, c.alerts.quorumLost)
+}
+
+// getUnavailableReason returns the reason why the shard is unavailable, empty
+// if it's available. The shards without leader are not reported within the
+// grace period, waiting for their first heartbeats to the new prophet leader.
+func (t *availabilityTracker) getUnavailableReason(now time.Time, res *core.CachedShard,
+	unavailable, quorumLost map[uint64]struct{}) UnavailableReason {
+	id := res.Meta.GetID()
+	if _, ok := unavailable[id]; ok {
+		return QuorumLost
+	}
+	if _, ok := quorumLost[id]; ok {
+		return QuorumLost
+	}
+	if res.GetLeader() == nil && now.Sub(t.start) >= alertStartGracePeriod {
+		return NoLeader
+	}
+	if res.Meta.GetGate().DisableWrite {
+		return WriteBlocked
+	}
+	return ""
+}
+
+func (t *availabilityTracker) check(now time.Time, shards []*core.CachedShard,
+	unavailable, quorumLost map[uint64]struct{}) {
+	t.Lock()
+	defer t.Unlock()
+
+	elapsed := now.Sub(t.last)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	t.last = now
+
+	counts := make(map[uint64]int)
+	current := make(map[uint64]struct{})
+	for _, res := range shards {
+		id := res.Meta.GetID()
+		group := res.Meta.GetGroup()
+		g := t.getGroupLocked(group)
+		g.Total += elapsed
+		counts[group]++
+
+		reason := t.getUnavailableReason(now, res, unavailable, quorumLost)
+		if w, ok := t.open[id]; ok && w.Reason != reason {
+			t.closeLocked(id, now)
+		}
+		if reason == "" {
+			continue
+		}
+
+		current[id] = struct{}{}
+		g.Unavailable[reason] += elapsed
+		shardUnavailableSecondsCounter.WithLabelValues(strconv.FormatUint(group, 10),
+			string(reason)).Add(elapsed.Seconds())
+		if _, ok := t.open[id]; !ok {
+			t.open[id] = &UnavailableWindow{
+				ShardID: id,
+				Group:   group,
+				Reason:  reason,
+				Start:   now,
+			}
+		}
+	}
+	// the removed shards are considered as recovered
+	for id := range t.open {
+		if _, ok := current[id]; !ok {
+			t.closeLocked(id, now)
+		}
+	}
+
+	for group, g := range t.groups {
+		g.Shards = counts[group]
+		g.Availability = g.availability()
+		shardAvailabilityGauge.WithLabelValues(strconv.FormatUint(group, 10)).Set(g.Availability)
+	}
+}
+
+func (t *availabilityTracker) getGroupLocked(group uint64) *GroupAvailability {
+	g, ok := t.groups[group]
+	if !ok {
+		g = &GroupAvailability{
+			Group:       group,
+			Unavailable: make(map[UnavailableReason]time.Duration),
+		}
+		t.groups[group] = g
+	}
+	return g
+}
+
+func (t *availabilityTracker) closeLocked(id uint64, now time.Time) {
+	w := t.open[id]
+	delete(t.open, id)
+	w.End = now
+	t.closed = append(t.closed, *w)
+	if n := len(t.closed) - maxClosedWindows; n > 0 {
+		t.closed = append(t.closed[:0], t.closed[n:]...)
+	}
+}
+
+func (g *GroupAvailability) availability() float64 {
+	if g.Total <= 0 {
+		return 1
+	}
+	var unavailable time.Duration
+	for _, d := range g.Unavailable {
+		unavailable += d
+	}
+	return 1 - float64(unavailable)/float64(g.Total)
+}
+
+func (t *availabilityTracker) report(now time.Time) AvailabilityReport {
+	t.RLock()
+	defer t.RUnlock()
+
+	report := AvailabilityReport{Since: t.start, Time: now}
+	for _, g := range t.groups {
+		ga := *g
+		ga.Unavailable = make(map[UnavailableReason]time.Duration, len(g.Unavailable))
+		for reason, d := range g.Unavailable {
+			ga.Unavailable[reason] = d
+		}
+		report.Groups = append(report.Groups, ga)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		return report.Groups[i].Group < report.Groups[j].Group
+	})
+
+	report.Windows = append(report.Windows, t.closed...)
+	for _, w := range t.open {
+		report.Windows = append(report.Windows, *w)
+	}
+	sort.SliceStable(report.Windows, func(i, j int) bool {
+		if report.Windows[i].Start.Equal(report.Windows[j].Start) {
+			return report.Windows[i].ShardID < report.Windows[j].ShardID
+		}
+		return report.Windows[i].Start.Before(report.Windows[j].Start)
+	})
+	return report
+}
+
+// GetAvailabilityReport returns the availability of the shards of all the
+// groups since the current prophet becomes the leader
+func (c *RaftCluster) GetAvailabilityReport() AvailabilityReport {
+	c.RLock()
+	t := c.availability
+	c.RUnlock()
+	if t == nil {
+		return AvailabilityReport{Time: time.Now()}
+	}
+	return t.report(time.Now())
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailabilityTrackerCheck(t *testing.T) {
+	start := time.Now()
+	tr := newAvailabilityTracker(start)
+
+	newShard := func(id, group uint64, hasLeader bool, gate metapb.ShardGate) *core.CachedShard {
+		replicas := []metapb.Replica{{ID: id * 10, StoreID: 1}}
+		var leader *metapb.Replica
+		if hasLeader {
+			leader = &replicas[0]
+		}
+		return core.NewCachedShard(metapb.Shard{ID: id, Group: group, Replicas: replicas, Gate: gate}, leader)
+	}
+	none := map[uint64]struct{}{}
+
+	// no leader is not reported within the grace period
+	shards := []*core.CachedShard{
+		newShard(1, 0, false, metapb.ShardGate{}),
+		newShard(2, 0, true, metapb.ShardGate{}),
+		newShard(3, 1, true, metapb.ShardGate{DisableWrite: true}),
+	}
+	now := start.Add(alertStartGracePeriod / 2)
+	tr.check(now, shards, none, none)
+	report := tr.report(now)
+	require.Equal(t, 1, len(report.Windows))
+	assert.Equal(t, uint64(3), report.Windows[0].ShardID)
+	assert.Equal(t, WriteBlocked, report.Windows[0].Reason)
+
+	// shard 1 has no leader, shard 2 lost the quorum
+	last := now
+	now = start.Add(alertStartGracePeriod)
+	tr.check(now, shards, map[uint64]struct{}{2: {}}, none)
+	report = tr.report(now)
+	require.Equal(t, 2, len(report.Groups))
+	assert.Equal(t, 2, report.Groups[0].Shards)
+	assert.Equal(t, 2*alertStartGracePeriod, report.Groups[0].Total)
+	assert.Equal(t, now.Sub(last), report.Groups[0].Unavailable[NoLeader])
+	assert.Equal(t, now.Sub(last), report.Groups[0].Unavailable[QuorumLost])
+	assert.Equal(t, 0.5, report.Groups[0].Availability)
+	assert.Equal(t, 1, report.Groups[1].Shards)
+	// the state found by the check is accounted for the whole elapsed period
+	assert.Equal(t, alertStartGracePeriod, report.Groups[1].Unavailable[WriteBlocked])
+	assert.Equal(t, 0.0, report.Groups[1].Availability)
+	require.Equal(t, 3, len(report.Windows))
+	for _, w := range report.Windows {
+		assert.True(t, w.End.IsZero())
+	}
+
+	// all recovered, shard 3 is removed
+	shards = []*core.CachedShard{
+		newShard(1, 0, true, metapb.ShardGate{}),
+		newShard(2, 0, true, metapb.ShardGate{}),
+	}
+	last = now
+	now = now.Add(alertStartGracePeriod)
+	tr.check(now, shards, none, none)
+	report = tr.report(now)
+	assert.Equal(t, 0.75, report.Groups[0].Availability)
+	assert.Equal(t, 0, report.Groups[1].Shards)
+	require.Equal(t, 3, len(report.Windows))
+	assert.Equal(t, uint64(3), report.Windows[0].ShardID)
+	assert.Equal(t, now.Sub(start.Add(alertStartGracePeriod/2)), report.Windows[0].Duration(now))
+	for _, w := range report.Windows[1:] {
+		assert.Equal(t, last, w.Start)
+		assert.Equal(t, now, w.End)
+		assert.Equal(t, alertStartGracePeriod, w.Duration(now))
+	}
+}
+
+func TestAvailabilityTrackerReasonChanged(t *testing.T) {
+	defer func(old int) { maxClosedWindows = old }(maxClosedWindows)
+	maxClosedWindows = 1
+
+	start := time.Now()
+	tr := newAvailabilityTracker(start.Add(-alertStartGracePeriod))
+	blocked := core.NewCachedShard(metapb.Shard{ID: 1, Gate: metapb.ShardGate{DisableWrite: true}},
+		&metapb.Replica{ID: 1, StoreID: 1})
+	noLeader := core.NewCachedShard(metapb.Shard{ID: 1}, nil)
+	none := map[uint64]struct{}{}
+
+	tr.check(start, []*core.CachedShard{blocked}, none, none)
+	tr.check(start.Add(time.Second), []*core.CachedShard{noLeader}, none, none)
+	tr.check(start.Add(2*time.Second), []*core.CachedShard{blocked}, none, none)
+
+	report := tr.report(start.Add(2 * time.Second))
+	// the oldest closed window is dropped
+	require.Equal(t, 2, len(report.Windows))
+	assert.Equal(t, NoLeader, report.Windows[0].Reason)
+	assert.Equal(t, time.Second, report.Windows[0].Duration(start))
+	assert.Equal(t, WriteBlocked, report.Windows[1].Reason)
+	assert.True(t, report.Windows[1].End.IsZero())
+}
@@ -0,0 +1,262 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/util"
+)
+
+// balanceDeviationEpsilon the deviations less than it are considered as 0
+const balanceDeviationEpsilon = 1e-9
+
+// BalanceReport is the balance analysis of the cluster, it's a dry run of the
+// balance schedulers, no operator is created.
+type BalanceReport struct {
+	Time time.Time `json:"time"`
+	// PredictedOperators the total number of the operators predicted to
+	// converge all the groups
+	PredictedOperators int                  `json:"predicted-operators"`
+	Groups             []GroupBalanceReport `json:"groups"`
+}
+
+// GroupBalanceReport is the balance of a schedule group
+type GroupBalanceReport struct {
+	Group    uint64       `json:"group"`
+	GroupKey string       `json:"group-key"`
+	Leader   BalanceStats `json:"leader"`
+	Shard    BalanceStats `json:"shard"`
+}
+
+// BalanceStats is the balance of the leaders or the shards of a schedule group
+type BalanceStats struct {
+	// ScoreVariance the variance of the store scores, 0 means balanced
+	ScoreVariance float64 `json:"score-variance"`
+	// PredictedOperators the number of the leaders or the replicas to move to
+	// make the counts of all the stores reach their targets
+	PredictedOperators int `json:"predicted-operators"`
+	// TopStores the most imbalanced stores, ordered by the deviation
+	TopStores []StoreBalance `json:"top-stores"`
+}
+
+// StoreBalance is the balance of a store
+type StoreBalance struct {
+	StoreID uint64  `json:"store-id"`
+	Count   int     `json:"count"`
+	Score   float64 `json:"score"`
+	// Target the count of the store when the group is balanced, proportional
+	// to the weight of the store
+	Target float64 `json:"target"`
+}
+
+// Deviation returns the count above or below the target
+func (s StoreBalance) Deviation() float64 {
+	return float64(s.Count) - s.Target
+}
+
+// balanceReporter produces the balance reports on schedule
+type balanceReporter struct {
+	cfg  config.BalanceReportConfig
+	next time.Time
+}
+
+func newBalanceReporter(cfg config.BalanceReportConfig, now time.Time) *balanceReporter {
+	if !cfg.Enable {
+		return nil
+	}
+	return &balanceReporter{cfg: cfg, next: cfg.NextReportTime(now)}
+}
+
+// checkBalanceReport produces and persists the balance report if it's time
+func (c *RaftCluster) checkBalanceReport(now time.Time) {
+	r := c.balanceReporter
+	if r == nil || now.Before(r.next) || !c.isPrepared() {
+		return
+	}
+	r.next = r.cfg.NextReportTime(now)
+
+	if _, err := c.RunBalanceReport(); err != nil {
+		c.logger.Error("fail to create balance report",
+			zap.Error(err))
+	}
+}
+
+// RunBalanceReport creates a balance report at once and persists it, the
+// oldest reports exceeding the retention are removed.
+func (c *RaftCluster) RunBalanceReport() (BalanceReport, error) {
+	topStores, retention := 0, 0
+	if c.balanceReporter != nil {
+		topStores = c.balanceReporter.cfg.TopStores
+		retention = c.balanceReporter.cfg.Retention
+	}
+
+	report := c.GenerateBalanceReport(time.Now(), topStores)
+	if err := c.storage.PutBalanceReport(report.Time.UnixNano(), report); err != nil {
+		return report, err
+	}
+	c.logger.Info("balance report created",
+		zap.Int("groups", len(report.Groups)),
+		zap.Int("predicted-operators", report.PredictedOperators))
+
+	if retention > 0 {
+		if err := c.removeStaleBalanceReports(retention); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// GetBalanceReports returns the latest persisted balance reports, from the
+// oldest to the newest.
+func (c *RaftCluster) GetBalanceReports(limit int) ([]BalanceReport, error) {
+	var reports []BalanceReport
+	err := c.storage.LoadBalanceReports(batch, func(timestamp int64, v string) error {
+		report := BalanceReport{}
+		if err := json.Unmarshal([]byte(v), &report); err != nil {
+			return err
+		}
+		reports = append(reports, report)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(reports) > limit {
+		reports = reports[len(reports)-limit:]
+	}
+	return reports, nil
+}
+
+func (c *RaftCluster) removeStaleBalanceReports(retention int) error {
+	var timestamps []int64
+	if err := c.storage.LoadBalanceReports(batch, func(timestamp int64, v string) error {
+		timestamps = append(timestamps, timestamp)
+		return nil
+	}); err != nil {
+		return err
+	}
+	for len(timestamps) > retention {
+		if err := c.storage.RemoveBalanceReport(timestamps[0]); err != nil {
+			return err
+		}
+		timestamps = timestamps[1:]
+	}
+	return nil
+}
+
+// GenerateBalanceReport analyzes the leader and the shard balance of the up
+// stores in each schedule group, topStores 0 means all the imbalanced stores.
+func (c *RaftCluster) GenerateBalanceReport(now time.Time, topStores int) BalanceReport {
+	report := BalanceReport{Time: now}
+	lowSpaceRatio := c.opt.GetLowSpaceRatio()
+	highSpaceRatio := c.opt.GetHighSpaceRatio()
+	policy := c.opt.GetLeaderSchedulePolicy()
+
+	var stores []*core.CachedStore
+	for _, s := range c.GetStores() {
+		if s.IsUp() && !s.IsLowSpace(lowSpaceRatio) {
+			stores = append(stores, s)
+		}
+	}
+
+	for _, group := range c.core.GetScheduleGroupKeys() {
+		leaders := make([]StoreBalance, 0, len(stores))
+		shards := make([]StoreBalance, 0, len(stores))
+		leaderWeights := make([]float64, 0, len(stores))
+		shardWeights := make([]float64, 0, len(stores))
+		for _, s := range stores {
+			id := s.Meta.GetID()
+			leaders = append(leaders, StoreBalance{
+				StoreID: id,
+				Count:   c.core.GetStoreLeaderCount(group, id),
+				Score:   s.LeaderScore(group, policy, 0),
+			})
+			leaderWeights = append(leaderWeights, s.GetLeaderWeight())
+			shards = append(shards, StoreBalance{
+				StoreID: id,
+				Count:   c.core.GetStoreShardCount(group, id),
+				Score:   s.ShardScore(group, highSpaceRatio, lowSpaceRatio, 0, 0),
+			})
+			shardWeights = append(shardWeights, s.GetShardWeight())
+		}
+
+		value := GroupBalanceReport{
+			Group:    util.DecodeGroupKey(group),
+			GroupKey: hex.EncodeToString([]byte(group)),
+			Leader:   analyzeBalance(leaders, leaderWeights, topStores),
+			Shard:    analyzeBalance(shards, shardWeights, topStores),
+		}
+		report.PredictedOperators += value.Leader.PredictedOperators +
+			value.Shard.PredictedOperators
+		report.Groups = append(report.Groups, value)
+	}
+	return report
+}
+
+// analyzeBalance sets the targets of the stores by their weights, and
+// predicts the operators as the count to move out of the stores above their
+// targets.
+func analyzeBalance(stores []StoreBalance, weights []float64, topStores int) BalanceStats {
+	stats := BalanceStats{}
+	if len(stores) == 0 {
+		return stats
+	}
+
+	total, totalWeight, totalScore := 0, 0.0, 0.0
+	for idx := range stores {
+		total += stores[idx].Count
+		totalWeight += weights[idx]
+		totalScore += stores[idx].Score
+	}
+
+	mean := totalScore / float64(len(stores))
+	excess := 0.0
+	for idx := range stores {
+		if totalWeight > 0 {
+			stores[idx].Target = float64(total) * weights[idx] / totalWeight
+		}
+		if d := stores[idx].Deviation(); d > 0 {
+			excess += d
+		}
+		diff := stores[idx].Score - mean
+		stats.ScoreVariance += diff * diff
+	}
+	stats.ScoreVariance /= float64(len(stores))
+	stats.PredictedOperators = int(math.Ceil(excess - balanceDeviationEpsilon))
+
+	sort.Slice(stores, func(i, j int) bool {
+		di, dj := math.Abs(stores[i].Deviation()), math.Abs(stores[j].Deviation())
+		if di != dj {
+			return di > dj
+		}
+		return stores[i].StoreID < stores[j].StoreID
+	})
+	for _, s := range stores {
+		if (topStores > 0 && len(stats.TopStores) >= topStores) ||
+			math.Abs(s.Deviation()) < balanceDeviationEpsilon {
+			break
+		}
+		stats.TopStores = append(stats.TopStores, s)
+	}
+	return stats
+}
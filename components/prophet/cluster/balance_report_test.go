@@ -0,0 +1,118 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeBalance(t *testing.T) {
+	stats := analyzeBalance([]StoreBalance{
+		{StoreID: 1, Count: 6, Score: 6},
+		{StoreID: 2, Count: 3, Score: 3},
+		{StoreID: 3, Count: 0, Score: 0},
+	}, []float64{1, 1, 1}, 0)
+	assert.Equal(t, 6.0, stats.ScoreVariance)
+	assert.Equal(t, 3, stats.PredictedOperators)
+	assert.Equal(t, []StoreBalance{
+		{StoreID: 1, Count: 6, Score: 6, Target: 3},
+		{StoreID: 3, Count: 0, Score: 0, Target: 3},
+	}, stats.TopStores)
+
+	// balanced by the weights
+	stats = analyzeBalance([]StoreBalance{
+		{StoreID: 1, Count: 4, Score: 2},
+		{StoreID: 2, Count: 2, Score: 2},
+	}, []float64{2, 1}, 0)
+	assert.Equal(t, 0.0, stats.ScoreVariance)
+	assert.Equal(t, 0, stats.PredictedOperators)
+	assert.Empty(t, stats.TopStores)
+
+	assert.Equal(t, BalanceStats{}, analyzeBalance(nil, nil, 1))
+}
+
+func TestGenerateBalanceReport(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	tc := newTestCluster(opt)
+	for id := uint64(1); id <= 4; id++ {
+		require.NoError(t, tc.addShardStore(id, 0))
+	}
+	for id := uint64(1); id <= 10; id++ {
+		require.NoError(t, tc.addLeaderShard(id, 1, 2, 3))
+	}
+	// the store scores are updated by the heartbeats
+	for _, group := range tc.core.GetScheduleGroupKeys() {
+		for id := uint64(1); id <= 4; id++ {
+			tc.updateStoreStatusLocked(group, id)
+		}
+	}
+
+	now := time.Now()
+	report := tc.GenerateBalanceReport(now, 1)
+	assert.Equal(t, now, report.Time)
+	require.Equal(t, 1, len(report.Groups))
+	group := report.Groups[0]
+	// 30 replicas, target 7.5 per store, 2.5 replicas to move out of store 1, 2
+	// and 3
+	assert.Equal(t, 8, group.Shard.PredictedOperators)
+	require.Equal(t, 1, len(group.Shard.TopStores))
+	assert.Equal(t, uint64(4), group.Shard.TopStores[0].StoreID)
+	assert.Equal(t, 7.5, group.Shard.TopStores[0].Target)
+	// 10 leaders, target 2.5 per store
+	assert.Equal(t, 8, group.Leader.PredictedOperators)
+	require.Equal(t, 1, len(group.Leader.TopStores))
+	assert.Equal(t, uint64(1), group.Leader.TopStores[0].StoreID)
+	assert.Equal(t, 10, group.Leader.TopStores[0].Count)
+	assert.True(t, group.Leader.ScoreVariance > 0)
+	assert.Equal(t, 16, report.PredictedOperators)
+}
+
+func TestBalanceReportPersisted(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	tc := newTestCluster(opt)
+	for id := uint64(1); id <= 3; id++ {
+		require.NoError(t, tc.addShardStore(id, 0))
+	}
+	require.NoError(t, tc.addLeaderShard(1, 1, 2, 3))
+
+	cfg := config.BalanceReportConfig{Enable: true, Retention: 2, TopStores: 1}
+	tc.balanceReporter = newBalanceReporter(cfg, time.Now())
+	var created []BalanceReport
+	for i := 0; i < 3; i++ {
+		report, err := tc.RunBalanceReport()
+		require.NoError(t, err)
+		created = append(created, report)
+	}
+
+	reports, err := tc.GetBalanceReports(0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(reports))
+	assert.True(t, created[1].Time.Equal(reports[0].Time))
+	assert.True(t, created[2].Time.Equal(reports[1].Time))
+	assert.Equal(t, created[2].Groups, reports[1].Groups)
+
+	reports, err = tc.GetBalanceReports(1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(reports))
+	assert.True(t, created[2].Time.Equal(reports[0].Time))
+
+	assert.Nil(t, newBalanceReporter(config.BalanceReportConfig{}, time.Now()))
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+// blockingReasons keeps the latest reason why each operation of the shards is
+// declined. The split and conf change reasons are reported by the shard
+// leaders, and the merge reasons are recorded by the merge checker.
+type blockingReasons struct {
+	sync.RWMutex
+	shards map[uint64]map[metapb.ShardOperation]metapb.BlockingReason
+}
+
+func newBlockingReasons() *blockingReasons {
+	return &blockingReasons{
+		shards: make(map[uint64]map[metapb.ShardOperation]metapb.BlockingReason),
+	}
+}
+
+// isReportedByLeader returns true if the reason of the operation is reported
+// by the shard leader
+func isReportedByLeader(op metapb.ShardOperation) bool {
+	return op != metapb.ShardOperation_Merge
+}
+
+// updateFromHeartbeat replaces the reasons reported by the shard leader
+func (b *blockingReasons) updateFromHeartbeat(res *core.CachedShard) {
+	b.Lock()
+	defer b.Unlock()
+	id := res.Meta.GetID()
+	reasons := b.shards[id]
+	for op := range reasons {
+		if isReportedByLeader(op) {
+			delete(reasons, op)
+		}
+	}
+	for _, r := range res.GetBlockingReasons() {
+		if !isReportedByLeader(r.Operation) {
+			continue
+		}
+		if reasons == nil {
+			reasons = make(map[metapb.ShardOperation]metapb.BlockingReason)
+		}
+		reasons[r.Operation] = r
+	}
+	b.setLocked(id, reasons)
+}
+
+func (b *blockingReasons) record(id uint64, op metapb.ShardOperation, reason string, now time.Time) {
+	b.Lock()
+	defer b.Unlock()
+	reasons := b.shards[id]
+	if reasons == nil {
+		reasons = make(map[metapb.ShardOperation]metapb.BlockingReason)
+		b.shards[id] = reasons
+	}
+	reasons[op] = metapb.BlockingReason{Operation: op, Reason: reason, Time: now.Unix()}
+}
+
+func (b *blockingReasons) clear(id uint64, op metapb.ShardOperation) {
+	b.Lock()
+	defer b.Unlock()
+	reasons := b.shards[id]
+	delete(reasons, op)
+	b.setLocked(id, reasons)
+}
+
+func (b *blockingReasons) remove(id uint64) {
+	b.Lock()
+	defer b.Unlock()
+	delete(b.shards, id)
+}
+
+func (b *blockingReasons) setLocked(id uint64, reasons map[metapb.ShardOperation]metapb.BlockingReason) {
+	if len(reasons) == 0 {
+		delete(b.shards, id)
+		return
+	}
+	b.shards[id] = reasons
+}
+
+// get returns the reasons of the shard ordered by the operation
+func (b *blockingReasons) get(id uint64) []metapb.BlockingReason {
+	b.RLock()
+	defer b.RUnlock()
+	return sortedBlockingReasons(b.shards[id])
+}
+
+func (b *blockingReasons) all() map[uint64][]metapb.BlockingReason {
+	b.RLock()
+	defer b.RUnlock()
+	values := make(map[uint64][]metapb.BlockingReason, len(b.shards))
+	for id, reasons := range b.shards {
+		values[id] = sortedBlockingReasons(reasons)
+	}
+	return values
+}
+
+func sortedBlockingReasons(reasons map[metapb.ShardOperation]metapb.BlockingReason) []metapb.BlockingReason {
+	if len(reasons) == 0 {
+		return nil
+	}
+	values := make([]metapb.BlockingReason, 0, len(reasons))
+	for _, r := range reasons {
+		values = append(values, r)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Operation < values[j].Operation
+	})
+	return values
+}
+
+// RecordBlockingReason records the latest reason why the operation of the shard
+// is declined by prophet, e.g. the merge checker.
+func (c *RaftCluster) RecordBlockingReason(shardID uint64, op metapb.ShardOperation, reason string) {
+	c.blockingReasons.record(shardID, op, reason, time.Now())
+}
+
+// ClearBlockingReason clears the reason of the operation of the shard, it's
+// called once the operation is not declined any more.
+func (c *RaftCluster) ClearBlockingReason(shardID uint64, op metapb.ShardOperation) {
+	c.blockingReasons.clear(shardID, op)
+}
+
+// GetShardBlockingReasons returns the latest reasons why the operations of the
+// shard are declined, ordered by the operation.
+func (c *RaftCluster) GetShardBlockingReasons(shardID uint64) []metapb.BlockingReason {
+	return c.blockingReasons.get(shardID)
+}
+
+// GetBlockingReasons returns the latest blocking reasons of all the shards
+// which have any operation declined.
+func (c *RaftCluster) GetBlockingReasons() map[uint64][]metapb.BlockingReason {
+	return c.blockingReasons.all()
+}
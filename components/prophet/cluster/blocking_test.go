@@ -0,0 +1,60 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardBlockingReasons(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	assert.NoError(t, err)
+
+	cluster := newTestRaftCluster(opt, storage.NewTestStorage(), core.NewBasicCluster(nil))
+	for _, store := range newTestStores(3, "2.0.0") {
+		assert.NoError(t, cluster.putStoreLocked(store))
+	}
+
+	shard := newTestShards(1, 3)[0]
+	assert.NoError(t, cluster.processShardHeartbeat(shard))
+	assert.Empty(t, cluster.GetShardBlockingReasons(shard.Meta.ID))
+	assert.Empty(t, cluster.GetBlockingReasons())
+
+	cluster.RecordBlockingReason(shard.Meta.ID, metapb.ShardOperation_Merge, "no target")
+	split := metapb.BlockingReason{Operation: metapb.ShardOperation_Split, Reason: "pending snapshot", Time: 1}
+	assert.NoError(t, cluster.processShardHeartbeat(shard.Clone(core.WithBlockingReasons([]metapb.BlockingReason{split}))))
+	reasons := cluster.GetShardBlockingReasons(shard.Meta.ID)
+	require.Equal(t, 2, len(reasons))
+	assert.Equal(t, split, reasons[0])
+	assert.Equal(t, metapb.ShardOperation_Merge, reasons[1].Operation)
+	assert.Equal(t, "no target", reasons[1].Reason)
+	assert.True(t, reasons[1].Time > 0)
+
+	// the reasons reported by the leader are replaced, the merge reason is kept
+	assert.NoError(t, cluster.processShardHeartbeat(shard))
+	reasons = cluster.GetShardBlockingReasons(shard.Meta.ID)
+	require.Equal(t, 1, len(reasons))
+	assert.Equal(t, metapb.ShardOperation_Merge, reasons[0].Operation)
+	assert.Equal(t, 1, len(cluster.GetBlockingReasons()))
+
+	cluster.ClearBlockingReason(shard.Meta.ID, metapb.ShardOperation_Merge)
+	assert.Empty(t, cluster.GetShardBlockingReasons(shard.Meta.ID))
+	assert.Empty(t, cluster.GetBlockingReasons())
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/core"
 	"github.com/matrixorigin/matrixcube/components/prophet/event"
 	"github.com/matrixorigin/matrixcube/components/prophet/limit"
+	"github.com/matrixorigin/matrixcube/components/prophet/notify"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/checker"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/hbstream"
@@ -92,12 +94,23 @@ type RaftCluster struct {
 	storage storage.Storage
 	limiter *StoreLimiter
 
+	expansion      *expansionController
+	adaptiveLimit  *adaptiveLimitController
+	offline        *offlineTracker
+	slowStores     *slowStoreTracker
+	alerts         *alertTracker
+	availability   *availabilityTracker
+	notifier       *notify.Notifier
 	prepareChecker *prepareChecker
 	changedEvents  chan rpcpb.EventNotify
 	createShardC   chan struct{}
 
 	labelLevelStats *statistics.LabelStatistics
 	shardStats      *statistics.ShardStatistics
+	balanceReporter *balanceReporter
+	timeline        *timeline
+	blockingReasons *blockingReasons
+	settings        *clusterSettings
 
 	coordinator      *coordinator
 	suspectShards    *cache.TTLUint64 // suspectShards are shards that may need fix
@@ -148,6 +161,12 @@ func (c *RaftCluster) InitCluster(opt *config.PersistOptions, storage storage.St
 	c.storage = storage
 	c.labelLevelStats = statistics.NewLabelStatistics()
 	c.prepareChecker = newPrepareChecker()
+	c.expansion = newExpansionController(c)
+	c.adaptiveLimit = newAdaptiveLimitController(c)
+	c.offline = newOfflineTracker()
+	c.slowStores = newSlowStoreTracker()
+	c.blockingReasons = newBlockingReasons()
+	c.settings = newClusterSettings()
 	c.suspectShards = cache.NewIDTTL(c.ctx, time.Minute, 3*time.Minute)
 	c.suspectKeyRanges = cache.NewStringTTL(c.ctx, time.Minute, 3*time.Minute)
 
@@ -185,6 +204,17 @@ func (c *RaftCluster) Start(s Server) error {
 	c.coordinator = newCoordinator(c.ctx, cluster, s.GetHBStreams())
 	c.shardStats = statistics.NewShardStatistics(c.opt, c.ruleManager)
 	c.limiter = NewStoreLimiter(s.GetPersistOptions(), c.logger)
+	c.alerts = newAlertTracker(time.Now())
+	c.availability = newAvailabilityTracker(time.Now())
+	c.balanceReporter = newBalanceReporter(s.GetConfig().BalanceReport, time.Now())
+	c.timeline = newTimeline(s.GetConfig().Timeline, c.storage, c.logger)
+	c.timeline.record(TimelineEvent{
+		Type:    ProphetLeaderChanged,
+		Message: fmt.Sprintf("%s becomes the prophet leader", s.GetConfig().Name),
+		Details: map[string]string{"leader": s.GetConfig().Name},
+	})
+	c.notifier = newNotifier(c.clusterID, &s.GetConfig().Notify, c.logger)
+	c.notifier.Start()
 	c.quit = make(chan struct{})
 
 	c.wg.Add(2)
@@ -228,6 +258,29 @@ func (c *RaftCluster) LoadClusterInfo() (*RaftCluster, error) {
 	c.logger.Info("shard group rules loaded",
 		zap.Int("count", c.core.GetShardGroupRuleCount()),
 		zap.Duration("cost", time.Since(start)))
+
+	// load cluster-wide settings
+	start = time.Now()
+	if err := c.settings.load(c.storage); err != nil {
+		return nil, err
+	}
+	settings := c.settings.list()
+	for _, setting := range settings {
+		cfg, err := applyScheduleSetting(c.opt.GetScheduleConfig(), setting)
+		if err != nil {
+			c.logger.Error("failed to apply cluster setting",
+				zap.String("key", setting.Key),
+				zap.String("value", setting.Value),
+				zap.Error(err))
+			continue
+		}
+		if cfg != nil {
+			c.opt.SetScheduleConfig(cfg)
+		}
+	}
+	c.logger.Info("cluster settings loaded",
+		zap.Int("count", len(settings)),
+		zap.Duration("cost", time.Since(start)))
 	return c, nil
 }
 
@@ -257,6 +310,13 @@ func (c *RaftCluster) runBackgroundJobs(interval time.Duration) {
 			return
 		case <-ticker.C:
 			c.checkStores()
+			c.checkSlowStores(time.Now())
+			c.checkAlerts(time.Now())
+			c.checkAvailability(time.Now())
+			c.checkBalanceReport(time.Now())
+			c.timeline.prune(time.Now())
+			c.expansion.check()
+			c.adaptiveLimit.check()
 			c.collectMetrics()
 			c.coordinator.opController.PruneHistory()
 			c.doNotifyCreateShards()
@@ -300,6 +360,7 @@ func (c *RaftCluster) Stop() {
 	c.coordinator.stop()
 	c.Unlock()
 	c.wg.Wait()
+	c.notifier.Stop()
 }
 
 // IsRunning return if the cluster is running.
@@ -495,6 +556,7 @@ func (c *RaftCluster) processShardHeartbeat(res *core.CachedShard) error {
 	// Save to cache if meta or leader is updated, or contains any down/pending peer.
 	// Mark isNew if the shard in cache does not have leader.
 	var saveKV, saveCache, isNew bool
+	var overlaps []*core.CachedShard
 	if origin == nil {
 		c.logger.Debug("insert new shard",
 			zap.Uint64("shard", res.Meta.GetID()))
@@ -550,7 +612,8 @@ func (c *RaftCluster) processShardHeartbeat(res *core.CachedShard) error {
 		if res.GetBytesWritten() != origin.GetBytesWritten() ||
 			res.GetBytesRead() != origin.GetBytesRead() ||
 			res.GetKeysWritten() != origin.GetKeysWritten() ||
-			res.GetKeysRead() != origin.GetKeysRead() {
+			res.GetKeysRead() != origin.GetKeysRead() ||
+			res.GetReadLatency() != origin.GetReadLatency() {
 			saveCache = true
 		}
 		if res.GetLease().GetEpoch() > origin.GetLease().GetEpoch() ||
@@ -562,6 +625,7 @@ func (c *RaftCluster) processShardHeartbeat(res *core.CachedShard) error {
 		}
 	}
 
+	c.blockingReasons.updateFromHeartbeat(res)
 	if !saveKV && !saveCache && !isNew {
 		return nil
 	}
@@ -585,7 +649,7 @@ func (c *RaftCluster) processShardHeartbeat(res *core.CachedShard) error {
 			return err
 		}
 
-		overlaps := c.core.PutShard(res)
+		overlaps = c.core.PutShard(res)
 		if c.storage != nil {
 			for _, item := range overlaps {
 				if err := c.storage.RemoveShard(item.Meta); err != nil {
@@ -600,6 +664,7 @@ func (c *RaftCluster) processShardHeartbeat(res *core.CachedShard) error {
 				c.shardStats.ClearDefunctShard(item.Meta.GetID())
 			}
 			c.labelLevelStats.ClearDefunctShard(item.Meta.GetID())
+			c.blockingReasons.remove(item.Meta.GetID())
 		}
 
 		// Update related stores.
@@ -633,9 +698,14 @@ func (c *RaftCluster) processShardHeartbeat(res *core.CachedShard) error {
 	if c.shardStats != nil {
 		c.shardStats.Observe(res, c.takeShardStoresLocked(res))
 	}
+	tl := c.timeline
 
 	c.Unlock()
 
+	if saveCache {
+		tl.recordShardEvents(origin, res, overlaps)
+	}
+
 	// If there are concurrent heartbeats from the same shard, the last write will win even if
 	// writes to storage in the critical area. So don't use mutex to protect it.
 	if saveKV && c.storage != nil {
@@ -865,7 +935,8 @@ func (c *RaftCluster) putStoreImpl(store metapb.Store, force bool) error {
 	}
 
 	s := c.GetStore(store.GetID())
-	if s == nil {
+	isNew := s == nil
+	if isNew {
 		// Add a new store.
 		s = core.NewCachedStore(store)
 	} else {
@@ -883,12 +954,27 @@ func (c *RaftCluster) putStoreImpl(store metapb.Store, force bool) error {
 			core.SetStoreLabels(labels),
 			core.SetStoreStartTime(store.GetStartTime()),
 			core.SetStoreDeployPath(store.GetDeployPath()),
+			core.SetStoreDraining(store.GetDraining()),
 		)
 	}
 	if err := c.checkStoreLabels(s); err != nil {
 		return err
 	}
-	return c.putStoreLocked(s)
+	if err := c.putStoreLocked(s); err != nil {
+		return err
+	}
+	if isNew {
+		c.timeline.record(TimelineEvent{
+			Type:    StoreJoined,
+			StoreID: store.GetID(),
+			Message: fmt.Sprintf("store %d joined", store.GetID()),
+			Details: map[string]string{
+				"address":      store.GetClientAddress(),
+				"raft-address": store.GetRaftAddress(),
+			},
+		})
+	}
+	return nil
 }
 
 func (c *RaftCluster) checkStoreLabels(s *core.CachedStore) error {
@@ -955,6 +1041,15 @@ func (c *RaftCluster) RemoveStore(storeID uint64, physicallyDestroyed bool) erro
 		// TODO: if the persist operation encounters error, the "Unlimited" will be rollback.
 		// And considering the store state has changed, RemoveStore is actually successful.
 		c.SetStoreLimit(storeID, limit.RemovePeer, limit.Unlimited)
+		c.timeline.record(TimelineEvent{
+			Type:    StoreOffline,
+			StoreID: storeID,
+			Message: fmt.Sprintf("store %d is offline", storeID),
+			Details: map[string]string{
+				"address":              newStore.Meta.GetClientAddress(),
+				"physically-destroyed": strconv.FormatBool(physicallyDestroyed),
+			},
+		})
 	}
 	return err
 }
@@ -1070,7 +1165,7 @@ func (c *RaftCluster) putStoreLocked(store *core.CachedStore) error {
 }
 
 func (c *RaftCluster) checkStores() {
-	var offlineStores []metapb.Store
+	var offlineStores []*core.CachedStore
 	var upStoreCount int
 	stores := c.GetStores()
 	groupKeys := c.core.GetScheduleGroupKeys()
@@ -1102,10 +1197,11 @@ func (c *RaftCluster) checkStores() {
 					zap.Error(err))
 			}
 		} else {
-			offlineStores = append(offlineStores, offlineStore)
+			offlineStores = append(offlineStores, store)
 		}
 	}
 
+	c.updateOfflineProgress(offlineStores, upStoreCount, groupKeys)
 	if len(offlineStores) == 0 {
 		return
 	}
@@ -1114,10 +1210,29 @@ func (c *RaftCluster) checkStores() {
 	if !c.opt.IsPlacementRulesEnabled() && upStoreCount < c.opt.GetMaxReplicas() {
 		for _, store := range offlineStores {
 			c.logger.Warn("store may not turn into Tombstone, there are no extra up store has enough space to accommodate the extra replica",
-				zap.Uint64("store", store.GetID()),
-				zap.String("store-address", store.GetClientAddress()))
+				zap.Uint64("store", store.Meta.GetID()),
+				zap.String("store-address", store.Meta.GetClientAddress()))
+		}
+	}
+}
+
+func (c *RaftCluster) updateOfflineProgress(offlineStores []*core.CachedStore,
+	upStoreCount int, groupKeys []string) {
+	now := time.Now()
+	ids := make(map[uint64]struct{}, len(offlineStores))
+	for _, store := range offlineStores {
+		id := store.Meta.GetID()
+		ids[id] = struct{}{}
+		count := 0
+		var size int64
+		for _, group := range groupKeys {
+			count += c.core.GetStoreShardCount(group, id)
+			size += c.core.GetStoreShardSize(group, id)
 		}
+		c.offline.update(now, id, count, size,
+			c.getOfflineBlockingReasons(store, upStoreCount, groupKeys))
 	}
+	c.offline.retain(ids)
 }
 
 // RemoveTombStoneRecords removes the tombStone Records.
@@ -1259,6 +1374,14 @@ func (c *RaftCluster) GetMergeChecker() *checker.MergeChecker {
 	return c.coordinator.checkers.GetMergeChecker()
 }
 
+// GetEmptyShards returns the IDs of the empty shards found after the mass
+// deletions, they are merged into the adjacent shards or reused by the pools.
+func (c *RaftCluster) GetEmptyShards() []uint64 {
+	c.RLock()
+	defer c.RUnlock()
+	return c.coordinator.checkers.GetEmptyShardChecker().GetEmptyShards()
+}
+
 // isPrepared if the cluster information is collected
 func (c *RaftCluster) isPrepared() bool {
 	c.RLock()
@@ -1376,6 +1499,12 @@ func (c *RaftCluster) IsSchedulerDisabled(name string) (bool, error) {
 	return c.coordinator.isSchedulerDisabled(name)
 }
 
+// GetExpansionProgress returns the rebalancing progress of the new joined stores,
+// the schedule limits are boosted until all the new stores are balanced.
+func (c *RaftCluster) GetExpansionProgress() []ExpansionProgress {
+	return c.expansion.getProgress()
+}
+
 // GetStoreLimiter returns the dynamic adjusting limiter
 func (c *RaftCluster) GetStoreLimiter() *StoreLimiter {
 	return c.limiter
@@ -1429,6 +1558,7 @@ func (c *RaftCluster) RemoveStoreLimit(storeID uint64) {
 		c.AttachAvailableFunc(storeID, limitType, nil)
 	}
 	delete(cfg.StoreLimit, storeID)
+	delete(cfg.StoreResourceLimit, storeID)
 	c.opt.SetScheduleConfig(cfg)
 
 	var err error
@@ -1465,6 +1595,62 @@ func (c *RaftCluster) SetStoreLimit(storeID uint64, typ limit.Type, ratePerMin f
 	return nil
 }
 
+// GetStoreResourceLimit returns the resource limits of a store, which are set
+// by SetStoreResourceLimit or the labels of the store.
+func (c *RaftCluster) GetStoreResourceLimit(storeID uint64) (config.StoreResourceLimitConfig, error) {
+	store := c.GetStore(storeID)
+	if store == nil {
+		return config.StoreResourceLimitConfig{}, fmt.Errorf("store %d not found", storeID)
+	}
+	return c.opt.GetStoreResourceLimit(store), nil
+}
+
+// SetStoreResourceLimit sets the resource limits of a store, which take
+// precedence over the limits set by the labels of the store.
+func (c *RaftCluster) SetStoreResourceLimit(storeID uint64, cfg config.StoreResourceLimitConfig) error {
+	if c.GetStore(storeID) == nil {
+		return fmt.Errorf("store %d not found", storeID)
+	}
+
+	old := c.opt.GetScheduleConfig().Clone()
+	c.opt.SetStoreResourceLimit(storeID, cfg)
+	if err := c.opt.Persist(c.storage); err != nil {
+		// roll back the store resource limit
+		c.opt.SetScheduleConfig(old)
+		c.logger.Error("fail to persist store resource limit",
+			zap.Uint64("store", storeID),
+			zap.Error(err))
+		return err
+	}
+
+	c.logger.Info("store resource limit changed",
+		zap.Uint64("store", storeID),
+		zap.Uint64("max-replicas", cfg.MaxReplicas),
+		zap.Uint64("max-leaders", cfg.MaxLeaders),
+		zap.Uint64("max-size", cfg.MaxSize))
+	return nil
+}
+
+// DeleteStoreResourceLimit deletes the resource limits of a store set by
+// SetStoreResourceLimit, then the limits set by the labels of the store are
+// used.
+func (c *RaftCluster) DeleteStoreResourceLimit(storeID uint64) error {
+	old := c.opt.GetScheduleConfig().Clone()
+	c.opt.DeleteStoreResourceLimit(storeID)
+	if err := c.opt.Persist(c.storage); err != nil {
+		// roll back the store resource limit
+		c.opt.SetScheduleConfig(old)
+		c.logger.Error("fail to persist store resource limit",
+			zap.Uint64("store", storeID),
+			zap.Error(err))
+		return err
+	}
+
+	c.logger.Info("store resource limit deleted",
+		zap.Uint64("store", storeID))
+	return nil
+}
+
 // SetAllStoresLimit sets all store limit for a given type and rate.
 func (c *RaftCluster) SetAllStoresLimit(typ limit.Type, ratePerMin float64) error {
 	old := c.opt.GetScheduleConfig().Clone()
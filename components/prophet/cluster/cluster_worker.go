@@ -17,7 +17,9 @@ package cluster
 import (
 	"fmt"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/components/prophet/core"
 	"github.com/matrixorigin/matrixcube/components/prophet/event"
 	"github.com/matrixorigin/matrixcube/components/prophet/id"
@@ -353,6 +355,39 @@ func (c *RaftCluster) HandleCheckShardState(request *rpcpb.ProphetRequest) (*rpc
 	}, nil
 }
 
+// HandleCheckTombstoneReplicas returns the shards whose tombstone replicas are
+// safe to delete. A tombstone replica is safe to delete if the shard has been
+// destroyed, or the shard epoch in prophet has caught up with the epoch seen by
+// the tombstone replica and the replica is no longer a member of the shard.
+func (c *RaftCluster) HandleCheckTombstoneReplicas(request *rpcpb.ProphetRequest) (*rpcpb.CheckTombstoneReplicasRsp, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	removable := roaring64.New()
+	for _, tr := range request.CheckTombstoneReplicas.Replicas {
+		if c.core.AlreadyRemoved(tr.ShardID) {
+			removable.Add(tr.ShardID)
+			continue
+		}
+
+		res := c.core.GetShard(tr.ShardID)
+		if res == nil {
+			continue
+		}
+		if _, ok := res.GetPeer(tr.ReplicaID); ok {
+			continue
+		}
+		epoch := res.Meta.GetEpoch()
+		if epoch.ConfigVer >= tr.Epoch.ConfigVer &&
+			epoch.Generation >= tr.Epoch.Generation {
+			removable.Add(tr.ShardID)
+		}
+	}
+	return &rpcpb.CheckTombstoneReplicasRsp{
+		Removable: util.MustMarshalBM64(removable),
+	}, nil
+}
+
 // HandlePutPlacementRule handle put placement rule
 func (c *RaftCluster) HandlePutPlacementRule(request *rpcpb.ProphetRequest) error {
 	return c.GetRuleManager().SetRule(placement.NewRuleFromRPC(request.PutPlacementRule.Rule))
@@ -477,3 +512,82 @@ func (c *RaftCluster) saveDestroyingStatusLocked(id uint64, status *metapb.Destr
 	c.core.UpdateDestroyingStatus(id, status)
 	return nil
 }
+
+// HandlePutClusterSetting handles the cluster-wide setting update, the new
+// setting is persisted and then notified to all the watchers.
+func (c *RaftCluster) HandlePutClusterSetting(request *rpcpb.ProphetRequest) (metapb.ClusterSetting, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if !c.running {
+		return metapb.ClusterSetting{}, util.ErrNotLeader
+	}
+
+	req := request.PutClusterSetting.Setting
+	if req.Key == "" {
+		return metapb.ClusterSetting{}, fmt.Errorf("missing cluster setting key")
+	}
+
+	setting := c.settings.next(req.Key, req.Value)
+	cfg, err := applyScheduleSetting(c.opt.GetScheduleConfig(), setting)
+	if err != nil {
+		return metapb.ClusterSetting{}, err
+	}
+
+	// sync with etcd
+	if err := c.storage.PutClusterSetting(setting); err != nil {
+		return metapb.ClusterSetting{}, err
+	}
+
+	c.settings.put(setting)
+	if cfg != nil {
+		c.opt.SetScheduleConfig(cfg)
+	}
+	c.logger.Info("cluster setting updated",
+		zap.String("key", setting.Key),
+		zap.String("value", setting.Value),
+		zap.Uint64("version", setting.Version))
+	c.addNotifyLocked(event.NewSettingEvent(setting))
+	return setting, nil
+}
+
+// HandleGetClusterSettings returns all the cluster-wide settings
+func (c *RaftCluster) HandleGetClusterSettings(request *rpcpb.ProphetRequest) ([]metapb.ClusterSetting, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if !c.running {
+		return nil, util.ErrNotLeader
+	}
+	return c.settings.list(), nil
+}
+
+// HandleScatterShards scatters the replicas and the leaders of the shards in
+// the range, e.g. the shards pre-split before a bulk load, so the load is not
+// concentrated on the stores of the original shard. The shards not fully
+// replicated yet are counted as failed, and can be scattered by a retry.
+func (c *RaftCluster) HandleScatterShards(request *rpcpb.ProphetRequest) (*rpcpb.ScatterShardsRsp, error) {
+	if !c.IsRunning() {
+		return nil, util.ErrNotLeader
+	}
+
+	req := request.ScatterShards
+	ops, failures, err := c.GetShardScatter().ScatterShardsByRange(req.Group,
+		req.Start, req.End, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	added := 0
+	if len(ops) > 0 {
+		added = c.GetOperatorController().AddWaitingOperator(ops...)
+	}
+	c.logger.Info("shards scattered",
+		zap.Uint64("group", req.Group),
+		log.HexField("start", req.Start),
+		log.HexField("end", req.End),
+		zap.Int("operators", added),
+		zap.Int("failed", len(failures)))
+	return &rpcpb.ScatterShardsRsp{
+		Scattered: uint64(added),
+		Failed:    uint64(len(failures)),
+	}, nil
+}
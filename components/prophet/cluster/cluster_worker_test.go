@@ -266,6 +266,41 @@ func TestHandleCheckShardState(t *testing.T) {
 	assert.Equal(t, 3, len(destroyed))
 }
 
+func TestHandleCheckTombstoneReplicas(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	assert.NoError(t, err)
+	cluster := newTestRaftCluster(opt, storage.NewTestStorage(), core.NewBasicCluster(nil))
+
+	n, np := uint64(5), uint64(3)
+	shards := newTestShards(n, np)
+	for i := uint64(1); i < n; i++ {
+		cluster.processShardHeartbeat(shards[i])
+	}
+	cluster.HandleRemoveShards(&rpcpb.ProphetRequest{
+		RemoveShards: rpcpb.RemoveShardsReq{IDs: []uint64{4}},
+	})
+
+	epoch := metapb.ShardEpoch{ConfigVer: 2, Generation: 2}
+	rsp, err := cluster.HandleCheckTombstoneReplicas(&rpcpb.ProphetRequest{
+		CheckTombstoneReplicas: rpcpb.CheckTombstoneReplicasReq{
+			Replicas: []rpcpb.TombstoneReplica{
+				// not a member
+				{ShardID: 1, ReplicaID: 100, Epoch: epoch},
+				// still a member
+				{ShardID: 2, ReplicaID: 6, Epoch: epoch},
+				// prophet has not seen the epoch
+				{ShardID: 3, ReplicaID: 100, Epoch: metapb.ShardEpoch{ConfigVer: 3, Generation: 2}},
+				// destroyed
+				{ShardID: 4, ReplicaID: 12, Epoch: epoch},
+				// unknown shard
+				{ShardID: 10, ReplicaID: 100, Epoch: epoch},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 4}, util.MustUnmarshalBM64(rsp.Removable).ToArray())
+}
+
 func checkNotifyCount(t *testing.T, nc <-chan rpcpb.EventNotify, expectNotifyTypes ...uint32) {
 	for _, nt := range expectNotifyTypes {
 		select {
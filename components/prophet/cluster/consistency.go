@@ -0,0 +1,73 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"sort"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+)
+
+// InconsistentReplica is a replica whose data checksum diverges from the
+// checksum of the leader at the same applied index, it's found by the
+// consistency check of the shard and reported by the store heartbeats.
+type InconsistentReplica struct {
+	StoreID uint64 `json:"store-id"`
+	ShardID uint64 `json:"shard-id"`
+	// Index the applied index of the compared checksums
+	Index uint64 `json:"index"`
+	// Hash the data checksum of the replica
+	Hash uint64 `json:"hash"`
+	// ExpectHash the data checksum of the leader
+	ExpectHash uint64 `json:"expect-hash"`
+}
+
+// collectInconsistentReplicas returns the inconsistent replicas reported by the
+// stores, sorted by the shard and the store. The reports of the tombstone
+// stores and the skipped stores are ignored.
+func collectInconsistentReplicas(stores []*core.CachedStore,
+	skip map[uint64]struct{}) []InconsistentReplica {
+	var values []InconsistentReplica
+	for _, s := range stores {
+		if _, ok := skip[s.Meta.GetID()]; ok || s.IsTombstone() {
+			continue
+		}
+		stats := s.GetStoreStats()
+		if stats == nil {
+			continue
+		}
+		for _, v := range stats.InconsistentShards {
+			values = append(values, InconsistentReplica{
+				StoreID:    s.Meta.GetID(),
+				ShardID:    v.ShardID,
+				Index:      v.Index,
+				Hash:       v.Hash,
+				ExpectHash: v.ExpectHash,
+			})
+		}
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].ShardID != values[j].ShardID {
+			return values[i].ShardID < values[j].ShardID
+		}
+		return values[i].StoreID < values[j].StoreID
+	})
+	return values
+}
+
+// GetInconsistentReplicas returns the replicas whose data checksums diverge
+// from the leaders', reported by the latest store heartbeats
+func (c *RaftCluster) GetInconsistentReplicas() []InconsistentReplica {
+	return collectInconsistentReplicas(c.GetStores(), nil)
+}
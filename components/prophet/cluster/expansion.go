@@ -0,0 +1,212 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ExpansionProgress is the rebalancing progress of a new joined store
+type ExpansionProgress struct {
+	StoreID uint64 `json:"store-id"`
+	// StartTime the time when the store is found as a new store
+	StartTime time.Time `json:"start-time"`
+	// InitShardCount the shard count when the store is found as a new store
+	InitShardCount int `json:"init-shard-count"`
+	// ShardCount the current shard count of the store
+	ShardCount int `json:"shard-count"`
+	// LeaderCount the current leader count of the store
+	LeaderCount int `json:"leader-count"`
+	// TargetShardCount the expansion of the store is finished when the shard
+	// count reaches this value
+	TargetShardCount int `json:"target-shard-count"`
+}
+
+// Ratio returns the finished ratio of the rebalancing
+func (p ExpansionProgress) Ratio() float64 {
+	total := p.TargetShardCount - p.InitShardCount
+	if total <= 0 {
+		return 1
+	}
+	moved := p.ShardCount - p.InitShardCount
+	if moved <= 0 {
+		return 0
+	}
+	if moved >= total {
+		return 1
+	}
+	return float64(moved) / float64(total)
+}
+
+// expansionController watches the new empty stores joined into the cluster, and
+// boosts the schedule limits until the new stores are balanced.
+type expansionController struct {
+	sync.Mutex
+	cluster  *RaftCluster
+	progress map[uint64]*ExpansionProgress
+}
+
+func newExpansionController(cluster *RaftCluster) *expansionController {
+	return &expansionController{
+		cluster:  cluster,
+		progress: make(map[uint64]*ExpansionProgress),
+	}
+}
+
+// check updates the progress of the new stores and the boost of the schedule
+// limits, it is called periodically by the background jobs.
+func (e *expansionController) check() {
+	e.Lock()
+	defer e.Unlock()
+
+	opt := e.cluster.GetOpts()
+	if !opt.IsExpansionRebalanceEnabled() {
+		e.finishLocked("disabled")
+		return
+	}
+
+	shards, leaders, avg := e.getStoreCounts()
+	if avg == 0 {
+		e.finishLocked("no shards")
+		return
+	}
+
+	now := time.Now()
+	target := int(avg * opt.GetExpansionFinishRatio())
+	for id, p := range e.progress {
+		count, ok := shards[id]
+		if !ok {
+			delete(e.progress, id)
+			expansionProgressGauge.DeleteLabelValues(fmt.Sprintf("%d", id))
+			e.cluster.logger.Info("expansion rebalancing of store aborted, store is not up",
+				zap.Uint64("store", id))
+			continue
+		}
+		p.ShardCount = count
+		p.LeaderCount = leaders[id]
+		p.TargetShardCount = target
+		if count >= target {
+			delete(e.progress, id)
+			expansionProgressGauge.DeleteLabelValues(fmt.Sprintf("%d", id))
+			e.cluster.logger.Info("expansion rebalancing of store completed",
+				zap.Uint64("store", id),
+				zap.Int("shard-count", count),
+				zap.Int("leader-count", p.LeaderCount),
+				zap.Duration("cost", now.Sub(p.StartTime)))
+		}
+	}
+
+	trigger := avg * opt.GetExpansionTriggerRatio()
+	for id, count := range shards {
+		if _, ok := e.progress[id]; ok {
+			continue
+		}
+		if float64(count) < trigger {
+			e.progress[id] = &ExpansionProgress{
+				StoreID:          id,
+				StartTime:        now,
+				InitShardCount:   count,
+				ShardCount:       count,
+				LeaderCount:      leaders[id],
+				TargetShardCount: target,
+			}
+			e.cluster.logger.Info("new store found, start expansion rebalancing",
+				zap.Uint64("store", id),
+				zap.Int("shard-count", count),
+				zap.Int("target-shard-count", target))
+		}
+	}
+
+	if len(e.progress) == 0 {
+		e.finishLocked("balanced")
+		return
+	}
+
+	stores := make([]uint64, 0, len(e.progress))
+	for id, p := range e.progress {
+		stores = append(stores, id)
+		expansionProgressGauge.WithLabelValues(fmt.Sprintf("%d", id)).Set(p.Ratio())
+		e.cluster.logger.Info("expansion rebalancing in progress",
+			zap.Uint64("store", id),
+			zap.Int("shard-count", p.ShardCount),
+			zap.Int("leader-count", p.LeaderCount),
+			zap.Int("target-shard-count", p.TargetShardCount),
+			zap.Float64("ratio", p.Ratio()))
+	}
+	if !opt.IsExpansionBoosted() {
+		e.cluster.logger.Info("schedule limits boosted for expansion rebalancing",
+			zap.Uint64("factor", opt.GetExpansionScheduleFactor()),
+			zap.Uint64s("stores", stores))
+	}
+	opt.SetExpansionBoost(opt.GetExpansionScheduleFactor(), stores)
+}
+
+func (e *expansionController) finishLocked(reason string) {
+	for id := range e.progress {
+		delete(e.progress, id)
+		expansionProgressGauge.DeleteLabelValues(fmt.Sprintf("%d", id))
+	}
+	opt := e.cluster.GetOpts()
+	if opt.IsExpansionBoosted() {
+		opt.ClearExpansionBoost()
+		e.cluster.logger.Info("schedule limits restored, expansion rebalancing finished",
+			zap.String("reason", reason))
+	}
+}
+
+// getStoreCounts returns the shard and leader counts of the up stores and the
+// average shard count of the up stores.
+func (e *expansionController) getStoreCounts() (map[uint64]int, map[uint64]int, float64) {
+	groupKeys := e.cluster.core.GetScheduleGroupKeys()
+	lowSpaceRatio := e.cluster.opt.GetLowSpaceRatio()
+	shards := make(map[uint64]int)
+	leaders := make(map[uint64]int)
+	total := 0
+	for _, store := range e.cluster.GetStores() {
+		if !store.IsUp() || store.IsLowSpace(lowSpaceRatio) {
+			continue
+		}
+		id := store.Meta.GetID()
+		count := 0
+		for _, group := range groupKeys {
+			count += e.cluster.core.GetStoreShardCount(group, id)
+			leaders[id] += e.cluster.core.GetStoreLeaderCount(group, id)
+		}
+		shards[id] = count
+		total += count
+	}
+	if len(shards) == 0 {
+		return shards, leaders, 0
+	}
+	return shards, leaders, float64(total) / float64(len(shards))
+}
+
+// getProgress returns the progress of the stores in expansion rebalancing
+func (e *expansionController) getProgress() []ExpansionProgress {
+	e.Lock()
+	defer e.Unlock()
+	values := make([]ExpansionProgress, 0, len(e.progress))
+	for _, p := range e.progress {
+		values = append(values, *p)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].StoreID < values[j].StoreID
+	})
+	return values
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/limit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpansionRebalance(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	cfg := opt.GetScheduleConfig().Clone()
+	cfg.EnableExpansionRebalance = true
+	opt.SetScheduleConfig(cfg)
+	tc := newTestCluster(opt)
+
+	leaderLimit := opt.GetLeaderScheduleLimit()
+	shardLimit := opt.GetShardScheduleLimit()
+	replicaLimit := opt.GetReplicaScheduleLimit()
+	for id := uint64(1); id <= 4; id++ {
+		require.NoError(t, tc.addShardStore(id, 0))
+	}
+	addPeerLimit := opt.GetStoreLimitByType(4, limit.AddPeer)
+	for id := uint64(1); id <= 10; id++ {
+		require.NoError(t, tc.addLeaderShard(id, 1, 2, 3))
+	}
+
+	tc.expansion.check()
+	progress := tc.GetExpansionProgress()
+	require.Equal(t, 1, len(progress))
+	assert.Equal(t, uint64(4), progress[0].StoreID)
+	assert.Equal(t, 0, progress[0].ShardCount)
+	// avg = 30 / 4, target = int(7.5 * 0.9)
+	assert.Equal(t, 6, progress[0].TargetShardCount)
+	assert.Equal(t, float64(0), progress[0].Ratio())
+
+	factor := opt.GetExpansionScheduleFactor()
+	assert.True(t, opt.IsExpansionBoosted())
+	assert.Equal(t, leaderLimit*factor, opt.GetLeaderScheduleLimit())
+	assert.Equal(t, shardLimit*factor, opt.GetShardScheduleLimit())
+	assert.Equal(t, replicaLimit*factor, opt.GetReplicaScheduleLimit())
+	assert.Equal(t, addPeerLimit*float64(factor), opt.GetStoreLimitByType(4, limit.AddPeer))
+	assert.Equal(t, addPeerLimit, opt.GetStoreLimitByType(1, limit.AddPeer))
+
+	// move replicas to the new store
+	for id := uint64(1); id <= 3; id++ {
+		require.NoError(t, tc.addLeaderShard(id, 4, 2, 3))
+	}
+	tc.expansion.check()
+	progress = tc.GetExpansionProgress()
+	require.Equal(t, 1, len(progress))
+	assert.Equal(t, 3, progress[0].ShardCount)
+	assert.Equal(t, 3, progress[0].LeaderCount)
+	assert.Equal(t, 0.5, progress[0].Ratio())
+	assert.True(t, opt.IsExpansionBoosted())
+
+	for id := uint64(4); id <= 6; id++ {
+		require.NoError(t, tc.addLeaderShard(id, 4, 2, 3))
+	}
+	tc.expansion.check()
+	assert.Empty(t, tc.GetExpansionProgress())
+	assert.False(t, opt.IsExpansionBoosted())
+	assert.Equal(t, leaderLimit, opt.GetLeaderScheduleLimit())
+	assert.Equal(t, shardLimit, opt.GetShardScheduleLimit())
+	assert.Equal(t, replicaLimit, opt.GetReplicaScheduleLimit())
+	assert.Equal(t, addPeerLimit, opt.GetStoreLimitByType(4, limit.AddPeer))
+}
+
+func TestExpansionRebalanceDisabled(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	tc := newTestCluster(opt)
+	for id := uint64(1); id <= 4; id++ {
+		require.NoError(t, tc.addShardStore(id, 0))
+	}
+	for id := uint64(1); id <= 10; id++ {
+		require.NoError(t, tc.addLeaderShard(id, 1, 2, 3))
+	}
+
+	tc.expansion.check()
+	assert.Empty(t, tc.GetExpansionProgress())
+	assert.False(t, opt.IsExpansionBoosted())
+}
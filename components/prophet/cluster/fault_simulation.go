@@ -0,0 +1,144 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"sort"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+// FaultScenario is a hypothetical loss of stores. A store is considered as lost
+// if its id is in StoreIDs, or it has all the Labels, e.g. the labels
+// [zone=z1] means the zone z1 dies, and [zone=z1, rack=r1] means the rack r1 in
+// the zone z1 dies.
+type FaultScenario struct {
+	StoreIDs []uint64       `json:"store-ids,omitempty"`
+	Labels   []metapb.Label `json:"labels,omitempty"`
+}
+
+// ShardFaultImpact is the impact of the fault scenario on a shard
+type ShardFaultImpact struct {
+	ShardID uint64 `json:"shard-id"`
+	Group   uint64 `json:"group"`
+	// Voters the count of the voters
+	Voters int `json:"voters"`
+	// LostVoters the count of the voters on the lost stores
+	LostVoters int `json:"lost-voters"`
+	// LostLearners the count of the learners on the lost stores
+	LostLearners int `json:"lost-learners"`
+	// LostLeader the leader is on the lost stores
+	LostLeader bool `json:"lost-leader"`
+}
+
+// FaultSimulationResult is the result of the fault simulation. A shard which
+// lost quorum is only in LostQuorum, and a shard which lost the leader is also
+// in LostReplica.
+type FaultSimulationResult struct {
+	// LostStores the stores matched by the fault scenario
+	LostStores []uint64 `json:"lost-stores"`
+	// LostQuorum the shards that lost the majority of the voters, they become
+	// unavailable until the stores recovered
+	LostQuorum []ShardFaultImpact `json:"lost-quorum,omitempty"`
+	// LostReplica the shards that lost replicas but still have the quorum
+	LostReplica []ShardFaultImpact `json:"lost-replica,omitempty"`
+	// LostLeader the shards that lost the leader but still have the quorum, a
+	// new leader needs to be elected
+	LostLeader []ShardFaultImpact `json:"lost-leader,omitempty"`
+}
+
+// SimulateFault computes which shards would lose quorum, lose a replica, or
+// lose leadership if the stores in the fault scenario are lost. The tombstone
+// stores and the destroyed shards are ignored.
+func (c *RaftCluster) SimulateFault(scenario FaultScenario) FaultSimulationResult {
+	var result FaultSimulationResult
+	lost := make(map[uint64]struct{})
+	for _, store := range c.GetStores() {
+		if store.IsTombstone() || !scenario.matches(store) {
+			continue
+		}
+		lost[store.Meta.GetID()] = struct{}{}
+		result.LostStores = append(result.LostStores, store.Meta.GetID())
+	}
+	sort.Slice(result.LostStores, func(i, j int) bool {
+		return result.LostStores[i] < result.LostStores[j]
+	})
+	if len(lost) == 0 {
+		return result
+	}
+
+	for _, res := range c.GetShards() {
+		if res.IsDestroyState() {
+			continue
+		}
+		impact, ok := simulateShardFault(res, lost)
+		if !ok {
+			continue
+		}
+		if impact.LostVoters*2 >= impact.Voters {
+			result.LostQuorum = append(result.LostQuorum, impact)
+			continue
+		}
+		result.LostReplica = append(result.LostReplica, impact)
+		if impact.LostLeader {
+			result.LostLeader = append(result.LostLeader, impact)
+		}
+	}
+	for _, impacts := range [][]ShardFaultImpact{result.LostQuorum, result.LostReplica, result.LostLeader} {
+		sort.Slice(impacts, func(i, j int) bool {
+			return impacts[i].ShardID < impacts[j].ShardID
+		})
+	}
+	return result
+}
+
+func simulateShardFault(res *core.CachedShard, lost map[uint64]struct{}) (ShardFaultImpact, bool) {
+	impact := ShardFaultImpact{
+		ShardID: res.Meta.GetID(),
+		Group:   res.Meta.GetGroup(),
+		Voters:  len(res.GetVoters()),
+	}
+	for _, p := range res.GetVoters() {
+		if _, ok := lost[p.StoreID]; ok {
+			impact.LostVoters++
+		}
+	}
+	for _, p := range res.GetLearners() {
+		if _, ok := lost[p.StoreID]; ok {
+			impact.LostLearners++
+		}
+	}
+	if leader := res.GetLeader(); leader != nil {
+		_, impact.LostLeader = lost[leader.StoreID]
+	}
+	return impact, impact.LostVoters > 0 || impact.LostLearners > 0
+}
+
+func (s FaultScenario) matches(store *core.CachedStore) bool {
+	for _, id := range s.StoreIDs {
+		if id == store.Meta.GetID() {
+			return true
+		}
+	}
+	if len(s.Labels) == 0 {
+		return false
+	}
+	for _, label := range s.Labels {
+		if store.GetLabelValue(label.Key) != label.Value {
+			return false
+		}
+	}
+	return true
+}
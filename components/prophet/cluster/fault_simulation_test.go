@@ -0,0 +1,80 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateFault(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	tc := newTestCluster(opt)
+
+	stores := map[uint64][]metapb.Label{
+		1: {{Key: "zone", Value: "z1"}, {Key: "rack", Value: "r1"}},
+		2: {{Key: "zone", Value: "z1"}, {Key: "rack", Value: "r2"}},
+		3: {{Key: "zone", Value: "z2"}, {Key: "rack", Value: "r1"}},
+		4: {{Key: "zone", Value: "z3"}, {Key: "rack", Value: "r1"}},
+	}
+	for id, labels := range stores {
+		store := core.NewCachedStore(metapb.Store{ID: id, Labels: labels},
+			core.SetLastHeartbeatTS(time.Now()))
+		tc.Lock()
+		require.NoError(t, tc.putStoreLocked(store))
+		tc.Unlock()
+	}
+	// all replicas in zone z1 except one
+	require.NoError(t, tc.addLeaderShard(1, 1, 2, 3))
+	// well placed across zones
+	require.NoError(t, tc.addLeaderShard(2, 1, 3, 4))
+	require.NoError(t, tc.addLeaderShard(3, 3, 1, 4))
+	require.NoError(t, tc.addLeaderShard(4, 3, 4))
+
+	// zone z1 dies
+	r := tc.SimulateFault(FaultScenario{Labels: []metapb.Label{{Key: "zone", Value: "z1"}}})
+	assert.Equal(t, []uint64{1, 2}, r.LostStores)
+	require.Equal(t, 1, len(r.LostQuorum))
+	assert.Equal(t, ShardFaultImpact{ShardID: 1, Voters: 3, LostVoters: 2, LostLeader: true}, r.LostQuorum[0])
+	require.Equal(t, 2, len(r.LostReplica))
+	assert.Equal(t, uint64(2), r.LostReplica[0].ShardID)
+	assert.Equal(t, uint64(3), r.LostReplica[1].ShardID)
+	require.Equal(t, 1, len(r.LostLeader))
+	assert.Equal(t, uint64(2), r.LostLeader[0].ShardID)
+
+	// rack r1 in zone z1 dies
+	r = tc.SimulateFault(FaultScenario{Labels: []metapb.Label{{Key: "zone", Value: "z1"}, {Key: "rack", Value: "r1"}}})
+	assert.Equal(t, []uint64{1}, r.LostStores)
+	assert.Empty(t, r.LostQuorum)
+	assert.Equal(t, 3, len(r.LostReplica))
+
+	// a shard with 2 voters loses quorum if any store dies
+	r = tc.SimulateFault(FaultScenario{StoreIDs: []uint64{4}})
+	assert.Equal(t, []uint64{4}, r.LostStores)
+	require.Equal(t, 1, len(r.LostQuorum))
+	assert.Equal(t, uint64(4), r.LostQuorum[0].ShardID)
+	assert.Empty(t, r.LostLeader)
+
+	// no store matched
+	r = tc.SimulateFault(FaultScenario{Labels: []metapb.Label{{Key: "zone", Value: "z4"}}})
+	assert.Empty(t, r.LostStores)
+	assert.Empty(t, r.LostReplica)
+	assert.Empty(t, tc.SimulateFault(FaultScenario{}).LostStores)
+}
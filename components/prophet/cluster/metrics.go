@@ -73,6 +73,46 @@ var (
 			Name:      "resource_waiting_list",
 			Help:      "Number of resource in waiting list",
 		})
+
+	expansionProgressGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "prophet",
+			Subsystem: "cluster",
+			Name:      "expansion_progress",
+			Help:      "Rebalancing progress of the new joined stores.",
+		}, []string{"store"})
+
+	adaptiveLimitRatioGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "prophet",
+			Subsystem: "cluster",
+			Name:      "adaptive_store_limit_ratio",
+			Help:      "Ratio of the store limits tuned by the adaptive limit mode.",
+		})
+
+	shardAvailabilityGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "prophet",
+			Subsystem: "cluster",
+			Name:      "shard_availability",
+			Help:      "Availability of the shards since the prophet becomes the leader.",
+		}, []string{"group"})
+
+	shardUnavailableSecondsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "prophet",
+			Subsystem: "cluster",
+			Name:      "shard_unavailable_seconds_total",
+			Help:      "Total unavailable seconds of the shards.",
+		}, []string{"group", "reason"})
+
+	slowStoreScoreGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "prophet",
+			Subsystem: "cluster",
+			Name:      "slow_store_score",
+			Help:      "Heartbeat latency of the slow stores divided by the median latency.",
+		}, []string{"store"})
 )
 
 func init() {
@@ -83,4 +123,9 @@ func init() {
 	prometheus.MustRegister(clusterStateCPUGauge)
 	prometheus.MustRegister(clusterStateCurrent)
 	prometheus.MustRegister(resourceWaitingListGauge)
+	prometheus.MustRegister(expansionProgressGauge)
+	prometheus.MustRegister(adaptiveLimitRatioGauge)
+	prometheus.MustRegister(shardAvailabilityGauge)
+	prometheus.MustRegister(shardUnavailableSecondsCounter)
+	prometheus.MustRegister(slowStoreScoreGauge)
 }
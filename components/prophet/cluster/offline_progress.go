@@ -0,0 +1,185 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/checker"
+)
+
+var (
+	// offlineStallDuration an offline store is considered as stalled if no
+	// replica moved out of it within the duration
+	offlineStallDuration = 10 * time.Minute
+	// offlineSampleShards the number of the shards sampled to check if the
+	// replicas on the offline store can be moved to other stores
+	offlineSampleShards = 8
+)
+
+// OfflineProgress is the decommission progress of an offline store
+type OfflineProgress struct {
+	StoreID uint64 `json:"store-id"`
+	// StartTime the time when the store is found as offline
+	StartTime time.Time `json:"start-time"`
+	// InitShardCount the shard count when the store is found as offline
+	InitShardCount int `json:"init-shard-count"`
+	// RemainingShards the replicas which are not moved out yet
+	RemainingShards int `json:"remaining-shards"`
+	// InitSize the approximate size (MB) of the replicas when the store is found
+	// as offline
+	InitSize int64 `json:"init-size"`
+	// RemainingSize the approximate size (MB) of the remaining replicas
+	RemainingSize int64 `json:"remaining-size"`
+	// MovedSize the approximate size (MB) of the replicas moved out
+	MovedSize int64 `json:"moved-size"`
+	// Rate the moving rate, MB per second
+	Rate float64 `json:"rate"`
+	// ETA the estimated duration to finish the decommission, 0 if the rate is 0
+	ETA time.Duration `json:"eta"`
+	// BlockingReasons the reasons why the decommission can not make progress
+	BlockingReasons []string `json:"blocking-reasons,omitempty"`
+
+	lastChanged time.Time
+}
+
+// offlineTracker tracks the decommission progress of the offline stores
+type offlineTracker struct {
+	sync.Mutex
+	progress map[uint64]*OfflineProgress
+}
+
+func newOfflineTracker() *offlineTracker {
+	return &offlineTracker{
+		progress: make(map[uint64]*OfflineProgress),
+	}
+}
+
+func (t *offlineTracker) update(now time.Time, storeID uint64, count int,
+	size int64, reasons []string) {
+	t.Lock()
+	defer t.Unlock()
+
+	p, ok := t.progress[storeID]
+	if !ok {
+		p = &OfflineProgress{
+			StoreID:         storeID,
+			StartTime:       now,
+			InitShardCount:  count,
+			RemainingShards: count,
+			InitSize:        size,
+			lastChanged:     now,
+		}
+		t.progress[storeID] = p
+	}
+	if count != p.RemainingShards {
+		p.lastChanged = now
+	}
+	p.RemainingShards = count
+	p.RemainingSize = size
+	p.MovedSize = p.InitSize - size
+	if p.MovedSize < 0 {
+		p.MovedSize = 0
+	}
+	p.Rate = 0
+	p.ETA = 0
+	if elapsed := now.Sub(p.StartTime).Seconds(); elapsed > 0 && p.MovedSize > 0 {
+		p.Rate = float64(p.MovedSize) / elapsed
+		p.ETA = time.Duration(float64(size) / p.Rate * float64(time.Second))
+	}
+	if stalled := now.Sub(p.lastChanged); count > 0 && stalled >= offlineStallDuration {
+		reasons = append(reasons, fmt.Sprintf("no replica moved out in the last %s", stalled.Truncate(time.Second)))
+	}
+	p.BlockingReasons = reasons
+}
+
+// retain removes the stores which are not offline any more
+func (t *offlineTracker) retain(offlineStores map[uint64]struct{}) {
+	t.Lock()
+	defer t.Unlock()
+	for id := range t.progress {
+		if _, ok := offlineStores[id]; !ok {
+			delete(t.progress, id)
+		}
+	}
+}
+
+func (t *offlineTracker) get(storeID uint64) (OfflineProgress, bool) {
+	t.Lock()
+	defer t.Unlock()
+	if p, ok := t.progress[storeID]; ok {
+		return *p, true
+	}
+	return OfflineProgress{}, false
+}
+
+func (t *offlineTracker) getAll() []OfflineProgress {
+	t.Lock()
+	defer t.Unlock()
+	values := make([]OfflineProgress, 0, len(t.progress))
+	for _, p := range t.progress {
+		values = append(values, *p)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].StoreID < values[j].StoreID
+	})
+	return values
+}
+
+// getOfflineBlockingReasons returns the reasons why the replicas on the offline
+// store can not be moved out
+func (c *RaftCluster) getOfflineBlockingReasons(store *core.CachedStore,
+	upStoreCount int, groupKeys []string) []string {
+	var reasons []string
+	if !c.opt.IsReplaceOfflineReplicaEnabled() {
+		reasons = append(reasons, "replace-offline-replica is disabled")
+	}
+	if !c.opt.IsPlacementRulesEnabled() && upStoreCount < c.opt.GetMaxReplicas() {
+		reasons = append(reasons, fmt.Sprintf("only %d up stores with enough space, less than max replicas %d",
+			upStoreCount, c.opt.GetMaxReplicas()))
+		return reasons
+	}
+
+	sampled, blocked := 0, 0
+	for _, group := range groupKeys {
+		for _, res := range c.core.GetStoreShards(group, store.Meta.GetID()) {
+			if sampled >= offlineSampleShards {
+				break
+			}
+			sampled++
+			if !checker.HasStoreToReplace(c, res, store.Meta.GetID()) {
+				blocked++
+			}
+		}
+	}
+	if blocked > 0 {
+		reasons = append(reasons, fmt.Sprintf("no valid target store for %d of %d sampled shards, check the location labels and isolation level",
+			blocked, sampled))
+	}
+	return reasons
+}
+
+// GetOfflineProgress returns the decommission progress of the offline store
+func (c *RaftCluster) GetOfflineProgress(storeID uint64) (OfflineProgress, bool) {
+	return c.offline.get(storeID)
+}
+
+// GetAllOfflineProgress returns the decommission progress of all the offline stores
+func (c *RaftCluster) GetAllOfflineProgress() []OfflineProgress {
+	return c.offline.getAll()
+}
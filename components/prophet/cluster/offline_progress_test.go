@@ -0,0 +1,139 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineTrackerProgress(t *testing.T) {
+	tr := newOfflineTracker()
+	now := time.Now()
+	tr.update(now, 1, 10, 100, nil)
+	p, ok := tr.get(1)
+	require.True(t, ok)
+	assert.Equal(t, 10, p.InitShardCount)
+	assert.Equal(t, int64(100), p.InitSize)
+	assert.Equal(t, float64(0), p.Rate)
+	assert.Equal(t, time.Duration(0), p.ETA)
+
+	now = now.Add(10 * time.Second)
+	tr.update(now, 1, 5, 50, nil)
+	p, _ = tr.get(1)
+	assert.Equal(t, 5, p.RemainingShards)
+	assert.Equal(t, int64(50), p.MovedSize)
+	assert.Equal(t, float64(5), p.Rate)
+	assert.Equal(t, 10*time.Second, p.ETA)
+	assert.Empty(t, p.BlockingReasons)
+
+	now = now.Add(offlineStallDuration)
+	tr.update(now, 1, 5, 50, []string{"reason"})
+	p, _ = tr.get(1)
+	require.Equal(t, 2, len(p.BlockingReasons))
+	assert.Equal(t, "reason", p.BlockingReasons[0])
+
+	tr.update(now, 2, 1, 1, nil)
+	assert.Equal(t, 2, len(tr.getAll()))
+	tr.retain(map[uint64]struct{}{2: {}})
+	_, ok = tr.get(1)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(2), tr.getAll()[0].StoreID)
+}
+
+func TestOfflineProgressWithLabelConstraints(t *testing.T) {
+	cfg, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	cfg.EnableReplaceOfflineReplica = true
+	opt.SetScheduleConfig(cfg)
+	rc := opt.GetReplicationConfig().Clone()
+	rc.LocationLabels = []string{"zone"}
+	rc.IsolationLevel = "zone"
+	opt.SetReplicationConfig(rc)
+	tc := newTestCluster(opt)
+
+	for id, zone := range map[uint64]string{1: "z1", 2: "z2", 3: "z3", 4: "z1"} {
+		stats := &metapb.StoreStats{Capacity: 100 * (1 << 30), Available: 100 * (1 << 30)}
+		store := core.NewCachedStore(metapb.Store{ID: id, Labels: []metapb.Label{{Key: "zone", Value: zone}}},
+			core.SetStoreStats(stats),
+			core.SetLastHeartbeatTS(time.Now()))
+		tc.Lock()
+		require.NoError(t, tc.putStoreLocked(store))
+		tc.Unlock()
+	}
+	require.NoError(t, tc.addLeaderShard(1, 1, 2, 3))
+	require.NoError(t, tc.addLeaderShard(2, 1, 2, 3))
+
+	tc.checkStores()
+	assert.Empty(t, tc.GetAllOfflineProgress())
+
+	// the replicas on store 3 can only be moved to a store in zone z3
+	require.NoError(t, tc.setStoreOffline(3))
+	tc.checkStores()
+	p, ok := tc.GetOfflineProgress(3)
+	require.True(t, ok)
+	assert.Equal(t, 2, p.InitShardCount)
+	assert.Equal(t, 2, p.RemainingShards)
+	require.Equal(t, 1, len(p.BlockingReasons))
+	assert.Contains(t, p.BlockingReasons[0], "no valid target store for 2 of 2 sampled shards")
+
+	// the replicas on store 1 can be moved to store 4
+	require.NoError(t, tc.setStoreOffline(1))
+	tc.checkStores()
+	p, ok = tc.GetOfflineProgress(1)
+	require.True(t, ok)
+	assert.Empty(t, p.BlockingReasons)
+
+	// move out the replicas from store 3
+	require.NoError(t, tc.addLeaderShard(1, 1, 2, 4))
+	require.NoError(t, tc.addLeaderShard(2, 1, 2, 4))
+	tc.checkStores()
+	_, ok = tc.GetOfflineProgress(3)
+	assert.False(t, ok)
+}
+
+func TestOfflineProgressWithNotEnoughStores(t *testing.T) {
+	cfg, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	cfg.EnableReplaceOfflineReplica = true
+	opt.SetScheduleConfig(cfg)
+	rc := opt.GetReplicationConfig().Clone()
+	rc.EnablePlacementRules = false
+	opt.SetReplicationConfig(rc)
+	tc := newTestCluster(opt)
+
+	for i := uint64(1); i <= 3; i++ {
+		require.NoError(t, tc.addShardStore(i, 1))
+	}
+	require.NoError(t, tc.addLeaderShard(1, 1, 2, 3))
+	require.NoError(t, tc.setStoreOffline(3))
+	tc.checkStores()
+	p, ok := tc.GetOfflineProgress(3)
+	require.True(t, ok)
+	assert.Equal(t, 1, p.RemainingShards)
+	assert.Equal(t, []string{"only 2 up stores with enough space, less than max replicas 3"}, p.BlockingReasons)
+
+	// a new store is added
+	require.NoError(t, tc.addShardStore(4, 0))
+	tc.checkStores()
+	p, ok = tc.GetOfflineProgress(3)
+	require.True(t, ok)
+	assert.Empty(t, p.BlockingReasons)
+	assert.Equal(t, 1, len(tc.GetAllOfflineProgress()))
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+// scheduleSettingPrefix is the key prefix of the settings which override the
+// schedule config of the prophet, e.g. `schedule.leader-schedule-limit`.
+const scheduleSettingPrefix = "schedule."
+
+// clusterSettings caches the cluster-wide dynamic settings. The settings are
+// persisted in the prophet storage, which is replicated by the embedded etcd,
+// and are propagated to the stores by the watcher events.
+type clusterSettings struct {
+	sync.RWMutex
+	settings map[string]metapb.ClusterSetting
+}
+
+func newClusterSettings() *clusterSettings {
+	return &clusterSettings{
+		settings: make(map[string]metapb.ClusterSetting),
+	}
+}
+
+func (cs *clusterSettings) load(s storage.Storage) error {
+	cs.Lock()
+	defer cs.Unlock()
+	return s.LoadClusterSettings(batch, func(setting metapb.ClusterSetting) {
+		cs.settings[setting.Key] = setting
+	})
+}
+
+// next returns the setting with the next version of the key
+func (cs *clusterSettings) next(key, value string) metapb.ClusterSetting {
+	cs.RLock()
+	defer cs.RUnlock()
+	return metapb.ClusterSetting{
+		Key:     key,
+		Value:   value,
+		Version: cs.settings[key].Version + 1,
+	}
+}
+
+func (cs *clusterSettings) put(setting metapb.ClusterSetting) {
+	cs.Lock()
+	defer cs.Unlock()
+	cs.settings[setting.Key] = setting
+}
+
+func (cs *clusterSettings) list() []metapb.ClusterSetting {
+	cs.RLock()
+	defer cs.RUnlock()
+	settings := make([]metapb.ClusterSetting, 0, len(cs.settings))
+	for _, setting := range cs.settings {
+		settings = append(settings, setting)
+	}
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+	return settings
+}
+
+// applyScheduleSetting returns a copy of the schedule config overridden by the
+// setting. The name after the `schedule.` prefix is the json name of the
+// schedule config field, and the value is parsed as a json value, falling back
+// to a plain string. Nil is returned if the setting is not a schedule setting.
+func applyScheduleSetting(cfg *config.ScheduleConfig, setting metapb.ClusterSetting) (*config.ScheduleConfig, error) {
+	if !strings.HasPrefix(setting.Key, scheduleSettingPrefix) {
+		return nil, nil
+	}
+
+	name := strings.TrimPrefix(setting.Key, scheduleSettingPrefix)
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields[name]; !ok {
+		return nil, fmt.Errorf("unknown schedule setting %s", setting.Key)
+	}
+
+	value := json.RawMessage(setting.Value)
+	if !json.Valid(value) {
+		value, _ = json.Marshal(setting.Value)
+	}
+	fields[name] = value
+	if data, err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+
+	newCfg := cfg.Clone()
+	if err := json.Unmarshal(data, newCfg); err != nil {
+		return nil, fmt.Errorf("invalid schedule setting %s: %w", setting.Key, err)
+	}
+	if err := newCfg.Validate(); err != nil {
+		return nil, err
+	}
+	return newCfg, nil
+}
+
+// GetClusterSettings returns all the cluster-wide settings
+func (c *RaftCluster) GetClusterSettings() []metapb.ClusterSetting {
+	if c.settings == nil {
+		return nil
+	}
+	return c.settings.list()
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/event"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyScheduleSetting(t *testing.T) {
+	cfg, _, err := newTestScheduleConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		key, value string
+		apply      bool
+		hasError   bool
+		check      func(cfg *config.ScheduleConfig)
+	}{
+		{key: "feature.k1", value: "v1"},
+		{key: "schedule.unknown", value: "1", hasError: true},
+		{key: "schedule.leader-schedule-limit", value: "abc", hasError: true},
+		{key: "schedule.low-space-ratio", value: "2", hasError: true},
+		{key: "schedule.leader-schedule-limit", value: "8", apply: true,
+			check: func(cfg *config.ScheduleConfig) { assert.Equal(t, uint64(8), cfg.LeaderScheduleLimit) }},
+		{key: "schedule.leader-schedule-policy", value: "size", apply: true,
+			check: func(cfg *config.ScheduleConfig) { assert.Equal(t, "size", cfg.LeaderSchedulePolicy) }},
+		{key: "schedule.max-container-down-time", value: "1h", apply: true,
+			check: func(cfg *config.ScheduleConfig) { assert.Equal(t, time.Hour, cfg.MaxStoreDownTime.Duration) }},
+	}
+
+	for i, tt := range tests {
+		newCfg, err := applyScheduleSetting(cfg, metapb.ClusterSetting{Key: tt.key, Value: tt.value})
+		assert.Equal(t, tt.hasError, err != nil, "index %d", i)
+		assert.Equal(t, tt.apply, newCfg != nil, "index %d", i)
+		if tt.check != nil {
+			tt.check(newCfg)
+			// the origin config is not changed
+			assert.NotEqual(t, newCfg, cfg, "index %d", i)
+		}
+	}
+}
+
+func TestHandlePutClusterSetting(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+
+	s := storage.NewTestStorage()
+	cluster := newTestRaftCluster(opt, s, core.NewBasicCluster(nil))
+	newReq := func(key, value string) *rpcpb.ProphetRequest {
+		req := &rpcpb.ProphetRequest{}
+		req.PutClusterSetting.Setting = metapb.ClusterSetting{Key: key, Value: value}
+		return req
+	}
+
+	_, err = cluster.HandlePutClusterSetting(newReq("k1", "v1"))
+	assert.Error(t, err)
+
+	cluster.running = true
+	_, err = cluster.HandlePutClusterSetting(newReq("", "v1"))
+	assert.Error(t, err)
+	_, err = cluster.HandlePutClusterSetting(newReq("schedule.unknown", "v1"))
+	assert.Error(t, err)
+
+	setting, err := cluster.HandlePutClusterSetting(newReq("schedule.leader-schedule-limit", "8"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), setting.Version)
+	assert.Equal(t, uint64(8), opt.GetScheduleConfig().LeaderScheduleLimit)
+	setting, err = cluster.HandlePutClusterSetting(newReq("schedule.leader-schedule-limit", "16"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), setting.Version)
+	assert.Equal(t, uint64(16), opt.GetScheduleConfig().LeaderScheduleLimit)
+
+	for _, version := range []uint64{1, 2} {
+		e := <-cluster.ChangedEventNotifier()
+		assert.Equal(t, event.SettingEvent, e.Type)
+		assert.Equal(t, version, e.SettingEvent.Version)
+	}
+
+	settings, err := cluster.HandleGetClusterSettings(&rpcpb.ProphetRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []metapb.ClusterSetting{setting}, settings)
+
+	// the settings are reloaded by the new prophet leader
+	_, opt, err = newTestScheduleConfig()
+	require.NoError(t, err)
+	cluster = newTestRaftCluster(opt, s, core.NewBasicCluster(nil))
+	_, err = cluster.LoadClusterInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, []metapb.ClusterSetting{setting}, cluster.GetClusterSettings())
+	assert.Equal(t, uint64(16), opt.GetScheduleConfig().LeaderScheduleLimit)
+}
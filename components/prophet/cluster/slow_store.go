@@ -0,0 +1,207 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+)
+
+// slowStoreMinReporters the min number of the stores reporting the latencies
+// to a store, before the store can be considered as slow. The latencies
+// reported by a slow store are high too, since the heartbeat responses are
+// handled slowly by the slow store, so at least 2 reporters are required to
+// tell which one is slow.
+const slowStoreMinReporters = 2
+
+// SlowStore is a store whose replicas respond the heartbeats of the leaders in
+// the other stores slowly
+type SlowStore struct {
+	StoreID uint64 `json:"store-id"`
+	// Score the latency divided by the median latency of all the stores
+	Score float64 `json:"score"`
+	// Latency the min average heartbeat latency reported by the other stores
+	Latency time.Duration `json:"latency"`
+	// Since the time when the store is found slow
+	Since time.Time `json:"since"`
+	// Evidence the heartbeat latencies reported by the other stores
+	Evidence []SlowStoreEvidence `json:"evidence"`
+}
+
+// SlowStoreEvidence is the heartbeat latency from the leaders in the reporter
+// store to the replicas in the slow store
+type SlowStoreEvidence struct {
+	ReporterID uint64        `json:"reporter-id"`
+	Latency    time.Duration `json:"latency"`
+	Samples    uint64        `json:"samples"`
+}
+
+// slowStoreTracker tracks the slow stores found by the latest check
+type slowStoreTracker struct {
+	sync.Mutex
+	slow map[uint64]SlowStore
+}
+
+func newSlowStoreTracker() *slowStoreTracker {
+	return &slowStoreTracker{
+		slow: make(map[uint64]SlowStore),
+	}
+}
+
+// update replaces the slow stores, returns the ids of the stores which are
+// found slow and which are not slow any more.
+func (t *slowStoreTracker) update(now time.Time,
+	current map[uint64]SlowStore) (added, removed []uint64) {
+	t.Lock()
+	defer t.Unlock()
+
+	for id, s := range current {
+		if old, ok := t.slow[id]; ok {
+			s.Since = old.Since
+		} else {
+			s.Since = now
+			added = append(added, id)
+		}
+		current[id] = s
+	}
+	for id := range t.slow {
+		if _, ok := current[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	t.slow = current
+	return added, removed
+}
+
+func (t *slowStoreTracker) getAll() []SlowStore {
+	t.Lock()
+	defer t.Unlock()
+	values := make([]SlowStore, 0, len(t.slow))
+	for _, s := range t.slow {
+		values = append(values, s)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].StoreID < values[j].StoreID
+	})
+	return values
+}
+
+// detectSlowStores finds the slow stores by the heartbeat latencies reported by
+// the up stores. The latency of a store is the min latency reported by the
+// other stores, so a slow reporter can't make the healthy stores slow. A store
+// is slow if its latency exceeds minLatency and is ratio times of the median
+// latency of all the stores.
+func detectSlowStores(stores []*core.CachedStore, minLatency time.Duration,
+	ratio float64) map[uint64]SlowStore {
+	reports := make(map[uint64][]SlowStoreEvidence)
+	for _, s := range stores {
+		if !s.IsUp() || s.IsDisconnected() {
+			continue
+		}
+		stats := s.GetStoreStats()
+		if stats == nil {
+			continue
+		}
+		for _, l := range stats.PeerLatencies {
+			if l.Samples == 0 || l.StoreID == s.Meta.GetID() {
+				continue
+			}
+			reports[l.StoreID] = append(reports[l.StoreID], SlowStoreEvidence{
+				ReporterID: s.Meta.GetID(),
+				Latency:    time.Duration(l.Latency),
+				Samples:    l.Samples,
+			})
+		}
+	}
+
+	slow := make(map[uint64]SlowStore)
+	latencies := make(map[uint64]time.Duration, len(reports))
+	var values []time.Duration
+	for id, evidence := range reports {
+		if len(evidence) < slowStoreMinReporters {
+			continue
+		}
+		latency := evidence[0].Latency
+		for _, e := range evidence[1:] {
+			if e.Latency < latency {
+				latency = e.Latency
+			}
+		}
+		latencies[id] = latency
+		values = append(values, latency)
+	}
+	if len(values) == 0 {
+		return slow
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	median := values[len(values)/2]
+	if median <= 0 {
+		median = 1
+	}
+
+	for id, latency := range latencies {
+		score := float64(latency) / float64(median)
+		if latency < minLatency || score < ratio {
+			continue
+		}
+		evidence := reports[id]
+		sort.Slice(evidence, func(i, j int) bool {
+			return evidence[i].ReporterID < evidence[j].ReporterID
+		})
+		slow[id] = SlowStore{
+			StoreID:  id,
+			Score:    score,
+			Latency:  latency,
+			Evidence: evidence,
+		}
+	}
+	return slow
+}
+
+// checkSlowStores updates the slow stores, the leaders are not transferred to
+// the slow stores.
+func (c *RaftCluster) checkSlowStores(now time.Time) {
+	current := detectSlowStores(c.GetStores(),
+		c.opt.GetSlowStoreMinLatency(), c.opt.GetSlowStoreLatencyRatio())
+	added, removed := c.slowStores.update(now, current)
+	for _, id := range added {
+		s := current[id]
+		c.core.SetStoreSlow(id, true)
+		c.logger.Warn("store is slow, stop transferring leaders to it",
+			zap.Uint64("store", id),
+			zap.Float64("score", s.Score),
+			zap.Duration("latency", s.Latency))
+	}
+	for _, id := range removed {
+		c.core.SetStoreSlow(id, false)
+		slowStoreScoreGauge.DeleteLabelValues(fmt.Sprintf("%d", id))
+		c.logger.Info("store is not slow any more",
+			zap.Uint64("store", id))
+	}
+	for id, s := range current {
+		slowStoreScoreGauge.WithLabelValues(fmt.Sprintf("%d", id)).Set(s.Score)
+	}
+}
+
+// GetSlowStores returns the slow stores with the heartbeat latencies reported
+// by the other stores as the evidence
+func (c *RaftCluster) GetSlowStores() []SlowStore {
+	return c.slowStores.getAll()
+}
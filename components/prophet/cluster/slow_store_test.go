@@ -0,0 +1,126 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/filter"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPeerLatencies(latencies map[uint64]time.Duration) []metapb.PeerStoreLatency {
+	var values []metapb.PeerStoreLatency
+	for id, l := range latencies {
+		values = append(values, metapb.PeerStoreLatency{
+			StoreID: id,
+			Latency: uint64(l),
+			Samples: 10,
+		})
+	}
+	return values
+}
+
+func newTestLatencyStore(id uint64, latencies map[uint64]time.Duration) *core.CachedStore {
+	return core.NewCachedStore(metapb.Store{ID: id},
+		core.SetLastHeartbeatTS(time.Now()),
+		core.SetStoreStats(&metapb.StoreStats{
+			StoreID:       id,
+			PeerLatencies: newTestPeerLatencies(latencies),
+		}))
+}
+
+func TestDetectSlowStores(t *testing.T) {
+	ms := time.Millisecond
+	// store 3 is slow, the latencies reported by it are high too
+	stores := []*core.CachedStore{
+		newTestLatencyStore(1, map[uint64]time.Duration{2: 2 * ms, 3: 900 * ms, 4: 3 * ms}),
+		newTestLatencyStore(2, map[uint64]time.Duration{1: 2 * ms, 3: 800 * ms, 4: 2 * ms}),
+		newTestLatencyStore(3, map[uint64]time.Duration{1: 700 * ms, 2: 700 * ms, 4: 700 * ms}),
+		newTestLatencyStore(4, map[uint64]time.Duration{1: 3 * ms, 2: 2 * ms, 3: 1000 * ms}),
+	}
+
+	slow := detectSlowStores(stores, 100*ms, 4)
+	require.Equal(t, 1, len(slow))
+	s := slow[3]
+	assert.Equal(t, uint64(3), s.StoreID)
+	assert.Equal(t, 800*ms, s.Latency)
+	assert.Equal(t, float64(400), s.Score)
+	assert.Equal(t, []SlowStoreEvidence{
+		{ReporterID: 1, Latency: 900 * ms, Samples: 10},
+		{ReporterID: 2, Latency: 800 * ms, Samples: 10},
+		{ReporterID: 4, Latency: 1000 * ms, Samples: 10},
+	}, s.Evidence)
+
+	// below the min latency
+	assert.Empty(t, detectSlowStores(stores, time.Second, 4))
+	// below the ratio
+	assert.Empty(t, detectSlowStores(stores, 100*ms, 1000))
+	// only reported by the slow store
+	assert.Empty(t, detectSlowStores(stores[2:3], 100*ms, 4))
+}
+
+func TestCheckSlowStores(t *testing.T) {
+	ms := time.Millisecond
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	tc := newTestCluster(opt)
+	stores := []*core.CachedStore{
+		newTestLatencyStore(1, map[uint64]time.Duration{2: 2 * ms, 3: 900 * ms}),
+		newTestLatencyStore(2, map[uint64]time.Duration{1: 2 * ms, 3: 800 * ms}),
+		newTestLatencyStore(3, map[uint64]time.Duration{1: 700 * ms, 2: 700 * ms}),
+	}
+	for _, s := range stores {
+		require.NoError(t, tc.putStoreLocked(s))
+	}
+
+	now := time.Now()
+	tc.checkSlowStores(now)
+	slow := tc.GetSlowStores()
+	require.Equal(t, 1, len(slow))
+	assert.Equal(t, uint64(3), slow[0].StoreID)
+	assert.Equal(t, now, slow[0].Since)
+	assert.True(t, tc.GetStore(3).IsSlow())
+	assert.False(t, tc.GetStore(1).IsSlow())
+
+	// the slow store is not the target of transfer leader
+	f := &filter.StoreStateFilter{TransferLeader: true}
+	assert.False(t, f.Target(opt, tc.GetStore(3)))
+	assert.True(t, f.Target(opt, tc.GetStore(2)))
+
+	// the slow state is kept by the heartbeats
+	require.NoError(t, tc.HandleStoreHeartbeat(stores[2].GetStoreStats()))
+	assert.True(t, tc.GetStore(3).IsSlow())
+	tc.checkSlowStores(now.Add(time.Minute))
+	assert.Equal(t, now, tc.GetSlowStores()[0].Since)
+
+	// recovered
+	for id, latencies := range map[uint64]map[uint64]time.Duration{
+		1: {2: 2 * ms, 3: 3 * ms},
+		2: {1: 2 * ms, 3: 2 * ms},
+	} {
+		require.NoError(t, tc.HandleStoreHeartbeat(&metapb.StoreStats{
+			StoreID:       id,
+			PeerLatencies: newTestPeerLatencies(latencies),
+		}))
+	}
+	tc.checkSlowStores(now.Add(2 * time.Minute))
+	assert.Empty(t, tc.GetSlowStores())
+	assert.False(t, tc.GetStore(3).IsSlow())
+	assert.True(t, f.Target(opt, tc.GetStore(3)))
+}
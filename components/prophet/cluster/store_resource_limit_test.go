@@ -0,0 +1,64 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreResourceLimit(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	s := storage.NewTestStorage()
+	cluster := newTestRaftCluster(opt, s, core.NewBasicCluster(nil))
+
+	store := newTestStores(1, "2.0.0")[0].Clone(core.SetStoreLabels([]metapb.Label{
+		{Key: config.StoreLabelMaxReplicas, Value: "10"},
+		{Key: config.StoreLabelMaxSize, Value: "1024"},
+	}))
+	require.NoError(t, cluster.putStoreLocked(store))
+
+	_, err = cluster.GetStoreResourceLimit(2)
+	assert.Error(t, err)
+	assert.Error(t, cluster.SetStoreResourceLimit(2, config.StoreResourceLimitConfig{MaxReplicas: 1}))
+
+	// the limits are set by the labels
+	limit, err := cluster.GetStoreResourceLimit(1)
+	require.NoError(t, err)
+	assert.Equal(t, config.StoreResourceLimitConfig{MaxReplicas: 10, MaxSize: 1024}, limit)
+
+	// the limits set by the admin API take precedence over the labels
+	require.NoError(t, cluster.SetStoreResourceLimit(1, config.StoreResourceLimitConfig{MaxLeaders: 5}))
+	limit, err = cluster.GetStoreResourceLimit(1)
+	require.NoError(t, err)
+	assert.Equal(t, config.StoreResourceLimitConfig{MaxLeaders: 5}, limit)
+
+	persisted := config.NewConfig()
+	ok, err := s.LoadConfig(persisted)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, config.StoreResourceLimitConfig{MaxLeaders: 5}, persisted.Schedule.StoreResourceLimit[1])
+
+	require.NoError(t, cluster.DeleteStoreResourceLimit(1))
+	limit, err = cluster.GetStoreResourceLimit(1)
+	require.NoError(t, err)
+	assert.Equal(t, config.StoreResourceLimitConfig{MaxReplicas: 10, MaxSize: 1024}, limit)
+}
@@ -0,0 +1,320 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/notify"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+)
+
+// timelinePruneInterval the interval to remove the events exceeding the
+// retention
+var timelinePruneInterval = time.Minute
+
+// TimelineEventType the type of the cluster timeline event
+type TimelineEventType string
+
+const (
+	// StoreJoined a new store is added into the cluster
+	StoreJoined TimelineEventType = "store-joined"
+	// StoreOffline a store is set to offline
+	StoreOffline TimelineEventType = "store-offline"
+	// ShardCreated a new shard is reported by its first heartbeat
+	ShardCreated TimelineEventType = "shard-created"
+	// ShardSplit the range of a shard is shrunk by a split
+	ShardSplit TimelineEventType = "shard-split"
+	// ShardMerged the range of a shard is enlarged by a merge
+	ShardMerged TimelineEventType = "shard-merged"
+	// ShardUnavailable the majority of the voters of a shard are down
+	ShardUnavailable TimelineEventType = "shard-unavailable"
+	// ProphetLeaderChanged a new prophet leader starts the cluster
+	ProphetLeaderChanged TimelineEventType = "prophet-leader-changed"
+)
+
+// TimelineEvent is a major event of the cluster
+type TimelineEvent struct {
+	Type TimelineEventType `json:"type"`
+	// Time the time of the first event compacted into the event
+	Time time.Time `json:"time"`
+	// LastTime the time of the last event compacted into the event
+	LastTime time.Time `json:"last-time"`
+	// Count the number of the events compacted into the event
+	Count   int               `json:"count"`
+	StoreID uint64            `json:"store-id,omitempty"`
+	ShardID uint64            `json:"shard-id,omitempty"`
+	Message string            `json:"message,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// TimelineQuery is the filter of the timeline events, the zero fields match
+// all the events.
+type TimelineQuery struct {
+	// Start and End the time range of the events, [Start, End)
+	Start time.Time
+	End   time.Time
+	Types []TimelineEventType
+	// StoreID and ShardID the store or the shard of the events
+	StoreID uint64
+	ShardID uint64
+	// Limit the max number of the latest events to return
+	Limit int
+}
+
+func (q TimelineQuery) match(e TimelineEvent) bool {
+	if !q.Start.IsZero() && e.LastTime.Before(q.Start) {
+		return false
+	}
+	if !q.End.IsZero() && !e.Time.Before(q.End) {
+		return false
+	}
+	if q.StoreID > 0 && e.StoreID != q.StoreID {
+		return false
+	}
+	if q.ShardID > 0 && e.ShardID != q.ShardID {
+		return false
+	}
+	if len(q.Types) == 0 {
+		return true
+	}
+	for _, t := range q.Types {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
+
+type timelineKey struct {
+	eventType TimelineEventType
+	storeID   uint64
+	shardID   uint64
+}
+
+type timelineEntry struct {
+	timestamp int64
+	event     TimelineEvent
+}
+
+// timeline persists the cluster events, the repeated events within the
+// compact window are compacted into one event.
+type timeline struct {
+	sync.Mutex
+
+	cfg     config.TimelineConfig
+	storage storage.TimelineStorage
+	logger  *zap.Logger
+	// last the timestamp of the last persisted event, the timestamps are the
+	// keys of the events, so they are kept increasing
+	last      int64
+	recent    map[timelineKey]*timelineEntry
+	nextPrune time.Time
+}
+
+func newTimeline(cfg config.TimelineConfig, storage storage.TimelineStorage, logger *zap.Logger) *timeline {
+	if !cfg.Enable {
+		return nil
+	}
+	return &timeline{
+		cfg:     cfg,
+		storage: storage,
+		logger:  logger,
+		recent:  make(map[timelineKey]*timelineEntry),
+	}
+}
+
+// record persists the event, nothing to do if the timeline is disabled
+func (t *timeline) record(e TimelineEvent) {
+	if t == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	key := timelineKey{eventType: e.Type, storeID: e.StoreID, shardID: e.ShardID}
+	if entry, ok := t.recent[key]; ok &&
+		e.Time.Sub(entry.event.LastTime) < t.cfg.CompactWindow.Duration {
+		entry.event.Count++
+		entry.event.LastTime = e.Time
+		t.put(entry)
+		return
+	}
+
+	timestamp := e.Time.UnixNano()
+	if timestamp <= t.last {
+		timestamp = t.last + 1
+	}
+	t.last = timestamp
+	e.LastTime = e.Time
+	e.Count = 1
+	entry := &timelineEntry{timestamp: timestamp, event: e}
+	t.recent[key] = entry
+	t.put(entry)
+}
+
+func (t *timeline) put(entry *timelineEntry) {
+	if err := t.storage.PutTimelineEvent(entry.timestamp, entry.event); err != nil {
+		t.logger.Error("fail to save timeline event",
+			zap.String("type", string(entry.event.Type)),
+			zap.Error(err))
+	}
+}
+
+// prune removes the events exceeding the retention or the max number of the
+// events, at most once in the prune interval
+func (t *timeline) prune(now time.Time) {
+	if t == nil {
+		return
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if now.Before(t.nextPrune) {
+		return
+	}
+	t.nextPrune = now.Add(timelinePruneInterval)
+
+	for key, entry := range t.recent {
+		if now.Sub(entry.event.LastTime) >= t.cfg.CompactWindow.Duration {
+			delete(t.recent, key)
+		}
+	}
+
+	var timestamps []int64
+	if err := t.storage.LoadTimelineEvents(batch, func(timestamp int64, v string) error {
+		timestamps = append(timestamps, timestamp)
+		return nil
+	}); err != nil {
+		t.logger.Error("fail to load timeline events",
+			zap.Error(err))
+		return
+	}
+
+	expired := now.Add(-t.cfg.Retention.Duration).UnixNano()
+	for len(timestamps) > 0 &&
+		(timestamps[0] < expired || len(timestamps) > t.cfg.MaxEvents) {
+		if err := t.storage.RemoveTimelineEvent(timestamps[0]); err != nil {
+			t.logger.Error("fail to remove timeline event",
+				zap.Error(err))
+			return
+		}
+		timestamps = timestamps[1:]
+	}
+}
+
+// GetTimelineEvents returns the latest persisted timeline events matching the
+// query, from the oldest to the newest.
+func (c *RaftCluster) GetTimelineEvents(q TimelineQuery) ([]TimelineEvent, error) {
+	var events []TimelineEvent
+	err := c.storage.LoadTimelineEvents(batch, func(timestamp int64, v string) error {
+		e := TimelineEvent{}
+		if err := json.Unmarshal([]byte(v), &e); err != nil {
+			return err
+		}
+		if q.match(e) {
+			events = append(events, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if q.Limit > 0 && len(events) > q.Limit {
+		events = events[len(events)-q.Limit:]
+	}
+	return events, nil
+}
+
+// recordShardEvents records the shard created, split and merged events of
+// the shard heartbeat, the overlaps are the shards removed by the heartbeat.
+func (t *timeline) recordShardEvents(origin, res *core.CachedShard, overlaps []*core.CachedShard) {
+	if t == nil {
+		return
+	}
+
+	details := map[string]string{
+		"group": strconv.FormatUint(res.Meta.GetGroup(), 10),
+		"start": hex.EncodeToString(res.GetStartKey()),
+		"end":   hex.EncodeToString(res.GetEndKey()),
+	}
+	if origin == nil {
+		t.record(TimelineEvent{
+			Type:    ShardCreated,
+			ShardID: res.Meta.GetID(),
+			Message: "shard created",
+			Details: details,
+		})
+		return
+	}
+
+	if res.Meta.Epoch.Generation <= origin.Meta.Epoch.Generation {
+		return
+	}
+	if len(overlaps) > 0 {
+		ids := make([]string, 0, len(overlaps))
+		for _, item := range overlaps {
+			ids = append(ids, strconv.FormatUint(item.Meta.GetID(), 10))
+		}
+		details["merged-shards"] = strings.Join(ids, ",")
+		t.record(TimelineEvent{
+			Type:    ShardMerged,
+			ShardID: res.Meta.GetID(),
+			Message: "shard merged with " + details["merged-shards"],
+			Details: details,
+		})
+		return
+	}
+	if string(res.GetStartKey()) != string(origin.GetStartKey()) ||
+		string(res.GetEndKey()) != string(origin.GetEndKey()) {
+		details["origin-start"] = hex.EncodeToString(origin.GetStartKey())
+		details["origin-end"] = hex.EncodeToString(origin.GetEndKey())
+		t.record(TimelineEvent{
+			Type:    ShardSplit,
+			ShardID: res.Meta.GetID(),
+			Message: "shard split",
+			Details: details,
+		})
+	}
+}
+
+// recordAlertEvents records the alert events of the timeline event types
+func (c *RaftCluster) recordAlertEvents(events []notify.Event) {
+	for _, e := range events {
+		if e.Type != notify.ShardUnavailable {
+			continue
+		}
+		c.timeline.record(TimelineEvent{
+			Type:    ShardUnavailable,
+			Time:    e.Time,
+			ShardID: e.ShardID,
+			Message: e.Message,
+			Details: e.Details,
+		})
+	}
+}
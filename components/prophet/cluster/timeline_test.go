@@ -0,0 +1,155 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTimelineConfig() config.TimelineConfig {
+	return config.TimelineConfig{
+		Enable:        true,
+		Retention:     typeutil.NewDuration(time.Hour),
+		MaxEvents:     10,
+		CompactWindow: typeutil.NewDuration(time.Minute),
+	}
+}
+
+func getTestTimelineEvents(t *testing.T, c *RaftCluster, q TimelineQuery) []TimelineEvent {
+	events, err := c.GetTimelineEvents(q)
+	require.NoError(t, err)
+	return events
+}
+
+func TestTimelineCompactAndQuery(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	c := newTestRaftCluster(opt, storage.NewTestStorage(), core.NewBasicCluster(nil))
+	assert.Nil(t, newTimeline(config.TimelineConfig{}, c.storage, c.logger))
+	c.timeline = newTimeline(newTestTimelineConfig(), c.storage, c.logger)
+
+	now := time.Unix(1000, 0)
+	c.timeline.record(TimelineEvent{Type: ShardUnavailable, ShardID: 1, Time: now})
+	c.timeline.record(TimelineEvent{Type: ShardUnavailable, ShardID: 1, Time: now.Add(30 * time.Second)})
+	c.timeline.record(TimelineEvent{Type: ShardUnavailable, ShardID: 2, Time: now.Add(30 * time.Second)})
+	c.timeline.record(TimelineEvent{Type: StoreOffline, StoreID: 1, Time: now.Add(30 * time.Second)})
+	// out of the compact window of the first event
+	c.timeline.record(TimelineEvent{Type: ShardUnavailable, ShardID: 1, Time: now.Add(2 * time.Minute)})
+
+	events := getTestTimelineEvents(t, c, TimelineQuery{})
+	require.Equal(t, 4, len(events))
+	assert.Equal(t, uint64(1), events[0].ShardID)
+	assert.Equal(t, 2, events[0].Count)
+	assert.True(t, now.Equal(events[0].Time))
+	assert.True(t, now.Add(30*time.Second).Equal(events[0].LastTime))
+	assert.Equal(t, uint64(2), events[1].ShardID)
+	assert.Equal(t, uint64(1), events[2].StoreID)
+	assert.Equal(t, 1, events[3].Count)
+
+	assert.Equal(t, 3, len(getTestTimelineEvents(t, c, TimelineQuery{Types: []TimelineEventType{ShardUnavailable}})))
+	assert.Equal(t, 2, len(getTestTimelineEvents(t, c, TimelineQuery{ShardID: 1})))
+	assert.Equal(t, 1, len(getTestTimelineEvents(t, c, TimelineQuery{StoreID: 1})))
+	assert.Equal(t, 1, len(getTestTimelineEvents(t, c, TimelineQuery{Start: now.Add(time.Minute)})))
+	assert.Equal(t, 1, len(getTestTimelineEvents(t, c, TimelineQuery{End: now.Add(time.Second)})))
+	events = getTestTimelineEvents(t, c, TimelineQuery{Limit: 2})
+	require.Equal(t, 2, len(events))
+	assert.Equal(t, StoreOffline, events[0].Type)
+	assert.Equal(t, ShardUnavailable, events[1].Type)
+}
+
+func TestTimelinePrune(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	c := newTestRaftCluster(opt, storage.NewTestStorage(), core.NewBasicCluster(nil))
+	c.timeline = newTimeline(newTestTimelineConfig(), c.storage, c.logger)
+
+	now := time.Unix(10000, 0)
+	for i := 0; i < 15; i++ {
+		c.timeline.record(TimelineEvent{Type: ShardCreated, ShardID: uint64(i),
+			Time: now.Add(-2 * time.Hour).Add(time.Duration(i) * 10 * time.Minute)})
+	}
+
+	// 6 events exceed the retention, then 9 events are left
+	c.timeline.prune(now)
+	events := getTestTimelineEvents(t, c, TimelineQuery{})
+	require.Equal(t, 9, len(events))
+	assert.Equal(t, uint64(6), events[0].ShardID)
+
+	// pruned at most once in the interval
+	for i := 15; i < 20; i++ {
+		c.timeline.record(TimelineEvent{Type: ShardCreated, ShardID: uint64(i), Time: now})
+	}
+	c.timeline.prune(now)
+	assert.Equal(t, 14, len(getTestTimelineEvents(t, c, TimelineQuery{})))
+	c.timeline.prune(now.Add(timelinePruneInterval))
+	events = getTestTimelineEvents(t, c, TimelineQuery{})
+	require.Equal(t, 10, len(events))
+	assert.Equal(t, uint64(10), events[0].ShardID)
+}
+
+func TestTimelineClusterEvents(t *testing.T) {
+	_, opt, err := newTestScheduleConfig()
+	require.NoError(t, err)
+	c := newTestRaftCluster(opt, storage.NewTestStorage(), core.NewBasicCluster(nil))
+	c.timeline = newTimeline(newTestTimelineConfig(), c.storage, c.logger)
+
+	stores := newTestStores(3, "2.0.0")
+	for _, s := range stores {
+		require.NoError(t, c.PutStore(s.Meta))
+	}
+	// the existing store is updated
+	require.NoError(t, c.PutStore(stores[0].Meta))
+	require.NoError(t, c.RemoveStore(3, false))
+	events := getTestTimelineEvents(t, c, TimelineQuery{Types: []TimelineEventType{StoreJoined}})
+	assert.Equal(t, 3, len(events))
+	events = getTestTimelineEvents(t, c, TimelineQuery{Types: []TimelineEventType{StoreOffline}})
+	require.Equal(t, 1, len(events))
+	assert.Equal(t, uint64(3), events[0].StoreID)
+
+	shard := core.NewCachedShard(*newTestShardMeta(1), nil)
+	shard.Meta.Start, shard.Meta.End = []byte("a"), []byte("z")
+	require.NoError(t, c.processShardHeartbeat(shard))
+	// no event for the stats change
+	require.NoError(t, c.processShardHeartbeat(shard.Clone(core.SetApproximateSize(100))))
+
+	// shard 1 split into [a, m) and [m, z)
+	left := shard.Clone(core.WithEndKey([]byte("m")), core.WithIncVersion())
+	require.NoError(t, c.processShardHeartbeat(left))
+	right := shard.Clone(core.WithStartKey([]byte("m")), core.WithNewShardID(2), core.WithIncVersion())
+	require.NoError(t, c.processShardHeartbeat(right))
+
+	// shard 2 merged into shard 1
+	merged := left.Clone(core.WithEndKey([]byte("z")), core.WithIncVersion())
+	require.NoError(t, c.processShardHeartbeat(merged))
+
+	events = getTestTimelineEvents(t, c, TimelineQuery{Types: []TimelineEventType{ShardCreated, ShardSplit, ShardMerged}})
+	require.Equal(t, 4, len(events))
+	assert.Equal(t, ShardCreated, events[0].Type)
+	assert.Equal(t, uint64(1), events[0].ShardID)
+	assert.Equal(t, ShardSplit, events[1].Type)
+	assert.Equal(t, uint64(1), events[1].ShardID)
+	assert.Equal(t, "6d", events[1].Details["end"])
+	assert.Equal(t, ShardCreated, events[2].Type)
+	assert.Equal(t, uint64(2), events[2].ShardID)
+	assert.Equal(t, ShardMerged, events[3].Type)
+	assert.Equal(t, "2", events[3].Details["merged-shards"])
+}
@@ -53,6 +53,10 @@ type Config struct {
 	Schedule      ScheduleConfig      `toml:"schedule" json:"schedule"`
 	Replication   ReplicationConfig   `toml:"replication" json:"replication"`
 	LabelProperty LabelPropertyConfig `toml:"label-property" json:"label-property"`
+	Notify        NotifyConfig        `toml:"notify" json:"notify"`
+	Federation    FederationConfig    `toml:"federation" json:"federation"`
+	BalanceReport BalanceReportConfig `toml:"balance-report" json:"balance-report"`
+	Timeline      TimelineConfig      `toml:"timeline" json:"timeline"`
 
 	Handler                     metadata.RoleChangeHandler                                            `toml:"-" json:"-"`
 	ShardStateChangedHandler    func(res *metapb.Shard, from metapb.ShardState, to metapb.ShardState) `toml:"-" json:"-"`
@@ -206,6 +210,10 @@ type ScheduleConfig struct {
 	HotShardCacheHitsThreshold uint64 `toml:"hot-resource-cache-hits-threshold" json:"hot-resource-cache-hits-threshold"`
 	// StoreLimit is the limit of scheduling for containers.
 	StoreLimit map[uint64]StoreLimitConfig `toml:"container-limit" json:"container-limit"`
+	// StoreResourceLimit is the limit of the replicas, leaders and size held by
+	// the containers. The limits of a container can also be set by the labels
+	// of the container, and the limits set here take precedence over them.
+	StoreResourceLimit map[uint64]StoreResourceLimitConfig `toml:"container-resource-limit" json:"container-resource-limit"`
 	// TolerantSizeRatio is the ratio of buffer size for balance scheduler.
 	TolerantSizeRatio float64 `toml:"tolerant-size-ratio" json:"tolerant-size-ratio"`
 	//
@@ -246,13 +254,67 @@ type ScheduleConfig struct {
 	// Only used to display
 	SchedulersPayload map[string]interface{} `toml:"schedulers-payload" json:"schedulers-payload"`
 
-	// StoreLimitMode can be auto or manual, when set to auto,
+	// StoreLimitMode can be auto, adaptive or manual, when set to auto,
 	// Prophet tries to change the container limit values according to
 	// the load state of the cluster dynamically. User can
 	// overwrite the auto-tuned value by pd-ctl, when the value
 	// is overwritten, the value is fixed until it is deleted.
+	// When set to adaptive, Prophet scales the configured add peer and
+	// remove peer limits by the apply backlogs and latencies reported by
+	// the containers, slows down the data movement when the cluster is
+	// busy and speeds up it when the cluster is idle.
 	// Default: manual
 	StoreLimitMode string `toml:"container-limit-mode" json:"container-limit-mode"`
+	// AdaptiveLimitMaxApplyBacklog the cluster is considered as busy by the
+	// adaptive limit mode if the apply backlog of any container exceeds it.
+	AdaptiveLimitMaxApplyBacklog uint64 `toml:"adaptive-limit-max-apply-backlog" json:"adaptive-limit-max-apply-backlog"`
+	// AdaptiveLimitMaxApplyLatency the cluster is considered as busy by the
+	// adaptive limit mode if the average apply latency per entry of any
+	// container exceeds it.
+	AdaptiveLimitMaxApplyLatency typeutil.Duration `toml:"adaptive-limit-max-apply-latency" json:"adaptive-limit-max-apply-latency"`
+	// AdaptiveLimitMinRatio the minimum ratio of the configured container
+	// limits used by the adaptive limit mode.
+	AdaptiveLimitMinRatio float64 `toml:"adaptive-limit-min-ratio" json:"adaptive-limit-min-ratio"`
+	// AdaptiveLimitMaxRatio the maximum ratio of the configured container
+	// limits used by the adaptive limit mode.
+	AdaptiveLimitMaxRatio float64 `toml:"adaptive-limit-max-ratio" json:"adaptive-limit-max-ratio"`
+
+	// SlowStoreMinLatency a container is considered as slow only if the
+	// heartbeat latencies from the leaders in all the other containers to it
+	// exceed it.
+	SlowStoreMinLatency typeutil.Duration `toml:"slow-store-min-latency" json:"slow-store-min-latency"`
+	// SlowStoreLatencyRatio a container is considered as slow if its heartbeat
+	// latency is SlowStoreLatencyRatio times of the median latency of all the
+	// containers. The slow containers are not selected as the target of the
+	// leader transfer.
+	SlowStoreLatencyRatio float64 `toml:"slow-store-latency-ratio" json:"slow-store-latency-ratio"`
+
+	// EnableExpansionRebalance is the option to accelerate the rebalancing when
+	// new empty containers join the cluster. While the new containers are far
+	// below the average resource count, the leader, resource and replica schedule
+	// limits and the add peer limit of the new containers are multiplied by
+	// ExpansionScheduleFactor, and restored automatically once all the new
+	// containers are balanced.
+	EnableExpansionRebalance bool `toml:"enable-expansion-rebalance" json:"enable-expansion-rebalance,string"`
+	// ExpansionScheduleFactor is the multiple of the schedule limits during the
+	// expansion rebalancing.
+	ExpansionScheduleFactor uint64 `toml:"expansion-schedule-factor" json:"expansion-schedule-factor"`
+	// ExpansionTriggerRatio a up container is considered as a new container if its
+	// resource count is less than ExpansionTriggerRatio * average resource count.
+	ExpansionTriggerRatio float64 `toml:"expansion-trigger-ratio" json:"expansion-trigger-ratio"`
+	// ExpansionFinishRatio a new container is considered as balanced if its
+	// resource count reaches ExpansionFinishRatio * average resource count.
+	ExpansionFinishRatio float64 `toml:"expansion-finish-ratio" json:"expansion-finish-ratio"`
+
+	// OperatorStepTimeouts is the max duration of a single step of the operators
+	// by the operator kind, e.g. "replica" or "merge". An operator of multiple
+	// kinds uses the largest timeout of its kinds, and the operators of the kinds
+	// not configured are only limited by the whole operator timeout.
+	OperatorStepTimeouts map[string]typeutil.Duration `toml:"operator-step-timeouts" json:"operator-step-timeouts"`
+	// EnableOperatorRollback is the option to create a compensating operator when
+	// an operator times out, which removes the learners added by the operator
+	// but never promoted.
+	EnableOperatorRollback bool `toml:"enable-operator-rollback" json:"enable-operator-rollback,string"`
 }
 
 // SchedulerConfigs is a slice of customized scheduler configuration.
@@ -293,6 +355,29 @@ type StoreLimitConfig struct {
 	RemovePeer float64 `toml:"remove-peer" json:"remove-peer"`
 }
 
+// The label keys used to set the resource limits of a container.
+const (
+	StoreLabelMaxReplicas = "max-replicas"
+	StoreLabelMaxLeaders  = "max-leaders"
+	StoreLabelMaxSize     = "max-size"
+)
+
+// StoreResourceLimitConfig is a config about the resources a container can hold,
+// 0 means unlimited.
+type StoreResourceLimitConfig struct {
+	// MaxReplicas is the max number of replicas on the container.
+	MaxReplicas uint64 `toml:"max-replicas" json:"max-replicas"`
+	// MaxLeaders is the max number of leaders on the container.
+	MaxLeaders uint64 `toml:"max-leaders" json:"max-leaders"`
+	// MaxSize is the max total size of the replicas on the container in MB.
+	MaxSize uint64 `toml:"max-size" json:"max-size"`
+}
+
+// IsUnlimited returns true if no resource limit is set.
+func (c StoreResourceLimitConfig) IsUnlimited() bool {
+	return c.MaxReplicas == 0 && c.MaxLeaders == 0 && c.MaxSize == 0
+}
+
 // Clone returns a cloned scheduling configuration.
 func (c *ScheduleConfig) Clone() *ScheduleConfig {
 	schedulers := append(c.Schedulers[:0:0], c.Schedulers...)
@@ -303,8 +388,24 @@ func (c *ScheduleConfig) Clone() *ScheduleConfig {
 			containerLimit[k] = v
 		}
 	}
+	var resourceLimit map[uint64]StoreResourceLimitConfig
+	if c.StoreResourceLimit != nil {
+		resourceLimit = make(map[uint64]StoreResourceLimitConfig, len(c.StoreResourceLimit))
+		for k, v := range c.StoreResourceLimit {
+			resourceLimit[k] = v
+		}
+	}
+	var stepTimeouts map[string]typeutil.Duration
+	if c.OperatorStepTimeouts != nil {
+		stepTimeouts = make(map[string]typeutil.Duration, len(c.OperatorStepTimeouts))
+		for k, v := range c.OperatorStepTimeouts {
+			stepTimeouts[k] = v
+		}
+	}
 	cfg := *c
 	cfg.StoreLimit = containerLimit
+	cfg.StoreResourceLimit = resourceLimit
+	cfg.OperatorStepTimeouts = stepTimeouts
 	cfg.Schedulers = schedulers
 	cfg.SchedulersPayload = nil
 	return &cfg
@@ -362,8 +463,20 @@ func (c *ScheduleConfig) adjust(meta *configMetaData, reloading bool) error {
 	if !meta.IsDefined("enable-cross-table-merge") {
 		c.EnableCrossTableMerge = defaultEnableCrossTableMerge
 	}
+	if !meta.IsDefined("enable-operator-rollback") {
+		c.EnableOperatorRollback = defaultEnableOperatorRollback
+	}
 	adjustFloat64(&c.LowSpaceRatio, defaultLowSpaceRatio)
 	adjustFloat64(&c.HighSpaceRatio, defaultHighSpaceRatio)
+	adjustUint64(&c.ExpansionScheduleFactor, defaultExpansionScheduleFactor)
+	adjustFloat64(&c.ExpansionTriggerRatio, defaultExpansionTriggerRatio)
+	adjustFloat64(&c.ExpansionFinishRatio, defaultExpansionFinishRatio)
+	adjustUint64(&c.AdaptiveLimitMaxApplyBacklog, defaultAdaptiveLimitMaxApplyBacklog)
+	adjustDuration(&c.AdaptiveLimitMaxApplyLatency, defaultAdaptiveLimitMaxApplyLatency)
+	adjustFloat64(&c.AdaptiveLimitMinRatio, defaultAdaptiveLimitMinRatio)
+	adjustFloat64(&c.AdaptiveLimitMaxRatio, defaultAdaptiveLimitMaxRatio)
+	adjustDuration(&c.SlowStoreMinLatency, defaultSlowStoreMinLatency)
+	adjustFloat64(&c.SlowStoreLatencyRatio, defaultSlowStoreLatencyRatio)
 
 	// new cluster:v2, old cluster:v1
 	if !meta.IsDefined("resource-score-formula-version") && !reloading {
@@ -375,6 +488,9 @@ func (c *ScheduleConfig) adjust(meta *configMetaData, reloading bool) error {
 	if c.StoreLimit == nil {
 		c.StoreLimit = make(map[uint64]StoreLimitConfig)
 	}
+	if c.StoreResourceLimit == nil {
+		c.StoreResourceLimit = make(map[uint64]StoreResourceLimitConfig)
+	}
 
 	// TODO: disable JointConsensus. Consider opening again in the future
 	c.EnableJointConsensus = false
@@ -395,6 +511,23 @@ func (c *ScheduleConfig) Validate() error {
 	if c.LowSpaceRatio <= c.HighSpaceRatio {
 		return errors.New("low-space-ratio should be larger than high-space-ratio")
 	}
+	if c.ExpansionTriggerRatio >= c.ExpansionFinishRatio {
+		return errors.New("expansion-finish-ratio should be larger than expansion-trigger-ratio")
+	}
+	if c.AdaptiveLimitMinRatio <= 0 || c.AdaptiveLimitMinRatio > 1 {
+		return errors.New("adaptive-limit-min-ratio should between 0 and 1")
+	}
+	if c.AdaptiveLimitMaxRatio < 1 {
+		return errors.New("adaptive-limit-max-ratio should not be less than 1")
+	}
+	if c.SlowStoreLatencyRatio <= 1 {
+		return errors.New("slow-store-latency-ratio should be larger than 1")
+	}
+	for kind, timeout := range c.OperatorStepTimeouts {
+		if timeout.Duration < 0 {
+			return fmt.Errorf("operator-step-timeouts of %s should be nonnegative", kind)
+		}
+	}
 	for _, scheduleConfig := range c.Schedulers {
 		if !IsSchedulerRegistered(scheduleConfig.Type) {
 			return fmt.Errorf("create func of %v is not registered, maybe misspelled", scheduleConfig.Type)
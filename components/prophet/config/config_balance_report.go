@@ -0,0 +1,84 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+)
+
+const (
+	defaultBalanceReportInterval  = 24 * time.Hour
+	defaultBalanceReportStartAt   = "02:00"
+	defaultBalanceReportRetention = 30
+	defaultBalanceReportTopStores = 5
+)
+
+// BalanceReportConfig the config of the balance report. The prophet leader
+// analyzes the balance of the stores periodically without scheduling any
+// operator, and persists the reports, so the drift of the balance is visible
+// before it becomes an incident.
+type BalanceReportConfig struct {
+	Enable bool `toml:"enable" json:"enable"`
+	// Interval the interval between the reports, nightly by default
+	Interval typeutil.Duration `toml:"interval" json:"interval"`
+	// StartAt the local time of the day, in the format of HH:MM, the reports
+	// are produced at StartAt + N * Interval
+	StartAt string `toml:"start-at" json:"start-at"`
+	// Retention the max number of the persisted reports, the oldest reports
+	// are removed
+	Retention int `toml:"retention" json:"retention"`
+	// TopStores the number of the most imbalanced stores in the report
+	TopStores int `toml:"top-stores" json:"top-stores"`
+}
+
+func (c *BalanceReportConfig) adjust() error {
+	adjustDuration(&c.Interval, defaultBalanceReportInterval)
+	adjustString(&c.StartAt, defaultBalanceReportStartAt)
+	if c.Retention <= 0 {
+		c.Retention = defaultBalanceReportRetention
+	}
+	if c.TopStores <= 0 {
+		c.TopStores = defaultBalanceReportTopStores
+	}
+
+	if _, err := time.Parse("15:04", c.StartAt); err != nil {
+		return fmt.Errorf("invalid balance report start-at %q, must be HH:MM", c.StartAt)
+	}
+	return nil
+}
+
+// NextReportTime returns the first report time after now
+func (c *BalanceReportConfig) NextReportTime(now time.Time) time.Time {
+	at, err := time.Parse("15:04", c.StartAt)
+	if err != nil {
+		at, _ = time.Parse("15:04", defaultBalanceReportStartAt)
+	}
+	interval := c.Interval.Duration
+	if interval <= 0 {
+		interval = defaultBalanceReportInterval
+	}
+
+	next := time.Date(now.Year(), now.Month(), now.Day(),
+		at.Hour(), at.Minute(), 0, 0, now.Location())
+	for next.After(now) {
+		next = next.Add(-interval)
+	}
+	for !next.After(now) {
+		next = next.Add(interval)
+	}
+	return next
+}
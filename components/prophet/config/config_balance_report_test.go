@@ -0,0 +1,50 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceReportConfigAdjust(t *testing.T) {
+	cfg := BalanceReportConfig{}
+	assert.NoError(t, cfg.adjust())
+	assert.Equal(t, defaultBalanceReportInterval, cfg.Interval.Duration)
+	assert.Equal(t, defaultBalanceReportStartAt, cfg.StartAt)
+	assert.Equal(t, defaultBalanceReportRetention, cfg.Retention)
+	assert.Equal(t, defaultBalanceReportTopStores, cfg.TopStores)
+
+	cfg = BalanceReportConfig{StartAt: "25:00"}
+	assert.Error(t, cfg.adjust())
+}
+
+func TestBalanceReportNextReportTime(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2022, 1, day, hour, minute, 0, 0, time.Local)
+	}
+
+	cfg := BalanceReportConfig{}
+	assert.NoError(t, cfg.adjust())
+	assert.Equal(t, at(1, 2, 0), cfg.NextReportTime(at(1, 1, 0)))
+	assert.Equal(t, at(2, 2, 0), cfg.NextReportTime(at(1, 2, 0)))
+	assert.Equal(t, at(2, 2, 0), cfg.NextReportTime(at(1, 10, 30)))
+
+	cfg.Interval.Duration = time.Hour
+	cfg.StartAt = "23:30"
+	assert.Equal(t, at(1, 11, 30), cfg.NextReportTime(at(1, 10, 30)))
+	assert.Equal(t, at(1, 0, 30), cfg.NextReportTime(at(1, 0, 0)))
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+)
+
+// FederationConfig the config of the prophet federation. The shard groups of a
+// very large cluster can be partitioned across multiple prophet groups, each
+// prophet group has its own etcd cluster and only schedules the shard groups
+// it owns. The prophet group which the node belongs to is the home prophet
+// group, and it owns all the shard groups not owned by the members.
+//
+// The federation must be configured when the cluster is bootstrapped, as the
+// IDs allocated by the prophet groups are interleaved by the index.
+type FederationConfig struct {
+	// Index the index of the home prophet group in the federation
+	Index uint64 `toml:"index" json:"index"`
+	// Members the other prophet groups of the federation
+	Members []FederationMember `toml:"members" json:"members"`
+}
+
+// FederationMember a prophet group of the federation
+type FederationMember struct {
+	Name string `toml:"name" json:"name"`
+	// Index the index of the prophet group in the federation
+	Index uint64 `toml:"index" json:"index"`
+	// ExternalEtcd the etcd endpoints of the prophet group
+	ExternalEtcd []string `toml:"external-etcd" json:"external-etcd"`
+	// Groups the shard groups owned by the prophet group
+	Groups []uint64 `toml:"groups" json:"groups"`
+}
+
+// Enabled returns true if the federation is configured
+func (c *FederationConfig) Enabled() bool {
+	return len(c.Members) > 0
+}
+
+// Size returns the number of the prophet groups, including the home one
+func (c *FederationConfig) Size() uint64 {
+	return uint64(len(c.Members)) + 1
+}
+
+// GetOwner returns the member which owns the shard group, false if the shard
+// group is owned by the home prophet group.
+func (c *FederationConfig) GetOwner(group uint64) (FederationMember, bool) {
+	for _, m := range c.Members {
+		for _, g := range m.Groups {
+			if g == group {
+				return m, true
+			}
+		}
+	}
+	return FederationMember{}, false
+}
+
+func (c *FederationConfig) adjust() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	size := c.Size()
+	if c.Index >= size {
+		return fmt.Errorf("invalid federation index %d, must be less than %d",
+			c.Index, size)
+	}
+
+	indexes := map[uint64]struct{}{c.Index: {}}
+	groups := make(map[uint64]string)
+	for _, m := range c.Members {
+		if m.Name == "" {
+			return fmt.Errorf("missing federation member name")
+		}
+		if len(m.ExternalEtcd) == 0 {
+			return fmt.Errorf("missing external etcd of federation member %s", m.Name)
+		}
+		if m.Index >= size {
+			return fmt.Errorf("invalid index %d of federation member %s, must be less than %d",
+				m.Index, m.Name, size)
+		}
+		if _, ok := indexes[m.Index]; ok {
+			return fmt.Errorf("duplicate federation index %d", m.Index)
+		}
+		indexes[m.Index] = struct{}{}
+
+		for _, g := range m.Groups {
+			if owner, ok := groups[g]; ok {
+				return fmt.Errorf("shard group %d owned by federation members %s and %s",
+					g, owner, m.Name)
+			}
+			groups[g] = m.Name
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFederationConfigAdjust(t *testing.T) {
+	member := func(name string, index uint64, groups ...uint64) FederationMember {
+		return FederationMember{
+			Name:         name,
+			Index:        index,
+			ExternalEtcd: []string{"127.0.0.1:2379"},
+			Groups:       groups,
+		}
+	}
+
+	cases := []struct {
+		cfg FederationConfig
+		ok  bool
+	}{
+		{cfg: FederationConfig{}, ok: true},
+		{cfg: FederationConfig{Index: 1, Members: []FederationMember{member("p0", 0, 1)}}, ok: true},
+		{cfg: FederationConfig{Index: 2, Members: []FederationMember{member("p0", 0, 1)}}, ok: false},
+		{cfg: FederationConfig{Index: 0, Members: []FederationMember{member("p0", 0, 1)}}, ok: false},
+		{cfg: FederationConfig{Members: []FederationMember{member("", 1, 1)}}, ok: false},
+		{cfg: FederationConfig{Members: []FederationMember{{Name: "p1", Index: 1}}}, ok: false},
+		{cfg: FederationConfig{Members: []FederationMember{member("p1", 1, 1), member("p2", 2, 1)}}, ok: false},
+	}
+	for i, c := range cases {
+		err := c.cfg.adjust()
+		assert.Equal(t, c.ok, err == nil, "case %d", i)
+	}
+
+	cfg := FederationConfig{Members: []FederationMember{member("p1", 1, 1, 2)}}
+	m, ok := cfg.GetOwner(2)
+	assert.True(t, ok)
+	assert.Equal(t, "p1", m.Name)
+	_, ok = cfg.GetOwner(0)
+	assert.False(t, ok)
+}
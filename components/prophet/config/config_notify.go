@@ -0,0 +1,88 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/notify"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+)
+
+const (
+	defaultNotifyQueueSize     = 1024
+	defaultNotifyMaxRetries    = 5
+	defaultNotifyRetryInterval = time.Second
+	defaultNotifyTimeout       = 5 * time.Second
+)
+
+// NotifyConfig the config of the notifications sent by the prophet leader on
+// the significant events, e.g. store down and shard unavailable.
+type NotifyConfig struct {
+	// Webhooks the http endpoints receiving the events
+	Webhooks []WebhookConfig `toml:"webhooks" json:"webhooks"`
+	// QueueSize the max number of pending events of each endpoint
+	QueueSize int `toml:"queue-size" json:"queue-size"`
+	// MaxRetries the max times to retry a failed notification
+	MaxRetries int `toml:"max-retries" json:"max-retries"`
+	// RetryInterval the interval before the first retry, doubled for each retry
+	RetryInterval typeutil.Duration `toml:"retry-interval" json:"retry-interval"`
+	// Timeout the timeout of a webhook request
+	Timeout typeutil.Duration `toml:"timeout" json:"timeout"`
+
+	// Sinks the custom sinks receiving the events, e.g. a message bus producer
+	Sinks []notify.Sink `toml:"-" json:"-"`
+}
+
+// WebhookConfig a http endpoint receiving the events
+type WebhookConfig struct {
+	URL string `toml:"url" json:"url"`
+	// Secret the key to sign the requests, empty means no signature
+	Secret string `toml:"secret" json:"-"`
+	// Events the subscribed event types, empty means all
+	Events []string `toml:"events" json:"events"`
+}
+
+func (c *NotifyConfig) adjust() error {
+	if c.QueueSize == 0 {
+		c.QueueSize = defaultNotifyQueueSize
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = defaultNotifyMaxRetries
+	}
+	adjustDuration(&c.RetryInterval, defaultNotifyRetryInterval)
+	adjustDuration(&c.Timeout, defaultNotifyTimeout)
+
+	for _, w := range c.Webhooks {
+		if _, err := url.ParseRequestURI(w.URL); err != nil {
+			return fmt.Errorf("invalid notify webhook url %q: %w", w.URL, err)
+		}
+	}
+	return nil
+}
+
+// GetSinks returns the sinks of the webhooks and the custom sinks
+func (c *NotifyConfig) GetSinks() []notify.Sink {
+	sinks := make([]notify.Sink, 0, len(c.Webhooks)+len(c.Sinks))
+	for _, w := range c.Webhooks {
+		events := make([]notify.EventType, 0, len(w.Events))
+		for _, e := range w.Events {
+			events = append(events, notify.EventType(e))
+		}
+		sinks = append(sinks, notify.NewWebhookSink(w.URL, w.Secret, events, c.Timeout.Duration))
+	}
+	return append(sinks, c.Sinks...)
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+)
+
+const (
+	defaultTimelineRetention     = 7 * 24 * time.Hour
+	defaultTimelineMaxEvents     = 10000
+	defaultTimelineCompactWindow = 5 * time.Minute
+)
+
+// TimelineConfig the config of the cluster events timeline. The prophet
+// leader persists the major cluster events, e.g. store joined and shard
+// split, so the history of the cluster is available in the post-incident
+// reviews spanning days, even if the prophet leader changed.
+type TimelineConfig struct {
+	Enable bool `toml:"enable" json:"enable"`
+	// Retention the max age of the persisted events, the older events are
+	// removed
+	Retention typeutil.Duration `toml:"retention" json:"retention"`
+	// MaxEvents the max number of the persisted events, the oldest events are
+	// removed
+	MaxEvents int `toml:"max-events" json:"max-events"`
+	// CompactWindow the repeated events of the same type and the same store or
+	// shard within the window are compacted into one event with a count
+	CompactWindow typeutil.Duration `toml:"compact-window" json:"compact-window"`
+}
+
+func (c *TimelineConfig) adjust() error {
+	adjustDuration(&c.Retention, defaultTimelineRetention)
+	adjustDuration(&c.CompactWindow, defaultTimelineCompactWindow)
+	if c.MaxEvents == 0 {
+		c.MaxEvents = defaultTimelineMaxEvents
+	}
+
+	if c.MaxEvents < 0 {
+		return fmt.Errorf("invalid timeline max-events %d", c.MaxEvents)
+	}
+	return nil
+}
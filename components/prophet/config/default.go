@@ -52,6 +52,18 @@ const (
 	defaultStoreLimitMode              = "manual"
 	defaultEnableJointConsensus        = false
 	defaultEnableCrossTableMerge       = true
+	defaultEnableOperatorRollback      = true
+	defaultExpansionScheduleFactor     = 4
+	defaultExpansionTriggerRatio       = 0.2
+	defaultExpansionFinishRatio        = 0.9
+
+	defaultAdaptiveLimitMaxApplyBacklog = 1024
+	defaultAdaptiveLimitMaxApplyLatency = 5 * time.Millisecond
+	defaultAdaptiveLimitMinRatio        = 0.1
+	defaultAdaptiveLimitMaxRatio        = 2
+
+	defaultSlowStoreMinLatency   = 100 * time.Millisecond
+	defaultSlowStoreLatencyRatio = 4
 )
 
 var (
@@ -156,6 +168,18 @@ func (c *Config) Adjust(meta *toml.MetaData, reloading bool) error {
 	if err := c.Replication.adjust(configMetaData.Child("replication")); err != nil {
 		return err
 	}
+	if err := c.Notify.adjust(); err != nil {
+		return err
+	}
+	if err := c.Federation.adjust(); err != nil {
+		return err
+	}
+	if err := c.BalanceReport.adjust(); err != nil {
+		return err
+	}
+	if err := c.Timeline.adjust(); err != nil {
+		return err
+	}
 
 	if c.TestContext == nil {
 		c.TestContext = NewTestContext()
@@ -43,6 +43,17 @@ type PersistOptions struct {
 	replication    atomic.Value
 	labelProperty  atomic.Value
 	clusterVersion unsafe.Pointer
+	// expansion is the non-persistent boost of the schedule limits during
+	// the expansion rebalancing
+	expansion atomic.Value
+	// storeLimitRatio is the non-persistent ratio of the add peer and remove
+	// peer limits tuned by the adaptive limit mode
+	storeLimitRatio atomic.Value
+}
+
+type expansionBoost struct {
+	factor uint64
+	stores map[uint64]struct{}
 }
 
 // NewPersistOptions creates a new PersistOptions instance.
@@ -280,17 +291,17 @@ func (o *PersistOptions) GetMaxStoreDownTime() time.Duration {
 
 // GetLeaderScheduleLimit returns the limit for leader schedule.
 func (o *PersistOptions) GetLeaderScheduleLimit() uint64 {
-	return o.getTTLUintOr(leaderScheduleLimitKey, o.GetScheduleConfig().LeaderScheduleLimit)
+	return o.boostLimit(o.getTTLUintOr(leaderScheduleLimitKey, o.GetScheduleConfig().LeaderScheduleLimit))
 }
 
 // GetShardScheduleLimit returns the limit for resource schedule.
 func (o *PersistOptions) GetShardScheduleLimit() uint64 {
-	return o.getTTLUintOr(resourceScheduleLimitKey, o.GetScheduleConfig().ShardScheduleLimit)
+	return o.boostLimit(o.getTTLUintOr(resourceScheduleLimitKey, o.GetScheduleConfig().ShardScheduleLimit))
 }
 
 // GetReplicaScheduleLimit returns the limit for replica schedule.
 func (o *PersistOptions) GetReplicaScheduleLimit() uint64 {
-	return o.getTTLUintOr(replicaRescheduleLimitKey, o.GetScheduleConfig().ReplicaScheduleLimit)
+	return o.boostLimit(o.getTTLUintOr(replicaRescheduleLimitKey, o.GetScheduleConfig().ReplicaScheduleLimit))
 }
 
 // GetMergeScheduleLimit returns the limit for merge schedule.
@@ -350,6 +361,14 @@ func (o *PersistOptions) GetStoreLimitByType(containerID uint64, typ limit.Type)
 			returned = o.getTTLFloatOr(fmt.Sprintf("remove-peer-%v", containerID), returned)
 		} else if typ == limit.AddPeer {
 			returned = o.getTTLFloatOr(fmt.Sprintf("add-peer-%v", containerID), returned)
+			if b := o.getExpansionBoost(); b != nil {
+				if _, ok := b.stores[containerID]; ok {
+					returned *= float64(b.factor)
+				}
+			}
+		}
+		if returned < limit.Unlimited {
+			returned *= o.GetStoreLimitRatio()
 		}
 	}()
 	l := o.GetStoreLimit(containerID)
@@ -363,11 +382,178 @@ func (o *PersistOptions) GetStoreLimitByType(containerID uint64, typ limit.Type)
 	}
 }
 
+// SetExpansionBoost multiplies the leader, resource and replica schedule limits
+// and the add peer limit of the given containers by the factor. The boost is not
+// persisted, it is used to accelerate the rebalancing when new containers join.
+func (o *PersistOptions) SetExpansionBoost(factor uint64, containers []uint64) {
+	b := &expansionBoost{factor: factor, stores: make(map[uint64]struct{}, len(containers))}
+	for _, id := range containers {
+		b.stores[id] = struct{}{}
+	}
+	o.expansion.Store(b)
+}
+
+// ClearExpansionBoost restores the schedule limits changed by SetExpansionBoost.
+func (o *PersistOptions) ClearExpansionBoost() {
+	o.expansion.Store(&expansionBoost{})
+}
+
+// IsExpansionBoosted returns true if the schedule limits are boosted.
+func (o *PersistOptions) IsExpansionBoosted() bool {
+	return o.getExpansionBoost() != nil
+}
+
+// IsExpansionRebalanceEnabled returns if the expansion rebalancing is enabled.
+func (o *PersistOptions) IsExpansionRebalanceEnabled() bool {
+	return o.GetScheduleConfig().EnableExpansionRebalance
+}
+
+// GetExpansionScheduleFactor returns the multiple of the schedule limits during
+// the expansion rebalancing.
+func (o *PersistOptions) GetExpansionScheduleFactor() uint64 {
+	return o.GetScheduleConfig().ExpansionScheduleFactor
+}
+
+// GetExpansionTriggerRatio returns the ratio of the average resource count below
+// which a container is considered as a new container.
+func (o *PersistOptions) GetExpansionTriggerRatio() float64 {
+	return o.GetScheduleConfig().ExpansionTriggerRatio
+}
+
+// GetExpansionFinishRatio returns the ratio of the average resource count a new
+// container should reach to finish the expansion rebalancing.
+func (o *PersistOptions) GetExpansionFinishRatio() float64 {
+	return o.GetScheduleConfig().ExpansionFinishRatio
+}
+
+// SetStoreLimitRatio sets the ratio of the add peer and remove peer limits of
+// all containers. The ratio is not persisted, it is tuned by the adaptive limit
+// mode according to the load of the cluster.
+func (o *PersistOptions) SetStoreLimitRatio(ratio float64) {
+	o.storeLimitRatio.Store(ratio)
+}
+
+// GetStoreLimitRatio returns the ratio of the add peer and remove peer limits,
+// 1 if the ratio is never set.
+func (o *PersistOptions) GetStoreLimitRatio() float64 {
+	if v := o.storeLimitRatio.Load(); v != nil {
+		return v.(float64)
+	}
+	return 1
+}
+
+// GetOperatorStepTimeouts returns the max duration of a single operator step by
+// the operator kind name.
+func (o *PersistOptions) GetOperatorStepTimeouts() map[string]typeutil.Duration {
+	return o.GetScheduleConfig().OperatorStepTimeouts
+}
+
+// IsOperatorRollbackEnabled returns if the compensating operator is created
+// when an operator times out.
+func (o *PersistOptions) IsOperatorRollbackEnabled() bool {
+	return o.GetScheduleConfig().EnableOperatorRollback
+}
+
+// GetAdaptiveLimitMaxApplyBacklog returns the apply backlog of a container
+// above which the cluster is considered as busy by the adaptive limit mode.
+func (o *PersistOptions) GetAdaptiveLimitMaxApplyBacklog() uint64 {
+	return o.GetScheduleConfig().AdaptiveLimitMaxApplyBacklog
+}
+
+// GetAdaptiveLimitMaxApplyLatency returns the apply latency of a container
+// above which the cluster is considered as busy by the adaptive limit mode.
+func (o *PersistOptions) GetAdaptiveLimitMaxApplyLatency() time.Duration {
+	return o.GetScheduleConfig().AdaptiveLimitMaxApplyLatency.Duration
+}
+
+// GetAdaptiveLimitRatioRange returns the minimum and maximum ratios of the
+// container limits used by the adaptive limit mode.
+func (o *PersistOptions) GetAdaptiveLimitRatioRange() (float64, float64) {
+	cfg := o.GetScheduleConfig()
+	return cfg.AdaptiveLimitMinRatio, cfg.AdaptiveLimitMaxRatio
+}
+
+// GetSlowStoreMinLatency returns the heartbeat latency below which a container
+// is never considered as slow.
+func (o *PersistOptions) GetSlowStoreMinLatency() time.Duration {
+	return o.GetScheduleConfig().SlowStoreMinLatency.Duration
+}
+
+// GetSlowStoreLatencyRatio returns the ratio of the heartbeat latency of a
+// container to the median latency, above which the container is slow.
+func (o *PersistOptions) GetSlowStoreLatencyRatio() float64 {
+	return o.GetScheduleConfig().SlowStoreLatencyRatio
+}
+
+func (o *PersistOptions) getExpansionBoost() *expansionBoost {
+	if v := o.expansion.Load(); v != nil {
+		if b := v.(*expansionBoost); b.factor > 1 {
+			return b
+		}
+	}
+	return nil
+}
+
+func (o *PersistOptions) boostLimit(value uint64) uint64 {
+	if b := o.getExpansionBoost(); b != nil {
+		return value * b.factor
+	}
+	return value
+}
+
 // GetAllStoresLimit returns the limit of all containers.
 func (o *PersistOptions) GetAllStoresLimit() map[uint64]StoreLimitConfig {
 	return o.GetScheduleConfig().StoreLimit
 }
 
+// GetStoreResourceLimit returns the resource limits of the container. The
+// limits set by SetStoreResourceLimit take precedence over the ones set by the
+// labels of the container.
+func (o *PersistOptions) GetStoreResourceLimit(container *core.CachedStore) StoreResourceLimitConfig {
+	if cfg, ok := o.GetScheduleConfig().StoreResourceLimit[container.Meta.GetID()]; ok {
+		return cfg
+	}
+	return StoreResourceLimitConfig{
+		MaxReplicas: o.parseStoreLimitLabel(container, StoreLabelMaxReplicas),
+		MaxLeaders:  o.parseStoreLimitLabel(container, StoreLabelMaxLeaders),
+		MaxSize:     o.parseStoreLimitLabel(container, StoreLabelMaxSize),
+	}
+}
+
+func (o *PersistOptions) parseStoreLimitLabel(container *core.CachedStore, key string) uint64 {
+	value := container.GetLabelValue(key)
+	if value == "" {
+		return 0
+	}
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		o.logger.Debug("invalid container resource limit label, ignored",
+			zap.Uint64("container", container.Meta.GetID()),
+			zap.String("label", key),
+			zap.String("value", value))
+		return 0
+	}
+	return v
+}
+
+// SetStoreResourceLimit sets the resource limits of a container.
+func (o *PersistOptions) SetStoreResourceLimit(containerID uint64, cfg StoreResourceLimitConfig) {
+	v := o.GetScheduleConfig().Clone()
+	if v.StoreResourceLimit == nil {
+		v.StoreResourceLimit = make(map[uint64]StoreResourceLimitConfig)
+	}
+	v.StoreResourceLimit[containerID] = cfg
+	o.SetScheduleConfig(v)
+}
+
+// DeleteStoreResourceLimit deletes the resource limits of a container set by
+// SetStoreResourceLimit, the limits set by the labels are used again.
+func (o *PersistOptions) DeleteStoreResourceLimit(containerID uint64) {
+	v := o.GetScheduleConfig().Clone()
+	delete(v.StoreResourceLimit, containerID)
+	o.SetScheduleConfig(v)
+}
+
 // GetStoreLimitMode returns the limit mode of container.
 func (o *PersistOptions) GetStoreLimitMode() string {
 	return o.GetScheduleConfig().StoreLimitMode
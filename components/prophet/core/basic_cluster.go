@@ -246,6 +246,14 @@ func (bc *BasicCluster) ResumeLeaderTransfer(containerID uint64) {
 	bc.Stores.ResumeLeaderTransfer(containerID)
 }
 
+// SetStoreSlow sets the slow state of the container, the slow container can't
+// be selected as target container of TransferLeader.
+func (bc *BasicCluster) SetStoreSlow(containerID uint64, slow bool) {
+	bc.Lock()
+	defer bc.Unlock()
+	bc.Stores.SetStoreSlow(containerID, slow)
+}
+
 // AttachAvailableFunc attaches an available function to a specific container.
 func (bc *BasicCluster) AttachAvailableFunc(containerID uint64, limitType limit.Type, f func() bool) {
 	bc.Lock()
@@ -49,6 +49,7 @@ type CachedShard struct {
 	downReplicas    replicaStatsSlice
 	pendingReplicas replicaSlice
 	stats           metapb.ShardStats
+	blockingReasons []metapb.BlockingReason
 }
 
 // NewCachedShard creates CachedShard with shard's meta and leader peer.
@@ -66,11 +67,12 @@ func NewCachedShard(res metapb.Shard, leader *metapb.Replica, opts ...ShardCreat
 }
 
 // fillVoterAndLearner sorts out voter and learner from peers into different slice.
+// The observer is a learner which is never promoted.
 func fillVoterAndLearner(res *CachedShard) {
 	learners := make([]metapb.Replica, 0, 1)
 	voters := make([]metapb.Replica, 0, len(res.Meta.GetReplicas()))
 	for _, p := range res.Meta.GetReplicas() {
-		if metadata.IsLearner(p) {
+		if metadata.IsLearner(p) || metadata.IsObserver(p) {
 			learners = append(learners, p)
 		} else {
 			voters = append(voters, p)
@@ -110,6 +112,7 @@ func ShardFromHeartbeat(heartbeat rpcpb.ShardHeartbeatReq, meta metapb.Shard) *C
 		pendingReplicas: heartbeat.GetPendingReplicas(),
 		stats:           heartbeat.Stats,
 		lease:           heartbeat.Lease,
+		blockingReasons: heartbeat.BlockingReasons,
 	}
 	shard.stats.ApproximateSize = shardSize
 
@@ -147,6 +150,7 @@ func (r *CachedShard) Clone(opts ...ShardCreateOption) *CachedShard {
 		downReplicas:    downReplicas,
 		pendingReplicas: pendingReplicas,
 		stats:           r.stats,
+		blockingReasons: r.blockingReasons,
 	}
 	res.stats.Interval = proto.Clone(r.stats.Interval).(*metapb.TimeInterval)
 
@@ -182,6 +186,12 @@ func (r *CachedShard) GetLease() *metapb.EpochLease {
 	return r.lease
 }
 
+// GetBlockingReasons returns the latest reasons why the operations of the shard
+// are declined by the leader
+func (r *CachedShard) GetBlockingReasons() []metapb.BlockingReason {
+	return r.blockingReasons
+}
+
 // GetTerm returns the current term of the shard
 func (r *CachedShard) GetTerm() uint64 {
 	return r.term
@@ -220,7 +230,8 @@ func (r *CachedShard) GetDownPeer(peerID uint64) (metapb.Replica, bool) {
 // GetDownVoter returns the down voter with specified peer id.
 func (r *CachedShard) GetDownVoter(peerID uint64) (metapb.Replica, bool) {
 	for _, down := range r.downReplicas {
-		if down.Replica.ID == peerID && !metadata.IsLearner(down.Replica) {
+		if down.Replica.ID == peerID && !metadata.IsLearner(down.Replica) &&
+			!metadata.IsObserver(down.Replica) {
 			return down.Replica, true
 		}
 	}
@@ -250,7 +261,7 @@ func (r *CachedShard) GetPendingPeer(peerID uint64) (metapb.Replica, bool) {
 // GetPendingVoter returns the pending voter with specified peer id.
 func (r *CachedShard) GetPendingVoter(peerID uint64) (metapb.Replica, bool) {
 	for _, peer := range r.pendingReplicas {
-		if peer.ID == peerID && !metadata.IsLearner(peer) {
+		if peer.ID == peerID && !metadata.IsLearner(peer) && !metadata.IsObserver(peer) {
 			return peer, true
 		}
 	}
@@ -402,6 +413,12 @@ func (r *CachedShard) GetKeysRead() uint64 {
 	return r.stats.ReadKeys
 }
 
+// GetReadLatency returns the average read latency in microseconds of the shard
+// during the last heartbeat period.
+func (r *CachedShard) GetReadLatency() uint64 {
+	return r.stats.ReadLatency
+}
+
 // GetLeader returns the leader of the shard.
 func (r *CachedShard) GetLeader() *metapb.Replica {
 	return r.leader
@@ -888,10 +905,10 @@ func (r *ShardsContainer) GetShards() []*CachedShard {
 
 // GetStoreShards gets all CachedShard with a given storeID
 func (r *ShardsContainer) GetStoreShards(groupKey string, storeID uint64) []*CachedShard {
-	r.maybeInitWithGroup(groupKey)
+	// GetStoreShardCount acquires the lock, so it must be called before RLock
+	shards := make([]*CachedShard, 0, r.GetStoreShardCount(groupKey, storeID))
 	r.RLock()
 	defer r.RUnlock()
-	shards := make([]*CachedShard, 0, r.GetStoreShardCount(groupKey, storeID))
 	if leaders, ok := r.leaders[groupKey][storeID]; ok {
 		shards = append(shards, leaders.scanRanges()...)
 	}
@@ -79,6 +79,13 @@ func WithLease(lease *metapb.EpochLease) ShardCreateOption {
 	}
 }
 
+// WithBlockingReasons sets the blocking reasons for the shard.
+func WithBlockingReasons(reasons []metapb.BlockingReason) ShardCreateOption {
+	return func(res *CachedShard) {
+		res.blockingReasons = reasons
+	}
+}
+
 // WithStartKey sets the start key for the shard.
 func WithStartKey(key []byte) ShardCreateOption {
 	return func(res *CachedShard) {
@@ -168,6 +175,13 @@ func SetReadKeys(v uint64) ShardCreateOption {
 	}
 }
 
+// SetReadLatency sets the average read latency in microseconds for the shard.
+func SetReadLatency(v uint64) ShardCreateOption {
+	return func(res *CachedShard) {
+		res.stats.ReadLatency = v
+	}
+}
+
 // SetApproximateSize sets the approximate size for the shard.
 func SetApproximateSize(v int64) ShardCreateOption {
 	return func(res *CachedShard) {
@@ -203,7 +217,7 @@ func WithAddPeer(peer metapb.Replica) ShardCreateOption {
 		peers = append(peers, peer)
 		res.Meta.SetReplicas(peers)
 
-		if metadata.IsLearner(peer) {
+		if metadata.IsLearner(peer) || metadata.IsObserver(peer) {
 			res.learners = append(res.learners, peer)
 		} else {
 			res.voters = append(res.voters, peer)
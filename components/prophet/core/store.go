@@ -44,6 +44,7 @@ type CachedStore struct {
 	Meta metapb.Store
 	*storeStats
 	pauseLeaderTransfer bool // not allow to be used as source or target of transfer leader
+	slow                bool // not allow to be used as target of transfer leader
 	shardInfo           map[string]counterAndSize
 	leaderInfo          map[string]counterAndSize
 	pendingPeerCounts   map[string]int
@@ -76,6 +77,7 @@ func (cr *CachedStore) Clone(opts ...StoreCreateOption) *CachedStore {
 		Meta:                cr.Meta,
 		storeStats:          cr.storeStats,
 		pauseLeaderTransfer: cr.pauseLeaderTransfer,
+		slow:                cr.slow,
 		shardInfo:           make(map[string]counterAndSize),
 		leaderInfo:          make(map[string]counterAndSize),
 		pendingPeerCounts:   make(map[string]int),
@@ -107,6 +109,7 @@ func (cr *CachedStore) ShallowClone(opts ...StoreCreateOption) *CachedStore {
 		Meta:                cr.Meta,
 		storeStats:          cr.storeStats,
 		pauseLeaderTransfer: cr.pauseLeaderTransfer,
+		slow:                cr.slow,
 		shardInfo:           make(map[string]counterAndSize),
 		leaderInfo:          make(map[string]counterAndSize),
 		pendingPeerCounts:   make(map[string]int),
@@ -138,6 +141,13 @@ func (cr *CachedStore) AllowLeaderTransfer() bool {
 	return !cr.pauseLeaderTransfer
 }
 
+// IsSlow returns if the store is found slow by the heartbeat latencies from the
+// leaders in the other stores, the slow store can't be selected as the target
+// of transfer leader.
+func (cr *CachedStore) IsSlow() bool {
+	return cr.slow
+}
+
 // IsAvailable returns if the store bucket of limitation is available
 func (cr *CachedStore) IsAvailable(limitType limit.Type) bool {
 	if cr.available != nil && cr.available[limitType] != nil {
@@ -166,6 +176,12 @@ func (cr *CachedStore) IsPhysicallyDestroyed() bool {
 	return cr.Meta.GetDestroyed()
 }
 
+// IsDraining checks if the store is draining, the draining store can't be
+// selected as the target of the leaders or the replicas.
+func (cr *CachedStore) IsDraining() bool {
+	return cr.Meta.GetDraining()
+}
+
 // DownTime returns the time elapsed since last heartbeat.
 func (cr *CachedStore) DownTime() time.Duration {
 	return time.Since(cr.GetLastHeartbeatTS())
@@ -520,6 +536,13 @@ func (s *StoresContainer) ResumeLeaderTransfer(storeID uint64) {
 	s.stores[storeID] = store.Clone(ResumeLeaderTransfer())
 }
 
+// SetStoreSlow sets the slow state of a CachedStore with storeID.
+func (s *StoresContainer) SetStoreSlow(storeID uint64, slow bool) {
+	if store, ok := s.stores[storeID]; ok && store.IsSlow() != slow {
+		s.stores[storeID] = store.Clone(SetStoreSlow(slow))
+	}
+}
+
 // AttachAvailableFunc attaches f to a specific store.
 func (s *StoresContainer) AttachAvailableFunc(storeID uint64, limitType limit.Type, f func() bool) {
 	if store, ok := s.stores[storeID]; ok {
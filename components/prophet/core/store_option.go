@@ -59,6 +59,13 @@ func SetStoreDeployPath(deployPath string) StoreCreateOption {
 	}
 }
 
+// SetStoreDraining sets the draining state for the cachedStore.
+func SetStoreDraining(draining bool) StoreCreateOption {
+	return func(cachedStore *CachedStore) {
+		cachedStore.Meta.SetDraining(draining)
+	}
+}
+
 // OfflineStore offline a cachedStore
 func OfflineStore(physicallyDestroyed bool) StoreCreateOption {
 	return func(cachedStore *CachedStore) {
@@ -97,6 +104,14 @@ func ResumeLeaderTransfer() StoreCreateOption {
 	}
 }
 
+// SetStoreSlow sets the slow state for the cachedStore, the slow cachedStore
+// can't be selected as target cachedStore of TransferLeader.
+func SetStoreSlow(slow bool) StoreCreateOption {
+	return func(cachedStore *CachedStore) {
+		cachedStore.slow = slow
+	}
+}
+
 // SetLeaderCount sets the leader count for the cachedStore.
 func SetLeaderCount(groupKey string, leaderCount int) StoreCreateOption {
 	return func(cachedStore *CachedStore) {
@@ -130,6 +130,14 @@ func (ss *storeStats) IsBusy() bool {
 	return ss.rawStats.GetIsBusy()
 }
 
+// IsDiskFailed returns if the store failed to write its data storage, the
+// failed replicas stop applying until the store is restarted.
+func (ss *storeStats) IsDiskFailed() bool {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.rawStats.GetDiskFailed()
+}
+
 // GetSendingSnapCount returns the current sending snapshot count of the store.
 func (ss *storeStats) GetSendingSnapCount() uint64 {
 	ss.mu.RLock()
@@ -29,6 +29,8 @@ var (
 	ShardStatsEvent uint32 = 1 << 4
 	// StoreStatsEvent store stats
 	StoreStatsEvent uint32 = 1 << 5
+	// SettingEvent cluster-wide setting changed
+	SettingEvent uint32 = 1 << 6
 	// AllEvent all event
 	AllEvent uint32 = 0xffffffff
 
@@ -38,6 +40,7 @@ var (
 		ShardStatsEvent: "shard-stats",
 		StoreEvent:      "store",
 		StoreStatsEvent: "store-stats",
+		SettingEvent:    "setting",
 		AllEvent:        "all",
 	}
 )
@@ -53,6 +56,7 @@ type Snapshot struct {
 	Stores            []metapb.Store
 	LeaderReplicasIDs map[uint64]uint64
 	Leases            map[uint64]*metapb.EpochLease
+	Settings          []metapb.ClusterSetting
 }
 
 // MatchEvent returns the flag has the target event
@@ -89,6 +93,7 @@ func NewInitEvent(snap Snapshot) (*rpcpb.InitEventData, error) {
 		}
 	}
 
+	resp.Settings = append(resp.Settings, snap.Settings...)
 	return resp, nil
 }
 
@@ -141,3 +146,11 @@ func NewStoreEvent(target metapb.Store) rpcpb.EventNotify {
 		},
 	}
 }
+
+// NewSettingEvent create cluster-wide setting event
+func NewSettingEvent(setting metapb.ClusterSetting) rpcpb.EventNotify {
+	return rpcpb.EventNotify{
+		Type:         SettingEvent,
+		SettingEvent: &setting,
+	}
+}
@@ -85,6 +85,7 @@ func (wn *eventNotifier) handleCreateWatcher(req *rpcpb.ProphetRequest, resp *rp
 				snap.LeaderReplicasIDs[res.Meta.GetID()] = res.GetLeader().GetID()
 				snap.Leases[res.Meta.GetID()] = res.GetLease()
 			}
+			snap.Settings = wn.cluster.GetClusterSettings()
 
 			rsp, err := event.NewInitEvent(snap)
 			if err != nil {
@@ -0,0 +1,622 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prophet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/matrixorigin/matrixcube/components/log"
+	pconfig "github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/election"
+	"github.com/matrixorigin/matrixcube/components/prophet/member"
+	"github.com/matrixorigin/matrixcube/components/prophet/util"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+// federationMember is the connection to a prophet group of the federation
+type federationMember struct {
+	name   string
+	groups []uint64
+	client Client
+	close  func()
+}
+
+// newFederationMember watches the leader of the prophet group via its etcd,
+// and creates the client to the leader.
+func newFederationMember(cfg pconfig.FederationMember, prophetCfg *pconfig.Config,
+	logger *zap.Logger) (*federationMember, error) {
+	logger = logger.With(zap.String("federation-member", cfg.Name))
+	etcdClient, err := clientv3.New(clientv3.Config{
+		Endpoints:        cfg.ExternalEtcd,
+		AutoSyncInterval: time.Second * 30,
+		DialTimeout:      time.Second * 10,
+		Logger:           logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	elector, err := election.NewElector(
+		etcdClient,
+		election.WithLeaderLeaseSeconds(prophetCfg.LeaderLease),
+		election.WithLogger(logger.Named("elector")),
+	)
+	if err != nil {
+		etcdClient.Close()
+		return nil, err
+	}
+
+	noop := func() error { return nil }
+	m := member.NewMember(nil, elector, false, noop, noop, logger)
+	m.InitMemberInfo(prophetCfg.Name, prophetCfg.AdvertiseRPCAddr)
+	m.ElectionLoop()
+
+	client := NewClient(
+		WithRPCTimeout(prophetCfg.RPCTimeout.Duration),
+		WithLeaderGetter(m.GetLeader),
+		WithLogger(logger))
+	return &federationMember{
+		name:   cfg.Name,
+		groups: cfg.Groups,
+		client: client,
+		close: func() {
+			client.Close()
+			m.Stop()
+			etcdClient.Close()
+		},
+	}, nil
+}
+
+// federatedClient routes the requests to the prophet groups of the federation.
+// The shard requests are sent to the prophet group which owns the shard group,
+// the shard group of a shard is learned from the heartbeats and the events, the
+// requests of the unknown shards are sent to the home prophet group. The store
+// metadata and the store heartbeats are sent to all the prophet groups, as all
+// of them schedule the replicas on the stores. The IDs, the jobs and the cluster
+// settings are managed by the home prophet group.
+type federatedClient struct {
+	logger  *zap.Logger
+	home    Client
+	members []*federationMember
+	// owners shard group -> index of the member
+	owners map[uint64]int
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	notifyMu  sync.Mutex
+	notifyC   chan rpcpb.ShardHeartbeatRsp
+	closeOnce sync.Once
+
+	mu struct {
+		sync.RWMutex
+		// groups shard id -> shard group
+		groups map[uint64]uint64
+	}
+}
+
+func newFederatedClient(home Client, members []*federationMember, logger *zap.Logger) *federatedClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &federatedClient{
+		logger:  log.Adjust(logger).Named("federation"),
+		home:    home,
+		members: members,
+		owners:  make(map[uint64]int),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	for idx, m := range members {
+		for _, g := range m.groups {
+			c.owners[g] = idx
+		}
+	}
+	c.mu.groups = make(map[uint64]uint64)
+	return c
+}
+
+// clients returns the clients of all the prophet groups, the home one first
+func (c *federatedClient) clients() []Client {
+	clients := make([]Client, 0, len(c.members)+1)
+	clients = append(clients, c.home)
+	for _, m := range c.members {
+		clients = append(clients, m.client)
+	}
+	return clients
+}
+
+func (c *federatedClient) getGroupClient(group uint64) Client {
+	if idx, ok := c.owners[group]; ok {
+		return c.members[idx].client
+	}
+	return c.home
+}
+
+func (c *federatedClient) getShardClient(id uint64) Client {
+	c.mu.RLock()
+	group, ok := c.mu.groups[id]
+	c.mu.RUnlock()
+	if !ok {
+		return c.home
+	}
+	return c.getGroupClient(group)
+}
+
+func (c *federatedClient) learnShard(shard metapb.Shard) {
+	c.mu.Lock()
+	c.mu.groups[shard.ID] = shard.Group
+	c.mu.Unlock()
+}
+
+func (c *federatedClient) Close() error {
+	c.closeOnce.Do(func() {
+		c.cancel()
+		for _, m := range c.members {
+			m.close()
+		}
+		c.home.Close()
+	})
+	return nil
+}
+
+func (c *federatedClient) AllocID() (uint64, error) {
+	return c.home.AllocID()
+}
+
+func (c *federatedClient) CreateDestroying(id uint64, index uint64, removeData bool, replicas []uint64) (metapb.ShardState, error) {
+	return c.getShardClient(id).CreateDestroying(id, index, removeData, replicas)
+}
+
+func (c *federatedClient) ReportDestroyed(id uint64, replicaID uint64) (metapb.ShardState, error) {
+	return c.getShardClient(id).ReportDestroyed(id, replicaID)
+}
+
+func (c *federatedClient) GetDestroying(id uint64) (*metapb.DestroyingStatus, error) {
+	return c.getShardClient(id).GetDestroying(id)
+}
+
+func (c *federatedClient) PutStore(store metapb.Store) error {
+	for _, client := range c.clients() {
+		if err := client.PutStore(store); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *federatedClient) GetStore(storeID uint64) (*metapb.Store, error) {
+	return c.home.GetStore(storeID)
+}
+
+func (c *federatedClient) ShardHeartbeat(meta metapb.Shard, hb rpcpb.ShardHeartbeatReq) error {
+	c.learnShard(meta)
+	return c.getGroupClient(meta.Group).ShardHeartbeat(meta, hb)
+}
+
+// StoreHeartbeat sends the heartbeat to all the prophet groups, and returns
+// the response of the home prophet group.
+func (c *federatedClient) StoreHeartbeat(hb rpcpb.StoreHeartbeatReq) (rpcpb.StoreHeartbeatRsp, error) {
+	for _, m := range c.members {
+		if _, err := m.client.StoreHeartbeat(hb); err != nil {
+			c.logger.Error("fail to send store heartbeat to federation member",
+				zap.String("member", m.name),
+				zap.Error(err))
+		}
+	}
+	return c.home.StoreHeartbeat(hb)
+}
+
+func (c *federatedClient) AskBatchSplit(shard metapb.Shard, count uint32) ([]rpcpb.SplitID, error) {
+	c.learnShard(shard)
+	return c.getGroupClient(shard.Group).AskBatchSplit(shard, count)
+}
+
+func (c *federatedClient) NewWatcher(flag uint32) (EventWatcher, error) {
+	var watchers []EventWatcher
+	for _, client := range c.clients() {
+		w, err := client.NewWatcher(flag)
+		if err != nil {
+			for _, w := range watchers {
+				w.Close()
+			}
+			return nil, err
+		}
+		watchers = append(watchers, w)
+	}
+	return newFederatedWatcher(watchers, c, c.logger), nil
+}
+
+// GetShardHeartbeatRspNotifier returns the channel merged from the channels of
+// all the prophet groups.
+func (c *federatedClient) GetShardHeartbeatRspNotifier() (chan rpcpb.ShardHeartbeatRsp, error) {
+	c.notifyMu.Lock()
+	defer c.notifyMu.Unlock()
+
+	if c.notifyC != nil {
+		return c.notifyC, nil
+	}
+
+	var sources []chan rpcpb.ShardHeartbeatRsp
+	for _, client := range c.clients() {
+		source, err := client.GetShardHeartbeatRspNotifier()
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	c.notifyC = make(chan rpcpb.ShardHeartbeatRsp, 128)
+	var wg sync.WaitGroup
+	for _, source := range sources {
+		wg.Add(1)
+		go func(source chan rpcpb.ShardHeartbeatRsp) {
+			defer wg.Done()
+			for {
+				select {
+				case <-c.ctx.Done():
+					return
+				case rsp, ok := <-source:
+					if !ok {
+						return
+					}
+					select {
+					case c.notifyC <- rsp:
+					case <-c.ctx.Done():
+						return
+					}
+				}
+			}
+		}(source)
+	}
+	go func(notifyC chan rpcpb.ShardHeartbeatRsp) {
+		wg.Wait()
+		close(notifyC)
+	}(c.notifyC)
+	return c.notifyC, nil
+}
+
+func (c *federatedClient) AsyncAddShards(shards ...metapb.Shard) error {
+	return c.AsyncAddShardsWithLeastPeers(shards, make([]int, len(shards)))
+}
+
+func (c *federatedClient) AsyncAddShardsWithLeastPeers(shards []metapb.Shard, leastPeers []int) error {
+	type batch struct {
+		shards     []metapb.Shard
+		leastPeers []int
+	}
+	var clients []Client
+	batches := make(map[Client]*batch)
+	for idx, shard := range shards {
+		client := c.getGroupClient(shard.Group)
+		b, ok := batches[client]
+		if !ok {
+			b = &batch{}
+			batches[client] = b
+			clients = append(clients, client)
+		}
+		b.shards = append(b.shards, shard)
+		b.leastPeers = append(b.leastPeers, leastPeers[idx])
+	}
+
+	for _, client := range clients {
+		b := batches[client]
+		if err := client.AsyncAddShardsWithLeastPeers(b.shards, b.leastPeers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *federatedClient) AsyncRemoveShards(ids ...uint64) error {
+	var clients []Client
+	batches := make(map[Client][]uint64)
+	for _, id := range ids {
+		client := c.getShardClient(id)
+		if _, ok := batches[client]; !ok {
+			clients = append(clients, client)
+		}
+		batches[client] = append(batches[client], id)
+	}
+
+	for _, client := range clients {
+		if err := client.AsyncRemoveShards(batches[client]...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckShardState checks the shards in all the prophet groups, a shard is only
+// known by the prophet group which owns it.
+func (c *federatedClient) CheckShardState(shards *roaring64.Bitmap) (rpcpb.CheckShardStateRsp, error) {
+	destroyed := roaring64.NewBitmap()
+	destroying := roaring64.NewBitmap()
+	for _, client := range c.clients() {
+		rsp, err := client.CheckShardState(shards)
+		if err != nil {
+			return rpcpb.CheckShardStateRsp{}, err
+		}
+		destroyed.Or(util.MustUnmarshalBM64(rsp.Destroyed))
+		destroying.Or(util.MustUnmarshalBM64(rsp.Destroying))
+	}
+	return rpcpb.CheckShardStateRsp{
+		Destroyed:  util.MustMarshalBM64(destroyed),
+		Destroying: util.MustMarshalBM64(destroying),
+	}, nil
+}
+
+func (c *federatedClient) CheckTombstoneReplicas(replicas []rpcpb.TombstoneReplica) (*roaring64.Bitmap, error) {
+	var clients []Client
+	batches := make(map[Client][]rpcpb.TombstoneReplica)
+	for _, r := range replicas {
+		client := c.getShardClient(r.ShardID)
+		if _, ok := batches[client]; !ok {
+			clients = append(clients, client)
+		}
+		batches[client] = append(batches[client], r)
+	}
+
+	removable := roaring64.NewBitmap()
+	for _, client := range clients {
+		bm, err := client.CheckTombstoneReplicas(batches[client])
+		if err != nil {
+			return nil, err
+		}
+		removable.Or(bm)
+	}
+	return removable, nil
+}
+
+func (c *federatedClient) PutPlacementRule(rule rpcpb.PlacementRule) error {
+	for _, client := range c.clients() {
+		if err := client.PutPlacementRule(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *federatedClient) GetAppliedRules(id uint64) ([]rpcpb.PlacementRule, error) {
+	return c.getShardClient(id).GetAppliedRules(id)
+}
+
+func (c *federatedClient) AddSchedulingRule(groupID uint64, ruleName string, labelName string) error {
+	return c.getGroupClient(groupID).AddSchedulingRule(groupID, ruleName, labelName)
+}
+
+func (c *federatedClient) GetSchedulingRules() ([]metapb.ScheduleGroupRule, error) {
+	var rules []metapb.ScheduleGroupRule
+	for _, client := range c.clients() {
+		values, err := client.GetSchedulingRules()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, values...)
+	}
+	return rules, nil
+}
+
+func (c *federatedClient) PutClusterSetting(key, value string) (metapb.ClusterSetting, error) {
+	return c.home.PutClusterSetting(key, value)
+}
+
+func (c *federatedClient) GetClusterSettings() ([]metapb.ClusterSetting, error) {
+	return c.home.GetClusterSettings()
+}
+
+func (c *federatedClient) ScatterShards(group uint64, start, end []byte) (rpcpb.ScatterShardsRsp, error) {
+	return c.getGroupClient(group).ScatterShards(group, start, end)
+}
+
+func (c *federatedClient) CreateJob(job metapb.Job) error {
+	return c.home.CreateJob(job)
+}
+
+func (c *federatedClient) RemoveJob(job metapb.Job) error {
+	return c.home.RemoveJob(job)
+}
+
+func (c *federatedClient) ExecuteJob(job metapb.Job, data []byte) ([]byte, error) {
+	return c.home.ExecuteJob(job, data)
+}
+
+type federatedShard struct {
+	data     []byte
+	leaderID uint64
+	lease    metapb.EpochLease
+}
+
+// federatedSource is the shards and stores known by a prophet group
+type federatedSource struct {
+	inited bool
+	shards map[uint64]federatedShard
+	stores map[uint64][]byte
+	// settings is only set by the home prophet group
+	settings map[string]metapb.ClusterSetting
+}
+
+// federatedWatcher merges the events of all the prophet groups. The init event
+// of a prophet group is merged with the shards and stores of the others, as
+// the init event resets all the metadata of the receiver.
+type federatedWatcher struct {
+	logger   *zap.Logger
+	client   *federatedClient
+	watchers []EventWatcher
+	eventC   chan rpcpb.EventNotify
+
+	mu struct {
+		sync.Mutex
+		seq     uint64
+		sources []*federatedSource
+	}
+}
+
+func newFederatedWatcher(watchers []EventWatcher, client *federatedClient, logger *zap.Logger) EventWatcher {
+	w := &federatedWatcher{
+		logger:   log.Adjust(logger).Named("watcher"),
+		client:   client,
+		watchers: watchers,
+		eventC:   make(chan rpcpb.EventNotify, 128),
+	}
+	for range watchers {
+		w.mu.sources = append(w.mu.sources, &federatedSource{})
+	}
+
+	var wg sync.WaitGroup
+	for idx, watcher := range watchers {
+		wg.Add(1)
+		go func(idx int, watcher EventWatcher) {
+			defer wg.Done()
+			for e := range watcher.GetNotify() {
+				w.handleEvent(idx, e)
+			}
+		}(idx, watcher)
+	}
+	go func() {
+		wg.Wait()
+		close(w.eventC)
+	}()
+	return w
+}
+
+func (w *federatedWatcher) GetNotify() chan rpcpb.EventNotify {
+	return w.eventC
+}
+
+func (w *federatedWatcher) Close() {
+	for _, watcher := range w.watchers {
+		watcher.Close()
+	}
+}
+
+// handleEvent keeps the order of the merged events the same as the order of the
+// changes of the sources.
+func (w *federatedWatcher) handleEvent(idx int, e rpcpb.EventNotify) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	source := w.mu.sources[idx]
+	switch {
+	case e.InitEvent != nil:
+		source.inited = true
+		source.shards = make(map[uint64]federatedShard)
+		source.stores = make(map[uint64][]byte)
+		source.settings = make(map[string]metapb.ClusterSetting)
+		for _, setting := range e.InitEvent.Settings {
+			source.settings[setting.Key] = setting
+		}
+		for i, data := range e.InitEvent.Shards {
+			shard := metapb.Shard{}
+			if err := shard.Unmarshal(data); err != nil {
+				w.logger.Error("fail to unmarshal shard", zap.Error(err))
+				continue
+			}
+			w.learnShard(shard)
+			source.shards[shard.ID] = federatedShard{
+				data:     data,
+				leaderID: e.InitEvent.LeaderReplicaIDs[i],
+				lease:    e.InitEvent.Leases[i],
+			}
+		}
+		for _, data := range e.InitEvent.Stores {
+			if id, ok := w.decodeStoreID(data); ok {
+				source.stores[id] = data
+			}
+		}
+		e.InitEvent = w.mergeInitEventLocked()
+	case e.ShardEvent != nil:
+		shard := metapb.Shard{}
+		if err := shard.Unmarshal(e.ShardEvent.Data); err != nil {
+			w.logger.Error("fail to unmarshal shard", zap.Error(err))
+			break
+		}
+		if source.inited {
+			if e.ShardEvent.Removed {
+				delete(source.shards, shard.ID)
+			} else {
+				lease := metapb.EpochLease{}
+				if e.ShardEvent.Lease != nil {
+					lease = *e.ShardEvent.Lease
+				}
+				source.shards[shard.ID] = federatedShard{
+					data:     e.ShardEvent.Data,
+					leaderID: e.ShardEvent.LeaderReplicaID,
+					lease:    lease,
+				}
+			}
+		}
+		// the removed shards are kept, the destroying replicas still report
+		// to the prophet group which owns the shard
+		w.learnShard(shard)
+	case e.StoreEvent != nil:
+		if source.inited {
+			if id, ok := w.decodeStoreID(e.StoreEvent.Data); ok {
+				source.stores[id] = e.StoreEvent.Data
+			}
+		}
+	case e.SettingEvent != nil:
+		if source.inited {
+			source.settings[e.SettingEvent.Key] = *e.SettingEvent
+		}
+	}
+
+	e.Seq = w.mu.seq
+	w.mu.seq++
+	w.eventC <- e
+}
+
+func (w *federatedWatcher) mergeInitEventLocked() *rpcpb.InitEventData {
+	merged := &rpcpb.InitEventData{}
+	stores := make(map[uint64]struct{})
+	for _, source := range w.mu.sources {
+		if !source.inited {
+			continue
+		}
+		for _, shard := range source.shards {
+			merged.Shards = append(merged.Shards, shard.data)
+			merged.LeaderReplicaIDs = append(merged.LeaderReplicaIDs, shard.leaderID)
+			merged.Leases = append(merged.Leases, shard.lease)
+		}
+		for id, data := range source.stores {
+			if _, ok := stores[id]; ok {
+				continue
+			}
+			stores[id] = struct{}{}
+			merged.Stores = append(merged.Stores, data)
+		}
+		for _, setting := range source.settings {
+			merged.Settings = append(merged.Settings, setting)
+		}
+	}
+	return merged
+}
+
+func (w *federatedWatcher) decodeStoreID(data []byte) (uint64, bool) {
+	store := metapb.Store{}
+	if err := store.Unmarshal(data); err != nil {
+		w.logger.Error("fail to unmarshal store", zap.Error(err))
+		return 0, false
+	}
+	return store.ID, true
+}
+
+func (w *federatedWatcher) learnShard(shard metapb.Shard) {
+	if w.client != nil {
+		w.client.learnShard(shard)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prophet
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/components/prophet/util"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFederationClient records the requests, the methods not implemented
+// panic.
+type testFederationClient struct {
+	Client
+	heartbeats []uint64
+	added      []uint64
+	stores     []uint64
+	destroying []uint64
+	destroyed  *roaring64.Bitmap
+}
+
+func (c *testFederationClient) ShardHeartbeat(meta metapb.Shard, hb rpcpb.ShardHeartbeatReq) error {
+	c.heartbeats = append(c.heartbeats, meta.ID)
+	return nil
+}
+
+func (c *testFederationClient) AsyncAddShardsWithLeastPeers(shards []metapb.Shard, leastPeers []int) error {
+	for _, shard := range shards {
+		c.added = append(c.added, shard.ID)
+	}
+	return nil
+}
+
+func (c *testFederationClient) PutStore(store metapb.Store) error {
+	c.stores = append(c.stores, store.ID)
+	return nil
+}
+
+func (c *testFederationClient) GetDestroying(id uint64) (*metapb.DestroyingStatus, error) {
+	c.destroying = append(c.destroying, id)
+	return nil, nil
+}
+
+func (c *testFederationClient) CheckShardState(shards *roaring64.Bitmap) (rpcpb.CheckShardStateRsp, error) {
+	return rpcpb.CheckShardStateRsp{
+		Destroyed:  util.MustMarshalBM64(c.destroyed),
+		Destroying: util.MustMarshalBM64(roaring64.NewBitmap()),
+	}, nil
+}
+
+func newTestFederatedClient() (*federatedClient, *testFederationClient, *testFederationClient) {
+	home := &testFederationClient{destroyed: roaring64.BitmapOf(1)}
+	member := &testFederationClient{destroyed: roaring64.BitmapOf(2)}
+	c := newFederatedClient(home, []*federationMember{
+		{name: "p1", groups: []uint64{1}, client: member, close: func() {}},
+	}, nil)
+	return c, home, member
+}
+
+func TestFederatedClientRoute(t *testing.T) {
+	c, home, member := newTestFederatedClient()
+
+	assert.NoError(t, c.ShardHeartbeat(metapb.Shard{ID: 1, Group: 0}, rpcpb.ShardHeartbeatReq{}))
+	assert.NoError(t, c.ShardHeartbeat(metapb.Shard{ID: 2, Group: 1}, rpcpb.ShardHeartbeatReq{}))
+	assert.Equal(t, []uint64{1}, home.heartbeats)
+	assert.Equal(t, []uint64{2}, member.heartbeats)
+
+	assert.NoError(t, c.AsyncAddShards(metapb.Shard{ID: 3, Group: 1}, metapb.Shard{ID: 4, Group: 0},
+		metapb.Shard{ID: 5, Group: 1}))
+	assert.Equal(t, []uint64{4}, home.added)
+	assert.Equal(t, []uint64{3, 5}, member.added)
+
+	assert.NoError(t, c.PutStore(metapb.Store{ID: 10}))
+	assert.Equal(t, []uint64{10}, home.stores)
+	assert.Equal(t, []uint64{10}, member.stores)
+
+	// shard 2 is learned from the heartbeat, shard 100 is unknown
+	_, err := c.GetDestroying(2)
+	assert.NoError(t, err)
+	_, err = c.GetDestroying(100)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{2}, member.destroying)
+	assert.Equal(t, []uint64{100}, home.destroying)
+
+	rsp, err := c.CheckShardState(roaring64.BitmapOf(1, 2, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, util.MustUnmarshalBM64(rsp.Destroyed).ToArray())
+}
+
+type testFederationWatcher struct {
+	c chan rpcpb.EventNotify
+}
+
+func (w *testFederationWatcher) GetNotify() chan rpcpb.EventNotify {
+	return w.c
+}
+
+func (w *testFederationWatcher) Close() {
+	close(w.c)
+}
+
+func TestFederatedWatcherMergeInitEvent(t *testing.T) {
+	c, _, _ := newTestFederatedClient()
+	w1 := &testFederationWatcher{c: make(chan rpcpb.EventNotify)}
+	w2 := &testFederationWatcher{c: make(chan rpcpb.EventNotify)}
+	w := newFederatedWatcher([]EventWatcher{w1, w2}, c, nil)
+	defer w.Close()
+
+	newInitEvent := func(stores []uint64, shards ...metapb.Shard) rpcpb.EventNotify {
+		e := rpcpb.EventNotify{InitEvent: &rpcpb.InitEventData{}}
+		for _, id := range stores {
+			e.InitEvent.Stores = append(e.InitEvent.Stores, protoc.MustMarshal(&metapb.Store{ID: id}))
+		}
+		for _, shard := range shards {
+			e.InitEvent.Shards = append(e.InitEvent.Shards, protoc.MustMarshal(&shard))
+			e.InitEvent.LeaderReplicaIDs = append(e.InitEvent.LeaderReplicaIDs, shard.ID*10)
+			e.InitEvent.Leases = append(e.InitEvent.Leases, metapb.EpochLease{})
+		}
+		return e
+	}
+	shardIDs := func(e rpcpb.EventNotify) []uint64 {
+		var ids []uint64
+		for _, data := range e.InitEvent.Shards {
+			shard := metapb.Shard{}
+			protoc.MustUnmarshal(&shard, data)
+			ids = append(ids, shard.ID)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		return ids
+	}
+
+	w1.c <- newInitEvent([]uint64{10}, metapb.Shard{ID: 1})
+	e := <-w.GetNotify()
+	assert.Equal(t, uint64(0), e.Seq)
+	assert.Equal(t, []uint64{1}, shardIDs(e))
+
+	w2.c <- newInitEvent([]uint64{10}, metapb.Shard{ID: 2, Group: 1})
+	e = <-w.GetNotify()
+	assert.Equal(t, uint64(1), e.Seq)
+	assert.Equal(t, []uint64{1, 2}, shardIDs(e))
+	assert.Equal(t, 1, len(e.InitEvent.Stores))
+
+	w2.c <- rpcpb.EventNotify{ShardEvent: &rpcpb.ShardEventData{
+		Data: protoc.MustMarshal(&metapb.Shard{ID: 3, Group: 1}),
+	}}
+	e = <-w.GetNotify()
+	require.NotNil(t, e.ShardEvent)
+
+	// the shards of the other prophet group are kept
+	w1.c <- newInitEvent([]uint64{10})
+	e = <-w.GetNotify()
+	assert.Equal(t, []uint64{2, 3}, shardIDs(e))
+
+	// the shard group is learned from the events
+	assert.Equal(t, c.members[0].client, c.getShardClient(3))
+
+	// the cluster settings of the home prophet group are kept
+	setting := metapb.ClusterSetting{Key: "k1", Value: "v1", Version: 1}
+	e = newInitEvent(nil)
+	e.InitEvent.Settings = []metapb.ClusterSetting{setting}
+	w1.c <- e
+	<-w.GetNotify()
+	setting.Version = 2
+	w1.c <- rpcpb.EventNotify{SettingEvent: &setting}
+	<-w.GetNotify()
+	w2.c <- newInitEvent(nil)
+	e = <-w.GetNotify()
+	assert.Equal(t, []metapb.ClusterSetting{setting}, e.InitEvent.Settings)
+}
@@ -0,0 +1,40 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package id
+
+// interleavedGenerator allocate ID which is unique across multiple generators,
+// the IDs of the generator with index i are i+1, i+1+count, i+1+2*count, ...
+type interleavedGenerator struct {
+	g     Generator
+	index uint64
+	count uint64
+}
+
+// NewInterleavedGenerator returns the ID allocator of the index-th of the count
+// generators, e.g. the prophet groups of a federation.
+func NewInterleavedGenerator(g Generator, index, count uint64) Generator {
+	if index >= count {
+		panic("index must be less than count")
+	}
+	return &interleavedGenerator{g: g, index: index, count: count}
+}
+
+// AllocID allocs a unique id.
+func (alloc *interleavedGenerator) AllocID() (uint64, error) {
+	id, err := alloc.g.AllocID()
+	if err != nil {
+		return UninitializedID, err
+	}
+	return (id-1)*alloc.count + alloc.index + 1, nil
+}
@@ -52,3 +52,18 @@ func TestAllocID(t *testing.T) {
 		assert.Equal(t, i, id)
 	}
 }
+
+func TestInterleavedAllocID(t *testing.T) {
+	ids := make(map[uint64]struct{})
+	for i := uint64(0); i < 3; i++ {
+		allocator := NewInterleavedGenerator(NewMemGenerator(), i, 3)
+		for j := 0; j < 10; j++ {
+			id, err := allocator.AllocID()
+			assert.NoError(t, err)
+			assert.Equal(t, i, (id-1)%3)
+			_, ok := ids[id]
+			assert.False(t, ok)
+			ids[id] = struct{}{}
+		}
+	}
+}
@@ -22,6 +22,16 @@ func IsLearner(peer metapb.Replica) bool {
 	return peer.Role == metapb.ReplicaRole_Learner
 }
 
+// IsWitness judges whether the Peer's Role is Witness.
+func IsWitness(peer metapb.Replica) bool {
+	return peer.Role == metapb.ReplicaRole_Witness
+}
+
+// IsObserver judges whether the Peer's Role is Observer.
+func IsObserver(peer metapb.Replica) bool {
+	return peer.Role == metapb.ReplicaRole_Observer
+}
+
 // IsVoterOrIncomingVoter judges whether peer role will become Voter.
 // The peer is not nil and the role is equal to IncomingVoter or Voter.
 func IsVoterOrIncomingVoter(peer metapb.Replica) bool {
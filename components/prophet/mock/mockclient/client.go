@@ -146,6 +146,21 @@ func (mr *MockClientMockRecorder) CheckShardState(resources interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckShardState", reflect.TypeOf((*MockClient)(nil).CheckShardState), resources)
 }
 
+// CheckTombstoneReplicas mocks base method.
+func (m *MockClient) CheckTombstoneReplicas(replicas []rpcpb.TombstoneReplica) (*roaring64.Bitmap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckTombstoneReplicas", replicas)
+	ret0, _ := ret[0].(*roaring64.Bitmap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckTombstoneReplicas indicates an expected call of CheckTombstoneReplicas.
+func (mr *MockClientMockRecorder) CheckTombstoneReplicas(replicas interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckTombstoneReplicas", reflect.TypeOf((*MockClient)(nil).CheckTombstoneReplicas), replicas)
+}
+
 // Close mocks base method.
 func (m *MockClient) Close() error {
 	m.ctrl.T.Helper()
@@ -249,6 +264,21 @@ func (mr *MockClientMockRecorder) GetSchedulingRules() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedulingRules", reflect.TypeOf((*MockClient)(nil).GetSchedulingRules))
 }
 
+// GetClusterSettings mocks base method.
+func (m *MockClient) GetClusterSettings() ([]metapb.ClusterSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusterSettings")
+	ret0, _ := ret[0].([]metapb.ClusterSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClusterSettings indicates an expected call of GetClusterSettings.
+func (mr *MockClientMockRecorder) GetClusterSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterSettings", reflect.TypeOf((*MockClient)(nil).GetClusterSettings))
+}
+
 // GetShardHeartbeatRspNotifier mocks base method.
 func (m *MockClient) GetShardHeartbeatRspNotifier() (chan rpcpb.ShardHeartbeatRsp, error) {
 	m.ctrl.T.Helper()
@@ -294,6 +324,21 @@ func (mr *MockClientMockRecorder) NewWatcher(flag interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWatcher", reflect.TypeOf((*MockClient)(nil).NewWatcher), flag)
 }
 
+// PutClusterSetting mocks base method.
+func (m *MockClient) PutClusterSetting(arg0, arg1 string) (metapb.ClusterSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutClusterSetting", arg0, arg1)
+	ret0, _ := ret[0].(metapb.ClusterSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutClusterSetting indicates an expected call of PutClusterSetting.
+func (mr *MockClientMockRecorder) PutClusterSetting(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutClusterSetting", reflect.TypeOf((*MockClient)(nil).PutClusterSetting), arg0, arg1)
+}
+
 // PutPlacementRule mocks base method.
 func (m *MockClient) PutPlacementRule(rule rpcpb.PlacementRule) error {
 	m.ctrl.T.Helper()
@@ -351,6 +396,21 @@ func (mr *MockClientMockRecorder) ReportDestroyed(id, replicaID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportDestroyed", reflect.TypeOf((*MockClient)(nil).ReportDestroyed), id, replicaID)
 }
 
+// ScatterShards mocks base method.
+func (m *MockClient) ScatterShards(group uint64, start, end []byte) (rpcpb.ScatterShardsRsp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScatterShards", group, start, end)
+	ret0, _ := ret[0].(rpcpb.ScatterShardsRsp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScatterShards indicates an expected call of ScatterShards.
+func (mr *MockClientMockRecorder) ScatterShards(group, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScatterShards", reflect.TypeOf((*MockClient)(nil).ScatterShards), group, start, end)
+}
+
 // ShardHeartbeat mocks base method.
 func (m *MockClient) ShardHeartbeat(meta metapb.Shard, hb rpcpb.ShardHeartbeatReq) error {
 	m.ctrl.T.Helper()
@@ -195,6 +195,18 @@ func (mc *Cluster) SetStoreBusy(containerID uint64, busy bool) {
 	mc.PutStore(newStore)
 }
 
+// SetStoreDiskFailed sets container disk failed.
+func (mc *Cluster) SetStoreDiskFailed(containerID uint64, failed bool) {
+	container := mc.GetStore(containerID)
+	newStats := proto.Clone(container.GetStoreStats()).(*metapb.StoreStats)
+	newStats.DiskFailed = failed
+	newStore := container.Clone(
+		core.SetStoreStats(newStats),
+		core.SetLastHeartbeatTS(time.Now()),
+	)
+	mc.PutStore(newStore)
+}
+
 // AddLeaderStore adds container with specified count of leader.
 func (mc *Cluster) AddLeaderStore(containerID uint64, leaderCount int, leaderSizes ...int64) {
 	stats := &metapb.StoreStats{}
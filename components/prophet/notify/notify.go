@@ -0,0 +1,210 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/util/uuid"
+	"go.uber.org/zap"
+)
+
+// EventType the type of the event
+type EventType string
+
+const (
+	// StoreDown the store has not sent heartbeat for max-store-down-time
+	StoreDown EventType = "store-down"
+	// ShardUnavailable the majority of the voters of the shard are down
+	ShardUnavailable EventType = "shard-unavailable"
+	// ShardQuorumLost the surviving replicas of the shard report the shard has
+	// no leader and lost the write quorum
+	ShardQuorumLost EventType = "shard-quorum-lost"
+	// ShardInconsistent the data checksum of a replica diverges from the
+	// checksum of the leader at the same applied index
+	ShardInconsistent EventType = "shard-inconsistent"
+	// UnsafeRecoveryPerformed the replicas are removed by the unsafe recovery,
+	// the data of the shard may be lost
+	UnsafeRecoveryPerformed EventType = "unsafe-recovery-performed"
+	// BackupFinished the backup is finished
+	BackupFinished EventType = "backup-finished"
+)
+
+// Event is a significant event of the cluster sent to the sinks
+type Event struct {
+	// ID the unique id of the event, the receivers can use it to drop the
+	// duplicated deliveries caused by the retries
+	ID        string    `json:"id"`
+	Type      EventType `json:"type"`
+	ClusterID uint64    `json:"cluster-id"`
+	Time      time.Time `json:"time"`
+	StoreID   uint64    `json:"store-id,omitempty"`
+	ShardID   uint64    `json:"shard-id,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	// Details the event specific details, e.g. the address of the store
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Sink sends the events to the external systems, e.g. a http endpoint or a
+// message bus. The failed sends are retried by the Notifier.
+type Sink interface {
+	// Name returns the name of the sink used in the logs
+	Name() string
+	// Send sends the event, the ctx is cancelled if the notifier is stopped
+	Send(ctx context.Context, e Event) error
+}
+
+// Options the options of the Notifier
+type Options struct {
+	// ClusterID the id of the cluster filled into the events
+	ClusterID uint64
+	// QueueSize the max number of the pending events of each sink, the new
+	// events are dropped if the queue is full
+	QueueSize int
+	// MaxRetries the max times to retry a failed send
+	MaxRetries int
+	// RetryInterval the interval before the first retry, it's doubled for each
+	// retry
+	RetryInterval time.Duration
+}
+
+// Notifier sends the events to the sinks asynchronously. Each sink has its own
+// queue and goroutine, so a slow or failing sink doesn't delay the others.
+type Notifier struct {
+	logger  *zap.Logger
+	opts    Options
+	workers []*sinkWorker
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type sinkWorker struct {
+	sink  Sink
+	queue chan Event
+}
+
+// NewNotifier returns a Notifier sending the events to the sinks
+func NewNotifier(opts Options, sinks []Sink, logger *zap.Logger) *Notifier {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1
+	}
+	n := &Notifier{
+		logger: log.Adjust(logger).Named("notifier"),
+		opts:   opts,
+	}
+	for _, sink := range sinks {
+		n.workers = append(n.workers, &sinkWorker{
+			sink:  sink,
+			queue: make(chan Event, opts.QueueSize),
+		})
+	}
+	return n
+}
+
+// Start starts the goroutines sending the events
+func (n *Notifier) Start() {
+	if n == nil {
+		return
+	}
+	n.ctx, n.cancel = context.WithCancel(context.Background())
+	for _, w := range n.workers {
+		n.wg.Add(1)
+		go n.run(w)
+	}
+}
+
+// Stop stops the notifier, the pending events are dropped
+func (n *Notifier) Stop() {
+	if n == nil || n.cancel == nil {
+		return
+	}
+	n.cancel()
+	n.wg.Wait()
+}
+
+// Notify queues the event to all the sinks without blocking. The ID, cluster
+// id and time are filled if not set. It's safe to call on a nil Notifier.
+func (n *Notifier) Notify(e Event) {
+	if n == nil || len(n.workers) == 0 {
+		return
+	}
+	if e.ID == "" {
+		e.ID = uuid.NewID()
+	}
+	if e.ClusterID == 0 {
+		e.ClusterID = n.opts.ClusterID
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	for _, w := range n.workers {
+		select {
+		case w.queue <- e:
+		default:
+			n.logger.Warn("event dropped, too many pending events",
+				zap.String("sink", w.sink.Name()),
+				zap.String("event", string(e.Type)),
+				zap.String("id", e.ID))
+		}
+	}
+}
+
+func (n *Notifier) run(w *sinkWorker) {
+	defer n.wg.Done()
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case e := <-w.queue:
+			n.send(w.sink, e)
+		}
+	}
+}
+
+func (n *Notifier) send(sink Sink, e Event) {
+	interval := n.opts.RetryInterval
+	for i := 0; ; i++ {
+		err := sink.Send(n.ctx, e)
+		if err == nil {
+			return
+		}
+		if i >= n.opts.MaxRetries {
+			n.logger.Error("fail to send event",
+				zap.String("sink", sink.Name()),
+				zap.String("event", string(e.Type)),
+				zap.String("id", e.ID),
+				zap.Int("retries", i),
+				zap.Error(err))
+			return
+		}
+
+		n.logger.Warn("fail to send event, retry later",
+			zap.String("sink", sink.Name()),
+			zap.String("event", string(e.Type)),
+			zap.String("id", e.ID),
+			zap.Duration("after", interval),
+			zap.Error(err))
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSink struct {
+	failures int32
+	sends    int32
+	c        chan Event
+}
+
+func (s *testSink) Name() string { return "test" }
+
+func (s *testSink) Send(ctx context.Context, e Event) error {
+	if atomic.AddInt32(&s.sends, 1) <= s.failures {
+		return errors.New("failed")
+	}
+	s.c <- e
+	return nil
+}
+
+func TestNotifierRetry(t *testing.T) {
+	sink := &testSink{failures: 2, c: make(chan Event, 1)}
+	n := NewNotifier(Options{ClusterID: 1, QueueSize: 8, MaxRetries: 2, RetryInterval: time.Millisecond},
+		[]Sink{sink}, nil)
+	n.Start()
+	defer n.Stop()
+
+	n.Notify(Event{Type: StoreDown, StoreID: 2})
+	select {
+	case e := <-sink.c:
+		assert.Equal(t, StoreDown, e.Type)
+		assert.Equal(t, uint64(1), e.ClusterID)
+		assert.Equal(t, uint64(2), e.StoreID)
+		assert.NotEmpty(t, e.ID)
+		assert.False(t, e.Time.IsZero())
+	case <-time.After(time.Second * 10):
+		assert.FailNow(t, "timeout")
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&sink.sends))
+}
+
+func TestNotifierGiveUp(t *testing.T) {
+	sink := &testSink{failures: 2, c: make(chan Event, 2)}
+	n := NewNotifier(Options{QueueSize: 8, MaxRetries: 1, RetryInterval: time.Millisecond},
+		[]Sink{sink}, nil)
+	n.Start()
+	defer n.Stop()
+
+	n.Notify(Event{Type: StoreDown, StoreID: 1})
+	n.Notify(Event{Type: StoreDown, StoreID: 2})
+	select {
+	case e := <-sink.c:
+		assert.Equal(t, uint64(2), e.StoreID)
+	case <-time.After(time.Second * 10):
+		assert.FailNow(t, "timeout")
+	}
+}
+
+func TestNilNotifier(t *testing.T) {
+	var n *Notifier
+	n.Start()
+	n.Notify(Event{Type: StoreDown})
+	n.Stop()
+}
+
+func TestWebhookSink(t *testing.T) {
+	secret := []byte("secret")
+	received := make(chan Event, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		if !Verify(secret, r.Header.Get(HeaderTimestamp), body, r.Header.Get(HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var e Event
+		require.NoError(t, json.Unmarshal(body, &e))
+		assert.Equal(t, string(e.Type), r.Header.Get(HeaderEvent))
+		assert.Equal(t, e.ID, r.Header.Get(HeaderDelivery))
+		received <- e
+	}))
+	defer ts.Close()
+
+	s := NewWebhookSink(ts.URL, string(secret), []EventType{ShardUnavailable}, time.Second)
+	// not subscribed
+	assert.NoError(t, s.Send(context.Background(), Event{ID: "1", Type: StoreDown}))
+	assert.NoError(t, s.Send(context.Background(), Event{ID: "2", Type: ShardUnavailable, ShardID: 1}))
+	e := <-received
+	assert.Equal(t, "2", e.ID)
+	assert.Equal(t, uint64(1), e.ShardID)
+
+	s = NewWebhookSink(ts.URL, "bad secret", nil, time.Second)
+	assert.Error(t, s.Send(context.Background(), Event{ID: "3", Type: StoreDown}))
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// HeaderEvent the header of the event type
+	HeaderEvent = "X-Cube-Event"
+	// HeaderDelivery the header of the event id
+	HeaderDelivery = "X-Cube-Delivery"
+	// HeaderTimestamp the header of the unix time when the request is signed
+	HeaderTimestamp = "X-Cube-Timestamp"
+	// HeaderSignature the header of the signature, sha256=<hex hmac>
+	HeaderSignature = "X-Cube-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// WebhookSink posts the events as json to a http endpoint. If the secret is
+// set, the requests are signed by HMAC-SHA256 of "<timestamp>.<body>", the
+// receivers can check them by Verify.
+type WebhookSink struct {
+	url    string
+	secret []byte
+	events map[EventType]struct{}
+	client *http.Client
+}
+
+// NewWebhookSink returns a WebhookSink, only the events in the events are sent,
+// empty events means all events.
+func NewWebhookSink(url, secret string, events []EventType, timeout time.Duration) *WebhookSink {
+	s := &WebhookSink{
+		url:    url,
+		secret: []byte(secret),
+		client: &http.Client{Timeout: timeout},
+	}
+	if len(events) > 0 {
+		s.events = make(map[EventType]struct{}, len(events))
+		for _, e := range events {
+			s.events[e] = struct{}{}
+		}
+	}
+	return s
+}
+
+// Name implements Sink
+func (s *WebhookSink) Name() string {
+	return "webhook:" + s.url
+}
+
+// Send implements Sink
+func (s *WebhookSink) Send(ctx context.Context, e Event) error {
+	if s.events != nil {
+		if _, ok := s.events[e.Type]; !ok {
+			return nil
+		}
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(e.Type))
+	req.Header.Set(HeaderDelivery, e.ID)
+	if len(s.secret) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(HeaderTimestamp, timestamp)
+		req.Header.Set(HeaderSignature, Sign(s.secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body to reuse the connection
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returns %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the signature of the body signed at the timestamp
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of the webhook request body
+func Verify(secret []byte, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
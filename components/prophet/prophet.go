@@ -189,6 +189,9 @@ func (p *defaultProphet) Start() {
 
 	kv := storage.NewEtcdKV(rootPath, p.elector.Client(), p.member.GetLeadership())
 	idGenerator := id.NewEtcdGenerator(rootPath, p.elector.Client(), p.member.GetLeadership())
+	if federation := p.cfg.Prophet.Federation; federation.Enabled() {
+		idGenerator = id.NewInterleavedGenerator(idGenerator, federation.Index, federation.Size())
+	}
 	p.storage = storage.NewStorage(rootPath, kv, idGenerator)
 	p.logger.Info("storage created")
 
@@ -152,6 +152,12 @@ func (p *defaultProphet) handleRPCRequest(rs goetty.IOSession, data interface{},
 		if err != nil {
 			resp.Error = err.Error()
 		}
+	case rpcpb.TypeCheckTombstoneReplicasReq:
+		resp.Type = rpcpb.TypeCheckTombstoneReplicasRsp
+		err := p.handleCheckTombstoneReplicas(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
 	case rpcpb.TypePutPlacementRuleReq:
 		resp.Type = rpcpb.TypePutPlacementRuleRsp
 		err := p.handlePutPlacementRule(rc, req, resp)
@@ -194,6 +200,24 @@ func (p *defaultProphet) handleRPCRequest(rs goetty.IOSession, data interface{},
 		if err != nil {
 			resp.Error = err.Error()
 		}
+	case rpcpb.TypePutClusterSettingReq:
+		resp.Type = rpcpb.TypePutClusterSettingRsp
+		err := p.handlePutClusterSetting(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	case rpcpb.TypeGetClusterSettingsReq:
+		resp.Type = rpcpb.TypeGetClusterSettingsRsp
+		err := p.handleGetClusterSettings(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+	case rpcpb.TypeScatterShardsReq:
+		resp.Type = rpcpb.TypeScatterShardsRsp
+		err := p.handleScatterShards(rc, req, resp)
+		if err != nil {
+			resp.Error = err.Error()
+		}
 	default:
 		return fmt.Errorf("type %s not support", req.Type.String())
 	}
@@ -377,6 +401,16 @@ func (p *defaultProphet) handleCheckShardState(rc *cluster.RaftCluster, req *rpc
 	return nil
 }
 
+func (p *defaultProphet) handleCheckTombstoneReplicas(rc *cluster.RaftCluster, req *rpcpb.ProphetRequest, resp *rpcpb.ProphetResponse) error {
+	rsp, err := rc.HandleCheckTombstoneReplicas(req)
+	if err != nil {
+		return err
+	}
+
+	resp.CheckTombstoneReplicas = *rsp
+	return nil
+}
+
 func (p *defaultProphet) handlePutPlacementRule(rc *cluster.RaftCluster, req *rpcpb.ProphetRequest, resp *rpcpb.ProphetResponse) error {
 	return rc.HandlePutPlacementRule(req)
 }
@@ -408,6 +442,34 @@ func (p *defaultProphet) handleGetScheduleGroupRule(rc *cluster.RaftCluster, req
 	return nil
 }
 
+func (p *defaultProphet) handlePutClusterSetting(rc *cluster.RaftCluster, req *rpcpb.ProphetRequest, resp *rpcpb.ProphetResponse) error {
+	setting, err := rc.HandlePutClusterSetting(req)
+	if err != nil {
+		return err
+	}
+	resp.PutClusterSetting.Setting = setting
+	return nil
+}
+
+func (p *defaultProphet) handleGetClusterSettings(rc *cluster.RaftCluster, req *rpcpb.ProphetRequest, resp *rpcpb.ProphetResponse) error {
+	settings, err := rc.HandleGetClusterSettings(req)
+	if err != nil {
+		return err
+	}
+	resp.GetClusterSettings.Settings = settings
+	return nil
+}
+
+func (p *defaultProphet) handleScatterShards(rc *cluster.RaftCluster, req *rpcpb.ProphetRequest, resp *rpcpb.ProphetResponse) error {
+	rsp, err := rc.HandleScatterShards(req)
+	if err != nil {
+		return err
+	}
+
+	resp.ScatterShards = *rsp
+	return nil
+}
+
 // checkStore returns an error response if the store exists and is in tombstone state.
 // It returns nil if it can't get the store.
 func checkStore(rc *cluster.RaftCluster, storeID uint64) error {
@@ -94,5 +94,22 @@ func (p *defaultProphet) initClient() {
 			WithRPCTimeout(p.cfg.Prophet.RPCTimeout.Duration),
 			WithLeaderGetter(p.GetLeader),
 			WithLogger(p.logger))
+		if p.cfg.Prophet.Federation.Enabled() {
+			p.client = p.newFederatedClient(p.client)
+		}
 	})
 }
+
+func (p *defaultProphet) newFederatedClient(home Client) Client {
+	var members []*federationMember
+	for _, cfg := range p.cfg.Prophet.Federation.Members {
+		m, err := newFederationMember(cfg, &p.cfg.Prophet, p.logger)
+		if err != nil {
+			p.logger.Fatal("fail to connect to federation member",
+				zap.String("member", cfg.Name),
+				zap.Error(err))
+		}
+		members = append(members, m)
+	}
+	return newFederatedClient(home, members, p.logger)
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/opt"
+	"go.uber.org/zap"
+)
+
+// IsEmptyShard returns true if the shard has no key and the size is less than
+// 1MB, e.g. all the data is removed by the DeleteRange of the TTL GC. The shards
+// without any heartbeat have no approximate size, they are not considered as
+// empty.
+func IsEmptyShard(res *core.CachedShard) bool {
+	size := res.GetApproximateSize()
+	return size > 0 && size <= core.MinShardSize && res.GetApproximateKeys() == 0
+}
+
+// EmptyShardChecker marks the empty shards after the mass deletions. The merge
+// checker merges the empty shards into the adjacent shards regardless of the
+// size of the targets, the marked shards which cannot be merged are the
+// candidates of the shard pools, so the shard count is not bloated by the
+// lifecycle of the data.
+type EmptyShardChecker struct {
+	cluster opt.Cluster
+
+	mu struct {
+		sync.RWMutex
+		// shards the time when the shard is found empty
+		shards map[uint64]time.Time
+	}
+}
+
+// NewEmptyShardChecker creates an empty shard checker.
+func NewEmptyShardChecker(cluster opt.Cluster) *EmptyShardChecker {
+	c := &EmptyShardChecker{cluster: cluster}
+	c.mu.shards = make(map[uint64]time.Time)
+	return c
+}
+
+// GetType return EmptyShardChecker's type
+func (c *EmptyShardChecker) GetType() string {
+	return "empty-shard-checker"
+}
+
+// Check marks the shard if it's empty, the mark is removed once the shard is
+// written again or destroyed. It returns true if the shard is empty.
+func (c *EmptyShardChecker) Check(res *core.CachedShard) bool {
+	checkerCounter.WithLabelValues("empty_shard_checker", "check").Inc()
+	id := res.Meta.GetID()
+	empty := !res.IsDestroyState() && IsEmptyShard(res)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, marked := c.mu.shards[id]
+	if empty && !marked {
+		checkerCounter.WithLabelValues("empty_shard_checker", "new-empty").Inc()
+		c.mu.shards[id] = time.Now()
+		c.cluster.GetLogger().Info("empty shard found",
+			zap.Uint64("shard", id))
+	} else if !empty && marked {
+		delete(c.mu.shards, id)
+	}
+	return empty
+}
+
+// GetEmptyShards returns the IDs of the marked empty shards in ascending order,
+// the shards removed from the cluster are unmarked.
+func (c *EmptyShardChecker) GetEmptyShards() []uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	shards := make([]uint64, 0, len(c.mu.shards))
+	for id := range c.mu.shards {
+		if res := c.cluster.GetShard(id); res == nil || res.IsDestroyState() {
+			delete(c.mu.shards, id)
+			continue
+		}
+		shards = append(shards, id)
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+	return shards
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmptyShardChecker(t *testing.T) {
+	s := &testMergeChecker{}
+	s.setup()
+	defer s.tearDown()
+
+	c := NewEmptyShardChecker(s.cluster)
+	for _, res := range s.resources {
+		assert.False(t, c.Check(res))
+	}
+	assert.Empty(t, c.GetEmptyShards())
+
+	// no heartbeat received
+	assert.False(t, c.Check(s.resources[2].Clone(core.SetApproximateSize(0), core.SetApproximateKeys(0))))
+
+	emptied := s.resources[2].Clone(core.SetApproximateKeys(0))
+	s.cluster.PutShard(emptied)
+	assert.True(t, c.Check(emptied))
+	assert.True(t, c.Check(emptied))
+	assert.Equal(t, []uint64{3}, c.GetEmptyShards())
+
+	// written again
+	assert.False(t, c.Check(s.resources[2]))
+	assert.Empty(t, c.GetEmptyShards())
+
+	// removed from the cluster
+	assert.True(t, c.Check(emptied))
+	s.cluster.RemoveShard(emptied)
+	assert.Empty(t, c.GetEmptyShards())
+}
+
+func TestMergeEmptyShardIntoLargeTarget(t *testing.T) {
+	s := &testMergeChecker{}
+	s.setup()
+	defer s.tearDown()
+
+	s.cluster.SetSplitMergeInterval(0)
+	s.cluster.PutShard(s.resources[1].Clone(core.SetApproximateSize(600)))
+	assert.Empty(t, s.mc.Check(s.resources[2]))
+
+	ops := s.mc.Check(s.resources[2].Clone(core.SetApproximateKeys(0)))
+	assert.NotEmpty(t, ops)
+	assert.Equal(t, s.resources[2].Meta.GetID(), ops[0].ShardID())
+	assert.Equal(t, s.resources[1].Meta.GetID(), ops[1].ShardID())
+}
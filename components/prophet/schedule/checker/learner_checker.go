@@ -16,6 +16,7 @@ package checker
 
 import (
 	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/metadata"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/opt"
 	"go.uber.org/zap"
@@ -36,6 +37,10 @@ func NewLearnerChecker(cluster opt.Cluster) *LearnerChecker {
 // Check verifies a resource's role, creating an Operator if need.
 func (l *LearnerChecker) Check(res *core.CachedShard) *operator.Operator {
 	for _, p := range res.GetLearners() {
+		// the observer is never promoted
+		if metadata.IsObserver(p) {
+			continue
+		}
 		op, err := operator.CreatePromoteLearnerOperator("promote-learner", l.cluster, res, p)
 		if err != nil {
 			l.cluster.GetLogger().Debug("fail to create promote learner operator",
@@ -56,3 +56,23 @@ func TestPromoteLearner(t *testing.T) {
 	op = lc.Check(resource)
 	assert.Nil(t, op)
 }
+
+func TestObserverNotPromoted(t *testing.T) {
+	cluster := mockcluster.NewCluster(config.NewTestOptions())
+	lc := NewLearnerChecker(cluster)
+	for id := uint64(1); id <= 10; id++ {
+		cluster.PutStoreWithLabels(id)
+	}
+
+	resource := core.NewCachedShard(
+		metapb.Shard{
+			ID: 1,
+			Replicas: []metapb.Replica{
+				{ID: 101, StoreID: 1},
+				{ID: 102, StoreID: 2},
+				{ID: 103, StoreID: 3, Role: metapb.ReplicaRole_Observer},
+			},
+		}, &metapb.Replica{ID: 101, StoreID: 1})
+	assert.Equal(t, 2, len(resource.GetVoters()))
+	assert.Nil(t, lc.Check(resource))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/opt"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/placement"
 	"github.com/matrixorigin/matrixcube/components/prophet/util"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"go.uber.org/zap"
 )
 
@@ -73,6 +74,7 @@ func (m *MergeChecker) Check(res *core.CachedShard) []*operator.Operator {
 
 	if m.splitCache.Exists(res.Meta.GetID()) {
 		checkerCounter.WithLabelValues("merge_checker", "recently-split").Inc()
+		m.blocked(res, "recently split")
 		return nil
 	}
 
@@ -91,17 +93,20 @@ func (m *MergeChecker) Check(res *core.CachedShard) []*operator.Operator {
 	if res.GetApproximateSize() > int64(m.opts.GetMaxMergeShardSize()) ||
 		res.GetApproximateKeys() > int64(m.opts.GetMaxMergeShardKeys()) {
 		checkerCounter.WithLabelValues("merge_checker", "no-need").Inc()
+		m.unblocked(res)
 		return nil
 	}
 
 	// skip resource has down peers or pending peers or learner peers
 	if !opt.IsShardHealthy(m.cluster, res) {
 		checkerCounter.WithLabelValues("merge_checker", "special-peer").Inc()
+		m.blocked(res, "has down, pending or learner peers")
 		return nil
 	}
 
 	if !opt.IsShardReplicated(m.cluster, res) {
 		checkerCounter.WithLabelValues("merge_checker", "abnormal-replica").Inc()
+		m.blocked(res, "abnormal replicas")
 		return nil
 	}
 
@@ -119,11 +124,14 @@ func (m *MergeChecker) Check(res *core.CachedShard) []*operator.Operator {
 
 	if target == nil {
 		checkerCounter.WithLabelValues("merge_checker", "no-target").Inc()
+		m.blocked(res, "no adjacent shard can be merged")
 		return nil
 	}
 
-	if target.GetApproximateSize() > maxTargetShardSize {
+	// the empty shard doesn't grow the target
+	if target.GetApproximateSize() > maxTargetShardSize && !IsEmptyShard(res) {
 		checkerCounter.WithLabelValues("merge_checker", "target-too-large").Inc()
+		m.blocked(res, "target shard too large")
 		return nil
 	}
 
@@ -135,9 +143,11 @@ func (m *MergeChecker) Check(res *core.CachedShard) []*operator.Operator {
 	if err != nil {
 		m.cluster.GetLogger().Warn("fail to create merge resource operator",
 			zap.Error(err))
+		m.blocked(res, err.Error())
 		return nil
 	}
 	checkerCounter.WithLabelValues("merge_checker", "new-operator").Inc()
+	m.unblocked(res)
 	if res.GetApproximateSize() > target.GetApproximateSize() ||
 		res.GetApproximateKeys() > target.GetApproximateKeys() {
 		checkerCounter.WithLabelValues("merge_checker", "larger-source").Inc()
@@ -145,6 +155,25 @@ func (m *MergeChecker) Check(res *core.CachedShard) []*operator.Operator {
 	return ops
 }
 
+// blocked records the reason why the shard cannot be merged, if the cluster
+// keeps the blocking reasons of the shards.
+func (m *MergeChecker) blocked(res *core.CachedShard, reason string) {
+	if cl, ok := m.cluster.(withBlockingReasons); ok {
+		cl.RecordBlockingReason(res.Meta.GetID(), metapb.ShardOperation_Merge, reason)
+	}
+}
+
+func (m *MergeChecker) unblocked(res *core.CachedShard) {
+	if cl, ok := m.cluster.(withBlockingReasons); ok {
+		cl.ClearBlockingReason(res.Meta.GetID(), metapb.ShardOperation_Merge)
+	}
+}
+
+type withBlockingReasons interface {
+	RecordBlockingReason(shardID uint64, op metapb.ShardOperation, reason string)
+	ClearBlockingReason(shardID uint64, op metapb.ShardOperation)
+}
+
 func (m *MergeChecker) checkTarget(region, adjacent *core.CachedShard) bool {
 	return adjacent != nil && !m.splitCache.Exists(adjacent.Meta.GetID()) &&
 		AllowMerge(m.cluster, region, adjacent) && opt.IsShardHealthy(m.cluster, adjacent) &&
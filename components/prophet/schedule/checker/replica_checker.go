@@ -29,11 +29,13 @@ import (
 
 const (
 	replicaCheckerName = "replica-checker"
+	ruleCheckerName    = "rule-checker"
 )
 
 const (
-	offlineStatus = "offline"
-	downStatus    = "down"
+	offlineStatus    = "offline"
+	downStatus       = "down"
+	diskFailedStatus = "disk-failed"
 )
 
 // ReplicaChecker ensures resource has the best replicas.
@@ -83,6 +85,9 @@ func (r *ReplicaChecker) FillReplicas(res *core.CachedShard, leastPeers int) err
 		peers = append(peers, metapb.Replica{StoreID: container})
 		res.Meta.SetReplicas(peers)
 	}
+	if len(res.Meta.GetReplicas()) == 0 {
+		return noStoreToAddError("no container to add peers", rs.ResourceLimitReasons())
+	}
 
 	if (leastPeers == 0 && len(res.Meta.GetReplicas()) == r.opts.GetMaxReplicas()) || // all peers matches
 		(leastPeers > 0 && len(res.Meta.GetReplicas()) == leastPeers) { // least peers matches
@@ -168,6 +173,9 @@ func (r *ReplicaChecker) checkOfflinePeer(res *core.CachedShard) *operator.Opera
 				zap.Uint64("container", containerID))
 			return nil
 		}
+		if container.IsDiskFailed() {
+			return r.fixPeer(res, containerID, diskFailedStatus)
+		}
 		if container.IsUp() {
 			continue
 		}
@@ -193,10 +201,12 @@ func (r *ReplicaChecker) checkMakeUpReplica(res *core.CachedShard) *operator.Ope
 		log.ResourceField(res.Meta.GetID()),
 		zap.Int("peers", len(res.Meta.GetReplicas())))
 	resourceStores := r.cluster.GetShardStores(res)
-	target := r.strategy(res).SelectStoreToAdd(resourceStores)
+	rs := r.strategy(res)
+	target := rs.SelectStoreToAdd(resourceStores)
 	if target == 0 {
 		r.cluster.GetLogger().Debug("no container to add replica for resource",
-			log.ResourceField(res.Meta.GetID()))
+			log.ResourceField(res.Meta.GetID()),
+			zap.Strings("resource-limits", rs.ResourceLimitReasons()))
 		checkerCounter.WithLabelValues("replica_checker", "no-target-container").Inc()
 		r.resourceWaitingList.Put(res.Meta.GetID(), nil)
 		return nil
@@ -205,6 +205,30 @@ func TestFillReplicas(t *testing.T) {
 	assert.Equal(t, rc.cluster.GetOpts().GetMaxReplicas(), len(res.Meta.GetReplicas()))
 }
 
+func TestFillReplicasWithResourceLimit(t *testing.T) {
+	opt := config.NewTestOptions()
+	tc := mockcluster.NewCluster(opt)
+	rc := NewReplicaChecker(tc, cache.NewDefaultCache(10))
+
+	tc.AddShardStore(1, 1)
+	tc.AddShardStore(2, 1)
+	tc.AddShardStore(3, 1)
+	for id := uint64(1); id <= 3; id++ {
+		opt.SetStoreResourceLimit(id, config.StoreResourceLimitConfig{MaxReplicas: 1})
+	}
+
+	res := core.NewTestCachedShard(nil, nil)
+	err := rc.FillReplicas(res, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "max-replicas")
+	assert.Empty(t, res.Meta.GetReplicas())
+
+	// only the containers under the limits are selected
+	opt.SetStoreResourceLimit(2, config.StoreResourceLimitConfig{MaxReplicas: 2})
+	assert.NoError(t, rc.FillReplicas(res, 0))
+	assert.Equal(t, map[uint64]struct{}{2: {}}, res.GetStoreIDs())
+}
+
 func TestDownPeer(t *testing.T) {
 	s := &testReplicaChecker{}
 	s.setup()
@@ -15,6 +15,10 @@
 package checker
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/components/prophet/core"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/filter"
@@ -80,6 +84,30 @@ func (s *ReplicaStrategy) SelectStoreToAdd(coLocationStores []*core.CachedStore,
 	return target.Meta.GetID()
 }
 
+// ResourceLimitReasons returns the reasons why the containers can't be selected
+// to add a replica to the resource because of their resource limits. It's used
+// to report the placement failures.
+func (s *ReplicaStrategy) ResourceLimitReasons() []string {
+	var reasons []string
+	for _, container := range s.cluster.GetStores() {
+		if _, ok := s.resource.GetStorePeer(container.Meta.GetID()); ok || container.IsTombstone() {
+			continue
+		}
+		if reason := filter.ReplicaLimitReason(s.cluster.GetOpts(), container); reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
+func noStoreToAddError(msg string, limitReasons []string) error {
+	if len(limitReasons) == 0 {
+		return errors.New(msg)
+	}
+	return fmt.Errorf("%s, blocked by the container resource limits: %s",
+		msg, strings.Join(limitReasons, "; "))
+}
+
 // SelectStoreToReplace returns a container to replace oldStore. The location
 // placement after scheduling should be not worse than original.
 func (s *ReplicaStrategy) SelectStoreToReplace(coLocationStores []*core.CachedStore, old uint64) uint64 {
@@ -90,6 +118,79 @@ func (s *ReplicaStrategy) SelectStoreToReplace(coLocationStores []*core.CachedSt
 	return s.SelectStoreToAdd(coLocationStores[1:], safeGuard)
 }
 
+// HasStoreToReplace returns true if there is a container which can be used to
+// replace the replica of the resource on the old container without breaking the
+// location constraints. The placement rules are used if enabled. Unlike
+// SelectStoreToReplace, the temporary states of the containers are ignored, so
+// it's used to find the replicas which can never be moved until the placement
+// configuration is changed.
+func HasStoreToReplace(cluster opt.Cluster, res *core.CachedShard, old uint64) bool {
+	opts := cluster.GetOpts()
+	if !opts.IsPlacementRulesEnabled() {
+		s := &ReplicaStrategy{
+			checkerName:    replicaCheckerName,
+			cluster:        cluster,
+			locationLabels: opts.GetLocationLabels(),
+			isolationLevel: opts.GetIsolationLevel(),
+			resource:       res,
+		}
+		return s.hasStoreToReplace(cluster.GetShardStores(res), old)
+	}
+
+	for _, rf := range cluster.FitShard(res).RuleFits {
+		var ruleStores []*core.CachedStore
+		found := false
+		for _, p := range rf.Peers {
+			if p.StoreID == old {
+				found = true
+			}
+			if s := cluster.GetStore(p.StoreID); s != nil {
+				ruleStores = append(ruleStores, s)
+			}
+		}
+		if !found {
+			continue
+		}
+		s := &ReplicaStrategy{
+			checkerName:    ruleCheckerName,
+			cluster:        cluster,
+			locationLabels: rf.Rule.LocationLabels,
+			isolationLevel: rf.Rule.IsolationLevel,
+			resource:       res,
+			extraFilters:   []filter.Filter{filter.NewLabelConstaintFilter(ruleCheckerName, rf.Rule.LabelConstraints)},
+		}
+		return s.hasStoreToReplace(ruleStores, old)
+	}
+	return true
+}
+
+func (s *ReplicaStrategy) hasStoreToReplace(shardStores []*core.CachedStore, old uint64) bool {
+	coLocationStores := make([]*core.CachedStore, 0, len(shardStores))
+	for _, container := range shardStores {
+		if container.Meta.GetID() != old {
+			coLocationStores = append(coLocationStores, container)
+		}
+	}
+
+	filters := []filter.Filter{
+		filter.NewExcludedFilter(s.checkerName, nil, s.resource.GetStoreIDs()),
+		filter.NewStorageThresholdFilter(s.checkerName),
+		filter.NewSpecialUseFilter(s.checkerName),
+		&filter.StoreStateFilter{ActionScope: s.checkerName, MoveShard: true, AllowTemporaryStates: true},
+	}
+	if oldStore := s.cluster.GetStore(old); oldStore != nil {
+		filters = append(filters, filter.NewLocationSafeguard(s.checkerName, s.locationLabels,
+			shardStores, oldStore))
+	}
+	if len(s.locationLabels) > 0 && s.isolationLevel != "" {
+		filters = append(filters, filter.NewIsolationFilter(s.checkerName, s.isolationLevel,
+			s.locationLabels, coLocationStores))
+	}
+	filters = append(filters, s.extraFilters...)
+	return len(filter.NewCandidates(s.cluster.GetStores()).
+		FilterTarget(s.cluster.GetOpts(), filters...).Stores) > 0
+}
+
 // SelectStoreToImprove returns a container to replace oldStore. The location
 // placement after scheduling should be better than original.
 func (s *ReplicaStrategy) SelectStoreToImprove(coLocationStores []*core.CachedStore, old uint64) uint64 {
@@ -43,7 +43,7 @@ func NewRuleChecker(cluster opt.Cluster, ruleManager *placement.RuleManager, res
 	return &RuleChecker{
 		cluster:             cluster,
 		ruleManager:         ruleManager,
-		name:                "rule-checker",
+		name:                ruleCheckerName,
 		resourceWaitingList: resourceWaitingList,
 	}
 }
@@ -65,9 +65,10 @@ func (c *RuleChecker) FillReplicas(res *core.CachedShard, leastPeers int) error
 	}
 
 	cnt := 0
+	var rs *ReplicaStrategy
 	for _, rf := range fit.RuleFits {
 		cnt += rf.Rule.Count
-		rs := c.strategy(res, rf.Rule)
+		rs = c.strategy(res, rf.Rule)
 		ruleStores := c.getRuleFitStores(rf)
 
 		for i := 0; i < rf.Rule.Count; i++ {
@@ -80,6 +81,10 @@ func (c *RuleChecker) FillReplicas(res *core.CachedShard, leastPeers int) error
 			switch rf.Rule.Role {
 			case placement.Voter, placement.Follower, placement.Leader:
 				p.Role = metapb.ReplicaRole_Voter
+			case placement.Witness:
+				p.Role = metapb.ReplicaRole_Witness
+			case placement.Observer:
+				p.Role = metapb.ReplicaRole_Observer
 			default:
 				p.Role = metapb.ReplicaRole_Learner
 			}
@@ -95,7 +100,7 @@ func (c *RuleChecker) FillReplicas(res *core.CachedShard, leastPeers int) error
 		return nil
 	}
 
-	return errors.New("no container to add peers")
+	return noStoreToAddError("no container to add peers", rs.ResourceLimitReasons())
 }
 
 // Check checks if the resource matches placement rules and returns Operator to
@@ -174,6 +179,10 @@ func (c *RuleChecker) fixRulePeer(res *core.CachedShard, fit *placement.ShardFit
 			checkerCounter.WithLabelValues("rule_checker", "replace-offline").Inc()
 			return c.replaceRulePeer(res, rf, peer, offlineStatus)
 		}
+		if c.isDiskFailedPeer(peer) {
+			checkerCounter.WithLabelValues("rule_checker", "replace-disk-failed").Inc()
+			return c.replaceRulePeer(res, rf, peer, diskFailedStatus)
+		}
 	}
 	// fix loose matched peers.
 	for _, peer := range rf.PeersWithDifferentRole {
@@ -191,11 +200,12 @@ func (c *RuleChecker) fixRulePeer(res *core.CachedShard, fit *placement.ShardFit
 func (c *RuleChecker) addRulePeer(res *core.CachedShard, rf *placement.RuleFit) (*operator.Operator, error) {
 	checkerCounter.WithLabelValues("rule_checker", "add-rule-peer").Inc()
 	ruleStores := c.getRuleFitStores(rf)
-	container := c.strategy(res, rf.Rule).SelectStoreToAdd(ruleStores)
+	rs := c.strategy(res, rf.Rule)
+	container := rs.SelectStoreToAdd(ruleStores)
 	if container == 0 {
 		checkerCounter.WithLabelValues("rule_checker", "no-container-add").Inc()
 		c.resourceWaitingList.Put(res.Meta.GetID(), nil)
-		return nil, errors.New("no container to add peer")
+		return nil, noStoreToAddError("no container to add peer", rs.ResourceLimitReasons())
 	}
 	peer := metapb.Replica{StoreID: container, Role: rf.Rule.Role.MetaPeerRole()}
 	return operator.CreateAddPeerOperator("add-rule-peer", c.cluster, res, peer, operator.OpReplica)
@@ -210,11 +220,12 @@ func (c *RuleChecker) replaceRulePeer(res *core.CachedShard, rf *placement.RuleF
 	}
 
 	ruleStores := c.getRuleFitStores(rf)
-	container := c.strategy(res, rf.Rule).SelectStoreToReplace(ruleStores, peer.StoreID)
+	rs := c.strategy(res, rf.Rule)
+	container := rs.SelectStoreToReplace(ruleStores, peer.StoreID)
 	if container == 0 {
 		checkerCounter.WithLabelValues("rule_checker", "no-container-replace").Inc()
 		c.resourceWaitingList.Put(res.Meta.GetID(), nil)
-		return nil, errors.New("no container to replace peer")
+		return nil, noStoreToAddError("no container to replace peer", rs.ResourceLimitReasons())
 	}
 	newPeer := metapb.Replica{StoreID: container, Role: rf.Rule.Role.MetaPeerRole()}
 	return operator.CreateMovePeerOperator("replace-rule-"+status+"-peer",
@@ -254,7 +265,7 @@ func (c *RuleChecker) fixLooseMatchPeer(res *core.CachedShard, fit *placement.Sh
 }
 
 func (c *RuleChecker) allowLeader(fit *placement.ShardFit, peer metapb.Replica) bool {
-	if metadata.IsLearner(peer) {
+	if metadata.IsLearner(peer) || metadata.IsWitness(peer) || metadata.IsObserver(peer) {
 		return false
 	}
 	s := c.cluster.GetStore(peer.StoreID)
@@ -351,6 +362,13 @@ func (c *RuleChecker) isOfflinePeer(res *core.CachedShard, peer metapb.Replica)
 	return !container.IsUp()
 }
 
+// isDiskFailedPeer returns true if the store of the peer failed to write its
+// data storage, the peer stops applying until the store is restarted.
+func (c *RuleChecker) isDiskFailedPeer(peer metapb.Replica) bool {
+	container := c.cluster.GetStore(peer.StoreID)
+	return container != nil && container.IsDiskFailed()
+}
+
 func (c *RuleChecker) strategy(res *core.CachedShard, rule *placement.Rule) *ReplicaStrategy {
 	return &ReplicaStrategy{
 		checkerName:    c.name,
@@ -80,6 +80,37 @@ func TestAddRulePeer(t *testing.T) {
 	assert.Equal(t, uint64(3), op.Step(0).(operator.AddLearner).ToStore)
 }
 
+func TestAddRuleObserver(t *testing.T) {
+	s := &testRuleChecker{}
+	s.setup()
+
+	s.cluster.AddLeaderStore(1, 1)
+	s.cluster.AddLeaderStore(2, 1)
+	s.cluster.AddLeaderStore(3, 1)
+	s.cluster.AddLabelsStore(4, 1, map[string]string{"engine": "tp"})
+	s.cluster.AddLabelsStore(5, 1, map[string]string{"engine": "ap"})
+	s.cluster.AddLeaderShardWithRange(1, "", "", 1, 2, 3)
+	s.ruleManager.SetRule(&placement.Rule{
+		GroupID: "prophet",
+		ID:      "observer",
+		Role:    placement.Observer,
+		Count:   1,
+		LabelConstraints: []placement.LabelConstraint{
+			{Key: "engine", Op: "in", Values: []string{"ap"}},
+		},
+	})
+	op := s.rc.Check(s.cluster.GetShard(1))
+	assert.NotNil(t, op)
+	assert.Equal(t, "add-rule-peer", op.Desc())
+	assert.Equal(t, 1, op.Len())
+	assert.Equal(t, uint64(5), op.Step(0).(operator.AddObserver).ToStore)
+
+	// the observer is never promoted
+	s.cluster.PutShard(s.cluster.GetShard(1).Clone(core.WithAddPeer(metapb.Replica{
+		ID: 5, StoreID: 5, Role: metapb.ReplicaRole_Observer})))
+	assert.Nil(t, s.rc.Check(s.cluster.GetShard(1)))
+}
+
 func TestFillReplicasWithRule(t *testing.T) {
 	s := &testRuleChecker{}
 	s.setup()
@@ -166,6 +197,24 @@ func TestFixPeer(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestFixDiskFailedPeer(t *testing.T) {
+	s := &testRuleChecker{}
+	s.setup()
+
+	s.cluster.AddLeaderStore(1, 1)
+	s.cluster.AddLeaderStore(2, 1)
+	s.cluster.AddLeaderStore(3, 1)
+	s.cluster.AddLeaderStore(4, 1)
+	s.cluster.AddLeaderShardWithRange(1, "", "", 1, 2, 3)
+	assert.Nil(t, s.rc.Check(s.cluster.GetShard(1)))
+
+	s.cluster.SetStoreDiskFailed(2, true)
+	op := s.rc.Check(s.cluster.GetShard(1))
+	assert.NotNil(t, op)
+	assert.Equal(t, "replace-rule-disk-failed-peer", op.Desc())
+	assert.Equal(t, uint64(4), op.Step(0).(operator.AddLearner).ToStore)
+}
+
 func TestFixOrphanPeers(t *testing.T) {
 	s := &testRuleChecker{}
 	s.setup()
@@ -39,6 +39,7 @@ type CheckerController struct {
 	replicaChecker      *checker.ReplicaChecker
 	ruleChecker         *checker.RuleChecker
 	mergeChecker        *checker.MergeChecker
+	emptyShardChecker   *checker.EmptyShardChecker
 	jointStateChecker   *checker.JointStateChecker
 	resourceWaitingList cache.Cache
 }
@@ -55,6 +56,7 @@ func NewCheckerController(ctx context.Context, cluster opt.Cluster, ruleManager
 		replicaChecker:      checker.NewReplicaChecker(cluster, resourceWaitingList),
 		ruleChecker:         checker.NewRuleChecker(cluster, ruleManager, resourceWaitingList),
 		mergeChecker:        checker.NewMergeChecker(ctx, cluster),
+		emptyShardChecker:   checker.NewEmptyShardChecker(cluster),
 		jointStateChecker:   checker.NewJointStateChecker(cluster),
 		leaseChecker:        checker.NewLeaseChecker(cluster),
 		resourceWaitingList: resourceWaitingList,
@@ -101,6 +103,7 @@ func (c *CheckerController) CheckShard(res *core.CachedShard) []*operator.Operat
 		}
 	}
 
+	c.emptyShardChecker.Check(res)
 	if c.mergeChecker != nil && opController.OperatorCount(operator.OpMerge) < c.opts.GetMergeScheduleLimit() {
 		allowed := opController.OperatorCount(operator.OpMerge) < c.opts.GetMergeScheduleLimit()
 		if !allowed {
@@ -125,6 +128,11 @@ func (c *CheckerController) GetMergeChecker() *checker.MergeChecker {
 	return c.mergeChecker
 }
 
+// GetEmptyShardChecker returns the empty shard checker.
+func (c *CheckerController) GetEmptyShardChecker() *checker.EmptyShardChecker {
+	return c.emptyShardChecker
+}
+
 // GetWaitingShards returns the resources in the waiting list.
 func (c *CheckerController) GetWaitingShards() []*cache.Item {
 	return c.resourceWaitingList.Elems()
@@ -344,6 +344,34 @@ func (f *StoreStateFilter) pauseLeaderTransfer(opt *config.PersistOptions, conta
 	return !container.AllowLeaderTransfer()
 }
 
+func (f *StoreStateFilter) isDraining(opt *config.PersistOptions, container *core.CachedStore) bool {
+	f.Reason = "draining"
+	return container.IsDraining()
+}
+
+func (f *StoreStateFilter) isSlow(opt *config.PersistOptions, container *core.CachedStore) bool {
+	f.Reason = "slow"
+	return !f.AllowTemporaryStates && container.IsSlow()
+}
+
+func (f *StoreStateFilter) exceedReplicaLimit(opt *config.PersistOptions, container *core.CachedStore) bool {
+	f.Reason = "exceed-replica-limit"
+	max := opt.GetStoreResourceLimit(container).MaxReplicas
+	return max > 0 && uint64(container.GetTotalShardCount()) >= max
+}
+
+func (f *StoreStateFilter) exceedSizeLimit(opt *config.PersistOptions, container *core.CachedStore) bool {
+	f.Reason = "exceed-size-limit"
+	max := opt.GetStoreResourceLimit(container).MaxSize
+	return max > 0 && uint64(container.GetTotalShardSize()) >= max
+}
+
+func (f *StoreStateFilter) exceedLeaderLimit(opt *config.PersistOptions, container *core.CachedStore) bool {
+	f.Reason = "exceed-leader-limit"
+	max := opt.GetStoreResourceLimit(container).MaxLeaders
+	return max > 0 && uint64(container.GetTotalLeaderCount()) >= max
+}
+
 func (f *StoreStateFilter) isDisconnected(opt *config.PersistOptions, container *core.CachedStore) bool {
 	f.Reason = "disconnected"
 	return !f.AllowTemporaryStates && container.IsDisconnected()
@@ -354,6 +382,11 @@ func (f *StoreStateFilter) isBusy(opt *config.PersistOptions, container *core.Ca
 	return !f.AllowTemporaryStates && container.IsBusy()
 }
 
+func (f *StoreStateFilter) isDiskFailed(opt *config.PersistOptions, container *core.CachedStore) bool {
+	f.Reason = "disk-failed"
+	return container.IsDiskFailed()
+}
+
 func (f *StoreStateFilter) exceedRemoveLimit(opt *config.PersistOptions, container *core.CachedStore) bool {
 	f.Reason = "exceed-remove-limit"
 	return !f.AllowTemporaryStates && !container.IsAvailable(limit.RemovePeer)
@@ -388,13 +421,16 @@ func (f *StoreStateFilter) hasRejectLeaderProperty(opts *config.PersistOptions,
 // N: the condition is expected to be true for a long time.
 // X means when the condition is true, the container CANNOT be selected.
 //
-// Condition      Down Offline Tomb Pause Disconn Busy RmLimit AddLimit Snap Pending Reject
-// IsTemporary    N    N       N    N     Y       Y    Y       Y        Y    Y       N
+// Condition      Down Offline Tomb Pause Disconn Busy RmLimit AddLimit Snap Pending Reject Drain Slow ResLimit DiskFail
+// IsTemporary    N    N       N    N     Y       Y    Y       Y        Y    Y       N      N     Y    N        N
 //
 // LeaderSource   X            X    X     X
 // ShardSource                                  X    X                X
-// LeaderTarget   X    X       X    X     X       X                                  X
-// ShardTarget X    X       X          X       X            X        X    X
+// LeaderTarget   X    X       X    X     X       X                                  X      X     X    X        X
+// ShardTarget X    X       X          X       X            X        X    X              X          X        X
+//
+// ResLimit is the max-replicas and max-size limits for the ShardTarget and the
+// max-leaders limit for the LeaderTarget.
 
 const (
 	leaderSource = iota
@@ -413,12 +449,15 @@ func (f *StoreStateFilter) anyConditionMatch(typ int, opt *config.PersistOptions
 		funcs = []conditionFunc{f.isBusy, f.exceedRemoveLimit, f.tooManySnapshots}
 	case leaderTarget:
 		funcs = []conditionFunc{f.isTombstone, f.isOffline, f.isDown, f.pauseLeaderTransfer,
-			f.isDisconnected, f.isBusy, f.hasRejectLeaderProperty}
+			f.isDisconnected, f.isBusy, f.hasRejectLeaderProperty, f.isDraining, f.isSlow, f.exceedLeaderLimit,
+			f.isDiskFailed}
 	case resourceTarget:
 		funcs = []conditionFunc{f.isTombstone, f.isOffline, f.isDown, f.isDisconnected, f.isBusy,
-			f.exceedAddLimit, f.tooManySnapshots, f.tooManyPendingPeers}
+			f.exceedAddLimit, f.tooManySnapshots, f.tooManyPendingPeers, f.isDraining, f.exceedReplicaLimit,
+			f.exceedSizeLimit, f.isDiskFailed}
 	case scatterShardTarget:
-		funcs = []conditionFunc{f.isTombstone, f.isOffline, f.isDown, f.isDisconnected, f.isBusy}
+		funcs = []conditionFunc{f.isTombstone, f.isOffline, f.isDown, f.isDisconnected, f.isBusy,
+			f.isDraining, f.exceedReplicaLimit, f.exceedSizeLimit, f.isDiskFailed}
 
 	}
 	for _, cf := range funcs {
@@ -456,6 +495,32 @@ func (f *StoreStateFilter) Target(opts *config.PersistOptions, container *core.C
 	return true
 }
 
+// ReplicaLimitReason returns the reason why the container cannot hold one more
+// replica because of its resource limits, returns "" if it can.
+func ReplicaLimitReason(opt *config.PersistOptions, container *core.CachedStore) string {
+	limit := opt.GetStoreResourceLimit(container)
+	if n := uint64(container.GetTotalShardCount()); limit.MaxReplicas > 0 && n >= limit.MaxReplicas {
+		return fmt.Sprintf("container %d has %d replicas, reached the max-replicas limit %d",
+			container.Meta.GetID(), n, limit.MaxReplicas)
+	}
+	if n := uint64(container.GetTotalShardSize()); limit.MaxSize > 0 && n >= limit.MaxSize {
+		return fmt.Sprintf("container %d has %dMB replicas, reached the max-size limit %dMB",
+			container.Meta.GetID(), n, limit.MaxSize)
+	}
+	return ""
+}
+
+// LeaderLimitReason returns the reason why the container cannot hold one more
+// leader because of its resource limits, returns "" if it can.
+func LeaderLimitReason(opt *config.PersistOptions, container *core.CachedStore) string {
+	limit := opt.GetStoreResourceLimit(container)
+	if n := uint64(container.GetTotalLeaderCount()); limit.MaxLeaders > 0 && n >= limit.MaxLeaders {
+		return fmt.Sprintf("container %d has %d leaders, reached the max-leaders limit %d",
+			container.Meta.GetID(), n, limit.MaxLeaders)
+	}
+	return ""
+}
+
 // labelConstraintFilter is a filter that selects containers satisfy the constraints.
 type labelConstraintFilter struct {
 	scope       string
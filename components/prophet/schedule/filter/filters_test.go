@@ -175,6 +175,76 @@ func TestStoreStateFilter(t *testing.T) {
 		{3, true, true},
 	}
 	check(container, testCases)
+
+	// Draining
+	container = container.Clone(core.SetStoreStats(&metapb.StoreStats{})).
+		Clone(core.SetStoreDraining(true))
+	testCases = []testCase{
+		{0, true, false},
+		{1, true, false},
+		{2, true, false},
+		{3, true, false},
+	}
+	check(container, testCases)
+
+	// Slow
+	container = container.Clone(core.SetStoreDraining(false)).
+		Clone(core.SetStoreSlow(true))
+	testCases = []testCase{
+		{0, true, false},
+		{1, true, true},
+		{2, true, false},
+		{3, true, true},
+	}
+	check(container, testCases)
+
+	// DiskFail
+	container = container.Clone(core.SetStoreSlow(false)).
+		Clone(core.SetStoreStats(&metapb.StoreStats{DiskFailed: true}))
+	testCases = []testCase{
+		{0, true, false},
+		{1, true, false},
+		{2, true, false},
+		{3, true, false},
+	}
+	check(container, testCases)
+}
+
+func TestStoreStateFilterResourceLimit(t *testing.T) {
+	opt := config.NewTestOptions()
+	leaderFilter := &StoreStateFilter{TransferLeader: true}
+	shardFilter := &StoreStateFilter{MoveShard: true}
+	scatterFilter := &StoreStateFilter{MoveShard: true, ScatterShard: true}
+
+	// 10 replicas of 100MB, 5 leaders
+	container := core.NewTestStoreInfoWithLabel(1, 10, map[string]string{
+		config.StoreLabelMaxReplicas: "10",
+	}).Clone(core.SetLastHeartbeatTS(time.Now()), core.SetLeaderCount("", 5))
+	assert.True(t, leaderFilter.Target(opt, container))
+	assert.False(t, shardFilter.Target(opt, container))
+	assert.Equal(t, "exceed-replica-limit", shardFilter.Reason)
+	assert.False(t, scatterFilter.Target(opt, container))
+	assert.True(t, shardFilter.Source(opt, container))
+	assert.Contains(t, ReplicaLimitReason(opt, container), "max-replicas")
+	assert.Empty(t, LeaderLimitReason(opt, container))
+
+	// the limits set by the options take precedence over the labels
+	opt.SetStoreResourceLimit(1, config.StoreResourceLimitConfig{MaxLeaders: 5, MaxSize: 200})
+	assert.False(t, leaderFilter.Target(opt, container))
+	assert.Equal(t, "exceed-leader-limit", leaderFilter.Reason)
+	assert.Contains(t, LeaderLimitReason(opt, container), "max-leaders")
+	assert.True(t, shardFilter.Target(opt, container))
+	assert.Empty(t, ReplicaLimitReason(opt, container))
+
+	opt.SetStoreResourceLimit(1, config.StoreResourceLimitConfig{MaxSize: 100})
+	assert.True(t, leaderFilter.Target(opt, container))
+	assert.False(t, shardFilter.Target(opt, container))
+	assert.Equal(t, "exceed-size-limit", shardFilter.Reason)
+	assert.Contains(t, ReplicaLimitReason(opt, container), "max-size")
+
+	opt.DeleteStoreResourceLimit(1)
+	assert.False(t, shardFilter.Target(opt, container))
+	assert.Equal(t, "exceed-replica-limit", shardFilter.Reason)
 }
 
 func TestIsolationFilter(t *testing.T) {
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -32,25 +33,136 @@ import (
 const (
 	heartbeatStreamKeepAliveInterval = time.Minute
 	heartbeatChanCapacity            = 1024
+	// heartbeatQueueCapacity max number of the pending messages of a bound
+	// stream, the oldest message is dropped if the queue is full
+	heartbeatQueueCapacity = 256
 )
 
-type streamUpdate struct {
+// StreamQueueState is the state of the queue of the heartbeat stream bound to a
+// store, it's used to find the stuck streams.
+type StreamQueueState struct {
+	StoreID uint64
+	// Bound false if the stream failed and no new stream is bound yet
+	Bound bool
+	// Depth number of the pending messages
+	Depth int
+	// Dropped number of the messages dropped due to the full queue
+	Dropped uint64
+	// LastSent the time of the last message sent successfully
+	LastSent time.Time
+}
+
+// streamQueue is the bounded message queue of the stream bound to a store, the
+// messages are sent by the queue's own goroutine, so a slow or stuck stream
+// never blocks the messages to the other stores.
+type streamQueue struct {
 	containerID uint64
-	stream      opt.HeartbeatStream
+	notifyC     chan struct{}
+
+	mu struct {
+		sync.Mutex
+		stream   opt.HeartbeatStream
+		msgs     []*rpcpb.ShardHeartbeatRsp
+		dropped  uint64
+		lastSent time.Time
+	}
+}
+
+func newStreamQueue(containerID uint64, stream opt.HeartbeatStream) *streamQueue {
+	q := &streamQueue{
+		containerID: containerID,
+		notifyC:     make(chan struct{}, 1),
+	}
+	q.mu.stream = stream
+	return q
+}
+
+func (q *streamQueue) bind(stream opt.HeartbeatStream) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.mu.stream = stream
+}
+
+// unbind removes the failed stream, the stream bound after the failure is kept
+func (q *streamQueue) unbind(stream opt.HeartbeatStream) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.mu.stream == stream {
+		q.mu.stream = nil
+	}
+}
+
+// push adds the message to the queue, returns true if the oldest message is
+// dropped.
+func (q *streamQueue) push(msg *rpcpb.ShardHeartbeatRsp) bool {
+	q.mu.Lock()
+	dropped := false
+	if len(q.mu.msgs) >= heartbeatQueueCapacity {
+		q.mu.msgs[0] = nil
+		q.mu.msgs = q.mu.msgs[1:]
+		q.mu.dropped++
+		dropped = true
+	}
+	q.mu.msgs = append(q.mu.msgs, msg)
+	heartbeatQueueGauge.WithLabelValues(strconv.FormatUint(q.containerID, 10)).Set(float64(len(q.mu.msgs)))
+	q.mu.Unlock()
+
+	select {
+	case q.notifyC <- struct{}{}:
+	default:
+	}
+	return dropped
+}
+
+// pop returns the oldest message and the current stream, the stream is nil if
+// no stream is bound.
+func (q *streamQueue) pop() (opt.HeartbeatStream, *rpcpb.ShardHeartbeatRsp, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.mu.msgs) == 0 {
+		return nil, nil, false
+	}
+	msg := q.mu.msgs[0]
+	q.mu.msgs[0] = nil
+	q.mu.msgs = q.mu.msgs[1:]
+	heartbeatQueueGauge.WithLabelValues(strconv.FormatUint(q.containerID, 10)).Set(float64(len(q.mu.msgs)))
+	return q.mu.stream, msg, true
+}
+
+func (q *streamQueue) sent(now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.mu.lastSent = now
+}
+
+func (q *streamQueue) state() StreamQueueState {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return StreamQueueState{
+		StoreID:  q.containerID,
+		Bound:    q.mu.stream != nil,
+		Depth:    len(q.mu.msgs),
+		Dropped:  q.mu.dropped,
+		LastSent: q.mu.lastSent,
+	}
 }
 
 // HeartbeatStreams is the bridge of communication with your storage application instance.
+// Each bound stream has its own bounded queue and sender goroutine.
 type HeartbeatStreams struct {
 	wg                sync.WaitGroup
 	hbStreamCtx       context.Context
 	hbStreamCancel    context.CancelFunc
 	clusterID         uint64
-	streams           map[uint64]opt.HeartbeatStream
-	msgCh             chan *rpcpb.ShardHeartbeatRsp
-	streamCh          chan streamUpdate
+	msgCh             chan *rpcpb.ShardHeartbeatRsp // For test only.
 	containerInformer core.StoreSetInformer
 	logger            *zap.Logger
 	needRun           bool // For test only.
+
+	mu struct {
+		sync.RWMutex
+		queues map[uint64]*streamQueue
+	}
 }
 
 // NewHeartbeatStreams creates a new HeartbeatStreams which enable background running by default.
@@ -70,21 +182,18 @@ func newHbStreams(ctx context.Context, clusterID uint64, containerInformer core.
 		hbStreamCtx:       hbStreamCtx,
 		hbStreamCancel:    hbStreamCancel,
 		clusterID:         clusterID,
-		streams:           make(map[uint64]opt.HeartbeatStream),
-		msgCh:             make(chan *rpcpb.ShardHeartbeatRsp, heartbeatChanCapacity),
-		streamCh:          make(chan streamUpdate, 1),
 		containerInformer: containerInformer,
 		needRun:           needRun,
 		logger:            log.Adjust(logger),
 	}
-	if needRun {
-		hs.wg.Add(1)
-		go hs.run()
+	hs.mu.queues = make(map[uint64]*streamQueue)
+	if !needRun {
+		hs.msgCh = make(chan *rpcpb.ShardHeartbeatRsp, heartbeatChanCapacity)
 	}
 	return hs
 }
 
-func (s *HeartbeatStreams) run() {
+func (s *HeartbeatStreams) run(q *streamQueue) {
 	defer func() {
 		if err := recover(); err != nil {
 			panic(fmt.Sprintf("hb streams runner failed with %+v", err))
@@ -95,35 +204,16 @@ func (s *HeartbeatStreams) run() {
 
 	for {
 		select {
-		case update := <-s.streamCh:
-			s.streams[update.containerID] = update.stream
-		case msg := <-s.msgCh:
-			containerID := msg.GetTargetReplica().StoreID
-			containerLabel := strconv.FormatUint(containerID, 10)
-			container := s.containerInformer.GetStore(containerID)
-			if container == nil {
-				s.logger.Error("fail to get container, not found",
-					log.ResourceField(msg.ShardID),
-					zap.Uint64("container", containerID))
-				delete(s.streams, containerID)
-				continue
-			}
-			containerAddress := container.Meta.GetClientAddress()
-			if stream, ok := s.streams[containerID]; ok {
-				if err := stream.Send(msg); err != nil {
-					s.logger.Error("fail to send heartbeat message",
-						log.ResourceField(msg.ShardID),
-						zap.Error(err))
-					delete(s.streams, containerID)
-					heartbeatStreamCounter.WithLabelValues(containerAddress, containerLabel, "push", "err").Inc()
-				} else {
-					heartbeatStreamCounter.WithLabelValues(containerAddress, containerLabel, "push", "ok").Inc()
+		case <-q.notifyC:
+			for {
+				stream, msg, ok := q.pop()
+				if !ok {
+					break
+				}
+				s.send(q, stream, msg)
+				if s.hbStreamCtx.Err() != nil {
+					return
 				}
-			} else {
-				s.logger.Debug("heartbeat stream not found, skip send message",
-					log.ResourceField(msg.ShardID),
-					zap.Uint64("container", containerID))
-				heartbeatStreamCounter.WithLabelValues(containerAddress, containerLabel, "push", "skip").Inc()
 			}
 		case <-s.hbStreamCtx.Done():
 			return
@@ -131,25 +221,71 @@ func (s *HeartbeatStreams) run() {
 	}
 }
 
+func (s *HeartbeatStreams) send(q *streamQueue, stream opt.HeartbeatStream, msg *rpcpb.ShardHeartbeatRsp) {
+	containerID := q.containerID
+	containerLabel := strconv.FormatUint(containerID, 10)
+	container := s.containerInformer.GetStore(containerID)
+	if container == nil {
+		s.logger.Error("fail to get container, not found",
+			log.ResourceField(msg.ShardID),
+			zap.Uint64("container", containerID))
+		if stream != nil {
+			q.unbind(stream)
+		}
+		return
+	}
+	containerAddress := container.Meta.GetClientAddress()
+	if stream == nil {
+		s.logger.Debug("heartbeat stream not found, skip send message",
+			log.ResourceField(msg.ShardID),
+			zap.Uint64("container", containerID))
+		heartbeatStreamCounter.WithLabelValues(containerAddress, containerLabel, "push", "skip").Inc()
+		return
+	}
+
+	if err := stream.Send(msg); err != nil {
+		s.logger.Error("fail to send heartbeat message",
+			log.ResourceField(msg.ShardID),
+			zap.Error(err))
+		q.unbind(stream)
+		heartbeatStreamCounter.WithLabelValues(containerAddress, containerLabel, "push", "err").Inc()
+		return
+	}
+	q.sent(time.Now())
+	heartbeatStreamCounter.WithLabelValues(containerAddress, containerLabel, "push", "ok").Inc()
+}
+
 // Close closes background running.
 func (s *HeartbeatStreams) Close() {
+	s.mu.Lock()
 	s.hbStreamCancel()
+	s.mu.Unlock()
 	s.wg.Wait()
 }
 
 // BindStream binds a stream with a specified container.
 func (s *HeartbeatStreams) BindStream(containerID uint64, stream opt.HeartbeatStream) {
-	update := streamUpdate{
-		containerID: containerID,
-		stream:      stream,
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if q, ok := s.mu.queues[containerID]; ok {
+		q.bind(stream)
+		return
 	}
-	select {
-	case s.streamCh <- update:
-	case <-s.hbStreamCtx.Done():
+	if s.hbStreamCtx.Err() != nil {
+		return
+	}
+
+	q := newStreamQueue(containerID, stream)
+	s.mu.queues[containerID] = q
+	if s.needRun {
+		s.wg.Add(1)
+		go s.run(q)
 	}
 }
 
-// SendMsg sends a message to related container.
+// SendMsg sends a message to related container. The message is dropped if no
+// stream was ever bound to the container, and the oldest pending message is
+// dropped if the queue of the stream is full.
 func (s *HeartbeatStreams) SendMsg(res *core.CachedShard, msg *rpcpb.ShardHeartbeatRsp) {
 	if res.GetLeader() == nil {
 		return
@@ -159,10 +295,53 @@ func (s *HeartbeatStreams) SendMsg(res *core.CachedShard, msg *rpcpb.ShardHeartb
 	msg.ShardEpoch = res.Meta.GetEpoch()
 	msg.TargetReplica = res.GetLeader()
 
-	select {
-	case s.msgCh <- msg:
-	case <-s.hbStreamCtx.Done():
+	if !s.needRun {
+		select {
+		case s.msgCh <- msg:
+		case <-s.hbStreamCtx.Done():
+		}
+		return
+	}
+
+	containerID := msg.GetTargetReplica().StoreID
+	s.mu.RLock()
+	q, ok := s.mu.queues[containerID]
+	s.mu.RUnlock()
+	if !ok {
+		s.logger.Debug("heartbeat stream not found, skip send message",
+			log.ResourceField(msg.ShardID),
+			zap.Uint64("container", containerID))
+		heartbeatStreamCounter.WithLabelValues(s.getAddress(containerID),
+			strconv.FormatUint(containerID, 10), "push", "skip").Inc()
+		return
+	}
+	if q.push(msg) {
+		heartbeatStreamCounter.WithLabelValues(s.getAddress(containerID),
+			strconv.FormatUint(containerID, 10), "push", "drop").Inc()
+	}
+}
+
+func (s *HeartbeatStreams) getAddress(containerID uint64) string {
+	if container := s.containerInformer.GetStore(containerID); container != nil {
+		return container.Meta.GetClientAddress()
+	}
+	return ""
+}
+
+// QueueStates returns the states of the queues of the bound streams ordered by
+// the store id, the streams with a large depth or an old last sent time are
+// probably stuck.
+func (s *HeartbeatStreams) QueueStates() []StreamQueueState {
+	s.mu.RLock()
+	states := make([]StreamQueueState, 0, len(s.mu.queues))
+	for _, q := range s.mu.queues {
+		states = append(states, q.state())
 	}
+	s.mu.RUnlock()
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].StoreID < states[j].StoreID
+	})
+	return states
 }
 
 // MsgLength gets the length of msgCh.
@@ -25,6 +25,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/testutil"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestActivity(t *testing.T) {
@@ -66,3 +67,66 @@ func TestActivity(t *testing.T) {
 		return stream1.Recv() != nil && stream2.Recv() == nil
 	})
 }
+
+type blockedStream struct {
+	c chan struct{}
+}
+
+func (s *blockedStream) Send(m *rpcpb.ShardHeartbeatRsp) error {
+	<-s.c
+	return nil
+}
+
+func TestStreamQueueDropOldest(t *testing.T) {
+	q := newStreamQueue(1, nil)
+	for i := 0; i < heartbeatQueueCapacity+2; i++ {
+		dropped := q.push(&rpcpb.ShardHeartbeatRsp{ShardID: uint64(i)})
+		assert.Equal(t, i >= heartbeatQueueCapacity, dropped)
+	}
+
+	state := q.state()
+	assert.False(t, state.Bound)
+	assert.Equal(t, heartbeatQueueCapacity, state.Depth)
+	assert.Equal(t, uint64(2), state.Dropped)
+	_, msg, ok := q.pop()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), msg.ShardID)
+}
+
+func TestStuckStreamNotBlockOthers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cluster := mockcluster.NewCluster(config.NewTestOptions())
+	cluster.AddShardStore(1, 1)
+	cluster.AddShardStore(2, 1)
+	cluster.AddLeaderShard(1, 1)
+	cluster.AddLeaderShard(2, 2)
+
+	hbs := NewTestHeartbeatStreams(ctx, cluster.ID, cluster, true, nil)
+	blocked := &blockedStream{c: make(chan struct{})}
+	stream := mockhbstream.NewHeartbeatStream()
+	hbs.BindStream(1, blocked)
+	hbs.BindStream(2, stream)
+	defer hbs.Close()
+	defer close(blocked.c)
+
+	for i := 0; i < heartbeatQueueCapacity+10; i++ {
+		hbs.SendMsg(cluster.GetShard(1), &rpcpb.ShardHeartbeatRsp{})
+	}
+	testutil.WaitUntil(t, func(t *testing.T) bool {
+		hbs.SendMsg(cluster.GetShard(2), &rpcpb.ShardHeartbeatRsp{})
+		return stream.Recv() != nil
+	})
+
+	states := hbs.QueueStates()
+	assert.Equal(t, 2, len(states))
+	assert.Equal(t, uint64(1), states[0].StoreID)
+	assert.True(t, states[0].Bound)
+	// the first message may be blocked in the stream
+	assert.True(t, states[0].Depth >= heartbeatQueueCapacity-1)
+	assert.True(t, states[0].Dropped > 0)
+	assert.True(t, states[0].LastSent.IsZero())
+	assert.Equal(t, uint64(2), states[1].StoreID)
+	assert.False(t, states[1].LastSent.IsZero())
+}
@@ -24,8 +24,17 @@ var (
 			Name:      "resource_message",
 			Help:      "Counter of message hbstream sent.",
 		}, []string{"address", "container", "type", "status"})
+
+	heartbeatQueueGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "prophet",
+			Subsystem: "hbstream",
+			Name:      "queue_depth",
+			Help:      "Number of the pending messages of the stream.",
+		}, []string{"container"})
 )
 
 func init() {
 	prometheus.MustRegister(heartbeatStreamCounter)
+	prometheus.MustRegister(heartbeatQueueGauge)
 }
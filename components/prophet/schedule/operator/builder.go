@@ -218,7 +218,7 @@ func (b *Builder) DemoteVoter(containerID uint64) *Builder {
 	}
 	if peer, ok := b.targetPeers[containerID]; !ok {
 		b.err = fmt.Errorf("cannot demote voter %d: not found", containerID)
-	} else if metadata.IsLearner(peer) {
+	} else if metadata.IsLearner(peer) || metadata.IsObserver(peer) {
 		b.err = fmt.Errorf("cannot demote voter %d: is already learner", containerID)
 	} else {
 		b.targetPeers.Set(metapb.Replica{
@@ -237,7 +237,7 @@ func (b *Builder) SetLeader(containerID uint64) *Builder {
 	}
 	if peer, ok := b.targetPeers[containerID]; !ok {
 		b.err = fmt.Errorf("cannot transfer leader to %d: not found", containerID)
-	} else if metadata.IsLearner(peer) {
+	} else if metadata.IsLearner(peer) || metadata.IsObserver(peer) {
 		b.err = fmt.Errorf("cannot transfer leader to %d: not voter", containerID)
 	} else if _, ok := b.unhealthyPeers[containerID]; ok {
 		b.err = fmt.Errorf("cannot transfer leader to %d: unhealthy", containerID)
@@ -299,7 +299,7 @@ func (b *Builder) SetExpectedRoles(roles map[uint64]placement.ReplicaRoleType) *
 			leaderCount++
 		case placement.Voter:
 			voterCount++
-		case placement.Follower, placement.Learner:
+		case placement.Follower, placement.Learner, placement.Witness:
 			if b.targetLeaderStoreID == id {
 				b.targetLeaderStoreID = 0
 			}
@@ -359,7 +359,7 @@ func (b *Builder) prepareBuild() (string, error) {
 
 	voterCount := 0
 	for _, peer := range b.targetPeers {
-		if !metadata.IsLearner(peer) {
+		if !metadata.IsLearner(peer) && !metadata.IsWitness(peer) && !metadata.IsObserver(peer) {
 			voterCount++
 		}
 	}
@@ -386,6 +386,19 @@ func (b *Builder) prepareBuild() (string, error) {
 			}
 		}
 
+		if metadata.IsWitness(o) != metadata.IsWitness(n) {
+			// the witness has no user data, it can't be converted to or from
+			// other roles, replace it instead.
+			b.toRemove.Set(o)
+			continue
+		}
+		if metadata.IsObserver(o) != metadata.IsObserver(n) {
+			// the observer is never promoted, and the other roles can't become
+			// an observer, replace it instead.
+			b.toRemove.Set(o)
+			continue
+		}
+
 		if metadata.IsLearner(o) {
 			if !metadata.IsLearner(n) {
 				// learner -> voter
@@ -406,8 +419,12 @@ func (b *Builder) prepareBuild() (string, error) {
 	for _, n := range b.targetPeers {
 		// old peer not exists, or target is learner while old one is voter.
 		o, ok := b.originPeers[n.StoreID]
-		if !ok || (!b.allowDemote && !metadata.IsLearner(o) && metadata.IsLearner(n)) {
-			if n.ID == 0 {
+		replaced := ok && (metadata.IsWitness(o) != metadata.IsWitness(n) ||
+			metadata.IsObserver(o) != metadata.IsObserver(n))
+		if !ok || (!b.allowDemote && !metadata.IsLearner(o) && metadata.IsLearner(n)) || replaced {
+			// The replaced peer's ID can't be reused by the witness or the
+			// observer on the same container.
+			if n.ID == 0 || replaced {
 				// Allocate peer ID if need.
 				id, err := b.cluster.AllocID()
 				if err != nil {
@@ -424,8 +441,9 @@ func (b *Builder) prepareBuild() (string, error) {
 		}
 	}
 
-	// If the target leader does not exist or is a Learner, the target is cancelled.
-	if peer, ok := b.targetPeers[b.targetLeaderStoreID]; !ok || metadata.IsLearner(peer) {
+	// If the target leader does not exist or is a Learner, a Witness or an Observer, the target is cancelled.
+	if peer, ok := b.targetPeers[b.targetLeaderStoreID]; !ok || metadata.IsLearner(peer) ||
+		metadata.IsWitness(peer) || metadata.IsObserver(peer) {
 		b.targetLeaderStoreID = 0
 	}
 
@@ -442,6 +460,22 @@ func (b *Builder) prepareBuild() (string, error) {
 		// If only one peer changed, joint consensus is not used.
 		b.useJointConsensus = false
 	}
+	for _, peer := range b.toAdd {
+		// The witness is added as a voter directly, it can't join as a learner
+		// as joint consensus does.
+		if metadata.IsWitness(peer) {
+			b.useJointConsensus = false
+		}
+	}
+	for _, peers := range []peersMap{b.toAdd, b.toRemove} {
+		for _, peer := range peers {
+			// Joint consensus promotes the added peers and demotes the removed
+			// peers, the observer is never promoted or demoted.
+			if metadata.IsObserver(peer) {
+				b.useJointConsensus = false
+			}
+		}
+	}
 
 	b.peerAddStep = make(map[uint64]int)
 
@@ -676,12 +710,16 @@ func (b *Builder) execDemoteFollower(peer metapb.Replica) {
 }
 
 func (b *Builder) execAddPeer(peer metapb.Replica) {
-	if b.lightWeight {
+	if metadata.IsWitness(peer) {
+		b.steps = append(b.steps, AddWitness{ToStore: peer.StoreID, PeerID: peer.ID})
+	} else if metadata.IsObserver(peer) {
+		b.steps = append(b.steps, AddObserver{ToStore: peer.StoreID, PeerID: peer.ID})
+	} else if b.lightWeight {
 		b.steps = append(b.steps, AddLightLearner{ToStore: peer.StoreID, PeerID: peer.ID})
 	} else {
 		b.steps = append(b.steps, AddLearner{ToStore: peer.StoreID, PeerID: peer.ID})
 	}
-	if !metadata.IsLearner(peer) {
+	if !metadata.IsLearner(peer) && !metadata.IsWitness(peer) && !metadata.IsObserver(peer) {
 		b.steps = append(b.steps, PromoteLearner{ToStore: peer.StoreID, PeerID: peer.ID})
 	}
 	b.currentPeers.Set(peer)
@@ -737,7 +775,8 @@ func (b *Builder) execChangePeerV2(needEnter bool, needTransferLeader bool) {
 func (b *Builder) allowLeader(peer metapb.Replica, ignoreClusterLimit bool) bool {
 	// these peer roles are not allowed to become leader.
 	switch peer.Role {
-	case metapb.ReplicaRole_Learner, metapb.ReplicaRole_DemotingVoter:
+	case metapb.ReplicaRole_Learner, metapb.ReplicaRole_DemotingVoter, metapb.ReplicaRole_Witness,
+		metapb.ReplicaRole_Observer:
 		return false
 	}
 
@@ -838,12 +877,13 @@ func (b *Builder) planReplace() stepPlan {
 			best = b.planReplaceLeaders(best, stepPlan{promote: &promote, demote: &demote})
 		}
 	}
-	// add voter + remove voter OR add learner + remove learner.
+	// add voter + remove voter OR add learner + remove learner. The peer
+	// replaced on the same container must be removed before adding.
 	for _, i := range b.toAdd.IDs() {
 		add := b.toAdd[i]
 		for _, j := range b.toRemove.IDs() {
 			remove := b.toRemove[j]
-			if metadata.IsLearner(remove) == metadata.IsLearner(add) {
+			if metadata.IsLearner(remove) == metadata.IsLearner(add) && i != j {
 				best = b.planReplaceLeaders(best, stepPlan{add: &add, remove: &remove})
 			}
 		}
@@ -228,6 +228,79 @@ func TestBuild(t *testing.T) {
 				TransferLeader{FromStore: 1, ToStore: 2},
 			},
 		},
+		{ // add witness
+			false, false,
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}},
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {StoreID: 3, Role: metapb.ReplicaRole_Witness}},
+			OpShard,
+			[]OpStep{
+				AddWitness{ToStore: 3},
+			},
+		},
+		{ // replace voter with witness: witness is not added as learner by joint consensus
+			true, true,
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3}},
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {StoreID: 4, Role: metapb.ReplicaRole_Witness}},
+			OpShard,
+			[]OpStep{
+				AddWitness{ToStore: 4},
+				RemovePeer{FromStore: 3},
+			},
+		},
+		{ // voter can't be converted to witness
+			false, false,
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3}},
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3, Role: metapb.ReplicaRole_Witness}},
+			OpShard,
+			[]OpStep{
+				RemovePeer{FromStore: 3},
+				AddWitness{ToStore: 3},
+			},
+		},
+		{ // witness can't be the leader
+			false, false,
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2, Role: metapb.ReplicaRole_Witness}},
+			[]metapb.Replica{{ID: 2, StoreID: 2, Role: metapb.ReplicaRole_Witness}},
+			0,
+			[]OpStep{},
+		},
+		{ // add observer
+			true, true,
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}},
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {StoreID: 3, Role: metapb.ReplicaRole_Observer}},
+			OpShard,
+			[]OpStep{
+				AddObserver{ToStore: 3},
+			},
+		},
+		{ // replace voter with observer: observer is not promoted by joint consensus
+			true, true,
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3}},
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {StoreID: 4, Role: metapb.ReplicaRole_Observer}},
+			OpShard,
+			[]OpStep{
+				AddObserver{ToStore: 4},
+				RemovePeer{FromStore: 3},
+			},
+		},
+		{ // observer is never promoted
+			false, false,
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3, Role: metapb.ReplicaRole_Observer}},
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3}},
+			OpShard,
+			[]OpStep{
+				RemovePeer{FromStore: 3},
+				AddLearner{ToStore: 3},
+				PromoteLearner{ToStore: 3},
+			},
+		},
+		{ // observer can't be the leader
+			false, false,
+			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2, Role: metapb.ReplicaRole_Observer}},
+			[]metapb.Replica{{ID: 2, StoreID: 2, Role: metapb.ReplicaRole_Observer}},
+			0,
+			[]OpStep{},
+		},
 		{ // not use joint consensus: prefer replace
 			false, false,
 			[]metapb.Replica{{ID: 1, StoreID: 1}, {ID: 2, StoreID: 2}, {ID: 3, StoreID: 3, Role: metapb.ReplicaRole_Learner}},
@@ -452,6 +525,13 @@ func TestBuild(t *testing.T) {
 				assert.Equal(t, step.ToStore, tc.steps[i].(AddLightLearner).ToStore)
 			case PromoteLearner:
 				assert.Equal(t, step.ToStore, tc.steps[i].(PromoteLearner).ToStore)
+			case AddWitness:
+				assert.Equal(t, step.ToStore, tc.steps[i].(AddWitness).ToStore)
+				if origin, ok := resource.GetStorePeer(step.ToStore); ok {
+					assert.NotEqual(t, origin.ID, step.PeerID)
+				}
+			case AddObserver:
+				assert.Equal(t, step.ToStore, tc.steps[i].(AddObserver).ToStore)
 			case DemoteFollower:
 				assert.Equal(t, step.ToStore, tc.steps[i].(DemoteFollower).ToStore)
 			case ChangePeerV2Enter:
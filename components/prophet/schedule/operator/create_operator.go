@@ -187,7 +187,7 @@ func CreateScatterShardOperator(desc string, cluster opt.Cluster, origin *core.C
 	// randomly pick a leader.
 	var ids []uint64
 	for id, peer := range targetPeers {
-		if !metadata.IsLearner(peer) {
+		if !metadata.IsLearner(peer) && !metadata.IsObserver(peer) {
 			ids = append(ids, id)
 		}
 	}
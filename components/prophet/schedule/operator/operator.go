@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -49,6 +50,7 @@ type Operator struct {
 	steps            []OpStep
 	stepsTime        []int64 // step finish time
 	currentStep      int32
+	stepTimeout      time.Duration
 	status           OpStatusTracker
 	level            core.PriorityLevel
 	Counters         []prometheus.Counter
@@ -198,17 +200,58 @@ func (o *Operator) CheckExpired() bool {
 	return o.status.CheckExpired(OperatorExpireTime)
 }
 
+// SetStepTimeout sets the max duration of a single step, the operator is timeout
+// once its current step runs longer than it. It must be set before the operator
+// is started.
+func (o *Operator) SetStepTimeout(timeout time.Duration) {
+	o.stepTimeout = timeout
+}
+
+// GetStepTimeout returns the largest step timeout of the kinds of the operator,
+// 0 if none of its kinds is configured.
+func GetStepTimeout(kind OpKind, timeouts map[string]typeutil.Duration) time.Duration {
+	var timeout time.Duration
+	for flag := OpKind(1); flag < opMax; flag <<= 1 {
+		if kind&flag == 0 {
+			continue
+		}
+		if v, ok := timeouts[flagToName[flag]]; ok && v.Duration > timeout {
+			timeout = v.Duration
+		}
+	}
+	return timeout
+}
+
 // CheckTimeout checks if the operator is timeout, and update the status.
 func (o *Operator) CheckTimeout() bool {
 	if o.CheckSuccess() {
 		return false
 	}
+	if o.checkStepTimeout() {
+		return true
+	}
 	if o.kind&OpShard != 0 {
 		return o.status.CheckTimeout(SlowOperatorWaitTime)
 	}
 	return o.status.CheckTimeout(FastOperatorWaitTime)
 }
 
+// checkStepTimeout checks if the current step runs longer than the step timeout,
+// and update the status.
+func (o *Operator) checkStepTimeout() bool {
+	if o.stepTimeout <= 0 || o.Status() != STARTED {
+		return false
+	}
+	start := o.GetStartTime()
+	if step := atomic.LoadInt32(&o.currentStep); step > 0 {
+		start = time.Unix(0, atomic.LoadInt64(&o.stepsTime[step-1]))
+	}
+	if time.Since(start) < o.stepTimeout {
+		return false
+	}
+	return o.status.To(TIMEOUT)
+}
+
 // Len returns the operator's steps count.
 func (o *Operator) Len() int {
 	return len(o.steps)
@@ -319,6 +362,10 @@ func (o *Operator) History() []OpHistory {
 			addPeerStores = append(addPeerStores, s.ToStore)
 		case AddLightLearner:
 			addPeerStores = append(addPeerStores, s.ToStore)
+		case AddWitness:
+			addPeerStores = append(addPeerStores, s.ToStore)
+		case AddObserver:
+			addPeerStores = append(addPeerStores, s.ToStore)
 		case RemovePeer:
 			removePeerStores = append(removePeerStores, s.FromStore)
 		}
@@ -26,6 +26,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/limit"
 	"github.com/matrixorigin/matrixcube/components/prophet/mock/mockcluster"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/opt"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/stretchr/testify/assert"
 )
@@ -353,6 +354,40 @@ func TestCheckTimeout(t *testing.T) {
 	}
 }
 
+func TestCheckStepTimeout(t *testing.T) {
+	s := &testOperator{}
+	s.setup()
+
+	steps := []OpStep{
+		AddLearner{ToStore: 1, PeerID: 1},
+		PromoteLearner{ToStore: 1, PeerID: 1},
+	}
+	op := s.newTestOperator(1, OpReplica|OpShard, steps...)
+	op.SetStepTimeout(time.Minute)
+	assert.True(t, op.Start())
+	SetOperatorStatusReachTime(op, STARTED, time.Now().Add(-2*time.Minute))
+	// the first step just finished
+	op.stepsTime[0] = time.Now().UnixNano()
+	op.currentStep = 1
+	assert.False(t, op.CheckTimeout())
+	assert.Equal(t, STARTED, op.Status())
+
+	op.stepsTime[0] = time.Now().Add(-time.Minute).UnixNano()
+	assert.True(t, op.CheckTimeout())
+	assert.Equal(t, TIMEOUT, op.Status())
+}
+
+func TestGetStepTimeout(t *testing.T) {
+	timeouts := map[string]typeutil.Duration{
+		"replica": typeutil.NewDuration(time.Minute),
+		"merge":   typeutil.NewDuration(time.Second),
+	}
+	assert.Equal(t, time.Duration(0), GetStepTimeout(OpLeader, timeouts))
+	assert.Equal(t, time.Second, GetStepTimeout(OpMerge|OpLeader, timeouts))
+	assert.Equal(t, time.Minute, GetStepTimeout(OpMerge|OpReplica, timeouts))
+	assert.Equal(t, time.Duration(0), GetStepTimeout(OpReplica, nil))
+}
+
 func TestStart(t *testing.T) {
 	s := &testOperator{}
 	s.setup()
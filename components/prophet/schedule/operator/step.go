@@ -62,7 +62,7 @@ func (tl TransferLeader) CheckSafety(res *core.CachedShard) error {
 	if !ok {
 		return errors.New("peer does not existed")
 	}
-	if metadata.IsLearner(peer) {
+	if metadata.IsLearner(peer) || metadata.IsObserver(peer) {
 		return errors.New("peer already is a learner")
 	}
 	return nil
@@ -228,6 +228,119 @@ func (pl PromoteLearner) CheckSafety(res *core.CachedShard) error {
 // Influence calculates the container difference that current step makes.
 func (pl PromoteLearner) Influence(opInfluence OpInfluence, res *core.CachedShard) {}
 
+// AddWitness is an OpStep that adds a resource witness peer, the witness votes
+// but stores no user data.
+type AddWitness struct {
+	ToStore, PeerID uint64
+}
+
+// ConfVerChanged returns the delta value for version increased by this step.
+func (aw AddWitness) ConfVerChanged(res *core.CachedShard) uint64 {
+	peer, _ := res.GetStoreVoter(aw.ToStore)
+	return typeutil.BoolToUint64(peer.ID == aw.PeerID && metadata.IsWitness(peer))
+}
+
+func (aw AddWitness) String() string {
+	return fmt.Sprintf("add witness peer %v on container %v", aw.PeerID, aw.ToStore)
+}
+
+// IsFinish checks if current step is finished.
+func (aw AddWitness) IsFinish(res *core.CachedShard) bool {
+	if peer, ok := res.GetStoreVoter(aw.ToStore); ok {
+		if peer.ID != aw.PeerID || !metadata.IsWitness(peer) {
+			return false
+		}
+		_, ok := res.GetPendingVoter(peer.ID)
+		return !ok
+	}
+	return false
+}
+
+// CheckSafety checks if the step meets the safety properties.
+func (aw AddWitness) CheckSafety(res *core.CachedShard) error {
+	peer, ok := res.GetStorePeer(aw.ToStore)
+	if !ok {
+		return nil
+	}
+	if peer.ID != aw.PeerID {
+		return fmt.Errorf("peer %d has already existed in container %d, the operator is trying to add peer %d on the same container", peer.ID, aw.ToStore, aw.PeerID)
+	}
+	if !metadata.IsWitness(peer) {
+		return errors.New("peer already is not a witness")
+	}
+	return nil
+}
+
+// Influence calculates the container difference that current step makes. The
+// witness stores no user data, so only the shard count is influenced.
+func (aw AddWitness) Influence(opInfluence OpInfluence, res *core.CachedShard) {
+	to := opInfluence.GetStoreInfluence(aw.ToStore)
+
+	groupKey := res.GetGroupKey()
+	stats := to.InfluenceStats[groupKey]
+	stats.ShardCount++
+	to.InfluenceStats[groupKey] = stats
+
+	to.AdjustStepCost(limit.AddPeer, 0)
+}
+
+// AddObserver is an OpStep that adds a resource observer peer, the observer is
+// a learner never promoted.
+type AddObserver struct {
+	ToStore, PeerID uint64
+}
+
+// ConfVerChanged returns the delta value for version increased by this step.
+func (ao AddObserver) ConfVerChanged(res *core.CachedShard) uint64 {
+	peer, _ := res.GetStoreLearner(ao.ToStore)
+	return typeutil.BoolToUint64(peer.ID == ao.PeerID && metadata.IsObserver(peer))
+}
+
+func (ao AddObserver) String() string {
+	return fmt.Sprintf("add observer peer %v on container %v", ao.PeerID, ao.ToStore)
+}
+
+// IsFinish checks if current step is finished.
+func (ao AddObserver) IsFinish(res *core.CachedShard) bool {
+	if peer, ok := res.GetStoreLearner(ao.ToStore); ok {
+		if peer.ID != ao.PeerID || !metadata.IsObserver(peer) {
+			return false
+		}
+		_, ok := res.GetPendingPeer(peer.ID)
+		return !ok
+	}
+	return false
+}
+
+// CheckSafety checks if the step meets the safety properties.
+func (ao AddObserver) CheckSafety(res *core.CachedShard) error {
+	peer, ok := res.GetStorePeer(ao.ToStore)
+	if !ok {
+		return nil
+	}
+	if peer.ID != ao.PeerID {
+		return fmt.Errorf("peer %d has already existed in container %d, the operator is trying to add peer %d on the same container", peer.ID, ao.ToStore, ao.PeerID)
+	}
+	if !metadata.IsObserver(peer) {
+		return errors.New("peer already is not an observer")
+	}
+	return nil
+}
+
+// Influence calculates the container difference that current step makes.
+func (ao AddObserver) Influence(opInfluence OpInfluence, res *core.CachedShard) {
+	to := opInfluence.GetStoreInfluence(ao.ToStore)
+
+	size := res.GetApproximateSize()
+	groupKey := res.GetGroupKey()
+	stats := to.InfluenceStats[groupKey]
+	stats.ShardSize += size
+	stats.ShardCount++
+	to.InfluenceStats[groupKey] = stats
+
+	to.AdjustStepCost(limit.AddPeer, size)
+}
+
 // RemovePeer is an OpStep that removes a resource peer.
 type RemovePeer struct {
 	FromStore, PeerID uint64
@@ -101,7 +101,7 @@ func (oc *OperatorController) GetCluster() opt.Cluster {
 // DispatchDestroyDirectly send DestroyDirect cmd to the current container, because
 // the resource has been removed.
 func (oc *OperatorController) DispatchDestroyDirectly(res *core.CachedShard, source string) {
-	oc.SendScheduleCommand(res, operator.DestroyDirectly{}, source)
+	oc.SendScheduleCommand(res, operator.DestroyDirectly{}, source, "")
 }
 
 // Dispatch is used to dispatch the operator of a resource.
@@ -119,7 +119,7 @@ func (oc *OperatorController) Dispatch(res *core.CachedShard, source string) {
 			if source == DispatchFromHeartBeat && oc.checkStaleOperator(op, step, res) {
 				return
 			}
-			oc.SendScheduleCommand(res, step, source)
+			oc.SendScheduleCommand(res, step, source, op.Desc())
 		case operator.SUCCESS:
 			oc.pushHistory(op)
 			if oc.RemoveOperator(op, "") {
@@ -128,6 +128,7 @@ func (oc *OperatorController) Dispatch(res *core.CachedShard, source string) {
 			}
 		case operator.TIMEOUT:
 			if oc.RemoveOperator(op, "") {
+				oc.rollbackOperator(op, res)
 				operatorCounter.WithLabelValues(op.Desc(), "promote-timeout").Inc()
 				oc.PromoteWaitingOperator()
 			}
@@ -150,6 +151,66 @@ func (oc *OperatorController) Dispatch(res *core.CachedShard, source string) {
 	}
 }
 
+// rollbackOperator adds a compensating operator for the timeout operator, which
+// removes the learners added by the operator but never promoted, otherwise the
+// learners are left in the resource until they are removed manually.
+func (oc *OperatorController) rollbackOperator(op *operator.Operator, res *core.CachedShard) {
+	if !oc.cluster.GetOpts().IsOperatorRollbackEnabled() {
+		return
+	}
+
+	promoted := make(map[uint64]struct{})
+	for i := 0; i < op.Len(); i++ {
+		switch step := op.Step(i).(type) {
+		case operator.PromoteLearner:
+			promoted[step.ToStore] = struct{}{}
+		case operator.ChangePeerV2Enter:
+			for _, pl := range step.PromoteLearners {
+				promoted[pl.ToStore] = struct{}{}
+			}
+		}
+	}
+
+	var containers []uint64
+	for i := 0; i < op.Len(); i++ {
+		al, ok := op.Step(i).(operator.AddLearner)
+		if !ok {
+			continue
+		}
+		// the learner is the target role of the operator if it's not promoted by
+		// the operator
+		if _, ok := promoted[al.ToStore]; !ok {
+			continue
+		}
+		if peer, ok := res.GetStoreLearner(al.ToStore); ok && peer.ID == al.PeerID {
+			containers = append(containers, al.ToStore)
+		}
+	}
+	if len(containers) == 0 {
+		return
+	}
+
+	b := operator.NewBuilder("rollback-"+op.Desc(), oc.cluster, res)
+	for _, id := range containers {
+		b.RemovePeer(id)
+	}
+	rollback, err := b.Build(operator.OpShard | operator.OpAdmin)
+	if err != nil {
+		oc.cluster.GetLogger().Error("fail to create rollback operator",
+			log.ResourceField(op.ShardID()),
+			zap.Stringer("op", op),
+			zap.Error(err))
+		return
+	}
+	if oc.AddOperator(rollback) {
+		oc.cluster.GetLogger().Info("rollback timeout operator",
+			log.ResourceField(op.ShardID()),
+			zap.Stringer("op", op),
+			zap.Uint64s("learner-containers", containers))
+		operatorCounter.WithLabelValues(op.Desc(), "rollback").Inc()
+	}
+}
+
 func (oc *OperatorController) checkStaleOperator(op *operator.Operator, step operator.OpStep, res *core.CachedShard) bool {
 	err := step.CheckSafety(res)
 	if err != nil {
@@ -438,6 +499,7 @@ func (oc *OperatorController) addOperatorLocked(op *operator.Operator) bool {
 		oc.buryOperator(old, "")
 	}
 
+	op.SetStepTimeout(operator.GetStepTimeout(op.Kind(), oc.cluster.GetOpts().GetOperatorStepTimeouts()))
 	if !op.Start() {
 		oc.cluster.GetLogger().Error("resource adding operator with unexpected status",
 			log.ResourceField(resID),
@@ -472,7 +534,7 @@ func (oc *OperatorController) addOperatorLocked(op *operator.Operator) bool {
 	var step operator.OpStep
 	if res := oc.cluster.GetShard(op.ShardID()); res != nil {
 		if step = op.Check(res); step != nil {
-			oc.SendScheduleCommand(res, step, DispatchFromCreate)
+			oc.SendScheduleCommand(res, step, DispatchFromCreate, op.Desc())
 		}
 	}
 
@@ -609,12 +671,15 @@ func (oc *OperatorController) GetWaitingOperators() []*operator.Operator {
 	return oc.wop.ListOperator()
 }
 
-// SendScheduleCommand sends a command to the resource.
-func (oc *OperatorController) SendScheduleCommand(res *core.CachedShard, step operator.OpStep, source string) {
+// SendScheduleCommand sends a command to the resource. The initiator is the
+// description of the operator, it's recorded in the config change history of
+// the shard.
+func (oc *OperatorController) SendScheduleCommand(res *core.CachedShard, step operator.OpStep, source string, initiator string) {
 	oc.cluster.GetLogger().Info("resource send schedule command",
 		log.ResourceField(res.Meta.GetID()),
 		zap.Stringer("step", step),
-		zap.String("source", source))
+		zap.String("source", source),
+		zap.String("initiator", initiator))
 
 	var cmd *rpcpb.ShardHeartbeatRsp
 	switch st := step.(type) {
@@ -696,6 +761,36 @@ func (oc *OperatorController) SendScheduleCommand(res *core.CachedShard, step op
 				},
 			},
 		}
+	case operator.AddWitness:
+		if _, ok := res.GetStorePeer(st.ToStore); ok {
+			// The newly added peer is pending.
+			return
+		}
+		cmd = &rpcpb.ShardHeartbeatRsp{
+			ConfigChange: &rpcpb.ConfigChange{
+				ChangeType: metapb.ConfigChangeType_AddNode,
+				Replica: metapb.Replica{
+					ID:      st.PeerID,
+					StoreID: st.ToStore,
+					Role:    metapb.ReplicaRole_Witness,
+				},
+			},
+		}
+	case operator.AddObserver:
+		if _, ok := res.GetStorePeer(st.ToStore); ok {
+			// The newly added peer is pending.
+			return
+		}
+		cmd = &rpcpb.ShardHeartbeatRsp{
+			ConfigChange: &rpcpb.ConfigChange{
+				ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+				Replica: metapb.Replica{
+					ID:      st.PeerID,
+					StoreID: st.ToStore,
+					Role:    metapb.ReplicaRole_Observer,
+				},
+			},
+		}
 	case operator.PromoteLearner:
 		cmd = &rpcpb.ShardHeartbeatRsp{
 			ConfigChange: &rpcpb.ConfigChange{
@@ -759,6 +854,9 @@ func (oc *OperatorController) SendScheduleCommand(res *core.CachedShard, step op
 		return
 	}
 
+	if cmd.ConfigChange != nil {
+		cmd.ConfigChange.Initiator = initiator
+	}
 	oc.hbStreams.SendMsg(res, cmd)
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/checker"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/hbstream"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/stretchr/testify/assert"
 )
@@ -132,6 +133,86 @@ func TestOperatorStatus(t *testing.T) {
 	assert.Equal(t, oc.GetOperatorStatus(2).Status, metapb.OperatorStatus_SUCCESS)
 }
 
+func TestRollbackTimeoutOperator(t *testing.T) {
+	s := &testOperatorController{}
+	s.setup(t)
+	defer s.tearDown()
+
+	opt := config.NewTestOptions()
+	tc := mockcluster.NewCluster(opt)
+	stream := hbstream.NewTestHeartbeatStreams(s.ctx, tc.ID, tc, false /* no need to run */, nil)
+	oc := NewOperatorController(s.ctx, tc, stream)
+	tc.AddLeaderStore(1, 1)
+	tc.AddLeaderStore(2, 0)
+	tc.AddLeaderStore(3, 0)
+	tc.AddLeaderShard(1, 1, 2)
+	tc.AddLeaderShard(2, 1, 2)
+
+	newOp := func(id uint64) *operator.Operator {
+		return operator.NewOperator("test", "test", id, metapb.ShardEpoch{}, operator.OpShard,
+			operator.AddLearner{ToStore: 3, PeerID: 10 + id},
+			operator.PromoteLearner{ToStore: 3, PeerID: 10 + id})
+	}
+	addLearner := func(id uint64) *core.CachedShard {
+		res := tc.GetShard(id).Clone(core.WithAddPeer(metapb.Replica{ID: 10 + id, StoreID: 3, Role: metapb.ReplicaRole_Learner}))
+		tc.PutShard(res)
+		return res
+	}
+
+	// the learner is removed once the operator is timeout
+	op := newOp(1)
+	assert.True(t, op.Start())
+	oc.SetOperator(op)
+	res := addLearner(1)
+	operator.SetOperatorStatusReachTime(op, operator.STARTED, time.Now().Add(-operator.SlowOperatorWaitTime))
+	oc.Dispatch(res, "test")
+	assert.Equal(t, operator.TIMEOUT, op.Status())
+	rollback := oc.GetOperator(1)
+	assert.NotNil(t, rollback)
+	assert.Equal(t, operator.RemovePeer{FromStore: 3, PeerID: 11}, rollback.Step(0))
+
+	// the rollback is disabled
+	cfg := tc.GetOpts().GetScheduleConfig().Clone()
+	cfg.EnableOperatorRollback = false
+	tc.GetOpts().SetScheduleConfig(cfg)
+	op = newOp(2)
+	assert.True(t, op.Start())
+	oc.SetOperator(op)
+	res = addLearner(2)
+	operator.SetOperatorStatusReachTime(op, operator.STARTED, time.Now().Add(-operator.SlowOperatorWaitTime))
+	oc.Dispatch(res, "test")
+	assert.Equal(t, operator.TIMEOUT, op.Status())
+	assert.Nil(t, oc.GetOperator(2))
+}
+
+func TestOperatorStepTimeout(t *testing.T) {
+	s := &testOperatorController{}
+	s.setup(t)
+	defer s.tearDown()
+
+	opt := config.NewTestOptions()
+	cfg := opt.GetScheduleConfig().Clone()
+	cfg.OperatorStepTimeouts = map[string]typeutil.Duration{"replica": typeutil.NewDuration(time.Minute)}
+	opt.SetScheduleConfig(cfg)
+	tc := mockcluster.NewCluster(opt)
+	stream := hbstream.NewTestHeartbeatStreams(s.ctx, tc.ID, tc, false /* no need to run */, nil)
+	oc := NewOperatorController(s.ctx, tc, stream)
+	tc.AddLeaderStore(1, 1)
+	tc.AddLeaderStore(2, 0)
+	tc.AddLeaderShard(1, 1)
+
+	op := operator.NewOperator("test", "test", 1, metapb.ShardEpoch{}, operator.OpReplica|operator.OpShard,
+		operator.AddLearner{ToStore: 2, PeerID: 2},
+		operator.PromoteLearner{ToStore: 2, PeerID: 2})
+	assert.True(t, oc.AddOperator(op))
+	operator.SetOperatorStatusReachTime(op, operator.STARTED, time.Now().Add(-30*time.Second))
+	oc.Dispatch(tc.GetShard(1), "test")
+	assert.Equal(t, operator.STARTED, op.Status())
+	operator.SetOperatorStatusReachTime(op, operator.STARTED, time.Now().Add(-2*time.Minute))
+	oc.Dispatch(tc.GetShard(1), "test")
+	assert.Equal(t, operator.TIMEOUT, op.Status())
+}
+
 func TestFastFailOperator(t *testing.T) {
 	s := &testOperatorController{}
 	s.setup(t)
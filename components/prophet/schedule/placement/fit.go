@@ -272,13 +272,19 @@ type fitPeer struct {
 func (p *fitPeer) matchRoleStrict(role ReplicaRoleType) bool {
 	switch role {
 	case Voter: // Voter matches either Leader or Follower.
-		return !metadata.IsLearner(p.Replica)
+		return !metadata.IsLearner(p.Replica) && !metadata.IsWitness(p.Replica) &&
+			!metadata.IsObserver(p.Replica)
 	case Leader:
-		return p.isLeader
+		return p.isLeader && !metadata.IsWitness(p.Replica)
 	case Follower:
-		return !metadata.IsLearner(p.Replica) && !p.isLeader
+		return !metadata.IsLearner(p.Replica) && !metadata.IsWitness(p.Replica) &&
+			!metadata.IsObserver(p.Replica) && !p.isLeader
 	case Learner:
 		return metadata.IsLearner(p.Replica)
+	case Witness:
+		return metadata.IsWitness(p.Replica)
+	case Observer:
+		return metadata.IsObserver(p.Replica)
 	}
 	return false
 }
@@ -286,7 +292,15 @@ func (p *fitPeer) matchRoleStrict(role ReplicaRoleType) bool {
 func (p *fitPeer) matchRoleLoose(role ReplicaRoleType) bool {
 	// non-learner cannot become learner. All other roles can migrate to
 	// others by scheduling. For example, Leader->Follower, Learner->Leader
-	// are possible, but Voter->Learner is impossible.
+	// are possible, but Voter->Learner is impossible. The witness has no user
+	// data, so it can neither become nor be converted from other roles. The
+	// observer is never promoted, it is replaced instead.
+	if role == Witness || metadata.IsWitness(p.Replica) {
+		return role == Witness && metadata.IsWitness(p.Replica)
+	}
+	if role == Observer || metadata.IsObserver(p.Replica) {
+		return role == Observer && metadata.IsObserver(p.Replica)
+	}
 	return role != Learner || metadata.IsLearner(p.Replica)
 }
 
@@ -35,6 +35,10 @@ const (
 	Follower ReplicaRoleType = "follower"
 	// Learner matches a learner.
 	Learner ReplicaRoleType = "learner"
+	// Witness matches a witness, which votes but stores no user data.
+	Witness ReplicaRoleType = "witness"
+	// Observer matches an observer, which is a learner never promoted.
+	Observer ReplicaRoleType = "observer"
 )
 
 func getReplicaRoleTypeFromRPC(tpe rpcpb.ReplicaRoleType) ReplicaRoleType {
@@ -47,18 +51,28 @@ func getReplicaRoleTypeFromRPC(tpe rpcpb.ReplicaRoleType) ReplicaRoleType {
 		return Follower
 	case rpcpb.Learner:
 		return Learner
+	case rpcpb.Witness:
+		return Witness
+	case rpcpb.Observer:
+		return Observer
 	}
 	return Voter
 }
 
 func validateRole(s ReplicaRoleType) bool {
-	return s == Voter || s == Leader || s == Follower || s == Learner
+	return s == Voter || s == Leader || s == Follower || s == Learner || s == Witness ||
+		s == Observer
 }
 
 // MetaPeerRole converts placement.ReplicaRoleType to metapb.PeerRole.
 func (s ReplicaRoleType) MetaPeerRole() metapb.ReplicaRole {
-	if s == Learner {
+	switch s {
+	case Learner:
 		return metapb.ReplicaRole_Learner
+	case Witness:
+		return metapb.ReplicaRole_Witness
+	case Observer:
+		return metapb.ReplicaRole_Observer
 	}
 	return metapb.ReplicaRole_Voter
 }
@@ -74,6 +88,10 @@ func (s ReplicaRoleType) RPCPeerRole() rpcpb.ReplicaRoleType {
 		return rpcpb.Follower
 	case Learner:
 		return rpcpb.Learner
+	case Witness:
+		return rpcpb.Witness
+	case Observer:
+		return rpcpb.Observer
 	}
 	return rpcpb.Voter
 }
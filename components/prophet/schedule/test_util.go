@@ -78,6 +78,26 @@ func ApplyOperatorStep(resource *core.CachedShard, op *operator.Operator) *core.
 				Role:    metapb.ReplicaRole_Learner,
 			}
 			resource = resource.Clone(core.WithAddPeer(peer))
+		case operator.AddWitness:
+			if _, ok := resource.GetStorePeer(s.ToStore); ok {
+				panic("Add witness that exists")
+			}
+			peer := metapb.Replica{
+				ID:      s.PeerID,
+				StoreID: s.ToStore,
+				Role:    metapb.ReplicaRole_Witness,
+			}
+			resource = resource.Clone(core.WithAddPeer(peer))
+		case operator.AddObserver:
+			if _, ok := resource.GetStorePeer(s.ToStore); ok {
+				panic("Add observer that exists")
+			}
+			peer := metapb.Replica{
+				ID:      s.PeerID,
+				StoreID: s.ToStore,
+				Role:    metapb.ReplicaRole_Observer,
+			}
+			resource = resource.Clone(core.WithAddPeer(peer))
 		case operator.PromoteLearner:
 			if _, ok := resource.GetStoreLearner(s.ToStore); !ok {
 				panic("Promote peer that doesn't exist")
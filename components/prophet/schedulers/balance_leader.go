@@ -24,6 +24,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/filter"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
 	"github.com/matrixorigin/matrixcube/components/prophet/schedule/opt"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/placement"
 	"github.com/matrixorigin/matrixcube/components/prophet/storage"
 	"github.com/matrixorigin/matrixcube/components/prophet/util"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
@@ -67,13 +68,17 @@ func init() {
 }
 
 type balanceLeaderSchedulerConfig struct {
-	Name        string                     `json:"name"`
-	Ranges      []core.KeyRange            `json:"ranges"`
+	Name   string          `json:"name"`
+	Ranges []core.KeyRange `json:"ranges"`
+	// ZoneLabel the leaders are only transferred between the stores with the
+	// same value of the label if it's set
+	ZoneLabel   string                     `json:"zone-label,omitempty"`
 	groupRanges map[uint64][]core.KeyRange `json:"-"`
 }
 
 type balanceLeaderScheduler struct {
 	*BaseScheduler
+	tp            string
 	conf          *balanceLeaderSchedulerConfig
 	opController  *schedule.OperatorController
 	filters       []filter.Filter
@@ -88,6 +93,7 @@ func newBalanceLeaderScheduler(opController *schedule.OperatorController, conf *
 	conf.groupRanges = groupKeyRanges(conf.Ranges, opController.GetCluster().GetOpts().GetReplicationConfig().Groups)
 	s := &balanceLeaderScheduler{
 		BaseScheduler: base,
+		tp:            BalanceLeaderType,
 		conf:          conf,
 		opController:  opController,
 		counter:       balanceLeaderCounter,
@@ -125,7 +131,7 @@ func (l *balanceLeaderScheduler) GetName() string {
 }
 
 func (l *balanceLeaderScheduler) GetType() string {
-	return BalanceLeaderType
+	return l.tp
 }
 
 func (l *balanceLeaderScheduler) EncodeConfig() ([]byte, error) {
@@ -230,10 +236,7 @@ func (l *balanceLeaderScheduler) transferLeaderOut(groupKey string, cluster opt.
 		return nil
 	}
 	targets := cluster.GetFollowerStores(resource)
-	finalFilters := l.filters
-	if leaderFilter := filter.NewPlacementLeaderSafeguard(l.GetName(), cluster, resource, source); leaderFilter != nil {
-		finalFilters = append(l.filters, leaderFilter)
-	}
+	finalFilters := l.getFinalFilters(cluster, resource, source)
 	targets = filter.SelectTargetStores(targets, finalFilters, cluster.GetOpts())
 	leaderSchedulePolicy := l.opController.GetLeaderSchedulePolicy()
 	sort.Slice(targets, func(i, j int) bool {
@@ -285,10 +288,7 @@ func (l *balanceLeaderScheduler) transferLeaderIn(groupKey string, cluster opt.C
 	targets := []*core.CachedStore{
 		target,
 	}
-	finalFilters := l.filters
-	if leaderFilter := filter.NewPlacementLeaderSafeguard(l.GetName(), cluster, resource, source); leaderFilter != nil {
-		finalFilters = append(l.filters, leaderFilter)
-	}
+	finalFilters := l.getFinalFilters(cluster, resource, source)
 	targets = filter.SelectTargetStores(targets, finalFilters, cluster.GetOpts())
 	if len(targets) < 1 {
 		cluster.GetLogger().Debug("selected random follower resource has no target container",
@@ -301,6 +301,25 @@ func (l *balanceLeaderScheduler) transferLeaderIn(groupKey string, cluster opt.C
 	return l.createOperator(cluster, resource, source, targets[0])
 }
 
+// getFinalFilters returns the filters of the target containers to transfer the
+// leader of the resource from the source container.
+func (l *balanceLeaderScheduler) getFinalFilters(cluster opt.Cluster, res *core.CachedShard, source *core.CachedStore) []filter.Filter {
+	finalFilters := l.filters
+	if leaderFilter := filter.NewPlacementLeaderSafeguard(l.GetName(), cluster, res, source); leaderFilter != nil {
+		finalFilters = append(finalFilters, leaderFilter)
+	}
+	if l.conf.ZoneLabel != "" {
+		// the source container without the zone label never matches any target
+		finalFilters = append(finalFilters,
+			filter.NewLabelConstaintFilter(l.GetName(), []placement.LabelConstraint{{
+				Key:    l.conf.ZoneLabel,
+				Op:     placement.In,
+				Values: []string{source.GetLabelValue(l.conf.ZoneLabel)},
+			}}))
+	}
+	return finalFilters
+}
+
 // createOperator creates the operator according to the source and target container.
 // If the resource is hot or the difference between the two containers is tolerable, then
 // no new operator need to be created, otherwise create an operator that transfers
@@ -0,0 +1,304 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/filter"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/opt"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/components/prophet/util"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// ReadLatencyLeaderName is read latency leader scheduler name.
+	ReadLatencyLeaderName = "read-latency-leader-scheduler"
+	// ReadLatencyLeaderType is read latency leader scheduler type.
+	ReadLatencyLeaderType = "read-latency-leader"
+	// defaultReadLatencyMoveBudget is the default max number of the pending
+	// leader transfers created by the scheduler
+	defaultReadLatencyMoveBudget = 4
+)
+
+func init() {
+	// args: target latency, e.g. 10ms, and the optional move budget
+	schedule.RegisterSliceDecoderBuilder(ReadLatencyLeaderType, func(args []string) schedule.ConfigDecoder {
+		return func(v interface{}) error {
+			conf, ok := v.(*readLatencyLeaderSchedulerConfig)
+			if !ok {
+				return errors.New("scheduler error configuration")
+			}
+			if len(args) == 0 || len(args) > 2 {
+				return errors.New("scheduler error configuration")
+			}
+			target, err := time.ParseDuration(args[0])
+			if err != nil || target <= 0 {
+				return errors.New("scheduler error configuration")
+			}
+			conf.TargetLatency = typeutil.NewDuration(target)
+			conf.MoveBudget = defaultReadLatencyMoveBudget
+			if len(args) == 2 {
+				budget, err := strconv.ParseUint(args[1], 10, 64)
+				if err != nil || budget == 0 {
+					return errors.New("scheduler error configuration")
+				}
+				conf.MoveBudget = budget
+			}
+			conf.Name = ReadLatencyLeaderName
+			return nil
+		}
+	})
+
+	schedule.RegisterScheduler(ReadLatencyLeaderType, func(opController *schedule.OperatorController, storage storage.Storage, decoder schedule.ConfigDecoder) (schedule.Scheduler, error) {
+		conf := &readLatencyLeaderSchedulerConfig{}
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		return newReadLatencyLeaderScheduler(opController, conf), nil
+	})
+}
+
+type readLatencyLeaderSchedulerConfig struct {
+	Name string `json:"name"`
+	// TargetLatency is the read latency SLO of the shards, the leaders of the
+	// shards exceeding it are moved out of the loaded stores.
+	TargetLatency typeutil.Duration `json:"target-latency"`
+	// MoveBudget is the max number of the pending leader transfers created by
+	// the scheduler.
+	MoveBudget uint64 `json:"move-budget"`
+}
+
+// readLatencyStoreLoad is the read latency of the leader shards of a store in
+// a schedule group.
+type readLatencyStoreLoad struct {
+	storeID      uint64
+	leaders      int
+	latencySum   uint64
+	latencyCount uint64
+	// hotShards the leader shards exceeding the target latency
+	hotShards []*core.CachedShard
+}
+
+// avgLatency returns the average read latency of the leader shards with reads
+func (l *readLatencyStoreLoad) avgLatency() uint64 {
+	if l.latencyCount == 0 {
+		return 0
+	}
+	return l.latencySum / l.latencyCount
+}
+
+func (l *readLatencyStoreLoad) add(shard *core.CachedShard) {
+	l.leaders++
+	if latency := shard.GetReadLatency(); latency > 0 {
+		l.latencySum += latency
+		l.latencyCount++
+	}
+}
+
+type readLatencyLeaderScheduler struct {
+	*BaseScheduler
+	conf    *readLatencyLeaderSchedulerConfig
+	filters []filter.Filter
+}
+
+// newReadLatencyLeaderScheduler creates a scheduler that transfers the leaders
+// of the shards exceeding the read latency target to the less loaded stores.
+func newReadLatencyLeaderScheduler(opController *schedule.OperatorController, conf *readLatencyLeaderSchedulerConfig) schedule.Scheduler {
+	return &readLatencyLeaderScheduler{
+		BaseScheduler: NewBaseScheduler(opController),
+		conf:          conf,
+		filters: []filter.Filter{
+			&filter.StoreStateFilter{ActionScope: conf.Name, TransferLeader: true},
+			filter.NewSpecialUseFilter(conf.Name),
+		},
+	}
+}
+
+func (s *readLatencyLeaderScheduler) GetName() string {
+	return s.conf.Name
+}
+
+func (s *readLatencyLeaderScheduler) GetType() string {
+	return ReadLatencyLeaderType
+}
+
+func (s *readLatencyLeaderScheduler) EncodeConfig() ([]byte, error) {
+	return schedule.EncodeConfig(s.conf)
+}
+
+func (s *readLatencyLeaderScheduler) IsScheduleAllowed(cluster opt.Cluster) bool {
+	allowed := s.OpController.OperatorCount(operator.OpLeader) < cluster.GetOpts().GetLeaderScheduleLimit()
+	if !allowed {
+		operator.OperatorLimitCounter.WithLabelValues(s.GetType(), operator.OpLeader.String()).Inc()
+		return false
+	}
+	pending, _ := s.pendingMoves()
+	allowed = pending < s.conf.MoveBudget
+	if !allowed {
+		operator.OperatorLimitCounter.WithLabelValues(s.GetType(), operator.OpHotShard.String()).Inc()
+	}
+	return allowed
+}
+
+func (s *readLatencyLeaderScheduler) Schedule(cluster opt.Cluster) []*operator.Operator {
+	schedulerCounter.WithLabelValues(s.GetName(), "schedule").Inc()
+	pending, waiting := s.pendingMoves()
+	if pending >= s.conf.MoveBudget {
+		return nil
+	}
+	budget := int(s.conf.MoveBudget - pending)
+
+	var ops []*operator.Operator
+	for _, groupKey := range cluster.GetScheduleGroupKeys() {
+		ops = append(ops, s.scheduleByGroup(groupKey, cluster, waiting, budget-len(ops))...)
+		if len(ops) >= budget {
+			break
+		}
+	}
+	return ops
+}
+
+// pendingMoves returns the number of the running and the waiting leader
+// transfers created by the scheduler, and the shards of the waiting ones.
+func (s *readLatencyLeaderScheduler) pendingMoves() (uint64, map[uint64]struct{}) {
+	waiting := make(map[uint64]struct{})
+	for _, op := range s.OpController.GetWaitingOperators() {
+		if op.Desc() == ReadLatencyLeaderType {
+			waiting[op.ShardID()] = struct{}{}
+		}
+	}
+	return s.OpController.OperatorCount(operator.OpHotShard) + uint64(len(waiting)), waiting
+}
+
+func (s *readLatencyLeaderScheduler) scheduleByGroup(groupKey string, cluster opt.Cluster,
+	waiting map[uint64]struct{}, budget int) []*operator.Operator {
+	target := uint64(s.conf.TargetLatency.Microseconds())
+	loads := s.collectStoreLoads(groupKey, cluster, target)
+
+	var hotStores []*readLatencyStoreLoad
+	for _, load := range loads {
+		if len(load.hotShards) > 0 {
+			hotStores = append(hotStores, load)
+		}
+	}
+	if len(hotStores) == 0 {
+		schedulerCounter.WithLabelValues(s.GetName(), "no-hot-store").Inc()
+		return nil
+	}
+	sort.Slice(hotStores, func(i, j int) bool {
+		if hotStores[i].avgLatency() != hotStores[j].avgLatency() {
+			return hotStores[i].avgLatency() > hotStores[j].avgLatency()
+		}
+		return hotStores[i].storeID < hotStores[j].storeID
+	})
+
+	var ops []*operator.Operator
+	for _, source := range hotStores {
+		for _, shard := range source.hotShards {
+			if len(ops) >= budget {
+				return ops
+			}
+			if _, ok := waiting[shard.Meta.GetID()]; ok ||
+				s.OpController.GetOperator(shard.Meta.GetID()) != nil {
+				continue
+			}
+			dest := s.selectTarget(cluster, shard, loads, target)
+			if dest == nil {
+				schedulerCounter.WithLabelValues(s.GetName(), "no-target-store").Inc()
+				continue
+			}
+
+			op, err := operator.CreateTransferLeaderOperator(ReadLatencyLeaderType, cluster, shard,
+				source.storeID, dest.storeID, operator.OpHotShard)
+			if err != nil {
+				cluster.GetLogger().Error("fail to create read latency leader operator",
+					rebalanceHotField,
+					shardField(shard.Meta.GetID()),
+					zap.Error(err))
+				continue
+			}
+			op.Counters = append(op.Counters, schedulerCounter.WithLabelValues(s.GetName(), "new-operator"))
+			ops = append(ops, op)
+
+			// the moved shard is expected to bring its reads to the target store
+			source.leaders--
+			dest.add(shard)
+		}
+	}
+	return ops
+}
+
+// collectStoreLoads returns the read latency loads of the stores which have
+// the leaders of the group, the hot shards are ordered by the latency.
+func (s *readLatencyLeaderScheduler) collectStoreLoads(groupKey string, cluster opt.Cluster, target uint64) map[uint64]*readLatencyStoreLoad {
+	loads := make(map[uint64]*readLatencyStoreLoad)
+	healthy := opt.HealthShard(cluster)
+	for _, shard := range cluster.ScanShards(util.DecodeGroupKey(groupKey), nil, nil, 0) {
+		if shard.GetGroupKey() != groupKey || shard.GetLeader() == nil {
+			continue
+		}
+		storeID := shard.GetLeader().GetStoreID()
+		load, ok := loads[storeID]
+		if !ok {
+			load = &readLatencyStoreLoad{storeID: storeID}
+			loads[storeID] = load
+		}
+		load.add(shard)
+		if shard.GetReadLatency() > target && healthy(shard) {
+			load.hotShards = append(load.hotShards, shard)
+		}
+	}
+	for _, load := range loads {
+		sort.Slice(load.hotShards, func(i, j int) bool {
+			return load.hotShards[i].GetReadLatency() > load.hotShards[j].GetReadLatency()
+		})
+	}
+	return loads
+}
+
+// selectTarget returns the least loaded follower store of the shard, whose
+// average read latency is under the target.
+func (s *readLatencyLeaderScheduler) selectTarget(cluster opt.Cluster, shard *core.CachedShard,
+	loads map[uint64]*readLatencyStoreLoad, target uint64) *readLatencyStoreLoad {
+	candidates := filter.NewCandidates(cluster.GetFollowerStores(shard)).
+		FilterTarget(cluster.GetOpts(), s.filters...)
+
+	var dest *readLatencyStoreLoad
+	for _, store := range candidates.Stores {
+		id := store.Meta.GetID()
+		load, ok := loads[id]
+		if !ok {
+			load = &readLatencyStoreLoad{storeID: id}
+			loads[id] = load
+		}
+		if len(load.hotShards) > 0 || load.avgLatency() >= target {
+			continue
+		}
+		if dest == nil ||
+			load.avgLatency() < dest.avgLatency() ||
+			(load.avgLatency() == dest.avgLatency() && load.leaders < dest.leaders) {
+			dest = load
+		}
+	}
+	return dest
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/core"
+	"github.com/matrixorigin/matrixcube/components/prophet/mock/mockcluster"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/hbstream"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/components/prophet/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func addReadLatencyShard(tc *mockcluster.Cluster, id uint64, latency uint64, leader uint64, followers ...uint64) {
+	res := tc.AddLeaderShard(id, leader, followers...)
+	tc.PutShard(res.Clone(core.SetReadLatency(latency)))
+}
+
+func TestReadLatencyLeader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opt := config.NewTestOptions()
+	tc := mockcluster.NewCluster(opt)
+	stream := hbstream.NewTestHeartbeatStreams(ctx, tc.ID, tc, false /* no need to run */, nil)
+	oc := schedule.NewOperatorController(ctx, tc, stream)
+
+	s, err := schedule.CreateScheduler(ReadLatencyLeaderType, oc, storage.NewTestStorage(),
+		schedule.ConfigSliceDecoder(ReadLatencyLeaderType, []string{"10ms", "2"}))
+	assert.NoError(t, err)
+	assert.Empty(t, s.Schedule(tc))
+
+	tc.AddLeaderStore(1, 3)
+	tc.AddLeaderStore(2, 1)
+	tc.AddLeaderStore(3, 1)
+	addReadLatencyShard(tc, 1, 50000, 1, 2, 3)
+	addReadLatencyShard(tc, 2, 20000, 1, 2, 3)
+	addReadLatencyShard(tc, 3, 1000, 1, 2, 3)
+	addReadLatencyShard(tc, 4, 2000, 2, 1, 3)
+	addReadLatencyShard(tc, 5, 500, 3, 1, 2)
+
+	// the hottest shard is moved to the least loaded store 3, then store 3 is
+	// over the target, the second hot shard is moved to store 2
+	assert.True(t, s.IsScheduleAllowed(tc))
+	ops := s.Schedule(tc)
+	assert.Equal(t, 2, len(ops))
+	testutil.CheckTransferLeader(t, ops[0], operator.OpHotShard, 1, 3)
+	assert.Equal(t, uint64(1), ops[0].ShardID())
+	testutil.CheckTransferLeader(t, ops[1], operator.OpHotShard, 1, 2)
+	assert.Equal(t, uint64(2), ops[1].ShardID())
+
+	// the pending transfers use up the move budget
+	assert.Equal(t, 2, oc.AddWaitingOperator(ops...))
+	assert.False(t, s.IsScheduleAllowed(tc))
+	assert.Empty(t, s.Schedule(tc))
+}
+
+func TestReadLatencyLeaderNoTarget(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opt := config.NewTestOptions()
+	tc := mockcluster.NewCluster(opt)
+
+	s, err := schedule.CreateScheduler(ReadLatencyLeaderType, schedule.NewOperatorController(ctx, tc, nil),
+		storage.NewTestStorage(), schedule.ConfigSliceDecoder(ReadLatencyLeaderType, []string{"10ms"}))
+	assert.NoError(t, err)
+
+	tc.AddLeaderStore(1, 1)
+	tc.AddLeaderStore(2, 1)
+	tc.AddLeaderStore(3, 1)
+	addReadLatencyShard(tc, 1, 15000, 1, 2, 3)
+	addReadLatencyShard(tc, 2, 30000, 2, 1, 3)
+	addReadLatencyShard(tc, 3, 12000, 3, 1, 2)
+	// all the stores are over the target
+	assert.Empty(t, s.Schedule(tc))
+
+	// no shard is over the target
+	for id := uint64(1); id <= 3; id++ {
+		tc.PutShard(tc.GetShard(id).Clone(core.SetReadLatency(3000)))
+	}
+	assert.Empty(t, s.Schedule(tc))
+
+	// the target store can not accept leaders
+	tc.PutShard(tc.GetShard(2).Clone(core.SetReadLatency(30000)))
+	assert.NoError(t, tc.PauseLeaderTransfer(1))
+	assert.NoError(t, tc.PauseLeaderTransfer(3))
+	assert.Empty(t, s.Schedule(tc))
+	tc.ResumeLeaderTransfer(3)
+	ops := s.Schedule(tc)
+	assert.Equal(t, 1, len(ops))
+	testutil.CheckTransferLeader(t, ops[0], operator.OpHotShard, 2, 3)
+}
+
+func TestReadLatencyLeaderConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opt := config.NewTestOptions()
+	tc := mockcluster.NewCluster(opt)
+	oc := schedule.NewOperatorController(ctx, tc, nil)
+
+	for _, args := range [][]string{nil, {"10"}, {"0s"}, {"10ms", "0"}, {"10ms", "x"}, {"10ms", "1", "2"}} {
+		_, err := schedule.CreateScheduler(ReadLatencyLeaderType, oc, storage.NewTestStorage(),
+			schedule.ConfigSliceDecoder(ReadLatencyLeaderType, args))
+		assert.Error(t, err, "args %v", args)
+	}
+
+	s, err := schedule.CreateScheduler(ReadLatencyLeaderType, oc, storage.NewTestStorage(),
+		schedule.ConfigSliceDecoder(ReadLatencyLeaderType, []string{"10ms"}))
+	assert.NoError(t, err)
+	assert.Equal(t, ReadLatencyLeaderName, s.GetName())
+	data, err := s.EncodeConfig()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"read-latency-leader-scheduler","target-latency":"10ms","move-budget":4}`, string(data))
+}
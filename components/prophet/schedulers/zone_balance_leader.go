@@ -0,0 +1,68 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"errors"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+)
+
+const (
+	// ZoneBalanceLeaderName is zone balance leader scheduler name.
+	ZoneBalanceLeaderName = "zone-balance-leader-scheduler"
+	// ZoneBalanceLeaderType is zone balance leader scheduler type.
+	ZoneBalanceLeaderType = "zone-balance-leader"
+	// defaultZoneLabel is the default label key of the zone
+	defaultZoneLabel = "zone"
+)
+
+func init() {
+	// args: the optional label key of the zone, "zone" by default
+	schedule.RegisterSliceDecoderBuilder(ZoneBalanceLeaderType, func(args []string) schedule.ConfigDecoder {
+		return func(v interface{}) error {
+			conf, ok := v.(*balanceLeaderSchedulerConfig)
+			if !ok {
+				return errors.New("scheduler not found")
+			}
+			if len(args) > 1 || (len(args) == 1 && args[0] == "") {
+				return errors.New("scheduler error configuration")
+			}
+			conf.ZoneLabel = defaultZoneLabel
+			if len(args) == 1 {
+				conf.ZoneLabel = args[0]
+			}
+			conf.Name = ZoneBalanceLeaderName
+			return nil
+		}
+	})
+
+	schedule.RegisterScheduler(ZoneBalanceLeaderType, func(opController *schedule.OperatorController, storage storage.Storage, decoder schedule.ConfigDecoder) (schedule.Scheduler, error) {
+		conf := &balanceLeaderSchedulerConfig{}
+		if err := decoder(conf); err != nil {
+			return nil, err
+		}
+		return newZoneBalanceLeaderScheduler(opController, conf), nil
+	})
+}
+
+// newZoneBalanceLeaderScheduler creates a scheduler that keeps the leaders
+// balanced among the containers in the same zone, the leaders are never
+// transferred across the zones.
+func newZoneBalanceLeaderScheduler(opController *schedule.OperatorController, conf *balanceLeaderSchedulerConfig) schedule.Scheduler {
+	s := newBalanceLeaderScheduler(opController, conf).(*balanceLeaderScheduler)
+	s.tp = ZoneBalanceLeaderType
+	return s
+}
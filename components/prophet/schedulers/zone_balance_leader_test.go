@@ -0,0 +1,85 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/config"
+	"github.com/matrixorigin/matrixcube/components/prophet/mock/mockcluster"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/hbstream"
+	"github.com/matrixorigin/matrixcube/components/prophet/schedule/operator"
+	"github.com/matrixorigin/matrixcube/components/prophet/storage"
+	"github.com/matrixorigin/matrixcube/components/prophet/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoneBalanceLeader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	opt := config.NewTestOptions()
+	tc := mockcluster.NewCluster(opt)
+	stream := hbstream.NewTestHeartbeatStreams(ctx, tc.ID, tc, false /* no need to run */, nil)
+	oc := schedule.NewOperatorController(ctx, tc, stream)
+
+	s, err := schedule.CreateScheduler(ZoneBalanceLeaderType, oc, storage.NewTestStorage(),
+		schedule.ConfigSliceDecoder(ZoneBalanceLeaderType, []string{"az"}))
+	assert.NoError(t, err)
+	assert.Equal(t, ZoneBalanceLeaderType, s.GetType())
+	assert.Equal(t, ZoneBalanceLeaderName, s.GetName())
+	data, err := s.EncodeConfig()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"zone-label":"az"`)
+
+	tc.AddLabelsStore(1, 2, map[string]string{"az": "z1"})
+	tc.AddLabelsStore(2, 2, map[string]string{"az": "z1"})
+	tc.AddLabelsStore(3, 2, map[string]string{"az": "z2"})
+	tc.AddLabelsStore(4, 2, map[string]string{"az": "z2"})
+	tc.UpdateLeaderCount(1, 16)
+	tc.UpdateLeaderCount(2, 8)
+	tc.UpdateLeaderCount(3, 0)
+	tc.UpdateLeaderCount(4, 0)
+	// the leader of shard 1 can only be transferred to the other zone
+	tc.AddLeaderShard(1, 1, 3, 4)
+	tc.AddLeaderShard(2, 1, 2, 3)
+
+	for i := 0; i < 10; i++ {
+		ops := s.Schedule(tc)
+		assert.Equal(t, 1, len(ops))
+		testutil.CheckTransferLeader(t, ops[0], operator.OpLeader, 1, 2)
+		assert.Equal(t, uint64(2), ops[0].ShardID())
+	}
+
+	// no leader can be balanced in the zone
+	tc.UpdateLeaderCount(2, 16)
+	assert.Empty(t, s.Schedule(tc))
+}
+
+func TestZoneBalanceLeaderConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tc := mockcluster.NewCluster(config.NewTestOptions())
+	oc := schedule.NewOperatorController(ctx, tc, nil)
+
+	s, err := schedule.CreateScheduler(ZoneBalanceLeaderType, oc, storage.NewTestStorage(),
+		schedule.ConfigSliceDecoder(ZoneBalanceLeaderType, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, defaultZoneLabel, s.(*balanceLeaderScheduler).conf.ZoneLabel)
+
+	_, err = schedule.CreateScheduler(ZoneBalanceLeaderType, oc, storage.NewTestStorage(),
+		schedule.ConfigSliceDecoder(ZoneBalanceLeaderType, []string{"az", "rack"}))
+	assert.Error(t, err)
+}
@@ -75,6 +75,27 @@ type CustomDataStorage interface {
 	RemoveCustomData(key []byte) error
 }
 
+// ReportStorage analysis report storage
+type ReportStorage interface {
+	// PutBalanceReport puts the balance report created at the timestamp
+	PutBalanceReport(timestamp int64, report interface{}) error
+	// LoadBalanceReports load all balance reports in the order of the timestamp
+	LoadBalanceReports(limit int64, f func(timestamp int64, v string) error) error
+	// RemoveBalanceReport remove the balance report created at the timestamp
+	RemoveBalanceReport(timestamp int64) error
+}
+
+// TimelineStorage cluster events timeline storage
+type TimelineStorage interface {
+	// PutTimelineEvent puts the timeline event happened at the timestamp, the
+	// event with the same timestamp is overwritten
+	PutTimelineEvent(timestamp int64, event interface{}) error
+	// LoadTimelineEvents load all timeline events in the order of the timestamp
+	LoadTimelineEvents(limit int64, f func(timestamp int64, v string) error) error
+	// RemoveTimelineEvent remove the timeline event happened at the timestamp
+	RemoveTimelineEvent(timestamp int64) error
+}
+
 // ShardStorage resource storage
 type ShardStorage interface {
 	// AllocShardLeaseEpoch alloc lease epoch
@@ -100,6 +121,11 @@ type ShardStorage interface {
 
 	PutScheduleGroupRule(metapb.ScheduleGroupRule) error
 	LoadScheduleGroupRules(limit int64, do func(metapb.ScheduleGroupRule)) error
+
+	// PutClusterSetting puts the cluster-wide setting
+	PutClusterSetting(metapb.ClusterSetting) error
+	// LoadClusterSettings load all cluster-wide settings
+	LoadClusterSettings(limit int64, do func(metapb.ClusterSetting)) error
 }
 
 // ConfigStorage  config storage
@@ -151,6 +177,8 @@ type Storage interface {
 	ShardStorage
 	StoreStorage
 	ClusterStorage
+	ReportStorage
+	TimelineStorage
 
 	// KV return KV storage
 	KV() KV
@@ -168,6 +196,7 @@ type storage struct {
 	resourceExtraPath        string
 	resourceLeaseEpochPath   string
 	scheduleGroupRulePath    string
+	clusterSettingPath       string
 	containerPath            string
 	rulePath                 string
 	ruleGroupPath            string
@@ -177,6 +206,8 @@ type storage struct {
 	jobPath                  string
 	jobDataPath              string
 	customDataPath           string
+	balanceReportPath        string
+	timelinePath             string
 }
 
 // NewTestStorage create test storage
@@ -195,6 +226,7 @@ func NewStorage(rootPath string, kv KV, idGen id.Generator) Storage {
 		resourceExtraPath:        fmt.Sprintf("%s/resources-extra", rootPath),
 		resourceLeaseEpochPath:   fmt.Sprintf("%s/resources-lease-epoch", rootPath),
 		scheduleGroupRulePath:    fmt.Sprintf("%s/schdule-group-rules", rootPath),
+		clusterSettingPath:       fmt.Sprintf("%s/cluster-settings", rootPath),
 		containerPath:            fmt.Sprintf("%s/containers", rootPath),
 		rulePath:                 fmt.Sprintf("%s/rules", rootPath),
 		ruleGroupPath:            fmt.Sprintf("%s/rule-groups", rootPath),
@@ -204,6 +236,8 @@ func NewStorage(rootPath string, kv KV, idGen id.Generator) Storage {
 		jobPath:                  fmt.Sprintf("%s/jobs", rootPath),
 		jobDataPath:              fmt.Sprintf("%s/job-data", rootPath),
 		customDataPath:           fmt.Sprintf("%s/custom", rootPath),
+		balanceReportPath:        fmt.Sprintf("%s/balance-reports", rootPath),
+		timelinePath:             fmt.Sprintf("%s/timeline", rootPath),
 	}
 }
 
@@ -341,6 +375,19 @@ func (s *storage) LoadScheduleGroupRules(limit int64, do func(metapb.ScheduleGro
 	})
 }
 
+func (s *storage) PutClusterSetting(setting metapb.ClusterSetting) error {
+	return s.kv.Save(path.Join(s.clusterSettingPath, setting.Key), string(protoc.MustMarshal(&setting)))
+}
+
+func (s *storage) LoadClusterSettings(limit int64, do func(metapb.ClusterSetting)) error {
+	return s.LoadRangeByPrefix(limit, s.clusterSettingPath+"/", func(k, v string) error {
+		var setting metapb.ClusterSetting
+		protoc.MustUnmarshal(&setting, []byte(v))
+		do(setting)
+		return nil
+	})
+}
+
 func (s *storage) PutShardAndExtra(res metapb.Shard, extra []byte) error {
 	data, err := res.Marshal()
 	if err != nil {
@@ -552,6 +599,42 @@ func (s *storage) RemoveCustomData(key []byte) error {
 	return s.kv.Remove(path.Join(s.customDataPath, string(key)))
 }
 
+func (s *storage) PutBalanceReport(timestamp int64, report interface{}) error {
+	return s.SaveJSON(s.balanceReportPath, timestampKey(timestamp), report)
+}
+
+func (s *storage) LoadBalanceReports(limit int64, f func(timestamp int64, v string) error) error {
+	return s.LoadRangeByPrefix(limit, s.balanceReportPath+"/", func(k, v string) error {
+		timestamp, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			return err
+		}
+		return f(timestamp, v)
+	})
+}
+
+func (s *storage) RemoveBalanceReport(timestamp int64) error {
+	return s.kv.Remove(path.Join(s.balanceReportPath, timestampKey(timestamp)))
+}
+
+func (s *storage) PutTimelineEvent(timestamp int64, event interface{}) error {
+	return s.SaveJSON(s.timelinePath, timestampKey(timestamp), event)
+}
+
+func (s *storage) LoadTimelineEvents(limit int64, f func(timestamp int64, v string) error) error {
+	return s.LoadRangeByPrefix(limit, s.timelinePath+"/", func(k, v string) error {
+		timestamp, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			return err
+		}
+		return f(timestamp, v)
+	})
+}
+
+func (s *storage) RemoveTimelineEvent(timestamp int64) error {
+	return s.kv.Remove(path.Join(s.timelinePath, timestampKey(timestamp)))
+}
+
 func (s *storage) PutBootstrapped(container metapb.Store, resources ...*metapb.Shard) (bool, error) {
 	clusterID, err := s.idGen.AllocID()
 	if err != nil {
@@ -624,3 +707,9 @@ func (s *storage) jobDataKey(jobType metapb.JobType) string {
 func (s *storage) AllocID() (uint64, error) {
 	return s.idGen.AllocID()
 }
+
+// timestampKey pads the timestamp, so the reports and the events are loaded in
+// order
+func timestampKey(timestamp int64) string {
+	return fmt.Sprintf("%020d", timestamp)
+}
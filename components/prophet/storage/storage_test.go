@@ -301,6 +301,19 @@ func TestScheduleGroupRule(t *testing.T) {
 	assert.Equal(t, ruleCache.RuleCount(), 10)
 }
 
+func TestClusterSetting(t *testing.T) {
+	storage := NewTestStorage()
+	assert.NoError(t, storage.PutClusterSetting(metapb.ClusterSetting{Key: "k1", Value: "v1", Version: 1}))
+	assert.NoError(t, storage.PutClusterSetting(metapb.ClusterSetting{Key: "k2", Value: "v2", Version: 1}))
+	assert.NoError(t, storage.PutClusterSetting(metapb.ClusterSetting{Key: "k1", Value: "v3", Version: 2}))
+
+	var settings []metapb.ClusterSetting
+	assert.NoError(t, storage.LoadClusterSettings(16, func(setting metapb.ClusterSetting) {
+		settings = append(settings, setting)
+	}))
+	assert.Equal(t, []metapb.ClusterSetting{{Key: "k1", Value: "v3", Version: 2}, {Key: "k2", Value: "v2", Version: 1}}, settings)
+}
+
 func TestPutAndDeleteAndLoadCustomData(t *testing.T) {
 	stopC, port := mock.StartTestSingleEtcd(t)
 	defer close(stopC)
@@ -384,3 +397,68 @@ func waitLeaderReady(t *testing.T, ls *election.Leadership) {
 		time.Sleep(time.Millisecond * 50)
 	}
 }
+
+func TestPutAndRemoveAndLoadBalanceReports(t *testing.T) {
+	stopC, port := mock.StartTestSingleEtcd(t)
+	defer close(stopC)
+
+	client := mock.NewEtcdClient(t, port)
+	defer client.Close()
+
+	e, err := election.NewElector(client)
+	assert.NoError(t, err)
+	ls := e.CreateLeadship("prophet", "node1", "node1", true, func(string) bool { return true }, func(string) bool { return true })
+	defer ls.Stop()
+
+	ls.ElectionLoop()
+	waitLeaderReady(t, ls)
+
+	storage := NewStorage("/root", NewEtcdKV("/root", client, ls), id.NewMemGenerator())
+	assert.NoError(t, storage.PutBalanceReport(100, "r100"))
+	assert.NoError(t, storage.PutBalanceReport(9, "r9"))
+	assert.NoError(t, storage.PutBalanceReport(10, "r10"))
+
+	var timestamps []int64
+	var values []string
+	assert.NoError(t, storage.LoadBalanceReports(1, func(timestamp int64, v string) error {
+		timestamps = append(timestamps, timestamp)
+		values = append(values, v)
+		return nil
+	}))
+	assert.Equal(t, []int64{9, 10, 100}, timestamps)
+	assert.Equal(t, []string{`"r9"`, `"r10"`, `"r100"`}, values)
+
+	assert.NoError(t, storage.RemoveBalanceReport(9))
+	timestamps = timestamps[:0]
+	assert.NoError(t, storage.LoadBalanceReports(10, func(timestamp int64, v string) error {
+		timestamps = append(timestamps, timestamp)
+		return nil
+	}))
+	assert.Equal(t, []int64{10, 100}, timestamps)
+}
+
+func TestPutAndRemoveAndLoadTimelineEvents(t *testing.T) {
+	storage := NewTestStorage()
+	assert.NoError(t, storage.PutTimelineEvent(100, "e100"))
+	assert.NoError(t, storage.PutTimelineEvent(9, "e9"))
+	assert.NoError(t, storage.PutTimelineEvent(10, "e10"))
+	assert.NoError(t, storage.PutBalanceReport(11, "r11"))
+
+	var timestamps []int64
+	var values []string
+	assert.NoError(t, storage.LoadTimelineEvents(10, func(timestamp int64, v string) error {
+		timestamps = append(timestamps, timestamp)
+		values = append(values, v)
+		return nil
+	}))
+	assert.Equal(t, []int64{9, 10, 100}, timestamps)
+	assert.Equal(t, []string{`"e9"`, `"e10"`, `"e100"`}, values)
+
+	assert.NoError(t, storage.RemoveTimelineEvent(9))
+	timestamps = timestamps[:0]
+	assert.NoError(t, storage.LoadTimelineEvents(10, func(timestamp int64, v string) error {
+		timestamps = append(timestamps, timestamp)
+		return nil
+	}))
+	assert.Equal(t, []int64{10, 100}, timestamps)
+}
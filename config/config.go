@@ -14,6 +14,7 @@
 package config
 
 import (
+	"context"
 	"path"
 	"time"
 
@@ -37,12 +38,16 @@ var (
 	defaultSendRaftBatchSize        uint64 = 64
 	defaultMaxConcurrencySnapChunks uint64 = 8
 	defaultSnapChunkSize                   = 4 * mb
+	defaultSnapGenerateWorkers      uint64 = 4
+	defaultDestroyWorkerCount       uint64 = 1
 	defaultRaftMaxWorkers           uint64 = 64
+	defaultGroupPoolWorkers         uint64 = 8
 	defaultRaftElectionTick                = 10
 	defaultRaftHeartbeatTick               = 2
 	defaultShardStateCheckDuration         = time.Second * 60
 	defaultCompactLogCheckDuration         = time.Second * 60
 	defaultMaxEntryBytes                   = 10 * mb
+	defaultMaxProposalBytes                = 100 * mb
 	defaultMaxAllowTransferLag      uint64 = 2
 	defaultCompactThreshold         uint64 = 256
 	defaultRaftTickDuration                = time.Second
@@ -55,6 +60,37 @@ var (
 	defaultProphetDirName                  = "prophet"
 	defaultRaftAddr                        = "127.0.0.1:20001"
 	defaultRPCAddr                         = "127.0.0.1:20002"
+	defaultQoSRefreshInterval              = time.Second
+	defaultQoSSaturationRatio              = 0.9
+	defaultQoSGroupWeight           uint64 = 1
+	defaultRequestTraceCapacity            = 1024
+	defaultCrashReportDirName              = "crash-reports"
+	defaultCrashReportRecentLogs           = 1024
+	defaultCrashReportUploadTimeout        = time.Second * 10
+	defaultReadCacheMaxEntries             = 10000
+	defaultReadCacheTTL                    = time.Second
+	defaultAuditSyncPrefix                 = "matrixcube"
+	defaultAuditSyncMetaInterval           = time.Minute * 10
+	defaultAuditSyncQueueSize              = 256
+	defaultAuditSyncUploadTimeout          = time.Second * 30
+	defaultDiskPressureCheck               = time.Second * 10
+	defaultDiskPressureViewAge             = time.Minute
+	defaultIOErrorRetryTimes               = 3
+	defaultIOErrorRetryBackoff             = time.Millisecond * 100
+	defaultHeartbeatFullSync               = time.Minute
+	defaultHeartbeatBytesDelta             = 1 * mb
+	defaultHeartbeatKeysDelta       uint64 = 1024
+	defaultFsyncWALWorkers                 = 4
+	defaultFsyncDataWorkers                = 2
+	defaultFsyncQueueSize                  = 1024
+	defaultFsyncWALGroupSize               = 64
+	defaultAutoTuneInterval                = time.Second * 10
+	defaultAutoTuneApplyLatency            = time.Millisecond * 10
+	defaultAutoTuneMinBatchSize            = 64 * kb
+	defaultAutoTuneMaxApplyBatch           = 64 * mb
+	defaultAutoTuneMaxCommitDelay          = time.Millisecond
+	defaultAutoTuneDecreaseRatio           = 0.5
+	defaultAutoTuneIncreaseSteps    uint64 = 16
 )
 
 // Config matrixcube config
@@ -73,10 +109,38 @@ type Config struct {
 	UseMemoryAsStorage bool              `toml:"use-memory-as-storage"`
 	Replication        ReplicationConfig `toml:"replication"`
 	Snapshot           SnapshotConfig    `toml:"snapshot"`
+	// Preset the profiles used to fill the items not set explicitly
+	Preset PresetConfig `toml:"preset"`
 	// Raft raft config
 	Raft RaftConfig `toml:"raft"`
 	// Worker worker config
 	Worker WorkerConfig `toml:"worker"`
+	// QoS shard group io qos config
+	QoS QoSConfig `toml:"qos"`
+	// Fsync fsync pools config
+	Fsync FsyncConfig `toml:"fsync"`
+
+	RequestTrace RequestTraceConfig `toml:"request-trace"`
+
+	ReadLoadShedding ReadLoadSheddingConfig `toml:"read-load-shedding"`
+
+	WriteAdmission WriteAdmissionConfig `toml:"write-admission"`
+
+	QuorumLoss QuorumLossConfig `toml:"quorum-loss"`
+
+	Debug DebugConfig `toml:"debug"`
+
+	CrashReport CrashReportConfig `toml:"crash-report"`
+
+	ReadCache ReadCacheConfig `toml:"read-cache"`
+
+	AuditSync AuditSyncConfig `toml:"audit-sync"`
+
+	DiskPressure DiskPressureConfig `toml:"disk-pressure"`
+
+	IOError IOErrorConfig `toml:"io-error"`
+
+	AutoTune AutoTuneConfig `toml:"auto-tune"`
 	// Prophet prophet config
 	Prophet pconfig.Config `toml:"prophet"`
 	// Storage config
@@ -91,11 +155,20 @@ type Config struct {
 	FS vfs.FS `json:"-" toml:"-"`
 	// Test only used in testing
 	Test TestConfig
+
+	origin configOrigin
 }
 
-// Adjust adjust
+// Adjust fills the items not set by the presets and the defaults, and panics
+// with a ValidationError if the adjusted config is invalid.
 func (c *Config) Adjust() {
 	c.validate()
+	c.recordUserValues()
+	for _, p := range c.getPresets() {
+		before := c.configValues()
+		p.apply(c)
+		c.recordPresetValues(p.name, before)
+	}
 
 	if c.FS == nil {
 		c.FS = vfs.Default
@@ -138,6 +211,15 @@ func (c *Config) Adjust() {
 		panic(err)
 	}
 	(&c.Worker).adjust()
+	(&c.QoS).adjust()
+	(&c.Fsync).adjust()
+	(&c.RequestTrace).adjust()
+	(&c.CrashReport).adjust(c.DataPath)
+	(&c.ReadCache).adjust()
+	(&c.AuditSync).adjust()
+	(&c.DiskPressure).adjust()
+	(&c.IOError).adjust()
+	(&c.AutoTune).adjust(c.Raft)
 
 	if c.Test.ShardStateAware != nil {
 		if c.Customize.CustomShardStateAwareFactory != nil {
@@ -157,9 +239,18 @@ func (c *Config) Adjust() {
 
 	if len(c.Prophet.Replication.Groups) == 0 {
 		c.Storage.ForeachDataStorageFunc(func(g uint64, ds storage.DataStorage) {
+			// the groups owned by the other prophet groups of the federation are
+			// scheduled by them
+			if _, ok := c.Prophet.Federation.GetOwner(g); ok {
+				return
+			}
 			c.Prophet.Replication.Groups = append(c.Prophet.Replication.Groups, g)
 		})
 	}
+
+	if err := c.Validate(); err != nil {
+		panic(err)
+	}
 }
 
 func (c *Config) validate() {
@@ -190,6 +281,34 @@ type ReplicationConfig struct {
 	ShardStateCheckDuration typeutil.Duration `toml:"shard-state-check-duration"`
 	CompactLogCheckDuration typeutil.Duration `toml:"compact-log-check-duration"`
 	AllowRemoveLeader       bool              `toml:"allow-remove-leader"`
+	// UnsafeConfigChange disables the live quorum check of config changes. It is
+	// reserved for the unsafe recovery workflow, never enable it in a healthy
+	// cluster.
+	UnsafeConfigChange bool `toml:"unsafe-config-change"`
+	// DeltaShardHeartbeat only the shards whose state or stats changed beyond
+	// the thresholds since the last heartbeat send the periodic heartbeats, all
+	// shards still send the heartbeats every ShardHeartbeatFullSyncDuration.
+	DeltaShardHeartbeat bool `toml:"delta-shard-heartbeat"`
+	// ShardHeartbeatFullSyncDuration max duration between two heartbeats of a
+	// shard if DeltaShardHeartbeat is enabled
+	ShardHeartbeatFullSyncDuration typeutil.Duration `toml:"shard-heartbeat-full-sync-duration"`
+	// ShardHeartbeatBytesThreshold min change of the written, read bytes or the
+	// approximate size to send the heartbeat if DeltaShardHeartbeat is enabled
+	ShardHeartbeatBytesThreshold typeutil.ByteSize `toml:"shard-heartbeat-bytes-threshold"`
+	// ShardHeartbeatKeysThreshold min change of the written, read keys or the
+	// approximate keys to send the heartbeat if DeltaShardHeartbeat is enabled
+	ShardHeartbeatKeysThreshold uint64 `toml:"shard-heartbeat-keys-threshold"`
+	// TombstoneGCGracePeriod the replicas removed from the shards are kept as
+	// tombstones for at least the duration, and their data is deleted after
+	// prophet confirms that the shard epoch has advanced and the replica is no
+	// longer a member of the shard. The data is deleted immediately if it's 0.
+	TombstoneGCGracePeriod typeutil.Duration `toml:"tombstone-gc-grace-period"`
+	// DestroyWorkerCount number of the workers destroying the removed replicas,
+	// the replicas of the same shard are always destroyed by the same worker
+	DestroyWorkerCount uint64 `toml:"destroy-worker-count"`
+	// DestroyBytesPerSecond limits the data deleted by the destroyed replicas
+	// and the tombstones per second on the store, 0 means unlimited
+	DestroyBytesPerSecond typeutil.ByteSize `toml:"destroy-bytes-per-second"`
 }
 
 func (c *ReplicationConfig) adjust() {
@@ -212,12 +331,35 @@ func (c *ReplicationConfig) adjust() {
 	if c.CompactLogCheckDuration.Duration == 0 {
 		c.CompactLogCheckDuration.Duration = defaultCompactLogCheckDuration
 	}
+
+	if c.ShardHeartbeatFullSyncDuration.Duration == 0 {
+		c.ShardHeartbeatFullSyncDuration.Duration = defaultHeartbeatFullSync
+	}
+
+	if c.ShardHeartbeatBytesThreshold == 0 {
+		c.ShardHeartbeatBytesThreshold = typeutil.ByteSize(defaultHeartbeatBytesDelta)
+	}
+
+	if c.ShardHeartbeatKeysThreshold == 0 {
+		c.ShardHeartbeatKeysThreshold = defaultHeartbeatKeysDelta
+	}
+
+	if c.DestroyWorkerCount == 0 {
+		c.DestroyWorkerCount = defaultDestroyWorkerCount
+	}
 }
 
 // SnapshotConfig snapshot config
 type SnapshotConfig struct {
 	MaxConcurrencySnapChunks uint64            `toml:"max-concurrency-snap-chunks"`
 	SnapChunkSize            typeutil.ByteSize `toml:"snap-chunk-size"`
+	// SendBytesPerSecond limits the bandwidth used by all snapshots sent by the
+	// store, 0 means unlimited
+	SendBytesPerSecond typeutil.ByteSize `toml:"send-bytes-per-second"`
+	// GenerateWorkers number of the workers saving the snapshots from the
+	// checkpoints of the data storage, the snapshots of the data storage
+	// unable to take checkpoints are saved by the raft event workers.
+	GenerateWorkers uint64 `toml:"generate-workers"`
 }
 
 func (c *SnapshotConfig) adjust() {
@@ -228,17 +370,440 @@ func (c *SnapshotConfig) adjust() {
 	if c.SnapChunkSize == 0 {
 		c.SnapChunkSize = typeutil.ByteSize(defaultSnapChunkSize)
 	}
+
+	if c.GenerateWorkers == 0 {
+		c.GenerateWorkers = defaultSnapGenerateWorkers
+	}
 }
 
 // WorkerConfig worker config
 type WorkerConfig struct {
 	RaftEventWorkers uint64 `toml:"raft-event-workers"`
+	// GroupPools dedicated raft event worker pools of the shard groups, the
+	// shards of the groups not specified are processed by the shared
+	// RaftEventWorkers, so a latency sensitive group isn't slowed down by the
+	// bulk ingestion of another group.
+	GroupPools []GroupWorkerPoolConfig `toml:"group-pools"`
+}
+
+// GroupWorkerPoolConfig dedicated raft event worker pool of the shard groups
+type GroupWorkerPoolConfig struct {
+	// Groups shard groups processed by the pool
+	Groups []uint64 `toml:"groups"`
+	// Workers number of the raft event workers of the pool
+	Workers uint64 `toml:"workers"`
+	// TickInterval raft tick interval of the shards in the pool, Raft.TickInterval
+	// is used if 0
+	TickInterval typeutil.Duration `toml:"tick-interval"`
+	// MaxApplyBatchSize max bytes of the committed entries applied in a raft
+	// ready by the shards in the pool, Raft.MaxApplyBatchSize is used if 0
+	MaxApplyBatchSize typeutil.ByteSize `toml:"max-apply-batch-size"`
+}
+
+// GetGroupPool returns the dedicated worker pool of the group, returns false if
+// the shards of the group are processed by the shared workers.
+func (c *WorkerConfig) GetGroupPool(group uint64) (GroupWorkerPoolConfig, bool) {
+	for _, p := range c.GroupPools {
+		for _, g := range p.Groups {
+			if g == group {
+				return p, true
+			}
+		}
+	}
+	return GroupWorkerPoolConfig{}, false
 }
 
 func (c *WorkerConfig) adjust() {
 	if c.RaftEventWorkers == 0 {
 		c.RaftEventWorkers = defaultRaftMaxWorkers
 	}
+
+	for idx := range c.GroupPools {
+		if c.GroupPools[idx].Workers == 0 {
+			c.GroupPools[idx].Workers = defaultGroupPoolWorkers
+		}
+	}
+}
+
+// FsyncConfig is the config of the fsync pools. The fsync of the raft log
+// appends and the fsync of the data storage flushes run on separate bounded
+// pools, so the data flush bursts, e.g. during compactions, don't inflate the
+// raft log append latency and thus the commit latency.
+type FsyncConfig struct {
+	// Enable runs the fsync on the pools, otherwise the fsync runs on the
+	// goroutines writing the raft logs and the data
+	Enable bool `toml:"enable"`
+	// WALWorkers number of the goroutines syncing the raft log appends
+	WALWorkers int `toml:"wal-workers"`
+	// WALQueueSize max number of the pending raft log appends
+	WALQueueSize int `toml:"wal-queue-size"`
+	// DataWorkers number of the goroutines syncing the data storage flushes
+	DataWorkers int `toml:"data-workers"`
+	// DataQueueSize max number of the pending data storage flushes
+	DataQueueSize int `toml:"data-queue-size"`
+	// WALGroupSize max number of the raft log appends of the replicas made
+	// durable by a shared fsync
+	WALGroupSize int `toml:"wal-group-size"`
+}
+
+func (c *FsyncConfig) adjust() {
+	if c.WALWorkers <= 0 {
+		c.WALWorkers = defaultFsyncWALWorkers
+	}
+	if c.WALQueueSize <= 0 {
+		c.WALQueueSize = defaultFsyncQueueSize
+	}
+	if c.DataWorkers <= 0 {
+		c.DataWorkers = defaultFsyncDataWorkers
+	}
+	if c.DataQueueSize <= 0 {
+		c.DataQueueSize = defaultFsyncQueueSize
+	}
+	if c.WALGroupSize <= 0 {
+		c.WALGroupSize = defaultFsyncWALGroupSize
+	}
+}
+
+// QoSConfig is the config of the io scheduler which enforces the disk bandwidth
+// shares of the shard groups when the disk is saturated.
+type QoSConfig struct {
+	// Enable enable the io scheduler
+	Enable bool `toml:"enable"`
+	// RefreshInterval interval to sample the disk throughput
+	RefreshInterval typeutil.Duration `toml:"refresh-interval"`
+	// ReadBandwidth read throughput of the device in bytes per second, the read
+	// side is not scheduled if it's 0
+	ReadBandwidth typeutil.ByteSize `toml:"read-bandwidth"`
+	// WriteBandwidth write throughput of the device in bytes per second, the
+	// write side is not scheduled if it's 0
+	WriteBandwidth typeutil.ByteSize `toml:"write-bandwidth"`
+	// SaturationRatio the disk is considered as saturated when the measured
+	// throughput reaches SaturationRatio * device bandwidth
+	SaturationRatio float64 `toml:"saturation-ratio"`
+	// DefaultWeight weight of the groups not specified in Groups
+	DefaultWeight uint64 `toml:"default-weight"`
+	// Groups bandwidth weights of the shard groups
+	Groups []GroupQoSConfig `toml:"groups"`
+}
+
+// GroupQoSConfig bandwidth weight of a shard group
+type GroupQoSConfig struct {
+	Group  uint64 `toml:"group"`
+	Weight uint64 `toml:"weight"`
+}
+
+func (c *QoSConfig) adjust() {
+	if c.RefreshInterval.Duration == 0 {
+		c.RefreshInterval.Duration = defaultQoSRefreshInterval
+	}
+
+	if c.SaturationRatio <= 0 || c.SaturationRatio > 1 {
+		c.SaturationRatio = defaultQoSSaturationRatio
+	}
+
+	if c.DefaultWeight == 0 {
+		c.DefaultWeight = defaultQoSGroupWeight
+	}
+}
+
+// RequestTraceConfig is the config of the request tracer, which records the
+// stages of the recent requests by the request id.
+type RequestTraceConfig struct {
+	// Enable enable the request tracer
+	Enable bool `toml:"enable"`
+	// Capacity max number of the recent requests to keep the trace
+	Capacity int `toml:"capacity"`
+}
+
+func (c *RequestTraceConfig) adjust() {
+	if c.Capacity <= 0 {
+		c.Capacity = defaultRequestTraceCapacity
+	}
+}
+
+// ReadLoadSheddingConfig is the config of the read load shedding. The reads
+// received by a replica whose apply lag exceeds the threshold are served as
+// stale reads if the requests allow it, otherwise they are rejected, so the
+// reads fail fast instead of timing out during the catch-up.
+type ReadLoadSheddingConfig struct {
+	// MaxApplyLag max count of the committed but not applied raft log entries
+	// to serve the linearizable reads, 0 disables the load shedding
+	MaxApplyLag uint64 `toml:"max-apply-lag"`
+}
+
+// WriteAdmissionConfig is the config of the write admission control. The
+// writes proposed to a leader whose apply backlog or disk throughput exceeds
+// the thresholds are rejected with the ServerIsBusy error, and retried by the
+// proxy later, so a hot shard can not accumulate unbounded proposals in the
+// memory of the leader.
+type WriteAdmissionConfig struct {
+	// MaxApplyLag max count of the committed but not applied raft log entries
+	// to admit the writes, 0 means unlimited
+	MaxApplyLag uint64 `toml:"max-apply-lag"`
+	// MaxPendingProposals max count of the proposed but not applied write
+	// batches to admit the writes, 0 means unlimited
+	MaxPendingProposals int `toml:"max-pending-proposals"`
+	// RejectOnDiskSaturated rejects the writes while the disk write throughput
+	// is saturated, it only works if the io scheduler is enabled
+	RejectOnDiskSaturated bool `toml:"reject-on-disk-saturated"`
+}
+
+// QuorumLossConfig is the config of the quorum loss detection. A replica
+// considers its shard has lost the write quorum if the shard has no leader for
+// the timeout and the majority of the voters are unreachable, then the writes
+// and the linearizable reads fail fast, and the reads allowing stale reads are
+// served from the local state, until the shard has a leader again.
+type QuorumLossConfig struct {
+	// DetectTimeout the duration without leader and the majority of the voters
+	// to consider the quorum is lost, 0 disables the detection
+	DetectTimeout typeutil.Duration `toml:"detect-timeout"`
+}
+
+// CrashReportConfig is the config of the crash report. On panic or fatal, the
+// store gathers the recent logs, shard states, raft statuses, config and
+// goroutine dump into an archive, so the users can attach it to the reports.
+type CrashReportConfig struct {
+	// Enable enable the crash report
+	Enable bool `toml:"enable"`
+	// Dir the directory of the archives, default is <dir-data>/crash-reports
+	Dir string `toml:"dir"`
+	// RecentLogs max number of the recent log entries kept for the archive
+	RecentLogs int `toml:"recent-logs"`
+	// UploadURL the archive is posted to the http address if it's set
+	UploadURL string `toml:"upload-url"`
+	// UploadTimeout timeout of the upload
+	UploadTimeout typeutil.Duration `toml:"upload-timeout"`
+}
+
+func (c *CrashReportConfig) adjust(dataPath string) {
+	if c.Dir == "" {
+		c.Dir = path.Join(dataPath, defaultCrashReportDirName)
+	}
+	if c.RecentLogs <= 0 {
+		c.RecentLogs = defaultCrashReportRecentLogs
+	}
+	if c.UploadTimeout.Duration == 0 {
+		c.UploadTimeout.Duration = defaultCrashReportUploadTimeout
+	}
+}
+
+// ReadCacheConfig is the config of the read cache of the shards proxy. The
+// responses of the idempotent reads are cached by the shard, the key and the
+// applied index at which the reads are served, and invalidated by the writes
+// through the same proxy and by the key changes, e.g. the CDC events, so the hot
+// keys re-read frequently are served by the proxy without the round trip to the
+// replicas. The writes invalidate the key or the keys range of the request.
+type ReadCacheConfig struct {
+	// Enable enable the read cache
+	Enable bool `toml:"enable"`
+	// MaxEntries max number of the cached responses, the least recently used
+	// ones are evicted
+	MaxEntries int `toml:"max-entries"`
+	// TTL max time to serve a cached response, which bounds the staleness if
+	// the key changes are not delivered
+	TTL typeutil.Duration `toml:"ttl"`
+	// CustomTypes the custom types of the idempotent reads to cache, empty
+	// means all the reads of a single key
+	CustomTypes []uint64 `toml:"custom-types"`
+}
+
+func (c *ReadCacheConfig) adjust() {
+	if c.MaxEntries <= 0 {
+		c.MaxEntries = defaultReadCacheMaxEntries
+	}
+	if c.TTL.Duration == 0 {
+		c.TTL.Duration = defaultReadCacheTTL
+	}
+}
+
+// AuditSyncConfig is the config of the audit sync. The sealed raft log
+// segments, i.e. the entries compacted by the shard leaders, and the periodic
+// snapshots of the shard metadata are uploaded asynchronously to the object
+// storage set by `CustomizeConfig.CustomObjectStorage`, as the audit trail and
+// the input of the point-in-time recovery tools.
+type AuditSyncConfig struct {
+	// Enable enable the audit sync
+	Enable bool `toml:"enable"`
+	// Prefix the key prefix of the uploaded objects
+	Prefix string `toml:"prefix"`
+	// MetadataInterval interval of the shard metadata snapshots
+	MetadataInterval typeutil.Duration `toml:"metadata-interval"`
+	// Retention the uploaded objects older than it are removed, 0 means the
+	// objects are kept forever
+	Retention typeutil.Duration `toml:"retention"`
+	// QueueSize max number of the objects waiting for upload, the objects are
+	// dropped if the queue is full
+	QueueSize int `toml:"queue-size"`
+	// UploadTimeout timeout of an upload
+	UploadTimeout typeutil.Duration `toml:"upload-timeout"`
+}
+
+func (c *AuditSyncConfig) adjust() {
+	if c.Prefix == "" {
+		c.Prefix = defaultAuditSyncPrefix
+	}
+	if c.MetadataInterval.Duration == 0 {
+		c.MetadataInterval.Duration = defaultAuditSyncMetaInterval
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = defaultAuditSyncQueueSize
+	}
+	if c.UploadTimeout.Duration == 0 {
+		c.UploadTimeout.Duration = defaultAuditSyncUploadTimeout
+	}
+}
+
+// DiskPressureConfig is the config of the disk pressure protection. Once the
+// used ratio of the store capacity reaches the threshold, the store force
+// releases the old storage views pinning the disk space, compacts the storages
+// urgently and reports itself as busy, so prophet stops placing new replicas on
+// it until the pressure is gone.
+type DiskPressureConfig struct {
+	// UsedRatio the threshold of the used ratio of the capacity, 0 disables the
+	// protection
+	UsedRatio float64 `toml:"used-ratio"`
+	// CheckInterval interval of the disk usage checks
+	CheckInterval typeutil.Duration `toml:"check-interval"`
+	// MaxViewAge the views created earlier are released under the pressure,
+	// their holders get storage.ErrViewReleased on next use
+	MaxViewAge typeutil.Duration `toml:"max-view-age"`
+}
+
+func (c *DiskPressureConfig) adjust() {
+	if c.CheckInterval.Duration == 0 {
+		c.CheckInterval.Duration = defaultDiskPressureCheck
+	}
+	if c.MaxViewAge.Duration == 0 {
+		c.MaxViewAge.Duration = defaultDiskPressureViewAge
+	}
+}
+
+const (
+	// IOErrorPanic the store panics on the failure of writing the data storage
+	IOErrorPanic = "panic"
+	// IOErrorRetry the failed write is retried with backoff, the replica is
+	// handled as IOErrorMarkStoreDown if all the retries are failed
+	IOErrorRetry = "retry"
+	// IOErrorMarkStoreDown the failed replica stops applying, its pending
+	// requests fail with the StorageIOError, and the store reports the disk
+	// failure to prophet, so its replicas are moved to the other stores
+	IOErrorMarkStoreDown = "mark-store-down"
+	// IOErrorPropagate the failed replica stops applying, its pending requests
+	// fail with the StorageIOError, the store keeps serving the other replicas
+	// and is not reported as disk failed
+	IOErrorPropagate = "propagate"
+)
+
+// IOErrorConfig is the config of the handling of the data storage write
+// failures. The entries failed to apply are not marked as applied, they are
+// applied again after the store is restarted.
+type IOErrorConfig struct {
+	// Policy one of panic, retry, mark-store-down and propagate, default is
+	// panic
+	Policy string `toml:"policy"`
+	// RetryTimes the max retry times of the retry policy
+	RetryTimes int `toml:"retry-times"`
+	// RetryBackoff the backoff before the first retry, it's doubled after each
+	// retry
+	RetryBackoff typeutil.Duration `toml:"retry-backoff"`
+}
+
+func (c *IOErrorConfig) adjust() {
+	if c.Policy == "" {
+		c.Policy = IOErrorPanic
+	}
+	if c.RetryTimes == 0 {
+		c.RetryTimes = defaultIOErrorRetryTimes
+	}
+	if c.RetryBackoff.Duration == 0 {
+		c.RetryBackoff.Duration = defaultIOErrorRetryBackoff
+	}
+}
+
+// AutoTuneConfig is the config of the batch auto-tuner, which adjusts the write
+// batch size, the apply batch size and the group commit delay of each shard
+// group from the apply latency and throughput observed in the last interval.
+// The values are raised additively while the latency is below the target and
+// the throughput keeps up, and cut multiplicatively otherwise.
+type AutoTuneConfig struct {
+	// Enable enable the auto-tuner, the static Raft config is used otherwise
+	Enable bool `toml:"enable"`
+	// Interval interval to adjust the batch sizes
+	Interval typeutil.Duration `toml:"interval"`
+	// TargetApplyLatency the batches are shrunk once the average latency of
+	// applying the committed entries of a raft ready exceeds it
+	TargetApplyLatency typeutil.Duration `toml:"target-apply-latency"`
+	// MinWriteBatchSize min bytes of the requests batched into a proposal
+	MinWriteBatchSize typeutil.ByteSize `toml:"min-write-batch-size"`
+	// MaxWriteBatchSize max bytes of the requests batched into a proposal,
+	// Raft.MaxEntryBytes is used if 0 or larger
+	MaxWriteBatchSize typeutil.ByteSize `toml:"max-write-batch-size"`
+	// MinApplyBatchSize min bytes of the committed entries applied in a raft
+	// ready
+	MinApplyBatchSize typeutil.ByteSize `toml:"min-apply-batch-size"`
+	// MaxApplyBatchSize max bytes of the committed entries applied in a raft
+	// ready. The tuned apply batch size is used by the raft nodes of the
+	// replicas created after it's chosen.
+	MaxApplyBatchSize typeutil.ByteSize `toml:"max-apply-batch-size"`
+	// MaxCommitDelay max time a write batch not filled up waits for the
+	// following requests before it's proposed
+	MaxCommitDelay typeutil.Duration `toml:"max-commit-delay"`
+	// DisableCommitDelay never delays the write batches
+	DisableCommitDelay bool `toml:"disable-commit-delay"`
+	// DecreaseRatio the values are multiplied by DecreaseRatio when the latency
+	// exceeds the target or the throughput drops
+	DecreaseRatio float64 `toml:"decrease-ratio"`
+	// IncreaseSteps number of the additive increases from the min to the max
+	IncreaseSteps uint64 `toml:"increase-steps"`
+}
+
+func (c *AutoTuneConfig) adjust(raft RaftConfig) {
+	if c.Interval.Duration == 0 {
+		c.Interval.Duration = defaultAutoTuneInterval
+	}
+	if c.TargetApplyLatency.Duration == 0 {
+		c.TargetApplyLatency.Duration = defaultAutoTuneApplyLatency
+	}
+	if c.MaxWriteBatchSize == 0 || c.MaxWriteBatchSize > raft.MaxEntryBytes {
+		c.MaxWriteBatchSize = raft.MaxEntryBytes
+	}
+	if c.MinWriteBatchSize == 0 {
+		c.MinWriteBatchSize = typeutil.ByteSize(defaultAutoTuneMinBatchSize)
+	}
+	if c.MinWriteBatchSize > c.MaxWriteBatchSize {
+		c.MinWriteBatchSize = c.MaxWriteBatchSize
+	}
+	if c.MaxApplyBatchSize == 0 {
+		c.MaxApplyBatchSize = typeutil.ByteSize(defaultAutoTuneMaxApplyBatch)
+	}
+	if c.MinApplyBatchSize == 0 {
+		c.MinApplyBatchSize = typeutil.ByteSize(defaultAutoTuneMinBatchSize)
+	}
+	if c.MaxCommitDelay.Duration == 0 {
+		c.MaxCommitDelay.Duration = defaultAutoTuneMaxCommitDelay
+	}
+	if c.DecreaseRatio == 0 {
+		c.DecreaseRatio = defaultAutoTuneDecreaseRatio
+	}
+	if c.IncreaseSteps == 0 {
+		c.IncreaseSteps = defaultAutoTuneIncreaseSteps
+	}
+}
+
+// DebugConfig is the config of the debug service, which exposes the internal
+// state of the store and the profiles for debugging.
+type DebugConfig struct {
+	// Addr listen address of the debug service, the debug service is disabled
+	// if it is empty
+	Addr string `toml:"addr"`
+	// Token the token that the requests to the debug service must carry in the
+	// `Authorization: Bearer <token>` header. The debug service is not started
+	// without the token unless the Addr is a loopback address.
+	Token string `toml:"token"`
+	// ConsoleSocket path of the unix socket of the interactive debug console,
+	// the socket is only accessible to the owner of the process. The console
+	// is disabled if it is empty.
+	ConsoleSocket string `toml:"console-socket"`
 }
 
 // ShardConfig shard config
@@ -258,18 +823,103 @@ type RaftConfig struct {
 	HeartbeatTicks int `toml:"heartbeat-ticks"`
 	// ElectionTimeoutTicks how many ticks to send election message
 	ElectionTimeoutTicks int `toml:"election-timeout-ticks"`
+	// HibernateTicks the replicas of a shard stop ticking once the shard has
+	// been quiet for HibernateTicks ticks, i.e. all the replicas have the same
+	// log and applied it, until a proposal or a raft message arrives. The
+	// hibernated leader sends a keepalive message every HibernateTicks ticks
+	// instead of the heartbeats, and a follower missing the keepalives wakes up
+	// to elect a new leader. 0 disables the hibernation.
+	HibernateTicks int `toml:"hibernate-ticks"`
 	// MaxSizePerMsg max bytes per raft message
 	MaxSizePerMsg typeutil.ByteSize `toml:"max-size-per-msg"`
 	// MaxInflightMsgs max raft message count in a raft rpc
 	MaxInflightMsgs int `toml:"max-inflight-msgs"`
 	// MaxEntryBytes max bytes of entry in a proposal message
 	MaxEntryBytes typeutil.ByteSize `toml:"max-entry-bytes"`
+	// MaxProposalBytes max bytes of a proposal. The proposal larger than the
+	// MaxEntryBytes is split into multiple raft entries, and reassembled when
+	// the last entry is applied. The proposal is not split if it's not greater
+	// than MaxEntryBytes.
+	MaxProposalBytes typeutil.ByteSize `toml:"max-proposal-bytes"`
 	// SendRaftBatchSize raft message sender count
 	SendRaftBatchSize uint64 `toml:"send-raft-batch-size"`
 	// RaftLog raft log 配置
 	RaftLog RaftLogConfig `toml:"raft-log"`
 	// LimitRequestBytesPerShard request's bytes per second limit
 	LimitRequestBytesPerShard typeutil.ByteSize `toml:"send-raft-batch-size"`
+	// LeaderLeaseDuration the leader serves the linearizable reads locally
+	// without the ReadIndex round trip within the lease, and falls back to the
+	// ReadIndex once the lease is uncertain. It must be less than the election
+	// timeout, the gap tolerates the clock drift between the stores. 0 disables
+	// the leader lease reads.
+	LeaderLeaseDuration typeutil.Duration `toml:"leader-lease-duration"`
+	// MaxApplyBatchSize max bytes of the committed entries applied in a raft
+	// ready, MaxSizePerMsg is used if 0. The committed entries already
+	// persisted are applied while the new entries are being persisted if the
+	// fsync pools are enabled.
+	MaxApplyBatchSize typeutil.ByteSize `toml:"max-apply-batch-size"`
+	// DisablePreVote disables the raft PreVote. With PreVote, a candidate only
+	// increases its term after it learns that it could win the election, so a
+	// partitioned replica rejoining the cluster doesn't disrupt the leader.
+	DisablePreVote bool `toml:"disable-pre-vote"`
+	// DisableCheckQuorum disables the raft CheckQuorum. With CheckQuorum, the
+	// leader steps down if it can't hear from a quorum, and the followers
+	// ignore the votes while they are hearing from the leader. The leader lease
+	// reads require it.
+	DisableCheckQuorum bool `toml:"disable-check-quorum"`
+	// Groups overrides the election options of the shard groups
+	Groups []GroupRaftConfig `toml:"groups"`
+}
+
+// GetMaxProposalBytes returns the max bytes of a proposal
+func (c *RaftConfig) GetMaxProposalBytes() uint64 {
+	if c.MaxProposalBytes > c.MaxEntryBytes {
+		return uint64(c.MaxProposalBytes)
+	}
+	return uint64(c.MaxEntryBytes)
+}
+
+// GroupRaftConfig election options of a shard group, the store level options
+// are used if not set
+type GroupRaftConfig struct {
+	Group       uint64 `toml:"group"`
+	PreVote     *bool  `toml:"pre-vote"`
+	CheckQuorum *bool  `toml:"check-quorum"`
+}
+
+// GetElectionOptions returns whether the PreVote and CheckQuorum are enabled
+// for the shards in the group
+func (c *RaftConfig) GetElectionOptions(group uint64) (preVote, checkQuorum bool) {
+	preVote, checkQuorum = !c.DisablePreVote, !c.DisableCheckQuorum
+	for _, g := range c.Groups {
+		if g.Group != group {
+			continue
+		}
+		if g.PreVote != nil {
+			preVote = *g.PreVote
+		}
+		if g.CheckQuorum != nil {
+			checkQuorum = *g.CheckQuorum
+		}
+	}
+	return preVote, checkQuorum
+}
+
+// GetTickInterval returns the raft tick interval of the shards in the group
+func (c *Config) GetTickInterval(group uint64) time.Duration {
+	if p, ok := c.Worker.GetGroupPool(group); ok && p.TickInterval.Duration > 0 {
+		return p.TickInterval.Duration
+	}
+	return c.Raft.TickInterval.Duration
+}
+
+// GetMaxApplyBatchSize returns the max bytes of the committed entries applied
+// in a raft ready by the shards in the group
+func (c *Config) GetMaxApplyBatchSize(group uint64) uint64 {
+	if p, ok := c.Worker.GetGroupPool(group); ok && p.MaxApplyBatchSize > 0 {
+		return uint64(p.MaxApplyBatchSize)
+	}
+	return uint64(c.Raft.MaxApplyBatchSize)
 }
 
 // GetElectionTimeoutDuration returns ElectionTimeoutTicks * TickInterval
@@ -307,6 +957,10 @@ func (c *RaftConfig) adjust() {
 		c.MaxEntryBytes = typeutil.ByteSize(defaultMaxEntryBytes)
 	}
 
+	if c.MaxProposalBytes == 0 {
+		c.MaxProposalBytes = typeutil.ByteSize(defaultMaxProposalBytes)
+	}
+
 	if c.LimitRequestBytesPerShard == 0 {
 		c.LimitRequestBytesPerShard = typeutil.ByteSize(1 << 30)
 	}
@@ -316,9 +970,73 @@ func (c *RaftConfig) adjust() {
 
 // RaftLogConfig raft log config
 type RaftLogConfig struct {
-	DisableSync         bool   `toml:"disable-sync"`
+	DisableSync bool `toml:"disable-sync"`
+	// CompactThreshold the raft log is compacted up to the index replicated by
+	// all the replicas once more than CompactThreshold such entries are retained.
 	CompactThreshold    uint64 `toml:"compact-threshold"`
 	MaxAllowTransferLag uint64 `toml:"max-allow-transfer-lag"`
+	// MaxEntries the raft log is compacted up to the applied index even if some
+	// followers lag behind once MaxEntries entries are retained, 0 means using
+	// the ForceCompactCount of the data storage.
+	MaxEntries uint64 `toml:"max-entries"`
+	// MaxBytes the raft log is compacted up to the applied index even if some
+	// followers lag behind once MaxBytes bytes are retained, 0 means using the
+	// ForceCompactBytes of the data storage.
+	MaxBytes typeutil.ByteSize `toml:"max-bytes"`
+	// MaxFollowerLag the raft log is never compacted beyond the slowest follower
+	// if its lag is no more than MaxFollowerLag entries, so the follower catches
+	// up by the log rather than a snapshot. 0 means the lagging followers are not
+	// waited.
+	MaxFollowerLag uint64 `toml:"max-follower-lag"`
+	// Shards overrides the raft log gc policy of the specified shards.
+	Shards []ShardRaftLogConfig `toml:"shards"`
+}
+
+// ShardRaftLogConfig raft log gc policy of a shard, the store level options
+// are used if not set
+type ShardRaftLogConfig struct {
+	Shard            uint64            `toml:"shard"`
+	CompactThreshold uint64            `toml:"compact-threshold"`
+	MaxEntries       uint64            `toml:"max-entries"`
+	MaxBytes         typeutil.ByteSize `toml:"max-bytes"`
+	MaxFollowerLag   uint64            `toml:"max-follower-lag"`
+}
+
+// RaftLogGCPolicy the thresholds used to decide whether to compact the raft
+// log of a shard
+type RaftLogGCPolicy struct {
+	CompactThreshold uint64
+	MaxEntries       uint64
+	MaxBytes         uint64
+	MaxFollowerLag   uint64
+}
+
+// GetGCPolicy returns the raft log gc policy of the shard
+func (c *RaftLogConfig) GetGCPolicy(shard uint64) RaftLogGCPolicy {
+	p := RaftLogGCPolicy{
+		CompactThreshold: c.CompactThreshold,
+		MaxEntries:       c.MaxEntries,
+		MaxBytes:         uint64(c.MaxBytes),
+		MaxFollowerLag:   c.MaxFollowerLag,
+	}
+	for _, s := range c.Shards {
+		if s.Shard != shard {
+			continue
+		}
+		if s.CompactThreshold > 0 {
+			p.CompactThreshold = s.CompactThreshold
+		}
+		if s.MaxEntries > 0 {
+			p.MaxEntries = s.MaxEntries
+		}
+		if s.MaxBytes > 0 {
+			p.MaxBytes = uint64(s.MaxBytes)
+		}
+		if s.MaxFollowerLag > 0 {
+			p.MaxFollowerLag = s.MaxFollowerLag
+		}
+	}
+	return p
 }
 
 func (c *RaftLogConfig) adjust() {
@@ -344,6 +1062,9 @@ type StorageConfig struct {
 type CustomizeConfig struct {
 	// CustomShardStateAwareFactory is a factory func to create aware.ShardStateAware to handled shard life cycle.
 	CustomShardStateAwareFactory func() aware.ShardStateAware `json:"-" toml:"-"`
+	// CustomAdminResultAware is notified after the admin commands (split, config
+	// change, log compaction, etc.) are applied on the current store.
+	CustomAdminResultAware aware.AdminResultAware `json:"-" toml:"-"`
 	// CustomInitShardsFactory is a factory func to provide init shards to cube to bootstrap the cluster.
 	CustomInitShardsFactory func() []metapb.Shard `json:"-" toml:"-"`
 	// CustomStoreHeartbeatDataProcessor process store heartbeat data, collect, store and process customize data
@@ -351,6 +1072,29 @@ type CustomizeConfig struct {
 	// CustomShardPoolShardFactory is factory create a shard used by shard pool, `start, end and unique` is created by
 	// `ShardPool` based on `offsetInPool`, these can be modified, provided that the only non-conflict.
 	CustomShardPoolShardFactory func(g uint64, start, end []byte, unique string, offsetInPool uint64) metapb.Shard `json:"-" toml:"-"`
+	// CustomSplitKeyCodecFactory returns the SplitKeyCodec of the shard group, the
+	// shards in the group are only split at the valid split points defined by the
+	// codec. Returns nil if the group has no codec.
+	CustomSplitKeyCodecFactory func(group uint64) storage.SplitKeyCodec `json:"-" toml:"-"`
+	// CustomSplitCheckPolicyFactory returns the SplitCheckPolicy of the shard
+	// group, which chooses the split keys of the size based and the load based
+	// splits. The manual splits are not checked by the policy. Returns nil if the
+	// group has no policy.
+	CustomSplitCheckPolicyFactory func(group uint64) storage.SplitCheckPolicy `json:"-" toml:"-"`
+	// CustomSplitShardAttributesFunc returns the attributes of the new shard
+	// split from the parent shard. It's called on every replica when the split
+	// is applied, so it must be deterministic. The new shards inherit the
+	// attributes of the parent shard if nil.
+	CustomSplitShardAttributesFunc func(parent, newShard metapb.Shard) []byte `json:"-" toml:"-"`
+	// CustomStorageLifecycleHooksFactory returns the LifecycleHooks of the data
+	// storage of the shard group. Returns nil if the group has no hooks.
+	CustomStorageLifecycleHooksFactory func(group uint64) storage.LifecycleHooks `json:"-" toml:"-"`
+	// CustomKeyRouter routes the keys to the shard groups. The client sends the
+	// requests to the routed groups, and the store rejects the requests whose
+	// group is not the routed group of their keys.
+	CustomKeyRouter KeyRouter `json:"-" toml:"-"`
+	// CustomObjectStorage the object storage that the audit sync uploads to
+	CustomObjectStorage ObjectStorage `json:"-" toml:"-"`
 	// CustomTransportFilter transport filter
 	CustomTransportFilter func(metapb.RaftMessage) bool `json:"-" toml:"-"`
 	// CustomWrapNewTransport wraps new transports
@@ -385,6 +1129,40 @@ type StoreHeartbeatDataProcessor interface {
 	CollectData() []byte
 }
 
+// KeyRouter routes the keys to the shard groups, so that the different key
+// namespaces, e.g. metadata and data, are placed in different shard groups.
+type KeyRouter interface {
+	// Route returns the shard group of the key, and false if the key is not
+	// routed by the router.
+	Route(key []byte) (uint64, bool)
+}
+
+// KeyRouterFunc is an adapter to allow the use of ordinary functions as
+// KeyRouter.
+type KeyRouterFunc func(key []byte) (uint64, bool)
+
+// Route implements KeyRouter
+func (f KeyRouterFunc) Route(key []byte) (uint64, bool) {
+	return f(key)
+}
+
+// ObjectInfo is the info of an object in the object storage
+type ObjectInfo struct {
+	Key     string
+	ModTime time.Time
+}
+
+// ObjectStorage is the object storage, e.g. S3, that the audit trail is
+// uploaded to. The implementations must be safe for concurrent use.
+type ObjectStorage interface {
+	// Put creates or replaces the object of the key
+	Put(ctx context.Context, key string, data []byte) error
+	// List returns the objects whose keys have the prefix
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// Delete removes the object of the key, no error if it doesn't exist
+	Delete(ctx context.Context, key string) error
+}
+
 // TestConfig all test config
 type TestConfig struct {
 	// ShardStateAware is a ShardStateAware wrapper for the aware which created by
@@ -398,6 +1176,10 @@ type TestConfig struct {
 	ShardPoolCreateWaitC chan struct{} `json:"-" toml:"-"`
 	// Shards test config for shards
 	Shards map[uint64]*TestShardConfig `json:"-" toml:"-"`
+	// Deterministic drives all replicas, the received raft messages and the timer
+	// tasks of the store in the caller's goroutine with a logical clock, they are
+	// only processed by raftstore.DeterministicDriver.
+	Deterministic bool `json:"-" toml:"-"`
 }
 
 // TestShardConfig shard test config
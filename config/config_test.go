@@ -0,0 +1,245 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	_ "github.com/matrixorigin/matrixcube/components/prophet/schedulers"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig() *Config {
+	c := &Config{}
+	c.Storage.DataStorageFactory = func(group uint64) storage.DataStorage { return nil }
+	c.Storage.ForeachDataStorageFunc = func(cb func(uint64, storage.DataStorage)) {}
+	return c
+}
+
+func TestAdjustWithPresets(t *testing.T) {
+	c := newTestConfig()
+	c.Preset = PresetConfig{Size: "large", Disk: "hdd"}
+	c.Snapshot.MaxConcurrencySnapChunks = 4
+	c.Adjust()
+
+	// the user value wins, then the disk preset, the size preset and the default
+	assert.Equal(t, uint64(4), c.Snapshot.MaxConcurrencySnapChunks)
+	assert.Equal(t, typeutil.ByteSize(8*mb), c.Snapshot.SnapChunkSize)
+	assert.Equal(t, 2*defaultRaftElectionTick, c.Raft.ElectionTimeoutTicks)
+	assert.Equal(t, uint64(128), c.Worker.RaftEventWorkers)
+	assert.Equal(t, defaultRaftHeartbeatTick, c.Raft.HeartbeatTicks)
+}
+
+func TestDescribeConfig(t *testing.T) {
+	c := newTestConfig()
+	c.Preset = PresetConfig{Size: "small", Disk: "hdd"}
+	c.Snapshot.MaxConcurrencySnapChunks = 4
+	c.QoS.SaturationRatio = 2
+	c.Adjust()
+
+	items := make(map[string]ConfigItem)
+	for _, item := range c.DescribeConfig() {
+		items[item.Name] = item
+	}
+	assert.Equal(t, ConfigItem{Name: "snapshot.max-concurrency-snap-chunks", Value: "4", Source: SourceUser},
+		items["snapshot.max-concurrency-snap-chunks"])
+	assert.Equal(t, "preset:hdd", items["snapshot.snap-chunk-size"].Source)
+	assert.Equal(t, "preset:small", items["worker.raft-event-workers"].Source)
+	assert.Equal(t, ConfigItem{Name: "raft.tick-interval", Value: "1s", Source: SourceDefault},
+		items["raft.tick-interval"])
+	assert.Equal(t, SourceUnset, items["debug.addr"].Source)
+	// the invalid user value is replaced by the default
+	assert.Equal(t, ConfigItem{Name: "qos.saturation-ratio", Value: "0.9", Source: SourceDefault, Origin: "2"},
+		items["qos.saturation-ratio"])
+	_, ok := items["prophet.schedule.max-snapshot-count"]
+	assert.True(t, ok)
+}
+
+func TestValidate(t *testing.T) {
+	c := newTestConfig()
+	c.Adjust()
+	require.NoError(t, c.Validate())
+
+	c.Preset = PresetConfig{Size: "huge", Disk: "ssd"}
+	c.Labels = [][]string{{"zone", "z1"}, {"rack"}}
+	c.ClientAddr = c.RaftAddr
+	c.Raft.ElectionTimeoutTicks = c.Raft.HeartbeatTicks
+	c.Capacity = typeutil.ByteSize(100 * mb)
+	c.QoS.Groups = []GroupQoSConfig{{Group: 1, Weight: 1}, {Group: 1}}
+	err := c.Validate()
+	require.Error(t, err)
+	problems := err.(*ValidationError).Problems
+	assert.Equal(t, 7, len(problems), "%v", problems)
+	assert.Contains(t, problems[0], "[large small standard]")
+	assert.Contains(t, problems[4], "lower raft.raft-log.compact-threshold to at most 10")
+
+	c = newTestConfig()
+	c.Adjust()
+	c.Raft.LeaderLeaseDuration.Duration = c.Raft.GetElectionTimeoutDuration()
+	err = c.Validate()
+	require.Error(t, err)
+	problems = err.(*ValidationError).Problems
+	require.Equal(t, 1, len(problems), "%v", problems)
+	assert.Contains(t, problems[0], "raft.leader-lease-duration")
+	c.Raft.LeaderLeaseDuration.Duration = c.Raft.GetElectionTimeoutDuration() / 2
+	require.NoError(t, c.Validate())
+
+	c.Raft.HibernateTicks = c.Raft.ElectionTimeoutTicks
+	err = c.Validate()
+	require.Error(t, err)
+	problems = err.(*ValidationError).Problems
+	require.Equal(t, 1, len(problems), "%v", problems)
+	assert.Contains(t, problems[0], "raft.hibernate-ticks")
+	c.Raft.HibernateTicks = 2 * c.Raft.ElectionTimeoutTicks
+	require.NoError(t, c.Validate())
+
+	c.AuditSync.Enable = true
+	err = c.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.(*ValidationError).Problems[0], "audit-sync")
+	c.AuditSync.Enable = false
+
+	c.DiskPressure.UsedRatio = 1
+	err = c.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.(*ValidationError).Problems[0], "disk-pressure.used-ratio")
+
+	c.DiskPressure.UsedRatio = 0
+
+	c.IOError.Policy = "ignore"
+	err = c.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.(*ValidationError).Problems[0], "io-error.policy")
+	c.IOError.Policy = IOErrorRetry
+	require.NoError(t, c.Validate())
+
+	c.AutoTune.DecreaseRatio = 1
+	err = c.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.(*ValidationError).Problems[0], "auto-tune.decrease-ratio")
+	c.AutoTune.DecreaseRatio = 0
+
+	c.AutoTune.MinApplyBatchSize = 2
+	c.AutoTune.MaxApplyBatchSize = 1
+	err = c.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.(*ValidationError).Problems[0], "auto-tune.min-apply-batch-size")
+	c.AutoTune.MinApplyBatchSize = 0
+	c.AutoTune.MaxApplyBatchSize = 0
+	require.NoError(t, c.Validate())
+
+	disabled := false
+	c.Raft.Groups = []GroupRaftConfig{{Group: 1, CheckQuorum: &disabled}, {Group: 1}}
+	err = c.Validate()
+	require.Error(t, err)
+	problems = err.(*ValidationError).Problems
+	require.Equal(t, 2, len(problems), "%v", problems)
+	assert.Contains(t, problems[0], "check-quorum of group 1")
+	assert.Contains(t, problems[1], "duplicated group 1")
+	c.Raft.Groups = nil
+
+	c.Raft.RaftLog.Shards = []ShardRaftLogConfig{{Shard: 1}, {Shard: 1, MaxEntries: 10}}
+	err = c.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.(*ValidationError).Problems[0], "duplicated shard 1")
+	c.Raft.RaftLog.Shards = nil
+
+	c.Worker.GroupPools = []GroupWorkerPoolConfig{
+		{Groups: []uint64{1, 2}},
+		{Groups: []uint64{2}, TickInterval: typeutil.NewDuration(c.Raft.TickInterval.Duration / 10)},
+		{},
+	}
+	err = c.Validate()
+	require.Error(t, err)
+	problems = err.(*ValidationError).Problems
+	require.Equal(t, 3, len(problems), "%v", problems)
+	assert.Contains(t, problems[0], "duplicated group 2")
+	assert.Contains(t, problems[1], "of the groups [2]")
+	assert.Contains(t, problems[2], "pool without groups")
+	c.Worker.GroupPools = nil
+
+	c = newTestConfig()
+	c.Raft.HeartbeatTicks = 20
+	assert.Panics(t, c.Adjust)
+}
+
+func TestGetElectionOptions(t *testing.T) {
+	enabled, disabled := true, false
+	c := RaftConfig{
+		DisablePreVote: true,
+		Groups: []GroupRaftConfig{
+			{Group: 1, PreVote: &enabled},
+			{Group: 2, CheckQuorum: &disabled},
+		},
+	}
+
+	preVote, checkQuorum := c.GetElectionOptions(0)
+	assert.False(t, preVote)
+	assert.True(t, checkQuorum)
+	preVote, checkQuorum = c.GetElectionOptions(1)
+	assert.True(t, preVote)
+	assert.True(t, checkQuorum)
+	preVote, checkQuorum = c.GetElectionOptions(2)
+	assert.False(t, preVote)
+	assert.False(t, checkQuorum)
+}
+
+func TestGetRaftLogGCPolicy(t *testing.T) {
+	c := RaftLogConfig{
+		CompactThreshold: 256,
+		MaxEntries:       1000,
+		Shards: []ShardRaftLogConfig{
+			{Shard: 1, MaxEntries: 10, MaxFollowerLag: 5},
+		},
+	}
+
+	assert.Equal(t, RaftLogGCPolicy{CompactThreshold: 256, MaxEntries: 1000}, c.GetGCPolicy(2))
+	assert.Equal(t, RaftLogGCPolicy{CompactThreshold: 256, MaxEntries: 10, MaxFollowerLag: 5}, c.GetGCPolicy(1))
+}
+
+func TestGetMaxProposalBytes(t *testing.T) {
+	c := &RaftConfig{}
+	c.adjust()
+	assert.Equal(t, uint64(defaultMaxProposalBytes), c.GetMaxProposalBytes())
+
+	c.MaxProposalBytes = c.MaxEntryBytes / 2
+	assert.Equal(t, uint64(c.MaxEntryBytes), c.GetMaxProposalBytes())
+}
+
+func TestGetGroupPool(t *testing.T) {
+	c := &Config{}
+	c.Raft.TickInterval = typeutil.NewDuration(time.Millisecond * 100)
+	c.Raft.MaxApplyBatchSize = typeutil.ByteSize(mb)
+	c.Worker.GroupPools = []GroupWorkerPoolConfig{
+		{Groups: []uint64{1}, TickInterval: typeutil.NewDuration(time.Second)},
+		{Groups: []uint64{2, 3}, MaxApplyBatchSize: typeutil.ByteSize(kb)},
+	}
+	c.Worker.adjust()
+
+	_, ok := c.Worker.GetGroupPool(0)
+	assert.False(t, ok)
+	p, ok := c.Worker.GetGroupPool(3)
+	assert.True(t, ok)
+	assert.Equal(t, defaultGroupPoolWorkers, p.Workers)
+
+	assert.Equal(t, time.Millisecond*100, c.GetTickInterval(0))
+	assert.Equal(t, time.Second, c.GetTickInterval(1))
+	assert.Equal(t, time.Millisecond*100, c.GetTickInterval(2))
+	assert.Equal(t, uint64(mb), c.GetMaxApplyBatchSize(1))
+	assert.Equal(t, uint64(kb), c.GetMaxApplyBatchSize(2))
+}
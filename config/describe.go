@@ -0,0 +1,140 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	// SourceUser the value is set explicitly
+	SourceUser = "user"
+	// SourceDefault the value is filled by the default
+	SourceDefault = "default"
+	// SourceUnset the value is not set and has no default
+	SourceUnset = "unset"
+	// sourcePresetPrefix the value is filled by the preset, e.g. preset:hdd
+	sourcePresetPrefix = "preset:"
+)
+
+// ConfigItem is the effective value of a config item and where it comes from
+type ConfigItem struct {
+	// Name the toml path of the item, e.g. raft.raft-log.compact-threshold
+	Name string `json:"name"`
+	// Value the effective value
+	Value string `json:"value"`
+	// Source one of user, default, unset or preset:<name>
+	Source string `json:"source"`
+	// Origin the value set explicitly, only if it's replaced by Adjust
+	Origin string `json:"origin,omitempty"`
+}
+
+// configOrigin is the values before and during Adjust, used to find out the
+// source of the effective values.
+type configOrigin struct {
+	user    map[string]configValue
+	presets map[string]string // item name -> preset name
+}
+
+type configValue struct {
+	value string
+	zero  bool
+}
+
+// DescribeConfig explains each effective config item and its source. The
+// sources are only known if the config is adjusted, otherwise all the items
+// are described as set explicitly.
+func (c *Config) DescribeConfig() []ConfigItem {
+	var items []ConfigItem
+	walkConfig(reflect.ValueOf(c).Elem(), "", func(name string, v configValue) {
+		item := ConfigItem{Name: name, Value: v.value}
+		user, adjusted := c.origin.user[name]
+		switch {
+		case !adjusted:
+			item.Source = SourceUser
+		case !user.zero && user.value == v.value:
+			item.Source = SourceUser
+		case c.origin.presets[name] != "":
+			item.Source = sourcePresetPrefix + c.origin.presets[name]
+		case v.zero:
+			item.Source = SourceUnset
+		default:
+			item.Source = SourceDefault
+		}
+		if adjusted && !user.zero && user.value != v.value {
+			item.Origin = user.value
+		}
+		items = append(items, item)
+	})
+	return items
+}
+
+// recordUserValues records the values set explicitly, must be called before
+// any value is changed by Adjust.
+func (c *Config) recordUserValues() {
+	c.origin.user = c.configValues()
+	c.origin.presets = make(map[string]string)
+}
+
+// recordPresetValues records the values changed by the preset
+func (c *Config) recordPresetValues(name string, before map[string]configValue) {
+	for item, v := range c.configValues() {
+		if before[item] != v {
+			c.origin.presets[item] = name
+		}
+	}
+}
+
+func (c *Config) configValues() map[string]configValue {
+	values := make(map[string]configValue)
+	walkConfig(reflect.ValueOf(c).Elem(), "", func(name string, v configValue) {
+		values[name] = v
+	})
+	return values
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// walkConfig visits the config items with the toml tags, the nested structs
+// are visited recursively unless they are marshaled as text.
+func walkConfig(v reflect.Value, prefix string, fn func(name string, v configValue)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("toml"), ",")[0]
+		if !field.IsExported() || tag == "" || tag == "-" {
+			continue
+		}
+
+		name := prefix + tag
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct && !fv.Type().Implements(textMarshalerType) {
+			walkConfig(fv, name+".", fn)
+			continue
+		}
+		fn(name, configValue{value: formatConfigValue(fv), zero: fv.IsZero()})
+	}
+}
+
+func formatConfigValue(v reflect.Value) string {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sort"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+)
+
+// PresetConfig selects the named profiles used to fill the config items which
+// are not set explicitly. The disk profile is applied before the size profile,
+// the items not covered by any profile use the defaults.
+type PresetConfig struct {
+	// Size the size profile of the store, one of small, standard and large
+	Size string `toml:"size"`
+	// Disk the disk profile of the store, one of ssd and hdd
+	Disk string `toml:"disk"`
+}
+
+// preset fills the config items which are not set
+type preset func(c *Config)
+
+var (
+	sizePresets = map[string]preset{
+		"small": func(c *Config) {
+			setIfZero(&c.Worker.RaftEventWorkers, 8)
+			setIfZero(&c.Raft.SendRaftBatchSize, 16)
+			setIfZero(&c.Raft.MaxEntryBytes, typeutil.ByteSize(2*mb))
+			setIfZero(&c.Raft.RaftLog.CompactThreshold, 128)
+			setIfZero(&c.Snapshot.MaxConcurrencySnapChunks, 2)
+			setIfZero(&c.Snapshot.SnapChunkSize, typeutil.ByteSize(mb))
+		},
+		// standard uses the defaults
+		"standard": func(c *Config) {},
+		"large": func(c *Config) {
+			setIfZero(&c.Worker.RaftEventWorkers, 128)
+			setIfZero(&c.Raft.SendRaftBatchSize, 128)
+			setIfZero(&c.Raft.MaxInflightMsgs, 32)
+			setIfZero(&c.Raft.RaftLog.CompactThreshold, 1024)
+			setIfZero(&c.Snapshot.MaxConcurrencySnapChunks, 16)
+			setIfZero(&c.Snapshot.SnapChunkSize, typeutil.ByteSize(8*mb))
+		},
+	}
+
+	diskPresets = map[string]preset{
+		"ssd": func(c *Config) {},
+		// hdd has higher and unstable fsync latency, the election timeout is
+		// longer to avoid the unnecessary elections, and the snapshots are sent
+		// in fewer but bigger chunks to keep the io sequential.
+		"hdd": func(c *Config) {
+			setIfZero(&c.Raft.ElectionTimeoutTicks, 2*defaultRaftElectionTick)
+			setIfZero(&c.Snapshot.MaxConcurrencySnapChunks, 2)
+			setIfZero(&c.Snapshot.SnapChunkSize, typeutil.ByteSize(8*mb))
+			setIfZero(&c.Replication.CompactLogCheckDuration, typeutil.NewDuration(2*defaultCompactLogCheckDuration))
+		},
+	}
+)
+
+func setIfZero[T comparable](v *T, value T) {
+	var zero T
+	if *v == zero {
+		*v = value
+	}
+}
+
+type namedPreset struct {
+	name  string
+	apply preset
+}
+
+// getPresets returns the selected presets in the order to be applied
+func (c *Config) getPresets() []namedPreset {
+	var presets []namedPreset
+	if p, ok := diskPresets[c.Preset.Disk]; ok {
+		presets = append(presets, namedPreset{name: c.Preset.Disk, apply: p})
+	}
+	if p, ok := sizePresets[c.Preset.Size]; ok {
+		presets = append(presets, namedPreset{name: c.Preset.Size, apply: p})
+	}
+	return presets
+}
+
+func presetNames(presets map[string]preset) []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ValidationError is returned if the config is invalid, each problem describes
+// the conflicting items and how to fix them.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid config, %d problem(s):\n  - %s",
+		len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+func (e *ValidationError) addf(format string, args ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
+}
+
+// Validate checks the adjusted config, the conflicting items that would make
+// the store misbehave at runtime are reported together.
+func (c *Config) Validate() error {
+	e := &ValidationError{}
+
+	if _, ok := sizePresets[c.Preset.Size]; c.Preset.Size != "" && !ok {
+		e.addf("preset.size %q is unknown, use one of %v",
+			c.Preset.Size, presetNames(sizePresets))
+	}
+	if _, ok := diskPresets[c.Preset.Disk]; c.Preset.Disk != "" && !ok {
+		e.addf("preset.disk %q is unknown, use one of %v",
+			c.Preset.Disk, presetNames(diskPresets))
+	}
+
+	for i, label := range c.Labels {
+		if len(label) != 2 {
+			e.addf("labels[%d] %v must be a [key, value] pair", i, label)
+		}
+	}
+
+	if c.RaftAddr == c.ClientAddr {
+		e.addf("addr-raft and addr-client are both %q, use different addresses",
+			c.RaftAddr)
+	}
+	if c.Debug.Addr != "" &&
+		(c.Debug.Addr == c.RaftAddr || c.Debug.Addr == c.ClientAddr) {
+		e.addf("debug.addr %q conflicts with addr-raft or addr-client, use a different address",
+			c.Debug.Addr)
+	}
+
+	if c.Raft.ElectionTimeoutTicks <= c.Raft.HeartbeatTicks {
+		e.addf("raft.election-timeout-ticks (%d) must be greater than raft.heartbeat-ticks (%d), set it to at least %d",
+			c.Raft.ElectionTimeoutTicks, c.Raft.HeartbeatTicks, c.Raft.HeartbeatTicks*defaultRaftElectionTick/defaultRaftHeartbeatTick)
+	}
+
+	if c.Raft.HibernateTicks > 0 && c.Raft.HibernateTicks <= c.Raft.ElectionTimeoutTicks {
+		e.addf("raft.hibernate-ticks (%d) must be greater than raft.election-timeout-ticks (%d), set it to at least %d or 0 to disable the hibernation",
+			c.Raft.HibernateTicks, c.Raft.ElectionTimeoutTicks, 2*c.Raft.ElectionTimeoutTicks)
+	}
+
+	if c.Raft.LeaderLeaseDuration.Duration >= c.Raft.GetElectionTimeoutDuration() {
+		e.addf("raft.leader-lease-duration (%s) must be less than the election timeout (%s), lower it to leave a margin for the clock drift",
+			c.Raft.LeaderLeaseDuration.Duration, c.Raft.GetElectionTimeoutDuration())
+	}
+
+	if c.Raft.LeaderLeaseDuration.Duration > 0 {
+		if c.Raft.DisableCheckQuorum {
+			e.addf("raft.leader-lease-duration is set with raft.disable-check-quorum, the lease reads require the check quorum, disable one of them")
+		}
+		for _, g := range c.Raft.Groups {
+			if g.CheckQuorum != nil && !*g.CheckQuorum {
+				e.addf("raft.groups check-quorum of group %d is disabled with raft.leader-lease-duration set, the lease reads require the check quorum, disable one of them",
+					g.Group)
+			}
+		}
+	}
+	raftGroups := make(map[uint64]struct{})
+	for _, g := range c.Raft.Groups {
+		if _, ok := raftGroups[g.Group]; ok {
+			e.addf("raft.groups has duplicated group %d, keep only one override for it", g.Group)
+		}
+		raftGroups[g.Group] = struct{}{}
+	}
+	poolGroups := make(map[uint64]struct{})
+	for _, p := range c.Worker.GroupPools {
+		if len(p.Groups) == 0 {
+			e.addf("worker.group-pools has a pool without groups, set its groups or remove it")
+		}
+		for _, g := range p.Groups {
+			if _, ok := poolGroups[g]; ok {
+				e.addf("worker.group-pools has duplicated group %d, keep it in only one pool", g)
+			}
+			poolGroups[g] = struct{}{}
+		}
+		electionTimeout := time.Duration(c.Raft.ElectionTimeoutTicks) * p.TickInterval.Duration
+		if p.TickInterval.Duration > 0 && c.Raft.LeaderLeaseDuration.Duration >= electionTimeout {
+			e.addf("raft.leader-lease-duration (%s) must be less than the election timeout (%s) of the groups %v, raise their worker.group-pools tick-interval",
+				c.Raft.LeaderLeaseDuration.Duration, electionTimeout, p.Groups)
+		}
+	}
+	raftLogShards := make(map[uint64]struct{})
+	for _, s := range c.Raft.RaftLog.Shards {
+		if _, ok := raftLogShards[s.Shard]; ok {
+			e.addf("raft.raft-log.shards has duplicated shard %d, keep only one override for it", s.Shard)
+		}
+		raftLogShards[s.Shard] = struct{}{}
+	}
+
+	// the raft log is only compacted after CompactThreshold entries are
+	// replicated, the uncompacted log must fit in the capacity
+	if c.Capacity > 0 {
+		retained := c.Raft.RaftLog.CompactThreshold * uint64(c.Raft.MaxEntryBytes)
+		if uint64(c.Raft.MaxEntryBytes) > uint64(c.Capacity) {
+			e.addf("raft.max-entry-bytes (%d) exceeds capacity (%d), lower raft.max-entry-bytes",
+				c.Raft.MaxEntryBytes, c.Capacity)
+		} else if retained > uint64(c.Capacity) {
+			e.addf("raft.raft-log.compact-threshold (%d) entries of raft.max-entry-bytes (%d) may retain %d bytes of raft log, more than capacity (%d), lower raft.raft-log.compact-threshold to at most %d",
+				c.Raft.RaftLog.CompactThreshold, c.Raft.MaxEntryBytes, retained,
+				c.Capacity, uint64(c.Capacity)/uint64(c.Raft.MaxEntryBytes))
+		}
+	}
+
+	if c.Replication.DeltaShardHeartbeat &&
+		c.Replication.ShardHeartbeatFullSyncDuration.Duration < c.Replication.ShardHeartbeatDuration.Duration {
+		e.addf("replication.shard-heartbeat-full-sync-duration (%s) is less than replication.shard-heartbeat-duration (%s), delta-shard-heartbeat has no effect",
+			c.Replication.ShardHeartbeatFullSyncDuration.Duration, c.Replication.ShardHeartbeatDuration.Duration)
+	}
+
+	groups := make(map[uint64]struct{})
+	for _, g := range c.QoS.Groups {
+		if _, ok := groups[g.Group]; ok {
+			e.addf("qos.groups has duplicated group %d, keep only one weight for it", g.Group)
+		}
+		groups[g.Group] = struct{}{}
+		if g.Weight == 0 {
+			e.addf("qos.groups weight of group %d is 0, use a positive weight or remove it to use qos.default-weight",
+				g.Group)
+		}
+	}
+
+	if c.AuditSync.Enable && c.Customize.CustomObjectStorage == nil {
+		e.addf("audit-sync is enabled without the object storage, set Customize.CustomObjectStorage or disable it")
+	}
+
+	if c.DiskPressure.UsedRatio < 0 || c.DiskPressure.UsedRatio >= 1 {
+		e.addf("disk-pressure.used-ratio (%v) must be in [0, 1), set it to 0 to disable the protection",
+			c.DiskPressure.UsedRatio)
+	}
+
+	switch c.IOError.Policy {
+	case "", IOErrorPanic, IOErrorRetry, IOErrorMarkStoreDown, IOErrorPropagate:
+	default:
+		e.addf("io-error.policy (%s) must be one of %s, %s, %s and %s",
+			c.IOError.Policy, IOErrorPanic, IOErrorRetry, IOErrorMarkStoreDown, IOErrorPropagate)
+	}
+	if c.IOError.RetryTimes < 0 {
+		e.addf("io-error.retry-times (%d) must not be negative", c.IOError.RetryTimes)
+	}
+
+	if c.AutoTune.DecreaseRatio < 0 || c.AutoTune.DecreaseRatio >= 1 {
+		e.addf("auto-tune.decrease-ratio (%v) must be in (0, 1), set it to 0 to use the default",
+			c.AutoTune.DecreaseRatio)
+	}
+	if c.AutoTune.MaxApplyBatchSize > 0 && c.AutoTune.MinApplyBatchSize > c.AutoTune.MaxApplyBatchSize {
+		e.addf("auto-tune.min-apply-batch-size (%d) exceeds auto-tune.max-apply-batch-size (%d)",
+			c.AutoTune.MinApplyBatchSize, c.AutoTune.MaxApplyBatchSize)
+	}
+	if c.AutoTune.MaxWriteBatchSize > 0 && c.AutoTune.MinWriteBatchSize > c.AutoTune.MaxWriteBatchSize {
+		e.addf("auto-tune.min-write-batch-size (%d) exceeds auto-tune.max-write-batch-size (%d)",
+			c.AutoTune.MinWriteBatchSize, c.AutoTune.MaxWriteBatchSize)
+	}
+
+	if len(e.Problems) > 0 {
+		return e
+	}
+	return nil
+}
@@ -25,6 +25,8 @@ const (
 	appliedIndexSuffix = 0x07
 	metadataSuffix     = 0x08
 	snapshotSuffix     = 0x09
+	// snapshotApplyingSuffix the key of the snapshot being applied
+	snapshotApplyingSuffix = 0x0A
 )
 
 // data is in (z, z+1)
@@ -106,6 +108,25 @@ func GetMetadataKey(shardID uint64, index uint64, key []byte) []byte {
 	return getIndexedIDKey(metadataSuffix, shardID, index, key)
 }
 
+// GetSnapshotApplyingKey returns key that used to store the
+// `SnapshotApplyingState` for `storage.DataStorage`
+func GetSnapshotApplyingKey(shardID uint64, key []byte) []byte {
+	key = getKeySlice(key, idKeyLength)
+	return getIDKey(snapshotApplyingSuffix, shardID, key)
+}
+
+// GetShardIDFromSnapshotApplyingKey returns shard id
+func GetShardIDFromSnapshotApplyingKey(key []byte) (uint64, error) {
+	if !IsSnapshotApplyingKey(key) {
+		return 0, fmt.Errorf("key<%v> is not a valid snapshot applying key", key)
+	}
+	return parseUint64(key[len(raftPrefixKey):]), nil
+}
+
+func IsSnapshotApplyingKey(key []byte) bool {
+	return isRaftSuffixKey(key, snapshotApplyingSuffix) && len(key) == idKeyLength
+}
+
 func GetMetadataIndex(key []byte) (uint64, error) {
 	if !IsMetadataKey(key) {
 		return 0, fmt.Errorf("key<%v> is not a valid metadata key", key)
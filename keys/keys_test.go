@@ -94,6 +94,31 @@ func TestGetShardIDFromAppliedIndexKey(t *testing.T) {
 	}
 }
 
+func TestGetShardIDFromSnapshotApplyingKey(t *testing.T) {
+	tests := []struct {
+		key     []byte
+		result  uint64
+		noError bool
+	}{
+		{GetSnapshotApplyingKey(0, nil), 0, true},
+		{GetSnapshotApplyingKey(1, nil), 1, true},
+		{GetSnapshotApplyingKey(math.MaxUint64, nil), math.MaxUint64, true},
+		{GetAppliedIndexKey(1, nil), 0, false},
+		{GetMaxIndexKey(1, nil), 0, false},
+		{GetMetadataKey(100, 1, nil), 0, false},
+	}
+
+	for idx, ct := range tests {
+		shardID, err := GetShardIDFromSnapshotApplyingKey(ct.key)
+		if ct.noError {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+		assert.Equal(t, ct.result, shardID, "index %d", idx)
+	}
+}
+
 func TestGetMetadataShradID(t *testing.T) {
 	tests := []struct {
 		key     []byte
@@ -42,6 +42,7 @@ import (
 	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/util"
 	"github.com/matrixorigin/matrixcube/util/buf"
+	"github.com/matrixorigin/matrixcube/util/fsync"
 )
 
 var (
@@ -94,6 +95,11 @@ type LogDB interface {
 	// concurrently called with the same shardID.
 	SaveRaftState(shardID uint64,
 		replicaID uint64, rd raft.Ready, ctx *WorkerContext) error
+	// SaveRaftStateAsync is the asynchronous version of SaveRaftState, the
+	// returned func waits until the raft state is saved and must be called
+	// exactly once before the ctx is reused.
+	SaveRaftStateAsync(shardID uint64,
+		replicaID uint64, rd raft.Ready, ctx *WorkerContext) func() error
 	// IterateEntries returns the continuous Raft log entries of the specified
 	// Raft node between the index value range of [low, high) up to a max size
 	// limit of maxSize bytes. It returns the located log entries, their total
@@ -119,8 +125,9 @@ type LogDB interface {
 
 // KVLogDB is a LogDB implementation built on top of a Key-Value store.
 type KVLogDB struct {
-	logger *zap.Logger
-	ms     storage.KVMetadataStore
+	logger   *zap.Logger
+	ms       storage.KVMetadataStore
+	syncPool *fsync.Pool
 }
 
 var _ LogDB = (*KVLogDB)(nil)
@@ -132,6 +139,12 @@ func NewKVLogDB(ms storage.KVMetadataStore, logger *zap.Logger) *KVLogDB {
 	}
 }
 
+// SetSyncPool sets the pool running the fsync of the raft log appends, nil
+// means the fsync runs on the raft worker goroutines.
+func (l *KVLogDB) SetSyncPool(pool *fsync.Pool) {
+	l.syncPool = pool
+}
+
 func (l *KVLogDB) Name() string {
 	return "KVLogDB"
 }
@@ -186,8 +199,13 @@ func (l *KVLogDB) GetAllSnapshots(shardID uint64) ([]raftpb.Snapshot, error) {
 
 func (l *KVLogDB) SaveRaftState(shardID uint64,
 	replicaID uint64, rd raft.Ready, ctx *WorkerContext) error {
+	return l.SaveRaftStateAsync(shardID, replicaID, rd, ctx)()
+}
+
+func (l *KVLogDB) SaveRaftStateAsync(shardID uint64,
+	replicaID uint64, rd raft.Ready, ctx *WorkerContext) func() error {
 	if IsEmptyRaftReady(rd) {
-		return nil
+		return func() error { return nil }
 	}
 
 	l.logger.Debug("save raft state",
@@ -232,7 +250,16 @@ func (l *KVLogDB) SaveRaftState(shardID uint64,
 			buf.Uint64ToBytesTo(rd.Entries[len(rd.Entries)-1].Index, value)
 		})
 	}
-	return l.ms.Write(ctx.wb, true)
+	// the writes of the replicas are made durable by a shared fsync if the
+	// pool groups the commits
+	if l.syncPool.GroupCommit() {
+		return l.syncPool.CommitAsync(func() error {
+			return l.ms.Write(ctx.wb, false)
+		})
+	}
+	return l.syncPool.DoAsync(func() error {
+		return l.ms.Write(ctx.wb, true)
+	})
 }
 
 func (l *KVLogDB) IterateEntries(ents []raftpb.Entry,
@@ -14,7 +14,10 @@
 package metric
 
 import (
+	"net/http"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var (
@@ -26,22 +29,47 @@ func MustRegister(cs ...prometheus.Collector) {
 	registry.MustRegister(cs...)
 }
 
+// Handler returns a http handler to expose the metrics, the OpenMetrics format
+// is enabled to expose the exemplars. It's served at /metrics by the debug
+// service of the store, embedders without the debug service mount it on their
+// own http servers.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+}
+
 func init() {
 	registry.MustRegister(queueGauge)
 	registry.MustRegister(batchGauge)
 	registry.MustRegister(storeStorageGauge)
 	registry.MustRegister(shardCountGauge)
+	registry.MustRegister(topApplyCPUShardsGauge)
+	registry.MustRegister(autoTuneGauge)
 
 	registry.MustRegister(raftReadyCounter)
 	registry.MustRegister(raftMsgsCounter)
 	registry.MustRegister(raftCommandCounter)
 	registry.MustRegister(raftAdminCommandCounter)
+	registry.MustRegister(tombstoneGCReplicasCounter)
+	registry.MustRegister(tombstoneGCBytesCounter)
+	registry.MustRegister(readLoadSheddingCounter)
+	registry.MustRegister(writeAdmissionRejectedCounter)
+	registry.MustRegister(auditSyncCounter)
+	registry.MustRegister(diskPressureCounter)
+	registry.MustRegister(proxyReadCacheCounter)
+	registry.MustRegister(consistencyCheckCounter)
+	registry.MustRegister(raftLogGCCounter)
 
 	registry.MustRegister(raftLogLagHistogram)
 	registry.MustRegister(raftLogAppendDurationHistogram)
 	registry.MustRegister(raftLogApplyDurationHistogram)
+	registry.MustRegister(requestDurationHistogram)
 	registry.MustRegister(raftProposalSizeHistogram)
+	registry.MustRegister(raftLogEntrySizeHistogram)
 	registry.MustRegister(snapshotSizeHistogram)
 	registry.MustRegister(snapshotBuildingDurationHistogram)
 	registry.MustRegister(snapshotSendingDurationHistogram)
+	registry.MustRegister(fsyncWaitDurationHistogram)
+	registry.MustRegister(fsyncDurationHistogram)
+	registry.MustRegister(fsyncGroupSizeHistogram)
+	registry.MustRegister(raftReadyStageDurationHistogram)
 }
@@ -49,8 +49,129 @@ var (
 			Name:      "command_admin_total",
 			Help:      "Total number of admin commands processed.",
 		}, []string{"type", "status"})
+
+	tombstoneGCReplicasCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "tombstone_gc_replicas_total",
+			Help:      "Total number of tombstone replicas deleted by the tombstone gc.",
+		})
+
+	tombstoneGCBytesCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "tombstone_gc_reclaimed_bytes_total",
+			Help:      "Total approximate bytes reclaimed by the tombstone gc.",
+		})
+
+	readLoadSheddingCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "read_load_shedding_total",
+			Help:      "Total number of reads rejected or downgraded to stale reads due to the apply lag.",
+		}, []string{"type"})
+
+	writeAdmissionRejectedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "write_admission_rejected_total",
+			Help:      "Total number of write batches rejected by the write admission control.",
+		}, []string{"reason"})
+
+	auditSyncCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "audit_sync_total",
+			Help:      "Total number of the objects handled by the audit sync.",
+		}, []string{"type", "result"})
+
+	diskPressureCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "disk_pressure_total",
+			Help:      "Total number of the actions taken under the disk pressure.",
+		}, []string{"action"})
+
+	proxyReadCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "proxy_read_cache_total",
+			Help:      "Total number of the cacheable reads served by or missed in the proxy read cache.",
+		}, []string{"type"})
+
+	consistencyCheckCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "consistency_check_total",
+			Help:      "Total number of the data checksums verified by the replicas.",
+		}, []string{"result"})
+
+	raftLogGCCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "raft_log_gc_total",
+			Help:      "Total number of the raft log compactions requested by the gc policy.",
+		}, []string{"reason"})
 )
 
+// AddTombstoneGCReclaimed add the deleted tombstone replicas and the reclaimed
+// bytes
+func AddTombstoneGCReclaimed(replicas, bytes uint64) {
+	tombstoneGCReplicasCounter.Add(float64(replicas))
+	tombstoneGCBytesCounter.Add(float64(bytes))
+}
+
+// IncReadLoadShedding inc the reads rejected or downgraded to stale reads, the
+// type is rejected or stale
+func IncReadLoadShedding(tp string) {
+	readLoadSheddingCounter.WithLabelValues(tp).Inc()
+}
+
+// IncWriteAdmissionRejected inc the write batches rejected by the write
+// admission control due to the reason
+func IncWriteAdmissionRejected(reason string) {
+	writeAdmissionRejectedCounter.WithLabelValues(reason).Inc()
+}
+
+// IncAuditSync inc the objects of the type handled by the audit sync, the
+// result is uploaded, failed or dropped
+func IncAuditSync(tp, result string) {
+	auditSyncCounter.WithLabelValues(tp, result).Inc()
+}
+
+// AddDiskPressure adds the number of the actions taken under the disk pressure,
+// the action is released-view or compaction
+func AddDiskPressure(action string, n int) {
+	diskPressureCounter.WithLabelValues(action).Add(float64(n))
+}
+
+// IncProxyReadCache inc the cacheable reads of the proxy, the type is hit or
+// miss
+func IncProxyReadCache(tp string) {
+	proxyReadCacheCounter.WithLabelValues(tp).Inc()
+}
+
+// IncConsistencyCheck inc the data checksums verified by the replicas, the
+// result is matched, mismatched or skipped
+func IncConsistencyCheck(result string) {
+	consistencyCheckCounter.WithLabelValues(result).Inc()
+}
+
+// IncRaftLogGC inc the raft log compactions requested by the gc policy, the
+// reason is the threshold reached
+func IncRaftLogGC(reason string) {
+	raftLogGCCounter.WithLabelValues(reason).Inc()
+}
+
 // IncComandCount inc the command received
 func IncComandCount(cmd string) {
 	raftCommandCounter.WithLabelValues(cmd).Inc()
@@ -126,6 +247,11 @@ func AddRaftProposalReadIndexCount(value uint64) {
 	raftMsgsCounter.WithLabelValues("read-index").Add(float64(value))
 }
 
+// AddRaftProposalFollowerReadIndexCount add read index of follower reads
+func AddRaftProposalFollowerReadIndexCount(value uint64) {
+	raftMsgsCounter.WithLabelValues("follower-read-index").Add(float64(value))
+}
+
 // AddRaftProposalNormalCount add normal
 func AddRaftProposalNormalCount(value uint64) {
 	raftMsgsCounter.WithLabelValues("normal").Add(float64(value))
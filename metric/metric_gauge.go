@@ -14,6 +14,9 @@
 package metric
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -49,13 +52,40 @@ var (
 			Name:      "store_storage_bytes",
 			Help:      "Size of raftstore storage.",
 		}, []string{"type"})
+
+	topApplyCPUShardsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "top_apply_cpu_shard_seconds",
+			Help:      "Time spent by the apply loop of the top shards in the last sampling window.",
+		}, []string{"shard"})
+
+	autoTuneGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "auto_tune_value",
+			Help:      "Batch sizes and commit delay chosen by the auto-tuner of the shard groups.",
+		}, []string{"group", "type"})
 )
 
+// SetVacuumQueueMetric set the count of the pending tasks of destroying the
+// replicas and the tombstones
+func SetVacuumQueueMetric(size int64) {
+	queueGauge.WithLabelValues("vacuum").Set(float64(size))
+}
+
 // SetRaftMsgQueueMetric set send raft message queue size
 func SetRaftMsgQueueMetric(size int64) {
 	queueGauge.WithLabelValues("sent-raft").Set(float64(size))
 }
 
+// SetFsyncQueueMetric set the queue size of the fsync pool
+func SetFsyncQueueMetric(pool string, size int64) {
+	queueGauge.WithLabelValues("fsync-" + pool).Set(float64(size))
+}
+
 // SetRaftTickQueueMetric set raft tick queue size
 func SetRaftTickQueueMetric(size int64) {
 	queueGauge.WithLabelValues("raft-tick").Set(float64(size))
@@ -97,8 +127,38 @@ func SetShardsOnStore(leader int, count int) {
 	shardCountGauge.WithLabelValues("leader").Set(float64(leader))
 }
 
+// SetTombstonesOnStore set the count of the tombstone replicas kept on the
+// current store which are pending to be deleted
+func SetTombstonesOnStore(count int) {
+	shardCountGauge.WithLabelValues("tombstone").Set(float64(count))
+}
+
+// SetQuorumLostShardsOnStore set the count of the shards which have lost the
+// write quorum on the current store
+func SetQuorumLostShardsOnStore(count int) {
+	shardCountGauge.WithLabelValues("quorum-lost").Set(float64(count))
+}
+
 // SetStorageOnStore set total and free storage on the current store
 func SetStorageOnStore(total uint64, free uint64) {
 	storeStorageGauge.WithLabelValues("total").Set(float64(total))
 	storeStorageGauge.WithLabelValues("free").Set(float64(free))
 }
+
+// SetTopApplyCPUShards replaces the apply time in seconds of the top shards, the
+// shards not in the top are removed to bound the cardinality
+func SetTopApplyCPUShards(shards map[uint64]float64) {
+	topApplyCPUShardsGauge.Reset()
+	for id, seconds := range shards {
+		topApplyCPUShardsGauge.WithLabelValues(strconv.FormatUint(id, 10)).Set(seconds)
+	}
+}
+
+// SetAutoTuneMetric set the write batch bytes, the apply batch bytes and the
+// commit delay chosen by the auto-tuner of the group
+func SetAutoTuneMetric(group uint64, writeBatchSize, applyBatchSize uint64, commitDelay time.Duration) {
+	g := strconv.FormatUint(group, 10)
+	autoTuneGauge.WithLabelValues(g, "write-batch-bytes").Set(float64(writeBatchSize))
+	autoTuneGauge.WithLabelValues(g, "apply-batch-bytes").Set(float64(applyBatchSize))
+	autoTuneGauge.WithLabelValues(g, "commit-delay-seconds").Set(commitDelay.Seconds())
+}
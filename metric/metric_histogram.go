@@ -20,6 +20,15 @@ import (
 )
 
 var (
+	raftLogEntrySizeHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "raft_log_entry_bytes",
+			Help:      "Bucketed histogram of applied raft log entry size by entry type.",
+			Buckets:   []float64{0, 256.0, 512.0, 1024.0, 4096.0, 65536.0, 262144.0, 524288.0, 1048576.0, 2097152.0, 4194304.0, 8388608.0, 16777216.0},
+		}, []string{"type"})
+
 	raftProposalSizeHistogram = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "matrixcube",
@@ -73,6 +82,15 @@ var (
 			Help:      "Bucketed histogram of server send snapshots duration.",
 		})
 
+	requestDurationHistogram = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "request_duration_seconds",
+			Help:      "Bucketed histogram of request handling duration.",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2.0, 20),
+		})
+
 	raftLogLagHistogram = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "matrixcube",
@@ -81,6 +99,42 @@ var (
 			Help:      "Bucketed histogram of log lag in a shard.",
 			Buckets:   []float64{2.0, 4.0, 8.0, 16.0, 32.0, 64.0, 128.0, 256.0, 512.0, 1024.0, 5120.0, 10240.0},
 		})
+
+	fsyncWaitDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "fsync_wait_duration_seconds",
+			Help:      "Bucketed histogram of fsync queueing duration by fsync pool.",
+			Buckets:   prometheus.ExponentialBuckets(0.00005, 2.0, 20),
+		}, []string{"pool"})
+
+	fsyncDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "fsync_duration_seconds",
+			Help:      "Bucketed histogram of fsync duration by fsync pool.",
+			Buckets:   prometheus.ExponentialBuckets(0.00005, 2.0, 20),
+		}, []string{"pool"})
+
+	fsyncGroupSizeHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "fsync_group_size",
+			Help:      "Bucketed histogram of the writes committed by a group fsync by fsync pool.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2.0, 10),
+		}, []string{"pool"})
+
+	raftReadyStageDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "matrixcube",
+			Subsystem: "raftstore",
+			Name:      "raft_ready_stage_duration_seconds",
+			Help:      "Bucketed histogram of raft ready handling duration by pipeline stage.",
+			Buckets:   prometheus.ExponentialBuckets(0.00005, 2.0, 20),
+		}, []string{"stage"})
 )
 
 // ObserveProposalBytes observe bytes per raft proposal
@@ -88,6 +142,11 @@ func ObserveProposalBytes(size int64) {
 	raftProposalSizeHistogram.Observe(float64(size))
 }
 
+// ObserveRaftLogEntryBytes observe bytes per applied raft log entry of the type
+func ObserveRaftLogEntryBytes(tp string, size int) {
+	raftLogEntrySizeHistogram.WithLabelValues(tp).Observe(float64(size))
+}
+
 // ObserveSnapshotBytes observe bytes per snapshot
 func ObserveSnapshotBytes(size int64) {
 	snapshotSizeHistogram.Observe(float64(size))
@@ -113,7 +172,44 @@ func ObserveRaftLogApplyDuration(start time.Time) {
 	raftLogApplyDurationHistogram.Observe(time.Since(start).Seconds())
 }
 
+// ObserveRequestDuration observe seconds a request was handled, the request id
+// of the trace is attached as the exemplar to correlate the latency with the
+// request trace, no exemplar is attached if it's empty.
+func ObserveRequestDuration(start time.Time, requestID string) {
+	v := time.Since(start).Seconds()
+	// prometheus limits the exemplar labels to 128 runes
+	if requestID == "" || len(requestID) > 96 {
+		requestDurationHistogram.Observe(v)
+		return
+	}
+	requestDurationHistogram.(prometheus.ExemplarObserver).ObserveWithExemplar(v,
+		prometheus.Labels{"request_id": requestID})
+}
+
 // ObserveRaftLogLag observe raft log lag
 func ObserveRaftLogLag(size uint64) {
 	raftLogLagHistogram.Observe(float64(size))
 }
+
+// ObserveFsyncWaitDuration observe seconds a fsync waited in the queue of the
+// fsync pool
+func ObserveFsyncWaitDuration(pool string, start time.Time) {
+	fsyncWaitDurationHistogram.WithLabelValues(pool).Observe(time.Since(start).Seconds())
+}
+
+// ObserveFsyncDuration observe seconds a fsync ran on the fsync pool
+func ObserveFsyncDuration(pool string, start time.Time) {
+	fsyncDurationHistogram.WithLabelValues(pool).Observe(time.Since(start).Seconds())
+}
+
+// ObserveFsyncGroupSize observe the number of the writes committed by a group
+// fsync of the fsync pool
+func ObserveFsyncGroupSize(pool string, size int) {
+	fsyncGroupSizeHistogram.WithLabelValues(pool).Observe(float64(size))
+}
+
+// ObserveRaftReadyStageDuration observe seconds spent in the stage of the raft
+// ready pipeline
+func ObserveRaftReadyStageDuration(stage string, start time.Time) {
+	raftReadyStageDurationHistogram.WithLabelValues(stage).Observe(time.Since(start).Seconds())
+}
@@ -23,5 +23,20 @@ func Retryable(err Error) bool {
 	return HasError(err) &&
 		err.RaftEntryTooLarge == nil && // can not retry
 		err.ShardUnavailable == nil &&
-		err.LeaseMismatch == nil
+		err.LeaseMismatch == nil &&
+		err.ApplyLagTooLarge == nil && // fail fast instead of waiting for the catch-up
+		err.AppLeaseMismatch == nil && // the writer was fenced
+		err.GroupMismatch == nil && // the key router rejects the group
+		err.QuorumLost == nil && // fail fast until the quorum is restored
+		err.InvalidSplitKeys == nil &&
+		!permanentGate(err) && // blocked until the gate is removed
+		err.DeadlineExceeded == nil &&
+		err.StorageIOError == nil // the replica stops applying until restarted
+}
+
+// permanentGate returns true if the request is blocked by a permanent shard gate
+func permanentGate(err Error) bool {
+	return err.ShardReadDisabled.GetPermanent() ||
+		err.ShardWriteDisabled.GetPermanent() ||
+		err.ShardDisabled.GetPermanent()
 }
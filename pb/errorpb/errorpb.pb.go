@@ -336,8 +336,11 @@ func (m *StaleEpoch) GetNewShards() []metapb.Shard {
 	return nil
 }
 
-// ServerIsBusy the server is busy
+// ServerIsBusy the server is busy, the write is rejected by the admission
+// control of the shard
 type ServerIsBusy struct {
+	ShardID              uint64   `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -376,6 +379,20 @@ func (m *ServerIsBusy) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ServerIsBusy proto.InternalMessageInfo
 
+func (m *ServerIsBusy) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
+	}
+	return 0
+}
+
+func (m *ServerIsBusy) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 // StaleCommand the command is stale, need to retry
 type StaleCommand struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -632,38 +649,29 @@ func (m *LeaseReadNotReady) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_LeaseReadNotReady proto.InternalMessageInfo
 
-// Error is a raft error
-type Error struct {
-	Message              string             `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	NotLeader            *NotLeader         `protobuf:"bytes,2,opt,name=notLeader,proto3" json:"notLeader,omitempty"`
-	ShardNotFound        *ShardNotFound     `protobuf:"bytes,3,opt,name=shardNotFound,proto3" json:"shardNotFound,omitempty"`
-	KeyNotInShard        *KeyNotInShard     `protobuf:"bytes,4,opt,name=KeyNotInShard,proto3" json:"KeyNotInShard,omitempty"`
-	StaleEpoch           *StaleEpoch        `protobuf:"bytes,5,opt,name=staleEpoch,proto3" json:"staleEpoch,omitempty"`
-	ServerIsBusy         *ServerIsBusy      `protobuf:"bytes,6,opt,name=serverIsBusy,proto3" json:"serverIsBusy,omitempty"`
-	StaleCommand         *StaleCommand      `protobuf:"bytes,7,opt,name=staleCommand,proto3" json:"staleCommand,omitempty"`
-	StoreMismatch        *StoreMismatch     `protobuf:"bytes,8,opt,name=storeMismatch,proto3" json:"storeMismatch,omitempty"`
-	RaftEntryTooLarge    *RaftEntryTooLarge `protobuf:"bytes,9,opt,name=raftEntryTooLarge,proto3" json:"raftEntryTooLarge,omitempty"`
-	ShardUnavailable     *ShardUnavailable  `protobuf:"bytes,10,opt,name=shardUnavailable,proto3" json:"shardUnavailable,omitempty"`
-	LeaseMissing         *LeaseMissing      `protobuf:"bytes,11,opt,name=leaseMissing,proto3" json:"leaseMissing,omitempty"`
-	LeaseMismatch        *LeaseMismatch     `protobuf:"bytes,12,opt,name=leaseMismatch,proto3" json:"leaseMismatch,omitempty"`
-	LeaseReadNotReady    *LeaseReadNotReady `protobuf:"bytes,13,opt,name=leaseReadNotReady,proto3" json:"leaseReadNotReady,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+// ShardReadDisabled the read requests of the shard are blocked by the shard gate
+type ShardReadDisabled struct {
+	ShardID  uint64 `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	Redirect string `protobuf:"bytes,2,opt,name=redirect,proto3" json:"redirect,omitempty"`
+	// Permanent the gate is permanent, the request should not be retried
+	Permanent            bool     `protobuf:"varint,3,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Error) Reset()         { *m = Error{} }
-func (m *Error) String() string { return proto.CompactTextString(m) }
-func (*Error) ProtoMessage()    {}
-func (*Error) Descriptor() ([]byte, []int) {
+func (m *ShardReadDisabled) Reset()         { *m = ShardReadDisabled{} }
+func (m *ShardReadDisabled) String() string { return proto.CompactTextString(m) }
+func (*ShardReadDisabled) ProtoMessage()    {}
+func (*ShardReadDisabled) Descriptor() ([]byte, []int) {
 	return fileDescriptor_390aa86757fd1154, []int{12}
 }
-func (m *Error) XXX_Unmarshal(b []byte) error {
+func (m *ShardReadDisabled) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ShardReadDisabled) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ShardReadDisabled.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -673,962 +681,3488 @@ func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Error) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Error.Merge(m, src)
+func (m *ShardReadDisabled) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardReadDisabled.Merge(m, src)
 }
-func (m *Error) XXX_Size() int {
+func (m *ShardReadDisabled) XXX_Size() int {
 	return m.Size()
 }
-func (m *Error) XXX_DiscardUnknown() {
-	xxx_messageInfo_Error.DiscardUnknown(m)
+func (m *ShardReadDisabled) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardReadDisabled.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Error proto.InternalMessageInfo
+var xxx_messageInfo_ShardReadDisabled proto.InternalMessageInfo
 
-func (m *Error) GetMessage() string {
+func (m *ShardReadDisabled) GetShardID() uint64 {
 	if m != nil {
-		return m.Message
+		return m.ShardID
 	}
-	return ""
+	return 0
 }
 
-func (m *Error) GetNotLeader() *NotLeader {
+func (m *ShardReadDisabled) GetRedirect() string {
 	if m != nil {
-		return m.NotLeader
+		return m.Redirect
 	}
-	return nil
+	return ""
 }
 
-func (m *Error) GetShardNotFound() *ShardNotFound {
+func (m *ShardReadDisabled) GetPermanent() bool {
 	if m != nil {
-		return m.ShardNotFound
+		return m.Permanent
 	}
-	return nil
+	return false
 }
 
-func (m *Error) GetKeyNotInShard() *KeyNotInShard {
-	if m != nil {
-		return m.KeyNotInShard
-	}
-	return nil
+// ShardWriteDisabled the write requests of the shard are blocked by the shard gate
+type ShardWriteDisabled struct {
+	ShardID  uint64 `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	Redirect string `protobuf:"bytes,2,opt,name=redirect,proto3" json:"redirect,omitempty"`
+	// Permanent the gate is permanent, the request should not be retried
+	Permanent            bool     `protobuf:"varint,3,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Error) GetStaleEpoch() *StaleEpoch {
-	if m != nil {
-		return m.StaleEpoch
+func (m *ShardWriteDisabled) Reset()         { *m = ShardWriteDisabled{} }
+func (m *ShardWriteDisabled) String() string { return proto.CompactTextString(m) }
+func (*ShardWriteDisabled) ProtoMessage()    {}
+func (*ShardWriteDisabled) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{13}
+}
+func (m *ShardWriteDisabled) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardWriteDisabled) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardWriteDisabled.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *ShardWriteDisabled) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardWriteDisabled.Merge(m, src)
+}
+func (m *ShardWriteDisabled) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardWriteDisabled) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardWriteDisabled.DiscardUnknown(m)
 }
 
-func (m *Error) GetServerIsBusy() *ServerIsBusy {
+var xxx_messageInfo_ShardWriteDisabled proto.InternalMessageInfo
+
+func (m *ShardWriteDisabled) GetShardID() uint64 {
 	if m != nil {
-		return m.ServerIsBusy
+		return m.ShardID
 	}
-	return nil
+	return 0
 }
 
-func (m *Error) GetStaleCommand() *StaleCommand {
+func (m *ShardWriteDisabled) GetRedirect() string {
 	if m != nil {
-		return m.StaleCommand
+		return m.Redirect
 	}
-	return nil
+	return ""
 }
 
-func (m *Error) GetStoreMismatch() *StoreMismatch {
+func (m *ShardWriteDisabled) GetPermanent() bool {
 	if m != nil {
-		return m.StoreMismatch
+		return m.Permanent
 	}
-	return nil
+	return false
 }
 
-func (m *Error) GetRaftEntryTooLarge() *RaftEntryTooLarge {
-	if m != nil {
-		return m.RaftEntryTooLarge
-	}
-	return nil
+// ShardDisabled both the read and write requests of the shard are blocked by the
+// shard gate
+type ShardDisabled struct {
+	ShardID  uint64 `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	Redirect string `protobuf:"bytes,2,opt,name=redirect,proto3" json:"redirect,omitempty"`
+	// Permanent the gate is permanent, the request should not be retried
+	Permanent            bool     `protobuf:"varint,3,opt,name=permanent,proto3" json:"permanent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Error) GetShardUnavailable() *ShardUnavailable {
-	if m != nil {
-		return m.ShardUnavailable
+func (m *ShardDisabled) Reset()         { *m = ShardDisabled{} }
+func (m *ShardDisabled) String() string { return proto.CompactTextString(m) }
+func (*ShardDisabled) ProtoMessage()    {}
+func (*ShardDisabled) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{14}
+}
+func (m *ShardDisabled) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardDisabled) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardDisabled.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *ShardDisabled) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardDisabled.Merge(m, src)
+}
+func (m *ShardDisabled) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardDisabled) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardDisabled.DiscardUnknown(m)
 }
 
-func (m *Error) GetLeaseMissing() *LeaseMissing {
+var xxx_messageInfo_ShardDisabled proto.InternalMessageInfo
+
+func (m *ShardDisabled) GetShardID() uint64 {
 	if m != nil {
-		return m.LeaseMissing
+		return m.ShardID
 	}
-	return nil
+	return 0
 }
 
-func (m *Error) GetLeaseMismatch() *LeaseMismatch {
+func (m *ShardDisabled) GetRedirect() string {
 	if m != nil {
-		return m.LeaseMismatch
+		return m.Redirect
 	}
-	return nil
+	return ""
 }
 
-func (m *Error) GetLeaseReadNotReady() *LeaseReadNotReady {
+func (m *ShardDisabled) GetPermanent() bool {
 	if m != nil {
-		return m.LeaseReadNotReady
+		return m.Permanent
 	}
-	return nil
+	return false
 }
 
-func init() {
-	proto.RegisterType((*NotLeader)(nil), "errorpb.NotLeader")
-	proto.RegisterType((*StoreMismatch)(nil), "errorpb.StoreMismatch")
-	proto.RegisterType((*ShardUnavailable)(nil), "errorpb.ShardUnavailable")
-	proto.RegisterType((*ShardNotFound)(nil), "errorpb.ShardNotFound")
-	proto.RegisterType((*KeyNotInShard)(nil), "errorpb.KeyNotInShard")
-	proto.RegisterType((*StaleEpoch)(nil), "errorpb.StaleEpoch")
-	proto.RegisterType((*ServerIsBusy)(nil), "errorpb.ServerIsBusy")
-	proto.RegisterType((*StaleCommand)(nil), "errorpb.StaleCommand")
-	proto.RegisterType((*RaftEntryTooLarge)(nil), "errorpb.RaftEntryTooLarge")
-	proto.RegisterType((*LeaseMissing)(nil), "errorpb.LeaseMissing")
-	proto.RegisterType((*LeaseMismatch)(nil), "errorpb.LeaseMismatch")
-	proto.RegisterType((*LeaseReadNotReady)(nil), "errorpb.LeaseReadNotReady")
-	proto.RegisterType((*Error)(nil), "errorpb.Error")
+// ApplyLagTooLarge the read is rejected as the replica's apply lag exceeds the
+// threshold, and the request doesn't allow stale reads
+type ApplyLagTooLarge struct {
+	ShardID              uint64   `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	ApplyLag             uint64   `protobuf:"varint,2,opt,name=applyLag,proto3" json:"applyLag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("errorpb.proto", fileDescriptor_390aa86757fd1154) }
-
-var fileDescriptor_390aa86757fd1154 = []byte{
-	// 673 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xdf, 0x4e, 0xdb, 0x3e,
-	0x18, 0x25, 0x14, 0xca, 0xaf, 0x1f, 0xcd, 0x8f, 0x12, 0xb6, 0xc9, 0x43, 0x53, 0x87, 0x72, 0xc5,
-	0xa4, 0x41, 0x37, 0x90, 0x26, 0x21, 0x4d, 0x9b, 0xc4, 0x56, 0x04, 0x82, 0x71, 0xe1, 0xb2, 0x07,
-	0x70, 0x1a, 0x93, 0x46, 0x4b, 0xe2, 0xce, 0x76, 0xd9, 0xba, 0x67, 0xd8, 0x4b, 0xec, 0x6d, 0xb8,
-	0xe4, 0x09, 0xa6, 0x8d, 0x27, 0x99, 0xec, 0xfc, 0xa9, 0x9d, 0x8a, 0x5e, 0x25, 0x9f, 0xbf, 0x73,
-	0x8e, 0x9d, 0xe3, 0xf3, 0x05, 0x5c, 0xca, 0x39, 0xe3, 0xe3, 0x60, 0x7f, 0xcc, 0x99, 0x64, 0xde,
-	0x5a, 0x51, 0x6e, 0x1f, 0x45, 0xb1, 0x1c, 0x4d, 0x82, 0xfd, 0x21, 0x4b, 0x7b, 0x29, 0x91, 0x3c,
-	0xfe, 0xce, 0x78, 0x1c, 0xc5, 0x59, 0x51, 0x0c, 0x27, 0x01, 0xed, 0x8d, 0x83, 0x5e, 0x4a, 0x25,
-	0xa9, 0x1e, 0xb9, 0xc6, 0xf6, 0x9e, 0x41, 0x8d, 0x58, 0xc4, 0x7a, 0x7a, 0x39, 0x98, 0x5c, 0xeb,
-	0x4a, 0x17, 0xfa, 0x2d, 0x87, 0xfb, 0x57, 0xd0, 0xba, 0x64, 0xf2, 0x82, 0x92, 0x90, 0x72, 0x0f,
-	0xc1, 0x9a, 0x18, 0x11, 0x1e, 0x9e, 0x7d, 0x44, 0xce, 0x8e, 0xb3, 0xbb, 0x82, 0xcb, 0xd2, 0xdb,
-	0x83, 0x66, 0xa2, 0x31, 0x68, 0x79, 0xc7, 0xd9, 0x5d, 0x3f, 0xd8, 0xd8, 0x2f, 0x36, 0xc5, 0x74,
-	0x9c, 0xc4, 0x43, 0x72, 0xbc, 0x72, 0xfb, 0xfb, 0xf9, 0x12, 0x2e, 0x40, 0xfe, 0x06, 0xb8, 0x03,
-	0xc9, 0x38, 0xfd, 0x14, 0x8b, 0x94, 0xc8, 0xe1, 0xc8, 0x7f, 0x09, 0x9d, 0x81, 0x92, 0xfa, 0x9c,
-	0x91, 0x1b, 0x12, 0x27, 0x24, 0x48, 0xe8, 0xc3, 0xbb, 0xf9, 0x2f, 0xc0, 0xd5, 0xe8, 0x4b, 0x26,
-	0x4f, 0xd8, 0x24, 0x0b, 0x17, 0x40, 0x87, 0xe0, 0x9e, 0xd3, 0xe9, 0x25, 0x93, 0x67, 0x99, 0xa6,
-	0x78, 0x1d, 0x68, 0x7c, 0xa1, 0x53, 0x0d, 0x6b, 0x63, 0xf5, 0x6a, 0x92, 0x97, 0xed, 0xaf, 0x7a,
-	0x04, 0xab, 0x42, 0x12, 0x2e, 0x51, 0x43, 0xa3, 0xf3, 0x42, 0x29, 0xd0, 0x2c, 0x44, 0x2b, 0xb9,
-	0x02, 0xcd, 0x42, 0xff, 0x3d, 0xc0, 0x40, 0x92, 0x84, 0xf6, 0xc7, 0x6c, 0x38, 0xf2, 0x5e, 0x43,
-	0x2b, 0xa3, 0xdf, 0xf4, 0x6e, 0x02, 0x39, 0x3b, 0x8d, 0xdd, 0xf5, 0x03, 0xb7, 0xb4, 0x43, 0xaf,
-	0x16, 0x66, 0xcc, 0x50, 0xfe, 0xff, 0xd0, 0x1e, 0x50, 0x7e, 0x43, 0xf9, 0x99, 0x38, 0x9e, 0x88,
-	0xa9, 0xae, 0x95, 0xe0, 0x07, 0x96, 0xa6, 0x24, 0x0b, 0xfd, 0x73, 0xd8, 0xc4, 0xe4, 0x5a, 0xf6,
-	0x33, 0xc9, 0xa7, 0x57, 0x8c, 0x5d, 0x10, 0x1e, 0x2d, 0xf0, 0xc7, 0x7b, 0x06, 0x2d, 0xaa, 0xa0,
-	0x83, 0xf8, 0x07, 0x2d, 0xbe, 0x69, 0xb6, 0xe0, 0x9f, 0x40, 0xfb, 0x82, 0x12, 0xa1, 0xcc, 0x17,
-	0x71, 0x16, 0x2d, 0xd6, 0xe1, 0xf9, 0xfd, 0x55, 0xde, 0xcc, 0x16, 0xfc, 0x5f, 0x0e, 0xb8, 0xa5,
-	0x90, 0xbe, 0xc5, 0x05, 0x4a, 0x6f, 0xa0, 0xcd, 0xe9, 0xd7, 0x09, 0x15, 0x52, 0x33, 0x8a, 0x94,
-	0x78, 0xa5, 0x2d, 0xda, 0x38, 0xdd, 0xc1, 0x16, 0xce, 0x7b, 0x07, 0x9d, 0x62, 0xc3, 0x53, 0x9a,
-	0x84, 0x39, 0xb7, 0xf1, 0x20, 0x77, 0x0e, 0xeb, 0x6f, 0xc1, 0x66, 0xde, 0xa2, 0x44, 0xa5, 0x45,
-	0x3d, 0xa6, 0xfe, 0xcf, 0x26, 0xac, 0xf6, 0xd5, 0x24, 0xa9, 0x03, 0xa7, 0x54, 0x08, 0x12, 0x51,
-	0x7d, 0xe0, 0x16, 0x2e, 0x4b, 0xef, 0x15, 0xb4, 0xb2, 0x32, 0xf7, 0xd5, 0x69, 0xcb, 0x69, 0xac,
-	0x26, 0x02, 0xcf, 0x40, 0xde, 0x5b, 0x70, 0x85, 0x19, 0xca, 0xe2, 0x9c, 0x4f, 0x2a, 0x96, 0x15,
-	0x59, 0x6c, 0x83, 0x15, 0xdb, 0xca, 0xa9, 0x8e, 0x97, 0xc9, 0xb6, 0xba, 0xb8, 0x16, 0xea, 0x43,
-	0x00, 0x51, 0x05, 0x10, 0xad, 0x6a, 0xea, 0xd6, 0x6c, 0xe3, 0xaa, 0x85, 0x0d, 0x98, 0x77, 0x04,
-	0x6d, 0x61, 0x84, 0x0e, 0x35, 0x35, 0xed, 0xf1, 0x8c, 0x66, 0x34, 0xb1, 0x05, 0xd5, 0x54, 0x23,
-	0x9f, 0x68, 0xad, 0x4e, 0x35, 0x9a, 0xd8, 0x82, 0x6a, 0x9b, 0xcc, 0xd1, 0x47, 0xff, 0xd5, 0x6d,
-	0x32, 0xbb, 0xd8, 0x06, 0x7b, 0xa7, 0xb0, 0xc9, 0xeb, 0x83, 0x80, 0x5a, 0x5a, 0x61, 0xbb, 0x52,
-	0x98, 0x1b, 0x15, 0x3c, 0x4f, 0xf2, 0xfa, 0xd0, 0x11, 0xb5, 0x3f, 0x0e, 0x02, 0x2d, 0xf4, 0xd4,
-	0xbe, 0x31, 0x03, 0x80, 0xe7, 0x28, 0xca, 0x89, 0xc4, 0x18, 0x26, 0xb4, 0x5e, 0x73, 0xc2, 0x9c,
-	0x34, 0x6c, 0x41, 0x95, 0x13, 0x89, 0x39, 0x3e, 0xa8, 0x5d, 0x73, 0xc2, 0x1a, 0x2e, 0x6c, 0x83,
-	0x95, 0x13, 0x49, 0x3d, 0xd9, 0xc8, 0xad, 0x39, 0x31, 0x97, 0x7d, 0x3c, 0x4f, 0x3a, 0xee, 0xdc,
-	0xfd, 0xed, 0x2e, 0xdd, 0xde, 0x77, 0x9d, 0xbb, 0xfb, 0xae, 0xf3, 0xe7, 0xbe, 0xeb, 0x04, 0x4d,
-	0xfd, 0xef, 0x3f, 0xfc, 0x17, 0x00, 0x00, 0xff, 0xff, 0x76, 0xa2, 0x28, 0x9c, 0x7f, 0x06, 0x00,
-	0x00,
+func (m *ApplyLagTooLarge) Reset()         { *m = ApplyLagTooLarge{} }
+func (m *ApplyLagTooLarge) String() string { return proto.CompactTextString(m) }
+func (*ApplyLagTooLarge) ProtoMessage()    {}
+func (*ApplyLagTooLarge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{15}
 }
-
-func (m *NotLeader) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ApplyLagTooLarge) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ApplyLagTooLarge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ApplyLagTooLarge.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *ApplyLagTooLarge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyLagTooLarge.Merge(m, src)
+}
+func (m *ApplyLagTooLarge) XXX_Size() int {
+	return m.Size()
+}
+func (m *ApplyLagTooLarge) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyLagTooLarge.DiscardUnknown(m)
 }
 
-func (m *NotLeader) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ShardID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
-	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintErrorpb(dAtA, i, uint64(m.Leader.Size()))
-	n1, err := m.Leader.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n1
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_ApplyLagTooLarge proto.InternalMessageInfo
+
+func (m *ApplyLagTooLarge) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
 	}
-	return i, nil
+	return 0
 }
 
-func (m *StoreMismatch) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ApplyLagTooLarge) GetApplyLag() uint64 {
+	if m != nil {
+		return m.ApplyLag
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *StoreMismatch) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+// AppLeaseMismatch the write is rejected as its token is not the token of the
+// current application lease of the shard
+type AppLeaseMismatch struct {
+	ShardID              uint64          `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	RequestToken         uint64          `protobuf:"varint,2,opt,name=requestToken,proto3" json:"requestToken,omitempty"`
+	CurrentLease         metapb.AppLease `protobuf:"bytes,3,opt,name=currentLease,proto3" json:"currentLease"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *AppLeaseMismatch) Reset()         { *m = AppLeaseMismatch{} }
+func (m *AppLeaseMismatch) String() string { return proto.CompactTextString(m) }
+func (*AppLeaseMismatch) ProtoMessage()    {}
+func (*AppLeaseMismatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{16}
+}
+func (m *AppLeaseMismatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AppLeaseMismatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AppLeaseMismatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *AppLeaseMismatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppLeaseMismatch.Merge(m, src)
+}
+func (m *AppLeaseMismatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *AppLeaseMismatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppLeaseMismatch.DiscardUnknown(m)
 }
 
-func (m *ShardUnavailable) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_AppLeaseMismatch proto.InternalMessageInfo
+
+func (m *AppLeaseMismatch) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *ShardUnavailable) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ShardID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+func (m *AppLeaseMismatch) GetRequestToken() uint64 {
+	if m != nil {
+		return m.RequestToken
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return 0
+}
+
+func (m *AppLeaseMismatch) GetCurrentLease() metapb.AppLease {
+	if m != nil {
+		return m.CurrentLease
 	}
-	return i, nil
+	return metapb.AppLease{}
 }
 
-func (m *ShardNotFound) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// GroupMismatch the request is rejected as its shard group is not the group
+// which the key is routed to by the key router
+type GroupMismatch struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Group                uint64   `protobuf:"varint,2,opt,name=group,proto3" json:"group,omitempty"`
+	RoutedGroup          uint64   `protobuf:"varint,3,opt,name=routedGroup,proto3" json:"routedGroup,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ShardNotFound) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ShardID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *GroupMismatch) Reset()         { *m = GroupMismatch{} }
+func (m *GroupMismatch) String() string { return proto.CompactTextString(m) }
+func (*GroupMismatch) ProtoMessage()    {}
+func (*GroupMismatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{17}
+}
+func (m *GroupMismatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GroupMismatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GroupMismatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *GroupMismatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupMismatch.Merge(m, src)
+}
+func (m *GroupMismatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *GroupMismatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupMismatch.DiscardUnknown(m)
 }
 
-func (m *KeyNotInShard) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GroupMismatch proto.InternalMessageInfo
+
+func (m *GroupMismatch) GetKey() []byte {
+	if m != nil {
+		return m.Key
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *KeyNotInShard) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Key) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
-	}
-	if m.ShardID != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
-	}
-	if len(m.Start) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Start)))
-		i += copy(dAtA[i:], m.Start)
-	}
-	if len(m.End) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.End)))
-		i += copy(dAtA[i:], m.End)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *GroupMismatch) GetGroup() uint64 {
+	if m != nil {
+		return m.Group
 	}
-	return i, nil
+	return 0
 }
 
-func (m *StaleEpoch) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *GroupMismatch) GetRoutedGroup() uint64 {
+	if m != nil {
+		return m.RoutedGroup
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *StaleEpoch) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.NewShards) > 0 {
-		for _, msg := range m.NewShards {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintErrorpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+// QuorumLost the request is rejected as the shard has lost the write quorum,
+// only the reads allowing stale reads are served by the surviving replicas
+type QuorumLost struct {
+	ShardID              uint64   `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuorumLost) Reset()         { *m = QuorumLost{} }
+func (m *QuorumLost) String() string { return proto.CompactTextString(m) }
+func (*QuorumLost) ProtoMessage()    {}
+func (*QuorumLost) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{18}
+}
+func (m *QuorumLost) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuorumLost) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuorumLost.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+}
+func (m *QuorumLost) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuorumLost.Merge(m, src)
+}
+func (m *QuorumLost) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuorumLost) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuorumLost.DiscardUnknown(m)
 }
 
-func (m *ServerIsBusy) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_QuorumLost proto.InternalMessageInfo
+
+func (m *QuorumLost) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *ServerIsBusy) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+// InvalidSplitKeys the split keys of the manual split are not in ascending
+// order or not inside the shard range
+type InvalidSplitKeys struct {
+	ShardID              uint64   `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StaleCommand) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *InvalidSplitKeys) Reset()         { *m = InvalidSplitKeys{} }
+func (m *InvalidSplitKeys) String() string { return proto.CompactTextString(m) }
+func (*InvalidSplitKeys) ProtoMessage()    {}
+func (*InvalidSplitKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{19}
+}
+func (m *InvalidSplitKeys) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InvalidSplitKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InvalidSplitKeys.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *InvalidSplitKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvalidSplitKeys.Merge(m, src)
+}
+func (m *InvalidSplitKeys) XXX_Size() int {
+	return m.Size()
+}
+func (m *InvalidSplitKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvalidSplitKeys.DiscardUnknown(m)
 }
 
-func (m *StaleCommand) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_InvalidSplitKeys proto.InternalMessageInfo
+
+func (m *InvalidSplitKeys) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
 	}
-	return i, nil
+	return 0
 }
 
-func (m *RaftEntryTooLarge) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *InvalidSplitKeys) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *RaftEntryTooLarge) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ShardID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
-	}
-	if m.EntrySize != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.EntrySize))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+// DeadlineExceeded the request is given up by the store as its deadline is
+// exceeded. The request dropped before it's proposed is never executed, the
+// proposed one may still be executed.
+type DeadlineExceeded struct {
+	ShardID              uint64   `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LeaseMissing) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *DeadlineExceeded) Reset()         { *m = DeadlineExceeded{} }
+func (m *DeadlineExceeded) String() string { return proto.CompactTextString(m) }
+func (*DeadlineExceeded) ProtoMessage()    {}
+func (*DeadlineExceeded) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{20}
+}
+func (m *DeadlineExceeded) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeadlineExceeded) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeadlineExceeded.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *DeadlineExceeded) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadlineExceeded.Merge(m, src)
+}
+func (m *DeadlineExceeded) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeadlineExceeded) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadlineExceeded.DiscardUnknown(m)
 }
 
-func (m *LeaseMissing) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ShardID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+var xxx_messageInfo_DeadlineExceeded proto.InternalMessageInfo
+
+func (m *DeadlineExceeded) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
 	}
-	if m.ReplicaID != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ReplicaID))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+	return 0
 }
 
-func (m *LeaseMismatch) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// StorageIOError the request is failed as the replica failed to write the data
+// storage, the replica stops applying until the store is restarted
+type StorageIOError struct {
+	ShardID              uint64   `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LeaseMismatch) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ShardID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
-	}
-	if m.RequestLease != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.RequestLease.Size()))
-		n2, err := m.RequestLease.MarshalTo(dAtA[i:])
+func (m *StorageIOError) Reset()         { *m = StorageIOError{} }
+func (m *StorageIOError) String() string { return proto.CompactTextString(m) }
+func (*StorageIOError) ProtoMessage()    {}
+func (*StorageIOError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{21}
+}
+func (m *StorageIOError) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageIOError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageIOError.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n2
+		return b[:n], nil
 	}
-	if m.ReplicaHeldLease != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ReplicaHeldLease.Size()))
-		n3, err := m.ReplicaHeldLease.MarshalTo(dAtA[i:])
+}
+func (m *StorageIOError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageIOError.Merge(m, src)
+}
+func (m *StorageIOError) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageIOError) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageIOError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageIOError proto.InternalMessageInfo
+
+func (m *StorageIOError) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
+	}
+	return 0
+}
+
+// Error is a raft error
+type Error struct {
+	Message              string              `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	NotLeader            *NotLeader          `protobuf:"bytes,2,opt,name=notLeader,proto3" json:"notLeader,omitempty"`
+	ShardNotFound        *ShardNotFound      `protobuf:"bytes,3,opt,name=shardNotFound,proto3" json:"shardNotFound,omitempty"`
+	KeyNotInShard        *KeyNotInShard      `protobuf:"bytes,4,opt,name=KeyNotInShard,proto3" json:"KeyNotInShard,omitempty"`
+	StaleEpoch           *StaleEpoch         `protobuf:"bytes,5,opt,name=staleEpoch,proto3" json:"staleEpoch,omitempty"`
+	ServerIsBusy         *ServerIsBusy       `protobuf:"bytes,6,opt,name=serverIsBusy,proto3" json:"serverIsBusy,omitempty"`
+	StaleCommand         *StaleCommand       `protobuf:"bytes,7,opt,name=staleCommand,proto3" json:"staleCommand,omitempty"`
+	StoreMismatch        *StoreMismatch      `protobuf:"bytes,8,opt,name=storeMismatch,proto3" json:"storeMismatch,omitempty"`
+	RaftEntryTooLarge    *RaftEntryTooLarge  `protobuf:"bytes,9,opt,name=raftEntryTooLarge,proto3" json:"raftEntryTooLarge,omitempty"`
+	ShardUnavailable     *ShardUnavailable   `protobuf:"bytes,10,opt,name=shardUnavailable,proto3" json:"shardUnavailable,omitempty"`
+	LeaseMissing         *LeaseMissing       `protobuf:"bytes,11,opt,name=leaseMissing,proto3" json:"leaseMissing,omitempty"`
+	LeaseMismatch        *LeaseMismatch      `protobuf:"bytes,12,opt,name=leaseMismatch,proto3" json:"leaseMismatch,omitempty"`
+	LeaseReadNotReady    *LeaseReadNotReady  `protobuf:"bytes,13,opt,name=leaseReadNotReady,proto3" json:"leaseReadNotReady,omitempty"`
+	ShardReadDisabled    *ShardReadDisabled  `protobuf:"bytes,14,opt,name=shardReadDisabled,proto3" json:"shardReadDisabled,omitempty"`
+	ShardWriteDisabled   *ShardWriteDisabled `protobuf:"bytes,15,opt,name=shardWriteDisabled,proto3" json:"shardWriteDisabled,omitempty"`
+	ShardDisabled        *ShardDisabled      `protobuf:"bytes,16,opt,name=shardDisabled,proto3" json:"shardDisabled,omitempty"`
+	ApplyLagTooLarge     *ApplyLagTooLarge   `protobuf:"bytes,17,opt,name=applyLagTooLarge,proto3" json:"applyLagTooLarge,omitempty"`
+	AppLeaseMismatch     *AppLeaseMismatch   `protobuf:"bytes,18,opt,name=appLeaseMismatch,proto3" json:"appLeaseMismatch,omitempty"`
+	GroupMismatch        *GroupMismatch      `protobuf:"bytes,19,opt,name=groupMismatch,proto3" json:"groupMismatch,omitempty"`
+	QuorumLost           *QuorumLost         `protobuf:"bytes,20,opt,name=quorumLost,proto3" json:"quorumLost,omitempty"`
+	InvalidSplitKeys     *InvalidSplitKeys   `protobuf:"bytes,21,opt,name=invalidSplitKeys,proto3" json:"invalidSplitKeys,omitempty"`
+	DeadlineExceeded     *DeadlineExceeded   `protobuf:"bytes,23,opt,name=deadlineExceeded,proto3" json:"deadlineExceeded,omitempty"`
+	StorageIOError       *StorageIOError     `protobuf:"bytes,24,opt,name=storageIOError,proto3" json:"storageIOError,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *Error) Reset()         { *m = Error{} }
+func (m *Error) String() string { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()    {}
+func (*Error) Descriptor() ([]byte, []int) {
+	return fileDescriptor_390aa86757fd1154, []int{22}
+}
+func (m *Error) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Error) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Error.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n3
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (m *Error) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Error.Merge(m, src)
+}
+func (m *Error) XXX_Size() int {
+	return m.Size()
+}
+func (m *Error) XXX_DiscardUnknown() {
+	xxx_messageInfo_Error.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Error proto.InternalMessageInfo
+
+func (m *Error) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	return i, nil
+	return ""
 }
 
-func (m *LeaseReadNotReady) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Error) GetNotLeader() *NotLeader {
+	if m != nil {
+		return m.NotLeader
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *LeaseReadNotReady) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *Error) GetShardNotFound() *ShardNotFound {
+	if m != nil {
+		return m.ShardNotFound
 	}
-	return i, nil
+	return nil
 }
 
-func (m *Error) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *Error) GetKeyNotInShard() *KeyNotInShard {
+	if m != nil {
+		return m.KeyNotInShard
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Error) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Message) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Message)))
-		i += copy(dAtA[i:], m.Message)
+func (m *Error) GetStaleEpoch() *StaleEpoch {
+	if m != nil {
+		return m.StaleEpoch
 	}
-	if m.NotLeader != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.NotLeader.Size()))
-		n4, err := m.NotLeader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n4
+	return nil
+}
+
+func (m *Error) GetServerIsBusy() *ServerIsBusy {
+	if m != nil {
+		return m.ServerIsBusy
 	}
-	if m.ShardNotFound != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardNotFound.Size()))
-		n5, err := m.ShardNotFound.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n5
+	return nil
+}
+
+func (m *Error) GetStaleCommand() *StaleCommand {
+	if m != nil {
+		return m.StaleCommand
 	}
-	if m.KeyNotInShard != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.KeyNotInShard.Size()))
-		n6, err := m.KeyNotInShard.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n6
+	return nil
+}
+
+func (m *Error) GetStoreMismatch() *StoreMismatch {
+	if m != nil {
+		return m.StoreMismatch
 	}
-	if m.StaleEpoch != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.StaleEpoch.Size()))
-		n7, err := m.StaleEpoch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n7
+	return nil
+}
+
+func (m *Error) GetRaftEntryTooLarge() *RaftEntryTooLarge {
+	if m != nil {
+		return m.RaftEntryTooLarge
 	}
-	if m.ServerIsBusy != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ServerIsBusy.Size()))
-		n8, err := m.ServerIsBusy.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n8
+	return nil
+}
+
+func (m *Error) GetShardUnavailable() *ShardUnavailable {
+	if m != nil {
+		return m.ShardUnavailable
 	}
-	if m.StaleCommand != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.StaleCommand.Size()))
-		n9, err := m.StaleCommand.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n9
+	return nil
+}
+
+func (m *Error) GetLeaseMissing() *LeaseMissing {
+	if m != nil {
+		return m.LeaseMissing
 	}
-	if m.StoreMismatch != nil {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.StoreMismatch.Size()))
-		n10, err := m.StoreMismatch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n10
+	return nil
+}
+
+func (m *Error) GetLeaseMismatch() *LeaseMismatch {
+	if m != nil {
+		return m.LeaseMismatch
 	}
-	if m.RaftEntryTooLarge != nil {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.RaftEntryTooLarge.Size()))
-		n11, err := m.RaftEntryTooLarge.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n11
-	}
-	if m.ShardUnavailable != nil {
-		dAtA[i] = 0x52
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardUnavailable.Size()))
-		n12, err := m.ShardUnavailable.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n12
+	return nil
+}
+
+func (m *Error) GetLeaseReadNotReady() *LeaseReadNotReady {
+	if m != nil {
+		return m.LeaseReadNotReady
 	}
-	if m.LeaseMissing != nil {
-		dAtA[i] = 0x5a
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.LeaseMissing.Size()))
-		n13, err := m.LeaseMissing.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n13
+	return nil
+}
+
+func (m *Error) GetShardReadDisabled() *ShardReadDisabled {
+	if m != nil {
+		return m.ShardReadDisabled
 	}
-	if m.LeaseMismatch != nil {
-		dAtA[i] = 0x62
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.LeaseMismatch.Size()))
-		n14, err := m.LeaseMismatch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n14
+	return nil
+}
+
+func (m *Error) GetShardWriteDisabled() *ShardWriteDisabled {
+	if m != nil {
+		return m.ShardWriteDisabled
 	}
-	if m.LeaseReadNotReady != nil {
-		dAtA[i] = 0x6a
-		i++
-		i = encodeVarintErrorpb(dAtA, i, uint64(m.LeaseReadNotReady.Size()))
-		n15, err := m.LeaseReadNotReady.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n15
+	return nil
+}
+
+func (m *Error) GetShardDisabled() *ShardDisabled {
+	if m != nil {
+		return m.ShardDisabled
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return nil
+}
+
+func (m *Error) GetApplyLagTooLarge() *ApplyLagTooLarge {
+	if m != nil {
+		return m.ApplyLagTooLarge
 	}
-	return i, nil
+	return nil
 }
 
-func encodeVarintErrorpb(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Error) GetAppLeaseMismatch() *AppLeaseMismatch {
+	if m != nil {
+		return m.AppLeaseMismatch
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return nil
 }
-func (m *NotLeader) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *Error) GetGroupMismatch() *GroupMismatch {
+	if m != nil {
+		return m.GroupMismatch
 	}
-	var l int
-	_ = l
-	if m.ShardID != 0 {
-		n += 1 + sovErrorpb(uint64(m.ShardID))
+	return nil
+}
+
+func (m *Error) GetQuorumLost() *QuorumLost {
+	if m != nil {
+		return m.QuorumLost
 	}
-	l = m.Leader.Size()
-	n += 1 + l + sovErrorpb(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+func (m *Error) GetInvalidSplitKeys() *InvalidSplitKeys {
+	if m != nil {
+		return m.InvalidSplitKeys
 	}
-	return n
+	return nil
 }
 
-func (m *StoreMismatch) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Error) GetDeadlineExceeded() *DeadlineExceeded {
+	if m != nil {
+		return m.DeadlineExceeded
 	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+func (m *Error) GetStorageIOError() *StorageIOError {
+	if m != nil {
+		return m.StorageIOError
 	}
-	return n
+	return nil
 }
 
-func (m *ShardUnavailable) Size() (n int) {
-	if m == nil {
-		return 0
+func init() {
+	proto.RegisterType((*NotLeader)(nil), "errorpb.NotLeader")
+	proto.RegisterType((*StoreMismatch)(nil), "errorpb.StoreMismatch")
+	proto.RegisterType((*ShardUnavailable)(nil), "errorpb.ShardUnavailable")
+	proto.RegisterType((*ShardNotFound)(nil), "errorpb.ShardNotFound")
+	proto.RegisterType((*KeyNotInShard)(nil), "errorpb.KeyNotInShard")
+	proto.RegisterType((*StaleEpoch)(nil), "errorpb.StaleEpoch")
+	proto.RegisterType((*ServerIsBusy)(nil), "errorpb.ServerIsBusy")
+	proto.RegisterType((*StaleCommand)(nil), "errorpb.StaleCommand")
+	proto.RegisterType((*RaftEntryTooLarge)(nil), "errorpb.RaftEntryTooLarge")
+	proto.RegisterType((*LeaseMissing)(nil), "errorpb.LeaseMissing")
+	proto.RegisterType((*LeaseMismatch)(nil), "errorpb.LeaseMismatch")
+	proto.RegisterType((*LeaseReadNotReady)(nil), "errorpb.LeaseReadNotReady")
+	proto.RegisterType((*ShardReadDisabled)(nil), "errorpb.ShardReadDisabled")
+	proto.RegisterType((*ShardWriteDisabled)(nil), "errorpb.ShardWriteDisabled")
+	proto.RegisterType((*ShardDisabled)(nil), "errorpb.ShardDisabled")
+	proto.RegisterType((*ApplyLagTooLarge)(nil), "errorpb.ApplyLagTooLarge")
+	proto.RegisterType((*AppLeaseMismatch)(nil), "errorpb.AppLeaseMismatch")
+	proto.RegisterType((*GroupMismatch)(nil), "errorpb.GroupMismatch")
+	proto.RegisterType((*QuorumLost)(nil), "errorpb.QuorumLost")
+	proto.RegisterType((*InvalidSplitKeys)(nil), "errorpb.InvalidSplitKeys")
+	proto.RegisterType((*DeadlineExceeded)(nil), "errorpb.DeadlineExceeded")
+	proto.RegisterType((*StorageIOError)(nil), "errorpb.StorageIOError")
+	proto.RegisterType((*Error)(nil), "errorpb.Error")
+}
+
+func init() { proto.RegisterFile("errorpb.proto", fileDescriptor_390aa86757fd1154) }
+
+var fileDescriptor_390aa86757fd1154 = []byte{
+	// 1020 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdd, 0x6e, 0x1c, 0x35,
+	0x14, 0xee, 0x36, 0xbf, 0x7b, 0xb2, 0xdb, 0x4c, 0x9c, 0xb4, 0x98, 0x80, 0x42, 0x34, 0x17, 0x28,
+	0x48, 0x6d, 0x02, 0xad, 0x84, 0x54, 0x54, 0xf1, 0x13, 0x92, 0x92, 0x28, 0x21, 0x12, 0xde, 0x20,
+	0xc4, 0xa5, 0x77, 0xe6, 0x74, 0x32, 0xea, 0xec, 0x78, 0x6a, 0x7b, 0x42, 0x97, 0x67, 0xe0, 0x25,
+	0x78, 0x9b, 0x5e, 0xf6, 0x09, 0x10, 0xe4, 0x09, 0x78, 0x04, 0x64, 0xef, 0xec, 0xec, 0xd8, 0xd3,
+	0xae, 0x2a, 0xa4, 0x5e, 0x65, 0x8f, 0xfd, 0x7d, 0x9f, 0x3d, 0xe7, 0x1c, 0x7f, 0x27, 0xd0, 0x47,
+	0x29, 0x85, 0x2c, 0x86, 0xfb, 0x85, 0x14, 0x5a, 0x90, 0x95, 0x2a, 0xdc, 0x7e, 0x9c, 0xa4, 0xfa,
+	0xaa, 0x1c, 0xee, 0x47, 0x62, 0x74, 0x30, 0xe2, 0x5a, 0xa6, 0x2f, 0x85, 0x4c, 0x93, 0x34, 0xaf,
+	0x82, 0xa8, 0x1c, 0xe2, 0x41, 0x31, 0x3c, 0x18, 0xa1, 0xe6, 0xf5, 0x9f, 0x89, 0xc6, 0xf6, 0x83,
+	0x06, 0x35, 0x11, 0x89, 0x38, 0xb0, 0xcb, 0xc3, 0xf2, 0x99, 0x8d, 0x6c, 0x60, 0x7f, 0x4d, 0xe0,
+	0xe1, 0x25, 0x74, 0x2f, 0x84, 0x3e, 0x47, 0x1e, 0xa3, 0x24, 0x14, 0x56, 0xd4, 0x15, 0x97, 0xf1,
+	0xe9, 0x11, 0xed, 0xec, 0x76, 0xf6, 0x16, 0xd9, 0x34, 0x24, 0x0f, 0x60, 0x39, 0xb3, 0x18, 0x7a,
+	0x7b, 0xb7, 0xb3, 0xb7, 0xf6, 0x70, 0x7d, 0xbf, 0x3a, 0x94, 0x61, 0x91, 0xa5, 0x11, 0x3f, 0x5c,
+	0x7c, 0xf5, 0xd7, 0x27, 0xb7, 0x58, 0x05, 0x0a, 0xd7, 0xa1, 0x3f, 0xd0, 0x42, 0xe2, 0x8f, 0xa9,
+	0x1a, 0x71, 0x1d, 0x5d, 0x85, 0xf7, 0x21, 0x18, 0x18, 0xa9, 0x9f, 0x73, 0x7e, 0xcd, 0xd3, 0x8c,
+	0x0f, 0x33, 0x7c, 0xfb, 0x69, 0xe1, 0x67, 0xd0, 0xb7, 0xe8, 0x0b, 0xa1, 0x9f, 0x8a, 0x32, 0x8f,
+	0xe7, 0x40, 0x23, 0xe8, 0x9f, 0xe1, 0xf8, 0x42, 0xe8, 0xd3, 0xdc, 0x52, 0x48, 0x00, 0x0b, 0xcf,
+	0x71, 0x6c, 0x61, 0x3d, 0x66, 0x7e, 0x36, 0xc9, 0xb7, 0xdd, 0xaf, 0xda, 0x82, 0x25, 0xa5, 0xb9,
+	0xd4, 0x74, 0xc1, 0xa2, 0x27, 0x81, 0x51, 0xc0, 0x3c, 0xa6, 0x8b, 0x13, 0x05, 0xcc, 0xe3, 0xf0,
+	0x1b, 0x80, 0x81, 0xe6, 0x19, 0x1e, 0x17, 0x22, 0xba, 0x22, 0x5f, 0x40, 0x37, 0xc7, 0xdf, 0xec,
+	0x69, 0x8a, 0x76, 0x76, 0x17, 0xf6, 0xd6, 0x1e, 0xf6, 0xa7, 0xe9, 0xb0, 0xab, 0x55, 0x32, 0x66,
+	0xa8, 0xf0, 0x5b, 0xe8, 0x0d, 0x50, 0x5e, 0xa3, 0x3c, 0x55, 0x87, 0xa5, 0x1a, 0xcf, 0x49, 0xf4,
+	0x3d, 0x58, 0x96, 0xc8, 0x95, 0xc8, 0xed, 0x5d, 0xbb, 0xac, 0x8a, 0xc2, 0x3b, 0xd0, 0xb3, 0x57,
+	0xf8, 0x5e, 0x8c, 0x46, 0x3c, 0x8f, 0xc3, 0x33, 0xd8, 0x60, 0xfc, 0x99, 0x3e, 0xce, 0xb5, 0x1c,
+	0x5f, 0x0a, 0x71, 0xce, 0x65, 0x32, 0x27, 0xa3, 0xe4, 0x63, 0xe8, 0xa2, 0x81, 0x0e, 0xd2, 0xdf,
+	0xb1, 0xca, 0xc2, 0x6c, 0x21, 0x7c, 0x0a, 0xbd, 0x73, 0xe4, 0xca, 0x94, 0x4b, 0xa5, 0x79, 0x32,
+	0x5f, 0x47, 0x4e, 0x2a, 0x5e, 0x67, 0x73, 0xb6, 0x10, 0xfe, 0xd9, 0x81, 0xfe, 0x54, 0xc8, 0xd6,
+	0x7d, 0x8e, 0xd2, 0x97, 0xd0, 0x93, 0xf8, 0xa2, 0x44, 0xa5, 0x2d, 0xa3, 0xea, 0x2b, 0x32, 0x4d,
+	0xa4, 0x4d, 0xb5, 0xdd, 0x61, 0x0e, 0x8e, 0x7c, 0x0d, 0x41, 0x75, 0xe0, 0x09, 0x66, 0xf1, 0x84,
+	0xbb, 0xf0, 0x56, 0x6e, 0x0b, 0x1b, 0x6e, 0xc2, 0xc6, 0x64, 0x0b, 0xb9, 0xe9, 0x2f, 0xf3, 0x67,
+	0x1c, 0x26, 0xb0, 0x61, 0x2b, 0x65, 0xa2, 0xa3, 0x54, 0x99, 0xf6, 0x9c, 0xd3, 0x74, 0x64, 0x1b,
+	0x56, 0x25, 0xc6, 0xa9, 0xc4, 0x48, 0x57, 0x65, 0xaa, 0x63, 0x93, 0xa1, 0x02, 0xe5, 0x88, 0xe7,
+	0x98, 0x4f, 0xfa, 0x6a, 0x95, 0xcd, 0x16, 0xc2, 0x2b, 0x20, 0xf6, 0xa0, 0x5f, 0x64, 0xaa, 0xf1,
+	0xbd, 0x9e, 0x14, 0x55, 0x6f, 0xe8, 0xbd, 0x1e, 0x72, 0x02, 0xc1, 0x77, 0x45, 0x91, 0x8d, 0xcf,
+	0x79, 0xf2, 0x0e, 0x4d, 0xb8, 0x0d, 0xab, 0xbc, 0x42, 0x57, 0xbd, 0x53, 0xc7, 0xe1, 0x1f, 0x1d,
+	0x2b, 0xf5, 0xae, 0xdd, 0x13, 0xd6, 0xdd, 0x73, 0x29, 0x9e, 0x63, 0x5e, 0xc9, 0x39, 0x6b, 0xe4,
+	0x2b, 0xe8, 0x45, 0xa5, 0x94, 0x98, 0xeb, 0x66, 0x97, 0x04, 0xd3, 0x2e, 0x99, 0x9e, 0x56, 0xbd,
+	0x56, 0x07, 0x1b, 0xfe, 0x0a, 0xfd, 0x1f, 0xa4, 0x28, 0x8b, 0xfa, 0x2a, 0x6d, 0x5b, 0xd9, 0x82,
+	0xa5, 0xc4, 0x40, 0xaa, 0xb3, 0x27, 0x01, 0xd9, 0x85, 0x35, 0x29, 0x4a, 0x8d, 0xb1, 0xa5, 0xdb,
+	0x33, 0x17, 0x59, 0x73, 0x29, 0xfc, 0x14, 0xe0, 0xa7, 0x52, 0xc8, 0x72, 0x74, 0x2e, 0x94, 0x9e,
+	0xe3, 0x6c, 0x47, 0x10, 0x9c, 0xe6, 0xd7, 0x3c, 0x4b, 0xe3, 0x41, 0x91, 0xa5, 0xfa, 0x0c, 0xc7,
+	0xea, 0x7f, 0xf8, 0xc6, 0x7d, 0x08, 0x8e, 0x90, 0xc7, 0x59, 0x9a, 0xe3, 0xf1, 0xcb, 0x08, 0x31,
+	0x9e, 0xd7, 0x09, 0xe1, 0xbf, 0x00, 0x4b, 0xc7, 0x66, 0x06, 0x19, 0xcc, 0x08, 0x95, 0xe2, 0x09,
+	0x5a, 0x4c, 0x97, 0x4d, 0x43, 0xf2, 0x39, 0x74, 0xf3, 0xe9, 0xc4, 0xa8, 0x5f, 0xed, 0x74, 0x8e,
+	0xd5, 0xb3, 0x84, 0xcd, 0x40, 0xe4, 0x09, 0xf4, 0x55, 0xd3, 0xce, 0xab, 0x4a, 0xdc, 0xab, 0x59,
+	0x8e, 0xd9, 0x33, 0x17, 0x4c, 0x9e, 0x78, 0x0e, 0x4f, 0x17, 0x3d, 0xb6, 0xb3, 0xcb, 0x5c, 0x30,
+	0x79, 0x04, 0xa0, 0x6a, 0xeb, 0xa6, 0x4b, 0x96, 0xba, 0x39, 0x3b, 0xb8, 0xde, 0x62, 0x0d, 0x18,
+	0x79, 0x0c, 0x3d, 0xd5, 0xb0, 0x6b, 0xba, 0x6c, 0x69, 0x77, 0x67, 0xb4, 0xc6, 0x26, 0x73, 0xa0,
+	0x96, 0xda, 0xf0, 0x69, 0xba, 0xe2, 0x53, 0x1b, 0x9b, 0xcc, 0x81, 0xda, 0x34, 0x35, 0x87, 0x26,
+	0x5d, 0xf5, 0xd3, 0xd4, 0xdc, 0x65, 0x2e, 0x98, 0x9c, 0xc0, 0x86, 0xf4, 0x07, 0x02, 0xed, 0x5a,
+	0x85, 0xed, 0x5a, 0xa1, 0x35, 0x32, 0x58, 0x9b, 0x44, 0x8e, 0x21, 0x50, 0xde, 0xac, 0xa6, 0x60,
+	0x85, 0x3e, 0x74, 0x2b, 0xd6, 0x00, 0xb0, 0x16, 0xc5, 0x64, 0x22, 0x6b, 0x0c, 0x15, 0xba, 0xe6,
+	0x65, 0xa2, 0x39, 0x71, 0x98, 0x03, 0x35, 0x99, 0xc8, 0x9a, 0x46, 0x40, 0x7b, 0x5e, 0x26, 0x1c,
+	0x9b, 0x60, 0x2e, 0xd8, 0x64, 0x22, 0xf3, 0x1d, 0x9e, 0xf6, 0xbd, 0x4c, 0xb4, 0x66, 0x00, 0x6b,
+	0x93, 0x8c, 0x92, 0xf2, 0xc7, 0x02, 0xbd, 0xe3, 0x29, 0xb5, 0x06, 0x07, 0x6b, 0x93, 0xc8, 0x19,
+	0x10, 0xd5, 0xf2, 0x7d, 0xba, 0x6e, 0xa5, 0x3e, 0x72, 0xa5, 0x1c, 0x08, 0x7b, 0x03, 0xad, 0x7e,
+	0x4f, 0xb5, 0x4e, 0xf0, 0xa6, 0xf7, 0x54, 0x4b, 0xb8, 0x60, 0x53, 0x5e, 0xee, 0x79, 0x36, 0xdd,
+	0xf0, 0xca, 0xeb, 0x9b, 0x3a, 0x6b, 0x51, 0x2a, 0x19, 0xa7, 0x10, 0x94, 0xb4, 0x65, 0xdc, 0x4a,
+	0xb5, 0x28, 0xe6, 0x5b, 0x92, 0xa6, 0xd1, 0xd2, 0x4d, 0xef, 0x5b, 0x1c, 0x1b, 0x66, 0x2e, 0xd8,
+	0xbc, 0xee, 0x17, 0xb5, 0x97, 0xd2, 0x2d, 0xef, 0x75, 0xcf, 0x6c, 0x96, 0x35, 0x60, 0xe6, 0xe6,
+	0xa9, 0x67, 0xac, 0xf4, 0xae, 0x77, 0x73, 0xdf, 0x79, 0x59, 0x8b, 0x62, 0x64, 0x62, 0xcf, 0x59,
+	0xe9, 0x07, 0x9e, 0x8c, 0x6f, 0xbd, 0xac, 0x45, 0x39, 0x0c, 0x5e, 0xff, 0xb3, 0x73, 0xeb, 0xd5,
+	0xcd, 0x4e, 0xe7, 0xf5, 0xcd, 0x4e, 0xe7, 0xef, 0x9b, 0x9d, 0xce, 0x70, 0xd9, 0xfe, 0x67, 0xfe,
+	0xe8, 0xbf, 0x01, 0x00, 0x56, 0x95, 0x99, 0xc9, 0x1d, 0x0c, 0x00, 0x00,
+}
+
+func (m *NotLeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *NotLeader) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.ShardID != 0 {
-		n += 1 + sovErrorpb(uint64(m.ShardID))
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintErrorpb(dAtA, i, uint64(m.Leader.Size()))
+	n1, err := m.Leader.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n1
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ShardNotFound) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *StoreMismatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreMismatch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ShardID != 0 {
-		n += 1 + sovErrorpb(uint64(m.ShardID))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *KeyNotInShard) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ShardUnavailable) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardUnavailable) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovErrorpb(uint64(l))
-	}
 	if m.ShardID != 0 {
-		n += 1 + sovErrorpb(uint64(m.ShardID))
-	}
-	l = len(m.Start)
-	if l > 0 {
-		n += 1 + l + sovErrorpb(uint64(l))
-	}
-	l = len(m.End)
-	if l > 0 {
-		n += 1 + l + sovErrorpb(uint64(l))
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *StaleEpoch) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.NewShards) > 0 {
-		for _, e := range m.NewShards {
-			l = e.Size()
-			n += 1 + l + sovErrorpb(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ShardNotFound) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ServerIsBusy) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *ShardNotFound) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *StaleCommand) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KeyNotInShard) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyNotInShard) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if m.ShardID != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+	}
+	if len(m.Start) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Start)))
+		i += copy(dAtA[i:], m.Start)
+	}
+	if len(m.End) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.End)))
+		i += copy(dAtA[i:], m.End)
+	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RaftEntryTooLarge) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *StaleEpoch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StaleEpoch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.NewShards) > 0 {
+		for _, msg := range m.NewShards {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintErrorpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ServerIsBusy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ServerIsBusy) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.ShardID != 0 {
-		n += 1 + sovErrorpb(uint64(m.ShardID))
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *StaleCommand) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StaleCommand) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RaftEntryTooLarge) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RaftEntryTooLarge) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
 	}
 	if m.EntrySize != 0 {
-		n += 1 + sovErrorpb(uint64(m.EntrySize))
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.EntrySize))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *LeaseMissing) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LeaseMissing) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaseMissing) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.ShardID != 0 {
-		n += 1 + sovErrorpb(uint64(m.ShardID))
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
 	}
 	if m.ReplicaID != 0 {
-		n += 1 + sovErrorpb(uint64(m.ReplicaID))
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ReplicaID))
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *LeaseMismatch) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LeaseMismatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaseMismatch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.ShardID != 0 {
-		n += 1 + sovErrorpb(uint64(m.ShardID))
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
 	}
 	if m.RequestLease != nil {
-		l = m.RequestLease.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.RequestLease.Size()))
+		n2, err := m.RequestLease.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
 	}
 	if m.ReplicaHeldLease != nil {
-		l = m.ReplicaHeldLease.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ReplicaHeldLease.Size()))
+		n3, err := m.ReplicaHeldLease.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *LeaseReadNotReady) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *LeaseReadNotReady) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaseReadNotReady) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *Error) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ShardReadDisabled) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardReadDisabled) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Message)
-	if l > 0 {
-		n += 1 + l + sovErrorpb(uint64(l))
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
 	}
-	if m.NotLeader != nil {
-		l = m.NotLeader.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	if len(m.Redirect) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Redirect)))
+		i += copy(dAtA[i:], m.Redirect)
 	}
-	if m.ShardNotFound != nil {
-		l = m.ShardNotFound.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	if m.Permanent {
+		dAtA[i] = 0x18
+		i++
+		if m.Permanent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	if m.KeyNotInShard != nil {
-		l = m.KeyNotInShard.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.StaleEpoch != nil {
-		l = m.StaleEpoch.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	return i, nil
+}
+
+func (m *ShardWriteDisabled) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.ServerIsBusy != nil {
-		l = m.ServerIsBusy.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *ShardWriteDisabled) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
 	}
-	if m.StaleCommand != nil {
-		l = m.StaleCommand.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	if len(m.Redirect) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Redirect)))
+		i += copy(dAtA[i:], m.Redirect)
 	}
-	if m.StoreMismatch != nil {
-		l = m.StoreMismatch.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	if m.Permanent {
+		dAtA[i] = 0x18
+		i++
+		if m.Permanent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	if m.RaftEntryTooLarge != nil {
-		l = m.RaftEntryTooLarge.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ShardUnavailable != nil {
-		l = m.ShardUnavailable.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	return i, nil
+}
+
+func (m *ShardDisabled) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.LeaseMissing != nil {
-		l = m.LeaseMissing.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *ShardDisabled) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
 	}
-	if m.LeaseMismatch != nil {
-		l = m.LeaseMismatch.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	if len(m.Redirect) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Redirect)))
+		i += copy(dAtA[i:], m.Redirect)
 	}
-	if m.LeaseReadNotReady != nil {
-		l = m.LeaseReadNotReady.Size()
-		n += 1 + l + sovErrorpb(uint64(l))
+	if m.Permanent {
+		dAtA[i] = 0x18
+		i++
+		if m.Permanent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ApplyLagTooLarge) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ApplyLagTooLarge) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+	}
+	if m.ApplyLag != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ApplyLag))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *AppLeaseMismatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppLeaseMismatch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+	}
+	if m.RequestToken != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.RequestToken))
+	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintErrorpb(dAtA, i, uint64(m.CurrentLease.Size()))
+	n4, err := m.CurrentLease.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n4
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GroupMismatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GroupMismatch) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if m.Group != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.Group))
+	}
+	if m.RoutedGroup != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.RoutedGroup))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *QuorumLost) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeadlineExceeded) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageIOError) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuorumLost) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DeadlineExceeded) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *StorageIOError) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InvalidSplitKeys) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InvalidSplitKeys) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardID))
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Error) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Error) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Message) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
+	}
+	if m.NotLeader != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.NotLeader.Size()))
+		n5, err := m.NotLeader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	if m.ShardNotFound != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardNotFound.Size()))
+		n6, err := m.ShardNotFound.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n6
+	}
+	if m.KeyNotInShard != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.KeyNotInShard.Size()))
+		n7, err := m.KeyNotInShard.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
+	if m.StaleEpoch != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.StaleEpoch.Size()))
+		n8, err := m.StaleEpoch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if m.ServerIsBusy != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ServerIsBusy.Size()))
+		n9, err := m.ServerIsBusy.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
+	}
+	if m.StaleCommand != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.StaleCommand.Size()))
+		n10, err := m.StaleCommand.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
+	}
+	if m.StoreMismatch != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.StoreMismatch.Size()))
+		n11, err := m.StoreMismatch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n11
+	}
+	if m.RaftEntryTooLarge != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.RaftEntryTooLarge.Size()))
+		n12, err := m.RaftEntryTooLarge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
+	}
+	if m.ShardUnavailable != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardUnavailable.Size()))
+		n13, err := m.ShardUnavailable.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
+	}
+	if m.LeaseMissing != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.LeaseMissing.Size()))
+		n14, err := m.LeaseMissing.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
+	}
+	if m.LeaseMismatch != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.LeaseMismatch.Size()))
+		n15, err := m.LeaseMismatch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.LeaseReadNotReady != nil {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.LeaseReadNotReady.Size()))
+		n16, err := m.LeaseReadNotReady.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
+	}
+	if m.ShardReadDisabled != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardReadDisabled.Size()))
+		n17, err := m.ShardReadDisabled.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
+	}
+	if m.ShardWriteDisabled != nil {
+		dAtA[i] = 0x7a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardWriteDisabled.Size()))
+		n18, err := m.ShardWriteDisabled.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
+	}
+	if m.ShardDisabled != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ShardDisabled.Size()))
+		n19, err := m.ShardDisabled.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
+	}
+	if m.ApplyLagTooLarge != nil {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ApplyLagTooLarge.Size()))
+		n20, err := m.ApplyLagTooLarge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n20
+	}
+	if m.AppLeaseMismatch != nil {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.AppLeaseMismatch.Size()))
+		n21, err := m.AppLeaseMismatch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
+	}
+	if m.GroupMismatch != nil {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.GroupMismatch.Size()))
+		n22, err := m.GroupMismatch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n22
+	}
+	if m.QuorumLost != nil {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.QuorumLost.Size()))
+		n23, err := m.QuorumLost.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
+	}
+	if m.InvalidSplitKeys != nil {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.InvalidSplitKeys.Size()))
+		n24, err := m.InvalidSplitKeys.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
+	}
+	if m.DeadlineExceeded != nil {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.DeadlineExceeded.Size()))
+		n25, err := m.DeadlineExceeded.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n25
+	}
+	if m.StorageIOError != nil {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.StorageIOError.Size()))
+		n26, err := m.StorageIOError.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n26
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func encodeVarintErrorpb(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *NotLeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	l = m.Leader.Size()
+	n += 1 + l + sovErrorpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StoreMismatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardUnavailable) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardNotFound) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KeyNotInShard) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	l = len(m.Start)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	l = len(m.End)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StaleEpoch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.NewShards) > 0 {
+		for _, e := range m.NewShards {
+			l = e.Size()
+			n += 1 + l + sovErrorpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ServerIsBusy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StaleCommand) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RaftEntryTooLarge) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	if m.EntrySize != 0 {
+		n += 1 + sovErrorpb(uint64(m.EntrySize))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LeaseMissing) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	if m.ReplicaID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ReplicaID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LeaseMismatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	if m.RequestLease != nil {
+		l = m.RequestLease.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.ReplicaHeldLease != nil {
+		l = m.ReplicaHeldLease.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LeaseReadNotReady) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardReadDisabled) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	l = len(m.Redirect)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.Permanent {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardWriteDisabled) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	l = len(m.Redirect)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.Permanent {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardDisabled) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	l = len(m.Redirect)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.Permanent {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ApplyLagTooLarge) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	if m.ApplyLag != 0 {
+		n += 1 + sovErrorpb(uint64(m.ApplyLag))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AppLeaseMismatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	if m.RequestToken != 0 {
+		n += 1 + sovErrorpb(uint64(m.RequestToken))
+	}
+	l = m.CurrentLease.Size()
+	n += 1 + l + sovErrorpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GroupMismatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.Group != 0 {
+		n += 1 + sovErrorpb(uint64(m.Group))
+	}
+	if m.RoutedGroup != 0 {
+		n += 1 + sovErrorpb(uint64(m.RoutedGroup))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *QuorumLost) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeadlineExceeded) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StorageIOError) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InvalidSplitKeys) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovErrorpb(uint64(m.ShardID))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Error) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.NotLeader != nil {
+		l = m.NotLeader.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.ShardNotFound != nil {
+		l = m.ShardNotFound.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.KeyNotInShard != nil {
+		l = m.KeyNotInShard.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.StaleEpoch != nil {
+		l = m.StaleEpoch.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.ServerIsBusy != nil {
+		l = m.ServerIsBusy.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.StaleCommand != nil {
+		l = m.StaleCommand.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.StoreMismatch != nil {
+		l = m.StoreMismatch.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.RaftEntryTooLarge != nil {
+		l = m.RaftEntryTooLarge.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.ShardUnavailable != nil {
+		l = m.ShardUnavailable.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.LeaseMissing != nil {
+		l = m.LeaseMissing.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.LeaseMismatch != nil {
+		l = m.LeaseMismatch.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.LeaseReadNotReady != nil {
+		l = m.LeaseReadNotReady.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.ShardReadDisabled != nil {
+		l = m.ShardReadDisabled.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.ShardWriteDisabled != nil {
+		l = m.ShardWriteDisabled.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.ShardDisabled != nil {
+		l = m.ShardDisabled.Size()
+		n += 2 + l + sovErrorpb(uint64(l))
+	}
+	if m.ApplyLagTooLarge != nil {
+		l = m.ApplyLagTooLarge.Size()
+		n += 2 + l + sovErrorpb(uint64(l))
+	}
+	if m.AppLeaseMismatch != nil {
+		l = m.AppLeaseMismatch.Size()
+		n += 2 + l + sovErrorpb(uint64(l))
+	}
+	if m.GroupMismatch != nil {
+		l = m.GroupMismatch.Size()
+		n += 2 + l + sovErrorpb(uint64(l))
+	}
+	if m.QuorumLost != nil {
+		l = m.QuorumLost.Size()
+		n += 2 + l + sovErrorpb(uint64(l))
+	}
+	if m.InvalidSplitKeys != nil {
+		l = m.InvalidSplitKeys.Size()
+		n += 2 + l + sovErrorpb(uint64(l))
+	}
+	if m.DeadlineExceeded != nil {
+		l = m.DeadlineExceeded.Size()
+		n += 2 + l + sovErrorpb(uint64(l))
+	}
+	if m.StorageIOError != nil {
+		l = m.StorageIOError.Size()
+		n += 2 + l + sovErrorpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovErrorpb(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozErrorpb(x uint64) (n int) {
+	return sovErrorpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *NotLeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NotLeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NotLeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreMismatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreMismatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreMismatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardUnavailable) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardUnavailable: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardUnavailable: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardNotFound) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardNotFound: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardNotFound: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KeyNotInShard) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyNotInShard: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyNotInShard: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
+			if m.Start == nil {
+				m.Start = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
+			if m.End == nil {
+				m.End = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StaleEpoch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StaleEpoch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StaleEpoch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewShards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewShards = append(m.NewShards, metapb.Shard{})
+			if err := m.NewShards[len(m.NewShards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServerIsBusy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServerIsBusy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServerIsBusy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StaleCommand) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StaleCommand: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StaleCommand: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftEntryTooLarge) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftEntryTooLarge: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftEntryTooLarge: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EntrySize", wireType)
+			}
+			m.EntrySize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EntrySize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseMissing) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseMissing: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseMissing: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
+			}
+			m.ReplicaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplicaID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseMismatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseMismatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseMismatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestLease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RequestLease == nil {
+				m.RequestLease = &metapb.EpochLease{}
+			}
+			if err := m.RequestLease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaHeldLease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReplicaHeldLease == nil {
+				m.ReplicaHeldLease = &metapb.EpochLease{}
+			}
+			if err := m.ReplicaHeldLease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseReadNotReady) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseReadNotReady: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseReadNotReady: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovErrorpb(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozErrorpb(x uint64) (n int) {
-	return sovErrorpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *NotLeader) Unmarshal(dAtA []byte) error {
+func (m *ShardReadDisabled) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1651,10 +4185,10 @@ func (m *NotLeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NotLeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: ShardReadDisabled: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NotLeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ShardReadDisabled: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1678,9 +4212,9 @@ func (m *NotLeader) Unmarshal(dAtA []byte) error {
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Redirect", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowErrorpb
@@ -1690,25 +4224,169 @@ func (m *NotLeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthErrorpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthErrorpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Redirect = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permanent", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Permanent = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardWriteDisabled) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardWriteDisabled: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardWriteDisabled: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Redirect", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Redirect = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permanent", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Permanent = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -1718,51 +4396,122 @@ func (m *NotLeader) Unmarshal(dAtA []byte) error {
 			if skippy < 0 {
 				return ErrInvalidLengthErrorpb
 			}
-			if (iNdEx + skippy) < 0 {
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardDisabled) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardDisabled: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardDisabled: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Redirect", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthErrorpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *StoreMismatch) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowErrorpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			m.Redirect = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permanent", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: StoreMismatch: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreMismatch: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.Permanent = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -1788,7 +4537,7 @@ func (m *StoreMismatch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardUnavailable) Unmarshal(dAtA []byte) error {
+func (m *ApplyLagTooLarge) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1811,10 +4560,10 @@ func (m *ShardUnavailable) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardUnavailable: wiretype end group for non-group")
+			return fmt.Errorf("proto: ApplyLagTooLarge: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardUnavailable: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ApplyLagTooLarge: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1836,6 +4585,25 @@ func (m *ShardUnavailable) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplyLag", wireType)
+			}
+			m.ApplyLag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApplyLag |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -1861,7 +4629,7 @@ func (m *ShardUnavailable) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardNotFound) Unmarshal(dAtA []byte) error {
+func (m *AppLeaseMismatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1884,10 +4652,10 @@ func (m *ShardNotFound) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardNotFound: wiretype end group for non-group")
+			return fmt.Errorf("proto: AppLeaseMismatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardNotFound: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AppLeaseMismatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1909,6 +4677,58 @@ func (m *ShardNotFound) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestToken", wireType)
+			}
+			m.RequestToken = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequestToken |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrentLease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CurrentLease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -1934,7 +4754,7 @@ func (m *ShardNotFound) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KeyNotInShard) Unmarshal(dAtA []byte) error {
+func (m *GroupMismatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1957,10 +4777,10 @@ func (m *KeyNotInShard) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KeyNotInShard: wiretype end group for non-group")
+			return fmt.Errorf("proto: GroupMismatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KeyNotInShard: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GroupMismatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1999,9 +4819,9 @@ func (m *KeyNotInShard) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
 			}
-			m.ShardID = 0
+			m.Group = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowErrorpb
@@ -2011,50 +4831,16 @@ func (m *KeyNotInShard) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
+				m.Group |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowErrorpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthErrorpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthErrorpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
-			if m.Start == nil {
-				m.Start = []byte{}
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoutedGroup", wireType)
 			}
-			var byteLen int
+			m.RoutedGroup = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowErrorpb
@@ -2064,26 +4850,11 @@ func (m *KeyNotInShard) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.RoutedGroup |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthErrorpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthErrorpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
-			if m.End == nil {
-				m.End = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -2109,7 +4880,7 @@ func (m *KeyNotInShard) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StaleEpoch) Unmarshal(dAtA []byte) error {
+func (m *QuorumLost) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2132,17 +4903,17 @@ func (m *StaleEpoch) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StaleEpoch: wiretype end group for non-group")
+			return fmt.Errorf("proto: QuorumLost: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StaleEpoch: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QuorumLost: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewShards", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
 			}
-			var msglen int
+			m.ShardID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowErrorpb
@@ -2152,26 +4923,11 @@ func (m *StaleEpoch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ShardID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthErrorpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthErrorpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.NewShards = append(m.NewShards, metapb.Shard{})
-			if err := m.NewShards[len(m.NewShards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -2197,7 +4953,8 @@ func (m *StaleEpoch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServerIsBusy) Unmarshal(dAtA []byte) error {
+
+func (m *DeadlineExceeded) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2220,12 +4977,31 @@ func (m *ServerIsBusy) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServerIsBusy: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeadlineExceeded: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServerIsBusy: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeadlineExceeded: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -2251,7 +5027,8 @@ func (m *ServerIsBusy) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StaleCommand) Unmarshal(dAtA []byte) error {
+
+func (m *StorageIOError) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2274,12 +5051,31 @@ func (m *StaleCommand) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StaleCommand: wiretype end group for non-group")
+			return fmt.Errorf("proto: StorageIOError: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StaleCommand: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StorageIOError: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -2305,7 +5101,7 @@ func (m *StaleCommand) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftEntryTooLarge) Unmarshal(dAtA []byte) error {
+func (m *InvalidSplitKeys) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2328,10 +5124,10 @@ func (m *RaftEntryTooLarge) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftEntryTooLarge: wiretype end group for non-group")
+			return fmt.Errorf("proto: InvalidSplitKeys: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftEntryTooLarge: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InvalidSplitKeys: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2354,10 +5150,10 @@ func (m *RaftEntryTooLarge) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EntrySize", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
 			}
-			m.EntrySize = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowErrorpb
@@ -2367,11 +5163,24 @@ func (m *RaftEntryTooLarge) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EntrySize |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -2397,7 +5206,7 @@ func (m *RaftEntryTooLarge) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LeaseMissing) Unmarshal(dAtA []byte) error {
+func (m *Error) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2420,17 +5229,17 @@ func (m *LeaseMissing) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LeaseMissing: wiretype end group for non-group")
+			return fmt.Errorf("proto: Error: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaseMissing: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
-			m.ShardID = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowErrorpb
@@ -2440,16 +5249,29 @@ func (m *LeaseMissing) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotLeader", wireType)
 			}
-			m.ReplicaID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowErrorpb
@@ -2459,70 +5281,177 @@ func (m *LeaseMissing) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReplicaID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipErrorpb(dAtA[iNdEx:])
-			if err != nil {
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NotLeader == nil {
+				m.NotLeader = &NotLeader{}
+			}
+			if err := m.NotLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardNotFound", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthErrorpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthErrorpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LeaseMismatch) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowErrorpb
+			if m.ShardNotFound == nil {
+				m.ShardNotFound = &ShardNotFound{}
 			}
-			if iNdEx >= l {
+			if err := m.ShardNotFound.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyNotInShard", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.KeyNotInShard == nil {
+				m.KeyNotInShard = &KeyNotInShard{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LeaseMismatch: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaseMismatch: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			if err := m.KeyNotInShard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StaleEpoch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StaleEpoch == nil {
+				m.StaleEpoch = &StaleEpoch{}
+			}
+			if err := m.StaleEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerIsBusy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ServerIsBusy == nil {
+				m.ServerIsBusy = &ServerIsBusy{}
+			}
+			if err := m.ServerIsBusy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StaleCommand", wireType)
 			}
-			m.ShardID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowErrorpb
@@ -2532,14 +5461,31 @@ func (m *LeaseMismatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StaleCommand == nil {
+				m.StaleCommand = &StaleCommand{}
+			}
+			if err := m.StaleCommand.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequestLease", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreMismatch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2566,16 +5512,16 @@ func (m *LeaseMismatch) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RequestLease == nil {
-				m.RequestLease = &metapb.EpochLease{}
+			if m.StoreMismatch == nil {
+				m.StoreMismatch = &StoreMismatch{}
 			}
-			if err := m.RequestLease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.StoreMismatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaHeldLease", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RaftEntryTooLarge", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2602,126 +5548,54 @@ func (m *LeaseMismatch) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ReplicaHeldLease == nil {
-				m.ReplicaHeldLease = &metapb.EpochLease{}
+			if m.RaftEntryTooLarge == nil {
+				m.RaftEntryTooLarge = &RaftEntryTooLarge{}
 			}
-			if err := m.ReplicaHeldLease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.RaftEntryTooLarge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipErrorpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthErrorpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthErrorpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *LeaseReadNotReady) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowErrorpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardUnavailable", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LeaseReadNotReady: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaseReadNotReady: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipErrorpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthErrorpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthErrorpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Error) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowErrorpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.ShardUnavailable == nil {
+				m.ShardUnavailable = &ShardUnavailable{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.ShardUnavailable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Error: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseMissing", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowErrorpb
@@ -2731,27 +5605,31 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthErrorpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthErrorpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			if m.LeaseMissing == nil {
+				m.LeaseMissing = &LeaseMissing{}
+			}
+			if err := m.LeaseMissing.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotLeader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseMismatch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2778,16 +5656,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.NotLeader == nil {
-				m.NotLeader = &NotLeader{}
+			if m.LeaseMismatch == nil {
+				m.LeaseMismatch = &LeaseMismatch{}
 			}
-			if err := m.NotLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LeaseMismatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardNotFound", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseReadNotReady", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2814,16 +5692,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ShardNotFound == nil {
-				m.ShardNotFound = &ShardNotFound{}
+			if m.LeaseReadNotReady == nil {
+				m.LeaseReadNotReady = &LeaseReadNotReady{}
 			}
-			if err := m.ShardNotFound.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LeaseReadNotReady.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KeyNotInShard", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardReadDisabled", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2850,16 +5728,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.KeyNotInShard == nil {
-				m.KeyNotInShard = &KeyNotInShard{}
+			if m.ShardReadDisabled == nil {
+				m.ShardReadDisabled = &ShardReadDisabled{}
 			}
-			if err := m.KeyNotInShard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ShardReadDisabled.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StaleEpoch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardWriteDisabled", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2886,16 +5764,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StaleEpoch == nil {
-				m.StaleEpoch = &StaleEpoch{}
+			if m.ShardWriteDisabled == nil {
+				m.ShardWriteDisabled = &ShardWriteDisabled{}
 			}
-			if err := m.StaleEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ShardWriteDisabled.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServerIsBusy", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardDisabled", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2922,16 +5800,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ServerIsBusy == nil {
-				m.ServerIsBusy = &ServerIsBusy{}
+			if m.ShardDisabled == nil {
+				m.ShardDisabled = &ShardDisabled{}
 			}
-			if err := m.ServerIsBusy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ShardDisabled.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StaleCommand", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplyLagTooLarge", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2958,16 +5836,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StaleCommand == nil {
-				m.StaleCommand = &StaleCommand{}
+			if m.ApplyLagTooLarge == nil {
+				m.ApplyLagTooLarge = &ApplyLagTooLarge{}
 			}
-			if err := m.StaleCommand.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ApplyLagTooLarge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 18:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreMismatch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppLeaseMismatch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2994,16 +5872,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StoreMismatch == nil {
-				m.StoreMismatch = &StoreMismatch{}
+			if m.AppLeaseMismatch == nil {
+				m.AppLeaseMismatch = &AppLeaseMismatch{}
 			}
-			if err := m.StoreMismatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AppLeaseMismatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		case 19:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RaftEntryTooLarge", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupMismatch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3030,16 +5908,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RaftEntryTooLarge == nil {
-				m.RaftEntryTooLarge = &RaftEntryTooLarge{}
+			if m.GroupMismatch == nil {
+				m.GroupMismatch = &GroupMismatch{}
 			}
-			if err := m.RaftEntryTooLarge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.GroupMismatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 10:
+		case 20:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardUnavailable", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field QuorumLost", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3066,16 +5944,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ShardUnavailable == nil {
-				m.ShardUnavailable = &ShardUnavailable{}
+			if m.QuorumLost == nil {
+				m.QuorumLost = &QuorumLost{}
 			}
-			if err := m.ShardUnavailable.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.QuorumLost.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 11:
+		case 21:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaseMissing", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InvalidSplitKeys", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3102,16 +5980,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.LeaseMissing == nil {
-				m.LeaseMissing = &LeaseMissing{}
+			if m.InvalidSplitKeys == nil {
+				m.InvalidSplitKeys = &InvalidSplitKeys{}
 			}
-			if err := m.LeaseMissing.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.InvalidSplitKeys.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 12:
+		case 23:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaseMismatch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeadlineExceeded", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3138,16 +6016,16 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.LeaseMismatch == nil {
-				m.LeaseMismatch = &LeaseMismatch{}
+			if m.DeadlineExceeded == nil {
+				m.DeadlineExceeded = &DeadlineExceeded{}
 			}
-			if err := m.LeaseMismatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.DeadlineExceeded.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 13:
+		case 24:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaseReadNotReady", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageIOError", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3174,10 +6052,10 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.LeaseReadNotReady == nil {
-				m.LeaseReadNotReady = &LeaseReadNotReady{}
+			if m.StorageIOError == nil {
+				m.StorageIOError = &StorageIOError{}
 			}
-			if err := m.LeaseReadNotReady.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.StorageIOError.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
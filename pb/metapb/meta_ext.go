@@ -123,6 +123,10 @@ func (m *Store) SetDestroyed(value bool) {
 	m.Destroyed = value
 }
 
+func (m *Store) SetDraining(value bool) {
+	m.Draining = value
+}
+
 func (m *Store) SetState(value StoreState) {
 	m.State = value
 }
@@ -145,6 +149,23 @@ func (m *Shard) MinEnd(end []byte) []byte {
 	return m.End
 }
 
+// Contains returns true if the key is purged by the marker, the range is purged
+// if either bound is set, and the empty end means no upper bound. The restore
+// tooling can skip the purged keys of a backup taken before the marker index.
+func (m *PurgeMarker) Contains(key []byte) bool {
+	if (len(m.Start) > 0 || len(m.End) > 0) &&
+		bytes.Compare(key, m.Start) >= 0 &&
+		(len(m.End) == 0 || bytes.Compare(key, m.End) < 0) {
+		return true
+	}
+	for _, k := range m.Keys {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
 // Match return true if two lease are matched
 func (m *EpochLease) Match(target *EpochLease) bool {
 	return m.GetEpoch() == target.GetEpoch() && m.GetReplicaID() == target.GetReplicaID()
@@ -164,6 +164,11 @@ const (
 	ReplicaRole_Learner       ReplicaRole = 1
 	ReplicaRole_IncomingVoter ReplicaRole = 2
 	ReplicaRole_DemotingVoter ReplicaRole = 3
+	// Witness is a voter that persists the raft log but stores no user data
+	ReplicaRole_Witness ReplicaRole = 4
+	// Observer is a learner that is never promoted, it serves the reads of the
+	// applied state and is kept out of the quorum
+	ReplicaRole_Observer ReplicaRole = 5
 )
 
 var ReplicaRole_name = map[int32]string{
@@ -171,6 +176,8 @@ var ReplicaRole_name = map[int32]string{
 	1: "Learner",
 	2: "IncomingVoter",
 	3: "DemotingVoter",
+	4: "Witness",
+	5: "Observer",
 }
 
 var ReplicaRole_value = map[string]int32{
@@ -178,6 +185,8 @@ var ReplicaRole_value = map[string]int32{
 	"Learner":       1,
 	"IncomingVoter": 2,
 	"DemotingVoter": 3,
+	"Witness":       4,
+	"Observer":      5,
 }
 
 func (x ReplicaRole) String() string {
@@ -188,6 +197,35 @@ func (ReplicaRole) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_77b4d575d5a68dda, []int{4}
 }
 
+// ShardOperation the operations of the shard which may be declined
+type ShardOperation int32
+
+const (
+	ShardOperation_Split      ShardOperation = 0
+	ShardOperation_Merge      ShardOperation = 1
+	ShardOperation_ConfChange ShardOperation = 2
+)
+
+var ShardOperation_name = map[int32]string{
+	0: "Split",
+	1: "Merge",
+	2: "ConfChange",
+}
+
+var ShardOperation_value = map[string]int32{
+	"Split":      0,
+	"Merge":      1,
+	"ConfChange": 2,
+}
+
+func (x ShardOperation) String() string {
+	return proto.EnumName(ShardOperation_name, int32(x))
+}
+
+func (ShardOperation) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{5}
+}
+
 // CheckPolicy check policy
 type CheckPolicy int32
 
@@ -214,7 +252,7 @@ func (x CheckPolicy) String() string {
 }
 
 func (CheckPolicy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{5}
+	return fileDescriptor_77b4d575d5a68dda, []int{6}
 }
 
 // OperatorStatus Operator Status
@@ -249,7 +287,7 @@ func (x OperatorStatus) String() string {
 }
 
 func (OperatorStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{6}
+	return fileDescriptor_77b4d575d5a68dda, []int{7}
 }
 
 // JobType job type
@@ -281,7 +319,7 @@ func (x JobType) String() string {
 }
 
 func (JobType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{7}
+	return fileDescriptor_77b4d575d5a68dda, []int{8}
 }
 
 // JobState job state
@@ -313,7 +351,7 @@ func (x JobState) String() string {
 }
 
 func (JobState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{8}
+	return fileDescriptor_77b4d575d5a68dda, []int{9}
 }
 
 // ReplicaState the state of the shard peer
@@ -342,7 +380,7 @@ func (x ReplicaState) String() string {
 }
 
 func (ReplicaState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{9}
+	return fileDescriptor_77b4d575d5a68dda, []int{10}
 }
 
 // ShardsPoolCmdType shards pool cmd
@@ -368,7 +406,7 @@ func (x ShardsPoolCmdType) String() string {
 }
 
 func (ShardsPoolCmdType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{10}
+	return fileDescriptor_77b4d575d5a68dda, []int{11}
 }
 
 // ShardEpoch shard epoch
@@ -613,6 +651,71 @@ func (m *Label) GetValue() string {
 	return ""
 }
 
+// BlockingReason the latest reason why an operation of the shard is declined
+type BlockingReason struct {
+	Operation ShardOperation `protobuf:"varint,1,opt,name=operation,proto3,enum=metapb.ShardOperation" json:"operation,omitempty"`
+	Reason    string         `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// time the unix seconds when the operation is declined
+	Time                 int64    `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockingReason) Reset()         { *m = BlockingReason{} }
+func (m *BlockingReason) String() string { return proto.CompactTextString(m) }
+func (*BlockingReason) ProtoMessage()    {}
+func (*BlockingReason) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{4}
+}
+func (m *BlockingReason) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockingReason) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockingReason.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockingReason) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockingReason.Merge(m, src)
+}
+func (m *BlockingReason) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockingReason) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockingReason.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockingReason proto.InternalMessageInfo
+
+func (m *BlockingReason) GetOperation() ShardOperation {
+	if m != nil {
+		return m.Operation
+	}
+	return ShardOperation_Split
+}
+
+func (m *BlockingReason) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *BlockingReason) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
 // ShardStats shard stats
 type ShardStats struct {
 	// shard ID
@@ -630,17 +733,19 @@ type ShardStats struct {
 	// approximate count of keys in the shard
 	ApproximateKeys uint64 `protobuf:"varint,7,opt,name=approximateKeys,proto3" json:"approximateKeys,omitempty"`
 	// Actually reported time interval
-	Interval             *TimeInterval `protobuf:"bytes,8,opt,name=interval,proto3" json:"interval,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Interval *TimeInterval `protobuf:"bytes,8,opt,name=interval,proto3" json:"interval,omitempty"`
+	// average latency in microseconds of the reads during this period
+	ReadLatency          uint64   `protobuf:"varint,9,opt,name=readLatency,proto3" json:"readLatency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ShardStats) Reset()         { *m = ShardStats{} }
 func (m *ShardStats) String() string { return proto.CompactTextString(m) }
 func (*ShardStats) ProtoMessage()    {}
 func (*ShardStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{4}
+	return fileDescriptor_77b4d575d5a68dda, []int{5}
 }
 func (m *ShardStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -725,6 +830,13 @@ func (m *ShardStats) GetInterval() *TimeInterval {
 	return nil
 }
 
+func (m *ShardStats) GetReadLatency() uint64 {
+	if m != nil {
+		return m.ReadLatency
+	}
+	return 0
+}
+
 // StoreStats store stats
 type StoreStats struct {
 	// Store id
@@ -762,17 +874,32 @@ type StoreStats struct {
 	// Threads' read disk I/O rates in the store
 	ReadIORates []RecordPair `protobuf:"bytes,17,rep,name=readIORates,proto3" json:"readIORates"`
 	// Threads' write disk I/O rates in the store
-	WriteIORates         []RecordPair `protobuf:"bytes,18,rep,name=writeIORates,proto3" json:"writeIORates"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	WriteIORates []RecordPair `protobuf:"bytes,18,rep,name=writeIORates,proto3" json:"writeIORates"`
+	// Shards without leader which have lost the write quorum on the store
+	QuorumLostShards []uint64 `protobuf:"varint,19,rep,packed,name=quorumLostShards,proto3" json:"quorumLostShards,omitempty"`
+	// Committed but not applied raft log entries of all replicas in the store
+	ApplyBacklog uint64 `protobuf:"varint,20,opt,name=applyBacklog,proto3" json:"applyBacklog,omitempty"`
+	// Average nanoseconds spent by the apply loops of the store per applied
+	// entry in the last sampling window
+	ApplyLatency uint64 `protobuf:"varint,21,opt,name=applyLatency,proto3" json:"applyLatency,omitempty"`
+	// Replication latencies from the leaders in the store to the replicas in
+	// the other stores in the last reporting window
+	PeerLatencies []PeerStoreLatency `protobuf:"bytes,22,rep,name=peerLatencies,proto3" json:"peerLatencies"`
+	// Replicas in the store whose data checksums diverge from the leaders'
+	InconsistentShards []InconsistentShard `protobuf:"bytes,23,rep,name=inconsistentShards,proto3" json:"inconsistentShards"`
+	// The store failed to write the data storage, the failed replicas stop
+	// applying until the store is restarted
+	DiskFailed           bool     `protobuf:"varint,24,opt,name=diskFailed,proto3" json:"diskFailed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StoreStats) Reset()         { *m = StoreStats{} }
 func (m *StoreStats) String() string { return proto.CompactTextString(m) }
 func (*StoreStats) ProtoMessage()    {}
 func (*StoreStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{5}
+	return fileDescriptor_77b4d575d5a68dda, []int{6}
 }
 func (m *StoreStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -927,6 +1054,48 @@ func (m *StoreStats) GetWriteIORates() []RecordPair {
 	return nil
 }
 
+func (m *StoreStats) GetQuorumLostShards() []uint64 {
+	if m != nil {
+		return m.QuorumLostShards
+	}
+	return nil
+}
+
+func (m *StoreStats) GetApplyBacklog() uint64 {
+	if m != nil {
+		return m.ApplyBacklog
+	}
+	return 0
+}
+
+func (m *StoreStats) GetApplyLatency() uint64 {
+	if m != nil {
+		return m.ApplyLatency
+	}
+	return 0
+}
+
+func (m *StoreStats) GetPeerLatencies() []PeerStoreLatency {
+	if m != nil {
+		return m.PeerLatencies
+	}
+	return nil
+}
+
+func (m *StoreStats) GetInconsistentShards() []InconsistentShard {
+	if m != nil {
+		return m.InconsistentShards
+	}
+	return nil
+}
+
+func (m *StoreStats) GetDiskFailed() bool {
+	if m != nil {
+		return m.DiskFailed
+	}
+	return false
+}
+
 // RecordPair record pair
 type RecordPair struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -940,7 +1109,7 @@ func (m *RecordPair) Reset()         { *m = RecordPair{} }
 func (m *RecordPair) String() string { return proto.CompactTextString(m) }
 func (*RecordPair) ProtoMessage()    {}
 func (*RecordPair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{6}
+	return fileDescriptor_77b4d575d5a68dda, []int{7}
 }
 func (m *RecordPair) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -983,6 +1152,149 @@ func (m *RecordPair) GetValue() uint64 {
 	return 0
 }
 
+// PeerStoreLatency the heartbeat round trip latency from the leaders in the
+// store to the replicas in the peer store
+type PeerStoreLatency struct {
+	StoreID uint64 `protobuf:"varint,1,opt,name=storeID,proto3" json:"storeID,omitempty"`
+	// Average round trip nanoseconds of the sampled heartbeats
+	Latency uint64 `protobuf:"varint,2,opt,name=latency,proto3" json:"latency,omitempty"`
+	// Number of the sampled heartbeats
+	Samples              uint64   `protobuf:"varint,3,opt,name=samples,proto3" json:"samples,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerStoreLatency) Reset()         { *m = PeerStoreLatency{} }
+func (m *PeerStoreLatency) String() string { return proto.CompactTextString(m) }
+func (*PeerStoreLatency) ProtoMessage()    {}
+func (*PeerStoreLatency) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{8}
+}
+func (m *PeerStoreLatency) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerStoreLatency) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerStoreLatency.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PeerStoreLatency) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerStoreLatency.Merge(m, src)
+}
+func (m *PeerStoreLatency) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerStoreLatency) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerStoreLatency.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerStoreLatency proto.InternalMessageInfo
+
+func (m *PeerStoreLatency) GetStoreID() uint64 {
+	if m != nil {
+		return m.StoreID
+	}
+	return 0
+}
+
+func (m *PeerStoreLatency) GetLatency() uint64 {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
+func (m *PeerStoreLatency) GetSamples() uint64 {
+	if m != nil {
+		return m.Samples
+	}
+	return 0
+}
+
+// InconsistentShard the replica whose data checksum diverges from the checksum
+// of the leader at the same applied index
+type InconsistentShard struct {
+	ShardID uint64 `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	// Index the applied index of the compared checksums
+	Index uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// Hash the data checksum of the local replica
+	Hash uint64 `protobuf:"varint,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	// ExpectHash the data checksum of the leader
+	ExpectHash           uint64   `protobuf:"varint,4,opt,name=expectHash,proto3" json:"expectHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InconsistentShard) Reset()         { *m = InconsistentShard{} }
+func (m *InconsistentShard) String() string { return proto.CompactTextString(m) }
+func (*InconsistentShard) ProtoMessage()    {}
+func (*InconsistentShard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{9}
+}
+func (m *InconsistentShard) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InconsistentShard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InconsistentShard.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InconsistentShard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InconsistentShard.Merge(m, src)
+}
+func (m *InconsistentShard) XXX_Size() int {
+	return m.Size()
+}
+func (m *InconsistentShard) XXX_DiscardUnknown() {
+	xxx_messageInfo_InconsistentShard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InconsistentShard proto.InternalMessageInfo
+
+func (m *InconsistentShard) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
+	}
+	return 0
+}
+
+func (m *InconsistentShard) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *InconsistentShard) GetHash() uint64 {
+	if m != nil {
+		return m.Hash
+	}
+	return 0
+}
+
+func (m *InconsistentShard) GetExpectHash() uint64 {
+	if m != nil {
+		return m.ExpectHash
+	}
+	return 0
+}
+
 // Member prophet member
 type Member struct {
 	ID                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -997,7 +1309,7 @@ func (m *Member) Reset()         { *m = Member{} }
 func (m *Member) String() string { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()    {}
 func (*Member) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{7}
+	return fileDescriptor_77b4d575d5a68dda, []int{10}
 }
 func (m *Member) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1060,7 +1372,7 @@ func (m *ProphetCluster) Reset()         { *m = ProphetCluster{} }
 func (m *ProphetCluster) String() string { return proto.CompactTextString(m) }
 func (*ProphetCluster) ProtoMessage()    {}
 func (*ProphetCluster) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{8}
+	return fileDescriptor_77b4d575d5a68dda, []int{11}
 }
 func (m *ProphetCluster) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1118,7 +1430,7 @@ func (m *TimeInterval) Reset()         { *m = TimeInterval{} }
 func (m *TimeInterval) String() string { return proto.CompactTextString(m) }
 func (*TimeInterval) ProtoMessage()    {}
 func (*TimeInterval) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{9}
+	return fileDescriptor_77b4d575d5a68dda, []int{12}
 }
 func (m *TimeInterval) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1175,7 +1487,7 @@ func (m *Job) Reset()         { *m = Job{} }
 func (m *Job) String() string { return proto.CompactTextString(m) }
 func (*Job) ProtoMessage()    {}
 func (*Job) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{10}
+	return fileDescriptor_77b4d575d5a68dda, []int{13}
 }
 func (m *Job) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1238,7 +1550,7 @@ func (m *RemoveShardJob) Reset()         { *m = RemoveShardJob{} }
 func (m *RemoveShardJob) String() string { return proto.CompactTextString(m) }
 func (*RemoveShardJob) ProtoMessage()    {}
 func (*RemoveShardJob) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{11}
+	return fileDescriptor_77b4d575d5a68dda, []int{14}
 }
 func (m *RemoveShardJob) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1293,7 +1605,7 @@ func (m *ShardPoolJob) Reset()         { *m = ShardPoolJob{} }
 func (m *ShardPoolJob) String() string { return proto.CompactTextString(m) }
 func (*ShardPoolJob) ProtoMessage()    {}
 func (*ShardPoolJob) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{12}
+	return fileDescriptor_77b4d575d5a68dda, []int{15}
 }
 func (m *ShardPoolJob) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1343,7 +1655,7 @@ func (m *ShardPoolJobMeta) Reset()         { *m = ShardPoolJobMeta{} }
 func (m *ShardPoolJobMeta) String() string { return proto.CompactTextString(m) }
 func (*ShardPoolJobMeta) ProtoMessage()    {}
 func (*ShardPoolJobMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{13}
+	return fileDescriptor_77b4d575d5a68dda, []int{16}
 }
 func (m *ShardPoolJobMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1408,7 +1720,7 @@ func (m *DestroyingStatus) Reset()         { *m = DestroyingStatus{} }
 func (m *DestroyingStatus) String() string { return proto.CompactTextString(m) }
 func (*DestroyingStatus) ProtoMessage()    {}
 func (*DestroyingStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{14}
+	return fileDescriptor_77b4d575d5a68dda, []int{17}
 }
 func (m *DestroyingStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1478,7 +1790,7 @@ func (m *ShardExtra) Reset()         { *m = ShardExtra{} }
 func (m *ShardExtra) String() string { return proto.CompactTextString(m) }
 func (*ShardExtra) ProtoMessage()    {}
 func (*ShardExtra) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{15}
+	return fileDescriptor_77b4d575d5a68dda, []int{18}
 }
 func (m *ShardExtra) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1536,7 +1848,7 @@ func (m *ScheduleGroupRule) Reset()         { *m = ScheduleGroupRule{} }
 func (m *ScheduleGroupRule) String() string { return proto.CompactTextString(m) }
 func (*ScheduleGroupRule) ProtoMessage()    {}
 func (*ScheduleGroupRule) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{16}
+	return fileDescriptor_77b4d575d5a68dda, []int{19}
 }
 func (m *ScheduleGroupRule) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1593,26 +1905,30 @@ func (m *ScheduleGroupRule) GetGroupByLabel() string {
 	return ""
 }
 
-// RaftMessageBatch is a group of messages sent to the same store.
-type RaftMessageBatch struct {
-	Messages             []RaftMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+// ClusterSetting is a cluster-wide dynamic setting, the settings are stored
+// by prophet and propagated to all stores by the watchers.
+type ClusterSetting struct {
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Version is increased by prophet on every update of the setting
+	Version              uint64   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RaftMessageBatch) Reset()         { *m = RaftMessageBatch{} }
-func (m *RaftMessageBatch) String() string { return proto.CompactTextString(m) }
-func (*RaftMessageBatch) ProtoMessage()    {}
-func (*RaftMessageBatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{17}
+func (m *ClusterSetting) Reset()         { *m = ClusterSetting{} }
+func (m *ClusterSetting) String() string { return proto.CompactTextString(m) }
+func (*ClusterSetting) ProtoMessage()    {}
+func (*ClusterSetting) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{20}
 }
-func (m *RaftMessageBatch) XXX_Unmarshal(b []byte) error {
+func (m *ClusterSetting) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RaftMessageBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ClusterSetting) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RaftMessageBatch.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ClusterSetting.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -1622,50 +1938,115 @@ func (m *RaftMessageBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *RaftMessageBatch) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RaftMessageBatch.Merge(m, src)
+func (m *ClusterSetting) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterSetting.Merge(m, src)
 }
-func (m *RaftMessageBatch) XXX_Size() int {
+func (m *ClusterSetting) XXX_Size() int {
 	return m.Size()
 }
-func (m *RaftMessageBatch) XXX_DiscardUnknown() {
-	xxx_messageInfo_RaftMessageBatch.DiscardUnknown(m)
+func (m *ClusterSetting) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterSetting.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RaftMessageBatch proto.InternalMessageInfo
+var xxx_messageInfo_ClusterSetting proto.InternalMessageInfo
 
-func (m *RaftMessageBatch) GetMessages() []RaftMessage {
+func (m *ClusterSetting) GetKey() string {
 	if m != nil {
-		return m.Messages
+		return m.Key
 	}
-	return nil
+	return ""
 }
 
-// RaftMessage the message wrapped raft msg with shard info
-type RaftMessage struct {
-	ShardID              uint64         `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
-	Group                uint64         `protobuf:"varint,2,opt,name=group,proto3" json:"group,omitempty"`
-	From                 Replica        `protobuf:"bytes,3,opt,name=from,proto3" json:"from"`
-	To                   Replica        `protobuf:"bytes,4,opt,name=to,proto3" json:"to"`
-	Message              raftpb.Message `protobuf:"bytes,5,opt,name=message,proto3" json:"message"`
-	ShardEpoch           ShardEpoch     `protobuf:"bytes,6,opt,name=shardEpoch,proto3" json:"shardEpoch"`
-	IsTombstone          bool           `protobuf:"varint,7,opt,name=isTombstone,proto3" json:"isTombstone,omitempty"`
-	Start                []byte         `protobuf:"bytes,8,opt,name=start,proto3" json:"start,omitempty"`
-	End                  []byte         `protobuf:"bytes,9,opt,name=end,proto3" json:"end,omitempty"`
-	Unique               string         `protobuf:"bytes,10,opt,name=unique,proto3" json:"unique,omitempty"`
-	RuleGroups           []string       `protobuf:"bytes,11,rep,name=ruleGroups,proto3" json:"ruleGroups,omitempty"`
-	CommitIndex          uint64         `protobuf:"varint,12,opt,name=commitIndex,proto3" json:"commitIndex,omitempty"`
-	SendTime             uint64         `protobuf:"varint,13,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
-}
+func (m *ClusterSetting) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *ClusterSetting) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+// RaftMessageBatch is a group of messages sent to the same store.
+type RaftMessageBatch struct {
+	Messages             []RaftMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RaftMessageBatch) Reset()         { *m = RaftMessageBatch{} }
+func (m *RaftMessageBatch) String() string { return proto.CompactTextString(m) }
+func (*RaftMessageBatch) ProtoMessage()    {}
+func (*RaftMessageBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{21}
+}
+func (m *RaftMessageBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RaftMessageBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RaftMessageBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RaftMessageBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftMessageBatch.Merge(m, src)
+}
+func (m *RaftMessageBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *RaftMessageBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftMessageBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftMessageBatch proto.InternalMessageInfo
+
+func (m *RaftMessageBatch) GetMessages() []RaftMessage {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+// RaftMessage the message wrapped raft msg with shard info
+type RaftMessage struct {
+	ShardID     uint64         `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	Group       uint64         `protobuf:"varint,2,opt,name=group,proto3" json:"group,omitempty"`
+	From        Replica        `protobuf:"bytes,3,opt,name=from,proto3" json:"from"`
+	To          Replica        `protobuf:"bytes,4,opt,name=to,proto3" json:"to"`
+	Message     raftpb.Message `protobuf:"bytes,5,opt,name=message,proto3" json:"message"`
+	ShardEpoch  ShardEpoch     `protobuf:"bytes,6,opt,name=shardEpoch,proto3" json:"shardEpoch"`
+	IsTombstone bool           `protobuf:"varint,7,opt,name=isTombstone,proto3" json:"isTombstone,omitempty"`
+	Start       []byte         `protobuf:"bytes,8,opt,name=start,proto3" json:"start,omitempty"`
+	End         []byte         `protobuf:"bytes,9,opt,name=end,proto3" json:"end,omitempty"`
+	Unique      string         `protobuf:"bytes,10,opt,name=unique,proto3" json:"unique,omitempty"`
+	RuleGroups  []string       `protobuf:"bytes,11,rep,name=ruleGroups,proto3" json:"ruleGroups,omitempty"`
+	CommitIndex uint64         `protobuf:"varint,12,opt,name=commitIndex,proto3" json:"commitIndex,omitempty"`
+	SendTime    uint64         `protobuf:"varint,13,opt,name=sendTime,proto3" json:"sendTime,omitempty"`
+	// hibernate marks the message used to hibernate the replicas of an idle
+	// shard, it's handled by the replica and never stepped into the raft
+	Hibernate            bool     `protobuf:"varint,14,opt,name=hibernate,proto3" json:"hibernate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
 func (m *RaftMessage) Reset()         { *m = RaftMessage{} }
 func (m *RaftMessage) String() string { return proto.CompactTextString(m) }
 func (*RaftMessage) ProtoMessage()    {}
 func (*RaftMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{18}
+	return fileDescriptor_77b4d575d5a68dda, []int{22}
 }
 func (m *RaftMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1785,33 +2166,43 @@ func (m *RaftMessage) GetSendTime() uint64 {
 	return 0
 }
 
+func (m *RaftMessage) GetHibernate() bool {
+	if m != nil {
+		return m.Hibernate
+	}
+	return false
+}
+
 type SnapshotChunk struct {
-	StoreID              uint64           `protobuf:"varint,1,opt,name=storeID,proto3" json:"storeID,omitempty"`
-	ShardID              uint64           `protobuf:"varint,2,opt,name=shardID,proto3" json:"shardID,omitempty"`
-	ReplicaID            uint64           `protobuf:"varint,3,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
-	From                 uint64           `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
-	ChunkID              uint64           `protobuf:"varint,5,opt,name=chunkID,proto3" json:"chunkID,omitempty"`
-	ChunkSize            uint64           `protobuf:"varint,6,opt,name=chunkSize,proto3" json:"chunkSize,omitempty"`
-	ChunkCount           uint64           `protobuf:"varint,7,opt,name=chunkCount,proto3" json:"chunkCount,omitempty"`
-	Index                uint64           `protobuf:"varint,8,opt,name=index,proto3" json:"index,omitempty"`
-	Term                 uint64           `protobuf:"varint,9,opt,name=term,proto3" json:"term,omitempty"`
-	FilePath             string           `protobuf:"bytes,10,opt,name=filePath,proto3" json:"filePath,omitempty"`
-	FileSize             uint64           `protobuf:"varint,11,opt,name=fileSize,proto3" json:"fileSize,omitempty"`
-	FileChunkID          uint64           `protobuf:"varint,12,opt,name=fileChunkID,proto3" json:"fileChunkID,omitempty"`
-	FileChunkCount       uint64           `protobuf:"varint,13,opt,name=fileChunkCount,proto3" json:"fileChunkCount,omitempty"`
-	Data                 []byte           `protobuf:"bytes,14,opt,name=data,proto3" json:"data,omitempty"`
-	Extra                []byte           `protobuf:"bytes,15,opt,name=extra,proto3" json:"extra,omitempty"`
-	ConfState            raftpb.ConfState `protobuf:"bytes,16,opt,name=confState,proto3" json:"confState"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	StoreID        uint64           `protobuf:"varint,1,opt,name=storeID,proto3" json:"storeID,omitempty"`
+	ShardID        uint64           `protobuf:"varint,2,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	ReplicaID      uint64           `protobuf:"varint,3,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+	From           uint64           `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	ChunkID        uint64           `protobuf:"varint,5,opt,name=chunkID,proto3" json:"chunkID,omitempty"`
+	ChunkSize      uint64           `protobuf:"varint,6,opt,name=chunkSize,proto3" json:"chunkSize,omitempty"`
+	ChunkCount     uint64           `protobuf:"varint,7,opt,name=chunkCount,proto3" json:"chunkCount,omitempty"`
+	Index          uint64           `protobuf:"varint,8,opt,name=index,proto3" json:"index,omitempty"`
+	Term           uint64           `protobuf:"varint,9,opt,name=term,proto3" json:"term,omitempty"`
+	FilePath       string           `protobuf:"bytes,10,opt,name=filePath,proto3" json:"filePath,omitempty"`
+	FileSize       uint64           `protobuf:"varint,11,opt,name=fileSize,proto3" json:"fileSize,omitempty"`
+	FileChunkID    uint64           `protobuf:"varint,12,opt,name=fileChunkID,proto3" json:"fileChunkID,omitempty"`
+	FileChunkCount uint64           `protobuf:"varint,13,opt,name=fileChunkCount,proto3" json:"fileChunkCount,omitempty"`
+	Data           []byte           `protobuf:"bytes,14,opt,name=data,proto3" json:"data,omitempty"`
+	Extra          []byte           `protobuf:"bytes,15,opt,name=extra,proto3" json:"extra,omitempty"`
+	ConfState      raftpb.ConfState `protobuf:"bytes,16,opt,name=confState,proto3" json:"confState"`
+	// checksum the crc32 checksum of the data, 0 if the sender does not
+	// checksum the chunks
+	Checksum             uint32   `protobuf:"varint,17,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SnapshotChunk) Reset()         { *m = SnapshotChunk{} }
 func (m *SnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*SnapshotChunk) ProtoMessage()    {}
 func (*SnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{19}
+	return fileDescriptor_77b4d575d5a68dda, []int{23}
 }
 func (m *SnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1952,6 +2343,195 @@ func (m *SnapshotChunk) GetConfState() raftpb.ConfState {
 	return raftpb.ConfState{}
 }
 
+func (m *SnapshotChunk) GetChecksum() uint32 {
+	if m != nil {
+		return m.Checksum
+	}
+	return 0
+}
+
+// SnapshotResume is sent by the snapshot sender to query the receiving progress
+// of a snapshot, the receiver replies with the next chunk ID it expects, so an
+// interrupted transfer can be resumed from there.
+type SnapshotResume struct {
+	ShardID              uint64   `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	ReplicaID            uint64   `protobuf:"varint,2,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+	From                 uint64   `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	Index                uint64   `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	Next                 uint64   `protobuf:"varint,5,opt,name=next,proto3" json:"next,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotResume) Reset()         { *m = SnapshotResume{} }
+func (m *SnapshotResume) String() string { return proto.CompactTextString(m) }
+func (*SnapshotResume) ProtoMessage()    {}
+func (*SnapshotResume) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{24}
+}
+func (m *SnapshotResume) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotResume) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotResume.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SnapshotResume) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotResume.Merge(m, src)
+}
+func (m *SnapshotResume) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotResume) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotResume.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotResume proto.InternalMessageInfo
+
+func (m *SnapshotResume) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
+	}
+	return 0
+}
+
+func (m *SnapshotResume) GetReplicaID() uint64 {
+	if m != nil {
+		return m.ReplicaID
+	}
+	return 0
+}
+
+func (m *SnapshotResume) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *SnapshotResume) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SnapshotResume) GetNext() uint64 {
+	if m != nil {
+		return m.Next
+	}
+	return 0
+}
+
+// TransportHandshake is exchanged when a raft transport connection is
+// established. The dialer sends its own info, and the acceptor replies with
+// its own info or the reason of the rejection.
+type TransportHandshake struct {
+	ClusterID uint64 `protobuf:"varint,1,opt,name=clusterID,proto3" json:"clusterID,omitempty"`
+	StoreID   uint64 `protobuf:"varint,2,opt,name=storeID,proto3" json:"storeID,omitempty"`
+	// targetStoreID the store expected by the dialer, 0 means unknown
+	TargetStoreID   uint64   `protobuf:"varint,3,opt,name=targetStoreID,proto3" json:"targetStoreID,omitempty"`
+	ProtocolVersion uint32   `protobuf:"varint,4,opt,name=protocolVersion,proto3" json:"protocolVersion,omitempty"`
+	Version         string   `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Capabilities    []string `protobuf:"bytes,6,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// rejected the reason why the acceptor rejects the connection
+	Rejected             string   `protobuf:"bytes,7,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransportHandshake) Reset()         { *m = TransportHandshake{} }
+func (m *TransportHandshake) String() string { return proto.CompactTextString(m) }
+func (*TransportHandshake) ProtoMessage()    {}
+func (*TransportHandshake) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{25}
+}
+func (m *TransportHandshake) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransportHandshake) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransportHandshake.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransportHandshake) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransportHandshake.Merge(m, src)
+}
+func (m *TransportHandshake) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransportHandshake) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransportHandshake.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransportHandshake proto.InternalMessageInfo
+
+func (m *TransportHandshake) GetClusterID() uint64 {
+	if m != nil {
+		return m.ClusterID
+	}
+	return 0
+}
+
+func (m *TransportHandshake) GetStoreID() uint64 {
+	if m != nil {
+		return m.StoreID
+	}
+	return 0
+}
+
+func (m *TransportHandshake) GetTargetStoreID() uint64 {
+	if m != nil {
+		return m.TargetStoreID
+	}
+	return 0
+}
+
+func (m *TransportHandshake) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *TransportHandshake) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *TransportHandshake) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *TransportHandshake) GetRejected() string {
+	if m != nil {
+		return m.Rejected
+	}
+	return ""
+}
+
 // StoreIdent store ident
 type StoreIdent struct {
 	ClusterID            uint64   `protobuf:"varint,1,opt,name=clusterID,proto3" json:"clusterID,omitempty"`
@@ -1965,7 +2545,7 @@ func (m *StoreIdent) Reset()         { *m = StoreIdent{} }
 func (m *StoreIdent) String() string { return proto.CompactTextString(m) }
 func (*StoreIdent) ProtoMessage()    {}
 func (*StoreIdent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{20}
+	return fileDescriptor_77b4d575d5a68dda, []int{26}
 }
 func (m *StoreIdent) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2010,26 +2590,36 @@ func (m *StoreIdent) GetStoreID() uint64 {
 
 // Shard a shard [start,end) of the data
 type Shard struct {
-	ID                   uint64     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Start                []byte     `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
-	End                  []byte     `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
-	Epoch                ShardEpoch `protobuf:"bytes,4,opt,name=epoch,proto3" json:"epoch"`
-	State                ShardState `protobuf:"varint,5,opt,name=state,proto3,enum=metapb.ShardState" json:"state,omitempty"`
-	Replicas             []Replica  `protobuf:"bytes,6,rep,name=replicas,proto3" json:"replicas"`
-	Group                uint64     `protobuf:"varint,7,opt,name=group,proto3" json:"group,omitempty"`
-	Unique               string     `protobuf:"bytes,8,opt,name=unique,proto3" json:"unique,omitempty"`
-	RuleGroups           []string   `protobuf:"bytes,9,rep,name=ruleGroups,proto3" json:"ruleGroups,omitempty"`
-	Labels               []Label    `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	ID         uint64     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start      []byte     `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End        []byte     `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	Epoch      ShardEpoch `protobuf:"bytes,4,opt,name=epoch,proto3" json:"epoch"`
+	State      ShardState `protobuf:"varint,5,opt,name=state,proto3,enum=metapb.ShardState" json:"state,omitempty"`
+	Replicas   []Replica  `protobuf:"bytes,6,rep,name=replicas,proto3" json:"replicas"`
+	Group      uint64     `protobuf:"varint,7,opt,name=group,proto3" json:"group,omitempty"`
+	Unique     string     `protobuf:"bytes,8,opt,name=unique,proto3" json:"unique,omitempty"`
+	RuleGroups []string   `protobuf:"bytes,9,rep,name=ruleGroups,proto3" json:"ruleGroups,omitempty"`
+	Labels     []Label    `protobuf:"bytes,10,rep,name=labels,proto3" json:"labels"`
+	Gate       ShardGate  `protobuf:"bytes,11,opt,name=gate,proto3" json:"gate"`
+	AppLease   AppLease   `protobuf:"bytes,12,opt,name=appLease,proto3" json:"appLease"`
+	// Attributes application defined opaque attributes of the shard, e.g. the
+	// schema version tracked by the upper layer. Set at the shard creation and
+	// inherited or recomputed by CustomSplitShardAttributesFunc on split.
+	Attributes []byte `protobuf:"bytes,13,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	// TTL the seconds after the last write that the data of the shard expires,
+	// the expired data is removed by the TTL GC of the shard leader. 0 means
+	// the data never expires. Inherited by the new shards on split.
+	TTL                  uint64   `protobuf:"varint,14,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Shard) Reset()         { *m = Shard{} }
 func (m *Shard) String() string { return proto.CompactTextString(m) }
 func (*Shard) ProtoMessage()    {}
 func (*Shard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{21}
+	return fileDescriptor_77b4d575d5a68dda, []int{27}
 }
 func (m *Shard) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2128,27 +2718,64 @@ func (m *Shard) GetLabels() []Label {
 	return nil
 }
 
-// LogIndex is used to indicate a position in the log.
-type LogIndex struct {
-	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
-	Term                 uint64   `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+func (m *Shard) GetGate() ShardGate {
+	if m != nil {
+		return m.Gate
+	}
+	return ShardGate{}
+}
+
+func (m *Shard) GetAppLease() AppLease {
+	if m != nil {
+		return m.AppLease
+	}
+	return AppLease{}
+}
+
+func (m *Shard) GetAttributes() []byte {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Shard) GetTTL() uint64 {
+	if m != nil {
+		return m.TTL
+	}
+	return 0
+}
+
+// ShardGate is used to block the read or write requests of the shard, e.g. the
+// migration or archive tools block the writes during the cutover.
+type ShardGate struct {
+	// DisableRead block the read requests
+	DisableRead bool `protobuf:"varint,1,opt,name=disableRead,proto3" json:"disableRead,omitempty"`
+	// DisableWrite block the write requests
+	DisableWrite bool `protobuf:"varint,2,opt,name=disableWrite,proto3" json:"disableWrite,omitempty"`
+	// Redirect optional hint returned to the clients, e.g. the address of the
+	// cluster which serves the shard after the cutover
+	Redirect string `protobuf:"bytes,3,opt,name=redirect,proto3" json:"redirect,omitempty"`
+	// Permanent the block is permanent, e.g. the frozen shards after the tier
+	// migration, the blocked requests are not retryable
+	Permanent            bool     `protobuf:"varint,4,opt,name=permanent,proto3" json:"permanent,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LogIndex) Reset()         { *m = LogIndex{} }
-func (m *LogIndex) String() string { return proto.CompactTextString(m) }
-func (*LogIndex) ProtoMessage()    {}
-func (*LogIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{22}
+func (m *ShardGate) Reset()         { *m = ShardGate{} }
+func (m *ShardGate) String() string { return proto.CompactTextString(m) }
+func (*ShardGate) ProtoMessage()    {}
+func (*ShardGate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{28}
 }
-func (m *LogIndex) XXX_Unmarshal(b []byte) error {
+func (m *ShardGate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LogIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ShardGate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LogIndex.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ShardGate.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2158,34 +2785,175 @@ func (m *LogIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *LogIndex) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogIndex.Merge(m, src)
+func (m *ShardGate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardGate.Merge(m, src)
 }
-func (m *LogIndex) XXX_Size() int {
+func (m *ShardGate) XXX_Size() int {
 	return m.Size()
 }
-func (m *LogIndex) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogIndex.DiscardUnknown(m)
+func (m *ShardGate) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardGate.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LogIndex proto.InternalMessageInfo
+var xxx_messageInfo_ShardGate proto.InternalMessageInfo
 
-func (m *LogIndex) GetIndex() uint64 {
+func (m *ShardGate) GetDisableRead() bool {
 	if m != nil {
-		return m.Index
+		return m.DisableRead
 	}
-	return 0
+	return false
 }
 
-func (m *LogIndex) GetTerm() uint64 {
+func (m *ShardGate) GetDisableWrite() bool {
 	if m != nil {
-		return m.Term
+		return m.DisableWrite
 	}
-	return 0
+	return false
 }
 
-// ShardMetadata is the metadata of the shard consistent with the current table
-// shard data
+func (m *ShardGate) GetRedirect() string {
+	if m != nil {
+		return m.Redirect
+	}
+	return ""
+}
+
+func (m *ShardGate) GetPermanent() bool {
+	if m != nil {
+		return m.Permanent
+	}
+	return false
+}
+
+// AppLease is an exclusive application level lease of the shard, it's used by
+// the applications to build the single writer protocols. The token is increased
+// every time the lease is granted to a new holder, the writes tagged with a
+// stale token are rejected.
+type AppLease struct {
+	// Holder the holder of the lease, empty means the lease is not held
+	Holder string `protobuf:"bytes,1,opt,name=holder,proto3" json:"holder,omitempty"`
+	// Token the fencing token of the lease
+	Token uint64 `protobuf:"varint,2,opt,name=token,proto3" json:"token,omitempty"`
+	// ExpireAt the expiration time in unix nanoseconds decided by the leader's
+	// clock
+	ExpireAt             int64    `protobuf:"varint,3,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppLease) Reset()         { *m = AppLease{} }
+func (m *AppLease) String() string { return proto.CompactTextString(m) }
+func (*AppLease) ProtoMessage()    {}
+func (*AppLease) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{29}
+}
+func (m *AppLease) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AppLease) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AppLease.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AppLease) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppLease.Merge(m, src)
+}
+func (m *AppLease) XXX_Size() int {
+	return m.Size()
+}
+func (m *AppLease) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppLease.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppLease proto.InternalMessageInfo
+
+func (m *AppLease) GetHolder() string {
+	if m != nil {
+		return m.Holder
+	}
+	return ""
+}
+
+func (m *AppLease) GetToken() uint64 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+func (m *AppLease) GetExpireAt() int64 {
+	if m != nil {
+		return m.ExpireAt
+	}
+	return 0
+}
+
+// LogIndex is used to indicate a position in the log.
+type LogIndex struct {
+	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Term                 uint64   `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogIndex) Reset()         { *m = LogIndex{} }
+func (m *LogIndex) String() string { return proto.CompactTextString(m) }
+func (*LogIndex) ProtoMessage()    {}
+func (*LogIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{30}
+}
+func (m *LogIndex) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogIndex.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LogIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogIndex.Merge(m, src)
+}
+func (m *LogIndex) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogIndex proto.InternalMessageInfo
+
+func (m *LogIndex) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *LogIndex) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+// ShardMetadata is the metadata of the shard consistent with the current table
+// shard data
 type ShardMetadata struct {
 	ShardID              uint64          `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
 	LogIndex             uint64          `protobuf:"varint,2,opt,name=logIndex,proto3" json:"logIndex,omitempty"`
@@ -2199,7 +2967,7 @@ func (m *ShardMetadata) Reset()         { *m = ShardMetadata{} }
 func (m *ShardMetadata) String() string { return proto.CompactTextString(m) }
 func (*ShardMetadata) ProtoMessage()    {}
 func (*ShardMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{23}
+	return fileDescriptor_77b4d575d5a68dda, []int{31}
 }
 func (m *ShardMetadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2256,7 +3024,22 @@ type ShardLocalState struct {
 	State ReplicaState `protobuf:"varint,3,opt,name=state,proto3,enum=metapb.ReplicaState" json:"state,omitempty"`
 	// RemoveData Whether or not the local Shard data needs to be deleted,
 	// which needs to be specified when the Shard status is set to Destroying
-	RemoveData           bool     `protobuf:"varint,4,opt,name=removeData,proto3" json:"removeData,omitempty"`
+	RemoveData bool `protobuf:"varint,4,opt,name=removeData,proto3" json:"removeData,omitempty"`
+	// ConfigChanges the recent membership changes applied to the shard, the
+	// oldest first
+	ConfigChanges []ConfigChangeRecord `protobuf:"bytes,5,rep,name=configChanges,proto3" json:"configChanges"`
+	// Purges the recent purges applied to the shard, the oldest first
+	Purges []PurgeMarker `protobuf:"bytes,6,rep,name=purges,proto3" json:"purges"`
+	// AppliedAdmins the recent admin requests applied to the shard, the oldest
+	// first
+	AppliedAdmins []AppliedAdminRecord `protobuf:"bytes,7,rep,name=appliedAdmins,proto3" json:"appliedAdmins"`
+	// Dictionaries the compression dictionaries of the shard, the oldest first.
+	// The last one compresses the new values, the old ones are kept to
+	// decompress the values written before.
+	Dictionaries []CompressionDictionary `protobuf:"bytes,8,rep,name=dictionaries,proto3" json:"dictionaries"`
+	// LastWriteIndex the index of the last applied write, it decides whether the
+	// replicated DeleteRange is skipped, so it's the same on all the replicas
+	LastWriteIndex       uint64   `protobuf:"varint,9,opt,name=lastWriteIndex,proto3" json:"lastWriteIndex,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2266,7 +3049,7 @@ func (m *ShardLocalState) Reset()         { *m = ShardLocalState{} }
 func (m *ShardLocalState) String() string { return proto.CompactTextString(m) }
 func (*ShardLocalState) ProtoMessage()    {}
 func (*ShardLocalState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{24}
+	return fileDescriptor_77b4d575d5a68dda, []int{32}
 }
 func (m *ShardLocalState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2323,36 +3106,68 @@ func (m *ShardLocalState) GetRemoveData() bool {
 	return false
 }
 
-// Store the host store metadata
-type Store struct {
-	ID                   uint64     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	RaftAddress          string     `protobuf:"bytes,2,opt,name=raftAddress,proto3" json:"raftAddress,omitempty"`
-	ClientAddress        string     `protobuf:"bytes,3,opt,name=clientAddress,proto3" json:"clientAddress,omitempty"`
-	Labels               []Label    `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels"`
-	State                StoreState `protobuf:"varint,5,opt,name=state,proto3,enum=metapb.StoreState" json:"state,omitempty"`
-	StartTime            int64      `protobuf:"varint,6,opt,name=startTime,proto3" json:"startTime,omitempty"`
-	LastHeartbeatTime    int64      `protobuf:"varint,7,opt,name=lastHeartbeatTime,proto3" json:"lastHeartbeatTime,omitempty"`
-	Version              string     `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
-	CommitID             string     `protobuf:"bytes,9,opt,name=commitID,proto3" json:"commitID,omitempty"`
-	DeployPath           string     `protobuf:"bytes,10,opt,name=deployPath,proto3" json:"deployPath,omitempty"`
-	Destroyed            bool       `protobuf:"varint,11,opt,name=destroyed,proto3" json:"destroyed,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *ShardLocalState) GetConfigChanges() []ConfigChangeRecord {
+	if m != nil {
+		return m.ConfigChanges
+	}
+	return nil
 }
 
-func (m *Store) Reset()         { *m = Store{} }
-func (m *Store) String() string { return proto.CompactTextString(m) }
-func (*Store) ProtoMessage()    {}
-func (*Store) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{25}
+func (m *ShardLocalState) GetPurges() []PurgeMarker {
+	if m != nil {
+		return m.Purges
+	}
+	return nil
 }
-func (m *Store) XXX_Unmarshal(b []byte) error {
+
+func (m *ShardLocalState) GetAppliedAdmins() []AppliedAdminRecord {
+	if m != nil {
+		return m.AppliedAdmins
+	}
+	return nil
+}
+
+func (m *ShardLocalState) GetDictionaries() []CompressionDictionary {
+	if m != nil {
+		return m.Dictionaries
+	}
+	return nil
+}
+
+func (m *ShardLocalState) GetLastWriteIndex() uint64 {
+	if m != nil {
+		return m.LastWriteIndex
+	}
+	return 0
+}
+
+// ConfigChangeRecord a membership change applied to the shard
+type ConfigChangeRecord struct {
+	// Epoch the shard epoch after the change
+	Epoch      ShardEpoch       `protobuf:"bytes,1,opt,name=epoch,proto3" json:"epoch"`
+	ChangeType ConfigChangeType `protobuf:"varint,2,opt,name=changeType,proto3,enum=metapb.ConfigChangeType" json:"changeType,omitempty"`
+	Replica    Replica          `protobuf:"bytes,3,opt,name=replica,proto3" json:"replica"`
+	// Index the raft log index of the change
+	Index uint64 `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	// Initiator the operator initiating the change, empty if unknown
+	Initiator            string   `protobuf:"bytes,5,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigChangeRecord) Reset()         { *m = ConfigChangeRecord{} }
+func (m *ConfigChangeRecord) String() string { return proto.CompactTextString(m) }
+func (*ConfigChangeRecord) ProtoMessage()    {}
+func (*ConfigChangeRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{33}
+}
+func (m *ConfigChangeRecord) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Store) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ConfigChangeRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Store.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ConfigChangeRecord.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2362,115 +3177,156 @@ func (m *Store) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Store) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Store.Merge(m, src)
+func (m *ConfigChangeRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigChangeRecord.Merge(m, src)
 }
-func (m *Store) XXX_Size() int {
+func (m *ConfigChangeRecord) XXX_Size() int {
 	return m.Size()
 }
-func (m *Store) XXX_DiscardUnknown() {
-	xxx_messageInfo_Store.DiscardUnknown(m)
+func (m *ConfigChangeRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigChangeRecord.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Store proto.InternalMessageInfo
+var xxx_messageInfo_ConfigChangeRecord proto.InternalMessageInfo
 
-func (m *Store) GetID() uint64 {
+func (m *ConfigChangeRecord) GetEpoch() ShardEpoch {
 	if m != nil {
-		return m.ID
+		return m.Epoch
 	}
-	return 0
+	return ShardEpoch{}
 }
 
-func (m *Store) GetRaftAddress() string {
+func (m *ConfigChangeRecord) GetChangeType() ConfigChangeType {
 	if m != nil {
-		return m.RaftAddress
+		return m.ChangeType
 	}
-	return ""
+	return ConfigChangeType_AddNode
 }
 
-func (m *Store) GetClientAddress() string {
+func (m *ConfigChangeRecord) GetReplica() Replica {
 	if m != nil {
-		return m.ClientAddress
+		return m.Replica
 	}
-	return ""
+	return Replica{}
 }
 
-func (m *Store) GetLabels() []Label {
+func (m *ConfigChangeRecord) GetIndex() uint64 {
 	if m != nil {
-		return m.Labels
+		return m.Index
 	}
-	return nil
+	return 0
 }
 
-func (m *Store) GetState() StoreState {
+func (m *ConfigChangeRecord) GetInitiator() string {
 	if m != nil {
-		return m.State
+		return m.Initiator
 	}
-	return StoreState_Up
+	return ""
 }
 
-func (m *Store) GetStartTime() int64 {
-	if m != nil {
-		return m.StartTime
-	}
-	return 0
+// PurgeMarker proves the keys are deleted from all the replicas of the shard by
+// the purge applied at the index
+type PurgeMarker struct {
+	// Index the raft log index of the applied purge
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Start, End the purged key range [start, end), empty if only the keys are
+	// purged
+	Start []byte `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   []byte `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	// Keys the purged keys
+	Keys                 [][]byte `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Store) GetLastHeartbeatTime() int64 {
-	if m != nil {
-		return m.LastHeartbeatTime
+func (m *PurgeMarker) Reset()         { *m = PurgeMarker{} }
+func (m *PurgeMarker) String() string { return proto.CompactTextString(m) }
+func (*PurgeMarker) ProtoMessage()    {}
+func (*PurgeMarker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{34}
+}
+func (m *PurgeMarker) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PurgeMarker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PurgeMarker.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return 0
+}
+func (m *PurgeMarker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeMarker.Merge(m, src)
+}
+func (m *PurgeMarker) XXX_Size() int {
+	return m.Size()
+}
+func (m *PurgeMarker) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeMarker.DiscardUnknown(m)
 }
 
-func (m *Store) GetVersion() string {
+var xxx_messageInfo_PurgeMarker proto.InternalMessageInfo
+
+func (m *PurgeMarker) GetIndex() uint64 {
 	if m != nil {
-		return m.Version
+		return m.Index
 	}
-	return ""
+	return 0
 }
 
-func (m *Store) GetCommitID() string {
+func (m *PurgeMarker) GetStart() []byte {
 	if m != nil {
-		return m.CommitID
+		return m.Start
 	}
-	return ""
+	return nil
 }
 
-func (m *Store) GetDeployPath() string {
+func (m *PurgeMarker) GetEnd() []byte {
 	if m != nil {
-		return m.DeployPath
+		return m.End
 	}
-	return ""
+	return nil
 }
 
-func (m *Store) GetDestroyed() bool {
+func (m *PurgeMarker) GetKeys() [][]byte {
 	if m != nil {
-		return m.Destroyed
+		return m.Keys
 	}
-	return false
+	return nil
 }
 
-// ShardsPool shards pool
-type ShardsPool struct {
-	Pools                map[uint64]*ShardPool `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+// AppliedAdminRecord an admin request applied to the shard, the admin request
+// re-proposed with the same id and epoch is not applied again
+type AppliedAdminRecord struct {
+	// RequestID the id of the admin request
+	RequestID []byte `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	// Epoch the shard epoch the request was proposed with
+	Epoch ShardEpoch `protobuf:"bytes,2,opt,name=epoch,proto3" json:"epoch"`
+	// Index the raft log index the request was applied at
+	Index                uint64   `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ShardsPool) Reset()         { *m = ShardsPool{} }
-func (m *ShardsPool) String() string { return proto.CompactTextString(m) }
-func (*ShardsPool) ProtoMessage()    {}
-func (*ShardsPool) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{26}
+func (m *AppliedAdminRecord) Reset()         { *m = AppliedAdminRecord{} }
+func (m *AppliedAdminRecord) String() string { return proto.CompactTextString(m) }
+func (*AppliedAdminRecord) ProtoMessage()    {}
+func (*AppliedAdminRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{35}
 }
-func (m *ShardsPool) XXX_Unmarshal(b []byte) error {
+func (m *AppliedAdminRecord) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ShardsPool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AppliedAdminRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ShardsPool.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AppliedAdminRecord.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -2480,30 +3336,288 @@ func (m *ShardsPool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *ShardsPool) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ShardsPool.Merge(m, src)
+func (m *AppliedAdminRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppliedAdminRecord.Merge(m, src)
 }
-func (m *ShardsPool) XXX_Size() int {
+func (m *AppliedAdminRecord) XXX_Size() int {
 	return m.Size()
 }
-func (m *ShardsPool) XXX_DiscardUnknown() {
-	xxx_messageInfo_ShardsPool.DiscardUnknown(m)
+func (m *AppliedAdminRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppliedAdminRecord.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ShardsPool proto.InternalMessageInfo
+var xxx_messageInfo_AppliedAdminRecord proto.InternalMessageInfo
 
-func (m *ShardsPool) GetPools() map[uint64]*ShardPool {
+func (m *AppliedAdminRecord) GetRequestID() []byte {
 	if m != nil {
-		return m.Pools
+		return m.RequestID
 	}
 	return nil
 }
 
-// ShardPool shard pool
-type ShardPool struct {
-	Capacity             uint64            `protobuf:"varint,1,opt,name=capacity,proto3" json:"capacity,omitempty"`
-	RangePrefix          []byte            `protobuf:"bytes,2,opt,name=rangePrefix,proto3" json:"rangePrefix,omitempty"`
-	AllocatedShards      []*AllocatedShard `protobuf:"bytes,3,rep,name=allocatedShards,proto3" json:"allocatedShards,omitempty"`
+func (m *AppliedAdminRecord) GetEpoch() ShardEpoch {
+	if m != nil {
+		return m.Epoch
+	}
+	return ShardEpoch{}
+}
+
+func (m *AppliedAdminRecord) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+// CompressionDictionary a version of the dictionary compressing the values of
+// the shard, it's replicated by the raft log
+type CompressionDictionary struct {
+	// Version the version of the dictionary, starts from 1
+	Version uint64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Data the zstd dictionary
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompressionDictionary) Reset()         { *m = CompressionDictionary{} }
+func (m *CompressionDictionary) String() string { return proto.CompactTextString(m) }
+func (*CompressionDictionary) ProtoMessage()    {}
+func (*CompressionDictionary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{36}
+}
+func (m *CompressionDictionary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompressionDictionary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompressionDictionary.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompressionDictionary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompressionDictionary.Merge(m, src)
+}
+func (m *CompressionDictionary) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompressionDictionary) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompressionDictionary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompressionDictionary proto.InternalMessageInfo
+
+func (m *CompressionDictionary) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *CompressionDictionary) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// Store the host store metadata
+type Store struct {
+	ID                uint64     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RaftAddress       string     `protobuf:"bytes,2,opt,name=raftAddress,proto3" json:"raftAddress,omitempty"`
+	ClientAddress     string     `protobuf:"bytes,3,opt,name=clientAddress,proto3" json:"clientAddress,omitempty"`
+	Labels            []Label    `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels"`
+	State             StoreState `protobuf:"varint,5,opt,name=state,proto3,enum=metapb.StoreState" json:"state,omitempty"`
+	StartTime         int64      `protobuf:"varint,6,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	LastHeartbeatTime int64      `protobuf:"varint,7,opt,name=lastHeartbeatTime,proto3" json:"lastHeartbeatTime,omitempty"`
+	Version           string     `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	CommitID          string     `protobuf:"bytes,9,opt,name=commitID,proto3" json:"commitID,omitempty"`
+	DeployPath        string     `protobuf:"bytes,10,opt,name=deployPath,proto3" json:"deployPath,omitempty"`
+	Destroyed         bool       `protobuf:"varint,11,opt,name=destroyed,proto3" json:"destroyed,omitempty"`
+	// Draining the store is draining, no leader or replica is scheduled to it
+	Draining             bool     `protobuf:"varint,12,opt,name=draining,proto3" json:"draining,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Store) Reset()         { *m = Store{} }
+func (m *Store) String() string { return proto.CompactTextString(m) }
+func (*Store) ProtoMessage()    {}
+func (*Store) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{37}
+}
+func (m *Store) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Store) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Store.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Store) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Store.Merge(m, src)
+}
+func (m *Store) XXX_Size() int {
+	return m.Size()
+}
+func (m *Store) XXX_DiscardUnknown() {
+	xxx_messageInfo_Store.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Store proto.InternalMessageInfo
+
+func (m *Store) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *Store) GetRaftAddress() string {
+	if m != nil {
+		return m.RaftAddress
+	}
+	return ""
+}
+
+func (m *Store) GetClientAddress() string {
+	if m != nil {
+		return m.ClientAddress
+	}
+	return ""
+}
+
+func (m *Store) GetLabels() []Label {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Store) GetState() StoreState {
+	if m != nil {
+		return m.State
+	}
+	return StoreState_Up
+}
+
+func (m *Store) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *Store) GetLastHeartbeatTime() int64 {
+	if m != nil {
+		return m.LastHeartbeatTime
+	}
+	return 0
+}
+
+func (m *Store) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Store) GetCommitID() string {
+	if m != nil {
+		return m.CommitID
+	}
+	return ""
+}
+
+func (m *Store) GetDeployPath() string {
+	if m != nil {
+		return m.DeployPath
+	}
+	return ""
+}
+
+func (m *Store) GetDestroyed() bool {
+	if m != nil {
+		return m.Destroyed
+	}
+	return false
+}
+
+func (m *Store) GetDraining() bool {
+	if m != nil {
+		return m.Draining
+	}
+	return false
+}
+
+// ShardsPool shards pool
+type ShardsPool struct {
+	Pools                map[uint64]*ShardPool `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ShardsPool) Reset()         { *m = ShardsPool{} }
+func (m *ShardsPool) String() string { return proto.CompactTextString(m) }
+func (*ShardsPool) ProtoMessage()    {}
+func (*ShardsPool) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{38}
+}
+func (m *ShardsPool) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ShardsPool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ShardsPool.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ShardsPool) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShardsPool.Merge(m, src)
+}
+func (m *ShardsPool) XXX_Size() int {
+	return m.Size()
+}
+func (m *ShardsPool) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShardsPool.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShardsPool proto.InternalMessageInfo
+
+func (m *ShardsPool) GetPools() map[uint64]*ShardPool {
+	if m != nil {
+		return m.Pools
+	}
+	return nil
+}
+
+// ShardPool shard pool
+type ShardPool struct {
+	Capacity             uint64            `protobuf:"varint,1,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	RangePrefix          []byte            `protobuf:"bytes,2,opt,name=rangePrefix,proto3" json:"rangePrefix,omitempty"`
+	AllocatedShards      []*AllocatedShard `protobuf:"bytes,3,rep,name=allocatedShards,proto3" json:"allocatedShards,omitempty"`
 	Seq                  uint64            `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
 	AllocatedOffset      uint64            `protobuf:"varint,5,opt,name=allocatedOffset,proto3" json:"allocatedOffset,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
@@ -2515,7 +3629,7 @@ func (m *ShardPool) Reset()         { *m = ShardPool{} }
 func (m *ShardPool) String() string { return proto.CompactTextString(m) }
 func (*ShardPool) ProtoMessage()    {}
 func (*ShardPool) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{27}
+	return fileDescriptor_77b4d575d5a68dda, []int{39}
 }
 func (m *ShardPool) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2593,7 +3707,7 @@ func (m *AllocatedShard) Reset()         { *m = AllocatedShard{} }
 func (m *AllocatedShard) String() string { return proto.CompactTextString(m) }
 func (*AllocatedShard) ProtoMessage()    {}
 func (*AllocatedShard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{28}
+	return fileDescriptor_77b4d575d5a68dda, []int{40}
 }
 func (m *AllocatedShard) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2657,7 +3771,7 @@ func (m *ShardsPoolCmd) Reset()         { *m = ShardsPoolCmd{} }
 func (m *ShardsPoolCmd) String() string { return proto.CompactTextString(m) }
 func (*ShardsPoolCmd) ProtoMessage()    {}
 func (*ShardsPoolCmd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{29}
+	return fileDescriptor_77b4d575d5a68dda, []int{41}
 }
 func (m *ShardsPoolCmd) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2718,7 +3832,7 @@ func (m *ShardsPoolCreateCmd) Reset()         { *m = ShardsPoolCreateCmd{} }
 func (m *ShardsPoolCreateCmd) String() string { return proto.CompactTextString(m) }
 func (*ShardsPoolCreateCmd) ProtoMessage()    {}
 func (*ShardsPoolCreateCmd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{30}
+	return fileDescriptor_77b4d575d5a68dda, []int{42}
 }
 func (m *ShardsPoolCreateCmd) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2760,7 +3874,7 @@ func (m *ShardsPoolAllocCmd) Reset()         { *m = ShardsPoolAllocCmd{} }
 func (m *ShardsPoolAllocCmd) String() string { return proto.CompactTextString(m) }
 func (*ShardsPoolAllocCmd) ProtoMessage()    {}
 func (*ShardsPoolAllocCmd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{31}
+	return fileDescriptor_77b4d575d5a68dda, []int{43}
 }
 func (m *ShardsPoolAllocCmd) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2805,18 +3919,22 @@ func (m *ShardsPoolAllocCmd) GetPurpose() []byte {
 
 // SnapshotInfo contains additional information associated with a snapshot.
 type SnapshotInfo struct {
-	Extra                uint64   `protobuf:"varint,1,opt,name=extra,proto3" json:"extra,omitempty"`
-	Dummy                bool     `protobuf:"varint,2,opt,name=dummy,proto3" json:"dummy,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Extra uint64 `protobuf:"varint,1,opt,name=extra,proto3" json:"extra,omitempty"`
+	Dummy bool   `protobuf:"varint,2,opt,name=dummy,proto3" json:"dummy,omitempty"`
+	// witness snapshot carries the shard metadata only, it has no on disk
+	// snapshot image
+	Witness              bool          `protobuf:"varint,3,opt,name=witness,proto3" json:"witness,omitempty"`
+	Metadata             ShardMetadata `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *SnapshotInfo) Reset()         { *m = SnapshotInfo{} }
 func (m *SnapshotInfo) String() string { return proto.CompactTextString(m) }
 func (*SnapshotInfo) ProtoMessage()    {}
 func (*SnapshotInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{32}
+	return fileDescriptor_77b4d575d5a68dda, []int{44}
 }
 func (m *SnapshotInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2859,27 +3977,100 @@ func (m *SnapshotInfo) GetDummy() bool {
 	return false
 }
 
-// EpochLease an Epoch-based Lease. A Shard has one and only one Replica that
-// can hold a Lease, and all read and write requests to the Shard need to be
-// initiated by the node holding the Lease. In most cases, the Replica holding
-// the Lease is the Raft Leader, except for a short period of inconsistency when
-// the Raft Leader switches. When Prophet finds that the Lease's holding Replica
-// is inconsistent with Raft's Leader Replica, it will initiate the Lease switch.
-type EpochLease struct {
-	// Epoch lease epoch, each time a Lease switch occurs, the epoch is increased
-	Epoch uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
-	// ReplicaID lease holding replica
-	ReplicaID            uint64   `protobuf:"varint,2,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+func (m *SnapshotInfo) GetWitness() bool {
+	if m != nil {
+		return m.Witness
+	}
+	return false
+}
+
+func (m *SnapshotInfo) GetMetadata() ShardMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return ShardMetadata{}
+}
+
+// SnapshotApplyingState is the pointer to the snapshot directory being applied
+// into the DataStorage. It's saved before any shard data is changed, and
+// removed together with the update of the applied index once the snapshot is
+// fully applied.
+type SnapshotApplyingState struct {
+	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Dir                  string   `protobuf:"bytes,2,opt,name=dir,proto3" json:"dir,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EpochLease) Reset()         { *m = EpochLease{} }
+func (m *SnapshotApplyingState) Reset()         { *m = SnapshotApplyingState{} }
+func (m *SnapshotApplyingState) String() string { return proto.CompactTextString(m) }
+func (*SnapshotApplyingState) ProtoMessage()    {}
+func (*SnapshotApplyingState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77b4d575d5a68dda, []int{45}
+}
+func (m *SnapshotApplyingState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotApplyingState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotApplyingState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SnapshotApplyingState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotApplyingState.Merge(m, src)
+}
+func (m *SnapshotApplyingState) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotApplyingState) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotApplyingState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotApplyingState proto.InternalMessageInfo
+
+func (m *SnapshotApplyingState) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SnapshotApplyingState) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+// EpochLease an Epoch-based Lease. A Shard has one and only one Replica that
+// can hold a Lease, and all read and write requests to the Shard need to be
+// initiated by the node holding the Lease. In most cases, the Replica holding
+// the Lease is the Raft Leader, except for a short period of inconsistency when
+// the Raft Leader switches. When Prophet finds that the Lease's holding Replica
+// is inconsistent with Raft's Leader Replica, it will initiate the Lease switch.
+type EpochLease struct {
+	// Epoch lease epoch, each time a Lease switch occurs, the epoch is increased
+	Epoch uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// ReplicaID lease holding replica
+	ReplicaID            uint64   `protobuf:"varint,2,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EpochLease) Reset()         { *m = EpochLease{} }
 func (m *EpochLease) String() string { return proto.CompactTextString(m) }
 func (*EpochLease) ProtoMessage()    {}
 func (*EpochLease) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77b4d575d5a68dda, []int{33}
+	return fileDescriptor_77b4d575d5a68dda, []int{46}
 }
 func (m *EpochLease) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2928,6 +4119,7 @@ func init() {
 	proto.RegisterEnum("metapb.ShardState", ShardState_name, ShardState_value)
 	proto.RegisterEnum("metapb.ConfigChangeType", ConfigChangeType_name, ConfigChangeType_value)
 	proto.RegisterEnum("metapb.ReplicaRole", ReplicaRole_name, ReplicaRole_value)
+	proto.RegisterEnum("metapb.ShardOperation", ShardOperation_name, ShardOperation_value)
 	proto.RegisterEnum("metapb.CheckPolicy", CheckPolicy_name, CheckPolicy_value)
 	proto.RegisterEnum("metapb.OperatorStatus", OperatorStatus_name, OperatorStatus_value)
 	proto.RegisterEnum("metapb.JobType", JobType_name, JobType_value)
@@ -2938,9 +4130,12 @@ func init() {
 	proto.RegisterType((*Replica)(nil), "metapb.Replica")
 	proto.RegisterType((*ReplicaStats)(nil), "metapb.ReplicaStats")
 	proto.RegisterType((*Label)(nil), "metapb.Label")
+	proto.RegisterType((*BlockingReason)(nil), "metapb.BlockingReason")
 	proto.RegisterType((*ShardStats)(nil), "metapb.ShardStats")
 	proto.RegisterType((*StoreStats)(nil), "metapb.StoreStats")
 	proto.RegisterType((*RecordPair)(nil), "metapb.RecordPair")
+	proto.RegisterType((*PeerStoreLatency)(nil), "metapb.PeerStoreLatency")
+	proto.RegisterType((*InconsistentShard)(nil), "metapb.InconsistentShard")
 	proto.RegisterType((*Member)(nil), "metapb.Member")
 	proto.RegisterType((*ProphetCluster)(nil), "metapb.ProphetCluster")
 	proto.RegisterType((*TimeInterval)(nil), "metapb.TimeInterval")
@@ -2953,14 +4148,23 @@ func init() {
 	proto.RegisterType((*ShardExtra)(nil), "metapb.ShardExtra")
 	proto.RegisterMapType((map[string]string)(nil), "metapb.ShardExtra.LabelsEntry")
 	proto.RegisterType((*ScheduleGroupRule)(nil), "metapb.ScheduleGroupRule")
+	proto.RegisterType((*ClusterSetting)(nil), "metapb.ClusterSetting")
 	proto.RegisterType((*RaftMessageBatch)(nil), "metapb.RaftMessageBatch")
 	proto.RegisterType((*RaftMessage)(nil), "metapb.RaftMessage")
 	proto.RegisterType((*SnapshotChunk)(nil), "metapb.SnapshotChunk")
+	proto.RegisterType((*SnapshotResume)(nil), "metapb.SnapshotResume")
+	proto.RegisterType((*TransportHandshake)(nil), "metapb.TransportHandshake")
 	proto.RegisterType((*StoreIdent)(nil), "metapb.StoreIdent")
 	proto.RegisterType((*Shard)(nil), "metapb.Shard")
+	proto.RegisterType((*ShardGate)(nil), "metapb.ShardGate")
+	proto.RegisterType((*AppLease)(nil), "metapb.AppLease")
 	proto.RegisterType((*LogIndex)(nil), "metapb.LogIndex")
 	proto.RegisterType((*ShardMetadata)(nil), "metapb.ShardMetadata")
 	proto.RegisterType((*ShardLocalState)(nil), "metapb.ShardLocalState")
+	proto.RegisterType((*ConfigChangeRecord)(nil), "metapb.ConfigChangeRecord")
+	proto.RegisterType((*PurgeMarker)(nil), "metapb.PurgeMarker")
+	proto.RegisterType((*AppliedAdminRecord)(nil), "metapb.AppliedAdminRecord")
+	proto.RegisterType((*CompressionDictionary)(nil), "metapb.CompressionDictionary")
 	proto.RegisterType((*Store)(nil), "metapb.Store")
 	proto.RegisterType((*ShardsPool)(nil), "metapb.ShardsPool")
 	proto.RegisterMapType((map[uint64]*ShardPool)(nil), "metapb.ShardsPool.PoolsEntry")
@@ -2970,165 +4174,214 @@ func init() {
 	proto.RegisterType((*ShardsPoolCreateCmd)(nil), "metapb.ShardsPoolCreateCmd")
 	proto.RegisterType((*ShardsPoolAllocCmd)(nil), "metapb.ShardsPoolAllocCmd")
 	proto.RegisterType((*SnapshotInfo)(nil), "metapb.SnapshotInfo")
+	proto.RegisterType((*SnapshotApplyingState)(nil), "metapb.SnapshotApplyingState")
 	proto.RegisterType((*EpochLease)(nil), "metapb.EpochLease")
 }
 
 func init() { proto.RegisterFile("metapb.proto", fileDescriptor_77b4d575d5a68dda) }
 
 var fileDescriptor_77b4d575d5a68dda = []byte{
-	// 2431 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x59, 0x4f, 0x73, 0x23, 0x47,
-	0x15, 0xf7, 0x8c, 0x64, 0x5b, 0x7a, 0xb2, 0xec, 0x71, 0x67, 0x09, 0xc2, 0x84, 0x8d, 0x6b, 0x08,
-	0x89, 0x23, 0x12, 0x3b, 0xec, 0x6e, 0x52, 0x49, 0xa0, 0xa8, 0xc8, 0x92, 0x49, 0x94, 0xf5, 0x7a,
-	0x5d, 0xa3, 0x75, 0x80, 0x63, 0x4b, 0xd3, 0x92, 0xa7, 0x76, 0x66, 0x7a, 0x32, 0xd3, 0x72, 0x56,
-	0x54, 0x51, 0xc5, 0x99, 0x03, 0xdf, 0x82, 0x1b, 0x27, 0x8e, 0x9c, 0xb8, 0x50, 0xe4, 0x46, 0xce,
-	0x1c, 0x52, 0xb0, 0x5f, 0x81, 0x3b, 0x45, 0xf5, 0xeb, 0x9e, 0x99, 0x1e, 0xc9, 0xf6, 0x2e, 0x17,
-	0x6b, 0xde, 0xeb, 0xf7, 0xfa, 0xcf, 0xfb, 0xfb, 0xeb, 0x36, 0x6c, 0x45, 0x4c, 0xd0, 0x64, 0x7c,
-	0x98, 0xa4, 0x5c, 0x70, 0xb2, 0xa1, 0xa8, 0xbd, 0x77, 0x67, 0x81, 0xb8, 0x9c, 0x8f, 0x0f, 0x27,
-	0x3c, 0x3a, 0x9a, 0xf1, 0x19, 0x3f, 0xc2, 0xe1, 0xf1, 0x7c, 0x8a, 0x14, 0x12, 0xf8, 0xa5, 0xd4,
-	0xf6, 0xde, 0x9e, 0xf1, 0x43, 0x26, 0x26, 0xfe, 0x61, 0xc0, 0x8f, 0xe4, 0xef, 0x51, 0x4a, 0xa7,
-	0xe2, 0xe8, 0xea, 0x3e, 0xfe, 0x26, 0x63, 0xfc, 0x51, 0xa2, 0xee, 0xe7, 0x00, 0xa3, 0x4b, 0x9a,
-	0xfa, 0x27, 0x09, 0x9f, 0x5c, 0x92, 0xd7, 0xa0, 0x39, 0xe1, 0xf1, 0x34, 0x98, 0x7d, 0xc1, 0xd2,
-	0x8e, 0xb5, 0x6f, 0x1d, 0xd4, 0xbd, 0x92, 0x41, 0xee, 0x02, 0xcc, 0x58, 0xcc, 0x52, 0x2a, 0x02,
-	0x1e, 0x77, 0x6c, 0x1c, 0x36, 0x38, 0xee, 0xef, 0x2d, 0xd8, 0xf4, 0x58, 0x12, 0x06, 0x13, 0x4a,
-	0x5e, 0x05, 0x3b, 0xf0, 0xd5, 0x14, 0xc7, 0x1b, 0xcf, 0xbf, 0x7d, 0xdd, 0x1e, 0x0e, 0x3c, 0x3b,
-	0xf0, 0x49, 0x07, 0x36, 0x33, 0xc1, 0x53, 0x36, 0x1c, 0xe8, 0x09, 0x72, 0x92, 0xbc, 0x05, 0xf5,
-	0x94, 0x87, 0xac, 0x53, 0xdb, 0xb7, 0x0e, 0xb6, 0xef, 0xbd, 0x72, 0xa8, 0x0d, 0xa1, 0x27, 0xf4,
-	0x78, 0xc8, 0x3c, 0x14, 0x20, 0x6f, 0x40, 0x3b, 0x88, 0x03, 0x11, 0xd0, 0xf0, 0x11, 0x8b, 0xc6,
-	0x2c, 0xed, 0xd4, 0xf7, 0xad, 0x83, 0x86, 0x57, 0x65, 0xba, 0x14, 0xb6, 0xb4, 0xea, 0x48, 0x50,
-	0x91, 0x91, 0x23, 0xd8, 0x4c, 0x15, 0x8d, 0xbb, 0x6a, 0xdd, 0xdb, 0x59, 0x5a, 0xe1, 0xb8, 0xfe,
-	0xf5, 0xb7, 0xaf, 0xaf, 0x79, 0xb9, 0x14, 0xd9, 0x87, 0x96, 0xcf, 0xbf, 0x8a, 0x47, 0x6c, 0xc2,
-	0x63, 0x3f, 0xd3, 0xbb, 0x35, 0x59, 0xee, 0x11, 0xac, 0x9f, 0xd2, 0x31, 0x0b, 0x89, 0x03, 0xb5,
-	0xa7, 0x6c, 0x81, 0xf3, 0x36, 0x3d, 0xf9, 0x49, 0xee, 0xc0, 0xfa, 0x15, 0x0d, 0xe7, 0x0c, 0xd5,
-	0x9a, 0x9e, 0x22, 0xdc, 0x3f, 0xd9, 0xda, 0xda, 0x6a, 0x4b, 0xd2, 0x16, 0x92, 0x1a, 0x0e, 0xb4,
-	0xad, 0x73, 0x92, 0xb8, 0xb0, 0xf5, 0x55, 0x1a, 0x08, 0xc1, 0xe2, 0xe3, 0x85, 0x60, 0xf9, 0xe2,
-	0x15, 0x9e, 0xdc, 0x9f, 0xa6, 0x1f, 0xb2, 0x45, 0x86, 0x66, 0xab, 0x7b, 0x26, 0x4b, 0x7a, 0x33,
-	0x65, 0xd4, 0x57, 0x53, 0xd4, 0x95, 0x37, 0x0b, 0x06, 0xd9, 0x83, 0x86, 0x24, 0x50, 0x79, 0x1d,
-	0x07, 0x0b, 0x9a, 0x1c, 0xc0, 0x0e, 0x4d, 0x92, 0x94, 0x3f, 0x0b, 0x22, 0x2a, 0xd8, 0x28, 0xf8,
-	0x0d, 0xeb, 0x6c, 0xa0, 0xc8, 0x32, 0x7b, 0x49, 0x12, 0x27, 0xdb, 0x5c, 0x91, 0xc4, 0x39, 0xdf,
-	0x83, 0x46, 0x10, 0x0b, 0x96, 0x5e, 0xd1, 0xb0, 0xd3, 0x40, 0x0f, 0xdc, 0xc9, 0x3d, 0xf0, 0x24,
-	0x88, 0xd8, 0x50, 0x8f, 0x79, 0x85, 0x94, 0xfb, 0xd7, 0x75, 0x80, 0x91, 0x8c, 0x8e, 0xd2, 0x5c,
-	0x3a, 0x74, 0xac, 0x6a, 0xe8, 0xbc, 0x06, 0xcd, 0x4c, 0xd0, 0x54, 0xc8, 0x79, 0xb4, 0xad, 0x4a,
-	0x46, 0x65, 0xe1, 0xda, 0xcb, 0x2c, 0x2c, 0x4d, 0x33, 0xa1, 0x09, 0x9d, 0x04, 0x62, 0xa1, 0xed,
-	0x56, 0xd0, 0x72, 0x2d, 0x7a, 0x45, 0x83, 0x90, 0x8e, 0x43, 0xa6, 0xed, 0x56, 0x32, 0xa4, 0xe6,
-	0x3c, 0x63, 0xbe, 0x61, 0xb1, 0x82, 0x26, 0xaf, 0xc2, 0x46, 0x90, 0x1d, 0xcf, 0xb3, 0x05, 0x5a,
-	0xa8, 0xe1, 0x69, 0x4a, 0xa6, 0x15, 0xfa, 0xbd, 0xcf, 0xe7, 0xb1, 0x40, 0xd3, 0xd4, 0x3d, 0x83,
-	0x43, 0xba, 0xe0, 0x64, 0x2c, 0xf6, 0x83, 0x78, 0x36, 0x8a, 0x69, 0xa2, 0xa4, 0x9a, 0x28, 0xb5,
-	0xc2, 0x27, 0x87, 0x40, 0x52, 0x36, 0x61, 0xc1, 0x55, 0x45, 0x1a, 0x50, 0xfa, 0x9a, 0x11, 0xf2,
-	0x0e, 0xec, 0xd2, 0x24, 0x09, 0x17, 0x15, 0xf1, 0x16, 0x8a, 0xaf, 0x0e, 0xac, 0x84, 0xe5, 0xd6,
-	0x35, 0x61, 0x59, 0x09, 0xba, 0xf6, 0x72, 0xd0, 0x2d, 0x05, 0xed, 0xf6, 0x6a, 0xd0, 0x9a, 0x61,
-	0xb9, 0xb3, 0x14, 0x96, 0x1f, 0x40, 0x73, 0x92, 0xcc, 0x2f, 0x32, 0x3a, 0x63, 0x59, 0xc7, 0xd9,
-	0xaf, 0x1d, 0xb4, 0xee, 0x91, 0x32, 0x8b, 0x27, 0x3c, 0xf5, 0xcf, 0x69, 0x90, 0xea, 0x44, 0x2e,
-	0x45, 0xc9, 0xc7, 0xd0, 0x92, 0x73, 0x0c, 0x1f, 0x7b, 0x54, 0xee, 0x6a, 0xf7, 0x05, 0x9a, 0xa6,
-	0x30, 0xf9, 0x99, 0x3a, 0x33, 0xcb, 0x95, 0xc9, 0x0b, 0x94, 0x2b, 0xd2, 0xee, 0x03, 0x80, 0x52,
-	0xe2, 0x45, 0x75, 0xa2, 0x9e, 0xd7, 0x89, 0xcf, 0x60, 0x43, 0x55, 0xb1, 0x1b, 0xcb, 0x28, 0x81,
-	0x7a, 0x4c, 0xa3, 0xbc, 0xbc, 0xe0, 0xb7, 0xe4, 0x51, 0xdf, 0x4f, 0x31, 0xc6, 0x9b, 0x1e, 0x7e,
-	0xbb, 0x1e, 0x6c, 0x9f, 0xa7, 0x3c, 0xb9, 0x64, 0xa2, 0x1f, 0xce, 0x33, 0x71, 0xcb, 0x8c, 0x07,
-	0xb0, 0x13, 0xd1, 0x67, 0xba, 0x16, 0xaa, 0x38, 0x90, 0x93, 0xb7, 0xbd, 0x65, 0xb6, 0xfb, 0x01,
-	0x6c, 0x99, 0x79, 0x23, 0xcf, 0x80, 0xc9, 0xa6, 0xb3, 0x52, 0x11, 0xf2, 0xac, 0x2c, 0xf6, 0xf5,
-	0xb9, 0xe4, 0xa7, 0x1b, 0x42, 0xed, 0x73, 0x3e, 0x26, 0x3f, 0x84, 0xba, 0x58, 0x24, 0x0c, 0xa5,
-	0xb7, 0xcb, 0x2a, 0xfc, 0x39, 0x1f, 0x3f, 0x59, 0x24, 0xcc, 0xc3, 0x41, 0x99, 0xeb, 0x13, 0x1e,
-	0x0b, 0xa6, 0x77, 0xb1, 0xe5, 0xe5, 0x24, 0x79, 0x13, 0x57, 0x13, 0x79, 0x9f, 0x70, 0x0c, 0x7d,
-	0x59, 0x26, 0x98, 0xa7, 0x86, 0x5d, 0x06, 0xdb, 0x1e, 0x8b, 0xf8, 0x15, 0xc3, 0x82, 0x2b, 0x17,
-	0xde, 0x5f, 0x2a, 0xb7, 0xc5, 0xf1, 0x8b, 0xb2, 0xfb, 0x13, 0x19, 0x7b, 0x78, 0x52, 0x59, 0x72,
-	0x6b, 0x37, 0x37, 0x89, 0x42, 0xcc, 0x1d, 0xc0, 0x16, 0x2e, 0x70, 0xce, 0x79, 0x28, 0x17, 0x79,
-	0x00, 0xeb, 0x09, 0xe7, 0x61, 0xd6, 0xb1, 0x50, 0xbf, 0x93, 0xeb, 0x9b, 0x42, 0x8f, 0x98, 0xc8,
-	0x27, 0x52, 0xc2, 0xee, 0x14, 0x9c, 0x65, 0x01, 0x69, 0xd6, 0x59, 0xca, 0xe7, 0x49, 0x6e, 0x56,
-	0x24, 0x2a, 0xa5, 0xc9, 0x5e, 0x2a, 0x4d, 0xfb, 0xd0, 0x4a, 0x69, 0x3c, 0x63, 0xe7, 0x29, 0x9b,
-	0x06, 0xcf, 0xd0, 0x40, 0x5b, 0x9e, 0xc9, 0x72, 0xff, 0x63, 0x81, 0x33, 0x60, 0x99, 0x48, 0x39,
-	0x26, 0xb6, 0xa0, 0x62, 0x9e, 0xc9, 0x85, 0x82, 0xd8, 0x67, 0xcf, 0xf2, 0x85, 0x90, 0x20, 0xc7,
-	0x2b, 0xb6, 0x78, 0x33, 0x3f, 0xcb, 0xf2, 0x0c, 0xb9, 0x71, 0xb2, 0x93, 0x58, 0xa4, 0x8b, 0xd2,
-	0x38, 0xe4, 0xa0, 0xea, 0x2b, 0x52, 0x31, 0x86, 0xe9, 0x2d, 0x59, 0x03, 0x53, 0xf4, 0xd6, 0x80,
-	0x0a, 0xaa, 0x1b, 0xba, 0xc1, 0xd9, 0xfb, 0x29, 0xb4, 0x2b, 0x8b, 0x98, 0xa9, 0x54, 0xbf, 0x26,
-	0x95, 0x1a, 0x3a, 0x95, 0x3e, 0xb6, 0x3f, 0xb4, 0xdc, 0xbf, 0x59, 0x39, 0xc8, 0x79, 0x26, 0x52,
-	0x4a, 0x3e, 0x80, 0x8d, 0x50, 0xb6, 0xed, 0xdc, 0x47, 0x77, 0x2b, 0xdb, 0x42, 0x99, 0x43, 0xec,
-	0xeb, 0xfa, 0x3c, 0x5a, 0x9a, 0x0c, 0xc0, 0xf1, 0x97, 0x4e, 0x8e, 0x6b, 0x19, 0x5e, 0x5e, 0xb6,
-	0x8c, 0xb7, 0xa2, 0xb1, 0xf7, 0x11, 0xb4, 0x8c, 0xc9, 0x5f, 0x16, 0x3a, 0xe0, 0x39, 0x7e, 0x0b,
-	0xbb, 0xa3, 0xc9, 0x25, 0xf3, 0xe7, 0x21, 0xfb, 0x54, 0x06, 0x83, 0x37, 0x0f, 0xd9, 0x6d, 0x40,
-	0x0b, 0x23, 0xa6, 0x04, 0x5a, 0x9a, 0x2c, 0x6a, 0x47, 0xcd, 0xa8, 0x1d, 0x2e, 0x6c, 0xe1, 0xf0,
-	0xf1, 0x02, 0x37, 0x87, 0x1e, 0x68, 0x7a, 0x15, 0x9e, 0x3b, 0x04, 0xc7, 0xa3, 0x53, 0xf1, 0x88,
-	0x65, 0xb2, 0xaa, 0x1e, 0x53, 0x31, 0xb9, 0x24, 0xef, 0x43, 0x23, 0x52, 0x74, 0x6e, 0xcd, 0x12,
-	0xb8, 0x19, 0xb2, 0x3a, 0x6b, 0x72, 0x51, 0xf7, 0x2f, 0x35, 0x68, 0x19, 0xe3, 0xb7, 0x20, 0xa1,
-	0x22, 0x0b, 0x6c, 0x33, 0x0b, 0xde, 0x86, 0xfa, 0x34, 0xe5, 0x91, 0x6e, 0xe7, 0x37, 0x24, 0x29,
-	0x8a, 0x90, 0x1f, 0x81, 0x2d, 0x38, 0x9e, 0xe7, 0x46, 0x41, 0x5b, 0x70, 0x09, 0x0f, 0xf5, 0xee,
-	0xb0, 0xa9, 0x4b, 0x59, 0x05, 0x96, 0x0f, 0xab, 0x67, 0xc8, 0xa5, 0xc8, 0x87, 0xba, 0x6b, 0x23,
-	0x70, 0xc6, 0x5e, 0xdf, 0x5a, 0x0a, 0x70, 0x1c, 0xd1, 0x6a, 0x86, 0xac, 0x4c, 0xd3, 0x20, 0x7b,
-	0xc2, 0xa3, 0x71, 0x26, 0x78, 0xcc, 0x34, 0x18, 0x30, 0x59, 0x65, 0x45, 0x6d, 0x60, 0x0a, 0x57,
-	0x2b, 0x6a, 0x13, 0x79, 0xf2, 0x53, 0x22, 0x8a, 0x79, 0x1c, 0x7c, 0x39, 0x67, 0xd8, 0xe1, 0x9b,
-	0x9e, 0xa6, 0x30, 0x9b, 0xf2, 0x20, 0xc9, 0x3a, 0xad, 0xfd, 0xda, 0x41, 0xd3, 0x33, 0x38, 0x72,
-	0x07, 0x13, 0x1e, 0x45, 0x81, 0x18, 0x62, 0xde, 0xab, 0x36, 0x6e, 0xb2, 0x64, 0x99, 0x91, 0xd8,
-	0x02, 0x01, 0x95, 0x6a, 0xe2, 0x05, 0xed, 0xfe, 0xb3, 0x06, 0x6d, 0x89, 0x09, 0xb2, 0x4b, 0x2e,
-	0xfa, 0x97, 0xf3, 0xf8, 0xe9, 0x2d, 0xc8, 0xcc, 0x70, 0xac, 0x5d, 0x75, 0x2c, 0xe2, 0x04, 0xf4,
-	0xc2, 0x70, 0xa0, 0xc1, 0x6b, 0xc9, 0x90, 0x31, 0x8a, 0x0e, 0x56, 0xe8, 0x4b, 0x79, 0x52, 0xf6,
-	0x04, 0xb9, 0xdc, 0x70, 0xa0, 0x71, 0x57, 0x4e, 0xe2, 0xb5, 0x45, 0x7e, 0x1a, 0xb0, 0xab, 0x64,
-	0x48, 0x6b, 0x20, 0xa1, 0x9a, 0x9a, 0x42, 0xa7, 0x06, 0xa7, 0xac, 0x7f, 0x0d, 0xb3, 0xfe, 0x11,
-	0xa8, 0x0b, 0x96, 0x46, 0x1a, 0x69, 0xe1, 0xb7, 0xb4, 0xca, 0x34, 0x08, 0xd9, 0x39, 0x15, 0x97,
-	0xda, 0xe2, 0x05, 0x9d, 0x8f, 0xe1, 0x16, 0x14, 0x80, 0x2a, 0x68, 0x69, 0x6f, 0xf9, 0xdd, 0xd7,
-	0xbb, 0xd7, 0xf6, 0x36, 0x58, 0xe4, 0x4d, 0xd8, 0x2e, 0x48, 0xb5, 0x4f, 0x65, 0xf5, 0x25, 0xae,
-	0xdc, 0x95, 0x2f, 0x2b, 0xe4, 0x36, 0x06, 0x01, 0x7e, 0xcb, 0xfd, 0x33, 0x59, 0xb4, 0x10, 0x2e,
-	0x6d, 0x79, 0x8a, 0x20, 0xef, 0xab, 0xab, 0x1c, 0x56, 0xd9, 0x8e, 0x83, 0xe1, 0xb9, 0x9b, 0x87,
-	0x74, 0x3f, 0x1f, 0x28, 0xa0, 0x52, 0xce, 0x70, 0x07, 0x1a, 0x72, 0x0f, 0x7d, 0xd9, 0x6c, 0xa5,
-	0x61, 0x15, 0x6e, 0x28, 0x5c, 0x5b, 0x32, 0x6e, 0xbe, 0xcb, 0xb9, 0xff, 0xb0, 0x61, 0x1d, 0x73,
-	0xe0, 0xc6, 0xf2, 0x54, 0x84, 0xb8, 0x7d, 0x4d, 0x88, 0xd7, 0xca, 0x10, 0x3f, 0x84, 0x75, 0x86,
-	0x19, 0x56, 0x7f, 0x41, 0x86, 0x29, 0xb1, 0xb2, 0xe5, 0xac, 0xbf, 0xa8, 0xe5, 0x98, 0xcd, 0x7e,
-	0xe3, 0xa5, 0x9a, 0x7d, 0x59, 0x8c, 0x36, 0xcd, 0x62, 0x54, 0x66, 0x61, 0xe3, 0x96, 0x2c, 0x6c,
-	0xae, 0x64, 0xe1, 0x8f, 0x8b, 0x3e, 0x04, 0xb8, 0x7c, 0x3b, 0x5f, 0x1e, 0xcb, 0xad, 0x5e, 0x5c,
-	0x8b, 0xb8, 0x0f, 0xa0, 0x71, 0xca, 0x67, 0x2a, 0x39, 0xaf, 0x6f, 0xd8, 0x79, 0xc0, 0xda, 0x65,
-	0xc0, 0xba, 0xbf, 0xb3, 0xa0, 0x8d, 0x27, 0x97, 0x88, 0x02, 0x83, 0xe5, 0xe6, 0x4a, 0xbb, 0x07,
-	0x8d, 0x50, 0xaf, 0x90, 0x23, 0x8b, 0x9c, 0x26, 0x1f, 0xc9, 0x32, 0xaf, 0x66, 0xd0, 0x35, 0xf7,
-	0xbb, 0x15, 0xc3, 0x9e, 0xf2, 0x09, 0x0d, 0xcd, 0x88, 0x2a, 0xc4, 0xdd, 0x3f, 0x5b, 0xb0, 0xb3,
-	0x24, 0x43, 0xde, 0x86, 0x75, 0x5c, 0x55, 0xdf, 0xc4, 0xdb, 0x95, 0xb9, 0x72, 0x7f, 0xa2, 0x84,
-	0xf4, 0x67, 0xc8, 0x68, 0xc6, 0x74, 0xa7, 0x2d, 0xfc, 0x89, 0xae, 0x3f, 0x95, 0x23, 0x9e, 0x12,
-	0x20, 0xdd, 0x2a, 0xd8, 0xb8, 0xb3, 0xe4, 0xcc, 0xff, 0x07, 0x6e, 0xb8, 0xff, 0x95, 0xf1, 0x2b,
-	0x63, 0xf9, 0xc6, 0xf8, 0x45, 0xac, 0x35, 0x15, 0x3d, 0xdf, 0x4f, 0x59, 0x96, 0xe9, 0x5e, 0x6d,
-	0xb2, 0xc8, 0x1b, 0xd0, 0x9e, 0x84, 0x01, 0x8b, 0x0b, 0x19, 0xd5, 0x6f, 0xab, 0x4c, 0x23, 0x08,
-	0xea, 0x2f, 0x0c, 0x82, 0x9b, 0x83, 0x3b, 0xbf, 0x24, 0x17, 0x07, 0xac, 0xdc, 0x88, 0x65, 0x45,
-	0xac, 0x99, 0x37, 0xe2, 0x77, 0x60, 0x37, 0xa4, 0x99, 0xf8, 0x8c, 0xd1, 0x54, 0x8c, 0x19, 0x55,
-	0x52, 0x9b, 0x28, 0xb5, 0x3a, 0x20, 0x43, 0xe6, 0x8a, 0xa5, 0x59, 0xc0, 0x63, 0x1d, 0xe0, 0x39,
-	0x89, 0x60, 0x54, 0x35, 0x8d, 0x01, 0xd6, 0xc9, 0xa6, 0x57, 0xd0, 0xd2, 0xc4, 0x3e, 0x4b, 0x42,
-	0xbe, 0x30, 0xaa, 0xa5, 0xc1, 0x91, 0x3b, 0xd4, 0xd8, 0x88, 0xf9, 0x58, 0x30, 0x1b, 0x5e, 0xc9,
-	0x70, 0xff, 0x90, 0x43, 0xb6, 0x4c, 0x42, 0x62, 0x72, 0xbf, 0x8a, 0xaa, 0x7f, 0x50, 0x09, 0x18,
-	0x14, 0x39, 0x94, 0x7f, 0x34, 0x60, 0x53, 0xb2, 0x7b, 0x0f, 0x01, 0x4a, 0xe6, 0x35, 0x80, 0xf1,
-	0x2d, 0x13, 0x68, 0xc9, 0xea, 0xb8, 0x0c, 0xd5, 0x4d, 0xec, 0xf5, 0x77, 0x0b, 0x9a, 0xc5, 0x40,
-	0x05, 0x85, 0x5b, 0xb7, 0xa3, 0x70, 0x7b, 0x05, 0x85, 0x93, 0x4f, 0x60, 0x87, 0x86, 0x21, 0x9f,
-	0x50, 0xc1, 0x7c, 0x75, 0x82, 0x4e, 0x0d, 0xcf, 0xf5, 0x6a, 0xbe, 0x85, 0x5e, 0x65, 0xd8, 0x5b,
-	0x16, 0x97, 0x87, 0xc9, 0xd8, 0x97, 0xba, 0x3b, 0xca, 0x4f, 0x7c, 0x87, 0xc9, 0x85, 0x1e, 0x4f,
-	0xa7, 0x19, 0x13, 0xba, 0x49, 0x2e, 0xb3, 0xdd, 0x29, 0x6c, 0x57, 0xa7, 0xbf, 0xa5, 0x26, 0xec,
-	0x43, 0xab, 0x50, 0xef, 0x89, 0xfc, 0x0d, 0xcc, 0x60, 0x49, 0xdd, 0x64, 0x9e, 0x26, 0x3c, 0x63,
-	0xba, 0x6a, 0xe7, 0xa4, 0xfb, 0xc7, 0xbc, 0xf6, 0xa0, 0x7f, 0xfa, 0x91, 0x4f, 0xde, 0xad, 0xdc,
-	0xfc, 0xbe, 0xb7, 0xea, 0xc4, 0x7e, 0xe4, 0x1b, 0x77, 0xc0, 0xfb, 0xb0, 0x31, 0x49, 0x99, 0x0c,
-	0x77, 0xe5, 0xa0, 0xef, 0x5f, 0xa3, 0x80, 0xe3, 0xfd, 0xc8, 0xf7, 0xb4, 0x28, 0x79, 0x0f, 0xd6,
-	0x71, 0x7b, 0xba, 0x4c, 0xed, 0xad, 0xea, 0xe0, 0xe1, 0xa5, 0x8a, 0x12, 0x74, 0xbf, 0x03, 0xaf,
-	0x5c, 0x33, 0xa1, 0x3b, 0x00, 0xb2, 0xaa, 0x73, 0xc3, 0xa5, 0xcc, 0x30, 0x82, 0x5d, 0x35, 0xc2,
-	0xc7, 0xb0, 0x95, 0x43, 0xa5, 0x61, 0x3c, 0xe5, 0x65, 0xaf, 0xd6, 0xfa, 0xaa, 0x57, 0xdf, 0x81,
-	0x75, 0x7f, 0x1e, 0x45, 0x8b, 0xfc, 0xea, 0x82, 0x84, 0xfb, 0x09, 0x40, 0x59, 0xe5, 0x50, 0x13,
-	0x1b, 0x61, 0xae, 0x99, 0x3f, 0xd8, 0x96, 0x28, 0xca, 0x5e, 0x42, 0x51, 0xdd, 0xae, 0x8e, 0x59,
-	0x69, 0x54, 0xb2, 0x0d, 0x70, 0xca, 0xa8, 0xcf, 0xd2, 0xc7, 0x71, 0xb8, 0x70, 0xd6, 0x48, 0x1b,
-	0x9a, 0xbd, 0x30, 0x54, 0x67, 0x74, 0xac, 0xee, 0x3d, 0xe3, 0xad, 0x8d, 0x91, 0x0d, 0xb0, 0x2f,
-	0x12, 0x67, 0x8d, 0x34, 0xa0, 0x3e, 0xe0, 0x5f, 0xc5, 0x8e, 0x45, 0x08, 0x6c, 0xe3, 0x78, 0x81,
-	0x52, 0x1d, 0xbb, 0xfb, 0x0b, 0xe3, 0x39, 0x93, 0x91, 0x16, 0x6c, 0x7a, 0xf3, 0x38, 0x0e, 0xe2,
-	0x99, 0xb3, 0x46, 0xb6, 0xa0, 0x81, 0xb6, 0x94, 0x94, 0x25, 0xd7, 0x2e, 0xaf, 0x46, 0x8e, 0x2d,
-	0xd7, 0x1e, 0xe4, 0xb9, 0xee, 0xd4, 0xba, 0x23, 0x70, 0xfa, 0xf8, 0xca, 0xdc, 0xbf, 0x94, 0x69,
-	0x82, 0xdb, 0x6d, 0xc1, 0x66, 0xcf, 0xf7, 0xcf, 0xb8, 0xcf, 0x9c, 0x35, 0xa9, 0xaf, 0x2e, 0xf3,
-	0x48, 0xe3, 0x7c, 0x17, 0x89, 0x4f, 0x85, 0xa2, 0x6d, 0xb9, 0xb9, 0x9e, 0xef, 0x9f, 0x32, 0x9a,
-	0xc6, 0x2c, 0x45, 0x5e, 0xad, 0xfb, 0x10, 0x5a, 0xc6, 0xdb, 0x31, 0x69, 0xc2, 0xfa, 0x17, 0x5c,
-	0xb0, 0xd4, 0x59, 0x93, 0x53, 0x6b, 0x51, 0xc7, 0x22, 0xbb, 0xd0, 0x1e, 0xc6, 0x13, 0x1e, 0x05,
-	0xf1, 0x4c, 0x8d, 0xdb, 0x92, 0x35, 0x60, 0x11, 0x17, 0x05, 0xab, 0xd6, 0x7d, 0x00, 0xad, 0xfe,
-	0x25, 0x9b, 0x3c, 0x3d, 0xe7, 0x61, 0x30, 0x59, 0x48, 0xb3, 0x8c, 0xfa, 0xbd, 0x33, 0x67, 0x8d,
-	0xec, 0x40, 0xab, 0x77, 0x7e, 0xee, 0x3d, 0xfe, 0xd5, 0xf0, 0x51, 0xef, 0xc9, 0x89, 0x63, 0x11,
-	0x80, 0x8d, 0x8b, 0xd1, 0xc9, 0xc3, 0x93, 0x5f, 0x3b, 0x76, 0xf7, 0x1c, 0xb6, 0x1f, 0x27, 0x2c,
-	0xa5, 0x82, 0xa7, 0xfa, 0xae, 0xdd, 0x82, 0xcd, 0xd1, 0x45, 0xbf, 0x7f, 0x32, 0x1a, 0xa9, 0x7d,
-	0x3c, 0x19, 0x3e, 0x3a, 0x79, 0x7c, 0xf1, 0x44, 0xe9, 0xf5, 0x7b, 0x67, 0xfd, 0x93, 0x53, 0xc7,
-	0x46, 0x4b, 0x9e, 0x9c, 0x9f, 0xf6, 0xfa, 0x27, 0x4e, 0x0d, 0x89, 0x8b, 0xb3, 0xb3, 0xe1, 0xd9,
-	0xa7, 0x4e, 0xbd, 0x7b, 0x0c, 0x9b, 0xfa, 0xa1, 0x44, 0xae, 0x6c, 0x3c, 0x70, 0x38, 0x6b, 0xe4,
-	0x15, 0xd8, 0x51, 0xe1, 0x5b, 0xd4, 0x29, 0x75, 0xbc, 0xfe, 0x3c, 0x13, 0x3c, 0x1a, 0xc9, 0xea,
-	0xdf, 0x13, 0x8e, 0xdf, 0xbd, 0x0f, 0x8d, 0xfc, 0xb1, 0x44, 0x4e, 0xae, 0x74, 0x7c, 0xb5, 0x9f,
-	0x5f, 0xf2, 0xf4, 0xa9, 0x72, 0x59, 0x1b, 0x9a, 0x7d, 0x1e, 0x25, 0x21, 0x93, 0x63, 0x76, 0xf7,
-	0xe7, 0x95, 0xe7, 0x74, 0x26, 0xb7, 0x7b, 0xc6, 0xd3, 0x88, 0x86, 0xca, 0xd7, 0x3d, 0xfd, 0x56,
-	0xe8, 0x58, 0xe4, 0x0e, 0x38, 0x5a, 0xd2, 0x0c, 0x95, 0x07, 0xb0, 0xbb, 0x92, 0xe7, 0xf2, 0x08,
-	0xc6, 0x8e, 0x95, 0x9f, 0x31, 0xd5, 0x14, 0x6d, 0x1d, 0x3b, 0xdf, 0xfc, 0xfb, 0xae, 0xf5, 0xf5,
-	0xf3, 0xbb, 0xd6, 0x37, 0xcf, 0xef, 0x5a, 0xff, 0x7a, 0x7e, 0xd7, 0x1a, 0x6f, 0xe0, 0xbf, 0x2d,
-	0xee, 0xff, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x83, 0x65, 0x37, 0x77, 0x28, 0x19, 0x00, 0x00,
+	// 3190 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x5a, 0xcd, 0x73, 0x24, 0xc7,
+	0x52, 0xd7, 0x7c, 0x48, 0x9a, 0xc9, 0xd1, 0x47, 0xab, 0xf6, 0xe3, 0x8d, 0x85, 0x59, 0x2b, 0x9a,
+	0x87, 0x9f, 0xac, 0xc7, 0xd3, 0xda, 0xbb, 0xeb, 0xc5, 0x36, 0x04, 0x58, 0x9a, 0x91, 0xbd, 0xb2,
+	0xb5, 0xbb, 0x8a, 0x1e, 0xad, 0x6d, 0x4e, 0x50, 0x9a, 0x2e, 0xcd, 0x34, 0xea, 0xe9, 0x6a, 0x77,
+	0xd7, 0xec, 0x4a, 0x44, 0x10, 0xc1, 0x89, 0x03, 0x04, 0xfc, 0x01, 0xdc, 0x39, 0xf2, 0x67, 0x10,
+	0x38, 0x82, 0x08, 0xc2, 0x47, 0x4e, 0x0e, 0x58, 0x8e, 0x1c, 0x89, 0xf0, 0x99, 0xc8, 0xac, 0xaa,
+	0xee, 0xea, 0x19, 0x49, 0xbb, 0x70, 0x59, 0x75, 0x66, 0x65, 0x55, 0x65, 0x65, 0x66, 0xfd, 0x32,
+	0x2b, 0x67, 0x61, 0x65, 0x22, 0x14, 0x4f, 0x4f, 0x77, 0xd3, 0x4c, 0x2a, 0xc9, 0x96, 0x34, 0xb5,
+	0xf9, 0x9b, 0x51, 0xa4, 0xc6, 0xd3, 0xd3, 0xdd, 0xa1, 0x9c, 0xdc, 0x1f, 0xc9, 0x91, 0xbc, 0x4f,
+	0xc3, 0xa7, 0xd3, 0x33, 0xa2, 0x88, 0xa0, 0x2f, 0x3d, 0x6d, 0xf3, 0x83, 0x91, 0xdc, 0x15, 0x6a,
+	0x18, 0xee, 0x46, 0xf2, 0x3e, 0xfe, 0xbd, 0x9f, 0xf1, 0x33, 0x75, 0xff, 0xe5, 0x43, 0xfa, 0x9b,
+	0x9e, 0xd2, 0x1f, 0x2d, 0xea, 0x7f, 0x05, 0x30, 0x18, 0xf3, 0x2c, 0x3c, 0x48, 0xe5, 0x70, 0xcc,
+	0xde, 0x85, 0xf6, 0x50, 0x26, 0x67, 0xd1, 0xe8, 0x1b, 0x91, 0x75, 0x6b, 0x5b, 0xb5, 0xed, 0x66,
+	0x50, 0x32, 0xd8, 0x3d, 0x80, 0x91, 0x48, 0x44, 0xc6, 0x55, 0x24, 0x93, 0x6e, 0x9d, 0x86, 0x1d,
+	0x8e, 0xff, 0x37, 0x35, 0x58, 0x0e, 0x44, 0x1a, 0x47, 0x43, 0xce, 0xee, 0x42, 0x3d, 0x0a, 0xf5,
+	0x12, 0xfb, 0x4b, 0xaf, 0x7f, 0x7a, 0xaf, 0x7e, 0xd8, 0x0f, 0xea, 0x51, 0xc8, 0xba, 0xb0, 0x9c,
+	0x2b, 0x99, 0x89, 0xc3, 0xbe, 0x59, 0xc0, 0x92, 0xec, 0x57, 0xd0, 0xcc, 0x64, 0x2c, 0xba, 0x8d,
+	0xad, 0xda, 0xf6, 0xda, 0x83, 0x5b, 0xbb, 0xc6, 0x10, 0x66, 0xc1, 0x40, 0xc6, 0x22, 0x20, 0x01,
+	0xf6, 0x4b, 0x58, 0x8d, 0x92, 0x48, 0x45, 0x3c, 0x7e, 0x2a, 0x26, 0xa7, 0x22, 0xeb, 0x36, 0xb7,
+	0x6a, 0xdb, 0xad, 0xa0, 0xca, 0xf4, 0x39, 0xac, 0x98, 0xa9, 0x03, 0xc5, 0x55, 0xce, 0xee, 0xc3,
+	0x72, 0xa6, 0x69, 0xd2, 0xaa, 0xf3, 0x60, 0x7d, 0x66, 0x87, 0xfd, 0xe6, 0x0f, 0x3f, 0xbd, 0xb7,
+	0x10, 0x58, 0x29, 0xb6, 0x05, 0x9d, 0x50, 0xbe, 0x4a, 0x06, 0x62, 0x28, 0x93, 0x30, 0x37, 0xda,
+	0xba, 0x2c, 0xff, 0x3e, 0x2c, 0x1e, 0xf1, 0x53, 0x11, 0x33, 0x0f, 0x1a, 0xe7, 0xe2, 0x92, 0xd6,
+	0x6d, 0x07, 0xf8, 0xc9, 0x6e, 0xc3, 0xe2, 0x4b, 0x1e, 0x4f, 0x05, 0x4d, 0x6b, 0x07, 0x9a, 0xf0,
+	0x33, 0x58, 0xdb, 0x8f, 0xe5, 0xf0, 0x3c, 0x4a, 0x46, 0x81, 0xe0, 0xb9, 0x4c, 0xd8, 0x23, 0x68,
+	0xcb, 0xd4, 0x5a, 0xb4, 0x46, 0x27, 0xbf, 0x6b, 0xf5, 0x22, 0xbf, 0x3c, 0xb7, 0xa3, 0x41, 0x29,
+	0xc8, 0xee, 0xc2, 0x52, 0x46, 0xf3, 0xcd, 0xf2, 0x86, 0x62, 0x0c, 0x9a, 0x2a, 0x9a, 0x68, 0x13,
+	0x36, 0x02, 0xfa, 0xf6, 0xff, 0xad, 0x6e, 0x3c, 0xac, 0xcd, 0x80, 0xf6, 0x47, 0xea, 0xb0, 0x6f,
+	0xfc, 0x6b, 0x49, 0xe6, 0xc3, 0xca, 0xab, 0x2c, 0x52, 0x4a, 0x24, 0xfb, 0x97, 0x4a, 0xd8, 0x03,
+	0x57, 0x78, 0x68, 0x13, 0x43, 0x7f, 0x2d, 0x2e, 0x73, 0xda, 0xa7, 0x19, 0xb8, 0x2c, 0x8c, 0xa0,
+	0x4c, 0xf0, 0x50, 0x2f, 0xd1, 0xd4, 0x11, 0x54, 0x30, 0xd8, 0x26, 0xb4, 0x90, 0xa0, 0xc9, 0x8b,
+	0x34, 0x58, 0xd0, 0x6c, 0x1b, 0xd6, 0x79, 0x9a, 0x66, 0xf2, 0x22, 0x9a, 0x70, 0x25, 0x06, 0xd1,
+	0x5f, 0x88, 0xee, 0x12, 0x89, 0xcc, 0xb2, 0x67, 0x24, 0x69, 0xb1, 0xe5, 0x39, 0x49, 0x5a, 0xf3,
+	0x43, 0x68, 0x45, 0x89, 0x12, 0xd9, 0x4b, 0x1e, 0x77, 0x5b, 0xe4, 0xf5, 0xdb, 0xd6, 0xba, 0x27,
+	0xd1, 0x44, 0x1c, 0x9a, 0xb1, 0xa0, 0x90, 0xc2, 0x13, 0xa2, 0x46, 0x47, 0x5c, 0x89, 0x64, 0x78,
+	0xd9, 0x6d, 0xeb, 0x13, 0x3a, 0x2c, 0xff, 0x5f, 0x97, 0x01, 0x06, 0x18, 0xb3, 0xa5, 0x41, 0x4d,
+	0x40, 0xd7, 0xaa, 0x01, 0xfd, 0x2e, 0xb4, 0x73, 0xc5, 0x33, 0x85, 0x3b, 0x19, 0x6b, 0x96, 0x8c,
+	0x8a, 0x6a, 0x8d, 0xb7, 0x52, 0x6d, 0x13, 0x5a, 0x43, 0x9e, 0xf2, 0x61, 0xa4, 0x2e, 0x8d, 0x65,
+	0x0b, 0x1a, 0xf7, 0xe2, 0x2f, 0x79, 0x14, 0xf3, 0xd3, 0x58, 0x18, 0xcb, 0x96, 0x0c, 0x9c, 0x39,
+	0xcd, 0x45, 0xe8, 0xd8, 0xb4, 0xa0, 0x31, 0x96, 0xa2, 0x7c, 0x7f, 0x9a, 0x5f, 0x92, 0x0d, 0x5b,
+	0x81, 0xa1, 0xf0, 0xb2, 0x53, 0x64, 0xf4, 0xe4, 0x34, 0x51, 0x64, 0xbc, 0x66, 0xe0, 0x70, 0xd8,
+	0x0e, 0x78, 0xb9, 0x48, 0xc2, 0x28, 0x19, 0x0d, 0x12, 0x9e, 0x6a, 0x29, 0x6d, 0xad, 0x39, 0x3e,
+	0xdb, 0x05, 0x96, 0x89, 0xa1, 0x88, 0x5e, 0x56, 0xa4, 0x81, 0xa4, 0xaf, 0x18, 0x61, 0xbf, 0x07,
+	0x1b, 0x3c, 0x4d, 0xe3, 0xcb, 0x8a, 0x78, 0x87, 0xc4, 0xe7, 0x07, 0xe6, 0x02, 0x77, 0xe5, 0x8a,
+	0xc0, 0xad, 0x84, 0xe5, 0xea, 0x6c, 0x58, 0xce, 0x84, 0xf5, 0xda, 0x7c, 0x58, 0xbb, 0x81, 0xbb,
+	0x3e, 0x13, 0xb8, 0x8f, 0xa1, 0x3d, 0x4c, 0xa7, 0x2f, 0x72, 0x3e, 0x12, 0x79, 0xd7, 0xdb, 0x6a,
+	0x6c, 0x77, 0x1e, 0xb0, 0x12, 0x5b, 0x86, 0x32, 0x0b, 0x8f, 0x79, 0x94, 0x19, 0x78, 0x29, 0x45,
+	0xd9, 0x67, 0x3a, 0xd4, 0x0e, 0x9f, 0x07, 0x1c, 0xb5, 0xda, 0x78, 0xc3, 0x4c, 0x57, 0x98, 0xfd,
+	0xa1, 0x3e, 0xb3, 0xb0, 0x93, 0xd9, 0x1b, 0x26, 0x57, 0xa4, 0xd1, 0x77, 0xdf, 0x4f, 0x65, 0x36,
+	0x9d, 0x1c, 0xc9, 0x5c, 0x11, 0x38, 0xe4, 0xdd, 0x5b, 0x5b, 0x0d, 0xf4, 0xdd, 0x2c, 0x1f, 0xad,
+	0x4b, 0x26, 0xdf, 0xe7, 0xc3, 0xf3, 0x58, 0x8e, 0xba, 0xb7, 0xb5, 0x75, 0x5d, 0x5e, 0x21, 0x63,
+	0x6f, 0xcd, 0x1d, 0x47, 0xc6, 0xf0, 0x58, 0x1f, 0x56, 0x53, 0x21, 0x32, 0x4d, 0x46, 0x22, 0xef,
+	0xde, 0x25, 0x95, 0xbb, 0x56, 0xe5, 0x63, 0x21, 0x32, 0xba, 0x56, 0x66, 0x82, 0x51, 0xbc, 0x3a,
+	0x89, 0x3d, 0x07, 0x16, 0x25, 0x43, 0x99, 0xe4, 0x51, 0xae, 0x44, 0x62, 0x75, 0xff, 0x05, 0x2d,
+	0xf5, 0x8e, 0x5d, 0xea, 0x70, 0x56, 0xc2, 0xac, 0x75, 0xc5, 0x54, 0xff, 0x11, 0x40, 0x69, 0xac,
+	0x37, 0x01, 0x79, 0xd3, 0x02, 0xf9, 0x9f, 0x81, 0x37, 0xab, 0xef, 0x0d, 0x40, 0xd0, 0x85, 0xe5,
+	0xd8, 0x58, 0xc6, 0xe4, 0xbc, 0xd8, 0x99, 0xc3, 0x27, 0x69, 0x2c, 0x2c, 0x96, 0x5a, 0xd2, 0x7f,
+	0x05, 0x1b, 0x73, 0xc7, 0xb8, 0x01, 0xbc, 0x6f, 0xc3, 0x62, 0x94, 0x84, 0xe2, 0xc2, 0xaa, 0x49,
+	0x04, 0xe6, 0x83, 0x31, 0xcf, 0xc7, 0x66, 0x6d, 0xfa, 0xc6, 0x7b, 0x2d, 0x2e, 0x52, 0x31, 0x54,
+	0x4f, 0x70, 0x44, 0xe3, 0x88, 0xc3, 0xf1, 0x9f, 0xc0, 0x92, 0xce, 0xa0, 0xd7, 0xa6, 0x70, 0x06,
+	0xcd, 0x84, 0x4f, 0x6c, 0x6a, 0xa3, 0x6f, 0xe4, 0xf1, 0x30, 0xcc, 0x68, 0xa7, 0x76, 0x40, 0xdf,
+	0x7e, 0x00, 0x6b, 0xc7, 0x99, 0x4c, 0xc7, 0x42, 0xf5, 0xe2, 0x69, 0xae, 0x6e, 0x58, 0x71, 0x1b,
+	0xd6, 0x27, 0xfc, 0xc2, 0xe4, 0x61, 0x7d, 0xdb, 0x71, 0xf1, 0xd5, 0x60, 0x96, 0xed, 0x3f, 0x86,
+	0x15, 0x17, 0x1d, 0xf1, 0xdc, 0x04, 0xa9, 0xc6, 0x1e, 0x9a, 0x40, 0x37, 0x8a, 0x24, 0x34, 0xb6,
+	0xc0, 0x4f, 0x3f, 0x86, 0xc6, 0x57, 0xf2, 0x94, 0xfd, 0x0e, 0x34, 0xd5, 0x65, 0x2a, 0x4c, 0xa6,
+	0x2d, 0x2a, 0x80, 0xaf, 0xe4, 0xe9, 0xc9, 0x65, 0x2a, 0x02, 0x1a, 0x44, 0x2b, 0x0f, 0x65, 0x82,
+	0x56, 0xa7, 0x15, 0x56, 0x02, 0x4b, 0xb2, 0xf7, 0x69, 0x37, 0x65, 0x6b, 0x14, 0xcf, 0x99, 0x8f,
+	0xc9, 0x40, 0x04, 0x7a, 0xd8, 0x17, 0xb0, 0x16, 0x88, 0x89, 0x7c, 0x29, 0xc8, 0x6d, 0xb8, 0xf1,
+	0xd6, 0x8c, 0xe7, 0x8a, 0xe3, 0x17, 0x1e, 0xfc, 0x08, 0x11, 0x86, 0x4e, 0x8a, 0xa9, 0xb7, 0x71,
+	0x7d, 0x81, 0x52, 0x88, 0xf9, 0x7d, 0x58, 0xa1, 0x0d, 0x8e, 0xa5, 0x8c, 0x71, 0x93, 0x47, 0xb0,
+	0x98, 0x4a, 0x19, 0xe7, 0xdd, 0x5a, 0xf5, 0x6a, 0xb9, 0x42, 0x4f, 0x85, 0xb2, 0x0b, 0x69, 0x61,
+	0xff, 0x0c, 0xbc, 0x59, 0x01, 0x34, 0xeb, 0x28, 0x93, 0xd3, 0xd4, 0x9a, 0x95, 0x88, 0x4a, 0x02,
+	0xaa, 0xcf, 0x24, 0x20, 0xcc, 0x9b, 0x3c, 0x19, 0x89, 0xe3, 0x4c, 0x9c, 0x45, 0x17, 0x64, 0xa0,
+	0x95, 0xc0, 0x65, 0xf9, 0xff, 0x53, 0x03, 0xaf, 0x2f, 0x72, 0x95, 0x49, 0x82, 0x6f, 0xc5, 0xd5,
+	0x34, 0x2f, 0xe3, 0xb6, 0xe6, 0xc6, 0xed, 0xfe, 0x9c, 0x2d, 0xde, 0xb7, 0x67, 0x99, 0x5d, 0xc1,
+	0x1a, 0x27, 0x3f, 0x48, 0x54, 0x76, 0x59, 0x1a, 0x87, 0x6d, 0x57, 0x7d, 0xc5, 0x2a, 0xc6, 0x70,
+	0xbd, 0x85, 0x37, 0x22, 0x23, 0x6f, 0xf5, 0xb9, 0xe2, 0xa6, 0x98, 0x74, 0x38, 0x9b, 0x7f, 0x00,
+	0xab, 0x95, 0x4d, 0x5c, 0x94, 0x68, 0x5e, 0x81, 0x12, 0x2d, 0x83, 0x12, 0x9f, 0xd5, 0x3f, 0xa9,
+	0xf9, 0xff, 0x5c, 0xb3, 0x05, 0xf6, 0x85, 0xca, 0x38, 0x7b, 0x0c, 0x4b, 0x31, 0x96, 0x8c, 0xd6,
+	0x47, 0xf7, 0x2a, 0x6a, 0x91, 0xcc, 0x2e, 0xd5, 0x94, 0xe6, 0x3c, 0x46, 0x9a, 0xf5, 0xc1, 0x0b,
+	0x67, 0x4e, 0x4e, 0x7b, 0x39, 0x5e, 0x9e, 0xb5, 0x4c, 0x30, 0x37, 0x63, 0xf3, 0x53, 0xe8, 0x38,
+	0x8b, 0xbf, 0x6d, 0xd9, 0x4a, 0xe7, 0xf8, 0x4b, 0xd8, 0x18, 0x0c, 0xc7, 0x22, 0x9c, 0xc6, 0xe2,
+	0x4b, 0x0c, 0x86, 0x60, 0x1a, 0x8b, 0x9b, 0x8a, 0x7c, 0x8a, 0x98, 0xb2, 0xc8, 0x37, 0x64, 0x81,
+	0x1d, 0x0d, 0x07, 0x3b, 0x7c, 0x58, 0xa1, 0xe1, 0xfd, 0x4b, 0x52, 0x8e, 0x3c, 0xd0, 0x0e, 0x2a,
+	0x3c, 0xc4, 0x12, 0x03, 0x22, 0x03, 0xa1, 0x54, 0x94, 0x8c, 0xde, 0x56, 0x79, 0xd4, 0xe5, 0xa5,
+	0xc8, 0x72, 0xac, 0xaf, 0x0d, 0xc4, 0x1a, 0xd2, 0x3f, 0x04, 0x2f, 0xe0, 0x67, 0xea, 0xa9, 0xc8,
+	0x31, 0x1f, 0xef, 0x73, 0x35, 0x1c, 0xb3, 0x8f, 0xa1, 0x35, 0xd1, 0xb4, 0xf5, 0x50, 0xf9, 0x10,
+	0x71, 0x64, 0xcd, 0x4d, 0xb4, 0xa2, 0xfe, 0xbf, 0x37, 0xa0, 0xe3, 0x8c, 0xdf, 0x0c, 0xd4, 0xfa,
+	0x66, 0xd5, 0xdd, 0x9b, 0xf5, 0x01, 0x34, 0xcf, 0x32, 0x39, 0x31, 0x85, 0xe0, 0x35, 0x17, 0x9f,
+	0x44, 0xd8, 0xef, 0x42, 0x5d, 0xc9, 0x6e, 0xf3, 0x26, 0xc1, 0xba, 0x92, 0xf8, 0xdc, 0x31, 0xda,
+	0x75, 0x17, 0x8d, 0xac, 0x7e, 0xfc, 0xed, 0x56, 0xcf, 0x60, 0xa5, 0xd8, 0x27, 0xa6, 0xde, 0xa3,
+	0x87, 0x20, 0x55, 0x89, 0x9d, 0x99, 0x4b, 0x43, 0x23, 0x66, 0x9a, 0x23, 0x8b, 0x57, 0x3f, 0xca,
+	0x4f, 0xe4, 0xe4, 0x34, 0x57, 0x32, 0x11, 0xa6, 0x8c, 0x74, 0x59, 0x25, 0x4a, 0xb7, 0x08, 0x16,
+	0xaa, 0x28, 0xdd, 0x26, 0x1e, 0x7e, 0x62, 0x2d, 0x3a, 0x4d, 0xa2, 0xef, 0xa7, 0x82, 0x6a, 0xc3,
+	0x76, 0x60, 0x28, 0xba, 0xa1, 0x36, 0xf0, 0xf2, 0x6e, 0x67, 0xab, 0xb1, 0xdd, 0x0e, 0x1c, 0x0e,
+	0x6a, 0x30, 0x94, 0x93, 0x49, 0xa4, 0x0e, 0x09, 0x4b, 0x74, 0x01, 0xe8, 0xb2, 0x10, 0xba, 0xb0,
+	0x2a, 0xa5, 0x52, 0x5c, 0x97, 0x7f, 0x05, 0x8d, 0xb5, 0xe1, 0x38, 0x3a, 0x15, 0x59, 0x82, 0x68,
+	0xb1, 0x46, 0xda, 0x97, 0x0c, 0xff, 0xe7, 0x06, 0xac, 0x62, 0xad, 0x99, 0x8f, 0xa5, 0xea, 0x8d,
+	0xa7, 0xc9, 0xf9, 0xcd, 0x89, 0xde, 0xba, 0xbd, 0x5e, 0x75, 0x3b, 0xd5, 0x9f, 0xe4, 0xa3, 0xc3,
+	0xbe, 0x89, 0xc3, 0x92, 0x81, 0xb7, 0x82, 0xdc, 0xaf, 0xb3, 0x31, 0x7d, 0x53, 0x16, 0xc2, 0xed,
+	0x0e, 0xfb, 0xa6, 0x9e, 0xb7, 0x24, 0x3d, 0xd2, 0xf1, 0xd3, 0x29, 0xe7, 0x4b, 0x06, 0xda, 0x8a,
+	0x08, 0x9d, 0x46, 0xf5, 0xbb, 0xc8, 0xe1, 0x94, 0x88, 0xdb, 0x9a, 0xa9, 0x14, 0x94, 0xc8, 0x26,
+	0xa6, 0x82, 0xa7, 0x6f, 0xb4, 0xd9, 0x59, 0x14, 0x8b, 0x63, 0xae, 0xc6, 0xc6, 0x1f, 0x05, 0x6d,
+	0xc7, 0x48, 0x05, 0x5d, 0x98, 0x17, 0x34, 0x7a, 0x03, 0xbf, 0x7b, 0x46, 0x7b, 0xe3, 0x0d, 0x87,
+	0xc5, 0xde, 0x87, 0xb5, 0x82, 0xd4, 0x7a, 0x6a, 0x9f, 0xcc, 0x70, 0x51, 0xab, 0x10, 0x31, 0x79,
+	0x8d, 0x42, 0x84, 0xbe, 0x51, 0x7f, 0x81, 0x30, 0x49, 0x65, 0xf8, 0x4a, 0xa0, 0x09, 0xf6, 0xb1,
+	0x6e, 0x5c, 0x10, 0xae, 0x77, 0x3d, 0x0a, 0xde, 0x0d, 0x1b, 0xf0, 0x3d, 0x3b, 0x50, 0x94, 0xe0,
+	0x96, 0x41, 0x19, 0x6d, 0x2c, 0x86, 0xe7, 0xf9, 0x74, 0xd2, 0xdd, 0xa0, 0x8a, 0xa3, 0xa0, 0xfd,
+	0xbf, 0xae, 0xc1, 0x9a, 0x75, 0x7c, 0x20, 0xf2, 0xe9, 0xe4, 0xa6, 0x6b, 0x5d, 0xf1, 0x6f, 0xfd,
+	0x3a, 0xff, 0x36, 0x1c, 0xff, 0x16, 0x7e, 0x68, 0xce, 0xf8, 0x21, 0x11, 0x17, 0xca, 0xb8, 0x9c,
+	0xbe, 0xfd, 0x9f, 0x6b, 0xc0, 0x4e, 0x32, 0x9e, 0xe4, 0xa9, 0xcc, 0xd4, 0x13, 0x9e, 0x84, 0xf9,
+	0x98, 0x9f, 0x53, 0xd8, 0x0e, 0x35, 0x24, 0x16, 0xea, 0x94, 0x8c, 0x1b, 0xfa, 0x2c, 0xbf, 0x84,
+	0x55, 0xc5, 0xb3, 0x91, 0x50, 0x03, 0x33, 0xae, 0xb5, 0xaa, 0x32, 0xb1, 0x24, 0xa3, 0x06, 0xd1,
+	0x50, 0xc6, 0xdf, 0x18, 0xf8, 0x6c, 0xea, 0x92, 0x6c, 0x86, 0xed, 0x02, 0xec, 0x22, 0x45, 0x89,
+	0x25, 0x11, 0xd8, 0xb1, 0x3e, 0x38, 0x8d, 0xe2, 0x48, 0x61, 0xc5, 0xbf, 0x44, 0x17, 0xb7, 0xc2,
+	0xd3, 0x0f, 0xab, 0x3f, 0x17, 0x43, 0x25, 0x42, 0x0a, 0xd6, 0x76, 0x50, 0xd0, 0x7e, 0xdf, 0x3c,
+	0xb4, 0x0f, 0x43, 0x2c, 0xbe, 0xfe, 0x9f, 0xe7, 0xf5, 0xff, 0xbb, 0x01, 0x8b, 0xba, 0x7c, 0xbe,
+	0x2e, 0x5d, 0x15, 0xf0, 0x54, 0xbf, 0x02, 0x9e, 0x1a, 0x25, 0x3c, 0xed, 0xc2, 0xa2, 0x20, 0x74,
+	0x6c, 0xbe, 0x01, 0x1d, 0xb5, 0x58, 0x59, 0x82, 0x2c, 0xbe, 0xa9, 0x04, 0x71, 0x8b, 0xbf, 0xa5,
+	0xb7, 0x2a, 0xfe, 0xca, 0x44, 0xb2, 0xec, 0x26, 0x92, 0x12, 0x41, 0x5b, 0x37, 0x20, 0x68, 0x7b,
+	0x0e, 0x41, 0x7f, 0x5d, 0xd4, 0x25, 0x40, 0xdb, 0xaf, 0xda, 0xed, 0x29, 0xfd, 0x9a, 0xcd, 0x8d,
+	0x08, 0xfb, 0x35, 0x34, 0x47, 0x5c, 0xe9, 0x8b, 0x8f, 0xf7, 0xcc, 0x3d, 0xd6, 0x97, 0xe5, 0x3d,
+	0x23, 0x21, 0xf6, 0x00, 0x5a, 0x3c, 0x4d, 0x8f, 0x04, 0xcf, 0x05, 0x41, 0x41, 0xa7, 0x2c, 0x9b,
+	0xf7, 0x0c, 0xdf, 0x9e, 0xcd, 0xca, 0xa1, 0xb6, 0x5c, 0xa9, 0x2c, 0x3a, 0x9d, 0xda, 0xe7, 0xfa,
+	0x4a, 0xe0, 0x70, 0xd8, 0x3b, 0xd0, 0x50, 0x2a, 0xd6, 0xef, 0xf4, 0xfd, 0xe5, 0xd7, 0x3f, 0xbd,
+	0xd7, 0x38, 0x39, 0x39, 0x0a, 0x90, 0xe7, 0xff, 0x6d, 0x0d, 0xda, 0x85, 0x22, 0xd4, 0xc3, 0x8b,
+	0x72, 0xec, 0x81, 0x04, 0x82, 0x6b, 0xd7, 0xb7, 0x02, 0x97, 0x85, 0x31, 0x6a, 0xc8, 0x6f, 0xf1,
+	0x85, 0x6c, 0x0a, 0xb8, 0x0a, 0x4f, 0xc7, 0x68, 0x18, 0x65, 0x62, 0xa8, 0x4c, 0xe1, 0x52, 0xd0,
+	0x18, 0x95, 0xa9, 0xc8, 0x26, 0x3c, 0xc1, 0xe7, 0x82, 0xae, 0x1d, 0x4b, 0x86, 0x7f, 0x02, 0x2d,
+	0x7b, 0x48, 0x74, 0xcd, 0x58, 0xc6, 0xa1, 0x69, 0xac, 0xb6, 0x03, 0x43, 0xa1, 0x23, 0x95, 0x3c,
+	0x17, 0xb6, 0xa1, 0xaa, 0x09, 0xdc, 0x53, 0x5c, 0xa4, 0x51, 0x26, 0xf6, 0x94, 0x69, 0xe7, 0x15,
+	0xb4, 0xff, 0x08, 0x5a, 0x47, 0x72, 0xa4, 0x13, 0xdb, 0xd5, 0x05, 0xb4, 0x85, 0xf3, 0x7a, 0x09,
+	0xe7, 0xfe, 0x5f, 0xd5, 0x60, 0x95, 0x2c, 0x83, 0x15, 0x3e, 0x41, 0xe9, 0xf5, 0x70, 0xb6, 0x09,
+	0xad, 0xd8, 0xec, 0x60, 0x2b, 0x7d, 0x4b, 0xb3, 0x4f, 0xb1, 0x44, 0xd2, 0x2b, 0x98, 0x7a, 0xe5,
+	0x17, 0x95, 0x08, 0x38, 0x92, 0x43, 0x1e, 0xbb, 0x78, 0x5b, 0x88, 0xfb, 0xff, 0x54, 0x87, 0xf5,
+	0x19, 0x19, 0xf6, 0x01, 0x2c, 0xd2, 0xae, 0xa6, 0x2b, 0xbb, 0x5a, 0x59, 0xcb, 0xde, 0x27, 0x92,
+	0xc0, 0xfb, 0x14, 0x53, 0x1c, 0xd5, 0xab, 0xf7, 0x8f, 0xae, 0x1e, 0x19, 0x39, 0xd0, 0x02, 0x6c,
+	0xa7, 0x5a, 0xfc, 0xdf, 0x9e, 0xb9, 0x4c, 0xff, 0x97, 0xf2, 0x9f, 0x7d, 0x01, 0xab, 0xba, 0x05,
+	0xde, 0x1b, 0xe3, 0x6b, 0x06, 0x1b, 0x97, 0x78, 0x43, 0x36, 0xed, 0x9a, 0x3d, 0x67, 0x50, 0xb7,
+	0x12, 0x6c, 0xeb, 0xa2, 0x32, 0x8d, 0x7d, 0x04, 0x4b, 0xe9, 0x34, 0x1b, 0x09, 0x7b, 0xc3, 0x8b,
+	0xc2, 0xf2, 0x18, 0xb9, 0x4f, 0x79, 0x76, 0x2e, 0x6c, 0xb7, 0xc6, 0x08, 0xfa, 0xff, 0x55, 0x03,
+	0x36, 0xbf, 0x7c, 0x89, 0x43, 0xb5, 0xb7, 0xc3, 0xa1, 0x4f, 0xb0, 0x24, 0xc0, 0xf9, 0xf8, 0xc8,
+	0x25, 0xe3, 0xad, 0x95, 0xcf, 0x06, 0x77, 0x7d, 0x1c, 0x0f, 0x1c, 0x59, 0xb7, 0x69, 0xde, 0x78,
+	0xab, 0xa6, 0xf9, 0xd5, 0x59, 0xed, 0x5d, 0x68, 0xeb, 0xe6, 0xbc, 0x92, 0x99, 0x49, 0x12, 0x25,
+	0xc3, 0xff, 0x53, 0xe8, 0x38, 0x26, 0xb8, 0x26, 0xa2, 0xdf, 0x16, 0xa3, 0x19, 0x34, 0xcf, 0xb1,
+	0x49, 0xd7, 0xdc, 0x6a, 0x60, 0xc9, 0x80, 0xdf, 0xfe, 0x3f, 0x60, 0x06, 0xc0, 0x6c, 0x70, 0x6d,
+	0x06, 0xa0, 0xd7, 0xeb, 0x99, 0xda, 0x0b, 0xc3, 0x4c, 0xe4, 0xb9, 0x79, 0x40, 0xb8, 0x2c, 0xcc,
+	0x9a, 0xc3, 0x38, 0x12, 0x49, 0x21, 0xa3, 0x81, 0xa0, 0xca, 0x74, 0x60, 0xb4, 0xf9, 0x66, 0x18,
+	0xbd, 0x36, 0x3d, 0xd8, 0xe6, 0x72, 0x11, 0xa2, 0x95, 0x4e, 0xf2, 0x12, 0xa1, 0x41, 0xc9, 0xc0,
+	0x6e, 0x69, 0xcc, 0x73, 0xf5, 0x44, 0xf0, 0x4c, 0x9d, 0x0a, 0xae, 0xa5, 0x96, 0x49, 0x6a, 0x7e,
+	0xc0, 0x4d, 0xd7, 0xad, 0x6a, 0xba, 0xc6, 0x62, 0x48, 0x97, 0xcc, 0x7d, 0xaa, 0x03, 0xdb, 0x41,
+	0x41, 0xe3, 0x25, 0x09, 0x45, 0x1a, 0xcb, 0x4b, 0xa7, 0x1a, 0x74, 0x38, 0xa8, 0xa1, 0x79, 0x6d,
+	0x8a, 0x90, 0xf2, 0x42, 0x2b, 0x28, 0x19, 0xb8, 0x72, 0x98, 0xf1, 0x28, 0x89, 0x92, 0x11, 0xe5,
+	0x80, 0x56, 0x50, 0xd0, 0xfe, 0xdf, 0xdb, 0x07, 0x72, 0x8e, 0x0d, 0x08, 0xf6, 0xb0, 0xda, 0xc3,
+	0xf8, 0xed, 0x4a, 0x6c, 0x93, 0xc8, 0x2e, 0xfe, 0x63, 0x9e, 0xc7, 0x5a, 0x76, 0xf3, 0x6b, 0x80,
+	0x92, 0x79, 0xc5, 0xf3, 0xfc, 0x57, 0xee, 0xcb, 0x70, 0x36, 0x63, 0xe1, 0x4c, 0xf7, 0xa5, 0xfb,
+	0x2f, 0x36, 0x83, 0x90, 0x3e, 0x6e, 0xcf, 0xa3, 0x76, 0x73, 0xcf, 0xa3, 0x3e, 0xd7, 0xf3, 0x60,
+	0x9f, 0xc3, 0x3a, 0x8f, 0x63, 0x39, 0xe4, 0x4a, 0x84, 0xfa, 0x04, 0xdd, 0x06, 0x9d, 0xab, 0xf8,
+	0x91, 0x67, 0xaf, 0x32, 0x1c, 0xcc, 0x8a, 0xe3, 0x61, 0x72, 0xf1, 0xbd, 0xb9, 0x4e, 0xf8, 0x49,
+	0xbf, 0x7e, 0x58, 0xa1, 0xe7, 0x67, 0x67, 0xb9, 0xb0, 0xd5, 0xe2, 0x2c, 0xdb, 0x3f, 0x83, 0xb5,
+	0xea, 0xf2, 0x37, 0x20, 0xfe, 0x16, 0x74, 0x8a, 0xe9, 0x7b, 0xca, 0xfe, 0xda, 0xe5, 0xb0, 0x70,
+	0x6e, 0x3a, 0xcd, 0x52, 0x99, 0x0b, 0x73, 0xdf, 0x2c, 0xe9, 0xff, 0xa3, 0xcd, 0x2c, 0xe4, 0x9f,
+	0xde, 0x24, 0x64, 0xbf, 0xa9, 0xf4, 0xd9, 0xde, 0x99, 0x77, 0x62, 0x6f, 0x12, 0x3a, 0x1d, 0xb7,
+	0x87, 0xb0, 0x34, 0xcc, 0x04, 0x57, 0xd6, 0x41, 0xbf, 0x75, 0xc5, 0x04, 0x1a, 0xef, 0x4d, 0xc2,
+	0xc0, 0x88, 0xb2, 0x0f, 0x61, 0x91, 0xd4, 0x33, 0xc8, 0xb4, 0x39, 0x3f, 0x87, 0x0e, 0x8f, 0x53,
+	0xb4, 0xa0, 0x7f, 0x07, 0x6e, 0x5d, 0xb1, 0xa0, 0xdf, 0x07, 0x36, 0x3f, 0xe7, 0x9a, 0x16, 0x98,
+	0x63, 0x84, 0x7a, 0xd5, 0x08, 0x7f, 0x57, 0x83, 0x15, 0xfb, 0x5c, 0x38, 0x4c, 0xce, 0x64, 0xf9,
+	0x50, 0x31, 0x0b, 0x10, 0x81, 0xdc, 0x70, 0x3a, 0x99, 0x5c, 0xda, 0x4e, 0x11, 0x11, 0xb8, 0xec,
+	0xab, 0x48, 0x25, 0x16, 0x57, 0x5a, 0x81, 0x25, 0xd9, 0xef, 0x3b, 0xd9, 0x56, 0x97, 0x9d, 0x77,
+	0x2a, 0x07, 0xb5, 0xc9, 0x7c, 0x2e, 0xd7, 0xfe, 0x31, 0xdc, 0xb1, 0xea, 0xec, 0xd9, 0x9f, 0x4c,
+	0x08, 0x4c, 0xae, 0xc6, 0x57, 0x0f, 0x1a, 0x61, 0x94, 0x19, 0xe4, 0xc3, 0x4f, 0xff, 0x73, 0x80,
+	0x32, 0xb1, 0xd2, 0x69, 0x8a, 0x9c, 0xd3, 0xb4, 0x99, 0xe5, 0xc6, 0x67, 0xcf, 0xce, 0x8e, 0xb9,
+	0x48, 0x94, 0x4a, 0xd6, 0x00, 0x8e, 0x04, 0x0f, 0x45, 0xf6, 0x3c, 0x89, 0x2f, 0xbd, 0x05, 0xb6,
+	0x0a, 0xed, 0xbd, 0x38, 0xd6, 0x86, 0xf7, 0x6a, 0x3b, 0x0f, 0x9c, 0x1f, 0xd5, 0x04, 0x5b, 0x82,
+	0xfa, 0x8b, 0xd4, 0x5b, 0x60, 0x2d, 0x68, 0xf6, 0xe5, 0xab, 0xc4, 0xab, 0x31, 0x06, 0x6b, 0x34,
+	0x5e, 0x34, 0x15, 0xbc, 0xfa, 0xce, 0x17, 0xce, 0x2f, 0x9b, 0x82, 0x75, 0x60, 0x39, 0x98, 0x26,
+	0x88, 0x29, 0xde, 0x02, 0x5b, 0x81, 0x16, 0x39, 0x18, 0xa9, 0x1a, 0xee, 0x5d, 0x76, 0xc7, 0xbc,
+	0x3a, 0xee, 0xdd, 0xb7, 0xe0, 0xe4, 0x35, 0x76, 0x06, 0xe0, 0xcd, 0x66, 0x41, 0x5c, 0x6d, 0x2f,
+	0x0c, 0x9f, 0xc9, 0x50, 0x78, 0x0b, 0x38, 0x5f, 0xf7, 0x73, 0x89, 0xa6, 0xf5, 0x5e, 0xa4, 0x21,
+	0x57, 0x9a, 0xae, 0xa3, 0x72, 0x7b, 0x61, 0x78, 0x24, 0x78, 0x96, 0x88, 0x8c, 0x78, 0x8d, 0x9d,
+	0xef, 0xa0, 0xe3, 0xfc, 0x74, 0xcd, 0xda, 0xb0, 0xf8, 0x8d, 0x54, 0x22, 0xf3, 0x16, 0x70, 0x69,
+	0x23, 0xea, 0xd5, 0xd8, 0x06, 0xac, 0x62, 0x9f, 0x7f, 0x12, 0x25, 0x23, 0x3d, 0x5e, 0x47, 0x56,
+	0x5f, 0x4c, 0xa4, 0x2a, 0x58, 0x0d, 0x9c, 0xf2, 0xad, 0x0e, 0x08, 0xaf, 0xb9, 0xf3, 0x18, 0xd6,
+	0xaa, 0x3f, 0x0d, 0xe3, 0xe2, 0x83, 0x34, 0x8e, 0x94, 0xb7, 0x80, 0x9f, 0x4f, 0x45, 0x36, 0x32,
+	0x5a, 0xe2, 0xb1, 0xf4, 0xa1, 0xbc, 0xfa, 0xce, 0x23, 0xe8, 0xf4, 0xf0, 0x71, 0x7b, 0x2c, 0xe3,
+	0x68, 0x78, 0x89, 0xb6, 0x1d, 0xf4, 0xf6, 0x9e, 0x79, 0x0b, 0x6c, 0x1d, 0x3a, 0x7b, 0xc7, 0xc7,
+	0xc1, 0xf3, 0xef, 0x0e, 0x9f, 0xee, 0x9d, 0x1c, 0x78, 0x35, 0x06, 0xb0, 0xf4, 0x62, 0x70, 0xf0,
+	0xf5, 0xc1, 0x9f, 0x78, 0xf5, 0x9d, 0x63, 0x58, 0xd3, 0x1b, 0xc9, 0xcc, 0xf4, 0x6c, 0x3b, 0xb0,
+	0x3c, 0x78, 0xd1, 0xeb, 0x1d, 0x0c, 0x06, 0xfa, 0x30, 0x27, 0x87, 0x4f, 0x0f, 0x9e, 0xbf, 0x38,
+	0xd1, 0xf3, 0x7a, 0x7b, 0xcf, 0x7a, 0x07, 0x47, 0x5e, 0x9d, 0xdc, 0x71, 0x70, 0x7c, 0xb4, 0xd7,
+	0x3b, 0xd0, 0xfa, 0x07, 0x2f, 0x9e, 0x3d, 0x3b, 0x7c, 0xf6, 0xa5, 0xd7, 0xdc, 0xd9, 0x87, 0x65,
+	0xd3, 0x70, 0xc7, 0x9d, 0x9d, 0x46, 0xb9, 0xb7, 0xc0, 0x6e, 0xc1, 0xba, 0xbe, 0x98, 0x05, 0x02,
+	0x6b, 0x1b, 0xf5, 0xa6, 0xb9, 0x92, 0x93, 0x01, 0xe6, 0xbc, 0x3d, 0xe5, 0x85, 0x3b, 0x0f, 0xa1,
+	0x65, 0x9b, 0xee, 0xb8, 0xb8, 0x9e, 0x13, 0x6a, 0x7d, 0xbe, 0x95, 0xd9, 0xb9, 0xf6, 0xfb, 0x2a,
+	0xb4, 0x7b, 0x12, 0x7f, 0x4f, 0xc1, 0xb1, 0xfa, 0xce, 0x1f, 0x55, 0xfe, 0x4b, 0x80, 0x40, 0x75,
+	0x9f, 0xc9, 0x6c, 0xc2, 0x63, 0x1d, 0x30, 0xf6, 0x9a, 0x78, 0x35, 0x76, 0x1b, 0x3c, 0x23, 0xe9,
+	0xc6, 0xdb, 0x23, 0xd8, 0x98, 0x43, 0x30, 0x3c, 0x82, 0xa3, 0xb1, 0x0e, 0x16, 0x02, 0x11, 0x4d,
+	0xd7, 0xf6, 0xbd, 0x1f, 0xff, 0xf3, 0x5e, 0xed, 0x87, 0xd7, 0xf7, 0x6a, 0x3f, 0xbe, 0xbe, 0x57,
+	0xfb, 0x8f, 0xd7, 0xf7, 0x6a, 0xa7, 0x4b, 0xf4, 0x84, 0x7e, 0xf8, 0xbf, 0x03, 0x00, 0xb8, 0x5c,
+	0x04, 0x77, 0xec, 0x21, 0x00, 0x00,
 }
 
 func (m *ShardEpoch) Marshal() (dAtA []byte, err error) {
@@ -3275,6 +4528,43 @@ func (m *Label) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *BlockingReason) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockingReason) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Operation != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Operation))
+	}
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.Time != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Time))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *ShardStats) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3335,6 +4625,11 @@ func (m *ShardStats) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n2
 	}
+	if m.ReadLatency != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ReadLatency))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -3483,6 +4778,79 @@ func (m *StoreStats) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.QuorumLostShards) > 0 {
+		dAtA5 := make([]byte, len(m.QuorumLostShards)*10)
+		var j4 int
+		for _, num := range m.QuorumLostShards {
+			for num >= 1<<7 {
+				dAtA5[j4] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j4++
+			}
+			dAtA5[j4] = uint8(num)
+			j4++
+		}
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(j4))
+		i += copy(dAtA[i:], dAtA5[:j4])
+	}
+	if m.ApplyBacklog != 0 {
+		dAtA[i] = 0xa0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ApplyBacklog))
+	}
+	if m.ApplyLatency != 0 {
+		dAtA[i] = 0xa8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ApplyLatency))
+	}
+	if len(m.PeerLatencies) > 0 {
+		for _, msg := range m.PeerLatencies {
+			dAtA[i] = 0xb2
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintMetapb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.InconsistentShards) > 0 {
+		for _, msg := range m.InconsistentShards {
+			dAtA[i] = 0xba
+			i++
+			dAtA[i] = 0x1
+			i++
+			i = encodeVarintMetapb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.DiskFailed {
+		dAtA[i] = 0xc0
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.DiskFailed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -3521,6 +4889,83 @@ func (m *RecordPair) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *PeerStoreLatency) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerStoreLatency) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.StoreID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.StoreID))
+	}
+	if m.Latency != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Latency))
+	}
+	if m.Samples != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Samples))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InconsistentShard) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InconsistentShard) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ShardID))
+	}
+	if m.Index != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Index))
+	}
+	if m.Hash != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Hash))
+	}
+	if m.ExpectHash != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ExpectHash))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *Member) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3863,11 +5308,11 @@ func (m *ShardExtra) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintMetapb(dAtA, i, uint64(m.DestroyingStatus.Size()))
-		n4, err := m.DestroyingStatus.MarshalTo(dAtA[i:])
+		n6, err := m.DestroyingStatus.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n4
+		i += n6
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -3918,6 +5363,44 @@ func (m *ScheduleGroupRule) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ClusterSetting) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ClusterSetting) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if m.Version != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *RaftMessageBatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3979,35 +5462,35 @@ func (m *RaftMessage) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintMetapb(dAtA, i, uint64(m.From.Size()))
-	n5, err := m.From.MarshalTo(dAtA[i:])
+	n7, err := m.From.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n5
+	i += n7
 	dAtA[i] = 0x22
 	i++
 	i = encodeVarintMetapb(dAtA, i, uint64(m.To.Size()))
-	n6, err := m.To.MarshalTo(dAtA[i:])
+	n8, err := m.To.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n8
 	dAtA[i] = 0x2a
 	i++
 	i = encodeVarintMetapb(dAtA, i, uint64(m.Message.Size()))
-	n7, err := m.Message.MarshalTo(dAtA[i:])
+	n9, err := m.Message.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n7
+	i += n9
 	dAtA[i] = 0x32
 	i++
 	i = encodeVarintMetapb(dAtA, i, uint64(m.ShardEpoch.Size()))
-	n8, err := m.ShardEpoch.MarshalTo(dAtA[i:])
+	n10, err := m.ShardEpoch.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n10
 	if m.IsTombstone {
 		dAtA[i] = 0x38
 		i++
@@ -4061,6 +5544,16 @@ func (m *RaftMessage) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMetapb(dAtA, i, uint64(m.SendTime))
 	}
+	if m.Hibernate {
+		dAtA[i] = 0x70
+		i++
+		if m.Hibernate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4165,18 +5658,25 @@ func (m *SnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1
 	i++
 	i = encodeVarintMetapb(dAtA, i, uint64(m.ConfState.Size()))
-	n9, err := m.ConfState.MarshalTo(dAtA[i:])
+	n11, err := m.ConfState.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n9
+	i += n11
+	if m.Checksum != 0 {
+		dAtA[i] = 0x88
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Checksum))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *StoreIdent) Marshal() (dAtA []byte, err error) {
+func (m *SnapshotResume) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -4186,27 +5686,141 @@ func (m *StoreIdent) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StoreIdent) MarshalTo(dAtA []byte) (int, error) {
+func (m *SnapshotResume) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.ClusterID != 0 {
+	if m.ShardID != 0 {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintMetapb(dAtA, i, uint64(m.ClusterID))
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ShardID))
 	}
-	if m.StoreID != 0 {
+	if m.ReplicaID != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintMetapb(dAtA, i, uint64(m.StoreID))
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ReplicaID))
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.From != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.From))
 	}
-	return i, nil
-}
-
+	if m.Index != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Index))
+	}
+	if m.Next != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Next))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *TransportHandshake) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransportHandshake) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ClusterID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ClusterID))
+	}
+	if m.StoreID != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.StoreID))
+	}
+	if m.TargetStoreID != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.TargetStoreID))
+	}
+	if m.ProtocolVersion != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ProtocolVersion))
+	}
+	if len(m.Version) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			dAtA[i] = 0x32
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Rejected) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Rejected)))
+		i += copy(dAtA[i:], m.Rejected)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *StoreIdent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreIdent) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ClusterID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ClusterID))
+	}
+	if m.StoreID != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.StoreID))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *Shard) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4242,11 +5856,11 @@ func (m *Shard) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x22
 	i++
 	i = encodeVarintMetapb(dAtA, i, uint64(m.Epoch.Size()))
-	n10, err := m.Epoch.MarshalTo(dAtA[i:])
+	n12, err := m.Epoch.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n10
+	i += n12
 	if m.State != 0 {
 		dAtA[i] = 0x28
 		i++
@@ -4302,6 +5916,127 @@ func (m *Shard) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	dAtA[i] = 0x5a
+	i++
+	i = encodeVarintMetapb(dAtA, i, uint64(m.Gate.Size()))
+	n13, err := m.Gate.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n13
+	dAtA[i] = 0x62
+	i++
+	i = encodeVarintMetapb(dAtA, i, uint64(m.AppLease.Size()))
+	n14, err := m.AppLease.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n14
+	if len(m.Attributes) > 0 {
+		dAtA[i] = 0x6a
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Attributes)))
+		i += copy(dAtA[i:], m.Attributes)
+	}
+	if m.TTL != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.TTL))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ShardGate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ShardGate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.DisableRead {
+		dAtA[i] = 0x8
+		i++
+		if m.DisableRead {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.DisableWrite {
+		dAtA[i] = 0x10
+		i++
+		if m.DisableWrite {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.Redirect) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Redirect)))
+		i += copy(dAtA[i:], m.Redirect)
+	}
+	if m.Permanent {
+		dAtA[i] = 0x20
+		i++
+		if m.Permanent {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *AppLease) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppLease) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Holder) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Holder)))
+		i += copy(dAtA[i:], m.Holder)
+	}
+	if m.Token != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Token))
+	}
+	if m.ExpireAt != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ExpireAt))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4367,11 +6102,11 @@ func (m *ShardMetadata) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x1a
 	i++
 	i = encodeVarintMetapb(dAtA, i, uint64(m.Metadata.Size()))
-	n11, err := m.Metadata.MarshalTo(dAtA[i:])
+	n15, err := m.Metadata.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n11
+	i += n15
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4396,20 +6131,20 @@ func (m *ShardLocalState) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintMetapb(dAtA, i, uint64(m.Shard.Size()))
-	n12, err := m.Shard.MarshalTo(dAtA[i:])
+	n16, err := m.Shard.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n12
+	i += n16
 	if m.Lease != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintMetapb(dAtA, i, uint64(m.Lease.Size()))
-		n13, err := m.Lease.MarshalTo(dAtA[i:])
+		n17, err := m.Lease.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n17
 	}
 	if m.State != 0 {
 		dAtA[i] = 0x18
@@ -4426,13 +6161,66 @@ func (m *ShardLocalState) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if len(m.ConfigChanges) > 0 {
+		for _, msg := range m.ConfigChanges {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintMetapb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Purges) > 0 {
+		for _, msg := range m.Purges {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintMetapb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.AppliedAdmins) > 0 {
+		for _, msg := range m.AppliedAdmins {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintMetapb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Dictionaries) > 0 {
+		for _, msg := range m.Dictionaries {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintMetapb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.LastWriteIndex != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.LastWriteIndex))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *Store) Marshal() (dAtA []byte, err error) {
+func (m *ConfigChangeRecord) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -4442,21 +6230,192 @@ func (m *Store) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Store) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConfigChangeRecord) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		dAtA[i] = 0x8
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintMetapb(dAtA, i, uint64(m.Epoch.Size()))
+	n18, err := m.Epoch.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n18
+	if m.ChangeType != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintMetapb(dAtA, i, uint64(m.ID))
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ChangeType))
 	}
-	if len(m.RaftAddress) > 0 {
-		dAtA[i] = 0x12
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintMetapb(dAtA, i, uint64(m.Replica.Size()))
+	n19, err := m.Replica.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n19
+	if m.Index != 0 {
+		dAtA[i] = 0x20
 		i++
-		i = encodeVarintMetapb(dAtA, i, uint64(len(m.RaftAddress)))
-		i += copy(dAtA[i:], m.RaftAddress)
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Index))
+	}
+	if len(m.Initiator) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Initiator)))
+		i += copy(dAtA[i:], m.Initiator)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *PurgeMarker) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PurgeMarker) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Index))
+	}
+	if len(m.Start) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Start)))
+		i += copy(dAtA[i:], m.Start)
+	}
+	if len(m.End) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.End)))
+		i += copy(dAtA[i:], m.End)
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintMetapb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *AppliedAdminRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AppliedAdminRecord) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.RequestID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.RequestID)))
+		i += copy(dAtA[i:], m.RequestID)
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintMetapb(dAtA, i, uint64(m.Epoch.Size()))
+	n1, err := m.Epoch.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n1
+	if m.Index != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Index))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CompressionDictionary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompressionDictionary) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Version))
+	}
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Store) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Store) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.ID))
+	}
+	if len(m.RaftAddress) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.RaftAddress)))
+		i += copy(dAtA[i:], m.RaftAddress)
 	}
 	if len(m.ClientAddress) > 0 {
 		dAtA[i] = 0x1a
@@ -4519,6 +6478,16 @@ func (m *Store) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.Draining {
+		dAtA[i] = 0x60
+		i++
+		if m.Draining {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4559,11 +6528,11 @@ func (m *ShardsPool) MarshalTo(dAtA []byte) (int, error) {
 				dAtA[i] = 0x12
 				i++
 				i = encodeVarintMetapb(dAtA, i, uint64(v.Size()))
-				n14, err := v.MarshalTo(dAtA[i:])
+				n20, err := v.MarshalTo(dAtA[i:])
 				if err != nil {
 					return 0, err
 				}
-				i += n14
+				i += n20
 			}
 		}
 	}
@@ -4688,21 +6657,21 @@ func (m *ShardsPoolCmd) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintMetapb(dAtA, i, uint64(m.Create.Size()))
-		n15, err := m.Create.MarshalTo(dAtA[i:])
+		n21, err := m.Create.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n21
 	}
 	if m.Alloc != nil {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintMetapb(dAtA, i, uint64(m.Alloc.Size()))
-		n16, err := m.Alloc.MarshalTo(dAtA[i:])
+		n22, err := m.Alloc.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n22
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -4793,6 +6762,56 @@ func (m *SnapshotInfo) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.Witness {
+		dAtA[i] = 0x18
+		i++
+		if m.Witness {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintMetapb(dAtA, i, uint64(m.Metadata.Size()))
+	n23, err := m.Metadata.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n23
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *SnapshotApplyingState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotApplyingState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(m.Index))
+	}
+	if len(m.Dir) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintMetapb(dAtA, i, uint64(len(m.Dir)))
+		i += copy(dAtA[i:], m.Dir)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -4918,6 +6937,28 @@ func (m *Label) Size() (n int) {
 	return n
 }
 
+func (m *BlockingReason) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Operation != 0 {
+		n += 1 + sovMetapb(uint64(m.Operation))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovMetapb(uint64(m.Time))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *ShardStats) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4949,6 +6990,9 @@ func (m *ShardStats) Size() (n int) {
 		l = m.Interval.Size()
 		n += 1 + l + sovMetapb(uint64(l))
 	}
+	if m.ReadLatency != 0 {
+		n += 1 + sovMetapb(uint64(m.ReadLatency))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5025,6 +7069,34 @@ func (m *StoreStats) Size() (n int) {
 			n += 2 + l + sovMetapb(uint64(l))
 		}
 	}
+	if len(m.QuorumLostShards) > 0 {
+		l = 0
+		for _, e := range m.QuorumLostShards {
+			l += sovMetapb(uint64(e))
+		}
+		n += 2 + sovMetapb(uint64(l)) + l
+	}
+	if m.ApplyBacklog != 0 {
+		n += 2 + sovMetapb(uint64(m.ApplyBacklog))
+	}
+	if m.ApplyLatency != 0 {
+		n += 2 + sovMetapb(uint64(m.ApplyLatency))
+	}
+	if len(m.PeerLatencies) > 0 {
+		for _, e := range m.PeerLatencies {
+			l = e.Size()
+			n += 2 + l + sovMetapb(uint64(l))
+		}
+	}
+	if len(m.InconsistentShards) > 0 {
+		for _, e := range m.InconsistentShards {
+			l = e.Size()
+			n += 2 + l + sovMetapb(uint64(l))
+		}
+	}
+	if m.DiskFailed {
+		n += 3
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5050,6 +7122,51 @@ func (m *RecordPair) Size() (n int) {
 	return n
 }
 
+func (m *PeerStoreLatency) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StoreID != 0 {
+		n += 1 + sovMetapb(uint64(m.StoreID))
+	}
+	if m.Latency != 0 {
+		n += 1 + sovMetapb(uint64(m.Latency))
+	}
+	if m.Samples != 0 {
+		n += 1 + sovMetapb(uint64(m.Samples))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InconsistentShard) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovMetapb(uint64(m.ShardID))
+	}
+	if m.Index != 0 {
+		n += 1 + sovMetapb(uint64(m.Index))
+	}
+	if m.Hash != 0 {
+		n += 1 + sovMetapb(uint64(m.Hash))
+	}
+	if m.ExpectHash != 0 {
+		n += 1 + sovMetapb(uint64(m.ExpectHash))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *Member) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5271,18 +7388,41 @@ func (m *ScheduleGroupRule) Size() (n int) {
 	return n
 }
 
-func (m *RaftMessageBatch) Size() (n int) {
+func (m *ClusterSetting) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Messages) > 0 {
-		for _, e := range m.Messages {
-			l = e.Size()
-			n += 1 + l + sovMetapb(uint64(l))
-		}
-	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovMetapb(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RaftMessageBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovMetapb(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5336,6 +7476,9 @@ func (m *RaftMessage) Size() (n int) {
 	if m.SendTime != 0 {
 		n += 1 + sovMetapb(uint64(m.SendTime))
 	}
+	if m.Hibernate {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5398,6 +7541,74 @@ func (m *SnapshotChunk) Size() (n int) {
 	}
 	l = m.ConfState.Size()
 	n += 2 + l + sovMetapb(uint64(l))
+	if m.Checksum != 0 {
+		n += 2 + sovMetapb(uint64(m.Checksum))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SnapshotResume) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovMetapb(uint64(m.ShardID))
+	}
+	if m.ReplicaID != 0 {
+		n += 1 + sovMetapb(uint64(m.ReplicaID))
+	}
+	if m.From != 0 {
+		n += 1 + sovMetapb(uint64(m.From))
+	}
+	if m.Index != 0 {
+		n += 1 + sovMetapb(uint64(m.Index))
+	}
+	if m.Next != 0 {
+		n += 1 + sovMetapb(uint64(m.Next))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransportHandshake) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ClusterID != 0 {
+		n += 1 + sovMetapb(uint64(m.ClusterID))
+	}
+	if m.StoreID != 0 {
+		n += 1 + sovMetapb(uint64(m.StoreID))
+	}
+	if m.TargetStoreID != 0 {
+		n += 1 + sovMetapb(uint64(m.TargetStoreID))
+	}
+	if m.ProtocolVersion != 0 {
+		n += 1 + sovMetapb(uint64(m.ProtocolVersion))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	if len(m.Capabilities) > 0 {
+		for _, s := range m.Capabilities {
+			l = len(s)
+			n += 1 + l + sovMetapb(uint64(l))
+		}
+	}
+	l = len(m.Rejected)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5469,6 +7680,64 @@ func (m *Shard) Size() (n int) {
 			n += 1 + l + sovMetapb(uint64(l))
 		}
 	}
+	l = m.Gate.Size()
+	n += 1 + l + sovMetapb(uint64(l))
+	l = m.AppLease.Size()
+	n += 1 + l + sovMetapb(uint64(l))
+	l = len(m.Attributes)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	if m.TTL != 0 {
+		n += 1 + sovMetapb(uint64(m.TTL))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ShardGate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DisableRead {
+		n += 2
+	}
+	if m.DisableWrite {
+		n += 2
+	}
+	l = len(m.Redirect)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	if m.Permanent {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AppLease) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Holder)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	if m.Token != 0 {
+		n += 1 + sovMetapb(uint64(m.Token))
+	}
+	if m.ExpireAt != 0 {
+		n += 1 + sovMetapb(uint64(m.ExpireAt))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5531,6 +7800,128 @@ func (m *ShardLocalState) Size() (n int) {
 	if m.RemoveData {
 		n += 2
 	}
+	if len(m.ConfigChanges) > 0 {
+		for _, e := range m.ConfigChanges {
+			l = e.Size()
+			n += 1 + l + sovMetapb(uint64(l))
+		}
+	}
+	if len(m.Purges) > 0 {
+		for _, e := range m.Purges {
+			l = e.Size()
+			n += 1 + l + sovMetapb(uint64(l))
+		}
+	}
+	if len(m.AppliedAdmins) > 0 {
+		for _, e := range m.AppliedAdmins {
+			l = e.Size()
+			n += 1 + l + sovMetapb(uint64(l))
+		}
+	}
+	if len(m.Dictionaries) > 0 {
+		for _, e := range m.Dictionaries {
+			l = e.Size()
+			n += 1 + l + sovMetapb(uint64(l))
+		}
+	}
+	if m.LastWriteIndex != 0 {
+		n += 1 + sovMetapb(uint64(m.LastWriteIndex))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConfigChangeRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Epoch.Size()
+	n += 1 + l + sovMetapb(uint64(l))
+	if m.ChangeType != 0 {
+		n += 1 + sovMetapb(uint64(m.ChangeType))
+	}
+	l = m.Replica.Size()
+	n += 1 + l + sovMetapb(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovMetapb(uint64(m.Index))
+	}
+	l = len(m.Initiator)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PurgeMarker) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovMetapb(uint64(m.Index))
+	}
+	l = len(m.Start)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	l = len(m.End)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovMetapb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AppliedAdminRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RequestID)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
+	l = m.Epoch.Size()
+	n += 1 + l + sovMetapb(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovMetapb(uint64(m.Index))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CompressionDictionary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovMetapb(uint64(m.Version))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5584,6 +7975,9 @@ func (m *Store) Size() (n int) {
 	if m.Destroyed {
 		n += 2
 	}
+	if m.Draining {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -5734,6 +8128,30 @@ func (m *SnapshotInfo) Size() (n int) {
 	if m.Dummy {
 		n += 2
 	}
+	if m.Witness {
+		n += 2
+	}
+	l = m.Metadata.Size()
+	n += 1 + l + sovMetapb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SnapshotApplyingState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovMetapb(uint64(m.Index))
+	}
+	l = len(m.Dir)
+	if l > 0 {
+		n += 1 + l + sovMetapb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -6218,7 +8636,7 @@ func (m *Label) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardStats) Unmarshal(dAtA []byte) error {
+func (m *BlockingReason) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6241,17 +8659,17 @@ func (m *ShardStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardStats: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockingReason: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockingReason: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
 			}
-			m.ShardID = 0
+			m.Operation = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -6261,14 +8679,138 @@ func (m *ShardStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
+				m.Operation |= ShardOperation(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WrittenBytes", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WrittenBytes", wireType)
 			}
 			m.WrittenBytes = 0
 			for shift := uint(0); ; shift += 7 {
@@ -6416,6 +8958,25 @@ func (m *ShardStats) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadLatency", wireType)
+			}
+			m.ReadLatency = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadLatency |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -6875,65 +9436,106 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipMetapb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RecordPair) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowMetapb
+		case 19:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMetapb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.QuorumLostShards = append(m.QuorumLostShards, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMetapb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthMetapb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthMetapb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.QuorumLostShards) == 0 {
+					m.QuorumLostShards = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMetapb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.QuorumLostShards = append(m.QuorumLostShards, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuorumLostShards", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplyBacklog", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ApplyBacklog = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApplyBacklog |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RecordPair: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RecordPair: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplyLatency", wireType)
 			}
-			var stringLen uint64
+			m.ApplyLatency = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -6943,29 +9545,84 @@ func (m *RecordPair) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.ApplyLatency |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerLatencies", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthMetapb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthMetapb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = string(dAtA[iNdEx:postIndex])
+			m.PeerLatencies = append(m.PeerLatencies, PeerStoreLatency{})
+			if err := m.PeerLatencies[len(m.PeerLatencies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InconsistentShards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InconsistentShards = append(m.InconsistentShards, InconsistentShard{})
+			if err := m.InconsistentShards[len(m.InconsistentShards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 24:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DiskFailed", wireType)
 			}
-			m.Value = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -6975,11 +9632,12 @@ func (m *RecordPair) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Value |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.DiskFailed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -7005,7 +9663,7 @@ func (m *RecordPair) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Member) Unmarshal(dAtA []byte) error {
+func (m *RecordPair) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7028,34 +9686,15 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Member: wiretype end group for non-group")
+			return fmt.Errorf("proto: RecordPair: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Member: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RecordPair: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			m.ID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7083,13 +9722,13 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Key = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var stringLen uint64
+			m.Value = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7099,24 +9738,11 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Value |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Addr = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -7142,7 +9768,7 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProphetCluster) Unmarshal(dAtA []byte) error {
+func (m *PeerStoreLatency) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7165,17 +9791,17 @@ func (m *ProphetCluster) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProphetCluster: wiretype end group for non-group")
+			return fmt.Errorf("proto: PeerStoreLatency: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProphetCluster: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PeerStoreLatency: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
 			}
-			m.ID = 0
+			m.StoreID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7185,16 +9811,16 @@ func (m *ProphetCluster) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
+				m.StoreID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxReplicaCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Latency", wireType)
 			}
-			m.MaxReplicaCount = 0
+			m.Latency = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7204,7 +9830,26 @@ func (m *ProphetCluster) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MaxReplicaCount |= uint32(b&0x7F) << shift
+				m.Latency |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
+			}
+			m.Samples = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Samples |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7234,7 +9879,7 @@ func (m *ProphetCluster) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TimeInterval) Unmarshal(dAtA []byte) error {
+func (m *InconsistentShard) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7257,17 +9902,17 @@ func (m *TimeInterval) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TimeInterval: wiretype end group for non-group")
+			return fmt.Errorf("proto: InconsistentShard: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TimeInterval: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InconsistentShard: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
 			}
-			m.Start = 0
+			m.ShardID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7277,16 +9922,16 @@ func (m *TimeInterval) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Start |= uint64(b&0x7F) << shift
+				m.ShardID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.End = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7296,7 +9941,45 @@ func (m *TimeInterval) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.End |= uint64(b&0x7F) << shift
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			m.Hash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hash |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectHash", wireType)
+			}
+			m.ExpectHash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectHash |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7326,7 +10009,7 @@ func (m *TimeInterval) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Job) Unmarshal(dAtA []byte) error {
+func (m *Member) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7349,17 +10032,17 @@ func (m *Job) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Job: wiretype end group for non-group")
+			return fmt.Errorf("proto: Member: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Job: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Member: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			m.Type = 0
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7369,16 +10052,16 @@ func (m *Job) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= JobType(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7388,31 +10071,29 @@ func (m *Job) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthMetapb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMetapb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Content = append(m.Content[:0], dAtA[iNdEx:postIndex]...)
-			if m.Content == nil {
-				m.Content = []byte{}
-			}
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
 			}
-			m.State = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7422,11 +10103,24 @@ func (m *Job) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= JobState(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -7452,7 +10146,7 @@ func (m *Job) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveShardJob) Unmarshal(dAtA []byte) error {
+func (m *ProphetCluster) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7475,10 +10169,10 @@ func (m *RemoveShardJob) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveShardJob: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProphetCluster: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveShardJob: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProphetCluster: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7501,10 +10195,10 @@ func (m *RemoveShardJob) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxReplicaCount", wireType)
 			}
-			var msglen int
+			m.MaxReplicaCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7514,26 +10208,11 @@ func (m *RemoveShardJob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.MaxReplicaCount |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Replicas = append(m.Replicas, Replica{})
-			if err := m.Replicas[len(m.Replicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -7559,7 +10238,7 @@ func (m *RemoveShardJob) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardPoolJob) Unmarshal(dAtA []byte) error {
+func (m *TimeInterval) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7582,17 +10261,17 @@ func (m *ShardPoolJob) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardPoolJob: wiretype end group for non-group")
+			return fmt.Errorf("proto: TimeInterval: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardPoolJob: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TimeInterval: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pools", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
 			}
-			var msglen int
+			m.Start = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7602,26 +10281,30 @@ func (m *ShardPoolJob) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Start |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
 			}
-			m.Pools = append(m.Pools, ShardPoolJobMeta{})
-			if err := m.Pools[len(m.Pools)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.End = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.End |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -7647,7 +10330,7 @@ func (m *ShardPoolJob) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardPoolJobMeta) Unmarshal(dAtA []byte) error {
+func (m *Job) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7670,17 +10353,17 @@ func (m *ShardPoolJobMeta) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardPoolJobMeta: wiretype end group for non-group")
+			return fmt.Errorf("proto: Job: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardPoolJobMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Job: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			m.Group = 0
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7690,33 +10373,14 @@ func (m *ShardPoolJobMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Group |= uint64(b&0x7F) << shift
+				m.Type |= JobType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
-			}
-			m.Capacity = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Capacity |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RangePrefix", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -7743,11 +10407,30 @@ func (m *ShardPoolJobMeta) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RangePrefix = append(m.RangePrefix[:0], dAtA[iNdEx:postIndex]...)
-			if m.RangePrefix == nil {
-				m.RangePrefix = []byte{}
+			m.Content = append(m.Content[:0], dAtA[iNdEx:postIndex]...)
+			if m.Content == nil {
+				m.Content = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= JobState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -7773,7 +10456,7 @@ func (m *ShardPoolJobMeta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DestroyingStatus) Unmarshal(dAtA []byte) error {
+func (m *RemoveShardJob) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7796,17 +10479,17 @@ func (m *DestroyingStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DestroyingStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: RemoveShardJob: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DestroyingStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RemoveShardJob: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			m.Index = 0
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -7816,7 +10499,7 @@ func (m *DestroyingStatus) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= uint64(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7850,117 +10533,11 @@ func (m *DestroyingStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Replicas == nil {
-				m.Replicas = make(map[uint64]bool)
-			}
-			var mapkey uint64
-			var mapvalue bool
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowMetapb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowMetapb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else if fieldNum == 2 {
-					var mapvaluetemp int
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowMetapb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvaluetemp |= int(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					mapvalue = bool(mapvaluetemp != 0)
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipMetapb(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthMetapb
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			m.Replicas = append(m.Replicas, Replica{})
+			if err := m.Replicas[len(m.Replicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Replicas[mapkey] = mapvalue
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
-			}
-			m.State = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.State |= ShardState(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveData", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.RemoveData = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -7986,7 +10563,7 @@ func (m *DestroyingStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardExtra) Unmarshal(dAtA []byte) error {
+func (m *ShardPoolJob) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8009,15 +10586,15 @@ func (m *ShardExtra) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardExtra: wiretype end group for non-group")
+			return fmt.Errorf("proto: ShardPoolJob: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardExtra: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ShardPoolJob: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pools", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8044,138 +10621,9 @@ func (m *ShardExtra) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = make(map[string]string)
-			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowMetapb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowMetapb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthMetapb
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthMetapb
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowMetapb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthMetapb
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue < 0 {
-						return ErrInvalidLengthMetapb
-					}
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipMetapb(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthMetapb
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.Labels[mapkey] = mapvalue
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DestroyingStatus", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.DestroyingStatus == nil {
-				m.DestroyingStatus = &DestroyingStatus{}
-			}
-			if err := m.DestroyingStatus.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Pools = append(m.Pools, ShardPoolJobMeta{})
+			if err := m.Pools[len(m.Pools)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -8203,7 +10651,7 @@ func (m *ShardExtra) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ScheduleGroupRule) Unmarshal(dAtA []byte) error {
+func (m *ShardPoolJobMeta) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8226,17 +10674,17 @@ func (m *ScheduleGroupRule) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ScheduleGroupRule: wiretype end group for non-group")
+			return fmt.Errorf("proto: ShardPoolJobMeta: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ScheduleGroupRule: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ShardPoolJobMeta: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
 			}
-			m.ID = 0
+			m.Group = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8246,16 +10694,16 @@ func (m *ScheduleGroupRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
+				m.Group |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
 			}
-			m.GroupID = 0
+			m.Capacity = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8265,16 +10713,16 @@ func (m *ScheduleGroupRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupID |= uint64(b&0x7F) << shift
+				m.Capacity |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RangePrefix", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8284,55 +10732,25 @@ func (m *ScheduleGroupRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthMetapb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMetapb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupByLabel", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.RangePrefix = append(m.RangePrefix[:0], dAtA[iNdEx:postIndex]...)
+			if m.RangePrefix == nil {
+				m.RangePrefix = []byte{}
 			}
-			m.GroupByLabel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -8359,7 +10777,7 @@ func (m *ScheduleGroupRule) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftMessageBatch) Unmarshal(dAtA []byte) error {
+func (m *DestroyingStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8382,15 +10800,34 @@ func (m *RaftMessageBatch) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftMessageBatch: wiretype end group for non-group")
+			return fmt.Errorf("proto: DestroyingStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftMessageBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DestroyingStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8417,48 +10854,2087 @@ func (m *RaftMessageBatch) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Messages = append(m.Messages, RaftMessage{})
-			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipMetapb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RaftMessage) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowMetapb
+			if m.Replicas == nil {
+				m.Replicas = make(map[uint64]bool)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var mapkey uint64
+			var mapvalue bool
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMetapb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMetapb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					var mapvaluetemp int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMetapb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvaluetemp |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					mapvalue = bool(mapvaluetemp != 0)
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipMetapb(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthMetapb
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Replicas[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= ShardState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveData", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RemoveData = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardExtra) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardExtra: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardExtra: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMetapb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMetapb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthMetapb
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthMetapb
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMetapb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthMetapb
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthMetapb
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipMetapb(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthMetapb
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Labels[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DestroyingStatus", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DestroyingStatus == nil {
+				m.DestroyingStatus = &DestroyingStatus{}
+			}
+			if err := m.DestroyingStatus.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScheduleGroupRule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScheduleGroupRule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScheduleGroupRule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupByLabel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupByLabel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ClusterSetting) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ClusterSetting: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ClusterSetting: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftMessageBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftMessageBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftMessageBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Messages = append(m.Messages, RaftMessage{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			m.Group = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Group |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.To.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardEpoch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ShardEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsTombstone", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsTombstone = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
+			if m.Start == nil {
+				m.Start = []byte{}
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
+			if m.End == nil {
+				m.End = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unique", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Unique = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RuleGroups", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RuleGroups = append(m.RuleGroups, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitIndex", wireType)
+			}
+			m.CommitIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CommitIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendTime", wireType)
+			}
+			m.SendTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SendTime |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hibernate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Hibernate = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+			}
+			m.StoreID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
+			}
+			m.ReplicaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplicaID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			m.From = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.From |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkID", wireType)
+			}
+			m.ChunkID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkSize", wireType)
+			}
+			m.ChunkSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkCount", wireType)
+			}
+			m.ChunkCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChunkCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FilePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FilePath = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileSize", wireType)
+			}
+			m.FileSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FileSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileChunkID", wireType)
+			}
+			m.FileChunkID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FileChunkID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileChunkCount", wireType)
+			}
+			m.FileChunkCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FileChunkCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extra", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Extra = append(m.Extra[:0], dAtA[iNdEx:postIndex]...)
+			if m.Extra == nil {
+				m.Extra = []byte{}
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ConfState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
+			}
+			m.Checksum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Checksum |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotResume) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotResume: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotResume: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
+			}
+			m.ReplicaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplicaID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			m.From = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.From |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Next", wireType)
+			}
+			m.Next = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Next |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransportHandshake) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransportHandshake: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransportHandshake: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterID", wireType)
+			}
+			m.ClusterID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClusterID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+			}
+			m.StoreID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetStoreID", wireType)
+			}
+			m.TargetStoreID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetStoreID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
+			}
+			m.ProtocolVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProtocolVersion |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Capabilities = append(m.Capabilities, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rejected", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rejected = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreIdent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreIdent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreIdent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterID", wireType)
+			}
+			m.ClusterID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ClusterID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+			}
+			m.StoreID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Shard) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
@@ -8470,17 +12946,190 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: Shard: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Shard: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
+			if m.Start == nil {
+				m.Start = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
+			if m.End == nil {
+				m.End = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Epoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= ShardState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Replicas = append(m.Replicas, Replica{})
+			if err := m.Replicas[len(m.Replicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
 			}
-			m.ShardID = 0
+			m.Group = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8490,16 +13139,16 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
+				m.Group |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unique", wireType)
 			}
-			m.Group = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8509,16 +13158,29 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Group |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Unique = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RuleGroups", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8528,28 +13190,27 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthMetapb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMetapb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.From.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.RuleGroups = append(m.RuleGroups, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 4:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8576,13 +13237,14 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.To.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Labels = append(m.Labels, Label{})
+			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Gate", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8609,13 +13271,13 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Message.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Gate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardEpoch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppLease", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8642,15 +13304,15 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ShardEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.AppLease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsTombstone", wireType)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attributes", wireType)
 			}
-			var v int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8660,17 +13322,31 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsTombstone = bool(v != 0)
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
 			}
-			var byteLen int
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attributes = append(m.Attributes[:0], dAtA[iNdEx:postIndex]...)
+			if m.Attributes == nil {
+				m.Attributes = []byte{}
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
+			}
+			m.TTL = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8680,31 +13356,70 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.TTL |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthMetapb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthMetapb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
-			if m.Start == nil {
-				m.Start = []byte{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardGate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
 			}
-			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var byteLen int
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardGate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardGate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableRead", wireType)
+			}
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8714,29 +13429,35 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.DisableRead = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableWrite", wireType)
 			}
-			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
-			if m.End == nil {
-				m.End = []byte{}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 10:
+			m.DisableWrite = bool(v != 0)
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Unique", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Redirect", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8764,11 +13485,85 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Unique = string(dAtA[iNdEx:postIndex])
+			m.Redirect = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 11:
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permanent", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Permanent = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AppLease) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AppLease: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AppLease: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RuleGroups", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Holder", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8796,13 +13591,13 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RuleGroups = append(m.RuleGroups, string(dAtA[iNdEx:postIndex]))
+			m.Holder = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 12:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CommitIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			m.CommitIndex = 0
+			m.Token = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8812,16 +13607,16 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CommitIndex |= uint64(b&0x7F) << shift
+				m.Token |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 13:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SendTime", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireAt", wireType)
 			}
-			m.SendTime = 0
+			m.ExpireAt = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8831,7 +13626,7 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SendTime |= uint64(b&0x7F) << shift
+				m.ExpireAt |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -8861,7 +13656,7 @@ func (m *RaftMessage) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
+func (m *LogIndex) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8884,17 +13679,17 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SnapshotChunk: wiretype end group for non-group")
+			return fmt.Errorf("proto: LogIndex: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SnapshotChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LogIndex: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.StoreID = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8904,16 +13699,16 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StoreID |= uint64(b&0x7F) << shift
+				m.Index |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-			m.ShardID = 0
+			m.Term = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8923,73 +13718,70 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
+				m.Term |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			m.ReplicaID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ReplicaID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
 			}
-			m.From = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.From |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChunkID", wireType)
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardMetadata) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
 			}
-			m.ChunkID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ChunkID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-		case 6:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardMetadata: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChunkSize", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
 			}
-			m.ChunkSize = 0
+			m.ShardID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -8999,16 +13791,16 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ChunkSize |= uint64(b&0x7F) << shift
+				m.ShardID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChunkCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LogIndex", wireType)
 			}
-			m.ChunkCount = 0
+			m.LogIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9018,16 +13810,16 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ChunkCount |= uint64(b&0x7F) << shift
+				m.LogIndex |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
-			m.Index = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9037,35 +13829,84 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
 			}
-			m.Term = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Term |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
 			}
-		case 10:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardLocalState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardLocalState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardLocalState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FilePath", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9075,29 +13916,30 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthMetapb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthMetapb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.FilePath = string(dAtA[iNdEx:postIndex])
+			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FileSize", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
 			}
-			m.FileSize = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9107,16 +13949,33 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FileSize |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 12:
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Lease == nil {
+				m.Lease = &EpochLease{}
+			}
+			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FileChunkID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			m.FileChunkID = 0
+			m.State = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9126,16 +13985,16 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FileChunkID |= uint64(b&0x7F) << shift
+				m.State |= ReplicaState(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 13:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FileChunkCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveData", wireType)
 			}
-			m.FileChunkCount = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9145,16 +14004,17 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.FileChunkCount |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 14:
+			m.RemoveData = bool(v != 0)
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigChanges", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9164,31 +14024,31 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthMetapb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthMetapb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.ConfigChanges = append(m.ConfigChanges, ConfigChangeRecord{})
+			if err := m.ConfigChanges[len(m.ConfigChanges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 15:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Extra", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Purges", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9198,29 +14058,29 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthMetapb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthMetapb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Extra = append(m.Extra[:0], dAtA[iNdEx:postIndex]...)
-			if m.Extra == nil {
-				m.Extra = []byte{}
+			m.Purges = append(m.Purges, PurgeMarker{})
+			if err := m.Purges[len(m.Purges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 16:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConfState", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedAdmins", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9247,69 +14107,16 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ConfState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.AppliedAdmins = append(m.AppliedAdmins, AppliedAdminRecord{})
+			if err := m.AppliedAdmins[len(m.AppliedAdmins)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipMetapb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *StoreIdent) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowMetapb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: StoreIdent: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreIdent: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClusterID", wireType)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dictionaries", wireType)
 			}
-			m.ClusterID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9319,16 +14126,31 @@ func (m *StoreIdent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ClusterID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dictionaries = append(m.Dictionaries, CompressionDictionary{})
+			if err := m.Dictionaries[len(m.Dictionaries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastWriteIndex", wireType)
 			}
-			m.StoreID = 0
+			m.LastWriteIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9338,7 +14160,7 @@ func (m *StoreIdent) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StoreID |= uint64(b&0x7F) << shift
+				m.LastWriteIndex |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -9368,7 +14190,7 @@ func (m *StoreIdent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Shard) Unmarshal(dAtA []byte) error {
+func (m *ConfigChangeRecord) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9378,113 +14200,26 @@ func (m *Shard) Unmarshal(dAtA []byte) error {
 			if shift >= 64 {
 				return ErrIntOverflowMetapb
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Shard: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Shard: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			m.ID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
-			if m.Start == nil {
-				m.Start = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
-			if m.End == nil {
-				m.End = []byte{}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 4:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigChangeRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigChangeRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
 			}
@@ -9517,11 +14252,11 @@ func (m *Shard) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
 			}
-			m.State = 0
+			m.ChangeType = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9531,14 +14266,14 @@ func (m *Shard) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= ShardState(b&0x7F) << shift
+				m.ChangeType |= ConfigChangeType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9565,35 +14300,15 @@ func (m *Shard) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Replicas = append(m.Replicas, Replica{})
-			if err := m.Replicas[len(m.Replicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Replica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
-			}
-			m.Group = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Group |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Unique", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var stringLen uint64
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9603,27 +14318,14 @@ func (m *Shard) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Index |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Unique = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 9:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RuleGroups", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Initiator", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9651,41 +14353,7 @@ func (m *Shard) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RuleGroups = append(m.RuleGroups, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 10:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Labels = append(m.Labels, Label{})
-			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Initiator = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9712,7 +14380,7 @@ func (m *Shard) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LogIndex) Unmarshal(dAtA []byte) error {
+func (m *PurgeMarker) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9735,10 +14403,10 @@ func (m *LogIndex) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LogIndex: wiretype end group for non-group")
+			return fmt.Errorf("proto: PurgeMarker: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LogIndex: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PurgeMarker: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -9761,10 +14429,10 @@ func (m *LogIndex) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
 			}
-			m.Term = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9774,11 +14442,92 @@ func (m *LogIndex) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
+			if m.Start == nil {
+				m.Start = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
+			if m.End == nil {
+				m.End = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
+			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -9804,7 +14553,7 @@ func (m *LogIndex) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardMetadata) Unmarshal(dAtA []byte) error {
+func (m *AppliedAdminRecord) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9827,17 +14576,17 @@ func (m *ShardMetadata) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardMetadata: wiretype end group for non-group")
+			return fmt.Errorf("proto: AppliedAdminRecord: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AppliedAdminRecord: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestID", wireType)
 			}
-			m.ShardID = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9847,33 +14596,29 @@ func (m *ShardMetadata) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogIndex", wireType)
+			if byteLen < 0 {
+				return ErrInvalidLengthMetapb
 			}
-			m.LogIndex = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.LogIndex |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
 			}
-		case 3:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestID = append(m.RequestID[:0], dAtA[iNdEx:postIndex]...)
+			if m.RequestID == nil {
+				m.RequestID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9900,10 +14645,29 @@ func (m *ShardMetadata) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Epoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			iNdEx = postIndex
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -9929,7 +14693,7 @@ func (m *ShardMetadata) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardLocalState) Unmarshal(dAtA []byte) error {
+func (m *CompressionDictionary) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9952,17 +14716,17 @@ func (m *ShardLocalState) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardLocalState: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompressionDictionary: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardLocalState: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompressionDictionary: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var msglen int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -9972,30 +14736,16 @@ func (m *ShardLocalState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Version |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthMetapb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowMetapb
@@ -10005,67 +14755,26 @@ func (m *ShardLocalState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthMetapb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthMetapb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Lease == nil {
-				m.Lease = &EpochLease{}
-			}
-			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
-			}
-			m.State = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.State |= ReplicaState(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveData", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowMetapb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.RemoveData = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -10410,6 +15119,26 @@ func (m *Store) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Destroyed = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Draining", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Draining = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -11283,6 +16012,164 @@ func (m *SnapshotInfo) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Dummy = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Witness", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Witness = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMetapb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SnapshotApplyingState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMetapb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotApplyingState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotApplyingState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dir", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMetapb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMetapb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dir = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMetapb(dAtA[iNdEx:])
@@ -54,6 +54,55 @@ func (m *RequestBatch) GetTransferLeaderRequest() TransferLeaderRequest {
 	return req
 }
 
+// GetSplitShardRequest return SplitShardRequest request
+func (m *RequestBatch) GetSplitShardRequest() SplitShardRequest {
+	var req SplitShardRequest
+	protoc.MustUnmarshal(&req, m.GetAdminRequest().Cmd)
+	return req
+}
+
+// GetDeleteRangeRequest return DeleteRangeRequest request
+func (m *RequestBatch) GetDeleteRangeRequest() DeleteRangeRequest {
+	var req DeleteRangeRequest
+	protoc.MustUnmarshal(&req, m.GetAdminRequest().Cmd)
+	return req
+}
+
+// GetBarrierRequest return BarrierRequest request
+func (m *RequestBatch) GetBarrierRequest() BarrierRequest {
+	var req BarrierRequest
+	protoc.MustUnmarshal(&req, m.GetAdminRequest().Cmd)
+	return req
+}
+
+// GetComputeHashRequest return ComputeHashRequest request
+func (m *RequestBatch) GetComputeHashRequest() ComputeHashRequest {
+	var req ComputeHashRequest
+	protoc.MustUnmarshal(&req, m.GetAdminRequest().Cmd)
+	return req
+}
+
+// GetVerifyHashRequest return VerifyHashRequest request
+func (m *RequestBatch) GetVerifyHashRequest() VerifyHashRequest {
+	var req VerifyHashRequest
+	protoc.MustUnmarshal(&req, m.GetAdminRequest().Cmd)
+	return req
+}
+
+// GetPurgeRequest return PurgeRequest request
+func (m *RequestBatch) GetPurgeRequest() PurgeRequest {
+	var req PurgeRequest
+	protoc.MustUnmarshal(&req, m.GetAdminRequest().Cmd)
+	return req
+}
+
+// GetUpdateDictionaryRequest return UpdateDictionaryRequest request
+func (m *RequestBatch) GetUpdateDictionaryRequest() UpdateDictionaryRequest {
+	var req UpdateDictionaryRequest
+	protoc.MustUnmarshal(&req, m.GetAdminRequest().Cmd)
+	return req
+}
+
 // GetUpdateLabelsRequest return UpdateLabelsRequest request
 func (m *RequestBatch) GetUpdateLabelsRequest() UpdateLabelsRequest {
 	var req UpdateLabelsRequest
@@ -68,6 +117,27 @@ func (m *RequestBatch) GetUpdateEpochLeaseRequest() UpdateEpochLeaseRequest {
 	return req
 }
 
+// GetUpdateGateRequest return UpdateGateRequest request
+func (m *RequestBatch) GetUpdateGateRequest() UpdateGateRequest {
+	var req UpdateGateRequest
+	protoc.MustUnmarshal(&req, m.GetAdminRequest().Cmd)
+	return req
+}
+
+// GetAcquireAppLeaseRequest return AcquireAppLeaseRequest request
+func (m *RequestBatch) GetAcquireAppLeaseRequest() AcquireAppLeaseRequest {
+	var req AcquireAppLeaseRequest
+	protoc.MustUnmarshal(&req, m.GetAdminRequest().Cmd)
+	return req
+}
+
+// GetReleaseAppLeaseRequest return ReleaseAppLeaseRequest request
+func (m *RequestBatch) GetReleaseAppLeaseRequest() ReleaseAppLeaseRequest {
+	var req ReleaseAppLeaseRequest
+	protoc.MustUnmarshal(&req, m.GetAdminRequest().Cmd)
+	return req
+}
+
 // IsEmpty returns true if is a empty batch
 func (m *RequestBatch) IsEmpty() bool {
 	return len(m.Header.ID) == 0
@@ -140,6 +210,27 @@ func (m *ResponseBatch) GetUpdateLabelsResponse() UpdateLabelsResponse {
 	return req
 }
 
+// GetUpdateGateResponse return UpdateGateResponse Response
+func (m *ResponseBatch) GetUpdateGateResponse() UpdateGateResponse {
+	var req UpdateGateResponse
+	protoc.MustUnmarshal(&req, m.GetAdminResponse().Value)
+	return req
+}
+
+// GetAcquireAppLeaseResponse return AcquireAppLeaseResponse Response
+func (m *ResponseBatch) GetAcquireAppLeaseResponse() AcquireAppLeaseResponse {
+	var req AcquireAppLeaseResponse
+	protoc.MustUnmarshal(&req, m.GetAdminResponse().Value)
+	return req
+}
+
+// GetReleaseAppLeaseResponse return ReleaseAppLeaseResponse Response
+func (m *ResponseBatch) GetReleaseAppLeaseResponse() ReleaseAppLeaseResponse {
+	var req ReleaseAppLeaseResponse
+	protoc.MustUnmarshal(&req, m.GetAdminResponse().Value)
+	return req
+}
+
 // GetTransferLeaderResponse return TransferLeaderResponse Response
 func (m *ResponseBatch) GetTransferLeaderResponse() TransferLeaderResponse {
 	var req TransferLeaderResponse
@@ -147,6 +238,55 @@ func (m *ResponseBatch) GetTransferLeaderResponse() TransferLeaderResponse {
 	return req
 }
 
+// GetSplitShardResponse return SplitShardResponse Response
+func (m *ResponseBatch) GetSplitShardResponse() SplitShardResponse {
+	var req SplitShardResponse
+	protoc.MustUnmarshal(&req, m.GetAdminResponse().Value)
+	return req
+}
+
+// GetDeleteRangeResponse return DeleteRangeResponse Response
+func (m *ResponseBatch) GetDeleteRangeResponse() DeleteRangeResponse {
+	var req DeleteRangeResponse
+	protoc.MustUnmarshal(&req, m.GetAdminResponse().Value)
+	return req
+}
+
+// GetBarrierResponse return BarrierResponse Response
+func (m *ResponseBatch) GetBarrierResponse() BarrierResponse {
+	var req BarrierResponse
+	protoc.MustUnmarshal(&req, m.GetAdminResponse().Value)
+	return req
+}
+
+// GetComputeHashResponse return ComputeHashResponse Response
+func (m *ResponseBatch) GetComputeHashResponse() ComputeHashResponse {
+	var req ComputeHashResponse
+	protoc.MustUnmarshal(&req, m.GetAdminResponse().Value)
+	return req
+}
+
+// GetVerifyHashResponse return VerifyHashResponse Response
+func (m *ResponseBatch) GetVerifyHashResponse() VerifyHashResponse {
+	var req VerifyHashResponse
+	protoc.MustUnmarshal(&req, m.GetAdminResponse().Value)
+	return req
+}
+
+// GetPurgeResponse return PurgeResponse Response
+func (m *ResponseBatch) GetPurgeResponse() PurgeResponse {
+	var req PurgeResponse
+	protoc.MustUnmarshal(&req, m.GetAdminResponse().Value)
+	return req
+}
+
+// GetUpdateDictionaryResponse return UpdateDictionaryResponse Response
+func (m *ResponseBatch) GetUpdateDictionaryResponse() UpdateDictionaryResponse {
+	var req UpdateDictionaryResponse
+	protoc.MustUnmarshal(&req, m.GetAdminResponse().Value)
+	return req
+}
+
 // IsTransaction returns true if the request is transaction request
 func (m Request) IsTransaction() bool {
 	return uint64(CmdUpdateTxnRecord) <= m.CustomType &&
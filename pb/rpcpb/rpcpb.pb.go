@@ -31,47 +31,55 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type Type int32
 
 const (
-	TypeRegisterStore           Type = 0
-	TypeShardHeartbeatReq       Type = 1
-	TypeShardHeartbeatRsp       Type = 2
-	TypeStoreHeartbeatReq       Type = 3
-	TypeStoreHeartbeatRsp       Type = 4
-	TypePutStoreReq             Type = 5
-	TypePutStoreRsp             Type = 6
-	TypeGetStoreReq             Type = 7
-	TypeGetStoreRsp             Type = 8
-	TypeAllocIDReq              Type = 9
-	TypeAllocIDRsp              Type = 10
-	TypeAskBatchSplitReq        Type = 11
-	TypeAskBatchSplitRsp        Type = 12
-	TypeCreateDestroyingReq     Type = 13
-	TypeCreateDestroyingRsp     Type = 14
-	TypeReportDestroyedReq      Type = 15
-	TypeReportDestroyedRsp      Type = 16
-	TypeGetDestroyingReq        Type = 17
-	TypeGetDestroyingRsp        Type = 18
-	TypeCreateWatcherReq        Type = 19
-	TypeEventNotify             Type = 20
-	TypeCreateShardsReq         Type = 21
-	TypeCreateShardsRsp         Type = 22
-	TypeRemoveShardsReq         Type = 23
-	TypeRemoveShardsRsp         Type = 24
-	TypeCheckShardStateReq      Type = 25
-	TypeCheckShardStateRsp      Type = 26
-	TypePutPlacementRuleReq     Type = 27
-	TypePutPlacementRuleRsp     Type = 28
-	TypeGetAppliedRulesReq      Type = 29
-	TypeGetAppliedRulesRsp      Type = 30
-	TypeCreateJobReq            Type = 31
-	TypeCreateJobRsp            Type = 32
-	TypeRemoveJobReq            Type = 33
-	TypeRemoveJobRsp            Type = 34
-	TypeExecuteJobReq           Type = 35
-	TypeExecuteJobRsp           Type = 36
-	TypeAddScheduleGroupRuleReq Type = 37
-	TypeAddScheduleGroupRuleRsp Type = 38
-	TypeGetScheduleGroupRuleReq Type = 39
-	TypeGetScheduleGroupRuleRsp Type = 40
+	TypeRegisterStore             Type = 0
+	TypeShardHeartbeatReq         Type = 1
+	TypeShardHeartbeatRsp         Type = 2
+	TypeStoreHeartbeatReq         Type = 3
+	TypeStoreHeartbeatRsp         Type = 4
+	TypePutStoreReq               Type = 5
+	TypePutStoreRsp               Type = 6
+	TypeGetStoreReq               Type = 7
+	TypeGetStoreRsp               Type = 8
+	TypeAllocIDReq                Type = 9
+	TypeAllocIDRsp                Type = 10
+	TypeAskBatchSplitReq          Type = 11
+	TypeAskBatchSplitRsp          Type = 12
+	TypeCreateDestroyingReq       Type = 13
+	TypeCreateDestroyingRsp       Type = 14
+	TypeReportDestroyedReq        Type = 15
+	TypeReportDestroyedRsp        Type = 16
+	TypeGetDestroyingReq          Type = 17
+	TypeGetDestroyingRsp          Type = 18
+	TypeCreateWatcherReq          Type = 19
+	TypeEventNotify               Type = 20
+	TypeCreateShardsReq           Type = 21
+	TypeCreateShardsRsp           Type = 22
+	TypeRemoveShardsReq           Type = 23
+	TypeRemoveShardsRsp           Type = 24
+	TypeCheckShardStateReq        Type = 25
+	TypeCheckShardStateRsp        Type = 26
+	TypePutPlacementRuleReq       Type = 27
+	TypePutPlacementRuleRsp       Type = 28
+	TypeGetAppliedRulesReq        Type = 29
+	TypeGetAppliedRulesRsp        Type = 30
+	TypeCreateJobReq              Type = 31
+	TypeCreateJobRsp              Type = 32
+	TypeRemoveJobReq              Type = 33
+	TypeRemoveJobRsp              Type = 34
+	TypeExecuteJobReq             Type = 35
+	TypeExecuteJobRsp             Type = 36
+	TypeAddScheduleGroupRuleReq   Type = 37
+	TypeAddScheduleGroupRuleRsp   Type = 38
+	TypeGetScheduleGroupRuleReq   Type = 39
+	TypeGetScheduleGroupRuleRsp   Type = 40
+	TypeCheckTombstoneReplicasReq Type = 41
+	TypeCheckTombstoneReplicasRsp Type = 42
+	TypePutClusterSettingReq      Type = 43
+	TypePutClusterSettingRsp      Type = 44
+	TypeGetClusterSettingsReq     Type = 45
+	TypeGetClusterSettingsRsp     Type = 46
+	TypeScatterShardsReq          Type = 47
+	TypeScatterShardsRsp          Type = 48
 )
 
 var Type_name = map[int32]string{
@@ -116,50 +124,66 @@ var Type_name = map[int32]string{
 	38: "TypeAddScheduleGroupRuleRsp",
 	39: "TypeGetScheduleGroupRuleReq",
 	40: "TypeGetScheduleGroupRuleRsp",
+	41: "TypeCheckTombstoneReplicasReq",
+	42: "TypeCheckTombstoneReplicasRsp",
+	43: "TypePutClusterSettingReq",
+	44: "TypePutClusterSettingRsp",
+	45: "TypeGetClusterSettingsReq",
+	46: "TypeGetClusterSettingsRsp",
+	47: "TypeScatterShardsReq",
+	48: "TypeScatterShardsRsp",
 }
 
 var Type_value = map[string]int32{
-	"TypeRegisterStore":           0,
-	"TypeShardHeartbeatReq":       1,
-	"TypeShardHeartbeatRsp":       2,
-	"TypeStoreHeartbeatReq":       3,
-	"TypeStoreHeartbeatRsp":       4,
-	"TypePutStoreReq":             5,
-	"TypePutStoreRsp":             6,
-	"TypeGetStoreReq":             7,
-	"TypeGetStoreRsp":             8,
-	"TypeAllocIDReq":              9,
-	"TypeAllocIDRsp":              10,
-	"TypeAskBatchSplitReq":        11,
-	"TypeAskBatchSplitRsp":        12,
-	"TypeCreateDestroyingReq":     13,
-	"TypeCreateDestroyingRsp":     14,
-	"TypeReportDestroyedReq":      15,
-	"TypeReportDestroyedRsp":      16,
-	"TypeGetDestroyingReq":        17,
-	"TypeGetDestroyingRsp":        18,
-	"TypeCreateWatcherReq":        19,
-	"TypeEventNotify":             20,
-	"TypeCreateShardsReq":         21,
-	"TypeCreateShardsRsp":         22,
-	"TypeRemoveShardsReq":         23,
-	"TypeRemoveShardsRsp":         24,
-	"TypeCheckShardStateReq":      25,
-	"TypeCheckShardStateRsp":      26,
-	"TypePutPlacementRuleReq":     27,
-	"TypePutPlacementRuleRsp":     28,
-	"TypeGetAppliedRulesReq":      29,
-	"TypeGetAppliedRulesRsp":      30,
-	"TypeCreateJobReq":            31,
-	"TypeCreateJobRsp":            32,
-	"TypeRemoveJobReq":            33,
-	"TypeRemoveJobRsp":            34,
-	"TypeExecuteJobReq":           35,
-	"TypeExecuteJobRsp":           36,
-	"TypeAddScheduleGroupRuleReq": 37,
-	"TypeAddScheduleGroupRuleRsp": 38,
-	"TypeGetScheduleGroupRuleReq": 39,
-	"TypeGetScheduleGroupRuleRsp": 40,
+	"TypeRegisterStore":             0,
+	"TypeShardHeartbeatReq":         1,
+	"TypeShardHeartbeatRsp":         2,
+	"TypeStoreHeartbeatReq":         3,
+	"TypeStoreHeartbeatRsp":         4,
+	"TypePutStoreReq":               5,
+	"TypePutStoreRsp":               6,
+	"TypeGetStoreReq":               7,
+	"TypeGetStoreRsp":               8,
+	"TypeAllocIDReq":                9,
+	"TypeAllocIDRsp":                10,
+	"TypeAskBatchSplitReq":          11,
+	"TypeAskBatchSplitRsp":          12,
+	"TypeCreateDestroyingReq":       13,
+	"TypeCreateDestroyingRsp":       14,
+	"TypeReportDestroyedReq":        15,
+	"TypeReportDestroyedRsp":        16,
+	"TypeGetDestroyingReq":          17,
+	"TypeGetDestroyingRsp":          18,
+	"TypeCreateWatcherReq":          19,
+	"TypeEventNotify":               20,
+	"TypeCreateShardsReq":           21,
+	"TypeCreateShardsRsp":           22,
+	"TypeRemoveShardsReq":           23,
+	"TypeRemoveShardsRsp":           24,
+	"TypeCheckShardStateReq":        25,
+	"TypeCheckShardStateRsp":        26,
+	"TypePutPlacementRuleReq":       27,
+	"TypePutPlacementRuleRsp":       28,
+	"TypeGetAppliedRulesReq":        29,
+	"TypeGetAppliedRulesRsp":        30,
+	"TypeCreateJobReq":              31,
+	"TypeCreateJobRsp":              32,
+	"TypeRemoveJobReq":              33,
+	"TypeRemoveJobRsp":              34,
+	"TypeExecuteJobReq":             35,
+	"TypeExecuteJobRsp":             36,
+	"TypeAddScheduleGroupRuleReq":   37,
+	"TypeAddScheduleGroupRuleRsp":   38,
+	"TypeGetScheduleGroupRuleReq":   39,
+	"TypeGetScheduleGroupRuleRsp":   40,
+	"TypeCheckTombstoneReplicasReq": 41,
+	"TypeCheckTombstoneReplicasRsp": 42,
+	"TypePutClusterSettingReq":      43,
+	"TypePutClusterSettingRsp":      44,
+	"TypeGetClusterSettingsReq":     45,
+	"TypeGetClusterSettingsRsp":     46,
+	"TypeScatterShardsReq":          47,
+	"TypeScatterShardsRsp":          48,
 }
 
 func (x Type) String() string {
@@ -182,6 +206,10 @@ const (
 	Follower ReplicaRoleType = 2
 	// Learner matches a learner.
 	Learner ReplicaRoleType = 3
+	// Witness matches a witness.
+	Witness ReplicaRoleType = 4
+	// Observer matches an observer.
+	Observer ReplicaRoleType = 5
 )
 
 var ReplicaRoleType_name = map[int32]string{
@@ -189,6 +217,8 @@ var ReplicaRoleType_name = map[int32]string{
 	1: "Leader",
 	2: "Follower",
 	3: "Learner",
+	4: "Witness",
+	5: "Observer",
 }
 
 var ReplicaRoleType_value = map[string]int32{
@@ -196,6 +226,8 @@ var ReplicaRoleType_value = map[string]int32{
 	"Leader":   1,
 	"Follower": 2,
 	"Learner":  3,
+	"Witness":  4,
+	"Observer": 5,
 }
 
 func (x ReplicaRoleType) String() string {
@@ -297,6 +329,26 @@ const (
 	CmdUpdateLabels InternalCmd = 7
 	// CmdUpdateEpochLease update shard epoch lease
 	CmdUpdateEpochLease InternalCmd = 8
+	// CmdUpdateGate update shard gate command, admin type
+	CmdUpdateGate InternalCmd = 9
+	// CmdAcquireAppLease acquire or renew the application lease, admin type
+	CmdAcquireAppLease InternalCmd = 10
+	// CmdReleaseAppLease release the application lease, admin type
+	CmdReleaseAppLease InternalCmd = 11
+	// CmdSplitShard split shard by the specified keys command, admin type
+	CmdSplitShard InternalCmd = 12
+	// CmdDeleteRange delete the data of a key range of the shard, admin type
+	CmdDeleteRange InternalCmd = 13
+	// CmdBarrier no-op barrier command to get the applied index, admin type
+	CmdBarrier InternalCmd = 15
+	// CmdComputeHash compute the data checksum on every replica, admin type
+	CmdComputeHash InternalCmd = 16
+	// CmdVerifyHash verify the data checksum on every replica, admin type
+	CmdVerifyHash InternalCmd = 17
+	// CmdPurge purge the data on every replica with a purge marker, admin type
+	CmdPurge InternalCmd = 18
+	// CmdUpdateDictionary add the compression dictionary of the shard, admin type
+	CmdUpdateDictionary InternalCmd = 19
 	// CmdUpdateTxnRecord update txn record command, write type
 	CmdUpdateTxnRecord InternalCmd = 100
 	// CmdDeleteTxnRecord delete txn record command, write type
@@ -325,6 +377,10 @@ const (
 	CmdKVScan InternalCmd = 207
 	// CmdKVBatchMixedWrite mixed all kv write request
 	CmdKVBatchMixedWrite InternalCmd = 208
+	// CmdKVGetDel kv get and delete command, write type
+	CmdKVGetDel InternalCmd = 209
+	// CmdKVDeleteIf kv conditional delete command, write type
+	CmdKVDeleteIf InternalCmd = 210
 	// CmdReserved cube reserved cmd type value, all custom cmd type read and
 	// write cmd type can not use the value below the reserved value.
 	CmdReserved InternalCmd = 1000
@@ -338,6 +394,16 @@ var InternalCmd_name = map[int32]string{
 	6:    "CmdUpdateMetadata",
 	7:    "CmdUpdateLabels",
 	8:    "CmdUpdateEpochLease",
+	9:    "CmdUpdateGate",
+	10:   "CmdAcquireAppLease",
+	11:   "CmdReleaseAppLease",
+	12:   "CmdSplitShard",
+	13:   "CmdDeleteRange",
+	15:   "CmdBarrier",
+	16:   "CmdComputeHash",
+	17:   "CmdVerifyHash",
+	18:   "CmdPurge",
+	19:   "CmdUpdateDictionary",
 	100:  "CmdUpdateTxnRecord",
 	101:  "CmdDeleteTxnRecord",
 	102:  "CmdCommitTxnData",
@@ -352,6 +418,8 @@ var InternalCmd_name = map[int32]string{
 	206:  "CmdKVRangeDelete",
 	207:  "CmdKVScan",
 	208:  "CmdKVBatchMixedWrite",
+	209:  "CmdKVGetDel",
+	210:  "CmdKVDeleteIf",
 	1000: "CmdReserved",
 }
 
@@ -363,6 +431,16 @@ var InternalCmd_value = map[string]int32{
 	"CmdUpdateMetadata":    6,
 	"CmdUpdateLabels":      7,
 	"CmdUpdateEpochLease":  8,
+	"CmdUpdateGate":        9,
+	"CmdAcquireAppLease":   10,
+	"CmdReleaseAppLease":   11,
+	"CmdSplitShard":        12,
+	"CmdDeleteRange":       13,
+	"CmdBarrier":           15,
+	"CmdComputeHash":       16,
+	"CmdVerifyHash":        17,
+	"CmdPurge":             18,
+	"CmdUpdateDictionary":  19,
 	"CmdUpdateTxnRecord":   100,
 	"CmdDeleteTxnRecord":   101,
 	"CmdCommitTxnData":     102,
@@ -377,6 +455,8 @@ var InternalCmd_value = map[string]int32{
 	"CmdKVRangeDelete":     206,
 	"CmdKVScan":            207,
 	"CmdKVBatchMixedWrite": 208,
+	"CmdKVGetDel":          209,
+	"CmdKVDeleteIf":        210,
 	"CmdReserved":          1000,
 }
 
@@ -388,6 +468,37 @@ func (InternalCmd) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_25e491924c678914, []int{4}
 }
 
+// RequestPriority the priority of the requests, the replica proposes the
+// batches of higher priority first. The admin requests are always proposed as
+// HighPriority.
+type RequestPriority int32
+
+const (
+	NormalPriority RequestPriority = 0
+	HighPriority   RequestPriority = 1
+	LowPriority    RequestPriority = 2
+)
+
+var RequestPriority_name = map[int32]string{
+	0: "NormalPriority",
+	1: "HighPriority",
+	2: "LowPriority",
+}
+
+var RequestPriority_value = map[string]int32{
+	"NormalPriority": 0,
+	"HighPriority":   1,
+	"LowPriority":    2,
+}
+
+func (x RequestPriority) String() string {
+	return proto.EnumName(RequestPriority_name, int32(x))
+}
+
+func (RequestPriority) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{5}
+}
+
 // UpdatePolicy update policy
 type UpdatePolicy int32
 
@@ -421,7 +532,7 @@ func (x UpdatePolicy) String() string {
 }
 
 func (UpdatePolicy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{5}
+	return fileDescriptor_25e491924c678914, []int{6}
 }
 
 // ReplicaSelectPolicy strategies for selecting replica
@@ -453,37 +564,41 @@ func (x ReplicaSelectPolicy) String() string {
 }
 
 func (ReplicaSelectPolicy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{6}
+	return fileDescriptor_25e491924c678914, []int{7}
 }
 
 // ProphetRequest the prophet rpc request
 type ProphetRequest struct {
-	ID                   uint64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	StoreID              uint64                  `protobuf:"varint,2,opt,name=storeID,proto3" json:"storeID,omitempty"`
-	Type                 Type                    `protobuf:"varint,3,opt,name=type,proto3,enum=rpcpb.Type" json:"type,omitempty"`
-	ShardHeartbeat       ShardHeartbeatReq       `protobuf:"bytes,4,opt,name=shardHeartbeat,proto3" json:"shardHeartbeat"`
-	StoreHeartbeat       StoreHeartbeatReq       `protobuf:"bytes,5,opt,name=storeHeartbeat,proto3" json:"storeHeartbeat"`
-	PutStore             PutStoreReq             `protobuf:"bytes,6,opt,name=putStore,proto3" json:"putStore"`
-	GetStore             GetStoreReq             `protobuf:"bytes,7,opt,name=getStore,proto3" json:"getStore"`
-	AllocID              AllocIDReq              `protobuf:"bytes,8,opt,name=allocID,proto3" json:"allocID"`
-	AskBatchSplit        AskBatchSplitReq        `protobuf:"bytes,9,opt,name=askBatchSplit,proto3" json:"askBatchSplit"`
-	CreateDestroying     CreateDestroyingReq     `protobuf:"bytes,10,opt,name=createDestroying,proto3" json:"createDestroying"`
-	ReportDestroyed      ReportDestroyedReq      `protobuf:"bytes,11,opt,name=ReportDestroyed,proto3" json:"ReportDestroyed"`
-	GetDestroying        GetDestroyingReq        `protobuf:"bytes,12,opt,name=getDestroying,proto3" json:"getDestroying"`
-	CreateWatcher        CreateWatcherReq        `protobuf:"bytes,13,opt,name=createWatcher,proto3" json:"createWatcher"`
-	CreateShards         CreateShardsReq         `protobuf:"bytes,14,opt,name=createShards,proto3" json:"createShards"`
-	RemoveShards         RemoveShardsReq         `protobuf:"bytes,15,opt,name=removeShards,proto3" json:"removeShards"`
-	CheckShardState      CheckShardStateReq      `protobuf:"bytes,16,opt,name=checkShardState,proto3" json:"checkShardState"`
-	PutPlacementRule     PutPlacementRuleReq     `protobuf:"bytes,17,opt,name=putPlacementRule,proto3" json:"putPlacementRule"`
-	GetAppliedRules      GetAppliedRulesReq      `protobuf:"bytes,18,opt,name=getAppliedRules,proto3" json:"getAppliedRules"`
-	CreateJob            CreateJobReq            `protobuf:"bytes,19,opt,name=createJob,proto3" json:"createJob"`
-	RemoveJob            RemoveJobReq            `protobuf:"bytes,20,opt,name=removeJob,proto3" json:"removeJob"`
-	ExecuteJob           ExecuteJobReq           `protobuf:"bytes,21,opt,name=executeJob,proto3" json:"executeJob"`
-	AddScheduleGroupRule AddScheduleGroupRuleReq `protobuf:"bytes,22,opt,name=addScheduleGroupRule,proto3" json:"addScheduleGroupRule"`
-	GetScheduleGroupRule GetScheduleGroupRuleReq `protobuf:"bytes,23,opt,name=getScheduleGroupRule,proto3" json:"getScheduleGroupRule"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	ID                     uint64                    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StoreID                uint64                    `protobuf:"varint,2,opt,name=storeID,proto3" json:"storeID,omitempty"`
+	Type                   Type                      `protobuf:"varint,3,opt,name=type,proto3,enum=rpcpb.Type" json:"type,omitempty"`
+	ShardHeartbeat         ShardHeartbeatReq         `protobuf:"bytes,4,opt,name=shardHeartbeat,proto3" json:"shardHeartbeat"`
+	StoreHeartbeat         StoreHeartbeatReq         `protobuf:"bytes,5,opt,name=storeHeartbeat,proto3" json:"storeHeartbeat"`
+	PutStore               PutStoreReq               `protobuf:"bytes,6,opt,name=putStore,proto3" json:"putStore"`
+	GetStore               GetStoreReq               `protobuf:"bytes,7,opt,name=getStore,proto3" json:"getStore"`
+	AllocID                AllocIDReq                `protobuf:"bytes,8,opt,name=allocID,proto3" json:"allocID"`
+	AskBatchSplit          AskBatchSplitReq          `protobuf:"bytes,9,opt,name=askBatchSplit,proto3" json:"askBatchSplit"`
+	CreateDestroying       CreateDestroyingReq       `protobuf:"bytes,10,opt,name=createDestroying,proto3" json:"createDestroying"`
+	ReportDestroyed        ReportDestroyedReq        `protobuf:"bytes,11,opt,name=ReportDestroyed,proto3" json:"ReportDestroyed"`
+	GetDestroying          GetDestroyingReq          `protobuf:"bytes,12,opt,name=getDestroying,proto3" json:"getDestroying"`
+	CreateWatcher          CreateWatcherReq          `protobuf:"bytes,13,opt,name=createWatcher,proto3" json:"createWatcher"`
+	CreateShards           CreateShardsReq           `protobuf:"bytes,14,opt,name=createShards,proto3" json:"createShards"`
+	RemoveShards           RemoveShardsReq           `protobuf:"bytes,15,opt,name=removeShards,proto3" json:"removeShards"`
+	CheckShardState        CheckShardStateReq        `protobuf:"bytes,16,opt,name=checkShardState,proto3" json:"checkShardState"`
+	PutPlacementRule       PutPlacementRuleReq       `protobuf:"bytes,17,opt,name=putPlacementRule,proto3" json:"putPlacementRule"`
+	GetAppliedRules        GetAppliedRulesReq        `protobuf:"bytes,18,opt,name=getAppliedRules,proto3" json:"getAppliedRules"`
+	CreateJob              CreateJobReq              `protobuf:"bytes,19,opt,name=createJob,proto3" json:"createJob"`
+	RemoveJob              RemoveJobReq              `protobuf:"bytes,20,opt,name=removeJob,proto3" json:"removeJob"`
+	ExecuteJob             ExecuteJobReq             `protobuf:"bytes,21,opt,name=executeJob,proto3" json:"executeJob"`
+	AddScheduleGroupRule   AddScheduleGroupRuleReq   `protobuf:"bytes,22,opt,name=addScheduleGroupRule,proto3" json:"addScheduleGroupRule"`
+	GetScheduleGroupRule   GetScheduleGroupRuleReq   `protobuf:"bytes,23,opt,name=getScheduleGroupRule,proto3" json:"getScheduleGroupRule"`
+	CheckTombstoneReplicas CheckTombstoneReplicasReq `protobuf:"bytes,24,opt,name=checkTombstoneReplicas,proto3" json:"checkTombstoneReplicas"`
+	PutClusterSetting      PutClusterSettingReq      `protobuf:"bytes,25,opt,name=putClusterSetting,proto3" json:"putClusterSetting"`
+	GetClusterSettings     GetClusterSettingsReq     `protobuf:"bytes,26,opt,name=getClusterSettings,proto3" json:"getClusterSettings"`
+	ScatterShards          ScatterShardsReq          `protobuf:"bytes,27,opt,name=scatterShards,proto3" json:"scatterShards"`
+	XXX_NoUnkeyedLiteral   struct{}                  `json:"-"`
+	XXX_unrecognized       []byte                    `json:"-"`
+	XXX_sizecache          int32                     `json:"-"`
 }
 
 func (m *ProphetRequest) Reset()         { *m = ProphetRequest{} }
@@ -680,35 +795,67 @@ func (m *ProphetRequest) GetGetScheduleGroupRule() GetScheduleGroupRuleReq {
 	return GetScheduleGroupRuleReq{}
 }
 
+func (m *ProphetRequest) GetCheckTombstoneReplicas() CheckTombstoneReplicasReq {
+	if m != nil {
+		return m.CheckTombstoneReplicas
+	}
+	return CheckTombstoneReplicasReq{}
+}
+
+func (m *ProphetRequest) GetPutClusterSetting() PutClusterSettingReq {
+	if m != nil {
+		return m.PutClusterSetting
+	}
+	return PutClusterSettingReq{}
+}
+
+func (m *ProphetRequest) GetGetClusterSettings() GetClusterSettingsReq {
+	if m != nil {
+		return m.GetClusterSettings
+	}
+	return GetClusterSettingsReq{}
+}
+
+func (m *ProphetRequest) GetScatterShards() ScatterShardsReq {
+	if m != nil {
+		return m.ScatterShards
+	}
+	return ScatterShardsReq{}
+}
+
 // ProphetResponse the prophet rpc response
 type ProphetResponse struct {
-	ID                   uint64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Type                 Type                    `protobuf:"varint,2,opt,name=type,proto3,enum=rpcpb.Type" json:"type,omitempty"`
-	Error                string                  `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	Leader               string                  `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
-	ShardHeartbeat       ShardHeartbeatRsp       `protobuf:"bytes,5,opt,name=shardHeartbeat,proto3" json:"shardHeartbeat"`
-	StoreHeartbeat       StoreHeartbeatRsp       `protobuf:"bytes,6,opt,name=storeHeartbeat,proto3" json:"storeHeartbeat"`
-	PutStore             PutStoreRsp             `protobuf:"bytes,7,opt,name=putStore,proto3" json:"putStore"`
-	GetStore             GetStoreRsp             `protobuf:"bytes,8,opt,name=getStore,proto3" json:"getStore"`
-	AllocID              AllocIDRsp              `protobuf:"bytes,9,opt,name=allocID,proto3" json:"allocID"`
-	AskBatchSplit        AskBatchSplitRsp        `protobuf:"bytes,10,opt,name=askBatchSplit,proto3" json:"askBatchSplit"`
-	CreateDestroying     CreateDestroyingRsp     `protobuf:"bytes,11,opt,name=createDestroying,proto3" json:"createDestroying"`
-	ReportDestroyed      ReportDestroyedRsp      `protobuf:"bytes,12,opt,name=ReportDestroyed,proto3" json:"ReportDestroyed"`
-	GetDestroying        GetDestroyingRsp        `protobuf:"bytes,13,opt,name=getDestroying,proto3" json:"getDestroying"`
-	Event                EventNotify             `protobuf:"bytes,14,opt,name=event,proto3" json:"event"`
-	CreateShards         CreateShardsRsp         `protobuf:"bytes,15,opt,name=createShards,proto3" json:"createShards"`
-	RemoveShards         RemoveShardsRsp         `protobuf:"bytes,16,opt,name=removeShards,proto3" json:"removeShards"`
-	CheckShardState      CheckShardStateRsp      `protobuf:"bytes,17,opt,name=checkShardState,proto3" json:"checkShardState"`
-	PutPlacementRule     PutPlacementRuleRsp     `protobuf:"bytes,18,opt,name=putPlacementRule,proto3" json:"putPlacementRule"`
-	GetAppliedRules      GetAppliedRulesRsp      `protobuf:"bytes,19,opt,name=getAppliedRules,proto3" json:"getAppliedRules"`
-	CreateJob            CreateJobRsp            `protobuf:"bytes,20,opt,name=createJob,proto3" json:"createJob"`
-	RemoveJob            RemoveJobRsp            `protobuf:"bytes,21,opt,name=removeJob,proto3" json:"removeJob"`
-	ExecuteJob           ExecuteJobRsp           `protobuf:"bytes,22,opt,name=executeJob,proto3" json:"executeJob"`
-	AddScheduleGroupRule AddScheduleGroupRuleRsp `protobuf:"bytes,23,opt,name=addScheduleGroupRule,proto3" json:"addScheduleGroupRule"`
-	GetScheduleGroupRule GetScheduleGroupRuleRsp `protobuf:"bytes,24,opt,name=getScheduleGroupRule,proto3" json:"getScheduleGroupRule"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	ID                     uint64                    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                   Type                      `protobuf:"varint,2,opt,name=type,proto3,enum=rpcpb.Type" json:"type,omitempty"`
+	Error                  string                    `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Leader                 string                    `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
+	ShardHeartbeat         ShardHeartbeatRsp         `protobuf:"bytes,5,opt,name=shardHeartbeat,proto3" json:"shardHeartbeat"`
+	StoreHeartbeat         StoreHeartbeatRsp         `protobuf:"bytes,6,opt,name=storeHeartbeat,proto3" json:"storeHeartbeat"`
+	PutStore               PutStoreRsp               `protobuf:"bytes,7,opt,name=putStore,proto3" json:"putStore"`
+	GetStore               GetStoreRsp               `protobuf:"bytes,8,opt,name=getStore,proto3" json:"getStore"`
+	AllocID                AllocIDRsp                `protobuf:"bytes,9,opt,name=allocID,proto3" json:"allocID"`
+	AskBatchSplit          AskBatchSplitRsp          `protobuf:"bytes,10,opt,name=askBatchSplit,proto3" json:"askBatchSplit"`
+	CreateDestroying       CreateDestroyingRsp       `protobuf:"bytes,11,opt,name=createDestroying,proto3" json:"createDestroying"`
+	ReportDestroyed        ReportDestroyedRsp        `protobuf:"bytes,12,opt,name=ReportDestroyed,proto3" json:"ReportDestroyed"`
+	GetDestroying          GetDestroyingRsp          `protobuf:"bytes,13,opt,name=getDestroying,proto3" json:"getDestroying"`
+	Event                  EventNotify               `protobuf:"bytes,14,opt,name=event,proto3" json:"event"`
+	CreateShards           CreateShardsRsp           `protobuf:"bytes,15,opt,name=createShards,proto3" json:"createShards"`
+	RemoveShards           RemoveShardsRsp           `protobuf:"bytes,16,opt,name=removeShards,proto3" json:"removeShards"`
+	CheckShardState        CheckShardStateRsp        `protobuf:"bytes,17,opt,name=checkShardState,proto3" json:"checkShardState"`
+	PutPlacementRule       PutPlacementRuleRsp       `protobuf:"bytes,18,opt,name=putPlacementRule,proto3" json:"putPlacementRule"`
+	GetAppliedRules        GetAppliedRulesRsp        `protobuf:"bytes,19,opt,name=getAppliedRules,proto3" json:"getAppliedRules"`
+	CreateJob              CreateJobRsp              `protobuf:"bytes,20,opt,name=createJob,proto3" json:"createJob"`
+	RemoveJob              RemoveJobRsp              `protobuf:"bytes,21,opt,name=removeJob,proto3" json:"removeJob"`
+	ExecuteJob             ExecuteJobRsp             `protobuf:"bytes,22,opt,name=executeJob,proto3" json:"executeJob"`
+	AddScheduleGroupRule   AddScheduleGroupRuleRsp   `protobuf:"bytes,23,opt,name=addScheduleGroupRule,proto3" json:"addScheduleGroupRule"`
+	GetScheduleGroupRule   GetScheduleGroupRuleRsp   `protobuf:"bytes,24,opt,name=getScheduleGroupRule,proto3" json:"getScheduleGroupRule"`
+	CheckTombstoneReplicas CheckTombstoneReplicasRsp `protobuf:"bytes,25,opt,name=checkTombstoneReplicas,proto3" json:"checkTombstoneReplicas"`
+	PutClusterSetting      PutClusterSettingRsp      `protobuf:"bytes,26,opt,name=putClusterSetting,proto3" json:"putClusterSetting"`
+	GetClusterSettings     GetClusterSettingsRsp     `protobuf:"bytes,27,opt,name=getClusterSettings,proto3" json:"getClusterSettings"`
+	ScatterShards          ScatterShardsRsp          `protobuf:"bytes,28,opt,name=scatterShards,proto3" json:"scatterShards"`
+	XXX_NoUnkeyedLiteral   struct{}                  `json:"-"`
+	XXX_unrecognized       []byte                    `json:"-"`
+	XXX_sizecache          int32                     `json:"-"`
 }
 
 func (m *ProphetResponse) Reset()         { *m = ProphetResponse{} }
@@ -912,21 +1059,52 @@ func (m *ProphetResponse) GetGetScheduleGroupRule() GetScheduleGroupRuleRsp {
 	return GetScheduleGroupRuleRsp{}
 }
 
+func (m *ProphetResponse) GetCheckTombstoneReplicas() CheckTombstoneReplicasRsp {
+	if m != nil {
+		return m.CheckTombstoneReplicas
+	}
+	return CheckTombstoneReplicasRsp{}
+}
+
+func (m *ProphetResponse) GetPutClusterSetting() PutClusterSettingRsp {
+	if m != nil {
+		return m.PutClusterSetting
+	}
+	return PutClusterSettingRsp{}
+}
+
+func (m *ProphetResponse) GetGetClusterSettings() GetClusterSettingsRsp {
+	if m != nil {
+		return m.GetClusterSettings
+	}
+	return GetClusterSettingsRsp{}
+}
+
+func (m *ProphetResponse) GetScatterShards() ScatterShardsRsp {
+	if m != nil {
+		return m.ScatterShards
+	}
+	return ScatterShardsRsp{}
+}
+
 // ShardHeartbeatReq shard heartbeat request
 type ShardHeartbeatReq struct {
 	StoreID uint64 `protobuf:"varint,1,opt,name=storeID,proto3" json:"storeID,omitempty"`
 	Shard   []byte `protobuf:"bytes,2,opt,name=shard,proto3" json:"shard,omitempty"`
 	// Term is the term of raft group.
-	Term                 uint64                `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
-	Leader               *metapb.Replica       `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
-	DownReplicas         []metapb.ReplicaStats `protobuf:"bytes,5,rep,name=downReplicas,proto3" json:"downReplicas"`
-	PendingReplicas      []metapb.Replica      `protobuf:"bytes,6,rep,name=pendingReplicas,proto3" json:"pendingReplicas"`
-	Stats                metapb.ShardStats     `protobuf:"bytes,7,opt,name=stats,proto3" json:"stats"`
-	GroupKey             string                `protobuf:"bytes,8,opt,name=groupKey,proto3" json:"groupKey,omitempty"`
-	Lease                *metapb.EpochLease    `protobuf:"bytes,9,opt,name=lease,proto3" json:"lease,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Term            uint64                `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
+	Leader          *metapb.Replica       `protobuf:"bytes,4,opt,name=leader,proto3" json:"leader,omitempty"`
+	DownReplicas    []metapb.ReplicaStats `protobuf:"bytes,5,rep,name=downReplicas,proto3" json:"downReplicas"`
+	PendingReplicas []metapb.Replica      `protobuf:"bytes,6,rep,name=pendingReplicas,proto3" json:"pendingReplicas"`
+	Stats           metapb.ShardStats     `protobuf:"bytes,7,opt,name=stats,proto3" json:"stats"`
+	GroupKey        string                `protobuf:"bytes,8,opt,name=groupKey,proto3" json:"groupKey,omitempty"`
+	Lease           *metapb.EpochLease    `protobuf:"bytes,9,opt,name=lease,proto3" json:"lease,omitempty"`
+	// BlockingReasons the latest reasons why the operations of the shard are
+	// declined by the leader
+	BlockingReasons      []metapb.BlockingReason `protobuf:"bytes,10,rep,name=blockingReasons,proto3" json:"blockingReasons"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
 func (m *ShardHeartbeatReq) Reset()         { *m = ShardHeartbeatReq{} }
@@ -1025,6 +1203,13 @@ func (m *ShardHeartbeatReq) GetLease() *metapb.EpochLease {
 	return nil
 }
 
+func (m *ShardHeartbeatReq) GetBlockingReasons() []metapb.BlockingReason {
+	if m != nil {
+		return m.BlockingReasons
+	}
+	return nil
+}
+
 // ShardHeartbeatRsp shard heartbeat response.
 type ShardHeartbeatRsp struct {
 	ShardID    uint64            `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
@@ -2369,6 +2554,170 @@ func (m *CheckShardStateRsp) GetDestroying() []byte {
 	return nil
 }
 
+// TombstoneReplica the replica tombstoned in the store, with the shard epoch
+// at the time the replica was removed
+type TombstoneReplica struct {
+	ShardID              uint64            `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	ReplicaID            uint64            `protobuf:"varint,2,opt,name=replicaID,proto3" json:"replicaID,omitempty"`
+	Epoch                metapb.ShardEpoch `protobuf:"bytes,3,opt,name=epoch,proto3" json:"epoch"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TombstoneReplica) Reset()         { *m = TombstoneReplica{} }
+func (m *TombstoneReplica) String() string { return proto.CompactTextString(m) }
+func (*TombstoneReplica) ProtoMessage()    {}
+func (*TombstoneReplica) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{28}
+}
+func (m *TombstoneReplica) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TombstoneReplica) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TombstoneReplica.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TombstoneReplica) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TombstoneReplica.Merge(m, src)
+}
+func (m *TombstoneReplica) XXX_Size() int {
+	return m.Size()
+}
+func (m *TombstoneReplica) XXX_DiscardUnknown() {
+	xxx_messageInfo_TombstoneReplica.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TombstoneReplica proto.InternalMessageInfo
+
+func (m *TombstoneReplica) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
+	}
+	return 0
+}
+
+func (m *TombstoneReplica) GetReplicaID() uint64 {
+	if m != nil {
+		return m.ReplicaID
+	}
+	return 0
+}
+
+func (m *TombstoneReplica) GetEpoch() metapb.ShardEpoch {
+	if m != nil {
+		return m.Epoch
+	}
+	return metapb.ShardEpoch{}
+}
+
+// CheckTombstoneReplicasReq check whether the tombstone replicas are safe to
+// delete
+type CheckTombstoneReplicasReq struct {
+	Replicas             []TombstoneReplica `protobuf:"bytes,1,rep,name=replicas,proto3" json:"replicas"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *CheckTombstoneReplicasReq) Reset()         { *m = CheckTombstoneReplicasReq{} }
+func (m *CheckTombstoneReplicasReq) String() string { return proto.CompactTextString(m) }
+func (*CheckTombstoneReplicasReq) ProtoMessage()    {}
+func (*CheckTombstoneReplicasReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{29}
+}
+func (m *CheckTombstoneReplicasReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CheckTombstoneReplicasReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckTombstoneReplicasReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CheckTombstoneReplicasReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckTombstoneReplicasReq.Merge(m, src)
+}
+func (m *CheckTombstoneReplicasReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckTombstoneReplicasReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckTombstoneReplicasReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckTombstoneReplicasReq proto.InternalMessageInfo
+
+func (m *CheckTombstoneReplicasReq) GetReplicas() []TombstoneReplica {
+	if m != nil {
+		return m.Replicas
+	}
+	return nil
+}
+
+// CheckTombstoneReplicasRsp check tombstone replicas rsp
+type CheckTombstoneReplicasRsp struct {
+	// removable the bitmap of the shard ids whose tombstone replicas are safe
+	// to delete
+	Removable            []byte   `protobuf:"bytes,1,opt,name=removable,proto3" json:"removable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckTombstoneReplicasRsp) Reset()         { *m = CheckTombstoneReplicasRsp{} }
+func (m *CheckTombstoneReplicasRsp) String() string { return proto.CompactTextString(m) }
+func (*CheckTombstoneReplicasRsp) ProtoMessage()    {}
+func (*CheckTombstoneReplicasRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{30}
+}
+func (m *CheckTombstoneReplicasRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CheckTombstoneReplicasRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CheckTombstoneReplicasRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CheckTombstoneReplicasRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckTombstoneReplicasRsp.Merge(m, src)
+}
+func (m *CheckTombstoneReplicasRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *CheckTombstoneReplicasRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckTombstoneReplicasRsp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckTombstoneReplicasRsp proto.InternalMessageInfo
+
+func (m *CheckTombstoneReplicasRsp) GetRemovable() []byte {
+	if m != nil {
+		return m.Removable
+	}
+	return nil
+}
+
 // PutPlacementRuleReq put placement rule req
 type PutPlacementRuleReq struct {
 	Rule                 PlacementRule `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule"`
@@ -2381,7 +2730,7 @@ func (m *PutPlacementRuleReq) Reset()         { *m = PutPlacementRuleReq{} }
 func (m *PutPlacementRuleReq) String() string { return proto.CompactTextString(m) }
 func (*PutPlacementRuleReq) ProtoMessage()    {}
 func (*PutPlacementRuleReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{28}
+	return fileDescriptor_25e491924c678914, []int{31}
 }
 func (m *PutPlacementRuleReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2428,7 +2777,7 @@ func (m *PutPlacementRuleRsp) Reset()         { *m = PutPlacementRuleRsp{} }
 func (m *PutPlacementRuleRsp) String() string { return proto.CompactTextString(m) }
 func (*PutPlacementRuleRsp) ProtoMessage()    {}
 func (*PutPlacementRuleRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{29}
+	return fileDescriptor_25e491924c678914, []int{32}
 }
 func (m *PutPlacementRuleRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2469,7 +2818,7 @@ func (m *GetAppliedRulesReq) Reset()         { *m = GetAppliedRulesReq{} }
 func (m *GetAppliedRulesReq) String() string { return proto.CompactTextString(m) }
 func (*GetAppliedRulesReq) ProtoMessage()    {}
 func (*GetAppliedRulesReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{30}
+	return fileDescriptor_25e491924c678914, []int{33}
 }
 func (m *GetAppliedRulesReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2517,7 +2866,7 @@ func (m *GetAppliedRulesRsp) Reset()         { *m = GetAppliedRulesRsp{} }
 func (m *GetAppliedRulesRsp) String() string { return proto.CompactTextString(m) }
 func (*GetAppliedRulesRsp) ProtoMessage()    {}
 func (*GetAppliedRulesRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{31}
+	return fileDescriptor_25e491924c678914, []int{34}
 }
 func (m *GetAppliedRulesRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2565,7 +2914,7 @@ func (m *CreateJobReq) Reset()         { *m = CreateJobReq{} }
 func (m *CreateJobReq) String() string { return proto.CompactTextString(m) }
 func (*CreateJobReq) ProtoMessage()    {}
 func (*CreateJobReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{32}
+	return fileDescriptor_25e491924c678914, []int{35}
 }
 func (m *CreateJobReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2612,7 +2961,7 @@ func (m *CreateJobRsp) Reset()         { *m = CreateJobRsp{} }
 func (m *CreateJobRsp) String() string { return proto.CompactTextString(m) }
 func (*CreateJobRsp) ProtoMessage()    {}
 func (*CreateJobRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{33}
+	return fileDescriptor_25e491924c678914, []int{36}
 }
 func (m *CreateJobRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2653,7 +3002,7 @@ func (m *RemoveJobReq) Reset()         { *m = RemoveJobReq{} }
 func (m *RemoveJobReq) String() string { return proto.CompactTextString(m) }
 func (*RemoveJobReq) ProtoMessage()    {}
 func (*RemoveJobReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{34}
+	return fileDescriptor_25e491924c678914, []int{37}
 }
 func (m *RemoveJobReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2700,7 +3049,7 @@ func (m *RemoveJobRsp) Reset()         { *m = RemoveJobRsp{} }
 func (m *RemoveJobRsp) String() string { return proto.CompactTextString(m) }
 func (*RemoveJobRsp) ProtoMessage()    {}
 func (*RemoveJobRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{35}
+	return fileDescriptor_25e491924c678914, []int{38}
 }
 func (m *RemoveJobRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2742,7 +3091,7 @@ func (m *ExecuteJobReq) Reset()         { *m = ExecuteJobReq{} }
 func (m *ExecuteJobReq) String() string { return proto.CompactTextString(m) }
 func (*ExecuteJobReq) ProtoMessage()    {}
 func (*ExecuteJobReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{36}
+	return fileDescriptor_25e491924c678914, []int{39}
 }
 func (m *ExecuteJobReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2797,7 +3146,7 @@ func (m *ExecuteJobRsp) Reset()         { *m = ExecuteJobRsp{} }
 func (m *ExecuteJobRsp) String() string { return proto.CompactTextString(m) }
 func (*ExecuteJobRsp) ProtoMessage()    {}
 func (*ExecuteJobRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{37}
+	return fileDescriptor_25e491924c678914, []int{40}
 }
 func (m *ExecuteJobRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2844,7 +3193,7 @@ func (m *AddScheduleGroupRuleReq) Reset()         { *m = AddScheduleGroupRuleReq
 func (m *AddScheduleGroupRuleReq) String() string { return proto.CompactTextString(m) }
 func (*AddScheduleGroupRuleReq) ProtoMessage()    {}
 func (*AddScheduleGroupRuleReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{38}
+	return fileDescriptor_25e491924c678914, []int{41}
 }
 func (m *AddScheduleGroupRuleReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2890,7 +3239,7 @@ func (m *AddScheduleGroupRuleRsp) Reset()         { *m = AddScheduleGroupRuleRsp
 func (m *AddScheduleGroupRuleRsp) String() string { return proto.CompactTextString(m) }
 func (*AddScheduleGroupRuleRsp) ProtoMessage()    {}
 func (*AddScheduleGroupRuleRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{39}
+	return fileDescriptor_25e491924c678914, []int{42}
 }
 func (m *AddScheduleGroupRuleRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2929,7 +3278,7 @@ func (m *GetScheduleGroupRuleReq) Reset()         { *m = GetScheduleGroupRuleReq
 func (m *GetScheduleGroupRuleReq) String() string { return proto.CompactTextString(m) }
 func (*GetScheduleGroupRuleReq) ProtoMessage()    {}
 func (*GetScheduleGroupRuleReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{40}
+	return fileDescriptor_25e491924c678914, []int{43}
 }
 func (m *GetScheduleGroupRuleReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2969,7 +3318,7 @@ func (m *GetScheduleGroupRuleRsp) Reset()         { *m = GetScheduleGroupRuleRsp
 func (m *GetScheduleGroupRuleRsp) String() string { return proto.CompactTextString(m) }
 func (*GetScheduleGroupRuleRsp) ProtoMessage()    {}
 func (*GetScheduleGroupRuleRsp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{41}
+	return fileDescriptor_25e491924c678914, []int{44}
 }
 func (m *GetScheduleGroupRuleRsp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3005,32 +3354,26 @@ func (m *GetScheduleGroupRuleRsp) GetRules() []metapb.ScheduleGroupRule {
 	return nil
 }
 
-// EventNotify event notify
-type EventNotify struct {
-	Seq                  uint64             `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
-	Type                 uint32             `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
-	InitEvent            *InitEventData     `protobuf:"bytes,3,opt,name=initEvent,proto3" json:"initEvent,omitempty"`
-	ShardEvent           *ShardEventData    `protobuf:"bytes,4,opt,name=shardEvent,proto3" json:"shardEvent,omitempty"`
-	StoreEvent           *StoreEventData    `protobuf:"bytes,5,opt,name=storeEvent,proto3" json:"storeEvent,omitempty"`
-	ShardStatsEvent      *metapb.ShardStats `protobuf:"bytes,6,opt,name=shardStatsEvent,proto3" json:"shardStatsEvent,omitempty"`
-	StoreStatsEvent      *metapb.StoreStats `protobuf:"bytes,7,opt,name=storeStatsEvent,proto3" json:"storeStatsEvent,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+// PutClusterSettingReq put a cluster setting, the version is ignored
+type PutClusterSettingReq struct {
+	Setting              metapb.ClusterSetting `protobuf:"bytes,1,opt,name=setting,proto3" json:"setting"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *EventNotify) Reset()         { *m = EventNotify{} }
-func (m *EventNotify) String() string { return proto.CompactTextString(m) }
-func (*EventNotify) ProtoMessage()    {}
-func (*EventNotify) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{42}
+func (m *PutClusterSettingReq) Reset()         { *m = PutClusterSettingReq{} }
+func (m *PutClusterSettingReq) String() string { return proto.CompactTextString(m) }
+func (*PutClusterSettingReq) ProtoMessage()    {}
+func (*PutClusterSettingReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{45}
 }
-func (m *EventNotify) XXX_Unmarshal(b []byte) error {
+func (m *PutClusterSettingReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *EventNotify) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PutClusterSettingReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_EventNotify.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PutClusterSettingReq.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3040,42 +3383,355 @@ func (m *EventNotify) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *EventNotify) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EventNotify.Merge(m, src)
+func (m *PutClusterSettingReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutClusterSettingReq.Merge(m, src)
 }
-func (m *EventNotify) XXX_Size() int {
+func (m *PutClusterSettingReq) XXX_Size() int {
 	return m.Size()
 }
-func (m *EventNotify) XXX_DiscardUnknown() {
-	xxx_messageInfo_EventNotify.DiscardUnknown(m)
+func (m *PutClusterSettingReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutClusterSettingReq.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EventNotify proto.InternalMessageInfo
+var xxx_messageInfo_PutClusterSettingReq proto.InternalMessageInfo
 
-func (m *EventNotify) GetSeq() uint64 {
+func (m *PutClusterSettingReq) GetSetting() metapb.ClusterSetting {
 	if m != nil {
-		return m.Seq
+		return m.Setting
 	}
-	return 0
+	return metapb.ClusterSetting{}
 }
 
-func (m *EventNotify) GetType() uint32 {
-	if m != nil {
-		return m.Type
-	}
-	return 0
+// PutClusterSettingRsp returns the setting with the new version
+type PutClusterSettingRsp struct {
+	Setting              metapb.ClusterSetting `protobuf:"bytes,1,opt,name=setting,proto3" json:"setting"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *EventNotify) GetInitEvent() *InitEventData {
-	if m != nil {
-		return m.InitEvent
+func (m *PutClusterSettingRsp) Reset()         { *m = PutClusterSettingRsp{} }
+func (m *PutClusterSettingRsp) String() string { return proto.CompactTextString(m) }
+func (*PutClusterSettingRsp) ProtoMessage()    {}
+func (*PutClusterSettingRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{46}
+}
+func (m *PutClusterSettingRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PutClusterSettingRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PutClusterSettingRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *PutClusterSettingRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutClusterSettingRsp.Merge(m, src)
+}
+func (m *PutClusterSettingRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *PutClusterSettingRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutClusterSettingRsp.DiscardUnknown(m)
 }
 
-func (m *EventNotify) GetShardEvent() *ShardEventData {
+var xxx_messageInfo_PutClusterSettingRsp proto.InternalMessageInfo
+
+func (m *PutClusterSettingRsp) GetSetting() metapb.ClusterSetting {
 	if m != nil {
-		return m.ShardEvent
+		return m.Setting
+	}
+	return metapb.ClusterSetting{}
+}
+
+type GetClusterSettingsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClusterSettingsReq) Reset()         { *m = GetClusterSettingsReq{} }
+func (m *GetClusterSettingsReq) String() string { return proto.CompactTextString(m) }
+func (*GetClusterSettingsReq) ProtoMessage()    {}
+func (*GetClusterSettingsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{47}
+}
+func (m *GetClusterSettingsReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetClusterSettingsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetClusterSettingsReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetClusterSettingsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClusterSettingsReq.Merge(m, src)
+}
+func (m *GetClusterSettingsReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetClusterSettingsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClusterSettingsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClusterSettingsReq proto.InternalMessageInfo
+
+type GetClusterSettingsRsp struct {
+	Settings             []metapb.ClusterSetting `protobuf:"bytes,1,rep,name=settings,proto3" json:"settings"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetClusterSettingsRsp) Reset()         { *m = GetClusterSettingsRsp{} }
+func (m *GetClusterSettingsRsp) String() string { return proto.CompactTextString(m) }
+func (*GetClusterSettingsRsp) ProtoMessage()    {}
+func (*GetClusterSettingsRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{48}
+}
+func (m *GetClusterSettingsRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetClusterSettingsRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetClusterSettingsRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetClusterSettingsRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClusterSettingsRsp.Merge(m, src)
+}
+func (m *GetClusterSettingsRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetClusterSettingsRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClusterSettingsRsp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClusterSettingsRsp proto.InternalMessageInfo
+
+func (m *GetClusterSettingsRsp) GetSettings() []metapb.ClusterSetting {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+// ScatterShardsReq scatter the replicas and the leaders of the shards in the
+// range [start, end) of the group
+type ScatterShardsReq struct {
+	Group                uint64   `protobuf:"varint,1,opt,name=group,proto3" json:"group,omitempty"`
+	Start                []byte   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End                  []byte   `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScatterShardsReq) Reset()         { *m = ScatterShardsReq{} }
+func (m *ScatterShardsReq) String() string { return proto.CompactTextString(m) }
+func (*ScatterShardsReq) ProtoMessage()    {}
+func (*ScatterShardsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{49}
+}
+func (m *ScatterShardsReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScatterShardsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScatterShardsReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScatterShardsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScatterShardsReq.Merge(m, src)
+}
+func (m *ScatterShardsReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScatterShardsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScatterShardsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScatterShardsReq proto.InternalMessageInfo
+
+func (m *ScatterShardsReq) GetGroup() uint64 {
+	if m != nil {
+		return m.Group
+	}
+	return 0
+}
+
+func (m *ScatterShardsReq) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *ScatterShardsReq) GetEnd() []byte {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+// ScatterShardsRsp scatter shards rsp
+type ScatterShardsRsp struct {
+	// Scattered the number of the shards whose replicas are relocated
+	Scattered uint64 `protobuf:"varint,1,opt,name=scattered,proto3" json:"scattered,omitempty"`
+	// Failed the number of the shards failed to scatter, e.g. the shards
+	// not fully replicated yet
+	Failed               uint64   `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScatterShardsRsp) Reset()         { *m = ScatterShardsRsp{} }
+func (m *ScatterShardsRsp) String() string { return proto.CompactTextString(m) }
+func (*ScatterShardsRsp) ProtoMessage()    {}
+func (*ScatterShardsRsp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{50}
+}
+func (m *ScatterShardsRsp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScatterShardsRsp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScatterShardsRsp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScatterShardsRsp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScatterShardsRsp.Merge(m, src)
+}
+func (m *ScatterShardsRsp) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScatterShardsRsp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScatterShardsRsp.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScatterShardsRsp proto.InternalMessageInfo
+
+func (m *ScatterShardsRsp) GetScattered() uint64 {
+	if m != nil {
+		return m.Scattered
+	}
+	return 0
+}
+
+func (m *ScatterShardsRsp) GetFailed() uint64 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+// EventNotify event notify
+type EventNotify struct {
+	Seq                  uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Type                 uint32                 `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	InitEvent            *InitEventData         `protobuf:"bytes,3,opt,name=initEvent,proto3" json:"initEvent,omitempty"`
+	ShardEvent           *ShardEventData        `protobuf:"bytes,4,opt,name=shardEvent,proto3" json:"shardEvent,omitempty"`
+	StoreEvent           *StoreEventData        `protobuf:"bytes,5,opt,name=storeEvent,proto3" json:"storeEvent,omitempty"`
+	ShardStatsEvent      *metapb.ShardStats     `protobuf:"bytes,6,opt,name=shardStatsEvent,proto3" json:"shardStatsEvent,omitempty"`
+	StoreStatsEvent      *metapb.StoreStats     `protobuf:"bytes,7,opt,name=storeStatsEvent,proto3" json:"storeStatsEvent,omitempty"`
+	SettingEvent         *metapb.ClusterSetting `protobuf:"bytes,8,opt,name=settingEvent,proto3" json:"settingEvent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *EventNotify) Reset()         { *m = EventNotify{} }
+func (m *EventNotify) String() string { return proto.CompactTextString(m) }
+func (*EventNotify) ProtoMessage()    {}
+func (*EventNotify) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{51}
+}
+func (m *EventNotify) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventNotify) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventNotify.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventNotify) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventNotify.Merge(m, src)
+}
+func (m *EventNotify) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventNotify) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventNotify.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventNotify proto.InternalMessageInfo
+
+func (m *EventNotify) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *EventNotify) GetType() uint32 {
+	if m != nil {
+		return m.Type
+	}
+	return 0
+}
+
+func (m *EventNotify) GetInitEvent() *InitEventData {
+	if m != nil {
+		return m.InitEvent
+	}
+	return nil
+}
+
+func (m *EventNotify) GetShardEvent() *ShardEventData {
+	if m != nil {
+		return m.ShardEvent
 	}
 	return nil
 }
@@ -3101,22 +3757,30 @@ func (m *EventNotify) GetStoreStatsEvent() *metapb.StoreStats {
 	return nil
 }
 
+func (m *EventNotify) GetSettingEvent() *metapb.ClusterSetting {
+	if m != nil {
+		return m.SettingEvent
+	}
+	return nil
+}
+
 // InitEventData init event data
 type InitEventData struct {
-	Shards               [][]byte            `protobuf:"bytes,1,rep,name=shards,proto3" json:"shards,omitempty"`
-	Stores               [][]byte            `protobuf:"bytes,2,rep,name=stores,proto3" json:"stores,omitempty"`
-	LeaderReplicaIDs     []uint64            `protobuf:"varint,3,rep,packed,name=leaderReplicaIDs,proto3" json:"leaderReplicaIDs,omitempty"`
-	Leases               []metapb.EpochLease `protobuf:"bytes,4,rep,name=leases,proto3" json:"leases"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Shards               [][]byte                `protobuf:"bytes,1,rep,name=shards,proto3" json:"shards,omitempty"`
+	Stores               [][]byte                `protobuf:"bytes,2,rep,name=stores,proto3" json:"stores,omitempty"`
+	LeaderReplicaIDs     []uint64                `protobuf:"varint,3,rep,packed,name=leaderReplicaIDs,proto3" json:"leaderReplicaIDs,omitempty"`
+	Leases               []metapb.EpochLease     `protobuf:"bytes,4,rep,name=leases,proto3" json:"leases"`
+	Settings             []metapb.ClusterSetting `protobuf:"bytes,5,rep,name=settings,proto3" json:"settings"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
 func (m *InitEventData) Reset()         { *m = InitEventData{} }
 func (m *InitEventData) String() string { return proto.CompactTextString(m) }
 func (*InitEventData) ProtoMessage()    {}
 func (*InitEventData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{43}
+	return fileDescriptor_25e491924c678914, []int{52}
 }
 func (m *InitEventData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3173,6 +3837,13 @@ func (m *InitEventData) GetLeases() []metapb.EpochLease {
 	return nil
 }
 
+func (m *InitEventData) GetSettings() []metapb.ClusterSetting {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
 // ShardEventData shard created or updated
 type ShardEventData struct {
 	Data                 []byte             `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
@@ -3189,7 +3860,7 @@ func (m *ShardEventData) Reset()         { *m = ShardEventData{} }
 func (m *ShardEventData) String() string { return proto.CompactTextString(m) }
 func (*ShardEventData) ProtoMessage()    {}
 func (*ShardEventData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{44}
+	return fileDescriptor_25e491924c678914, []int{53}
 }
 func (m *ShardEventData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3265,7 +3936,7 @@ func (m *StoreEventData) Reset()         { *m = StoreEventData{} }
 func (m *StoreEventData) String() string { return proto.CompactTextString(m) }
 func (*StoreEventData) ProtoMessage()    {}
 func (*StoreEventData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{45}
+	return fileDescriptor_25e491924c678914, []int{54}
 }
 func (m *StoreEventData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3303,18 +3974,20 @@ func (m *StoreEventData) GetData() []byte {
 
 // ChangePeer change peer
 type ConfigChange struct {
-	Replica              metapb.Replica          `protobuf:"bytes,1,opt,name=replica,proto3" json:"replica"`
-	ChangeType           metapb.ConfigChangeType `protobuf:"varint,2,opt,name=changeType,proto3,enum=metapb.ConfigChangeType" json:"changeType,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	Replica    metapb.Replica          `protobuf:"bytes,1,opt,name=replica,proto3" json:"replica"`
+	ChangeType metapb.ConfigChangeType `protobuf:"varint,2,opt,name=changeType,proto3,enum=metapb.ConfigChangeType" json:"changeType,omitempty"`
+	// Initiator the operator initiating the change
+	Initiator            string   `protobuf:"bytes,3,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ConfigChange) Reset()         { *m = ConfigChange{} }
 func (m *ConfigChange) String() string { return proto.CompactTextString(m) }
 func (*ConfigChange) ProtoMessage()    {}
 func (*ConfigChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{46}
+	return fileDescriptor_25e491924c678914, []int{55}
 }
 func (m *ConfigChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3357,6 +4030,13 @@ func (m *ConfigChange) GetChangeType() metapb.ConfigChangeType {
 	return metapb.ConfigChangeType_AddNode
 }
 
+func (m *ConfigChange) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
 // TransferLeader transfer leader
 type TransferLeader struct {
 	Replica              metapb.Replica `protobuf:"bytes,1,opt,name=replica,proto3" json:"replica"`
@@ -3369,7 +4049,7 @@ func (m *TransferLeader) Reset()         { *m = TransferLeader{} }
 func (m *TransferLeader) String() string { return proto.CompactTextString(m) }
 func (*TransferLeader) ProtoMessage()    {}
 func (*TransferLeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{47}
+	return fileDescriptor_25e491924c678914, []int{56}
 }
 func (m *TransferLeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3417,7 +4097,7 @@ func (m *TransferLease) Reset()         { *m = TransferLease{} }
 func (m *TransferLease) String() string { return proto.CompactTextString(m) }
 func (*TransferLease) ProtoMessage()    {}
 func (*TransferLease) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{48}
+	return fileDescriptor_25e491924c678914, []int{57}
 }
 func (m *TransferLease) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3466,7 +4146,7 @@ func (m *ConfigChangeV2) Reset()         { *m = ConfigChangeV2{} }
 func (m *ConfigChangeV2) String() string { return proto.CompactTextString(m) }
 func (*ConfigChangeV2) ProtoMessage()    {}
 func (*ConfigChangeV2) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{49}
+	return fileDescriptor_25e491924c678914, []int{58}
 }
 func (m *ConfigChangeV2) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3515,7 +4195,7 @@ func (m *Merge) Reset()         { *m = Merge{} }
 func (m *Merge) String() string { return proto.CompactTextString(m) }
 func (*Merge) ProtoMessage()    {}
 func (*Merge) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{50}
+	return fileDescriptor_25e491924c678914, []int{59}
 }
 func (m *Merge) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3564,7 +4244,7 @@ func (m *SplitShard) Reset()         { *m = SplitShard{} }
 func (m *SplitShard) String() string { return proto.CompactTextString(m) }
 func (*SplitShard) ProtoMessage()    {}
 func (*SplitShard) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{51}
+	return fileDescriptor_25e491924c678914, []int{60}
 }
 func (m *SplitShard) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3621,7 +4301,7 @@ func (m *LabelConstraint) Reset()         { *m = LabelConstraint{} }
 func (m *LabelConstraint) String() string { return proto.CompactTextString(m) }
 func (*LabelConstraint) ProtoMessage()    {}
 func (*LabelConstraint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{52}
+	return fileDescriptor_25e491924c678914, []int{61}
 }
 func (m *LabelConstraint) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3702,7 +4382,7 @@ func (m *PlacementRule) Reset()         { *m = PlacementRule{} }
 func (m *PlacementRule) String() string { return proto.CompactTextString(m) }
 func (*PlacementRule) ProtoMessage()    {}
 func (*PlacementRule) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{53}
+	return fileDescriptor_25e491924c678914, []int{62}
 }
 func (m *PlacementRule) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3810,20 +4490,26 @@ func (m *PlacementRule) GetIsolationLevel() string {
 
 // RequestHeader raft request header, it contains the shard's metadata
 type RequestBatchHeader struct {
-	ID                   []byte             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ShardID              uint64             `protobuf:"varint,2,opt,name=shardID,proto3" json:"shardID,omitempty"`
-	Replica              metapb.Replica     `protobuf:"bytes,3,opt,name=replica,proto3" json:"replica"`
-	Lease                *metapb.EpochLease `protobuf:"bytes,4,opt,name=lease,proto3" json:"lease,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	ID      []byte             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShardID uint64             `protobuf:"varint,2,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	Replica metapb.Replica     `protobuf:"bytes,3,opt,name=replica,proto3" json:"replica"`
+	Lease   *metapb.EpochLease `protobuf:"bytes,4,opt,name=lease,proto3" json:"lease,omitempty"`
+	// commitTime the wall-clock time in nanoseconds stamped by the leader when
+	// the batch is proposed, all replicas see the same time of the raft entry.
+	// It never goes backwards in the log of the shard, see stampCommitTime.
+	CommitTime int64 `protobuf:"varint,5,opt,name=commitTime,proto3" json:"commitTime,omitempty"`
+	// priority the priority of the requests in the batch
+	Priority             RequestPriority `protobuf:"varint,6,opt,name=priority,proto3,enum=rpcpb.RequestPriority" json:"priority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *RequestBatchHeader) Reset()         { *m = RequestBatchHeader{} }
 func (m *RequestBatchHeader) String() string { return proto.CompactTextString(m) }
 func (*RequestBatchHeader) ProtoMessage()    {}
 func (*RequestBatchHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{54}
+	return fileDescriptor_25e491924c678914, []int{63}
 }
 func (m *RequestBatchHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3880,6 +4566,20 @@ func (m *RequestBatchHeader) GetLease() *metapb.EpochLease {
 	return nil
 }
 
+func (m *RequestBatchHeader) GetCommitTime() int64 {
+	if m != nil {
+		return m.CommitTime
+	}
+	return 0
+}
+
+func (m *RequestBatchHeader) GetPriority() RequestPriority {
+	if m != nil {
+		return m.Priority
+	}
+	return NormalPriority
+}
+
 type ResponseBatchHeader struct {
 	ID                   []byte        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Error                errorpb.Error `protobuf:"bytes,2,opt,name=error,proto3" json:"error"`
@@ -3892,7 +4592,7 @@ func (m *ResponseBatchHeader) Reset()         { *m = ResponseBatchHeader{} }
 func (m *ResponseBatchHeader) String() string { return proto.CompactTextString(m) }
 func (*ResponseBatchHeader) ProtoMessage()    {}
 func (*ResponseBatchHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{55}
+	return fileDescriptor_25e491924c678914, []int{64}
 }
 func (m *ResponseBatchHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3938,18 +4638,25 @@ func (m *ResponseBatchHeader) GetError() errorpb.Error {
 // RequestBatch we can't include both normal requests and administrator request
 // at same time.
 type RequestBatch struct {
-	Header               RequestBatchHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
-	Requests             []Request          `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Header   RequestBatchHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
+	Requests []Request          `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests"`
+	// Chunk a chunk of the request batch larger than the max entry size, the
+	// request batch is split into multiple raft entries and reassembled when
+	// the last chunk is applied.
+	Chunk *ProposalChunk `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// Compressed the marshaled request batch compressed with the compression
+	// dictionary of the shard, it is decompressed before applied.
+	Compressed           []byte   `protobuf:"bytes,4,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *RequestBatch) Reset()         { *m = RequestBatch{} }
 func (m *RequestBatch) String() string { return proto.CompactTextString(m) }
 func (*RequestBatch) ProtoMessage()    {}
 func (*RequestBatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{56}
+	return fileDescriptor_25e491924c678914, []int{65}
 }
 func (m *RequestBatch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3992,6 +4699,86 @@ func (m *RequestBatch) GetRequests() []Request {
 	return nil
 }
 
+func (m *RequestBatch) GetChunk() *ProposalChunk {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+func (m *RequestBatch) GetCompressed() []byte {
+	if m != nil {
+		return m.Compressed
+	}
+	return nil
+}
+
+// ProposalChunk a chunk of the marshaled request batch
+type ProposalChunk struct {
+	// Index the index of the chunk, starts from 0
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Count the number of the chunks of the request batch
+	Count                uint32   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProposalChunk) Reset()         { *m = ProposalChunk{} }
+func (m *ProposalChunk) String() string { return proto.CompactTextString(m) }
+func (*ProposalChunk) ProtoMessage()    {}
+func (*ProposalChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{66}
+}
+func (m *ProposalChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ProposalChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ProposalChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ProposalChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProposalChunk.Merge(m, src)
+}
+func (m *ProposalChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *ProposalChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProposalChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProposalChunk proto.InternalMessageInfo
+
+func (m *ProposalChunk) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ProposalChunk) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ProposalChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
 // ResponseBatch response batch
 type ResponseBatch struct {
 	Header               ResponseBatchHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header"`
@@ -4005,7 +4792,7 @@ func (m *ResponseBatch) Reset()         { *m = ResponseBatch{} }
 func (m *ResponseBatch) String() string { return proto.CompactTextString(m) }
 func (*ResponseBatch) ProtoMessage()    {}
 func (*ResponseBatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{57}
+	return fileDescriptor_25e491924c678914, []int{67}
 }
 func (m *ResponseBatch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4069,22 +4856,42 @@ type Request struct {
 	KeysRange           *Range              `protobuf:"bytes,12,opt,name=keysRange,proto3" json:"keysRange,omitempty"`
 	ReplicaSelectPolicy ReplicaSelectPolicy `protobuf:"varint,13,opt,name=replicaSelectPolicy,proto3,enum=rpcpb.ReplicaSelectPolicy" json:"replicaSelectPolicy,omitempty"`
 	// TxnBatchRequest tranasction request if type == Txn
-	TxnBatchRequest      *txnpb.TxnBatchRequest      `protobuf:"bytes,14,opt,name=txnBatchRequest,proto3" json:"txnBatchRequest,omitempty"`
-	UpdateTxnRecord      UpdateTxnRecordRequest      `protobuf:"bytes,15,opt,name=updateTxnRecord,proto3" json:"updateTxnRecord"`
-	DeleteTxnRecord      DeleteTxnRecordRequest      `protobuf:"bytes,16,opt,name=deleteTxnRecord,proto3" json:"deleteTxnRecord"`
-	CommitTxnWriteData   CommitTxnWriteDataRequest   `protobuf:"bytes,17,opt,name=commitTxnWriteData,proto3" json:"commitTxnWriteData"`
-	RollbackTxnRecord    RollbackTxnWriteDataRequest `protobuf:"bytes,18,opt,name=rollbackTxnRecord,proto3" json:"rollbackTxnRecord"`
-	CleanTxnMVCCData     CleanTxnMVCCDataRequest     `protobuf:"bytes,19,opt,name=cleanTxnMVCCData,proto3" json:"cleanTxnMVCCData"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+	TxnBatchRequest    *txnpb.TxnBatchRequest      `protobuf:"bytes,14,opt,name=txnBatchRequest,proto3" json:"txnBatchRequest,omitempty"`
+	UpdateTxnRecord    UpdateTxnRecordRequest      `protobuf:"bytes,15,opt,name=updateTxnRecord,proto3" json:"updateTxnRecord"`
+	DeleteTxnRecord    DeleteTxnRecordRequest      `protobuf:"bytes,16,opt,name=deleteTxnRecord,proto3" json:"deleteTxnRecord"`
+	CommitTxnWriteData CommitTxnWriteDataRequest   `protobuf:"bytes,17,opt,name=commitTxnWriteData,proto3" json:"commitTxnWriteData"`
+	RollbackTxnRecord  RollbackTxnWriteDataRequest `protobuf:"bytes,18,opt,name=rollbackTxnRecord,proto3" json:"rollbackTxnRecord"`
+	CleanTxnMVCCData   CleanTxnMVCCDataRequest     `protobuf:"bytes,19,opt,name=cleanTxnMVCCData,proto3" json:"cleanTxnMVCCData"`
+	// AllowStaleRead the read can be served from the local state of the replica
+	// without the read index if the replica's apply lag exceeds the threshold
+	AllowStaleRead bool `protobuf:"varint,20,opt,name=allowStaleRead,proto3" json:"allowStaleRead,omitempty"`
+	// AppLeaseToken the fencing token of the shard's application lease, the
+	// write is rejected if the token is not the token of the current lease. 0
+	// means the write is not fenced.
+	AppLeaseToken uint64 `protobuf:"varint,21,opt,name=appLeaseToken,proto3" json:"appLeaseToken,omitempty"`
+	// FollowerRead the read can be served by a follower replica, the follower
+	// confirms the read index with the leader and reads its local state once
+	// the read index is applied.
+	FollowerRead bool `protobuf:"varint,22,opt,name=followerRead,proto3" json:"followerRead,omitempty"`
+	// CF the column family of the request, empty for the default column family
+	CF string `protobuf:"bytes,23,opt,name=cf,proto3" json:"cf,omitempty"`
+	// Priority the priority of the request to be proposed
+	Priority RequestPriority `protobuf:"varint,24,opt,name=priority,proto3,enum=rpcpb.RequestPriority" json:"priority,omitempty"`
+	// Timeout the time left before the deadline of the request in nanoseconds
+	// when it's sent. The store receiving the request turns it into a deadline
+	// on its own monotonic clock, and drops the request with DeadlineExceeded
+	// error once the deadline is exceeded. 0 means no timeout.
+	Timeout              int64    `protobuf:"varint,25,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{58}
+	return fileDescriptor_25e491924c678914, []int{68}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4246,6 +5053,48 @@ func (m *Request) GetCleanTxnMVCCData() CleanTxnMVCCDataRequest {
 	return CleanTxnMVCCDataRequest{}
 }
 
+func (m *Request) GetAllowStaleRead() bool {
+	if m != nil {
+		return m.AllowStaleRead
+	}
+	return false
+}
+
+func (m *Request) GetAppLeaseToken() uint64 {
+	if m != nil {
+		return m.AppLeaseToken
+	}
+	return 0
+}
+
+func (m *Request) GetFollowerRead() bool {
+	if m != nil {
+		return m.FollowerRead
+	}
+	return false
+}
+
+func (m *Request) GetCF() string {
+	if m != nil {
+		return m.CF
+	}
+	return ""
+}
+
+func (m *Request) GetPriority() RequestPriority {
+	if m != nil {
+		return m.Priority
+	}
+	return NormalPriority
+}
+
+func (m *Request) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
 // Range key range [from, to)
 type Range struct {
 	// From include
@@ -4261,7 +5110,7 @@ func (m *Range) Reset()         { *m = Range{} }
 func (m *Range) String() string { return proto.CompactTextString(m) }
 func (*Range) ProtoMessage()    {}
 func (*Range) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{59}
+	return fileDescriptor_25e491924c678914, []int{69}
 }
 func (m *Range) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4313,22 +5162,28 @@ type Response struct {
 	PID        int64         `protobuf:"varint,5,opt,name=pid,proto3" json:"pid,omitempty"`
 	Error      errorpb.Error `protobuf:"bytes,6,opt,name=error,proto3" json:"error"`
 	// TxnBatchRequest tranasction request if type == Txn
-	TxnBatchResponse     *txnpb.TxnBatchResponse      `protobuf:"bytes,7,opt,name=txnBatchResponse,proto3" json:"txnBatchResponse,omitempty"`
-	UpdateTxnRecord      *UpdateTxnRecordRequest      `protobuf:"bytes,8,opt,name=updateTxnRecord,proto3" json:"updateTxnRecord,omitempty"`
-	DeleteTxnRecord      *DeleteTxnRecordRequest      `protobuf:"bytes,9,opt,name=deleteTxnRecord,proto3" json:"deleteTxnRecord,omitempty"`
-	CommitTxnWriteData   *CommitTxnWriteDataRequest   `protobuf:"bytes,10,opt,name=commitTxnWriteData,proto3" json:"commitTxnWriteData,omitempty"`
-	RollbackTxnRecord    *RollbackTxnWriteDataRequest `protobuf:"bytes,11,opt,name=rollbackTxnRecord,proto3" json:"rollbackTxnRecord,omitempty"`
-	CleanTxnMVCCData     *CleanTxnMVCCDataRequest     `protobuf:"bytes,12,opt,name=cleanTxnMVCCData,proto3" json:"cleanTxnMVCCData,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+	TxnBatchResponse   *txnpb.TxnBatchResponse      `protobuf:"bytes,7,opt,name=txnBatchResponse,proto3" json:"txnBatchResponse,omitempty"`
+	UpdateTxnRecord    *UpdateTxnRecordRequest      `protobuf:"bytes,8,opt,name=updateTxnRecord,proto3" json:"updateTxnRecord,omitempty"`
+	DeleteTxnRecord    *DeleteTxnRecordRequest      `protobuf:"bytes,9,opt,name=deleteTxnRecord,proto3" json:"deleteTxnRecord,omitempty"`
+	CommitTxnWriteData *CommitTxnWriteDataRequest   `protobuf:"bytes,10,opt,name=commitTxnWriteData,proto3" json:"commitTxnWriteData,omitempty"`
+	RollbackTxnRecord  *RollbackTxnWriteDataRequest `protobuf:"bytes,11,opt,name=rollbackTxnRecord,proto3" json:"rollbackTxnRecord,omitempty"`
+	CleanTxnMVCCData   *CleanTxnMVCCDataRequest     `protobuf:"bytes,12,opt,name=cleanTxnMVCCData,proto3" json:"cleanTxnMVCCData,omitempty"`
+	// ApplyLag the apply lag of the replica if the read is served as a stale read
+	ApplyLag uint64 `protobuf:"varint,13,opt,name=applyLag,proto3" json:"applyLag,omitempty"`
+	// AppliedIndex the applied index of the replica when the read is served,
+	// the result of the read reflects the state at or after the index. It's
+	// the index of the write if the request is a write.
+	AppliedIndex         uint64   `protobuf:"varint,14,opt,name=appliedIndex,proto3" json:"appliedIndex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{60}
+	return fileDescriptor_25e491924c678914, []int{70}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4441,20 +5296,36 @@ func (m *Response) GetCleanTxnMVCCData() *CleanTxnMVCCDataRequest {
 	return nil
 }
 
+func (m *Response) GetApplyLag() uint64 {
+	if m != nil {
+		return m.ApplyLag
+	}
+	return 0
+}
+
+func (m *Response) GetAppliedIndex() uint64 {
+	if m != nil {
+		return m.AppliedIndex
+	}
+	return 0
+}
+
 type ConfigChangeRequest struct {
 	// This can be only called in internal RaftStore now.
-	ChangeType           metapb.ConfigChangeType `protobuf:"varint,1,opt,name=changeType,proto3,enum=metapb.ConfigChangeType" json:"changeType,omitempty"`
-	Replica              metapb.Replica          `protobuf:"bytes,2,opt,name=replica,proto3" json:"replica"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	ChangeType metapb.ConfigChangeType `protobuf:"varint,1,opt,name=changeType,proto3,enum=metapb.ConfigChangeType" json:"changeType,omitempty"`
+	Replica    metapb.Replica          `protobuf:"bytes,2,opt,name=replica,proto3" json:"replica"`
+	// Initiator the operator initiating the change, empty if unknown
+	Initiator            string   `protobuf:"bytes,3,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ConfigChangeRequest) Reset()         { *m = ConfigChangeRequest{} }
 func (m *ConfigChangeRequest) String() string { return proto.CompactTextString(m) }
 func (*ConfigChangeRequest) ProtoMessage()    {}
 func (*ConfigChangeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{61}
+	return fileDescriptor_25e491924c678914, []int{71}
 }
 func (m *ConfigChangeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4497,6 +5368,13 @@ func (m *ConfigChangeRequest) GetReplica() metapb.Replica {
 	return metapb.Replica{}
 }
 
+func (m *ConfigChangeRequest) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
 // ConfigChangeResponse change peer response
 type ConfigChangeResponse struct {
 	Shard                metapb.Shard `protobuf:"bytes,1,opt,name=shard,proto3" json:"shard"`
@@ -4509,7 +5387,7 @@ func (m *ConfigChangeResponse) Reset()         { *m = ConfigChangeResponse{} }
 func (m *ConfigChangeResponse) String() string { return proto.CompactTextString(m) }
 func (*ConfigChangeResponse) ProtoMessage()    {}
 func (*ConfigChangeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{62}
+	return fileDescriptor_25e491924c678914, []int{72}
 }
 func (m *ConfigChangeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4557,7 +5435,7 @@ func (m *CompactLogRequest) Reset()         { *m = CompactLogRequest{} }
 func (m *CompactLogRequest) String() string { return proto.CompactTextString(m) }
 func (*CompactLogRequest) ProtoMessage()    {}
 func (*CompactLogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{63}
+	return fileDescriptor_25e491924c678914, []int{73}
 }
 func (m *CompactLogRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4604,7 +5482,7 @@ func (m *CompactLogResponse) Reset()         { *m = CompactLogResponse{} }
 func (m *CompactLogResponse) String() string { return proto.CompactTextString(m) }
 func (*CompactLogResponse) ProtoMessage()    {}
 func (*CompactLogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{64}
+	return fileDescriptor_25e491924c678914, []int{74}
 }
 func (m *CompactLogResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4645,7 +5523,7 @@ func (m *TransferLeaderRequest) Reset()         { *m = TransferLeaderRequest{} }
 func (m *TransferLeaderRequest) String() string { return proto.CompactTextString(m) }
 func (*TransferLeaderRequest) ProtoMessage()    {}
 func (*TransferLeaderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{65}
+	return fileDescriptor_25e491924c678914, []int{75}
 }
 func (m *TransferLeaderRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4691,7 +5569,7 @@ func (m *TransferLeaderResponse) Reset()         { *m = TransferLeaderResponse{}
 func (m *TransferLeaderResponse) String() string { return proto.CompactTextString(m) }
 func (*TransferLeaderResponse) ProtoMessage()    {}
 func (*TransferLeaderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{66}
+	return fileDescriptor_25e491924c678914, []int{76}
 }
 func (m *TransferLeaderResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4736,7 +5614,7 @@ func (m *BatchSplitRequest) Reset()         { *m = BatchSplitRequest{} }
 func (m *BatchSplitRequest) String() string { return proto.CompactTextString(m) }
 func (*BatchSplitRequest) ProtoMessage()    {}
 func (*BatchSplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{67}
+	return fileDescriptor_25e491924c678914, []int{77}
 }
 func (m *BatchSplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4797,7 +5675,7 @@ func (m *SplitRequest) Reset()         { *m = SplitRequest{} }
 func (m *SplitRequest) String() string { return proto.CompactTextString(m) }
 func (*SplitRequest) ProtoMessage()    {}
 func (*SplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{68}
+	return fileDescriptor_25e491924c678914, []int{78}
 }
 func (m *SplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4865,7 +5743,7 @@ func (m *BatchSplitResponse) Reset()         { *m = BatchSplitResponse{} }
 func (m *BatchSplitResponse) String() string { return proto.CompactTextString(m) }
 func (*BatchSplitResponse) ProtoMessage()    {}
 func (*BatchSplitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{69}
+	return fileDescriptor_25e491924c678914, []int{79}
 }
 func (m *BatchSplitResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4912,7 +5790,7 @@ func (m *UpdateMetadataRequest) Reset()         { *m = UpdateMetadataRequest{} }
 func (m *UpdateMetadataRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateMetadataRequest) ProtoMessage()    {}
 func (*UpdateMetadataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{70}
+	return fileDescriptor_25e491924c678914, []int{80}
 }
 func (m *UpdateMetadataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4958,7 +5836,7 @@ func (m *UpdateMetadataResponse) Reset()         { *m = UpdateMetadataResponse{}
 func (m *UpdateMetadataResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateMetadataResponse) ProtoMessage()    {}
 func (*UpdateMetadataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{71}
+	return fileDescriptor_25e491924c678914, []int{81}
 }
 func (m *UpdateMetadataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -4999,7 +5877,7 @@ func (m *UpdateLabelsRequest) Reset()         { *m = UpdateLabelsRequest{} }
 func (m *UpdateLabelsRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateLabelsRequest) ProtoMessage()    {}
 func (*UpdateLabelsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{72}
+	return fileDescriptor_25e491924c678914, []int{82}
 }
 func (m *UpdateLabelsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5052,7 +5930,7 @@ func (m *UpdateLabelsResponse) Reset()         { *m = UpdateLabelsResponse{} }
 func (m *UpdateLabelsResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateLabelsResponse) ProtoMessage()    {}
 func (*UpdateLabelsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{73}
+	return fileDescriptor_25e491924c678914, []int{83}
 }
 func (m *UpdateLabelsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -5081,26 +5959,25 @@ func (m *UpdateLabelsResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_UpdateLabelsResponse proto.InternalMessageInfo
 
-type UpdateEpochLeaseRequest struct {
-	ShardID              uint64            `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
-	Lease                metapb.EpochLease `protobuf:"bytes,2,opt,name=lease,proto3" json:"lease"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+type UpdateGateRequest struct {
+	Gate                 metapb.ShardGate `protobuf:"bytes,1,opt,name=gate,proto3" json:"gate"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *UpdateEpochLeaseRequest) Reset()         { *m = UpdateEpochLeaseRequest{} }
-func (m *UpdateEpochLeaseRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateEpochLeaseRequest) ProtoMessage()    {}
-func (*UpdateEpochLeaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{74}
+func (m *UpdateGateRequest) Reset()         { *m = UpdateGateRequest{} }
+func (m *UpdateGateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateGateRequest) ProtoMessage()    {}
+func (*UpdateGateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{84}
 }
-func (m *UpdateEpochLeaseRequest) XXX_Unmarshal(b []byte) error {
+func (m *UpdateGateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UpdateEpochLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateGateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UpdateEpochLeaseRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateGateRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5110,50 +5987,43 @@ func (m *UpdateEpochLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]b
 		return b[:n], nil
 	}
 }
-func (m *UpdateEpochLeaseRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateEpochLeaseRequest.Merge(m, src)
+func (m *UpdateGateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateGateRequest.Merge(m, src)
 }
-func (m *UpdateEpochLeaseRequest) XXX_Size() int {
+func (m *UpdateGateRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *UpdateEpochLeaseRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateEpochLeaseRequest.DiscardUnknown(m)
+func (m *UpdateGateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateGateRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateEpochLeaseRequest proto.InternalMessageInfo
-
-func (m *UpdateEpochLeaseRequest) GetShardID() uint64 {
-	if m != nil {
-		return m.ShardID
-	}
-	return 0
-}
+var xxx_messageInfo_UpdateGateRequest proto.InternalMessageInfo
 
-func (m *UpdateEpochLeaseRequest) GetLease() metapb.EpochLease {
+func (m *UpdateGateRequest) GetGate() metapb.ShardGate {
 	if m != nil {
-		return m.Lease
+		return m.Gate
 	}
-	return metapb.EpochLease{}
+	return metapb.ShardGate{}
 }
 
-type UpdateEpochLeaseResponse struct {
+type UpdateGateResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateEpochLeaseResponse) Reset()         { *m = UpdateEpochLeaseResponse{} }
-func (m *UpdateEpochLeaseResponse) String() string { return proto.CompactTextString(m) }
-func (*UpdateEpochLeaseResponse) ProtoMessage()    {}
-func (*UpdateEpochLeaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{75}
+func (m *UpdateGateResponse) Reset()         { *m = UpdateGateResponse{} }
+func (m *UpdateGateResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateGateResponse) ProtoMessage()    {}
+func (*UpdateGateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{85}
 }
-func (m *UpdateEpochLeaseResponse) XXX_Unmarshal(b []byte) error {
+func (m *UpdateGateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UpdateEpochLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateGateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UpdateEpochLeaseResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateGateResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5163,38 +6033,44 @@ func (m *UpdateEpochLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]
 		return b[:n], nil
 	}
 }
-func (m *UpdateEpochLeaseResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateEpochLeaseResponse.Merge(m, src)
+func (m *UpdateGateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateGateResponse.Merge(m, src)
 }
-func (m *UpdateEpochLeaseResponse) XXX_Size() int {
+func (m *UpdateGateResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *UpdateEpochLeaseResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateEpochLeaseResponse.DiscardUnknown(m)
+func (m *UpdateGateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateGateResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateEpochLeaseResponse proto.InternalMessageInfo
+var xxx_messageInfo_UpdateGateResponse proto.InternalMessageInfo
 
-// UpdateTxnRecordRequest update txn record request
-type UpdateTxnRecordRequest struct {
-	TxnRecord            txnpb.TxnRecord `protobuf:"bytes,1,opt,name=txnRecord,proto3" json:"txnRecord"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+// AcquireAppLeaseRequest acquires the application lease of the shard, or renews
+// it if the holder already holds the lease
+type AcquireAppLeaseRequest struct {
+	Holder string `protobuf:"bytes,1,opt,name=holder,proto3" json:"holder,omitempty"`
+	// TTL the duration of the lease in nanoseconds
+	TTL int64 `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Now the unix nanoseconds set by the leader when proposing, so all replicas
+	// decide the expiration with the same clock
+	Now                  int64    `protobuf:"varint,3,opt,name=now,proto3" json:"now,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateTxnRecordRequest) Reset()         { *m = UpdateTxnRecordRequest{} }
-func (m *UpdateTxnRecordRequest) String() string { return proto.CompactTextString(m) }
-func (*UpdateTxnRecordRequest) ProtoMessage()    {}
-func (*UpdateTxnRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{76}
+func (m *AcquireAppLeaseRequest) Reset()         { *m = AcquireAppLeaseRequest{} }
+func (m *AcquireAppLeaseRequest) String() string { return proto.CompactTextString(m) }
+func (*AcquireAppLeaseRequest) ProtoMessage()    {}
+func (*AcquireAppLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{86}
 }
-func (m *UpdateTxnRecordRequest) XXX_Unmarshal(b []byte) error {
+func (m *AcquireAppLeaseRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UpdateTxnRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AcquireAppLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UpdateTxnRecordRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AcquireAppLeaseRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5204,45 +6080,61 @@ func (m *UpdateTxnRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *UpdateTxnRecordRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateTxnRecordRequest.Merge(m, src)
+func (m *AcquireAppLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcquireAppLeaseRequest.Merge(m, src)
 }
-func (m *UpdateTxnRecordRequest) XXX_Size() int {
+func (m *AcquireAppLeaseRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *UpdateTxnRecordRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateTxnRecordRequest.DiscardUnknown(m)
+func (m *AcquireAppLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcquireAppLeaseRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateTxnRecordRequest proto.InternalMessageInfo
+var xxx_messageInfo_AcquireAppLeaseRequest proto.InternalMessageInfo
 
-func (m *UpdateTxnRecordRequest) GetTxnRecord() txnpb.TxnRecord {
+func (m *AcquireAppLeaseRequest) GetHolder() string {
 	if m != nil {
-		return m.TxnRecord
+		return m.Holder
 	}
-	return txnpb.TxnRecord{}
+	return ""
 }
 
-// UpdateTxnRecordResponse update txn record response
-type UpdateTxnRecordResponse struct {
-	TxnRecord            txnpb.TxnRecord `protobuf:"bytes,1,opt,name=txnRecord,proto3" json:"txnRecord"`
+func (m *AcquireAppLeaseRequest) GetTTL() int64 {
+	if m != nil {
+		return m.TTL
+	}
+	return 0
+}
+
+func (m *AcquireAppLeaseRequest) GetNow() int64 {
+	if m != nil {
+		return m.Now
+	}
+	return 0
+}
+
+type AcquireAppLeaseResponse struct {
+	// Acquired false means the lease is held by another holder
+	Acquired bool `protobuf:"varint,1,opt,name=acquired,proto3" json:"acquired,omitempty"`
+	// Lease the current lease of the shard
+	Lease                metapb.AppLease `protobuf:"bytes,2,opt,name=lease,proto3" json:"lease"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *UpdateTxnRecordResponse) Reset()         { *m = UpdateTxnRecordResponse{} }
-func (m *UpdateTxnRecordResponse) String() string { return proto.CompactTextString(m) }
-func (*UpdateTxnRecordResponse) ProtoMessage()    {}
-func (*UpdateTxnRecordResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{77}
+func (m *AcquireAppLeaseResponse) Reset()         { *m = AcquireAppLeaseResponse{} }
+func (m *AcquireAppLeaseResponse) String() string { return proto.CompactTextString(m) }
+func (*AcquireAppLeaseResponse) ProtoMessage()    {}
+func (*AcquireAppLeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{87}
 }
-func (m *UpdateTxnRecordResponse) XXX_Unmarshal(b []byte) error {
+func (m *AcquireAppLeaseResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UpdateTxnRecordResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AcquireAppLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UpdateTxnRecordResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AcquireAppLeaseResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5252,46 +6144,52 @@ func (m *UpdateTxnRecordResponse) XXX_Marshal(b []byte, deterministic bool) ([]b
 		return b[:n], nil
 	}
 }
-func (m *UpdateTxnRecordResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateTxnRecordResponse.Merge(m, src)
+func (m *AcquireAppLeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcquireAppLeaseResponse.Merge(m, src)
 }
-func (m *UpdateTxnRecordResponse) XXX_Size() int {
+func (m *AcquireAppLeaseResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *UpdateTxnRecordResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateTxnRecordResponse.DiscardUnknown(m)
+func (m *AcquireAppLeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcquireAppLeaseResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateTxnRecordResponse proto.InternalMessageInfo
+var xxx_messageInfo_AcquireAppLeaseResponse proto.InternalMessageInfo
 
-func (m *UpdateTxnRecordResponse) GetTxnRecord() txnpb.TxnRecord {
+func (m *AcquireAppLeaseResponse) GetAcquired() bool {
 	if m != nil {
-		return m.TxnRecord
+		return m.Acquired
 	}
-	return txnpb.TxnRecord{}
+	return false
 }
 
-// DeleteTxnRecordRequest delete txn record request
-type DeleteTxnRecordRequest struct {
-	TxnRecordRouteKey    []byte   `protobuf:"bytes,1,opt,name=txnRecordRouteKey,proto3" json:"txnRecordRouteKey,omitempty"`
-	TxnID                []byte   `protobuf:"bytes,2,opt,name=txnID,proto3" json:"txnID,omitempty"`
+func (m *AcquireAppLeaseResponse) GetLease() metapb.AppLease {
+	if m != nil {
+		return m.Lease
+	}
+	return metapb.AppLease{}
+}
+
+// ReleaseAppLeaseRequest releases the application lease held with the token
+type ReleaseAppLeaseRequest struct {
+	Token                uint64   `protobuf:"varint,1,opt,name=token,proto3" json:"token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteTxnRecordRequest) Reset()         { *m = DeleteTxnRecordRequest{} }
-func (m *DeleteTxnRecordRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteTxnRecordRequest) ProtoMessage()    {}
-func (*DeleteTxnRecordRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{78}
+func (m *ReleaseAppLeaseRequest) Reset()         { *m = ReleaseAppLeaseRequest{} }
+func (m *ReleaseAppLeaseRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseAppLeaseRequest) ProtoMessage()    {}
+func (*ReleaseAppLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{88}
 }
-func (m *DeleteTxnRecordRequest) XXX_Unmarshal(b []byte) error {
+func (m *ReleaseAppLeaseRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteTxnRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ReleaseAppLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteTxnRecordRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ReleaseAppLeaseRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5301,51 +6199,45 @@ func (m *DeleteTxnRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *DeleteTxnRecordRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteTxnRecordRequest.Merge(m, src)
+func (m *ReleaseAppLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseAppLeaseRequest.Merge(m, src)
 }
-func (m *DeleteTxnRecordRequest) XXX_Size() int {
+func (m *ReleaseAppLeaseRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteTxnRecordRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteTxnRecordRequest.DiscardUnknown(m)
+func (m *ReleaseAppLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseAppLeaseRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteTxnRecordRequest proto.InternalMessageInfo
-
-func (m *DeleteTxnRecordRequest) GetTxnRecordRouteKey() []byte {
-	if m != nil {
-		return m.TxnRecordRouteKey
-	}
-	return nil
-}
+var xxx_messageInfo_ReleaseAppLeaseRequest proto.InternalMessageInfo
 
-func (m *DeleteTxnRecordRequest) GetTxnID() []byte {
+func (m *ReleaseAppLeaseRequest) GetToken() uint64 {
 	if m != nil {
-		return m.TxnID
+		return m.Token
 	}
-	return nil
+	return 0
 }
 
-// DeleteTxnRecordResponse delete txn record response
-type DeleteTxnRecordResponse struct {
+type ReleaseAppLeaseResponse struct {
+	// Released false means the token is not the token of the current lease
+	Released             bool     `protobuf:"varint,1,opt,name=released,proto3" json:"released,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteTxnRecordResponse) Reset()         { *m = DeleteTxnRecordResponse{} }
-func (m *DeleteTxnRecordResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteTxnRecordResponse) ProtoMessage()    {}
-func (*DeleteTxnRecordResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{79}
+func (m *ReleaseAppLeaseResponse) Reset()         { *m = ReleaseAppLeaseResponse{} }
+func (m *ReleaseAppLeaseResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleaseAppLeaseResponse) ProtoMessage()    {}
+func (*ReleaseAppLeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{89}
 }
-func (m *DeleteTxnRecordResponse) XXX_Unmarshal(b []byte) error {
+func (m *ReleaseAppLeaseResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteTxnRecordResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ReleaseAppLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteTxnRecordResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ReleaseAppLeaseResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5355,39 +6247,46 @@ func (m *DeleteTxnRecordResponse) XXX_Marshal(b []byte, deterministic bool) ([]b
 		return b[:n], nil
 	}
 }
-func (m *DeleteTxnRecordResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteTxnRecordResponse.Merge(m, src)
+func (m *ReleaseAppLeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseAppLeaseResponse.Merge(m, src)
 }
-func (m *DeleteTxnRecordResponse) XXX_Size() int {
+func (m *ReleaseAppLeaseResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteTxnRecordResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteTxnRecordResponse.DiscardUnknown(m)
+func (m *ReleaseAppLeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseAppLeaseResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteTxnRecordResponse proto.InternalMessageInfo
+var xxx_messageInfo_ReleaseAppLeaseResponse proto.InternalMessageInfo
 
-// CommitTxnWriteDataRequest commit txn write data request
-type CommitTxnWriteDataRequest struct {
-	OriginKey            []byte          `protobuf:"bytes,1,opt,name=originKey,proto3" json:"originKey,omitempty"`
-	CommitTS             hlcpb.Timestamp `protobuf:"bytes,2,opt,name=commitTS,proto3" json:"commitTS"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *ReleaseAppLeaseResponse) GetReleased() bool {
+	if m != nil {
+		return m.Released
+	}
+	return false
 }
 
-func (m *CommitTxnWriteDataRequest) Reset()         { *m = CommitTxnWriteDataRequest{} }
-func (m *CommitTxnWriteDataRequest) String() string { return proto.CompactTextString(m) }
-func (*CommitTxnWriteDataRequest) ProtoMessage()    {}
-func (*CommitTxnWriteDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{80}
+// SplitShardRequest split the shard by the specified split keys, the keys must
+// be in ascending order and inside the shard range.
+type SplitShardRequest struct {
+	SplitKeys            [][]byte `protobuf:"bytes,1,rep,name=splitKeys,proto3" json:"splitKeys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *CommitTxnWriteDataRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *SplitShardRequest) Reset()         { *m = SplitShardRequest{} }
+func (m *SplitShardRequest) String() string { return proto.CompactTextString(m) }
+func (*SplitShardRequest) ProtoMessage()    {}
+func (*SplitShardRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{90}
+}
+func (m *SplitShardRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CommitTxnWriteDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SplitShardRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CommitTxnWriteDataRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SplitShardRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5397,51 +6296,43 @@ func (m *CommitTxnWriteDataRequest) XXX_Marshal(b []byte, deterministic bool) ([
 		return b[:n], nil
 	}
 }
-func (m *CommitTxnWriteDataRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommitTxnWriteDataRequest.Merge(m, src)
+func (m *SplitShardRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SplitShardRequest.Merge(m, src)
 }
-func (m *CommitTxnWriteDataRequest) XXX_Size() int {
+func (m *SplitShardRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CommitTxnWriteDataRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommitTxnWriteDataRequest.DiscardUnknown(m)
+func (m *SplitShardRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SplitShardRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommitTxnWriteDataRequest proto.InternalMessageInfo
+var xxx_messageInfo_SplitShardRequest proto.InternalMessageInfo
 
-func (m *CommitTxnWriteDataRequest) GetOriginKey() []byte {
+func (m *SplitShardRequest) GetSplitKeys() [][]byte {
 	if m != nil {
-		return m.OriginKey
+		return m.SplitKeys
 	}
 	return nil
 }
 
-func (m *CommitTxnWriteDataRequest) GetCommitTS() hlcpb.Timestamp {
-	if m != nil {
-		return m.CommitTS
-	}
-	return hlcpb.Timestamp{}
-}
-
-// CommitTxnWriteDataResponse commit txn write data response
-type CommitTxnWriteDataResponse struct {
+type SplitShardResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CommitTxnWriteDataResponse) Reset()         { *m = CommitTxnWriteDataResponse{} }
-func (m *CommitTxnWriteDataResponse) String() string { return proto.CompactTextString(m) }
-func (*CommitTxnWriteDataResponse) ProtoMessage()    {}
-func (*CommitTxnWriteDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{81}
+func (m *SplitShardResponse) Reset()         { *m = SplitShardResponse{} }
+func (m *SplitShardResponse) String() string { return proto.CompactTextString(m) }
+func (*SplitShardResponse) ProtoMessage()    {}
+func (*SplitShardResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{91}
 }
-func (m *CommitTxnWriteDataResponse) XXX_Unmarshal(b []byte) error {
+func (m *SplitShardResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CommitTxnWriteDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SplitShardResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CommitTxnWriteDataResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SplitShardResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5451,39 +6342,42 @@ func (m *CommitTxnWriteDataResponse) XXX_Marshal(b []byte, deterministic bool) (
 		return b[:n], nil
 	}
 }
-func (m *CommitTxnWriteDataResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CommitTxnWriteDataResponse.Merge(m, src)
+func (m *SplitShardResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SplitShardResponse.Merge(m, src)
 }
-func (m *CommitTxnWriteDataResponse) XXX_Size() int {
+func (m *SplitShardResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *CommitTxnWriteDataResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CommitTxnWriteDataResponse.DiscardUnknown(m)
+func (m *SplitShardResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SplitShardResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CommitTxnWriteDataResponse proto.InternalMessageInfo
+var xxx_messageInfo_SplitShardResponse proto.InternalMessageInfo
 
-// RollbackTxnWriteDataRequest rollback txn write data request
-type RollbackTxnWriteDataRequest struct {
-	OriginKey            []byte          `protobuf:"bytes,1,opt,name=originKey,proto3" json:"originKey,omitempty"`
-	Timestamp            hlcpb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+// DeleteRangeRequest deletes the data in [start, end) of the shard, it's
+// proposed by the TTL GC. The deletion is skipped if any write is applied after
+// the checkIndex, which is the applied index when the data is found expired.
+type DeleteRangeRequest struct {
+	Start                []byte   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  []byte   `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	CheckIndex           uint64   `protobuf:"varint,3,opt,name=checkIndex,proto3" json:"checkIndex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RollbackTxnWriteDataRequest) Reset()         { *m = RollbackTxnWriteDataRequest{} }
-func (m *RollbackTxnWriteDataRequest) String() string { return proto.CompactTextString(m) }
-func (*RollbackTxnWriteDataRequest) ProtoMessage()    {}
-func (*RollbackTxnWriteDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{82}
+func (m *DeleteRangeRequest) Reset()         { *m = DeleteRangeRequest{} }
+func (m *DeleteRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRangeRequest) ProtoMessage()    {}
+func (*DeleteRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{92}
 }
-func (m *RollbackTxnWriteDataRequest) XXX_Unmarshal(b []byte) error {
+func (m *DeleteRangeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RollbackTxnWriteDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeleteRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RollbackTxnWriteDataRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeleteRangeRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5493,51 +6387,60 @@ func (m *RollbackTxnWriteDataRequest) XXX_Marshal(b []byte, deterministic bool)
 		return b[:n], nil
 	}
 }
-func (m *RollbackTxnWriteDataRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RollbackTxnWriteDataRequest.Merge(m, src)
+func (m *DeleteRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRangeRequest.Merge(m, src)
 }
-func (m *RollbackTxnWriteDataRequest) XXX_Size() int {
+func (m *DeleteRangeRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *RollbackTxnWriteDataRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RollbackTxnWriteDataRequest.DiscardUnknown(m)
+func (m *DeleteRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRangeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RollbackTxnWriteDataRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeleteRangeRequest proto.InternalMessageInfo
 
-func (m *RollbackTxnWriteDataRequest) GetOriginKey() []byte {
+func (m *DeleteRangeRequest) GetStart() []byte {
 	if m != nil {
-		return m.OriginKey
+		return m.Start
 	}
 	return nil
 }
 
-func (m *RollbackTxnWriteDataRequest) GetTimestamp() hlcpb.Timestamp {
+func (m *DeleteRangeRequest) GetEnd() []byte {
 	if m != nil {
-		return m.Timestamp
+		return m.End
 	}
-	return hlcpb.Timestamp{}
+	return nil
 }
 
-// RollbackTxnWriteDataResponse rollback txn write data response
-type RollbackTxnWriteDataResponse struct {
+func (m *DeleteRangeRequest) GetCheckIndex() uint64 {
+	if m != nil {
+		return m.CheckIndex
+	}
+	return 0
+}
+
+// DeleteRangeResponse is the response of DeleteRangeRequest
+type DeleteRangeResponse struct {
+	// Deleted false if the deletion is skipped
+	Deleted              bool     `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RollbackTxnWriteDataResponse) Reset()         { *m = RollbackTxnWriteDataResponse{} }
-func (m *RollbackTxnWriteDataResponse) String() string { return proto.CompactTextString(m) }
-func (*RollbackTxnWriteDataResponse) ProtoMessage()    {}
-func (*RollbackTxnWriteDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{83}
+func (m *DeleteRangeResponse) Reset()         { *m = DeleteRangeResponse{} }
+func (m *DeleteRangeResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteRangeResponse) ProtoMessage()    {}
+func (*DeleteRangeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{93}
 }
-func (m *RollbackTxnWriteDataResponse) XXX_Unmarshal(b []byte) error {
+func (m *DeleteRangeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RollbackTxnWriteDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeleteRangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RollbackTxnWriteDataResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeleteRangeResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5547,38 +6450,45 @@ func (m *RollbackTxnWriteDataResponse) XXX_Marshal(b []byte, deterministic bool)
 		return b[:n], nil
 	}
 }
-func (m *RollbackTxnWriteDataResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RollbackTxnWriteDataResponse.Merge(m, src)
+func (m *DeleteRangeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRangeResponse.Merge(m, src)
 }
-func (m *RollbackTxnWriteDataResponse) XXX_Size() int {
+func (m *DeleteRangeResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *RollbackTxnWriteDataResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RollbackTxnWriteDataResponse.DiscardUnknown(m)
+func (m *DeleteRangeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRangeResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RollbackTxnWriteDataResponse proto.InternalMessageInfo
+var xxx_messageInfo_DeleteRangeResponse proto.InternalMessageInfo
 
-// CleanTxnMVCCDataRequest clean txn mvcc data request
-type CleanTxnMVCCDataRequest struct {
-	Timestamp            hlcpb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *DeleteRangeResponse) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
 }
 
-func (m *CleanTxnMVCCDataRequest) Reset()         { *m = CleanTxnMVCCDataRequest{} }
-func (m *CleanTxnMVCCDataRequest) String() string { return proto.CompactTextString(m) }
-func (*CleanTxnMVCCDataRequest) ProtoMessage()    {}
-func (*CleanTxnMVCCDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{84}
+// BarrierRequest is a no-op admin request, all the requests proposed before the
+// barrier are applied once the barrier is applied.
+type BarrierRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *CleanTxnMVCCDataRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *BarrierRequest) Reset()         { *m = BarrierRequest{} }
+func (m *BarrierRequest) String() string { return proto.CompactTextString(m) }
+func (*BarrierRequest) ProtoMessage()    {}
+func (*BarrierRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{94}
+}
+func (m *BarrierRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CleanTxnMVCCDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BarrierRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CleanTxnMVCCDataRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BarrierRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5588,44 +6498,39 @@ func (m *CleanTxnMVCCDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]b
 		return b[:n], nil
 	}
 }
-func (m *CleanTxnMVCCDataRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CleanTxnMVCCDataRequest.Merge(m, src)
+func (m *BarrierRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BarrierRequest.Merge(m, src)
 }
-func (m *CleanTxnMVCCDataRequest) XXX_Size() int {
+func (m *BarrierRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *CleanTxnMVCCDataRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CleanTxnMVCCDataRequest.DiscardUnknown(m)
+func (m *BarrierRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BarrierRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CleanTxnMVCCDataRequest proto.InternalMessageInfo
-
-func (m *CleanTxnMVCCDataRequest) GetTimestamp() hlcpb.Timestamp {
-	if m != nil {
-		return m.Timestamp
-	}
-	return hlcpb.Timestamp{}
-}
+var xxx_messageInfo_BarrierRequest proto.InternalMessageInfo
 
-// CleanTxnMVCCDataResponse clean txn mvcc data response
-type CleanTxnMVCCDataResponse struct {
+// BarrierResponse is the response of BarrierRequest
+type BarrierResponse struct {
+	// Index the raft log index of the applied barrier
+	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CleanTxnMVCCDataResponse) Reset()         { *m = CleanTxnMVCCDataResponse{} }
-func (m *CleanTxnMVCCDataResponse) String() string { return proto.CompactTextString(m) }
-func (*CleanTxnMVCCDataResponse) ProtoMessage()    {}
-func (*CleanTxnMVCCDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{85}
+func (m *BarrierResponse) Reset()         { *m = BarrierResponse{} }
+func (m *BarrierResponse) String() string { return proto.CompactTextString(m) }
+func (*BarrierResponse) ProtoMessage()    {}
+func (*BarrierResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{95}
 }
-func (m *CleanTxnMVCCDataResponse) XXX_Unmarshal(b []byte) error {
+func (m *BarrierResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *CleanTxnMVCCDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BarrierResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_CleanTxnMVCCDataResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BarrierResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5635,39 +6540,45 @@ func (m *CleanTxnMVCCDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]
 		return b[:n], nil
 	}
 }
-func (m *CleanTxnMVCCDataResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CleanTxnMVCCDataResponse.Merge(m, src)
+func (m *BarrierResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BarrierResponse.Merge(m, src)
 }
-func (m *CleanTxnMVCCDataResponse) XXX_Size() int {
+func (m *BarrierResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *CleanTxnMVCCDataResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CleanTxnMVCCDataResponse.DiscardUnknown(m)
+func (m *BarrierResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BarrierResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CleanTxnMVCCDataResponse proto.InternalMessageInfo
+var xxx_messageInfo_BarrierResponse proto.InternalMessageInfo
 
-// KVSetRequest kv set request
-type KVSetRequest struct {
-	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+func (m *BarrierResponse) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+// ComputeHashRequest computes the checksum of the shard data on every replica,
+// at the applied index of the request.
+type ComputeHashRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVSetRequest) Reset()         { *m = KVSetRequest{} }
-func (m *KVSetRequest) String() string { return proto.CompactTextString(m) }
-func (*KVSetRequest) ProtoMessage()    {}
-func (*KVSetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{86}
+func (m *ComputeHashRequest) Reset()         { *m = ComputeHashRequest{} }
+func (m *ComputeHashRequest) String() string { return proto.CompactTextString(m) }
+func (*ComputeHashRequest) ProtoMessage()    {}
+func (*ComputeHashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{96}
 }
-func (m *KVSetRequest) XXX_Unmarshal(b []byte) error {
+func (m *ComputeHashRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ComputeHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVSetRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ComputeHashRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5677,51 +6588,44 @@ func (m *KVSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *KVSetRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVSetRequest.Merge(m, src)
+func (m *ComputeHashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeHashRequest.Merge(m, src)
 }
-func (m *KVSetRequest) XXX_Size() int {
+func (m *ComputeHashRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVSetRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVSetRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_KVSetRequest proto.InternalMessageInfo
-
-func (m *KVSetRequest) GetKey() []byte {
-	if m != nil {
-		return m.Key
-	}
-	return nil
+func (m *ComputeHashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeHashRequest.DiscardUnknown(m)
 }
 
-func (m *KVSetRequest) GetValue() []byte {
-	if m != nil {
-		return m.Value
-	}
-	return nil
-}
+var xxx_messageInfo_ComputeHashRequest proto.InternalMessageInfo
 
-// KVSetResponse kv set response
-type KVSetResponse struct {
+// ComputeHashResponse is the response of ComputeHashRequest, the checksum is
+// computed by the replica returning the response.
+type ComputeHashResponse struct {
+	// Index the raft log index of the applied request
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Hash  uint64 `protobuf:"varint,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Computed false if the replica can't compute the checksum, e.g. it's a
+	// witness without the shard data
+	Computed             bool     `protobuf:"varint,3,opt,name=computed,proto3" json:"computed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVSetResponse) Reset()         { *m = KVSetResponse{} }
-func (m *KVSetResponse) String() string { return proto.CompactTextString(m) }
-func (*KVSetResponse) ProtoMessage()    {}
-func (*KVSetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{87}
+func (m *ComputeHashResponse) Reset()         { *m = ComputeHashResponse{} }
+func (m *ComputeHashResponse) String() string { return proto.CompactTextString(m) }
+func (*ComputeHashResponse) ProtoMessage()    {}
+func (*ComputeHashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{97}
 }
-func (m *KVSetResponse) XXX_Unmarshal(b []byte) error {
+func (m *ComputeHashResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ComputeHashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVSetResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ComputeHashResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5731,39 +6635,61 @@ func (m *KVSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *KVSetResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVSetResponse.Merge(m, src)
+func (m *ComputeHashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeHashResponse.Merge(m, src)
 }
-func (m *KVSetResponse) XXX_Size() int {
+func (m *ComputeHashResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVSetResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVSetResponse.DiscardUnknown(m)
+func (m *ComputeHashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeHashResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVSetResponse proto.InternalMessageInfo
+var xxx_messageInfo_ComputeHashResponse proto.InternalMessageInfo
 
-// KVBatchSetRequest kv batch set request
-type KVBatchSetRequest struct {
-	Keys                 [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
-	Values               [][]byte `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+func (m *ComputeHashResponse) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ComputeHashResponse) GetHash() uint64 {
+	if m != nil {
+		return m.Hash
+	}
+	return 0
+}
+
+func (m *ComputeHashResponse) GetComputed() bool {
+	if m != nil {
+		return m.Computed
+	}
+	return false
+}
+
+// VerifyHashRequest compares the checksum computed by every replica at the
+// index with the expected hash, the divergence is reported to prophet.
+type VerifyHashRequest struct {
+	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Hash                 uint64   `protobuf:"varint,2,opt,name=hash,proto3" json:"hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVBatchSetRequest) Reset()         { *m = KVBatchSetRequest{} }
-func (m *KVBatchSetRequest) String() string { return proto.CompactTextString(m) }
-func (*KVBatchSetRequest) ProtoMessage()    {}
-func (*KVBatchSetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{88}
+func (m *VerifyHashRequest) Reset()         { *m = VerifyHashRequest{} }
+func (m *VerifyHashRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyHashRequest) ProtoMessage()    {}
+func (*VerifyHashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{98}
 }
-func (m *KVBatchSetRequest) XXX_Unmarshal(b []byte) error {
+func (m *VerifyHashRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVBatchSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *VerifyHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVBatchSetRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_VerifyHashRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5773,51 +6699,50 @@ func (m *KVBatchSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *KVBatchSetRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVBatchSetRequest.Merge(m, src)
+func (m *VerifyHashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyHashRequest.Merge(m, src)
 }
-func (m *KVBatchSetRequest) XXX_Size() int {
+func (m *VerifyHashRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVBatchSetRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVBatchSetRequest.DiscardUnknown(m)
+func (m *VerifyHashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyHashRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVBatchSetRequest proto.InternalMessageInfo
+var xxx_messageInfo_VerifyHashRequest proto.InternalMessageInfo
 
-func (m *KVBatchSetRequest) GetKeys() [][]byte {
+func (m *VerifyHashRequest) GetIndex() uint64 {
 	if m != nil {
-		return m.Keys
+		return m.Index
 	}
-	return nil
+	return 0
 }
 
-func (m *KVBatchSetRequest) GetValues() [][]byte {
+func (m *VerifyHashRequest) GetHash() uint64 {
 	if m != nil {
-		return m.Values
+		return m.Hash
 	}
-	return nil
+	return 0
 }
 
-// KVBatchSetResponse kv batch set response
-type KVBatchSetResponse struct {
+type VerifyHashResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVBatchSetResponse) Reset()         { *m = KVBatchSetResponse{} }
-func (m *KVBatchSetResponse) String() string { return proto.CompactTextString(m) }
-func (*KVBatchSetResponse) ProtoMessage()    {}
-func (*KVBatchSetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{89}
+func (m *VerifyHashResponse) Reset()         { *m = VerifyHashResponse{} }
+func (m *VerifyHashResponse) String() string { return proto.CompactTextString(m) }
+func (*VerifyHashResponse) ProtoMessage()    {}
+func (*VerifyHashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{99}
 }
-func (m *KVBatchSetResponse) XXX_Unmarshal(b []byte) error {
+func (m *VerifyHashResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVBatchSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *VerifyHashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVBatchSetResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_VerifyHashResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5827,38 +6752,43 @@ func (m *KVBatchSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *KVBatchSetResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVBatchSetResponse.Merge(m, src)
+func (m *VerifyHashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyHashResponse.Merge(m, src)
 }
-func (m *KVBatchSetResponse) XXX_Size() int {
+func (m *VerifyHashResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVBatchSetResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVBatchSetResponse.DiscardUnknown(m)
+func (m *VerifyHashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyHashResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVBatchSetResponse proto.InternalMessageInfo
+var xxx_messageInfo_VerifyHashResponse proto.InternalMessageInfo
 
-// KVGetRequest kv get request
-type KVGetRequest struct {
-	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+// PurgeRequest deletes the data in [start, end) and the keys of the shard on
+// every replica, and records a purge marker proving the deletion at the
+// applied index. The range is clipped to the shard, and the keys not in the
+// shard are skipped.
+type PurgeRequest struct {
+	Start                []byte   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  []byte   `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Keys                 [][]byte `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVGetRequest) Reset()         { *m = KVGetRequest{} }
-func (m *KVGetRequest) String() string { return proto.CompactTextString(m) }
-func (*KVGetRequest) ProtoMessage()    {}
-func (*KVGetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{90}
+func (m *PurgeRequest) Reset()         { *m = PurgeRequest{} }
+func (m *PurgeRequest) String() string { return proto.CompactTextString(m) }
+func (*PurgeRequest) ProtoMessage()    {}
+func (*PurgeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{100}
 }
-func (m *KVGetRequest) XXX_Unmarshal(b []byte) error {
+func (m *PurgeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PurgeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVGetRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PurgeRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5868,45 +6798,67 @@ func (m *KVGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *KVGetRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVGetRequest.Merge(m, src)
+func (m *PurgeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeRequest.Merge(m, src)
 }
-func (m *KVGetRequest) XXX_Size() int {
+func (m *PurgeRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVGetRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVGetRequest.DiscardUnknown(m)
+func (m *PurgeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVGetRequest proto.InternalMessageInfo
+var xxx_messageInfo_PurgeRequest proto.InternalMessageInfo
 
-func (m *KVGetRequest) GetKey() []byte {
+func (m *PurgeRequest) GetStart() []byte {
 	if m != nil {
-		return m.Key
+		return m.Start
 	}
 	return nil
 }
 
-// KVGetResponse kv get response
-type KVGetResponse struct {
-	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+func (m *PurgeRequest) GetEnd() []byte {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *PurgeRequest) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+// PurgeResponse is the response of PurgeRequest
+type PurgeResponse struct {
+	// Purged false if the data storage can't delete the data, no marker is
+	// recorded
+	Purged bool `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	// Index the raft log index of the applied purge
+	Index uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// Start, End and Keys the purged range and keys clipped to the shard
+	Start                []byte   `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End                  []byte   `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	Keys                 [][]byte `protobuf:"bytes,5,rep,name=keys,proto3" json:"keys,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVGetResponse) Reset()         { *m = KVGetResponse{} }
-func (m *KVGetResponse) String() string { return proto.CompactTextString(m) }
-func (*KVGetResponse) ProtoMessage()    {}
-func (*KVGetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{91}
+func (m *PurgeResponse) Reset()         { *m = PurgeResponse{} }
+func (m *PurgeResponse) String() string { return proto.CompactTextString(m) }
+func (*PurgeResponse) ProtoMessage()    {}
+func (*PurgeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{101}
 }
-func (m *KVGetResponse) XXX_Unmarshal(b []byte) error {
+func (m *PurgeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PurgeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVGetResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PurgeResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -5916,105 +6868,75 @@ func (m *KVGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *KVGetResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVGetResponse.Merge(m, src)
+func (m *PurgeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PurgeResponse.Merge(m, src)
 }
-func (m *KVGetResponse) XXX_Size() int {
+func (m *PurgeResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVGetResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVGetResponse.DiscardUnknown(m)
+func (m *PurgeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PurgeResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVGetResponse proto.InternalMessageInfo
+var xxx_messageInfo_PurgeResponse proto.InternalMessageInfo
 
-func (m *KVGetResponse) GetValue() []byte {
+func (m *PurgeResponse) GetPurged() bool {
 	if m != nil {
-		return m.Value
+		return m.Purged
 	}
-	return nil
+	return false
 }
 
-// KVBatchGetRequest kv batch get request
-type KVBatchGetRequest struct {
-	Keys [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
-	// Indexes the original BatchGetRequest may be split and executed on multiple
-	// Shards, and this field indicates the location of these Keys in the original
-	// Keys Slice.
-	Indexes              []uint64 `protobuf:"varint,2,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *PurgeResponse) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
 }
 
-func (m *KVBatchGetRequest) Reset()         { *m = KVBatchGetRequest{} }
-func (m *KVBatchGetRequest) String() string { return proto.CompactTextString(m) }
-func (*KVBatchGetRequest) ProtoMessage()    {}
-func (*KVBatchGetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{92}
-}
-func (m *KVBatchGetRequest) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *KVBatchGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_KVBatchGetRequest.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalTo(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *PurgeResponse) GetStart() []byte {
+	if m != nil {
+		return m.Start
 	}
+	return nil
 }
-func (m *KVBatchGetRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVBatchGetRequest.Merge(m, src)
-}
-func (m *KVBatchGetRequest) XXX_Size() int {
-	return m.Size()
-}
-func (m *KVBatchGetRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVBatchGetRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_KVBatchGetRequest proto.InternalMessageInfo
 
-func (m *KVBatchGetRequest) GetKeys() [][]byte {
+func (m *PurgeResponse) GetEnd() []byte {
 	if m != nil {
-		return m.Keys
+		return m.End
 	}
 	return nil
 }
 
-func (m *KVBatchGetRequest) GetIndexes() []uint64 {
+func (m *PurgeResponse) GetKeys() [][]byte {
 	if m != nil {
-		return m.Indexes
+		return m.Keys
 	}
 	return nil
 }
 
-// KVBatchGetResponse kv batch get response
-type KVBatchGetResponse struct {
-	Values               [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
-	Indexes              []uint64 `protobuf:"varint,2,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
+// UpdateDictionaryRequest adds the next version of the compression dictionary
+// of the shard, it's skipped unless the version is the current one plus 1.
+type UpdateDictionaryRequest struct {
+	Version              uint64   `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVBatchGetResponse) Reset()         { *m = KVBatchGetResponse{} }
-func (m *KVBatchGetResponse) String() string { return proto.CompactTextString(m) }
-func (*KVBatchGetResponse) ProtoMessage()    {}
-func (*KVBatchGetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{93}
+func (m *UpdateDictionaryRequest) Reset()         { *m = UpdateDictionaryRequest{} }
+func (m *UpdateDictionaryRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateDictionaryRequest) ProtoMessage()    {}
+func (*UpdateDictionaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{102}
 }
-func (m *KVBatchGetResponse) XXX_Unmarshal(b []byte) error {
+func (m *UpdateDictionaryRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVBatchGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateDictionaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVBatchGetResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateDictionaryRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6024,52 +6946,53 @@ func (m *KVBatchGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *KVBatchGetResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVBatchGetResponse.Merge(m, src)
+func (m *UpdateDictionaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDictionaryRequest.Merge(m, src)
 }
-func (m *KVBatchGetResponse) XXX_Size() int {
+func (m *UpdateDictionaryRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVBatchGetResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVBatchGetResponse.DiscardUnknown(m)
+func (m *UpdateDictionaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDictionaryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVBatchGetResponse proto.InternalMessageInfo
+var xxx_messageInfo_UpdateDictionaryRequest proto.InternalMessageInfo
 
-func (m *KVBatchGetResponse) GetValues() [][]byte {
+func (m *UpdateDictionaryRequest) GetVersion() uint64 {
 	if m != nil {
-		return m.Values
+		return m.Version
 	}
-	return nil
+	return 0
 }
 
-func (m *KVBatchGetResponse) GetIndexes() []uint64 {
+func (m *UpdateDictionaryRequest) GetData() []byte {
 	if m != nil {
-		return m.Indexes
+		return m.Data
 	}
 	return nil
 }
 
-// KVDeleteRequest kv Delete request
-type KVDeleteRequest struct {
-	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+// UpdateDictionaryResponse is the response of UpdateDictionaryRequest
+type UpdateDictionaryResponse struct {
+	// Updated false if the version is stale
+	Updated              bool     `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVDeleteRequest) Reset()         { *m = KVDeleteRequest{} }
-func (m *KVDeleteRequest) String() string { return proto.CompactTextString(m) }
-func (*KVDeleteRequest) ProtoMessage()    {}
-func (*KVDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{94}
+func (m *UpdateDictionaryResponse) Reset()         { *m = UpdateDictionaryResponse{} }
+func (m *UpdateDictionaryResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateDictionaryResponse) ProtoMessage()    {}
+func (*UpdateDictionaryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{103}
 }
-func (m *KVDeleteRequest) XXX_Unmarshal(b []byte) error {
+func (m *UpdateDictionaryResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateDictionaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVDeleteRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateDictionaryResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6079,44 +7002,45 @@ func (m *KVDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *KVDeleteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVDeleteRequest.Merge(m, src)
+func (m *UpdateDictionaryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDictionaryResponse.Merge(m, src)
 }
-func (m *KVDeleteRequest) XXX_Size() int {
+func (m *UpdateDictionaryResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVDeleteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVDeleteRequest.DiscardUnknown(m)
+func (m *UpdateDictionaryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDictionaryResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVDeleteRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateDictionaryResponse proto.InternalMessageInfo
 
-func (m *KVDeleteRequest) GetKey() []byte {
+func (m *UpdateDictionaryResponse) GetUpdated() bool {
 	if m != nil {
-		return m.Key
+		return m.Updated
 	}
-	return nil
+	return false
 }
 
-// KVDeleteResponse kv Delete response
-type KVDeleteResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type UpdateEpochLeaseRequest struct {
+	ShardID              uint64            `protobuf:"varint,1,opt,name=shardID,proto3" json:"shardID,omitempty"`
+	Lease                metapb.EpochLease `protobuf:"bytes,2,opt,name=lease,proto3" json:"lease"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *KVDeleteResponse) Reset()         { *m = KVDeleteResponse{} }
-func (m *KVDeleteResponse) String() string { return proto.CompactTextString(m) }
-func (*KVDeleteResponse) ProtoMessage()    {}
-func (*KVDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{95}
+func (m *UpdateEpochLeaseRequest) Reset()         { *m = UpdateEpochLeaseRequest{} }
+func (m *UpdateEpochLeaseRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateEpochLeaseRequest) ProtoMessage()    {}
+func (*UpdateEpochLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{104}
 }
-func (m *KVDeleteResponse) XXX_Unmarshal(b []byte) error {
+func (m *UpdateEpochLeaseRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateEpochLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVDeleteResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateEpochLeaseRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6126,38 +7050,50 @@ func (m *KVDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *KVDeleteResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVDeleteResponse.Merge(m, src)
+func (m *UpdateEpochLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateEpochLeaseRequest.Merge(m, src)
 }
-func (m *KVDeleteResponse) XXX_Size() int {
+func (m *UpdateEpochLeaseRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVDeleteResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVDeleteResponse.DiscardUnknown(m)
+func (m *UpdateEpochLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateEpochLeaseRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVDeleteResponse proto.InternalMessageInfo
+var xxx_messageInfo_UpdateEpochLeaseRequest proto.InternalMessageInfo
 
-// KVBatchDeleteRequest kv BatchDelete request
-type KVBatchDeleteRequest struct {
-	Keys                 [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+func (m *UpdateEpochLeaseRequest) GetShardID() uint64 {
+	if m != nil {
+		return m.ShardID
+	}
+	return 0
+}
+
+func (m *UpdateEpochLeaseRequest) GetLease() metapb.EpochLease {
+	if m != nil {
+		return m.Lease
+	}
+	return metapb.EpochLease{}
+}
+
+type UpdateEpochLeaseResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVBatchDeleteRequest) Reset()         { *m = KVBatchDeleteRequest{} }
-func (m *KVBatchDeleteRequest) String() string { return proto.CompactTextString(m) }
-func (*KVBatchDeleteRequest) ProtoMessage()    {}
-func (*KVBatchDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{96}
+func (m *UpdateEpochLeaseResponse) Reset()         { *m = UpdateEpochLeaseResponse{} }
+func (m *UpdateEpochLeaseResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateEpochLeaseResponse) ProtoMessage()    {}
+func (*UpdateEpochLeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{105}
 }
-func (m *KVBatchDeleteRequest) XXX_Unmarshal(b []byte) error {
+func (m *UpdateEpochLeaseResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVBatchDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateEpochLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVBatchDeleteRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateEpochLeaseResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6167,44 +7103,86 @@ func (m *KVBatchDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *KVBatchDeleteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVBatchDeleteRequest.Merge(m, src)
+func (m *UpdateEpochLeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateEpochLeaseResponse.Merge(m, src)
 }
-func (m *KVBatchDeleteRequest) XXX_Size() int {
+func (m *UpdateEpochLeaseResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVBatchDeleteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVBatchDeleteRequest.DiscardUnknown(m)
+func (m *UpdateEpochLeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateEpochLeaseResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVBatchDeleteRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateEpochLeaseResponse proto.InternalMessageInfo
 
-func (m *KVBatchDeleteRequest) GetKeys() [][]byte {
+// UpdateTxnRecordRequest update txn record request
+type UpdateTxnRecordRequest struct {
+	TxnRecord            txnpb.TxnRecord `protobuf:"bytes,1,opt,name=txnRecord,proto3" json:"txnRecord"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *UpdateTxnRecordRequest) Reset()         { *m = UpdateTxnRecordRequest{} }
+func (m *UpdateTxnRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateTxnRecordRequest) ProtoMessage()    {}
+func (*UpdateTxnRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{106}
+}
+func (m *UpdateTxnRecordRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateTxnRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateTxnRecordRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateTxnRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTxnRecordRequest.Merge(m, src)
+}
+func (m *UpdateTxnRecordRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateTxnRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTxnRecordRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTxnRecordRequest proto.InternalMessageInfo
+
+func (m *UpdateTxnRecordRequest) GetTxnRecord() txnpb.TxnRecord {
 	if m != nil {
-		return m.Keys
+		return m.TxnRecord
 	}
-	return nil
+	return txnpb.TxnRecord{}
 }
 
-// KVBatchDeleteResponse kv BatchDelete response
-type KVBatchDeleteResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// UpdateTxnRecordResponse update txn record response
+type UpdateTxnRecordResponse struct {
+	TxnRecord            txnpb.TxnRecord `protobuf:"bytes,1,opt,name=txnRecord,proto3" json:"txnRecord"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *KVBatchDeleteResponse) Reset()         { *m = KVBatchDeleteResponse{} }
-func (m *KVBatchDeleteResponse) String() string { return proto.CompactTextString(m) }
-func (*KVBatchDeleteResponse) ProtoMessage()    {}
-func (*KVBatchDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{97}
+func (m *UpdateTxnRecordResponse) Reset()         { *m = UpdateTxnRecordResponse{} }
+func (m *UpdateTxnRecordResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateTxnRecordResponse) ProtoMessage()    {}
+func (*UpdateTxnRecordResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{107}
 }
-func (m *KVBatchDeleteResponse) XXX_Unmarshal(b []byte) error {
+func (m *UpdateTxnRecordResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVBatchDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateTxnRecordResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVBatchDeleteResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateTxnRecordResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6214,41 +7192,46 @@ func (m *KVBatchDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *KVBatchDeleteResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVBatchDeleteResponse.Merge(m, src)
+func (m *UpdateTxnRecordResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTxnRecordResponse.Merge(m, src)
 }
-func (m *KVBatchDeleteResponse) XXX_Size() int {
+func (m *UpdateTxnRecordResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVBatchDeleteResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVBatchDeleteResponse.DiscardUnknown(m)
+func (m *UpdateTxnRecordResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTxnRecordResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVBatchDeleteResponse proto.InternalMessageInfo
+var xxx_messageInfo_UpdateTxnRecordResponse proto.InternalMessageInfo
 
-// KVRangeDeleteRequest kv RangeDelete request
-type KVRangeDeleteRequest struct {
-	// Start include
-	Start []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
-	// End exclude
-	End                  []byte   `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+func (m *UpdateTxnRecordResponse) GetTxnRecord() txnpb.TxnRecord {
+	if m != nil {
+		return m.TxnRecord
+	}
+	return txnpb.TxnRecord{}
+}
+
+// DeleteTxnRecordRequest delete txn record request
+type DeleteTxnRecordRequest struct {
+	TxnRecordRouteKey    []byte   `protobuf:"bytes,1,opt,name=txnRecordRouteKey,proto3" json:"txnRecordRouteKey,omitempty"`
+	TxnID                []byte   `protobuf:"bytes,2,opt,name=txnID,proto3" json:"txnID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVRangeDeleteRequest) Reset()         { *m = KVRangeDeleteRequest{} }
-func (m *KVRangeDeleteRequest) String() string { return proto.CompactTextString(m) }
-func (*KVRangeDeleteRequest) ProtoMessage()    {}
-func (*KVRangeDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{98}
+func (m *DeleteTxnRecordRequest) Reset()         { *m = DeleteTxnRecordRequest{} }
+func (m *DeleteTxnRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteTxnRecordRequest) ProtoMessage()    {}
+func (*DeleteTxnRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{108}
 }
-func (m *KVRangeDeleteRequest) XXX_Unmarshal(b []byte) error {
+func (m *DeleteTxnRecordRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVRangeDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeleteTxnRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVRangeDeleteRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeleteTxnRecordRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6258,51 +7241,51 @@ func (m *KVRangeDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *KVRangeDeleteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVRangeDeleteRequest.Merge(m, src)
+func (m *DeleteTxnRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTxnRecordRequest.Merge(m, src)
 }
-func (m *KVRangeDeleteRequest) XXX_Size() int {
+func (m *DeleteTxnRecordRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVRangeDeleteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVRangeDeleteRequest.DiscardUnknown(m)
+func (m *DeleteTxnRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTxnRecordRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVRangeDeleteRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeleteTxnRecordRequest proto.InternalMessageInfo
 
-func (m *KVRangeDeleteRequest) GetStart() []byte {
+func (m *DeleteTxnRecordRequest) GetTxnRecordRouteKey() []byte {
 	if m != nil {
-		return m.Start
+		return m.TxnRecordRouteKey
 	}
 	return nil
 }
 
-func (m *KVRangeDeleteRequest) GetEnd() []byte {
+func (m *DeleteTxnRecordRequest) GetTxnID() []byte {
 	if m != nil {
-		return m.End
+		return m.TxnID
 	}
 	return nil
 }
 
-// KVRangeDeleteResponse kv RangeDelete response
-type KVRangeDeleteResponse struct {
+// DeleteTxnRecordResponse delete txn record response
+type DeleteTxnRecordResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVRangeDeleteResponse) Reset()         { *m = KVRangeDeleteResponse{} }
-func (m *KVRangeDeleteResponse) String() string { return proto.CompactTextString(m) }
-func (*KVRangeDeleteResponse) ProtoMessage()    {}
-func (*KVRangeDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{99}
+func (m *DeleteTxnRecordResponse) Reset()         { *m = DeleteTxnRecordResponse{} }
+func (m *DeleteTxnRecordResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteTxnRecordResponse) ProtoMessage()    {}
+func (*DeleteTxnRecordResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{109}
 }
-func (m *KVRangeDeleteResponse) XXX_Unmarshal(b []byte) error {
+func (m *DeleteTxnRecordResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVRangeDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeleteTxnRecordResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVRangeDeleteResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeleteTxnRecordResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6312,49 +7295,39 @@ func (m *KVRangeDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *KVRangeDeleteResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVRangeDeleteResponse.Merge(m, src)
+func (m *DeleteTxnRecordResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteTxnRecordResponse.Merge(m, src)
 }
-func (m *KVRangeDeleteResponse) XXX_Size() int {
+func (m *DeleteTxnRecordResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVRangeDeleteResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVRangeDeleteResponse.DiscardUnknown(m)
+func (m *DeleteTxnRecordResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteTxnRecordResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVRangeDeleteResponse proto.InternalMessageInfo
+var xxx_messageInfo_DeleteTxnRecordResponse proto.InternalMessageInfo
 
-// KVScanRequest kv scan request
-type KVScanRequest struct {
-	// Start include, not set means min key
-	Start []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
-	// End exclude, not set means max key
-	End []byte `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
-	// Limit maximum count of scanned data
-	Limit uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	// LimitBytes maximum count of scanned data
-	LimitBytes uint64 `protobuf:"varint,4,opt,name=limitBytes,proto3" json:"limitBytes,omitempty"`
-	// WithValue return the value
-	WithValue bool `protobuf:"varint,5,opt,name=withValue,proto3" json:"withValue,omitempty"`
-	// OnlyCount only returns count
-	OnlyCount            bool     `protobuf:"varint,6,opt,name=onlyCount,proto3" json:"onlyCount,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// CommitTxnWriteDataRequest commit txn write data request
+type CommitTxnWriteDataRequest struct {
+	OriginKey            []byte          `protobuf:"bytes,1,opt,name=originKey,proto3" json:"originKey,omitempty"`
+	CommitTS             hlcpb.Timestamp `protobuf:"bytes,2,opt,name=commitTS,proto3" json:"commitTS"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *KVScanRequest) Reset()         { *m = KVScanRequest{} }
-func (m *KVScanRequest) String() string { return proto.CompactTextString(m) }
-func (*KVScanRequest) ProtoMessage()    {}
-func (*KVScanRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{100}
+func (m *CommitTxnWriteDataRequest) Reset()         { *m = CommitTxnWriteDataRequest{} }
+func (m *CommitTxnWriteDataRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitTxnWriteDataRequest) ProtoMessage()    {}
+func (*CommitTxnWriteDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{110}
 }
-func (m *KVScanRequest) XXX_Unmarshal(b []byte) error {
+func (m *CommitTxnWriteDataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVScanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CommitTxnWriteDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVScanRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CommitTxnWriteDataRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6364,89 +7337,51 @@ func (m *KVScanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *KVScanRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVScanRequest.Merge(m, src)
+func (m *CommitTxnWriteDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitTxnWriteDataRequest.Merge(m, src)
 }
-func (m *KVScanRequest) XXX_Size() int {
+func (m *CommitTxnWriteDataRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVScanRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVScanRequest.DiscardUnknown(m)
+func (m *CommitTxnWriteDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitTxnWriteDataRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVScanRequest proto.InternalMessageInfo
-
-func (m *KVScanRequest) GetStart() []byte {
-	if m != nil {
-		return m.Start
-	}
-	return nil
-}
+var xxx_messageInfo_CommitTxnWriteDataRequest proto.InternalMessageInfo
 
-func (m *KVScanRequest) GetEnd() []byte {
+func (m *CommitTxnWriteDataRequest) GetOriginKey() []byte {
 	if m != nil {
-		return m.End
+		return m.OriginKey
 	}
 	return nil
 }
 
-func (m *KVScanRequest) GetLimit() uint64 {
-	if m != nil {
-		return m.Limit
-	}
-	return 0
-}
-
-func (m *KVScanRequest) GetLimitBytes() uint64 {
-	if m != nil {
-		return m.LimitBytes
-	}
-	return 0
-}
-
-func (m *KVScanRequest) GetWithValue() bool {
-	if m != nil {
-		return m.WithValue
-	}
-	return false
-}
-
-func (m *KVScanRequest) GetOnlyCount() bool {
+func (m *CommitTxnWriteDataRequest) GetCommitTS() hlcpb.Timestamp {
 	if m != nil {
-		return m.OnlyCount
+		return m.CommitTS
 	}
-	return false
+	return hlcpb.Timestamp{}
 }
 
-// KVScanResponse kv scan response
-type KVScanResponse struct {
-	// Keys scan keys result
-	Keys [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
-	// Values scan values result
-	Values [][]byte `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
-	// Count scan count result
-	Count uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
-	// Completed true if no data in current shard
-	Completed bool `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
-	// ShardEnd shard end key
-	ShardEnd             []byte   `protobuf:"bytes,5,opt,name=shardEnd,proto3" json:"shardEnd,omitempty"`
+// CommitTxnWriteDataResponse commit txn write data response
+type CommitTxnWriteDataResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVScanResponse) Reset()         { *m = KVScanResponse{} }
-func (m *KVScanResponse) String() string { return proto.CompactTextString(m) }
-func (*KVScanResponse) ProtoMessage()    {}
-func (*KVScanResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{101}
+func (m *CommitTxnWriteDataResponse) Reset()         { *m = CommitTxnWriteDataResponse{} }
+func (m *CommitTxnWriteDataResponse) String() string { return proto.CompactTextString(m) }
+func (*CommitTxnWriteDataResponse) ProtoMessage()    {}
+func (*CommitTxnWriteDataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{111}
 }
-func (m *KVScanResponse) XXX_Unmarshal(b []byte) error {
+func (m *CommitTxnWriteDataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVScanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CommitTxnWriteDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVScanResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CommitTxnWriteDataResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6456,73 +7391,39 @@ func (m *KVScanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *KVScanResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVScanResponse.Merge(m, src)
+func (m *CommitTxnWriteDataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitTxnWriteDataResponse.Merge(m, src)
 }
-func (m *KVScanResponse) XXX_Size() int {
+func (m *CommitTxnWriteDataResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVScanResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVScanResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_KVScanResponse proto.InternalMessageInfo
-
-func (m *KVScanResponse) GetKeys() [][]byte {
-	if m != nil {
-		return m.Keys
-	}
-	return nil
-}
-
-func (m *KVScanResponse) GetValues() [][]byte {
-	if m != nil {
-		return m.Values
-	}
-	return nil
-}
-
-func (m *KVScanResponse) GetCount() uint64 {
-	if m != nil {
-		return m.Count
-	}
-	return 0
-}
-
-func (m *KVScanResponse) GetCompleted() bool {
-	if m != nil {
-		return m.Completed
-	}
-	return false
+func (m *CommitTxnWriteDataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitTxnWriteDataResponse.DiscardUnknown(m)
 }
 
-func (m *KVScanResponse) GetShardEnd() []byte {
-	if m != nil {
-		return m.ShardEnd
-	}
-	return nil
-}
+var xxx_messageInfo_CommitTxnWriteDataResponse proto.InternalMessageInfo
 
-// KVBatchMixedWriteRequest kv batch MixedWrite requests
-type KVBatchMixedWriteRequest struct {
-	Requests             []KVMixedWriteRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+// RollbackTxnWriteDataRequest rollback txn write data request
+type RollbackTxnWriteDataRequest struct {
+	OriginKey            []byte          `protobuf:"bytes,1,opt,name=originKey,proto3" json:"originKey,omitempty"`
+	Timestamp            hlcpb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *KVBatchMixedWriteRequest) Reset()         { *m = KVBatchMixedWriteRequest{} }
-func (m *KVBatchMixedWriteRequest) String() string { return proto.CompactTextString(m) }
-func (*KVBatchMixedWriteRequest) ProtoMessage()    {}
-func (*KVBatchMixedWriteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{102}
+func (m *RollbackTxnWriteDataRequest) Reset()         { *m = RollbackTxnWriteDataRequest{} }
+func (m *RollbackTxnWriteDataRequest) String() string { return proto.CompactTextString(m) }
+func (*RollbackTxnWriteDataRequest) ProtoMessage()    {}
+func (*RollbackTxnWriteDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{112}
 }
-func (m *KVBatchMixedWriteRequest) XXX_Unmarshal(b []byte) error {
+func (m *RollbackTxnWriteDataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVBatchMixedWriteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RollbackTxnWriteDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVBatchMixedWriteRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RollbackTxnWriteDataRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6532,45 +7433,51 @@ func (m *KVBatchMixedWriteRequest) XXX_Marshal(b []byte, deterministic bool) ([]
 		return b[:n], nil
 	}
 }
-func (m *KVBatchMixedWriteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVBatchMixedWriteRequest.Merge(m, src)
+func (m *RollbackTxnWriteDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackTxnWriteDataRequest.Merge(m, src)
 }
-func (m *KVBatchMixedWriteRequest) XXX_Size() int {
+func (m *RollbackTxnWriteDataRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVBatchMixedWriteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVBatchMixedWriteRequest.DiscardUnknown(m)
+func (m *RollbackTxnWriteDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackTxnWriteDataRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVBatchMixedWriteRequest proto.InternalMessageInfo
+var xxx_messageInfo_RollbackTxnWriteDataRequest proto.InternalMessageInfo
 
-func (m *KVBatchMixedWriteRequest) GetRequests() []KVMixedWriteRequest {
+func (m *RollbackTxnWriteDataRequest) GetOriginKey() []byte {
 	if m != nil {
-		return m.Requests
+		return m.OriginKey
 	}
 	return nil
 }
 
-// KVBatchMixedWriteResponse kv batch MixedWrite responses
-type KVBatchMixedWriteResponse struct {
-	Responses            []KVMixedWriteResponse `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+func (m *RollbackTxnWriteDataRequest) GetTimestamp() hlcpb.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return hlcpb.Timestamp{}
 }
 
-func (m *KVBatchMixedWriteResponse) Reset()         { *m = KVBatchMixedWriteResponse{} }
-func (m *KVBatchMixedWriteResponse) String() string { return proto.CompactTextString(m) }
-func (*KVBatchMixedWriteResponse) ProtoMessage()    {}
-func (*KVBatchMixedWriteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{103}
+// RollbackTxnWriteDataResponse rollback txn write data response
+type RollbackTxnWriteDataResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *KVBatchMixedWriteResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *RollbackTxnWriteDataResponse) Reset()         { *m = RollbackTxnWriteDataResponse{} }
+func (m *RollbackTxnWriteDataResponse) String() string { return proto.CompactTextString(m) }
+func (*RollbackTxnWriteDataResponse) ProtoMessage()    {}
+func (*RollbackTxnWriteDataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{113}
+}
+func (m *RollbackTxnWriteDataResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVBatchMixedWriteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RollbackTxnWriteDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVBatchMixedWriteResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RollbackTxnWriteDataResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6580,48 +7487,38 @@ func (m *KVBatchMixedWriteResponse) XXX_Marshal(b []byte, deterministic bool) ([
 		return b[:n], nil
 	}
 }
-func (m *KVBatchMixedWriteResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVBatchMixedWriteResponse.Merge(m, src)
+func (m *RollbackTxnWriteDataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackTxnWriteDataResponse.Merge(m, src)
 }
-func (m *KVBatchMixedWriteResponse) XXX_Size() int {
+func (m *RollbackTxnWriteDataResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVBatchMixedWriteResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVBatchMixedWriteResponse.DiscardUnknown(m)
+func (m *RollbackTxnWriteDataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackTxnWriteDataResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVBatchMixedWriteResponse proto.InternalMessageInfo
-
-func (m *KVBatchMixedWriteResponse) GetResponses() []KVMixedWriteResponse {
-	if m != nil {
-		return m.Responses
-	}
-	return nil
-}
+var xxx_messageInfo_RollbackTxnWriteDataResponse proto.InternalMessageInfo
 
-// KVMixedWriteRequest mixed requests
-type KVMixedWriteRequest struct {
-	CmdType              uint64               `protobuf:"varint,1,opt,name=cmdType,proto3" json:"cmdType,omitempty"`
-	Set                  KVSetRequest         `protobuf:"bytes,2,opt,name=set,proto3" json:"set"`
-	Delete               KVDeleteRequest      `protobuf:"bytes,3,opt,name=delete,proto3" json:"delete"`
-	RangeDelete          KVRangeDeleteRequest `protobuf:"bytes,4,opt,name=rangeDelete,proto3" json:"rangeDelete"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+// CleanTxnMVCCDataRequest clean txn mvcc data request
+type CleanTxnMVCCDataRequest struct {
+	Timestamp            hlcpb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *KVMixedWriteRequest) Reset()         { *m = KVMixedWriteRequest{} }
-func (m *KVMixedWriteRequest) String() string { return proto.CompactTextString(m) }
-func (*KVMixedWriteRequest) ProtoMessage()    {}
-func (*KVMixedWriteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{104}
+func (m *CleanTxnMVCCDataRequest) Reset()         { *m = CleanTxnMVCCDataRequest{} }
+func (m *CleanTxnMVCCDataRequest) String() string { return proto.CompactTextString(m) }
+func (*CleanTxnMVCCDataRequest) ProtoMessage()    {}
+func (*CleanTxnMVCCDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{114}
 }
-func (m *KVMixedWriteRequest) XXX_Unmarshal(b []byte) error {
+func (m *CleanTxnMVCCDataRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVMixedWriteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *CleanTxnMVCCDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVMixedWriteRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_CleanTxnMVCCDataRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6631,69 +7528,140 @@ func (m *KVMixedWriteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *KVMixedWriteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVMixedWriteRequest.Merge(m, src)
+func (m *CleanTxnMVCCDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CleanTxnMVCCDataRequest.Merge(m, src)
 }
-func (m *KVMixedWriteRequest) XXX_Size() int {
+func (m *CleanTxnMVCCDataRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVMixedWriteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVMixedWriteRequest.DiscardUnknown(m)
+func (m *CleanTxnMVCCDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CleanTxnMVCCDataRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVMixedWriteRequest proto.InternalMessageInfo
+var xxx_messageInfo_CleanTxnMVCCDataRequest proto.InternalMessageInfo
 
-func (m *KVMixedWriteRequest) GetCmdType() uint64 {
+func (m *CleanTxnMVCCDataRequest) GetTimestamp() hlcpb.Timestamp {
 	if m != nil {
-		return m.CmdType
+		return m.Timestamp
 	}
-	return 0
+	return hlcpb.Timestamp{}
 }
 
-func (m *KVMixedWriteRequest) GetSet() KVSetRequest {
-	if m != nil {
-		return m.Set
+// CleanTxnMVCCDataResponse clean txn mvcc data response
+type CleanTxnMVCCDataResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CleanTxnMVCCDataResponse) Reset()         { *m = CleanTxnMVCCDataResponse{} }
+func (m *CleanTxnMVCCDataResponse) String() string { return proto.CompactTextString(m) }
+func (*CleanTxnMVCCDataResponse) ProtoMessage()    {}
+func (*CleanTxnMVCCDataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{115}
+}
+func (m *CleanTxnMVCCDataResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CleanTxnMVCCDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CleanTxnMVCCDataResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return KVSetRequest{}
+}
+func (m *CleanTxnMVCCDataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CleanTxnMVCCDataResponse.Merge(m, src)
+}
+func (m *CleanTxnMVCCDataResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CleanTxnMVCCDataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CleanTxnMVCCDataResponse.DiscardUnknown(m)
 }
 
-func (m *KVMixedWriteRequest) GetDelete() KVDeleteRequest {
+var xxx_messageInfo_CleanTxnMVCCDataResponse proto.InternalMessageInfo
+
+// KVSetRequest kv set request
+type KVSetRequest struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVSetRequest) Reset()         { *m = KVSetRequest{} }
+func (m *KVSetRequest) String() string { return proto.CompactTextString(m) }
+func (*KVSetRequest) ProtoMessage()    {}
+func (*KVSetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{116}
+}
+func (m *KVSetRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVSetRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KVSetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVSetRequest.Merge(m, src)
+}
+func (m *KVSetRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVSetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVSetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVSetRequest proto.InternalMessageInfo
+
+func (m *KVSetRequest) GetKey() []byte {
 	if m != nil {
-		return m.Delete
+		return m.Key
 	}
-	return KVDeleteRequest{}
+	return nil
 }
 
-func (m *KVMixedWriteRequest) GetRangeDelete() KVRangeDeleteRequest {
+func (m *KVSetRequest) GetValue() []byte {
 	if m != nil {
-		return m.RangeDelete
+		return m.Value
 	}
-	return KVRangeDeleteRequest{}
+	return nil
 }
 
-// KVMixedWriteResponse mixed response
-type KVMixedWriteResponse struct {
-	CmdType              uint64               `protobuf:"varint,1,opt,name=cmdType,proto3" json:"cmdType,omitempty"`
-	Set                  KVSetRequest         `protobuf:"bytes,2,opt,name=set,proto3" json:"set"`
-	Delete               KVDeleteRequest      `protobuf:"bytes,3,opt,name=delete,proto3" json:"delete"`
-	RangeDelete          KVRangeDeleteRequest `protobuf:"bytes,4,opt,name=rangeDelete,proto3" json:"rangeDelete"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+// KVSetResponse kv set response
+type KVSetResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVMixedWriteResponse) Reset()         { *m = KVMixedWriteResponse{} }
-func (m *KVMixedWriteResponse) String() string { return proto.CompactTextString(m) }
-func (*KVMixedWriteResponse) ProtoMessage()    {}
-func (*KVMixedWriteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_25e491924c678914, []int{105}
+func (m *KVSetResponse) Reset()         { *m = KVSetResponse{} }
+func (m *KVSetResponse) String() string { return proto.CompactTextString(m) }
+func (*KVSetResponse) ProtoMessage()    {}
+func (*KVSetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{117}
 }
-func (m *KVMixedWriteResponse) XXX_Unmarshal(b []byte) error {
+func (m *KVSetResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *KVMixedWriteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *KVSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_KVMixedWriteResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_KVSetResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -6703,1515 +7671,1713 @@ func (m *KVMixedWriteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *KVMixedWriteResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KVMixedWriteResponse.Merge(m, src)
+func (m *KVSetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVSetResponse.Merge(m, src)
 }
-func (m *KVMixedWriteResponse) XXX_Size() int {
+func (m *KVSetResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *KVMixedWriteResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_KVMixedWriteResponse.DiscardUnknown(m)
+func (m *KVSetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVSetResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KVMixedWriteResponse proto.InternalMessageInfo
+var xxx_messageInfo_KVSetResponse proto.InternalMessageInfo
 
-func (m *KVMixedWriteResponse) GetCmdType() uint64 {
-	if m != nil {
-		return m.CmdType
-	}
-	return 0
+// KVBatchSetRequest kv batch set request
+type KVBatchSetRequest struct {
+	Keys                 [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Values               [][]byte `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KVMixedWriteResponse) GetSet() KVSetRequest {
-	if m != nil {
-		return m.Set
+func (m *KVBatchSetRequest) Reset()         { *m = KVBatchSetRequest{} }
+func (m *KVBatchSetRequest) String() string { return proto.CompactTextString(m) }
+func (*KVBatchSetRequest) ProtoMessage()    {}
+func (*KVBatchSetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{118}
+}
+func (m *KVBatchSetRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVBatchSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVBatchSetRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return KVSetRequest{}
+}
+func (m *KVBatchSetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVBatchSetRequest.Merge(m, src)
+}
+func (m *KVBatchSetRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVBatchSetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVBatchSetRequest.DiscardUnknown(m)
 }
 
-func (m *KVMixedWriteResponse) GetDelete() KVDeleteRequest {
+var xxx_messageInfo_KVBatchSetRequest proto.InternalMessageInfo
+
+func (m *KVBatchSetRequest) GetKeys() [][]byte {
 	if m != nil {
-		return m.Delete
+		return m.Keys
 	}
-	return KVDeleteRequest{}
+	return nil
 }
 
-func (m *KVMixedWriteResponse) GetRangeDelete() KVRangeDeleteRequest {
+func (m *KVBatchSetRequest) GetValues() [][]byte {
 	if m != nil {
-		return m.RangeDelete
+		return m.Values
 	}
-	return KVRangeDeleteRequest{}
+	return nil
 }
 
-func init() {
-	proto.RegisterEnum("rpcpb.Type", Type_name, Type_value)
-	proto.RegisterEnum("rpcpb.ReplicaRoleType", ReplicaRoleType_name, ReplicaRoleType_value)
-	proto.RegisterEnum("rpcpb.LabelConstraintOp", LabelConstraintOp_name, LabelConstraintOp_value)
-	proto.RegisterEnum("rpcpb.CmdType", CmdType_name, CmdType_value)
-	proto.RegisterEnum("rpcpb.InternalCmd", InternalCmd_name, InternalCmd_value)
-	proto.RegisterEnum("rpcpb.UpdatePolicy", UpdatePolicy_name, UpdatePolicy_value)
-	proto.RegisterEnum("rpcpb.ReplicaSelectPolicy", ReplicaSelectPolicy_name, ReplicaSelectPolicy_value)
-	proto.RegisterType((*ProphetRequest)(nil), "rpcpb.ProphetRequest")
-	proto.RegisterType((*ProphetResponse)(nil), "rpcpb.ProphetResponse")
-	proto.RegisterType((*ShardHeartbeatReq)(nil), "rpcpb.ShardHeartbeatReq")
-	proto.RegisterType((*ShardHeartbeatRsp)(nil), "rpcpb.ShardHeartbeatRsp")
-	proto.RegisterType((*PutStoreReq)(nil), "rpcpb.PutStoreReq")
-	proto.RegisterType((*PutStoreRsp)(nil), "rpcpb.PutStoreRsp")
-	proto.RegisterType((*StoreHeartbeatReq)(nil), "rpcpb.StoreHeartbeatReq")
-	proto.RegisterType((*StoreHeartbeatRsp)(nil), "rpcpb.StoreHeartbeatRsp")
-	proto.RegisterType((*GetStoreReq)(nil), "rpcpb.GetStoreReq")
-	proto.RegisterType((*GetStoreRsp)(nil), "rpcpb.GetStoreRsp")
-	proto.RegisterType((*AllocIDReq)(nil), "rpcpb.AllocIDReq")
-	proto.RegisterType((*AllocIDRsp)(nil), "rpcpb.AllocIDRsp")
-	proto.RegisterType((*AskBatchSplitReq)(nil), "rpcpb.AskBatchSplitReq")
-	proto.RegisterType((*AskBatchSplitRsp)(nil), "rpcpb.AskBatchSplitRsp")
-	proto.RegisterType((*CreateDestroyingReq)(nil), "rpcpb.CreateDestroyingReq")
-	proto.RegisterType((*CreateDestroyingRsp)(nil), "rpcpb.CreateDestroyingRsp")
-	proto.RegisterType((*GetDestroyingReq)(nil), "rpcpb.GetDestroyingReq")
-	proto.RegisterType((*GetDestroyingRsp)(nil), "rpcpb.GetDestroyingRsp")
-	proto.RegisterType((*ReportDestroyedReq)(nil), "rpcpb.ReportDestroyedReq")
-	proto.RegisterType((*ReportDestroyedRsp)(nil), "rpcpb.ReportDestroyedRsp")
-	proto.RegisterType((*SplitID)(nil), "rpcpb.SplitID")
-	proto.RegisterType((*CreateWatcherReq)(nil), "rpcpb.CreateWatcherReq")
-	proto.RegisterType((*CreateShardsReq)(nil), "rpcpb.CreateShardsReq")
-	proto.RegisterType((*CreateShardsRsp)(nil), "rpcpb.CreateShardsRsp")
-	proto.RegisterType((*RemoveShardsReq)(nil), "rpcpb.RemoveShardsReq")
-	proto.RegisterType((*RemoveShardsRsp)(nil), "rpcpb.RemoveShardsRsp")
-	proto.RegisterType((*CheckShardStateReq)(nil), "rpcpb.CheckShardStateReq")
-	proto.RegisterType((*CheckShardStateRsp)(nil), "rpcpb.CheckShardStateRsp")
-	proto.RegisterType((*PutPlacementRuleReq)(nil), "rpcpb.PutPlacementRuleReq")
-	proto.RegisterType((*PutPlacementRuleRsp)(nil), "rpcpb.PutPlacementRuleRsp")
-	proto.RegisterType((*GetAppliedRulesReq)(nil), "rpcpb.GetAppliedRulesReq")
-	proto.RegisterType((*GetAppliedRulesRsp)(nil), "rpcpb.GetAppliedRulesRsp")
-	proto.RegisterType((*CreateJobReq)(nil), "rpcpb.CreateJobReq")
-	proto.RegisterType((*CreateJobRsp)(nil), "rpcpb.CreateJobRsp")
-	proto.RegisterType((*RemoveJobReq)(nil), "rpcpb.RemoveJobReq")
-	proto.RegisterType((*RemoveJobRsp)(nil), "rpcpb.RemoveJobRsp")
-	proto.RegisterType((*ExecuteJobReq)(nil), "rpcpb.ExecuteJobReq")
-	proto.RegisterType((*ExecuteJobRsp)(nil), "rpcpb.ExecuteJobRsp")
-	proto.RegisterType((*AddScheduleGroupRuleReq)(nil), "rpcpb.AddScheduleGroupRuleReq")
-	proto.RegisterType((*AddScheduleGroupRuleRsp)(nil), "rpcpb.AddScheduleGroupRuleRsp")
-	proto.RegisterType((*GetScheduleGroupRuleReq)(nil), "rpcpb.GetScheduleGroupRuleReq")
-	proto.RegisterType((*GetScheduleGroupRuleRsp)(nil), "rpcpb.GetScheduleGroupRuleRsp")
-	proto.RegisterType((*EventNotify)(nil), "rpcpb.EventNotify")
-	proto.RegisterType((*InitEventData)(nil), "rpcpb.InitEventData")
-	proto.RegisterType((*ShardEventData)(nil), "rpcpb.ShardEventData")
-	proto.RegisterType((*StoreEventData)(nil), "rpcpb.StoreEventData")
-	proto.RegisterType((*ConfigChange)(nil), "rpcpb.ConfigChange")
-	proto.RegisterType((*TransferLeader)(nil), "rpcpb.TransferLeader")
-	proto.RegisterType((*TransferLease)(nil), "rpcpb.TransferLease")
-	proto.RegisterType((*ConfigChangeV2)(nil), "rpcpb.ConfigChangeV2")
-	proto.RegisterType((*Merge)(nil), "rpcpb.Merge")
-	proto.RegisterType((*SplitShard)(nil), "rpcpb.SplitShard")
-	proto.RegisterType((*LabelConstraint)(nil), "rpcpb.LabelConstraint")
-	proto.RegisterType((*PlacementRule)(nil), "rpcpb.PlacementRule")
-	proto.RegisterType((*RequestBatchHeader)(nil), "rpcpb.RequestBatchHeader")
-	proto.RegisterType((*ResponseBatchHeader)(nil), "rpcpb.ResponseBatchHeader")
-	proto.RegisterType((*RequestBatch)(nil), "rpcpb.RequestBatch")
-	proto.RegisterType((*ResponseBatch)(nil), "rpcpb.ResponseBatch")
-	proto.RegisterType((*Request)(nil), "rpcpb.Request")
-	proto.RegisterType((*Range)(nil), "rpcpb.Range")
-	proto.RegisterType((*Response)(nil), "rpcpb.Response")
-	proto.RegisterType((*ConfigChangeRequest)(nil), "rpcpb.ConfigChangeRequest")
-	proto.RegisterType((*ConfigChangeResponse)(nil), "rpcpb.ConfigChangeResponse")
-	proto.RegisterType((*CompactLogRequest)(nil), "rpcpb.CompactLogRequest")
-	proto.RegisterType((*CompactLogResponse)(nil), "rpcpb.CompactLogResponse")
-	proto.RegisterType((*TransferLeaderRequest)(nil), "rpcpb.TransferLeaderRequest")
-	proto.RegisterType((*TransferLeaderResponse)(nil), "rpcpb.TransferLeaderResponse")
-	proto.RegisterType((*BatchSplitRequest)(nil), "rpcpb.BatchSplitRequest")
-	proto.RegisterType((*SplitRequest)(nil), "rpcpb.SplitRequest")
-	proto.RegisterType((*BatchSplitResponse)(nil), "rpcpb.BatchSplitResponse")
-	proto.RegisterType((*UpdateMetadataRequest)(nil), "rpcpb.UpdateMetadataRequest")
-	proto.RegisterType((*UpdateMetadataResponse)(nil), "rpcpb.UpdateMetadataResponse")
-	proto.RegisterType((*UpdateLabelsRequest)(nil), "rpcpb.UpdateLabelsRequest")
-	proto.RegisterType((*UpdateLabelsResponse)(nil), "rpcpb.UpdateLabelsResponse")
-	proto.RegisterType((*UpdateEpochLeaseRequest)(nil), "rpcpb.UpdateEpochLeaseRequest")
-	proto.RegisterType((*UpdateEpochLeaseResponse)(nil), "rpcpb.UpdateEpochLeaseResponse")
-	proto.RegisterType((*UpdateTxnRecordRequest)(nil), "rpcpb.UpdateTxnRecordRequest")
-	proto.RegisterType((*UpdateTxnRecordResponse)(nil), "rpcpb.UpdateTxnRecordResponse")
-	proto.RegisterType((*DeleteTxnRecordRequest)(nil), "rpcpb.DeleteTxnRecordRequest")
-	proto.RegisterType((*DeleteTxnRecordResponse)(nil), "rpcpb.DeleteTxnRecordResponse")
-	proto.RegisterType((*CommitTxnWriteDataRequest)(nil), "rpcpb.CommitTxnWriteDataRequest")
-	proto.RegisterType((*CommitTxnWriteDataResponse)(nil), "rpcpb.CommitTxnWriteDataResponse")
-	proto.RegisterType((*RollbackTxnWriteDataRequest)(nil), "rpcpb.RollbackTxnWriteDataRequest")
-	proto.RegisterType((*RollbackTxnWriteDataResponse)(nil), "rpcpb.RollbackTxnWriteDataResponse")
-	proto.RegisterType((*CleanTxnMVCCDataRequest)(nil), "rpcpb.CleanTxnMVCCDataRequest")
-	proto.RegisterType((*CleanTxnMVCCDataResponse)(nil), "rpcpb.CleanTxnMVCCDataResponse")
-	proto.RegisterType((*KVSetRequest)(nil), "rpcpb.KVSetRequest")
-	proto.RegisterType((*KVSetResponse)(nil), "rpcpb.KVSetResponse")
-	proto.RegisterType((*KVBatchSetRequest)(nil), "rpcpb.KVBatchSetRequest")
-	proto.RegisterType((*KVBatchSetResponse)(nil), "rpcpb.KVBatchSetResponse")
-	proto.RegisterType((*KVGetRequest)(nil), "rpcpb.KVGetRequest")
-	proto.RegisterType((*KVGetResponse)(nil), "rpcpb.KVGetResponse")
-	proto.RegisterType((*KVBatchGetRequest)(nil), "rpcpb.KVBatchGetRequest")
-	proto.RegisterType((*KVBatchGetResponse)(nil), "rpcpb.KVBatchGetResponse")
-	proto.RegisterType((*KVDeleteRequest)(nil), "rpcpb.KVDeleteRequest")
-	proto.RegisterType((*KVDeleteResponse)(nil), "rpcpb.KVDeleteResponse")
-	proto.RegisterType((*KVBatchDeleteRequest)(nil), "rpcpb.KVBatchDeleteRequest")
-	proto.RegisterType((*KVBatchDeleteResponse)(nil), "rpcpb.KVBatchDeleteResponse")
-	proto.RegisterType((*KVRangeDeleteRequest)(nil), "rpcpb.KVRangeDeleteRequest")
-	proto.RegisterType((*KVRangeDeleteResponse)(nil), "rpcpb.KVRangeDeleteResponse")
-	proto.RegisterType((*KVScanRequest)(nil), "rpcpb.KVScanRequest")
-	proto.RegisterType((*KVScanResponse)(nil), "rpcpb.KVScanResponse")
-	proto.RegisterType((*KVBatchMixedWriteRequest)(nil), "rpcpb.KVBatchMixedWriteRequest")
-	proto.RegisterType((*KVBatchMixedWriteResponse)(nil), "rpcpb.KVBatchMixedWriteResponse")
-	proto.RegisterType((*KVMixedWriteRequest)(nil), "rpcpb.KVMixedWriteRequest")
-	proto.RegisterType((*KVMixedWriteResponse)(nil), "rpcpb.KVMixedWriteResponse")
+// KVBatchSetResponse kv batch set response
+type KVBatchSetResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("rpcpb.proto", fileDescriptor_25e491924c678914) }
+func (m *KVBatchSetResponse) Reset()         { *m = KVBatchSetResponse{} }
+func (m *KVBatchSetResponse) String() string { return proto.CompactTextString(m) }
+func (*KVBatchSetResponse) ProtoMessage()    {}
+func (*KVBatchSetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{119}
+}
+func (m *KVBatchSetResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVBatchSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVBatchSetResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KVBatchSetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVBatchSetResponse.Merge(m, src)
+}
+func (m *KVBatchSetResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVBatchSetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVBatchSetResponse.DiscardUnknown(m)
+}
 
-var fileDescriptor_25e491924c678914 = []byte{
-	// 4351 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3b, 0xcb, 0x72, 0x1c, 0xc9,
-	0x56, 0xae, 0x7e, 0xa9, 0xfb, 0xf4, 0x2b, 0x95, 0x6a, 0x49, 0x6d, 0xd9, 0xd7, 0x36, 0xe5, 0x79,
-	0x08, 0xf9, 0x22, 0x73, 0xed, 0x3b, 0xf8, 0xce, 0x65, 0x18, 0x5f, 0xb9, 0xe5, 0x91, 0xe5, 0xd7,
-	0x28, 0x4a, 0x46, 0x73, 0x89, 0xb8, 0x9b, 0x52, 0x57, 0xba, 0xd5, 0xb8, 0xbb, 0xaa, 0xa6, 0xaa,
-	0x64, 0x4b, 0x1b, 0x20, 0x82, 0x1d, 0x41, 0x04, 0x11, 0xec, 0x59, 0xb0, 0x21, 0x02, 0x3e, 0x80,
-	0x2f, 0x60, 0x31, 0xbc, 0x87, 0x15, 0xac, 0x26, 0xc0, 0x2b, 0xfe, 0x80, 0x2d, 0x91, 0xaf, 0xca,
-	0xcc, 0x7a, 0x48, 0x6d, 0x76, 0x6c, 0xac, 0xce, 0xf3, 0xca, 0x93, 0x27, 0x4f, 0x9e, 0x3c, 0xe7,
-	0x64, 0x19, 0xda, 0x51, 0x38, 0x0e, 0x8f, 0xb7, 0xc3, 0x28, 0x48, 0x02, 0x5c, 0x67, 0x83, 0x8d,
-	0xdf, 0x9e, 0x4c, 0x93, 0x93, 0xd3, 0xe3, 0xed, 0x71, 0x30, 0xbf, 0x3b, 0x77, 0x93, 0x68, 0x7a,
-	0x16, 0x44, 0xd3, 0xc9, 0xd4, 0x17, 0x83, 0xf1, 0xe9, 0x31, 0xb9, 0x1b, 0x1e, 0xdf, 0x25, 0x51,
-	0x14, 0x44, 0xea, 0x2f, 0x97, 0xb1, 0xf1, 0xf9, 0x62, 0xcc, 0x73, 0x92, 0xb8, 0xe9, 0x1f, 0xc1,
-	0xfa, 0x60, 0x31, 0xd6, 0xe4, 0xcc, 0x97, 0xff, 0x0a, 0xc6, 0x05, 0x15, 0x3e, 0x99, 0x8d, 0x29,
-	0xe3, 0x74, 0x4e, 0xe2, 0xc4, 0x9d, 0x87, 0x82, 0xf9, 0x37, 0x34, 0xe6, 0x49, 0x30, 0x09, 0xee,
-	0x32, 0xf0, 0xf1, 0xe9, 0x6b, 0x36, 0x62, 0x03, 0xf6, 0x8b, 0x93, 0xdb, 0x7f, 0xd7, 0x86, 0xde,
-	0x41, 0x14, 0x84, 0x27, 0x24, 0x71, 0xc8, 0xb7, 0xa7, 0x24, 0x4e, 0xf0, 0x1a, 0x54, 0xa6, 0xde,
-	0xd0, 0xba, 0x65, 0x6d, 0xd6, 0x1e, 0x35, 0xde, 0xff, 0x70, 0xb3, 0xb2, 0xbf, 0xeb, 0x54, 0xa6,
-	0x1e, 0x1e, 0xc2, 0x52, 0x9c, 0x04, 0x11, 0xd9, 0xdf, 0x1d, 0x56, 0x28, 0xd2, 0x91, 0x43, 0x7c,
-	0x13, 0x6a, 0xc9, 0x79, 0x48, 0x86, 0xd5, 0x5b, 0xd6, 0x66, 0xef, 0x5e, 0x7b, 0x9b, 0x6f, 0xc2,
-	0xab, 0xf3, 0x90, 0x38, 0x0c, 0x81, 0xbf, 0x82, 0x5e, 0x7c, 0xe2, 0x46, 0xde, 0x13, 0xe2, 0x46,
-	0xc9, 0x31, 0x71, 0x93, 0x61, 0xed, 0x96, 0xb5, 0xd9, 0xbe, 0x37, 0x14, 0xa4, 0x87, 0x06, 0xd2,
-	0x21, 0xdf, 0x3e, 0xaa, 0x7d, 0xf7, 0xc3, 0xcd, 0x2b, 0x4e, 0x86, 0x8b, 0xc9, 0xa1, 0x73, 0x2a,
-	0x39, 0x75, 0x53, 0x8e, 0x81, 0xd4, 0xe5, 0x18, 0x08, 0xfc, 0x53, 0x68, 0x86, 0xa7, 0x09, 0xa3,
-	0x1e, 0x36, 0x98, 0x04, 0x2c, 0x24, 0x1c, 0x08, 0xb0, 0xe2, 0x4d, 0x29, 0x29, 0xd7, 0x84, 0x08,
-	0xae, 0x25, 0x83, 0x6b, 0x8f, 0xe4, 0xb8, 0x24, 0x25, 0xfe, 0x09, 0x2c, 0xb9, 0xb3, 0x59, 0x30,
-	0xde, 0xdf, 0x1d, 0x36, 0x19, 0xd3, 0xb2, 0x60, 0xda, 0xe1, 0x50, 0xc5, 0x23, 0xe9, 0xf0, 0x08,
-	0xba, 0x6e, 0xfc, 0xe6, 0x91, 0x9b, 0x8c, 0x4f, 0x0e, 0xc3, 0xd9, 0x34, 0x19, 0xb6, 0x18, 0xe3,
-	0xba, 0x64, 0xd4, 0x71, 0x8a, 0xdd, 0xe4, 0xc1, 0xcf, 0x01, 0x8d, 0x23, 0xe2, 0x26, 0x64, 0x97,
-	0xc4, 0x49, 0x14, 0x9c, 0x4f, 0xfd, 0xc9, 0x10, 0x98, 0x9c, 0x0d, 0x21, 0x67, 0x94, 0x41, 0x2b,
-	0x51, 0x39, 0x4e, 0xbc, 0x0f, 0x7d, 0x87, 0x84, 0x41, 0x94, 0x08, 0x18, 0xf1, 0x86, 0x6d, 0x26,
-	0xec, 0xaa, 0x10, 0x96, 0xc1, 0x2a, 0x59, 0x59, 0x3e, 0xba, 0xba, 0x09, 0x49, 0x34, 0xad, 0x3a,
-	0xc6, 0xea, 0xf6, 0x74, 0x9c, 0xb6, 0x3a, 0x83, 0x87, 0x0a, 0xe1, 0x3a, 0x7e, 0x43, 0x57, 0x4c,
-	0xa2, 0x61, 0xd7, 0x10, 0x32, 0xd2, 0x71, 0x9a, 0x10, 0x83, 0x07, 0xff, 0x02, 0x3a, 0x1c, 0xc0,
-	0xfc, 0x2f, 0x1e, 0xf6, 0x98, 0x8c, 0x35, 0x43, 0x06, 0x47, 0x29, 0x11, 0x06, 0x07, 0x95, 0x10,
-	0x91, 0x79, 0xf0, 0x56, 0x4a, 0xe8, 0x1b, 0x12, 0x1c, 0x0d, 0xa5, 0x49, 0xd0, 0x39, 0xa8, 0x61,
-	0xc7, 0x27, 0x64, 0xfc, 0x86, 0x0d, 0x0f, 0x13, 0x37, 0x21, 0x43, 0x64, 0x18, 0x76, 0x64, 0x62,
-	0x35, 0xc3, 0x66, 0xf8, 0xe8, 0x8e, 0x87, 0xa7, 0xc9, 0xc1, 0xcc, 0x1d, 0x93, 0x39, 0xf1, 0x13,
-	0xe7, 0x74, 0x46, 0x86, 0xcb, 0xc6, 0x8e, 0x1f, 0x64, 0xd0, 0xda, 0x8e, 0x67, 0x39, 0xa9, 0x62,
-	0x13, 0x92, 0xec, 0x84, 0xe1, 0x6c, 0x4a, 0x3c, 0x0a, 0x89, 0x87, 0xd8, 0x50, 0x6c, 0xcf, 0xc4,
-	0x6a, 0x8a, 0x65, 0xf8, 0xf0, 0x03, 0x68, 0x71, 0xab, 0x3d, 0x0d, 0x8e, 0x87, 0x2b, 0x4c, 0xc8,
-	0x8a, 0x61, 0xe4, 0xa7, 0xc1, 0xb1, 0x62, 0x57, 0xb4, 0x94, 0x91, 0x1b, 0x8b, 0x32, 0x0e, 0x0c,
-	0x46, 0x47, 0xc2, 0x35, 0xc6, 0x94, 0x16, 0xff, 0x1c, 0x80, 0x9c, 0x91, 0xf1, 0x29, 0x9f, 0x72,
-	0x95, 0x71, 0x0e, 0x04, 0xe7, 0xe3, 0x14, 0xa1, 0x58, 0x35, 0x6a, 0xfc, 0x4b, 0x18, 0xb8, 0x9e,
-	0x77, 0x38, 0x3e, 0x21, 0xde, 0xe9, 0x8c, 0xec, 0x45, 0xc1, 0x69, 0xc8, 0x4c, 0xb9, 0xc6, 0xa4,
-	0xdc, 0x90, 0x87, 0xb0, 0x80, 0x44, 0xc9, 0x2b, 0x94, 0x40, 0x25, 0xd3, 0xb0, 0x90, 0x93, 0xbc,
-	0x6e, 0x48, 0xde, 0x2b, 0x20, 0xd1, 0x24, 0x17, 0x49, 0xa0, 0x61, 0xbc, 0x9f, 0x86, 0xf1, 0x38,
-	0x0c, 0xfc, 0x98, 0x94, 0xc6, 0x71, 0x19, 0xad, 0x2b, 0x65, 0xd1, 0x7a, 0x00, 0x75, 0x76, 0x09,
-	0xb2, 0x78, 0xde, 0x72, 0xf8, 0x00, 0xaf, 0x41, 0x63, 0x46, 0x5c, 0x8f, 0x44, 0x2c, 0x76, 0xb7,
-	0x1c, 0x31, 0x2a, 0x88, 0xed, 0xf5, 0x8b, 0x62, 0x7b, 0x1c, 0x2e, 0x1c, 0xdb, 0x1b, 0x17, 0xc5,
-	0x76, 0x4d, 0x4e, 0x79, 0x6c, 0x5f, 0x2a, 0x8e, 0xed, 0x29, 0x6f, 0x71, 0x6c, 0x6f, 0x16, 0xc7,
-	0x76, 0xc5, 0x55, 0x14, 0xdb, 0x5b, 0x85, 0xb1, 0x3d, 0xe5, 0x29, 0x8f, 0xed, 0x70, 0x41, 0x6c,
-	0x4f, 0xd9, 0x17, 0x88, 0xed, 0xed, 0x8b, 0x63, 0x7b, 0x2a, 0x6a, 0xa1, 0xd8, 0xde, 0xb9, 0x30,
-	0xb6, 0xa7, 0xb2, 0x2e, 0x8f, 0xed, 0xdd, 0x0b, 0x62, 0xbb, 0x5a, 0x9d, 0x19, 0xdb, 0xb7, 0xa1,
-	0x4e, 0xde, 0x12, 0x3f, 0x11, 0xf1, 0x58, 0x6e, 0xc4, 0x63, 0x0a, 0x7b, 0x19, 0x24, 0xd3, 0xd7,
-	0xe7, 0x82, 0x8f, 0x93, 0xe5, 0xc2, 0x78, 0xbf, 0x3c, 0x8c, 0xa7, 0x53, 0x5e, 0x1c, 0xc6, 0x51,
-	0x79, 0x18, 0x57, 0x12, 0x2e, 0x0b, 0xe3, 0xcb, 0x17, 0x86, 0x71, 0x65, 0xc3, 0x45, 0xc2, 0x38,
-	0xbe, 0x38, 0x8c, 0xab, 0xcd, 0x5d, 0x24, 0x8c, 0xaf, 0x5c, 0x18, 0xc6, 0x95, 0x62, 0x17, 0x86,
-	0xf1, 0x41, 0x49, 0x18, 0x4f, 0xd9, 0xcb, 0xc2, 0xf8, 0x6a, 0x49, 0x18, 0x57, 0x8c, 0x65, 0x61,
-	0x7c, 0xad, 0x2c, 0x8c, 0xa7, 0xac, 0x8b, 0x84, 0xf1, 0xf5, 0xcb, 0xc3, 0x78, 0x2a, 0xef, 0xc3,
-	0xc2, 0xf8, 0xf0, 0xf2, 0x30, 0xae, 0x24, 0x17, 0x86, 0xf1, 0xff, 0xa9, 0xc0, 0x72, 0x2e, 0x17,
-	0xd6, 0x13, 0x6f, 0xcb, 0x4c, 0xbc, 0x07, 0x50, 0x67, 0x51, 0x94, 0xc5, 0xf2, 0x8e, 0xc3, 0x07,
-	0x18, 0x43, 0x2d, 0x21, 0xd1, 0x9c, 0x85, 0xef, 0x9a, 0xc3, 0x7e, 0xe3, 0x4f, 0x8d, 0xe8, 0xdd,
-	0xbe, 0xd7, 0xdf, 0x16, 0xb5, 0x8a, 0x43, 0xc2, 0xd9, 0x74, 0xec, 0xa6, 0xe1, 0xfc, 0x4b, 0xe8,
-	0x78, 0xc1, 0x3b, 0x5f, 0x80, 0xe3, 0x61, 0xfd, 0x56, 0x95, 0x19, 0xdd, 0x24, 0xa7, 0x9e, 0x1a,
-	0xcb, 0x83, 0xa0, 0xd3, 0xe3, 0x87, 0xd0, 0x0f, 0x89, 0xef, 0xb1, 0xdc, 0x4d, 0x88, 0x68, 0x30,
-	0x11, 0xd9, 0x19, 0xa5, 0x97, 0x65, 0xa8, 0xe9, 0xe9, 0x8f, 0xa9, 0xf4, 0x34, 0x78, 0x0b, 0xb6,
-	0xf4, 0x84, 0xc8, 0x79, 0x39, 0x19, 0xde, 0x80, 0xe6, 0x84, 0x1a, 0xf0, 0x19, 0x39, 0x67, 0x91,
-	0xbb, 0xe5, 0xa4, 0x63, 0xbc, 0x09, 0xf5, 0x19, 0x71, 0x63, 0x22, 0xa2, 0x73, 0x2a, 0xeb, 0x71,
-	0x18, 0x8c, 0x4f, 0x9e, 0x53, 0x8c, 0xc3, 0x09, 0xec, 0x3f, 0xaf, 0xe5, 0x2c, 0x1f, 0x87, 0xcc,
-	0xf2, 0x14, 0xa8, 0x59, 0x9e, 0x0f, 0xf1, 0xcf, 0x00, 0xd8, 0x4f, 0x26, 0x89, 0x99, 0x3f, 0xab,
-	0x2a, 0xc3, 0x48, 0xbf, 0x54, 0xb4, 0xf8, 0x33, 0xe8, 0x26, 0x6e, 0x34, 0xa1, 0x17, 0x35, 0x5b,
-	0x31, 0xdb, 0xa6, 0x82, 0x0d, 0x31, 0xa9, 0xf0, 0x03, 0xe8, 0x8c, 0x03, 0xff, 0xf5, 0x74, 0x32,
-	0x3a, 0x71, 0xfd, 0x09, 0x11, 0xdb, 0x98, 0x9e, 0x3f, 0x0d, 0xe5, 0x18, 0x84, 0xf8, 0x77, 0xa0,
-	0x97, 0x44, 0xae, 0x1f, 0xbf, 0x26, 0xd1, 0x73, 0xee, 0x01, 0xfc, 0x7e, 0x5e, 0x95, 0x17, 0xbf,
-	0x81, 0x74, 0x32, 0xc4, 0xd8, 0x86, 0xfa, 0x9c, 0x44, 0x13, 0x59, 0x27, 0x75, 0x04, 0xd7, 0x0b,
-	0x0a, 0x73, 0x38, 0x0a, 0xff, 0x04, 0x20, 0xa6, 0xf7, 0x12, 0x5b, 0xb7, 0xd8, 0x37, 0x79, 0x13,
-	0x1e, 0xa6, 0x08, 0x47, 0x23, 0xa2, 0x5a, 0xe9, 0x5a, 0x1e, 0xdd, 0x13, 0xb7, 0xee, 0x6a, 0xc1,
-	0x82, 0x8e, 0xee, 0x39, 0x19, 0x62, 0xfc, 0x73, 0xe8, 0x6a, 0x7a, 0xa6, 0x1b, 0x3c, 0xc8, 0xaf,
-	0x29, 0x26, 0x8e, 0x49, 0x8a, 0x37, 0xa1, 0xef, 0xf1, 0xcb, 0x66, 0x77, 0x1a, 0x91, 0x71, 0x32,
-	0x3b, 0x67, 0x77, 0x70, 0xd3, 0xc9, 0x82, 0xed, 0xdb, 0xd0, 0xd6, 0xea, 0x41, 0x76, 0xda, 0x58,
-	0x82, 0x60, 0x89, 0xd3, 0x46, 0x07, 0xf6, 0x7d, 0x8d, 0x28, 0x0e, 0xf1, 0x47, 0xd0, 0x15, 0x62,
-	0xc4, 0x5d, 0xc2, 0x89, 0x4d, 0xa0, 0xfd, 0x0d, 0x2c, 0xe7, 0x6a, 0x55, 0xe5, 0xf9, 0x56, 0xc6,
-	0x9d, 0x28, 0x65, 0x81, 0xe7, 0x63, 0xa8, 0x79, 0x6e, 0xe2, 0x8a, 0xc3, 0xcf, 0x7e, 0xdb, 0x9f,
-	0xe6, 0x04, 0xc7, 0x61, 0x4a, 0x68, 0x69, 0x84, 0x1f, 0x43, 0x5b, 0xab, 0x5a, 0xcb, 0x92, 0x45,
-	0xfb, 0x99, 0x46, 0x56, 0x2c, 0x89, 0x1e, 0x32, 0xae, 0x76, 0xa5, 0x4c, 0x6d, 0xa1, 0xb0, 0xdd,
-	0x01, 0x50, 0x45, 0xaf, 0xfd, 0x91, 0x1a, 0xc5, 0x61, 0xa9, 0x02, 0x5f, 0x00, 0xca, 0xd6, 0xbb,
-	0x85, 0x5a, 0x0c, 0xa0, 0x3e, 0x0e, 0x4e, 0xfd, 0x84, 0x69, 0xd1, 0x75, 0xf8, 0xc0, 0xde, 0xcd,
-	0x72, 0xc7, 0x21, 0xfe, 0x4d, 0x68, 0x32, 0x47, 0xdc, 0xdf, 0xa5, 0x96, 0xa6, 0xa1, 0xa9, 0xa7,
-	0xfb, 0xea, 0xfe, 0xae, 0x4c, 0xf3, 0x24, 0x95, 0xfd, 0x87, 0xb0, 0x52, 0x50, 0x2b, 0x97, 0x26,
-	0xd8, 0x03, 0xa8, 0x4f, 0x7d, 0x8f, 0x9c, 0x89, 0x36, 0x09, 0x1f, 0xd0, 0x38, 0x15, 0xc9, 0x88,
-	0x58, 0xbd, 0x55, 0xdd, 0xac, 0x39, 0xe9, 0x18, 0xdf, 0x00, 0xe0, 0x97, 0xde, 0x2e, 0x5d, 0x56,
-	0x8d, 0x79, 0xa3, 0x06, 0xb1, 0x1f, 0x16, 0x28, 0x10, 0x87, 0xd2, 0xf2, 0xdc, 0x21, 0x7b, 0x05,
-	0xa1, 0x92, 0x70, 0xcb, 0x13, 0x7b, 0x0b, 0x50, 0xb6, 0xae, 0x2e, 0xb5, 0xf8, 0x6e, 0x96, 0x96,
-	0xd9, 0xac, 0x41, 0x05, 0x9d, 0x4a, 0xdf, 0x1c, 0xca, 0xa9, 0x14, 0xd9, 0x21, 0xc3, 0x3b, 0x82,
-	0xce, 0x7e, 0x0a, 0x38, 0xdf, 0x12, 0x28, 0x35, 0xd9, 0x75, 0x9a, 0x21, 0x30, 0x63, 0xa4, 0xdd,
-	0x25, 0x05, 0xb0, 0xbf, 0xcc, 0xcb, 0xfa, 0xa0, 0xd5, 0x3f, 0x86, 0x25, 0xb1, 0xb5, 0x74, 0x6f,
-	0x7c, 0xf2, 0x2e, 0x8d, 0xe7, 0x7c, 0x40, 0x0f, 0xad, 0x4f, 0xde, 0x39, 0x72, 0x42, 0xea, 0xca,
-	0x74, 0x83, 0x4c, 0xa0, 0xfd, 0x09, 0xa0, 0x6c, 0x5f, 0x81, 0xba, 0xe2, 0xeb, 0x99, 0x3b, 0x61,
-	0xe2, 0xba, 0x0e, 0xfb, 0x6d, 0x7f, 0x0d, 0xfd, 0x4c, 0xef, 0x80, 0x16, 0x4f, 0xb1, 0x0c, 0x07,
-	0xd5, 0xcd, 0x8e, 0x23, 0x46, 0x74, 0x62, 0x7a, 0xff, 0x24, 0xe9, 0x5d, 0x29, 0x26, 0x36, 0x80,
-	0xf6, 0x72, 0x46, 0x60, 0x1c, 0xda, 0x3f, 0xa6, 0x39, 0xbb, 0xd1, 0x5d, 0xc0, 0x57, 0xa1, 0x3a,
-	0x15, 0x13, 0xd4, 0x1e, 0x2d, 0xbd, 0xff, 0xe1, 0x66, 0x75, 0x7f, 0x37, 0x76, 0x28, 0x8c, 0x0a,
-	0xc8, 0x24, 0xb1, 0xf6, 0x5d, 0xc0, 0xf9, 0xce, 0x82, 0x92, 0x61, 0x6d, 0x76, 0x32, 0x32, 0x9c,
-	0x3c, 0x43, 0x1c, 0xd2, 0x8d, 0xf3, 0xd2, 0xaa, 0x81, 0x9f, 0x47, 0x05, 0xa0, 0x7e, 0xed, 0xa9,
-	0x5a, 0x80, 0xc7, 0x29, 0x0d, 0x62, 0x3f, 0x86, 0x95, 0x82, 0x96, 0x04, 0xde, 0x86, 0x5a, 0x44,
-	0x13, 0x2a, 0xcb, 0x08, 0xea, 0x06, 0x99, 0x38, 0xa3, 0x8c, 0xce, 0x5e, 0x2d, 0x10, 0x13, 0x87,
-	0xf6, 0x36, 0xe0, 0x7c, 0x8f, 0xa2, 0xfc, 0x4e, 0xb7, 0xbf, 0xca, 0xd3, 0x33, 0xd7, 0xaf, 0x47,
-	0x2c, 0x6d, 0xb6, 0x44, 0x26, 0x54, 0xae, 0x0d, 0x27, 0xb4, 0xef, 0x43, 0x47, 0x6f, 0x6b, 0xe0,
-	0xdb, 0x50, 0xfd, 0xfd, 0xe0, 0x58, 0xac, 0xa6, 0x2d, 0xdd, 0xf4, 0x69, 0x70, 0x2c, 0xd8, 0x28,
-	0xd6, 0xee, 0xe9, 0x4c, 0x71, 0x48, 0x85, 0xe8, 0x2d, 0x8e, 0x85, 0x85, 0xe8, 0x09, 0xb5, 0xfd,
-	0x04, 0xba, 0x46, 0xb7, 0x63, 0x21, 0x29, 0x85, 0xf7, 0xca, 0x6d, 0x43, 0x52, 0xc9, 0x9d, 0xf2,
-	0x12, 0xd6, 0x4b, 0xda, 0x22, 0xf8, 0xbe, 0xb1, 0xa5, 0x57, 0xd3, 0xb3, 0x9a, 0xa5, 0x35, 0xf6,
-	0xf5, 0x6a, 0x89, 0xbc, 0x38, 0xa4, 0xa8, 0x92, 0x3e, 0x89, 0x7d, 0x50, 0x82, 0x8a, 0x43, 0xfc,
-	0x99, 0xb9, 0x97, 0x97, 0xaa, 0x21, 0x36, 0xf4, 0xdf, 0x2a, 0xd0, 0xd6, 0xaa, 0x4f, 0x8c, 0xa0,
-	0x1a, 0x93, 0x6f, 0x85, 0xfb, 0xd0, 0x9f, 0x2c, 0xe5, 0x96, 0x3d, 0x95, 0xae, 0x68, 0xa3, 0xdc,
-	0x83, 0xd6, 0xd4, 0x9f, 0x26, 0x8c, 0x51, 0x24, 0x79, 0xd2, 0x79, 0xf6, 0x25, 0x9c, 0x46, 0x77,
-	0x47, 0x91, 0xe1, 0xcf, 0x64, 0x5a, 0xc9, 0x98, 0x6a, 0x46, 0x4a, 0x74, 0x98, 0x22, 0x18, 0x97,
-	0x46, 0xc8, 0xd8, 0xe8, 0x6d, 0xcb, 0xd9, 0xcc, 0xfc, 0xee, 0x30, 0x45, 0x08, 0xb6, 0x74, 0x8c,
-	0xbf, 0x80, 0x7e, 0x9c, 0x66, 0xd5, 0x9c, 0xb7, 0x51, 0x96, 0x74, 0x3b, 0x59, 0x52, 0xc6, 0x9d,
-	0x5e, 0xf1, 0x9c, 0x7b, 0xa9, 0x34, 0x03, 0xc8, 0x92, 0xda, 0x7f, 0x61, 0x41, 0xd7, 0x30, 0x43,
-	0x69, 0x8c, 0xa4, 0x70, 0xca, 0xcc, 0x83, 0x23, 0x85, 0xb3, 0x11, 0xde, 0x02, 0xc4, 0x6b, 0x16,
-	0x2d, 0x6e, 0xf3, 0x8b, 0x35, 0x07, 0xa7, 0xf7, 0x17, 0xcb, 0xf3, 0xe3, 0x61, 0x8d, 0xed, 0x7c,
-	0x41, 0x25, 0x20, 0xb6, 0x5c, 0xd0, 0xd9, 0x7f, 0x63, 0x41, 0xcf, 0xb4, 0x78, 0x49, 0xf2, 0xd3,
-	0xcf, 0x4c, 0x26, 0xae, 0xaf, 0x2c, 0x58, 0xd5, 0x22, 0xd5, 0x4b, 0x6a, 0x11, 0x1a, 0xa1, 0xf8,
-	0xdd, 0xef, 0x89, 0x54, 0x40, 0x0e, 0xa9, 0x29, 0x78, 0x55, 0xcd, 0xf6, 0xb8, 0xe9, 0x88, 0x91,
-	0xfd, 0x11, 0xf4, 0xcc, 0x6d, 0x2e, 0x3c, 0x9e, 0xe7, 0xd0, 0xd1, 0xd3, 0x6a, 0x7c, 0x97, 0xce,
-	0xc3, 0x6b, 0x10, 0xab, 0xb0, 0x06, 0x91, 0xbd, 0x2b, 0x41, 0x45, 0x8b, 0x9e, 0x31, 0x63, 0x7d,
-	0xa5, 0xfa, 0x87, 0x69, 0x26, 0xa0, 0x8b, 0x66, 0xcd, 0x44, 0x8d, 0xd6, 0xde, 0x81, 0x9e, 0x59,
-	0x67, 0x7c, 0xf0, 0xe4, 0xf6, 0x43, 0xe8, 0x1a, 0x69, 0x3d, 0x4d, 0x97, 0xb9, 0x41, 0xad, 0x32,
-	0x83, 0xca, 0x53, 0xcc, 0x4b, 0xbc, 0xc7, 0xd0, 0x33, 0xab, 0x0a, 0x7c, 0x1f, 0x96, 0xb8, 0x8e,
-	0x32, 0x20, 0x14, 0x95, 0x53, 0x52, 0x0f, 0x41, 0x69, 0xdf, 0x84, 0x3a, 0x2b, 0x7e, 0xe8, 0x66,
-	0xf0, 0x12, 0x4d, 0x18, 0x59, 0x8c, 0xec, 0x17, 0x00, 0xaa, 0xe8, 0xc1, 0x77, 0xa0, 0x11, 0x06,
-	0xb3, 0xe9, 0xf8, 0x5c, 0xa4, 0x29, 0x2b, 0xa9, 0xbd, 0xe8, 0x65, 0x7a, 0xc0, 0x50, 0x8e, 0x20,
-	0xa1, 0xbb, 0xf6, 0x86, 0x9c, 0x4b, 0x47, 0x67, 0xbf, 0x6d, 0x02, 0xfd, 0xe7, 0xee, 0x31, 0x99,
-	0x8d, 0x02, 0x3f, 0x4e, 0x22, 0x77, 0xea, 0x27, 0x34, 0xfe, 0xbc, 0x21, 0x5c, 0x60, 0xcb, 0xa1,
-	0x3f, 0xf1, 0x26, 0x54, 0x82, 0x30, 0xdd, 0x11, 0xbe, 0x88, 0x0c, 0xd7, 0xd7, 0xa1, 0x53, 0x09,
-	0x68, 0x9e, 0xdd, 0x78, 0xeb, 0xce, 0x4e, 0x09, 0x3f, 0x2b, 0x2d, 0x47, 0x8c, 0xec, 0x3f, 0xae,
-	0x42, 0xd7, 0xec, 0x1c, 0xa9, 0x5c, 0xad, 0x95, 0x7d, 0x07, 0x64, 0x05, 0xb6, 0x70, 0xf5, 0x96,
-	0x23, 0x87, 0x2a, 0xf1, 0xad, 0xf2, 0x1c, 0x3c, 0x4d, 0x7c, 0x83, 0xb7, 0x24, 0x8a, 0xa6, 0x1e,
-	0x11, 0xfe, 0x9c, 0x8e, 0x29, 0x2e, 0x4e, 0xdc, 0x28, 0xa1, 0xc5, 0x7b, 0x9d, 0x59, 0x31, 0x1d,
-	0x53, 0x4d, 0x89, 0xef, 0x51, 0x4c, 0x83, 0xdb, 0x97, 0x8f, 0xf0, 0x16, 0xd4, 0xa2, 0x60, 0xc6,
-	0x9b, 0xbb, 0x3d, 0xad, 0x49, 0xc7, 0xcb, 0xe6, 0x60, 0xc6, 0xbd, 0x8f, 0xd1, 0xa8, 0xaa, 0xa0,
-	0xa9, 0x55, 0x05, 0xf8, 0x09, 0xa0, 0x99, 0x69, 0x9c, 0x78, 0xd8, 0x62, 0x0e, 0xb0, 0x56, 0x6c,
-	0x3b, 0xd9, 0x5d, 0xcb, 0x72, 0xe1, 0x4f, 0xa0, 0x37, 0x0b, 0xc6, 0x6e, 0x32, 0x0d, 0x7c, 0xc6,
-	0x12, 0x0f, 0x81, 0x59, 0x35, 0x03, 0xa5, 0x74, 0xd3, 0x38, 0x98, 0x71, 0x10, 0x79, 0x4b, 0x66,
-	0xac, 0x5d, 0xdb, 0x72, 0x32, 0x50, 0xfb, 0x2f, 0x2d, 0x9a, 0xea, 0xb2, 0x77, 0x58, 0x56, 0xb4,
-	0x3c, 0xe1, 0x87, 0x45, 0x6d, 0x45, 0x27, 0xf7, 0x24, 0x2b, 0x72, 0x99, 0x8a, 0xd9, 0x9f, 0xd0,
-	0x8e, 0x57, 0x75, 0xa1, 0xb3, 0x9d, 0x86, 0xa7, 0xda, 0x65, 0xad, 0x92, 0xdf, 0x83, 0x15, 0xf9,
-	0xc6, 0xb0, 0x88, 0x8e, 0x5b, 0xf2, 0x35, 0x81, 0x97, 0x87, 0xbd, 0x6d, 0xf9, 0xc0, 0xfe, 0x98,
-	0xfe, 0x4d, 0x3b, 0xb9, 0x74, 0x40, 0x23, 0x94, 0xbe, 0x7a, 0xfc, 0x00, 0x1a, 0x27, 0xbc, 0x67,
-	0x61, 0x65, 0x1a, 0xd2, 0x59, 0x13, 0xc9, 0xe8, 0xcd, 0xc9, 0x69, 0x8d, 0x17, 0x71, 0x1a, 0x7e,
-	0x98, 0x54, 0x8d, 0x27, 0x59, 0x45, 0x8d, 0x27, 0xa9, 0xec, 0x3f, 0x80, 0xae, 0xb1, 0x2a, 0xfc,
-	0xb3, 0xcc, 0xdc, 0x1b, 0xa9, 0x80, 0xdc, 0xda, 0x33, 0x93, 0xdf, 0xa7, 0xc5, 0x0c, 0x27, 0x92,
-	0xb3, 0xf7, 0xb3, 0xcc, 0x69, 0xab, 0x53, 0xd0, 0xd9, 0x7f, 0xbb, 0x04, 0x4b, 0xf9, 0x17, 0xf8,
-	0x4e, 0xb6, 0xb0, 0x64, 0x47, 0x4d, 0x16, 0x96, 0x6c, 0x80, 0x6d, 0xe3, 0xf5, 0x5d, 0xae, 0x73,
-	0x34, 0xf7, 0xb4, 0x27, 0x9d, 0x1b, 0x00, 0xe3, 0xd3, 0x38, 0x09, 0xe6, 0x2c, 0x72, 0xd7, 0x18,
-	0xbb, 0x06, 0x91, 0x11, 0x85, 0x1f, 0x41, 0x16, 0x51, 0x10, 0x54, 0xc7, 0x73, 0x4f, 0x1c, 0x3d,
-	0xfa, 0x93, 0xd6, 0x06, 0xe1, 0x94, 0xb7, 0x77, 0xaa, 0xbc, 0x36, 0x38, 0xd8, 0xdf, 0x75, 0x28,
-	0x8c, 0xfa, 0x61, 0x12, 0xf0, 0xee, 0x4f, 0x93, 0xfb, 0xa1, 0x18, 0xd2, 0x4b, 0x7a, 0x3a, 0xf1,
-	0xe9, 0xd5, 0x44, 0xfd, 0x88, 0xc5, 0x3c, 0xd6, 0xab, 0x69, 0x3a, 0x39, 0x38, 0xeb, 0xfb, 0xb3,
-	0x76, 0x1a, 0x5c, 0xd2, 0x4e, 0xe3, 0x64, 0xca, 0x65, 0xdb, 0x97, 0xdd, 0xa8, 0x5b, 0xd0, 0xa2,
-	0xb1, 0xd4, 0x61, 0x9d, 0xb3, 0x8e, 0xd1, 0xc8, 0x62, 0x30, 0x47, 0xa1, 0xf1, 0x73, 0x58, 0x11,
-	0x67, 0xe2, 0x90, 0xcc, 0xc8, 0x38, 0xe1, 0x21, 0x9a, 0x3d, 0x64, 0xf4, 0x34, 0x27, 0xc8, 0x51,
-	0x38, 0x45, 0x6c, 0xf8, 0x17, 0xd0, 0x4f, 0xce, 0x7c, 0xe6, 0x2b, 0x62, 0x77, 0xd3, 0x57, 0x66,
-	0xfe, 0xc9, 0xc7, 0x2b, 0x13, 0xeb, 0x64, 0xc9, 0xf1, 0x0b, 0xe8, 0x9f, 0x86, 0x9e, 0x9b, 0x90,
-	0x57, 0x67, 0xbe, 0x43, 0xc6, 0x41, 0xe4, 0x89, 0x07, 0x8e, 0x1f, 0x09, 0x5d, 0x7e, 0xd7, 0xc4,
-	0x9a, 0x0e, 0x9e, 0xe5, 0xa5, 0xe2, 0x3c, 0x32, 0x23, 0xba, 0x38, 0x64, 0x88, 0xdb, 0x35, 0xb1,
-	0x19, 0x71, 0x19, 0x5e, 0x7c, 0x04, 0x78, 0x1c, 0xcc, 0xe7, 0xd3, 0xe4, 0xd5, 0x99, 0xff, 0x4d,
-	0x34, 0x4d, 0x78, 0x07, 0x83, 0x3f, 0x7d, 0xdc, 0x4a, 0x6f, 0xd3, 0x2c, 0x81, 0x29, 0xb4, 0x40,
-	0x02, 0x3e, 0x82, 0xe5, 0x28, 0x98, 0xcd, 0x8e, 0xdd, 0xf1, 0x1b, 0xa5, 0x28, 0x7f, 0x05, 0xb1,
-	0xe5, 0x1e, 0x28, 0x7c, 0x89, 0xe0, 0xbc, 0x08, 0x7c, 0x00, 0x68, 0x3c, 0x23, 0xae, 0xff, 0xea,
-	0xcc, 0x7f, 0x71, 0x34, 0x1a, 0x31, 0x6d, 0x57, 0x8c, 0xbe, 0xfd, 0x28, 0x83, 0x36, 0x45, 0xe6,
-	0xb8, 0xed, 0x3b, 0x50, 0xe7, 0x8e, 0x83, 0xa1, 0xf6, 0x3a, 0x0a, 0xe6, 0x32, 0xe5, 0xa2, 0xbf,
-	0x71, 0x0f, 0x2a, 0x49, 0x20, 0x0a, 0xa9, 0x4a, 0x12, 0xd8, 0x7f, 0x52, 0x87, 0x66, 0xc1, 0x03,
-	0xad, 0x79, 0xcc, 0x6d, 0xe3, 0x81, 0x76, 0x91, 0x03, 0x5d, 0xcd, 0x1d, 0xe8, 0x01, 0xd4, 0xd9,
-	0xc5, 0xce, 0xce, 0x7a, 0xc7, 0xe1, 0x03, 0x79, 0x84, 0xeb, 0x05, 0x47, 0x38, 0x0d, 0xd3, 0x8d,
-	0x4b, 0xc3, 0x34, 0x1e, 0x01, 0x52, 0x5e, 0xca, 0x17, 0x23, 0x52, 0xff, 0xf5, 0x9c, 0x57, 0x73,
-	0xb4, 0x93, 0x63, 0xc0, 0x7b, 0x79, 0xbf, 0x6e, 0x2e, 0xe0, 0xd7, 0x79, 0x8f, 0xde, 0xcb, 0x7b,
-	0x74, 0x6b, 0x01, 0x8f, 0xce, 0xfb, 0xf2, 0x41, 0xa1, 0x2f, 0xc3, 0x62, 0xbe, 0x5c, 0xe8, 0xc5,
-	0x07, 0x45, 0x5e, 0xdc, 0x5e, 0xd4, 0x8b, 0x8b, 0xfc, 0xf7, 0x69, 0x81, 0xff, 0x76, 0x16, 0xf1,
-	0xdf, 0x02, 0xcf, 0xfd, 0x23, 0x0b, 0x56, 0x8c, 0x87, 0x03, 0x11, 0x71, 0xcc, 0x34, 0xdf, 0x5a,
-	0x3c, 0xcd, 0xd7, 0xb3, 0x8e, 0xca, 0x42, 0x49, 0xfd, 0x0e, 0x0c, 0x4c, 0x0d, 0x84, 0x73, 0xfc,
-	0xba, 0x7c, 0xd8, 0xe2, 0x77, 0x6f, 0xd7, 0xb8, 0x0a, 0xd2, 0x2e, 0x38, 0x1d, 0xd8, 0x0f, 0x60,
-	0x79, 0x14, 0xcc, 0x43, 0x77, 0x9c, 0x3c, 0x0f, 0x26, 0x72, 0x09, 0x36, 0x74, 0xc6, 0x1c, 0xb8,
-	0xcf, 0x12, 0x52, 0x5e, 0xaa, 0x1b, 0x30, 0x7b, 0x00, 0x58, 0x67, 0xe4, 0x33, 0xdb, 0x4f, 0x60,
-	0x35, 0xf3, 0x22, 0x22, 0x44, 0x7e, 0x70, 0xc1, 0x32, 0x84, 0xb5, 0xac, 0x24, 0x31, 0x87, 0x07,
-	0xcb, 0x46, 0x43, 0x9b, 0xc9, 0xff, 0x4c, 0x4b, 0x59, 0xcc, 0x6a, 0x44, 0x27, 0xcb, 0xe6, 0x2d,
-	0xf4, 0xea, 0x1d, 0x07, 0x7e, 0x42, 0xce, 0x12, 0x11, 0x66, 0xe4, 0xd0, 0xfe, 0x33, 0x0b, 0x3a,
-	0xc6, 0x0c, 0xec, 0xfd, 0xc2, 0x8d, 0x12, 0xf5, 0x7e, 0xe1, 0x46, 0xac, 0x98, 0x20, 0xbe, 0x7c,
-	0x41, 0xa4, 0x3f, 0x69, 0x6c, 0xf1, 0xc9, 0xbb, 0x43, 0x91, 0x58, 0x8a, 0xd8, 0xa2, 0x20, 0xf8,
-	0x01, 0xb4, 0x55, 0x63, 0x54, 0x56, 0xd4, 0x25, 0xd6, 0xd0, 0x29, 0xed, 0x1d, 0xc0, 0xfa, 0xba,
-	0xc5, 0x5e, 0xdf, 0x31, 0xea, 0xfe, 0x92, 0xcd, 0x16, 0x24, 0xb6, 0x03, 0xab, 0x3c, 0x2e, 0xbc,
-	0x20, 0x89, 0xeb, 0x29, 0xf7, 0xc6, 0x9f, 0x43, 0x73, 0x2e, 0x40, 0x62, 0x7f, 0xd6, 0x0d, 0x39,
-	0xcf, 0x83, 0xb1, 0x3b, 0x63, 0x6d, 0x4b, 0x69, 0x42, 0x49, 0x4e, 0x37, 0x2a, 0x2b, 0x53, 0x6c,
-	0x54, 0x00, 0x2b, 0x1c, 0xc3, 0xd3, 0x78, 0x39, 0xd7, 0x1d, 0x68, 0xcc, 0x78, 0xb6, 0x9f, 0xd1,
-	0x98, 0x91, 0xa5, 0x8d, 0x04, 0x9e, 0xfa, 0xab, 0x02, 0xb0, 0x22, 0x0a, 0x40, 0x3d, 0xbc, 0x99,
-	0x05, 0xa0, 0xbd, 0x06, 0x03, 0x73, 0x42, 0xa1, 0xc8, 0x18, 0xd6, 0x39, 0x5c, 0xcb, 0x6d, 0x84,
-	0x32, 0xe5, 0x6f, 0x94, 0x69, 0x81, 0x5c, 0x59, 0xac, 0x40, 0xde, 0x80, 0x61, 0x7e, 0x12, 0xa1,
-	0xc0, 0x4b, 0x69, 0xa3, 0x6c, 0x18, 0xc5, 0x3f, 0x85, 0x56, 0x92, 0xc6, 0x36, 0x6e, 0x79, 0xa4,
-	0x6e, 0x01, 0x0e, 0x97, 0xe9, 0x6e, 0x4a, 0x68, 0x7f, 0x2d, 0x17, 0xa4, 0xc9, 0x13, 0xfe, 0xf0,
-	0x7f, 0x13, 0xf8, 0x2b, 0x58, 0x2b, 0x8e, 0xf3, 0xf8, 0xc7, 0xb0, 0x9c, 0x92, 0x39, 0xc1, 0x69,
-	0x42, 0x9e, 0x89, 0xda, 0xb9, 0xe3, 0xe4, 0x11, 0xf4, 0x90, 0x24, 0x67, 0xbe, 0x28, 0xa8, 0x3a,
-	0x0e, 0x1f, 0xd8, 0x57, 0x61, 0x3d, 0x27, 0x5d, 0x58, 0x66, 0x0e, 0x57, 0x4b, 0x2f, 0x05, 0x7c,
-	0x1d, 0x5a, 0xfc, 0xdb, 0x5d, 0x35, 0xa7, 0x02, 0xe0, 0x7b, 0xd0, 0x14, 0x97, 0xc6, 0xa1, 0xd8,
-	0x23, 0xb4, 0xcd, 0xbe, 0xea, 0xdd, 0x7e, 0x25, 0xbf, 0xea, 0x95, 0xce, 0x2a, 0xe9, 0xec, 0xeb,
-	0xb0, 0x51, 0x34, 0x9d, 0x50, 0xe6, 0x5b, 0xb8, 0x76, 0xc1, 0x85, 0x72, 0x89, 0x3a, 0xd4, 0xf0,
-	0x72, 0xde, 0x4b, 0xf4, 0x51, 0x84, 0xf6, 0x0d, 0xb8, 0x5e, 0x3c, 0xa5, 0x50, 0xe9, 0x6b, 0x58,
-	0x2f, 0xb9, 0x92, 0xcc, 0x09, 0xad, 0x45, 0x27, 0xdc, 0x80, 0x61, 0x5e, 0xa0, 0x98, 0xec, 0xb7,
-	0xa0, 0xf3, 0xec, 0xe8, 0x50, 0x7d, 0xcb, 0xac, 0x75, 0x4a, 0x44, 0x5d, 0x93, 0x26, 0x46, 0x15,
-	0x2d, 0x31, 0xb2, 0xfb, 0xd0, 0x15, 0x7c, 0x42, 0xd0, 0x43, 0x58, 0x7e, 0x76, 0xc4, 0x83, 0x95,
-	0x92, 0x26, 0xdb, 0x33, 0x96, 0x6a, 0xcf, 0x68, 0xfd, 0x14, 0xd1, 0x9d, 0x14, 0xfd, 0x94, 0x01,
-	0x60, 0x5d, 0x80, 0x10, 0x7b, 0x8b, 0xea, 0xb7, 0x77, 0x81, 0x7e, 0xf6, 0xc7, 0x54, 0x93, 0x3d,
-	0xed, 0x33, 0xbe, 0x54, 0x61, 0x4b, 0x57, 0x78, 0x27, 0xd5, 0x6f, 0xef, 0x62, 0xfd, 0x86, 0xb0,
-	0xc4, 0xda, 0x30, 0x44, 0xbe, 0x2d, 0xc9, 0xa1, 0xfd, 0x55, 0xaa, 0xe1, 0x9e, 0xf1, 0xd5, 0xa0,
-	0x5c, 0x8f, 0xa5, 0xaf, 0xe7, 0x02, 0x39, 0xb7, 0xa1, 0xff, 0xec, 0x88, 0x9f, 0x8e, 0xf2, 0x65,
-	0x61, 0x40, 0x8a, 0x48, 0x18, 0x63, 0x0b, 0x06, 0x42, 0x01, 0x93, 0xbb, 0x60, 0x19, 0xf6, 0x3a,
-	0xac, 0x66, 0x68, 0x85, 0x90, 0x2f, 0xa9, 0x10, 0x96, 0x80, 0x9b, 0x42, 0x16, 0xbc, 0xec, 0xb8,
-	0x60, 0x83, 0x5f, 0x08, 0xfe, 0x6b, 0x8b, 0xf9, 0xc4, 0xd8, 0xf5, 0x3f, 0xf4, 0xfe, 0x1c, 0x40,
-	0x7d, 0x36, 0x9d, 0x4f, 0x13, 0x71, 0x75, 0xf2, 0x01, 0xbd, 0x55, 0xd9, 0x8f, 0x47, 0xe7, 0x09,
-	0x6b, 0x43, 0xb3, 0x5b, 0x55, 0x41, 0xe8, 0xd9, 0x7c, 0x37, 0x4d, 0x4e, 0x8e, 0xd8, 0x5e, 0xf3,
-	0xf6, 0xae, 0x02, 0xb0, 0x93, 0xeb, 0xcf, 0xce, 0x47, 0xac, 0x99, 0xd5, 0xe0, 0xd8, 0x14, 0x60,
-	0xff, 0xa9, 0x05, 0x3d, 0xa9, 0xab, 0xd8, 0xc7, 0x0f, 0xf0, 0x55, 0xd5, 0x25, 0x13, 0x0a, 0xf3,
-	0x2e, 0xd9, 0x75, 0x68, 0xd1, 0x7c, 0x89, 0x1a, 0x45, 0x36, 0xa2, 0x15, 0x80, 0x75, 0xee, 0x58,
-	0x5d, 0xee, 0x7b, 0x69, 0xe7, 0x4e, 0x8c, 0xed, 0x5f, 0xc2, 0x50, 0x6c, 0xd6, 0x8b, 0xe9, 0x19,
-	0xf1, 0x58, 0x4c, 0x90, 0x46, 0xfc, 0x22, 0x97, 0xe6, 0xc8, 0x9a, 0xfa, 0xd9, 0x51, 0x8e, 0x3a,
-	0xd7, 0xa5, 0xf9, 0x15, 0x5c, 0x2d, 0x90, 0x2c, 0x96, 0xfc, 0x30, 0xdf, 0x77, 0xb9, 0x56, 0x28,
-	0xbb, 0xac, 0x07, 0xf3, 0xef, 0x16, 0xac, 0x14, 0x68, 0xc1, 0x72, 0x2c, 0x5e, 0x7d, 0xc9, 0x2b,
-	0x56, 0x0c, 0xf1, 0x1d, 0xa8, 0xc6, 0x24, 0x11, 0xc1, 0x72, 0x25, 0x9d, 0x4c, 0xc5, 0x0c, 0xf9,
-	0xae, 0x16, 0x13, 0x1a, 0xee, 0x1a, 0xbc, 0xe4, 0x10, 0x2d, 0xb9, 0xb5, 0x94, 0xde, 0x70, 0x5d,
-	0x99, 0x3f, 0x70, 0x5a, 0x3c, 0x82, 0x76, 0xa4, 0xdc, 0x53, 0xb4, 0xe7, 0xd4, 0xba, 0xf2, 0xae,
-	0x2f, 0x33, 0x2f, 0x8d, 0xcb, 0xfe, 0x0f, 0x8b, 0x1e, 0x93, 0x02, 0x9b, 0xfd, 0xbf, 0x5f, 0xda,
-	0xd6, 0x5f, 0x35, 0xa1, 0xc6, 0x14, 0x5e, 0x85, 0x65, 0x56, 0x90, 0x90, 0xc9, 0x34, 0x4e, 0x48,
-	0xc4, 0x1e, 0x44, 0xd0, 0x15, 0x7c, 0x15, 0x56, 0x29, 0x38, 0xf7, 0x59, 0x1d, 0xb2, 0x4a, 0x50,
-	0x71, 0x88, 0x2a, 0x29, 0x2a, 0xfb, 0x91, 0x0e, 0xaa, 0x96, 0xa0, 0xe2, 0x10, 0xd5, 0xf0, 0x0a,
-	0xf4, 0x29, 0x4a, 0xfb, 0x68, 0x08, 0xd5, 0x73, 0xc0, 0x38, 0x44, 0x0d, 0x09, 0xd4, 0x3e, 0xc1,
-	0x41, 0x4b, 0x39, 0x60, 0x1c, 0xa2, 0x26, 0xc6, 0xd0, 0xa3, 0x40, 0xf5, 0xe1, 0x0c, 0x6a, 0x65,
-	0x61, 0x71, 0x88, 0x00, 0x0f, 0x61, 0xc0, 0x60, 0x99, 0x8f, 0x65, 0x50, 0xbb, 0x18, 0x13, 0x87,
-	0xa8, 0x83, 0xaf, 0xc1, 0x3a, 0xc5, 0x14, 0x7c, 0xdc, 0x82, 0xba, 0xa5, 0xc8, 0x38, 0x44, 0x3d,
-	0xbc, 0x01, 0x6b, 0xdc, 0xd8, 0xd9, 0x4f, 0x3c, 0x50, 0xbf, 0x0c, 0x17, 0x87, 0x08, 0x49, 0x5d,
-	0xb2, 0x1f, 0xa3, 0xa0, 0xe5, 0x62, 0x4c, 0x1c, 0x22, 0x2c, 0x31, 0xd9, 0x6f, 0x2f, 0xd0, 0x8a,
-	0x34, 0x98, 0xf6, 0x36, 0x8b, 0x06, 0x78, 0x1d, 0x56, 0x14, 0x79, 0xfa, 0x79, 0x04, 0x5a, 0x2d,
-	0x44, 0xc4, 0x21, 0x5a, 0x93, 0x88, 0xcc, 0x07, 0x15, 0x68, 0xbd, 0x10, 0x11, 0x87, 0x68, 0x28,
-	0x97, 0x98, 0xff, 0x82, 0x02, 0x5d, 0x2d, 0xc3, 0xc5, 0x21, 0xda, 0x90, 0x36, 0x2d, 0xf8, 0xe8,
-	0x01, 0x5d, 0x2b, 0x45, 0xc6, 0x21, 0xba, 0x2e, 0xa5, 0xe6, 0x3f, 0x68, 0x40, 0x3f, 0x2a, 0xc3,
-	0xc5, 0x21, 0xba, 0x81, 0x07, 0x80, 0xd4, 0xa2, 0xf9, 0x57, 0x00, 0xe8, 0x66, 0x1e, 0x1a, 0x87,
-	0xe8, 0x96, 0x84, 0xea, 0xdf, 0x1d, 0xa0, 0x5f, 0xcb, 0x43, 0xe3, 0x10, 0xd9, 0xf2, 0xb4, 0x19,
-	0x9f, 0x17, 0xa0, 0xdb, 0x05, 0xe0, 0x38, 0x44, 0x1f, 0xe1, 0x9b, 0x70, 0x8d, 0xb9, 0x60, 0xf1,
-	0xd7, 0x01, 0xe8, 0xe3, 0x0b, 0x09, 0xe2, 0x10, 0x7d, 0x22, 0x09, 0x4a, 0x1e, 0xfd, 0xd1, 0xa7,
-	0x17, 0x12, 0xc4, 0x21, 0xda, 0xdc, 0x1a, 0xb1, 0xcf, 0xdc, 0xf5, 0x47, 0x22, 0xdc, 0x82, 0xfa,
-	0x51, 0x90, 0x90, 0x08, 0x5d, 0xc1, 0x00, 0x0d, 0x5e, 0xa5, 0x23, 0x0b, 0x77, 0xa0, 0xf9, 0x55,
-	0x30, 0x9b, 0x05, 0xef, 0x48, 0x84, 0x2a, 0xb8, 0x0d, 0x4b, 0xcf, 0x89, 0x1b, 0xf9, 0x24, 0x42,
-	0xd5, 0xad, 0x1d, 0x58, 0xce, 0xbd, 0xab, 0xe1, 0x06, 0x54, 0xf6, 0x7d, 0x74, 0x85, 0x8a, 0x7b,
-	0x19, 0x24, 0xfb, 0x3e, 0xb2, 0xa8, 0xb8, 0xc7, 0x67, 0xd3, 0x38, 0x89, 0x51, 0x05, 0x77, 0xa1,
-	0xf5, 0x32, 0x48, 0xc4, 0xb0, 0xba, 0x75, 0x0f, 0x96, 0x44, 0x2f, 0x8f, 0x32, 0xb0, 0x70, 0x8c,
-	0xae, 0xe0, 0x26, 0xd4, 0x1c, 0xe2, 0x7a, 0xc8, 0xa2, 0xc0, 0x1d, 0x6f, 0x3e, 0xf5, 0x51, 0x05,
-	0x2f, 0x41, 0xf5, 0xd5, 0x99, 0x8f, 0xaa, 0x5b, 0x3f, 0x54, 0xa1, 0xbd, 0xef, 0x27, 0x24, 0xf2,
-	0xdd, 0xd9, 0x68, 0xee, 0x51, 0xc7, 0x1f, 0xcd, 0x3d, 0xbd, 0x75, 0x82, 0xae, 0xe0, 0x65, 0xe8,
-	0x32, 0xa0, 0xec, 0x69, 0x20, 0x8b, 0x6e, 0x07, 0x9d, 0xcb, 0x68, 0x43, 0xa0, 0x8a, 0xa0, 0x54,
-	0xd1, 0x00, 0xd5, 0x05, 0xa5, 0x59, 0x07, 0xf3, 0x38, 0x95, 0x82, 0x79, 0x4d, 0x8a, 0x96, 0xe8,
-	0xb1, 0x48, 0x81, 0xaa, 0x56, 0x44, 0x4d, 0xbc, 0x06, 0x38, 0x45, 0xa4, 0x95, 0x12, 0xf2, 0x04,
-	0x3c, 0x53, 0x41, 0x21, 0x9a, 0xdb, 0x22, 0xae, 0x31, 0xaf, 0x67, 0x68, 0x2a, 0x8f, 0x5e, 0x0b,
-	0x6a, 0xad, 0xa8, 0x60, 0xf0, 0x89, 0x98, 0x36, 0x9b, 0xfb, 0xa3, 0x13, 0xdc, 0x85, 0xe6, 0x68,
-	0xee, 0xb1, 0xbb, 0x09, 0x7d, 0x67, 0x61, 0xcc, 0x56, 0xa7, 0xb2, 0x6f, 0xf4, 0xf7, 0x56, 0x4a,
-	0xb2, 0x47, 0x12, 0xf4, 0x0f, 0x19, 0x12, 0x0a, 0xfb, 0x47, 0x0b, 0x23, 0x68, 0x33, 0x18, 0x57,
-	0x13, 0xfd, 0x13, 0xb5, 0x1e, 0x52, 0x54, 0x02, 0xfc, 0xcf, 0x0a, 0xac, 0xdd, 0x4f, 0xe8, 0x5f,
-	0x2c, 0xdc, 0x83, 0x16, 0xd7, 0x62, 0xec, 0xfa, 0xe8, 0x5f, 0xe9, 0xed, 0x32, 0x50, 0xdc, 0xea,
-	0xea, 0x45, 0xdf, 0xcb, 0xa9, 0x1c, 0x12, 0x93, 0xe8, 0x2d, 0xf1, 0xd0, 0x7f, 0x2f, 0x6d, 0x7d,
-	0x0e, 0x1d, 0xbd, 0x21, 0x40, 0x77, 0x7e, 0xc7, 0xf3, 0xb8, 0x5f, 0xf2, 0x93, 0xc7, 0x3d, 0x83,
-	0xf2, 0x24, 0xa8, 0x42, 0x7f, 0x52, 0x43, 0x50, 0x97, 0x3c, 0x80, 0x95, 0x82, 0xe7, 0x08, 0x8c,
-	0xa0, 0xc3, 0xc7, 0x62, 0xd7, 0xaf, 0x28, 0x88, 0xe3, 0xfa, 0x5e, 0x30, 0xe7, 0xee, 0x91, 0xd2,
-	0xc4, 0xe4, 0x49, 0x30, 0x63, 0xee, 0xf1, 0x08, 0x7d, 0xff, 0x5f, 0x37, 0xae, 0x7c, 0xf7, 0xfe,
-	0x86, 0xf5, 0xfd, 0xfb, 0x1b, 0xd6, 0x7f, 0xbe, 0xbf, 0x61, 0x1d, 0x37, 0xd8, 0xff, 0x16, 0xbd,
-	0xff, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x1f, 0xb4, 0xdd, 0x76, 0x60, 0x3b, 0x00, 0x00,
+var xxx_messageInfo_KVBatchSetResponse proto.InternalMessageInfo
+
+// KVGetRequest kv get request
+type KVGetRequest struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ProphetRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *KVGetRequest) Reset()         { *m = KVGetRequest{} }
+func (m *KVGetRequest) String() string { return proto.CompactTextString(m) }
+func (*KVGetRequest) ProtoMessage()    {}
+func (*KVGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{120}
+}
+func (m *KVGetRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVGetRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *KVGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVGetRequest.Merge(m, src)
+}
+func (m *KVGetRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVGetRequest.DiscardUnknown(m)
 }
 
-func (m *ProphetRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
-	}
-	if m.StoreID != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreID))
-	}
-	if m.Type != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
+var xxx_messageInfo_KVGetRequest proto.InternalMessageInfo
+
+func (m *KVGetRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
 	}
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardHeartbeat.Size()))
-	n1, err := m.ShardHeartbeat.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+// KVGetResponse kv get response
+type KVGetResponse struct {
+	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVGetResponse) Reset()         { *m = KVGetResponse{} }
+func (m *KVGetResponse) String() string { return proto.CompactTextString(m) }
+func (*KVGetResponse) ProtoMessage()    {}
+func (*KVGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{121}
+}
+func (m *KVGetResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVGetResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n1
-	dAtA[i] = 0x2a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreHeartbeat.Size()))
-	n2, err := m.StoreHeartbeat.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *KVGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVGetResponse.Merge(m, src)
+}
+func (m *KVGetResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVGetResponse proto.InternalMessageInfo
+
+func (m *KVGetResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	i += n2
-	dAtA[i] = 0x32
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutStore.Size()))
-	n3, err := m.PutStore.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n3
-	dAtA[i] = 0x3a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetStore.Size()))
-	n4, err := m.GetStore.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+// KVBatchGetRequest kv batch get request
+type KVBatchGetRequest struct {
+	Keys [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Indexes the original BatchGetRequest may be split and executed on multiple
+	// Shards, and this field indicates the location of these Keys in the original
+	// Keys Slice.
+	Indexes              []uint64 `protobuf:"varint,2,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVBatchGetRequest) Reset()         { *m = KVBatchGetRequest{} }
+func (m *KVBatchGetRequest) String() string { return proto.CompactTextString(m) }
+func (*KVBatchGetRequest) ProtoMessage()    {}
+func (*KVBatchGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{122}
+}
+func (m *KVBatchGetRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVBatchGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVBatchGetRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n4
-	dAtA[i] = 0x42
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.AllocID.Size()))
-	n5, err := m.AllocID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *KVBatchGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVBatchGetRequest.Merge(m, src)
+}
+func (m *KVBatchGetRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVBatchGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVBatchGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVBatchGetRequest proto.InternalMessageInfo
+
+func (m *KVBatchGetRequest) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
 	}
-	i += n5
-	dAtA[i] = 0x4a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskBatchSplit.Size()))
-	n6, err := m.AskBatchSplit.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+func (m *KVBatchGetRequest) GetIndexes() []uint64 {
+	if m != nil {
+		return m.Indexes
 	}
-	i += n6
-	dAtA[i] = 0x52
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateDestroying.Size()))
-	n7, err := m.CreateDestroying.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+// KVBatchGetResponse kv batch get response
+type KVBatchGetResponse struct {
+	Values               [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	Indexes              []uint64 `protobuf:"varint,2,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVBatchGetResponse) Reset()         { *m = KVBatchGetResponse{} }
+func (m *KVBatchGetResponse) String() string { return proto.CompactTextString(m) }
+func (*KVBatchGetResponse) ProtoMessage()    {}
+func (*KVBatchGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{123}
+}
+func (m *KVBatchGetResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVBatchGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVBatchGetResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n7
-	dAtA[i] = 0x5a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ReportDestroyed.Size()))
-	n8, err := m.ReportDestroyed.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *KVBatchGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVBatchGetResponse.Merge(m, src)
+}
+func (m *KVBatchGetResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVBatchGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVBatchGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVBatchGetResponse proto.InternalMessageInfo
+
+func (m *KVBatchGetResponse) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
 	}
-	i += n8
-	dAtA[i] = 0x62
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetDestroying.Size()))
-	n9, err := m.GetDestroying.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+func (m *KVBatchGetResponse) GetIndexes() []uint64 {
+	if m != nil {
+		return m.Indexes
 	}
-	i += n9
-	dAtA[i] = 0x6a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateWatcher.Size()))
-	n10, err := m.CreateWatcher.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+// KVDeleteRequest kv Delete request
+type KVDeleteRequest struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVDeleteRequest) Reset()         { *m = KVDeleteRequest{} }
+func (m *KVDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*KVDeleteRequest) ProtoMessage()    {}
+func (*KVDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{124}
+}
+func (m *KVDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVDeleteRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n10
-	dAtA[i] = 0x72
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateShards.Size()))
-	n11, err := m.CreateShards.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *KVDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVDeleteRequest.Merge(m, src)
+}
+func (m *KVDeleteRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVDeleteRequest proto.InternalMessageInfo
+
+func (m *KVDeleteRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
 	}
-	i += n11
-	dAtA[i] = 0x7a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveShards.Size()))
-	n12, err := m.RemoveShards.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+// KVDeleteResponse kv Delete response
+type KVDeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVDeleteResponse) Reset()         { *m = KVDeleteResponse{} }
+func (m *KVDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*KVDeleteResponse) ProtoMessage()    {}
+func (*KVDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{125}
+}
+func (m *KVDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVDeleteResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n12
-	dAtA[i] = 0x82
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckShardState.Size()))
-	n13, err := m.CheckShardState.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n13
-	dAtA[i] = 0x8a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutPlacementRule.Size()))
-	n14, err := m.PutPlacementRule.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n14
-	dAtA[i] = 0x92
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetAppliedRules.Size()))
-	n15, err := m.GetAppliedRules.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n15
-	dAtA[i] = 0x9a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateJob.Size()))
-	n16, err := m.CreateJob.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *KVDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVDeleteResponse.Merge(m, src)
+}
+func (m *KVDeleteResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVDeleteResponse proto.InternalMessageInfo
+
+// KVGetDelRequest kv GetDel request, get the value and delete the key
+type KVGetDelRequest struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVGetDelRequest) Reset()         { *m = KVGetDelRequest{} }
+func (m *KVGetDelRequest) String() string { return proto.CompactTextString(m) }
+func (*KVGetDelRequest) ProtoMessage()    {}
+func (*KVGetDelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{126}
+}
+func (m *KVGetDelRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVGetDelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVGetDelRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n16
-	dAtA[i] = 0xa2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveJob.Size()))
-	n17, err := m.RemoveJob.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *KVGetDelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVGetDelRequest.Merge(m, src)
+}
+func (m *KVGetDelRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVGetDelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVGetDelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVGetDelRequest proto.InternalMessageInfo
+
+func (m *KVGetDelRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
 	}
-	i += n17
-	dAtA[i] = 0xaa
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ExecuteJob.Size()))
-	n18, err := m.ExecuteJob.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+// KVGetDelResponse kv GetDel response
+type KVGetDelResponse struct {
+	// Value the value before the key deleted, empty if the key not exists
+	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVGetDelResponse) Reset()         { *m = KVGetDelResponse{} }
+func (m *KVGetDelResponse) String() string { return proto.CompactTextString(m) }
+func (*KVGetDelResponse) ProtoMessage()    {}
+func (*KVGetDelResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{127}
+}
+func (m *KVGetDelResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVGetDelResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVGetDelResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n18
-	dAtA[i] = 0xb2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.AddScheduleGroupRule.Size()))
-	n19, err := m.AddScheduleGroupRule.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+}
+func (m *KVGetDelResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVGetDelResponse.Merge(m, src)
+}
+func (m *KVGetDelResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVGetDelResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVGetDelResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVGetDelResponse proto.InternalMessageInfo
+
+func (m *KVGetDelResponse) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	i += n19
-	dAtA[i] = 0xba
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetScheduleGroupRule.Size()))
-	n20, err := m.GetScheduleGroupRule.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return nil
+}
+
+// KVDeleteIfRequest kv DeleteIf request, delete the key only if its current
+// value equals the expected value
+type KVDeleteIfRequest struct {
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVDeleteIfRequest) Reset()         { *m = KVDeleteIfRequest{} }
+func (m *KVDeleteIfRequest) String() string { return proto.CompactTextString(m) }
+func (*KVDeleteIfRequest) ProtoMessage()    {}
+func (*KVDeleteIfRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{128}
+}
+func (m *KVDeleteIfRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVDeleteIfRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVDeleteIfRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	i += n20
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (m *KVDeleteIfRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVDeleteIfRequest.Merge(m, src)
+}
+func (m *KVDeleteIfRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVDeleteIfRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVDeleteIfRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVDeleteIfRequest proto.InternalMessageInfo
+
+func (m *KVDeleteIfRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
 	}
-	return i, nil
+	return nil
 }
 
-func (m *ProphetResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *KVDeleteIfRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ProphetResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
+// KVDeleteIfResponse kv DeleteIf response
+type KVDeleteIfResponse struct {
+	// Deleted the key is deleted, false if the key not exists or the value
+	// mismatch
+	Deleted              bool     `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVDeleteIfResponse) Reset()         { *m = KVDeleteIfResponse{} }
+func (m *KVDeleteIfResponse) String() string { return proto.CompactTextString(m) }
+func (*KVDeleteIfResponse) ProtoMessage()    {}
+func (*KVDeleteIfResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{129}
+}
+func (m *KVDeleteIfResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVDeleteIfResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVDeleteIfResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Type != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
+}
+func (m *KVDeleteIfResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVDeleteIfResponse.Merge(m, src)
+}
+func (m *KVDeleteIfResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVDeleteIfResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVDeleteIfResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVDeleteIfResponse proto.InternalMessageInfo
+
+func (m *KVDeleteIfResponse) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
 	}
-	if len(m.Error) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Error)))
-		i += copy(dAtA[i:], m.Error)
+	return false
+}
+
+// KVBatchDeleteRequest kv BatchDelete request
+type KVBatchDeleteRequest struct {
+	Keys                 [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVBatchDeleteRequest) Reset()         { *m = KVBatchDeleteRequest{} }
+func (m *KVBatchDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*KVBatchDeleteRequest) ProtoMessage()    {}
+func (*KVBatchDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{130}
+}
+func (m *KVBatchDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVBatchDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVBatchDeleteRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if len(m.Leader) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Leader)))
-		i += copy(dAtA[i:], m.Leader)
-	}
-	dAtA[i] = 0x2a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardHeartbeat.Size()))
-	n21, err := m.ShardHeartbeat.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n21
-	dAtA[i] = 0x32
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreHeartbeat.Size()))
-	n22, err := m.StoreHeartbeat.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n22
-	dAtA[i] = 0x3a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutStore.Size()))
-	n23, err := m.PutStore.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n23
-	dAtA[i] = 0x42
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetStore.Size()))
-	n24, err := m.GetStore.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n24
-	dAtA[i] = 0x4a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.AllocID.Size()))
-	n25, err := m.AllocID.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n25
-	dAtA[i] = 0x52
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskBatchSplit.Size()))
-	n26, err := m.AskBatchSplit.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n26
-	dAtA[i] = 0x5a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateDestroying.Size()))
-	n27, err := m.CreateDestroying.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n27
-	dAtA[i] = 0x62
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ReportDestroyed.Size()))
-	n28, err := m.ReportDestroyed.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n28
-	dAtA[i] = 0x6a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetDestroying.Size()))
-	n29, err := m.GetDestroying.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n29
-	dAtA[i] = 0x72
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Event.Size()))
-	n30, err := m.Event.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n30
-	dAtA[i] = 0x7a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateShards.Size()))
-	n31, err := m.CreateShards.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n31
-	dAtA[i] = 0x82
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveShards.Size()))
-	n32, err := m.RemoveShards.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n32
-	dAtA[i] = 0x8a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckShardState.Size()))
-	n33, err := m.CheckShardState.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n33
-	dAtA[i] = 0x92
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutPlacementRule.Size()))
-	n34, err := m.PutPlacementRule.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n34
-	dAtA[i] = 0x9a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetAppliedRules.Size()))
-	n35, err := m.GetAppliedRules.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n35
-	dAtA[i] = 0xa2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateJob.Size()))
-	n36, err := m.CreateJob.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n36
-	dAtA[i] = 0xaa
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveJob.Size()))
-	n37, err := m.RemoveJob.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n37
-	dAtA[i] = 0xb2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ExecuteJob.Size()))
-	n38, err := m.ExecuteJob.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n38
-	dAtA[i] = 0xba
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.AddScheduleGroupRule.Size()))
-	n39, err := m.AddScheduleGroupRule.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n39
-	dAtA[i] = 0xc2
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetScheduleGroupRule.Size()))
-	n40, err := m.GetScheduleGroupRule.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n40
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+}
+func (m *KVBatchDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVBatchDeleteRequest.Merge(m, src)
+}
+func (m *KVBatchDeleteRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVBatchDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVBatchDeleteRequest.DiscardUnknown(m)
 }
 
-func (m *ShardHeartbeatReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_KVBatchDeleteRequest proto.InternalMessageInfo
+
+func (m *KVBatchDeleteRequest) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ShardHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.StoreID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreID))
-	}
-	if len(m.Shard) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Shard)))
-		i += copy(dAtA[i:], m.Shard)
-	}
-	if m.Term != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Term))
-	}
-	if m.Leader != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Leader.Size()))
-		n41, err := m.Leader.MarshalTo(dAtA[i:])
+// KVBatchDeleteResponse kv BatchDelete response
+type KVBatchDeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVBatchDeleteResponse) Reset()         { *m = KVBatchDeleteResponse{} }
+func (m *KVBatchDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*KVBatchDeleteResponse) ProtoMessage()    {}
+func (*KVBatchDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{131}
+}
+func (m *KVBatchDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVBatchDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVBatchDeleteResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
-		}
-		i += n41
-	}
-	if len(m.DownReplicas) > 0 {
-		for _, msg := range m.DownReplicas {
-			dAtA[i] = 0x2a
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.PendingReplicas) > 0 {
-		for _, msg := range m.PendingReplicas {
-			dAtA[i] = 0x32
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	dAtA[i] = 0x3a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
-	n42, err := m.Stats.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n42
-	if len(m.GroupKey) > 0 {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.GroupKey)))
-		i += copy(dAtA[i:], m.GroupKey)
-	}
-	if m.Lease != nil {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-		n43, err := m.Lease.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n43
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+}
+func (m *KVBatchDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVBatchDeleteResponse.Merge(m, src)
+}
+func (m *KVBatchDeleteResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVBatchDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVBatchDeleteResponse.DiscardUnknown(m)
 }
 
-func (m *ShardHeartbeatRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+var xxx_messageInfo_KVBatchDeleteResponse proto.InternalMessageInfo
+
+// KVRangeDeleteRequest kv RangeDelete request
+type KVRangeDeleteRequest struct {
+	// Start include
+	Start []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// End exclude
+	End                  []byte   `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ShardHeartbeatRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ShardID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardID))
-	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardEpoch.Size()))
-	n44, err := m.ShardEpoch.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n44
-	if m.TargetReplica != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.TargetReplica.Size()))
-		n45, err := m.TargetReplica.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n45
-	}
-	if m.ConfigChange != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ConfigChange.Size()))
-		n46, err := m.ConfigChange.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n46
-	}
-	if m.TransferLeader != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.TransferLeader.Size()))
-		n47, err := m.TransferLeader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n47
-	}
-	if m.Merge != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Merge.Size()))
-		n48, err := m.Merge.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n48
-	}
-	if m.SplitShard != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.SplitShard.Size()))
-		n49, err := m.SplitShard.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n49
-	}
-	if m.ConfigChangeV2 != nil {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ConfigChangeV2.Size()))
-		n50, err := m.ConfigChangeV2.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n50
-	}
-	if m.TransferLease != nil {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.TransferLease.Size()))
-		n51, err := m.TransferLease.MarshalTo(dAtA[i:])
+func (m *KVRangeDeleteRequest) Reset()         { *m = KVRangeDeleteRequest{} }
+func (m *KVRangeDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*KVRangeDeleteRequest) ProtoMessage()    {}
+func (*KVRangeDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{132}
+}
+func (m *KVRangeDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVRangeDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVRangeDeleteRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
-		}
-		i += n51
-	}
-	if m.DestroyDirectly {
-		dAtA[i] = 0x50
-		i++
-		if m.DestroyDirectly {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+			return nil, err
 		}
-		i++
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+		return b[:n], nil
 	}
-	return i, nil
 }
-
-func (m *PutStoreReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *KVRangeDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVRangeDeleteRequest.Merge(m, src)
+}
+func (m *KVRangeDeleteRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVRangeDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVRangeDeleteRequest.DiscardUnknown(m)
 }
 
-func (m *PutStoreReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Store) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Store)))
-		i += copy(dAtA[i:], m.Store)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+var xxx_messageInfo_KVRangeDeleteRequest proto.InternalMessageInfo
+
+func (m *KVRangeDeleteRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
 	}
-	return i, nil
+	return nil
 }
 
-func (m *PutStoreRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *KVRangeDeleteRequest) GetEnd() []byte {
+	if m != nil {
+		return m.End
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PutStoreRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.DestroyShards) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.DestroyShards)))
-		i += copy(dAtA[i:], m.DestroyShards)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+// KVRangeDeleteResponse kv RangeDelete response
+type KVRangeDeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StoreHeartbeatReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *KVRangeDeleteResponse) Reset()         { *m = KVRangeDeleteResponse{} }
+func (m *KVRangeDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*KVRangeDeleteResponse) ProtoMessage()    {}
+func (*KVRangeDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{133}
+}
+func (m *KVRangeDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVRangeDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVRangeDeleteResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *StoreHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
-	n52, err := m.Stats.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n52
-	if len(m.Data) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+func (m *KVRangeDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVRangeDeleteResponse.Merge(m, src)
+}
+func (m *KVRangeDeleteResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVRangeDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVRangeDeleteResponse.DiscardUnknown(m)
 }
 
-func (m *StoreHeartbeatRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+var xxx_messageInfo_KVRangeDeleteResponse proto.InternalMessageInfo
+
+// KVScanRequest kv scan request
+type KVScanRequest struct {
+	// Start include, not set means min key
+	Start []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// End exclude, not set means max key
+	End []byte `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// Limit maximum count of scanned data
+	Limit uint64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// LimitBytes maximum count of scanned data
+	LimitBytes uint64 `protobuf:"varint,4,opt,name=limitBytes,proto3" json:"limitBytes,omitempty"`
+	// WithValue return the value
+	WithValue bool `protobuf:"varint,5,opt,name=withValue,proto3" json:"withValue,omitempty"`
+	// OnlyCount only returns count
+	OnlyCount            bool     `protobuf:"varint,6,opt,name=onlyCount,proto3" json:"onlyCount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StoreHeartbeatRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
+func (m *KVScanRequest) Reset()         { *m = KVScanRequest{} }
+func (m *KVScanRequest) String() string { return proto.CompactTextString(m) }
+func (*KVScanRequest) ProtoMessage()    {}
+func (*KVScanRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{134}
+}
+func (m *KVScanRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVScanRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVScanRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (m *KVScanRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVScanRequest.Merge(m, src)
+}
+func (m *KVScanRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVScanRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVScanRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVScanRequest proto.InternalMessageInfo
+
+func (m *KVScanRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
 	}
-	return i, nil
+	return nil
 }
 
-func (m *GetStoreReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *KVScanRequest) GetEnd() []byte {
+	if m != nil {
+		return m.End
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *GetStoreReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
+func (m *KVScanRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return 0
+}
+
+func (m *KVScanRequest) GetLimitBytes() uint64 {
+	if m != nil {
+		return m.LimitBytes
 	}
-	return i, nil
+	return 0
 }
 
-func (m *GetStoreRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *KVScanRequest) GetWithValue() bool {
+	if m != nil {
+		return m.WithValue
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *GetStoreRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
+func (m *KVScanRequest) GetOnlyCount() bool {
+	if m != nil {
+		return m.OnlyCount
 	}
-	if m.Stats != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
-		n53, err := m.Stats.MarshalTo(dAtA[i:])
+	return false
+}
+
+// KVScanResponse kv scan response
+type KVScanResponse struct {
+	// Keys scan keys result
+	Keys [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Values scan values result
+	Values [][]byte `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// Count scan count result
+	Count uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// Completed true if no data in current shard
+	Completed bool `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	// ShardEnd shard end key
+	ShardEnd             []byte   `protobuf:"bytes,5,opt,name=shardEnd,proto3" json:"shardEnd,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVScanResponse) Reset()         { *m = KVScanResponse{} }
+func (m *KVScanResponse) String() string { return proto.CompactTextString(m) }
+func (*KVScanResponse) ProtoMessage()    {}
+func (*KVScanResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{135}
+}
+func (m *KVScanResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVScanResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVScanResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n53
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (m *KVScanResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVScanResponse.Merge(m, src)
+}
+func (m *KVScanResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVScanResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVScanResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVScanResponse proto.InternalMessageInfo
+
+func (m *KVScanResponse) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
 	}
-	return i, nil
+	return nil
 }
 
-func (m *AllocIDReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *KVScanResponse) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AllocIDReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *KVScanResponse) GetCount() uint64 {
+	if m != nil {
+		return m.Count
 	}
-	return i, nil
+	return 0
 }
 
-func (m *AllocIDRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *KVScanResponse) GetCompleted() bool {
+	if m != nil {
+		return m.Completed
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *AllocIDRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *KVScanResponse) GetShardEnd() []byte {
+	if m != nil {
+		return m.ShardEnd
 	}
-	return i, nil
+	return nil
 }
 
-func (m *AskBatchSplitReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// KVBatchMixedWriteRequest kv batch MixedWrite requests
+type KVBatchMixedWriteRequest struct {
+	Requests             []KVMixedWriteRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *AskBatchSplitReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
-	}
-	if m.Count != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Count))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *KVBatchMixedWriteRequest) Reset()         { *m = KVBatchMixedWriteRequest{} }
+func (m *KVBatchMixedWriteRequest) String() string { return proto.CompactTextString(m) }
+func (*KVBatchMixedWriteRequest) ProtoMessage()    {}
+func (*KVBatchMixedWriteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{136}
+}
+func (m *KVBatchMixedWriteRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVBatchMixedWriteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVBatchMixedWriteRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *KVBatchMixedWriteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVBatchMixedWriteRequest.Merge(m, src)
+}
+func (m *KVBatchMixedWriteRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVBatchMixedWriteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVBatchMixedWriteRequest.DiscardUnknown(m)
 }
 
-func (m *AskBatchSplitRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_KVBatchMixedWriteRequest proto.InternalMessageInfo
+
+func (m *KVBatchMixedWriteRequest) GetRequests() []KVMixedWriteRequest {
+	if m != nil {
+		return m.Requests
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *AskBatchSplitRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.SplitIDs) > 0 {
-		for _, msg := range m.SplitIDs {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+// KVBatchMixedWriteResponse kv batch MixedWrite responses
+type KVBatchMixedWriteResponse struct {
+	Responses            []KVMixedWriteResponse `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *KVBatchMixedWriteResponse) Reset()         { *m = KVBatchMixedWriteResponse{} }
+func (m *KVBatchMixedWriteResponse) String() string { return proto.CompactTextString(m) }
+func (*KVBatchMixedWriteResponse) ProtoMessage()    {}
+func (*KVBatchMixedWriteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{137}
+}
+func (m *KVBatchMixedWriteResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVBatchMixedWriteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVBatchMixedWriteResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
+}
+func (m *KVBatchMixedWriteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVBatchMixedWriteResponse.Merge(m, src)
+}
+func (m *KVBatchMixedWriteResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVBatchMixedWriteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVBatchMixedWriteResponse.DiscardUnknown(m)
 }
 
-func (m *CreateDestroyingReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_KVBatchMixedWriteResponse proto.InternalMessageInfo
+
+func (m *KVBatchMixedWriteResponse) GetResponses() []KVMixedWriteResponse {
+	if m != nil {
+		return m.Responses
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *CreateDestroyingReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
-	}
-	if m.Index != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Index))
-	}
-	if len(m.Replicas) > 0 {
-		dAtA55 := make([]byte, len(m.Replicas)*10)
-		var j54 int
-		for _, num := range m.Replicas {
-			for num >= 1<<7 {
-				dAtA55[j54] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j54++
-			}
-			dAtA55[j54] = uint8(num)
-			j54++
+// KVMixedWriteRequest mixed requests
+type KVMixedWriteRequest struct {
+	CmdType              uint64               `protobuf:"varint,1,opt,name=cmdType,proto3" json:"cmdType,omitempty"`
+	Set                  KVSetRequest         `protobuf:"bytes,2,opt,name=set,proto3" json:"set"`
+	Delete               KVDeleteRequest      `protobuf:"bytes,3,opt,name=delete,proto3" json:"delete"`
+	RangeDelete          KVRangeDeleteRequest `protobuf:"bytes,4,opt,name=rangeDelete,proto3" json:"rangeDelete"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *KVMixedWriteRequest) Reset()         { *m = KVMixedWriteRequest{} }
+func (m *KVMixedWriteRequest) String() string { return proto.CompactTextString(m) }
+func (*KVMixedWriteRequest) ProtoMessage()    {}
+func (*KVMixedWriteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{138}
+}
+func (m *KVMixedWriteRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVMixedWriteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVMixedWriteRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j54))
-		i += copy(dAtA[i:], dAtA55[:j54])
+		return b[:n], nil
 	}
-	if m.RemoveData {
-		dAtA[i] = 0x20
-		i++
-		if m.RemoveData {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+}
+func (m *KVMixedWriteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVMixedWriteRequest.Merge(m, src)
+}
+func (m *KVMixedWriteRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVMixedWriteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVMixedWriteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVMixedWriteRequest proto.InternalMessageInfo
+
+func (m *KVMixedWriteRequest) GetCmdType() uint64 {
+	if m != nil {
+		return m.CmdType
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return 0
+}
+
+func (m *KVMixedWriteRequest) GetSet() KVSetRequest {
+	if m != nil {
+		return m.Set
 	}
-	return i, nil
+	return KVSetRequest{}
 }
 
-func (m *CreateDestroyingRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *KVMixedWriteRequest) GetDelete() KVDeleteRequest {
+	if m != nil {
+		return m.Delete
 	}
-	return dAtA[:n], nil
+	return KVDeleteRequest{}
 }
 
-func (m *CreateDestroyingRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.State != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.State))
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+func (m *KVMixedWriteRequest) GetRangeDelete() KVRangeDeleteRequest {
+	if m != nil {
+		return m.RangeDelete
 	}
-	return i, nil
+	return KVRangeDeleteRequest{}
 }
 
-func (m *GetDestroyingReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// KVMixedWriteResponse mixed response
+type KVMixedWriteResponse struct {
+	CmdType              uint64               `protobuf:"varint,1,opt,name=cmdType,proto3" json:"cmdType,omitempty"`
+	Set                  KVSetRequest         `protobuf:"bytes,2,opt,name=set,proto3" json:"set"`
+	Delete               KVDeleteRequest      `protobuf:"bytes,3,opt,name=delete,proto3" json:"delete"`
+	RangeDelete          KVRangeDeleteRequest `protobuf:"bytes,4,opt,name=rangeDelete,proto3" json:"rangeDelete"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *GetDestroyingReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.ID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
+func (m *KVMixedWriteResponse) Reset()         { *m = KVMixedWriteResponse{} }
+func (m *KVMixedWriteResponse) String() string { return proto.CompactTextString(m) }
+func (*KVMixedWriteResponse) ProtoMessage()    {}
+func (*KVMixedWriteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_25e491924c678914, []int{139}
+}
+func (m *KVMixedWriteResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KVMixedWriteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KVMixedWriteResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+}
+func (m *KVMixedWriteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVMixedWriteResponse.Merge(m, src)
+}
+func (m *KVMixedWriteResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *KVMixedWriteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVMixedWriteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVMixedWriteResponse proto.InternalMessageInfo
+
+func (m *KVMixedWriteResponse) GetCmdType() uint64 {
+	if m != nil {
+		return m.CmdType
 	}
-	return i, nil
+	return 0
 }
 
-func (m *GetDestroyingRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *KVMixedWriteResponse) GetSet() KVSetRequest {
+	if m != nil {
+		return m.Set
 	}
-	return dAtA[:n], nil
+	return KVSetRequest{}
 }
 
-func (m *GetDestroyingRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Status != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Status.Size()))
-		n56, err := m.Status.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n56
+func (m *KVMixedWriteResponse) GetDelete() KVDeleteRequest {
+	if m != nil {
+		return m.Delete
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	return KVDeleteRequest{}
+}
+
+func (m *KVMixedWriteResponse) GetRangeDelete() KVRangeDeleteRequest {
+	if m != nil {
+		return m.RangeDelete
 	}
-	return i, nil
+	return KVRangeDeleteRequest{}
 }
 
-func (m *ReportDestroyedReq) Marshal() (dAtA []byte, err error) {
+func init() {
+	proto.RegisterEnum("rpcpb.Type", Type_name, Type_value)
+	proto.RegisterEnum("rpcpb.ReplicaRoleType", ReplicaRoleType_name, ReplicaRoleType_value)
+	proto.RegisterEnum("rpcpb.LabelConstraintOp", LabelConstraintOp_name, LabelConstraintOp_value)
+	proto.RegisterEnum("rpcpb.CmdType", CmdType_name, CmdType_value)
+	proto.RegisterEnum("rpcpb.InternalCmd", InternalCmd_name, InternalCmd_value)
+	proto.RegisterEnum("rpcpb.RequestPriority", RequestPriority_name, RequestPriority_value)
+	proto.RegisterEnum("rpcpb.UpdatePolicy", UpdatePolicy_name, UpdatePolicy_value)
+	proto.RegisterEnum("rpcpb.ReplicaSelectPolicy", ReplicaSelectPolicy_name, ReplicaSelectPolicy_value)
+	proto.RegisterType((*ProphetRequest)(nil), "rpcpb.ProphetRequest")
+	proto.RegisterType((*ProphetResponse)(nil), "rpcpb.ProphetResponse")
+	proto.RegisterType((*ShardHeartbeatReq)(nil), "rpcpb.ShardHeartbeatReq")
+	proto.RegisterType((*ShardHeartbeatRsp)(nil), "rpcpb.ShardHeartbeatRsp")
+	proto.RegisterType((*PutStoreReq)(nil), "rpcpb.PutStoreReq")
+	proto.RegisterType((*PutStoreRsp)(nil), "rpcpb.PutStoreRsp")
+	proto.RegisterType((*StoreHeartbeatReq)(nil), "rpcpb.StoreHeartbeatReq")
+	proto.RegisterType((*StoreHeartbeatRsp)(nil), "rpcpb.StoreHeartbeatRsp")
+	proto.RegisterType((*GetStoreReq)(nil), "rpcpb.GetStoreReq")
+	proto.RegisterType((*GetStoreRsp)(nil), "rpcpb.GetStoreRsp")
+	proto.RegisterType((*AllocIDReq)(nil), "rpcpb.AllocIDReq")
+	proto.RegisterType((*AllocIDRsp)(nil), "rpcpb.AllocIDRsp")
+	proto.RegisterType((*AskBatchSplitReq)(nil), "rpcpb.AskBatchSplitReq")
+	proto.RegisterType((*AskBatchSplitRsp)(nil), "rpcpb.AskBatchSplitRsp")
+	proto.RegisterType((*CreateDestroyingReq)(nil), "rpcpb.CreateDestroyingReq")
+	proto.RegisterType((*CreateDestroyingRsp)(nil), "rpcpb.CreateDestroyingRsp")
+	proto.RegisterType((*GetDestroyingReq)(nil), "rpcpb.GetDestroyingReq")
+	proto.RegisterType((*GetDestroyingRsp)(nil), "rpcpb.GetDestroyingRsp")
+	proto.RegisterType((*ReportDestroyedReq)(nil), "rpcpb.ReportDestroyedReq")
+	proto.RegisterType((*ReportDestroyedRsp)(nil), "rpcpb.ReportDestroyedRsp")
+	proto.RegisterType((*SplitID)(nil), "rpcpb.SplitID")
+	proto.RegisterType((*CreateWatcherReq)(nil), "rpcpb.CreateWatcherReq")
+	proto.RegisterType((*CreateShardsReq)(nil), "rpcpb.CreateShardsReq")
+	proto.RegisterType((*CreateShardsRsp)(nil), "rpcpb.CreateShardsRsp")
+	proto.RegisterType((*RemoveShardsReq)(nil), "rpcpb.RemoveShardsReq")
+	proto.RegisterType((*RemoveShardsRsp)(nil), "rpcpb.RemoveShardsRsp")
+	proto.RegisterType((*CheckShardStateReq)(nil), "rpcpb.CheckShardStateReq")
+	proto.RegisterType((*CheckShardStateRsp)(nil), "rpcpb.CheckShardStateRsp")
+	proto.RegisterType((*TombstoneReplica)(nil), "rpcpb.TombstoneReplica")
+	proto.RegisterType((*CheckTombstoneReplicasReq)(nil), "rpcpb.CheckTombstoneReplicasReq")
+	proto.RegisterType((*CheckTombstoneReplicasRsp)(nil), "rpcpb.CheckTombstoneReplicasRsp")
+	proto.RegisterType((*PutPlacementRuleReq)(nil), "rpcpb.PutPlacementRuleReq")
+	proto.RegisterType((*PutPlacementRuleRsp)(nil), "rpcpb.PutPlacementRuleRsp")
+	proto.RegisterType((*GetAppliedRulesReq)(nil), "rpcpb.GetAppliedRulesReq")
+	proto.RegisterType((*GetAppliedRulesRsp)(nil), "rpcpb.GetAppliedRulesRsp")
+	proto.RegisterType((*CreateJobReq)(nil), "rpcpb.CreateJobReq")
+	proto.RegisterType((*CreateJobRsp)(nil), "rpcpb.CreateJobRsp")
+	proto.RegisterType((*RemoveJobReq)(nil), "rpcpb.RemoveJobReq")
+	proto.RegisterType((*RemoveJobRsp)(nil), "rpcpb.RemoveJobRsp")
+	proto.RegisterType((*ExecuteJobReq)(nil), "rpcpb.ExecuteJobReq")
+	proto.RegisterType((*ExecuteJobRsp)(nil), "rpcpb.ExecuteJobRsp")
+	proto.RegisterType((*AddScheduleGroupRuleReq)(nil), "rpcpb.AddScheduleGroupRuleReq")
+	proto.RegisterType((*AddScheduleGroupRuleRsp)(nil), "rpcpb.AddScheduleGroupRuleRsp")
+	proto.RegisterType((*GetScheduleGroupRuleReq)(nil), "rpcpb.GetScheduleGroupRuleReq")
+	proto.RegisterType((*GetScheduleGroupRuleRsp)(nil), "rpcpb.GetScheduleGroupRuleRsp")
+	proto.RegisterType((*PutClusterSettingReq)(nil), "rpcpb.PutClusterSettingReq")
+	proto.RegisterType((*PutClusterSettingRsp)(nil), "rpcpb.PutClusterSettingRsp")
+	proto.RegisterType((*GetClusterSettingsReq)(nil), "rpcpb.GetClusterSettingsReq")
+	proto.RegisterType((*GetClusterSettingsRsp)(nil), "rpcpb.GetClusterSettingsRsp")
+	proto.RegisterType((*ScatterShardsReq)(nil), "rpcpb.ScatterShardsReq")
+	proto.RegisterType((*ScatterShardsRsp)(nil), "rpcpb.ScatterShardsRsp")
+	proto.RegisterType((*EventNotify)(nil), "rpcpb.EventNotify")
+	proto.RegisterType((*InitEventData)(nil), "rpcpb.InitEventData")
+	proto.RegisterType((*ShardEventData)(nil), "rpcpb.ShardEventData")
+	proto.RegisterType((*StoreEventData)(nil), "rpcpb.StoreEventData")
+	proto.RegisterType((*ConfigChange)(nil), "rpcpb.ConfigChange")
+	proto.RegisterType((*TransferLeader)(nil), "rpcpb.TransferLeader")
+	proto.RegisterType((*TransferLease)(nil), "rpcpb.TransferLease")
+	proto.RegisterType((*ConfigChangeV2)(nil), "rpcpb.ConfigChangeV2")
+	proto.RegisterType((*Merge)(nil), "rpcpb.Merge")
+	proto.RegisterType((*SplitShard)(nil), "rpcpb.SplitShard")
+	proto.RegisterType((*LabelConstraint)(nil), "rpcpb.LabelConstraint")
+	proto.RegisterType((*PlacementRule)(nil), "rpcpb.PlacementRule")
+	proto.RegisterType((*RequestBatchHeader)(nil), "rpcpb.RequestBatchHeader")
+	proto.RegisterType((*ResponseBatchHeader)(nil), "rpcpb.ResponseBatchHeader")
+	proto.RegisterType((*RequestBatch)(nil), "rpcpb.RequestBatch")
+	proto.RegisterType((*ProposalChunk)(nil), "rpcpb.ProposalChunk")
+	proto.RegisterType((*ResponseBatch)(nil), "rpcpb.ResponseBatch")
+	proto.RegisterType((*Request)(nil), "rpcpb.Request")
+	proto.RegisterType((*Range)(nil), "rpcpb.Range")
+	proto.RegisterType((*Response)(nil), "rpcpb.Response")
+	proto.RegisterType((*ConfigChangeRequest)(nil), "rpcpb.ConfigChangeRequest")
+	proto.RegisterType((*ConfigChangeResponse)(nil), "rpcpb.ConfigChangeResponse")
+	proto.RegisterType((*CompactLogRequest)(nil), "rpcpb.CompactLogRequest")
+	proto.RegisterType((*CompactLogResponse)(nil), "rpcpb.CompactLogResponse")
+	proto.RegisterType((*TransferLeaderRequest)(nil), "rpcpb.TransferLeaderRequest")
+	proto.RegisterType((*TransferLeaderResponse)(nil), "rpcpb.TransferLeaderResponse")
+	proto.RegisterType((*BatchSplitRequest)(nil), "rpcpb.BatchSplitRequest")
+	proto.RegisterType((*SplitRequest)(nil), "rpcpb.SplitRequest")
+	proto.RegisterType((*BatchSplitResponse)(nil), "rpcpb.BatchSplitResponse")
+	proto.RegisterType((*UpdateMetadataRequest)(nil), "rpcpb.UpdateMetadataRequest")
+	proto.RegisterType((*UpdateMetadataResponse)(nil), "rpcpb.UpdateMetadataResponse")
+	proto.RegisterType((*UpdateLabelsRequest)(nil), "rpcpb.UpdateLabelsRequest")
+	proto.RegisterType((*UpdateLabelsResponse)(nil), "rpcpb.UpdateLabelsResponse")
+	proto.RegisterType((*UpdateGateRequest)(nil), "rpcpb.UpdateGateRequest")
+	proto.RegisterType((*UpdateGateResponse)(nil), "rpcpb.UpdateGateResponse")
+	proto.RegisterType((*AcquireAppLeaseRequest)(nil), "rpcpb.AcquireAppLeaseRequest")
+	proto.RegisterType((*AcquireAppLeaseResponse)(nil), "rpcpb.AcquireAppLeaseResponse")
+	proto.RegisterType((*ReleaseAppLeaseRequest)(nil), "rpcpb.ReleaseAppLeaseRequest")
+	proto.RegisterType((*ReleaseAppLeaseResponse)(nil), "rpcpb.ReleaseAppLeaseResponse")
+	proto.RegisterType((*SplitShardRequest)(nil), "rpcpb.SplitShardRequest")
+	proto.RegisterType((*SplitShardResponse)(nil), "rpcpb.SplitShardResponse")
+	proto.RegisterType((*DeleteRangeRequest)(nil), "rpcpb.DeleteRangeRequest")
+	proto.RegisterType((*DeleteRangeResponse)(nil), "rpcpb.DeleteRangeResponse")
+	proto.RegisterType((*BarrierRequest)(nil), "rpcpb.BarrierRequest")
+	proto.RegisterType((*BarrierResponse)(nil), "rpcpb.BarrierResponse")
+	proto.RegisterType((*ComputeHashRequest)(nil), "rpcpb.ComputeHashRequest")
+	proto.RegisterType((*ComputeHashResponse)(nil), "rpcpb.ComputeHashResponse")
+	proto.RegisterType((*VerifyHashRequest)(nil), "rpcpb.VerifyHashRequest")
+	proto.RegisterType((*VerifyHashResponse)(nil), "rpcpb.VerifyHashResponse")
+	proto.RegisterType((*PurgeRequest)(nil), "rpcpb.PurgeRequest")
+	proto.RegisterType((*PurgeResponse)(nil), "rpcpb.PurgeResponse")
+	proto.RegisterType((*UpdateDictionaryRequest)(nil), "rpcpb.UpdateDictionaryRequest")
+	proto.RegisterType((*UpdateDictionaryResponse)(nil), "rpcpb.UpdateDictionaryResponse")
+	proto.RegisterType((*UpdateEpochLeaseRequest)(nil), "rpcpb.UpdateEpochLeaseRequest")
+	proto.RegisterType((*UpdateEpochLeaseResponse)(nil), "rpcpb.UpdateEpochLeaseResponse")
+	proto.RegisterType((*UpdateTxnRecordRequest)(nil), "rpcpb.UpdateTxnRecordRequest")
+	proto.RegisterType((*UpdateTxnRecordResponse)(nil), "rpcpb.UpdateTxnRecordResponse")
+	proto.RegisterType((*DeleteTxnRecordRequest)(nil), "rpcpb.DeleteTxnRecordRequest")
+	proto.RegisterType((*DeleteTxnRecordResponse)(nil), "rpcpb.DeleteTxnRecordResponse")
+	proto.RegisterType((*CommitTxnWriteDataRequest)(nil), "rpcpb.CommitTxnWriteDataRequest")
+	proto.RegisterType((*CommitTxnWriteDataResponse)(nil), "rpcpb.CommitTxnWriteDataResponse")
+	proto.RegisterType((*RollbackTxnWriteDataRequest)(nil), "rpcpb.RollbackTxnWriteDataRequest")
+	proto.RegisterType((*RollbackTxnWriteDataResponse)(nil), "rpcpb.RollbackTxnWriteDataResponse")
+	proto.RegisterType((*CleanTxnMVCCDataRequest)(nil), "rpcpb.CleanTxnMVCCDataRequest")
+	proto.RegisterType((*CleanTxnMVCCDataResponse)(nil), "rpcpb.CleanTxnMVCCDataResponse")
+	proto.RegisterType((*KVSetRequest)(nil), "rpcpb.KVSetRequest")
+	proto.RegisterType((*KVSetResponse)(nil), "rpcpb.KVSetResponse")
+	proto.RegisterType((*KVBatchSetRequest)(nil), "rpcpb.KVBatchSetRequest")
+	proto.RegisterType((*KVBatchSetResponse)(nil), "rpcpb.KVBatchSetResponse")
+	proto.RegisterType((*KVGetRequest)(nil), "rpcpb.KVGetRequest")
+	proto.RegisterType((*KVGetResponse)(nil), "rpcpb.KVGetResponse")
+	proto.RegisterType((*KVBatchGetRequest)(nil), "rpcpb.KVBatchGetRequest")
+	proto.RegisterType((*KVBatchGetResponse)(nil), "rpcpb.KVBatchGetResponse")
+	proto.RegisterType((*KVDeleteRequest)(nil), "rpcpb.KVDeleteRequest")
+	proto.RegisterType((*KVDeleteResponse)(nil), "rpcpb.KVDeleteResponse")
+	proto.RegisterType((*KVGetDelRequest)(nil), "rpcpb.KVGetDelRequest")
+	proto.RegisterType((*KVGetDelResponse)(nil), "rpcpb.KVGetDelResponse")
+	proto.RegisterType((*KVDeleteIfRequest)(nil), "rpcpb.KVDeleteIfRequest")
+	proto.RegisterType((*KVDeleteIfResponse)(nil), "rpcpb.KVDeleteIfResponse")
+	proto.RegisterType((*KVBatchDeleteRequest)(nil), "rpcpb.KVBatchDeleteRequest")
+	proto.RegisterType((*KVBatchDeleteResponse)(nil), "rpcpb.KVBatchDeleteResponse")
+	proto.RegisterType((*KVRangeDeleteRequest)(nil), "rpcpb.KVRangeDeleteRequest")
+	proto.RegisterType((*KVRangeDeleteResponse)(nil), "rpcpb.KVRangeDeleteResponse")
+	proto.RegisterType((*KVScanRequest)(nil), "rpcpb.KVScanRequest")
+	proto.RegisterType((*KVScanResponse)(nil), "rpcpb.KVScanResponse")
+	proto.RegisterType((*KVBatchMixedWriteRequest)(nil), "rpcpb.KVBatchMixedWriteRequest")
+	proto.RegisterType((*KVBatchMixedWriteResponse)(nil), "rpcpb.KVBatchMixedWriteResponse")
+	proto.RegisterType((*KVMixedWriteRequest)(nil), "rpcpb.KVMixedWriteRequest")
+	proto.RegisterType((*KVMixedWriteResponse)(nil), "rpcpb.KVMixedWriteResponse")
+}
+
+func init() { proto.RegisterFile("rpcpb.proto", fileDescriptor_25e491924c678914) }
+
+var fileDescriptor_25e491924c678914 = []byte{
+	// 5325 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x7c, 0x49, 0x73, 0x1c, 0x47,
+	0x76, 0x30, 0x7b, 0x03, 0xba, 0x1f, 0x7a, 0xc9, 0x4e, 0x34, 0x81, 0x02, 0x48, 0x91, 0x9c, 0xd2,
+	0xc6, 0x01, 0x35, 0xe0, 0x27, 0x52, 0x1a, 0x4a, 0x1a, 0x8d, 0x24, 0xb2, 0x41, 0x81, 0xe0, 0x8a,
+	0xaf, 0x00, 0x43, 0xe3, 0xb0, 0xec, 0x88, 0x42, 0x77, 0x02, 0x68, 0xb3, 0xbb, 0xaa, 0x54, 0x55,
+	0x4d, 0x02, 0x3e, 0xd8, 0x07, 0x5f, 0xed, 0xb0, 0xc3, 0x17, 0xdf, 0x7c, 0xb7, 0xaf, 0xfe, 0x01,
+	0x3e, 0x39, 0x42, 0x33, 0xde, 0x64, 0x5f, 0xc6, 0x27, 0x85, 0xad, 0x83, 0xc3, 0x7f, 0xc0, 0x77,
+	0x47, 0x6e, 0x95, 0x99, 0xb5, 0x34, 0x1a, 0x73, 0xf3, 0x85, 0xa8, 0x7c, 0x5b, 0xbe, 0x5c, 0xde,
+	0x7b, 0xf9, 0x5e, 0x66, 0x13, 0x96, 0xc2, 0x60, 0x10, 0x1c, 0x6e, 0x06, 0xa1, 0x1f, 0xfb, 0xb8,
+	0xc6, 0x1a, 0xeb, 0x3f, 0x3b, 0x1e, 0xc5, 0x27, 0xd3, 0xc3, 0xcd, 0x81, 0x3f, 0xb9, 0x3d, 0x71,
+	0xe3, 0x70, 0x74, 0xea, 0x87, 0xa3, 0xe3, 0x91, 0x27, 0x1a, 0x83, 0xe9, 0x21, 0xb9, 0x1d, 0x1c,
+	0xde, 0x26, 0x61, 0xe8, 0x87, 0xea, 0x2f, 0x97, 0xb1, 0xfe, 0xf1, 0x7c, 0xcc, 0x13, 0x12, 0xbb,
+	0xc9, 0x1f, 0xc1, 0x7a, 0x6f, 0x3e, 0xd6, 0xf8, 0xd4, 0x93, 0xff, 0x0a, 0xc6, 0x39, 0x15, 0x3e,
+	0x19, 0x0f, 0x28, 0xe3, 0x68, 0x42, 0xa2, 0xd8, 0x9d, 0x04, 0x82, 0xf9, 0x27, 0x1a, 0xf3, 0xb1,
+	0x7f, 0xec, 0xdf, 0x66, 0xe0, 0xc3, 0xe9, 0x11, 0x6b, 0xb1, 0x06, 0xfb, 0xe2, 0xe4, 0xf6, 0x2f,
+	0x5b, 0xd0, 0xde, 0x0d, 0xfd, 0xe0, 0x84, 0xc4, 0x0e, 0xf9, 0x66, 0x4a, 0xa2, 0x18, 0xaf, 0x40,
+	0x79, 0x34, 0xb4, 0x4a, 0x37, 0x4a, 0x37, 0xab, 0x0f, 0x16, 0x7e, 0xf8, 0xfe, 0x7a, 0x79, 0x67,
+	0xcb, 0x29, 0x8f, 0x86, 0xd8, 0x82, 0xc5, 0x28, 0xf6, 0x43, 0xb2, 0xb3, 0x65, 0x95, 0x29, 0xd2,
+	0x91, 0x4d, 0x7c, 0x1d, 0xaa, 0xf1, 0x59, 0x40, 0xac, 0xca, 0x8d, 0xd2, 0xcd, 0xf6, 0x9d, 0xa5,
+	0x4d, 0xbe, 0x08, 0xfb, 0x67, 0x01, 0x71, 0x18, 0x02, 0x7f, 0x09, 0xed, 0xe8, 0xc4, 0x0d, 0x87,
+	0x8f, 0x88, 0x1b, 0xc6, 0x87, 0xc4, 0x8d, 0xad, 0xea, 0x8d, 0xd2, 0xcd, 0xa5, 0x3b, 0x96, 0x20,
+	0xdd, 0x33, 0x90, 0x0e, 0xf9, 0xe6, 0x41, 0xf5, 0xdb, 0xef, 0xaf, 0x5f, 0x72, 0x52, 0x5c, 0x4c,
+	0x0e, 0xed, 0x53, 0xc9, 0xa9, 0x99, 0x72, 0x0c, 0xa4, 0x2e, 0xc7, 0x40, 0xe0, 0x0f, 0xa0, 0x1e,
+	0x4c, 0x63, 0x46, 0x6d, 0x2d, 0x30, 0x09, 0x58, 0x48, 0xd8, 0x15, 0x60, 0xc5, 0x9b, 0x50, 0x52,
+	0xae, 0x63, 0x22, 0xb8, 0x16, 0x0d, 0xae, 0x6d, 0x92, 0xe1, 0x92, 0x94, 0xf8, 0x7d, 0x58, 0x74,
+	0xc7, 0x63, 0x7f, 0xb0, 0xb3, 0x65, 0xd5, 0x19, 0x53, 0x57, 0x30, 0xdd, 0xe7, 0x50, 0xc5, 0x23,
+	0xe9, 0x70, 0x1f, 0x5a, 0x6e, 0xf4, 0xf2, 0x81, 0x1b, 0x0f, 0x4e, 0xf6, 0x82, 0xf1, 0x28, 0xb6,
+	0x1a, 0x8c, 0x71, 0x55, 0x32, 0xea, 0x38, 0xc5, 0x6e, 0xf2, 0xe0, 0xa7, 0x80, 0x06, 0x21, 0x71,
+	0x63, 0xb2, 0x45, 0xa2, 0x38, 0xf4, 0xcf, 0x46, 0xde, 0xb1, 0x05, 0x4c, 0xce, 0xba, 0x90, 0xd3,
+	0x4f, 0xa1, 0x95, 0xa8, 0x0c, 0x27, 0xde, 0x81, 0x8e, 0x43, 0x02, 0x3f, 0x8c, 0x05, 0x8c, 0x0c,
+	0xad, 0x25, 0x26, 0x6c, 0x4d, 0x08, 0x4b, 0x61, 0x95, 0xac, 0x34, 0x1f, 0x1d, 0xdd, 0x31, 0x89,
+	0x35, 0xad, 0x9a, 0xc6, 0xe8, 0xb6, 0x75, 0x9c, 0x36, 0x3a, 0x83, 0x87, 0x0a, 0xe1, 0x3a, 0x7e,
+	0x45, 0x47, 0x4c, 0x42, 0xab, 0x65, 0x08, 0xe9, 0xeb, 0x38, 0x4d, 0x88, 0xc1, 0x83, 0xbf, 0x80,
+	0x26, 0x07, 0xb0, 0xfd, 0x17, 0x59, 0x6d, 0x26, 0x63, 0xc5, 0x90, 0xc1, 0x51, 0x4a, 0x84, 0xc1,
+	0x41, 0x25, 0x84, 0x64, 0xe2, 0xbf, 0x92, 0x12, 0x3a, 0x86, 0x04, 0x47, 0x43, 0x69, 0x12, 0x74,
+	0x0e, 0x3a, 0xb1, 0x83, 0x13, 0x32, 0x78, 0xc9, 0x9a, 0x7b, 0xb1, 0x1b, 0x13, 0x0b, 0x19, 0x13,
+	0xdb, 0x37, 0xb1, 0xda, 0xc4, 0xa6, 0xf8, 0xe8, 0x8a, 0x07, 0xd3, 0x78, 0x77, 0xec, 0x0e, 0xc8,
+	0x84, 0x78, 0xb1, 0x33, 0x1d, 0x13, 0xab, 0x6b, 0xac, 0xf8, 0x6e, 0x0a, 0xad, 0xad, 0x78, 0x9a,
+	0x93, 0x2a, 0x76, 0x4c, 0xe2, 0xfb, 0x41, 0x30, 0x1e, 0x91, 0x21, 0x85, 0x44, 0x16, 0x36, 0x14,
+	0xdb, 0x36, 0xb1, 0x9a, 0x62, 0x29, 0x3e, 0x7c, 0x0f, 0x1a, 0x7c, 0xd6, 0x1e, 0xfb, 0x87, 0xd6,
+	0x32, 0x13, 0xb2, 0x6c, 0x4c, 0xf2, 0x63, 0xff, 0x50, 0xb1, 0x2b, 0x5a, 0xca, 0xc8, 0x27, 0x8b,
+	0x32, 0xf6, 0x0c, 0x46, 0x47, 0xc2, 0x35, 0xc6, 0x84, 0x16, 0x7f, 0x02, 0x40, 0x4e, 0xc9, 0x60,
+	0xca, 0xbb, 0xbc, 0xcc, 0x38, 0x7b, 0x82, 0xf3, 0x61, 0x82, 0x50, 0xac, 0x1a, 0x35, 0xfe, 0x05,
+	0xf4, 0xdc, 0xe1, 0x70, 0x6f, 0x70, 0x42, 0x86, 0xd3, 0x31, 0xd9, 0x0e, 0xfd, 0x69, 0xc0, 0xa6,
+	0x72, 0x85, 0x49, 0xb9, 0x26, 0x8d, 0x30, 0x87, 0x44, 0xc9, 0xcb, 0x95, 0x40, 0x25, 0x53, 0xb7,
+	0x90, 0x91, 0xbc, 0x6a, 0x48, 0xde, 0x26, 0xf1, 0x2c, 0xc9, 0x79, 0x12, 0xf0, 0xef, 0xc1, 0x0a,
+	0xdb, 0x0d, 0xfb, 0xfe, 0xe4, 0x30, 0x8a, 0x7d, 0x8f, 0x38, 0x24, 0x18, 0x8f, 0x06, 0x6e, 0x64,
+	0x59, 0x4c, 0xf6, 0x0d, 0x7d, 0x33, 0x65, 0x88, 0x94, 0xf4, 0x02, 0x29, 0xf8, 0x05, 0x74, 0x83,
+	0x69, 0xdc, 0x1f, 0x4f, 0xa3, 0x98, 0x84, 0x7b, 0x24, 0x8e, 0xa9, 0xdd, 0xae, 0x31, 0xd1, 0x57,
+	0xd4, 0xde, 0x32, 0xf1, 0x4a, 0x6a, 0x96, 0x17, 0x3b, 0x80, 0x8f, 0x49, 0x0a, 0x18, 0x59, 0xeb,
+	0x4c, 0xe2, 0x55, 0x35, 0x11, 0x29, 0x02, 0x25, 0x32, 0x87, 0x9b, 0xc6, 0xb2, 0x4e, 0x12, 0xcb,
+	0xa2, 0xc0, 0xf7, 0x22, 0x52, 0x18, 0xcc, 0x64, 0xc8, 0x2a, 0x17, 0x85, 0xac, 0x1e, 0xd4, 0xd8,
+	0x49, 0x80, 0x05, 0xb5, 0x86, 0xc3, 0x1b, 0x78, 0x05, 0x16, 0xc6, 0xc4, 0x1d, 0x92, 0x90, 0x05,
+	0xb0, 0x86, 0x23, 0x5a, 0x39, 0x01, 0xae, 0x36, 0x2b, 0xc0, 0x45, 0xc1, 0xdc, 0x01, 0x6e, 0x61,
+	0x56, 0x80, 0xd3, 0xe4, 0x14, 0x07, 0xb8, 0xc5, 0xfc, 0x00, 0x97, 0xf0, 0xe6, 0x07, 0xb8, 0x7a,
+	0x7e, 0x80, 0x53, 0x5c, 0x79, 0x01, 0xae, 0x91, 0x1b, 0xe0, 0x12, 0x9e, 0xe2, 0x00, 0x07, 0x33,
+	0x02, 0x5c, 0xc2, 0x3e, 0x47, 0x80, 0x5b, 0x9a, 0x1d, 0xe0, 0x12, 0x51, 0x73, 0x05, 0xb8, 0xe6,
+	0xcc, 0x00, 0x97, 0xc8, 0x3a, 0x3f, 0xc0, 0xb5, 0x66, 0x04, 0x38, 0x35, 0x3a, 0x83, 0x07, 0x6f,
+	0x42, 0x8d, 0xbc, 0x22, 0x5e, 0x6c, 0xb5, 0x8d, 0x85, 0x78, 0x48, 0x61, 0xcf, 0xfd, 0x78, 0x74,
+	0x74, 0x26, 0xf8, 0x38, 0x59, 0x26, 0x96, 0x75, 0x8a, 0x63, 0x59, 0xd2, 0xe5, 0xec, 0x58, 0x86,
+	0x8a, 0x63, 0x99, 0x92, 0x70, 0x5e, 0x2c, 0xeb, 0xce, 0x8c, 0x65, 0x6a, 0x0e, 0xe7, 0x89, 0x65,
+	0x78, 0x76, 0x2c, 0x53, 0x8b, 0x3b, 0x4f, 0x2c, 0x5b, 0x9e, 0x19, 0xcb, 0x94, 0x62, 0x33, 0x63,
+	0x59, 0xaf, 0x20, 0x96, 0x25, 0xec, 0x45, 0xb1, 0xec, 0x72, 0x41, 0x2c, 0x53, 0x8c, 0x45, 0xb1,
+	0x6c, 0xa5, 0x28, 0x96, 0x25, 0xac, 0xf3, 0xc4, 0xb2, 0xd5, 0xf3, 0x63, 0x59, 0x22, 0xef, 0x62,
+	0xb1, 0xcc, 0x3a, 0x3f, 0x96, 0x29, 0xc9, 0x17, 0x8c, 0x65, 0x6b, 0xf3, 0xc4, 0xb2, 0x44, 0xfa,
+	0x85, 0x62, 0xd9, 0xfa, 0x39, 0xb1, 0x2c, 0x91, 0x3a, 0x77, 0x2c, 0xbb, 0x72, 0x5e, 0x2c, 0x4b,
+	0x44, 0xe6, 0xc5, 0xb2, 0xbf, 0xab, 0x40, 0x37, 0x93, 0x15, 0xe9, 0x29, 0x58, 0xc9, 0x4c, 0xc1,
+	0x7a, 0x50, 0x63, 0xa1, 0x84, 0x05, 0xb4, 0xa6, 0xc3, 0x1b, 0x18, 0x43, 0x35, 0x26, 0xe1, 0x84,
+	0xc5, 0xb0, 0xaa, 0xc3, 0xbe, 0xf1, 0xbb, 0x46, 0x08, 0x5b, 0xba, 0xd3, 0xd9, 0x14, 0x59, 0xab,
+	0x98, 0xa0, 0x24, 0xa6, 0x7d, 0x06, 0xcd, 0xa1, 0xff, 0xda, 0x4b, 0x66, 0xbf, 0x76, 0xa3, 0xc2,
+	0x76, 0x9e, 0x49, 0x4e, 0xcd, 0x35, 0x92, 0xde, 0x40, 0xa7, 0xc7, 0x9f, 0x43, 0x27, 0x20, 0xde,
+	0x90, 0xce, 0x9e, 0x14, 0xb1, 0x70, 0xa3, 0x92, 0xd3, 0xa3, 0x34, 0xb5, 0x14, 0x35, 0x75, 0x81,
+	0x11, 0x95, 0x9e, 0x44, 0x30, 0xc1, 0x96, 0xb8, 0x09, 0xd9, 0x2f, 0x27, 0xc3, 0xeb, 0x50, 0x3f,
+	0xa6, 0xbb, 0xe8, 0x09, 0x39, 0x63, 0xe1, 0xab, 0xe1, 0x24, 0x6d, 0x7c, 0x13, 0x6a, 0x63, 0xe2,
+	0x46, 0xc4, 0x6a, 0x98, 0xb2, 0x1e, 0x06, 0xfe, 0xe0, 0xe4, 0x29, 0xc5, 0x38, 0x9c, 0x00, 0x7f,
+	0x09, 0x9d, 0xc3, 0xb1, 0x3f, 0x78, 0xc9, 0x34, 0x71, 0x23, 0xdf, 0x8b, 0x2c, 0x60, 0x6a, 0xaf,
+	0x48, 0x9e, 0x07, 0x06, 0x5a, 0x6a, 0x9f, 0x62, 0xb2, 0xff, 0xa2, 0x9a, 0x59, 0xc1, 0x28, 0x60,
+	0x2b, 0x48, 0x81, 0xda, 0x0a, 0xf2, 0x26, 0xfe, 0x08, 0x80, 0x7d, 0x32, 0x8d, 0xac, 0xb2, 0xa9,
+	0xe6, 0x5e, 0x82, 0x91, 0x46, 0xae, 0x68, 0xf1, 0x87, 0xd0, 0x8a, 0xdd, 0xf0, 0x98, 0xc4, 0x62,
+	0xe6, 0xd8, 0x72, 0xe7, 0x2c, 0xac, 0x49, 0x85, 0xef, 0x41, 0x73, 0xe0, 0x7b, 0x47, 0xa3, 0xe3,
+	0xfe, 0x89, 0xeb, 0x1d, 0x13, 0xab, 0x6a, 0xf8, 0xa4, 0xbe, 0x86, 0x72, 0x0c, 0x42, 0xfc, 0x73,
+	0x68, 0xc7, 0xa1, 0xeb, 0x45, 0x47, 0x24, 0x7c, 0xca, 0x77, 0x12, 0x3f, 0xec, 0x5c, 0x96, 0xa7,
+	0x28, 0x03, 0xe9, 0xa4, 0x88, 0xb1, 0x0d, 0xb5, 0x09, 0x09, 0x8f, 0x65, 0xe6, 0xdd, 0x14, 0x5c,
+	0xcf, 0x28, 0xcc, 0xe1, 0x28, 0xfc, 0x3e, 0x40, 0x44, 0x83, 0x3c, 0x1b, 0xb7, 0xb5, 0x68, 0x1c,
+	0x2b, 0xf6, 0x12, 0x84, 0xa3, 0x11, 0x51, 0xad, 0x74, 0x2d, 0x0f, 0xee, 0x58, 0x75, 0x43, 0xab,
+	0xbe, 0x81, 0x74, 0x52, 0xc4, 0xf8, 0x13, 0x68, 0x69, 0x7a, 0x26, 0x1b, 0xa5, 0x97, 0x1d, 0x53,
+	0x44, 0x1c, 0x93, 0x14, 0xdf, 0x84, 0xce, 0x90, 0x47, 0xee, 0xad, 0x51, 0x48, 0x06, 0xf1, 0xf8,
+	0x8c, 0x1d, 0x68, 0xea, 0x4e, 0x1a, 0x6c, 0xbf, 0x09, 0x4b, 0x5a, 0x85, 0x81, 0x59, 0x2d, 0xfd,
+	0xb6, 0x4a, 0xc2, 0x6a, 0x69, 0xc3, 0xbe, 0xab, 0x11, 0x45, 0x01, 0x7e, 0x0b, 0x5a, 0x42, 0x8c,
+	0x08, 0xcc, 0x9c, 0xd8, 0x04, 0xda, 0x5f, 0x41, 0x37, 0x53, 0xfd, 0x50, 0x16, 0x54, 0x4a, 0x6d,
+	0x27, 0x4a, 0x99, 0x63, 0x41, 0x18, 0xaa, 0x43, 0x37, 0x76, 0x85, 0x13, 0x61, 0xdf, 0xf6, 0xbb,
+	0x19, 0xc1, 0x51, 0x90, 0x10, 0x96, 0x34, 0xc2, 0xb7, 0x61, 0x49, 0xab, 0x83, 0x14, 0x9d, 0xbc,
+	0xed, 0x27, 0x1a, 0x59, 0xbe, 0x24, 0x6a, 0xac, 0x5c, 0xed, 0x72, 0x91, 0xda, 0x42, 0x61, 0xbb,
+	0x09, 0xa0, 0xca, 0x28, 0xf6, 0x5b, 0xaa, 0x15, 0x05, 0x85, 0x0a, 0x7c, 0x0a, 0x28, 0x5d, 0x41,
+	0xc9, 0xd5, 0xa2, 0x07, 0xb5, 0x81, 0x3f, 0xf5, 0x62, 0xa6, 0x45, 0xcb, 0xe1, 0x0d, 0x7b, 0x2b,
+	0xcd, 0x1d, 0x05, 0xf8, 0xff, 0x41, 0x9d, 0x6d, 0xc4, 0x9d, 0x2d, 0x3a, 0xd3, 0xd4, 0x57, 0xb4,
+	0xf5, 0xbd, 0xba, 0xb3, 0x25, 0xcf, 0xcc, 0x92, 0xca, 0xfe, 0x23, 0x58, 0xce, 0xa9, 0xbe, 0x14,
+	0x66, 0x2b, 0x3d, 0xa8, 0x8d, 0xbc, 0x21, 0x39, 0x15, 0x85, 0x37, 0xde, 0xa0, 0xfe, 0x2e, 0x94,
+	0x9e, 0xb5, 0x72, 0xa3, 0x72, 0xb3, 0xea, 0x24, 0x6d, 0x7c, 0x0d, 0x80, 0x9f, 0x20, 0xb6, 0xe8,
+	0xb0, 0xaa, 0x6c, 0x37, 0x6a, 0x10, 0xfb, 0xf3, 0x1c, 0x05, 0xa2, 0x40, 0xce, 0x3c, 0xdf, 0x90,
+	0xed, 0x1c, 0x97, 0x4b, 0xf8, 0xcc, 0x13, 0x7b, 0x03, 0x50, 0xba, 0x52, 0x53, 0x38, 0xe3, 0x5b,
+	0x69, 0x5a, 0x36, 0x67, 0x0b, 0x54, 0xd0, 0x54, 0xee, 0x4d, 0x4b, 0x76, 0xa5, 0xc8, 0xf6, 0x18,
+	0xde, 0x11, 0x74, 0xf6, 0x63, 0xc0, 0xd9, 0x22, 0x53, 0xe1, 0x94, 0x5d, 0x85, 0x86, 0x98, 0x8c,
+	0xa4, 0x5e, 0xa9, 0x00, 0xf6, 0x67, 0x59, 0x59, 0x17, 0x1a, 0xfd, 0x43, 0x58, 0x14, 0x4b, 0x4b,
+	0xd7, 0xc6, 0x23, 0xaf, 0x13, 0x7f, 0xce, 0x1b, 0xd4, 0x68, 0x3d, 0xf2, 0xda, 0x91, 0x1d, 0xd2,
+	0xad, 0x4c, 0x17, 0xc8, 0x04, 0xda, 0xef, 0x00, 0x4a, 0x57, 0xaa, 0xe8, 0x56, 0x3c, 0x1a, 0xbb,
+	0xc7, 0x4c, 0x5c, 0xcb, 0x61, 0xdf, 0xf6, 0x0b, 0xe8, 0xa4, 0xaa, 0x51, 0x34, 0x13, 0x8d, 0xa4,
+	0x3b, 0xa8, 0xdc, 0x6c, 0x3a, 0xa2, 0x45, 0x3b, 0xa6, 0x71, 0x2c, 0x4e, 0x62, 0xae, 0xe8, 0xd8,
+	0x00, 0xda, 0xdd, 0x94, 0xc0, 0x28, 0xb0, 0xdf, 0xa3, 0x09, 0x90, 0x51, 0xaf, 0xc2, 0x6b, 0x50,
+	0x19, 0x89, 0x0e, 0xaa, 0x0f, 0x16, 0x7f, 0xf8, 0xfe, 0x7a, 0x65, 0x67, 0x2b, 0x72, 0x28, 0xcc,
+	0xee, 0xa6, 0xa8, 0xa3, 0xc0, 0xbe, 0x0d, 0x38, 0x5b, 0xab, 0x52, 0x32, 0x4a, 0x37, 0x9b, 0x29,
+	0x19, 0x4e, 0x96, 0x21, 0x0a, 0xe8, 0xc2, 0x0d, 0x93, 0x14, 0x8c, 0xdb, 0xa3, 0x02, 0xd0, 0x7d,
+	0x3d, 0x54, 0x89, 0x15, 0xf7, 0x53, 0x1a, 0xc4, 0xfe, 0x03, 0x40, 0xe9, 0x13, 0xdf, 0x8c, 0x98,
+	0x3b, 0x73, 0x93, 0xb0, 0x14, 0x8c, 0x05, 0xe3, 0xca, 0x39, 0xc1, 0x98, 0x93, 0xd9, 0x07, 0xb0,
+	0x56, 0x58, 0x5f, 0xc1, 0x1f, 0x6b, 0xc6, 0xca, 0x7d, 0x84, 0xcc, 0x07, 0xd3, 0xe4, 0xd2, 0x59,
+	0x48, 0x72, 0xfb, 0xe3, 0x42, 0xb9, 0x7c, 0xba, 0x98, 0x59, 0xbb, 0x87, 0x63, 0x19, 0x46, 0x14,
+	0xc0, 0x7e, 0x08, 0xcb, 0x39, 0x35, 0x3f, 0xbc, 0x09, 0xd5, 0x70, 0x2a, 0xe8, 0x55, 0x8c, 0x33,
+	0xc8, 0x84, 0x16, 0x8c, 0xce, 0xbe, 0x9c, 0x23, 0x26, 0x0a, 0xec, 0x4d, 0xc0, 0xd9, 0x22, 0x60,
+	0xf1, 0x74, 0xdb, 0x5f, 0x66, 0xe9, 0x99, 0x27, 0xa8, 0xd1, 0x4e, 0xe4, 0xb4, 0xcc, 0xd2, 0x86,
+	0x13, 0xda, 0x77, 0xa1, 0xa9, 0xd7, 0x0d, 0xf1, 0x9b, 0x50, 0xf9, 0x7d, 0xff, 0x50, 0x8c, 0x66,
+	0x49, 0x2e, 0xd3, 0x63, 0xff, 0x50, 0xb0, 0x51, 0xac, 0xdd, 0xd6, 0x99, 0xa2, 0x80, 0x0a, 0xd1,
+	0x6b, 0x88, 0x73, 0x0b, 0xd1, 0x93, 0x35, 0xfb, 0x11, 0xb4, 0x8c, 0x72, 0xe2, 0x5c, 0x52, 0x72,
+	0xc3, 0xec, 0x9b, 0x86, 0xa4, 0x82, 0x10, 0xfb, 0x1c, 0x56, 0x0b, 0xea, 0x8e, 0xf8, 0xae, 0xb1,
+	0xa4, 0x6b, 0xc9, 0x5e, 0x4d, 0xd3, 0x1a, 0xeb, 0xba, 0x56, 0x20, 0x2f, 0x0a, 0x28, 0xaa, 0xa0,
+	0x10, 0x69, 0xef, 0x16, 0xa0, 0xa2, 0x00, 0x7f, 0x68, 0xae, 0xe5, 0xb9, 0x6a, 0x88, 0x05, 0x7d,
+	0x0e, 0xbd, 0xbc, 0xf2, 0x21, 0xfe, 0x29, 0x2c, 0x46, 0xbc, 0x25, 0xc6, 0x95, 0x9c, 0xc1, 0x4d,
+	0x5a, 0x59, 0x5f, 0x12, 0xc4, 0xf9, 0xf2, 0xa2, 0xe0, 0x37, 0x96, 0xb7, 0x0a, 0x97, 0x73, 0x8b,
+	0x91, 0xf6, 0xff, 0xcf, 0x45, 0x44, 0x01, 0xfe, 0x08, 0xea, 0x82, 0x59, 0xce, 0xc5, 0xec, 0xae,
+	0x12, 0x6a, 0xfb, 0x4f, 0x2b, 0xb0, 0xa4, 0x55, 0x79, 0x30, 0x82, 0x4a, 0x44, 0xbe, 0x11, 0xa6,
+	0x44, 0x3f, 0x31, 0xd6, 0x6a, 0x97, 0x2d, 0x51, 0xae, 0xbc, 0x03, 0x8d, 0x91, 0x37, 0x8a, 0x19,
+	0xa3, 0xf0, 0x57, 0xd2, 0x90, 0x76, 0x24, 0x9c, 0x06, 0x7e, 0x47, 0x91, 0xe1, 0x0f, 0x65, 0xc6,
+	0xc1, 0x98, 0xaa, 0xc6, 0x69, 0x79, 0x2f, 0x41, 0x30, 0x2e, 0x8d, 0x90, 0xb1, 0xc5, 0x7e, 0x48,
+	0x38, 0x9b, 0x79, 0xf4, 0xdf, 0x4b, 0x10, 0x82, 0x2d, 0x69, 0xe3, 0x4f, 0xa1, 0x13, 0x25, 0x89,
+	0x1b, 0xe7, 0x5d, 0x28, 0xca, 0xeb, 0x9c, 0x34, 0x29, 0xe3, 0x4e, 0x4e, 0x7f, 0x9c, 0x7b, 0xb1,
+	0xf0, 0x70, 0x98, 0x26, 0xc5, 0x9f, 0x40, 0x53, 0xcc, 0x2f, 0x67, 0xad, 0xcf, 0x5a, 0x7c, 0xc7,
+	0xa0, 0xb5, 0x7f, 0x5d, 0x82, 0x96, 0x31, 0x85, 0x85, 0xa1, 0x97, 0xc2, 0x69, 0xc7, 0x3c, 0xe6,
+	0x36, 0x1d, 0xd1, 0xc2, 0x1b, 0x80, 0x78, 0x4a, 0xad, 0x1d, 0x07, 0xf8, 0x79, 0x2d, 0x03, 0xa7,
+	0xc7, 0x22, 0x96, 0x86, 0x46, 0x56, 0xf5, 0x46, 0x45, 0x1f, 0x9e, 0x4a, 0x54, 0xc5, 0x8e, 0x11,
+	0x74, 0xc6, 0x4e, 0xab, 0x5d, 0x68, 0xa7, 0xfd, 0x4d, 0x09, 0xda, 0xe6, 0x3a, 0x17, 0x9c, 0xc6,
+	0x3b, 0x29, 0x35, 0x45, 0xa8, 0x4c, 0x83, 0x55, 0x92, 0x5d, 0x39, 0x2f, 0xc9, 0xb6, 0x60, 0x91,
+	0x1f, 0x46, 0x87, 0xe2, 0x6c, 0x2a, 0x9b, 0x74, 0x12, 0x79, 0xcd, 0x8c, 0xed, 0xac, 0xba, 0x23,
+	0x5a, 0xf6, 0x5b, 0xd0, 0x36, 0x37, 0x57, 0xae, 0x83, 0xfc, 0xcb, 0x12, 0x34, 0xf5, 0x44, 0x0f,
+	0xdf, 0xa6, 0x1d, 0xf1, 0xac, 0xb8, 0x94, 0x9b, 0x15, 0x4b, 0x53, 0x17, 0x54, 0x34, 0x0d, 0x1f,
+	0x30, 0xd6, 0x7d, 0x75, 0x3d, 0x90, 0x9c, 0x4d, 0x75, 0xd1, 0x14, 0xef, 0x68, 0xb4, 0x34, 0x12,
+	0x53, 0xdb, 0x1a, 0xb9, 0x71, 0x72, 0x6b, 0xa0, 0x00, 0xf6, 0x7d, 0x68, 0x9b, 0x79, 0xf1, 0x85,
+	0x55, 0xb3, 0x3f, 0x87, 0x96, 0x91, 0x86, 0xd2, 0x03, 0x0a, 0x9f, 0xef, 0x52, 0xd1, 0x7c, 0x4b,
+	0x37, 0xcb, 0xc8, 0xec, 0x87, 0xd0, 0x36, 0xb3, 0x60, 0x7c, 0x17, 0x16, 0xf9, 0x08, 0xa4, 0x97,
+	0xca, 0x4b, 0xff, 0xa5, 0x1e, 0x82, 0xd2, 0xbe, 0x0e, 0x35, 0x96, 0xac, 0xd3, 0xb5, 0xe2, 0x25,
+	0x05, 0xb1, 0x06, 0xa2, 0x65, 0x3f, 0x03, 0x50, 0x49, 0x3a, 0xbe, 0x05, 0x0b, 0x81, 0x3f, 0x1e,
+	0x0d, 0xce, 0xc4, 0xb1, 0x7a, 0x39, 0x99, 0x4d, 0x7a, 0xa8, 0xd9, 0x65, 0x28, 0x47, 0x90, 0xd0,
+	0x45, 0x7d, 0x49, 0xce, 0xa4, 0x05, 0xb1, 0x6f, 0x9b, 0x40, 0xe7, 0xa9, 0x7b, 0x48, 0xc6, 0x7d,
+	0xdf, 0x8b, 0xe2, 0xd0, 0x1d, 0x79, 0x31, 0x75, 0x8a, 0x2f, 0x09, 0x17, 0xd8, 0x70, 0xe8, 0x27,
+	0xbe, 0x09, 0x65, 0x3f, 0x48, 0xd6, 0x8b, 0x0f, 0x22, 0xc5, 0xf5, 0x22, 0x70, 0xca, 0x3e, 0xcd,
+	0x0b, 0x17, 0x5e, 0xb9, 0xe3, 0x29, 0xe1, 0x46, 0xd8, 0x70, 0x44, 0xcb, 0xfe, 0xe3, 0x0a, 0xb4,
+	0xcc, 0xb2, 0xb1, 0xca, 0x2d, 0x1a, 0xe9, 0x97, 0x10, 0xac, 0xb0, 0x24, 0x2c, 0xa1, 0xe1, 0xc8,
+	0xa6, 0x4a, 0xd4, 0x2a, 0x3c, 0x67, 0x4c, 0x12, 0x35, 0xff, 0x15, 0x09, 0xc3, 0xd1, 0x90, 0x88,
+	0xed, 0x9e, 0xb4, 0x29, 0x2e, 0x8a, 0xdd, 0x30, 0xa6, 0x45, 0xab, 0x1a, 0x9b, 0xc5, 0xa4, 0x4d,
+	0x35, 0x25, 0xde, 0x90, 0x62, 0x16, 0xf8, 0xfc, 0xf2, 0x16, 0xde, 0x80, 0x6a, 0xe8, 0x8f, 0xf9,
+	0xcd, 0x4e, 0x5b, 0xab, 0xd0, 0xf3, 0x32, 0x8f, 0x3f, 0xe6, 0x7b, 0x93, 0xd1, 0xa8, 0x2c, 0xb6,
+	0xae, 0x65, 0xb1, 0xf8, 0x11, 0xa0, 0xb1, 0x39, 0x39, 0x91, 0xd5, 0x10, 0xce, 0x23, 0x77, 0xee,
+	0x64, 0x69, 0x3d, 0xcd, 0x85, 0xdf, 0x81, 0xf6, 0xd8, 0x1f, 0xb8, 0xf1, 0xc8, 0xf7, 0x18, 0x0b,
+	0xaf, 0x96, 0x35, 0x9c, 0x14, 0x94, 0xd2, 0x8d, 0x22, 0x7f, 0xcc, 0x41, 0xe4, 0x15, 0x19, 0xb3,
+	0xbb, 0x9a, 0x86, 0x93, 0x82, 0xda, 0xff, 0x53, 0x02, 0x2c, 0x5e, 0xa2, 0xb0, 0x24, 0xfb, 0x11,
+	0x37, 0x16, 0xb5, 0x14, 0xcd, 0xf4, 0x52, 0xc8, 0xc3, 0x66, 0xd9, 0x3c, 0xdb, 0x6b, 0xe6, 0x55,
+	0x99, 0xcb, 0xf2, 0x13, 0xef, 0x55, 0x3d, 0xcf, 0x7b, 0x5d, 0x03, 0x18, 0xf8, 0x93, 0xc9, 0x28,
+	0xde, 0x1f, 0x4d, 0xb8, 0x9f, 0xaa, 0x38, 0x1a, 0x04, 0xdf, 0x81, 0x7a, 0x10, 0x8e, 0xfc, 0x70,
+	0x14, 0xf3, 0x95, 0xd3, 0xd7, 0x88, 0x8d, 0x6c, 0x57, 0x60, 0x9d, 0x84, 0xce, 0xfe, 0x6d, 0x58,
+	0x96, 0x97, 0x96, 0xf3, 0x8c, 0x7b, 0x43, 0x5e, 0x4f, 0xf2, 0x12, 0x49, 0x7b, 0x53, 0x3e, 0x5b,
+	0x7a, 0x48, 0xff, 0x26, 0x79, 0x09, 0x6d, 0xd8, 0x7f, 0x5f, 0x82, 0xa6, 0xe8, 0x98, 0x89, 0xc6,
+	0xf7, 0x60, 0xe1, 0x84, 0x89, 0x4f, 0x4e, 0x8b, 0x86, 0x76, 0x5a, 0xff, 0x32, 0xd6, 0x70, 0x72,
+	0x5a, 0xe8, 0x08, 0x39, 0x0d, 0xb7, 0x50, 0x55, 0xe8, 0x90, 0xac, 0x49, 0xee, 0xc2, 0xa9, 0xa8,
+	0x9e, 0x83, 0x93, 0xa9, 0xf7, 0x32, 0x75, 0x26, 0xa1, 0xd7, 0xb4, 0x7e, 0xe4, 0x8e, 0xfb, 0x14,
+	0xe7, 0x70, 0x12, 0x31, 0xad, 0x41, 0x48, 0xa2, 0x48, 0xc4, 0x85, 0xa6, 0xa3, 0x41, 0xec, 0x17,
+	0xd0, 0x32, 0xf8, 0x94, 0xb5, 0x95, 0x74, 0x6b, 0xcb, 0xad, 0xdb, 0x24, 0xd1, 0xa2, 0xa2, 0x45,
+	0x8b, 0x3f, 0x84, 0x96, 0x31, 0xe7, 0xf8, 0xa3, 0xd4, 0xc4, 0xac, 0x27, 0xa3, 0xcb, 0xac, 0x4c,
+	0x6a, 0x66, 0xee, 0xd2, 0x34, 0x8c, 0x13, 0xc9, 0xa9, 0xe9, 0xa4, 0x99, 0x93, 0x9b, 0x1d, 0x41,
+	0x67, 0xff, 0x79, 0x03, 0x16, 0xb3, 0xaf, 0xae, 0x9a, 0xe9, 0xd2, 0x0f, 0x73, 0x2e, 0xb2, 0xf4,
+	0xc3, 0x1a, 0xd8, 0x36, 0x5e, 0x5c, 0xc9, 0x45, 0xe8, 0x4f, 0x86, 0xda, 0x0d, 0x36, 0x9d, 0xce,
+	0x69, 0x14, 0xfb, 0x13, 0x0a, 0x63, 0xd3, 0x59, 0x75, 0x34, 0x88, 0xf4, 0xa1, 0xdc, 0xe9, 0xd0,
+	0x4f, 0x0a, 0x19, 0x4c, 0x86, 0xc2, 0xd9, 0xd0, 0x4f, 0x9a, 0xbd, 0x07, 0x23, 0x5e, 0x80, 0xad,
+	0xf0, 0xec, 0x7d, 0x77, 0x67, 0xcb, 0xa9, 0x04, 0xdc, 0xf2, 0x62, 0x9f, 0xd7, 0x67, 0xeb, 0xdc,
+	0xf2, 0x44, 0x93, 0x9e, 0x77, 0x46, 0xc7, 0x1e, 0x8d, 0xd5, 0xd4, 0x72, 0x98, 0x97, 0x67, 0xd5,
+	0xd4, 0xba, 0x93, 0x81, 0xab, 0x1c, 0x1b, 0xe6, 0xca, 0xb1, 0x95, 0x91, 0x2e, 0x9d, 0x67, 0xa4,
+	0x1b, 0xd0, 0xa0, 0xd1, 0xc3, 0x61, 0xb5, 0xed, 0xa6, 0x51, 0x6a, 0x66, 0x30, 0x47, 0xa1, 0xf1,
+	0x53, 0x58, 0x16, 0x5e, 0x60, 0x8f, 0x8c, 0xc9, 0x20, 0xe6, 0x41, 0x89, 0xdd, 0xdb, 0xb6, 0xb5,
+	0x4d, 0x90, 0xa1, 0x70, 0xf2, 0xd8, 0xf0, 0x17, 0xd0, 0x89, 0x4f, 0x3d, 0xb6, 0x57, 0xc4, 0xea,
+	0x26, 0x2f, 0x8b, 0xf8, 0x33, 0xbf, 0x7d, 0x13, 0xeb, 0xa4, 0xc9, 0xf1, 0x33, 0xe8, 0x4c, 0x83,
+	0xa1, 0x1b, 0x93, 0xfd, 0x53, 0xcf, 0x21, 0x03, 0x3f, 0x1c, 0x8a, 0xfb, 0xdc, 0x37, 0x84, 0x2e,
+	0xbf, 0x65, 0x62, 0x4d, 0xeb, 0x4b, 0xf3, 0x52, 0x71, 0x43, 0x32, 0x26, 0xba, 0x38, 0x64, 0x88,
+	0xdb, 0x32, 0xb1, 0x29, 0x71, 0x29, 0x5e, 0x7c, 0x00, 0x58, 0x38, 0xbb, 0x53, 0xef, 0xab, 0x70,
+	0x14, 0xf3, 0x1a, 0x63, 0xd7, 0xbc, 0x9c, 0xcb, 0x10, 0x98, 0x42, 0x73, 0x24, 0xe0, 0x03, 0xe8,
+	0x86, 0xfe, 0x78, 0x7c, 0xe8, 0x0e, 0x5e, 0x2a, 0x45, 0xf9, 0xa5, 0xaf, 0x2d, 0xd7, 0x40, 0xe1,
+	0x0b, 0x04, 0x67, 0x45, 0xe0, 0x5d, 0x40, 0x83, 0x31, 0x71, 0xbd, 0xfd, 0x53, 0xef, 0xd9, 0x41,
+	0xbf, 0xcf, 0xb4, 0x5d, 0x36, 0xae, 0x29, 0xfb, 0x29, 0xb4, 0x29, 0x32, 0xc3, 0x4d, 0x83, 0x19,
+	0x7d, 0xca, 0xf0, 0x7a, 0x2f, 0x76, 0xc7, 0xc4, 0x21, 0xee, 0x90, 0xdd, 0x04, 0xd7, 0x9d, 0x14,
+	0x94, 0x16, 0xe3, 0xdc, 0x20, 0x60, 0xdb, 0x72, 0xdf, 0x7f, 0x49, 0x3c, 0x76, 0xef, 0x5b, 0x75,
+	0x4c, 0x20, 0xb6, 0xa1, 0x79, 0xe4, 0x53, 0x46, 0x12, 0x32, 0x59, 0x2b, 0x4c, 0x96, 0x01, 0xa3,
+	0xee, 0x61, 0x70, 0x64, 0xad, 0xaa, 0xa3, 0x48, 0xff, 0x4b, 0xa7, 0x3c, 0x38, 0x32, 0x42, 0x8d,
+	0x35, 0x5f, 0xa8, 0xa1, 0x47, 0x8e, 0x21, 0x71, 0x87, 0xe3, 0x91, 0x47, 0xd8, 0x95, 0x6a, 0xc5,
+	0x49, 0xda, 0xf6, 0x2d, 0xa8, 0x71, 0x93, 0xa0, 0x65, 0xc8, 0xd0, 0x9f, 0xc8, 0xd3, 0x35, 0xfd,
+	0xc6, 0x6d, 0x28, 0xc7, 0xbe, 0xa8, 0x5a, 0x94, 0x63, 0xdf, 0xfe, 0x55, 0x0d, 0xea, 0x39, 0x2f,
+	0x6d, 0x4c, 0x07, 0x66, 0x1b, 0x2f, 0x6d, 0xe6, 0x71, 0x55, 0x95, 0x8c, 0xab, 0xea, 0x41, 0x8d,
+	0x1d, 0xd2, 0x44, 0x50, 0xe0, 0x0d, 0xe9, 0x9c, 0x6a, 0x39, 0xce, 0x29, 0x09, 0x8f, 0x0b, 0xe7,
+	0x86, 0x47, 0xdc, 0x07, 0xa4, 0xec, 0x8f, 0x0f, 0x46, 0xe4, 0x96, 0xab, 0x19, 0x7b, 0xe5, 0x68,
+	0x27, 0xc3, 0x80, 0xb7, 0xb3, 0x16, 0x5b, 0x9f, 0xc3, 0x62, 0xb3, 0xb6, 0xba, 0x9d, 0xb5, 0xd5,
+	0xc6, 0x1c, 0xb6, 0x9a, 0xb5, 0xd2, 0xdd, 0x5c, 0x2b, 0x85, 0xf9, 0xac, 0x34, 0xd7, 0x3e, 0x77,
+	0xf3, 0xec, 0x73, 0x69, 0x5e, 0xfb, 0xcc, 0xb3, 0xcc, 0xc7, 0x39, 0x96, 0xd9, 0x9c, 0xc7, 0x32,
+	0x73, 0x6c, 0x72, 0x1d, 0xea, 0x6e, 0x10, 0x8c, 0xcf, 0x9e, 0xba, 0xfc, 0xc1, 0x4d, 0xd5, 0x49,
+	0xda, 0xd4, 0xc2, 0x5c, 0x5e, 0x75, 0xdc, 0x61, 0xe7, 0x85, 0x36, 0xc3, 0x1b, 0x30, 0xfb, 0xaf,
+	0x4a, 0xb0, 0x6c, 0x5c, 0x7a, 0x0a, 0x5f, 0x6c, 0x26, 0x84, 0xa5, 0x0b, 0x24, 0x84, 0xda, 0x09,
+	0xb4, 0x3c, 0xd7, 0x09, 0xf4, 0xbc, 0x0c, 0xb2, 0x67, 0xea, 0x27, 0xb6, 0xde, 0x8f, 0xe5, 0xd5,
+	0x3f, 0x3f, 0xb3, 0xb4, 0x8c, 0x10, 0x9a, 0xdc, 0xef, 0xd1, 0x86, 0x7d, 0x0f, 0xba, 0x7d, 0x7f,
+	0x12, 0xb8, 0x83, 0xf8, 0xa9, 0x7f, 0x2c, 0x07, 0x68, 0xd3, 0x7b, 0x60, 0x06, 0xdc, 0x49, 0x0e,
+	0x53, 0x55, 0xc7, 0x80, 0xd9, 0x3d, 0xc0, 0x3a, 0x23, 0xef, 0xd9, 0x7e, 0x04, 0x97, 0x53, 0x77,
+	0xbd, 0x42, 0xe4, 0x85, 0x53, 0x5b, 0x0b, 0x56, 0xd2, 0x92, 0x44, 0x1f, 0x43, 0xe8, 0x1a, 0x57,
+	0x75, 0x4c, 0xfe, 0x87, 0xda, 0x39, 0xd4, 0xcc, 0x5b, 0x75, 0xb2, 0xcc, 0x61, 0xd4, 0x82, 0xc5,
+	0x81, 0xef, 0xc5, 0xe4, 0x34, 0x16, 0x4e, 0x4c, 0x36, 0xed, 0x3f, 0x2b, 0x41, 0xd3, 0xe8, 0x81,
+	0xdd, 0xcc, 0xba, 0x61, 0xac, 0x6e, 0x66, 0xdd, 0x90, 0xa5, 0x9d, 0xc4, 0x93, 0x6f, 0x2c, 0xe8,
+	0x27, 0xf5, 0x5c, 0x1e, 0x79, 0xbd, 0x27, 0x52, 0x10, 0xe1, 0xb9, 0x14, 0x04, 0xdf, 0x83, 0x25,
+	0x75, 0xe5, 0x23, 0x8b, 0x3a, 0x05, 0xb3, 0xa1, 0x53, 0xda, 0xf7, 0x01, 0xeb, 0xe3, 0x16, 0x6b,
+	0x7d, 0xcb, 0x28, 0x3d, 0x15, 0x2c, 0xb6, 0x20, 0xb1, 0x1d, 0xb8, 0xcc, 0xbd, 0xce, 0x33, 0x12,
+	0xbb, 0x43, 0x65, 0x3c, 0xf4, 0x2e, 0x62, 0x22, 0x40, 0x62, 0x7d, 0x56, 0x0d, 0x39, 0x4f, 0xfd,
+	0x81, 0x3b, 0x66, 0x17, 0x32, 0x72, 0x0a, 0x25, 0x39, 0x5d, 0xa8, 0xb4, 0x4c, 0xb1, 0x50, 0x3e,
+	0x2c, 0x73, 0x0c, 0x4f, 0xf8, 0x64, 0x5f, 0xb7, 0x60, 0x81, 0xe5, 0x8c, 0x19, 0x8d, 0x19, 0x99,
+	0xd4, 0x98, 0x93, 0x68, 0xa5, 0x82, 0xb2, 0x28, 0x15, 0xe8, 0xce, 0xd3, 0x2c, 0x15, 0xd8, 0x2b,
+	0xd0, 0x33, 0x3b, 0x14, 0x8a, 0x7c, 0x01, 0x5d, 0x0e, 0xdf, 0xe6, 0x57, 0x50, 0x42, 0x8d, 0xea,
+	0xb1, 0xbc, 0xd9, 0xa3, 0x4f, 0x09, 0xf4, 0xe1, 0x6e, 0xab, 0x81, 0x32, 0x22, 0xba, 0xdb, 0x75,
+	0x09, 0x42, 0xee, 0xef, 0xc2, 0xca, 0xfd, 0xc1, 0x37, 0xd3, 0x51, 0x48, 0xee, 0x8b, 0xf0, 0xad,
+	0xce, 0xee, 0x0b, 0x27, 0xfe, 0x58, 0xa6, 0x0d, 0x0d, 0x47, 0xb4, 0x68, 0x80, 0x8a, 0xe3, 0xb1,
+	0x55, 0x56, 0x01, 0x6a, 0x7f, 0xff, 0xa9, 0x43, 0x61, 0x74, 0x27, 0x79, 0xfe, 0x6b, 0xb6, 0x61,
+	0x2a, 0x0e, 0xfd, 0xb4, 0x07, 0xb0, 0x9a, 0x11, 0x2f, 0x56, 0x9d, 0xba, 0x36, 0x8e, 0xe2, 0x46,
+	0x5e, 0x77, 0x92, 0x36, 0x7e, 0x4f, 0x1e, 0x88, 0xb9, 0x8b, 0x41, 0x72, 0x64, 0x52, 0x88, 0x59,
+	0x01, 0xda, 0x84, 0x15, 0x87, 0xb0, 0xcf, 0xf4, 0x18, 0x7a, 0x50, 0x8b, 0xd9, 0x11, 0x45, 0x5c,
+	0x63, 0xb2, 0x86, 0xfd, 0x21, 0xac, 0x66, 0xe8, 0x95, 0x52, 0x21, 0x47, 0x25, 0x4a, 0xc9, 0xb6,
+	0xfd, 0x3e, 0x74, 0xb5, 0x57, 0x1a, 0xa2, 0x87, 0xab, 0xd0, 0x60, 0xf7, 0xdf, 0x4f, 0xc8, 0x19,
+	0xdf, 0x0c, 0x4d, 0x47, 0x01, 0xe8, 0x9c, 0xeb, 0x2c, 0x62, 0xce, 0xbf, 0x06, 0xcc, 0xe3, 0x9d,
+	0xa3, 0xbb, 0xe4, 0x0b, 0x18, 0x27, 0x7b, 0x03, 0xb6, 0x93, 0x94, 0x64, 0xaa, 0x8e, 0x06, 0xb1,
+	0x6f, 0xc3, 0xb2, 0x21, 0x5d, 0x8c, 0xcc, 0x82, 0x45, 0x1e, 0x4c, 0xe5, 0xc0, 0x64, 0xd3, 0x46,
+	0xd0, 0x7e, 0xe0, 0x86, 0xe1, 0x28, 0xf1, 0x74, 0xf6, 0xbb, 0xd0, 0x49, 0x20, 0x82, 0xdd, 0xc8,
+	0x4a, 0xe5, 0x65, 0xbd, 0xf4, 0xa0, 0xd3, 0x98, 0x3c, 0x72, 0x23, 0x79, 0xd0, 0xb7, 0x7f, 0x07,
+	0x96, 0x0d, 0xe8, 0x2c, 0x11, 0xf4, 0x48, 0x76, 0xe2, 0x46, 0x27, 0x22, 0x13, 0x64, 0xdf, 0x74,
+	0x15, 0x06, 0x5c, 0xc0, 0x90, 0x0d, 0xb0, 0xee, 0x24, 0x6d, 0xfb, 0xe7, 0xd0, 0x3d, 0x20, 0xe1,
+	0xe8, 0xe8, 0x4c, 0xeb, 0x71, 0x7e, 0xd1, 0x54, 0x63, 0x9d, 0x5d, 0xac, 0xc8, 0x63, 0x68, 0xee,
+	0x4e, 0xc3, 0x8b, 0xaf, 0x85, 0x2c, 0xec, 0x55, 0xb4, 0xc2, 0xde, 0x19, 0xb4, 0x84, 0xac, 0xe4,
+	0x0c, 0xb9, 0x10, 0x50, 0x80, 0x9c, 0x78, 0xd1, 0x2a, 0x78, 0xff, 0x90, 0x74, 0x5d, 0xc9, 0xe9,
+	0xba, 0x9a, 0xed, 0xba, 0xa6, 0x75, 0x3d, 0x80, 0x55, 0x6e, 0xe2, 0x5a, 0xe2, 0x28, 0x46, 0x54,
+	0x7c, 0x5d, 0xbc, 0x69, 0xda, 0xda, 0xb9, 0xf5, 0xd6, 0x75, 0xb0, 0xb2, 0x9d, 0x88, 0x79, 0x7c,
+	0x2e, 0x1d, 0x69, 0xfa, 0x24, 0x87, 0x3f, 0x80, 0x46, 0x2c, 0x61, 0xc2, 0x5f, 0x21, 0x75, 0x10,
+	0xe5, 0x70, 0x59, 0x4b, 0x48, 0x08, 0xed, 0x17, 0x72, 0x40, 0x9a, 0x3c, 0x31, 0xab, 0xbf, 0x99,
+	0xc0, 0xaf, 0x61, 0x25, 0xff, 0xa8, 0x89, 0xdf, 0x83, 0x6e, 0x42, 0xe6, 0xf8, 0xd3, 0x98, 0x3c,
+	0x11, 0xa5, 0xd8, 0xa6, 0x93, 0x45, 0x30, 0xc7, 0x72, 0xea, 0x89, 0xfa, 0x5c, 0xd3, 0xe1, 0x0d,
+	0x7a, 0xbd, 0x98, 0x91, 0x2e, 0x66, 0x66, 0x02, 0x6b, 0x85, 0xe7, 0x52, 0xea, 0x44, 0xf8, 0x8f,
+	0xe1, 0x54, 0x9f, 0x0a, 0x40, 0xb3, 0x21, 0x71, 0x6e, 0xdd, 0x4b, 0xfc, 0x21, 0xfb, 0x99, 0xdc,
+	0xe6, 0xbe, 0xfc, 0x99, 0x9c, 0x8c, 0x68, 0x92, 0xce, 0xbe, 0x0a, 0xeb, 0x79, 0xdd, 0x09, 0x65,
+	0xbe, 0x81, 0x2b, 0x33, 0xce, 0xb4, 0xe7, 0xa8, 0x43, 0x27, 0x5e, 0xf6, 0x7b, 0x8e, 0x3e, 0x8a,
+	0xd0, 0xbe, 0x06, 0x57, 0xf3, 0xbb, 0x14, 0x2a, 0xbd, 0x80, 0xd5, 0x82, 0x53, 0xb1, 0xd9, 0x61,
+	0x69, 0xde, 0x0e, 0xd7, 0xc1, 0xca, 0x0a, 0x14, 0x9d, 0xfd, 0x14, 0x9a, 0x4f, 0x0e, 0xf6, 0xd4,
+	0x8f, 0x03, 0xb5, 0xc2, 0xbb, 0x28, 0x1a, 0x25, 0xb9, 0x59, 0x59, 0xcb, 0xcd, 0xec, 0x0e, 0xb4,
+	0x04, 0x9f, 0x10, 0xf4, 0x39, 0x74, 0x9f, 0x1c, 0xf0, 0x13, 0x8d, 0x92, 0x26, 0x2d, 0xb3, 0xa4,
+	0x2c, 0x53, 0x2b, 0xcf, 0x8b, 0x5b, 0x34, 0xde, 0xa2, 0xee, 0x48, 0x17, 0x20, 0xc4, 0xde, 0xa0,
+	0xfa, 0x6d, 0xcf, 0xd0, 0xcf, 0x7e, 0x1b, 0x5a, 0x82, 0x42, 0x39, 0x57, 0xae, 0x70, 0x49, 0x57,
+	0xf8, 0x7e, 0xa2, 0xdf, 0xf6, 0x6c, 0xfd, 0x2c, 0x58, 0x64, 0xee, 0x87, 0xc8, 0xa7, 0x35, 0xb2,
+	0x49, 0x9f, 0x37, 0xe8, 0x22, 0x94, 0x4f, 0x13, 0xe3, 0x29, 0xe9, 0xe3, 0x99, 0x21, 0xe7, 0x4d,
+	0xe8, 0x3c, 0x39, 0x10, 0x81, 0xa9, 0x70, 0x58, 0x18, 0x90, 0x22, 0x12, 0x93, 0xc1, 0x18, 0xd9,
+	0x4b, 0xab, 0x71, 0x31, 0xe3, 0x4d, 0x40, 0x8a, 0x68, 0xe6, 0x94, 0xfc, 0x0c, 0xba, 0xb2, 0x8b,
+	0x9d, 0xa3, 0x8b, 0x6e, 0x80, 0x4d, 0xc0, 0x3a, 0xf3, 0xb9, 0xa1, 0x75, 0x03, 0x7a, 0x62, 0xf2,
+	0xcc, 0x91, 0xe7, 0x2c, 0x01, 0xbd, 0x8e, 0x4f, 0xd1, 0x8a, 0x09, 0xf8, 0x8c, 0x0a, 0x61, 0xc1,
+	0xdc, 0x14, 0x32, 0x67, 0x90, 0xe2, 0x82, 0x0d, 0x7e, 0x21, 0xf8, 0xaf, 0x4b, 0x6c, 0x3f, 0x0f,
+	0x5c, 0xef, 0xa2, 0x71, 0xaf, 0x07, 0xb5, 0xf1, 0x68, 0x32, 0x8a, 0xc5, 0xf1, 0x83, 0x37, 0xe8,
+	0xc9, 0x84, 0x7d, 0x3c, 0x38, 0x8b, 0xd9, 0x55, 0x2f, 0x45, 0x69, 0x10, 0xea, 0x57, 0x5e, 0x8f,
+	0xe2, 0x93, 0x03, 0x36, 0xaf, 0xfc, 0x22, 0x54, 0x01, 0x28, 0xd6, 0xf7, 0xc6, 0x67, 0x7d, 0x56,
+	0xe5, 0x5e, 0xe0, 0xd8, 0x04, 0x60, 0xff, 0x49, 0x09, 0xda, 0x52, 0x57, 0x31, 0xed, 0x17, 0xb0,
+	0x33, 0x55, 0x3e, 0x17, 0x0a, 0xb3, 0x06, 0xed, 0x92, 0x9e, 0x2b, 0xf8, 0xd2, 0xf1, 0x3b, 0x2c,
+	0x05, 0x60, 0x97, 0x58, 0xac, 0x60, 0xeb, 0x0d, 0x93, 0x4b, 0x2c, 0xd1, 0xb6, 0x7f, 0x01, 0x96,
+	0x58, 0xac, 0x67, 0xa3, 0x53, 0x32, 0x64, 0xfe, 0x4c, 0x4e, 0xe2, 0xa7, 0x99, 0x3c, 0x4e, 0x16,
+	0x5b, 0x9f, 0x1c, 0x64, 0xa8, 0xd3, 0xe9, 0x9c, 0xfd, 0x35, 0xac, 0xe5, 0x48, 0x16, 0x43, 0xfe,
+	0x3c, 0x5b, 0x90, 0xbf, 0x92, 0x2b, 0xbb, 0xa8, 0x38, 0xff, 0xeb, 0x12, 0x2c, 0xe7, 0x68, 0xc1,
+	0x92, 0x48, 0x5e, 0xbc, 0x92, 0xc7, 0x03, 0xd1, 0xc4, 0xb7, 0xe8, 0x4b, 0x8d, 0x58, 0x38, 0xfa,
+	0xe5, 0xa4, 0x33, 0xe5, 0xef, 0x44, 0x27, 0x94, 0x0a, 0x7f, 0x00, 0x0b, 0x7c, 0xeb, 0x8b, 0x9b,
+	0x91, 0x95, 0x84, 0xde, 0xd8, 0xba, 0x32, 0x41, 0xe2, 0xb4, 0xb8, 0x0f, 0x4b, 0xa1, 0xda, 0x9e,
+	0xe2, 0xa6, 0x4a, 0x8d, 0x2b, 0xbb, 0xf5, 0x65, 0x6a, 0xa9, 0x71, 0xd9, 0xff, 0x5e, 0x82, 0x9e,
+	0x39, 0x32, 0x65, 0x9d, 0xff, 0xb7, 0x87, 0xb6, 0xf1, 0x5f, 0x0d, 0xa8, 0x32, 0x85, 0x2f, 0x43,
+	0x97, 0xfe, 0x75, 0xc8, 0xf1, 0x88, 0x3d, 0x81, 0x88, 0xfd, 0x90, 0xa0, 0x4b, 0x78, 0x0d, 0x2e,
+	0x53, 0x70, 0xe6, 0x97, 0x15, 0xa8, 0x54, 0x80, 0x8a, 0x02, 0x54, 0x4e, 0x50, 0xe9, 0xf7, 0xd5,
+	0xa8, 0x52, 0x80, 0x8a, 0x02, 0x54, 0xc5, 0xcb, 0xd0, 0xa1, 0x28, 0xed, 0xbd, 0x37, 0xaa, 0x65,
+	0x80, 0x51, 0x80, 0x16, 0x24, 0x50, 0x7b, 0x3d, 0x8d, 0x16, 0x33, 0xc0, 0x28, 0x40, 0x75, 0x8c,
+	0xa1, 0x4d, 0x81, 0xea, 0xcd, 0x33, 0x6a, 0xa4, 0x61, 0x51, 0x80, 0x00, 0x5b, 0xd0, 0x63, 0xb0,
+	0xd4, 0x3b, 0x67, 0xb4, 0x94, 0x8f, 0x89, 0x02, 0xd4, 0xc4, 0x57, 0x60, 0x95, 0x62, 0x72, 0xde,
+	0x25, 0xa3, 0x56, 0x21, 0x32, 0x0a, 0x50, 0x1b, 0xaf, 0xc3, 0x0a, 0x9f, 0xec, 0xf4, 0xeb, 0x5c,
+	0xd4, 0x29, 0xc2, 0x45, 0x01, 0x42, 0x52, 0x97, 0xf4, 0x3b, 0x62, 0xd4, 0xcd, 0xc7, 0x44, 0x01,
+	0xc2, 0x12, 0x93, 0x7e, 0x36, 0x8b, 0x96, 0xe5, 0x84, 0x69, 0x6f, 0xa7, 0x50, 0x0f, 0xaf, 0xc2,
+	0xb2, 0x22, 0x4f, 0x5e, 0xb6, 0xa2, 0xcb, 0xb9, 0x88, 0x28, 0x40, 0x2b, 0x12, 0x91, 0x7a, 0x0b,
+	0x8b, 0x56, 0x73, 0x11, 0x51, 0x80, 0x2c, 0x39, 0xc4, 0xec, 0xe3, 0x57, 0xb4, 0x56, 0x84, 0x8b,
+	0x02, 0xb4, 0x2e, 0xe7, 0x34, 0xe7, 0x81, 0x26, 0xba, 0x52, 0x88, 0x8c, 0x02, 0x74, 0x55, 0x4a,
+	0xcd, 0x3e, 0xbe, 0x44, 0x6f, 0x14, 0xe1, 0xa2, 0x00, 0x5d, 0xc3, 0x3d, 0x40, 0x6a, 0xd0, 0xfc,
+	0xc5, 0x22, 0xba, 0x9e, 0x85, 0x46, 0x01, 0xba, 0x21, 0xa1, 0xfa, 0x1b, 0x49, 0xf4, 0xa3, 0x2c,
+	0x34, 0x0a, 0x90, 0x2d, 0xad, 0xcd, 0x78, 0x0a, 0x89, 0xde, 0xcc, 0x01, 0x47, 0x01, 0x7a, 0x0b,
+	0x5f, 0x87, 0x2b, 0x6c, 0x0b, 0xe6, 0xbf, 0x64, 0x44, 0x6f, 0xcf, 0x24, 0x88, 0x02, 0xf4, 0x8e,
+	0x24, 0x28, 0x78, 0xa0, 0x88, 0xde, 0x9d, 0x49, 0x10, 0x05, 0xe8, 0x26, 0xfe, 0x11, 0xbc, 0x91,
+	0xac, 0x4b, 0xde, 0x7b, 0x5d, 0xf4, 0xe3, 0x73, 0x48, 0xa2, 0x00, 0x6d, 0xe0, 0xab, 0x60, 0x89,
+	0x45, 0xca, 0xbc, 0x5d, 0x44, 0xb7, 0x8a, 0xb1, 0x51, 0x80, 0xde, 0xc3, 0x6f, 0xc0, 0x9a, 0x50,
+	0x31, 0xfb, 0xae, 0x10, 0xfd, 0x64, 0x06, 0x3a, 0x0a, 0xd0, 0xe6, 0xc6, 0x2e, 0x74, 0x84, 0x2a,
+	0xf2, 0xbd, 0x07, 0x6e, 0x40, 0xed, 0xc0, 0x8f, 0x49, 0x88, 0x2e, 0x61, 0x80, 0x05, 0x5e, 0x46,
+	0x45, 0x25, 0xdc, 0x84, 0xfa, 0x97, 0xe2, 0x26, 0x09, 0x95, 0xf1, 0x12, 0x2c, 0x3e, 0x25, 0x6e,
+	0xe8, 0x91, 0x10, 0x55, 0x68, 0xe3, 0xab, 0x51, 0xec, 0x91, 0x28, 0x42, 0xd5, 0x8d, 0xfb, 0xd0,
+	0xcd, 0xbc, 0x97, 0xc1, 0x0b, 0x50, 0xde, 0xf1, 0xd0, 0x25, 0x2a, 0xfb, 0xb9, 0x1f, 0xef, 0x78,
+	0xa8, 0x44, 0x65, 0x3f, 0x3c, 0x1d, 0x45, 0x71, 0x84, 0xca, 0xb8, 0x05, 0x8d, 0xe7, 0x7e, 0x2c,
+	0x9a, 0x95, 0x8d, 0x3b, 0xb0, 0x28, 0xee, 0x75, 0x28, 0x03, 0x8b, 0x2d, 0xe8, 0x12, 0xae, 0x43,
+	0xd5, 0x21, 0xee, 0x10, 0x95, 0x28, 0xf0, 0xfe, 0x70, 0x32, 0xf2, 0x50, 0x19, 0x2f, 0x42, 0x65,
+	0xff, 0xd4, 0x43, 0x95, 0x8d, 0xbf, 0xad, 0xc1, 0xd2, 0x8e, 0x17, 0x93, 0xd0, 0x73, 0xc7, 0xfd,
+	0xc9, 0x90, 0x5a, 0x71, 0x7f, 0x32, 0xd4, 0x0b, 0xdd, 0xe8, 0x12, 0xee, 0x42, 0x8b, 0x01, 0x65,
+	0x05, 0x1a, 0x95, 0xe8, 0xde, 0xa2, 0x7d, 0x19, 0x45, 0x63, 0x54, 0x16, 0x94, 0xca, 0xb5, 0xa1,
+	0x9a, 0xa0, 0x34, 0xab, 0x96, 0xdc, 0xe9, 0x26, 0x60, 0x36, 0xf0, 0x08, 0x2d, 0x52, 0x1b, 0x4f,
+	0x80, 0x2a, 0x69, 0x47, 0x75, 0x21, 0x57, 0x55, 0x05, 0x51, 0x03, 0xaf, 0x00, 0xee, 0x4f, 0x86,
+	0xa9, 0x9a, 0x1d, 0x02, 0x01, 0x4f, 0x95, 0xcd, 0xd0, 0x92, 0x10, 0xa1, 0x8a, 0x5c, 0xa8, 0x49,
+	0x5d, 0x77, 0x7f, 0x32, 0xd4, 0x6a, 0x50, 0xa8, 0x85, 0xdb, 0x00, 0x6c, 0x04, 0xac, 0xa8, 0x84,
+	0x3a, 0x82, 0x46, 0xab, 0x12, 0x21, 0x24, 0x44, 0xa9, 0xea, 0x0c, 0xea, 0xd2, 0x65, 0xee, 0x4f,
+	0x86, 0xac, 0x9c, 0x82, 0xb0, 0xd0, 0x21, 0x55, 0x10, 0x40, 0x43, 0x01, 0x4f, 0x65, 0xde, 0x88,
+	0x26, 0x00, 0x88, 0x77, 0xc2, 0xf3, 0x60, 0x9a, 0x02, 0xa2, 0x23, 0x39, 0x12, 0x95, 0x8c, 0x32,
+	0xf8, 0xb1, 0x98, 0xa5, 0x74, 0xce, 0x88, 0x4e, 0x70, 0x8b, 0x29, 0xc1, 0xce, 0x05, 0xe8, 0xdb,
+	0x12, 0xc6, 0x4c, 0x4d, 0x95, 0xb5, 0xa1, 0x5f, 0x96, 0x12, 0x92, 0x6d, 0x12, 0xa3, 0x5f, 0xa5,
+	0x48, 0x28, 0xec, 0x1f, 0x4a, 0x18, 0xc1, 0x12, 0x83, 0x71, 0x35, 0xd1, 0x3f, 0xd2, 0xc5, 0x46,
+	0x8a, 0x4a, 0x80, 0xff, 0x49, 0x81, 0xb5, 0xb3, 0x01, 0xfa, 0xe7, 0x12, 0x6e, 0x43, 0x83, 0x6b,
+	0x31, 0x70, 0x3d, 0xf4, 0x2f, 0x34, 0xb2, 0xf7, 0x14, 0xb7, 0x3a, 0xf6, 0xa0, 0xef, 0x54, 0x57,
+	0x3c, 0x1f, 0x42, 0xff, 0xaa, 0x14, 0x92, 0xa9, 0x0b, 0xfa, 0x37, 0x49, 0xe5, 0x90, 0x88, 0x84,
+	0xaf, 0xc8, 0x10, 0xfd, 0xf7, 0xe2, 0xc6, 0x23, 0xe8, 0x88, 0x53, 0x88, 0xbc, 0x5f, 0xa5, 0xeb,
+	0xf4, 0xdc, 0x0f, 0x27, 0xee, 0x58, 0x42, 0xd0, 0x25, 0x8c, 0xa0, 0xf9, 0x68, 0x74, 0x7c, 0x92,
+	0x40, 0x4a, 0xb8, 0x03, 0x4b, 0x4f, 0xfd, 0xd7, 0x09, 0xa0, 0xbc, 0xf1, 0x31, 0x34, 0xf5, 0xea,
+	0x36, 0x35, 0x8c, 0xfb, 0xc3, 0x21, 0xb7, 0x61, 0xee, 0x65, 0xb9, 0xe1, 0xd0, 0xde, 0x63, 0x54,
+	0xa6, 0x9f, 0x74, 0xe2, 0x43, 0x54, 0xd9, 0xd8, 0x85, 0x65, 0xe1, 0x03, 0x8c, 0xe7, 0x07, 0x08,
+	0x9a, 0xbc, 0x2d, 0x8c, 0xe2, 0x92, 0x82, 0x38, 0xae, 0x37, 0xf4, 0x27, 0xdc, 0x7a, 0x12, 0x9a,
+	0x88, 0x3c, 0x62, 0xe5, 0x6a, 0x54, 0x7e, 0x80, 0xbe, 0xfb, 0xcf, 0x6b, 0x97, 0xbe, 0xfd, 0xe1,
+	0x5a, 0xe9, 0xbb, 0x1f, 0xae, 0x95, 0xfe, 0xe3, 0x87, 0x6b, 0xa5, 0xc3, 0x05, 0xf6, 0xdf, 0x04,
+	0xdd, 0xfd, 0xdf, 0x01, 0x00, 0xe0, 0x99, 0x6b, 0x10, 0x59, 0x49, 0x00, 0x00,
+}
+
+func (m *ProphetRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8221,7 +9387,7 @@ func (m *ReportDestroyedReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ReportDestroyedReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *ProphetRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -8231,87 +9397,239 @@ func (m *ReportDestroyedReq) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
 	}
-	if m.ReplicaID != 0 {
+	if m.StoreID != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ReplicaID))
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreID))
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.Type != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
 	}
-	return i, nil
-}
-
-func (m *ReportDestroyedRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardHeartbeat.Size()))
+	n1, err := m.ShardHeartbeat.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ReportDestroyedRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.State != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.State))
+	i += n1
+	dAtA[i] = 0x2a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreHeartbeat.Size()))
+	n2, err := m.StoreHeartbeat.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	i += n2
+	dAtA[i] = 0x32
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutStore.Size()))
+	n3, err := m.PutStore.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	return i, nil
-}
-
-func (m *SplitID) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	i += n3
+	dAtA[i] = 0x3a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetStore.Size()))
+	n4, err := m.GetStore.MarshalTo(dAtA[i:])
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *SplitID) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.NewID != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.NewID))
+	i += n4
+	dAtA[i] = 0x42
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AllocID.Size()))
+	n5, err := m.AllocID.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if len(m.NewReplicaIDs) > 0 {
-		dAtA58 := make([]byte, len(m.NewReplicaIDs)*10)
-		var j57 int
-		for _, num := range m.NewReplicaIDs {
-			for num >= 1<<7 {
-				dAtA58[j57] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j57++
-			}
-			dAtA58[j57] = uint8(num)
-			j57++
-		}
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j57))
-		i += copy(dAtA[i:], dAtA58[:j57])
+	i += n5
+	dAtA[i] = 0x4a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskBatchSplit.Size()))
+	n6, err := m.AskBatchSplit.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n6
+	dAtA[i] = 0x52
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateDestroying.Size()))
+	n7, err := m.CreateDestroying.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n7
+	dAtA[i] = 0x5a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ReportDestroyed.Size()))
+	n8, err := m.ReportDestroyed.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n8
+	dAtA[i] = 0x62
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetDestroying.Size()))
+	n9, err := m.GetDestroying.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
+	dAtA[i] = 0x6a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateWatcher.Size()))
+	n10, err := m.CreateWatcher.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n10
+	dAtA[i] = 0x72
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateShards.Size()))
+	n11, err := m.CreateShards.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n11
+	dAtA[i] = 0x7a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveShards.Size()))
+	n12, err := m.RemoveShards.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n12
+	dAtA[i] = 0x82
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckShardState.Size()))
+	n13, err := m.CheckShardState.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n13
+	dAtA[i] = 0x8a
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutPlacementRule.Size()))
+	n14, err := m.PutPlacementRule.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n14
+	dAtA[i] = 0x92
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetAppliedRules.Size()))
+	n15, err := m.GetAppliedRules.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n15
+	dAtA[i] = 0x9a
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateJob.Size()))
+	n16, err := m.CreateJob.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n16
+	dAtA[i] = 0xa2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveJob.Size()))
+	n17, err := m.RemoveJob.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n17
+	dAtA[i] = 0xaa
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ExecuteJob.Size()))
+	n18, err := m.ExecuteJob.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n18
+	dAtA[i] = 0xb2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AddScheduleGroupRule.Size()))
+	n19, err := m.AddScheduleGroupRule.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n19
+	dAtA[i] = 0xba
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetScheduleGroupRule.Size()))
+	n20, err := m.GetScheduleGroupRule.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n20
+	dAtA[i] = 0xc2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckTombstoneReplicas.Size()))
+	n21, err := m.CheckTombstoneReplicas.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n21
+	dAtA[i] = 0xca
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutClusterSetting.Size()))
+	n22, err := m.PutClusterSetting.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n22
+	dAtA[i] = 0xd2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetClusterSettings.Size()))
+	n23, err := m.GetClusterSettings.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n23
+	dAtA[i] = 0xda
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ScatterShards.Size()))
+	n133, err := m.ScatterShards.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n133
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *CreateWatcherReq) Marshal() (dAtA []byte, err error) {
+func (m *ProphetResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8321,90 +9639,258 @@ func (m *CreateWatcherReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateWatcherReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *ProphetResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Flag != 0 {
+	if m.ID != 0 {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Flag))
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.Type != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
 	}
-	return i, nil
-}
-
-func (m *CreateShardsReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	if len(m.Leader) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Leader)))
+		i += copy(dAtA[i:], m.Leader)
+	}
+	dAtA[i] = 0x2a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardHeartbeat.Size()))
+	n24, err := m.ShardHeartbeat.MarshalTo(dAtA[i:])
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CreateShardsReq) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Shards) > 0 {
-		for _, b := range m.Shards {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
+	i += n24
+	dAtA[i] = 0x32
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreHeartbeat.Size()))
+	n25, err := m.StoreHeartbeat.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if len(m.LeastReplicas) > 0 {
-		dAtA60 := make([]byte, len(m.LeastReplicas)*10)
-		var j59 int
-		for _, num := range m.LeastReplicas {
-			for num >= 1<<7 {
-				dAtA60[j59] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j59++
-			}
-			dAtA60[j59] = uint8(num)
-			j59++
-		}
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j59))
-		i += copy(dAtA[i:], dAtA60[:j59])
+	i += n25
+	dAtA[i] = 0x3a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutStore.Size()))
+	n26, err := m.PutStore.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	i += n26
+	dAtA[i] = 0x42
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetStore.Size()))
+	n27, err := m.GetStore.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	return i, nil
-}
-
-func (m *CreateShardsRsp) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	i += n27
+	dAtA[i] = 0x4a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AllocID.Size()))
+	n28, err := m.AllocID.MarshalTo(dAtA[i:])
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CreateShardsRsp) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
+	i += n28
+	dAtA[i] = 0x52
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AskBatchSplit.Size()))
+	n29, err := m.AskBatchSplit.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n29
+	dAtA[i] = 0x5a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateDestroying.Size()))
+	n30, err := m.CreateDestroying.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n30
+	dAtA[i] = 0x62
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ReportDestroyed.Size()))
+	n31, err := m.ReportDestroyed.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n31
+	dAtA[i] = 0x6a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetDestroying.Size()))
+	n32, err := m.GetDestroying.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n32
+	dAtA[i] = 0x72
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Event.Size()))
+	n33, err := m.Event.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n33
+	dAtA[i] = 0x7a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateShards.Size()))
+	n34, err := m.CreateShards.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n34
+	dAtA[i] = 0x82
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveShards.Size()))
+	n35, err := m.RemoveShards.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n35
+	dAtA[i] = 0x8a
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckShardState.Size()))
+	n36, err := m.CheckShardState.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n36
+	dAtA[i] = 0x92
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutPlacementRule.Size()))
+	n37, err := m.PutPlacementRule.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n37
+	dAtA[i] = 0x9a
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetAppliedRules.Size()))
+	n38, err := m.GetAppliedRules.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n38
+	dAtA[i] = 0xa2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CreateJob.Size()))
+	n39, err := m.CreateJob.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n39
+	dAtA[i] = 0xaa
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RemoveJob.Size()))
+	n40, err := m.RemoveJob.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n40
+	dAtA[i] = 0xb2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ExecuteJob.Size()))
+	n41, err := m.ExecuteJob.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n41
+	dAtA[i] = 0xba
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.AddScheduleGroupRule.Size()))
+	n42, err := m.AddScheduleGroupRule.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n42
+	dAtA[i] = 0xc2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetScheduleGroupRule.Size()))
+	n43, err := m.GetScheduleGroupRule.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n43
+	dAtA[i] = 0xca
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckTombstoneReplicas.Size()))
+	n44, err := m.CheckTombstoneReplicas.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n44
+	dAtA[i] = 0xd2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.PutClusterSetting.Size()))
+	n45, err := m.PutClusterSetting.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n45
+	dAtA[i] = 0xda
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.GetClusterSettings.Size()))
+	n46, err := m.GetClusterSettings.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n46
+	dAtA[i] = 0xe2
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ScatterShards.Size()))
+	n134, err := m.ScatterShards.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n134
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *RemoveShardsReq) Marshal() (dAtA []byte, err error) {
+func (m *ShardHeartbeatReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8414,35 +9900,104 @@ func (m *RemoveShardsReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RemoveShardsReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *ShardHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.IDs) > 0 {
-		dAtA62 := make([]byte, len(m.IDs)*10)
-		var j61 int
-		for _, num := range m.IDs {
-			for num >= 1<<7 {
-				dAtA62[j61] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j61++
-			}
-			dAtA62[j61] = uint8(num)
-			j61++
-		}
-		dAtA[i] = 0xa
+	if m.StoreID != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j61))
-		i += copy(dAtA[i:], dAtA62[:j61])
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreID))
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.Shard) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Shard)))
+		i += copy(dAtA[i:], m.Shard)
 	}
-	return i, nil
-}
+	if m.Term != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Term))
+	}
+	if m.Leader != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Leader.Size()))
+		n47, err := m.Leader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n47
+	}
+	if len(m.DownReplicas) > 0 {
+		for _, msg := range m.DownReplicas {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.PendingReplicas) > 0 {
+		for _, msg := range m.PendingReplicas {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	dAtA[i] = 0x3a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
+	n48, err := m.Stats.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n48
+	if len(m.GroupKey) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.GroupKey)))
+		i += copy(dAtA[i:], m.GroupKey)
+	}
+	if m.Lease != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
+		n49, err := m.Lease.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n49
+	}
+	if len(m.BlockingReasons) > 0 {
+		for _, msg := range m.BlockingReasons {
+			dAtA[i] = 0x52
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
 
-func (m *RemoveShardsRsp) Marshal() (dAtA []byte, err error) {
+func (m *ShardHeartbeatRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8452,18 +10007,111 @@ func (m *RemoveShardsRsp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RemoveShardsRsp) MarshalTo(dAtA []byte) (int, error) {
+func (m *ShardHeartbeatRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardID))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardEpoch.Size()))
+	n50, err := m.ShardEpoch.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n50
+	if m.TargetReplica != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.TargetReplica.Size()))
+		n51, err := m.TargetReplica.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n51
+	}
+	if m.ConfigChange != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ConfigChange.Size()))
+		n52, err := m.ConfigChange.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n52
+	}
+	if m.TransferLeader != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.TransferLeader.Size()))
+		n53, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n53
+	}
+	if m.Merge != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Merge.Size()))
+		n54, err := m.Merge.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n54
+	}
+	if m.SplitShard != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.SplitShard.Size()))
+		n55, err := m.SplitShard.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n55
+	}
+	if m.ConfigChangeV2 != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ConfigChangeV2.Size()))
+		n56, err := m.ConfigChangeV2.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n56
+	}
+	if m.TransferLease != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.TransferLease.Size()))
+		n57, err := m.TransferLease.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n57
+	}
+	if m.DestroyDirectly {
+		dAtA[i] = 0x50
+		i++
+		if m.DestroyDirectly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *CheckShardStateReq) Marshal() (dAtA []byte, err error) {
+func (m *PutStoreReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8473,16 +10121,16 @@ func (m *CheckShardStateReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CheckShardStateReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *PutStoreReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.IDs) > 0 {
+	if len(m.Store) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.IDs)))
-		i += copy(dAtA[i:], m.IDs)
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Store)))
+		i += copy(dAtA[i:], m.Store)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8490,7 +10138,7 @@ func (m *CheckShardStateReq) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *CheckShardStateRsp) Marshal() (dAtA []byte, err error) {
+func (m *PutStoreRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8500,22 +10148,16 @@ func (m *CheckShardStateRsp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CheckShardStateRsp) MarshalTo(dAtA []byte) (int, error) {
+func (m *PutStoreRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Destroyed) > 0 {
+	if len(m.DestroyShards) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Destroyed)))
-		i += copy(dAtA[i:], m.Destroyed)
-	}
-	if len(m.Destroying) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Destroying)))
-		i += copy(dAtA[i:], m.Destroying)
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.DestroyShards)))
+		i += copy(dAtA[i:], m.DestroyShards)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8523,7 +10165,7 @@ func (m *CheckShardStateRsp) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *PutPlacementRuleReq) Marshal() (dAtA []byte, err error) {
+func (m *StoreHeartbeatReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8533,26 +10175,32 @@ func (m *PutPlacementRuleReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PutPlacementRuleReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *StoreHeartbeatReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
 	dAtA[i] = 0xa
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Rule.Size()))
-	n63, err := m.Rule.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
+	n58, err := m.Stats.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n63
+	i += n58
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *PutPlacementRuleRsp) Marshal() (dAtA []byte, err error) {
+func (m *StoreHeartbeatRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8562,18 +10210,24 @@ func (m *PutPlacementRuleRsp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PutPlacementRuleRsp) MarshalTo(dAtA []byte) (int, error) {
+func (m *StoreHeartbeatRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *GetAppliedRulesReq) Marshal() (dAtA []byte, err error) {
+func (m *GetStoreReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8583,15 +10237,15 @@ func (m *GetAppliedRulesReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetAppliedRulesReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetStoreReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.ShardID != 0 {
+	if m.ID != 0 {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardID))
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8599,7 +10253,7 @@ func (m *GetAppliedRulesReq) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *GetAppliedRulesRsp) Marshal() (dAtA []byte, err error) {
+func (m *GetStoreRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8609,22 +10263,26 @@ func (m *GetAppliedRulesRsp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetAppliedRulesRsp) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetStoreRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Rules) > 0 {
-		for _, msg := range m.Rules {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if m.Stats != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Stats.Size()))
+		n59, err := m.Stats.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n59
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8632,7 +10290,7 @@ func (m *GetAppliedRulesRsp) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *CreateJobReq) Marshal() (dAtA []byte, err error) {
+func (m *AllocIDReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8642,26 +10300,18 @@ func (m *CreateJobReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateJobReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *AllocIDReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
-	n64, err := m.Job.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n64
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *CreateJobRsp) Marshal() (dAtA []byte, err error) {
+func (m *AllocIDRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8671,18 +10321,23 @@ func (m *CreateJobRsp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CreateJobRsp) MarshalTo(dAtA []byte) (int, error) {
+func (m *AllocIDRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.ID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *RemoveJobReq) Marshal() (dAtA []byte, err error) {
+func (m *AskBatchSplitReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8692,26 +10347,29 @@ func (m *RemoveJobReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RemoveJobReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *AskBatchSplitReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
-	n65, err := m.Job.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Count))
 	}
-	i += n65
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *RemoveJobRsp) Marshal() (dAtA []byte, err error) {
+func (m *AskBatchSplitRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8721,18 +10379,30 @@ func (m *RemoveJobRsp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RemoveJobRsp) MarshalTo(dAtA []byte) (int, error) {
+func (m *AskBatchSplitRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.SplitIDs) > 0 {
+		for _, msg := range m.SplitIDs {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ExecuteJobReq) Marshal() (dAtA []byte, err error) {
+func (m *CreateDestroyingReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8742,24 +10412,47 @@ func (m *ExecuteJobReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ExecuteJobReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateDestroyingReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
-	n66, err := m.Job.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.ID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
 	}
-	i += n66
-	if len(m.Data) > 0 {
-		dAtA[i] = 0x12
+	if m.Index != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Index))
+	}
+	if len(m.Replicas) > 0 {
+		dAtA61 := make([]byte, len(m.Replicas)*10)
+		var j60 int
+		for _, num := range m.Replicas {
+			for num >= 1<<7 {
+				dAtA61[j60] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j60++
+			}
+			dAtA61[j60] = uint8(num)
+			j60++
+		}
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(j60))
+		i += copy(dAtA[i:], dAtA61[:j60])
+	}
+	if m.RemoveData {
+		dAtA[i] = 0x20
+		i++
+		if m.RemoveData {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8767,7 +10460,7 @@ func (m *ExecuteJobReq) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ExecuteJobRsp) Marshal() (dAtA []byte, err error) {
+func (m *CreateDestroyingRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8777,16 +10470,15 @@ func (m *ExecuteJobRsp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ExecuteJobRsp) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateDestroyingRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Data) > 0 {
-		dAtA[i] = 0xa
+	if m.State != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.State))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8794,7 +10486,7 @@ func (m *ExecuteJobRsp) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *AddScheduleGroupRuleReq) Marshal() (dAtA []byte, err error) {
+func (m *GetDestroyingReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8804,26 +10496,23 @@ func (m *AddScheduleGroupRuleReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AddScheduleGroupRuleReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetDestroyingReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Rule.Size()))
-	n67, err := m.Rule.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.ID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
 	}
-	i += n67
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *AddScheduleGroupRuleRsp) Marshal() (dAtA []byte, err error) {
+func (m *GetDestroyingRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8833,18 +10522,28 @@ func (m *AddScheduleGroupRuleRsp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AddScheduleGroupRuleRsp) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetDestroyingRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if m.Status != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Status.Size()))
+		n62, err := m.Status.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n62
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *GetScheduleGroupRuleReq) Marshal() (dAtA []byte, err error) {
+func (m *ReportDestroyedReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8854,18 +10553,28 @@ func (m *GetScheduleGroupRuleReq) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetScheduleGroupRuleReq) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReportDestroyedReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if m.ID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ID))
+	}
+	if m.ReplicaID != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ReplicaID))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *GetScheduleGroupRuleRsp) Marshal() (dAtA []byte, err error) {
+func (m *ReportDestroyedRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8875,22 +10584,15 @@ func (m *GetScheduleGroupRuleRsp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetScheduleGroupRuleRsp) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReportDestroyedRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Rules) > 0 {
-		for _, msg := range m.Rules {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if m.State != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.State))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8898,7 +10600,7 @@ func (m *GetScheduleGroupRuleRsp) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *EventNotify) Marshal() (dAtA []byte, err error) {
+func (m *SplitID) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8908,70 +10610,58 @@ func (m *EventNotify) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *EventNotify) MarshalTo(dAtA []byte) (int, error) {
+func (m *SplitID) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Seq != 0 {
+	if m.NewID != 0 {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Seq))
-	}
-	if m.Type != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.NewID))
 	}
-	if m.InitEvent != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.InitEvent.Size()))
-		n68, err := m.InitEvent.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.NewReplicaIDs) > 0 {
+		dAtA64 := make([]byte, len(m.NewReplicaIDs)*10)
+		var j63 int
+		for _, num := range m.NewReplicaIDs {
+			for num >= 1<<7 {
+				dAtA64[j63] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j63++
+			}
+			dAtA64[j63] = uint8(num)
+			j63++
 		}
-		i += n68
-	}
-	if m.ShardEvent != nil {
-		dAtA[i] = 0x22
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardEvent.Size()))
-		n69, err := m.ShardEvent.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n69
+		i = encodeVarintRpcpb(dAtA, i, uint64(j63))
+		i += copy(dAtA[i:], dAtA64[:j63])
 	}
-	if m.StoreEvent != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreEvent.Size()))
-		n70, err := m.StoreEvent.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n70
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ShardStatsEvent != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardStatsEvent.Size()))
-		n71, err := m.ShardStatsEvent.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n71
+	return i, nil
+}
+
+func (m *CreateWatcherReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.StoreStatsEvent != nil {
-		dAtA[i] = 0x3a
+	return dAtA[:n], nil
+}
+
+func (m *CreateWatcherReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Flag != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreStatsEvent.Size()))
-		n72, err := m.StoreStatsEvent.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n72
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Flag))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -8979,7 +10669,7 @@ func (m *EventNotify) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *InitEventData) Marshal() (dAtA []byte, err error) {
+func (m *CreateShardsReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -8989,7 +10679,7 @@ func (m *InitEventData) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *InitEventData) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateShardsReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -9002,42 +10692,22 @@ func (m *InitEventData) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], b)
 		}
 	}
-	if len(m.Stores) > 0 {
-		for _, b := range m.Stores {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
-	}
-	if len(m.LeaderReplicaIDs) > 0 {
-		dAtA74 := make([]byte, len(m.LeaderReplicaIDs)*10)
-		var j73 int
-		for _, num := range m.LeaderReplicaIDs {
+	if len(m.LeastReplicas) > 0 {
+		dAtA66 := make([]byte, len(m.LeastReplicas)*10)
+		var j65 int
+		for _, num := range m.LeastReplicas {
 			for num >= 1<<7 {
-				dAtA74[j73] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA66[j65] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j73++
+				j65++
 			}
-			dAtA74[j73] = uint8(num)
-			j73++
+			dAtA66[j65] = uint8(num)
+			j65++
 		}
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j73))
-		i += copy(dAtA[i:], dAtA74[:j73])
-	}
-	if len(m.Leases) > 0 {
-		for _, msg := range m.Leases {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+		i = encodeVarintRpcpb(dAtA, i, uint64(j65))
+		i += copy(dAtA[i:], dAtA66[:j65])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9045,7 +10715,7 @@ func (m *InitEventData) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ShardEventData) Marshal() (dAtA []byte, err error) {
+func (m *CreateShardsRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9055,59 +10725,18 @@ func (m *ShardEventData) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ShardEventData) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateShardsRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Data) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
-	}
-	if m.LeaderReplicaID != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.LeaderReplicaID))
-	}
-	if m.Lease != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-		n75, err := m.Lease.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n75
-	}
-	if m.Removed {
-		dAtA[i] = 0x20
-		i++
-		if m.Removed {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.Create {
-		dAtA[i] = 0x28
-		i++
-		if m.Create {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *StoreEventData) Marshal() (dAtA []byte, err error) {
+func (m *RemoveShardsReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9117,16 +10746,27 @@ func (m *StoreEventData) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StoreEventData) MarshalTo(dAtA []byte) (int, error) {
+func (m *RemoveShardsReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Data) > 0 {
+	if len(m.IDs) > 0 {
+		dAtA68 := make([]byte, len(m.IDs)*10)
+		var j67 int
+		for _, num := range m.IDs {
+			for num >= 1<<7 {
+				dAtA68[j67] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j67++
+			}
+			dAtA68[j67] = uint8(num)
+			j67++
+		}
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
-		i += copy(dAtA[i:], m.Data)
+		i = encodeVarintRpcpb(dAtA, i, uint64(j67))
+		i += copy(dAtA[i:], dAtA68[:j67])
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9134,7 +10774,7 @@ func (m *StoreEventData) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ConfigChange) Marshal() (dAtA []byte, err error) {
+func (m *RemoveShardsRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9144,23 +10784,37 @@ func (m *ConfigChange) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConfigChange) MarshalTo(dAtA []byte) (int, error) {
+func (m *RemoveShardsRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
-	n76, err := m.Replica.MarshalTo(dAtA[i:])
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *CheckShardStateReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n76
-	if m.ChangeType != 0 {
-		dAtA[i] = 0x10
+	return dAtA[:n], nil
+}
+
+func (m *CheckShardStateReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.IDs) > 0 {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ChangeType))
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.IDs)))
+		i += copy(dAtA[i:], m.IDs)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9168,7 +10822,7 @@ func (m *ConfigChange) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
+func (m *CheckShardStateRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9178,26 +10832,30 @@ func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
+func (m *CheckShardStateRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
-	n77, err := m.Replica.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Destroyed) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Destroyed)))
+		i += copy(dAtA[i:], m.Destroyed)
+	}
+	if len(m.Destroying) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Destroying)))
+		i += copy(dAtA[i:], m.Destroying)
 	}
-	i += n77
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *TransferLease) Marshal() (dAtA []byte, err error) {
+func (m *TombstoneReplica) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9207,26 +10865,36 @@ func (m *TransferLease) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TransferLease) MarshalTo(dAtA []byte) (int, error) {
+func (m *TombstoneReplica) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardID))
+	}
+	if m.ReplicaID != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ReplicaID))
+	}
+	dAtA[i] = 0x1a
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-	n78, err := m.Lease.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Epoch.Size()))
+	n69, err := m.Epoch.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n78
+	i += n69
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ConfigChangeV2) Marshal() (dAtA []byte, err error) {
+func (m *CheckTombstoneReplicasReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9236,13 +10904,13 @@ func (m *ConfigChangeV2) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConfigChangeV2) MarshalTo(dAtA []byte) (int, error) {
+func (m *CheckTombstoneReplicasReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Changes) > 0 {
-		for _, msg := range m.Changes {
+	if len(m.Replicas) > 0 {
+		for _, msg := range m.Replicas {
 			dAtA[i] = 0xa
 			i++
 			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
@@ -9259,7 +10927,7 @@ func (m *ConfigChangeV2) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *Merge) Marshal() (dAtA []byte, err error) {
+func (m *CheckTombstoneReplicasRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9269,16 +10937,16 @@ func (m *Merge) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Merge) MarshalTo(dAtA []byte) (int, error) {
+func (m *CheckTombstoneReplicasRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Target) > 0 {
+	if len(m.Removable) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Target)))
-		i += copy(dAtA[i:], m.Target)
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Removable)))
+		i += copy(dAtA[i:], m.Removable)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9286,7 +10954,7 @@ func (m *Merge) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *SplitShard) Marshal() (dAtA []byte, err error) {
+func (m *PutPlacementRuleReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9296,31 +10964,47 @@ func (m *SplitShard) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SplitShard) MarshalTo(dAtA []byte) (int, error) {
+func (m *PutPlacementRuleReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Policy != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Policy))
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Rule.Size()))
+	n70, err := m.Rule.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
+	i += n70
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *PutPlacementRuleRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PutPlacementRuleRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *LabelConstraint) Marshal() (dAtA []byte, err error) {
+func (m *GetAppliedRulesReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9330,35 +11014,47 @@ func (m *LabelConstraint) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *LabelConstraint) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetAppliedRulesReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Key) > 0 {
-		dAtA[i] = 0xa
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardID))
 	}
-	if m.Op != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Op))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.Values) > 0 {
-		for _, s := range m.Values {
-			dAtA[i] = 0x1a
+	return i, nil
+}
+
+func (m *GetAppliedRulesRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAppliedRulesRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Rules) > 0 {
+		for _, msg := range m.Rules {
+			dAtA[i] = 0xa
 			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
 			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+			i += n
 		}
 	}
 	if m.XXX_unrecognized != nil {
@@ -9367,7 +11063,7 @@ func (m *LabelConstraint) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *PlacementRule) Marshal() (dAtA []byte, err error) {
+func (m *CreateJobReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9377,100 +11073,76 @@ func (m *PlacementRule) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PlacementRule) MarshalTo(dAtA []byte) (int, error) {
+func (m *CreateJobReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
-	}
-	if len(m.GroupID) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.GroupID)))
-		i += copy(dAtA[i:], m.GroupID)
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
+	n71, err := m.Job.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.Index != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Index))
+	i += n71
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Override {
-		dAtA[i] = 0x20
-		i++
-		if m.Override {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if len(m.StartKey) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.StartKey)))
-		i += copy(dAtA[i:], m.StartKey)
-	}
-	if len(m.EndKey) > 0 {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.EndKey)))
-		i += copy(dAtA[i:], m.EndKey)
-	}
-	if m.Role != 0 {
-		dAtA[i] = 0x38
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Role))
-	}
-	if m.Count != 0 {
-		dAtA[i] = 0x40
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Count))
+	return i, nil
+}
+
+func (m *CreateJobRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.LabelConstraints) > 0 {
-		for _, msg := range m.LabelConstraints {
-			dAtA[i] = 0x4a
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	return dAtA[:n], nil
+}
+
+func (m *CreateJobRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if len(m.LocationLabels) > 0 {
-		for _, s := range m.LocationLabels {
-			dAtA[i] = 0x52
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return i, nil
+}
+
+func (m *RemoveJobReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.IsolationLevel) > 0 {
-		dAtA[i] = 0x5a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.IsolationLevel)))
-		i += copy(dAtA[i:], m.IsolationLevel)
+	return dAtA[:n], nil
+}
+
+func (m *RemoveJobReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
+	n72, err := m.Job.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n72
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *RequestBatchHeader) Marshal() (dAtA []byte, err error) {
+func (m *RemoveJobRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9480,39 +11152,45 @@ func (m *RequestBatchHeader) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RequestBatchHeader) MarshalTo(dAtA []byte) (int, error) {
+func (m *RemoveJobRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.ShardID != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardID))
+	return i, nil
+}
+
+func (m *ExecuteJobReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[i] = 0x1a
+	return dAtA[:n], nil
+}
+
+func (m *ExecuteJobReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
-	n79, err := m.Replica.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Job.Size()))
+	n73, err := m.Job.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n79
-	if m.Lease != nil {
-		dAtA[i] = 0x22
+	i += n73
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-		n80, err := m.Lease.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n80
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9520,7 +11198,7 @@ func (m *RequestBatchHeader) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ResponseBatchHeader) Marshal() (dAtA []byte, err error) {
+func (m *ExecuteJobRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9530,32 +11208,53 @@ func (m *ResponseBatchHeader) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResponseBatchHeader) MarshalTo(dAtA []byte) (int, error) {
+func (m *ExecuteJobRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.ID) > 0 {
+	if len(m.Data) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
 	}
-	dAtA[i] = 0x12
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *AddScheduleGroupRuleReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddScheduleGroupRuleReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Error.Size()))
-	n81, err := m.Error.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Rule.Size()))
+	n74, err := m.Rule.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n81
+	i += n74
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *RequestBatch) Marshal() (dAtA []byte, err error) {
+func (m *AddScheduleGroupRuleRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9565,22 +11264,56 @@ func (m *RequestBatch) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RequestBatch) MarshalTo(dAtA []byte) (int, error) {
+func (m *AddScheduleGroupRuleRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Header.Size()))
-	n82, err := m.Header.MarshalTo(dAtA[i:])
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetScheduleGroupRuleReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n82
-	if len(m.Requests) > 0 {
-		for _, msg := range m.Requests {
-			dAtA[i] = 0x12
+	return dAtA[:n], nil
+}
+
+func (m *GetScheduleGroupRuleReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *GetScheduleGroupRuleRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetScheduleGroupRuleRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Rules) > 0 {
+		for _, msg := range m.Rules {
+			dAtA[i] = 0xa
 			i++
 			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -9596,7 +11329,7 @@ func (m *RequestBatch) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ResponseBatch) Marshal() (dAtA []byte, err error) {
+func (m *PutClusterSettingReq) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9606,38 +11339,26 @@ func (m *ResponseBatch) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResponseBatch) MarshalTo(dAtA []byte) (int, error) {
+func (m *PutClusterSettingReq) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
 	dAtA[i] = 0xa
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Header.Size()))
-	n83, err := m.Header.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Setting.Size()))
+	n75, err := m.Setting.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n83
-	if len(m.Responses) > 0 {
-		for _, msg := range m.Responses {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
+	i += n75
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *Request) Marshal() (dAtA []byte, err error) {
+func (m *PutClusterSettingRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9647,162 +11368,118 @@ func (m *Request) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Request) MarshalTo(dAtA []byte) (int, error) {
+func (m *PutClusterSettingRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
-	}
-	if m.Group != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Group))
-	}
-	if m.Type != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
-	}
-	if m.CustomType != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.CustomType))
-	}
-	if len(m.Key) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
-	}
-	if len(m.Cmd) > 0 {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Cmd)))
-		i += copy(dAtA[i:], m.Cmd)
-	}
-	if m.PID != 0 {
-		dAtA[i] = 0x38
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.PID))
-	}
-	if m.ToShard != 0 {
-		dAtA[i] = 0x40
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ToShard))
-	}
-	if m.IgnoreEpochCheck {
-		dAtA[i] = 0x48
-		i++
-		if m.IgnoreEpochCheck {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	dAtA[i] = 0x52
+	dAtA[i] = 0xa
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Epoch.Size()))
-	n84, err := m.Epoch.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Setting.Size()))
+	n76, err := m.Setting.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n84
-	if m.Lease != nil {
-		dAtA[i] = 0x5a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-		n85, err := m.Lease.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n85
+	i += n76
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.KeysRange != nil {
-		dAtA[i] = 0x62
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.KeysRange.Size()))
-		n86, err := m.KeysRange.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n86
+	return i, nil
+}
+
+func (m *GetClusterSettingsReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.ReplicaSelectPolicy != 0 {
-		dAtA[i] = 0x68
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ReplicaSelectPolicy))
+	return dAtA[:n], nil
+}
+
+func (m *GetClusterSettingsReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.TxnBatchRequest != nil {
-		dAtA[i] = 0x72
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnBatchRequest.Size()))
-		n87, err := m.TxnBatchRequest.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	return i, nil
+}
+
+func (m *GetClusterSettingsRsp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetClusterSettingsRsp) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Settings) > 0 {
+		for _, msg := range m.Settings {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += n87
 	}
-	dAtA[i] = 0x7a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.UpdateTxnRecord.Size()))
-	n88, err := m.UpdateTxnRecord.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	i += n88
-	dAtA[i] = 0x82
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.DeleteTxnRecord.Size()))
-	n89, err := m.DeleteTxnRecord.MarshalTo(dAtA[i:])
+	return i, nil
+}
+
+func (m *ScatterShardsReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n89
-	dAtA[i] = 0x8a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CommitTxnWriteData.Size()))
-	n90, err := m.CommitTxnWriteData.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	return dAtA[:n], nil
+}
+
+func (m *ScatterShardsReq) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Group != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Group))
 	}
-	i += n90
-	dAtA[i] = 0x92
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RollbackTxnRecord.Size()))
-	n91, err := m.RollbackTxnRecord.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Start) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Start)))
+		i += copy(dAtA[i:], m.Start)
 	}
-	i += n91
-	dAtA[i] = 0x9a
-	i++
-	dAtA[i] = 0x1
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CleanTxnMVCCData.Size()))
-	n92, err := m.CleanTxnMVCCData.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.End) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.End)))
+		i += copy(dAtA[i:], m.End)
 	}
-	i += n92
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *Range) Marshal() (dAtA []byte, err error) {
+func (m *ScatterShardsRsp) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9812,22 +11489,20 @@ func (m *Range) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Range) MarshalTo(dAtA []byte) (int, error) {
+func (m *ScatterShardsRsp) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.From) > 0 {
-		dAtA[i] = 0xa
+	if m.Scattered != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.From)))
-		i += copy(dAtA[i:], m.From)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Scattered))
 	}
-	if len(m.To) > 0 {
-		dAtA[i] = 0x12
+	if m.Failed != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.To)))
-		i += copy(dAtA[i:], m.To)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Failed))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9835,7 +11510,7 @@ func (m *Range) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
+func (m *EventNotify) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9845,105 +11520,80 @@ func (m *Response) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+func (m *EventNotify) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.ID) > 0 {
-		dAtA[i] = 0xa
+	if m.Seq != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ID)))
-		i += copy(dAtA[i:], m.ID)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Seq))
 	}
 	if m.Type != 0 {
 		dAtA[i] = 0x10
 		i++
 		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
 	}
-	if m.CustomType != 0 {
-		dAtA[i] = 0x18
+	if m.InitEvent != nil {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.CustomType))
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.InitEvent.Size()))
+		n77, err := m.InitEvent.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n77
 	}
-	if len(m.Value) > 0 {
+	if m.ShardEvent != nil {
 		dAtA[i] = 0x22
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
-	}
-	if m.PID != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.PID))
-	}
-	dAtA[i] = 0x32
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Error.Size()))
-	n93, err := m.Error.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n93
-	if m.TxnBatchResponse != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnBatchResponse.Size()))
-		n94, err := m.TxnBatchResponse.MarshalTo(dAtA[i:])
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardEvent.Size()))
+		n78, err := m.ShardEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n94
+		i += n78
 	}
-	if m.UpdateTxnRecord != nil {
-		dAtA[i] = 0x42
+	if m.StoreEvent != nil {
+		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.UpdateTxnRecord.Size()))
-		n95, err := m.UpdateTxnRecord.MarshalTo(dAtA[i:])
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreEvent.Size()))
+		n79, err := m.StoreEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n95
+		i += n79
 	}
-	if m.DeleteTxnRecord != nil {
-		dAtA[i] = 0x4a
+	if m.ShardStatsEvent != nil {
+		dAtA[i] = 0x32
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.DeleteTxnRecord.Size()))
-		n96, err := m.DeleteTxnRecord.MarshalTo(dAtA[i:])
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardStatsEvent.Size()))
+		n80, err := m.ShardStatsEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n96
-	}
-	if m.CommitTxnWriteData != nil {
-		dAtA[i] = 0x52
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.CommitTxnWriteData.Size()))
-		n97, err := m.CommitTxnWriteData.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n97
+		i += n80
 	}
-	if m.RollbackTxnRecord != nil {
-		dAtA[i] = 0x5a
+	if m.StoreStatsEvent != nil {
+		dAtA[i] = 0x3a
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.RollbackTxnRecord.Size()))
-		n98, err := m.RollbackTxnRecord.MarshalTo(dAtA[i:])
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.StoreStatsEvent.Size()))
+		n81, err := m.StoreStatsEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n98
+		i += n81
 	}
-	if m.CleanTxnMVCCData != nil {
-		dAtA[i] = 0x62
+	if m.SettingEvent != nil {
+		dAtA[i] = 0x42
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.CleanTxnMVCCData.Size()))
-		n99, err := m.CleanTxnMVCCData.MarshalTo(dAtA[i:])
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.SettingEvent.Size()))
+		n82, err := m.SettingEvent.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n99
+		i += n82
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -9951,7 +11601,7 @@ func (m *Response) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ConfigChangeRequest) Marshal() (dAtA []byte, err error) {
+func (m *InitEventData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9961,31 +11611,75 @@ func (m *ConfigChangeRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConfigChangeRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *InitEventData) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.ChangeType != 0 {
-		dAtA[i] = 0x8
+	if len(m.Shards) > 0 {
+		for _, b := range m.Shards {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Stores) > 0 {
+		for _, b := range m.Stores {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.LeaderReplicaIDs) > 0 {
+		dAtA84 := make([]byte, len(m.LeaderReplicaIDs)*10)
+		var j83 int
+		for _, num := range m.LeaderReplicaIDs {
+			for num >= 1<<7 {
+				dAtA84[j83] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j83++
+			}
+			dAtA84[j83] = uint8(num)
+			j83++
+		}
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ChangeType))
+		i = encodeVarintRpcpb(dAtA, i, uint64(j83))
+		i += copy(dAtA[i:], dAtA84[:j83])
 	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
-	n100, err := m.Replica.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Leases) > 0 {
+		for _, msg := range m.Leases {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Settings) > 0 {
+		for _, msg := range m.Settings {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	i += n100
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ConfigChangeResponse) Marshal() (dAtA []byte, err error) {
+func (m *ShardEventData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -9995,26 +11689,59 @@ func (m *ConfigChangeResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ConfigChangeResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ShardEventData) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Shard.Size()))
-	n101, err := m.Shard.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if m.LeaderReplicaID != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.LeaderReplicaID))
+	}
+	if m.Lease != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
+		n85, err := m.Lease.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n85
+	}
+	if m.Removed {
+		dAtA[i] = 0x20
+		i++
+		if m.Removed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Create {
+		dAtA[i] = 0x28
+		i++
+		if m.Create {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	i += n101
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *CompactLogRequest) Marshal() (dAtA []byte, err error) {
+func (m *StoreEventData) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10024,15 +11751,16 @@ func (m *CompactLogRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CompactLogRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *StoreEventData) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.CompactIndex != 0 {
-		dAtA[i] = 0x8
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.CompactIndex))
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -10040,7 +11768,7 @@ func (m *CompactLogRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *CompactLogResponse) Marshal() (dAtA []byte, err error) {
+func (m *ConfigChange) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10050,18 +11778,37 @@ func (m *CompactLogResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CompactLogResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConfigChange) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
+	n86, err := m.Replica.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n86
+	if m.ChangeType != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ChangeType))
+	}
+	if len(m.Initiator) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Initiator)))
+		i += copy(dAtA[i:], m.Initiator)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *TransferLeaderRequest) Marshal() (dAtA []byte, err error) {
+func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10071,7 +11818,7 @@ func (m *TransferLeaderRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TransferLeaderRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -10079,18 +11826,18 @@ func (m *TransferLeaderRequest) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0xa
 	i++
 	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
-	n102, err := m.Replica.MarshalTo(dAtA[i:])
+	n87, err := m.Replica.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n102
+	i += n87
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *TransferLeaderResponse) Marshal() (dAtA []byte, err error) {
+func (m *TransferLease) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10100,18 +11847,26 @@ func (m *TransferLeaderResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TransferLeaderResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *TransferLease) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
+	n88, err := m.Lease.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n88
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *BatchSplitRequest) Marshal() (dAtA []byte, err error) {
+func (m *ConfigChangeV2) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10121,13 +11876,13 @@ func (m *BatchSplitRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BatchSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConfigChangeV2) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Requests) > 0 {
-		for _, msg := range m.Requests {
+	if len(m.Changes) > 0 {
+		for _, msg := range m.Changes {
 			dAtA[i] = 0xa
 			i++
 			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
@@ -10138,19 +11893,13 @@ func (m *BatchSplitRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	if len(m.Context) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Context)))
-		i += copy(dAtA[i:], m.Context)
-	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *SplitRequest) Marshal() (dAtA []byte, err error) {
+func (m *Merge) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10160,39 +11909,16 @@ func (m *SplitRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SplitRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Merge) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Start) > 0 {
+	if len(m.Target) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Start)))
-		i += copy(dAtA[i:], m.Start)
-	}
-	if len(m.End) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.End)))
-		i += copy(dAtA[i:], m.End)
-	}
-	if m.NewShardID != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.NewShardID))
-	}
-	if len(m.NewReplicas) > 0 {
-		for _, msg := range m.NewReplicas {
-			dAtA[i] = 0x22
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Target)))
+		i += copy(dAtA[i:], m.Target)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -10200,7 +11926,7 @@ func (m *SplitRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *BatchSplitResponse) Marshal() (dAtA []byte, err error) {
+func (m *SplitShard) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10210,21 +11936,22 @@ func (m *BatchSplitResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BatchSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *SplitShard) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Shards) > 0 {
-		for _, msg := range m.Shards {
-			dAtA[i] = 0xa
+	if m.Policy != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Policy))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			dAtA[i] = 0x12
 			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
 		}
 	}
 	if m.XXX_unrecognized != nil {
@@ -10233,7 +11960,7 @@ func (m *BatchSplitResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *UpdateMetadataRequest) Marshal() (dAtA []byte, err error) {
+func (m *LabelConstraint) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10243,47 +11970,44 @@ func (m *UpdateMetadataRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateMetadataRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *LabelConstraint) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Metadata.Size()))
-	n103, err := m.Metadata.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n103
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
 	}
-	return i, nil
-}
-
-func (m *UpdateMetadataResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.Op != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Op))
+	}
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *UpdateMetadataResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *UpdateLabelsRequest) Marshal() (dAtA []byte, err error) {
+func (m *PlacementRule) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10293,14 +12017,63 @@ func (m *UpdateLabelsRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateLabelsRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *PlacementRule) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Labels) > 0 {
-		for _, msg := range m.Labels {
-			dAtA[i] = 0xa
+	if len(m.ID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if len(m.GroupID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.GroupID)))
+		i += copy(dAtA[i:], m.GroupID)
+	}
+	if m.Index != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Index))
+	}
+	if m.Override {
+		dAtA[i] = 0x20
+		i++
+		if m.Override {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.StartKey) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.StartKey)))
+		i += copy(dAtA[i:], m.StartKey)
+	}
+	if len(m.EndKey) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.EndKey)))
+		i += copy(dAtA[i:], m.EndKey)
+	}
+	if m.Role != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Role))
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Count))
+	}
+	if len(m.LabelConstraints) > 0 {
+		for _, msg := range m.LabelConstraints {
+			dAtA[i] = 0x4a
 			i++
 			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -10310,10 +12083,26 @@ func (m *UpdateLabelsRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	if m.Policy != 0 {
-		dAtA[i] = 0x10
+	if len(m.LocationLabels) > 0 {
+		for _, s := range m.LocationLabels {
+			dAtA[i] = 0x52
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.IsolationLevel) > 0 {
+		dAtA[i] = 0x5a
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Policy))
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.IsolationLevel)))
+		i += copy(dAtA[i:], m.IsolationLevel)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -10321,7 +12110,7 @@ func (m *UpdateLabelsRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *UpdateLabelsResponse) Marshal() (dAtA []byte, err error) {
+func (m *RequestBatchHeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10331,18 +12120,57 @@ func (m *UpdateLabelsResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateLabelsResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *RequestBatchHeader) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.ID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
+	}
+	if m.ShardID != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardID))
+	}
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
+	n89, err := m.Replica.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n89
+	if m.Lease != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
+		n90, err := m.Lease.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n90
+	}
+	if m.CommitTime != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.CommitTime))
+	}
+	if m.Priority != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Priority))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *UpdateEpochLeaseRequest) Marshal() (dAtA []byte, err error) {
+func (m *ResponseBatchHeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10352,31 +12180,32 @@ func (m *UpdateEpochLeaseRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateEpochLeaseRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ResponseBatchHeader) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.ShardID != 0 {
-		dAtA[i] = 0x8
+	if len(m.ID) > 0 {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardID))
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
 	}
 	dAtA[i] = 0x12
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
-	n104, err := m.Lease.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Error.Size()))
+	n91, err := m.Error.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n104
+	i += n91
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *UpdateEpochLeaseResponse) Marshal() (dAtA []byte, err error) {
+func (m *RequestBatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10386,18 +12215,54 @@ func (m *UpdateEpochLeaseResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateEpochLeaseResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *RequestBatch) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Header.Size()))
+	n92, err := m.Header.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n92
+	if len(m.Requests) > 0 {
+		for _, msg := range m.Requests {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Chunk != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Chunk.Size()))
+		n93, err := m.Chunk.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n93
+	}
+	if len(m.Compressed) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Compressed)))
+		i += copy(dAtA[i:], m.Compressed)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *UpdateTxnRecordRequest) Marshal() (dAtA []byte, err error) {
+func (m *ProposalChunk) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10407,26 +12272,34 @@ func (m *UpdateTxnRecordRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateTxnRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ProposalChunk) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnRecord.Size()))
-	n105, err := m.TxnRecord.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Index))
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Count))
+	}
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
 	}
-	i += n105
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *UpdateTxnRecordResponse) Marshal() (dAtA []byte, err error) {
+func (m *ResponseBatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10436,26 +12309,38 @@ func (m *UpdateTxnRecordResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UpdateTxnRecordResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ResponseBatch) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
 	dAtA[i] = 0xa
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnRecord.Size()))
-	n106, err := m.TxnRecord.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Header.Size()))
+	n94, err := m.Header.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n106
+	i += n94
+	if len(m.Responses) > 0 {
+		for _, msg := range m.Responses {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *DeleteTxnRecordRequest) Marshal() (dAtA []byte, err error) {
+func (m *Request) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10465,142 +12350,215 @@ func (m *DeleteTxnRecordRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteTxnRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Request) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.TxnRecordRouteKey) > 0 {
+	if len(m.ID) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.TxnRecordRouteKey)))
-		i += copy(dAtA[i:], m.TxnRecordRouteKey)
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
 	}
-	if len(m.TxnID) > 0 {
-		dAtA[i] = 0x12
+	if m.Group != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.TxnID)))
-		i += copy(dAtA[i:], m.TxnID)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Group))
 	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if m.Type != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
 	}
-	return i, nil
-}
-
-func (m *DeleteTxnRecordResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.CustomType != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.CustomType))
 	}
-	return dAtA[:n], nil
-}
-
-func (m *DeleteTxnRecordResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
 	}
-	return i, nil
-}
-
-func (m *CommitTxnWriteDataRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if len(m.Cmd) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Cmd)))
+		i += copy(dAtA[i:], m.Cmd)
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CommitTxnWriteDataRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.OriginKey) > 0 {
-		dAtA[i] = 0xa
+	if m.PID != 0 {
+		dAtA[i] = 0x38
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.OriginKey)))
-		i += copy(dAtA[i:], m.OriginKey)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.PID))
 	}
-	dAtA[i] = 0x12
+	if m.ToShard != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ToShard))
+	}
+	if m.IgnoreEpochCheck {
+		dAtA[i] = 0x48
+		i++
+		if m.IgnoreEpochCheck {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	dAtA[i] = 0x52
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.CommitTS.Size()))
-	n107, err := m.CommitTS.MarshalTo(dAtA[i:])
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Epoch.Size()))
+	n95, err := m.Epoch.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n107
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	i += n95
+	if m.Lease != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
+		n96, err := m.Lease.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n96
 	}
-	return i, nil
-}
-
-func (m *CommitTxnWriteDataResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	if m.KeysRange != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.KeysRange.Size()))
+		n97, err := m.KeysRange.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n97
+	}
+	if m.ReplicaSelectPolicy != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ReplicaSelectPolicy))
+	}
+	if m.TxnBatchRequest != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnBatchRequest.Size()))
+		n98, err := m.TxnBatchRequest.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n98
+	}
+	dAtA[i] = 0x7a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.UpdateTxnRecord.Size()))
+	n99, err := m.UpdateTxnRecord.MarshalTo(dAtA[i:])
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CommitTxnWriteDataResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	i += n99
+	dAtA[i] = 0x82
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.DeleteTxnRecord.Size()))
+	n100, err := m.DeleteTxnRecord.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	return i, nil
-}
-
-func (m *RollbackTxnWriteDataRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	i += n100
+	dAtA[i] = 0x8a
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CommitTxnWriteData.Size()))
+	n101, err := m.CommitTxnWriteData.MarshalTo(dAtA[i:])
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *RollbackTxnWriteDataRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.OriginKey) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.OriginKey)))
-		i += copy(dAtA[i:], m.OriginKey)
+	i += n101
+	dAtA[i] = 0x92
+	i++
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RollbackTxnRecord.Size()))
+	n102, err := m.RollbackTxnRecord.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	dAtA[i] = 0x12
+	i += n102
+	dAtA[i] = 0x9a
 	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Timestamp.Size()))
-	n108, err := m.Timestamp.MarshalTo(dAtA[i:])
+	dAtA[i] = 0x1
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CleanTxnMVCCData.Size()))
+	n103, err := m.CleanTxnMVCCData.MarshalTo(dAtA[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n108
+	i += n103
+	if m.AllowStaleRead {
+		dAtA[i] = 0xa0
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.AllowStaleRead {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.AppLeaseToken != 0 {
+		dAtA[i] = 0xa8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.AppLeaseToken))
+	}
+	if m.FollowerRead {
+		dAtA[i] = 0xb0
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.FollowerRead {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if len(m.CF) > 0 {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.CF)))
+		i += copy(dAtA[i:], m.CF)
+	}
+	if m.Priority != 0 {
+		dAtA[i] = 0xc0
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Priority))
+	}
+	if m.Timeout != 0 {
+		dAtA[i] = 0xc8
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Timeout))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *RollbackTxnWriteDataResponse) Marshal() (dAtA []byte, err error) {
+func (m *Range) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10610,18 +12568,30 @@ func (m *RollbackTxnWriteDataResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RollbackTxnWriteDataResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *Range) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.From) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.From)))
+		i += copy(dAtA[i:], m.From)
+	}
+	if len(m.To) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.To)))
+		i += copy(dAtA[i:], m.To)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *CleanTxnMVCCDataRequest) Marshal() (dAtA []byte, err error) {
+func (m *Response) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10631,47 +12601,123 @@ func (m *CleanTxnMVCCDataRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CleanTxnMVCCDataRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Timestamp.Size()))
-	n109, err := m.Timestamp.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n109
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.ID) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ID)))
+		i += copy(dAtA[i:], m.ID)
 	}
-	return i, nil
-}
-
-func (m *CleanTxnMVCCDataResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
+	if m.Type != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Type))
+	}
+	if m.CustomType != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.CustomType))
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	if m.PID != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.PID))
+	}
+	dAtA[i] = 0x32
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Error.Size()))
+	n104, err := m.Error.MarshalTo(dAtA[i:])
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	i += n104
+	if m.TxnBatchResponse != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnBatchResponse.Size()))
+		n105, err := m.TxnBatchResponse.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n105
+	}
+	if m.UpdateTxnRecord != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.UpdateTxnRecord.Size()))
+		n106, err := m.UpdateTxnRecord.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n106
+	}
+	if m.DeleteTxnRecord != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.DeleteTxnRecord.Size()))
+		n107, err := m.DeleteTxnRecord.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n107
+	}
+	if m.CommitTxnWriteData != nil {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.CommitTxnWriteData.Size()))
+		n108, err := m.CommitTxnWriteData.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n108
+	}
+	if m.RollbackTxnRecord != nil {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.RollbackTxnRecord.Size()))
+		n109, err := m.RollbackTxnRecord.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n109
+	}
+	if m.CleanTxnMVCCData != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.CleanTxnMVCCData.Size()))
+		n110, err := m.CleanTxnMVCCData.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n110
+	}
+	if m.ApplyLag != 0 {
+		dAtA[i] = 0x68
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ApplyLag))
+	}
+	if m.AppliedIndex != 0 {
+		dAtA[i] = 0x70
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.AppliedIndex))
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CleanTxnMVCCDataResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVSetRequest) Marshal() (dAtA []byte, err error) {
+func (m *ConfigChangeRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10681,22 +12727,29 @@ func (m *KVSetRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVSetRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConfigChangeRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Key) > 0 {
-		dAtA[i] = 0xa
+	if m.ChangeType != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ChangeType))
 	}
-	if len(m.Value) > 0 {
-		dAtA[i] = 0x12
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
+	n111, err := m.Replica.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n111
+	if len(m.Initiator) > 0 {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Initiator)))
+		i += copy(dAtA[i:], m.Initiator)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -10704,7 +12757,7 @@ func (m *KVSetRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *KVSetResponse) Marshal() (dAtA []byte, err error) {
+func (m *ConfigChangeResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10714,18 +12767,26 @@ func (m *KVSetResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVSetResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConfigChangeResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Shard.Size()))
+	n112, err := m.Shard.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n112
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVBatchSetRequest) Marshal() (dAtA []byte, err error) {
+func (m *CompactLogRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10735,26 +12796,15 @@ func (m *KVBatchSetRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVBatchSetRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *CompactLogRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
-	}
-	if len(m.Values) > 0 {
-		for _, b := range m.Values {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
+	if m.CompactIndex != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.CompactIndex))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -10762,7 +12812,7 @@ func (m *KVBatchSetRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *KVBatchSetResponse) Marshal() (dAtA []byte, err error) {
+func (m *CompactLogResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10772,7 +12822,7 @@ func (m *KVBatchSetResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVBatchSetResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *CompactLogResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -10783,7 +12833,7 @@ func (m *KVBatchSetResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *KVGetRequest) Marshal() (dAtA []byte, err error) {
+func (m *TransferLeaderRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10793,24 +12843,26 @@ func (m *KVGetRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVGetRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *TransferLeaderRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Key) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Replica.Size()))
+	n113, err := m.Replica.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n113
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVGetResponse) Marshal() (dAtA []byte, err error) {
+func (m *TransferLeaderResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10820,24 +12872,18 @@ func (m *KVGetResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVGetResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *TransferLeaderResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Value) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
-	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVBatchGetRequest) Marshal() (dAtA []byte, err error) {
+func (m *BatchSplitRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10847,35 +12893,28 @@ func (m *KVBatchGetRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVBatchGetRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *BatchSplitRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
+	if len(m.Requests) > 0 {
+		for _, msg := range m.Requests {
 			dAtA[i] = 0xa
 			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
-	}
-	if len(m.Indexes) > 0 {
-		dAtA111 := make([]byte, len(m.Indexes)*10)
-		var j110 int
-		for _, num := range m.Indexes {
-			for num >= 1<<7 {
-				dAtA111[j110] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j110++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
 			}
-			dAtA111[j110] = uint8(num)
-			j110++
+			i += n
 		}
+	}
+	if len(m.Context) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j110))
-		i += copy(dAtA[i:], dAtA111[:j110])
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Context)))
+		i += copy(dAtA[i:], m.Context)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -10883,7 +12922,7 @@ func (m *KVBatchGetRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *KVBatchGetResponse) Marshal() (dAtA []byte, err error) {
+func (m *SplitRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10893,35 +12932,39 @@ func (m *KVBatchGetResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVBatchGetResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *SplitRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Values) > 0 {
-		for _, b := range m.Values {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
+	if len(m.Start) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Start)))
+		i += copy(dAtA[i:], m.Start)
 	}
-	if len(m.Indexes) > 0 {
-		dAtA113 := make([]byte, len(m.Indexes)*10)
-		var j112 int
-		for _, num := range m.Indexes {
-			for num >= 1<<7 {
-				dAtA113[j112] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j112++
-			}
-			dAtA113[j112] = uint8(num)
-			j112++
-		}
+	if len(m.End) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(j112))
-		i += copy(dAtA[i:], dAtA113[:j112])
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.End)))
+		i += copy(dAtA[i:], m.End)
+	}
+	if m.NewShardID != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.NewShardID))
+	}
+	if len(m.NewReplicas) > 0 {
+		for _, msg := range m.NewReplicas {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -10929,7 +12972,7 @@ func (m *KVBatchGetResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *KVDeleteRequest) Marshal() (dAtA []byte, err error) {
+func (m *BatchSplitResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10939,24 +12982,30 @@ func (m *KVDeleteRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVDeleteRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *BatchSplitResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Key) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
+	if len(m.Shards) > 0 {
+		for _, msg := range m.Shards {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVDeleteResponse) Marshal() (dAtA []byte, err error) {
+func (m *UpdateMetadataRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10966,18 +13015,26 @@ func (m *KVDeleteResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateMetadataRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Metadata.Size()))
+	n114, err := m.Metadata.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n114
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVBatchDeleteRequest) Marshal() (dAtA []byte, err error) {
+func (m *UpdateMetadataResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -10987,26 +13044,56 @@ func (m *KVBatchDeleteRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVBatchDeleteRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateMetadataResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *UpdateLabelsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateLabelsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, msg := range m.Labels {
 			dAtA[i] = 0xa
 			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
+	if m.Policy != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Policy))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVBatchDeleteResponse) Marshal() (dAtA []byte, err error) {
+func (m *UpdateLabelsResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -11016,7 +13103,7 @@ func (m *KVBatchDeleteResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVBatchDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateLabelsResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -11027,7 +13114,7 @@ func (m *KVBatchDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *KVRangeDeleteRequest) Marshal() (dAtA []byte, err error) {
+func (m *UpdateGateRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -11037,30 +13124,26 @@ func (m *KVRangeDeleteRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVRangeDeleteRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateGateRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Start) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Start)))
-		i += copy(dAtA[i:], m.Start)
-	}
-	if len(m.End) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.End)))
-		i += copy(dAtA[i:], m.End)
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Gate.Size()))
+	n115, err := m.Gate.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n115
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVRangeDeleteResponse) Marshal() (dAtA []byte, err error) {
+func (m *UpdateGateResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -11070,7 +13153,7 @@ func (m *KVRangeDeleteResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVRangeDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *UpdateGateResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -11081,7 +13164,7 @@ func (m *KVRangeDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *KVScanRequest) Marshal() (dAtA []byte, err error) {
+func (m *AcquireAppLeaseRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -11091,60 +13174,73 @@ func (m *KVScanRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVScanRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *AcquireAppLeaseRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Start) > 0 {
+	if len(m.Holder) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Start)))
-		i += copy(dAtA[i:], m.Start)
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Holder)))
+		i += copy(dAtA[i:], m.Holder)
 	}
-	if len(m.End) > 0 {
-		dAtA[i] = 0x12
+	if m.TTL != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.End)))
-		i += copy(dAtA[i:], m.End)
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.TTL))
 	}
-	if m.Limit != 0 {
+	if m.Now != 0 {
 		dAtA[i] = 0x18
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Limit))
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Now))
 	}
-	if m.LimitBytes != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.LimitBytes))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.WithValue {
-		dAtA[i] = 0x28
-		i++
-		if m.WithValue {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
+	return i, nil
+}
+
+func (m *AcquireAppLeaseResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.OnlyCount {
-		dAtA[i] = 0x30
+	return dAtA[:n], nil
+}
+
+func (m *AcquireAppLeaseResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Acquired {
+		dAtA[i] = 0x8
 		i++
-		if m.OnlyCount {
+		if m.Acquired {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i++
 	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
+	n116, err := m.Lease.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n116
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVScanResponse) Marshal() (dAtA []byte, err error) {
+func (m *ReleaseAppLeaseRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -11154,55 +13250,54 @@ func (m *KVScanResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVScanResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *ReleaseAppLeaseRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
+	if m.Token != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Token))
 	}
-	if len(m.Values) > 0 {
-		for _, b := range m.Values {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
-			i += copy(dAtA[i:], b)
-		}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Count != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.Count))
+	return i, nil
+}
+
+func (m *ReleaseAppLeaseResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.Completed {
-		dAtA[i] = 0x20
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseAppLeaseResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Released {
+		dAtA[i] = 0x8
 		i++
-		if m.Completed {
+		if m.Released {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i++
 	}
-	if len(m.ShardEnd) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ShardEnd)))
-		i += copy(dAtA[i:], m.ShardEnd)
-	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVBatchMixedWriteRequest) Marshal() (dAtA []byte, err error) {
+func (m *SplitShardRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -11212,21 +13307,17 @@ func (m *KVBatchMixedWriteRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVBatchMixedWriteRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *SplitShardRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Requests) > 0 {
-		for _, msg := range m.Requests {
+	if len(m.SplitKeys) > 0 {
+		for _, b := range m.SplitKeys {
 			dAtA[i] = 0xa
 			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
 		}
 	}
 	if m.XXX_unrecognized != nil {
@@ -11235,7 +13326,7 @@ func (m *KVBatchMixedWriteRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *KVBatchMixedWriteResponse) Marshal() (dAtA []byte, err error) {
+func (m *SplitShardResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -11245,30 +13336,18 @@ func (m *KVBatchMixedWriteResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVBatchMixedWriteResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *SplitShardResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Responses) > 0 {
-		for _, msg := range m.Responses {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVMixedWriteRequest) Marshal() (dAtA []byte, err error) {
+func (m *DeleteRangeRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -11278,47 +13357,35 @@ func (m *KVMixedWriteRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVMixedWriteRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeleteRangeRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.CmdType != 0 {
-		dAtA[i] = 0x8
+	if len(m.Start) > 0 {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.CmdType))
-	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Set.Size()))
-	n114, err := m.Set.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Start)))
+		i += copy(dAtA[i:], m.Start)
 	}
-	i += n114
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Delete.Size()))
-	n115, err := m.Delete.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if len(m.End) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.End)))
+		i += copy(dAtA[i:], m.End)
 	}
-	i += n115
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RangeDelete.Size()))
-	n116, err := m.RangeDelete.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.CheckIndex != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.CheckIndex))
 	}
-	i += n116
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *KVMixedWriteResponse) Marshal() (dAtA []byte, err error) {
+func (m *DeleteRangeResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -11328,1249 +13395,1771 @@ func (m *KVMixedWriteResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *KVMixedWriteResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *DeleteRangeResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.CmdType != 0 {
+	if m.Deleted {
 		dAtA[i] = 0x8
 		i++
-		i = encodeVarintRpcpb(dAtA, i, uint64(m.CmdType))
-	}
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Set.Size()))
-	n117, err := m.Set.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+		if m.Deleted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	i += n117
-	dAtA[i] = 0x1a
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.Delete.Size()))
-	n118, err := m.Delete.MarshalTo(dAtA[i:])
-	if err != nil {
-		return 0, err
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	i += n118
-	dAtA[i] = 0x22
-	i++
-	i = encodeVarintRpcpb(dAtA, i, uint64(m.RangeDelete.Size()))
-	n119, err := m.RangeDelete.MarshalTo(dAtA[i:])
+	return i, nil
+}
+
+func (m *BarrierRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n119
+	return dAtA[:n], nil
+}
+
+func (m *BarrierRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func encodeVarintRpcpb(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *BarrierResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *ProphetRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *BarrierResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ID))
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Index))
 	}
-	if m.StoreID != 0 {
-		n += 1 + sovRpcpb(uint64(m.StoreID))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Type != 0 {
-		n += 1 + sovRpcpb(uint64(m.Type))
+	return i, nil
+}
+
+func (m *ComputeHashRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	l = m.ShardHeartbeat.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.StoreHeartbeat.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.PutStore.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.GetStore.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.AllocID.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.AskBatchSplit.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.CreateDestroying.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.ReportDestroyed.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.GetDestroying.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.CreateWatcher.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.CreateShards.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.RemoveShards.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.CheckShardState.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.PutPlacementRule.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.GetAppliedRules.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.CreateJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.RemoveJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.ExecuteJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.AddScheduleGroupRule.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.GetScheduleGroupRule.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *ComputeHashRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ProphetResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ComputeHashResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ComputeHashResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ID))
-	}
-	if m.Type != 0 {
-		n += 1 + sovRpcpb(uint64(m.Type))
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Index))
 	}
-	l = len(m.Error)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.Hash != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Hash))
 	}
-	l = len(m.Leader)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.Computed {
+		dAtA[i] = 0x18
+		i++
+		if m.Computed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	l = m.ShardHeartbeat.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.StoreHeartbeat.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.PutStore.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.GetStore.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.AllocID.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.AskBatchSplit.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.CreateDestroying.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.ReportDestroyed.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.GetDestroying.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.Event.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.CreateShards.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.RemoveShards.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.CheckShardState.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.PutPlacementRule.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.GetAppliedRules.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.CreateJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.RemoveJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.ExecuteJob.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.AddScheduleGroupRule.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.GetScheduleGroupRule.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ShardHeartbeatReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *VerifyHashRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyHashRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.StoreID != 0 {
-		n += 1 + sovRpcpb(uint64(m.StoreID))
+	if m.Index != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Index))
 	}
-	l = len(m.Shard)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.Hash != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Hash))
 	}
-	if m.Term != 0 {
-		n += 1 + sovRpcpb(uint64(m.Term))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Leader != nil {
-		l = m.Leader.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if len(m.DownReplicas) > 0 {
-		for _, e := range m.DownReplicas {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
-	}
-	if len(m.PendingReplicas) > 0 {
-		for _, e := range m.PendingReplicas {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
-	}
-	l = m.Stats.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = len(m.GroupKey)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.Lease != nil {
-		l = m.Lease.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	return i, nil
+}
+
+func (m *VerifyHashResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyHashResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ShardHeartbeatRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PurgeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PurgeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ShardID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ShardID))
+	if len(m.Start) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Start)))
+		i += copy(dAtA[i:], m.Start)
 	}
-	l = m.ShardEpoch.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	if m.TargetReplica != nil {
-		l = m.TargetReplica.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.End) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.End)))
+		i += copy(dAtA[i:], m.End)
 	}
-	if m.ConfigChange != nil {
-		l = m.ConfigChange.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
 	}
-	if m.TransferLeader != nil {
-		l = m.TransferLeader.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	if m.Merge != nil {
-		l = m.Merge.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	return i, nil
+}
+
+func (m *PurgeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.SplitShard != nil {
-		l = m.SplitShard.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *PurgeResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Purged {
+		dAtA[i] = 0x8
+		i++
+		if m.Purged {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	if m.ConfigChangeV2 != nil {
-		l = m.ConfigChangeV2.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.Index != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Index))
 	}
-	if m.TransferLease != nil {
-		l = m.TransferLease.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Start) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Start)))
+		i += copy(dAtA[i:], m.Start)
 	}
-	if m.DestroyDirectly {
-		n += 2
+	if len(m.End) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.End)))
+		i += copy(dAtA[i:], m.End)
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *PutStoreReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *UpdateDictionaryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateDictionaryRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Store)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.Version != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Version))
+	}
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *PutStoreRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *UpdateDictionaryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateDictionaryResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.DestroyShards)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.Updated {
+		dAtA[i] = 0x8
+		i++
+		if m.Updated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *StoreHeartbeatReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *UpdateEpochLeaseRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateEpochLeaseRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Stats.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.ShardID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.ShardID))
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Lease.Size()))
+	n117, err := m.Lease.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n117
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *StoreHeartbeatRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *UpdateEpochLeaseResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateEpochLeaseResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetStoreReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ID))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *UpdateTxnRecordRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetStoreRsp) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *UpdateTxnRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.Stats != nil {
-		l = m.Stats.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnRecord.Size()))
+	n118, err := m.TxnRecord.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n118
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *AllocIDReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *UpdateTxnRecordResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *AllocIDRsp) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *UpdateTxnRecordResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ID))
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.TxnRecord.Size()))
+	n119, err := m.TxnRecord.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n119
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *AskBatchSplitReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteTxnRecordRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteTxnRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.TxnRecordRouteKey) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.TxnRecordRouteKey)))
+		i += copy(dAtA[i:], m.TxnRecordRouteKey)
 	}
-	if m.Count != 0 {
-		n += 1 + sovRpcpb(uint64(m.Count))
+	if len(m.TxnID) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.TxnID)))
+		i += copy(dAtA[i:], m.TxnID)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *AskBatchSplitRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteTxnRecordResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteTxnRecordResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.SplitIDs) > 0 {
-		for _, e := range m.SplitIDs {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CreateDestroyingReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CommitTxnWriteDataRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitTxnWriteDataRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ID))
-	}
-	if m.Index != 0 {
-		n += 1 + sovRpcpb(uint64(m.Index))
-	}
-	if len(m.Replicas) > 0 {
-		l = 0
-		for _, e := range m.Replicas {
-			l += sovRpcpb(uint64(e))
-		}
-		n += 1 + sovRpcpb(uint64(l)) + l
+	if len(m.OriginKey) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.OriginKey)))
+		i += copy(dAtA[i:], m.OriginKey)
 	}
-	if m.RemoveData {
-		n += 2
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.CommitTS.Size()))
+	n120, err := m.CommitTS.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n120
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CreateDestroyingRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CommitTxnWriteDataResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitTxnWriteDataResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.State != 0 {
-		n += 1 + sovRpcpb(uint64(m.State))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetDestroyingReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RollbackTxnWriteDataRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RollbackTxnWriteDataRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ID))
+	if len(m.OriginKey) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.OriginKey)))
+		i += copy(dAtA[i:], m.OriginKey)
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Timestamp.Size()))
+	n121, err := m.Timestamp.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n121
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetDestroyingRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RollbackTxnWriteDataResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RollbackTxnWriteDataResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Status != nil {
-		l = m.Status.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ReportDestroyedReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ID))
+func (m *CleanTxnMVCCDataRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.ReplicaID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ReplicaID))
+	return dAtA[:n], nil
+}
+
+func (m *CleanTxnMVCCDataRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Timestamp.Size()))
+	n122, err := m.Timestamp.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n122
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ReportDestroyedRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *CleanTxnMVCCDataResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *CleanTxnMVCCDataResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.State != 0 {
-		n += 1 + sovRpcpb(uint64(m.State))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *SplitID) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVSetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVSetRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.NewID != 0 {
-		n += 1 + sovRpcpb(uint64(m.NewID))
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
 	}
-	if len(m.NewReplicaIDs) > 0 {
-		l = 0
-		for _, e := range m.NewReplicaIDs {
-			l += sovRpcpb(uint64(e))
-		}
-		n += 1 + sovRpcpb(uint64(l)) + l
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CreateWatcherReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVSetResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVSetResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Flag != 0 {
-		n += 1 + sovRpcpb(uint64(m.Flag))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CreateShardsReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVBatchSetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVBatchSetRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Shards) > 0 {
-		for _, b := range m.Shards {
-			l = len(b)
-			n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
 		}
 	}
-	if len(m.LeastReplicas) > 0 {
-		l = 0
-		for _, e := range m.LeastReplicas {
-			l += sovRpcpb(uint64(e))
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
 		}
-		n += 1 + sovRpcpb(uint64(l)) + l
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CreateShardsRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVBatchSetResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVBatchSetResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RemoveShardsReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVGetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVGetRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.IDs) > 0 {
-		l = 0
-		for _, e := range m.IDs {
-			l += sovRpcpb(uint64(e))
-		}
-		n += 1 + sovRpcpb(uint64(l)) + l
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RemoveShardsRsp) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *KVGetResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *CheckShardStateReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *KVGetResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.IDs)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Value) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CheckShardStateRsp) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Destroyed)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	l = len(m.Destroying)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *KVBatchGetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *PutPlacementRuleReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *KVBatchGetRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Rule.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
 	}
-	return n
-}
-
-func (m *PutPlacementRuleRsp) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Indexes) > 0 {
+		dAtA124 := make([]byte, len(m.Indexes)*10)
+		var j123 int
+		for _, num := range m.Indexes {
+			for num >= 1<<7 {
+				dAtA124[j123] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j123++
+			}
+			dAtA124[j123] = uint8(num)
+			j123++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(j123))
+		i += copy(dAtA[i:], dAtA124[:j123])
 	}
-	var l int
-	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetAppliedRulesReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ShardID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ShardID))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *KVBatchGetResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetAppliedRulesRsp) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *KVBatchGetResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Rules) > 0 {
-		for _, e := range m.Rules {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	if len(m.Indexes) > 0 {
+		dAtA126 := make([]byte, len(m.Indexes)*10)
+		var j125 int
+		for _, num := range m.Indexes {
+			for num >= 1<<7 {
+				dAtA126[j125] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j125++
+			}
+			dAtA126[j125] = uint8(num)
+			j125++
 		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(j125))
+		i += copy(dAtA[i:], dAtA126[:j125])
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CreateJobReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVDeleteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVDeleteRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Job.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CreateJobRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVDeleteResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RemoveJobReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVGetDelRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVGetDelRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Job.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RemoveJobRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVGetDelResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVGetDelResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
+	if len(m.Value) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ExecuteJobReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVDeleteIfRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVDeleteIfRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Job.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Key) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ExecuteJobRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVDeleteIfResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVDeleteIfResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.Deleted {
+		dAtA[i] = 0x8
+		i++
+		if m.Deleted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *AddScheduleGroupRuleReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVBatchDeleteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVBatchDeleteRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Rule.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *AddScheduleGroupRuleRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVBatchDeleteResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVBatchDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetScheduleGroupRuleReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVRangeDeleteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVRangeDeleteRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
+	if len(m.Start) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Start)))
+		i += copy(dAtA[i:], m.Start)
+	}
+	if len(m.End) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.End)))
+		i += copy(dAtA[i:], m.End)
+	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *GetScheduleGroupRuleRsp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVRangeDeleteResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVRangeDeleteResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Rules) > 0 {
-		for _, e := range m.Rules {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *EventNotify) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVScanRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVScanRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Seq != 0 {
-		n += 1 + sovRpcpb(uint64(m.Seq))
-	}
-	if m.Type != 0 {
-		n += 1 + sovRpcpb(uint64(m.Type))
+	if len(m.Start) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.Start)))
+		i += copy(dAtA[i:], m.Start)
 	}
-	if m.InitEvent != nil {
-		l = m.InitEvent.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.End) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.End)))
+		i += copy(dAtA[i:], m.End)
 	}
-	if m.ShardEvent != nil {
-		l = m.ShardEvent.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.Limit != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Limit))
 	}
-	if m.StoreEvent != nil {
-		l = m.StoreEvent.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.LimitBytes != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.LimitBytes))
 	}
-	if m.ShardStatsEvent != nil {
-		l = m.ShardStatsEvent.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.WithValue {
+		dAtA[i] = 0x28
+		i++
+		if m.WithValue {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	if m.StoreStatsEvent != nil {
-		l = m.StoreStatsEvent.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.OnlyCount {
+		dAtA[i] = 0x30
+		i++
+		if m.OnlyCount {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *InitEventData) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVScanResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVScanResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Shards) > 0 {
-		for _, b := range m.Shards {
-			l = len(b)
-			n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
 		}
 	}
-	if len(m.Stores) > 0 {
-		for _, b := range m.Stores {
-			l = len(b)
-			n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
 		}
 	}
-	if len(m.LeaderReplicaIDs) > 0 {
-		l = 0
-		for _, e := range m.LeaderReplicaIDs {
-			l += sovRpcpb(uint64(e))
-		}
-		n += 1 + sovRpcpb(uint64(l)) + l
+	if m.Count != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.Count))
 	}
-	if len(m.Leases) > 0 {
-		for _, e := range m.Leases {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
+	if m.Completed {
+		dAtA[i] = 0x20
+		i++
+		if m.Completed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i++
+	}
+	if len(m.ShardEnd) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(len(m.ShardEnd)))
+		i += copy(dAtA[i:], m.ShardEnd)
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ShardEventData) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVBatchMixedWriteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVBatchMixedWriteRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.LeaderReplicaID != 0 {
-		n += 1 + sovRpcpb(uint64(m.LeaderReplicaID))
-	}
-	if m.Lease != nil {
-		l = m.Lease.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.Removed {
-		n += 2
-	}
-	if m.Create {
-		n += 2
+	if len(m.Requests) > 0 {
+		for _, msg := range m.Requests {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *StoreEventData) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVBatchMixedWriteResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVBatchMixedWriteResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Responses) > 0 {
+		for _, msg := range m.Responses {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintRpcpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ConfigChange) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *KVMixedWriteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *KVMixedWriteRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.Replica.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	if m.ChangeType != 0 {
-		n += 1 + sovRpcpb(uint64(m.ChangeType))
+	if m.CmdType != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.CmdType))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Set.Size()))
+	n127, err := m.Set.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	return n
-}
-
-func (m *TransferLeader) Size() (n int) {
-	if m == nil {
-		return 0
+	i += n127
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Delete.Size()))
+	n128, err := m.Delete.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	var l int
-	_ = l
-	l = m.Replica.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
+	i += n128
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RangeDelete.Size()))
+	n129, err := m.RangeDelete.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n129
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *TransferLease) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Lease.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *KVMixedWriteResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ConfigChangeV2) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *KVMixedWriteResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Changes) > 0 {
-		for _, e := range m.Changes {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	if m.CmdType != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintRpcpb(dAtA, i, uint64(m.CmdType))
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Set.Size()))
+	n130, err := m.Set.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	return n
-}
-
-func (m *Merge) Size() (n int) {
-	if m == nil {
-		return 0
+	i += n130
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.Delete.Size()))
+	n131, err := m.Delete.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
-	var l int
-	_ = l
-	l = len(m.Target)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	i += n131
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintRpcpb(dAtA, i, uint64(m.RangeDelete.Size()))
+	n132, err := m.RangeDelete.MarshalTo(dAtA[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n132
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *SplitShard) Size() (n int) {
+func encodeVarintRpcpb(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *ProphetRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Policy != 0 {
-		n += 1 + sovRpcpb(uint64(m.Policy))
+	if m.ID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ID))
 	}
-	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
-			l = len(b)
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	if m.StoreID != 0 {
+		n += 1 + sovRpcpb(uint64(m.StoreID))
+	}
+	if m.Type != 0 {
+		n += 1 + sovRpcpb(uint64(m.Type))
 	}
+	l = m.ShardHeartbeat.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.StoreHeartbeat.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.PutStore.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.GetStore.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.AllocID.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.AskBatchSplit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.CreateDestroying.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.ReportDestroyed.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.GetDestroying.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.CreateWatcher.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.CreateShards.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.RemoveShards.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.CheckShardState.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.PutPlacementRule.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetAppliedRules.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.CreateJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.RemoveJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.ExecuteJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.AddScheduleGroupRule.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetScheduleGroupRule.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.CheckTombstoneReplicas.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.PutClusterSetting.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetClusterSettings.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.ScatterShards.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *LabelConstraint) Size() (n int) {
+func (m *ProphetResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Key)
+	if m.ID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ID))
+	}
+	if m.Type != 0 {
+		n += 1 + sovRpcpb(uint64(m.Type))
+	}
+	l = len(m.Error)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	if m.Op != 0 {
-		n += 1 + sovRpcpb(uint64(m.Op))
-	}
-	if len(m.Values) > 0 {
-		for _, s := range m.Values {
-			l = len(s)
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	l = len(m.Leader)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
 	}
+	l = m.ShardHeartbeat.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.StoreHeartbeat.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.PutStore.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.GetStore.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.AllocID.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.AskBatchSplit.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.CreateDestroying.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.ReportDestroyed.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.GetDestroying.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.Event.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.CreateShards.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.RemoveShards.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.CheckShardState.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.PutPlacementRule.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetAppliedRules.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.CreateJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.RemoveJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.ExecuteJob.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.AddScheduleGroupRule.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetScheduleGroupRule.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.CheckTombstoneReplicas.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.PutClusterSetting.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.GetClusterSettings.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.ScatterShards.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *PlacementRule) Size() (n int) {
+func (m *ShardHeartbeatReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.StoreID != 0 {
+		n += 1 + sovRpcpb(uint64(m.StoreID))
 	}
-	l = len(m.GroupID)
+	l = len(m.Shard)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	if m.Index != 0 {
-		n += 1 + sovRpcpb(uint64(m.Index))
-	}
-	if m.Override {
-		n += 2
-	}
-	l = len(m.StartKey)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.Term != 0 {
+		n += 1 + sovRpcpb(uint64(m.Term))
 	}
-	l = len(m.EndKey)
-	if l > 0 {
+	if m.Leader != nil {
+		l = m.Leader.Size()
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	if m.Role != 0 {
-		n += 1 + sovRpcpb(uint64(m.Role))
-	}
-	if m.Count != 0 {
-		n += 1 + sovRpcpb(uint64(m.Count))
-	}
-	if len(m.LabelConstraints) > 0 {
-		for _, e := range m.LabelConstraints {
+	if len(m.DownReplicas) > 0 {
+		for _, e := range m.DownReplicas {
 			l = e.Size()
 			n += 1 + l + sovRpcpb(uint64(l))
 		}
 	}
-	if len(m.LocationLabels) > 0 {
-		for _, s := range m.LocationLabels {
-			l = len(s)
+	if len(m.PendingReplicas) > 0 {
+		for _, e := range m.PendingReplicas {
+			l = e.Size()
 			n += 1 + l + sovRpcpb(uint64(l))
 		}
 	}
-	l = len(m.IsolationLevel)
+	l = m.Stats.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = len(m.GroupKey)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
+	if m.Lease != nil {
+		l = m.Lease.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if len(m.BlockingReasons) > 0 {
+		for _, e := range m.BlockingReasons {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *RequestBatchHeader) Size() (n int) {
+func (m *ShardHeartbeatRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
 	if m.ShardID != 0 {
 		n += 1 + sovRpcpb(uint64(m.ShardID))
 	}
-	l = m.Replica.Size()
+	l = m.ShardEpoch.Size()
 	n += 1 + l + sovRpcpb(uint64(l))
-	if m.Lease != nil {
-		l = m.Lease.Size()
+	if m.TargetReplica != nil {
+		l = m.TargetReplica.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.ConfigChange != nil {
+		l = m.ConfigChange.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.TransferLeader != nil {
+		l = m.TransferLeader.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Merge != nil {
+		l = m.Merge.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.SplitShard != nil {
+		l = m.SplitShard.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.ConfigChangeV2 != nil {
+		l = m.ConfigChangeV2.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.TransferLease != nil {
+		l = m.TransferLease.Size()
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
+	if m.DestroyDirectly {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ResponseBatchHeader) Size() (n int) {
+func (m *PutStoreReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.ID)
+	l = len(m.Store)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	l = m.Error.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *RequestBatch) Size() (n int) {
+func (m *PutStoreRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = m.Header.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	if len(m.Requests) > 0 {
-		for _, e := range m.Requests {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	l = len(m.DestroyShards)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -12578,19 +15167,17 @@ func (m *RequestBatch) Size() (n int) {
 	return n
 }
 
-func (m *ResponseBatch) Size() (n int) {
+func (m *StoreHeartbeatReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = m.Header.Size()
+	l = m.Stats.Size()
 	n += 1 + l + sovRpcpb(uint64(l))
-	if len(m.Responses) > 0 {
-		for _, e := range m.Responses {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -12598,87 +15185,49 @@ func (m *ResponseBatch) Size() (n int) {
 	return n
 }
 
-func (m *Request) Size() (n int) {
+func (m *StoreHeartbeatRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.Group != 0 {
-		n += 1 + sovRpcpb(uint64(m.Group))
-	}
-	if m.Type != 0 {
-		n += 1 + sovRpcpb(uint64(m.Type))
-	}
-	if m.CustomType != 0 {
-		n += 1 + sovRpcpb(uint64(m.CustomType))
-	}
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	l = len(m.Cmd)
+	l = len(m.Data)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	if m.PID != 0 {
-		n += 1 + sovRpcpb(uint64(m.PID))
-	}
-	if m.ToShard != 0 {
-		n += 1 + sovRpcpb(uint64(m.ToShard))
-	}
-	if m.IgnoreEpochCheck {
-		n += 2
-	}
-	l = m.Epoch.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	if m.Lease != nil {
-		l = m.Lease.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.KeysRange != nil {
-		l = m.KeysRange.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if m.ReplicaSelectPolicy != 0 {
-		n += 1 + sovRpcpb(uint64(m.ReplicaSelectPolicy))
+	return n
+}
+
+func (m *GetStoreReq) Size() (n int) {
+	if m == nil {
+		return 0
 	}
-	if m.TxnBatchRequest != nil {
-		l = m.TxnBatchRequest.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ID))
 	}
-	l = m.UpdateTxnRecord.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.DeleteTxnRecord.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.CommitTxnWriteData.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.RollbackTxnRecord.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
-	l = m.CleanTxnMVCCData.Size()
-	n += 2 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *Range) Size() (n int) {
+func (m *GetStoreRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.From)
+	l = len(m.Data)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	l = len(m.To)
-	if l > 0 {
+	if m.Stats != nil {
+		l = m.Stats.Size()
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -12687,100 +15236,63 @@ func (m *Range) Size() (n int) {
 	return n
 }
 
-func (m *Response) Size() (n int) {
+func (m *AllocIDReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.Type != 0 {
-		n += 1 + sovRpcpb(uint64(m.Type))
-	}
-	if m.CustomType != 0 {
-		n += 1 + sovRpcpb(uint64(m.CustomType))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.PID != 0 {
-		n += 1 + sovRpcpb(uint64(m.PID))
-	}
-	l = m.Error.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	if m.TxnBatchResponse != nil {
-		l = m.TxnBatchResponse.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.UpdateTxnRecord != nil {
-		l = m.UpdateTxnRecord.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.DeleteTxnRecord != nil {
-		l = m.DeleteTxnRecord.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.CommitTxnWriteData != nil {
-		l = m.CommitTxnWriteData.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.RollbackTxnRecord != nil {
-		l = m.RollbackTxnRecord.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.CleanTxnMVCCData != nil {
-		l = m.CleanTxnMVCCData.Size()
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ConfigChangeRequest) Size() (n int) {
+func (m *AllocIDRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.ChangeType != 0 {
-		n += 1 + sovRpcpb(uint64(m.ChangeType))
+	if m.ID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ID))
 	}
-	l = m.Replica.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ConfigChangeResponse) Size() (n int) {
+func (m *AskBatchSplitReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = m.Shard.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovRpcpb(uint64(m.Count))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *CompactLogRequest) Size() (n int) {
+func (m *AskBatchSplitRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.CompactIndex != 0 {
-		n += 1 + sovRpcpb(uint64(m.CompactIndex))
+	if len(m.SplitIDs) > 0 {
+		for _, e := range m.SplitIDs {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -12788,58 +15300,72 @@ func (m *CompactLogRequest) Size() (n int) {
 	return n
 }
 
-func (m *CompactLogResponse) Size() (n int) {
+func (m *CreateDestroyingReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ID))
+	}
+	if m.Index != 0 {
+		n += 1 + sovRpcpb(uint64(m.Index))
+	}
+	if len(m.Replicas) > 0 {
+		l = 0
+		for _, e := range m.Replicas {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
+	}
+	if m.RemoveData {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *TransferLeaderRequest) Size() (n int) {
+func (m *CreateDestroyingRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = m.Replica.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
+	if m.State != 0 {
+		n += 1 + sovRpcpb(uint64(m.State))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *TransferLeaderResponse) Size() (n int) {
+func (m *GetDestroyingReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ID))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *BatchSplitRequest) Size() (n int) {
+func (m *GetDestroyingRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Requests) > 0 {
-		for _, e := range m.Requests {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
-	}
-	l = len(m.Context)
-	if l > 0 {
+	if m.Status != nil {
+		l = m.Status.Size()
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -12848,28 +15374,17 @@ func (m *BatchSplitRequest) Size() (n int) {
 	return n
 }
 
-func (m *SplitRequest) Size() (n int) {
+func (m *ReportDestroyedReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Start)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	l = len(m.End)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	if m.NewShardID != 0 {
-		n += 1 + sovRpcpb(uint64(m.NewShardID))
+	if m.ID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ID))
 	}
-	if len(m.NewReplicas) > 0 {
-		for _, e := range m.NewReplicas {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	if m.ReplicaID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ReplicaID))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -12877,17 +15392,14 @@ func (m *SplitRequest) Size() (n int) {
 	return n
 }
 
-func (m *BatchSplitResponse) Size() (n int) {
+func (m *ReportDestroyedRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Shards) > 0 {
-		for _, e := range m.Shards {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	if m.State != 0 {
+		n += 1 + sovRpcpb(uint64(m.State))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -12895,46 +15407,61 @@ func (m *BatchSplitResponse) Size() (n int) {
 	return n
 }
 
-func (m *UpdateMetadataRequest) Size() (n int) {
+func (m *SplitID) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = m.Metadata.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
+	if m.NewID != 0 {
+		n += 1 + sovRpcpb(uint64(m.NewID))
+	}
+	if len(m.NewReplicaIDs) > 0 {
+		l = 0
+		for _, e := range m.NewReplicaIDs {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *UpdateMetadataResponse) Size() (n int) {
+func (m *CreateWatcherReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.Flag != 0 {
+		n += 1 + sovRpcpb(uint64(m.Flag))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *UpdateLabelsRequest) Size() (n int) {
+func (m *CreateShardsReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Labels) > 0 {
-		for _, e := range m.Labels {
-			l = e.Size()
+	if len(m.Shards) > 0 {
+		for _, b := range m.Shards {
+			l = len(b)
 			n += 1 + l + sovRpcpb(uint64(l))
 		}
 	}
-	if m.Policy != 0 {
-		n += 1 + sovRpcpb(uint64(m.Policy))
+	if len(m.LeastReplicas) > 0 {
+		l = 0
+		for _, e := range m.LeastReplicas {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -12942,7 +15469,7 @@ func (m *UpdateLabelsRequest) Size() (n int) {
 	return n
 }
 
-func (m *UpdateLabelsResponse) Size() (n int) {
+func (m *CreateShardsRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -12954,24 +15481,26 @@ func (m *UpdateLabelsResponse) Size() (n int) {
 	return n
 }
 
-func (m *UpdateEpochLeaseRequest) Size() (n int) {
+func (m *RemoveShardsReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.ShardID != 0 {
-		n += 1 + sovRpcpb(uint64(m.ShardID))
+	if len(m.IDs) > 0 {
+		l = 0
+		for _, e := range m.IDs {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
 	}
-	l = m.Lease.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *UpdateEpochLeaseResponse) Size() (n int) {
+func (m *RemoveShardsRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -12983,227 +15512,232 @@ func (m *UpdateEpochLeaseResponse) Size() (n int) {
 	return n
 }
 
-func (m *UpdateTxnRecordRequest) Size() (n int) {
+func (m *CheckShardStateReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = m.TxnRecord.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
+	l = len(m.IDs)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *UpdateTxnRecordResponse) Size() (n int) {
+func (m *CheckShardStateRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = m.TxnRecord.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
+	l = len(m.Destroyed)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.Destroying)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *DeleteTxnRecordRequest) Size() (n int) {
+func (m *TombstoneReplica) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.TxnRecordRouteKey)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.ShardID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ShardID))
 	}
-	l = len(m.TxnID)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if m.ReplicaID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ReplicaID))
 	}
+	l = m.Epoch.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *DeleteTxnRecordResponse) Size() (n int) {
+func (m *CheckTombstoneReplicasReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if len(m.Replicas) > 0 {
+		for _, e := range m.Replicas {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *CommitTxnWriteDataRequest) Size() (n int) {
+func (m *CheckTombstoneReplicasRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.OriginKey)
+	l = len(m.Removable)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	l = m.CommitTS.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *CommitTxnWriteDataResponse) Size() (n int) {
+func (m *PutPlacementRuleReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = m.Rule.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *RollbackTxnWriteDataRequest) Size() (n int) {
+func (m *PutPlacementRuleRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.OriginKey)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	l = m.Timestamp.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *RollbackTxnWriteDataResponse) Size() (n int) {
+func (m *GetAppliedRulesReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ShardID))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *CleanTxnMVCCDataRequest) Size() (n int) {
+func (m *GetAppliedRulesRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = m.Timestamp.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Rules) > 0 {
+		for _, e := range m.Rules {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *CleanTxnMVCCDataResponse) Size() (n int) {
+func (m *CreateJobReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = m.Job.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVSetRequest) Size() (n int) {
+func (m *CreateJobRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVSetResponse) Size() (n int) {
+func (m *RemoveJobReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = m.Job.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVBatchSetRequest) Size() (n int) {
+func (m *RemoveJobRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
-			l = len(b)
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
-	}
-	if len(m.Values) > 0 {
-		for _, b := range m.Values {
-			l = len(b)
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVBatchSetResponse) Size() (n int) {
+func (m *ExecuteJobReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = m.Job.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVGetRequest) Size() (n int) {
+func (m *ExecuteJobRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Key)
+	l = len(m.Data)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
@@ -13213,136 +15747,129 @@ func (m *KVGetRequest) Size() (n int) {
 	return n
 }
 
-func (m *KVGetResponse) Size() (n int) {
+func (m *AddScheduleGroupRuleReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
+	l = m.Rule.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVBatchGetRequest) Size() (n int) {
+func (m *AddScheduleGroupRuleRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
-			l = len(b)
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if len(m.Indexes) > 0 {
-		l = 0
-		for _, e := range m.Indexes {
-			l += sovRpcpb(uint64(e))
-		}
-		n += 1 + sovRpcpb(uint64(l)) + l
+	return n
+}
+
+func (m *GetScheduleGroupRuleReq) Size() (n int) {
+	if m == nil {
+		return 0
 	}
+	var l int
+	_ = l
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVBatchGetResponse) Size() (n int) {
+func (m *GetScheduleGroupRuleRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Values) > 0 {
-		for _, b := range m.Values {
-			l = len(b)
+	if len(m.Rules) > 0 {
+		for _, e := range m.Rules {
+			l = e.Size()
 			n += 1 + l + sovRpcpb(uint64(l))
 		}
 	}
-	if len(m.Indexes) > 0 {
-		l = 0
-		for _, e := range m.Indexes {
-			l += sovRpcpb(uint64(e))
-		}
-		n += 1 + sovRpcpb(uint64(l)) + l
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVDeleteRequest) Size() (n int) {
+func (m *PutClusterSettingReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
-	}
+	l = m.Setting.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVDeleteResponse) Size() (n int) {
+func (m *PutClusterSettingRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	l = m.Setting.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVBatchDeleteRequest) Size() (n int) {
+func (m *GetClusterSettingsReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
-			l = len(b)
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVBatchDeleteResponse) Size() (n int) {
+func (m *GetClusterSettingsRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if len(m.Settings) > 0 {
+		for _, e := range m.Settings {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVRangeDeleteRequest) Size() (n int) {
+func (m *ScatterShardsReq) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.Group != 0 {
+		n += 1 + sovRpcpb(uint64(m.Group))
+	}
 	l = len(m.Start)
 	if l > 0 {
 		n += 1 + l + sovRpcpb(uint64(l))
@@ -13357,43 +15884,59 @@ func (m *KVRangeDeleteRequest) Size() (n int) {
 	return n
 }
 
-func (m *KVRangeDeleteResponse) Size() (n int) {
+func (m *ScatterShardsRsp) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if m.Scattered != 0 {
+		n += 1 + sovRpcpb(uint64(m.Scattered))
+	}
+	if m.Failed != 0 {
+		n += 1 + sovRpcpb(uint64(m.Failed))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVScanRequest) Size() (n int) {
+func (m *EventNotify) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = len(m.Start)
-	if l > 0 {
+	if m.Seq != 0 {
+		n += 1 + sovRpcpb(uint64(m.Seq))
+	}
+	if m.Type != 0 {
+		n += 1 + sovRpcpb(uint64(m.Type))
+	}
+	if m.InitEvent != nil {
+		l = m.InitEvent.Size()
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	l = len(m.End)
-	if l > 0 {
+	if m.ShardEvent != nil {
+		l = m.ShardEvent.Size()
 		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	if m.Limit != 0 {
-		n += 1 + sovRpcpb(uint64(m.Limit))
+	if m.StoreEvent != nil {
+		l = m.StoreEvent.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	if m.LimitBytes != 0 {
-		n += 1 + sovRpcpb(uint64(m.LimitBytes))
+	if m.ShardStatsEvent != nil {
+		l = m.ShardStatsEvent.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	if m.WithValue {
-		n += 2
+	if m.StoreStatsEvent != nil {
+		l = m.StoreStatsEvent.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
 	}
-	if m.OnlyCount {
-		n += 2
+	if m.SettingEvent != nil {
+		l = m.SettingEvent.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -13401,33 +15944,42 @@ func (m *KVScanRequest) Size() (n int) {
 	return n
 }
 
-func (m *KVScanResponse) Size() (n int) {
+func (m *InitEventData) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
+	if len(m.Shards) > 0 {
+		for _, b := range m.Shards {
 			l = len(b)
 			n += 1 + l + sovRpcpb(uint64(l))
 		}
 	}
-	if len(m.Values) > 0 {
-		for _, b := range m.Values {
+	if len(m.Stores) > 0 {
+		for _, b := range m.Stores {
 			l = len(b)
 			n += 1 + l + sovRpcpb(uint64(l))
 		}
 	}
-	if m.Count != 0 {
-		n += 1 + sovRpcpb(uint64(m.Count))
+	if len(m.LeaderReplicaIDs) > 0 {
+		l = 0
+		for _, e := range m.LeaderReplicaIDs {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
 	}
-	if m.Completed {
-		n += 2
+	if len(m.Leases) > 0 {
+		for _, e := range m.Leases {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
 	}
-	l = len(m.ShardEnd)
-	if l > 0 {
-		n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Settings) > 0 {
+		for _, e := range m.Settings {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -13435,17 +15987,28 @@ func (m *KVScanResponse) Size() (n int) {
 	return n
 }
 
-func (m *KVBatchMixedWriteRequest) Size() (n int) {
+func (m *ShardEventData) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Requests) > 0 {
-		for _, e := range m.Requests {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.LeaderReplicaID != 0 {
+		n += 1 + sovRpcpb(uint64(m.LeaderReplicaID))
+	}
+	if m.Lease != nil {
+		l = m.Lease.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Removed {
+		n += 2
+	}
+	if m.Create {
+		n += 2
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -13453,17 +16016,15 @@ func (m *KVBatchMixedWriteRequest) Size() (n int) {
 	return n
 }
 
-func (m *KVBatchMixedWriteResponse) Size() (n int) {
+func (m *StoreEventData) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Responses) > 0 {
-		for _, e := range m.Responses {
-			l = e.Size()
-			n += 1 + l + sovRpcpb(uint64(l))
-		}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -13471,62 +16032,3896 @@ func (m *KVBatchMixedWriteResponse) Size() (n int) {
 	return n
 }
 
-func (m *KVMixedWriteRequest) Size() (n int) {
+func (m *ConfigChange) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.CmdType != 0 {
-		n += 1 + sovRpcpb(uint64(m.CmdType))
-	}
-	l = m.Set.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.Delete.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.RangeDelete.Size()
+	l = m.Replica.Size()
 	n += 1 + l + sovRpcpb(uint64(l))
+	if m.ChangeType != 0 {
+		n += 1 + sovRpcpb(uint64(m.ChangeType))
+	}
+	l = len(m.Initiator)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *KVMixedWriteResponse) Size() (n int) {
+func (m *TransferLeader) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.CmdType != 0 {
-		n += 1 + sovRpcpb(uint64(m.CmdType))
-	}
-	l = m.Set.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.Delete.Size()
-	n += 1 + l + sovRpcpb(uint64(l))
-	l = m.RangeDelete.Size()
+	l = m.Replica.Size()
 	n += 1 + l + sovRpcpb(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
-	return n
-}
+	return n
+}
+
+func (m *TransferLease) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Lease.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConfigChangeV2) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Merge) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Target)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SplitShard) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Policy != 0 {
+		n += 1 + sovRpcpb(uint64(m.Policy))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LabelConstraint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Op != 0 {
+		n += 1 + sovRpcpb(uint64(m.Op))
+	}
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			l = len(s)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PlacementRule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.GroupID)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovRpcpb(uint64(m.Index))
+	}
+	if m.Override {
+		n += 2
+	}
+	l = len(m.StartKey)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.EndKey)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Role != 0 {
+		n += 1 + sovRpcpb(uint64(m.Role))
+	}
+	if m.Count != 0 {
+		n += 1 + sovRpcpb(uint64(m.Count))
+	}
+	if len(m.LabelConstraints) > 0 {
+		for _, e := range m.LabelConstraints {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if len(m.LocationLabels) > 0 {
+		for _, s := range m.LocationLabels {
+			l = len(s)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	l = len(m.IsolationLevel)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestBatchHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.ShardID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ShardID))
+	}
+	l = m.Replica.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.Lease != nil {
+		l = m.Lease.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.CommitTime != 0 {
+		n += 1 + sovRpcpb(uint64(m.CommitTime))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovRpcpb(uint64(m.Priority))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseBatchHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = m.Error.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.Chunk != nil {
+		l = m.Chunk.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.Compressed)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ProposalChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovRpcpb(uint64(m.Index))
+	}
+	if m.Count != 0 {
+		n += 1 + sovRpcpb(uint64(m.Count))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Header.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Request) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Group != 0 {
+		n += 1 + sovRpcpb(uint64(m.Group))
+	}
+	if m.Type != 0 {
+		n += 1 + sovRpcpb(uint64(m.Type))
+	}
+	if m.CustomType != 0 {
+		n += 1 + sovRpcpb(uint64(m.CustomType))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.Cmd)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.PID != 0 {
+		n += 1 + sovRpcpb(uint64(m.PID))
+	}
+	if m.ToShard != 0 {
+		n += 1 + sovRpcpb(uint64(m.ToShard))
+	}
+	if m.IgnoreEpochCheck {
+		n += 2
+	}
+	l = m.Epoch.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.Lease != nil {
+		l = m.Lease.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.KeysRange != nil {
+		l = m.KeysRange.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.ReplicaSelectPolicy != 0 {
+		n += 1 + sovRpcpb(uint64(m.ReplicaSelectPolicy))
+	}
+	if m.TxnBatchRequest != nil {
+		l = m.TxnBatchRequest.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = m.UpdateTxnRecord.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.DeleteTxnRecord.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.CommitTxnWriteData.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.RollbackTxnRecord.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	l = m.CleanTxnMVCCData.Size()
+	n += 2 + l + sovRpcpb(uint64(l))
+	if m.AllowStaleRead {
+		n += 3
+	}
+	if m.AppLeaseToken != 0 {
+		n += 2 + sovRpcpb(uint64(m.AppLeaseToken))
+	}
+	if m.FollowerRead {
+		n += 3
+	}
+	l = len(m.CF)
+	if l > 0 {
+		n += 2 + l + sovRpcpb(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 2 + sovRpcpb(uint64(m.Priority))
+	}
+	if m.Timeout != 0 {
+		n += 2 + sovRpcpb(uint64(m.Timeout))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Range) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.From)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.To)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Response) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovRpcpb(uint64(m.Type))
+	}
+	if m.CustomType != 0 {
+		n += 1 + sovRpcpb(uint64(m.CustomType))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.PID != 0 {
+		n += 1 + sovRpcpb(uint64(m.PID))
+	}
+	l = m.Error.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.TxnBatchResponse != nil {
+		l = m.TxnBatchResponse.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.UpdateTxnRecord != nil {
+		l = m.UpdateTxnRecord.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.DeleteTxnRecord != nil {
+		l = m.DeleteTxnRecord.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.CommitTxnWriteData != nil {
+		l = m.CommitTxnWriteData.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.RollbackTxnRecord != nil {
+		l = m.RollbackTxnRecord.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.CleanTxnMVCCData != nil {
+		l = m.CleanTxnMVCCData.Size()
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.ApplyLag != 0 {
+		n += 1 + sovRpcpb(uint64(m.ApplyLag))
+	}
+	if m.AppliedIndex != 0 {
+		n += 1 + sovRpcpb(uint64(m.AppliedIndex))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConfigChangeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangeType != 0 {
+		n += 1 + sovRpcpb(uint64(m.ChangeType))
+	}
+	l = m.Replica.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = len(m.Initiator)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConfigChangeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Shard.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CompactLogRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompactIndex != 0 {
+		n += 1 + sovRpcpb(uint64(m.CompactIndex))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CompactLogResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransferLeaderRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Replica.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransferLeaderResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchSplitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	l = len(m.Context)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SplitRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Start)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.End)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.NewShardID != 0 {
+		n += 1 + sovRpcpb(uint64(m.NewShardID))
+	}
+	if len(m.NewReplicas) > 0 {
+		for _, e := range m.NewReplicas {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchSplitResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Shards) > 0 {
+		for _, e := range m.Shards {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateMetadataRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Metadata.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateMetadataResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateLabelsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.Policy != 0 {
+		n += 1 + sovRpcpb(uint64(m.Policy))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateLabelsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateGateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Gate.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateGateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AcquireAppLeaseRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Holder)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.TTL != 0 {
+		n += 1 + sovRpcpb(uint64(m.TTL))
+	}
+	if m.Now != 0 {
+		n += 1 + sovRpcpb(uint64(m.Now))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AcquireAppLeaseResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Acquired {
+		n += 2
+	}
+	l = m.Lease.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReleaseAppLeaseRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Token != 0 {
+		n += 1 + sovRpcpb(uint64(m.Token))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReleaseAppLeaseResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Released {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SplitShardRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.SplitKeys) > 0 {
+		for _, b := range m.SplitKeys {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SplitShardResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteRangeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Start)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.End)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.CheckIndex != 0 {
+		n += 1 + sovRpcpb(uint64(m.CheckIndex))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteRangeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Deleted {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BarrierRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BarrierResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovRpcpb(uint64(m.Index))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ComputeHashRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ComputeHashResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovRpcpb(uint64(m.Index))
+	}
+	if m.Hash != 0 {
+		n += 1 + sovRpcpb(uint64(m.Hash))
+	}
+	if m.Computed {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VerifyHashRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovRpcpb(uint64(m.Index))
+	}
+	if m.Hash != 0 {
+		n += 1 + sovRpcpb(uint64(m.Hash))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VerifyHashResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PurgeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Start)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.End)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PurgeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Purged {
+		n += 2
+	}
+	if m.Index != 0 {
+		n += 1 + sovRpcpb(uint64(m.Index))
+	}
+	l = len(m.Start)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.End)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateDictionaryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != 0 {
+		n += 1 + sovRpcpb(uint64(m.Version))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateDictionaryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Updated {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateEpochLeaseRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ShardID != 0 {
+		n += 1 + sovRpcpb(uint64(m.ShardID))
+	}
+	l = m.Lease.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateEpochLeaseResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateTxnRecordRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.TxnRecord.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateTxnRecordResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.TxnRecord.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteTxnRecordRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxnRecordRouteKey)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.TxnID)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteTxnRecordResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitTxnWriteDataRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.OriginKey)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = m.CommitTS.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CommitTxnWriteDataResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RollbackTxnWriteDataRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.OriginKey)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = m.Timestamp.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RollbackTxnWriteDataResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CleanTxnMVCCDataRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Timestamp.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CleanTxnMVCCDataResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVSetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVSetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVBatchSetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVBatchSetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVGetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVGetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVBatchGetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if len(m.Indexes) > 0 {
+		l = 0
+		for _, e := range m.Indexes {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVBatchGetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if len(m.Indexes) > 0 {
+		l = 0
+		for _, e := range m.Indexes {
+			l += sovRpcpb(uint64(e))
+		}
+		n += 1 + sovRpcpb(uint64(l)) + l
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVDeleteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVDeleteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVGetDelRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVGetDelResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVDeleteIfRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVDeleteIfResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Deleted {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVBatchDeleteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVBatchDeleteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVRangeDeleteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Start)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.End)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVRangeDeleteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVScanRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Start)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	l = len(m.End)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovRpcpb(uint64(m.Limit))
+	}
+	if m.LimitBytes != 0 {
+		n += 1 + sovRpcpb(uint64(m.LimitBytes))
+	}
+	if m.WithValue {
+		n += 2
+	}
+	if m.OnlyCount {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVScanResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			l = len(b)
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.Count != 0 {
+		n += 1 + sovRpcpb(uint64(m.Count))
+	}
+	if m.Completed {
+		n += 2
+	}
+	l = len(m.ShardEnd)
+	if l > 0 {
+		n += 1 + l + sovRpcpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVBatchMixedWriteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVBatchMixedWriteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovRpcpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVMixedWriteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CmdType != 0 {
+		n += 1 + sovRpcpb(uint64(m.CmdType))
+	}
+	l = m.Set.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.Delete.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.RangeDelete.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *KVMixedWriteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CmdType != 0 {
+		n += 1 + sovRpcpb(uint64(m.CmdType))
+	}
+	l = m.Set.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.Delete.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	l = m.RangeDelete.Size()
+	n += 1 + l + sovRpcpb(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovRpcpb(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozRpcpb(x uint64) (n int) {
+	return sovRpcpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProphetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProphetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+			}
+			m.StoreID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardHeartbeat", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ShardHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreHeartbeat", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.StoreHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PutStore", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PutStore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetStore", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetStore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllocID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AllocID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AskBatchSplit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AskBatchSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateDestroying", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CreateDestroying.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReportDestroyed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ReportDestroyed.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetDestroying", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetDestroying.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateWatcher", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CreateWatcher.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateShards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CreateShards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveShards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RemoveShards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckShardState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CheckShardState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PutPlacementRule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PutPlacementRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetAppliedRules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetAppliedRules.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CreateJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RemoveJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ExecuteJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddScheduleGroupRule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AddScheduleGroupRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetScheduleGroupRule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetScheduleGroupRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTombstoneReplicas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CheckTombstoneReplicas.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PutClusterSetting", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PutClusterSetting.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetClusterSettings", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetClusterSettings.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 27:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScatterShards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ScatterShards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProphetResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProphetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardHeartbeat", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ShardHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreHeartbeat", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.StoreHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PutStore", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PutStore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetStore", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetStore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllocID", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AllocID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AskBatchSplit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AskBatchSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateDestroying", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CreateDestroying.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReportDestroyed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ReportDestroyed.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetDestroying", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetDestroying.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Event.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateShards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CreateShards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveShards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RemoveShards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckShardState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CheckShardState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PutPlacementRule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PutPlacementRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetAppliedRules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetAppliedRules.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CreateJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RemoveJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteJob", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ExecuteJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddScheduleGroupRule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.AddScheduleGroupRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetScheduleGroupRule", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetScheduleGroupRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckTombstoneReplicas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CheckTombstoneReplicas.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PutClusterSetting", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PutClusterSetting.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 27:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GetClusterSettings", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GetClusterSettings.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 28:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScatterShards", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ScatterShards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ShardHeartbeatReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ShardHeartbeatReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+			}
+			m.StoreID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shard = append(m.Shard[:0], dAtA[iNdEx:postIndex]...)
+			if m.Shard == nil {
+				m.Shard = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Leader == nil {
+				m.Leader = &metapb.Replica{}
+			}
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DownReplicas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DownReplicas = append(m.DownReplicas, metapb.ReplicaStats{})
+			if err := m.DownReplicas[len(m.DownReplicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingReplicas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PendingReplicas = append(m.PendingReplicas, metapb.Replica{})
+			if err := m.PendingReplicas[len(m.PendingReplicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Lease == nil {
+				m.Lease = &metapb.EpochLease{}
+			}
+			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockingReasons", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockingReasons = append(m.BlockingReasons, metapb.BlockingReason{})
+			if err := m.BlockingReasons[len(m.BlockingReasons)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
 
-func sovRpcpb(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozRpcpb(x uint64) (n int) {
-	return sovRpcpb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
+func (m *ShardHeartbeatRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13549,17 +19944,213 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProphetRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ShardHeartbeatRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProphetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ShardHeartbeatRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			}
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardEpoch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ShardEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetReplica", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TargetReplica == nil {
+				m.TargetReplica = &metapb.Replica{}
+			}
+			if err := m.TargetReplica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigChange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigChange == nil {
+				m.ConfigChange = &ConfigChange{}
+			}
+			if err := m.ConfigChange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TransferLeader == nil {
+				m.TransferLeader = &TransferLeader{}
+			}
+			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Merge", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Merge == nil {
+				m.Merge = &Merge{}
+			}
+			if err := m.Merge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitShard", wireType)
 			}
-			m.ID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13569,16 +20160,33 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			m.StoreID = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SplitShard == nil {
+				m.SplitShard = &SplitShard{}
+			}
+			if err := m.SplitShard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigChangeV2", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13588,16 +20196,33 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StoreID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			m.Type = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConfigChangeV2 == nil {
+				m.ConfigChangeV2 = &ConfigChangeV2{}
+			}
+			if err := m.ConfigChangeV2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferLease", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13607,16 +20232,33 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= Type(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardHeartbeat", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			var msglen int
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TransferLease == nil {
+				m.TransferLease = &TransferLease{}
+			}
+			if err := m.TransferLease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DestroyDirectly", wireType)
+			}
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13626,30 +20268,71 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			m.DestroyDirectly = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ShardHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutStoreReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 5:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutStoreReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutStoreReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreHeartbeat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Store", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13659,30 +20342,85 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.StoreHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Store = append(m.Store[:0], dAtA[iNdEx:postIndex]...)
+			if m.Store == nil {
+				m.Store = []byte{}
 			}
 			iNdEx = postIndex
-		case 6:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutStoreRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutStoreRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutStoreRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PutStore", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DestroyShards", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13692,28 +20430,83 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DestroyShards = append(m.DestroyShards[:0], dAtA[iNdEx:postIndex]...)
+			if m.DestroyShards == nil {
+				m.DestroyShards = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreHeartbeatReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PutStore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 7:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreHeartbeatReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreHeartbeatReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetStore", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13740,15 +20533,15 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetStore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AllocID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13758,63 +20551,85 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AllocID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AskBatchSplit", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AskBatchSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreHeartbeatRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 10:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreHeartbeatRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreHeartbeatRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateDestroying", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13824,63 +20639,85 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateDestroying.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReportDestroyed", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ReportDestroyed.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetStoreReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 12:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetDestroying", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var msglen int
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetStoreReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetStoreReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13890,30 +20727,70 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetStoreRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetDestroying.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 13:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetStoreRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetStoreRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateWatcher", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -13923,28 +20800,29 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateWatcher.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		case 14:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateShards", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13971,81 +20849,126 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateShards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Stats == nil {
+				m.Stats = &metapb.StoreStats{}
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 15:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveShards", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RemoveShards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AllocIDReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 16:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckShardState", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			if msglen < 0 {
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AllocIDReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AllocIDReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CheckShardState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AllocIDRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 17:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PutPlacementRule", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var msglen int
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AllocIDRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AllocIDRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14055,30 +20978,70 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PutPlacementRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AskBatchSplitReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 18:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AskBatchSplitReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AskBatchSplitReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetAppliedRules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14088,30 +21051,31 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetAppliedRules.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		case 19:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateJob", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
-			var msglen int
+			m.Count = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14121,28 +21085,68 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Count |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AskBatchSplitRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 20:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AskBatchSplitRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AskBatchSplitRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveJob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitIDs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14169,15 +21173,70 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RemoveJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.SplitIDs = append(m.SplitIDs, SplitID{})
+			if err := m.SplitIDs[len(m.SplitIDs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 21:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteJob", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateDestroyingReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateDestroyingReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateDestroyingReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14187,30 +21246,16 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.ExecuteJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 22:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AddScheduleGroupRule", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14220,30 +21265,92 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Index |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.AddScheduleGroupRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Replicas = append(m.Replicas, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Replicas) == 0 {
+					m.Replicas = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Replicas = append(m.Replicas, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
 			}
-			iNdEx = postIndex
-		case 23:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetScheduleGroupRule", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemoveData", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14253,25 +21360,12 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.GetScheduleGroupRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.RemoveData = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -14297,7 +21391,7 @@ func (m *ProphetRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
+func (m *CreateDestroyingRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14320,36 +21414,17 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProphetResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: CreateDestroyingRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProphetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CreateDestroyingRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			m.ID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
-			m.Type = 0
+			m.State = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14359,48 +21434,70 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= Type(b&0x7F) << shift
+				m.State |= metapb.ShardState(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Error = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetDestroyingReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			var stringLen uint64
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetDestroyingReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetDestroyingReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14410,27 +21507,68 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Leader = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetDestroyingRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetDestroyingRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetDestroyingRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardHeartbeat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -14457,15 +21595,72 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ShardHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Status == nil {
+				m.Status = &metapb.DestroyingStatus{}
+			}
+			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreHeartbeat", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReportDestroyedReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReportDestroyedReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReportDestroyedReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var msglen int
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14475,30 +21670,16 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.StoreHeartbeat.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PutStore", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
 			}
-			var msglen int
+			m.ReplicaID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14508,30 +21689,70 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ReplicaID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PutStore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReportDestroyedRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetStore", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var msglen int
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReportDestroyedRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReportDestroyedRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14541,30 +21762,70 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.State |= metapb.ShardState(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetStore.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SplitID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AllocID", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var msglen int
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplitID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplitID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewID", wireType)
+			}
+			m.NewID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14574,96 +21835,146 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.NewID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.AllocID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 10:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AskBatchSplit", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.NewReplicaIDs = append(m.NewReplicaIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.NewReplicaIDs) == 0 {
+					m.NewReplicaIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.NewReplicaIDs = append(m.NewReplicaIDs, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewReplicaIDs", wireType)
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AskBatchSplit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateDestroying", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateWatcherReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateDestroying.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 12:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReportDestroyed", wireType)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateWatcherReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateWatcherReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag", wireType)
 			}
-			var msglen int
+			m.Flag = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14673,63 +21984,70 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Flag |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ReportDestroyed.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 13:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetDestroying", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateShardsReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetDestroying.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 14:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateShardsReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateShardsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14739,228 +22057,397 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Event.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Shards = append(m.Shards, make([]byte, postIndex-iNdEx))
+			copy(m.Shards[len(m.Shards)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 15:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateShards", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
+				m.LeastReplicas = append(m.LeastReplicas, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.LeastReplicas) == 0 {
+					m.LeastReplicas = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.LeastReplicas = append(m.LeastReplicas, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeastReplicas", wireType)
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateShards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateShardsRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 16:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveShards", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			if msglen < 0 {
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateShardsRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateShardsRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RemoveShards.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveShardsReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 17:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckShardState", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveShardsReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveShardsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
+				m.IDs = append(m.IDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
 				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.CheckShardState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 18:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PutPlacementRule", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
 				}
-				if iNdEx >= l {
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.IDs) == 0 {
+					m.IDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.IDs = append(m.IDs, v)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.PutPlacementRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveShardsRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 19:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetAppliedRules", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			if msglen < 0 {
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveShardsRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveShardsRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetAppliedRules.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 20:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateJob", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckShardStateReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CreateJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 21:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckShardStateReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckShardStateReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveJob", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -14970,63 +22457,85 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RemoveJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.IDs = append(m.IDs[:0], dAtA[iNdEx:postIndex]...)
+			if m.IDs == nil {
+				m.IDs = []byte{}
 			}
 			iNdEx = postIndex
-		case 22:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteJob", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ExecuteJob.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckShardStateRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 23:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckShardStateRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckShardStateRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AddScheduleGroupRule", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Destroyed", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -15036,30 +22545,31 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.AddScheduleGroupRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Destroyed = append(m.Destroyed[:0], dAtA[iNdEx:postIndex]...)
+			if m.Destroyed == nil {
+				m.Destroyed = []byte{}
 			}
 			iNdEx = postIndex
-		case 24:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GetScheduleGroupRule", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Destroying", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -15069,23 +22579,24 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.GetScheduleGroupRule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Destroying = append(m.Destroying[:0], dAtA[iNdEx:postIndex]...)
+			if m.Destroying == nil {
+				m.Destroying = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -15113,7 +22624,7 @@ func (m *ProphetResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
+func (m *TombstoneReplica) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15136,17 +22647,17 @@ func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardHeartbeatReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: TombstoneReplica: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardHeartbeatReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TombstoneReplica: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
 			}
-			m.StoreID = 0
+			m.ShardID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -15156,50 +22667,16 @@ func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StoreID |= uint64(b&0x7F) << shift
+				m.ShardID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Shard = append(m.Shard[:0], dAtA[iNdEx:postIndex]...)
-			if m.Shard == nil {
-				m.Shard = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
 			}
-			m.Term = 0
+			m.ReplicaID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -15209,14 +22686,14 @@ func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= uint64(b&0x7F) << shift
+				m.ReplicaID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15243,16 +22720,67 @@ func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Leader == nil {
-				m.Leader = &metapb.Replica{}
-			}
-			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Epoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckTombstoneReplicasReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckTombstoneReplicasReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckTombstoneReplicasReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DownReplicas", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15279,16 +22807,70 @@ func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DownReplicas = append(m.DownReplicas, metapb.ReplicaStats{})
-			if err := m.DownReplicas[len(m.DownReplicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Replicas = append(m.Replicas, TombstoneReplica{})
+			if err := m.Replicas[len(m.Replicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CheckTombstoneReplicasRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CheckTombstoneReplicasRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CheckTombstoneReplicasRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PendingReplicas", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Removable", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -15298,29 +22880,83 @@ func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PendingReplicas = append(m.PendingReplicas, metapb.Replica{})
-			if err := m.PendingReplicas[len(m.PendingReplicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Removable = append(m.Removable[:0], dAtA[iNdEx:postIndex]...)
+			if m.Removable == nil {
+				m.Removable = []byte{}
 			}
 			iNdEx = postIndex
-		case 7:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutPlacementRuleReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutPlacementRuleReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutPlacementRuleReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Rule", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15347,78 +22983,64 @@ func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Rule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupKey", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GroupKey = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutPlacementRuleRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Lease == nil {
-				m.Lease = &metapb.EpochLease{}
-			}
-			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutPlacementRuleRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutPlacementRuleRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -15444,7 +23066,7 @@ func (m *ShardHeartbeatReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ShardHeartbeatRsp) Unmarshal(dAtA []byte) error {
+func (m *GetAppliedRulesReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15467,10 +23089,10 @@ func (m *ShardHeartbeatRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ShardHeartbeatRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetAppliedRulesReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardHeartbeatRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetAppliedRulesReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -15492,42 +23114,63 @@ func (m *ShardHeartbeatRsp) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardEpoch", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ShardEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAppliedRulesRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 3:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAppliedRulesRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAppliedRulesRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TargetReplica", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15554,52 +23197,68 @@ func (m *ShardHeartbeatRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TargetReplica == nil {
-				m.TargetReplica = &metapb.Replica{}
-			}
-			if err := m.TargetReplica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Rules = append(m.Rules, PlacementRule{})
+			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConfigChange", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConfigChange == nil {
-				m.ConfigChange = &ConfigChange{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateJobReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if err := m.ConfigChange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 5:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateJobReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15626,88 +23285,121 @@ func (m *ShardHeartbeatRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TransferLeader == nil {
-				m.TransferLeader = &TransferLeader{}
-			}
-			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Merge", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Merge == nil {
-				m.Merge = &Merge{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateJobRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if err := m.Merge.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SplitShard", wireType)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateJobRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.SplitShard == nil {
-				m.SplitShard = &SplitShard{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveJobReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if err := m.SplitShard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 8:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveJobReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConfigChangeV2", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -15734,69 +23426,64 @@ func (m *ShardHeartbeatRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConfigChangeV2 == nil {
-				m.ConfigChangeV2 = &ConfigChangeV2{}
-			}
-			if err := m.ConfigChangeV2.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransferLease", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TransferLease == nil {
-				m.TransferLease = &TransferLease{}
-			}
-			if err := m.TransferLease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveJobRsp) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DestroyDirectly", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			m.DestroyDirectly = bool(v != 0)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveJobRsp: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -15822,7 +23509,7 @@ func (m *ShardHeartbeatRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutStoreReq) Unmarshal(dAtA []byte) error {
+func (m *ExecuteJobReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15845,15 +23532,48 @@ func (m *PutStoreReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutStoreReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExecuteJobReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutStoreReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExecuteJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Store", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -15880,9 +23600,9 @@ func (m *PutStoreReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Store = append(m.Store[:0], dAtA[iNdEx:postIndex]...)
-			if m.Store == nil {
-				m.Store = []byte{}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -15910,7 +23630,7 @@ func (m *PutStoreReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutStoreRsp) Unmarshal(dAtA []byte) error {
+func (m *ExecuteJobRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -15933,15 +23653,15 @@ func (m *PutStoreRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutStoreRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExecuteJobRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutStoreRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExecuteJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DestroyShards", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -15968,9 +23688,9 @@ func (m *PutStoreRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DestroyShards = append(m.DestroyShards[:0], dAtA[iNdEx:postIndex]...)
-			if m.DestroyShards == nil {
-				m.DestroyShards = []byte{}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -15998,7 +23718,7 @@ func (m *PutStoreRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StoreHeartbeatReq) Unmarshal(dAtA []byte) error {
+func (m *AddScheduleGroupRuleReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16021,15 +23741,15 @@ func (m *StoreHeartbeatReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StoreHeartbeatReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddScheduleGroupRuleReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreHeartbeatReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddScheduleGroupRuleReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Rule", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16056,44 +23776,10 @@ func (m *StoreHeartbeatReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Rule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -16119,7 +23805,7 @@ func (m *StoreHeartbeatReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StoreHeartbeatRsp) Unmarshal(dAtA []byte) error {
+func (m *AddScheduleGroupRuleRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16142,46 +23828,12 @@ func (m *StoreHeartbeatRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StoreHeartbeatRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: AddScheduleGroupRuleRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreHeartbeatRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AddScheduleGroupRuleRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -16207,7 +23859,7 @@ func (m *StoreHeartbeatRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetStoreReq) Unmarshal(dAtA []byte) error {
+func (m *GetScheduleGroupRuleReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16230,31 +23882,12 @@ func (m *GetStoreReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetStoreReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetScheduleGroupRuleReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetStoreReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetScheduleGroupRuleReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			m.ID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -16280,7 +23913,7 @@ func (m *GetStoreReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetStoreRsp) Unmarshal(dAtA []byte) error {
+func (m *GetScheduleGroupRuleRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16303,49 +23936,15 @@ func (m *GetStoreRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetStoreRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetScheduleGroupRuleRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetStoreRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetScheduleGroupRuleRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16372,10 +23971,8 @@ func (m *GetStoreRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Stats == nil {
-				m.Stats = &metapb.StoreStats{}
-			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Rules = append(m.Rules, metapb.ScheduleGroupRule{})
+			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -16404,7 +24001,7 @@ func (m *GetStoreRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AllocIDReq) Unmarshal(dAtA []byte) error {
+func (m *PutClusterSettingReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16427,12 +24024,45 @@ func (m *AllocIDReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AllocIDReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: PutClusterSettingReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AllocIDReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PutClusterSettingReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Setting", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Setting.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -16458,7 +24088,7 @@ func (m *AllocIDReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AllocIDRsp) Unmarshal(dAtA []byte) error {
+func (m *PutClusterSettingRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16481,17 +24111,17 @@ func (m *AllocIDRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AllocIDRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: PutClusterSettingRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AllocIDRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PutClusterSettingRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Setting", wireType)
 			}
-			m.ID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -16501,11 +24131,25 @@ func (m *AllocIDRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Setting.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -16531,7 +24175,7 @@ func (m *AllocIDRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AskBatchSplitReq) Unmarshal(dAtA []byte) error {
+func (m *GetClusterSettingsReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16554,65 +24198,12 @@ func (m *AskBatchSplitReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AskBatchSplitReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetClusterSettingsReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AskBatchSplitReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetClusterSettingsReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
-			}
-			m.Count = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Count |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -16638,7 +24229,7 @@ func (m *AskBatchSplitReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AskBatchSplitRsp) Unmarshal(dAtA []byte) error {
+func (m *GetClusterSettingsRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16661,15 +24252,15 @@ func (m *AskBatchSplitRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AskBatchSplitRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetClusterSettingsRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AskBatchSplitRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetClusterSettingsRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SplitIDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Settings", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -16696,8 +24287,8 @@ func (m *AskBatchSplitRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SplitIDs = append(m.SplitIDs, SplitID{})
-			if err := m.SplitIDs[len(m.SplitIDs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Settings = append(m.Settings, metapb.ClusterSetting{})
+			if err := m.Settings[len(m.Settings)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -16726,7 +24317,7 @@ func (m *AskBatchSplitRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateDestroyingReq) Unmarshal(dAtA []byte) error {
+func (m *ScatterShardsReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -16749,17 +24340,17 @@ func (m *CreateDestroyingReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateDestroyingReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ScatterShardsReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateDestroyingReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ScatterShardsReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
 			}
-			m.ID = 0
+			m.Group = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -16769,111 +24360,16 @@ func (m *CreateDestroyingReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
+				m.Group |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
-			}
-			m.Index = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Index |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Replicas = append(m.Replicas, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Replicas) == 0 {
-					m.Replicas = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Replicas = append(m.Replicas, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemoveData", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
 			}
-			var v int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -16883,71 +24379,31 @@ func (m *CreateDestroyingReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.RemoveData = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CreateDestroyingRsp) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
+			if m.Start == nil {
+				m.Start = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CreateDestroyingRsp: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateDestroyingRsp: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
 			}
-			m.State = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -16957,11 +24413,26 @@ func (m *CreateDestroyingRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= metapb.ShardState(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
+			if m.End == nil {
+				m.End = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -16987,7 +24458,7 @@ func (m *CreateDestroyingRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetDestroyingReq) Unmarshal(dAtA []byte) error {
+func (m *ScatterShardsRsp) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17010,17 +24481,17 @@ func (m *GetDestroyingReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetDestroyingReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ScatterShardsRsp: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetDestroyingReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ScatterShardsRsp: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Scattered", wireType)
 			}
-			m.ID = 0
+			m.Scattered = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -17030,7 +24501,26 @@ func (m *GetDestroyingReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
+				m.Scattered |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failed", wireType)
+			}
+			m.Failed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Failed |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -17060,7 +24550,7 @@ func (m *GetDestroyingReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetDestroyingRsp) Unmarshal(dAtA []byte) error {
+func (m *EventNotify) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17083,15 +24573,233 @@ func (m *GetDestroyingRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetDestroyingRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: EventNotify: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetDestroyingRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EventNotify: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			m.Seq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Seq |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InitEvent == nil {
+				m.InitEvent = &InitEventData{}
+			}
+			if err := m.InitEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ShardEvent == nil {
+				m.ShardEvent = &ShardEventData{}
+			}
+			if err := m.ShardEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StoreEvent == nil {
+				m.StoreEvent = &StoreEventData{}
+			}
+			if err := m.StoreEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardStatsEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ShardStatsEvent == nil {
+				m.ShardStatsEvent = &metapb.ShardStats{}
+			}
+			if err := m.ShardStatsEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreStatsEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StoreStatsEvent == nil {
+				m.StoreStatsEvent = &metapb.StoreStats{}
+			}
+			if err := m.StoreStatsEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SettingEvent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -17118,10 +24826,10 @@ func (m *GetDestroyingRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Status == nil {
-				m.Status = &metapb.DestroyingStatus{}
+			if m.SettingEvent == nil {
+				m.SettingEvent = &metapb.ClusterSetting{}
 			}
-			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.SettingEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -17150,7 +24858,7 @@ func (m *GetDestroyingRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReportDestroyedReq) Unmarshal(dAtA []byte) error {
+func (m *InitEventData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17173,17 +24881,17 @@ func (m *ReportDestroyedReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReportDestroyedReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: InitEventData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReportDestroyedReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InitEventData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
 			}
-			m.ID = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -17193,16 +24901,29 @@ func (m *ReportDestroyedReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ID |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Shards = append(m.Shards, make([]byte, postIndex-iNdEx))
+			copy(m.Shards[len(m.Shards)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stores", wireType)
 			}
-			m.ReplicaID = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -17212,11 +24933,168 @@ func (m *ReportDestroyedReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReplicaID |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Stores = append(m.Stores, make([]byte, postIndex-iNdEx))
+			copy(m.Stores[len(m.Stores)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.LeaderReplicaIDs = append(m.LeaderReplicaIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.LeaderReplicaIDs) == 0 {
+					m.LeaderReplicaIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.LeaderReplicaIDs = append(m.LeaderReplicaIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderReplicaIDs", wireType)
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leases", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Leases = append(m.Leases, metapb.EpochLease{})
+			if err := m.Leases[len(m.Leases)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Settings", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Settings = append(m.Settings, metapb.ClusterSetting{})
+			if err := m.Settings[len(m.Settings)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -17242,7 +25120,7 @@ func (m *ReportDestroyedReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReportDestroyedRsp) Unmarshal(dAtA []byte) error {
+func (m *ShardEventData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17265,17 +25143,70 @@ func (m *ReportDestroyedRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReportDestroyedRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: ShardEventData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReportDestroyedRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ShardEventData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderReplicaID", wireType)
+			}
+			m.LeaderReplicaID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaderReplicaID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.State = 0
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -17285,70 +25216,33 @@ func (m *ReportDestroyedRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.State |= metapb.ShardState(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SplitID) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Lease == nil {
+				m.Lease = &metapb.EpochLease{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SplitID: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SplitID: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
 			}
-			m.NewID = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -17358,87 +25252,32 @@ func (m *SplitID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NewID |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.NewReplicaIDs = append(m.NewReplicaIDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
+			m.Removed = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Create", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.NewReplicaIDs) == 0 {
-					m.NewReplicaIDs = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.NewReplicaIDs = append(m.NewReplicaIDs, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewReplicaIDs", wireType)
 			}
+			m.Create = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -17464,7 +25303,7 @@ func (m *SplitID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateWatcherReq) Unmarshal(dAtA []byte) error {
+func (m *StoreEventData) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17487,17 +25326,17 @@ func (m *CreateWatcherReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateWatcherReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: StoreEventData: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateWatcherReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StoreEventData: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Flag", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			m.Flag = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -17507,11 +25346,26 @@ func (m *CreateWatcherReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Flag |= uint32(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -17537,7 +25391,7 @@ func (m *CreateWatcherReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateShardsReq) Unmarshal(dAtA []byte) error {
+func (m *ConfigChange) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17560,17 +25414,17 @@ func (m *CreateShardsReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateShardsReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConfigChange: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateShardsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConfigChange: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -17580,100 +25434,76 @@ func (m *CreateShardsReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Shards = append(m.Shards, make([]byte, postIndex-iNdEx))
-			copy(m.Shards[len(m.Shards)-1], dAtA[iNdEx:postIndex])
+			if err := m.Replica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+			}
+			m.ChangeType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
 				}
-				m.LeastReplicas = append(m.LeastReplicas, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChangeType |= metapb.ConfigChangeType(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Initiator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.LeastReplicas) == 0 {
-					m.LeastReplicas = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.LeastReplicas = append(m.LeastReplicas, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeastReplicas", wireType)
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Initiator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -17699,7 +25529,7 @@ func (m *CreateShardsReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateShardsRsp) Unmarshal(dAtA []byte) error {
+func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17722,12 +25552,45 @@ func (m *CreateShardsRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateShardsRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferLeader: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateShardsRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferLeader: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Replica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -17753,7 +25616,7 @@ func (m *CreateShardsRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveShardsReq) Unmarshal(dAtA []byte) error {
+func (m *TransferLease) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17776,88 +25639,45 @@ func (m *RemoveShardsReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveShardsReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferLease: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveShardsReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferLease: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.IDs = append(m.IDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.IDs) == 0 {
-					m.IDs = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.IDs = append(m.IDs, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -17883,7 +25703,7 @@ func (m *RemoveShardsReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveShardsRsp) Unmarshal(dAtA []byte) error {
+func (m *ConfigChangeV2) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17906,12 +25726,46 @@ func (m *RemoveShardsRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveShardsRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConfigChangeV2: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveShardsRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConfigChangeV2: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changes = append(m.Changes, ConfigChange{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -17937,7 +25791,7 @@ func (m *RemoveShardsRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CheckShardStateReq) Unmarshal(dAtA []byte) error {
+func (m *Merge) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -17960,15 +25814,15 @@ func (m *CheckShardStateReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CheckShardStateReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: Merge: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CheckShardStateReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Merge: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -17995,9 +25849,9 @@ func (m *CheckShardStateReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IDs = append(m.IDs[:0], dAtA[iNdEx:postIndex]...)
-			if m.IDs == nil {
-				m.IDs = []byte{}
+			m.Target = append(m.Target[:0], dAtA[iNdEx:postIndex]...)
+			if m.Target == nil {
+				m.Target = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -18025,7 +25879,7 @@ func (m *CheckShardStateReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CheckShardStateRsp) Unmarshal(dAtA []byte) error {
+func (m *SplitShard) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -18048,17 +25902,17 @@ func (m *CheckShardStateRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CheckShardStateRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: SplitShard: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CheckShardStateRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SplitShard: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Destroyed", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
 			}
-			var byteLen int
+			m.Policy = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -18068,29 +25922,14 @@ func (m *CheckShardStateRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Policy |= metapb.CheckPolicy(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Destroyed = append(m.Destroyed[:0], dAtA[iNdEx:postIndex]...)
-			if m.Destroyed == nil {
-				m.Destroyed = []byte{}
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Destroying", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -18117,10 +25956,8 @@ func (m *CheckShardStateRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Destroying = append(m.Destroying[:0], dAtA[iNdEx:postIndex]...)
-			if m.Destroying == nil {
-				m.Destroying = []byte{}
-			}
+			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
+			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -18147,7 +25984,7 @@ func (m *CheckShardStateRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutPlacementRuleReq) Unmarshal(dAtA []byte) error {
+func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -18170,17 +26007,17 @@ func (m *PutPlacementRuleReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutPlacementRuleReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelConstraint: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutPlacementRuleReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelConstraint: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Rule", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -18190,79 +26027,75 @@ func (m *PutPlacementRuleReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Rule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Key = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
+			m.Op = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Op |= LabelConstraintOp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PutPlacementRuleRsp) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PutPlacementRuleRsp: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutPlacementRuleRsp: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -18288,7 +26121,7 @@ func (m *PutPlacementRuleRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetAppliedRulesReq) Unmarshal(dAtA []byte) error {
+func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -18311,17 +26144,17 @@ func (m *GetAppliedRulesReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetAppliedRulesReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: PlacementRule: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetAppliedRulesReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PlacementRule: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			m.ShardID = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -18331,70 +26164,100 @@ func (m *GetAppliedRulesReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetAppliedRulesRsp) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
 			}
-			if iNdEx >= l {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.GroupID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Override", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetAppliedRulesRsp: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetAppliedRulesRsp: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Override = bool(v != 0)
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -18404,83 +26267,101 @@ func (m *GetAppliedRulesRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Rules = append(m.Rules, PlacementRule{})
-			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.StartKey = append(m.StartKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.StartKey == nil {
+				m.StartKey = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
 			}
-			if skippy < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CreateJobReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			m.EndKey = append(m.EndKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EndKey == nil {
+				m.EndKey = []byte{}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= ReplicaRoleType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CreateJobReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelConstraints", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -18507,123 +26388,48 @@ func (m *CreateJobReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.LabelConstraints = append(m.LabelConstraints, LabelConstraint{})
+			if err := m.LabelConstraints[len(m.LabelConstraints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CreateJobRsp) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CreateJobRsp: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocationLabels", wireType)
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *RemoveJobReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveJobReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LocationLabels = append(m.LocationLabels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IsolationLevel", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -18633,24 +26439,23 @@ func (m *RemoveJobReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.IsolationLevel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -18677,7 +26482,7 @@ func (m *RemoveJobReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveJobRsp) Unmarshal(dAtA []byte) error {
+func (m *RequestBatchHeader) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -18700,69 +26505,68 @@ func (m *RemoveJobRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveJobRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestBatchHeader: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestBatchHeader: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			if skippy < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ExecuteJobReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ShardID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ExecuteJobReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExecuteJobReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Job", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -18789,15 +26593,15 @@ func (m *ExecuteJobReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Job.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Replica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -18807,85 +26611,33 @@ func (m *ExecuteJobReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			if m.Lease == nil {
+				m.Lease = &metapb.EpochLease{}
 			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
+			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ExecuteJobRsp) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ExecuteJobRsp: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ExecuteJobRsp: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTime", wireType)
 			}
-			var byteLen int
+			m.CommitTime = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -18895,26 +26647,30 @@ func (m *ExecuteJobRsp) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.CommitTime |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= RequestPriority(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -18940,7 +26696,7 @@ func (m *ExecuteJobRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddScheduleGroupRuleReq) Unmarshal(dAtA []byte) error {
+func (m *ResponseBatchHeader) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -18963,15 +26719,49 @@ func (m *AddScheduleGroupRuleReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddScheduleGroupRuleReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResponseBatchHeader: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddScheduleGroupRuleReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResponseBatchHeader: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Rule", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -18998,7 +26788,7 @@ func (m *AddScheduleGroupRuleReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Rule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -19027,7 +26817,7 @@ func (m *AddScheduleGroupRuleReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddScheduleGroupRuleRsp) Unmarshal(dAtA []byte) error {
+func (m *RequestBatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -19050,123 +26840,82 @@ func (m *AddScheduleGroupRuleRsp) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddScheduleGroupRuleRsp: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestBatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddScheduleGroupRuleRsp: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestBatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetScheduleGroupRuleReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetScheduleGroupRuleReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetScheduleGroupRuleReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GetScheduleGroupRuleRsp) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Requests = append(m.Requests, Request{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GetScheduleGroupRuleRsp: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetScheduleGroupRuleRsp: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Chunk", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -19193,11 +26942,47 @@ func (m *GetScheduleGroupRuleRsp) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Rules = append(m.Rules, metapb.ScheduleGroupRule{})
-			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Chunk == nil {
+				m.Chunk = &ProposalChunk{}
+			}
+			if err := m.Chunk.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compressed", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compressed = append(m.Compressed[:0], dAtA[iNdEx:postIndex]...)
+			if m.Compressed == nil {
+				m.Compressed = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -19223,7 +27008,7 @@ func (m *GetScheduleGroupRuleRsp) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EventNotify) Unmarshal(dAtA []byte) error {
+func (m *ProposalChunk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -19246,17 +27031,17 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EventNotify: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProposalChunk: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EventNotify: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProposalChunk: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.Seq = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -19266,35 +27051,16 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Seq |= uint64(b&0x7F) << shift
+				m.Index |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InitEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
-			var msglen int
+			m.Count = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -19304,33 +27070,16 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Count |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.InitEvent == nil {
-				m.InitEvent = &InitEventData{}
-			}
-			if err := m.InitEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -19340,67 +27089,83 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ShardEvent == nil {
-				m.ShardEvent = &ShardEventData{}
-			}
-			if err := m.ShardEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreEvent", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StoreEvent == nil {
-				m.StoreEvent = &StoreEventData{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if err := m.StoreEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 6:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardStatsEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -19427,16 +27192,13 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ShardStatsEvent == nil {
-				m.ShardStatsEvent = &metapb.ShardStats{}
-			}
-			if err := m.ShardStatsEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreStatsEvent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -19463,10 +27225,8 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StoreStatsEvent == nil {
-				m.StoreStatsEvent = &metapb.StoreStats{}
-			}
-			if err := m.StoreStatsEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Responses = append(m.Responses, Response{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -19495,7 +27255,7 @@ func (m *EventNotify) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *InitEventData) Unmarshal(dAtA []byte) error {
+func (m *Request) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -19518,15 +27278,15 @@ func (m *InitEventData) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: InitEventData: wiretype end group for non-group")
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InitEventData: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -19553,14 +27313,16 @@ func (m *InitEventData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Shards = append(m.Shards, make([]byte, postIndex-iNdEx))
-			copy(m.Shards[len(m.Shards)-1], dAtA[iNdEx:postIndex])
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stores", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
 			}
-			var byteLen int
+			m.Group = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -19570,105 +27332,54 @@ func (m *InitEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Group |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Stores = append(m.Stores, make([]byte, postIndex-iNdEx))
-			copy(m.Stores[len(m.Stores)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
 				}
-				m.LeaderReplicaIDs = append(m.LeaderReplicaIDs, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= CmdType(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CustomType", wireType)
+			}
+			m.CustomType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.LeaderReplicaIDs) == 0 {
-					m.LeaderReplicaIDs = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.LeaderReplicaIDs = append(m.LeaderReplicaIDs, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CustomType |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaderReplicaIDs", wireType)
 			}
-		case 4:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leases", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -19678,83 +27389,29 @@ func (m *InitEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Leases = append(m.Leases, metapb.EpochLease{})
-			if err := m.Leases[len(m.Leases)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ShardEventData) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ShardEventData: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ShardEventData: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cmd", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -19781,16 +27438,16 @@ func (m *ShardEventData) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Cmd = append(m.Cmd[:0], dAtA[iNdEx:postIndex]...)
+			if m.Cmd == nil {
+				m.Cmd = []byte{}
 			}
 			iNdEx = postIndex
-		case 2:
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaderReplicaID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PID", wireType)
 			}
-			m.LeaderReplicaID = 0
+			m.PID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -19800,16 +27457,16 @@ func (m *ShardEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LeaderReplicaID |= uint64(b&0x7F) << shift
+				m.PID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ToShard", wireType)
 			}
-			var msglen int
+			m.ToShard = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -19819,31 +27476,14 @@ func (m *ShardEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ToShard |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Lease == nil {
-				m.Lease = &metapb.EpochLease{}
-			}
-			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreEpochCheck", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -19860,12 +27500,12 @@ func (m *ShardEventData) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Removed = bool(v != 0)
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Create", wireType)
+			m.IgnoreEpochCheck = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -19875,71 +27515,30 @@ func (m *ShardEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Create = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *StoreEventData) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Epoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: StoreEventData: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreEventData: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -19949,83 +27548,31 @@ func (m *StoreEventData) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ConfigChange) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Lease == nil {
+				m.Lease = &metapb.EpochLease{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigChange: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigChange: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field KeysRange", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -20052,15 +27599,18 @@ func (m *ConfigChange) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Replica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.KeysRange == nil {
+				m.KeysRange = &Range{}
+			}
+			if err := m.KeysRange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 13:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaSelectPolicy", wireType)
 			}
-			m.ChangeType = 0
+			m.ReplicaSelectPolicy = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20070,68 +27620,50 @@ func (m *ConfigChange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ChangeType |= metapb.ConfigChangeType(b&0x7F) << shift
+				m.ReplicaSelectPolicy |= ReplicaSelectPolicy(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxnBatchRequest", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *TransferLeader) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.TxnBatchRequest == nil {
+				m.TxnBatchRequest = &txnpb.TxnBatchRequest{}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.TxnBatchRequest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: TransferLeader: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferLeader: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateTxnRecord", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -20158,67 +27690,46 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Replica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.UpdateTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteTxnRecord", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *TransferLease) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.DeleteTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: TransferLease: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferLease: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTxnWriteData", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -20245,67 +27756,46 @@ func (m *TransferLease) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CommitTxnWriteData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RollbackTxnRecord", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ConfigChangeV2) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigChangeV2: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigChangeV2: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RollbackTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CleanTxnMVCCData", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -20332,70 +27822,74 @@ func (m *ConfigChangeV2) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Changes = append(m.Changes, ConfigChange{})
-			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.CleanTxnMVCCData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowStaleRead", wireType)
 			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			m.AllowStaleRead = bool(v != 0)
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppLeaseToken", wireType)
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Merge) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			m.AppLeaseToken = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppLeaseToken |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FollowerRead", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Merge: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Merge: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.FollowerRead = bool(v != 0)
+		case 23:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CF", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20405,85 +27899,29 @@ func (m *Merge) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Target = append(m.Target[:0], dAtA[iNdEx:postIndex]...)
-			if m.Target == nil {
-				m.Target = []byte{}
-			}
+			m.CF = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SplitShard) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SplitShard: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SplitShard: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 24:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
 			}
-			m.Policy = 0
+			m.Priority = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20493,16 +27931,16 @@ func (m *SplitShard) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Policy |= metapb.CheckPolicy(b&0x7F) << shift
+				m.Priority |= RequestPriority(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
-			var byteLen int
+			m.Timeout = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20512,24 +27950,11 @@ func (m *SplitShard) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Timeout |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
-			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -20555,7 +27980,7 @@ func (m *SplitShard) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
+func (m *Range) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -20578,17 +28003,17 @@ func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelConstraint: wiretype end group for non-group")
+			return fmt.Errorf("proto: Range: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelConstraint: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Range: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20598,48 +28023,31 @@ func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = string(dAtA[iNdEx:postIndex])
+			m.From = append(m.From[:0], dAtA[iNdEx:postIndex]...)
+			if m.From == nil {
+				m.From = []byte{}
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
-			}
-			m.Op = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Op |= LabelConstraintOp(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20649,23 +28057,25 @@ func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
+			m.To = append(m.To[:0], dAtA[iNdEx:postIndex]...)
+			if m.To == nil {
+				m.To = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -20692,7 +28102,7 @@ func (m *LabelConstraint) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PlacementRule) Unmarshal(dAtA []byte) error {
+func (m *Response) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -20715,17 +28125,17 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PlacementRule: wiretype end group for non-group")
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PlacementRule: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20735,29 +28145,69 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
+			if m.ID == nil {
+				m.ID = []byte{}
+			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= CmdType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CustomType", wireType)
+			}
+			m.CustomType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CustomType |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20767,29 +28217,31 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.GroupID = string(dAtA[iNdEx:postIndex])
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PID", wireType)
 			}
-			m.Index = 0
+			m.PID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20799,16 +28251,16 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Index |= uint32(b&0x7F) << shift
+				m.PID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Override", wireType)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20818,17 +28270,30 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Override = bool(v != 0)
-		case 5:
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TxnBatchResponse", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20838,31 +28303,33 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.StartKey = append(m.StartKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.StartKey == nil {
-				m.StartKey = []byte{}
+			if m.TxnBatchResponse == nil {
+				m.TxnBatchResponse = &txnpb.TxnBatchResponse{}
+			}
+			if err := m.TxnBatchResponse.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateTxnRecord", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20872,31 +28339,33 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EndKey = append(m.EndKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.EndKey == nil {
-				m.EndKey = []byte{}
+			if m.UpdateTxnRecord == nil {
+				m.UpdateTxnRecord = &UpdateTxnRecordRequest{}
+			}
+			if err := m.UpdateTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteTxnRecord", wireType)
 			}
-			m.Role = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20906,16 +28375,33 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Role |= ReplicaRoleType(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			m.Count = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DeleteTxnRecord == nil {
+				m.DeleteTxnRecord = &DeleteTxnRecordRequest{}
+			}
+			if err := m.DeleteTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitTxnWriteData", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20925,14 +28411,31 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Count |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 9:
+			if msglen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CommitTxnWriteData == nil {
+				m.CommitTxnWriteData = &CommitTxnWriteDataRequest{}
+			}
+			if err := m.CommitTxnWriteData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelConstraints", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RollbackTxnRecord", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -20959,16 +28462,18 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelConstraints = append(m.LabelConstraints, LabelConstraint{})
-			if err := m.LabelConstraints[len(m.LabelConstraints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.RollbackTxnRecord == nil {
+				m.RollbackTxnRecord = &RollbackTxnWriteDataRequest{}
+			}
+			if err := m.RollbackTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 10:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LocationLabels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CleanTxnMVCCData", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -20978,29 +28483,52 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LocationLabels = append(m.LocationLabels, string(dAtA[iNdEx:postIndex]))
+			if m.CleanTxnMVCCData == nil {
+				m.CleanTxnMVCCData = &CleanTxnMVCCDataRequest{}
+			}
+			if err := m.CleanTxnMVCCData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsolationLevel", wireType)
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ApplyLag", wireType)
 			}
-			var stringLen uint64
+			m.ApplyLag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ApplyLag |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedIndex", wireType)
+			}
+			m.AppliedIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -21010,24 +28538,11 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.AppliedIndex |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.IsolationLevel = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -21053,7 +28568,7 @@ func (m *PlacementRule) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RequestBatchHeader) Unmarshal(dAtA []byte) error {
+func (m *ConfigChangeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -21076,51 +28591,17 @@ func (m *RequestBatchHeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RequestBatchHeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConfigChangeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestBatchHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConfigChangeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ShardID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
 			}
-			m.ShardID = 0
+			m.ChangeType = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -21130,12 +28611,12 @@ func (m *RequestBatchHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ShardID |= uint64(b&0x7F) << shift
+				m.ChangeType |= metapb.ConfigChangeType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
 			}
@@ -21168,11 +28649,11 @@ func (m *RequestBatchHeader) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Initiator", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -21182,27 +28663,23 @@ func (m *RequestBatchHeader) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Lease == nil {
-				m.Lease = &metapb.EpochLease{}
-			}
-			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Initiator = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -21229,7 +28706,7 @@ func (m *RequestBatchHeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseBatchHeader) Unmarshal(dAtA []byte) error {
+func (m *ConfigChangeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -21252,49 +28729,15 @@ func (m *ResponseBatchHeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseBatchHeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: ConfigChangeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseBatchHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ConfigChangeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -21321,7 +28764,7 @@ func (m *ResponseBatchHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -21350,7 +28793,7 @@ func (m *ResponseBatchHeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RequestBatch) Unmarshal(dAtA []byte) error {
+func (m *CompactLogRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -21373,50 +28816,17 @@ func (m *RequestBatch) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RequestBatch: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompactLogRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RequestBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompactLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactIndex", wireType)
 			}
-			var msglen int
+			m.CompactIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -21426,26 +28836,11 @@ func (m *RequestBatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.CompactIndex |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Requests = append(m.Requests, Request{})
-			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -21471,7 +28866,7 @@ func (m *RequestBatch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponseBatch) Unmarshal(dAtA []byte) error {
+func (m *CompactLogResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -21494,48 +28889,69 @@ func (m *ResponseBatch) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponseBatch: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompactLogResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponseBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompactLogResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferLeaderRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 2:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferLeaderRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferLeaderRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -21562,8 +28978,7 @@ func (m *ResponseBatch) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Responses = append(m.Responses, Response{})
-			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Replica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -21592,7 +29007,7 @@ func (m *ResponseBatch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Request) Unmarshal(dAtA []byte) error {
+func (m *TransferLeaderResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -21615,142 +29030,71 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferLeaderResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferLeaderResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
-			}
-			m.Group = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Group |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= CmdType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CustomType", wireType)
-			}
-			m.CustomType = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CustomType |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchSplitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 6:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchSplitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cmd", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -21760,89 +29104,31 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Cmd = append(m.Cmd[:0], dAtA[iNdEx:postIndex]...)
-			if m.Cmd == nil {
-				m.Cmd = []byte{}
-			}
-			iNdEx = postIndex
-		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PID", wireType)
-			}
-			m.PID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.PID |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ToShard", wireType)
-			}
-			m.ToShard = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ToShard |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IgnoreEpochCheck", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			m.IgnoreEpochCheck = bool(v != 0)
-		case 10:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requests = append(m.Requests, SplitRequest{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -21852,30 +29138,85 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Epoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Context = append(m.Context[:0], dAtA[iNdEx:postIndex]...)
+			if m.Context == nil {
+				m.Context = []byte{}
 			}
 			iNdEx = postIndex
-		case 11:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SplitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -21885,33 +29226,31 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Lease == nil {
-				m.Lease = &metapb.EpochLease{}
-			}
-			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
+			if m.Start == nil {
+				m.Start = []byte{}
 			}
 			iNdEx = postIndex
-		case 12:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KeysRange", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -21921,33 +29260,31 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.KeysRange == nil {
-				m.KeysRange = &Range{}
-			}
-			if err := m.KeysRange.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
+			if m.End == nil {
+				m.End = []byte{}
 			}
 			iNdEx = postIndex
-		case 13:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReplicaSelectPolicy", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewShardID", wireType)
 			}
-			m.ReplicaSelectPolicy = 0
+			m.NewShardID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -21957,14 +29294,14 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ReplicaSelectPolicy |= ReplicaSelectPolicy(b&0x7F) << shift
+				m.NewShardID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 14:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TxnBatchRequest", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewReplicas", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -21991,16 +29328,68 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TxnBatchRequest == nil {
-				m.TxnBatchRequest = &txnpb.TxnBatchRequest{}
-			}
-			if err := m.TxnBatchRequest.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.NewReplicas = append(m.NewReplicas, metapb.Replica{})
+			if err := m.NewReplicas[len(m.NewReplicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 15:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchSplitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchSplitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdateTxnRecord", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -22027,13 +29416,68 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.UpdateTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Shards = append(m.Shards, metapb.Shard{})
+			if err := m.Shards[len(m.Shards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 16:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateMetadataRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateMetadataRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateMetadataRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeleteTxnRecord", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -22060,46 +29504,121 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.DeleteTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 17:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CommitTxnWriteData", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CommitTxnWriteData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateMetadataResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateMetadataResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateMetadataResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 18:
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateLabelsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateLabelsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateLabelsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RollbackTxnRecord", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -22126,15 +29645,16 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RollbackTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Labels = append(m.Labels, metapb.Label{})
+			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 19:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CleanTxnMVCCData", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
 			}
-			var msglen int
+			m.Policy = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22144,25 +29664,65 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Policy |= UpdatePolicy(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.CleanTxnMVCCData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateLabelsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateLabelsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateLabelsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -22188,7 +29748,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Range) Unmarshal(dAtA []byte) error {
+func (m *UpdateGateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22211,17 +29771,17 @@ func (m *Range) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Range: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateGateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Range: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateGateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Gate", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22231,60 +29791,79 @@ func (m *Range) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.From = append(m.From[:0], dAtA[iNdEx:postIndex]...)
-			if m.From == nil {
-				m.From = []byte{}
+			if err := m.Gate.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateGateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.To = append(m.To[:0], dAtA[iNdEx:postIndex]...)
-			if m.To == nil {
-				m.To = []byte{}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateGateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateGateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -22310,7 +29889,7 @@ func (m *Range) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *AcquireAppLeaseRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22333,17 +29912,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: AcquireAppLeaseRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AcquireAppLeaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Holder", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22353,31 +29932,29 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = append(m.ID[:0], dAtA[iNdEx:postIndex]...)
-			if m.ID == nil {
-				m.ID = []byte{}
-			}
+			m.Holder = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
 			}
-			m.Type = 0
+			m.TTL = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22387,16 +29964,16 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= CmdType(b&0x7F) << shift
+				m.TTL |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CustomType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Now", wireType)
 			}
-			m.CustomType = 0
+			m.Now = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22406,69 +29983,70 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CustomType |= uint64(b&0x7F) << shift
+				m.Now |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if byteLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AcquireAppLeaseResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PID", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.PID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.PID |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AcquireAppLeaseResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AcquireAppLeaseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Acquired", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22478,28 +30056,15 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 7:
+			m.Acquired = bool(v != 0)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TxnBatchResponse", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -22526,90 +30091,69 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TxnBatchResponse == nil {
-				m.TxnBatchResponse = &txnpb.TxnBatchResponse{}
-			}
-			if err := m.TxnBatchResponse.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Lease.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdateTxnRecord", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.UpdateTxnRecord == nil {
-				m.UpdateTxnRecord = &UpdateTxnRecordRequest{}
-			}
-			if err := m.UpdateTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeleteTxnRecord", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseAppLeaseRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DeleteTxnRecord == nil {
-				m.DeleteTxnRecord = &DeleteTxnRecordRequest{}
-			}
-			if err := m.DeleteTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 10:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CommitTxnWriteData", wireType)
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseAppLeaseRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseAppLeaseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Token", wireType)
 			}
-			var msglen int
+			m.Token = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22619,33 +30163,70 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Token |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.CommitTxnWriteData == nil {
-				m.CommitTxnWriteData = &CommitTxnWriteDataRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseAppLeaseResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if err := m.CommitTxnWriteData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 11:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RollbackTxnRecord", wireType)
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var msglen int
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseAppLeaseResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseAppLeaseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Released", wireType)
+			}
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22655,33 +30236,71 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			m.Released = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.RollbackTxnRecord == nil {
-				m.RollbackTxnRecord = &RollbackTxnWriteDataRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SplitShardRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			if err := m.RollbackTxnRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 12:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplitShardRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplitShardRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CleanTxnMVCCData", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitKeys", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22691,28 +30310,78 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.CleanTxnMVCCData == nil {
-				m.CleanTxnMVCCData = &CleanTxnMVCCDataRequest{}
-			}
-			if err := m.CleanTxnMVCCData.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.SplitKeys = append(m.SplitKeys, make([]byte, postIndex-iNdEx))
+			copy(m.SplitKeys[len(m.SplitKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SplitShardResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplitShardResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplitShardResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -22738,7 +30407,7 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigChangeRequest) Unmarshal(dAtA []byte) error {
+func (m *DeleteRangeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22761,17 +30430,17 @@ func (m *ConfigChangeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigChangeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteRangeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigChangeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteRangeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
 			}
-			m.ChangeType = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22781,16 +30450,31 @@ func (m *ConfigChangeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ChangeType |= metapb.ConfigChangeType(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
+			if m.Start == nil {
+				m.Start = []byte{}
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22800,25 +30484,45 @@ func (m *ConfigChangeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Replica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
+			if m.End == nil {
+				m.End = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckIndex", wireType)
+			}
+			m.CheckIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CheckIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -22844,7 +30548,7 @@ func (m *ConfigChangeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigChangeResponse) Unmarshal(dAtA []byte) error {
+func (m *DeleteRangeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22867,17 +30571,17 @@ func (m *ConfigChangeResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigChangeResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteRangeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigChangeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteRangeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22887,25 +30591,66 @@ func (m *ConfigChangeResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			m.Deleted = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Shard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BarrierRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
 			}
-			iNdEx = postIndex
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BarrierRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BarrierRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -22931,7 +30676,7 @@ func (m *ConfigChangeResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CompactLogRequest) Unmarshal(dAtA []byte) error {
+func (m *BarrierResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22954,17 +30699,17 @@ func (m *CompactLogRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CompactLogRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: BarrierResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CompactLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BarrierResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CompactIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			m.CompactIndex = 0
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -22974,7 +30719,7 @@ func (m *CompactLogRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CompactIndex |= uint64(b&0x7F) << shift
+				m.Index |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -23004,7 +30749,7 @@ func (m *CompactLogRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CompactLogResponse) Unmarshal(dAtA []byte) error {
+func (m *ComputeHashRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23027,10 +30772,10 @@ func (m *CompactLogResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CompactLogResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ComputeHashRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CompactLogResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ComputeHashRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -23058,7 +30803,7 @@ func (m *CompactLogResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TransferLeaderRequest) Unmarshal(dAtA []byte) error {
+func (m *ComputeHashResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23081,17 +30826,17 @@ func (m *TransferLeaderRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TransferLeaderRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ComputeHashResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferLeaderRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ComputeHashResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			var msglen int
+			m.Index = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -23101,25 +30846,50 @@ func (m *TransferLeaderRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Index |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
+			m.Hash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hash |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Computed", wireType)
 			}
-			if err := m.Replica.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
+			m.Computed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -23145,7 +30915,7 @@ func (m *TransferLeaderRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TransferLeaderResponse) Unmarshal(dAtA []byte) error {
+func (m *VerifyHashRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23168,12 +30938,50 @@ func (m *TransferLeaderResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TransferLeaderResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: VerifyHashRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferLeaderResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VerifyHashRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			m.Hash = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hash |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -23199,7 +31007,7 @@ func (m *TransferLeaderResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BatchSplitRequest) Unmarshal(dAtA []byte) error {
+func (m *VerifyHashResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23222,80 +31030,12 @@ func (m *BatchSplitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BatchSplitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: VerifyHashResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BatchSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: VerifyHashResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Requests = append(m.Requests, SplitRequest{})
-			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Context = append(m.Context[:0], dAtA[iNdEx:postIndex]...)
-			if m.Context == nil {
-				m.Context = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -23321,7 +31061,7 @@ func (m *BatchSplitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SplitRequest) Unmarshal(dAtA []byte) error {
+func (m *PurgeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23344,10 +31084,10 @@ func (m *SplitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SplitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PurgeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PurgeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -23419,29 +31159,10 @@ func (m *SplitRequest) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewShardID", wireType)
-			}
-			m.NewShardID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpcpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.NewShardID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewReplicas", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -23451,25 +31172,23 @@ func (m *SplitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NewReplicas = append(m.NewReplicas, metapb.Replica{})
-			if err := m.NewReplicas[len(m.NewReplicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
+			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -23496,7 +31215,7 @@ func (m *SplitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BatchSplitResponse) Unmarshal(dAtA []byte) error {
+func (m *PurgeResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23519,17 +31238,17 @@ func (m *BatchSplitResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BatchSplitResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PurgeResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BatchSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PurgeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shards", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Purged", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -23539,85 +31258,70 @@ func (m *BatchSplitResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
+			m.Purged = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Shards = append(m.Shards, metapb.Shard{})
-			if err := m.Shards[len(m.Shards)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
 			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if skippy < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *UpdateMetadataRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
-			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Start = append(m.Start[:0], dAtA[iNdEx:postIndex]...)
+			if m.Start == nil {
+				m.Start = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateMetadataRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateMetadataRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -23627,79 +31331,58 @@ func (m *UpdateMetadataRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.End = append(m.End[:0], dAtA[iNdEx:postIndex]...)
+			if m.End == nil {
+				m.End = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpcpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthRpcpb
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *UpdateMetadataResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpcpb
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateMetadataResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateMetadataResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
+			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -23725,7 +31408,7 @@ func (m *UpdateMetadataResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateLabelsRequest) Unmarshal(dAtA []byte) error {
+func (m *UpdateDictionaryRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23748,17 +31431,17 @@ func (m *UpdateLabelsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateLabelsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateDictionaryRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateLabelsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateDictionaryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var msglen int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -23768,31 +31451,16 @@ func (m *UpdateLabelsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Version |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpcpb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Labels = append(m.Labels, metapb.Label{})
-			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			m.Policy = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpcpb
@@ -23802,11 +31470,26 @@ func (m *UpdateLabelsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Policy |= UpdatePolicy(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -23832,7 +31515,7 @@ func (m *UpdateLabelsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateLabelsResponse) Unmarshal(dAtA []byte) error {
+func (m *UpdateDictionaryResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -23855,12 +31538,32 @@ func (m *UpdateLabelsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateLabelsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateDictionaryResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateLabelsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateDictionaryResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Updated = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -25153,8 +32856,238 @@ func (m *KVBatchSetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Values = append(m.Values, make([]byte, postIndex-iNdEx))
-			copy(m.Values[len(m.Values)-1], dAtA[iNdEx:postIndex])
+			m.Values = append(m.Values, make([]byte, postIndex-iNdEx))
+			copy(m.Values[len(m.Values)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KVBatchSetResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KVBatchSetResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KVBatchSetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KVGetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KVGetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KVGetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KVGetResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KVGetResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KVGetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -25181,7 +33114,7 @@ func (m *KVBatchSetRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KVBatchSetResponse) Unmarshal(dAtA []byte) error {
+func (m *KVBatchGetRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25204,12 +33137,120 @@ func (m *KVBatchSetResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KVBatchSetResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: KVBatchGetRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KVBatchSetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KVBatchGetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
+			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Indexes = append(m.Indexes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Indexes) == 0 {
+					m.Indexes = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Indexes = append(m.Indexes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -25235,7 +33276,7 @@ func (m *KVBatchSetResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KVGetRequest) Unmarshal(dAtA []byte) error {
+func (m *KVBatchGetResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25258,15 +33299,15 @@ func (m *KVGetRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KVGetRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: KVBatchGetResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KVGetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KVBatchGetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -25293,11 +33334,85 @@ func (m *KVGetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
+			m.Values = append(m.Values, make([]byte, postIndex-iNdEx))
+			copy(m.Values[len(m.Values)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Indexes = append(m.Indexes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowRpcpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthRpcpb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Indexes) == 0 {
+					m.Indexes = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowRpcpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Indexes = append(m.Indexes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -25323,7 +33438,7 @@ func (m *KVGetRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KVGetResponse) Unmarshal(dAtA []byte) error {
+func (m *KVDeleteRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25346,15 +33461,15 @@ func (m *KVGetResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KVGetResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: KVDeleteRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KVGetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KVDeleteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -25378,14 +33493,68 @@ func (m *KVGetResponse) Unmarshal(dAtA []byte) error {
 			if postIndex < 0 {
 				return ErrInvalidLengthRpcpb
 			}
-			if postIndex > l {
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpcpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KVDeleteResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpcpb
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KVDeleteResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KVDeleteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -25411,7 +33580,7 @@ func (m *KVGetResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KVBatchGetRequest) Unmarshal(dAtA []byte) error {
+func (m *KVGetDelRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25434,15 +33603,15 @@ func (m *KVBatchGetRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KVBatchGetRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: KVGetDelRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KVBatchGetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KVGetDelRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -25469,85 +33638,11 @@ func (m *KVBatchGetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
-			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Indexes = append(m.Indexes, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Indexes) == 0 {
-					m.Indexes = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Indexes = append(m.Indexes, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -25573,7 +33668,7 @@ func (m *KVBatchGetRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KVBatchGetResponse) Unmarshal(dAtA []byte) error {
+func (m *KVGetDelResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25596,15 +33691,15 @@ func (m *KVBatchGetResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KVBatchGetResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: KVGetDelResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KVBatchGetResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KVGetDelResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -25631,85 +33726,11 @@ func (m *KVBatchGetResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Values = append(m.Values, make([]byte, postIndex-iNdEx))
-			copy(m.Values[len(m.Values)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Indexes = append(m.Indexes, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowRpcpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthRpcpb
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Indexes) == 0 {
-					m.Indexes = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowRpcpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Indexes = append(m.Indexes, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -25735,7 +33756,7 @@ func (m *KVBatchGetResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KVDeleteRequest) Unmarshal(dAtA []byte) error {
+func (m *KVDeleteIfRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25758,10 +33779,10 @@ func (m *KVDeleteRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KVDeleteRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: KVDeleteIfRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KVDeleteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KVDeleteIfRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -25798,6 +33819,40 @@ func (m *KVDeleteRequest) Unmarshal(dAtA []byte) error {
 				m.Key = []byte{}
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpcpb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
@@ -25823,7 +33878,7 @@ func (m *KVDeleteRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *KVDeleteResponse) Unmarshal(dAtA []byte) error {
+func (m *KVDeleteIfResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -25846,12 +33901,32 @@ func (m *KVDeleteResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: KVDeleteResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: KVDeleteIfResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: KVDeleteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: KVDeleteIfResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpcpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deleted = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpcpb(dAtA[iNdEx:])
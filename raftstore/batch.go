@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/pb/errorpb"
@@ -34,6 +35,9 @@ type batch struct {
 	cb           func(rpcpb.ResponseBatch)
 	tp           int // request type of this batch
 	byteSize     int // bytes of this batch
+	// deadline the latest deadline of the requests of the batch, zero means
+	// one of the requests has no deadline
+	deadline time.Time
 }
 
 func newBatch(logger *zap.Logger, requestBatch rpcpb.RequestBatch, cb func(rpcpb.ResponseBatch), tp int, byteSize int) batch {
@@ -54,6 +58,23 @@ func newSingleBatch(logger *zap.Logger, req rpcpb.Request, cb func(rpcpb.Respons
 	}
 }
 
+// updateDeadline updates the deadline of the batch with the deadline of the
+// request added to the batch
+func (c *batch) updateDeadline(deadline time.Time) {
+	if c.deadline.IsZero() {
+		return
+	}
+	if deadline.IsZero() || deadline.After(c.deadline) {
+		c.deadline = deadline
+	}
+}
+
+// deadlineExceeded returns true if the deadlines of all the requests of the
+// batch are exceeded at the time
+func (c *batch) deadlineExceeded(now time.Time) bool {
+	return !c.deadline.IsZero() && !now.Before(c.deadline)
+}
+
 func (c *batch) notifyStaleCmd() {
 	c.resp(errorStaleCMDResp(c.getRequestID()))
 }
@@ -71,7 +92,27 @@ func (c *batch) isFull(n, max int) bool {
 
 func (c *batch) canBatches(req rpcpb.Request) bool {
 	return c.canBatchesWithEpoch(req) &&
-		c.canBatchesWithLease(req)
+		c.canBatchesWithLease(req) &&
+		c.canBatchesWithFollowerRead(req)
+}
+
+// canBatchesWithFollowerRead the follower reads are not batched with the reads
+// that must be served by the leader, so a follower can handle the whole batch.
+func (c *batch) canBatchesWithFollowerRead(req rpcpb.Request) bool {
+	return c.requestBatch.Requests[0].FollowerRead == req.FollowerRead
+}
+
+// isFollowerRead returns true if the batch can be served by a follower.
+func (c *batch) isFollowerRead() bool {
+	return c.tp == read && isFollowerReadBatch(c.requestBatch)
+}
+
+// isFollowerReadBatch returns true if the requests of the batch are follower
+// reads, the follower reads are never batched with other requests.
+func isFollowerReadBatch(req rpcpb.RequestBatch) bool {
+	return len(req.Requests) > 0 &&
+		req.Requests[0].Type == rpcpb.Read &&
+		req.Requests[0].FollowerRead
 }
 
 func (c *batch) canBatchesWithLease(req rpcpb.Request) bool {
@@ -170,6 +211,63 @@ func (c *batch) respNotLeader(shardID uint64, leader Replica) {
 	c.resp(rsp)
 }
 
+func (c *batch) respApplyLagTooLarge(shardID uint64, applyLag uint64) {
+	rsp := errorPbResp(c.getRequestID(), errorpb.Error{
+		Message: errApplyLagTooLarge.Error(),
+		ApplyLagTooLarge: &errorpb.ApplyLagTooLarge{
+			ShardID:  shardID,
+			ApplyLag: applyLag,
+		},
+	})
+	c.resp(rsp)
+}
+
+func (c *batch) respQuorumLost(shardID uint64) {
+	rsp := errorPbResp(c.getRequestID(), errorpb.Error{
+		Message:    errQuorumLost.Error(),
+		QuorumLost: &errorpb.QuorumLost{ShardID: shardID},
+	})
+	c.resp(rsp)
+}
+
+func (c *batch) respStorageIOError(shardID uint64) {
+	rsp := errorPbResp(c.getRequestID(), errorpb.Error{
+		Message:        errStorageIOError.Error(),
+		StorageIOError: &errorpb.StorageIOError{ShardID: shardID},
+	})
+	c.resp(rsp)
+}
+
+func (c *batch) respServerIsBusy(shardID uint64, reason string) {
+	rsp := errorPbResp(c.getRequestID(), errorpb.Error{
+		Message: errServerIsBusy.Error(),
+		ServerIsBusy: &errorpb.ServerIsBusy{
+			ShardID: shardID,
+			Reason:  reason,
+		},
+	})
+	c.resp(rsp)
+}
+
+func (c *batch) respDeadlineExceeded(shardID uint64) {
+	rsp := errorPbResp(c.getRequestID(), errorpb.Error{
+		Message:          errDeadlineExceeded.Error(),
+		DeadlineExceeded: &errorpb.DeadlineExceeded{ShardID: shardID},
+	})
+	c.resp(rsp)
+}
+
+func (c *batch) respInvalidSplitKeys(shardID uint64, reason string) {
+	rsp := errorPbResp(c.getRequestID(), errorpb.Error{
+		Message: errInvalidSplitKeys.Error(),
+		InvalidSplitKeys: &errorpb.InvalidSplitKeys{
+			ShardID: shardID,
+			Reason:  reason,
+		},
+	})
+	c.resp(rsp)
+}
+
 func (c *batch) getRequestID() []byte {
 	return c.requestBatch.Header.ID
 }
@@ -238,6 +336,16 @@ func respLeaseReadNotReady(req rpcpb.Request, cb func(rpcpb.ResponseBatch)) {
 	cb(rsp)
 }
 
+func respError(err errorpb.Error, req rpcpb.Request, cb func(rpcpb.ResponseBatch)) {
+	rsp := errorPbResp(uuid.NewV4().Bytes(), err)
+	resp := rpcpb.Response{
+		ID:  req.ID,
+		PID: req.PID,
+	}
+	rsp.Responses = append(rsp.Responses, resp)
+	cb(rsp)
+}
+
 func respShardUnavailable(id uint64, req rpcpb.Request, cb func(responseBatch rpcpb.ResponseBatch)) {
 	rsp := errorPbResp(uuid.NewV4().Bytes(), errorpb.Error{
 		Message:          fmt.Sprintf("shard %d is unavailable", id),
@@ -251,6 +359,19 @@ func respShardUnavailable(id uint64, req rpcpb.Request, cb func(responseBatch rp
 	cb(rsp)
 }
 
+func respDeadlineExceeded(id uint64, req rpcpb.Request, cb func(responseBatch rpcpb.ResponseBatch)) {
+	rsp := errorPbResp(uuid.NewV4().Bytes(), errorpb.Error{
+		Message:          errDeadlineExceeded.Error(),
+		DeadlineExceeded: &errorpb.DeadlineExceeded{ShardID: id},
+	})
+	resp := rpcpb.Response{
+		ID:  req.ID,
+		PID: req.PID,
+	}
+	rsp.Responses = append(rsp.Responses, resp)
+	cb(rsp)
+}
+
 func epochMatch(e1, e2 metapb.ShardEpoch) bool {
 	return e1.ConfigVer == e2.ConfigVer && e1.Generation == e2.Generation
 }
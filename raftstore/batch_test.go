@@ -100,6 +100,23 @@ func TestCanAppendCmd(t *testing.T) {
 	}
 }
 
+func TestCanBatchesWithFollowerRead(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := newBatch(nil, rpcpb.RequestBatch{
+		Requests: []rpcpb.Request{{Type: rpcpb.Read, FollowerRead: true}},
+	}, nil, read, 0)
+	assert.True(t, c.isFollowerRead())
+	assert.True(t, c.canBatches(rpcpb.Request{Type: rpcpb.Read, FollowerRead: true}))
+	assert.False(t, c.canBatches(rpcpb.Request{Type: rpcpb.Read}))
+
+	c = newBatch(nil, rpcpb.RequestBatch{
+		Requests: []rpcpb.Request{{Type: rpcpb.Read}},
+	}, nil, read, 0)
+	assert.False(t, c.isFollowerRead())
+	assert.False(t, c.canBatches(rpcpb.Request{Type: rpcpb.Read, FollowerRead: true}))
+}
+
 func TestBatchResp(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -0,0 +1,281 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/metric"
+	"go.uber.org/zap"
+)
+
+// throughputDropRatio the last increase is considered harmful if the throughput
+// drops below throughputDropRatio * the throughput before the increase
+const throughputDropRatio = 0.9
+
+// batchTuning the batch sizes and the commit delay chosen for a shard group
+type batchTuning struct {
+	writeBatchSize uint64
+	applyBatchSize uint64
+	commitDelay    time.Duration
+}
+
+// groupBatchTuner observes the applies of a shard group and adjusts its tuning
+type groupBatchTuner struct {
+	tuning batchTuning
+	// the applies observed in the current interval, updated by the replicas
+	applyBatches uint64
+	applyBytes   uint64
+	applyNanos   uint64
+
+	lastThroughput float64
+	increased      bool
+}
+
+func (g *groupBatchTuner) observe(bytes uint64, latency time.Duration) {
+	atomic.AddUint64(&g.applyBatches, 1)
+	atomic.AddUint64(&g.applyBytes, bytes)
+	atomic.AddUint64(&g.applyNanos, uint64(latency))
+}
+
+// adjust raises the tuning additively if the average apply latency is below
+// the target and the throughput keeps up with the last increase, otherwise
+// cuts it multiplicatively. The tuning is kept if the group is idle.
+func (g *groupBatchTuner) adjust(cfg config.AutoTuneConfig, elapsed time.Duration) {
+	batches := atomic.SwapUint64(&g.applyBatches, 0)
+	bytes := atomic.SwapUint64(&g.applyBytes, 0)
+	nanos := atomic.SwapUint64(&g.applyNanos, 0)
+	if batches == 0 || elapsed <= 0 {
+		return
+	}
+
+	latency := time.Duration(nanos / batches)
+	throughput := float64(bytes) / elapsed.Seconds()
+	if latency > cfg.TargetApplyLatency.Duration ||
+		(g.increased && throughput < g.lastThroughput*throughputDropRatio) {
+		g.decrease(cfg)
+		g.increased = false
+	} else {
+		g.increase(cfg)
+		g.increased = true
+	}
+	g.lastThroughput = throughput
+}
+
+func (g *groupBatchTuner) increase(cfg config.AutoTuneConfig) {
+	g.tuning.writeBatchSize = increaseSize(g.tuning.writeBatchSize,
+		uint64(cfg.MinWriteBatchSize), uint64(cfg.MaxWriteBatchSize), cfg.IncreaseSteps)
+	g.tuning.applyBatchSize = increaseSize(g.tuning.applyBatchSize,
+		uint64(cfg.MinApplyBatchSize), uint64(cfg.MaxApplyBatchSize), cfg.IncreaseSteps)
+	if !cfg.DisableCommitDelay {
+		g.tuning.commitDelay += cfg.MaxCommitDelay.Duration / time.Duration(cfg.IncreaseSteps)
+		if g.tuning.commitDelay > cfg.MaxCommitDelay.Duration {
+			g.tuning.commitDelay = cfg.MaxCommitDelay.Duration
+		}
+	}
+}
+
+func (g *groupBatchTuner) decrease(cfg config.AutoTuneConfig) {
+	g.tuning.writeBatchSize = decreaseSize(g.tuning.writeBatchSize,
+		uint64(cfg.MinWriteBatchSize), cfg.DecreaseRatio)
+	g.tuning.applyBatchSize = decreaseSize(g.tuning.applyBatchSize,
+		uint64(cfg.MinApplyBatchSize), cfg.DecreaseRatio)
+	g.tuning.commitDelay = time.Duration(float64(g.tuning.commitDelay) * cfg.DecreaseRatio)
+}
+
+func increaseSize(size, min, max, steps uint64) uint64 {
+	step := (max - min) / steps
+	if step == 0 {
+		step = 1
+	}
+	size += step
+	if size > max {
+		return max
+	}
+	return size
+}
+
+func decreaseSize(size, min uint64, ratio float64) uint64 {
+	size = uint64(float64(size) * ratio)
+	if size < min {
+		return min
+	}
+	return size
+}
+
+// batchTuner adjusts the write batch size, the apply batch size and the group
+// commit delay of each shard group in an AIMD way, so the batches grow until
+// the apply latency exceeds the target or the throughput stops improving, the
+// static config is always wrong for someone's hardware. The write batch size
+// bounds the bytes of the requests proposed in a raft entry, the commit delay
+// is the time a write batch not filled up waits for the following requests,
+// and the apply batch size bounds the committed entries applied in a raft
+// ready of the replicas created after it's chosen.
+type batchTuner struct {
+	logger *zap.Logger
+	cfg    config.AutoTuneConfig
+
+	mu struct {
+		sync.RWMutex
+		lastTime time.Time
+		groups   map[uint64]*groupBatchTuner
+	}
+}
+
+func newBatchTuner(logger *zap.Logger, cfg config.AutoTuneConfig) *batchTuner {
+	t := &batchTuner{
+		logger: logger,
+		cfg:    cfg,
+	}
+	t.mu.groups = make(map[uint64]*groupBatchTuner)
+	return t
+}
+
+func (t *batchTuner) enabled() bool {
+	return t != nil && t.cfg.Enable
+}
+
+// addGroup registers a shard group to the tuner, the tuning starts from the
+// given static values clamped to the configured bounds.
+func (t *batchTuner) addGroup(group uint64, initial batchTuning) {
+	if !t.enabled() {
+		return
+	}
+
+	initial.writeBatchSize = clampSize(initial.writeBatchSize,
+		uint64(t.cfg.MinWriteBatchSize), uint64(t.cfg.MaxWriteBatchSize))
+	initial.applyBatchSize = clampSize(initial.applyBatchSize,
+		uint64(t.cfg.MinApplyBatchSize), uint64(t.cfg.MaxApplyBatchSize))
+	initial.commitDelay = 0
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.mu.groups[group]; !ok {
+		t.mu.groups[group] = &groupBatchTuner{tuning: initial}
+		metric.SetAutoTuneMetric(group, initial.writeBatchSize,
+			initial.applyBatchSize, initial.commitDelay)
+	}
+}
+
+func clampSize(size, min, max uint64) uint64 {
+	if size < min {
+		return min
+	}
+	if size > max {
+		return max
+	}
+	return size
+}
+
+// get returns the tuning of the group, returns false if the group isn't tuned
+func (t *batchTuner) get(group uint64) (batchTuning, bool) {
+	if !t.enabled() {
+		return batchTuning{}, false
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if g, ok := t.mu.groups[group]; ok {
+		return g.tuning, true
+	}
+	return batchTuning{}, false
+}
+
+// observeApply records the bytes and the latency of applying the committed
+// entries of a raft ready
+func (t *batchTuner) observeApply(group uint64, bytes uint64, latency time.Duration) {
+	if !t.enabled() {
+		return
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if g, ok := t.mu.groups[group]; ok {
+		g.observe(bytes, latency)
+	}
+}
+
+// refresh adjusts the tuning of all groups from the applies observed since the
+// last refresh
+func (t *batchTuner) refresh(now time.Time) {
+	if !t.enabled() {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	elapsed := now.Sub(t.mu.lastTime)
+	first := t.mu.lastTime.IsZero()
+	t.mu.lastTime = now
+	for group, g := range t.mu.groups {
+		if first {
+			// drop the applies observed before the first interval
+			g.adjust(t.cfg, 0)
+			continue
+		}
+		before := g.tuning
+		g.adjust(t.cfg, elapsed)
+		if g.tuning != before {
+			t.logger.Debug("batch tuning changed",
+				zap.Uint64("group", group),
+				zap.Uint64("write-batch-size", g.tuning.writeBatchSize),
+				zap.Uint64("apply-batch-size", g.tuning.applyBatchSize),
+				zap.Duration("commit-delay", g.tuning.commitDelay))
+		}
+		metric.SetAutoTuneMetric(group, g.tuning.writeBatchSize,
+			g.tuning.applyBatchSize, g.tuning.commitDelay)
+	}
+}
+
+func (pr *replica) getBatchTuner() *batchTuner {
+	if pr.store == nil {
+		return nil
+	}
+	return pr.store.batchTuner
+}
+
+// tuneProposalBatch applies the write batch size and the commit delay chosen
+// by the batch tuner to the incoming proposals
+func (pr *replica) tuneProposalBatch() {
+	if tuning, ok := pr.getBatchTuner().get(pr.group); ok {
+		pr.incomingProposals.maxSize = tuning.writeBatchSize
+		pr.commitDelay = tuning.commitDelay
+	}
+}
+
+// delayProposals returns true if proposing the single write batch not filled up
+// is delayed by the commit delay, so the following requests are proposed in
+// the same raft entry. The replica is notified once the delay expires.
+func (pr *replica) delayProposals(now time.Time) bool {
+	if pr.commitDelay <= 0 ||
+		pr.incomingProposals.size() != 1 ||
+		pr.incomingProposals.batches[0].tp != write {
+		pr.proposalsDelayedAt = time.Time{}
+		return false
+	}
+
+	if pr.proposalsDelayedAt.IsZero() {
+		pr.proposalsDelayedAt = now
+		pr.store.afterFunc(pr.commitDelay, pr.notifyWorker)
+		return true
+	}
+	if now.Sub(pr.proposalsDelayedAt) < pr.commitDelay {
+		return true
+	}
+	pr.proposalsDelayedAt = time.Time{}
+	return false
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBatchTuner() *batchTuner {
+	cfg := config.AutoTuneConfig{
+		Enable:             true,
+		TargetApplyLatency: typeutil.NewDuration(10 * time.Millisecond),
+		MinWriteBatchSize:  100,
+		MaxWriteBatchSize:  1700,
+		MinApplyBatchSize:  100,
+		MaxApplyBatchSize:  1700,
+		MaxCommitDelay:     typeutil.NewDuration(16 * time.Millisecond),
+		DecreaseRatio:      0.5,
+		IncreaseSteps:      16,
+	}
+	t := newBatchTuner(log.GetDefaultZapLogger(), cfg)
+	t.addGroup(1, batchTuning{writeBatchSize: 10000, applyBatchSize: 1000})
+	return t
+}
+
+func TestBatchTunerDisabled(t *testing.T) {
+	var bt *batchTuner
+	assert.False(t, bt.enabled())
+	bt.observeApply(1, 100, time.Millisecond)
+	bt.refresh(time.Now())
+	_, ok := bt.get(1)
+	assert.False(t, ok)
+
+	bt = newBatchTuner(log.GetDefaultZapLogger(), config.AutoTuneConfig{})
+	bt.addGroup(1, batchTuning{writeBatchSize: 100})
+	_, ok = bt.get(1)
+	assert.False(t, ok)
+}
+
+func TestBatchTunerAIMD(t *testing.T) {
+	bt := newTestBatchTuner()
+	tuning, ok := bt.get(1)
+	require.True(t, ok)
+	assert.Equal(t, batchTuning{writeBatchSize: 1700, applyBatchSize: 1000}, tuning)
+	_, ok = bt.get(2)
+	assert.False(t, ok)
+
+	now := time.Now()
+	bt.observeApply(1, 1000, time.Millisecond)
+	bt.refresh(now)
+	tuning, _ = bt.get(1)
+	assert.Equal(t, uint64(1000), tuning.applyBatchSize, "the applies before the first interval are dropped")
+
+	// additive increase below the target latency
+	now = now.Add(time.Second)
+	bt.observeApply(1, 1000, time.Millisecond)
+	bt.refresh(now)
+	tuning, _ = bt.get(1)
+	assert.Equal(t, batchTuning{writeBatchSize: 1700, applyBatchSize: 1100, commitDelay: time.Millisecond}, tuning)
+
+	// idle group keeps the tuning
+	now = now.Add(time.Second)
+	bt.refresh(now)
+	tuning, _ = bt.get(1)
+	assert.Equal(t, batchTuning{writeBatchSize: 1700, applyBatchSize: 1100, commitDelay: time.Millisecond}, tuning)
+
+	// the throughput dropped after the increase
+	now = now.Add(time.Second)
+	bt.observeApply(1, 100, time.Millisecond)
+	bt.observeApply(1, 100, time.Millisecond)
+	bt.refresh(now)
+	tuning, _ = bt.get(1)
+	assert.Equal(t, batchTuning{writeBatchSize: 850, applyBatchSize: 550, commitDelay: time.Millisecond / 2}, tuning)
+
+	// multiplicative decrease above the target latency, bounded by the min
+	for i := 0; i < 5; i++ {
+		now = now.Add(time.Second)
+		bt.observeApply(1, 1000, 20*time.Millisecond)
+		bt.refresh(now)
+	}
+	tuning, _ = bt.get(1)
+	assert.Equal(t, uint64(100), tuning.writeBatchSize)
+	assert.Equal(t, uint64(100), tuning.applyBatchSize)
+}
+
+func TestDelayProposals(t *testing.T) {
+	pr := &replica{commitDelay: time.Hour}
+	pr.incomingProposals = newProposalBatch(nil, 1024, 1, Replica{ID: 1})
+	now := time.Now()
+	assert.False(t, pr.delayProposals(now), "no proposals")
+
+	pr.incomingProposals.push(0, newReqCtx(rpcpb.Request{ID: []byte("w1"), Type: rpcpb.Write}, time.Now(), nil))
+	pr.proposalsDelayedAt = now.Add(-time.Minute)
+	assert.True(t, pr.delayProposals(now))
+	assert.False(t, pr.delayProposals(now.Add(time.Hour)), "delay expired")
+	assert.True(t, pr.proposalsDelayedAt.IsZero())
+
+	pr.proposalsDelayedAt = now
+	pr.incomingProposals.push(0, newReqCtx(rpcpb.Request{ID: []byte("r1"), Type: rpcpb.Read}, time.Now(), nil))
+	assert.False(t, pr.delayProposals(now), "more than one batch")
+	assert.True(t, pr.proposalsDelayedAt.IsZero())
+
+	pr.commitDelay = 0
+	pr.incomingProposals = newProposalBatch(nil, 1024, 1, Replica{ID: 1})
+	pr.incomingProposals.push(0, newReqCtx(rpcpb.Request{ID: []byte("w1"), Type: rpcpb.Write}, time.Now(), nil))
+	assert.False(t, pr.delayProposals(now), "delay disabled")
+}
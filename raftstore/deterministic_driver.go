@@ -0,0 +1,208 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+// DeterministicDriver drives all replicas of a store in the caller's goroutine.
+// Raft ticks, received raft messages, timers, proposals and applies are only
+// processed when the driver is invoked, replicas are always processed in shard
+// id order and the time is a logical clock advanced by Tick. The store reads
+// the logical clock instead of the wall clock. It is only available when
+// Config.Test.Deterministic is set, to make complex test scenarios reproducible.
+type DeterministicDriver interface {
+	// Now returns the current logical time
+	Now() time.Time
+	// Tick advances the logical clock by a raft tick interval, fires the timers
+	// and the store timer tasks which are due, and sends a raft tick to all
+	// replicas.
+	Tick()
+	// RunOnce delivers the received raft messages and processes all replicas
+	// which have pending events, returns false if there is nothing to process.
+	RunOnce() bool
+	// RunUntilIdle calls RunOnce until there is nothing to process or the
+	// maxRounds is reached, returns the number of rounds executed.
+	RunUntilIdle(maxRounds int) int
+}
+
+// GetDeterministicDriver returns the DeterministicDriver of the store, false
+// if the store is not running in deterministic mode.
+func GetDeterministicDriver(s Store) (DeterministicDriver, bool) {
+	if v, ok := s.(*store); ok && v.driver != nil {
+		return v.driver, true
+	}
+	return nil, false
+}
+
+func newDeterministicDriver(s *store) *deterministicDriver {
+	return &deterministicDriver{store: s}
+}
+
+// driverTimer is a timer fired by the logical clock
+type driverTimer struct {
+	due time.Time
+	seq uint64
+	fn  func()
+}
+
+type deterministicDriver struct {
+	store *store
+
+	mu struct {
+		sync.Mutex
+		ticks uint64
+		// seq orders the timers with the same due time by the schedule order
+		seq      uint64
+		timers   []driverTimer
+		tasks    []*timerTasks
+		messages []metapb.RaftMessage
+	}
+}
+
+func (d *deterministicDriver) Now() time.Time {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.nowLocked()
+}
+
+func (d *deterministicDriver) nowLocked() time.Time {
+	return time.Unix(0, 0).Add(time.Duration(d.mu.ticks) * d.store.cfg.Raft.TickInterval.Duration)
+}
+
+func (d *deterministicDriver) Tick() {
+	d.mu.Lock()
+	d.mu.ticks++
+	now := d.nowLocked()
+	var due []driverTimer
+	timers := d.mu.timers[:0]
+	for _, t := range d.mu.timers {
+		if t.due.After(now) {
+			timers = append(timers, t)
+			continue
+		}
+		due = append(due, t)
+	}
+	d.mu.timers = timers
+	tasks := append([]*timerTasks(nil), d.mu.tasks...)
+	d.mu.Unlock()
+
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].due.Equal(due[j].due) {
+			return due[i].seq < due[j].seq
+		}
+		return due[i].due.Before(due[j].due)
+	})
+	for _, t := range due {
+		t.fn()
+	}
+	for _, t := range tasks {
+		t.runDue(now)
+	}
+
+	var replicas []*replica
+	d.store.forEachReplica(func(pr *replica) bool {
+		replicas = append(replicas, pr)
+		return true
+	})
+	sort.Slice(replicas, func(i, j int) bool {
+		return replicas[i].shardID < replicas[j].shardID
+	})
+	for _, pr := range replicas {
+		pr.addRaftTick()
+	}
+}
+
+func (d *deterministicDriver) RunOnce() bool {
+	delivered := d.deliverMessages()
+	return d.store.workerPool.runOnce() || delivered
+}
+
+func (d *deterministicDriver) RunUntilIdle(maxRounds int) int {
+	n := 0
+	for n < maxRounds && d.RunOnce() {
+		n++
+	}
+	return n
+}
+
+// afterFunc calls fn in Tick once the logical clock reaches now + after
+func (d *deterministicDriver) afterFunc(after time.Duration, fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.seq++
+	d.mu.timers = append(d.mu.timers, driverTimer{
+		due: d.nowLocked().Add(after),
+		seq: d.mu.seq,
+		fn:  fn,
+	})
+}
+
+// addTimerTasks registers the store timer tasks run by Tick
+func (d *deterministicDriver) addTimerTasks(tasks *timerTasks) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.tasks = append(d.mu.tasks, tasks)
+}
+
+// addMessages buffers the raft messages received by the transport, they are
+// delivered to the replicas by RunOnce.
+func (d *deterministicDriver) addMessages(msgs []metapb.RaftMessage) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.mu.messages = append(d.mu.messages, msgs...)
+}
+
+// deliverMessages delivers the buffered raft messages to the replicas. The
+// transport receives the messages on its own goroutines, so the messages are
+// sorted to make the delivery order independent of the arrival order.
+func (d *deterministicDriver) deliverMessages() bool {
+	d.mu.Lock()
+	msgs := d.mu.messages
+	d.mu.messages = nil
+	d.mu.Unlock()
+
+	sortRaftMessages(msgs)
+	for _, msg := range msgs {
+		d.store.onRaftMessage(msg)
+	}
+	return len(msgs) > 0
+}
+
+func sortRaftMessages(msgs []metapb.RaftMessage) {
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return lessRaftMessage(msgs[i], msgs[j])
+	})
+}
+
+func lessRaftMessage(a, b metapb.RaftMessage) bool {
+	switch {
+	case a.ShardID != b.ShardID:
+		return a.ShardID < b.ShardID
+	case a.From.ID != b.From.ID:
+		return a.From.ID < b.From.ID
+	case a.To.ID != b.To.ID:
+		return a.To.ID < b.To.ID
+	case a.Message.Term != b.Message.Term:
+		return a.Message.Term < b.Message.Term
+	case a.Message.Index != b.Message.Index:
+		return a.Message.Index < b.Message.Index
+	}
+	return a.Message.Type < b.Message.Type
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func TestDeterministicDriverTimers(t *testing.T) {
+	s := &store{cfg: &config.Config{}}
+	s.cfg.Raft.TickInterval.Duration = time.Millisecond * 100
+	d := newDeterministicDriver(s)
+
+	var fired []int
+	d.afterFunc(s.cfg.Raft.TickInterval.Duration*2, func() { fired = append(fired, 1) })
+	d.afterFunc(s.cfg.Raft.TickInterval.Duration, func() { fired = append(fired, 2) })
+	d.afterFunc(s.cfg.Raft.TickInterval.Duration, func() { fired = append(fired, 3) })
+
+	runs := 0
+	tasks := &timerTasks{}
+	tasks.add(d.Now(), s.cfg.Raft.TickInterval.Duration*2, func(time.Time) { runs++ })
+	d.addTimerTasks(tasks)
+
+	d.Tick()
+	assert.Equal(t, []int{2, 3}, fired)
+	assert.Equal(t, 0, runs)
+	d.Tick()
+	assert.Equal(t, []int{2, 3, 1}, fired)
+	assert.Equal(t, 1, runs)
+	assert.Equal(t, time.Unix(0, 0).Add(s.cfg.Raft.TickInterval.Duration*2), d.Now())
+}
+
+func TestDeterministicDriverDeliverMessagesInOrder(t *testing.T) {
+	msgs := []metapb.RaftMessage{
+		{ShardID: 2, From: Replica{ID: 1}, Message: raftpb.Message{Index: 1}},
+		{ShardID: 1, From: Replica{ID: 2}, Message: raftpb.Message{Index: 1}},
+		{ShardID: 1, From: Replica{ID: 1}, Message: raftpb.Message{Index: 2}},
+		{ShardID: 1, From: Replica{ID: 1}, Message: raftpb.Message{Index: 1}},
+	}
+	v := append([]metapb.RaftMessage(nil), msgs...)
+	sortRaftMessages(v)
+	assert.Equal(t, []metapb.RaftMessage{msgs[3], msgs[2], msgs[1], msgs[0]}, v)
+}
+
+func TestDeterministicStoreIsReproducible(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	run := func() []string {
+		c := NewSingleTestClusterStore(t,
+			WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+				cfg.Test.Deterministic = true
+			}))
+		c.Start()
+		defer c.Stop()
+
+		s := c.GetStore(0).(*store)
+		d, ok := GetDeterministicDriver(s)
+		require.True(t, ok)
+
+		var pr *replica
+		for i := 0; i < 1000 && (pr == nil || !pr.isLeader()); i++ {
+			d.Tick()
+			d.RunUntilIdle(100)
+			s.forEachReplica(func(v *replica) bool {
+				pr = v
+				return false
+			})
+		}
+		require.NotNil(t, pr)
+		require.True(t, pr.isLeader())
+
+		var trace []string
+		shard := pr.getShard()
+		for i := 0; i < 10; i++ {
+			req := createTestWriteReq(fmt.Sprintf("w%d", i), fmt.Sprintf("k%d", i%3), fmt.Sprintf("v%d", i))
+			req.ToShard = shard.ID
+			req.Group = shard.Group
+			req.Epoch = shard.Epoch
+			require.NoError(t, s.OnRequestWithCB(req, func(resp rpcpb.ResponseBatch) {
+				for _, r := range resp.Responses {
+					trace = append(trace, fmt.Sprintf("%s %s %s", r.ID, r.Value, r.Error.String()))
+				}
+			}))
+			// a request is processed in a later round every other time
+			if i%2 == 0 {
+				d.RunUntilIdle(100)
+			}
+		}
+		for i := 0; i < 10 && len(trace) < 10; i++ {
+			d.Tick()
+			d.RunUntilIdle(100)
+		}
+		require.Equal(t, 10, len(trace))
+		return append(trace, fmt.Sprintf("applied %d", pr.appliedIndex))
+	}
+
+	assert.Equal(t, run(), run())
+}
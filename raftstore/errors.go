@@ -15,13 +15,16 @@ package raftstore
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
 )
 
 var (
@@ -33,6 +36,19 @@ var (
 	errLargeRaftEntrySize = errors.New("raft entry is too large")
 	errKeyNotInShard      = errors.New("key not in shard")
 	errStoreNotMatch      = errors.New("store not match")
+	errShardReadDisabled  = errors.New("shard read disabled")
+	errShardWriteDisabled = errors.New("shard write disabled")
+	errShardDisabled      = errors.New("shard disabled")
+	errApplyLagTooLarge   = errors.New("apply lag too large")
+	errAppLeaseMismatch   = errors.New("app lease mismatch")
+	errGroupMismatch      = errors.New("group mismatch")
+	errQuorumLost         = errors.New("quorum lost")
+	errDeadlineExceeded   = errors.New("deadline exceeded")
+	errStorageIOError     = errors.New("storage io error")
+	errServerIsBusy       = errors.New("server is busy")
+	errInvalidSplitKeys   = errors.New("invalid split keys")
+	errInvalidTransferee  = errors.New("invalid transfer leader target")
+	errExportNotSupported = errors.New("data storage not support export")
 
 	infoStaleCMD  = new(errorpb.StaleCommand)
 	storeMismatch = new(errorpb.StoreMismatch)
@@ -87,6 +103,146 @@ func IsShardUnavailableErr(err error) bool {
 	return ok
 }
 
+// ApplyLagTooLargeErr is an error indicates the read is rejected as the
+// replica's apply lag exceeds the threshold
+type ApplyLagTooLargeErr struct {
+	// ShardID the id of the shard
+	ShardID uint64
+	// ApplyLag the count of the committed but not applied entries
+	ApplyLag uint64
+}
+
+// NewApplyLagTooLargeErr returns a wrapped error that the apply lag of the
+// shard replica is too large to serve the linearizable read
+func NewApplyLagTooLargeErr(id, applyLag uint64) error {
+	return ApplyLagTooLargeErr{ShardID: id, ApplyLag: applyLag}
+}
+
+// Error implements error interface
+func (err ApplyLagTooLargeErr) Error() string {
+	return fmt.Sprintf("shard %d apply lag %d is too large, retry later or allow stale reads",
+		err.ShardID, err.ApplyLag)
+}
+
+// IsApplyLagTooLargeErr checks if an error is ApplyLagTooLargeErr
+func IsApplyLagTooLargeErr(err error) bool {
+	_, ok := err.(ApplyLagTooLargeErr)
+	return ok
+}
+
+// AppLeaseMismatchErr is an error indicates the write is rejected as its token
+// is not the token of the current application lease of the shard
+type AppLeaseMismatchErr struct {
+	// ShardID the id of the shard
+	ShardID uint64
+	// RequestToken the token of the write
+	RequestToken uint64
+	// CurrentLease the current application lease of the shard
+	CurrentLease metapb.AppLease
+}
+
+// NewAppLeaseMismatchErr returns a wrapped error that the write is fenced by
+// the application lease of the shard
+func NewAppLeaseMismatchErr(id, requestToken uint64, currentLease metapb.AppLease) error {
+	return AppLeaseMismatchErr{ShardID: id, RequestToken: requestToken, CurrentLease: currentLease}
+}
+
+// Error implements error interface
+func (err AppLeaseMismatchErr) Error() string {
+	return fmt.Sprintf("shard %d app lease token %d mismatch, current lease %+v",
+		err.ShardID, err.RequestToken, err.CurrentLease)
+}
+
+// IsAppLeaseMismatchErr checks if an error is AppLeaseMismatchErr
+func IsAppLeaseMismatchErr(err error) bool {
+	_, ok := err.(AppLeaseMismatchErr)
+	return ok
+}
+
+// GroupMismatchErr is an error indicates the request is rejected as its shard
+// group is not the group which the key is routed to by the key router
+type GroupMismatchErr struct {
+	// Key the key of the request
+	Key []byte
+	// Group the shard group of the request
+	Group uint64
+	// RoutedGroup the shard group of the key routed by the key router
+	RoutedGroup uint64
+}
+
+// NewGroupMismatchErr returns a wrapped error that the key is routed to another
+// shard group
+func NewGroupMismatchErr(key []byte, group, routedGroup uint64) error {
+	return GroupMismatchErr{Key: key, Group: group, RoutedGroup: routedGroup}
+}
+
+// Error implements error interface
+func (err GroupMismatchErr) Error() string {
+	return fmt.Sprintf("key %x is routed to group %d, but the request group is %d",
+		err.Key, err.RoutedGroup, err.Group)
+}
+
+// IsGroupMismatchErr checks if an error is GroupMismatchErr
+func IsGroupMismatchErr(err error) bool {
+	_, ok := err.(GroupMismatchErr)
+	return ok
+}
+
+// QuorumLostErr is an error indicates the request is rejected as the shard has
+// lost the write quorum
+type QuorumLostErr struct {
+	// ShardID the id of the shard
+	ShardID uint64
+}
+
+// NewQuorumLostErr returns a wrapped error that the shard has lost the write
+// quorum
+func NewQuorumLostErr(id uint64) error {
+	return QuorumLostErr{ShardID: id}
+}
+
+// Error implements error interface
+func (err QuorumLostErr) Error() string {
+	return fmt.Sprintf("shard %d has lost the write quorum, only stale reads are allowed",
+		err.ShardID)
+}
+
+// IsQuorumLostErr checks if an error is QuorumLostErr
+func IsQuorumLostErr(err error) bool {
+	_, ok := err.(QuorumLostErr)
+	return ok
+}
+
+// DeadlineExceededErr is an error indicates the request is given up by the
+// store as its deadline is exceeded, a proposed write may still be applied. It
+// matches context.DeadlineExceeded by errors.Is.
+type DeadlineExceededErr struct {
+	// ShardID the id of the shard
+	ShardID uint64
+}
+
+// NewDeadlineExceededErr returns a wrapped error that the deadline of the
+// request is exceeded
+func NewDeadlineExceededErr(id uint64) error {
+	return DeadlineExceededErr{ShardID: id}
+}
+
+// Error implements error interface
+func (err DeadlineExceededErr) Error() string {
+	return fmt.Sprintf("shard %d request dropped: %s", err.ShardID, context.DeadlineExceeded)
+}
+
+// Is returns true if the target is context.DeadlineExceeded
+func (err DeadlineExceededErr) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// IsDeadlineExceededErr checks if an error is DeadlineExceededErr
+func IsDeadlineExceededErr(err error) bool {
+	_, ok := err.(DeadlineExceededErr)
+	return ok
+}
+
 func buildID(id []byte, resp *rpcpb.ResponseBatch) {
 	if resp.Header.IsEmpty() {
 		return
@@ -143,10 +299,19 @@ func errorBaseResp(id []byte) rpcpb.ResponseBatch {
 	return resp
 }
 
-func checkKeyInShard(key []byte, shard Shard) *errorpb.Error {
-	if bytes.Compare(key, shard.Start) >= 0 &&
-		(len(shard.End) == 0 || bytes.Compare(key, shard.End) < 0) {
-		return nil
+// checkKeyInShard returns the error if the key is not in the shard. If the
+// codec is not nil, the split point of the key must be in the shard too, which
+// means the logical key is not torn by the shard boundary, and the split point
+// is reported as the key in the error.
+func checkKeyInShard(key []byte, shard Shard, codec storage.SplitKeyCodec) *errorpb.Error {
+	if keyInShard(key, shard) {
+		if codec == nil {
+			return nil
+		}
+		key = codec.SplitPoint(key)
+		if keyInShard(key, shard) {
+			return nil
+		}
 	}
 
 	e := &errorpb.KeyNotInShard{
@@ -162,6 +327,72 @@ func checkKeyInShard(key []byte, shard Shard) *errorpb.Error {
 	}
 }
 
+func keyInShard(key []byte, shard Shard) bool {
+	return bytes.Compare(key, shard.Start) >= 0 &&
+		(len(shard.End) == 0 || bytes.Compare(key, shard.End) < 0)
+}
+
+// checkKeyGroup returns the error if the key of the request is routed to
+// another shard group by the router. The requests without key or the keys which
+// are not routed by the router are always accepted.
+func checkKeyGroup(router config.KeyRouter, req rpcpb.Request) *errorpb.Error {
+	if router == nil || len(req.Key) == 0 {
+		return nil
+	}
+
+	group, ok := router.Route(req.Key)
+	if !ok || group == req.Group {
+		return nil
+	}
+
+	return &errorpb.Error{
+		Message: errGroupMismatch.Error(),
+		GroupMismatch: &errorpb.GroupMismatch{
+			Key:         req.Key,
+			Group:       req.Group,
+			RoutedGroup: group,
+		},
+	}
+}
+
+// checkColumnFamily returns the error if the column family of the request is
+// not defined by the data storage
+func checkColumnFamily(feature storage.Feature, req rpcpb.Request) error {
+	if _, ok := feature.ColumnFamilyID(req.CF); !ok {
+		return fmt.Errorf("%w: %q", storage.ErrColumnFamilyNotFound, req.CF)
+	}
+	return nil
+}
+
+// checkShardGate returns the error if the requests of the type are blocked by
+// the gate of the shard. The admin requests are never blocked.
+func checkShardGate(reqType rpcpb.CmdType, shard Shard) *errorpb.Error {
+	gate := shard.Gate
+	switch {
+	case reqType == rpcpb.Read && gate.DisableRead,
+		reqType == rpcpb.Write && gate.DisableWrite:
+	default:
+		return nil
+	}
+
+	if gate.DisableRead && gate.DisableWrite {
+		return &errorpb.Error{
+			Message:       errShardDisabled.Error(),
+			ShardDisabled: &errorpb.ShardDisabled{ShardID: shard.ID, Redirect: gate.Redirect, Permanent: gate.Permanent},
+		}
+	}
+	if gate.DisableRead {
+		return &errorpb.Error{
+			Message:           errShardReadDisabled.Error(),
+			ShardReadDisabled: &errorpb.ShardReadDisabled{ShardID: shard.ID, Redirect: gate.Redirect, Permanent: gate.Permanent},
+		}
+	}
+	return &errorpb.Error{
+		Message:            errShardWriteDisabled.Error(),
+		ShardWriteDisabled: &errorpb.ShardWriteDisabled{ShardID: shard.ID, Redirect: gate.Redirect, Permanent: gate.Permanent},
+	}
+}
+
 // ErrTryAgain indicates that an operation should retry later
 type ErrTryAgain struct {
 	// caller should wait for this period before retry
@@ -171,3 +402,29 @@ type ErrTryAgain struct {
 func (e *ErrTryAgain) Error() string {
 	return fmt.Sprintf("should try again after %v", e.Wait)
 }
+
+// StorageIOErr is an error indicates the request is failed as the replica of
+// the shard failed to write the data storage. The request may be applied after
+// the store is restarted.
+type StorageIOErr struct {
+	// ShardID the id of the shard
+	ShardID uint64
+}
+
+// NewStorageIOErr returns a wrapped error that the replica failed to write the
+// data storage
+func NewStorageIOErr(id uint64) error {
+	return StorageIOErr{ShardID: id}
+}
+
+// Error implements error interface
+func (err StorageIOErr) Error() string {
+	return fmt.Sprintf("shard %d failed to write the data storage, the result is unknown",
+		err.ShardID)
+}
+
+// IsStorageIOErr checks if an error is StorageIOErr
+func IsStorageIOErr(err error) bool {
+	_, ok := err.(StorageIOErr)
+	return ok
+}
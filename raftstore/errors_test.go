@@ -14,13 +14,17 @@
 package raftstore
 
 import (
+	"bytes"
+	"context"
 	"errors"
 
 	"testing"
 
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/stretchr/testify/assert"
 )
@@ -183,6 +187,7 @@ func TestCheckKeyInShard(t *testing.T) {
 	cases := []struct {
 		key     []byte
 		shard   Shard
+		codec   storage.SplitKeyCodec
 		checker func(t assert.TestingT, object interface{}, msgAndArgs ...interface{}) bool
 	}{
 		{
@@ -200,9 +205,151 @@ func TestCheckKeyInShard(t *testing.T) {
 			shard:   Shard{ID: 1, Start: []byte("a"), End: []byte("b")},
 			checker: assert.NotNil,
 		},
+		{
+			key:     []byte("b#1"),
+			shard:   Shard{ID: 1, Start: []byte("b")},
+			codec:   testSplitKeyCodec{},
+			checker: assert.Nil,
+		},
+		{
+			key:     []byte("b#2"),
+			shard:   Shard{ID: 1, Start: []byte("b#1")},
+			codec:   testSplitKeyCodec{},
+			checker: assert.NotNil,
+		},
+	}
+
+	for i, c := range cases {
+		c.checker(t, checkKeyInShard(c.key, c.shard, c.codec), "index %d", i)
+	}
+
+	// the split point is reported if the logical key is torn
+	err := checkKeyInShard([]byte("b#2"), Shard{ID: 1, Start: []byte("b#1")}, testSplitKeyCodec{})
+	assert.Equal(t, []byte("b"), err.KeyNotInShard.Key)
+}
+
+func TestCheckKeyGroup(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	router := config.KeyRouterFunc(func(key []byte) (uint64, bool) {
+		if bytes.HasPrefix(key, []byte("m")) {
+			return 1, true
+		}
+		return 0, false
+	})
+
+	cases := []struct {
+		router config.KeyRouter
+		req    rpcpb.Request
+		err    bool
+	}{
+		{req: rpcpb.Request{Key: []byte("m1")}},
+		{router: router, req: rpcpb.Request{}},
+		{router: router, req: rpcpb.Request{Key: []byte("k1"), Group: 2}},
+		{router: router, req: rpcpb.Request{Key: []byte("m1"), Group: 1}},
+		{router: router, req: rpcpb.Request{Key: []byte("m1")}, err: true},
+		{router: router, req: rpcpb.Request{Key: []byte("m1"), Group: 2}, err: true},
+	}
+
+	for i, c := range cases {
+		err := checkKeyGroup(c.router, c.req)
+		if !c.err {
+			assert.Nil(t, err, "index %d", i)
+			continue
+		}
+		assert.NotNil(t, err, "index %d", i)
+		assert.False(t, errorpb.Retryable(*err), "index %d", i)
+		assert.Equal(t, c.req.Key, err.GroupMismatch.Key, "index %d", i)
+		assert.Equal(t, c.req.Group, err.GroupMismatch.Group, "index %d", i)
+		assert.Equal(t, uint64(1), err.GroupMismatch.RoutedGroup, "index %d", i)
+	}
+}
+
+func TestCheckShardGate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	cases := []struct {
+		reqType rpcpb.CmdType
+		gate    metapb.ShardGate
+		err     string
+	}{
+		{reqType: rpcpb.Read},
+		{reqType: rpcpb.Write},
+		{reqType: rpcpb.Admin, gate: metapb.ShardGate{DisableRead: true, DisableWrite: true}},
+		{reqType: rpcpb.Read, gate: metapb.ShardGate{DisableWrite: true}},
+		{reqType: rpcpb.Write, gate: metapb.ShardGate{DisableWrite: true}, err: errShardWriteDisabled.Error()},
+		{reqType: rpcpb.Write, gate: metapb.ShardGate{DisableRead: true}},
+		{reqType: rpcpb.Read, gate: metapb.ShardGate{DisableRead: true}, err: errShardReadDisabled.Error()},
+		{reqType: rpcpb.Read, gate: metapb.ShardGate{DisableRead: true, DisableWrite: true}, err: errShardDisabled.Error()},
+		{reqType: rpcpb.Write, gate: metapb.ShardGate{DisableRead: true, DisableWrite: true}, err: errShardDisabled.Error()},
 	}
 
 	for i, c := range cases {
-		c.checker(t, checkKeyInShard(c.key, c.shard), "index %d", i)
+		c.gate.Redirect = "redirect"
+		err := checkShardGate(c.reqType, Shard{ID: 1, Gate: c.gate})
+		if c.err == "" {
+			assert.Nil(t, err, "index %d", i)
+			continue
+		}
+		assert.NotNil(t, err, "index %d", i)
+		assert.Equal(t, c.err, err.Message, "index %d", i)
+		assert.True(t, errorpb.Retryable(*err), "index %d", i)
+		switch {
+		case err.ShardDisabled != nil:
+			assert.Equal(t, "redirect", err.ShardDisabled.Redirect, "index %d", i)
+		case err.ShardReadDisabled != nil:
+			assert.Equal(t, "redirect", err.ShardReadDisabled.Redirect, "index %d", i)
+		case err.ShardWriteDisabled != nil:
+			assert.Equal(t, "redirect", err.ShardWriteDisabled.Redirect, "index %d", i)
+		}
 	}
 }
+
+func TestCheckPermanentShardGate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	shard := Shard{ID: 1, Gate: metapb.ShardGate{DisableWrite: true}}
+	err := checkShardGate(rpcpb.Write, shard)
+	if assert.NotNil(t, err) {
+		assert.False(t, err.ShardWriteDisabled.Permanent)
+		assert.True(t, errorpb.Retryable(*err))
+	}
+
+	shard.Gate.Permanent = true
+	assert.Nil(t, checkShardGate(rpcpb.Read, shard))
+	assert.Nil(t, checkShardGate(rpcpb.Admin, shard))
+	err = checkShardGate(rpcpb.Write, shard)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, errShardWriteDisabled.Error(), err.Message)
+		assert.Equal(t, uint64(1), err.ShardWriteDisabled.ShardID)
+		assert.True(t, err.ShardWriteDisabled.Permanent)
+		assert.False(t, errorpb.Retryable(*err))
+	}
+
+	shard.Gate.DisableRead = true
+	err = checkShardGate(rpcpb.Read, shard)
+	if assert.NotNil(t, err) {
+		assert.True(t, err.ShardDisabled.Permanent)
+		assert.False(t, errorpb.Retryable(*err))
+	}
+}
+
+func TestCheckColumnFamily(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	feature := storage.Feature{ColumnFamilies: []string{"lock"}}
+	assert.NoError(t, checkColumnFamily(feature, rpcpb.Request{}))
+	assert.NoError(t, checkColumnFamily(feature, rpcpb.Request{CF: "lock"}))
+	err := checkColumnFamily(feature, rpcpb.Request{CF: "index"})
+	assert.True(t, errors.Is(err, storage.ErrColumnFamilyNotFound))
+	assert.Error(t, checkColumnFamily(storage.Feature{}, rpcpb.Request{CF: "lock"}))
+}
+
+func TestDeadlineExceededErr(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	err := NewDeadlineExceededErr(1)
+	assert.True(t, IsDeadlineExceededErr(err))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.False(t, errors.Is(err, context.Canceled))
+}
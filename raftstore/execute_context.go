@@ -83,10 +83,10 @@ func (ctx *writeContext) SetDiffBytes(value int64) {
 	ctx.diffBytes = value
 }
 
-func (ctx *writeContext) initialize(shard Shard, index uint64) {
+func (ctx *writeContext) initialize(shard Shard, index uint64, commitTime int64) {
 	ctx.buf.Clear()
 	ctx.shard = shard
-	ctx.batch = storage.Batch{Index: index}
+	ctx.batch = storage.Batch{Index: index, CommitTime: commitTime}
 	ctx.responses = ctx.responses[:0]
 	ctx.writtenBytes = 0
 	ctx.diffBytes = 0
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/storage/kv"
 	"github.com/matrixorigin/matrixcube/storage/kv/mem"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
@@ -36,9 +37,10 @@ func TestWriteContextCanBeInitialized(t *testing.T) {
 	ctx := newWriteContext(base)
 	assert.False(t, ctx.hasRequest())
 
-	ctx.initialize(shard, 0)
+	ctx.initialize(shard, 10, 100)
 	assert.Empty(t, ctx.responses)
 	assert.Equal(t, shard, ctx.shard)
+	assert.Equal(t, storage.Batch{Index: 10, CommitTime: 100}, ctx.Batch())
 }
 
 func newTestRPCRequests(n uint64) []rpcpb.Request {
@@ -15,15 +15,19 @@ package raftstore
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
 	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/matrixorigin/matrixcube/util/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSingleClusterReadAndWrite(t *testing.T) {
@@ -43,6 +47,62 @@ func TestSingleClusterReadAndWrite(t *testing.T) {
 	assert.Equal(t, "v1", v)
 }
 
+func TestSingleClusterReadAndWriteWithGroupWorkerPool(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewSingleTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Worker.GroupPools = []config.GroupWorkerPoolConfig{
+				{Groups: []uint64{0}, Workers: 2, TickInterval: cfg.Raft.TickInterval},
+			}
+		}))
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	s := c.GetStore(0).(*store)
+	assert.NotEqual(t, s.workerPool, s.getWorkerPool(0))
+	assert.Equal(t, s.workerPool, s.getWorkerPool(1))
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	assert.NoError(t, kv.Set("k1", "v1", testWaitTimeout))
+	v, err := kv.Get("k1", testWaitTimeout)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", v)
+}
+
+func TestSingleClusterReadAndWriteWithAutoTune(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewSingleTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.AutoTune.Enable = true
+			cfg.AutoTune.Interval = typeutil.NewDuration(time.Millisecond * 10)
+			cfg.AutoTune.MaxCommitDelay = typeutil.NewDuration(time.Millisecond * 5)
+		}))
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("k%d", i)
+		assert.NoError(t, kv.Set(key, key, testWaitTimeout))
+		v, err := kv.Get(key, testWaitTimeout)
+		assert.NoError(t, err)
+		assert.Equal(t, key, v)
+		time.Sleep(time.Millisecond * 5)
+	}
+
+	s := c.GetStore(0).(*store)
+	tuning, ok := s.batchTuner.get(0)
+	require.True(t, ok)
+	assert.True(t, tuning.commitDelay <= time.Millisecond*5)
+	assert.True(t, tuning.writeBatchSize <= uint64(s.cfg.Raft.MaxEntryBytes))
+}
+
 func TestAdvertiseAddr(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping in short mode.")
@@ -113,6 +173,31 @@ func TestClusterStartAndStop(t *testing.T) {
 	c.CheckShardCount(1)
 }
 
+func TestClusterSettingPropagation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	c := NewTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+
+	setting, err := c.GetProphet().GetClient().PutClusterSetting("feature.k1", "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), setting.Version)
+
+	c.EveryStore(func(i int, store Store) {
+		assert.Eventually(t, func() bool {
+			v, ok := store.GetClusterSetting("feature.k1")
+			return ok && v == "v1"
+		}, testWaitTimeout, time.Millisecond*100)
+	})
+}
+
 func TestClusterWithInitClusterStartAndStop(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping in short mode.")
@@ -246,3 +331,142 @@ func TestAddShardLabel(t *testing.T) {
 
 	c.WaitShardByLabel(sid, "label1", "value1", testWaitTimeout)
 }
+
+func TestFollowerRead(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	c := NewTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	sid := c.GetShardByIndex(0, 0).ID
+	c.WaitAllReplicasChangeToVoter(sid, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	assert.NoError(t, kv.Set("k1", "v1", testWaitTimeout))
+
+	// the follower reads are routed to all replicas in turn
+	followerKV := c.CreateTestKVClientWithAdjust(0, func(req *rpcpb.Request) {
+		req.FollowerRead = true
+	})
+	defer followerKV.Close()
+	for i := 0; i < 6; i++ {
+		v, err := followerKV.Get("k1", testWaitTimeout)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1", v)
+	}
+}
+
+func TestLeaderLeaseRead(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	c := NewTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Raft.LeaderLeaseDuration.Duration = 5 * cfg.Raft.TickInterval.Duration
+		}))
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	sid := c.GetShardByIndex(0, 0).ID
+	c.WaitAllReplicasChangeToVoter(sid, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	// the first read renews the lease by the read index, the following reads
+	// are served within the lease and must observe the previous writes
+	for i := 0; i < 10; i++ {
+		value := fmt.Sprintf("v%d", i)
+		assert.NoError(t, kv.Set("k1", value, testWaitTimeout))
+		v, err := kv.Get("k1", testWaitTimeout)
+		assert.NoError(t, err)
+		assert.Equal(t, value, v)
+	}
+}
+
+func TestWriteLargerThanMaxEntryBytes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	c := NewTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Raft.MaxEntryBytes = 4 * 1024
+			cfg.Raft.MaxProposalBytes = 64 * 1024
+		}))
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+
+	value := strings.Repeat("v", 20*1024)
+	assert.NoError(t, kv.Set("k1", value, testWaitTimeout))
+	v, err := kv.Get("k1", testWaitTimeout)
+	assert.NoError(t, err)
+	assert.Equal(t, value, v)
+	assert.Error(t, kv.Set("k2", strings.Repeat("v", 80*1024), testWaitTimeout))
+}
+
+func TestHibernateIdleShard(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	c := NewTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Raft.HibernateTicks = 20
+		}))
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	assert.NoError(t, kv.Set("k1", "v1", testWaitTimeout))
+
+	id := c.GetShardByIndex(0, 0).ID
+	leader := c.GetShardLeaderNode(id)
+	hibernated := func() bool {
+		for i := 0; i < 3; i++ {
+			pr := c.GetStore(i).(*store).getReplica(id, false)
+			if pr == nil || !pr.isHibernated() {
+				return false
+			}
+		}
+		return true
+	}
+	assert.Eventually(t, hibernated, testWaitTimeout, time.Millisecond*100)
+	// the hibernated replicas keep the leader over several keepalive rounds
+	time.Sleep(c.GetStore(0).(*store).cfg.Raft.TickInterval.Duration * 60)
+	assert.True(t, hibernated())
+
+	assert.NoError(t, kv.Set("k2", "v2", testWaitTimeout))
+	v, err := kv.Get("k2", testWaitTimeout)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", v)
+	assert.Equal(t, leader, c.GetShardLeaderNode(id))
+}
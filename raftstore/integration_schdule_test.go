@@ -14,6 +14,7 @@
 package raftstore
 
 import (
+	"context"
 	"sort"
 	"testing"
 
@@ -203,3 +204,32 @@ func TestRebalanceLeaderOnBalancedClusterWithLabel(t *testing.T) {
 	c.WaitLeadersByCountsAndShardGroupAndLabel([]int{1, 1, 1}, 1, "table", "t2", testWaitTimeout)
 	c.WaitLeadersByCountsAndShardGroupAndLabel([]int{1, 1, 1}, 1, "table", "t3", testWaitTimeout)
 }
+
+func TestDrainStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	c := NewTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	id := c.GetShardByIndex(0, 0).ID
+	node := c.GetShardLeaderNode(id)
+	s := c.GetStore(node)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testWaitTimeout)
+	defer cancel()
+	assert.NoError(t, s.Drain(ctx))
+	assert.False(t, s.MaybeLeader(id))
+
+	meta, err := c.GetProphet().GetClient().GetStore(s.Meta().ID)
+	assert.NoError(t, err)
+	assert.True(t, meta.Draining)
+}
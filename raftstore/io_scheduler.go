@@ -0,0 +1,228 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/juju/ratelimit"
+	"github.com/matrixorigin/matrixcube/config"
+	"go.uber.org/zap"
+)
+
+// ioCounterFunc returns the accumulated read and write bytes of the device
+type ioCounterFunc func() (read uint64, write uint64, err error)
+
+// compactionCounterFunc returns the accumulated bytes read and written by the
+// background flushes and compactions of the data storage of the shard group
+type compactionCounterFunc func(group uint64) (read uint64, write uint64)
+
+// ioDirection is the read or the write side of the disk bandwidth
+type ioDirection struct {
+	last        uint64
+	capacity    float64
+	saturated   bool
+	buckets     map[uint64]*ratelimit.Bucket
+	compactions map[uint64]uint64
+}
+
+func newIODirection(capacity uint64) ioDirection {
+	return ioDirection{
+		capacity:    float64(capacity),
+		buckets:     make(map[uint64]*ratelimit.Bucket),
+		compactions: make(map[uint64]uint64),
+	}
+}
+
+func (d *ioDirection) update(cfg config.QoSConfig, counter uint64, elapsed time.Duration,
+	weights map[uint64]uint64, totalWeight uint64) {
+	if d.capacity <= 0 {
+		return
+	}
+	if d.last == 0 || counter < d.last || elapsed <= 0 {
+		d.last = counter
+		return
+	}
+
+	rate := float64(counter-d.last) / elapsed.Seconds()
+	d.last = counter
+	d.saturated = rate >= d.capacity*cfg.SaturationRatio
+	if !d.saturated {
+		return
+	}
+
+	for group, weight := range weights {
+		share := d.capacity * float64(weight) / float64(totalWeight)
+		if b, ok := d.buckets[group]; ok && math.Abs(b.Rate()-share) < 1 {
+			continue
+		}
+		d.buckets[group] = ratelimit.NewBucketWithRate(share, int64(share)+1)
+	}
+}
+
+// chargeCompaction takes the bytes of the background flushes and compactions
+// done since the last refresh from the bucket of the group without waiting, so
+// the following foreground IO of the group pays for them.
+func (d *ioDirection) chargeCompaction(group uint64, counter uint64) {
+	last, ok := d.compactions[group]
+	d.compactions[group] = counter
+	if !ok || counter <= last {
+		return
+	}
+	if b := d.get(group); b != nil {
+		b.Take(int64(counter - last))
+	}
+}
+
+func (d *ioDirection) get(group uint64) *ratelimit.Bucket {
+	if !d.saturated {
+		return nil
+	}
+	return d.buckets[group]
+}
+
+// ioScheduler enforces the bandwidth shares of the shard groups when the disk is
+// saturated, so that a group with heavy IO can not starve the other groups. The
+// capacity of the device is the configured device bandwidth, and the device
+// throughput is sampled periodically to decide whether the disk is saturated.
+// The token buckets of all groups are fed by the capacity according to the
+// group weights, and they are only enforced while the disk is saturated. The
+// writes are charged when applied, so the followers pay for the writes as much
+// as the leader, and the background flushes and compactions are charged to the
+// group owning the data storage.
+type ioScheduler struct {
+	logger      *zap.Logger
+	cfg         config.QoSConfig
+	counter     ioCounterFunc
+	compactions compactionCounterFunc
+
+	mu struct {
+		sync.RWMutex
+		lastTime time.Time
+		read     ioDirection
+		write    ioDirection
+		weights  map[uint64]uint64
+	}
+}
+
+func newIOScheduler(logger *zap.Logger, cfg config.QoSConfig, counter ioCounterFunc,
+	compactions compactionCounterFunc) *ioScheduler {
+	s := &ioScheduler{
+		logger:      logger,
+		cfg:         cfg,
+		counter:     counter,
+		compactions: compactions,
+	}
+	s.mu.read = newIODirection(uint64(cfg.ReadBandwidth))
+	s.mu.write = newIODirection(uint64(cfg.WriteBandwidth))
+	s.mu.weights = make(map[uint64]uint64)
+	for _, g := range cfg.Groups {
+		s.mu.weights[g.Group] = g.Weight
+	}
+	if cfg.Enable && cfg.ReadBandwidth == 0 && cfg.WriteBandwidth == 0 {
+		logger.Warn("io scheduler enabled without the device bandwidth, nothing is scheduled")
+	}
+	return s
+}
+
+func (s *ioScheduler) enabled() bool {
+	return s != nil && s.cfg.Enable
+}
+
+// addGroup registers a shard group to the scheduler, groups without explicitly
+// configured weight use the default weight.
+func (s *ioScheduler) addGroup(group uint64) {
+	if !s.enabled() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.mu.weights[group]; !ok {
+		s.mu.weights[group] = s.cfg.DefaultWeight
+	}
+}
+
+// refresh samples the device throughput and adjusts the group buckets
+func (s *ioScheduler) refresh(now time.Time) {
+	if !s.enabled() {
+		return
+	}
+
+	read, write, err := s.counter()
+	if err != nil {
+		s.logger.Error("fail to get io counters",
+			zap.Error(err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	totalWeight := uint64(0)
+	for _, w := range s.mu.weights {
+		totalWeight += w
+	}
+	if totalWeight == 0 {
+		return
+	}
+
+	elapsed := now.Sub(s.mu.lastTime)
+	s.mu.lastTime = now
+	s.mu.read.update(s.cfg, read, elapsed, s.mu.weights, totalWeight)
+	s.mu.write.update(s.cfg, write, elapsed, s.mu.weights, totalWeight)
+	if s.compactions == nil {
+		return
+	}
+	for group := range s.mu.weights {
+		read, write := s.compactions(group)
+		s.mu.read.chargeCompaction(group, read)
+		s.mu.write.chargeCompaction(group, write)
+	}
+}
+
+// waitRead waits until the group is allowed to read n bytes
+func (s *ioScheduler) waitRead(group uint64, n int64) {
+	if !s.enabled() {
+		return
+	}
+
+	s.mu.RLock()
+	b := s.mu.read.get(group)
+	s.mu.RUnlock()
+	if b != nil {
+		b.Wait(n)
+	}
+}
+
+// waitWrite waits until the group is allowed to write n bytes
+func (s *ioScheduler) waitWrite(group uint64, n int64) {
+	if !s.enabled() {
+		return
+	}
+
+	s.mu.RLock()
+	b := s.mu.write.get(group)
+	s.mu.RUnlock()
+	if b != nil {
+		b.Wait(n)
+	}
+}
+
+func (s *ioScheduler) isSaturated() (read bool, write bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mu.read.saturated, s.mu.write.saturated
+}
@@ -0,0 +1,173 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func TestIOSchedulerDisabled(t *testing.T) {
+	var s *ioScheduler
+	assert.False(t, s.enabled())
+	s.waitRead(1, 100)
+	s.waitWrite(1, 100)
+	s.refresh(time.Now())
+
+	s = newIOScheduler(log.GetDefaultZapLogger(), config.QoSConfig{}, nil, nil)
+	assert.False(t, s.enabled())
+	s.addGroup(1)
+	s.refresh(time.Now())
+}
+
+func TestIOSchedulerSaturation(t *testing.T) {
+	var read, write uint64
+	cfg := config.QoSConfig{
+		Enable:          true,
+		ReadBandwidth:   4000,
+		WriteBandwidth:  8000,
+		SaturationRatio: 0.9,
+		DefaultWeight:   1,
+		Groups:          []config.GroupQoSConfig{{Group: 1, Weight: 3}},
+	}
+	s := newIOScheduler(log.GetDefaultZapLogger(), cfg, func() (uint64, uint64, error) {
+		return read, write, nil
+	}, nil)
+	s.addGroup(1)
+	s.addGroup(2)
+
+	now := time.Now()
+	read, write = 1, 1
+	s.refresh(now)
+	r, w := s.isSaturated()
+	assert.False(t, r)
+	assert.False(t, w)
+
+	// reached the device bandwidth
+	now = now.Add(time.Second)
+	read, write = 1+4000, 1+8000
+	s.refresh(now)
+	r, w = s.isSaturated()
+	assert.True(t, r)
+	assert.True(t, w)
+	require.NotNil(t, s.mu.write.get(1))
+	assert.InDelta(t, float64(6000), s.mu.write.get(1).Rate(), 1)
+	assert.InDelta(t, float64(2000), s.mu.write.get(2).Rate(), 1)
+	assert.InDelta(t, float64(3000), s.mu.read.get(1).Rate(), 1)
+	assert.InDelta(t, float64(1000), s.mu.read.get(2).Rate(), 1)
+
+	// throughput dropped, buckets are not enforced
+	now = now.Add(time.Second)
+	read, write = read+1000, write+1000
+	s.refresh(now)
+	r, w = s.isSaturated()
+	assert.False(t, r)
+	assert.False(t, w)
+	assert.Nil(t, s.mu.write.get(1))
+	assert.Nil(t, s.mu.read.get(2))
+
+	// a burst above the device bandwidth doesn't raise the capacity
+	now = now.Add(time.Second)
+	read, write = read+8000, write+16000
+	s.refresh(now)
+	r, w = s.isSaturated()
+	assert.True(t, r)
+	assert.True(t, w)
+	assert.InDelta(t, float64(6000), s.mu.write.get(1).Rate(), 1)
+	assert.InDelta(t, float64(1000), s.mu.read.get(2).Rate(), 1)
+}
+
+func TestIOSchedulerWithoutBandwidth(t *testing.T) {
+	var write uint64
+	cfg := config.QoSConfig{
+		Enable:          true,
+		SaturationRatio: 0.9,
+		DefaultWeight:   1,
+	}
+	s := newIOScheduler(log.GetDefaultZapLogger(), cfg, func() (uint64, uint64, error) {
+		return 0, write, nil
+	}, nil)
+	s.addGroup(1)
+
+	now := time.Now()
+	write = 1
+	s.refresh(now)
+	now = now.Add(time.Second)
+	write += 100000
+	s.refresh(now)
+	r, w := s.isSaturated()
+	assert.False(t, r)
+	assert.False(t, w)
+}
+
+func TestIOSchedulerChargesCompaction(t *testing.T) {
+	var write uint64
+	compactions := map[uint64]uint64{}
+	cfg := config.QoSConfig{
+		Enable:          true,
+		WriteBandwidth:  1000,
+		SaturationRatio: 0.9,
+		DefaultWeight:   1,
+	}
+	s := newIOScheduler(log.GetDefaultZapLogger(), cfg, func() (uint64, uint64, error) {
+		return 0, write, nil
+	}, func(group uint64) (uint64, uint64) {
+		return 0, compactions[group]
+	})
+	s.addGroup(1)
+	s.addGroup(2)
+
+	now := time.Now()
+	write = 1
+	s.refresh(now)
+
+	now = now.Add(time.Second)
+	write += 1000
+	compactions[1] = 300
+	s.refresh(now)
+	require.NotNil(t, s.mu.write.get(1))
+	require.NotNil(t, s.mu.write.get(2))
+	// group 1 is charged for the compaction since the last refresh
+	available1 := s.mu.write.get(1).Available()
+	available2 := s.mu.write.get(2).Available()
+
+	now = now.Add(time.Second)
+	write += 1000
+	compactions[1] = 800
+	s.refresh(now)
+	assert.True(t, s.mu.write.get(1).Available() <= available1-500)
+	assert.True(t, s.mu.write.get(2).Available() >= available2)
+}
+
+func TestStateMachineChargesAppliedWrites(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		var charged []int64
+		sm.throttleWrite = func(n int64) { charged = append(charged, n) }
+
+		e1 := newKVSetEntry(1, 1, []byte("k1"), []byte("v1"))
+		e2 := newKVSetEntry(2, 2, []byte("k2"), []byte("value2"))
+		sm.applyCommittedEntries([]raftpb.Entry{e1, e2})
+		require.Equal(t, 2, len(charged))
+		assert.True(t, charged[0] > 0)
+		assert.True(t, charged[1] > charged[0])
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
@@ -18,8 +18,9 @@ import (
 )
 
 type applyMetrics struct {
-	// an inaccurate difference in shard size since last reset.
-	approximateDiffHint uint64
+	// an inaccurate difference in shard size since last reset, it is negative
+	// if the deleted or expired bytes are more than the written bytes.
+	approximateDiffHint int64
 	// delete keys' count since last reset.
 	deleteKeysHint uint64
 	writtenBytes   uint64
@@ -125,11 +126,12 @@ func (m *raftMessageMetrics) flush() {
 }
 
 type raftProposeMetrics struct {
-	readLocal      uint64
-	readIndex      uint64
-	normal         uint64
-	transferLeader uint64
-	confChange     uint64
+	readLocal         uint64
+	readIndex         uint64
+	followerReadIndex uint64
+	normal            uint64
+	transferLeader    uint64
+	confChange        uint64
 }
 
 func (m *raftProposeMetrics) flush() {
@@ -143,6 +145,11 @@ func (m *raftProposeMetrics) flush() {
 		m.readIndex = 0
 	}
 
+	if m.followerReadIndex > 0 {
+		metric.AddRaftProposalFollowerReadIndexCount(m.followerReadIndex)
+		m.followerReadIndex = 0
+	}
+
 	if m.normal > 0 {
 		metric.AddRaftProposalNormalCount(m.normal)
 		m.normal = 0
@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 )
@@ -50,6 +51,22 @@ func (p *pendingProposals) clear() {
 	p.cmds = p.cmds[:0]
 }
 
+// releaseDeadlineExceeded responds the proposals whose deadlines are exceeded
+// with DeadlineExceeded error and releases their callbacks, as the clients have
+// given them up. The proposals are kept in place, so the applied results are
+// still matched in order, but nothing is responded once they are applied.
+func (p *pendingProposals) releaseDeadlineExceeded(shardID uint64, now time.Time) int {
+	n := 0
+	for idx := range p.cmds {
+		if p.cmds[idx].cb != nil && p.cmds[idx].deadlineExceeded(now) {
+			p.cmds[idx].respDeadlineExceeded(shardID)
+			p.cmds[idx].cb = nil
+			n++
+		}
+	}
+	return n
+}
+
 func (p *pendingProposals) pop() (batch, bool) {
 	if len(p.cmds) == 0 {
 		return emptyCMD, false
@@ -61,6 +78,14 @@ func (p *pendingProposals) pop() (batch, bool) {
 	return c, true
 }
 
+func (p *pendingProposals) size() int {
+	n := len(p.cmds)
+	if !p.confChangeCmd.requestBatch.IsEmpty() {
+		n++
+	}
+	return n
+}
+
 func (p *pendingProposals) append(c batch) {
 	p.cmds = append(p.cmds, c)
 }
@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"testing"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/pb/errorpb"
@@ -224,3 +225,36 @@ func TestPendingProposalCanNotifyRegularCmd(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, cmd3, v)
 }
+
+func TestPendingProposalReleaseDeadlineExceeded(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var responses []rpcpb.ResponseBatch
+	cb := func(resp rpcpb.ResponseBatch) {
+		responses = append(responses, resp)
+	}
+	now := time.Now()
+	cmd1 := newTestBatch("1", "", 0, rpcpb.Write, 0, cb)
+	cmd1.deadline = now.Add(-time.Second)
+	cmd2 := newTestBatch("2", "", 0, rpcpb.Write, 0, cb)
+	cmd2.deadline = now.Add(time.Second)
+	cmd3 := newTestBatch("3", "", 0, rpcpb.Write, 0, cb)
+	p := newPendingProposals()
+	p.append(cmd1)
+	p.append(cmd2)
+	p.append(cmd3)
+
+	assert.Equal(t, 1, p.releaseDeadlineExceeded(1, now))
+	assert.Equal(t, 0, p.releaseDeadlineExceeded(1, now))
+	assert.Equal(t, 1, len(responses))
+	assert.Equal(t, []byte("1"), responses[0].Responses[0].ID)
+	assert.Equal(t, &errorpb.DeadlineExceeded{ShardID: 1}, responses[0].Header.Error.DeadlineExceeded)
+
+	// the released proposal is kept to match the applied results in order, but
+	// it's never responded again
+	assert.Equal(t, 3, p.size())
+	p.notify(cmd2.getRequestID(), rpcpb.ResponseBatch{Responses: []rpcpb.Response{{}}}, false)
+	assert.Equal(t, 2, len(responses))
+	assert.Equal(t, []byte("2"), responses[1].Responses[0].ID)
+	assert.Equal(t, 1, p.size())
+}
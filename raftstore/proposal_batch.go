@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/metric"
@@ -41,10 +42,16 @@ type reqCtx struct {
 	reqType int
 	req     rpcpb.Request
 	cb      func(rpcpb.ResponseBatch)
+	// deadline the deadline of the request on the clock of the store,
+	// zero means no deadline
+	deadline time.Time
 }
 
-func newReqCtx(req rpcpb.Request, cb func(rpcpb.ResponseBatch)) reqCtx {
+func newReqCtx(req rpcpb.Request, now time.Time, cb func(rpcpb.ResponseBatch)) reqCtx {
 	ctx := reqCtx{req: req, cb: cb}
+	if req.Timeout > 0 {
+		ctx.deadline = now.Add(time.Duration(req.Timeout))
+	}
 	switch req.Type {
 	case rpcpb.Read:
 		ctx.reqType = read
@@ -58,6 +65,12 @@ func newReqCtx(req rpcpb.Request, cb func(rpcpb.ResponseBatch)) reqCtx {
 	return ctx
 }
 
+// deadlineExceeded returns true if the request has a deadline and the deadline
+// is exceeded at the time
+func (c reqCtx) deadlineExceeded(now time.Time) bool {
+	return !c.deadline.IsZero() && !now.Before(c.deadline)
+}
+
 type proposalBatch struct {
 	logger  *zap.Logger
 	maxSize uint64
@@ -85,14 +98,28 @@ func (b *proposalBatch) isEmpty() bool {
 	return b.size() == 0
 }
 
+// pop returns the first batch of the highest priority, the batches of the same
+// priority are popped in order.
 func (b *proposalBatch) pop() (batch, bool) {
 	if b.isEmpty() {
 		return emptyCMD, false
 	}
 
-	value := b.batches[0]
-	b.batches[0] = emptyCMD
-	b.batches = b.batches[1:]
+	idx := 0
+	for i := 1; i < len(b.batches) && priorityRank(b.batches[idx]) > 0; i++ {
+		if priorityRank(b.batches[i]) < priorityRank(b.batches[idx]) {
+			idx = i
+		}
+	}
+	value := b.batches[idx]
+	if idx == 0 {
+		b.batches[0] = emptyCMD
+		b.batches = b.batches[1:]
+	} else {
+		copy(b.batches[idx:], b.batches[idx+1:])
+		b.batches[len(b.batches)-1] = emptyCMD
+		b.batches = b.batches[:len(b.batches)-1]
+	}
 
 	metric.SetRaftProposalBatchMetric(int64(len(value.requestBatch.Requests)))
 	return value, true
@@ -126,15 +153,24 @@ func (b *proposalBatch) push(group uint64, c reqCtx) {
 		b.buf.Clear()
 	}
 
+	// the admin requests, e.g. config change and split, are never starved by
+	// the user requests
+	priority := req.Priority
+	if isAdmin {
+		priority = rpcpb.HighPriority
+	}
+
 	n := req.Size()
 	added := false
 	if !isAdmin {
 		for idx := range b.batches {
 			if b.batches[idx].tp == tp && // only batches same type requests
+				b.batches[idx].requestBatch.Header.Priority == priority && // only batches same priority requests
 				!b.batches[idx].isFull(n, int(b.maxSize)) && // check max batches size
 				b.batches[idx].canBatches(req) { // check epoch field
 				b.batches[idx].requestBatch.Requests = append(b.batches[idx].requestBatch.Requests, req)
 				b.batches[idx].byteSize += n
+				b.batches[idx].updateDeadline(c.deadline)
 				added = true
 				break
 			}
@@ -147,7 +183,23 @@ func (b *proposalBatch) push(group uint64, c reqCtx) {
 		rb.Header.ShardID = b.shardID
 		rb.Header.Replica = b.replica
 		rb.Header.ID = uuid.NewV4().Bytes()
+		rb.Header.Priority = priority
 		rb.Requests = append(rb.Requests, req)
-		b.batches = append(b.batches, newBatch(b.logger, rb, cb, tp, n))
+		nb := newBatch(b.logger, rb, cb, tp, n)
+		nb.deadline = c.deadline
+		b.batches = append(b.batches, nb)
+	}
+}
+
+// priorityRank returns the rank of the batch's priority, the lower rank is
+// proposed first
+func priorityRank(c batch) int {
+	switch c.requestBatch.Header.Priority {
+	case rpcpb.HighPriority:
+		return 0
+	case rpcpb.LowPriority:
+		return 2
+	default:
+		return 1
 	}
 }
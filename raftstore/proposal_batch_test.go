@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"testing"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
@@ -29,8 +30,8 @@ var (
 func TestProposalBatchNeverBatchesAdminReq(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	b := newProposalBatch(nil, testMaxBatchSize, 10, Replica{})
-	r1 := newReqCtx(rpcpb.Request{Type: rpcpb.Admin}, nil)
-	r2 := newReqCtx(rpcpb.Request{Type: rpcpb.Admin}, nil)
+	r1 := newReqCtx(rpcpb.Request{Type: rpcpb.Admin}, time.Now(), nil)
+	r2 := newReqCtx(rpcpb.Request{Type: rpcpb.Admin}, time.Now(), nil)
 	b.push(1, r1)
 	b.push(1, r2)
 	assert.Equal(t, 2, b.size())
@@ -40,10 +41,10 @@ func TestProposalBatchNeverBatchesDifferentTypeOfRequest(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	r1 := newReqCtx(rpcpb.Request{
 		Type: rpcpb.Write,
-	}, nil)
+	}, time.Now(), nil)
 	r2 := newReqCtx(rpcpb.Request{
 		Type: rpcpb.Read,
-	}, nil)
+	}, time.Now(), nil)
 	b := newProposalBatch(nil, testMaxBatchSize, 10, Replica{})
 	b.push(1, r1)
 	b.push(1, r2)
@@ -55,10 +56,10 @@ func TestProposalBatchLimitsBatchSize(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	r1 := newReqCtx(rpcpb.Request{
 		Type: rpcpb.Write,
-	}, nil)
+	}, time.Now(), nil)
 	r2 := newReqCtx(rpcpb.Request{
 		Type: rpcpb.Write,
-	}, nil)
+	}, time.Now(), nil)
 	b1 := newProposalBatch(nil, testMaxBatchSize, 10, Replica{})
 	b1.push(1, r1)
 	b1.push(1, r2)
@@ -78,11 +79,11 @@ func TestProposalBatchNeverBatchesRequestsFromDifferentEpoch(t *testing.T) {
 	r1 := newReqCtx(rpcpb.Request{
 		Type:  rpcpb.Write,
 		Epoch: metapb.ShardEpoch{ConfigVer: 1, Generation: 1},
-	}, nil)
+	}, time.Now(), nil)
 	r2 := newReqCtx(rpcpb.Request{
 		Type:  rpcpb.Write,
 		Epoch: metapb.ShardEpoch{ConfigVer: 2, Generation: 2},
-	}, nil)
+	}, time.Now(), nil)
 	b := newProposalBatch(nil, testMaxBatchSize, 10, Replica{})
 	b.push(1, r1)
 	b.push(1, r2)
@@ -100,11 +101,11 @@ func TestProposalBatchNeverBatchesRequestsFromDifferentLease(t *testing.T) {
 	r1 := newReqCtx(rpcpb.Request{
 		Type:  rpcpb.Write,
 		Lease: &metapb.EpochLease{Epoch: 1, ReplicaID: 1},
-	}, nil)
+	}, time.Now(), nil)
 	r2 := newReqCtx(rpcpb.Request{
 		Type:  rpcpb.Write,
 		Lease: &metapb.EpochLease{Epoch: 2, ReplicaID: 2},
-	}, nil)
+	}, time.Now(), nil)
 	b := newProposalBatch(nil, testMaxBatchSize, 10, Replica{})
 	b.push(1, r1)
 	b.push(1, r2)
@@ -121,10 +122,10 @@ func TestProposalBatchPop(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	r1 := newReqCtx(rpcpb.Request{
 		Type: rpcpb.Write,
-	}, nil)
+	}, time.Now(), nil)
 	r2 := newReqCtx(rpcpb.Request{
 		Type: rpcpb.Read,
-	}, nil)
+	}, time.Now(), nil)
 	b := newProposalBatch(nil, testMaxBatchSize, 10, Replica{})
 	b.push(1, r1)
 	b.push(1, r2)
@@ -139,3 +140,58 @@ func TestProposalBatchPop(t *testing.T) {
 	assert.False(t, ok)
 	assert.Equal(t, emptyCMD, v3)
 }
+
+func TestProposalBatchNeverBatchesDifferentPriority(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r1 := newReqCtx(rpcpb.Request{Type: rpcpb.Write}, time.Now(), nil)
+	r2 := newReqCtx(rpcpb.Request{Type: rpcpb.Write, Priority: rpcpb.LowPriority}, time.Now(), nil)
+	r3 := newReqCtx(rpcpb.Request{Type: rpcpb.Write}, time.Now(), nil)
+	b := newProposalBatch(nil, testMaxBatchSize, 10, Replica{})
+	b.push(1, r1)
+	b.push(1, r2)
+	b.push(1, r3)
+	assert.Equal(t, 2, b.size())
+}
+
+func TestProposalBatchPopByPriority(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	b := newProposalBatch(nil, testMaxBatchSize, 10, Replica{})
+	b.push(1, newReqCtx(rpcpb.Request{ID: []byte{1}, Type: rpcpb.Write, Priority: rpcpb.LowPriority}, time.Now(), nil))
+	b.push(1, newReqCtx(rpcpb.Request{ID: []byte{2}, Type: rpcpb.Read}, time.Now(), nil))
+	b.push(1, newReqCtx(rpcpb.Request{ID: []byte{3}, Type: rpcpb.Write}, time.Now(), nil))
+	b.push(1, newReqCtx(rpcpb.Request{ID: []byte{4}, Type: rpcpb.Admin}, time.Now(), nil))
+	b.push(1, newReqCtx(rpcpb.Request{ID: []byte{5}, Type: rpcpb.Read, Priority: rpcpb.HighPriority}, time.Now(), nil))
+
+	var ids []byte
+	for {
+		c, ok := b.pop()
+		if !ok {
+			break
+		}
+		ids = append(ids, c.requestBatch.Requests[0].ID[0])
+	}
+	assert.Equal(t, []byte{4, 5, 2, 3, 1}, ids)
+}
+
+func TestProposalBatchDeadline(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r1 := newReqCtx(rpcpb.Request{Type: rpcpb.Write, Timeout: int64(time.Second)}, time.Now(), nil)
+	r2 := newReqCtx(rpcpb.Request{Type: rpcpb.Write, Timeout: int64(time.Minute)}, time.Now(), nil)
+	r3 := newReqCtx(rpcpb.Request{Type: rpcpb.Write}, time.Now(), nil)
+	assert.True(t, r1.deadline.Before(r2.deadline))
+	assert.True(t, r3.deadline.IsZero())
+
+	// the deadline of the batch is the latest one of the requests
+	b := newProposalBatch(nil, testMaxBatchSize, 10, Replica{})
+	b.push(1, r2)
+	b.push(1, r1)
+	assert.Equal(t, 1, b.size())
+	assert.Equal(t, r2.deadline, b.batches[0].deadline)
+	assert.False(t, b.batches[0].deadlineExceeded(r1.deadline))
+	assert.True(t, b.batches[0].deadlineExceeded(r2.deadline))
+
+	// the batch has no deadline if one of the requests has no deadline
+	b.push(1, r3)
+	assert.True(t, b.batches[0].deadline.IsZero())
+	assert.False(t, b.batches[0].deadlineExceeded(r2.deadline.Add(time.Hour)))
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+// maxChunkOverhead the max bytes of a chunk entry except the header and the
+// chunk data
+const maxChunkOverhead = 64
+
+// splitProposal splits the marshaled request batch into the chunk entries, each
+// chunk entry is not larger than the maxEntryBytes. Returns nil if the header
+// of the request batch leaves no room for the chunk data.
+func splitProposal(header rpcpb.RequestBatchHeader, data []byte, maxEntryBytes int) [][]byte {
+	chunkSize := maxEntryBytes - header.Size() - maxChunkOverhead
+	if chunkSize <= 0 {
+		return nil
+	}
+
+	count := (len(data) + chunkSize - 1) / chunkSize
+	entries := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		entries = append(entries, protoc.MustMarshal(&rpcpb.RequestBatch{
+			Header: header,
+			Chunk: &rpcpb.ProposalChunk{
+				Index: uint32(i),
+				Count: uint32(count),
+				Data:  data[i*chunkSize : end],
+			},
+		}))
+	}
+	return entries
+}
+
+// proposalChunks reassembles the chunks of a request batch applied by the state
+// machine. The chunks of a request batch are proposed continuously, the
+// incomplete chunks left by a failed proposal or a leader change are dropped
+// once any other entry is applied, e.g. the abort entry or the empty entry of
+// the new leader.
+type proposalChunks struct {
+	id   []byte
+	next uint32
+	data []byte
+}
+
+// add adds the chunk, returns the reassembled request batch and true if the
+// chunk is the last chunk of the request batch.
+func (c *proposalChunks) add(req rpcpb.RequestBatch) (rpcpb.RequestBatch, bool) {
+	chunk := req.Chunk
+	if chunk.Index == 0 {
+		c.reset()
+		c.id = req.Header.ID
+	} else if chunk.Index != c.next ||
+		!bytes.Equal(c.id, req.Header.ID) {
+		c.reset()
+		return rpcpb.RequestBatch{}, false
+	}
+
+	c.data = append(c.data, chunk.Data...)
+	c.next = chunk.Index + 1
+	if c.next < chunk.Count {
+		return rpcpb.RequestBatch{}, false
+	}
+
+	var batch rpcpb.RequestBatch
+	if err := batch.FastUnmarshal(c.data); err != nil {
+		panic(err)
+	}
+	c.reset()
+	return batch, true
+}
+
+// pending returns true if some chunks are waiting for the rest chunks of the
+// request batch
+func (c *proposalChunks) pending() bool {
+	return len(c.id) > 0
+}
+
+func (c *proposalChunks) reset() {
+	c.id = nil
+	c.next = 0
+	c.data = nil
+}
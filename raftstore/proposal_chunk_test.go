@@ -0,0 +1,93 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLargeRequestBatch(id string, size int) (rpcpb.RequestBatch, []byte) {
+	req := rpcpb.RequestBatch{
+		Header: rpcpb.RequestBatchHeader{ID: []byte(id), ShardID: 1},
+		Requests: []rpcpb.Request{{
+			ID:   []byte(id),
+			Type: rpcpb.Write,
+			Cmd:  make([]byte, size),
+		}},
+	}
+	return req, protoc.MustMarshal(&req)
+}
+
+func TestSplitProposal(t *testing.T) {
+	req, data := newTestLargeRequestBatch("id", 10000)
+	entries := splitProposal(req.Header, data, 1024)
+	assert.True(t, len(entries) > 1)
+
+	var chunks proposalChunks
+	for i, entry := range entries {
+		assert.True(t, len(entry) <= 1024)
+		var chunk rpcpb.RequestBatch
+		protoc.MustUnmarshal(&chunk, entry)
+		assert.Equal(t, uint32(i), chunk.Chunk.Index)
+		assert.Equal(t, uint32(len(entries)), chunk.Chunk.Count)
+
+		batch, ok := chunks.add(chunk)
+		if i < len(entries)-1 {
+			assert.False(t, ok)
+			assert.True(t, chunks.pending())
+		} else {
+			assert.True(t, ok)
+			assert.False(t, chunks.pending())
+			assert.Equal(t, req, batch)
+		}
+	}
+
+	assert.Empty(t, splitProposal(req.Header, data, maxChunkOverhead))
+}
+
+func TestProposalChunksDropIncompleteChunks(t *testing.T) {
+	req1, data1 := newTestLargeRequestBatch("id1", 4000)
+	req2, data2 := newTestLargeRequestBatch("id2", 4000)
+	entries1 := splitProposal(req1.Header, data1, 1024)
+	entries2 := splitProposal(req2.Header, data2, 1024)
+
+	decode := func(entry []byte) rpcpb.RequestBatch {
+		var chunk rpcpb.RequestBatch
+		protoc.MustUnmarshal(&chunk, entry)
+		return chunk
+	}
+
+	// the rest chunks of id1 are lost by a leader change
+	var chunks proposalChunks
+	_, ok := chunks.add(decode(entries1[0]))
+	assert.False(t, ok)
+	for i, entry := range entries2 {
+		batch, ok := chunks.add(decode(entry))
+		assert.Equal(t, i == len(entries2)-1, ok)
+		if ok {
+			assert.Equal(t, req2, batch)
+		}
+	}
+
+	// the chunk out of order is dropped
+	_, ok = chunks.add(decode(entries1[0]))
+	assert.False(t, ok)
+	_, ok = chunks.add(decode(entries1[2]))
+	assert.False(t, ok)
+	assert.False(t, chunks.pending())
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
@@ -54,6 +55,9 @@ type ShardsProxy interface {
 	Stop() error
 	Dispatch(req rpcpb.Request) error
 	DispatchTo(req rpcpb.Request, shard Shard, store metapb.Store, lease *metapb.EpochLease) error
+	// InvalidateReadCache invalidates the cached reads by the key changes not
+	// written through the proxy, it's a no-op if the read cache is disabled
+	InvalidateReadCache(changes ...KeyChange)
 	SetCallback(SuccessCallback, FailureCallback)
 	SetRetryController(retryController RetryController)
 	OnResponse(rpcpb.ResponseBatch)
@@ -79,6 +83,7 @@ type shardsProxyConfig struct {
 	rpcpb           proxyRPC
 	maxBodySize     int
 	retryInterval   time.Duration
+	readCache       *readCache
 }
 
 type shardsProxyBuilder struct {
@@ -115,6 +120,13 @@ func (sb *shardsProxyBuilder) withRequestCallback(successCallback SuccessCallbac
 	return sb
 }
 
+func (sb *shardsProxyBuilder) withReadCache(cfg config.ReadCacheConfig) *shardsProxyBuilder {
+	if cfg.Enable {
+		sb.cfg.readCache = newReadCache(cfg)
+	}
+	return sb
+}
+
 func (sb *shardsProxyBuilder) withLogger(logger *zap.Logger) *shardsProxyBuilder {
 	sb.cfg.logger = logger
 	return sb
@@ -201,19 +213,27 @@ func (p *shardsProxy) SetRetryController(retryController RetryController) {
 
 func (p *shardsProxy) Dispatch(req rpcpb.Request) error {
 	if req.ToShard == 0 {
-		shard, store, lease := p.cfg.router.SelectShardWithPolicy(req.Group, req.Key, req.ReplicaSelectPolicy)
+		shard, store, lease := p.cfg.router.SelectShardWithPolicy(req.Group, req.Key, getReplicaSelectPolicy(req))
 		return p.DispatchTo(req, shard, store, lease)
 	}
 
-	store, lease := p.cfg.router.SelectReplicaStoreWithPolicy(req.ToShard, req.ReplicaSelectPolicy)
+	store, lease := p.cfg.router.SelectReplicaStoreWithPolicy(req.ToShard, getReplicaSelectPolicy(req))
 	return p.DispatchTo(req, p.cfg.router.GetShard(req.ToShard), store, lease)
 }
 
 func (p *shardsProxy) DispatchTo(req rpcpb.Request, shard Shard, store metapb.Store, lease *metapb.EpochLease) error {
+	if p.cfg.readCache != nil {
+		if rsp, ok := p.cfg.readCache.get(req, shard); ok {
+			p.cfg.successCallback(rsp)
+			return nil
+		}
+		p.cfg.readCache.trackWrite(req, shard)
+	}
+
 	to := store.ClientAddress
 
 	if ce := p.logger.Check(zap.DebugLevel, "dispatch request"); ce != nil {
-		ce.Write(log.HexField("id", req.ID),
+		ce.Write(log.RequestIDField(req.ID),
 			zap.Uint64("to-shard", shard.ID),
 			zap.String("to-store", to),
 			log.RaftRequestField("request", &req))
@@ -228,7 +248,7 @@ func (p *shardsProxy) DispatchTo(req rpcpb.Request, shard Shard, store metapb.St
 	// the current request is designed to operate on multiple Keys
 	if req.KeysRange != nil && !keysRangeInShard(req.KeysRange, shard) {
 		if ce := p.logger.Check(zap.DebugLevel, "keys not in shard"); ce != nil {
-			ce.Write(log.HexField("id", req.ID),
+			ce.Write(log.RequestIDField(req.ID),
 				log.ShardField("shard", shard))
 		}
 		return ErrKeysNotInShard
@@ -242,6 +262,12 @@ func (p *shardsProxy) DispatchTo(req rpcpb.Request, shard Shard, store metapb.St
 	return p.forwardToBackend(req, to)
 }
 
+func (p *shardsProxy) InvalidateReadCache(changes ...KeyChange) {
+	if p.cfg.readCache != nil {
+		p.cfg.readCache.invalidate(changes...)
+	}
+}
+
 func (p *shardsProxy) Router() Router {
 	return p.cfg.router
 }
@@ -298,7 +324,10 @@ func (p *shardsProxy) addBackendLocked(addr string, bc backend) {
 }
 
 func (p *shardsProxy) onLocalResp(header rpcpb.ResponseBatchHeader, rsp rpcpb.Response) {
-	rsp.Error = header.Error
+	// the requests in a batch may fail with their own errors
+	if errorpb.HasError(header.Error) {
+		rsp.Error = header.Error
+	}
 	p.done(rsp)
 }
 
@@ -312,21 +341,47 @@ func (p *shardsProxy) done(rsp rpcpb.Response) {
 	}
 
 	if !errorpb.HasError(rsp.Error) {
+		if p.cfg.readCache != nil {
+			p.cfg.readCache.put(rsp)
+		}
 		p.cfg.successCallback(rsp)
 		return
 	}
 
 	if !errorpb.Retryable(rsp.Error) {
 		if rsp.Error.ShardUnavailable != nil {
-			p.cfg.failureCallback(rsp.ID, NewShardUnavailableErr(rsp.Error.ShardUnavailable.ShardID))
+			p.fail(rsp.ID, NewShardUnavailableErr(rsp.Error.ShardUnavailable.ShardID))
 			return
 		} else if rsp.Error.LeaseMismatch != nil {
-			p.cfg.failureCallback(rsp.ID, NewShardLeaseMismatchErr(rsp.Error.LeaseMismatch.ShardID,
+			p.fail(rsp.ID, NewShardLeaseMismatchErr(rsp.Error.LeaseMismatch.ShardID,
 				rsp.Error.LeaseMismatch.RequestLease,
 				rsp.Error.LeaseMismatch.ReplicaHeldLease))
 			return
+		} else if rsp.Error.ApplyLagTooLarge != nil {
+			p.fail(rsp.ID, NewApplyLagTooLargeErr(rsp.Error.ApplyLagTooLarge.ShardID,
+				rsp.Error.ApplyLagTooLarge.ApplyLag))
+			return
+		} else if rsp.Error.AppLeaseMismatch != nil {
+			p.fail(rsp.ID, NewAppLeaseMismatchErr(rsp.Error.AppLeaseMismatch.ShardID,
+				rsp.Error.AppLeaseMismatch.RequestToken,
+				rsp.Error.AppLeaseMismatch.CurrentLease))
+			return
+		} else if rsp.Error.GroupMismatch != nil {
+			p.fail(rsp.ID, NewGroupMismatchErr(rsp.Error.GroupMismatch.Key,
+				rsp.Error.GroupMismatch.Group,
+				rsp.Error.GroupMismatch.RoutedGroup))
+			return
+		} else if rsp.Error.QuorumLost != nil {
+			p.fail(rsp.ID, NewQuorumLostErr(rsp.Error.QuorumLost.ShardID))
+			return
+		} else if rsp.Error.DeadlineExceeded != nil {
+			p.fail(rsp.ID, NewDeadlineExceededErr(rsp.Error.DeadlineExceeded.ShardID))
+			return
+		} else if rsp.Error.StorageIOError != nil {
+			p.fail(rsp.ID, NewStorageIOErr(rsp.Error.StorageIOError.ShardID))
+			return
 		}
-		p.cfg.failureCallback(rsp.ID, errors.New(rsp.Error.String()))
+		p.fail(rsp.ID, errors.New(rsp.Error.String()))
 		return
 	}
 
@@ -334,6 +389,14 @@ func (p *shardsProxy) done(rsp rpcpb.Response) {
 	p.retryDispatch(rsp.ID, rsp.Error.String())
 }
 
+// fail stops the request with the error
+func (p *shardsProxy) fail(requestID []byte, err error) {
+	if p.cfg.readCache != nil {
+		p.cfg.readCache.untrack(requestID)
+	}
+	p.cfg.failureCallback(requestID, err)
+}
+
 func (p *shardsProxy) adjustRoute(err errorpb.Error) {
 	if err.NotLeader != nil {
 		p.cfg.router.UpdateLeader(err.NotLeader.ShardID, err.NotLeader.Leader.ID)
@@ -345,33 +408,33 @@ func (p *shardsProxy) adjustRoute(err errorpb.Error) {
 func (p *shardsProxy) retryDispatch(requestID []byte, err string) {
 	if p.cfg.retryController == nil {
 		if ce := p.logger.Check(zap.DebugLevel, "dispatch request failed with no retry"); ce != nil {
-			ce.Write(log.HexField("id", requestID),
+			ce.Write(log.RequestIDField(requestID),
 				log.ReasonField("retry controller not set"),
 				zap.String("cause", err))
 		}
-		p.cfg.failureCallback(requestID, errors.New(err))
+		p.fail(requestID, errors.New(err))
 		return
 	}
 
 	req, ok := p.cfg.retryController.Retry(requestID)
 	if !ok {
 		if ce := p.logger.Check(zap.DebugLevel, "dispatch request failed with no retry"); ce != nil {
-			ce.Write(log.HexField("id", requestID),
+			ce.Write(log.RequestIDField(requestID),
 				log.ReasonField("retry controller return false"),
 				zap.String("cause", err))
 		}
-		p.cfg.failureCallback(requestID, errors.New(err))
+		p.fail(requestID, errors.New(err))
 		return
 	}
 
 	// FIXME: more efficient retry mechanism
 	if ce := p.logger.Check(zap.DebugLevel, "dispatch request failed, retry later"); ce != nil {
-		ce.Write(log.HexField("id", req.ID),
+		ce.Write(log.RequestIDField(req.ID),
 			zap.String("cause", err))
 	}
 	if _, err := util.DefaultTimeoutWheel().Schedule(p.cfg.retryInterval, p.doRetry, req); err != nil {
 		p.logger.Error("fail to retry request",
-			log.HexField("id", req.ID))
+			log.RequestIDField(req.ID))
 	}
 
 }
@@ -380,20 +443,34 @@ func (p *shardsProxy) doRetry(arg interface{}) {
 	req := arg.(rpcpb.Request)
 	if req.ToShard == 0 {
 		if err := p.Dispatch(req); err != nil {
-			p.cfg.failureCallback(req.ID, err)
+			p.fail(req.ID, err)
 		}
 		return
 	}
 
-	store, lease := p.cfg.router.SelectReplicaStoreWithPolicy(req.ToShard, req.ReplicaSelectPolicy)
+	store, lease := p.cfg.router.SelectReplicaStoreWithPolicy(req.ToShard, getReplicaSelectPolicy(req))
 	if err := p.DispatchTo(req, p.cfg.router.GetShard(req.ToShard), store, lease); err != nil {
-		p.cfg.failureCallback(req.ID, err)
+		p.fail(req.ID, err)
+	}
+}
+
+// getReplicaSelectPolicy returns the policy used to select the replica store
+// of the request. The follower reads routed to the leader are spread over all
+// replicas, so the read heavy workloads can scale across the replicas.
+func getReplicaSelectPolicy(req rpcpb.Request) rpcpb.ReplicaSelectPolicy {
+	if req.FollowerRead &&
+		req.Type == rpcpb.Read &&
+		req.ReplicaSelectPolicy == rpcpb.SelectLeader {
+		return rpcpb.SelectRandom
 	}
+	return req.ReplicaSelectPolicy
 }
 
+// keysRangeInShard returns true if the keys range is in the shard, the empty
+// To of the keys range is unbounded, it's only in the last shard.
 func keysRangeInShard(keys *rpcpb.Range, shard Shard) bool {
 	return (len(shard.Start) == 0 || bytes.Compare(shard.Start, keys.From) <= 0) &&
-		(len(shard.End) == 0 || bytes.Compare(shard.End, keys.To) >= 0)
+		(len(shard.End) == 0 || (len(keys.To) > 0 && bytes.Compare(shard.End, keys.To) >= 0))
 }
 
 // NewMockShardsProxy returns mock shards proxy to handle request
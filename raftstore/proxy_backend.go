@@ -49,7 +49,7 @@ type defaultBackendFactory struct {
 
 func newBackendFactory(logger *zap.Logger, s *store) backendFactory {
 	v := &rpcCodec{clientSide: true}
-	encoder, decoder := length.NewWithSize(v, v, 0, 0, 0, int(s.cfg.Raft.MaxEntryBytes)*2)
+	encoder, decoder := length.NewWithSize(v, v, 0, 0, 0, int(s.cfg.Raft.GetMaxProposalBytes())*2)
 	return &defaultBackendFactory{
 		logger:  logger,
 		s:       s,
@@ -213,7 +213,7 @@ func (bc *remoteBackend) writeLoop(ctx context.Context) {
 				}
 
 				if ce := bc.logger.Check(zap.DebugLevel, "send request"); ce != nil {
-					ce.Write(log.HexField("id", items[i].(rpcpb.Request).ID))
+					ce.Write(log.RequestIDField(items[i].(rpcpb.Request).ID))
 				}
 				if err := bc.conn.Write(items[i]); err != nil {
 					bc.logger.Error("write request to remote failed",
@@ -246,7 +246,7 @@ func (bc *remoteBackend) readLoop(ctx context.Context) {
 
 			if rsp, ok := data.(rpcpb.Response); ok {
 				if ce := bc.logger.Check(zap.DebugLevel, "backend received response"); ce != nil {
-					ce.Write(log.HexField("id", rsp.ID),
+					ce.Write(log.RequestIDField(rsp.ID),
 						log.RaftResponseField("response", &rsp))
 				}
 				bc.successCallback(rsp)
@@ -0,0 +1,337 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+	"container/list"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/fagongzi/util/hack"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+var (
+	// readCachePendingTimeout the in-flight read is not tracked after the
+	// timeout, its response may be lost
+	readCachePendingTimeout = time.Minute
+)
+
+// KeyChange is a change of the key applied to a shard, e.g. a CDC event of the
+// cluster, which invalidates the cached reads of the key.
+type KeyChange struct {
+	ShardID uint64
+	// Index the raft log index of the change in the shard
+	Index uint64
+	Key   []byte
+	// End the keys in range [Key, End) are changed if it's set
+	End []byte
+}
+
+type readCacheKey struct {
+	shardID    uint64
+	customType uint64
+	key        string
+	cmd        string
+}
+
+type readCacheEntry struct {
+	key          readCacheKey
+	value        []byte
+	appliedIndex uint64
+	expireAt     time.Time
+}
+
+type pendingRead struct {
+	key   readCacheKey
+	since time.Time
+	// change the keys changed by the in-flight write, nil for the read
+	change *KeyChange
+	// toEnd the keys changed by the write are not bounded by change.End
+	toEnd bool
+}
+
+// readCache caches the responses of the idempotent reads in the proxy. An entry
+// is keyed by the shard and the read request, with the applied index at which
+// the read is served. A key change at index i invalidates the entries of the
+// key served before i, and the in-flight reads of the shard served before i
+// are not cached. The writes dispatched by the same proxy invalidate the keys
+// they change once they are done, so the reads after the write see it.
+type readCache struct {
+	sync.Mutex
+	maxEntries  int
+	ttl         time.Duration
+	customTypes map[uint64]struct{}
+	now         func() time.Time
+
+	lru     *list.List
+	entries map[readCacheKey]*list.Element
+	// keys shard id -> key -> the cached entries of the key
+	keys map[uint64]map[string]map[readCacheKey]struct{}
+	// watermarks shard id -> max index of the changes of the shard
+	watermarks map[uint64]uint64
+	// pending request id -> the in-flight cacheable read or write
+	pending map[string]pendingRead
+}
+
+func newReadCache(cfg config.ReadCacheConfig) *readCache {
+	c := &readCache{
+		maxEntries:  cfg.MaxEntries,
+		ttl:         cfg.TTL.Duration,
+		customTypes: make(map[uint64]struct{}, len(cfg.CustomTypes)),
+		now:         time.Now,
+		lru:         list.New(),
+		entries:     make(map[readCacheKey]*list.Element),
+		keys:        make(map[uint64]map[string]map[readCacheKey]struct{}),
+		watermarks:  make(map[uint64]uint64),
+		pending:     make(map[string]pendingRead),
+	}
+	for _, t := range cfg.CustomTypes {
+		c.customTypes[t] = struct{}{}
+	}
+	return c
+}
+
+func (c *readCache) cacheable(req rpcpb.Request) bool {
+	if req.Type != rpcpb.Read || req.KeysRange != nil {
+		return false
+	}
+	if len(c.customTypes) == 0 {
+		return true
+	}
+	_, ok := c.customTypes[req.CustomType]
+	return ok
+}
+
+// get returns the cached response of the read, or tracks the read to cache its
+// response if it's not cached.
+func (c *readCache) get(req rpcpb.Request, shard Shard) (rpcpb.Response, bool) {
+	if shard.ID == 0 || !c.cacheable(req) {
+		return rpcpb.Response{}, false
+	}
+
+	key := readCacheKey{
+		shardID:    shard.ID,
+		customType: req.CustomType,
+		key:        string(req.Key),
+		cmd:        string(req.Cmd),
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	now := c.now()
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*readCacheEntry)
+		if now.Before(entry.expireAt) {
+			c.lru.MoveToFront(elem)
+			metric.IncProxyReadCache("hit")
+			rsp := getResponse(req)
+			rsp.Value = append([]byte(nil), entry.value...)
+			rsp.AppliedIndex = entry.appliedIndex
+			return rsp, true
+		}
+		c.removeLocked(elem)
+	}
+
+	metric.IncProxyReadCache("miss")
+	c.trackLocked(string(req.ID), key, now)
+	return rpcpb.Response{}, false
+}
+
+func (c *readCache) trackLocked(id string, key readCacheKey, now time.Time) {
+	c.removeTimeoutPendingLocked(now)
+	if len(c.pending) >= c.maxEntries {
+		return
+	}
+	c.pending[id] = pendingRead{key: key, since: now}
+}
+
+func (c *readCache) removeTimeoutPendingLocked(now time.Time) {
+	if len(c.pending) < c.maxEntries {
+		return
+	}
+	for k, p := range c.pending {
+		if now.Sub(p.since) > readCachePendingTimeout {
+			delete(c.pending, k)
+		}
+	}
+}
+
+// trackWrite tracks the write dispatched to the shard, the cached reads of the
+// key of the write, or of its keys range, are invalidated once it's done. The
+// writes are always tracked, otherwise their changes are not visible to the
+// reads through the cache.
+func (c *readCache) trackWrite(req rpcpb.Request, shard Shard) {
+	if shard.ID == 0 || req.Type != rpcpb.Write {
+		return
+	}
+
+	p := pendingRead{change: &KeyChange{ShardID: shard.ID, Key: req.Key}}
+	if req.KeysRange != nil {
+		p.change.Key, p.change.End = req.KeysRange.From, req.KeysRange.To
+		p.toEnd = len(req.KeysRange.To) == 0
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	p.since = c.now()
+	c.removeTimeoutPendingLocked(p.since)
+	c.pending[string(req.ID)] = p
+}
+
+// put caches the response of the tracked read, or invalidates the keys changed
+// by the tracked write at the index the write is applied.
+func (c *readCache) put(rsp rpcpb.Response) {
+	c.Lock()
+	defer c.Unlock()
+
+	id := hack.SliceToString(rsp.ID)
+	p, ok := c.pending[id]
+	if !ok {
+		return
+	}
+	delete(c.pending, id)
+	if p.change != nil {
+		c.writeDoneLocked(p, rsp.AppliedIndex)
+		return
+	}
+
+	// the stale reads and the reads served before a change of the shard are
+	// not cached
+	if rsp.ApplyLag > 0 || rsp.AppliedIndex == 0 ||
+		rsp.AppliedIndex < c.watermarks[p.key.shardID] {
+		return
+	}
+
+	if elem, ok := c.entries[p.key]; ok {
+		c.removeLocked(elem)
+	}
+	entry := &readCacheEntry{
+		key:          p.key,
+		value:        append([]byte(nil), rsp.Value...),
+		appliedIndex: rsp.AppliedIndex,
+		expireAt:     c.now().Add(c.ttl),
+	}
+	c.entries[p.key] = c.lru.PushFront(entry)
+	keys, ok := c.keys[p.key.shardID]
+	if !ok {
+		keys = make(map[string]map[readCacheKey]struct{})
+		c.keys[p.key.shardID] = keys
+	}
+	if _, ok := keys[p.key.key]; !ok {
+		keys[p.key.key] = make(map[readCacheKey]struct{})
+	}
+	keys[p.key.key][p.key] = struct{}{}
+
+	for c.lru.Len() > c.maxEntries {
+		c.removeLocked(c.lru.Back())
+	}
+}
+
+// untrack stops tracking the failed request. The failed write may still be
+// applied, the cached reads of its keys are removed.
+func (c *readCache) untrack(id []byte) {
+	c.Lock()
+	defer c.Unlock()
+
+	p, ok := c.pending[hack.SliceToString(id)]
+	if !ok {
+		return
+	}
+	delete(c.pending, hack.SliceToString(id))
+	if p.change != nil {
+		c.writeDoneLocked(p, 0)
+	}
+}
+
+// writeDoneLocked invalidates the keys changed by the write applied at the
+// index, all the cached reads of the keys are removed if the index is unknown.
+func (c *readCache) writeDoneLocked(p pendingRead, index uint64) {
+	change := *p.change
+	change.Index = index
+	if index == 0 {
+		change.Index = math.MaxUint64
+	} else {
+		c.updateWatermarkLocked(change)
+	}
+	c.invalidateLocked(change, p.toEnd)
+}
+
+// invalidate removes the cached reads served before the changes
+func (c *readCache) invalidate(changes ...KeyChange) {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, change := range changes {
+		c.updateWatermarkLocked(change)
+		c.invalidateLocked(change, false)
+	}
+}
+
+func (c *readCache) updateWatermarkLocked(change KeyChange) {
+	if change.Index > c.watermarks[change.ShardID] {
+		c.watermarks[change.ShardID] = change.Index
+	}
+}
+
+// invalidateLocked removes the cached reads of the changed keys served before
+// the change, the keys in [Key, End) are changed if End is set, or all the keys
+// after Key if toEnd is set.
+func (c *readCache) invalidateLocked(change KeyChange, toEnd bool) {
+	keys := c.keys[change.ShardID]
+	if len(change.End) == 0 && !toEnd {
+		c.invalidateKeyLocked(keys[string(change.Key)], change.Index)
+		return
+	}
+	for key, entries := range keys {
+		if bytes.Compare([]byte(key), change.Key) >= 0 &&
+			(toEnd || bytes.Compare([]byte(key), change.End) < 0) {
+			c.invalidateKeyLocked(entries, change.Index)
+		}
+	}
+}
+
+func (c *readCache) invalidateKeyLocked(entries map[readCacheKey]struct{}, index uint64) {
+	for key := range entries {
+		elem := c.entries[key]
+		if elem.Value.(*readCacheEntry).appliedIndex < index {
+			c.removeLocked(elem)
+		}
+	}
+}
+
+func (c *readCache) removeLocked(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*readCacheEntry)
+	delete(c.entries, entry.key)
+	keys := c.keys[entry.key.shardID]
+	delete(keys[entry.key.key], entry.key)
+	if len(keys[entry.key.key]) == 0 {
+		delete(keys, entry.key.key)
+	}
+	if len(keys) == 0 {
+		delete(c.keys, entry.key.shardID)
+	}
+}
+
+func (c *readCache) len() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.lru.Len()
+}
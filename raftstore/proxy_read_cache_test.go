@@ -0,0 +1,227 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+func newTestReadCache(maxEntries int) *readCache {
+	return newReadCache(config.ReadCacheConfig{
+		Enable:     true,
+		MaxEntries: maxEntries,
+		TTL:        typeutil.NewDuration(time.Minute),
+	})
+}
+
+func newTestReadRequest(id string, key string) rpcpb.Request {
+	return rpcpb.Request{ID: []byte(id), Type: rpcpb.Read, Key: []byte(key), Cmd: []byte("get")}
+}
+
+// readThroughCache reads the key via the cache, the miss is served at the
+// applied index
+func readThroughCache(c *readCache, id string, key string, appliedIndex uint64) bool {
+	req := newTestReadRequest(id, key)
+	if _, ok := c.get(req, Shard{ID: 1}); ok {
+		return true
+	}
+	c.put(rpcpb.Response{ID: req.ID, Value: []byte(key), AppliedIndex: appliedIndex})
+	return false
+}
+
+func TestReadCache(t *testing.T) {
+	c := newTestReadCache(10)
+
+	assert.False(t, readThroughCache(c, "r1", "k1", 10))
+	rsp, ok := c.get(newTestReadRequest("r2", "k1"), Shard{ID: 1})
+	require.True(t, ok)
+	assert.Equal(t, []byte("r2"), rsp.ID)
+	assert.Equal(t, []byte("k1"), rsp.Value)
+	assert.Equal(t, uint64(10), rsp.AppliedIndex)
+
+	// other shard, writes and multi-key reads are not cached
+	_, ok = c.get(newTestReadRequest("r3", "k1"), Shard{ID: 2})
+	assert.False(t, ok)
+	_, ok = c.get(rpcpb.Request{ID: []byte("r4"), Type: rpcpb.Write, Key: []byte("k1")}, Shard{ID: 1})
+	assert.False(t, ok)
+	req := newTestReadRequest("r5", "k1")
+	req.KeysRange = &rpcpb.Range{From: []byte("k1"), To: []byte("k2")}
+	_, ok = c.get(req, Shard{ID: 1})
+	assert.False(t, ok)
+
+	// the stale reads are not cached
+	req = newTestReadRequest("r6", "k2")
+	_, ok = c.get(req, Shard{ID: 1})
+	assert.False(t, ok)
+	c.put(rpcpb.Response{ID: req.ID, AppliedIndex: 10, ApplyLag: 1})
+	assert.Equal(t, 1, c.len())
+
+	// the failed reads are not tracked
+	req = newTestReadRequest("r7", "k2")
+	_, ok = c.get(req, Shard{ID: 1})
+	assert.False(t, ok)
+	c.untrack(req.ID)
+	c.put(rpcpb.Response{ID: req.ID, AppliedIndex: 10})
+	assert.Equal(t, 1, c.len())
+}
+
+func TestReadCacheInvalidate(t *testing.T) {
+	c := newTestReadCache(10)
+	assert.False(t, readThroughCache(c, "r1", "k1", 10))
+	assert.False(t, readThroughCache(c, "r2", "k2", 10))
+	assert.False(t, readThroughCache(c, "r3", "k3", 20))
+
+	// the change is already visible to the cached read
+	c.invalidate(KeyChange{ShardID: 1, Index: 10, Key: []byte("k1")})
+	assert.True(t, readThroughCache(c, "r4", "k1", 10))
+
+	c.invalidate(KeyChange{ShardID: 1, Index: 11, Key: []byte("k1")})
+	assert.False(t, readThroughCache(c, "r5", "k1", 11))
+	assert.True(t, readThroughCache(c, "r6", "k2", 10))
+
+	// range change
+	c.invalidate(KeyChange{ShardID: 1, Index: 15, Key: []byte("k2"), End: []byte("k4")})
+	assert.False(t, readThroughCache(c, "r7", "k2", 15))
+	assert.True(t, readThroughCache(c, "r8", "k3", 20))
+
+	// the in-flight read served before the change is not cached
+	req := newTestReadRequest("r9", "k4")
+	_, ok := c.get(req, Shard{ID: 1})
+	assert.False(t, ok)
+	c.invalidate(KeyChange{ShardID: 1, Index: 30, Key: []byte("k5")})
+	c.put(rpcpb.Response{ID: req.ID, AppliedIndex: 29})
+	assert.False(t, readThroughCache(c, "r10", "k4", 30))
+	assert.True(t, readThroughCache(c, "r11", "k4", 30))
+}
+
+func TestReadCacheWrite(t *testing.T) {
+	c := newTestReadCache(10)
+	assert.False(t, readThroughCache(c, "r1", "k1", 10))
+	assert.False(t, readThroughCache(c, "r2", "k2", 10))
+	assert.False(t, readThroughCache(c, "r3", "k3", 10))
+
+	// the write of k1 is applied at the index 11, the read served before it is
+	// not cached
+	write := rpcpb.Request{ID: []byte("w1"), Type: rpcpb.Write, Key: []byte("k1")}
+	c.trackWrite(write, Shard{ID: 1})
+	req := newTestReadRequest("r4", "k4")
+	_, ok := c.get(req, Shard{ID: 1})
+	assert.False(t, ok)
+	c.put(rpcpb.Response{ID: write.ID, AppliedIndex: 11})
+	c.put(rpcpb.Response{ID: req.ID, AppliedIndex: 10})
+	assert.False(t, readThroughCache(c, "r5", "k1", 11))
+	assert.True(t, readThroughCache(c, "r6", "k1", 11))
+	assert.True(t, readThroughCache(c, "r7", "k2", 10))
+	assert.False(t, readThroughCache(c, "r8", "k4", 11))
+
+	// the write of the keys range without the end
+	write = rpcpb.Request{ID: []byte("w2"), Type: rpcpb.Write, Key: []byte("k2"),
+		KeysRange: &rpcpb.Range{From: []byte("k2")}}
+	c.trackWrite(write, Shard{ID: 1})
+	c.put(rpcpb.Response{ID: write.ID, AppliedIndex: 12})
+	assert.True(t, readThroughCache(c, "r9", "k1", 12))
+	assert.False(t, readThroughCache(c, "r10", "k2", 12))
+	assert.False(t, readThroughCache(c, "r11", "k3", 12))
+
+	// the failed write may be applied, the cached reads of its key are removed
+	write = rpcpb.Request{ID: []byte("w3"), Type: rpcpb.Write, Key: []byte("k1")}
+	c.trackWrite(write, Shard{ID: 1})
+	c.untrack(write.ID)
+	assert.False(t, readThroughCache(c, "r12", "k1", 12))
+	assert.True(t, readThroughCache(c, "r13", "k2", 12))
+}
+
+func TestReadCacheEviction(t *testing.T) {
+	c := newTestReadCache(2)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	assert.False(t, readThroughCache(c, "r1", "k1", 10))
+	assert.False(t, readThroughCache(c, "r2", "k2", 10))
+	assert.True(t, readThroughCache(c, "r3", "k1", 10))
+	// k2 is the least recently used
+	assert.False(t, readThroughCache(c, "r4", "k3", 10))
+	assert.Equal(t, 2, c.len())
+	assert.True(t, readThroughCache(c, "r5", "k1", 10))
+	assert.False(t, readThroughCache(c, "r6", "k2", 10))
+
+	// expired
+	now = now.Add(time.Minute)
+	assert.False(t, readThroughCache(c, "r7", "k1", 10))
+}
+
+func TestProxyReadCache(t *testing.T) {
+	sc := make(chan rpcpb.Response, 1)
+	success := func(r rpcpb.Response) { sc <- r }
+	failure := func(id []byte, e error) {}
+	factory := newTestBackendFactory()
+	rr, err := newRouterBuilder().build(make(chan rpcpb.EventNotify))
+	assert.NoError(t, err)
+	sp, err := newShardsProxyBuilder().
+		withBackendFactory(factory).
+		withRequestCallback(success, failure).
+		withReadCache(config.ReadCacheConfig{Enable: true, MaxEntries: 10, TTL: typeutil.NewDuration(time.Minute)}).
+		build(rr)
+	require.NoError(t, err)
+
+	dispatched := 0
+	value, index := []byte("v1"), uint64(10)
+	factory.backends["b1"] = newLocalBackend(func(r rpcpb.Request) error {
+		dispatched++
+		if r.Type == rpcpb.Write {
+			value = r.Cmd
+			index++
+		}
+		sp.OnResponse(rpcpb.ResponseBatch{Responses: []rpcpb.Response{{ID: r.ID, Value: value, AppliedIndex: index}}})
+		return nil
+	})
+	shard := Shard{ID: 1}
+	store := metapb.Store{ClientAddress: "b1"}
+	read := func(id string) rpcpb.Response {
+		require.NoError(t, sp.DispatchTo(newTestReadRequest(id, "k1"), shard, store, nil))
+		return <-sc
+	}
+
+	assert.Equal(t, []byte("v1"), read("r1").Value)
+	assert.Equal(t, 1, dispatched)
+	rsp := read("r2")
+	assert.Equal(t, []byte("r2"), rsp.ID)
+	assert.Equal(t, []byte("v1"), rsp.Value)
+	assert.Equal(t, 1, dispatched)
+
+	sp.InvalidateReadCache(KeyChange{ShardID: 1, Index: 10, Key: []byte("k1")})
+	read("r3")
+	assert.Equal(t, 1, dispatched)
+	sp.InvalidateReadCache(KeyChange{ShardID: 1, Index: 11, Key: []byte("k1")})
+	read("r4")
+	assert.Equal(t, 2, dispatched)
+
+	// read your writes through the proxy
+	require.NoError(t, sp.DispatchTo(rpcpb.Request{ID: []byte("w1"), Type: rpcpb.Write,
+		Key: []byte("k1"), Cmd: []byte("v2")}, shard, store, nil))
+	<-sc
+	assert.Equal(t, []byte("v2"), read("r5").Value)
+	assert.Equal(t, 4, dispatched)
+	assert.Equal(t, []byte("v2"), read("r6").Value)
+	assert.Equal(t, 4, dispatched)
+}
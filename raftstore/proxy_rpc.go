@@ -17,6 +17,7 @@ import (
 	"github.com/fagongzi/goetty"
 	"github.com/fagongzi/goetty/codec/length"
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"go.uber.org/zap"
 )
@@ -86,15 +87,17 @@ func (r *defaultRPC) onMessage(rs goetty.IOSession, value interface{}, seq uint6
 
 func (r *defaultRPC) onResponse(header rpcpb.ResponseBatchHeader, rsp rpcpb.Response) {
 	if rs, _ := r.app.GetSession(uint64(rsp.PID)); rs != nil {
-		rsp.Error = header.Error
+		if errorpb.HasError(header.Error) {
+			rsp.Error = header.Error
+		}
 		if ce := r.logger.Check(zap.DebugLevel, "rpcpb received response"); ce != nil {
-			ce.Write(log.HexField("id", rsp.ID),
+			ce.Write(log.RequestIDField(rsp.ID),
 				log.RaftResponseField("response", &rsp))
 		}
 		rs.WriteAndFlush(rsp)
 	} else {
 		if ce := r.logger.Check(zap.DebugLevel, "rpcpb received response skipped"); ce != nil {
-			ce.Write(log.HexField("id", rsp.ID),
+			ce.Write(log.RequestIDField(rsp.ID),
 				log.RaftResponseField("response", &rsp),
 				log.ReasonField("missing session"))
 		}
@@ -14,6 +14,8 @@
 package raftstore
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -22,6 +24,7 @@ import (
 	"github.com/fagongzi/goetty"
 	"github.com/fagongzi/goetty/codec/length"
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
@@ -133,6 +136,83 @@ func TestLocalDispatch(t *testing.T) {
 	}
 }
 
+func TestLocalDispatchWithRequestErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	sc := make(chan rpcpb.Response, 2)
+	fc := make(chan []byte, 2)
+	success := func(r rpcpb.Response) { sc <- r }
+	failure := func(id []byte, e error) { fc <- id }
+	factory := newTestBackendFactory()
+	rr, err := newRouterBuilder().build(make(chan rpcpb.EventNotify))
+	assert.NoError(t, err)
+	sp, err := newShardsProxyBuilder().
+		withBackendFactory(factory).
+		withRequestCallback(success, failure).
+		build(rr)
+	assert.NoError(t, err)
+
+	// only the second request in the batch failed
+	factory.backends["b1"] = newLocalBackend(func(r rpcpb.Request) error {
+		sp.OnResponse(rpcpb.ResponseBatch{Responses: []rpcpb.Response{
+			{ID: []byte("k1")},
+			{ID: []byte("k2"), Error: errorpb.Error{
+				Message:          "shard unavailable",
+				ShardUnavailable: &errorpb.ShardUnavailable{ShardID: 1},
+			}},
+		}})
+		return nil
+	})
+	assert.NoError(t, sp.DispatchTo(rpcpb.Request{ID: []byte("k1")}, Shard{}, metapb.Store{ClientAddress: "b1"}, nil))
+	select {
+	case rsp := <-sc:
+		assert.Equal(t, []byte("k1"), rsp.ID)
+	case <-time.After(time.Millisecond * 50):
+		assert.Fail(t, "need succ")
+	}
+	select {
+	case id := <-fc:
+		assert.Equal(t, []byte("k2"), id)
+	case <-time.After(time.Millisecond * 50):
+		assert.Fail(t, "need failure callback")
+	}
+}
+
+func TestDispatchWithDeadlineExceeded(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	fc := make(chan error, 2)
+	success := func(r rpcpb.Response) {}
+	failure := func(id []byte, e error) { fc <- e }
+	factory := newTestBackendFactory()
+	rr, err := newRouterBuilder().build(make(chan rpcpb.EventNotify))
+	assert.NoError(t, err)
+	sp, err := newShardsProxyBuilder().
+		withBackendFactory(factory).
+		withRequestCallback(success, failure).
+		build(rr)
+	assert.NoError(t, err)
+
+	// the request is dropped by the store
+	factory.backends["b1"] = newLocalBackend(func(r rpcpb.Request) error {
+		sp.OnResponse(rpcpb.ResponseBatch{Responses: []rpcpb.Response{
+			{ID: r.ID, Error: errorpb.Error{
+				Message:          errDeadlineExceeded.Error(),
+				DeadlineExceeded: &errorpb.DeadlineExceeded{ShardID: 1},
+			}},
+		}})
+		return nil
+	})
+	assert.NoError(t, sp.DispatchTo(rpcpb.Request{ID: []byte("k1")}, Shard{}, metapb.Store{ClientAddress: "b1"}, nil))
+	select {
+	case err := <-fc:
+		assert.Equal(t, NewDeadlineExceededErr(1), err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	case <-time.After(time.Millisecond * 50):
+		assert.Fail(t, "need failure callback")
+	}
+}
+
 func TestRPCDispatch(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -218,3 +298,39 @@ func TestRPCDispatch(t *testing.T) {
 		assert.Fail(t, "need succ")
 	}
 }
+
+func TestGetReplicaSelectPolicy(t *testing.T) {
+	tests := []struct {
+		req    rpcpb.Request
+		policy rpcpb.ReplicaSelectPolicy
+	}{
+		{rpcpb.Request{Type: rpcpb.Read}, rpcpb.SelectLeader},
+		{rpcpb.Request{Type: rpcpb.Read, FollowerRead: true}, rpcpb.SelectRandom},
+		{rpcpb.Request{Type: rpcpb.Read, FollowerRead: true, ReplicaSelectPolicy: rpcpb.SelectLeaseHolder}, rpcpb.SelectLeaseHolder},
+		{rpcpb.Request{Type: rpcpb.Write, FollowerRead: true}, rpcpb.SelectLeader},
+	}
+
+	for idx, tt := range tests {
+		assert.Equal(t, tt.policy, getReplicaSelectPolicy(tt.req), "index %d", idx)
+	}
+}
+
+func TestKeysRangeInShard(t *testing.T) {
+	tests := []struct {
+		keys  rpcpb.Range
+		shard Shard
+		ok    bool
+	}{
+		{keys: rpcpb.Range{From: []byte("b"), To: []byte("c")}, shard: Shard{}, ok: true},
+		{keys: rpcpb.Range{From: []byte("b"), To: []byte("c")}, shard: Shard{Start: []byte("b"), End: []byte("c")}, ok: true},
+		{keys: rpcpb.Range{From: []byte("a"), To: []byte("c")}, shard: Shard{Start: []byte("b"), End: []byte("c")}, ok: false},
+		{keys: rpcpb.Range{From: []byte("b"), To: []byte("d")}, shard: Shard{Start: []byte("b"), End: []byte("c")}, ok: false},
+		// the empty To is unbounded
+		{keys: rpcpb.Range{From: []byte("b")}, shard: Shard{Start: []byte("b"), End: []byte("c")}, ok: false},
+		{keys: rpcpb.Range{From: []byte("b")}, shard: Shard{Start: []byte("b")}, ok: true},
+	}
+
+	for idx, tt := range tests {
+		assert.Equal(t, tt.ok, keysRangeInShard(&tt.keys, tt.shard), "index %d", idx)
+	}
+}
@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
@@ -27,6 +28,9 @@ type requestExecutor func(req rpcpb.Request)
 type readyRead struct {
 	batch batch
 	index uint64
+	// start the time the read index request was sent, used to renew the leader
+	// lease once the read index is returned
+	start time.Time
 }
 
 type readIndexQueue struct {
@@ -35,6 +39,9 @@ type readIndexQueue struct {
 	reads        []readyRead
 	readyCount   int
 	lastReadyIdx int
+	// leaseReads the reads served within the leader lease, they are ready once
+	// appended and are kept apart from the reads waiting for the read index.
+	leaseReads []readyRead
 }
 
 func newReadIndexQueue(shardID uint64, logger *zap.Logger) *readIndexQueue {
@@ -48,12 +55,21 @@ func (q *readIndexQueue) reset() {
 	q.reads = q.reads[:0]
 	q.readyCount = 0
 	q.lastReadyIdx = 0
+	q.leaseReads = q.leaseReads[:0]
+}
+
+// size returns the number of the pending reads
+func (q *readIndexQueue) size() int {
+	return len(q.reads) + len(q.leaseReads)
 }
 
 func (q *readIndexQueue) close() {
 	for _, rr := range q.reads {
 		rr.batch.respShardNotFound(q.shardID)
 	}
+	for _, rr := range q.leaseReads {
+		rr.batch.respShardNotFound(q.shardID)
+	}
 	q.reset()
 }
 
@@ -61,16 +77,37 @@ func (q *readIndexQueue) leaderChanged(newLeader Replica) {
 	for _, rr := range q.reads {
 		rr.batch.respNotLeader(q.shardID, newLeader)
 	}
+	for _, rr := range q.leaseReads {
+		rr.batch.respNotLeader(q.shardID, newLeader)
+	}
 	q.reset()
 }
 
 func (q *readIndexQueue) append(c batch) {
+	q.appendWithStart(c, time.Time{})
+}
+
+// appendWithStart appends the read with the time its read index request was
+// sent, the time is returned by ready.
+func (q *readIndexQueue) appendWithStart(c batch, start time.Time) {
 	q.reads = append(q.reads, readyRead{
 		batch: c,
+		start: start,
 	})
 }
 
-func (q *readIndexQueue) ready(state raft.ReadState) {
+// appendLeaseRead appends the read served within the leader lease, it's
+// executed once the index is applied.
+func (q *readIndexQueue) appendLeaseRead(c batch, index uint64) {
+	q.leaseReads = append(q.leaseReads, readyRead{
+		batch: c,
+		index: index,
+	})
+}
+
+// ready marks the read of the read state as ready, and returns the time its
+// read index request was sent if the read is found and the time was recorded.
+func (q *readIndexQueue) ready(state raft.ReadState) (time.Time, bool) {
 	if ce := q.logger.Check(zap.DebugLevel, "read index ready"); ce != nil {
 		ce.Write(log.IndexField(state.Index),
 			log.HexField("batch-id", state.RequestCtx))
@@ -81,17 +118,19 @@ func (q *readIndexQueue) ready(state raft.ReadState) {
 			q.reads[idx].index = state.Index
 			q.readyCount++
 			q.lastReadyIdx = idx
-			return
+			start := q.reads[idx].start
+			return start, !start.IsZero()
 		}
 	}
+	return time.Time{}, false
 }
 
 func (q *readIndexQueue) process(appliedIndex uint64, exector requestExecutor) bool {
+	handled := q.processLeaseReads(appliedIndex, exector)
 	if len(q.reads) == 0 || q.readyCount == 0 {
-		return false
+		return handled
 	}
 
-	handled := false
 	newReads := q.reads[:0] // avoid alloc new slice
 	for idx := range q.reads {
 		if q.reads[idx].index > 0 && q.reads[idx].index <= appliedIndex {
@@ -112,6 +151,27 @@ func (q *readIndexQueue) process(appliedIndex uint64, exector requestExecutor) b
 	return handled
 }
 
+func (q *readIndexQueue) processLeaseReads(appliedIndex uint64, exector requestExecutor) bool {
+	if len(q.leaseReads) == 0 {
+		return false
+	}
+
+	handled := false
+	newReads := q.leaseReads[:0]
+	for idx := range q.leaseReads {
+		if q.leaseReads[idx].index <= appliedIndex {
+			handled = true
+			for _, req := range q.leaseReads[idx].batch.requestBatch.Requests {
+				exector(req)
+			}
+		} else {
+			newReads = append(newReads, q.leaseReads[idx])
+		}
+	}
+	q.leaseReads = newReads
+	return handled
+}
+
 func (q *readIndexQueue) removeLost() bool {
 	if q.readyCount == 0 ||
 		len(q.reads[:q.lastReadyIdx+1]) == q.readyCount {
@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"testing"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/stretchr/testify/assert"
@@ -201,3 +202,42 @@ func TestReadIndexQueueProcessWithReadyApplied(t *testing.T) {
 	assert.Equal(t, 1, q.readyCount)
 	assert.Equal(t, 0, q.lastReadyIdx)
 }
+
+func TestReadIndexQueueReadyWithStart(t *testing.T) {
+	q := newReadIndexQueue(1, nil)
+	start := time.Now()
+	q.append(newTestBatch("1", "k1", 1, rpcpb.Write, 0, nil))
+	q.appendWithStart(newTestBatch("2", "k2", 1, rpcpb.Write, 0, nil), start)
+
+	_, ok := q.ready(raft.ReadState{Index: 1, RequestCtx: q.reads[0].batch.getRequestID()})
+	assert.False(t, ok)
+	v, ok := q.ready(raft.ReadState{Index: 1, RequestCtx: q.reads[1].batch.getRequestID()})
+	assert.True(t, ok)
+	assert.Equal(t, start, v)
+	_, ok = q.ready(raft.ReadState{Index: 1, RequestCtx: []byte("3")})
+	assert.False(t, ok)
+}
+
+func TestReadIndexQueueProcessLeaseReads(t *testing.T) {
+	q := newReadIndexQueue(1, nil)
+	q.append(newTestBatch("1", "k1", 1, rpcpb.Write, 0, nil))
+	q.appendLeaseRead(newTestBatch("2", "k2", 1, rpcpb.Write, 0, nil), 1)
+	q.appendLeaseRead(newTestBatch("3", "k3", 1, rpcpb.Write, 0, nil), 3)
+
+	n := 0
+	assert.True(t, q.process(2, func(req rpcpb.Request) { n++ }))
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 1, len(q.leaseReads))
+	// the lease reads are not counted in the reads waiting for the read index
+	assert.Equal(t, 1, len(q.reads))
+	assert.Equal(t, 0, q.readyCount)
+	assert.False(t, q.removeLost())
+
+	assert.True(t, q.process(3, func(req rpcpb.Request) { n++ }))
+	assert.Equal(t, 2, n)
+	assert.Empty(t, q.leaseReads)
+
+	q.appendLeaseRead(newTestBatch("4", "k4", 1, rpcpb.Write, 0, nil), 4)
+	q.reset()
+	assert.Empty(t, q.leaseReads)
+}
@@ -73,6 +73,7 @@ type replica struct {
 	snapshotter          *snapshotter
 	incomingProposals    *proposalBatch
 	pendingReads         *readIndexQueue
+	leaderLease          leaderLease
 	pendingProposals     *pendingProposals
 	readStopper          *stop.Stopper
 	sm                   *stateMachine
@@ -101,10 +102,14 @@ type replica struct {
 	// pushedIndex is the log index that has been passed to the state machine to
 	// be applied
 	pushedIndex uint64
-	stats       *replicaStats
-	metrics     localMetrics
+	// commitTime the last commit time stamped by the leader, see stampCommitTime
+	commitTime commitTimeState
+	stats      *replicaStats
+	metrics    localMetrics
+	ttlGC      ttlGCState
 
 	limiter *ratelimit.Bucket
+	tracer  *requestTracer
 
 	initialized bool
 	closedC     chan struct{}
@@ -118,6 +123,9 @@ type replica struct {
 	committedIndexes map[uint64]uint64 // replica-id -> committed index(saved into logdb)
 	// lastCommittedIndex last committed log
 	lastCommittedIndex uint64
+	// snapshotGenerating the snapshot is being saved by the snapshotGenerator,
+	// it's only accessed by the event worker
+	snapshotGenerating bool
 
 	destroyTaskFactory destroyReplicaTaskFactory
 	destroyTaskMu      struct {
@@ -128,7 +136,24 @@ type replica struct {
 
 	tickTotalCount   uint64
 	tickHandledCount uint64
-	feature          storage.Feature
+	// applyBacklog the apply lag updated on every tick, it can be read from
+	// any goroutine
+	applyBacklog uint64
+	feature      storage.Feature
+	// lastHeartbeat the state sent by the last shard heartbeat
+	lastHeartbeat heartbeatDigest
+	// quorumLoss detects whether the shard has lost the write quorum
+	quorumLoss quorumLossDetector
+	// loadSplit records the load of the shard for the load based split
+	loadSplit loadSplitRecorder
+	// hibernate the hibernation state of the replica
+	hibernate hibernateState
+
+	heartbeatLatency heartbeatLatencyTracker
+	// commitDelay the time the single write batch not filled up waits for the
+	// following requests, chosen by the batch tuner
+	commitDelay        time.Duration
+	proposalsDelayedAt time.Time
 }
 
 // createReplica called in:
@@ -169,6 +194,7 @@ func newReplica(store *store, shard Shard, r Replica, reason string) (*replica,
 		pendingProposals:  newPendingProposals(),
 		incomingProposals: newProposalBatch(l, maxBatchSize, shard.ID, r),
 		pendingReads:      newReadIndexQueue(shard.ID, l),
+		leaderLease:       newLeaderLease(store.cfg.Raft.LeaderLeaseDuration.Duration),
 		snapshotter:       snapshotter,
 		ticks:             task.New(32),
 		messages:          task.New(32),
@@ -196,6 +222,15 @@ func newReplica(store *store, shard Shard, r Replica, reason string) (*replica,
 			return newReplicaCreator(store)
 		},
 		pr.store.aware)
+	pr.sm.tracer = store.tracer
+	pr.sm.splitAttributesFunc = store.cfg.Customize.CustomSplitShardAttributesFunc
+	pr.sm.ioError = store.cfg.IOError
+	pr.sm.onIOFailed = pr.handleIOFailed
+	pr.sm.onWriteRetry = pr.scheduleWriteRetry
+	pr.sm.throttleWrite = func(n int64) {
+		store.ioScheduler.waitWrite(pr.group, n)
+	}
+	pr.tracer = store.tracer
 	pr.destroyTaskFactory = newDefaultDestroyReplicaTaskFactory(pr.addAction,
 		pr.prophetClient, defaultCheckInterval)
 	pr.feature = storage.Feature()
@@ -227,7 +262,10 @@ func (pr *replica) start(campaign bool) {
 		pr.logger.Fatal("failed to initialize log state",
 			zap.Error(err))
 	}
-	c := getRaftConfig(pr.replicaID, pr.appliedIndex, pr.lr, &pr.cfg, pr.logger)
+	c := getRaftConfig(pr.replicaID, pr.appliedIndex, pr.getShard().Group, pr.lr, &pr.cfg, pr.logger)
+	if tuning, ok := pr.getBatchTuner().get(pr.group); ok {
+		c.MaxCommittedSizePerReady = tuning.applyBatchSize
+	}
 	rn, err := raft.NewRawNode(c)
 	if err != nil {
 		pr.logger.Fatal("fail to create raft node",
@@ -319,7 +357,14 @@ func (pr *replica) getLease() *EpochLease {
 }
 
 func (pr *replica) getPersistentLogIndex() (uint64, error) {
-	return pr.sm.dataStorage.GetPersistentLogIndex(pr.shardID)
+	index, err := pr.sm.dataStorage.GetPersistentLogIndex(pr.shardID)
+	if err != nil {
+		return 0, err
+	}
+	// the pushed persistent log indexes are incremental, the queried one is
+	// the base
+	pr.sm.updatePersistentLogIndex(index)
+	return index, nil
 }
 
 func (pr *replica) getFirstIndex() uint64 {
@@ -384,9 +429,11 @@ func (pr *replica) initConfState() error {
 	} else {
 		shard := pr.getShard()
 		for _, p := range shard.Replicas {
-			if p.Role == metapb.ReplicaRole_Voter {
+			if p.Role == metapb.ReplicaRole_Voter ||
+				p.Role == metapb.ReplicaRole_Witness {
 				confState.Voters = append(confState.Voters, p.ID)
-			} else if p.Role == metapb.ReplicaRole_Learner {
+			} else if p.Role == metapb.ReplicaRole_Learner ||
+				p.Role == metapb.ReplicaRole_Observer {
 				confState.Learners = append(confState.Learners, p.ID)
 			}
 		}
@@ -489,7 +536,7 @@ func (pr *replica) leaseReadReady() bool {
 
 func (pr *replica) notifyWorker() {
 	pr.waitStarted()
-	pr.store.workerPool.notify(pr.shardID)
+	pr.store.getWorkerPool(pr.group).notify(pr.shardID)
 }
 
 func (pr *replica) doCampaign() error {
@@ -497,7 +544,7 @@ func (pr *replica) doCampaign() error {
 }
 
 func (pr *replica) onReq(req rpcpb.Request, cb func(rpcpb.ResponseBatch)) error {
-	return pr.addRequest(newReqCtx(req, cb))
+	return pr.addRequest(newReqCtx(req, pr.store.now(), cb))
 }
 
 func (pr *replica) maybeExecRead() {
@@ -505,6 +552,13 @@ func (pr *replica) maybeExecRead() {
 }
 
 func (pr *replica) execReadRequest(req rpcpb.Request) {
+	pr.execReadRequestWithApplyLag(req, 0)
+}
+
+// execReadRequestWithApplyLag executes the read on the local state of the
+// replica, the applyLag is returned in the response if it's a stale read.
+func (pr *replica) execReadRequestWithApplyLag(req rpcpb.Request, applyLag uint64) {
+	start := time.Now()
 	// FIXME: use an externally passed context instead of `context.Background()` for future tracking.
 	err := pr.readStopper.RunTask(context.Background(), func(ctx context.Context) {
 		select {
@@ -516,13 +570,17 @@ func (pr *replica) execReadRequest(req rpcpb.Request) {
 					log.RaftRequestField("request", &req))
 			}
 
+			// the read result reflects the state at or after the applied index
+			appliedIndex, _ := pr.sm.getAppliedIndexTerm()
 			ctx := acquireReadCtx()
 			defer releaseReadCtx(ctx)
 
 			// FIXME: pr.getShard() has a lock, it's a hot path.
 			ctx.reset(pr.getShard(), storage.Request{
+				ID:      req.ID,
 				CmdType: req.CustomType,
 				Key:     req.Key,
+				CF:      req.CF,
 				Cmd:     req.Cmd,
 			})
 
@@ -532,16 +590,18 @@ func (pr *replica) execReadRequest(req rpcpb.Request) {
 				pr.logger.Fatal("fail to exec read batch",
 					zap.Error(err))
 			}
+			pr.store.ioScheduler.waitRead(pr.group, int64(ctx.readBytes))
 
 			pr.addAction(action{
 				actionType: updateReadMetrics,
 				readMetrics: readMetrics{
 					readBytes: ctx.readBytes,
 					readKeys:  1,
+					latency:   time.Since(start),
 				},
 			})
 
-			requestDone(req, pr.store.shardsProxy.OnResponse, v)
+			requestDoneWithReadState(req, pr.store.shardsProxy.OnResponse, v, applyLag, appliedIndex)
 		}
 	})
 	if err == stop.ErrUnavailable {
@@ -569,7 +629,7 @@ func (pr *replica) resetIncomingProposals() {
 }
 
 func (pr *replica) collectDownReplicas() []metapb.ReplicaStats {
-	now := time.Now()
+	now := pr.store.now()
 	shard := pr.getShard()
 	var downReplicas []metapb.ReplicaStats
 	for _, p := range shard.Replicas {
@@ -591,6 +651,27 @@ func (pr *replica) collectDownReplicas() []metapb.ReplicaStats {
 	return downReplicas
 }
 
+// isUnderReplicated returns true if the number of the voters which are not down
+// is less than the replica target.
+func (pr *replica) isUnderReplicated() bool {
+	down := make(map[uint64]struct{})
+	for _, r := range pr.collectDownReplicas() {
+		down[r.Replica.ID] = struct{}{}
+	}
+	voters := uint64(0)
+	for _, r := range pr.getShard().Replicas {
+		if r.Role != metapb.ReplicaRole_Voter &&
+			r.Role != metapb.ReplicaRole_IncomingVoter &&
+			r.Role != metapb.ReplicaRole_Witness {
+			continue
+		}
+		if _, ok := down[r.ID]; !ok {
+			voters++
+		}
+	}
+	return voters < pr.cfg.Prophet.Replication.MaxReplicas
+}
+
 // collectPendingReplicas returns a list of replicas that are potentially waiting for
 // snapshots from the leader.
 func (pr *replica) collectPendingReplicas() []Replica {
@@ -610,7 +691,8 @@ func (pr *replica) getTickHandledCount() uint64 {
 	return atomic.LoadUint64(&pr.tickHandledCount)
 }
 
-func getRaftConfig(id, appliedIndex uint64, lr *LogReader, cfg *config.Config, logger *zap.Logger) *raft.Config {
+func getRaftConfig(id, appliedIndex, group uint64, lr *LogReader, cfg *config.Config, logger *zap.Logger) *raft.Config {
+	preVote, checkQuorum := cfg.Raft.GetElectionOptions(group)
 	return &raft.Config{
 		ID:                        id,
 		Applied:                   appliedIndex,
@@ -618,9 +700,10 @@ func getRaftConfig(id, appliedIndex uint64, lr *LogReader, cfg *config.Config, l
 		HeartbeatTick:             cfg.Raft.HeartbeatTicks,
 		MaxSizePerMsg:             uint64(cfg.Raft.MaxSizePerMsg),
 		MaxInflightMsgs:           cfg.Raft.MaxInflightMsgs,
+		MaxCommittedSizePerReady:  cfg.GetMaxApplyBatchSize(group),
 		Storage:                   lr,
-		CheckQuorum:               true,
-		PreVote:                   true,
+		CheckQuorum:               checkQuorum,
+		PreVote:                   preVote,
 		DisableProposalForwarding: true,
 		Logger:                    &etcdRaftLoggerAdapter{logger: logger.Sugar()},
 	}
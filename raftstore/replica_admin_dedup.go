@@ -0,0 +1,112 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+// maxAppliedAdmins the max number of the applied admin requests kept in the
+// shard metadata, the oldest records are dropped once exceeded
+const maxAppliedAdmins = 128
+
+// isDedupAdminCmd returns true if the admin requests of the type are applied at
+// most once for the same request id and epoch. Only the admin requests saving
+// the shard metadata are deduplicated, so the record is always persisted with
+// the effect of the request.
+func isDedupAdminCmd(cmd rpcpb.InternalCmd) bool {
+	switch cmd {
+	case rpcpb.CmdConfigChange,
+		rpcpb.CmdBatchSplit,
+		rpcpb.CmdUpdateMetadata,
+		rpcpb.CmdUpdateLabels,
+		rpcpb.CmdUpdateGate,
+		rpcpb.CmdDeleteRange,
+		rpcpb.CmdPurge:
+		return true
+	}
+	return false
+}
+
+// newAppliedAdminRecord returns the record of the admin request applied at the
+// index, returns false if the request isn't deduplicated.
+func newAppliedAdminRecord(index uint64, req rpcpb.RequestBatch) (metapb.AppliedAdminRecord, bool) {
+	if !req.IsAdmin() || len(req.Requests) == 0 ||
+		len(req.Requests[0].ID) == 0 ||
+		!isDedupAdminCmd(req.GetAdminCmdType()) {
+		return metapb.AppliedAdminRecord{}, false
+	}
+	return metapb.AppliedAdminRecord{
+		RequestID: append([]byte(nil), req.Requests[0].ID...),
+		Epoch:     req.Requests[0].Epoch,
+		Index:     index,
+	}, true
+}
+
+// getAppliedAdmins returns the applied admin records, the returned slice is
+// never modified as the records are copied on append.
+func (d *stateMachine) getAppliedAdmins() []metapb.AppliedAdminRecord {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	return d.metadataMu.appliedAdmins
+}
+
+func (d *stateMachine) updateAppliedAdmins(records []metapb.AppliedAdminRecord) {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	d.metadataMu.appliedAdmins = records
+}
+
+// isAdminApplied returns true if the admin request of the same id and epoch has
+// been applied, e.g. the request re-proposed by the client after the leader
+// changed while the original proposal was committed.
+func (d *stateMachine) isAdminApplied(record metapb.AppliedAdminRecord) bool {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	for _, r := range d.metadataMu.appliedAdmins {
+		if r.Epoch.ConfigVer == record.Epoch.ConfigVer &&
+			r.Epoch.Generation == record.Epoch.Generation &&
+			bytes.Equal(r.RequestID, record.RequestID) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendAppliedAdmin appends the record of the admin request being applied, it's
+// persisted with the shard metadata saved by the request.
+func (d *stateMachine) appendAppliedAdmin(record metapb.AppliedAdminRecord) {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	records := d.metadataMu.appliedAdmins
+	records = append(records[:len(records):len(records)], record)
+	if len(records) > maxAppliedAdmins {
+		records = records[len(records)-maxAppliedAdmins:]
+	}
+	d.metadataMu.appliedAdmins = records
+}
+
+// removeAppliedAdmin removes the record appended for the admin request applied
+// at the index, it's called if the request is rejected or aborted.
+func (d *stateMachine) removeAppliedAdmin(index uint64) {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	records := d.metadataMu.appliedAdmins
+	if n := len(records); n > 0 && records[n-1].Index == index {
+		d.metadataMu.appliedAdmins = records[: n-1 : n-1]
+	}
+}
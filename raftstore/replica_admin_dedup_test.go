@@ -0,0 +1,113 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func TestNewAppliedAdminRecord(t *testing.T) {
+	batch := newTestAdminRequestBatch("r1", 0, rpcpb.CmdConfigChange, nil)
+	batch.Requests[0].Epoch = metapb.ShardEpoch{ConfigVer: 1, Generation: 2}
+	record, ok := newAppliedAdminRecord(10, batch)
+	assert.True(t, ok)
+	assert.Equal(t, metapb.AppliedAdminRecord{
+		RequestID: []byte("r1"),
+		Epoch:     metapb.ShardEpoch{ConfigVer: 1, Generation: 2},
+		Index:     10,
+	}, record)
+
+	_, ok = newAppliedAdminRecord(10, newTestAdminRequestBatch("r1", 0, rpcpb.CmdCompactLog, nil))
+	assert.False(t, ok, "not deduplicated")
+	_, ok = newAppliedAdminRecord(10, newTestAdminRequestBatch("", 0, rpcpb.CmdConfigChange, nil))
+	assert.False(t, ok, "without request id")
+}
+
+func TestStateMachineAppendAppliedAdmin(t *testing.T) {
+	f := func(sm *stateMachine) {
+		assert.Empty(t, sm.getAppliedAdmins())
+		sm.appendAppliedAdmin(metapb.AppliedAdminRecord{RequestID: []byte("r1"), Index: 1})
+		old := sm.getAppliedAdmins()
+		for i := uint64(2); i <= maxAppliedAdmins+10; i++ {
+			sm.appendAppliedAdmin(metapb.AppliedAdminRecord{RequestID: []byte("r"), Index: i})
+		}
+
+		// the returned records are not modified by the later admin requests
+		assert.Equal(t, []metapb.AppliedAdminRecord{{RequestID: []byte("r1"), Index: 1}}, old)
+		records := sm.getAppliedAdmins()
+		require.Equal(t, maxAppliedAdmins, len(records))
+		assert.Equal(t, uint64(11), records[0].Index)
+		assert.False(t, sm.isAdminApplied(metapb.AppliedAdminRecord{RequestID: []byte("r1")}))
+		assert.True(t, sm.isAdminApplied(metapb.AppliedAdminRecord{RequestID: []byte("r")}))
+		assert.False(t, sm.isAdminApplied(metapb.AppliedAdminRecord{RequestID: []byte("r"),
+			Epoch: metapb.ShardEpoch{ConfigVer: 1}}))
+
+		sm.removeAppliedAdmin(1)
+		assert.Equal(t, maxAppliedAdmins, len(sm.getAppliedAdmins()))
+		sm.removeAppliedAdmin(maxAppliedAdmins + 10)
+		assert.Equal(t, maxAppliedAdmins-1, len(sm.getAppliedAdmins()))
+	}
+	runSimpleStateMachineTest(t, f, nil)
+}
+
+func TestStateMachineSkipsReproposedAdminRequest(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		sm.updateShard(Shard{ID: 100})
+		apply := func(index uint64, id string) {
+			// the re-proposed request has a new batch id
+			batch := newTestAdminRequestBatch(id, 0, rpcpb.CmdPurge, protoc.MustMarshal(&rpcpb.PurgeRequest{
+				Keys: [][]byte{[]byte("a")},
+			}))
+			batch.Header.ShardID = 100
+			sm.applyCommittedEntries([]raftpb.Entry{{
+				Index: index,
+				Term:  1,
+				Type:  raftpb.EntryNormal,
+				Data:  protoc.MustMarshal(&batch),
+			}})
+		}
+
+		apply(1, "p1")
+		assert.True(t, h.resp.GetPurgeResponse().Purged)
+		require.Equal(t, 1, len(sm.getPurgeMarkers()))
+
+		apply(2, "p1")
+		assert.NotNil(t, h.resp.Header.Error.StaleCommand)
+		assert.Equal(t, 1, len(sm.getPurgeMarkers()))
+		index, _ := sm.getAppliedIndexTerm()
+		assert.Equal(t, uint64(2), index)
+
+		apply(3, "p2")
+		assert.True(t, h.resp.GetPurgeResponse().Purged)
+		assert.Equal(t, 2, len(sm.getPurgeMarkers()))
+
+		// persisted with the shard metadata
+		states, err := sm.dataStorage.GetInitialStates()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(states))
+		assert.Equal(t, []metapb.AppliedAdminRecord{
+			{RequestID: []byte("p1"), Index: 1},
+			{RequestID: []byte("p2"), Index: 3},
+		}, states[0].Metadata.AppliedAdmins)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+// acquireAppLease returns the application lease after the acquire request is
+// applied, and false if the lease is held by another holder. The token is
+// increased when the lease is granted to a new holder or the previous lease
+// was expired, so the writes of the previous holder are fenced.
+func acquireAppLease(current metapb.AppLease, req rpcpb.AcquireAppLeaseRequest) (metapb.AppLease, bool) {
+	expired := req.Now >= current.ExpireAt
+	if current.Holder != "" && current.Holder != req.Holder && !expired {
+		return current, false
+	}
+
+	lease := metapb.AppLease{
+		Holder:   req.Holder,
+		Token:    current.Token,
+		ExpireAt: req.Now + req.TTL,
+	}
+	if current.Holder != req.Holder || expired {
+		lease.Token++
+	}
+	return lease, true
+}
+
+// releaseAppLease returns the application lease after the release request is
+// applied, and false if the token is not the token of the current lease. The
+// token is kept to make sure the tokens of the later leases are increasing.
+func releaseAppLease(current metapb.AppLease, req rpcpb.ReleaseAppLeaseRequest) (metapb.AppLease, bool) {
+	if current.Holder == "" || current.Token != req.Token {
+		return current, false
+	}
+	return metapb.AppLease{Token: current.Token}, true
+}
+
+// checkAppLease returns the error if the write is tagged with a token which is
+// not the token of the current application lease. The expiration is not
+// checked here, the writes of an expired lease are rejected once the lease is
+// granted to a new holder.
+func checkAppLease(req rpcpb.Request, shard Shard) *errorpb.Error {
+	if req.AppLeaseToken == 0 {
+		return nil
+	}
+	lease := shard.AppLease
+	if lease.Holder != "" && lease.Token == req.AppLeaseToken {
+		return nil
+	}
+	return &errorpb.Error{
+		Message: errAppLeaseMismatch.Error(),
+		AppLeaseMismatch: &errorpb.AppLeaseMismatch{
+			ShardID:      shard.ID,
+			RequestToken: req.AppLeaseToken,
+			CurrentLease: lease,
+		},
+	}
+}
+
+// stampAppLeaseRequest sets the leader's clock to the acquire request, so all
+// replicas decide the expiration of the lease with the same time.
+func stampAppLeaseRequest(req *rpcpb.RequestBatch, now time.Time) {
+	if !req.IsAdmin() ||
+		req.GetAdminCmdType() != rpcpb.CmdAcquireAppLease {
+		return
+	}
+
+	acquire := req.GetAcquireAppLeaseRequest()
+	acquire.Now = now.UnixNano()
+	req.Requests[0].Cmd = protoc.MustMarshal(&acquire)
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+func TestAcquireAppLease(t *testing.T) {
+	lease, ok := acquireAppLease(metapb.AppLease{}, rpcpb.AcquireAppLeaseRequest{Holder: "a", TTL: 10, Now: 100})
+	assert.True(t, ok)
+	assert.Equal(t, metapb.AppLease{Holder: "a", Token: 1, ExpireAt: 110}, lease)
+
+	// held by another holder
+	current, ok := acquireAppLease(lease, rpcpb.AcquireAppLeaseRequest{Holder: "b", TTL: 10, Now: 105})
+	assert.False(t, ok)
+	assert.Equal(t, lease, current)
+
+	// renew keeps the token
+	lease, ok = acquireAppLease(lease, rpcpb.AcquireAppLeaseRequest{Holder: "a", TTL: 10, Now: 105})
+	assert.True(t, ok)
+	assert.Equal(t, metapb.AppLease{Holder: "a", Token: 1, ExpireAt: 115}, lease)
+
+	// expired
+	lease, ok = acquireAppLease(lease, rpcpb.AcquireAppLeaseRequest{Holder: "b", TTL: 10, Now: 115})
+	assert.True(t, ok)
+	assert.Equal(t, metapb.AppLease{Holder: "b", Token: 2, ExpireAt: 125}, lease)
+
+	// renew after expired fences the writes of the previous term
+	lease, ok = acquireAppLease(lease, rpcpb.AcquireAppLeaseRequest{Holder: "b", TTL: 10, Now: 130})
+	assert.True(t, ok)
+	assert.Equal(t, metapb.AppLease{Holder: "b", Token: 3, ExpireAt: 140}, lease)
+}
+
+func TestReleaseAppLease(t *testing.T) {
+	current := metapb.AppLease{Holder: "a", Token: 2, ExpireAt: 110}
+	lease, ok := releaseAppLease(current, rpcpb.ReleaseAppLeaseRequest{Token: 1})
+	assert.False(t, ok)
+	assert.Equal(t, current, lease)
+
+	lease, ok = releaseAppLease(current, rpcpb.ReleaseAppLeaseRequest{Token: 2})
+	assert.True(t, ok)
+	assert.Equal(t, metapb.AppLease{Token: 2}, lease)
+
+	_, ok = releaseAppLease(lease, rpcpb.ReleaseAppLeaseRequest{Token: 2})
+	assert.False(t, ok)
+
+	// the new holder gets a greater token
+	lease, ok = acquireAppLease(lease, rpcpb.AcquireAppLeaseRequest{Holder: "b", TTL: 10, Now: 100})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), lease.Token)
+}
+
+func TestCheckAppLease(t *testing.T) {
+	shard := Shard{ID: 1, AppLease: metapb.AppLease{Holder: "a", Token: 2}}
+	assert.Nil(t, checkAppLease(rpcpb.Request{}, shard))
+	assert.Nil(t, checkAppLease(rpcpb.Request{AppLeaseToken: 2}, shard))
+
+	err := checkAppLease(rpcpb.Request{AppLeaseToken: 1}, shard)
+	assert.NotNil(t, err)
+	assert.Equal(t, &errorpb.AppLeaseMismatch{ShardID: 1, RequestToken: 1, CurrentLease: shard.AppLease}, err.AppLeaseMismatch)
+
+	// released
+	shard.AppLease = metapb.AppLease{Token: 2}
+	assert.NotNil(t, checkAppLease(rpcpb.Request{AppLeaseToken: 2}, shard))
+}
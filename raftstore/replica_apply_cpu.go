@@ -0,0 +1,180 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/metric"
+)
+
+const (
+	// applyCPUMetricTopN the number of the shards exposed by the apply cpu metric
+	applyCPUMetricTopN = 10
+)
+
+// applyCPUStats accounts the time spent by the apply loop of a replica. The
+// counters are only increased by the apply loop, and can be read from any
+// goroutine.
+type applyCPUStats struct {
+	// write the nanoseconds spent in DataStorage.Write, including the
+	// executors of the custom write requests
+	write int64
+	// handler the nanoseconds spent in the handlers of the transaction and
+	// admin requests
+	handler int64
+	entries uint64
+}
+
+func (s *applyCPUStats) addWrite(start time.Time) {
+	atomic.AddInt64(&s.write, int64(time.Since(start)))
+}
+
+func (s *applyCPUStats) addHandler(start time.Time) {
+	atomic.AddInt64(&s.handler, int64(time.Since(start)))
+}
+
+func (s *applyCPUStats) addEntries(n int) {
+	atomic.AddUint64(&s.entries, uint64(n))
+}
+
+func (s *applyCPUStats) load(shardID, group uint64) ShardApplyCPU {
+	return ShardApplyCPU{
+		ShardID: shardID,
+		Group:   group,
+		Write:   time.Duration(atomic.LoadInt64(&s.write)),
+		Handler: time.Duration(atomic.LoadInt64(&s.handler)),
+		Entries: atomic.LoadUint64(&s.entries),
+	}
+}
+
+// ShardApplyCPU is the time spent by the apply loop of a local replica
+type ShardApplyCPU struct {
+	ShardID uint64 `json:"shard-id"`
+	Group   uint64 `json:"group"`
+	// Write the time spent in DataStorage.Write
+	Write time.Duration `json:"write"`
+	// Handler the time spent in the handlers of the transaction and admin
+	// requests
+	Handler time.Duration `json:"handler"`
+	// Entries the number of the applied entries
+	Entries uint64 `json:"entries"`
+}
+
+// Total returns the total time spent by the apply loop
+func (c ShardApplyCPU) Total() time.Duration {
+	return c.Write + c.Handler
+}
+
+func (c ShardApplyCPU) sub(last ShardApplyCPU) ShardApplyCPU {
+	c.Write -= last.Write
+	c.Handler -= last.Handler
+	c.Entries -= last.Entries
+	return c
+}
+
+// applyCPUSampler samples the apply time of all local replicas periodically,
+// and keeps the apply time of the replicas in the last sampling window, so the
+// shards consuming the apply capacity now are reported instead of the long
+// living ones.
+type applyCPUSampler struct {
+	sync.Mutex
+	last   map[uint64]ShardApplyCPU
+	recent []ShardApplyCPU
+}
+
+func newApplyCPUSampler() *applyCPUSampler {
+	return &applyCPUSampler{last: make(map[uint64]ShardApplyCPU)}
+}
+
+// sample updates the recent apply time by the current cumulative apply time of
+// the replicas, the replicas without applied entries in the window are skipped.
+func (s *applyCPUSampler) sample(current []ShardApplyCPU) {
+	last := make(map[uint64]ShardApplyCPU, len(current))
+	var recent []ShardApplyCPU
+	s.Lock()
+	defer s.Unlock()
+	for _, c := range current {
+		last[c.ShardID] = c
+		delta := c
+		if v, ok := s.last[c.ShardID]; ok {
+			delta = c.sub(v)
+		}
+		if delta.Entries > 0 || delta.Total() > 0 {
+			recent = append(recent, delta)
+		}
+	}
+	sort.Slice(recent, func(i, j int) bool {
+		if recent[i].Total() == recent[j].Total() {
+			return recent[i].ShardID < recent[j].ShardID
+		}
+		return recent[i].Total() > recent[j].Total()
+	})
+	s.last = last
+	s.recent = recent
+}
+
+// top returns the top n shards ordered by the apply time in the last window
+func (s *applyCPUSampler) top(n int) []ShardApplyCPU {
+	s.Lock()
+	defer s.Unlock()
+	if n <= 0 || n > len(s.recent) {
+		n = len(s.recent)
+	}
+	return append([]ShardApplyCPU(nil), s.recent[:n]...)
+}
+
+// avgEntryLatency returns the average time spent per applied entry in the last
+// window
+func (s *applyCPUSampler) avgEntryLatency() time.Duration {
+	s.Lock()
+	defer s.Unlock()
+	var total time.Duration
+	var entries uint64
+	for _, c := range s.recent {
+		total += c.Total()
+		entries += c.Entries
+	}
+	if entries == 0 {
+		return 0
+	}
+	return total / time.Duration(entries)
+}
+
+func (s *store) handleApplyCPUSampleTask() {
+	var current []ShardApplyCPU
+	s.forEachReplica(func(pr *replica) bool {
+		if pr.sm != nil {
+			current = append(current, pr.sm.applyCPU.load(pr.shardID, pr.group))
+		}
+		return true
+	})
+	s.applyCPUSampler.sample(current)
+
+	top := s.applyCPUSampler.top(applyCPUMetricTopN)
+	seconds := make(map[uint64]float64, len(top))
+	for _, c := range top {
+		seconds[c.ShardID] = c.Total().Seconds()
+	}
+	metric.SetTopApplyCPUShards(seconds)
+}
+
+// GetTopApplyCPUShards returns the top n local replicas ordered by the time
+// spent by their apply loops in the last sampling window
+func (s *store) GetTopApplyCPUShards(n int) []ShardApplyCPU {
+	return s.applyCPUSampler.top(n)
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestApplyCPUSampler(t *testing.T) {
+	s := newApplyCPUSampler()
+	assert.Empty(t, s.top(0))
+
+	s.sample([]ShardApplyCPU{
+		{ShardID: 1, Write: 10, Entries: 1},
+		{ShardID: 2, Write: 20, Handler: 10, Entries: 2},
+		{ShardID: 3},
+	})
+	assert.Equal(t, []ShardApplyCPU{
+		{ShardID: 2, Write: 20, Handler: 10, Entries: 2},
+		{ShardID: 1, Write: 10, Entries: 1},
+	}, s.top(0))
+
+	// only the apply time in the last window is reported
+	s.sample([]ShardApplyCPU{
+		{ShardID: 1, Write: 110, Entries: 3},
+		{ShardID: 2, Write: 20, Handler: 10, Entries: 2},
+		{ShardID: 3, Handler: 50, Entries: 1},
+		{ShardID: 4, Write: 5, Entries: 1},
+	})
+	assert.Equal(t, []ShardApplyCPU{
+		{ShardID: 1, Write: 100, Entries: 2},
+		{ShardID: 3, Handler: 50, Entries: 1},
+	}, s.top(2))
+	assert.Equal(t, 3, len(s.top(10)))
+	assert.Equal(t, time.Duration(100), s.top(1)[0].Total())
+	// (100 + 50 + 5) / 4
+	assert.Equal(t, time.Duration(38), s.avgEntryLatency())
+
+	s.sample(nil)
+	assert.Equal(t, time.Duration(0), s.avgEntryLatency())
+}
+
+func TestGetTopApplyCPUShards(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	s := c.GetStore(0).(*store)
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, kv.Set(fmt.Sprintf("k%d", i), "v", testWaitTimeout))
+	}
+
+	shard := c.GetShardByIndex(0, 0)
+	pr := s.getReplica(shard.ID, false)
+	require.NotNil(t, pr)
+	stats := pr.sm.applyCPU.load(pr.shardID, pr.group)
+	assert.True(t, stats.Entries >= 10)
+	assert.True(t, stats.Write > 0)
+
+	// the shard is reported in the first window
+	s.applyCPUSampler.sample(nil)
+	s.handleApplyCPUSampleTask()
+	top := s.GetTopApplyCPUShards(1)
+	require.Equal(t, 1, len(top))
+	assert.Equal(t, shard.ID, top[0].ShardID)
+	assert.True(t, top[0].Write > 0)
+
+	w := httptest.NewRecorder()
+	s.debugHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/apply-cpu?top=x", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = httptest.NewRecorder()
+	s.debugHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/apply-cpu?top=1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var values []ShardApplyCPU
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &values))
+	assert.True(t, len(values) <= 1)
+}
@@ -15,8 +15,8 @@ package raftstore
 
 import (
 	"fmt"
-	"time"
 
+	"github.com/matrixorigin/matrixcube/aware"
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
@@ -30,6 +30,7 @@ type applyResult struct {
 	adminResult   *adminResult
 	ignoreMetrics bool
 	metrics       applyMetrics
+	commitTime    int64
 }
 
 func (res *applyResult) hasSplitResult() bool {
@@ -44,11 +45,24 @@ type adminResult struct {
 	compactionResult     compactionResult
 	updateMetadataResult updateMetadataResult
 	updateLabelsResult   updateLabelsResult
+	deleteRangeResult    deleteRangeResult
+	purgeResult          purgeResult
 }
 
 type updateLabelsResult struct {
 }
 
+type deleteRangeResult struct {
+	deleted bool
+	// emptied the whole range of the shard is deleted
+	emptied bool
+}
+
+type purgeResult struct {
+	purged bool
+	marker metapb.PurgeMarker
+}
+
 type updateMetadataResult struct {
 	changes []raftpb.ConfChangeV2
 }
@@ -63,6 +77,9 @@ type configChangeResult struct {
 type splitResult struct {
 	newShards []Shard
 	newLeases []*metapb.EpochLease
+	newPurges [][]metapb.PurgeMarker
+	// dictionaries the compression dictionaries of the new shards
+	dictionaries []metapb.CompressionDictionary
 }
 
 type compactionResult struct {
@@ -95,13 +112,30 @@ func (pr *replica) updateMetricsHints(result applyResult) {
 
 	pr.stats.writtenBytes += result.metrics.writtenBytes
 	pr.stats.writtenKeys += result.metrics.writtenKeys
+	if result.metrics.writtenKeys > 0 {
+		pr.ttlGC.onWrite(pr.store.now())
+	}
 	if result.hasSplitResult() {
 		pr.stats.deleteKeysHint = result.metrics.deleteKeysHint
-		pr.stats.approximateSize = result.metrics.approximateDiffHint
+		pr.stats.approximateSize = addSizeDiff(0, result.metrics.approximateDiffHint)
 	} else {
 		pr.stats.deleteKeysHint += result.metrics.deleteKeysHint
-		pr.stats.approximateSize += result.metrics.approximateDiffHint
+		pr.stats.approximateSize = addSizeDiff(pr.stats.approximateSize,
+			result.metrics.approximateDiffHint)
+	}
+}
+
+// addSizeDiff applies the diff to the approximate size. The deleted and the
+// expired bytes make the diff negative, so that the shards which have mostly
+// expired shrink and can be merged.
+func addSizeDiff(size uint64, diff int64) uint64 {
+	if diff >= 0 {
+		return size + uint64(diff)
+	}
+	if v := uint64(-diff); v < size {
+		return size - v
 	}
+	return 0
 }
 
 func (pr *replica) handleAdminResult(result applyResult) {
@@ -116,7 +150,60 @@ func (pr *replica) handleAdminResult(result applyResult) {
 		pr.applyUpdateMetadataResult(result.adminResult.updateMetadataResult)
 	case rpcpb.CmdUpdateLabels:
 		pr.applyUpdateLabels(result.adminResult.updateLabelsResult)
+	case rpcpb.CmdUpdateGate:
+		pr.applyUpdateGate()
+	case rpcpb.CmdAcquireAppLease, rpcpb.CmdReleaseAppLease:
+		pr.applyUpdateAppLease()
+	case rpcpb.CmdDeleteRange:
+		pr.applyDeleteRange(result.adminResult.deleteRangeResult)
 	}
+	pr.notifyAdminResult(result)
+}
+
+// notifyAdminResult notifies the embedders the admin command applied
+func (pr *replica) notifyAdminResult(result applyResult) {
+	if pr.store.adminAware == nil {
+		return
+	}
+
+	ar := result.adminResult
+	value := aware.AdminResult{
+		Shard:      pr.getShard(),
+		Index:      result.index,
+		CommitTime: result.commitTime,
+	}
+	switch ar.adminType {
+	case rpcpb.CmdConfigChange:
+		if ar.configChangeResult.index == 0 {
+			return
+		}
+		value.Type = aware.AdminConfigChange
+		value.ConfigChanges = ar.configChangeResult.changes
+	case rpcpb.CmdBatchSplit:
+		value.Type = aware.AdminSplit
+		value.NewShards = ar.splitResult.newShards
+	case rpcpb.CmdCompactLog:
+		value.Type = aware.AdminCompactLog
+		value.CompactIndex = ar.compactionResult.index
+	case rpcpb.CmdUpdateMetadata:
+		value.Type = aware.AdminUpdateMetadata
+	case rpcpb.CmdUpdateLabels:
+		value.Type = aware.AdminUpdateLabels
+	case rpcpb.CmdUpdateGate:
+		value.Type = aware.AdminUpdateGate
+	case rpcpb.CmdAcquireAppLease, rpcpb.CmdReleaseAppLease:
+		value.Type = aware.AdminUpdateAppLease
+	case rpcpb.CmdPurge:
+		if !ar.purgeResult.purged {
+			return
+		}
+		value.Type = aware.AdminPurge
+		marker := ar.purgeResult.marker
+		value.Purge = &marker
+	default:
+		return
+	}
+	pr.store.adminAware.AdminApplied(value)
 }
 
 func (pr *replica) applyUpdateMetadataResult(cp updateMetadataResult) {
@@ -134,6 +221,18 @@ func (pr *replica) applyUpdateLabels(result updateLabelsResult) {
 	}
 }
 
+func (pr *replica) applyUpdateGate() {
+	if pr.aware != nil {
+		pr.aware.Updated(pr.getShard())
+	}
+}
+
+func (pr *replica) applyUpdateAppLease() {
+	if pr.aware != nil {
+		pr.aware.Updated(pr.getShard())
+	}
+}
+
 func (pr *replica) applyCompactionResult(r compactionResult) {
 	if r.index > 0 {
 		pr.addAction(action{
@@ -157,7 +256,7 @@ func (pr *replica) applyConfChange(cp configChangeResult) {
 	pr.rn.ApplyConfChange(cp.confChange)
 
 	needPing := false
-	now := time.Now()
+	now := pr.store.now()
 	for _, change := range cp.changes {
 		changeType := change.ChangeType
 		replica := change.Replica
@@ -243,6 +342,11 @@ func (pr *replica) applySplit(result splitResult) {
 			r.stats.approximateSize = estimatedSize
 			r.sm.updateLease(result.newLeases[0])
 			result.newLeases = result.newLeases[1:]
+			if len(result.newPurges) > 0 {
+				r.sm.updatePurgeMarkers(result.newPurges[0])
+				result.newPurges = result.newPurges[1:]
+			}
+			r.sm.updateDictionaries(result.dictionaries)
 		}, func(r *replica) {
 			shard := r.getShard()
 			if isLeader && len(shard.Replicas) > 1 {
@@ -16,6 +16,7 @@ package raftstore
 import (
 	"testing"
 
+	"github.com/matrixorigin/matrixcube/aware"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
@@ -147,6 +148,40 @@ func TestUpdateMetricsHints(t *testing.T) {
 	assert.Equal(t, uint64(2), pr.stats.writtenKeys)
 }
 
+func TestUpdateMetricsHintsWithNegativeDiff(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	pr.stats.approximateSize = 100
+
+	pr.updateMetricsHints(applyResult{
+		metrics: applyMetrics{
+			approximateDiffHint: -40,
+		},
+	})
+	assert.Equal(t, uint64(60), pr.stats.approximateSize)
+
+	pr.updateMetricsHints(applyResult{
+		metrics: applyMetrics{
+			approximateDiffHint: -100,
+		},
+	})
+	assert.Equal(t, uint64(0), pr.stats.approximateSize)
+
+	pr.updateMetricsHints(applyResult{
+		metrics: applyMetrics{
+			approximateDiffHint: -10,
+		},
+		adminResult: &adminResult{
+			adminType:   rpcpb.CmdBatchSplit,
+			splitResult: splitResult{},
+		},
+	})
+	assert.Equal(t, uint64(0), pr.stats.approximateSize)
+}
+
 func TestUpdateMetricsHintsCanBeIgnored(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -181,3 +216,58 @@ func TestUpdateMetricsHintsCanBeIgnored(t *testing.T) {
 	assert.Equal(t, uint64(2), pr.stats.approximateSize)
 
 }
+
+type testAdminResultAware struct {
+	results []aware.AdminResult
+}
+
+func (a *testAdminResultAware) AdminApplied(result aware.AdminResult) {
+	a.results = append(a.results, result)
+}
+
+func TestNotifyAdminResult(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	a := &testAdminResultAware{}
+	s.adminAware = a
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 100}, s)
+
+	pr.handleAdminResult(applyResult{
+		index: 10,
+		adminResult: &adminResult{
+			adminType:        rpcpb.CmdCompactLog,
+			compactionResult: compactionResult{index: 8},
+		},
+	})
+	// failed conf change is not notified
+	pr.notifyAdminResult(applyResult{
+		index:       11,
+		adminResult: &adminResult{adminType: rpcpb.CmdConfigChange},
+	})
+	changes := []rpcpb.ConfigChangeRequest{{ChangeType: metapb.ConfigChangeType_AddNode, Replica: Replica{ID: 200}}}
+	pr.notifyAdminResult(applyResult{
+		index: 12,
+		adminResult: &adminResult{
+			adminType:          rpcpb.CmdConfigChange,
+			configChangeResult: configChangeResult{index: 12, changes: changes},
+		},
+	})
+	newShards := []Shard{{ID: 2}, {ID: 3}}
+	pr.notifyAdminResult(applyResult{
+		index:      13,
+		commitTime: 100,
+		adminResult: &adminResult{
+			adminType:   rpcpb.CmdBatchSplit,
+			splitResult: splitResult{newShards: newShards},
+		},
+	})
+
+	assert.Equal(t, []aware.AdminResult{
+		{Type: aware.AdminCompactLog, Shard: Shard{ID: 1}, Index: 10, CompactIndex: 8},
+		{Type: aware.AdminConfigChange, Shard: Shard{ID: 1}, Index: 12, ConfigChanges: changes},
+		{Type: aware.AdminSplit, Shard: Shard{ID: 1}, Index: 13, NewShards: newShards, CommitTime: 100},
+	}, a.results)
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+const (
+	blockingPendingSnapshot     = "pending snapshot"
+	blockingEpochMismatch       = "epoch mismatch"
+	blockingPendingConfigChange = "pending config change"
+	blockingDestroying          = "destroying"
+)
+
+// blockingReasons keeps the latest reason why each operation of the shard is
+// declined by the replica. The reason of an operation is cleared once the
+// operation is done. The reasons are recorded by the event loop and the apply
+// loop, and reported to prophet by the shard heartbeat.
+type blockingReasons struct {
+	sync.Mutex
+	reasons map[metapb.ShardOperation]metapb.BlockingReason
+	// changed the time of the last change, used to send the heartbeat in time
+	changed time.Time
+}
+
+func (b *blockingReasons) record(op metapb.ShardOperation, reason string) {
+	b.Lock()
+	defer b.Unlock()
+	if b.reasons == nil {
+		b.reasons = make(map[metapb.ShardOperation]metapb.BlockingReason)
+	}
+	now := time.Now()
+	if v, ok := b.reasons[op]; !ok || v.Reason != reason {
+		b.changed = now
+	}
+	b.reasons[op] = metapb.BlockingReason{Operation: op, Reason: reason, Time: now.Unix()}
+}
+
+func (b *blockingReasons) clear(op metapb.ShardOperation) {
+	b.Lock()
+	defer b.Unlock()
+	if _, ok := b.reasons[op]; ok {
+		delete(b.reasons, op)
+		b.changed = time.Now()
+	}
+}
+
+// get returns the reasons ordered by the operation and the time of the last
+// change
+func (b *blockingReasons) get() ([]metapb.BlockingReason, time.Time) {
+	b.Lock()
+	defer b.Unlock()
+	if len(b.reasons) == 0 {
+		return nil, b.changed
+	}
+	values := make([]metapb.BlockingReason, 0, len(b.reasons))
+	for _, v := range b.reasons {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Operation < values[j].Operation
+	})
+	return values, b.changed
+}
+
+// getShardOperation returns the shard operation of the admin request, false if
+// the request is not an operation which may be declined.
+func getShardOperation(req rpcpb.RequestBatch) (metapb.ShardOperation, bool) {
+	if !req.IsAdmin() {
+		return 0, false
+	}
+	switch req.GetAdminCmdType() {
+	case rpcpb.CmdBatchSplit:
+		return metapb.ShardOperation_Split, true
+	case rpcpb.CmdConfigChange:
+		return metapb.ShardOperation_ConfChange, true
+	}
+	return 0, false
+}
+
+// recordBlocked records the reason why the operation of the request is
+// declined by the state machine, the reason is cleared if it's empty, which
+// means the operation is applied.
+func (d *stateMachine) recordBlocked(req rpcpb.RequestBatch, reason string) {
+	op, ok := getShardOperation(req)
+	if !ok {
+		return
+	}
+	if reason == "" {
+		d.blocking.clear(op)
+		return
+	}
+	d.blocking.record(op, reason)
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+func TestBlockingReasons(t *testing.T) {
+	var b blockingReasons
+	reasons, changed := b.get()
+	assert.Empty(t, reasons)
+	assert.True(t, changed.IsZero())
+
+	b.record(metapb.ShardOperation_ConfChange, blockingPendingConfigChange)
+	b.record(metapb.ShardOperation_Split, blockingPendingSnapshot)
+	reasons, changed = b.get()
+	require.Equal(t, 2, len(reasons))
+	assert.Equal(t, metapb.ShardOperation_Split, reasons[0].Operation)
+	assert.Equal(t, blockingPendingSnapshot, reasons[0].Reason)
+	assert.Equal(t, metapb.ShardOperation_ConfChange, reasons[1].Operation)
+	assert.False(t, changed.IsZero())
+
+	// same reason again does not change the digest
+	b.record(metapb.ShardOperation_Split, blockingPendingSnapshot)
+	_, last := b.get()
+	assert.Equal(t, changed, last)
+
+	b.clear(metapb.ShardOperation_Merge)
+	_, last = b.get()
+	assert.Equal(t, changed, last)
+
+	b.clear(metapb.ShardOperation_Split)
+	reasons, last = b.get()
+	require.Equal(t, 1, len(reasons))
+	assert.Equal(t, metapb.ShardOperation_ConfChange, reasons[0].Operation)
+	assert.True(t, !last.Before(changed))
+}
+
+func TestRecordBlocked(t *testing.T) {
+	sm := &stateMachine{}
+	split := rpcpb.RequestBatch{Requests: []rpcpb.Request{{Type: rpcpb.Admin, CustomType: uint64(rpcpb.CmdBatchSplit)}}}
+	confChange := rpcpb.RequestBatch{Requests: []rpcpb.Request{{Type: rpcpb.Admin, CustomType: uint64(rpcpb.CmdConfigChange)}}}
+	write := rpcpb.RequestBatch{Requests: []rpcpb.Request{{}}}
+
+	sm.recordBlocked(write, blockingEpochMismatch)
+	reasons, _ := sm.blocking.get()
+	assert.Empty(t, reasons)
+
+	sm.recordBlocked(split, blockingEpochMismatch)
+	sm.recordBlocked(confChange, blockingDestroying)
+	reasons, _ = sm.blocking.get()
+	require.Equal(t, 2, len(reasons))
+	assert.Equal(t, blockingEpochMismatch, reasons[0].Reason)
+	assert.Equal(t, blockingDestroying, reasons[1].Reason)
+
+	sm.recordBlocked(split, "")
+	reasons, _ = sm.blocking.get()
+	require.Equal(t, 1, len(reasons))
+	assert.Equal(t, metapb.ShardOperation_ConfChange, reasons[0].Operation)
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+// maxConfigChangeHistory the max number of the config change records kept in
+// the shard metadata, the oldest records are dropped once exceeded
+const maxConfigChangeHistory = 128
+
+// newConfigChangeRecord returns the record of the applied config change, the
+// epoch is the shard epoch after the change.
+func newConfigChangeRecord(index uint64, req rpcpb.ConfigChangeRequest,
+	shard Shard) metapb.ConfigChangeRecord {
+	replica := req.Replica
+	if p := findReplica(shard, replica.StoreID); p != nil {
+		replica = *p
+	}
+	return metapb.ConfigChangeRecord{
+		Epoch:      shard.Epoch,
+		ChangeType: req.ChangeType,
+		Replica:    replica,
+		Index:      index,
+		Initiator:  req.Initiator,
+	}
+}
+
+// getConfigChanges returns the config change history, the returned slice is
+// never modified as the history is copied on append.
+func (d *stateMachine) getConfigChanges() []metapb.ConfigChangeRecord {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	return d.metadataMu.configChanges
+}
+
+func (d *stateMachine) updateConfigChanges(records []metapb.ConfigChangeRecord) {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	d.metadataMu.configChanges = records
+}
+
+// appendConfigChange appends the applied config change to the history, it's
+// persisted with the shard metadata saved by the config change.
+func (d *stateMachine) appendConfigChange(record metapb.ConfigChangeRecord) {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	records := d.metadataMu.configChanges
+	records = append(records[:len(records):len(records)], record)
+	if len(records) > maxConfigChangeHistory {
+		records = records[len(records)-maxConfigChangeHistory:]
+	}
+	d.metadataMu.configChanges = records
+}
+
+// GetConfigChangeHistory returns the config change history of the local replica
+func (s *store) GetConfigChangeHistory(shardID uint64) ([]metapb.ConfigChangeRecord, error) {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return nil, errShardNotFound
+	}
+	return pr.sm.getConfigChanges(), nil
+}
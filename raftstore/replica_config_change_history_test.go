@@ -0,0 +1,105 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func newTestConfigChangeEntry(index uint64, configVer uint64,
+	req rpcpb.ConfigChangeRequest) raftpb.Entry {
+	batch := newTestAdminRequestBatch(string([]byte{0x1, 0x2, byte(index)}), 0,
+		rpcpb.CmdConfigChange, protoc.MustMarshal(&req))
+	batch.Header.ShardID = 1
+	batch.Requests[0].Epoch.ConfigVer = configVer
+	cc := raftpb.ConfChange{
+		Type:    raftpb.ConfChangeAddLearnerNode,
+		NodeID:  req.Replica.ID,
+		Context: protoc.MustMarshal(&batch),
+	}
+	return raftpb.Entry{
+		Index: index,
+		Term:  1,
+		Type:  raftpb.EntryConfChange,
+		Data:  protoc.MustMarshal(&cc),
+	}
+}
+
+func TestStateMachineConfigChangeHistory(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		sm.applyCommittedEntries([]raftpb.Entry{
+			newTestConfigChangeEntry(1, 0, rpcpb.ConfigChangeRequest{
+				ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+				Replica:    metapb.Replica{ID: 100, StoreID: 200},
+				Initiator:  "add-rule-peer",
+			}),
+			newTestConfigChangeEntry(2, 1, rpcpb.ConfigChangeRequest{
+				ChangeType: metapb.ConfigChangeType_AddNode,
+				Replica:    metapb.Replica{ID: 100, StoreID: 200},
+			}),
+		})
+
+		expect := []metapb.ConfigChangeRecord{
+			{
+				Epoch:      metapb.ShardEpoch{ConfigVer: 1},
+				ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+				Replica:    metapb.Replica{ID: 100, StoreID: 200, Role: metapb.ReplicaRole_Learner},
+				Index:      1,
+				Initiator:  "add-rule-peer",
+			},
+			{
+				Epoch:      metapb.ShardEpoch{ConfigVer: 2},
+				ChangeType: metapb.ConfigChangeType_AddNode,
+				Replica:    metapb.Replica{ID: 100, StoreID: 200, Role: metapb.ReplicaRole_Voter},
+				Index:      2,
+			},
+		}
+		assert.Equal(t, expect, sm.getConfigChanges())
+
+		// persisted with the shard metadata
+		states, err := sm.dataStorage.GetInitialStates()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(states))
+		assert.Equal(t, uint64(2), states[0].LogIndex)
+		assert.Equal(t, expect, states[0].Metadata.ConfigChanges)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachineAppendConfigChange(t *testing.T) {
+	f := func(sm *stateMachine) {
+		assert.Empty(t, sm.getConfigChanges())
+		sm.appendConfigChange(metapb.ConfigChangeRecord{Index: 1})
+		old := sm.getConfigChanges()
+		for i := uint64(2); i <= maxConfigChangeHistory+10; i++ {
+			sm.appendConfigChange(metapb.ConfigChangeRecord{Index: i})
+		}
+
+		// the returned history is not modified by the later changes
+		assert.Equal(t, []metapb.ConfigChangeRecord{{Index: 1}}, old)
+		records := sm.getConfigChanges()
+		require.Equal(t, maxConfigChangeHistory, len(records))
+		assert.Equal(t, uint64(11), records[0].Index)
+		assert.Equal(t, uint64(maxConfigChangeHistory+10), records[len(records)-1].Index)
+	}
+	runSimpleStateMachineTest(t, f, nil)
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"go.uber.org/zap"
+)
+
+// consistencyState the data checksum computed by the latest ComputeHash, and
+// the divergence found by the latest VerifyHash. The divergence is reported to
+// prophet by the store heartbeats until it's cleared by a later matched
+// checksum or a snapshot.
+type consistencyState struct {
+	sync.Mutex
+	// computed false if no checksum is computed since started, e.g. the replica
+	// is a witness or the data storage can't compute the checksum
+	computed     bool
+	index        uint64
+	hash         uint64
+	inconsistent *metapb.InconsistentShard
+}
+
+func (c *consistencyState) setHash(index, hash uint64) {
+	c.Lock()
+	defer c.Unlock()
+	c.computed = true
+	c.index = index
+	c.hash = hash
+}
+
+// getHash returns the checksum computed at the index, returns false if no
+// checksum is computed at the index.
+func (c *consistencyState) getHash(index uint64) (uint64, bool) {
+	c.Lock()
+	defer c.Unlock()
+	if !c.computed || c.index != index {
+		return 0, false
+	}
+	return c.hash, true
+}
+
+func (c *consistencyState) setInconsistent(v *metapb.InconsistentShard) {
+	c.Lock()
+	defer c.Unlock()
+	c.inconsistent = v
+}
+
+func (c *consistencyState) getInconsistent() *metapb.InconsistentShard {
+	c.Lock()
+	defer c.Unlock()
+	return c.inconsistent
+}
+
+// reset drops the computed checksum and the divergence, the data is replaced
+// by a snapshot.
+func (c *consistencyState) reset() {
+	c.Lock()
+	defer c.Unlock()
+	c.computed = false
+	c.inconsistent = nil
+}
+
+// doComputeHash computes the checksum of the shard data on every replica. All
+// the writes before the request are applied, so the checksums of the replicas
+// are expected to be the same.
+func (d *stateMachine) doComputeHash(ctx *applyContext) (rpcpb.ResponseBatch, error) {
+	resp := &rpcpb.ComputeHashResponse{Index: ctx.index}
+	if cs, ok := d.dataStorage.(storage.Checksummer); ok && !d.isWitness() {
+		hash, err := cs.Checksum(d.getShard())
+		if err != nil {
+			d.logger.Fatal("failed to compute the data checksum",
+				zap.Error(err))
+		}
+		d.consistency.setHash(ctx.index, hash)
+		resp.Hash = hash
+		resp.Computed = true
+		d.logger.Info("data checksum computed",
+			log.IndexField(ctx.index),
+			zap.Uint64("hash", hash))
+	}
+	return newAdminResponseBatch(rpcpb.CmdComputeHash, resp), nil
+}
+
+// doVerifyHash compares the checksum computed at the index of the request with
+// the expected one computed by the leader. The replicas without the checksum at
+// the index, e.g. the replicas added or restarted after the ComputeHash, skip
+// the verification.
+func (d *stateMachine) doVerifyHash(ctx *applyContext) (rpcpb.ResponseBatch, error) {
+	req := ctx.req.GetVerifyHashRequest()
+	resp := newAdminResponseBatch(rpcpb.CmdVerifyHash, &rpcpb.VerifyHashResponse{})
+	hash, ok := d.consistency.getHash(req.Index)
+	if !ok {
+		metric.IncConsistencyCheck("skipped")
+		d.logger.Info("data checksum verification skipped",
+			log.IndexField(req.Index),
+			log.ReasonField("checksum not computed"))
+		return resp, nil
+	}
+
+	if hash == req.Hash {
+		metric.IncConsistencyCheck("matched")
+		d.consistency.setInconsistent(nil)
+		d.logger.Info("data checksum verified",
+			log.IndexField(req.Index),
+			zap.Uint64("hash", hash))
+		return resp, nil
+	}
+
+	metric.IncConsistencyCheck("mismatched")
+	d.consistency.setInconsistent(&metapb.InconsistentShard{
+		ShardID:    d.shardID,
+		Index:      req.Index,
+		Hash:       hash,
+		ExpectHash: req.Hash,
+	})
+	d.logger.Error("data checksum diverges from the leader",
+		log.IndexField(req.Index),
+		zap.Uint64("hash", hash),
+		zap.Uint64("expect-hash", req.Hash))
+	return resp, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func TestStateMachineConsistencyCheck(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		apply := func(index uint64, batch rpcpb.RequestBatch) rpcpb.ResponseBatch {
+			batch.Header.ShardID = 100
+			sm.applyCommittedEntries([]raftpb.Entry{{
+				Index: index,
+				Term:  1,
+				Type:  raftpb.EntryNormal,
+				Data:  protoc.MustMarshal(&batch),
+			}})
+			return h.resp
+		}
+		set := func(index uint64, key string) {
+			apply(index, rpcpb.RequestBatch{Requests: []rpcpb.Request{{
+				ID:         []byte(key),
+				Type:       rpcpb.Write,
+				Key:        []byte(key),
+				CustomType: uint64(rpcpb.CmdKVSet),
+				Cmd:        protoc.MustMarshal(&rpcpb.KVSetRequest{Key: []byte(key), Value: []byte(key)}),
+			}}})
+		}
+		computeHash := func(index uint64) rpcpb.ComputeHashResponse {
+			resp := apply(index, newTestAdminRequestBatch("", 0, rpcpb.CmdComputeHash,
+				protoc.MustMarshal(&rpcpb.ComputeHashRequest{})))
+			return resp.GetComputeHashResponse()
+		}
+		verifyHash := func(index uint64, req rpcpb.VerifyHashRequest) {
+			apply(index, newTestAdminRequestBatch("", 0, rpcpb.CmdVerifyHash,
+				protoc.MustMarshal(&req)))
+		}
+
+		set(1, "a")
+		resp1 := computeHash(2)
+		assert.Equal(t, uint64(2), resp1.Index)
+		assert.True(t, resp1.Computed)
+		set(3, "b")
+		resp2 := computeHash(4)
+		assert.Equal(t, uint64(4), resp2.Index)
+		assert.NotEqual(t, resp1.Hash, resp2.Hash)
+
+		// only the latest checksum is kept, the others are skipped
+		verifyHash(5, rpcpb.VerifyHashRequest{Index: 2, Hash: resp1.Hash + 1})
+		assert.Nil(t, sm.consistency.getInconsistent())
+
+		verifyHash(6, rpcpb.VerifyHashRequest{Index: 4, Hash: resp2.Hash + 1})
+		assert.Equal(t, &metapb.InconsistentShard{
+			ShardID:    100,
+			Index:      4,
+			Hash:       resp2.Hash,
+			ExpectHash: resp2.Hash + 1,
+		}, sm.consistency.getInconsistent())
+
+		// cleared by the matched checksum
+		verifyHash(7, rpcpb.VerifyHashRequest{Index: 4, Hash: resp2.Hash})
+		assert.Nil(t, sm.consistency.getInconsistent())
+
+		sm.consistency.setInconsistent(&metapb.InconsistentShard{ShardID: 100})
+		sm.consistency.reset()
+		assert.Nil(t, sm.consistency.getInconsistent())
+		_, ok := sm.consistency.getHash(4)
+		assert.False(t, ok)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachineWitnessSkipsComputeHash(t *testing.T) {
+	f := func(sm *stateMachine) {
+		sm.updateShard(Shard{ID: 100, Replicas: []Replica{{ID: 100, Role: metapb.ReplicaRole_Witness}}})
+		ctx := newApplyContext()
+		ctx.index = 10
+		ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdComputeHash,
+			protoc.MustMarshal(&rpcpb.ComputeHashRequest{}))
+		resp, err := sm.execAdminRequest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, rpcpb.ComputeHashResponse{Index: 10}, resp.GetComputeHashResponse())
+		_, ok := sm.consistency.getHash(10)
+		assert.False(t, ok)
+	}
+	runSimpleStateMachineTest(t, f, nil)
+}
@@ -78,19 +78,41 @@ func (s *store) destroyReplica(shardID uint64,
 	if shardRemoved {
 		s.createShardsProtector.addDestroyed(shardID)
 	}
+	var size uint64
+	if replica.stats != nil {
+		size = replica.stats.approximateSize
+	}
 	s.vacuumCleaner.addTask(vacuumTask{
 		shard:        replica.getShard(),
 		replica:      replica,
 		shardRemoved: shardRemoved,
 		removeData:   removeData,
 		reason:       reason,
+		size:         size,
 	})
 }
 
 // cleanupTombstones is invoked during restart to cleanup data belongs to those
-// shards that have been tombstoned.
+// shards that have been tombstoned. The tombstones of the replicas removed from
+// the live shards are kept by the tombstone gc if it's enabled.
 func (s *store) cleanupTombstones(shards []metapb.ShardLocalState) {
 	for _, sls := range shards {
+		if s.tombstones.enabled() &&
+			sls.State == metapb.ReplicaState_ReplicaTombstone &&
+			sls.Shard.State != metapb.ShardState_Destroyed {
+			// the replica is not in the shard if it's removed by config change
+			var replicaID uint64
+			if r := findReplica(sls.Shard, s.Meta().ID); r != nil {
+				replicaID = r.ID
+			}
+			s.addTombstone(tombstoneReplica{
+				shard:      sls.Shard,
+				replicaID:  replicaID,
+				removeData: sls.RemoveData,
+				since:      s.now(),
+			})
+			continue
+		}
 		s.vacuumCleaner.addTask(vacuumTask{
 			shard:      sls.Shard,
 			removeData: sls.RemoveData,
@@ -114,7 +136,7 @@ func (s *store) vacuum(t vacuumTask) error {
 			t.replica.logger.Info("skip vacuuming already closed replica")
 			return nil
 		}
-		if err := t.replica.destroy(t.shardRemoved, t.reason); err != nil {
+		if err := t.replica.destroy(t.shardRemoved, t.removeData, t.reason); err != nil {
 			// storage.ErrShardNotFound is returned by the AOE when the shard has
 			// already been removed as a result of split. we just ignore such
 			// error here.
@@ -134,25 +156,66 @@ func (s *store) vacuum(t vacuumTask) error {
 			log.ReplicaIDField(t.shard.ID))
 	}
 	s.removeDroppedVoteMsg(t.shard.ID)
-	if len(t.shard.Replicas) > 0 && !s.removeShardKeyRange(t.shard) {
+	if !t.tombstone && len(t.shard.Replicas) > 0 && !s.removeShardKeyRange(t.shard) {
 		// TODO: is it possible to not have shard related key range info in store?
 		// should this be an error?
 		// return ErrRemoveShardKeyRange
 		s.logger.Warn("failed to delete shard key range")
 	}
 
+	if t.replica != nil && !t.shardRemoved && s.tombstones.enabled() {
+		s.addTombstone(tombstoneReplica{
+			shard:      t.replica.getShard(),
+			replicaID:  t.replica.replicaID,
+			removeData: t.removeData,
+			size:       t.replica.stats.approximateSize,
+			since:      s.now(),
+		})
+		t.replica.confirmDestroyed()
+		return nil
+	}
+
+	removeData := t.removeData
+	if t.tombstone && removeData && s.hasOverlappedReplica(t.shard) {
+		s.logger.Info("tombstone data range is owned by other replica, keep data",
+			s.storeField(),
+			log.ShardIDField(t.shard.ID))
+		removeData = false
+	}
+	if removeData && !s.vacuumCleaner.throttle(t.size) {
+		// the tombstone metadata is kept, the deletion is resumed by
+		// cleanupTombstones after the store is restarted
+		s.logger.Info("vacuum cleaner stopped, delete shard data after restart",
+			s.storeField(),
+			log.ShardIDField(t.shard.ID))
+		return nil
+	}
+
 	s.logger.Info("deleting shard data",
 		s.storeField(),
 		log.ShardIDField(t.shard.ID))
 	if err := s.logdb.RemoveReplicaData(t.shard.ID); err != nil {
 		return err
 	}
-	err := s.DataStorageByGroup(t.shard.Group).RemoveShard(t.shard, t.removeData)
+	ds := s.DataStorageByGroup(t.shard.Group)
+	err := ds.RemoveShard(t.shard, removeData)
 	s.logger.Info("delete shard data returned",
 		s.storeField(),
 		log.ShardIDField(t.shard.ID),
 		zap.Error(err))
 	if err == nil {
+		notifyKeyRangeChanged(ds, storage.KeyRangeChange{
+			Type:       storage.KeyRangeRemoved,
+			Old:        t.shard,
+			RemoveData: removeData,
+		})
+		if dc, ok := ds.(storage.DictionaryCompressor); ok {
+			dc.RemoveDictionaries(t.shard.ID)
+		}
+		if t.tombstone {
+			s.tombstoneRemoved(t.shard.ID)
+			return nil
+		}
 		s.removeReplica(t.shard)
 		if t.replica != nil {
 			t.replica.confirmDestroyed()
@@ -161,9 +224,10 @@ func (s *store) vacuum(t vacuumTask) error {
 	return err
 }
 
-func (pr *replica) destroy(shardRemoved bool, reason string) error {
+func (pr *replica) destroy(shardRemoved, removeData bool, reason string) error {
 	pr.logger.Info("begin to destroy",
 		zap.Bool("shard-removed", shardRemoved),
+		zap.Bool("remove-data", removeData),
 		log.ShardField("metadata", pr.getShard()),
 		log.ReasonField(reason))
 
@@ -173,9 +237,13 @@ func (pr *replica) destroy(shardRemoved bool, reason string) error {
 
 	// Use last applied index as Tombstone metadata's log index. And any logs are
 	// not executed by the state machine anymore. So the index+1's metedata never
-	// be overrided.
+	// be overrided. The tombstone records whether the data is removed, so the
+	// deletion is resumed after restart if the store is stopped before the data
+	// is deleted.
 	index, _ := pr.sm.getAppliedIndexTerm()
-	return pr.sm.saveShardMetedata(index+1, pr.getShard(), metapb.ReplicaState_ReplicaTombstone, nil)
+	md := pr.sm.newShardMetadata(index+1, pr.getShard(), metapb.ReplicaState_ReplicaTombstone, nil)
+	md.Metadata.RemoveData = removeData
+	return pr.sm.dataStorage.SaveShardMetadata([]metapb.ShardMetadata{md})
 }
 
 func (pr *replica) confirmDestroyed() {
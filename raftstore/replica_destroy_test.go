@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"testing"
+	"time"
 
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/config"
@@ -55,26 +56,7 @@ func TestDestroyReplica(t *testing.T) {
 
 	assert.Equal(t, 2, scan())
 
-	pr := &replica{
-		shardID:           1,
-		replica:           r,
-		startedC:          make(chan struct{}),
-		closedC:           make(chan struct{}),
-		destroyedC:        make(chan struct{}),
-		unloadedC:         make(chan struct{}),
-		store:             s,
-		logger:            s.logger,
-		ticks:             task.New(32),
-		messages:          task.New(32),
-		requests:          task.New(32),
-		actions:           task.New(32),
-		feedbacks:         task.New(32),
-		pendingProposals:  newPendingProposals(),
-		incomingProposals: newProposalBatch(s.logger, 10, 1, r),
-		pendingReads:      &readIndexQueue{shardID: 1, logger: s.logger},
-		readStopper:       stop.NewStopper("TestDestroyReplica"),
-	}
-	pr.sm = newStateMachine(pr.logger, s.DataStorageByGroup(0), s.logdb, shard, pr.replica, nil, nil, nil)
+	pr := newTestDestroyReplica(s, shard, r)
 	s.vacuumCleaner.start()
 	defer s.vacuumCleaner.close()
 	close(pr.startedC)
@@ -102,6 +84,86 @@ func TestDestroyReplica(t *testing.T) {
 	require.Empty(t, smd)
 }
 
+func newTestDestroyReplica(s *store, shard Shard, r Replica) *replica {
+	pr := &replica{
+		shardID:           shard.ID,
+		replica:           r,
+		startedC:          make(chan struct{}),
+		closedC:           make(chan struct{}),
+		destroyedC:        make(chan struct{}),
+		unloadedC:         make(chan struct{}),
+		store:             s,
+		logger:            s.logger,
+		ticks:             task.New(32),
+		messages:          task.New(32),
+		requests:          task.New(32),
+		actions:           task.New(32),
+		feedbacks:         task.New(32),
+		pendingProposals:  newPendingProposals(),
+		incomingProposals: newProposalBatch(s.logger, 10, shard.ID, r),
+		pendingReads:      &readIndexQueue{shardID: shard.ID, logger: s.logger},
+		readStopper:       stop.NewStopper("TestDestroyReplica"),
+	}
+	pr.sm = newStateMachine(pr.logger, s.DataStorageByGroup(0), s.logdb, shard, pr.replica, nil, nil, nil)
+	return pr
+}
+
+func TestDestroyReplicaResumesDeletingDataAfterRestart(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r := Replica{ID: 1}
+	s, cancel := newTestStore(t)
+	defer cancel()
+	kv := s.DataStorageByGroup(0).(storage.KVStorageWrapper).GetKVStorage()
+	assert.NoError(t, kv.Set(keysutil.EncodeDataKey([]byte("a1"), nil), []byte("hello-a1"), false))
+
+	shard := Shard{
+		ID:       1,
+		Start:    []byte("a"),
+		End:      []byte("b"),
+		Replicas: []Replica{r},
+	}
+	get := func() []byte {
+		v, err := kv.Get(keysutil.EncodeDataKey([]byte("a1"), nil))
+		assert.NoError(t, err)
+		return v
+	}
+
+	// the vacuum cleaner is stopped while the deletion is throttled
+	pr := newTestDestroyReplica(s, shard, r)
+	close(pr.startedC)
+	s.addReplica(pr)
+	s.vacuumCleaner = newVacuumCleaner(s.vacuum).withLimits(0, 1024)
+	s.vacuumCleaner.close()
+	errC := make(chan error, 1)
+	go func() {
+		errC <- s.vacuum(vacuumTask{shard: shard, replica: pr, removeData: true, size: 1 << 20})
+	}()
+	for !pr.closed() {
+	}
+	_, err := pr.handleEvent(s.logdb.NewWorkerContext())
+	assert.NoError(t, err)
+	require.NoError(t, <-errC)
+	assert.Equal(t, []byte("hello-a1"), get())
+
+	// the tombstone records the data to be deleted
+	states, err := pr.sm.dataStorage.GetInitialStates()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(states))
+	assert.Equal(t, metapb.ReplicaState_ReplicaTombstone, states[0].Metadata.State)
+	assert.True(t, states[0].Metadata.RemoveData)
+
+	s.vacuumCleaner = newVacuumCleaner(s.vacuum)
+	s.vacuumCleaner.start()
+	defer s.vacuumCleaner.close()
+	s.cleanupTombstones([]metapb.ShardLocalState{states[0].Metadata})
+	for len(get()) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	states, err = pr.sm.dataStorage.GetInitialStates()
+	require.NoError(t, err)
+	assert.Empty(t, states)
+}
+
 func TestReplicaDestroyedState(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -0,0 +1,173 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"github.com/fagongzi/util/protoc"
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/compression"
+)
+
+// maxCompressionDictionaries the max number of the compression dictionaries of
+// a shard. All the versions are kept in the shard metadata to decompress the
+// values written before, so no more dictionary is trained once exceeded.
+const maxCompressionDictionaries = 8
+
+// entryCompressionLevel the zstd level compressing the request batches proposed
+// to the raft log
+const entryCompressionLevel = 3
+
+// getDictionaries returns the compression dictionaries of the shard, the
+// returned slice is never modified as the dictionaries are copied on append.
+func (d *stateMachine) getDictionaries() []metapb.CompressionDictionary {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	return d.metadataMu.dictionaries
+}
+
+// updateDictionaries sets the compression dictionaries of the shard, and sets
+// them to the data storage, so the values written after are compressed with
+// the last one.
+func (d *stateMachine) updateDictionaries(dicts []metapb.CompressionDictionary) {
+	d.metadataMu.Lock()
+	d.metadataMu.dictionaries = dicts
+	d.metadataMu.Unlock()
+
+	if dc, ok := d.dataStorage.(storage.DictionaryCompressor); ok {
+		dc.SetDictionaries(d.shardID, dicts)
+	}
+}
+
+// doUpdateDictionary adds the next version of the compression dictionary of
+// the shard. The dictionary is used by the writes applied after, and it's
+// persisted with the shard metadata saved here, so all the replicas compress
+// the same writes with the same dictionary. The stale versions proposed
+// concurrently are skipped.
+func (d *stateMachine) doUpdateDictionary(ctx *applyContext) (rpcpb.ResponseBatch, error) {
+	req := ctx.req.GetUpdateDictionaryRequest()
+	dicts := d.getDictionaries()
+	current := uint64(0)
+	if n := len(dicts); n > 0 {
+		current = dicts[n-1].Version
+	}
+
+	updated := req.Version == current+1 && len(req.Data) > 0
+	if updated {
+		dicts = append(dicts[:len(dicts):len(dicts)],
+			metapb.CompressionDictionary{Version: req.Version, Data: req.Data})
+		d.updateDictionaries(dicts)
+		if err := d.saveShardMetedata(ctx.index, d.getShard(), metapb.ReplicaState_Normal, d.getLease()); err != nil {
+			d.logger.Fatal("failed to save metadata after update dictionary",
+				zap.Error(err))
+		}
+	}
+
+	d.logger.Info("update dictionary applied",
+		log.IndexField(ctx.index),
+		zap.Uint64("version", req.Version),
+		zap.Int("size", len(req.Data)),
+		zap.Bool("updated", updated))
+	return newAdminResponseBatch(rpcpb.CmdUpdateDictionary,
+		&rpcpb.UpdateDictionaryResponse{Updated: updated}), nil
+}
+
+// handleDictionaryTrainTask trains the compression dictionaries of the shards
+// of the group led by the store, and proposes the trained dictionaries. The
+// training runs in the timer worker of the group rather than the event loop of
+// the replicas.
+func (s *store) handleDictionaryTrainTask(group uint64, dc storage.DictionaryCompressor) {
+	s.forEachReplica(func(pr *replica) bool {
+		if pr.group != group || !pr.isLeader() {
+			return true
+		}
+		dicts := pr.sm.getDictionaries()
+		if len(dicts) >= maxCompressionDictionaries {
+			return true
+		}
+		data, ok := dc.TrainDictionary(pr.shardID)
+		if !ok {
+			return true
+		}
+
+		version := uint64(1)
+		if n := len(dicts); n > 0 {
+			version = dicts[n-1].Version + 1
+		}
+		pr.logger.Info("requesting update dictionary",
+			zap.Uint64("version", version),
+			zap.Int("size", len(data)))
+		pr.addAdminRequest(rpcpb.CmdUpdateDictionary, &rpcpb.UpdateDictionaryRequest{
+			Version: version,
+			Data:    data,
+		})
+		return true
+	})
+}
+
+// compressRequestBatch compresses the marshaled write batch with the current
+// compression dictionary of the shard, the proposed entry only keeps the header
+// of the batch uncompressed. The leader has applied the update dictionary entry
+// of the current version, so the replicas applying the proposed entry always
+// have the dictionary. The batch is proposed as is if the shard has no
+// dictionary or the compression doesn't make it smaller.
+func (pr *replica) compressRequestBatch(req *rpcpb.RequestBatch, data []byte) []byte {
+	dicts := pr.sm.getDictionaries()
+	if len(dicts) == 0 || req.IsAdmin() {
+		return data
+	}
+	compressed, err := compression.CompressWithDictionary(entryCompressionLevel,
+		dicts[len(dicts)-1], data)
+	if err != nil {
+		pr.logger.Error("failed to compress the request batch",
+			zap.Error(err))
+		return data
+	}
+	if v := protoc.MustMarshal(&rpcpb.RequestBatch{
+		Header:     req.Header,
+		Compressed: compressed,
+	}); len(v) < len(data) {
+		return v
+	}
+	return data
+}
+
+// decompressRequestBatch replaces the compressed request batch of the apply
+// context with the decompressed one.
+func (d *stateMachine) decompressRequestBatch(ctx *applyContext) {
+	if len(ctx.req.Compressed) == 0 {
+		return
+	}
+	req, err := decompressRequestBatch(d.getDictionaries(), ctx.req.Compressed)
+	if err != nil {
+		d.logger.Fatal("failed to decompress the request batch",
+			log.IndexField(ctx.index),
+			zap.Error(err))
+	}
+	ctx.req = req
+}
+
+func decompressRequestBatch(dicts []metapb.CompressionDictionary, compressed []byte) (rpcpb.RequestBatch, error) {
+	var req rpcpb.RequestBatch
+	data, err := compression.DecompressWithDictionaries(dicts, compressed)
+	if err != nil {
+		return req, err
+	}
+	err = req.FastUnmarshal(data)
+	return req, err
+}
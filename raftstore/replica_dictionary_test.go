@@ -0,0 +1,162 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"testing"
+
+	cpebble "github.com/cockroachdb/pebble"
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/compression"
+	"github.com/matrixorigin/matrixcube/storage/executor"
+	"github.com/matrixorigin/matrixcube/storage/kv"
+	"github.com/matrixorigin/matrixcube/storage/kv/pebble"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/vfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestStateMachineUpdateDictionary(t *testing.T) {
+	f := func(sm *stateMachine) {
+		update := func(index, version uint64) bool {
+			ctx := newApplyContext()
+			ctx.index = index
+			ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdUpdateDictionary,
+				protoc.MustMarshal(&rpcpb.UpdateDictionaryRequest{Version: version, Data: []byte("dict")}))
+			resp, err := sm.execAdminRequest(ctx)
+			require.NoError(t, err)
+			return resp.GetUpdateDictionaryResponse().Updated
+		}
+
+		assert.True(t, update(1, 1))
+		// the stale and the future versions are skipped
+		assert.False(t, update(2, 1))
+		assert.False(t, update(3, 3))
+		assert.True(t, update(4, 2))
+
+		expect := []metapb.CompressionDictionary{{Version: 1, Data: []byte("dict")}, {Version: 2, Data: []byte("dict")}}
+		assert.Equal(t, expect, sm.getDictionaries())
+
+		// persisted with the shard metadata
+		states, err := sm.dataStorage.GetInitialStates()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(states))
+		assert.Equal(t, uint64(4), states[0].LogIndex)
+		assert.Equal(t, expect, states[0].Metadata.Dictionaries)
+	}
+	runSimpleStateMachineTest(t, f, nil)
+}
+
+func TestStateMachineCompressWithReplicatedDictionary(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	l := log.GetDefaultZapLogger(zap.OnFatal(zapcore.WriteThenPanic))
+	fs := vfs.NewMemFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	st, err := pebble.NewStorage("test-data", nil, &cpebble.Options{FS: vfs.NewPebbleFS(fs)})
+	require.NoError(t, err)
+	defer st.Close()
+	newDataStorage := func() storage.DataStorage {
+		return kv.NewKVDataStorage(kv.NewBaseStorage(st, fs),
+			executor.NewKVExecutorWithCompression(st, compression.NewDictionaries(3, 10, 1024)))
+	}
+
+	value := func(i int) []byte {
+		return []byte(fmt.Sprintf(`{"id":%d,"name":"user-%d","email":"user-%d@example.com","status":"active"}`, i, i, i))
+	}
+	var samples [][]byte
+	for i := 0; i < 10; i++ {
+		samples = append(samples, value(i))
+	}
+	dict := compression.TrainDictionary(samples, 1024)
+	require.NotEmpty(t, dict)
+
+	ds := newDataStorage()
+	sm := newStateMachine(l, ds, nil, Shard{ID: 1}, Replica{ID: 100}, &testReplicaResultHandler{}, nil, nil)
+	update := newTestAdminRequestBatch("", 0, rpcpb.CmdUpdateDictionary,
+		protoc.MustMarshal(&rpcpb.UpdateDictionaryRequest{Version: 1, Data: dict}))
+	update.Header.ShardID = 1
+	set := rpcpb.RequestBatch{
+		Header: rpcpb.RequestBatchHeader{ID: []byte{1}, ShardID: 1},
+		Requests: []rpcpb.Request{{
+			ID:         []byte{1},
+			Type:       rpcpb.Write,
+			Key:        []byte("k1"),
+			CustomType: uint64(rpcpb.CmdKVSet),
+			Cmd:        protoc.MustMarshal(&rpcpb.KVSetRequest{Key: []byte("k1"), Value: value(100)}),
+		}},
+	}
+	sm.applyCommittedEntries([]raftpb.Entry{
+		{Index: 1, Term: 1, Type: raftpb.EntryNormal, Data: protoc.MustMarshal(&update)},
+		{Index: 2, Term: 1, Type: raftpb.EntryNormal, Data: protoc.MustMarshal(&set)},
+	})
+
+	// the value is compressed with the replicated dictionary
+	v, err := st.Get(keysutil.EncodeDataKey([]byte("k1"), nil))
+	require.NoError(t, err)
+	assert.True(t, len(v) < len(value(100)))
+	assert.Equal(t, []byte{1, 1}, v[:2])
+
+	get := func(ds storage.DataStorage) ([]byte, error) {
+		return ds.Read(storage.NewSimpleReadContext(1, storage.Request{
+			CmdType: uint64(rpcpb.CmdKVGet),
+			Cmd:     protoc.MustMarshal(&rpcpb.KVGetRequest{Key: []byte("k1")}),
+		}))
+	}
+	resp, err := get(ds)
+	require.NoError(t, err)
+	var getResp rpcpb.KVGetResponse
+	protoc.MustUnmarshal(&getResp, resp)
+	assert.Equal(t, value(100), getResp.Value)
+
+	// the write batch is proposed compressed with the replicated dictionary
+	set.Header.ID = []byte{2}
+	set.Requests[0].ID = []byte{2}
+	set.Requests[0].Cmd = protoc.MustMarshal(&rpcpb.KVSetRequest{Key: []byte("k1"), Value: value(101)})
+	data := protoc.MustMarshal(&set)
+	pr := &replica{logger: l, sm: sm}
+	compressed := pr.compressRequestBatch(&set, data)
+	assert.True(t, len(compressed) < len(data))
+	assert.Equal(t, data, pr.compressRequestBatch(&update, data))
+	sm.applyCommittedEntries([]raftpb.Entry{
+		{Index: 3, Term: 1, Type: raftpb.EntryNormal, Data: compressed},
+	})
+	resp, err = get(ds)
+	require.NoError(t, err)
+	protoc.MustUnmarshal(&getResp, resp)
+	assert.Equal(t, value(101), getResp.Value)
+
+	// a restarted store loads the dictionaries from the shard metadata
+	ds = newDataStorage()
+	_, err = get(ds)
+	assert.Equal(t, compression.ErrDictionaryNotFound, err)
+	states, err := ds.GetInitialStates()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(states))
+	sm = newStateMachine(l, ds, nil, Shard{ID: 1}, Replica{ID: 100}, nil, nil, nil)
+	sm.updateDictionaries(states[0].Metadata.Dictionaries)
+	resp, err = get(ds)
+	require.NoError(t, err)
+	protoc.MustUnmarshal(&getResp, resp)
+	assert.Equal(t, value(101), getResp.Value)
+}
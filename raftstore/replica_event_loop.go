@@ -23,6 +23,7 @@ import (
 	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/snapshot"
 	"github.com/matrixorigin/matrixcube/util"
 	"github.com/matrixorigin/matrixcube/util/uuid"
 	"go.etcd.io/etcd/raft/v3"
@@ -42,12 +43,16 @@ type action struct {
 	targetIndex        uint64
 	readMetrics        readMetrics
 	epoch              Epoch
-	actionCallback     func(interface{})
+	// compactLogThreshold the min uncompacted raft log bytes of compactLogsAction
+	compactLogThreshold uint64
+	snapshotGenerated   snapshotGeneratedDetails
+	actionCallback      func(interface{})
 }
 
 type readMetrics struct {
 	readBytes uint64
 	readKeys  uint64
+	latency   time.Duration
 }
 
 type splitCheckData struct {
@@ -63,6 +68,12 @@ type snapshotCompactionDetails struct {
 	persistentLogIndex uint64
 }
 
+type snapshotGeneratedDetails struct {
+	snapshot raftpb.Snapshot
+	env      snapshot.SSEnv
+	err      error
+}
+
 type actionType int
 
 const (
@@ -77,9 +88,20 @@ const (
 	logCompactionAction
 	snapshotCompactionAction
 	checkPendingReadsAction
+	debugInfoAction
+	raftStatusAction
+	compactLogsAction
+	ttlGCAction
+	snapshotGeneratedAction
+	retryWriteAction
 )
 
 func (pr *replica) addAdminRequest(adminType rpcpb.InternalCmd, request protoc.PB) {
+	pr.addAdminRequestWithCallback(adminType, request, nil)
+}
+
+func (pr *replica) addAdminRequestWithCallback(adminType rpcpb.InternalCmd, request protoc.PB,
+	cb func(rpcpb.ResponseBatch)) {
 	shard := pr.getShard()
 	if err := pr.addRequest(newReqCtx(rpcpb.Request{
 		ID:         uuid.NewV4().Bytes(),
@@ -89,7 +111,7 @@ func (pr *replica) addAdminRequest(adminType rpcpb.InternalCmd, request protoc.P
 		CustomType: uint64(adminType),
 		Epoch:      shard.Epoch,
 		Cmd:        protoc.MustMarshal(request),
-	}, nil)); err != nil {
+	}, pr.store.now(), cb)); err != nil {
 		panic(err)
 	}
 }
@@ -144,10 +166,14 @@ func (pr *replica) addRaftTick() bool {
 }
 
 func (pr *replica) onRaftTick(arg interface{}) {
-	if pr.addRaftTick() {
+	if pr.skipHibernatedTick() || pr.addRaftTick() {
 		metric.SetRaftTickQueueMetric(pr.ticks.Len())
+		// ticks are generated by the DeterministicDriver
+		if pr.cfg.Test.Deterministic {
+			return
+		}
 		w := util.DefaultTimeoutWheel()
-		if _, err := w.Schedule(pr.cfg.Raft.TickInterval.Duration, pr.onRaftTick, nil); err != nil {
+		if _, err := w.Schedule(pr.cfg.GetTickInterval(pr.group), pr.onRaftTick, nil); err != nil {
 			panic(err)
 		}
 		return
@@ -168,6 +194,10 @@ func (pr *replica) onCheckPendingReads(arg interface{}) {
 	// We periodically check whether there are read requests that need to be cleaned up. These requests
 	// will not be responded to, and the client will try again.
 	if pr.addCheckPendingReads() {
+		if pr.cfg.Test.Deterministic {
+			pr.store.afterFunc(time.Minute, func() { pr.onCheckPendingReads(nil) })
+			return
+		}
 		w := util.DefaultTimeoutWheel()
 		if _, err := w.Schedule(time.Minute, pr.onCheckPendingReads, nil); err != nil {
 			panic(err)
@@ -310,12 +340,13 @@ func (pr *replica) handleAction(items []interface{}) (bool, error) {
 		case splitAction:
 			pr.doSplit(act)
 		case campaignAction:
+			pr.wakeUp()
 			if err := pr.doCampaign(); err != nil {
 				pr.logger.Fatal("failed to do campaign",
 					zap.Error(err))
 			}
 		case heartbeatAction:
-			pr.prophetHeartbeat()
+			pr.maybeProphetHeartbeat()
 		case updateReadMetrics:
 			pr.doUpdateReadMetrics(act)
 		case checkLogCommittedAction:
@@ -335,6 +366,20 @@ func (pr *replica) handleAction(items []interface{}) (bool, error) {
 			}
 		case checkPendingReadsAction:
 			pr.pendingReads.removeLost()
+		case debugInfoAction:
+			act.actionCallback(pr.getDebugInfo())
+		case raftStatusAction:
+			act.actionCallback(pr.getRaftStatus())
+		case compactLogsAction:
+			pr.doCompactLogs(act)
+		case ttlGCAction:
+			pr.doTTLGC(pr.store.now())
+		case snapshotGeneratedAction:
+			if err := pr.handleSnapshotGenerated(act.snapshotGenerated); err != nil {
+				return false, err
+			}
+		case retryWriteAction:
+			pr.doRetryWrite()
 		}
 	}
 
@@ -347,6 +392,8 @@ func (pr *replica) handleAction(items []interface{}) (bool, error) {
 func (pr *replica) doUpdateReadMetrics(act action) {
 	pr.stats.readBytes += act.readMetrics.readBytes
 	pr.stats.readKeys += act.readMetrics.readKeys
+	pr.stats.readLatency += act.readMetrics.latency
+	pr.stats.readCount++
 }
 
 func (pr *replica) handleMessage(items []interface{}) bool {
@@ -358,15 +405,29 @@ func (pr *replica) handleMessage(items []interface{}) bool {
 	if err != nil {
 		return false
 	}
+	detectQuorumLoss := pr.cfg.QuorumLoss.DetectTimeout.Duration > 0
 	for i := int64(0); i < n; i++ {
 		raftMsg := items[i].(metapb.RaftMessage)
 		msg := raftMsg.Message
 		pr.updateReplicasCommittedIndex(raftMsg)
+		if detectQuorumLoss && msg.From != 0 {
+			pr.quorumLoss.observe(msg.From, pr.store.now())
+		}
 
 		if pr.isLeader() && msg.From != 0 {
-			pr.replicaHeartbeatsMap.Store(msg.From, time.Now())
+			pr.replicaHeartbeatsMap.Store(msg.From, pr.store.now())
 		}
 
+		if raftMsg.Hibernate {
+			pr.handleHibernateMessage(raftMsg)
+			continue
+		}
+		if wakesUp(msg) {
+			pr.wakeUp()
+		}
+		if pr.isLeader() {
+			pr.observeHeartbeatLatency(raftMsg, false, time.Now())
+		}
 		if err := pr.rn.Step(msg); err != nil {
 			pr.logger.Error("fail to step raft",
 				zap.Error(err))
@@ -396,10 +457,20 @@ func (pr *replica) handleTick(items []interface{}) bool {
 	if err != nil {
 		return false
 	}
+	if pr.isHibernated() {
+		pr.handleHibernatedTick()
+		return true
+	}
 	for i := int64(0); i < n; i++ {
 		pr.rn.Tick()
 		atomic.AddUint64(&pr.tickHandledCount, 1)
 	}
+	pr.checkQuorumLoss()
+	pr.releaseDeadlineExceededProposals()
+	pr.maybeTransferWitnessLeader()
+	pr.maybeTransferIOFailedLeader()
+	pr.maybeHibernate(int(n))
+	atomic.StoreUint64(&pr.applyBacklog, pr.getApplyLag())
 
 	return true
 }
@@ -457,20 +528,47 @@ func (pr *replica) handleSnapshotStatus(items []interface{}) bool {
 }
 
 func (pr *replica) prophetHeartbeat() {
+	pr.doProphetHeartbeat(true)
+}
+
+// maybeProphetHeartbeat sends the periodic heartbeat. If the delta heartbeat is
+// enabled, the heartbeat is skipped if nothing notable changed since the last
+// heartbeat and the full sync duration is not reached.
+func (pr *replica) maybeProphetHeartbeat() {
+	pr.doProphetHeartbeat(!pr.cfg.Replication.DeltaShardHeartbeat)
+}
+
+func (pr *replica) doProphetHeartbeat(force bool) {
 	if !pr.isLeader() {
 		return
 	}
+	now := pr.store.now()
 	shard := pr.getShard()
+	blockingReasons, blockingChanged := pr.sm.blocking.get()
 	req := rpcpb.ShardHeartbeatReq{
 		Term:            pr.rn.BasicStatus().Term,
 		Leader:          &pr.replica,
 		StoreID:         pr.storeID,
 		DownReplicas:    pr.collectDownReplicas(),
 		PendingReplicas: pr.collectPendingReplicas(),
-		Stats:           pr.stats.heartbeatState(),
+		Stats:           pr.stats.heartbeatState(now),
 		GroupKey:        pr.groupController.getShardGroupKey(shard),
 		Lease:           pr.getLease(),
+		BlockingReasons: blockingReasons,
+	}
+	digest := newHeartbeatDigest(now, shard, req)
+	digest.blockingChanged = blockingChanged
+	if !force &&
+		now.Sub(pr.lastHeartbeat.time) < pr.cfg.Replication.ShardHeartbeatFullSyncDuration.Duration &&
+		!pr.lastHeartbeat.changed(digest,
+			uint64(pr.cfg.Replication.ShardHeartbeatBytesThreshold),
+			pr.cfg.Replication.ShardHeartbeatKeysThreshold) {
+		return
 	}
+	pr.stats.prophetHeartbeatTime = uint64(now.Unix())
+	pr.stats.resetReadLatency()
+	pr.lastHeartbeat = digest
+
 	pr.logger.Debug("start send shard heartbeat")
 	if err := pr.prophetClient.ShardHeartbeat(shard, req); err != nil {
 		pr.logger.Error("fail to send heartbeat to prophet",
@@ -503,37 +601,18 @@ func (pr *replica) doCheckLogCompact(progresses map[uint64]trackerPkg.Progress,
 		metric.ObserveRaftLogLag(lastIndex - minReplicatedIndex)
 	}
 
-	compactIndex := minReplicatedIndex
+	policy := pr.getLogGCPolicy()
 	appliedIndex := pr.appliedIndex
 	firstIndex := pr.getFirstIndex()
-	if minReplicatedIndex < firstIndex ||
-		minReplicatedIndex-firstIndex <= pr.store.cfg.Raft.RaftLog.CompactThreshold {
-		pr.logger.Debug("maybe skip requesting log compaction",
-			zap.Uint64("min-replicated-index", minReplicatedIndex),
-			zap.Uint64("applied-index", minReplicatedIndex),
-			zap.Uint64("last-index", lastIndex),
-			zap.Uint64("first-index", firstIndex),
-			zap.Uint64("threshold", pr.store.cfg.Raft.RaftLog.CompactThreshold))
-		compactIndex = 0
-	}
-
-	// check wether to force compaction or not
-	if compactIndex == 0 &&
-		appliedIndex > firstIndex &&
-		appliedIndex-firstIndex >= pr.feature.ForceCompactCount {
-		compactIndex = appliedIndex
-	} else if compactIndex == 0 &&
-		pr.stats.raftLogSizeHint >= pr.feature.ForceCompactBytes {
-		compactIndex = appliedIndex
-	}
-
+	compactIndex, reason := policy.compactIndex(firstIndex, appliedIndex, lastIndex,
+		minReplicatedIndex, pr.stats.raftLogSizeHint)
 	if compactIndex == 0 {
 		pr.logger.Debug("requesting log compaction skipped",
 			zap.Uint64("min-replicated-index", minReplicatedIndex),
-			zap.Uint64("applied-index", minReplicatedIndex),
+			zap.Uint64("applied-index", appliedIndex),
 			zap.Uint64("last-index", lastIndex),
 			zap.Uint64("first-index", firstIndex),
-			zap.Uint64("threshold", pr.store.cfg.Raft.RaftLog.CompactThreshold))
+			zap.Uint64("threshold", policy.CompactThreshold))
 		return
 	}
 
@@ -546,7 +625,9 @@ func (pr *replica) doCheckLogCompact(progresses map[uint64]trackerPkg.Progress,
 		return
 	}
 	pr.logger.Info("requesting log compaction",
-		log.IndexField(compactIndex))
+		log.IndexField(compactIndex),
+		zap.String("reason", reason))
+	metric.IncRaftLogGC(reason)
 	pr.addAdminRequest(rpcpb.CmdCompactLog, &rpcpb.CompactLogRequest{
 		CompactIndex: compactIndex,
 	})
@@ -592,6 +673,7 @@ func (pr *replica) doLogCompaction(index uint64) error {
 			Data: protoc.MustMarshal(&si),
 		},
 	}
+	pr.sealLogSegments(index)
 	wc := pr.logdb.NewWorkerContext()
 	defer wc.Close()
 	if err := pr.logdb.SaveRaftState(pr.shardID, pr.replicaID, rd, wc); err != nil {
@@ -599,6 +681,7 @@ func (pr *replica) doLogCompaction(index uint64) error {
 	}
 	pr.logger.Info("dummy snapshot saved",
 		log.IndexField(index))
+	pr.shrinkRaftLogSizeHint(index)
 	// update LogReader's range info to make the compacted entries invisible to
 	// raft.
 	if err := pr.lr.Compact(index); err != nil {
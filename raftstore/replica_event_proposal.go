@@ -14,6 +14,9 @@
 package raftstore
 
 import (
+	"math"
+	"time"
+
 	"github.com/cockroachdb/errors"
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/log"
@@ -32,6 +35,8 @@ var (
 	ErrPendingConfigChange        = errors.New("pending config change")
 	ErrDuplicatedRequest          = errors.New("duplicated config change request")
 	ErrLearnerOnlyChange          = errors.New("learner only change")
+	ErrRemoveLastVoter            = errors.New("removing the last voter")
+	ErrLiveQuorumLost             = errors.New("no live quorum after config change")
 )
 
 type tracker = trackerPkg.ProgressTracker
@@ -62,23 +67,39 @@ const (
 	proposalNormal
 	proposalConfigChange
 	requestTransferLeader
+	requestSplitShard
 )
 
 // FIXME: fix the len == 0 and len() > 0 check below
 func (pr *replica) handleRequest(items []interface{}) bool {
 	if size := pr.requests.Len(); size > 0 {
+		pr.wakeUp()
+		pr.hibernate.quietTicks = 0
 		n, err := pr.requests.Get(readyBatchSize, items)
 		if err != nil {
 			return false
 		}
+		pr.tuneProposalBatch()
+		now := pr.store.now()
 		for i := int64(0); i < n; i++ {
 			req := items[i].(reqCtx)
+			// the client has given up the request, drop it before it's proposed
+			if req.deadlineExceeded(now) {
+				if ce := pr.logger.Check(zap.DebugLevel, "drop request with deadline exceeded"); ce != nil {
+					ce.Write(log.RequestIDField(req.req.ID))
+				}
+				respDeadlineExceeded(pr.shardID, req.req, req.cb)
+				continue
+			}
 			if ce := pr.logger.Check(zap.DebugLevel, "push to proposal batch"); ce != nil {
-				ce.Write(log.HexField("id", req.req.ID))
+				ce.Write(log.RequestIDField(req.req.ID))
 			}
 			pr.incomingProposals.push(pr.group, req)
 		}
-	} else {
+		if pr.delayProposals(now) {
+			return true
+		}
+	} else if pr.incomingProposals.isEmpty() || pr.delayProposals(pr.store.now()) {
 		return false
 	}
 
@@ -100,21 +121,46 @@ func (pr *replica) handleRequest(items []interface{}) bool {
 	return true
 }
 
+// releaseDeadlineExceededProposals responds and releases the pending proposals
+// given up by the clients, so they are not held until they are applied, which
+// may never happen if the quorum is lost.
+func (pr *replica) releaseDeadlineExceededProposals() {
+	if n := pr.pendingProposals.releaseDeadlineExceeded(pr.shardID, pr.store.now()); n > 0 {
+		if ce := pr.logger.Check(zap.DebugLevel, "pending proposals released with deadline exceeded"); ce != nil {
+			ce.Write(zap.Int("count", n))
+		}
+	}
+}
+
 func (pr *replica) propose(c batch) {
 	if !pr.checkProposal(c) {
 		return
 	}
 	defer pr.notifyWorker()
 
+	if pr.rejectQuorumLost(c) {
+		return
+	}
+	if pr.rejectIOFailed(c) {
+		return
+	}
+	pr.recordLoad(c)
+
 	isConfChange := false
 	madeProposal := false
 	switch pr.getRequestType(c.requestBatch) {
 	case readIndex:
-		pr.execReadIndex(c)
+		if !pr.shedReads(c) {
+			pr.execReadIndex(c)
+		}
 	case proposalNormal:
-		madeProposal = pr.proposeNormal(c)
+		if pr.admitWrite(c) {
+			madeProposal = pr.proposeNormal(c)
+		}
 	case requestTransferLeader:
 		madeProposal = pr.requestTransferLeader(c)
+	case requestSplitShard:
+		pr.requestSplitShard(c)
 	case proposalConfigChange:
 		isConfChange = true
 		pr.metrics.admin.confChange++
@@ -151,11 +197,27 @@ func (pr *replica) execReadIndex(c batch) {
 	if c.tp != read {
 		panic("not a read index request")
 	}
+	if pr.isWitness() {
+		// the witness has no user data to serve the read
+		pr.respNotLeader(c)
+		return
+	}
 	if !pr.isLeader() {
+		if c.isFollowerRead() {
+			pr.execFollowerReadIndex(c)
+			return
+		}
 		pr.respNotLeader(c)
 		return
 	}
+	if pr.execLeaderLeaseRead(c) {
+		return
+	}
 
+	var start time.Time
+	if pr.leaderLease.enabled() {
+		start = time.Now()
+	}
 	prevPendingReadCount := pr.pendingReadCount()
 	prevReadyReadCount := pr.readyReadCount()
 
@@ -170,26 +232,138 @@ func (pr *replica) execReadIndex(c batch) {
 		return
 	}
 	pr.metrics.propose.readIndex++
+	pr.tracer.recordBatch(c.requestBatch.Requests, RequestReadIndex, pr.shardID, 0)
 	if ce := pr.logger.Check(zap.DebugLevel, "call read index"); ce != nil {
-		ce.Write(log.HexField("id", c.getRequestID()))
+		ce.Write(log.RequestIDField(c.getRequestID()))
+	}
+
+	pr.pendingReads.appendWithStart(c, start)
+}
+
+// execLeaderLeaseRead serves the read within the leader lease, the read is
+// executed once the current commit index is applied. Returns false to fall
+// back to the read index if the lease is uncertain, e.g. it's expired or a
+// leadership transfer is in flight.
+func (pr *replica) execLeaderLeaseRead(c batch) bool {
+	if !pr.leaderLease.enabled() {
+		return false
+	}
+	status := pr.rn.BasicStatus()
+	if status.LeadTransferee != 0 ||
+		!pr.leaderLease.valid(pr.store.now()) {
+		return false
+	}
+
+	pr.metrics.propose.readLocal++
+	pr.tracer.recordBatch(c.requestBatch.Requests, RequestReadIndex, pr.shardID, status.Commit)
+	if ce := pr.logger.Check(zap.DebugLevel, "call leader lease read"); ce != nil {
+		ce.Write(log.RequestIDField(c.getRequestID()),
+			log.IndexField(status.Commit))
+	}
+
+	pr.pendingReads.appendLeaseRead(c, status.Commit)
+	pr.maybeExecRead()
+	return true
+}
+
+// execFollowerReadIndex sends the read index request of the follower read to
+// the leader, the reads are executed on the local state once the returned read
+// index is applied. The pending reads are responded with NotLeader if the
+// leader changes before the read index is returned.
+func (pr *replica) execFollowerReadIndex(c batch) {
+	if pr.getLeaderReplicaID() == 0 {
+		pr.respNotLeader(c)
+		return
+	}
+
+	pr.rn.ReadIndex(c.getRequestID())
+	pr.metrics.propose.followerReadIndex++
+	pr.tracer.recordBatch(c.requestBatch.Requests, RequestReadIndex, pr.shardID, 0)
+	if ce := pr.logger.Check(zap.DebugLevel, "call follower read index"); ce != nil {
+		ce.Write(log.RequestIDField(c.getRequestID()),
+			zap.Uint64("leader", pr.getLeaderReplicaID()))
 	}
 
 	pr.pendingReads.append(c)
 }
 
+// commitTimeState the state of the commit times stamped by the leader, it's
+// only accessed by the event worker.
+type commitTimeState struct {
+	// term the term the last commit time is recovered in
+	term uint64
+	last int64
+}
+
+// stampCommitTime sets the leader's clock to the batch, the time is replicated
+// with the raft entry, so all replicas expose the same time of the entry. Like
+// the hybrid logical clock, the commit time never goes backwards in the log of
+// the shard: it's the wall clock unless the clock is behind the commit time of
+// any previous entry, then it's the last commit time plus 1ns. The leader of a
+// new term recovers the last commit time from the entries in its log that are
+// not applied yet, the applied ones are tracked by the state machine. The only
+// exception is a leader restarted with its clock behind the commit times it
+// applied before the restart, their entries may be compacted from the log.
+func (pr *replica) stampCommitTime(req *rpcpb.RequestBatch) {
+	if term := pr.rn.BasicStatus().Term; term != pr.commitTime.term {
+		pr.commitTime.term = term
+		pr.recoverCommitTime()
+	}
+	if last := pr.sm.getLastCommitTime(); last > pr.commitTime.last {
+		pr.commitTime.last = last
+	}
+	now := pr.store.now().UnixNano()
+	if now <= pr.commitTime.last {
+		now = pr.commitTime.last + 1
+	}
+	pr.commitTime.last = now
+	req.Header.CommitTime = now
+}
+
+// recoverCommitTime recovers the last commit time from the entries appended to
+// the log but not applied by the state machine.
+func (pr *replica) recoverCommitTime() {
+	applied, _ := pr.sm.getAppliedIndexTerm()
+	last, err := pr.lr.LastIndex()
+	if err != nil || last <= applied {
+		return
+	}
+	entries, err := pr.lr.Entries(applied+1, last+1, math.MaxUint64)
+	if err != nil {
+		pr.logger.Error("failed to recover the last commit time",
+			zap.Error(err))
+		return
+	}
+	ctx := newApplyContext()
+	for _, entry := range entries {
+		if len(entry.Data) == 0 {
+			continue
+		}
+		ctx.initialize(entry)
+		if t := ctx.req.Header.CommitTime; t > pr.commitTime.last {
+			pr.commitTime.last = t
+		}
+	}
+}
+
 func (pr *replica) proposeNormal(c batch) bool {
 	if !pr.isLeader() {
 		pr.respNotLeader(c)
 		return false
 	}
 
-	data := protoc.MustMarshal(&c.requestBatch)
+	pr.stampCommitTime(&c.requestBatch)
+	stampAppLeaseRequest(&c.requestBatch, pr.store.now())
+	data := pr.compressRequestBatch(&c.requestBatch, protoc.MustMarshal(&c.requestBatch))
 	size := len(data)
 	metric.ObserveProposalBytes(int64(size))
 
 	if size > int(pr.cfg.Raft.MaxEntryBytes) {
-		c.respLargeRaftEntrySize(pr.shardID, uint64(size))
-		return false
+		if size > int(pr.cfg.Raft.GetMaxProposalBytes()) {
+			c.respLargeRaftEntrySize(pr.shardID, uint64(size))
+			return false
+		}
+		return pr.proposeChunks(c, data)
 	}
 
 	idx := pr.nextProposalIndex()
@@ -201,8 +375,9 @@ func (pr *replica) proposeNormal(c batch) bool {
 		pr.respNotLeader(c)
 		return false
 	}
+	pr.tracer.recordBatch(c.requestBatch.Requests, RequestProposed, pr.shardID, idx)
 	if ce := pr.logger.Check(zap.DebugLevel, "made a proposal"); ce != nil {
-		ce.Write(
+		ce.Write(log.RequestIDField(c.getRequestID()),
 			log.ShardIDField(pr.shardID),
 			log.ReplicaIDField(pr.replicaID),
 			log.IndexField(idx))
@@ -211,6 +386,58 @@ func (pr *replica) proposeNormal(c batch) bool {
 	return true
 }
 
+// proposeChunks proposes the request batch larger than the max entry size as
+// multiple chunk entries, the entries are reassembled by the state machine when
+// the last chunk is applied.
+func (pr *replica) proposeChunks(c batch, data []byte) bool {
+	entries := splitProposal(c.requestBatch.Header, data, int(pr.cfg.Raft.MaxEntryBytes))
+	if len(entries) == 0 {
+		c.respLargeRaftEntrySize(pr.shardID, uint64(len(data)))
+		return false
+	}
+
+	for i, entry := range entries {
+		idx := pr.nextProposalIndex()
+		if err := pr.rn.Propose(entry); err != nil {
+			pr.abortChunks(i)
+			c.resp(errorOtherCMDResp(err))
+			return false
+		}
+		if idx == pr.nextProposalIndex() {
+			pr.abortChunks(i)
+			pr.respNotLeader(c)
+			return false
+		}
+	}
+	idx := pr.nextProposalIndex() - 1
+	pr.tracer.recordBatch(c.requestBatch.Requests, RequestProposed, pr.shardID, idx)
+	if ce := pr.logger.Check(zap.DebugLevel, "made a chunked proposal"); ce != nil {
+		ce.Write(log.RequestIDField(c.getRequestID()),
+			log.ShardIDField(pr.shardID),
+			log.ReplicaIDField(pr.replicaID),
+			log.IndexField(idx),
+			zap.Int("chunks", len(entries)))
+	}
+	pr.metrics.propose.normal++
+	return true
+}
+
+// abortChunks proposes an empty entry after the chunks of a request batch
+// failed to propose, the state machine drops the incomplete chunks once any
+// entry other than the next chunk is applied. If the abort entry is dropped as
+// well, the incomplete chunks are dropped by the empty entry proposed by the
+// next leader.
+func (pr *replica) abortChunks(proposed int) {
+	if proposed == 0 {
+		return
+	}
+	if err := pr.rn.Propose(nil); err != nil {
+		pr.logger.Warn("failed to abort the proposed chunks",
+			zap.Int("chunks", proposed),
+			zap.Error(err))
+	}
+}
+
 func (pr *replica) proposeConfChange(c batch) bool {
 	if !pr.isLeader() {
 		pr.respNotLeader(c)
@@ -223,12 +450,16 @@ func (pr *replica) proposeConfChange(c batch) bool {
 			zap.Uint64("conf-index", pr.rn.PendingConfIndex()),
 			zap.Uint64("applied-index", pr.appliedIndex))
 		c.respOtherError(ErrPendingConfigChange)
+		pr.sm.blocking.record(metapb.ShardOperation_ConfChange, blockingPendingConfigChange)
 		return false
 	}
 
 	if err := pr.proposeConfChangeInternal(c); err != nil {
 		pr.logger.Error("fail to proposal conf change",
 			zap.Error(err))
+		if err != errNotLeader {
+			pr.sm.blocking.record(metapb.ShardOperation_ConfChange, err.Error())
+		}
 		return false
 	}
 	return true
@@ -236,6 +467,7 @@ func (pr *replica) proposeConfChange(c batch) bool {
 
 func (pr *replica) proposeConfChangeInternal(c batch) error {
 	req := c.requestBatch.GetConfigChangeRequest()
+	pr.stampCommitTime(&c.requestBatch)
 	cc := pr.toConfChangeI(req, protoc.MustMarshal(&c.requestBatch))
 	var changes []rpcpb.ConfigChangeRequest
 	changes = append(changes, req)
@@ -297,6 +529,8 @@ func (pr *replica) doTransferLeader(peer Replica) {
 	// Broadcast heartbeat to make sure followers commit the entries immediately.
 	// It's only necessary to ping the target peer, but ping all for simplicity.
 	pr.rn.Ping()
+	// the transferee may be elected before the lease expires
+	pr.leaderLease.invalidate(pr.store.now())
 	pr.rn.TransferLeader(peer.ID)
 	pr.metrics.propose.transferLeader++
 }
@@ -306,6 +540,9 @@ func (pr *replica) isTransferLeaderAllowed(newLeader Replica) bool {
 	if _, ok := status.Progress[newLeader.ID]; !ok {
 		return false
 	}
+	if isWitnessReplica(pr.getShard(), newLeader.ID) {
+		return false
+	}
 	for _, p := range status.Progress {
 		if p.State == trackerPkg.StateSnapshot {
 			return false
@@ -345,11 +582,21 @@ func isValidConfigChangeRequest(ccr rpcpb.ConfigChangeRequest) bool {
 		ccr.Replica.Role == metapb.ReplicaRole_Voter {
 		return true
 	}
+	// add witness
+	if ccr.ChangeType == metapb.ConfigChangeType_AddNode &&
+		ccr.Replica.Role == metapb.ReplicaRole_Witness {
+		return true
+	}
 	// add learner
 	if ccr.ChangeType == metapb.ConfigChangeType_AddLearnerNode &&
 		ccr.Replica.Role == metapb.ReplicaRole_Learner {
 		return true
 	}
+	// add observer
+	if ccr.ChangeType == metapb.ConfigChangeType_AddLearnerNode &&
+		ccr.Replica.Role == metapb.ReplicaRole_Observer {
+		return true
+	}
 	return false
 }
 
@@ -427,6 +674,55 @@ func (pr *replica) checkConfChange(changes []rpcpb.ConfigChangeRequest,
 		return ErrLearnerOnlyChange
 	}
 
+	if !pr.cfg.Replication.UnsafeConfigChange {
+		return pr.checkLiveQuorum(changes)
+	}
+	return nil
+}
+
+// checkLiveQuorum makes sure that the voters left after the changes still have
+// a quorum of recently active members. Only changes removing or demoting
+// voters are checked, the leader itself is always considered as active.
+func (pr *replica) checkLiveQuorum(changes []rpcpb.ConfigChangeRequest) error {
+	voters := pr.rn.NewChanger().Tracker.Config.Voters.IDs()
+	removingVoter := false
+	for _, cp := range changes {
+		_, isVoter := voters[cp.Replica.ID]
+		switch cp.ChangeType {
+		case metapb.ConfigChangeType_RemoveNode,
+			metapb.ConfigChangeType_AddLearnerNode:
+			if isVoter {
+				delete(voters, cp.Replica.ID)
+				removingVoter = true
+			}
+		case metapb.ConfigChangeType_AddNode:
+			voters[cp.Replica.ID] = struct{}{}
+		}
+	}
+	if !removingVoter {
+		return nil
+	}
+	if len(voters) == 0 {
+		return ErrRemoveLastVoter
+	}
+
+	live := 0
+	progress := pr.rn.Status().Progress
+	for id := range voters {
+		if id == pr.replicaID {
+			live++
+		} else if p, ok := progress[id]; ok && p.RecentActive {
+			live++
+		}
+	}
+	if live < len(voters)/2+1 {
+		pr.logger.Error("config change rejected",
+			log.ConfigChangesField("changes", changes),
+			zap.Int("voters", len(voters)),
+			zap.Int("live-voters", live),
+			zap.Error(ErrLiveQuorumLost))
+		return ErrLiveQuorumLost
+	}
 	return nil
 }
 
@@ -459,6 +755,8 @@ func (pr *replica) getRequestType(req rpcpb.RequestBatch) requestType {
 			return proposalConfigChange
 		case rpcpb.CmdTransferLeader:
 			return requestTransferLeader
+		case rpcpb.CmdSplitShard:
+			return requestSplitShard
 		default:
 			return proposalNormal
 		}
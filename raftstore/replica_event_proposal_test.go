@@ -14,9 +14,12 @@
 package raftstore
 
 import (
+	"errors"
 	"math"
 	"testing"
+	"time"
 
+	"github.com/fagongzi/util/protoc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/etcd/raft/v3"
@@ -25,6 +28,7 @@ import (
 	"github.com/matrixorigin/matrixcube/components/log"
 	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/logdb"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
@@ -74,11 +78,31 @@ func TestIsValidConfigChangeRequest(t *testing.T) {
 			metapb.Replica{Role: metapb.ReplicaRole_Learner},
 			false,
 		},
+		{
+			metapb.ConfigChangeType_AddNode,
+			metapb.Replica{Role: metapb.ReplicaRole_Witness},
+			true,
+		},
+		{
+			metapb.ConfigChangeType_AddLearnerNode,
+			metapb.Replica{Role: metapb.ReplicaRole_Witness},
+			false,
+		},
 		{
 			metapb.ConfigChangeType_AddLearnerNode,
 			metapb.Replica{Role: metapb.ReplicaRole_Learner},
 			true,
 		},
+		{
+			metapb.ConfigChangeType_AddLearnerNode,
+			metapb.Replica{Role: metapb.ReplicaRole_Observer},
+			true,
+		},
+		{
+			metapb.ConfigChangeType_AddNode,
+			metapb.Replica{Role: metapb.ReplicaRole_Observer},
+			false,
+		},
 		{
 			metapb.ConfigChangeType_AddLearnerNode,
 			metapb.Replica{Role: metapb.ReplicaRole_Voter},
@@ -234,6 +258,10 @@ func TestGetRequestType(t *testing.T) {
 			newTestAdminRequestBatch("", 0, rpcpb.CmdBatchSplit, nil),
 			proposalNormal,
 		},
+		{
+			newTestAdminRequestBatch("", 0, rpcpb.CmdSplitShard, nil),
+			requestSplitShard,
+		},
 	}
 
 	for _, tt := range tests {
@@ -381,3 +409,355 @@ func TestInvalidConfigChangeRequestIsRejected(t *testing.T) {
 		assert.Equal(t, tt.err, result, "idx: %d", idx)
 	}
 }
+
+func TestCheckLiveQuorum(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tests := []struct {
+		voters []uint64
+		active []uint64
+		req    rpcpb.ConfigChangeRequest
+		unsafe bool
+		err    error
+	}{
+		{
+			// the last voter is never removed, even with the unsafe config change
+			voters: []uint64{1},
+			req: rpcpb.ConfigChangeRequest{
+				ChangeType: metapb.ConfigChangeType_RemoveNode,
+				Replica:    metapb.Replica{ID: 1},
+			},
+			unsafe: true,
+			err:    errors.New("removed all voters"),
+		},
+		{
+			voters: []uint64{1, 2, 3},
+			active: []uint64{2, 3},
+			req: rpcpb.ConfigChangeRequest{
+				ChangeType: metapb.ConfigChangeType_RemoveNode,
+				Replica:    metapb.Replica{ID: 3},
+			},
+		},
+		{
+			voters: []uint64{1, 2, 3},
+			active: []uint64{2},
+			req: rpcpb.ConfigChangeRequest{
+				ChangeType: metapb.ConfigChangeType_RemoveNode,
+				Replica:    metapb.Replica{ID: 2},
+			},
+			err: ErrLiveQuorumLost,
+		},
+		{
+			voters: []uint64{1, 2, 3},
+			active: []uint64{2},
+			req: rpcpb.ConfigChangeRequest{
+				ChangeType: metapb.ConfigChangeType_RemoveNode,
+				Replica:    metapb.Replica{ID: 2},
+			},
+			unsafe: true,
+		},
+		{
+			voters: []uint64{1, 2, 3},
+			req: rpcpb.ConfigChangeRequest{
+				ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+				Replica:    metapb.Replica{ID: 4, Role: metapb.ReplicaRole_Learner},
+			},
+		},
+		{
+			voters: []uint64{1, 2, 3},
+			active: []uint64{3},
+			req: rpcpb.ConfigChangeRequest{
+				ChangeType: metapb.ConfigChangeType_AddLearnerNode,
+				Replica:    metapb.Replica{ID: 3, Role: metapb.ReplicaRole_Learner},
+			},
+			err: ErrLiveQuorumLost,
+		},
+	}
+
+	for idx, tt := range tests {
+		func() {
+			l := log.GetDefaultZapLogger()
+			kv := getTestStorage()
+			defer kv.Close()
+			ldb := logdb.NewKVLogDB(kv, l)
+			defer ldb.Close()
+
+			lr := NewLogReader(l, 1, 1, ldb)
+			lr.SetConfState(raftpb.ConfState{Voters: tt.voters})
+			rn, err := raft.NewRawNode(&raft.Config{
+				ID:              1,
+				ElectionTick:    10,
+				HeartbeatTick:   1,
+				Storage:         lr,
+				MaxInflightMsgs: 100,
+			})
+			require.NoError(t, err)
+			require.NoError(t, rn.Campaign())
+			for _, id := range tt.voters[1:] {
+				require.NoError(t, rn.Step(raftpb.Message{
+					Type: raftpb.MsgVoteResp, From: id, To: 1, Term: rn.Status().Term}))
+			}
+			require.Equal(t, raft.StateLeader, rn.Status().RaftState)
+			for _, id := range tt.active {
+				require.NoError(t, rn.Step(raftpb.Message{
+					Type: raftpb.MsgHeartbeatResp, From: id, To: 1, Term: rn.Status().Term}))
+			}
+
+			r := replica{
+				logger:    l,
+				replicaID: 1,
+				rn:        rn,
+			}
+			r.cfg.Replication.AllowRemoveLeader = true
+			r.cfg.Replication.UnsafeConfigChange = tt.unsafe
+			cci := r.toConfChangeI(tt.req, nil)
+			assert.Equal(t, tt.err, r.checkConfChange([]rpcpb.ConfigChangeRequest{tt.req}, cci), "idx: %d", idx)
+		}()
+	}
+}
+
+func TestStampCommitTime(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	defer pr.readStopper.Stop()
+	// the entries stamped by the previous leader whose clock is ahead
+	ahead := time.Now().Add(time.Hour).UnixNano()
+	newEntry := func(index uint64, commitTime int64) raftpb.Entry {
+		req := rpcpb.RequestBatch{Header: rpcpb.RequestBatchHeader{ID: []byte{byte(index)}, CommitTime: commitTime}}
+		return raftpb.Entry{Index: index, Term: 1, Type: raftpb.EntryNormal, Data: protoc.MustMarshal(&req)}
+	}
+	entries := []raftpb.Entry{newEntry(1, ahead), newEntry(2, ahead-1), {Index: 3, Term: 1}}
+	require.NoError(t, pr.logdb.SaveRaftState(1, 1, raft.Ready{Entries: entries}, pr.logdb.NewWorkerContext()))
+	lr := NewLogReader(s.logger, 1, 1, pr.logdb)
+	require.NoError(t, lr.Append(entries))
+	lr.SetConfState(raftpb.ConfState{Voters: []uint64{1}})
+	lr.SetState(raftpb.HardState{Term: 1})
+	rn, err := raft.NewRawNode(&raft.Config{
+		ID:              1,
+		ElectionTick:    10,
+		HeartbeatTick:   1,
+		Storage:         lr,
+		MaxInflightMsgs: 100,
+	})
+	require.NoError(t, err)
+	require.NoError(t, rn.Campaign())
+	pr.rn = rn
+	pr.lr = lr
+
+	// recovered from the entries not applied yet
+	req := rpcpb.RequestBatch{}
+	pr.stampCommitTime(&req)
+	assert.Equal(t, ahead+1, req.Header.CommitTime)
+	pr.stampCommitTime(&req)
+	assert.Equal(t, ahead+2, req.Header.CommitTime)
+
+	// the applied entries are tracked by the state machine
+	pr.sm.lastCommitTime = ahead + 10
+	pr.stampCommitTime(&req)
+	assert.Equal(t, ahead+11, req.Header.CommitTime)
+
+	// the wall clock is used once it catches up
+	pr.commitTime.last = 0
+	pr.sm.lastCommitTime = 0
+	before := time.Now().UnixNano()
+	pr.stampCommitTime(&req)
+	assert.True(t, req.Header.CommitTime >= before)
+	assert.True(t, req.Header.CommitTime <= time.Now().UnixNano())
+
+	v := rpcpb.RequestBatch{}
+	protoc.MustUnmarshal(&v, protoc.MustMarshal(&req))
+	assert.Equal(t, req.Header.CommitTime, v.Header.CommitTime)
+}
+
+func TestExecFollowerReadIndex(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	defer pr.readStopper.Stop()
+	lr := NewLogReader(s.logger, 1, 1, pr.logdb)
+	lr.SetConfState(raftpb.ConfState{Voters: []uint64{1, 2, 3}})
+	rn, err := raft.NewRawNode(&raft.Config{
+		ID:              1,
+		ElectionTick:    10,
+		HeartbeatTick:   1,
+		Storage:         lr,
+		MaxInflightMsgs: 100,
+	})
+	require.NoError(t, err)
+	pr.rn = rn
+
+	var responses []rpcpb.ResponseBatch
+	cb := func(resp rpcpb.ResponseBatch) {
+		responses = append(responses, resp)
+	}
+	newReadBatch := func(id string, followerRead bool) batch {
+		return newBatch(s.logger, rpcpb.RequestBatch{
+			Header:   rpcpb.RequestBatchHeader{ID: []byte(id)},
+			Requests: []rpcpb.Request{{ID: []byte(id), Type: rpcpb.Read, FollowerRead: followerRead}},
+		}, cb, read, 0)
+	}
+
+	// no leader
+	pr.execReadIndex(newReadBatch("r1", true))
+	require.Equal(t, 1, len(responses))
+	assert.NotNil(t, responses[0].Responses[0].Error.NotLeader)
+
+	require.NoError(t, rn.Step(raftpb.Message{Type: raftpb.MsgHeartbeat, From: 2, To: 1, Term: 1}))
+	pr.setLeaderReplicaID(2)
+	rn.Ready()
+
+	// reads must be served by the leader
+	pr.execReadIndex(newReadBatch("r2", false))
+	require.Equal(t, 2, len(responses))
+	assert.NotNil(t, responses[1].Responses[0].Error.NotLeader)
+
+	pr.execReadIndex(newReadBatch("r3", true))
+	require.Equal(t, 2, len(responses))
+	assert.Equal(t, 1, len(pr.pendingReads.reads))
+	rd := rn.Ready()
+	require.Equal(t, 1, len(rd.Messages))
+	assert.Equal(t, raftpb.MsgReadIndex, rd.Messages[0].Type)
+	assert.Equal(t, uint64(2), rd.Messages[0].To)
+
+	// the read index returned by the leader
+	require.NoError(t, rn.Step(raftpb.Message{Type: raftpb.MsgReadIndexResp, From: 2, To: 1, Term: 1,
+		Index: 10, Entries: []raftpb.Entry{{Data: []byte("r3")}}}))
+	rd = rn.Ready()
+	require.Equal(t, 1, len(rd.ReadStates))
+	pr.pendingReads.ready(rd.ReadStates[0])
+	assert.Equal(t, uint64(10), pr.pendingReads.reads[0].index)
+}
+
+func TestExecLeaderLeaseRead(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	defer pr.readStopper.Stop()
+	lr := NewLogReader(s.logger, 1, 1, pr.logdb)
+	require.NoError(t, lr.ApplySnapshot(raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{
+		Index: 10, Term: 1, ConfState: raftpb.ConfState{Voters: []uint64{1, 2, 3}}}}))
+	lr.SetState(raftpb.HardState{Term: 1, Commit: 10})
+	rn, err := raft.NewRawNode(&raft.Config{
+		ID:              1,
+		ElectionTick:    10,
+		HeartbeatTick:   1,
+		Storage:         lr,
+		MaxInflightMsgs: 100,
+	})
+	require.NoError(t, err)
+	pr.rn = rn
+	pr.setLeaderReplicaID(1)
+
+	var responses []rpcpb.ResponseBatch
+	cb := func(resp rpcpb.ResponseBatch) {
+		responses = append(responses, resp)
+	}
+	newReadBatch := func(id string) batch {
+		return newBatch(s.logger, rpcpb.RequestBatch{
+			Header:   rpcpb.RequestBatchHeader{ID: []byte(id)},
+			Requests: []rpcpb.Request{{ID: []byte(id), Type: rpcpb.Read}},
+		}, cb, read, 0)
+	}
+
+	// falls back to the read index without a valid lease, the raft node isn't
+	// the leader, so the read index request is dropped
+	pr.leaderLease = newLeaderLease(time.Second)
+	pr.execReadIndex(newReadBatch("r1"))
+	require.Equal(t, 1, len(responses))
+	assert.NotNil(t, responses[0].Responses[0].Error.NotLeader)
+
+	// served within the lease once the commit index is applied
+	pr.leaderLease.renew(time.Now())
+	pr.execReadIndex(newReadBatch("r2"))
+	require.Equal(t, 1, len(responses))
+	require.Equal(t, 1, len(pr.pendingReads.leaseReads))
+	assert.Equal(t, uint64(10), pr.pendingReads.leaseReads[0].index)
+	assert.Empty(t, pr.pendingReads.reads)
+	assert.Equal(t, uint64(1), pr.metrics.propose.readLocal)
+
+	// the leadership transfer invalidates the lease
+	pr.leaderLease.invalidate(time.Now())
+	pr.execReadIndex(newReadBatch("r3"))
+	require.Equal(t, 2, len(responses))
+	assert.Equal(t, 1, len(pr.pendingReads.leaseReads))
+}
+
+func TestProposeChunksAbortsProposedChunks(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	defer pr.readStopper.Stop()
+	lr := NewLogReader(s.logger, 1, 1, pr.logdb)
+	lr.SetConfState(raftpb.ConfState{Voters: []uint64{1}})
+	rn, err := raft.NewRawNode(&raft.Config{
+		ID:                        1,
+		ElectionTick:              10,
+		HeartbeatTick:             1,
+		Storage:                   lr,
+		MaxInflightMsgs:           100,
+		MaxUncommittedEntriesSize: 2048,
+	})
+	require.NoError(t, err)
+	require.NoError(t, rn.Campaign())
+	require.Equal(t, raft.StateLeader, rn.Status().RaftState)
+	pr.rn = rn
+	pr.setLeaderReplicaID(1)
+	pr.cfg.Raft.MaxEntryBytes = 1024
+
+	var responses []rpcpb.ResponseBatch
+	cb := func(resp rpcpb.ResponseBatch) {
+		responses = append(responses, resp)
+	}
+	req, data := newTestLargeRequestBatch("id", 4000)
+	// the uncommitted entries limit drops the third chunk
+	assert.False(t, pr.proposeChunks(newBatch(s.logger, req, cb, write, 0), data))
+	require.Equal(t, 1, len(responses))
+	assert.NotEmpty(t, responses[0].Header.Error.Message)
+
+	// the leader's empty entry, two chunks and the abort entry
+	entries := rn.Ready().Entries
+	require.Equal(t, 4, len(entries))
+	for _, entry := range entries[1:3] {
+		var chunk rpcpb.RequestBatch
+		protoc.MustUnmarshal(&chunk, entry.Data)
+		assert.NotNil(t, chunk.Chunk)
+	}
+	assert.Empty(t, entries[3].Data)
+}
+
+func TestHandleRequestDropsDeadlineExceeded(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	defer pr.readStopper.Stop()
+
+	var responses []rpcpb.ResponseBatch
+	cb := func(resp rpcpb.ResponseBatch) {
+		responses = append(responses, resp)
+	}
+	require.NoError(t, pr.requests.Put(newReqCtx(rpcpb.Request{ID: []byte("w"), Type: rpcpb.Write, Timeout: 1}, time.Now(), cb)))
+	require.NoError(t, pr.requests.Put(newReqCtx(rpcpb.Request{ID: []byte("r"), Type: rpcpb.Read, Timeout: 1}, time.Now(), cb)))
+	time.Sleep(time.Millisecond)
+
+	assert.True(t, pr.handleRequest(make([]interface{}, readyBatchSize)))
+	assert.Equal(t, int64(0), pr.requests.Len())
+	assert.Equal(t, 0, pr.incomingProposals.size())
+	require.Equal(t, 2, len(responses))
+	for idx, id := range []string{"w", "r"} {
+		require.Equal(t, 1, len(responses[idx].Responses))
+		assert.Equal(t, []byte(id), responses[idx].Responses[0].ID)
+		assert.Equal(t, &errorpb.DeadlineExceeded{ShardID: 1}, responses[idx].Header.Error.DeadlineExceeded)
+		assert.False(t, errorpb.Retryable(responses[idx].Header.Error))
+	}
+}
@@ -30,6 +30,9 @@ import (
 var (
 	// ErrUnknownReplica indicates that the replica is unknown.
 	ErrUnknownReplica = errors.New("unknown replica")
+	// ErrWitnessSnapshot indicates that the witness can't send the snapshot to
+	// the replica with the user data.
+	ErrWitnessSnapshot = errors.New("witness can't send snapshot")
 )
 
 func (pr *replica) handleRaftReady(wc *logdb.WorkerContext) error {
@@ -52,14 +55,15 @@ func (pr *replica) commitRaftReady(rd raft.Ready) {
 func (pr *replica) processReady(rd raft.Ready, wc *logdb.WorkerContext) error {
 	pr.handleRaftState(rd)
 	pr.sendRaftAppendLogMessages(rd)
-	if err := pr.saveRaftState(rd, wc); err != nil {
+	applied, err := pr.saveRaftState(rd, wc)
+	if err != nil {
 		return err
 	}
 	if err := pr.appendEntries(rd); err != nil {
 		return err
 	}
 	pr.sendRaftMessages(rd)
-	if err := pr.applyCommittedEntries(rd); err != nil {
+	if err := pr.applyCommittedEntries(rd, applied); err != nil {
 		return err
 	}
 	pr.handleReadyToRead(rd)
@@ -73,12 +77,14 @@ func (pr *replica) handleRaftState(rd raft.Ready) {
 	// etcd raft won't repeatedly return the same non-empty soft state
 	if rd.SoftState != nil {
 		pr.setLeaderReplicaID(rd.SoftState.Lead)
+		pr.leaderLease.invalidate(pr.store.now())
 		shard := pr.getShard()
 		// If we become leader, send heartbeat to pd
 		if rd.SoftState.RaftState == raft.StateLeader {
 			pr.logger.Info("********become leader now********")
 			pr.prophetHeartbeat()
 			pr.resetIncomingProposals()
+			pr.heartbeatLatency.reset()
 			if pr.aware != nil {
 				pr.aware.BecomeLeader(shard)
 			}
@@ -87,7 +93,7 @@ func (pr *replica) handleRaftState(rd raft.Ready) {
 			for _, r := range shard.Replicas {
 				if r.ID != pr.replicaID {
 					if _, has := pr.replicaHeartbeatsMap.Load(r.ID); !has {
-						pr.replicaHeartbeatsMap.Store(r.ID, time.Now())
+						pr.replicaHeartbeatsMap.Store(r.ID, pr.store.now())
 					}
 				}
 			}
@@ -137,31 +143,78 @@ func (pr *replica) appendEntries(rd raft.Ready) error {
 	return nil
 }
 
-func (pr *replica) saveRaftState(rd raft.Ready, wc *logdb.WorkerContext) error {
+// saveRaftState saves the raft state of the ready, the committed entries which
+// are already persisted are applied while the raft state is being saved by the
+// fsync pool. It returns the number of the committed entries applied.
+func (pr *replica) saveRaftState(rd raft.Ready, wc *logdb.WorkerContext) (int, error) {
 	if logdb.IsEmptyRaftReady(rd) {
-		return nil
+		return 0, nil
 	}
 
-	var startTime int64
+	start := time.Now()
 	if ce := pr.logger.Check(zap.DebugLevel,
 		"begin to save raft state"); ce != nil {
-		startTime = time.Now().UnixMilli()
+		ce.Write(log.ShardIDField(pr.shardID),
+			log.ReplicaIDField(pr.replicaID))
 	}
-	err := pr.logdb.SaveRaftState(pr.shardID, pr.replicaID, rd, wc)
-	if err != nil {
-		return err
+	wait := pr.logdb.SaveRaftStateAsync(pr.shardID, pr.replicaID, rd, wc)
+	applied := 0
+	var applyErr error
+	if pr.store.walSyncPool != nil {
+		applied = pr.pipelinedEntries(rd)
+		applyErr = pr.applyEntries(rd.CommittedEntries[:applied])
 	}
+	// the wait must be called even if the apply failed, the worker context is
+	// reused after the ready is handled
+	if err := wait(); err != nil {
+		return 0, err
+	}
+	if applyErr != nil {
+		return 0, applyErr
+	}
+	metric.ObserveRaftReadyStageDuration("persist", start)
 	if ce := pr.logger.Check(zap.DebugLevel,
 		"save raft state completed"); ce != nil {
-		cost := time.Now().UnixMilli() - startTime
-		ce.Write(zap.Uint64("cost-millisecond", uint64(cost)))
+		ce.Write(zap.Duration("cost", time.Since(start)),
+			zap.Int("pipelined-entries", applied))
 	}
 
 	if !raft.IsEmptyHardState(rd.HardState) {
 		pr.lastCommittedIndex = rd.HardState.Commit
 		pr.committedIndexes[pr.replicaID] = pr.lastCommittedIndex
 	}
-	return nil
+	return applied, nil
+}
+
+// pipelinedEntries returns the number of the leading committed entries of the
+// ready which can be applied while the raft state of the ready is being saved.
+// Only the entries already persisted by the previous readies are applied, and
+// the pipeline stops before the config change entries, which may destroy the
+// replica. The entries are also capped at the commit index persisted by the
+// previous readies, the applied index of the data storage may become durable
+// before the HardState of the ready, and the applied index beyond the persisted
+// commit index can't be restored on restart.
+func (pr *replica) pipelinedEntries(rd raft.Ready) int {
+	if !raft.IsEmptySnap(rd.Snapshot) || len(rd.CommittedEntries) == 0 {
+		return 0
+	}
+	persisted, err := pr.lr.LastIndex()
+	if err != nil {
+		return 0
+	}
+	if pr.lastCommittedIndex < persisted {
+		persisted = pr.lastCommittedIndex
+	}
+	// the entries of the ready may overwrite the persisted entries
+	if len(rd.Entries) > 0 && rd.Entries[0].Index <= persisted {
+		persisted = rd.Entries[0].Index - 1
+	}
+	for i, entry := range rd.CommittedEntries {
+		if entry.Index > persisted || entry.Type != raftpb.EntryNormal {
+			return i
+		}
+	}
+	return len(rd.CommittedEntries)
 }
 
 func (pr *replica) entriesToApply(entries []raftpb.Entry) []raftpb.Entry {
@@ -187,7 +240,10 @@ func (pr *replica) entriesToApply(entries []raftpb.Entry) []raftpb.Entry {
 	return []raftpb.Entry{}
 }
 
-func (pr *replica) applyCommittedEntries(rd raft.Ready) error {
+// applyCommittedEntries applies the snapshot and the committed entries of the
+// ready, the first applied committed entries are skipped as they are applied
+// by the pipeline.
+func (pr *replica) applyCommittedEntries(rd raft.Ready, applied int) error {
 	if !raft.IsEmptySnap(rd.Snapshot) {
 		if err := pr.applySnapshot(rd.Snapshot); err != nil {
 			return err
@@ -195,16 +251,27 @@ func (pr *replica) applyCommittedEntries(rd raft.Ready) error {
 		pr.pushedIndex = rd.Snapshot.Metadata.Index
 		pr.logger.Info("snapshot applied into the replica")
 	}
-	for _, entry := range rd.CommittedEntries {
-		pr.stats.raftLogSizeHint += uint64(len(entry.Data))
+	return pr.applyEntries(rd.CommittedEntries[applied:])
+}
+
+func (pr *replica) applyEntries(entries []raftpb.Entry) error {
+	bytes := uint64(0)
+	for _, entry := range entries {
+		bytes += uint64(len(entry.Data))
 	}
-	if len(rd.CommittedEntries) > 0 {
+	if len(entries) > 0 {
+		pr.stats.raftLogSizeHint += bytes
+		start := time.Now()
+		defer metric.ObserveRaftReadyStageDuration("apply", start)
+		defer func() {
+			pr.getBatchTuner().observeApply(pr.group, bytes, time.Since(start))
+		}()
 		var startTime int64
 		if ce := pr.logger.Check(zap.DebugLevel,
 			"begin to apply committed entries"); ce != nil {
 			startTime = time.Now().UnixMilli()
 		}
-		if err := pr.doApplyCommittedEntries(rd.CommittedEntries); err != nil {
+		if err := pr.doApplyCommittedEntries(entries); err != nil {
 			return err
 		}
 		if ce := pr.logger.Check(zap.DebugLevel,
@@ -212,7 +279,7 @@ func (pr *replica) applyCommittedEntries(rd raft.Ready) error {
 			cost := time.Now().UnixMilli() - startTime
 			ce.Write(
 				zap.Uint64("cost-millisecond", uint64(cost)),
-				zap.Uint64("entriy-count", uint64(len(rd.CommittedEntries))),
+				zap.Uint64("entriy-count", uint64(len(entries))),
 			)
 		}
 		pr.metrics.ready.commit++
@@ -222,7 +289,9 @@ func (pr *replica) applyCommittedEntries(rd raft.Ready) error {
 
 func (pr *replica) handleReadyToRead(rd raft.Ready) {
 	for _, state := range rd.ReadStates {
-		pr.pendingReads.ready(state)
+		if start, ok := pr.pendingReads.ready(state); ok && pr.isLeader() {
+			pr.leaderLease.renew(start)
+		}
 	}
 	if len(rd.ReadStates) > 0 {
 		pr.maybeExecRead()
@@ -268,26 +337,9 @@ func (pr *replica) sendMessage(msg raftpb.Message) {
 
 func (pr *replica) sendRaftMessage(msg raftpb.Message) error {
 	shard := pr.getShard()
-	to, ok := pr.getReplicaRecord(msg.To)
-	if !ok {
-		return errors.Wrapf(ErrUnknownReplica,
-			"shardID %d, replicaID: %d", pr.shardID, msg.To)
-	}
-
-	m := metapb.RaftMessage{
-		ShardID:     pr.shardID,
-		From:        pr.replica,
-		To:          to,
-		Start:       shard.Start,
-		End:         shard.End,
-		ShardEpoch:  shard.Epoch,
-		Group:       shard.Group,
-		Unique:      shard.Unique,
-		RuleGroups:  shard.RuleGroups,
-		Message:     msg,
-		CommitIndex: pr.lastCommittedIndex,
-		// FIXME: remove this hack
-		SendTime: uint64(time.Now().UnixMilli()),
+	m, err := pr.newRaftMessage(shard, msg)
+	if err != nil {
+		return err
 	}
 
 	// There could be two cases:
@@ -308,15 +360,50 @@ func (pr *replica) sendRaftMessage(msg raftpb.Message) error {
 	}
 
 	if msg.Type == raftpb.MsgSnap {
-		pr.logger.Info("sending a snapshot message")
-		pr.transport.SendSnapshot(m)
+		if isWitnessReplica(shard, msg.To) {
+			pr.sendWitnessSnapshot(m)
+		} else if pr.isWitness() {
+			// the snapshot of the witness has no user data
+			pr.rn.ReportSnapshot(msg.To, raft.SnapshotFailure)
+			return errors.Wrapf(ErrWitnessSnapshot,
+				"shardID %d, replicaID: %d", pr.shardID, msg.To)
+		} else {
+			pr.logger.Info("sending a snapshot message")
+			pr.transport.SendSnapshot(m)
+		}
 	} else {
 		pr.transport.Send(m)
+		pr.observeHeartbeatLatency(m, true, time.Now())
 	}
 	pr.updateMessageMetrics(msg)
 	return nil
 }
 
+// newRaftMessage wraps the raft message with the shard info
+func (pr *replica) newRaftMessage(shard Shard, msg raftpb.Message) (metapb.RaftMessage, error) {
+	to, ok := pr.getReplicaRecord(msg.To)
+	if !ok {
+		return metapb.RaftMessage{}, errors.Wrapf(ErrUnknownReplica,
+			"shardID %d, replicaID: %d", pr.shardID, msg.To)
+	}
+
+	return metapb.RaftMessage{
+		ShardID:     pr.shardID,
+		From:        pr.replica,
+		To:          to,
+		Start:       shard.Start,
+		End:         shard.End,
+		ShardEpoch:  shard.Epoch,
+		Group:       shard.Group,
+		Unique:      shard.Unique,
+		RuleGroups:  shard.RuleGroups,
+		Message:     msg,
+		CommitIndex: pr.lastCommittedIndex,
+		// FIXME: remove this hack
+		SendTime: uint64(time.Now().UnixMilli()),
+	}, nil
+}
+
 func (pr *replica) updateMessageMetrics(msg raftpb.Message) {
 	switch msg.Type {
 	case raftpb.MsgApp:
@@ -321,3 +321,52 @@ func TestEntriesToApply(t *testing.T) {
 		}()
 	}
 }
+
+func TestPipelinedEntries(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	lr := NewLogReader(log.GetPanicZapLogger(), 1, 1, nil)
+	// entries [1, 10] are persisted
+	lr.SetRange(1, 10)
+	pr := &replica{lr: lr, lastCommittedIndex: 10}
+	last, err := lr.LastIndex()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), last)
+
+	entries := func(first, last uint64) []raftpb.Entry {
+		var v []raftpb.Entry
+		for i := first; i <= last; i++ {
+			v = append(v, raftpb.Entry{Index: i})
+		}
+		return v
+	}
+	withConfChange := entries(5, 10)
+	withConfChange[3].Type = raftpb.EntryConfChangeV2
+
+	tests := []struct {
+		rd       raft.Ready
+		expected int
+	}{
+		{raft.Ready{}, 0},
+		{raft.Ready{CommittedEntries: entries(5, 10)}, 6},
+		{raft.Ready{CommittedEntries: entries(5, 12), Entries: entries(11, 12)}, 6},
+		{raft.Ready{CommittedEntries: entries(5, 10), Entries: entries(8, 12)}, 3},
+		{raft.Ready{CommittedEntries: withConfChange}, 3},
+		{raft.Ready{CommittedEntries: entries(5, 10),
+			Snapshot: raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 4}}}, 0},
+	}
+	for i, tt := range tests {
+		assert.Equal(t, tt.expected, pr.pipelinedEntries(tt.rd), "index %d", i)
+	}
+
+	// the commit index of the ready is not persisted yet, only the entries
+	// committed by the previous readies are applied
+	pr.lastCommittedIndex = 7
+	rd := raft.Ready{
+		HardState:        raftpb.HardState{Commit: 10},
+		CommittedEntries: entries(5, 10),
+	}
+	assert.Equal(t, 3, pr.pipelinedEntries(rd))
+	pr.lastCommittedIndex = 4
+	assert.Equal(t, 0, pr.pipelinedEntries(rd))
+}
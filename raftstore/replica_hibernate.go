@@ -0,0 +1,224 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync/atomic"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+	trackerPkg "go.etcd.io/etcd/raft/v3/tracker"
+	"go.uber.org/zap"
+)
+
+// hibernateState the hibernation state of the replica. The hibernated replica
+// stops ticking, the leader sends a keepalive message to the followers every
+// HibernateTicks ticks instead of the heartbeats, and the followers ack it. A
+// hibernated follower missing the keepalives for 2*HibernateTicks ticks wakes
+// up, and the requests and the raft messages except the heartbeats wake up the
+// replica. It's only
+// used by the event loop except the hibernated flag and the skipped ticks,
+// which are also accessed by the tick timer.
+type hibernateState struct {
+	// quietTicks the ticks since the shard became quiet, only used by the leader
+	quietTicks int
+	// skippedTicks the ticks skipped since hibernated or the last keepalive
+	skippedTicks uint64
+	hibernated   uint32
+}
+
+func (h *hibernateState) isHibernated() bool {
+	return atomic.LoadUint32(&h.hibernated) == 1
+}
+
+// set updates the hibernated flag and resets the ticks
+func (h *hibernateState) set(hibernated bool) {
+	var v uint32
+	if hibernated {
+		v = 1
+	}
+	h.quietTicks = 0
+	atomic.StoreUint64(&h.skippedTicks, 0)
+	atomic.StoreUint32(&h.hibernated, v)
+}
+
+// skipTick is called by the tick timer, returns true if the tick is skipped.
+// Every hibernateTicks ticks are still handled by the hibernated replica to
+// send or check the keepalive.
+func (h *hibernateState) skipTick(hibernateTicks int) bool {
+	if !h.isHibernated() {
+		return false
+	}
+	n := atomic.AddUint64(&h.skippedTicks, 1)
+	return n%uint64(hibernateTicks) != 0
+}
+
+func (pr *replica) isHibernated() bool {
+	return pr.hibernate.isHibernated()
+}
+
+// skipHibernatedTick returns true if the tick timer skips the tick, the timer
+// stops once the replica is stopped.
+func (pr *replica) skipHibernatedTick() bool {
+	return !pr.ticks.Disposed() &&
+		pr.hibernate.skipTick(pr.cfg.Raft.HibernateTicks)
+}
+
+// maybeHibernate is called by the leader on ticks, the leader hibernates once
+// the shard has been quiet for HibernateTicks ticks.
+func (pr *replica) maybeHibernate(ticks int) {
+	if pr.cfg.Raft.HibernateTicks == 0 {
+		return
+	}
+	if !pr.isQuiet() {
+		pr.hibernate.quietTicks = 0
+		return
+	}
+	pr.hibernate.quietTicks += ticks
+	if pr.hibernate.quietTicks < pr.cfg.Raft.HibernateTicks {
+		return
+	}
+	pr.hibernate.set(true)
+	pr.logger.Debug("shard hibernated")
+	pr.sendHibernateKeepalive()
+}
+
+// isQuiet returns true if the local replica is the leader, and all the
+// replicas have the same log as the leader, which are all committed and
+// applied by the leader, and there is no pending request.
+func (pr *replica) isQuiet() bool {
+	st := pr.rn.BasicStatus()
+	if st.RaftState != raft.StateLeader || st.LeadTransferee != 0 {
+		return false
+	}
+	last := pr.rn.LastIndex()
+	if st.Commit != last || pr.appliedIndex != last ||
+		pr.pendingProposals.size() > 0 || pr.pendingReads.size() > 0 ||
+		pr.requests.Len() > 0 {
+		return false
+	}
+	quiet := true
+	pr.rn.WithProgress(func(id uint64, _ raft.ProgressType, p trackerPkg.Progress) {
+		if p.Match != last {
+			quiet = false
+		}
+	})
+	return quiet
+}
+
+// handleHibernatedTick handles the ticks of the hibernated replica
+func (pr *replica) handleHibernatedTick() {
+	if pr.isLeader() {
+		pr.sendHibernateKeepalive()
+		return
+	}
+	if atomic.LoadUint64(&pr.hibernate.skippedTicks) >= 2*uint64(pr.cfg.Raft.HibernateTicks) {
+		pr.logger.Info("hibernated replica missed the keepalive of the leader",
+			zap.Uint64("leader", pr.getLeaderReplicaID()))
+		pr.wakeUp()
+		// wakes up the leader if it's alive, so the follower doesn't campaign
+		if leader := pr.getLeaderReplicaID(); leader != 0 {
+			pr.sendHibernateMessage(leader, raftpb.MsgHeartbeatResp, true)
+		}
+	}
+}
+
+// handleHibernateMessage handles the keepalive of the hibernated leader and
+// the ack of the followers. The follower hibernates if it has the same log as
+// the leader, otherwise it rejects the keepalive to wake up the leader.
+func (pr *replica) handleHibernateMessage(m metapb.RaftMessage) {
+	msg := m.Message
+	if msg.Type != raftpb.MsgHeartbeat {
+		// the ack of the keepalive, the heartbeat time is already recorded
+		if msg.Reject {
+			pr.wakeUp()
+		}
+		return
+	}
+	if pr.canFollowHibernate(msg) {
+		if !pr.isHibernated() {
+			pr.logger.Debug("shard hibernated",
+				zap.Uint64("leader", msg.From))
+		}
+		pr.hibernate.set(true)
+		pr.sendHibernateMessage(msg.From, raftpb.MsgHeartbeatResp, false)
+		return
+	}
+	pr.wakeUp()
+	pr.sendHibernateMessage(msg.From, raftpb.MsgHeartbeatResp, true)
+}
+
+// canFollowHibernate returns true if the local replica is a follower of the
+// hibernated leader, and has the same log as the leader, which is applied.
+func (pr *replica) canFollowHibernate(msg raftpb.Message) bool {
+	st := pr.rn.BasicStatus()
+	return st.RaftState == raft.StateFollower &&
+		st.Term == msg.Term &&
+		st.Lead == msg.From &&
+		st.Commit == msg.Commit &&
+		pr.rn.LastIndex() == msg.Commit &&
+		pr.appliedIndex == msg.Commit &&
+		pr.requests.Len() == 0
+}
+
+// wakesUp returns true if the raft message wakes up the hibernated replica.
+// The heartbeats are still stepped into the raft of the hibernated replicas,
+// e.g. the heartbeats sent just before the leader hibernated, or the read
+// index heartbeats, but they don't mean the shard becomes active.
+func wakesUp(msg raftpb.Message) bool {
+	return msg.Type != raftpb.MsgHeartbeat &&
+		msg.Type != raftpb.MsgHeartbeatResp
+}
+
+// wakeUp resumes the ticking of the hibernated replica
+func (pr *replica) wakeUp() {
+	if pr.isHibernated() {
+		pr.hibernate.set(false)
+		pr.logger.Debug("shard woken up")
+	}
+}
+
+// sendHibernateKeepalive sends the keepalive to all the other replicas
+func (pr *replica) sendHibernateKeepalive() {
+	for _, r := range pr.getShard().Replicas {
+		if r.ID != pr.replicaID {
+			pr.sendHibernateMessage(r.ID, raftpb.MsgHeartbeat, false)
+		}
+	}
+}
+
+// sendHibernateMessage sends a hibernate message with the term and the commit
+// index of the local raft, the rejected message wakes up the receiver.
+func (pr *replica) sendHibernateMessage(to uint64, msgType raftpb.MessageType,
+	reject bool) {
+	st := pr.rn.BasicStatus()
+	m, err := pr.newRaftMessage(pr.getShard(), raftpb.Message{
+		Type:   msgType,
+		From:   pr.replicaID,
+		To:     to,
+		Term:   st.Term,
+		Commit: st.Commit,
+		Reject: reject,
+	})
+	if err != nil {
+		pr.logger.Debug("fail to send hibernate msg",
+			zap.Uint64("to", to),
+			zap.Error(err))
+		return
+	}
+	m.Hibernate = true
+	pr.transport.Send(m)
+	pr.updateMessageMetrics(m.Message)
+}
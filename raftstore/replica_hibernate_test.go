@@ -0,0 +1,45 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHibernateStateSkipTick(t *testing.T) {
+	var h hibernateState
+	assert.False(t, h.isHibernated())
+	assert.False(t, h.skipTick(3))
+
+	h.quietTicks = 10
+	h.set(true)
+	assert.True(t, h.isHibernated())
+	assert.Equal(t, 0, h.quietTicks)
+	// every 3 ticks are handled for the keepalive
+	var handled []int
+	for i := 1; i <= 7; i++ {
+		if !h.skipTick(3) {
+			handled = append(handled, i)
+		}
+	}
+	assert.Equal(t, []int{3, 6}, handled)
+	assert.Equal(t, uint64(7), h.skippedTicks)
+
+	h.set(false)
+	assert.False(t, h.isHibernated())
+	assert.Equal(t, uint64(0), h.skippedTicks)
+	assert.False(t, h.skipTick(3))
+}
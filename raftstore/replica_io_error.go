@@ -0,0 +1,259 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/util"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+// The failure of writing the data storage is handled by the io error policy,
+// see config.IOErrorConfig. Unless the policy is panic, the failed replica
+// stops applying: the entry failed to write and the following entries are not
+// marked as applied, their proposals fail with the StorageIOError, and they are
+// applied again from the raft log after the store is restarted. The failed
+// leader transfers the leadership away, and the store reports the disk failure
+// to prophet in the store heartbeat unless the policy is propagate.
+//
+// The retry policy retries the write off the apply loop: the entry failed to
+// write and the following committed entries are queued, and they are applied
+// by the replica event loop once the backoff expires. Only the write that left
+// nothing in the data storage is retried, as a partially written batch can not
+// be applied twice, e.g. GetDel and DeleteIf read the data they delete.
+
+// writeRetry is the failed write waiting for the retry
+type writeRetry struct {
+	// entry the entry failed to write
+	entry raftpb.Entry
+	// req the request batch of the entry, the chunks are already reassembled
+	req rpcpb.RequestBatch
+	// entries the committed entries after the entry, applied after the retry
+	entries []raftpb.Entry
+	retries int
+	backoff time.Duration
+	// pending true if the retry is scheduled
+	pending bool
+}
+
+func storageIOError(shardID uint64) errorpb.Error {
+	return errorpb.Error{
+		Message:        errStorageIOError.Error(),
+		StorageIOError: &errorpb.StorageIOError{ShardID: shardID},
+	}
+}
+
+// handleWriteError handles the failure of writing the requests into the data
+// storage by the io error policy. The retry is scheduled if the write can be
+// retried, the requests are applied again once the backoff expires.
+func (d *stateMachine) handleWriteError(ctx *applyContext, requests []rpcpb.Request, err error) error {
+	switch d.ioError.Policy {
+	case config.IOErrorRetry:
+		if d.canRetryWrite(requests, err) {
+			d.scheduleWriteRetry(ctx, err)
+			return err
+		}
+	case config.IOErrorMarkStoreDown, config.IOErrorPropagate:
+	default:
+		d.logger.Fatal("failed to exec write cmd",
+			zap.Error(err))
+	}
+
+	atomic.StoreUint32(&d.ioFailed, 1)
+	if d.onIOFailed != nil {
+		d.onIOFailed(err)
+	}
+	return err
+}
+
+// canRetryWrite returns true if the failed write of the requests can be
+// retried. The transactional requests are written by the transactional data
+// storage, the write is not atomic.
+func (d *stateMachine) canRetryWrite(requests []rpcpb.Request, err error) bool {
+	if !storage.IsNotWrittenError(err) {
+		return false
+	}
+	if d.writeRetry != nil && d.writeRetry.retries >= d.ioError.RetryTimes {
+		return false
+	}
+	for idx := range requests {
+		if requests[idx].IsTransaction() {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *stateMachine) scheduleWriteRetry(ctx *applyContext, err error) {
+	if d.writeRetry == nil {
+		d.writeRetry = &writeRetry{backoff: d.ioError.RetryBackoff.Duration}
+	} else {
+		d.writeRetry.backoff *= 2
+	}
+	d.writeRetry.retries++
+	d.writeRetry.pending = true
+	// the write batch is rebuilt by the retry
+	d.writeCtx.wb.Reset()
+	d.logger.Warn("failed to write data storage, retry",
+		log.IndexField(ctx.index),
+		zap.Int("retry", d.writeRetry.retries),
+		zap.Duration("backoff", d.writeRetry.backoff),
+		zap.Error(err))
+	if d.onWriteRetry != nil {
+		d.onWriteRetry(d.writeRetry.backoff)
+	}
+}
+
+// isWriteRetryScheduled returns true if the failed write is waiting for the
+// retry
+func (d *stateMachine) isWriteRetryScheduled() bool {
+	return d.writeRetry != nil && d.writeRetry.pending
+}
+
+// retryWrite applies the entry failed to write again, and then the committed
+// entries queued after it.
+func (d *stateMachine) retryWrite() {
+	if !d.isWriteRetryScheduled() {
+		return
+	}
+	r := d.writeRetry
+	r.pending = false
+	d.applyEntry(r.entry, &r.req)
+	if r.pending {
+		return
+	}
+	d.writeRetry = nil
+	if len(r.entries) > 0 {
+		d.applyCommittedEntries(r.entries)
+	}
+}
+
+// resetWriteRetry drops the failed write and the queued entries, they are
+// covered by the snapshot as raft only restores the snapshot beyond the
+// committed index.
+func (d *stateMachine) resetWriteRetry() {
+	d.writeRetry = nil
+}
+
+// isIOFailed returns true if the replica failed to write the data storage
+func (d *stateMachine) isIOFailed() bool {
+	return atomic.LoadUint32(&d.ioFailed) == 1
+}
+
+// storageIOErrorResp returns the responses of the requests failed to write
+func (d *stateMachine) storageIOErrorResp(requests []rpcpb.Request) rpcpb.ResponseBatch {
+	resp := rpcpb.ResponseBatch{}
+	for range requests {
+		resp.Responses = append(resp.Responses, rpcpb.Response{Error: storageIOError(d.shardID)})
+	}
+	return resp
+}
+
+// notifyIOFailed fails the proposal of the entry which is not applied after the
+// replica failed to write the data storage
+func (d *stateMachine) notifyIOFailed(ctx *applyContext) {
+	resp := errorPbResp(ctx.req.Header.ID, storageIOError(d.shardID))
+	d.resultHandler.notifyPendingProposal(ctx.req.Header.ID,
+		resp, isConfigChangeRequestBatch(ctx.req))
+}
+
+// handleIOFailed is called by the state machine once the replica failed to
+// write the data storage.
+func (pr *replica) handleIOFailed(err error) {
+	policy := pr.cfg.IOError.Policy
+	pr.logger.Error("failed to write data storage, stop applying until the store is restarted",
+		zap.String("policy", policy),
+		zap.Error(err))
+	if policy != config.IOErrorPropagate && pr.store != nil {
+		pr.store.markDiskFailed()
+	}
+}
+
+// scheduleWriteRetry retries the failed write in the event loop after the
+// backoff.
+func (pr *replica) scheduleWriteRetry(backoff time.Duration) {
+	if pr.cfg.Test.Deterministic {
+		pr.store.afterFunc(backoff, func() {
+			pr.addAction(action{actionType: retryWriteAction})
+		})
+		return
+	}
+	if _, err := util.DefaultTimeoutWheel().Schedule(backoff, func(interface{}) {
+		pr.addAction(action{actionType: retryWriteAction})
+	}, nil); err != nil {
+		panic(err)
+	}
+}
+
+func (pr *replica) doRetryWrite() {
+	pr.sm.retryWrite()
+	if pr.sm.isRemoved() {
+		// local replica is removed, keep the shard
+		pr.store.destroyReplica(pr.shardID, false, true, "removed by config change")
+	}
+}
+
+// rejectIOFailed rejects the batch if the replica failed to write the data
+// storage. The reads are rejected too as the local state is stale. Returns
+// false if the replica is healthy.
+func (pr *replica) rejectIOFailed(c batch) bool {
+	if !pr.sm.isIOFailed() {
+		return false
+	}
+	c.respStorageIOError(pr.shardID)
+	return true
+}
+
+// maybeTransferIOFailedLeader transfers the leadership of the replica failed
+// to write the data storage to a healthy voter.
+func (pr *replica) maybeTransferIOFailedLeader() {
+	if !pr.isLeader() || !pr.sm.isIOFailed() {
+		return
+	}
+	if pr.rn.BasicStatus().LeadTransferee != 0 {
+		return
+	}
+	for _, r := range pr.getShard().Replicas {
+		if r.ID != pr.replicaID &&
+			r.Role == metapb.ReplicaRole_Voter &&
+			pr.isTransferLeaderAllowed(r) {
+			pr.logger.Info("io failed leader transfers the leadership",
+				log.ReplicaField("to", r))
+			pr.doTransferLeader(r)
+			return
+		}
+	}
+}
+
+// markDiskFailed marks the store disk failed, it's reported to prophet until
+// the store is restarted.
+func (s *store) markDiskFailed() {
+	if atomic.CompareAndSwapUint32(&s.diskFailed, 0, 1) {
+		s.logger.Error("store marked disk failed, restart the store after the disk is fixed")
+	}
+}
+
+func (s *store) isDiskFailed() bool {
+	return atomic.LoadUint32(&s.diskFailed) == 1
+}
@@ -0,0 +1,201 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+	"go.uber.org/zap"
+)
+
+// failedWriteDataStorage fails the first writes of the data storage, nothing is
+// written by the failed writes unless partial is set.
+type failedWriteDataStorage struct {
+	storage.DataStorage
+	failures int
+	partial  bool
+}
+
+func (s *failedWriteDataStorage) Write(ctx storage.WriteContext) error {
+	if s.failures > 0 {
+		s.failures--
+		if s.partial {
+			return errors.New("disk failure")
+		}
+		return storage.NewNotWrittenError(errors.New("disk failure"))
+	}
+	return s.DataStorage.Write(ctx)
+}
+
+func newKVSetEntry(index uint64, id byte, key, value []byte) raftpb.Entry {
+	batch := rpcpb.RequestBatch{
+		Header: rpcpb.RequestBatchHeader{
+			ID:      []byte{id},
+			ShardID: 1,
+		},
+		Requests: []rpcpb.Request{
+			{
+				ID:         []byte{id},
+				Type:       rpcpb.Write,
+				Key:        key,
+				CustomType: uint64(rpcpb.CmdKVSet),
+				Cmd:        protoc.MustMarshal(&rpcpb.KVSetRequest{Key: key, Value: value}),
+			},
+		},
+	}
+	return raftpb.Entry{
+		Index: index,
+		Term:  1,
+		Type:  raftpb.EntryNormal,
+		Data:  protoc.MustMarshal(&batch),
+	}
+}
+
+func TestStateMachineRetriesFailedWrite(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		sm.dataStorage = &failedWriteDataStorage{DataStorage: sm.dataStorage, failures: 2}
+		sm.ioError = config.IOErrorConfig{
+			Policy:       config.IOErrorRetry,
+			RetryTimes:   2,
+			RetryBackoff: typeutil.NewDuration(time.Millisecond),
+		}
+		sm.onIOFailed = func(error) { assert.Fail(t, "unexpected io failure") }
+		var backoffs []time.Duration
+		sm.onWriteRetry = func(backoff time.Duration) { backoffs = append(backoffs, backoff) }
+
+		sm.applyCommittedEntries([]raftpb.Entry{
+			newKVSetEntry(1, 1, []byte("k1"), []byte("v1")),
+			newKVSetEntry(2, 2, []byte("k2"), []byte("v2")),
+		})
+		// the following entries are queued until the retry
+		sm.applyCommittedEntries([]raftpb.Entry{newKVSetEntry(3, 3, []byte("k3"), []byte("v3"))})
+		assert.True(t, sm.isWriteRetryScheduled())
+		assert.Equal(t, []time.Duration{time.Millisecond}, backoffs)
+		assert.Equal(t, uint64(0), h.notified)
+		assert.Equal(t, uint64(0), h.appliedIndex)
+
+		sm.retryWrite()
+		assert.True(t, sm.isWriteRetryScheduled())
+		assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond}, backoffs)
+		assert.Equal(t, uint64(0), h.notified)
+
+		sm.retryWrite()
+		assert.False(t, sm.isWriteRetryScheduled())
+		assert.False(t, sm.isIOFailed())
+		index, _ := sm.getAppliedIndexTerm()
+		assert.Equal(t, uint64(3), index)
+		assert.Equal(t, uint64(3), h.appliedIndex)
+		assert.Equal(t, uint64(3), h.notified)
+		assert.Equal(t, []byte{3}, h.id)
+		require.Equal(t, 1, len(h.resp.Responses))
+		assert.False(t, errorpb.HasError(h.resp.Responses[0].Error))
+
+		for i := 1; i <= 3; i++ {
+			key := []byte(fmt.Sprintf("k%d", i))
+			readContext := newReadContext()
+			readContext.reset(sm.getShard(), storage.Request{
+				Key:     key,
+				CmdType: uint64(rpcpb.CmdKVGet),
+				Cmd:     protoc.MustMarshal(&rpcpb.KVGetRequest{Key: key}),
+			})
+			data, err := sm.dataStorage.Read(readContext)
+			assert.NoError(t, err)
+			assert.Equal(t, protoc.MustMarshal(&rpcpb.KVGetResponse{Value: []byte(fmt.Sprintf("v%d", i))}), data)
+		}
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachineStopsApplyingAfterIOFailure(t *testing.T) {
+	tests := []struct {
+		policy   string
+		failures int
+		partial  bool
+	}{
+		{config.IOErrorPropagate, 1, false},
+		{config.IOErrorMarkStoreDown, 1, false},
+		// the retries are exhausted
+		{config.IOErrorRetry, 2, false},
+		// the partially written batch is never retried
+		{config.IOErrorRetry, 1, true},
+	}
+
+	for _, tt := range tests {
+		h := &testReplicaResultHandler{}
+		f := func(sm *stateMachine) {
+			sm.dataStorage = &failedWriteDataStorage{DataStorage: sm.dataStorage,
+				failures: tt.failures, partial: tt.partial}
+			sm.ioError = config.IOErrorConfig{
+				Policy:       tt.policy,
+				RetryTimes:   1,
+				RetryBackoff: typeutil.NewDuration(time.Millisecond),
+			}
+			var failures []error
+			sm.onIOFailed = func(err error) { failures = append(failures, err) }
+
+			sm.applyCommittedEntries([]raftpb.Entry{newKVSetEntry(1, 1, []byte("k1"), []byte("v1"))})
+			for sm.isWriteRetryScheduled() {
+				sm.retryWrite()
+			}
+			assert.True(t, sm.isIOFailed(), tt.policy)
+			assert.Equal(t, 1, len(failures), tt.policy)
+			require.Equal(t, 1, len(h.resp.Responses), tt.policy)
+			assert.Equal(t, &errorpb.StorageIOError{ShardID: 100}, h.resp.Responses[0].Error.StorageIOError, tt.policy)
+
+			// the following entries are not applied, their proposals fail
+			sm.applyCommittedEntries([]raftpb.Entry{newKVSetEntry(2, 2, []byte("k2"), []byte("v2"))})
+			assert.Equal(t, uint64(2), h.notified, tt.policy)
+			assert.Equal(t, []byte{2}, h.id, tt.policy)
+			assert.NotNil(t, h.resp.Header.Error.StorageIOError, tt.policy)
+			assert.Equal(t, 1, len(failures), tt.policy)
+
+			index, _ := sm.getAppliedIndexTerm()
+			assert.Equal(t, uint64(0), index, tt.policy)
+			assert.Equal(t, uint64(0), h.appliedIndex, tt.policy)
+		}
+		runSimpleStateMachineTest(t, f, h)
+	}
+}
+
+func TestRejectIOFailed(t *testing.T) {
+	pr := &replica{shardID: 1, sm: &stateMachine{}}
+	var responses []rpcpb.ResponseBatch
+	cb := func(resp rpcpb.ResponseBatch) { responses = append(responses, resp) }
+	writes := newBatch(zap.NewNop(), rpcpb.RequestBatch{
+		Header:   rpcpb.RequestBatchHeader{ID: []byte("writes")},
+		Requests: []rpcpb.Request{{ID: []byte("write"), Type: rpcpb.Write}},
+	}, cb, write, 0)
+	assert.False(t, pr.rejectIOFailed(writes))
+	assert.Empty(t, responses)
+
+	pr.sm.ioFailed = 1
+	assert.True(t, pr.rejectIOFailed(writes))
+	require.Equal(t, 1, len(responses))
+	require.Equal(t, 1, len(responses[0].Responses))
+	assert.Equal(t, &errorpb.StorageIOError{ShardID: 1}, responses[0].Responses[0].Error.StorageIOError)
+	assert.False(t, errorpb.Retryable(responses[0].Responses[0].Error))
+}
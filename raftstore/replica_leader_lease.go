@@ -0,0 +1,62 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"time"
+)
+
+// leaderLease is the lease of the raft leader, the leader serves the
+// linearizable reads locally without the read index round trip within the
+// lease. With CheckQuorum enabled, the followers reject the votes within the
+// election timeout since they last heard from the leader, so no other leader
+// can be elected before the lease expires. The lease is renewed from the time
+// the read index request confirmed by a quorum was sent. It's only accessed in
+// the event loop of the replica.
+type leaderLease struct {
+	duration time.Duration
+	expireAt time.Time
+	// invalidatedAt the read index requests sent before it can't renew the lease
+	invalidatedAt time.Time
+}
+
+func newLeaderLease(duration time.Duration) leaderLease {
+	return leaderLease{duration: duration}
+}
+
+func (l *leaderLease) enabled() bool {
+	return l.duration > 0
+}
+
+// renew extends the lease to start + duration, start is the time the read
+// index request confirmed by a quorum was sent.
+func (l *leaderLease) renew(start time.Time) {
+	if !l.enabled() || !start.After(l.invalidatedAt) {
+		return
+	}
+	if expireAt := start.Add(l.duration); expireAt.After(l.expireAt) {
+		l.expireAt = expireAt
+	}
+}
+
+// invalidate expires the lease, e.g. the leader changed or the leadership
+// transfer started.
+func (l *leaderLease) invalidate(now time.Time) {
+	l.expireAt = time.Time{}
+	l.invalidatedAt = now
+}
+
+func (l *leaderLease) valid(now time.Time) bool {
+	return l.enabled() && now.Before(l.expireAt)
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderLease(t *testing.T) {
+	now := time.Now()
+
+	l := newLeaderLease(0)
+	l.renew(now)
+	assert.False(t, l.valid(now))
+
+	l = newLeaderLease(time.Second)
+	assert.False(t, l.valid(now))
+
+	l.renew(now)
+	assert.True(t, l.valid(now.Add(time.Millisecond*999)))
+	assert.False(t, l.valid(now.Add(time.Second)))
+
+	// an earlier start never shortens the lease
+	l.renew(now.Add(-time.Millisecond * 500))
+	assert.True(t, l.valid(now.Add(time.Millisecond*999)))
+
+	l.invalidate(now.Add(time.Millisecond))
+	assert.False(t, l.valid(now.Add(time.Millisecond*2)))
+
+	// the read index requests sent before the invalidation can't renew
+	l.renew(now)
+	assert.False(t, l.valid(now.Add(time.Millisecond*2)))
+	l.renew(now.Add(time.Millisecond * 2))
+	assert.True(t, l.valid(now.Add(time.Millisecond*3)))
+}
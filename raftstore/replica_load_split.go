@@ -0,0 +1,138 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+)
+
+const (
+	// loadSplitSampleSize max number of the request keys sampled in a split
+	// check interval
+	loadSplitSampleSize = 64
+	// loadSplitMinSamples min number of the sampled keys to pick a split key
+	loadSplitMinSamples = 16
+)
+
+// loadSplitRecorder counts the reads and the writes served by the leader in
+// the current split check interval, and samples their keys to find the traffic
+// midpoint of the shard.
+type loadSplitRecorder struct {
+	start   time.Time
+	reads   uint64
+	writes  uint64
+	samples [][]byte
+	rand    *rand.Rand
+}
+
+// record counts a request and samples its key by the reservoir sampling, so all
+// requests of the interval are sampled with the same probability.
+func (r *loadSplitRecorder) record(key []byte, write bool) {
+	if write {
+		r.writes++
+	} else {
+		r.reads++
+	}
+	if len(key) == 0 {
+		return
+	}
+	if len(r.samples) < loadSplitSampleSize {
+		r.samples = append(r.samples, append([]byte(nil), key...))
+		return
+	}
+	if r.rand == nil {
+		r.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if i := r.rand.Int63n(int64(r.reads + r.writes)); i < loadSplitSampleSize {
+		r.samples[i] = append(r.samples[i][:0], key...)
+	}
+}
+
+// qps returns the reads and the writes per second of the current interval
+func (r *loadSplitRecorder) qps(now time.Time) (float64, float64) {
+	elapsed := now.Sub(r.start).Seconds()
+	if r.start.IsZero() || elapsed <= 0 {
+		return 0, 0
+	}
+	return float64(r.reads) / elapsed, float64(r.writes) / elapsed
+}
+
+// splitKey returns the median of the sampled keys, which splits the traffic of
+// the shard into halves. Nil is returned if the samples are too few or the
+// median is not a valid split key of the shard, e.g. all traffic goes to the
+// first key of the shard.
+func (r *loadSplitRecorder) splitKey(shard Shard) []byte {
+	if len(r.samples) < loadSplitMinSamples {
+		return nil
+	}
+	sorted := make([][]byte, len(r.samples))
+	copy(sorted, r.samples)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	key := sorted[len(sorted)/2]
+	if bytes.Compare(key, shard.Start) <= 0 ||
+		(len(shard.End) > 0 && bytes.Compare(key, shard.End) >= 0) {
+		return nil
+	}
+	return append([]byte(nil), key...)
+}
+
+// reset starts a new interval
+func (r *loadSplitRecorder) reset(now time.Time) {
+	r.start = now
+	r.reads = 0
+	r.writes = 0
+	r.samples = r.samples[:0]
+}
+
+// recordLoad records the requests served by the leader for the load based
+// split, the admin requests are not counted.
+func (pr *replica) recordLoad(c batch) {
+	if pr.feature.ShardSplitQPS == 0 || c.tp == admin || !pr.isLeader() {
+		return
+	}
+	for _, req := range c.requestBatch.Requests {
+		pr.loadSplit.record(req.Key, c.tp == write)
+	}
+}
+
+// checkLoadSplit returns the split key at the traffic midpoint if the QPS of
+// the shard in the current interval exceeds the threshold, nil is returned if
+// the shard is not hot. A new interval is started after the check.
+func (pr *replica) checkLoadSplit(now time.Time) []byte {
+	if pr.feature.ShardSplitQPS == 0 {
+		return nil
+	}
+	defer pr.loadSplit.reset(now)
+
+	reads, writes := pr.loadSplit.qps(now)
+	if reads+writes < float64(pr.feature.ShardSplitQPS) {
+		return nil
+	}
+	key := pr.loadSplit.splitKey(pr.getShard())
+	pr.logger.Info("shard is hot",
+		zap.Float64("read-qps", reads),
+		zap.Float64("write-qps", writes),
+		zap.Uint64("threshold", pr.feature.ShardSplitQPS),
+		log.HexField("split-key", key))
+	return key
+}
@@ -0,0 +1,108 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestLoadSplitRecorder(t *testing.T) {
+	now := time.Now()
+	var r loadSplitRecorder
+	r.reset(now)
+
+	for i := 0; i < 1000; i++ {
+		r.record([]byte(fmt.Sprintf("k%03d", i)), i%2 == 0)
+	}
+	r.record(nil, false)
+	assert.Equal(t, uint64(501), r.reads)
+	assert.Equal(t, uint64(500), r.writes)
+	assert.Equal(t, loadSplitSampleSize, len(r.samples))
+	reads, writes := r.qps(now.Add(10 * time.Second))
+	assert.Equal(t, 50.1, reads)
+	assert.Equal(t, 50.0, writes)
+
+	// the median of the uniformly sampled keys is close to the traffic midpoint
+	key := r.splitKey(Shard{Start: []byte("k"), End: []byte("l")})
+	require.NotNil(t, key)
+	assert.True(t, string(key) > "k250" && string(key) < "k750", string(key))
+	// the median must be inside the shard
+	assert.Nil(t, r.splitKey(Shard{Start: key}))
+	assert.Nil(t, r.splitKey(Shard{End: key}))
+
+	r.reset(now)
+	assert.Equal(t, uint64(0), r.reads+r.writes)
+	for i := 0; i < loadSplitMinSamples-1; i++ {
+		r.record([]byte("k1"), true)
+	}
+	assert.Nil(t, r.splitKey(Shard{}))
+}
+
+func TestTryCheckLoadSplit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1, Start: []byte("a"), End: []byte("z")}, Replica{ID: 1}, s)
+	pr.leaderID = 1
+	pr.feature.ShardSplitCheckBytes = 200
+	pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
+
+	newRead := func(key string) batch {
+		return newBatch(s.logger, rpcpb.RequestBatch{
+			Requests: []rpcpb.Request{{Key: []byte(key), Type: rpcpb.Read}},
+		}, nil, read, 0)
+	}
+
+	// disabled
+	pr.recordLoad(newRead("m"))
+	assert.Equal(t, uint64(0), pr.loadSplit.reads)
+
+	pr.feature.ShardSplitQPS = 1
+	pr.loadSplit.reset(time.Now().Add(-time.Second))
+	for i := 0; i < loadSplitMinSamples; i++ {
+		pr.recordLoad(newRead(fmt.Sprintf("m%d", i)))
+	}
+	var task interface{}
+	assert.True(t, pr.tryCheckSplit(action{actionType: checkSplitAction, actionCallback: func(v interface{}) {
+		task = v
+	}}))
+	require.IsType(t, splitCheckTask{}, task)
+	assert.Equal(t, pr.getShard(), task.(splitCheckTask).shard)
+	// the sorted keys are m0, m1, m10, ..., m15, m2, ..., m9
+	assert.Equal(t, [][]byte{[]byte("m2")}, task.(splitCheckTask).splitKeys)
+
+	// a new interval is started after the check
+	assert.Equal(t, uint64(0), pr.loadSplit.reads)
+	assert.False(t, pr.tryCheckSplit(action{actionType: checkSplitAction}))
+
+	// the size based split is preferred
+	pr.recordLoad(newRead("m"))
+	pr.stats.approximateSize = 200
+	assert.True(t, pr.tryCheckSplit(action{actionType: checkSplitAction, actionCallback: func(v interface{}) {
+		task = v
+	}}))
+	assert.Equal(t, pr.getShard(), task)
+}
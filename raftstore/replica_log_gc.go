@@ -0,0 +1,85 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"github.com/matrixorigin/matrixcube/config"
+)
+
+const (
+	// logGCReplicated the entries replicated by all the replicas exceed the
+	// compact threshold
+	logGCReplicated = "replicated"
+	// logGCMaxEntries the retained entries exceed the max entries
+	logGCMaxEntries = "max-entries"
+	// logGCMaxBytes the retained bytes exceed the max bytes
+	logGCMaxBytes = "max-bytes"
+)
+
+// logGCPolicy decides the index up to which the raft log of a shard is
+// compacted by the leader.
+type logGCPolicy struct {
+	config.RaftLogGCPolicy
+}
+
+// getLogGCPolicy returns the raft log gc policy of the replica, the max entries
+// and max bytes default to the force compaction thresholds of the data storage.
+func (pr *replica) getLogGCPolicy() logGCPolicy {
+	p := pr.store.cfg.Raft.RaftLog.GetGCPolicy(pr.shardID)
+	if p.MaxEntries == 0 {
+		p.MaxEntries = pr.feature.ForceCompactCount
+	}
+	if p.MaxBytes == 0 {
+		p.MaxBytes = pr.feature.ForceCompactBytes
+	}
+	return logGCPolicy{RaftLogGCPolicy: p}
+}
+
+// compactIndex returns the index up to which the raft log can be compacted and
+// the reason, 0 means the raft log should not be compacted.
+//
+// The log is compacted up to the index replicated by all the replicas once more
+// than CompactThreshold such entries are retained. Otherwise, once MaxEntries
+// entries or MaxBytes bytes are retained, the log is compacted up to the applied
+// index and the lagging followers will catch up by snapshots, unless the lag of
+// the slowest follower is no more than MaxFollowerLag.
+func (p logGCPolicy) compactIndex(firstIndex, appliedIndex, lastIndex,
+	minReplicatedIndex, logBytes uint64) (uint64, string) {
+	if minReplicatedIndex >= firstIndex &&
+		minReplicatedIndex-firstIndex > p.CompactThreshold {
+		return minReplicatedIndex, logGCReplicated
+	}
+
+	var reason string
+	if appliedIndex > firstIndex &&
+		appliedIndex-firstIndex >= p.MaxEntries {
+		reason = logGCMaxEntries
+	} else if logBytes >= p.MaxBytes {
+		reason = logGCMaxBytes
+	} else {
+		return 0, ""
+	}
+
+	// a new replica with nothing replicated needs a snapshot anyway
+	if p.MaxFollowerLag > 0 &&
+		minReplicatedIndex > 0 &&
+		appliedIndex > minReplicatedIndex &&
+		lastIndex-minReplicatedIndex <= p.MaxFollowerLag {
+		if minReplicatedIndex <= firstIndex {
+			return 0, ""
+		}
+		return minReplicatedIndex, reason
+	}
+	return appliedIndex, reason
+}
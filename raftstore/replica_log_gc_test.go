@@ -0,0 +1,82 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogGCPolicyCompactIndex(t *testing.T) {
+	p := logGCPolicy{RaftLogGCPolicy: config.RaftLogGCPolicy{
+		CompactThreshold: 10,
+		MaxEntries:       100,
+		MaxBytes:         1000,
+	}}
+
+	tests := []struct {
+		maxFollowerLag                                     uint64
+		first, applied, last, minReplicated, bytes, expect uint64
+		reason                                             string
+	}{
+		// nothing to compact
+		{first: 1, applied: 10, last: 10, minReplicated: 10},
+		// replicated entries exceed the compact threshold
+		{first: 1, applied: 20, last: 20, minReplicated: 20, expect: 20, reason: logGCReplicated},
+		// a follower lags behind, max entries reached
+		{first: 1, applied: 200, last: 200, minReplicated: 5, expect: 200, reason: logGCMaxEntries},
+		// a follower lags behind, max bytes reached
+		{first: 1, applied: 50, last: 50, minReplicated: 5, bytes: 1000, expect: 50, reason: logGCMaxBytes},
+		// the slowest follower is waited
+		{maxFollowerLag: 500, first: 1, applied: 200, last: 200, minReplicated: 5, expect: 5, reason: logGCMaxEntries},
+		{maxFollowerLag: 500, first: 5, applied: 200, last: 200, minReplicated: 5},
+		// the slowest follower lags too much
+		{maxFollowerLag: 100, first: 1, applied: 200, last: 200, minReplicated: 5, expect: 200, reason: logGCMaxEntries},
+		// the new replica is not waited
+		{maxFollowerLag: 500, first: 1, applied: 200, last: 200, minReplicated: 0, expect: 200, reason: logGCMaxEntries},
+	}
+
+	for i, tt := range tests {
+		p.MaxFollowerLag = tt.maxFollowerLag
+		index, reason := p.compactIndex(tt.first, tt.applied, tt.last, tt.minReplicated, tt.bytes)
+		assert.Equal(t, tt.expect, index, "index %d", i)
+		assert.Equal(t, tt.reason, reason, "index %d", i)
+	}
+}
+
+func TestGetLogGCPolicy(t *testing.T) {
+	pr := &replica{shardID: 1, store: &store{cfg: &config.Config{}}}
+	pr.feature.ForceCompactCount = 100
+	pr.feature.ForceCompactBytes = 1000
+	pr.store.cfg.Raft.RaftLog.CompactThreshold = 10
+	pr.store.cfg.Raft.RaftLog.Shards = []config.ShardRaftLogConfig{
+		{Shard: 1, MaxEntries: 50, MaxFollowerLag: 20},
+	}
+
+	assert.Equal(t, config.RaftLogGCPolicy{
+		CompactThreshold: 10,
+		MaxEntries:       50,
+		MaxBytes:         1000,
+		MaxFollowerLag:   20,
+	}, pr.getLogGCPolicy().RaftLogGCPolicy)
+
+	pr.shardID = 2
+	assert.Equal(t, config.RaftLogGCPolicy{
+		CompactThreshold: 10,
+		MaxEntries:       100,
+		MaxBytes:         1000,
+	}, pr.getLogGCPolicy().RaftLogGCPolicy)
+}
@@ -0,0 +1,127 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+// heartbeatLatencyTracker tracks the round trip latencies of the heartbeats
+// sent by the leader. Only the first heartbeat not responded is tracked for
+// each replica, so the latency of a slow replica keeps growing until it
+// responds. It's only used by the event loop.
+type heartbeatLatencyTracker struct {
+	// sent the time when the tracked heartbeat is sent to the replica
+	sent map[uint64]time.Time
+}
+
+// onSent records the heartbeat sent to the replica
+func (t *heartbeatLatencyTracker) onSent(to uint64, now time.Time) {
+	if t.sent == nil {
+		t.sent = make(map[uint64]time.Time)
+	}
+	if _, ok := t.sent[to]; !ok {
+		t.sent[to] = now
+	}
+}
+
+// onResponded returns the latency of the heartbeat responded by the replica,
+// returns false if no heartbeat is tracked.
+func (t *heartbeatLatencyTracker) onResponded(from uint64,
+	now time.Time) (time.Duration, bool) {
+	sent, ok := t.sent[from]
+	if !ok {
+		return 0, false
+	}
+	delete(t.sent, from)
+	return now.Sub(sent), true
+}
+
+// reset drops the tracked heartbeats, the heartbeats sent in the previous
+// leadership may never be responded.
+func (t *heartbeatLatencyTracker) reset() {
+	t.sent = nil
+}
+
+// peerLatencyStats accumulates the heartbeat latencies from the leaders in the
+// local store to the replicas in the other stores, the latencies are reported
+// to prophet by the store heartbeats to find the slow stores.
+type peerLatencyStats struct {
+	sync.Mutex
+	stores map[uint64]*peerLatency
+}
+
+type peerLatency struct {
+	total   time.Duration
+	samples uint64
+}
+
+func newPeerLatencyStats() *peerLatencyStats {
+	return &peerLatencyStats{
+		stores: make(map[uint64]*peerLatency),
+	}
+}
+
+func (s *peerLatencyStats) observe(storeID uint64, latency time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+	v, ok := s.stores[storeID]
+	if !ok {
+		v = &peerLatency{}
+		s.stores[storeID] = v
+	}
+	v.total += latency
+	v.samples++
+}
+
+// flush returns the average latencies since the last flush, and resets the
+// stats for the next reporting window.
+func (s *peerLatencyStats) flush() []metapb.PeerStoreLatency {
+	s.Lock()
+	defer s.Unlock()
+	values := make([]metapb.PeerStoreLatency, 0, len(s.stores))
+	for id, v := range s.stores {
+		values = append(values, metapb.PeerStoreLatency{
+			StoreID: id,
+			Latency: uint64(v.total / time.Duration(v.samples)),
+			Samples: v.samples,
+		})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].StoreID < values[j].StoreID
+	})
+	s.stores = make(map[uint64]*peerLatency)
+	return values
+}
+
+// observeHeartbeatLatency is called by the event loop for every raft message
+// sent or received by the leader, the heartbeat latency of the replica is
+// recorded once its response is received.
+func (pr *replica) observeHeartbeatLatency(m metapb.RaftMessage, sent bool,
+	now time.Time) {
+	switch {
+	case sent && m.Message.Type == raftpb.MsgHeartbeat:
+		pr.heartbeatLatency.onSent(m.To.ID, now)
+	case !sent && m.Message.Type == raftpb.MsgHeartbeatResp:
+		if latency, ok := pr.heartbeatLatency.onResponded(m.From.ID, now); ok &&
+			pr.store != nil {
+			pr.store.peerLatencies.observe(m.From.StoreID, latency)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func TestHeartbeatLatencyTracker(t *testing.T) {
+	var tr heartbeatLatencyTracker
+	now := time.Now()
+	_, ok := tr.onResponded(2, now)
+	assert.False(t, ok)
+
+	// only the first heartbeat not responded is tracked
+	tr.onSent(2, now)
+	tr.onSent(2, now.Add(time.Second))
+	latency, ok := tr.onResponded(2, now.Add(3*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, latency)
+	_, ok = tr.onResponded(2, now.Add(4*time.Second))
+	assert.False(t, ok)
+
+	tr.onSent(3, now)
+	tr.reset()
+	_, ok = tr.onResponded(3, now.Add(time.Second))
+	assert.False(t, ok)
+}
+
+func TestPeerLatencyStats(t *testing.T) {
+	s := newPeerLatencyStats()
+	assert.Empty(t, s.flush())
+
+	s.observe(3, 10*time.Millisecond)
+	s.observe(2, time.Millisecond)
+	s.observe(3, 20*time.Millisecond)
+	assert.Equal(t, []metapb.PeerStoreLatency{
+		{StoreID: 2, Latency: uint64(time.Millisecond), Samples: 1},
+		{StoreID: 3, Latency: uint64(15 * time.Millisecond), Samples: 2},
+	}, s.flush())
+	assert.Empty(t, s.flush())
+}
+
+func TestObserveHeartbeatLatency(t *testing.T) {
+	pr := &replica{store: &store{peerLatencies: newPeerLatencyStats()}}
+	leader := Replica{ID: 1, StoreID: 1}
+	follower := Replica{ID: 2, StoreID: 2}
+	now := time.Now()
+
+	pr.observeHeartbeatLatency(metapb.RaftMessage{From: leader, To: follower,
+		Message: raftpb.Message{Type: raftpb.MsgApp}}, true, now)
+	pr.observeHeartbeatLatency(metapb.RaftMessage{From: follower, To: leader,
+		Message: raftpb.Message{Type: raftpb.MsgHeartbeatResp}}, false, now)
+	assert.Empty(t, pr.store.peerLatencies.flush())
+
+	pr.observeHeartbeatLatency(metapb.RaftMessage{From: leader, To: follower,
+		Message: raftpb.Message{Type: raftpb.MsgHeartbeat}}, true, now)
+	pr.observeHeartbeatLatency(metapb.RaftMessage{From: follower, To: leader,
+		Message: raftpb.Message{Type: raftpb.MsgHeartbeatResp}}, false,
+		now.Add(time.Millisecond))
+	assert.Equal(t, []metapb.PeerStoreLatency{
+		{StoreID: 2, Latency: uint64(time.Millisecond), Samples: 1},
+	}, pr.store.peerLatencies.flush())
+}
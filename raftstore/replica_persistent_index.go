@@ -0,0 +1,70 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync/atomic"
+
+	"github.com/matrixorigin/matrixcube/storage"
+)
+
+// updatePersistentLogIndex advances the cached persistent log index, the
+// stale values are ignored as the concurrent flushes may be notified out of
+// order.
+func (d *stateMachine) updatePersistentLogIndex(index uint64) {
+	for {
+		current := atomic.LoadUint64(&d.persistentLogIndex)
+		if index <= current ||
+			atomic.CompareAndSwapUint64(&d.persistentLogIndex, current, index) {
+			return
+		}
+	}
+}
+
+// setPersistentLogIndexPushed marks the data storage of the replica is pushing
+// the persistent log indexes.
+func (d *stateMachine) setPersistentLogIndexPushed() {
+	atomic.StoreUint32(&d.persistentLogIndexPushed, 1)
+}
+
+// getPersistentLogIndex returns the persistent log index pushed by the data
+// storage once it's pushed, otherwise queries the data storage.
+func (d *stateMachine) getPersistentLogIndex() (uint64, error) {
+	if atomic.LoadUint32(&d.persistentLogIndexPushed) == 1 {
+		return atomic.LoadUint64(&d.persistentLogIndex), nil
+	}
+	return d.dataStorage.GetPersistentLogIndex(d.shardID)
+}
+
+// setPersistentLogIndexListeners sets the listener of the data storages
+// pushing the persistent log indexes
+func (s *store) setPersistentLogIndexListeners() {
+	s.cfg.Storage.ForeachDataStorageFunc(func(_ uint64, ds storage.DataStorage) {
+		if n, ok := ds.(storage.PersistentLogIndexNotifier); ok {
+			n.SetPersistentLogIndexListener(s.onPersistentLogIndexes)
+		}
+	})
+}
+
+// onPersistentLogIndexes is called by the data storage with the persistent log
+// indexes advanced by a flush. The indexes of the shards without local replica
+// are dropped, the replicas created later query the data storage on start.
+func (s *store) onPersistentLogIndexes(indexes map[uint64]uint64) {
+	for shardID, index := range indexes {
+		if pr := s.getReplica(shardID, false); pr != nil && pr.sm != nil {
+			pr.sm.updatePersistentLogIndex(index)
+			pr.sm.setPersistentLogIndexPushed()
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushedPersistentLogIndex(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	_, err := s.DataStorageByGroup(0).GetInitialStates()
+	require.NoError(t, err)
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	defer pr.readStopper.Stop()
+	s.addReplica(pr)
+
+	index, err := pr.getPersistentLogIndex()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), index)
+
+	assert.Equal(t, uint32(0), pr.sm.persistentLogIndexPushed)
+	s.onPersistentLogIndexes(map[uint64]uint64{1: 10, 2: 20})
+	assert.Equal(t, uint32(1), pr.sm.persistentLogIndexPushed)
+	index, err = pr.sm.getPersistentLogIndex()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), index)
+
+	// the stale index is ignored
+	s.onPersistentLogIndexes(map[uint64]uint64{1: 5})
+	index, err = pr.sm.getPersistentLogIndex()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), index)
+
+	compactIndex, err := pr.sm.adjustCompactionIndex(100)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), compactIndex)
+	compactIndex, err = pr.sm.adjustCompactionIndex(8)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), compactIndex)
+}
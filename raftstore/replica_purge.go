@@ -0,0 +1,168 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"go.uber.org/zap"
+)
+
+// maxPurgeMarkers the max number of the purge markers kept in the shard
+// metadata, the oldest markers are dropped once exceeded, so the markers
+// should be collected by the backup tooling with GetPurgeMarkers.
+const maxPurgeMarkers = 128
+
+// clipPurgeMarker returns the marker of the range and the keys clipped to the
+// [start, end) range of a shard, false if nothing is left. The range is not
+// purged if both bounds are empty.
+func clipPurgeMarker(m metapb.PurgeMarker, start, end []byte) (metapb.PurgeMarker, bool) {
+	clipped := metapb.PurgeMarker{Index: m.Index}
+	if len(m.Start) > 0 || len(m.End) > 0 {
+		from, to := m.Start, m.End
+		if bytes.Compare(start, from) > 0 {
+			from = start
+		}
+		if len(to) == 0 || (len(end) > 0 && bytes.Compare(end, to) < 0) {
+			to = end
+		}
+		if len(to) == 0 || bytes.Compare(from, to) < 0 {
+			clipped.Start, clipped.End = from, to
+		}
+	}
+	shard := Shard{Start: start, End: end}
+	for _, key := range m.Keys {
+		if shard.ContainsKey(key) {
+			clipped.Keys = append(clipped.Keys, key)
+		}
+	}
+	return clipped, len(clipped.Start) > 0 || len(clipped.End) > 0 || len(clipped.Keys) > 0
+}
+
+// clipPurgeMarkers returns the markers clipped to the range of the new shard
+// split from the current shard, so the new shard keeps the proofs of its data.
+func clipPurgeMarkers(markers []metapb.PurgeMarker, shard Shard) []metapb.PurgeMarker {
+	var clipped []metapb.PurgeMarker
+	for _, m := range markers {
+		if v, ok := clipPurgeMarker(m, shard.Start, shard.End); ok {
+			clipped = append(clipped, v)
+		}
+	}
+	return clipped
+}
+
+// getPurgeMarkers returns the purge markers, the returned slice is never
+// modified as the markers are copied on append.
+func (d *stateMachine) getPurgeMarkers() []metapb.PurgeMarker {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	return d.metadataMu.purges
+}
+
+func (d *stateMachine) updatePurgeMarkers(markers []metapb.PurgeMarker) {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	d.metadataMu.purges = markers
+}
+
+// appendPurgeMarker appends the applied purge to the markers, it's persisted
+// with the shard metadata saved by the purge.
+func (d *stateMachine) appendPurgeMarker(marker metapb.PurgeMarker) {
+	d.metadataMu.Lock()
+	defer d.metadataMu.Unlock()
+	markers := d.metadataMu.purges
+	markers = append(markers[:len(markers):len(markers)], marker)
+	if len(markers) > maxPurgeMarkers {
+		markers = markers[len(markers)-maxPurgeMarkers:]
+	}
+	d.metadataMu.purges = markers
+}
+
+// doPurge deletes the data of the range and the keys clipped to the shard, and
+// records the purge marker. The deletion is persisted no later than the marker
+// saved with the shard metadata, so the marker always proves the deletion. The
+// witness has no data to delete, so the marker is recorded directly.
+func (d *stateMachine) doPurge(ctx *applyContext) (rpcpb.ResponseBatch, error) {
+	purgeReq := ctx.req.GetPurgeRequest()
+	shard := d.getShard()
+	marker, _ := clipPurgeMarker(metapb.PurgeMarker{
+		Index: ctx.index,
+		Start: purgeReq.Start,
+		End:   purgeReq.End,
+		Keys:  purgeReq.Keys,
+	}, shard.Start, shard.End)
+
+	purged := true
+	if !d.isWitness() {
+		if rd, ok := d.dataStorage.(storage.RangeDeleter); ok {
+			if len(marker.Start) > 0 || len(marker.End) > 0 {
+				if err := rd.DeleteRange(shard, marker.Start, marker.End); err != nil {
+					d.logger.Fatal("failed to purge range",
+						zap.Error(err))
+				}
+			}
+			for _, key := range marker.Keys {
+				if err := rd.DeleteRange(shard, key, keysutil.NextKey(key, nil)); err != nil {
+					d.logger.Fatal("failed to purge key",
+						zap.Error(err))
+				}
+			}
+		} else {
+			purged = false
+		}
+	}
+	if purged {
+		d.appendPurgeMarker(marker)
+	}
+	if err := d.saveShardMetedata(ctx.index, shard, metapb.ReplicaState_Normal, d.getLease()); err != nil {
+		d.logger.Fatal("failed to save metadata after purge",
+			zap.Error(err))
+	}
+
+	d.logger.Info("purge applied",
+		log.IndexField(ctx.index),
+		log.HexField("start", marker.Start),
+		log.HexField("end", marker.End),
+		zap.Int("keys", len(marker.Keys)),
+		zap.Bool("purged", purged))
+
+	resp := &rpcpb.PurgeResponse{Purged: purged}
+	if purged {
+		resp.Index = marker.Index
+		resp.Start = marker.Start
+		resp.End = marker.End
+		resp.Keys = marker.Keys
+	}
+	ctx.adminResult = &adminResult{
+		adminType:   rpcpb.CmdPurge,
+		purgeResult: purgeResult{purged: purged, marker: marker},
+	}
+	return newAdminResponseBatch(rpcpb.CmdPurge, resp), nil
+}
+
+// GetPurgeMarkers returns the purge markers of the local replica, the oldest
+// first. The markers are persisted with the shard metadata, and only the recent
+// markers are kept.
+func (s *store) GetPurgeMarkers(shardID uint64) ([]metapb.PurgeMarker, error) {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return nil, errShardNotFound
+	}
+	return pr.sm.getPurgeMarkers(), nil
+}
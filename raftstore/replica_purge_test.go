@@ -0,0 +1,175 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/aware"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func TestClipPurgeMarker(t *testing.T) {
+	cases := []struct {
+		marker     metapb.PurgeMarker
+		start, end []byte
+		expect     metapb.PurgeMarker
+		ok         bool
+	}{
+		{
+			marker: metapb.PurgeMarker{Index: 1, Start: []byte("a"), End: []byte("z")},
+			start:  []byte("b"),
+			end:    []byte("c"),
+			expect: metapb.PurgeMarker{Index: 1, Start: []byte("b"), End: []byte("c")},
+			ok:     true,
+		},
+		{
+			marker: metapb.PurgeMarker{Index: 1, Start: []byte("b")},
+			start:  []byte("a"),
+			expect: metapb.PurgeMarker{Index: 1, Start: []byte("b")},
+			ok:     true,
+		},
+		{
+			marker: metapb.PurgeMarker{Index: 1, Start: []byte("a"), End: []byte("b")},
+			start:  []byte("c"),
+			end:    []byte("d"),
+			expect: metapb.PurgeMarker{Index: 1},
+		},
+		{
+			marker: metapb.PurgeMarker{Index: 1, Keys: [][]byte{[]byte("a"), []byte("c")}},
+			start:  []byte("b"),
+			expect: metapb.PurgeMarker{Index: 1, Keys: [][]byte{[]byte("c")}},
+			ok:     true,
+		},
+		{
+			marker: metapb.PurgeMarker{Index: 1},
+			expect: metapb.PurgeMarker{Index: 1},
+		},
+	}
+
+	for i, c := range cases {
+		v, ok := clipPurgeMarker(c.marker, c.start, c.end)
+		assert.Equal(t, c.ok, ok, "index %d", i)
+		assert.Equal(t, c.expect, v, "index %d", i)
+	}
+}
+
+func TestStateMachineAppendPurgeMarker(t *testing.T) {
+	f := func(sm *stateMachine) {
+		assert.Empty(t, sm.getPurgeMarkers())
+		sm.appendPurgeMarker(metapb.PurgeMarker{Index: 1})
+		old := sm.getPurgeMarkers()
+		for i := uint64(2); i <= maxPurgeMarkers+10; i++ {
+			sm.appendPurgeMarker(metapb.PurgeMarker{Index: i})
+		}
+
+		// the returned markers are not modified by the later purges
+		assert.Equal(t, []metapb.PurgeMarker{{Index: 1}}, old)
+		markers := sm.getPurgeMarkers()
+		require.Equal(t, maxPurgeMarkers, len(markers))
+		assert.Equal(t, uint64(11), markers[0].Index)
+		assert.Equal(t, uint64(maxPurgeMarkers+10), markers[len(markers)-1].Index)
+	}
+	runSimpleStateMachineTest(t, f, nil)
+}
+
+func TestStateMachinePurge(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		sm.updateShard(Shard{ID: 100, End: []byte("y")})
+		kv := sm.dataStorage.(storage.KVStorageWrapper).GetKVStorage()
+		for _, key := range []string{"a", "b", "c", "d", "x"} {
+			require.NoError(t, kv.Set(keysutil.EncodeDataKey([]byte(key), nil), []byte(key), false))
+		}
+
+		batch := newTestAdminRequestBatch("", 0, rpcpb.CmdPurge, protoc.MustMarshal(&rpcpb.PurgeRequest{
+			Start: []byte("c"),
+			End:   []byte("z"),
+			Keys:  [][]byte{[]byte("a"), []byte("z")},
+		}))
+		batch.Header.ShardID = 100
+		sm.applyCommittedEntries([]raftpb.Entry{{
+			Index: 1,
+			Term:  1,
+			Type:  raftpb.EntryNormal,
+			Data:  protoc.MustMarshal(&batch),
+		}})
+
+		expect := metapb.PurgeMarker{
+			Index: 1,
+			Start: []byte("c"),
+			End:   []byte("y"),
+			Keys:  [][]byte{[]byte("a")},
+		}
+		resp := h.resp.GetPurgeResponse()
+		assert.True(t, resp.Purged)
+		assert.Equal(t, expect.Index, resp.Index)
+		assert.Equal(t, expect.Start, resp.Start)
+		assert.Equal(t, expect.End, resp.End)
+		assert.Equal(t, expect.Keys, resp.Keys)
+		assert.Equal(t, []metapb.PurgeMarker{expect}, sm.getPurgeMarkers())
+
+		for key, exist := range map[string]bool{"a": false, "b": true, "c": false, "d": false, "x": false} {
+			v, err := kv.Get(keysutil.EncodeDataKey([]byte(key), nil))
+			require.NoError(t, err)
+			assert.Equal(t, exist, len(v) > 0, key)
+		}
+
+		// persisted with the shard metadata
+		states, err := sm.dataStorage.GetInitialStates()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(states))
+		assert.Equal(t, uint64(1), states[0].LogIndex)
+		assert.Equal(t, []metapb.PurgeMarker{expect}, states[0].Metadata.Purges)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachineWitnessPurge(t *testing.T) {
+	f := func(sm *stateMachine) {
+		sm.updateShard(Shard{ID: 100, Replicas: []Replica{{ID: 100, Role: metapb.ReplicaRole_Witness}}})
+		ctx := newApplyContext()
+		ctx.index = 1
+		ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdPurge, protoc.MustMarshal(&rpcpb.PurgeRequest{
+			Keys: [][]byte{[]byte("a")},
+		}))
+		resp, err := sm.execAdminRequest(ctx)
+		require.NoError(t, err)
+		assert.True(t, resp.GetPurgeResponse().Purged)
+		assert.Equal(t, []metapb.PurgeMarker{{Index: 1, Keys: [][]byte{[]byte("a")}}}, sm.getPurgeMarkers())
+		assert.True(t, ctx.adminResult.purgeResult.purged)
+	}
+	runSimpleStateMachineTest(t, f, nil)
+}
+
+func TestPurgeMarkerContains(t *testing.T) {
+	m := metapb.PurgeMarker{Start: []byte("b"), End: []byte("d"), Keys: [][]byte{[]byte("x")}}
+	assert.False(t, m.Contains([]byte("a")))
+	assert.True(t, m.Contains([]byte("b")))
+	assert.True(t, m.Contains([]byte("c")))
+	assert.False(t, m.Contains([]byte("d")))
+	assert.True(t, m.Contains([]byte("x")))
+
+	m = metapb.PurgeMarker{Keys: [][]byte{[]byte("x")}}
+	assert.False(t, m.Contains([]byte("a")))
+	assert.True(t, m.Contains([]byte("x")))
+	assert.Equal(t, "purge", aware.AdminPurge.String())
+}
@@ -0,0 +1,142 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"go.uber.org/zap"
+)
+
+// quorumLossDetector detects whether the shard has lost the write quorum from
+// the view of the local replica. The quorum is lost if the shard has no leader
+// for the timeout, and the replica received no raft message from the majority
+// of the voters in the timeout. It's only used by the event loop except the
+// lost flag.
+type quorumLossDetector struct {
+	// noLeaderSince the time since when the shard has no leader
+	noLeaderSince time.Time
+	// lastMessages the time of the last raft message from the replicas
+	lastMessages map[uint64]time.Time
+	lost         uint32
+}
+
+// observe records the raft message received from the replica
+func (d *quorumLossDetector) observe(from uint64, now time.Time) {
+	if d.lastMessages == nil {
+		d.lastMessages = make(map[uint64]time.Time)
+	}
+	d.lastMessages[from] = now
+}
+
+// check updates the lost flag, returns true if the flag is changed
+func (d *quorumLossDetector) check(now time.Time, timeout time.Duration,
+	leader, self uint64, voters []Replica) bool {
+	if leader != 0 {
+		d.noLeaderSince = time.Time{}
+		return d.setLost(false)
+	}
+	if d.noLeaderSince.IsZero() {
+		d.noLeaderSince = now
+	}
+	if now.Sub(d.noLeaderSince) < timeout {
+		return false
+	}
+
+	reachable := 0
+	for _, v := range voters {
+		if v.ID == self {
+			reachable++
+		} else if last, ok := d.lastMessages[v.ID]; ok && now.Sub(last) < timeout {
+			reachable++
+		}
+	}
+	return d.setLost(reachable <= len(voters)/2)
+}
+
+func (d *quorumLossDetector) setLost(lost bool) bool {
+	var v uint32
+	if lost {
+		v = 1
+	}
+	return atomic.SwapUint32(&d.lost, v) != v
+}
+
+func (d *quorumLossDetector) isLost() bool {
+	return atomic.LoadUint32(&d.lost) == 1
+}
+
+// checkQuorumLoss is called by the event loop on ticks to update the quorum
+// loss state of the shard
+func (pr *replica) checkQuorumLoss() {
+	timeout := pr.cfg.QuorumLoss.DetectTimeout.Duration
+	if timeout == 0 {
+		return
+	}
+
+	leader := pr.getLeaderReplicaID()
+	var voters []Replica
+	if leader == 0 {
+		for _, r := range pr.getShard().Replicas {
+			if r.Role == metapb.ReplicaRole_Voter ||
+				r.Role == metapb.ReplicaRole_IncomingVoter ||
+				r.Role == metapb.ReplicaRole_Witness {
+				voters = append(voters, r)
+			}
+		}
+	}
+	if !pr.quorumLoss.check(pr.store.now(), timeout, leader, pr.replicaID, voters) {
+		return
+	}
+	if pr.quorumLoss.isLost() {
+		pr.logger.Warn("shard lost the write quorum, only serve stale reads",
+			zap.Duration("timeout", timeout),
+			zap.Int("voters", len(voters)))
+	} else {
+		pr.logger.Info("shard write quorum restored",
+			zap.Uint64("leader", leader))
+	}
+}
+
+// rejectQuorumLost handles the batch without the raft if the shard has lost
+// the write quorum. The reads allowing stale reads are served from the local
+// state, and the other requests are rejected, so the requests fail fast instead
+// of timing out. Returns false if the quorum is not lost.
+func (pr *replica) rejectQuorumLost(c batch) bool {
+	if !pr.quorumLoss.isLost() {
+		return false
+	}
+
+	var rejected []rpcpb.Request
+	if c.tp == read {
+		applyLag := pr.getApplyLag()
+		for _, req := range c.requestBatch.Requests {
+			if req.AllowStaleRead {
+				pr.execReadRequestWithApplyLag(req, applyLag)
+				continue
+			}
+			rejected = append(rejected, req)
+		}
+	} else {
+		rejected = c.requestBatch.Requests
+	}
+	if len(rejected) > 0 {
+		c.requestBatch.Requests = rejected
+		c.respQuorumLost(pr.shardID)
+	}
+	return true
+}
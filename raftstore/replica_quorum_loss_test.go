@@ -0,0 +1,173 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage/executor"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorumLossDetector(t *testing.T) {
+	var d quorumLossDetector
+	timeout := time.Second
+	voters := []Replica{{ID: 1}, {ID: 2}, {ID: 3}}
+	now := time.Now()
+
+	// has leader
+	assert.False(t, d.check(now, timeout, 2, 1, voters))
+	assert.False(t, d.isLost())
+
+	// no leader within the timeout
+	assert.False(t, d.check(now, timeout, 0, 1, voters))
+	now = now.Add(timeout / 2)
+	assert.False(t, d.check(now, timeout, 0, 1, voters))
+	assert.False(t, d.isLost())
+
+	// no leader, but the majority is reachable
+	d.observe(2, now)
+	now = now.Add(timeout / 2)
+	assert.False(t, d.check(now, timeout, 0, 1, voters))
+	assert.False(t, d.isLost())
+
+	// the majority is unreachable
+	now = now.Add(timeout)
+	assert.True(t, d.check(now, timeout, 0, 1, voters))
+	assert.True(t, d.isLost())
+	assert.False(t, d.check(now, timeout, 0, 1, voters))
+
+	// the peer is back, but still no leader
+	d.observe(3, now)
+	assert.True(t, d.check(now, timeout, 0, 1, voters))
+	assert.False(t, d.isLost())
+
+	d.observe(3, now.Add(-2*timeout))
+	assert.True(t, d.check(now, timeout, 0, 1, voters))
+	assert.True(t, d.isLost())
+
+	// elected
+	assert.True(t, d.check(now, timeout, 3, 1, voters))
+	assert.False(t, d.isLost())
+	assert.True(t, d.noLeaderSince.IsZero())
+}
+
+func TestRejectQuorumLost(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	proxy := &testResponseProxy{c: make(chan rpcpb.ResponseBatch, 1)}
+	s.shardsProxy = proxy
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	defer pr.readStopper.Stop()
+
+	stale := executor.NewReadRequest([]byte("k1"))
+	linearizable := executor.NewReadRequest([]byte("k2"))
+	var responses []rpcpb.ResponseBatch
+	cb := func(resp rpcpb.ResponseBatch) {
+		responses = append(responses, resp)
+	}
+	reads := newBatch(s.logger, rpcpb.RequestBatch{
+		Header: rpcpb.RequestBatchHeader{ID: []byte("reads")},
+		Requests: []rpcpb.Request{
+			{ID: []byte("stale"), Type: rpcpb.Read, CustomType: stale.CmdType, Key: stale.Key, Cmd: stale.Cmd, AllowStaleRead: true},
+			{ID: []byte("linearizable"), Type: rpcpb.Read, CustomType: linearizable.CmdType, Key: linearizable.Key, Cmd: linearizable.Cmd},
+		},
+	}, cb, read, 0)
+	writes := newBatch(s.logger, rpcpb.RequestBatch{
+		Header:   rpcpb.RequestBatchHeader{ID: []byte("writes")},
+		Requests: []rpcpb.Request{{ID: []byte("write"), Type: rpcpb.Write, AllowStaleRead: true}},
+	}, cb, write, 0)
+
+	assert.False(t, pr.rejectQuorumLost(reads))
+	assert.False(t, pr.rejectQuorumLost(writes))
+	assert.Empty(t, responses)
+
+	pr.quorumLoss.setLost(true)
+	assert.True(t, pr.rejectQuorumLost(writes))
+	require.Equal(t, 1, len(responses))
+	require.Equal(t, 1, len(responses[0].Responses))
+	assert.Equal(t, []byte("write"), responses[0].Responses[0].ID)
+	assert.Equal(t, &errorpb.QuorumLost{ShardID: 1}, responses[0].Responses[0].Error.QuorumLost)
+	assert.False(t, errorpb.Retryable(responses[0].Responses[0].Error))
+
+	assert.True(t, pr.rejectQuorumLost(reads))
+	require.Equal(t, 2, len(responses))
+	require.Equal(t, 1, len(responses[1].Responses))
+	assert.Equal(t, []byte("linearizable"), responses[1].Responses[0].ID)
+	assert.NotNil(t, responses[1].Responses[0].Error.QuorumLost)
+
+	resp := <-proxy.c
+	require.Equal(t, 1, len(resp.Responses))
+	assert.Equal(t, []byte("stale"), resp.Responses[0].ID)
+	assert.False(t, errorpb.HasError(resp.Responses[0].Error))
+}
+
+func TestQuorumLossWithNetworkPartition(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+		return
+	}
+
+	defer leaktest.AfterTest(t)()
+	c := NewTestClusterStore(t,
+		WithTestClusterNodeCount(3),
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.QuorumLoss.DetectTimeout = typeutil.NewDuration(time.Second)
+		}))
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	shardID := c.GetShardByIndex(0, 0).ID
+	c.WaitAllReplicasChangeToVoter(shardID, testWaitTimeout)
+
+	waitQuorumLost := func(node int, lost bool) {
+		s := c.GetStore(node).(*store)
+		timeout := time.After(testWaitTimeout)
+		for {
+			if pr := s.getReplica(shardID, false); pr != nil && pr.quorumLoss.isLost() == lost {
+				return
+			}
+			select {
+			case <-timeout:
+				assert.FailNowf(t, "", "wait quorum lost %v of node %d timeout", lost, node)
+			default:
+				time.Sleep(100 * time.Millisecond)
+			}
+		}
+	}
+
+	// node2 lost the leader and the majority of the voters
+	c.StartNetworkPartition([][]int{{0, 1}, {2}})
+	waitQuorumLost(2, true)
+	req, err := c.GetStore(2).(*store).getStoreHeartbeat(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{shardID}, req.Stats.QuorumLostShards)
+	// the majority still has the leader
+	for _, node := range []int{0, 1} {
+		assert.False(t, c.GetStore(node).(*store).getReplica(shardID, false).quorumLoss.isLost())
+	}
+
+	c.StopNetworkPartition()
+	waitQuorumLost(2, false)
+}
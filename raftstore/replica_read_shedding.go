@@ -0,0 +1,67 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"go.uber.org/zap"
+)
+
+// getApplyLag returns the count of the committed but not applied entries
+func (pr *replica) getApplyLag() uint64 {
+	if pr.lastCommittedIndex > pr.appliedIndex {
+		return pr.lastCommittedIndex - pr.appliedIndex
+	}
+	return 0
+}
+
+// shedReads handles the read batch without the read index if the apply lag
+// of the replica exceeds the threshold. The reads allowing stale reads are
+// served from the local state immediately, and the others are rejected, so the
+// reads fail fast instead of waiting for the catch-up. Returns false if the
+// apply lag is acceptable and the batch is not handled.
+func (pr *replica) shedReads(c batch) bool {
+	maxApplyLag := pr.cfg.ReadLoadShedding.MaxApplyLag
+	if maxApplyLag == 0 {
+		return false
+	}
+	applyLag := pr.getApplyLag()
+	if applyLag <= maxApplyLag {
+		return false
+	}
+
+	var rejected []rpcpb.Request
+	for _, req := range c.requestBatch.Requests {
+		if req.AllowStaleRead {
+			metric.IncReadLoadShedding("stale")
+			pr.execReadRequestWithApplyLag(req, applyLag)
+			continue
+		}
+		metric.IncReadLoadShedding("rejected")
+		rejected = append(rejected, req)
+	}
+	if ce := pr.logger.Check(zap.DebugLevel, "reads shed"); ce != nil {
+		ce.Write(log.RequestIDField(c.getRequestID()),
+			zap.Uint64("apply-lag", applyLag),
+			zap.Int("stale", len(c.requestBatch.Requests)-len(rejected)),
+			zap.Int("rejected", len(rejected)))
+	}
+	if len(rejected) > 0 {
+		c.requestBatch.Requests = rejected
+		c.respApplyLagTooLarge(pr.shardID, applyLag)
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage/executor"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testResponseProxy struct {
+	ShardsProxy
+	c chan rpcpb.ResponseBatch
+}
+
+func (p *testResponseProxy) OnResponse(resp rpcpb.ResponseBatch) {
+	p.c <- resp
+}
+
+func TestShedReads(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	proxy := &testResponseProxy{c: make(chan rpcpb.ResponseBatch, 1)}
+	s.shardsProxy = proxy
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	defer pr.readStopper.Stop()
+	pr.lastCommittedIndex = 10
+	pr.appliedIndex = 4
+
+	stale := executor.NewReadRequest([]byte("k1"))
+	linearizable := executor.NewReadRequest([]byte("k2"))
+	var responses []rpcpb.ResponseBatch
+	c := newBatch(s.logger, rpcpb.RequestBatch{
+		Header: rpcpb.RequestBatchHeader{ID: []byte("batch")},
+		Requests: []rpcpb.Request{
+			{ID: []byte("stale"), Type: rpcpb.Read, CustomType: stale.CmdType, Key: stale.Key, Cmd: stale.Cmd, AllowStaleRead: true},
+			{ID: []byte("linearizable"), Type: rpcpb.Read, CustomType: linearizable.CmdType, Key: linearizable.Key, Cmd: linearizable.Cmd},
+		},
+	}, func(resp rpcpb.ResponseBatch) {
+		responses = append(responses, resp)
+	}, read, 0)
+
+	// disabled
+	assert.False(t, pr.shedReads(c))
+	// the apply lag is acceptable
+	pr.cfg.ReadLoadShedding.MaxApplyLag = 6
+	assert.False(t, pr.shedReads(c))
+
+	pr.cfg.ReadLoadShedding.MaxApplyLag = 5
+	assert.True(t, pr.shedReads(c))
+	require.Equal(t, 1, len(responses))
+	require.Equal(t, 1, len(responses[0].Responses))
+	assert.Equal(t, []byte("linearizable"), responses[0].Responses[0].ID)
+	assert.Equal(t, &errorpb.ApplyLagTooLarge{ShardID: 1, ApplyLag: 6}, responses[0].Responses[0].Error.ApplyLagTooLarge)
+	assert.False(t, errorpb.Retryable(responses[0].Responses[0].Error))
+
+	resp := <-proxy.c
+	require.Equal(t, 1, len(resp.Responses))
+	assert.Equal(t, []byte("stale"), resp.Responses[0].ID)
+	assert.Equal(t, uint64(6), resp.Responses[0].ApplyLag)
+	assert.False(t, errorpb.HasError(resp.Responses[0].Error))
+}
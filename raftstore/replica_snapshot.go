@@ -30,6 +30,10 @@ func (pr *replica) handleRaftCreateSnapshotRequest() error {
 		return nil
 	}
 	pr.logger.Info("requested to create snapshot")
+	if cp, ok := pr.sm.dataStorage.(storage.SnapshotCheckpointer); ok &&
+		pr.store.snapshotGenerator != nil {
+		return pr.generateSnapshot(cp)
+	}
 	ss, created, err := pr.createSnapshot()
 	if err != nil {
 		return err
@@ -48,6 +52,12 @@ func (pr *replica) createSnapshot() (raftpb.Snapshot, bool, error) {
 	}
 	logger := pr.logger.With(
 		zap.Uint64("snapshot-index", index))
+	// the snapshot can't include the chunks of an incomplete request batch, the
+	// replica recovered from the snapshot would never see the whole batch
+	if pr.sm.chunks.pending() {
+		logger.Info("snapshot skipped, waiting for the rest chunks")
+		return raftpb.Snapshot{}, false, nil
+	}
 
 	cs := pr.sm.getConfState()
 	logger.Info("createSnapshot called",
@@ -94,17 +104,129 @@ func (pr *replica) createSnapshot() (raftpb.Snapshot, bool, error) {
 	return ss, true, nil
 }
 
+// generateSnapshot takes a checkpoint of the data storage and saves the snapshot
+// from the checkpoint in the snapshotGenerator, so the apply loop is not
+// blocked by saving a big snapshot to rebuild a lagging replica. The snapshot
+// is registered with the raft instance once it's saved. Only one snapshot of
+// the replica is generated at a time, raft requests the snapshot again if it's
+// still needed.
+func (pr *replica) generateSnapshot(cp storage.SnapshotCheckpointer) error {
+	if pr.snapshotGenerating {
+		pr.logger.Info("snapshot skipped, the previous one is being generated")
+		return nil
+	}
+	index, term := pr.sm.getAppliedIndexTerm()
+	if index == 0 {
+		panic("invalid snapshot index")
+	}
+	logger := pr.logger.With(
+		zap.Uint64("snapshot-index", index))
+	if pr.sm.chunks.pending() {
+		logger.Info("snapshot skipped, waiting for the rest chunks")
+		return nil
+	}
+
+	cs := pr.sm.getConfState()
+	checkpoint, err := cp.CreateSnapshotCheckpoint(pr.shardID)
+	if err != nil {
+		logger.Error("failed to create snapshot checkpoint",
+			zap.Error(err))
+		return err
+	}
+	logger.Info("snapshot checkpoint created",
+		zap.Uint64("snapshot-term", term),
+		log.ReplicaIDsField("voters", cs.Voters),
+		log.ReplicaIDsField("learners", cs.Learners))
+
+	closeCheckpoint := func() {
+		if err := checkpoint.Close(); err != nil {
+			logger.Error("failed to close snapshot checkpoint",
+				zap.Error(err))
+		}
+	}
+	task := snapshotTask{
+		generate: func() {
+			defer closeCheckpoint()
+			ss, env, err := pr.snapshotter.save(checkpointSaver{checkpoint}, cs, index, term)
+			if err == nil {
+				err = pr.snapshotter.commit(ss, env)
+			}
+			pr.addAction(action{
+				actionType: snapshotGeneratedAction,
+				snapshotGenerated: snapshotGeneratedDetails{
+					snapshot: ss,
+					env:      env,
+					err:      err,
+				},
+			})
+		},
+		cancel: closeCheckpoint,
+	}
+	if !pr.store.snapshotGenerator.add(task) {
+		logger.Info("snapshot skipped, too many snapshots being generated")
+		task.cancel()
+		return nil
+	}
+	pr.snapshotGenerating = true
+	return nil
+}
+
+// handleSnapshotGenerated registers the snapshot saved by the snapshotGenerator
+// with the raft instance, the snapshot is dropped if a more recent one has been
+// registered or applied in the meantime.
+func (pr *replica) handleSnapshotGenerated(details snapshotGeneratedDetails) error {
+	pr.snapshotGenerating = false
+	ss := details.snapshot
+	logger := pr.logger.With(log.SnapshotField(ss))
+	if err := details.err; err != nil {
+		if errors.Is(err, storage.ErrAborted) {
+			logger.Info("snapshot aborted")
+		} else {
+			logger.Error("failed to generate snapshot",
+				zap.Error(err))
+		}
+		details.env.MustRemoveTempDir()
+		return nil
+	}
+	if err := pr.lr.CreateSnapshot(ss); err != nil {
+		if errors.Is(err, raft.ErrSnapOutOfDate) {
+			logger.Info("generated snapshot is out of date")
+			return pr.removeSnapshot(ss, false)
+		}
+		logger.Error("failed to register the snapshot with the LogReader",
+			zap.Error(err))
+		return err
+	}
+	logger.Info("snapshot created and registered with the raft instance")
+	return nil
+}
+
+// checkpointSaver saves the snapshot of the checkpoint by the snapshotter
+type checkpointSaver struct {
+	checkpoint storage.SnapshotCheckpoint
+}
+
+func (c checkpointSaver) CreateSnapshot(shardID uint64, path string) error {
+	return c.checkpoint.Save(path)
+}
+
 func (pr *replica) applySnapshot(ss raftpb.Snapshot) error {
 	logger := pr.logger.With(log.SnapshotField(ss))
 	// double check whether we are trying to recover from a dummy snapshot
+	var si metapb.SnapshotInfo
 	if len(ss.Data) > 0 {
-		var si metapb.SnapshotInfo
 		protoc.MustUnmarshal(&si, ss.Data)
 		if si.Dummy {
 			logger.Fatal("trying to recover from a dummy snapshot")
 		}
 	}
-	md, err := pr.snapshotter.recover(pr.sm.dataStorage, ss)
+	var md metapb.ShardMetadata
+	var err error
+	if si.Witness {
+		md, err = pr.sm.applyWitnessSnapshot(ss, si)
+	} else {
+		md, err = pr.snapshotter.recover(pr.sm.dataStorage, ss)
+	}
 	if err != nil {
 		logger.Error("failed to recover from the snapshot",
 			zap.Error(err))
@@ -121,12 +243,18 @@ func (pr *replica) applySnapshot(ss raftpb.Snapshot) error {
 	}
 	pr.sm.updateShard(md.Metadata.Shard)
 	pr.sm.updateLease(md.Metadata.Lease)
+	pr.sm.updateConfigChanges(md.Metadata.ConfigChanges)
+	pr.sm.updatePurgeMarkers(md.Metadata.Purges)
+	pr.sm.updateAppliedAdmins(md.Metadata.AppliedAdmins)
+	pr.sm.updateDictionaries(md.Metadata.Dictionaries)
 	// after snapshot applied, the shard range may changed, so we
 	// need update key ranges
 	pr.store.updateShardKeyRange(pr.group, md.Metadata.Shard)
 	// r.replica is more like a local cached copy of the replica record.
 	pr.replica = *findReplica(pr.getShard(), pr.storeID)
 	pr.sm.updateAppliedIndexTerm(ss.Metadata.Index, ss.Metadata.Term)
+	pr.sm.consistency.reset()
+	pr.sm.resetWriteRetry()
 	// persistentLogIndex is not guaranteed to be the same as ss.Metadata.Index
 	// as the log entry at ss.Metadata.Index, including a few nearby entries
 	// are entries not visible to the state machine, e.g. NOOP entries or admin
@@ -136,6 +264,7 @@ func (pr *replica) applySnapshot(ss raftpb.Snapshot) error {
 	if err != nil {
 		return err
 	}
+	pr.sm.lastWriteIndex = restoreLastWriteIndex(md, persistentLogIndex)
 	pr.addAction(action{
 		actionType: snapshotCompactionAction,
 		snapshotCompaction: snapshotCompactionDetails{
@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"testing"
+	"time"
 
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/log"
@@ -116,6 +117,51 @@ func TestReplicaSnapshotCanBeCreated(t *testing.T) {
 	runReplicaSnapshotTest(t, fn, fs)
 }
 
+func TestReplicaSnapshotCanBeGenerated(t *testing.T) {
+	fn := func(t *testing.T, r *replica, fs vfs.FS) {
+		r.store.snapshotGenerator = newSnapshotGenerator(1)
+		r.store.snapshotGenerator.start()
+		defer r.store.snapshotGenerator.close()
+
+		requestSnapshot := func() {
+			_, err := r.lr.Snapshot()
+			require.Equal(t, raft.ErrSnapshotTemporarilyUnavailable, err)
+		}
+		requestSnapshot()
+		require.NoError(t, r.handleRaftCreateSnapshotRequest())
+		assert.True(t, r.snapshotGenerating)
+		// only one snapshot is generated at a time
+		requestSnapshot()
+		require.NoError(t, r.handleRaftCreateSnapshotRequest())
+
+		for r.actions.Len() == 0 {
+			time.Sleep(time.Millisecond * 10)
+		}
+		assert.Equal(t, int64(1), r.actions.Len())
+		r.items = make([]interface{}, readyBatchSize)
+		_, err := r.handleAction(r.items)
+		require.NoError(t, err)
+		assert.False(t, r.snapshotGenerating)
+
+		ss, err := r.lr.Snapshot()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), ss.Metadata.Index)
+		assert.Equal(t, uint64(1), ss.Metadata.Term)
+		env := r.snapshotter.getRecoverSnapshotEnv(ss)
+		dbf := fs.PathJoin(env.GetFinalDir(), "db.data")
+		if _, err := fs.Stat(dbf); vfs.IsNotExist(err) {
+			t.Errorf("snapshot data file not created, %v", err)
+		}
+
+		// the out of date snapshot is removed
+		require.NoError(t, r.lr.CreateSnapshot(raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 200}}))
+		require.NoError(t, r.handleSnapshotGenerated(snapshotGeneratedDetails{snapshot: ss, env: env}))
+		assert.False(t, env.FinalDirExists())
+	}
+	fs := vfs.GetTestFS()
+	runReplicaSnapshotTest(t, fn, fs)
+}
+
 // other related tests
 // TestApplyInitialSnapshot
 // TestApplyReceivedSnapshot
@@ -197,6 +243,52 @@ func TestReplicaSnapshotCanBeApplied(t *testing.T) {
 	runReplicaSnapshotTest(t, fn, fs)
 }
 
+func TestReplicaWitnessSnapshotCanBeApplied(t *testing.T) {
+	fn := func(t *testing.T, r *replica, fs vfs.FS) {
+		r.store.updateShardKeyRange(r.getShard().Group, r.getShard())
+
+		replicaRec := Replica{ID: 1, StoreID: 100, Role: metapb.ReplicaRole_Witness}
+		shard := Shard{ID: 1, Start: []byte{1}, End: []byte{2},
+			Replicas: []Replica{replicaRec, {ID: 2, StoreID: 200}}}
+		r.sm.updateShard(shard)
+		ss := r.newWitnessSnapshot()
+		assert.Equal(t, uint64(100), ss.Metadata.Index)
+		assert.Equal(t, uint64(1), ss.Metadata.Term)
+		assert.Equal(t, []uint64{1, 2}, ss.Metadata.ConfState.Voters)
+
+		// reset the data storage
+		dsMem := mem.NewStorage()
+		base := kv.NewBaseStorage(dsMem, fs)
+		ds := kv.NewKVDataStorage(base, nil)
+		defer ds.Close()
+
+		r.sm = newStateMachine(r.logger, ds, r.logdb, Shard{ID: 1}, replicaRec, nil, nil, nil)
+		_, err := r.sm.dataStorage.GetInitialStates()
+		assert.NoError(t, err)
+
+		r.replicaID = replicaRec.ID
+		r.replica = Replica{}
+		assert.NoError(t, r.applySnapshot(ss))
+		_, err = r.handleAction(make([]interface{}, readyBatchSize))
+		require.NoError(t, err)
+
+		persistentLogIndex, err := r.getPersistentLogIndex()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(100), persistentLogIndex)
+		assert.Equal(t, replicaRec, r.replica)
+		assert.Equal(t, shard, r.getShard())
+		assert.True(t, r.isWitness())
+
+		sms, err := r.sm.dataStorage.GetInitialStates()
+		assert.NoError(t, err)
+		require.Equal(t, 1, len(sms))
+		assert.Equal(t, shard, sms[0].Metadata.Shard)
+		assert.Equal(t, uint64(100), sms[0].LogIndex)
+	}
+	fs := vfs.GetTestFS()
+	runReplicaSnapshotTest(t, fn, fs)
+}
+
 func TestCreatingTheSameSnapshotAgainIsTolerated(t *testing.T) {
 	fn := func(t *testing.T, r *replica, fs vfs.FS) {
 		ss1, created, err := r.createSnapshot()
@@ -14,10 +14,15 @@
 package raftstore
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+
 	trackerPkg "go.etcd.io/etcd/raft/v3/tracker"
 	"go.uber.org/zap"
 
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 )
 
@@ -26,8 +31,14 @@ func (pr *replica) tryCheckSplit(act action) bool {
 		return false
 	}
 
-	if !pr.needDoCheckSplit() {
-		return false
+	// the size based split is preferred, the load based split key is used if
+	// the shard is hot but not too large
+	var task interface{} = pr.getShard()
+	if loadSplitKey := pr.checkLoadSplit(pr.store.now()); !pr.needDoCheckSplit() {
+		if loadSplitKey == nil {
+			return false
+		}
+		task = splitCheckTask{shard: pr.getShard(), splitKeys: [][]byte{loadSplitKey}}
 	}
 
 	// If a replica is applying snapshot, skip split, avoid sent snapshot again in future.
@@ -35,6 +46,7 @@ func (pr *replica) tryCheckSplit(act action) bool {
 		pr.logger.Debug("check split skipped",
 			log.ReplicaIDField(id),
 			log.ReasonField("applying snapshot"))
+		pr.sm.blocking.record(metapb.ShardOperation_Split, blockingPendingSnapshot)
 		return false
 	}
 
@@ -46,10 +58,72 @@ func (pr *replica) tryCheckSplit(act action) bool {
 			log.ReasonField("missing callback"))
 	}
 
-	act.actionCallback(pr.getShard())
+	act.actionCallback(task)
 	return true
 }
 
+// requestSplitShard submits the split of the shard by the keys specified in the
+// request. Like the transfer leader, there is no guarantee that the split will
+// successfully complete.
+func (pr *replica) requestSplitShard(c batch) {
+	req := c.requestBatch.GetSplitShardRequest()
+	if err := pr.splitShard(req.SplitKeys); err != nil {
+		switch {
+		case errors.Is(err, errInvalidSplitKeys):
+			c.respInvalidSplitKeys(pr.shardID, err.Error())
+		case errors.Is(err, errServerIsBusy):
+			c.respServerIsBusy(pr.shardID, "split checker is busy")
+		default:
+			c.respOtherError(err)
+		}
+		return
+	}
+	c.resp(newAdminResponseBatch(rpcpb.CmdSplitShard,
+		&rpcpb.SplitShardResponse{}))
+}
+
+// splitShard submits the manual split task to the split checker, the new shard
+// ids are allocated by the split checker and then the shard is split by the
+// BatchSplit admin command.
+func (pr *replica) splitShard(splitKeys [][]byte) error {
+	if !pr.isLeader() {
+		return errNotLeader
+	}
+
+	shard := pr.getShard()
+	if err := checkSplitKeys(shard, splitKeys); err != nil {
+		return err
+	}
+	if !pr.store.splitChecker.addTask(splitCheckTask{shard: shard, splitKeys: splitKeys, manual: true}) {
+		return errServerIsBusy
+	}
+
+	pr.logger.Info("manual split submitted",
+		log.EpochField("epoch", shard.Epoch),
+		zap.ByteStrings("split-keys", splitKeys))
+	return nil
+}
+
+// checkSplitKeys returns an error if the split keys are not in ascending order,
+// or not inside the shard range. The start key of the shard is not a valid split
+// key.
+func checkSplitKeys(shard Shard, splitKeys [][]byte) error {
+	if len(splitKeys) == 0 {
+		return fmt.Errorf("%w: empty", errInvalidSplitKeys)
+	}
+	for idx, key := range splitKeys {
+		if !keyInShard(key, shard) || bytes.Equal(key, shard.Start) {
+			return fmt.Errorf("%w: key %x not in shard %d [%x, %x)",
+				errInvalidSplitKeys, key, shard.ID, shard.Start, shard.End)
+		}
+		if idx > 0 && bytes.Compare(key, splitKeys[idx-1]) <= 0 {
+			return fmt.Errorf("%w: key %x not greater than the previous key %x",
+				errInvalidSplitKeys, key, splitKeys[idx-1])
+		}
+	}
+	return nil
+}
+
 func (pr *replica) hasReplicaInSnapshotState() (bool, uint64) {
 	for id, p := range pr.rn.Status().Progress {
 		// If a peer is apply snapshot, skip split, avoid sent snapshot again in future.
@@ -75,6 +149,7 @@ func (pr *replica) doSplit(act action) {
 		pr.logger.Info("epoch changed, need re-check later",
 			log.EpochField("current-epoch", current.Epoch),
 			log.EpochField("check-epoch", epoch))
+		pr.sm.blocking.record(metapb.ShardOperation_Split, blockingEpochMismatch)
 		return
 	}
 
@@ -14,6 +14,7 @@
 package raftstore
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/fagongzi/util/protoc"
@@ -44,7 +45,7 @@ func TestTryCheckSplit(t *testing.T) {
 	assert.False(t, pr.tryCheckSplit(action{actionType: checkSplitAction}))
 
 	pr.feature.ShardSplitCheckBytes = 99
-	pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
+	pr.rn, _ = raft.NewRawNode(getRaftConfig(pr.replicaID, 0, 0, pr.lr, &pr.cfg, log.Adjust(nil)))
 	assert.True(t, pr.tryCheckSplit(action{actionType: checkSplitAction, actionCallback: func(v interface{}) {
 		assert.Equal(t, pr.getShard(), v)
 	}}))
@@ -115,3 +116,57 @@ func TestDoSplit(t *testing.T) {
 	assert.Equal(t, pr.getShard().End, req.Requests[1].End)
 	assert.Equal(t, act.splitCheckData.splitIDs[1].NewID, req.Requests[1].NewShardID)
 }
+
+func TestCheckSplitKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	shard := Shard{ID: 1, Start: []byte("b"), End: []byte("y")}
+	tests := []struct {
+		keys []string
+		ok   bool
+	}{
+		{nil, false},
+		{[]string{"a"}, false},
+		{[]string{"b"}, false},
+		{[]string{"y"}, false},
+		{[]string{"d", "c"}, false},
+		{[]string{"c", "c"}, false},
+		{[]string{"c"}, true},
+		{[]string{"c", "d", "x"}, true},
+	}
+
+	for i, tt := range tests {
+		var keys [][]byte
+		for _, key := range tt.keys {
+			keys = append(keys, []byte(key))
+		}
+		err := checkSplitKeys(shard, keys)
+		assert.Equal(t, tt.ok, err == nil, "index %d", i)
+		if err != nil {
+			assert.True(t, errors.Is(err, errInvalidSplitKeys), "index %d", i)
+		}
+	}
+}
+
+func TestSplitShard(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1, Start: []byte("b"), End: []byte("y")}, Replica{ID: 1}, s)
+	s.addReplica(pr)
+
+	assert.Equal(t, errShardNotFound, s.SplitShard(2, [][]byte{[]byte("c")}))
+	assert.Equal(t, errNotLeader, s.SplitShard(1, [][]byte{[]byte("c")}))
+
+	pr.leaderID = 1
+	assert.True(t, errors.Is(s.SplitShard(1, [][]byte{[]byte("a")}), errInvalidSplitKeys))
+	// the split checker is not running
+	assert.Equal(t, errServerIsBusy, s.SplitShard(1, [][]byte{[]byte("c")}))
+
+	s.splitChecker.mu.running = true
+	assert.NoError(t, s.SplitShard(1, [][]byte{[]byte("c"), []byte("d")}))
+	assert.Equal(t, splitCheckTask{shard: pr.getShard(), splitKeys: [][]byte{[]byte("c"), []byte("d")}, manual: true},
+		<-s.splitChecker.shardsC)
+}
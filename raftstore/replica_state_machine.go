@@ -23,6 +23,7 @@ import (
 
 	"github.com/matrixorigin/matrixcube/aware"
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/logdb"
 	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/errorpb"
@@ -94,6 +95,44 @@ type stateMachine struct {
 	replicaCreatorFactory    replicaCreatorFactory
 	resultHandler            replicaResultHandler
 	aware                    aware.ShardStateAware
+	tracer                   *requestTracer
+	// splitAttributesFunc see CustomizeConfig.CustomSplitShardAttributesFunc
+	splitAttributesFunc func(parent, newShard Shard) []byte
+	applyCPU            applyCPUStats
+	blocking            blockingReasons
+	// chunks the applied chunks of the request batch larger than the max entry
+	// size
+	chunks proposalChunks
+	// persistentLogIndex the persistent log index pushed by the data storage,
+	// only used once persistentLogIndexPushed is set
+	persistentLogIndex       uint64
+	persistentLogIndexPushed uint32
+	// lastWriteIndex the index of the last applied write, the DeleteRange
+	// proposed before it is skipped. It's persisted with the shard metadata and
+	// restored on start or after applying a snapshot, see restoreLastWriteIndex.
+	lastWriteIndex uint64
+	// consistency the data checksum computed by the ComputeHash, see
+	// doComputeHash
+	consistency consistencyState
+	// ioError the handling of the data storage write failures, see
+	// config.IOErrorConfig
+	ioError config.IOErrorConfig
+	// ioFailed is 1 once the replica failed to write the data storage, the
+	// following entries are not applied until the store is restarted
+	ioFailed uint32
+	// onIOFailed is called once the replica failed to write the data storage
+	onIOFailed func(error)
+	// writeRetry the failed write waiting for the retry, see writeRetry
+	writeRetry *writeRetry
+	// onWriteRetry is called to retry the failed write after the backoff
+	onWriteRetry func(backoff time.Duration)
+	// throttleWrite is called with the bytes to be written into the data
+	// storage before applying the write requests, it blocks while the shard
+	// group used up its disk bandwidth share, see ioScheduler
+	throttleWrite func(n int64)
+	// lastCommitTime the max commit time of the applied entries, it's only
+	// accessed by the event worker
+	lastCommitTime int64
 
 	metadataMu struct {
 		sync.Mutex
@@ -105,6 +144,15 @@ type stateMachine struct {
 		term    uint64
 		// TODO: maybe should move to replica struct
 		firstIndex uint64
+		// configChanges the config change history, see GetConfigChangeHistory
+		configChanges []metapb.ConfigChangeRecord
+		// purges the purge markers, see GetPurgeMarkers
+		purges []metapb.PurgeMarker
+		// appliedAdmins the recent applied admin requests, the re-proposed admin
+		// requests are not applied again
+		appliedAdmins []metapb.AppliedAdminRecord
+		// dictionaries the compression dictionaries, see doUpdateDictionary
+		dictionaries []metapb.CompressionDictionary
 	}
 }
 
@@ -144,6 +192,14 @@ func (d *stateMachine) updateShard(shard Shard) {
 	d.metadataMu.shard = shard
 }
 
+// notifyKeyRangeChanged notifies the data storage if it observes the key range
+// changes.
+func notifyKeyRangeChanged(ds storage.DataStorage, change storage.KeyRangeChange) {
+	if observer, ok := ds.(storage.KeyRangeObserver); ok {
+		observer.KeyRangeChanged(change)
+	}
+}
+
 func (d *stateMachine) getShard() Shard {
 	d.metadataMu.Lock()
 	defer d.metadataMu.Unlock()
@@ -171,9 +227,11 @@ func (d *stateMachine) getConfState() raftpb.ConfState {
 	defer d.metadataMu.Unlock()
 	cs := raftpb.ConfState{}
 	for _, r := range d.metadataMu.shard.Replicas {
-		if r.Role == metapb.ReplicaRole_Voter {
+		if r.Role == metapb.ReplicaRole_Voter ||
+			r.Role == metapb.ReplicaRole_Witness {
 			cs.Voters = append(cs.Voters, r.ID)
-		} else if r.Role == metapb.ReplicaRole_Learner {
+		} else if r.Role == metapb.ReplicaRole_Learner ||
+			r.Role == metapb.ReplicaRole_Observer {
 			cs.Learners = append(cs.Learners, r.ID)
 		} else {
 			panic("unknown replica role")
@@ -187,59 +245,126 @@ func (d *stateMachine) applyCommittedEntries(entries []raftpb.Entry) {
 		return
 	}
 
+	if d.writeRetry != nil {
+		// applied in order once the failed write is retried
+		d.writeRetry.entries = append(d.writeRetry.entries, entries...)
+		return
+	}
+
 	d.logger.Debug("apply committed logs",
 		zap.Int("count", len(entries)))
 	start := time.Now()
 	// FIXME: the initial idea is to batch multiple entries into the same
 	// executeContext so they can be applied into the stateMachine together.
 	// in the loop below, we are still applying entries one by one.
-	for _, entry := range entries {
-		d.applyCtx.initialize(entry)
-		d.checkEntryIndexTerm(entry)
-		// notify all clients that current shard has been removed or splitted
-		if !d.canApply(entry) {
-			if ce := d.logger.Check(zap.DebugLevel, "apply committed log skipped"); ce != nil {
-				ce.Write(log.IndexField(entry.Index),
-					zap.String("type", entry.Type.String()),
-					log.ReasonField("continue check failed"))
-			}
-			d.notifyShardRemoved(d.applyCtx)
-			d.updateAppliedIndexTerm(entry.Index, entry.Term)
-			continue
-		}
-		if len(entry.Data) == 0 {
-			// noop entry with empty payload proposed by the leader at the beginning
-			// of its term
-			d.updateAppliedIndexTerm(entry.Index, entry.Term)
-			d.resultHandler.handleApplyResult(applyResult{
-				index:         entry.Index,
-				ignoreMetrics: true,
-			})
-			continue
+	for idx, entry := range entries {
+		d.applyEntry(entry, nil)
+		if d.writeRetry != nil {
+			d.writeRetry.entry = entry
+			protoc.MustUnmarshal(&d.writeRetry.req, protoc.MustMarshal(&d.applyCtx.req))
+			d.writeRetry.entries = append(d.writeRetry.entries, entries[idx+1:]...)
+			break
 		}
+	}
+	metric.ObserveRaftLogApplyDuration(start)
+}
 
-		ignoreMetrics := d.applyRequestBatch(d.applyCtx)
-		result := applyResult{
-			shardID:       d.shardID,
-			adminResult:   d.applyCtx.adminResult,
-			index:         entry.Index,
-			ignoreMetrics: ignoreMetrics,
-			metrics:       d.applyCtx.metrics,
+// applyEntry applies the committed entry. The request batch of the entry is
+// decoded from the entry unless req is specified, e.g. the chunks of the
+// retried entry are already reassembled.
+func (d *stateMachine) applyEntry(entry raftpb.Entry, req *rpcpb.RequestBatch) {
+	d.applyCtx.initialize(entry)
+	if d.isIOFailed() {
+		// applied again from the raft log after the store is restarted
+		d.notifyIOFailed(d.applyCtx)
+		return
+	}
+	d.checkEntryIndexTerm(entry)
+	if t := d.applyCtx.req.Header.CommitTime; t > d.lastCommitTime {
+		d.lastCommitTime = t
+	}
+	metric.ObserveRaftLogEntryBytes(getRaftLogEntryType(entry, d.applyCtx.req),
+		len(entry.Data))
+	// notify all clients that current shard has been removed or splitted
+	if !d.canApply(entry) {
+		if ce := d.logger.Check(zap.DebugLevel, "apply committed log skipped"); ce != nil {
+			ce.Write(log.IndexField(entry.Index),
+				zap.String("type", entry.Type.String()),
+				log.ReasonField("continue check failed"))
 		}
-		if isConfigChangeEntry(entry) {
-			if result.adminResult == nil {
-				result.adminResult = &adminResult{
-					adminType:          rpcpb.CmdConfigChange,
-					configChangeResult: configChangeResult{},
-				}
-			} else {
-				result.adminResult.configChangeResult.confChange = d.applyCtx.v2cc
-			}
+		if d.getShard().State == metapb.ShardState_Destroying {
+			d.recordBlocked(d.applyCtx.req, blockingDestroying)
 		}
+		d.notifyShardRemoved(d.applyCtx)
 		d.updateAppliedIndexTerm(entry.Index, entry.Term)
-		d.resultHandler.handleApplyResult(result)
+		return
 	}
-	metric.ObserveRaftLogApplyDuration(start)
+	if req != nil {
+		d.applyCtx.req = *req
+	} else if !d.applyChunk(d.applyCtx) {
+		d.updateAppliedIndexTerm(entry.Index, entry.Term)
+		d.resultHandler.handleApplyResult(applyResult{
+			index:         entry.Index,
+			ignoreMetrics: true,
+		})
+		return
+	} else {
+		d.decompressRequestBatch(d.applyCtx)
+	}
+	if len(entry.Data) == 0 {
+		// noop entry with empty payload proposed by the leader at the beginning
+		// of its term
+		d.updateAppliedIndexTerm(entry.Index, entry.Term)
+		d.resultHandler.handleApplyResult(applyResult{
+			index:         entry.Index,
+			ignoreMetrics: true,
+		})
+		return
+	}
+
+	d.applyCPU.addEntries(1)
+	ignoreMetrics := d.applyRequestBatch(d.applyCtx)
+	if d.isIOFailed() || d.isWriteRetryScheduled() {
+		return
+	}
+	result := applyResult{
+		shardID:       d.shardID,
+		adminResult:   d.applyCtx.adminResult,
+		index:         entry.Index,
+		ignoreMetrics: ignoreMetrics,
+		metrics:       d.applyCtx.metrics,
+		commitTime:    d.applyCtx.req.Header.CommitTime,
+	}
+	if isConfigChangeEntry(entry) {
+		if result.adminResult == nil {
+			result.adminResult = &adminResult{
+				adminType:          rpcpb.CmdConfigChange,
+				configChangeResult: configChangeResult{},
+			}
+		} else {
+			result.adminResult.configChangeResult.confChange = d.applyCtx.v2cc
+		}
+	}
+	d.updateAppliedIndexTerm(entry.Index, entry.Term)
+	d.resultHandler.handleApplyResult(result)
+}
+
+// applyChunk returns false if the entry is a chunk of a request batch and the
+// request batch is not completed. The request batch is reassembled into the
+// apply context once the last chunk is applied. The chunks are proposed
+// continuously, so any other entry drops the incomplete chunks.
+func (d *stateMachine) applyChunk(ctx *applyContext) bool {
+	if ctx.req.Chunk == nil {
+		d.chunks.reset()
+		return true
+	}
+
+	req, ok := d.chunks.add(ctx.req)
+	if !ok {
+		return false
+	}
+	ctx.req = req
+	return true
 }
 
 func (d *stateMachine) checkEntryIndexTerm(entry raftpb.Entry) {
@@ -281,13 +406,21 @@ func (d *stateMachine) applyRequestBatch(ctx *applyContext) bool {
 	var resp rpcpb.ResponseBatch
 	ignoreMetrics := true
 	if !d.checkEpoch(ctx.req) {
-		if ce := d.logger.Check(zap.DebugLevel, "apply committed log skipped"); ce != nil {
-			ce.Write(log.IndexField(ctx.index),
-				log.ReasonField("epoch check failed"),
-				log.EpochField("current-epoch", d.getShard().Epoch),
-				log.IndexField(ctx.index))
+		executed := false
+		if !ctx.req.IsAdmin() {
+			resp, executed = d.execStaleEpochWriteRequest(ctx)
+			ignoreMetrics = !executed
+		}
+		if !executed {
+			if ce := d.logger.Check(zap.DebugLevel, "apply committed log skipped"); ce != nil {
+				ce.Write(log.IndexField(ctx.index),
+					log.ReasonField("epoch check failed"),
+					log.EpochField("current-epoch", d.getShard().Epoch),
+					log.IndexField(ctx.index))
+			}
+			resp = errorStaleEpochResp(ctx.req.Header.ID, d.getShard())
+			d.recordBlocked(ctx.req, blockingEpochMismatch)
 		}
-		resp = errorStaleEpochResp(ctx.req.Header.ID, d.getShard())
 	} else if !d.checkLease(ctx.req) {
 		if ce := d.logger.Check(zap.DebugLevel, "apply committed log skipped"); ce != nil {
 			ce.Write(log.IndexField(ctx.index),
@@ -297,6 +430,13 @@ func (d *stateMachine) applyRequestBatch(ctx *applyContext) bool {
 				log.IndexField(ctx.index))
 		}
 		resp = errorLeaseMismatchResp(ctx.req.Header.ID, d.shardID, ctx.req.Header.Lease, d.metadataMu.lease)
+	} else if gateErr := d.checkShardGate(ctx.req); gateErr != nil {
+		// the gate was updated after the requests were proposed
+		if ce := d.logger.Check(zap.DebugLevel, "apply committed log skipped"); ce != nil {
+			ce.Write(log.IndexField(ctx.index),
+				log.ReasonField("blocked by shard gate"))
+		}
+		resp = errorPbResp(ctx.req.Header.ID, *gateErr)
 	} else {
 		if ce := d.logger.Check(zap.DebugLevel, "begin to apply committed log"); ce != nil {
 			ce.Write(log.IndexField(ctx.index),
@@ -308,9 +448,31 @@ func (d *stateMachine) applyRequestBatch(ctx *applyContext) bool {
 				ce.Write(log.IndexField(ctx.index),
 					zap.String("type", ctx.req.GetAdminCmdType().String()))
 			}
-			resp, err = d.execAdminRequest(ctx)
-			if err != nil {
-				resp = errorStaleEpochResp(ctx.req.Header.ID, d.getShard())
+			record, dedup := newAppliedAdminRecord(ctx.index, ctx.req)
+			if dedup && d.isAdminApplied(record) {
+				d.logger.Info("duplicated admin request skipped",
+					log.IndexField(ctx.index),
+					log.RequestIDField(record.RequestID),
+					log.EpochField("epoch", record.Epoch),
+					zap.String("type", ctx.req.GetAdminCmdType().String()))
+				resp = errorStaleCMDResp(ctx.req.Header.ID)
+			} else {
+				if dedup {
+					d.appendAppliedAdmin(record)
+				}
+				start := time.Now()
+				resp, err = d.execAdminRequest(ctx)
+				d.applyCPU.addHandler(start)
+				if dedup && (err != nil || ctx.adminResult == nil) {
+					// rejected or aborted, the request can be applied again
+					d.removeAppliedAdmin(ctx.index)
+				}
+				if err != nil {
+					resp = errorStaleEpochResp(ctx.req.Header.ID, d.getShard())
+					d.recordBlocked(ctx.req, err.Error())
+				} else {
+					d.recordBlocked(ctx.req, "")
+				}
 			}
 		} else {
 			if ce := d.logger.Check(zap.DebugLevel, "apply write requests"); ce != nil {
@@ -326,6 +488,11 @@ func (d *stateMachine) applyRequestBatch(ctx *applyContext) bool {
 		}
 	}
 
+	if d.isWriteRetryScheduled() {
+		// the proposal is notified once the write is retried
+		return ignoreMetrics
+	}
+	d.tracer.recordBatch(ctx.req.Requests, RequestApplied, d.shardID, ctx.index)
 	// TODO: this implies that we can't have more than one batch in the
 	// executeContext
 	d.resultHandler.notifyPendingProposal(ctx.req.Header.ID,
@@ -333,6 +500,11 @@ func (d *stateMachine) applyRequestBatch(ctx *applyContext) bool {
 	return ignoreMetrics
 }
 
+// getLastCommitTime returns the max commit time of the applied entries
+func (d *stateMachine) getLastCommitTime() int64 {
+	return d.lastCommitTime
+}
+
 func (d *stateMachine) close() {
 	d.writeCtx.close()
 }
@@ -421,6 +593,13 @@ func (d *stateMachine) checkLease(req rpcpb.RequestBatch) bool {
 	return d.metadataMu.lease.Match(req.Header.Lease)
 }
 
+func (d *stateMachine) checkShardGate(req rpcpb.RequestBatch) *errorpb.Error {
+	if req.IsAdmin() || len(req.Requests) == 0 {
+		return nil
+	}
+	return checkShardGate(req.Requests[0].Type, d.getShard())
+}
+
 func isConfigChangeEntry(entry raftpb.Entry) bool {
 	return entry.Type == raftpb.EntryConfChange ||
 		entry.Type == raftpb.EntryConfChangeV2
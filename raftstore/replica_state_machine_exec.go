@@ -16,12 +16,13 @@ package raftstore
 import (
 	"bytes"
 	"fmt"
-	"math"
 	"sort"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
@@ -49,6 +50,24 @@ func (d *stateMachine) execAdminRequest(ctx *applyContext) (rpcpb.ResponseBatch,
 		return d.doUpdateLabels(ctx)
 	case rpcpb.CmdUpdateEpochLease:
 		return d.doUpdateEpochLease(ctx)
+	case rpcpb.CmdUpdateGate:
+		return d.doUpdateGate(ctx)
+	case rpcpb.CmdAcquireAppLease:
+		return d.doAcquireAppLease(ctx)
+	case rpcpb.CmdReleaseAppLease:
+		return d.doReleaseAppLease(ctx)
+	case rpcpb.CmdDeleteRange:
+		return d.doDeleteRange(ctx)
+	case rpcpb.CmdBarrier:
+		return d.doBarrier(ctx)
+	case rpcpb.CmdComputeHash:
+		return d.doComputeHash(ctx)
+	case rpcpb.CmdVerifyHash:
+		return d.doVerifyHash(ctx)
+	case rpcpb.CmdPurge:
+		return d.doPurge(ctx)
+	case rpcpb.CmdUpdateDictionary:
+		return d.doUpdateDictionary(ctx)
 	}
 
 	return rpcpb.ResponseBatch{}, nil
@@ -83,7 +102,7 @@ func (d *stateMachine) doExecCompactLog(ctx *applyContext) (rpcpb.ResponseBatch,
 func (d *stateMachine) adjustCompactionIndex(index uint64) (uint64, error) {
 	// take current persistent log index into consideration, never compact those
 	// raft log entries that might be required after reboot.
-	persistentLogIndex, err := d.dataStorage.GetPersistentLogIndex(d.shardID)
+	persistentLogIndex, err := d.getPersistentLogIndex()
 	if err != nil {
 		d.logger.Error("failed to get persistent log index",
 			zap.Error(err))
@@ -118,7 +137,9 @@ func (d *stateMachine) doExecConfigChange(ctx *applyContext) (rpcpb.ResponseBatc
 		if p != nil {
 			exists = true
 			if p.ID == replica.ID {
-				if p.Role != metapb.ReplicaRole_Learner {
+				// the learner has the user data, it can't be a witness
+				if p.Role != metapb.ReplicaRole_Learner ||
+					replica.Role == metapb.ReplicaRole_Witness {
 					err := errors.Wrapf(ErrReplicaDuplicated,
 						"shardID %d, replicaID %d, role %v", shard.ID, p.ID, p.Role)
 					return rpcpb.ResponseBatch{}, err
@@ -134,7 +155,9 @@ func (d *stateMachine) doExecConfigChange(ctx *applyContext) (rpcpb.ResponseBatc
 				log.StoreIDField(replica.StoreID))
 		}
 		if !exists {
-			replica.Role = metapb.ReplicaRole_Voter
+			if replica.Role != metapb.ReplicaRole_Witness {
+				replica.Role = metapb.ReplicaRole_Voter
+			}
 			shard.Replicas = append(shard.Replicas, replica)
 		}
 	case metapb.ConfigChangeType_RemoveNode:
@@ -173,7 +196,10 @@ func (d *stateMachine) doExecConfigChange(ctx *applyContext) (rpcpb.ResponseBatc
 				shard.ID, p.ID, p.Role, replica.StoreID)
 			return rpcpb.ResponseBatch{}, err
 		}
-		replica.Role = metapb.ReplicaRole_Learner
+		// the observer is a learner which is never promoted
+		if replica.Role != metapb.ReplicaRole_Observer {
+			replica.Role = metapb.ReplicaRole_Learner
+		}
 		shard.Replicas = append(shard.Replicas, replica)
 	}
 	state := metapb.ReplicaState_Normal
@@ -181,6 +207,7 @@ func (d *stateMachine) doExecConfigChange(ctx *applyContext) (rpcpb.ResponseBatc
 		state = metapb.ReplicaState_ReplicaTombstone
 	}
 	d.updateShard(shard)
+	d.appendConfigChange(newConfigChangeRecord(ctx.index, req, shard))
 	if err := d.saveShardMetedata(ctx.index, shard, state, d.getLease()); err != nil {
 		d.logger.Fatal("failed to save metadata",
 			zap.Error(err))
@@ -227,14 +254,15 @@ func (d *stateMachine) doExecSplit(ctx *applyContext) (rpcpb.ResponseBatch, erro
 			log.HexField("shard-end", current.End))
 	}
 
+	origin := current
 	newShardsCount := len(splitReqs.Requests)
 	var newShards []Shard
 	current.Epoch.Generation += uint64(newShardsCount)
 	expectStart := current.Start
 	last := len(splitReqs.Requests) - 1
 	for idx, req := range splitReqs.Requests {
-		if checkKeyInShard(req.Start, current) != nil ||
-			(idx != last && checkKeyInShard(req.End, current) != nil) {
+		if checkKeyInShard(req.Start, current, nil) != nil ||
+			(idx != last && checkKeyInShard(req.End, current, nil) != nil) {
 			d.logger.Fatal("invalid split reuqest range",
 				log.HexField("split-start", req.Start),
 				log.HexField("split-end", req.End),
@@ -254,10 +282,17 @@ func (d *stateMachine) doExecSplit(ctx *applyContext) (rpcpb.ResponseBatch, erro
 		newShard.Group = current.Group
 		newShard.Unique = current.Unique
 		newShard.RuleGroups = current.RuleGroups
+		newShard.Gate = current.Gate
+		newShard.TTL = current.TTL
+		newShard.AppLease = current.AppLease
 		newShard.Epoch = current.Epoch
 		newShard.Start = req.Start
 		newShard.End = req.End
 		newShard.Replicas = req.NewReplicas
+		newShard.Attributes = current.Attributes
+		if d.splitAttributesFunc != nil {
+			newShard.Attributes = d.splitAttributesFunc(origin, newShard)
+		}
 		newShards = append(newShards, newShard)
 		ctx.metrics.admin.splitSucceed++
 	}
@@ -280,12 +315,26 @@ func (d *stateMachine) doExecSplit(ctx *applyContext) (rpcpb.ResponseBatch, erro
 		ShardID:  current.ID,
 		LogIndex: ctx.index,
 		Metadata: metapb.ShardLocalState{
-			State:      metapb.ReplicaState_Normal,
-			Shard:      current,
-			RemoveData: false,
+			State:          metapb.ReplicaState_Normal,
+			Shard:          current,
+			RemoveData:     false,
+			ConfigChanges:  d.getConfigChanges(),
+			Purges:         d.getPurgeMarkers(),
+			AppliedAdmins:  d.getAppliedAdmins(),
+			Dictionaries:   d.getDictionaries(),
+			LastWriteIndex: d.lastWriteIndex,
 		},
 	}
-	err := d.dataStorage.Split(old, replicaFactory.getShardsMetadata(), splitReqs.Context)
+	// the new shards keep the purge markers of their own ranges, and the
+	// dictionaries decompressing the values moved to them
+	var newPurges [][]metapb.PurgeMarker
+	news := replicaFactory.getShardsMetadata()
+	for idx := range news {
+		news[idx].Metadata.Purges = clipPurgeMarkers(d.getPurgeMarkers(), news[idx].Metadata.Shard)
+		news[idx].Metadata.Dictionaries = d.getDictionaries()
+		newPurges = append(newPurges, news[idx].Metadata.Purges)
+	}
+	err := d.dataStorage.Split(old, news, splitReqs.Context)
 	if err != nil {
 		if err == storage.ErrAborted {
 			return rpcpb.ResponseBatch{}, nil
@@ -294,6 +343,12 @@ func (d *stateMachine) doExecSplit(ctx *applyContext) (rpcpb.ResponseBatch, erro
 			zap.Error(err))
 	}
 
+	notifyKeyRangeChanged(d.dataStorage, storage.KeyRangeChange{
+		Type: storage.KeyRangeSplit,
+		Old:  origin,
+		News: newShards,
+	})
+
 	d.setSplited()
 	d.updateShard(current)
 	resp := newAdminResponseBatch(rpcpb.CmdBatchSplit, &rpcpb.BatchSplitResponse{
@@ -307,8 +362,10 @@ func (d *stateMachine) doExecSplit(ctx *applyContext) (rpcpb.ResponseBatch, erro
 	ctx.adminResult = &adminResult{
 		adminType: rpcpb.CmdBatchSplit,
 		splitResult: splitResult{
-			newShards: newShards,
-			newLeases: newLeases,
+			newShards:    newShards,
+			newLeases:    newLeases,
+			newPurges:    newPurges,
+			dictionaries: d.getDictionaries(),
 		},
 	}
 	return resp, nil
@@ -375,6 +432,139 @@ func (d *stateMachine) doUpdateLabels(ctx *applyContext) (rpcpb.ResponseBatch, e
 	return resp, nil
 }
 
+func (d *stateMachine) doUpdateGate(ctx *applyContext) (rpcpb.ResponseBatch, error) {
+	updateReq := ctx.req.GetUpdateGateRequest()
+	current := d.getShard()
+	current.Gate = updateReq.Gate
+	if err := d.saveShardMetedata(ctx.index, current, metapb.ReplicaState_Normal, d.getLease()); err != nil {
+		d.logger.Fatal("failed to update gate",
+			zap.Error(err))
+	}
+	d.updateShard(current)
+
+	d.logger.Info("shard gate updated",
+		zap.Bool("disable-read", current.Gate.DisableRead),
+		zap.Bool("disable-write", current.Gate.DisableWrite),
+		zap.String("redirect", current.Gate.Redirect),
+		zap.Bool("permanent", current.Gate.Permanent))
+
+	resp := newAdminResponseBatch(rpcpb.CmdUpdateGate, &rpcpb.UpdateGateResponse{})
+	ctx.adminResult = &adminResult{
+		adminType: rpcpb.CmdUpdateGate,
+	}
+	return resp, nil
+}
+
+// doBarrier applies nothing, it only returns the index of the barrier. All the
+// requests proposed before the barrier are applied at the returned index.
+func (d *stateMachine) doBarrier(ctx *applyContext) (rpcpb.ResponseBatch, error) {
+	return newAdminResponseBatch(rpcpb.CmdBarrier, &rpcpb.BarrierResponse{
+		Index: ctx.index,
+	}), nil
+}
+
+// doDeleteRange removes the expired data of the shard, it's skipped if any
+// write is applied after the index checked by the proposer, so the data
+// written after the TTL check is never removed.
+func (d *stateMachine) doDeleteRange(ctx *applyContext) (rpcpb.ResponseBatch, error) {
+	deleteReq := ctx.req.GetDeleteRangeRequest()
+	deleted := false
+	shard := d.getShard()
+	if deleteReq.CheckIndex >= d.lastWriteIndex {
+		if rd, ok := d.dataStorage.(storage.RangeDeleter); ok && !d.isWitness() {
+			if err := rd.DeleteRange(shard, deleteReq.Start, deleteReq.End); err != nil {
+				d.logger.Fatal("failed to delete range",
+					zap.Error(err))
+			}
+			deleted = true
+		}
+	}
+	// the applied index of the admin request is always saved with the shard
+	// metadata, as the range deletion is not recorded in the data storage
+	if err := d.saveShardMetedata(ctx.index, d.getShard(), metapb.ReplicaState_Normal, d.getLease()); err != nil {
+		d.logger.Fatal("failed to save metadata after delete range",
+			zap.Error(err))
+	}
+
+	d.logger.Info("delete range applied",
+		log.IndexField(ctx.index),
+		zap.Uint64("check-index", deleteReq.CheckIndex),
+		zap.Uint64("last-write-index", d.lastWriteIndex),
+		zap.Bool("deleted", deleted))
+
+	resp := newAdminResponseBatch(rpcpb.CmdDeleteRange, &rpcpb.DeleteRangeResponse{
+		Deleted: deleted,
+	})
+	ctx.adminResult = &adminResult{
+		adminType: rpcpb.CmdDeleteRange,
+		deleteRangeResult: deleteRangeResult{
+			deleted: deleted,
+			emptied: deleted && bytes.Equal(deleteReq.Start, shard.Start) &&
+				bytes.Equal(deleteReq.End, shard.End),
+		},
+	}
+	return resp, nil
+}
+
+func (d *stateMachine) doAcquireAppLease(ctx *applyContext) (rpcpb.ResponseBatch, error) {
+	acquireReq := ctx.req.GetAcquireAppLeaseRequest()
+	current := d.getShard()
+	lease, acquired := acquireAppLease(current.AppLease, acquireReq)
+
+	resp := newAdminResponseBatch(rpcpb.CmdAcquireAppLease, &rpcpb.AcquireAppLeaseResponse{
+		Acquired: acquired,
+		Lease:    lease,
+	})
+	ctx.adminResult = &adminResult{
+		adminType: rpcpb.CmdAcquireAppLease,
+	}
+	if !acquired {
+		return resp, nil
+	}
+
+	granted := lease.Token != current.AppLease.Token
+	current.AppLease = lease
+	if err := d.saveShardMetedata(ctx.index, current, metapb.ReplicaState_Normal, d.getLease()); err != nil {
+		d.logger.Fatal("failed to update app lease",
+			zap.Error(err))
+	}
+	d.updateShard(current)
+
+	if granted {
+		d.logger.Info("shard app lease granted",
+			zap.String("holder", lease.Holder),
+			zap.Uint64("token", lease.Token))
+	}
+	return resp, nil
+}
+
+func (d *stateMachine) doReleaseAppLease(ctx *applyContext) (rpcpb.ResponseBatch, error) {
+	releaseReq := ctx.req.GetReleaseAppLeaseRequest()
+	current := d.getShard()
+	lease, released := releaseAppLease(current.AppLease, releaseReq)
+
+	resp := newAdminResponseBatch(rpcpb.CmdReleaseAppLease, &rpcpb.ReleaseAppLeaseResponse{
+		Released: released,
+	})
+	ctx.adminResult = &adminResult{
+		adminType: rpcpb.CmdReleaseAppLease,
+	}
+	if !released {
+		return resp, nil
+	}
+
+	current.AppLease = lease
+	if err := d.saveShardMetedata(ctx.index, current, metapb.ReplicaState_Normal, d.getLease()); err != nil {
+		d.logger.Fatal("failed to release app lease",
+			zap.Error(err))
+	}
+	d.updateShard(current)
+
+	d.logger.Info("shard app lease released",
+		zap.Uint64("token", releaseReq.Token))
+	return resp, nil
+}
+
 func (d *stateMachine) doUpdateEpochLease(ctx *applyContext) (rpcpb.ResponseBatch, error) {
 	updateReq := ctx.req.GetUpdateEpochLeaseRequest()
 	currentLease := d.getLease()
@@ -450,43 +640,123 @@ func (d *stateMachine) doUpdateMetadata(ctx *applyContext) (rpcpb.ResponseBatch,
 }
 
 func (d *stateMachine) execWriteRequest(ctx *applyContext) rpcpb.ResponseBatch {
-	d.writeCtx.initialize(d.getShard(), ctx.index)
-	requests := ctx.req.Requests
-	for idx := range requests {
-		if ce := d.logger.Check(zap.DebugLevel, "begin to execute write"); ce != nil {
-			ce.Write(log.HexField("id", requests[idx].ID),
-				log.ShardIDField(d.shardID),
-				log.ReplicaIDField(d.replica.ID),
-				log.IndexField(ctx.index))
+	shard := d.getShard()
+	var errs []*errorpb.Error
+	for idx, req := range ctx.req.Requests {
+		if err := checkAppLease(req, shard); err != nil {
+			if errs == nil {
+				errs = make([]*errorpb.Error, len(ctx.req.Requests))
+			}
+			errs[idx] = err
 		}
-		if !requests[idx].IsTransaction() {
-			d.writeCtx.batch.Requests = append(d.writeCtx.batch.Requests, storage.Request{
-				CmdType: requests[idx].CustomType,
-				Key:     requests[idx].Key,
-				Cmd:     requests[idx].Cmd,
-			})
+	}
+	if errs == nil {
+		return d.execWriteRequests(ctx, ctx.req.Requests)
+	}
+
+	// the requests fenced by the application lease fail with their own errors
+	resp, _ := d.execWriteRequestsWithErrors(ctx, errs)
+	return resp
+}
+
+// execStaleEpochWriteRequest executes the requests of the batch proposed with a
+// stale epoch. The requests whose keys are still in the shard are executed, the
+// others fail with their own errors, so the client only needs to retry the
+// failed requests. The whole batch fails if no request can be executed.
+func (d *stateMachine) execStaleEpochWriteRequest(ctx *applyContext) (rpcpb.ResponseBatch, bool) {
+	shard := d.getShard()
+	errs := make([]*errorpb.Error, len(ctx.req.Requests))
+	for idx, req := range ctx.req.Requests {
+		if err := checkStaleEpochRequest(req, shard); err != nil {
+			errs[idx] = err
 			continue
 		}
+		errs[idx] = checkAppLease(req, shard)
+	}
+	return d.execWriteRequestsWithErrors(ctx, errs)
+}
 
-		d.execTransactionWrite(requests[idx], d.writeCtx)
+// execWriteRequestsWithErrors executes the requests of the batch without
+// errors, the others fail with their own errors. Returns false if no request
+// can be executed, the response contains the errors of all the requests.
+func (d *stateMachine) execWriteRequestsWithErrors(ctx *applyContext, errs []*errorpb.Error) (rpcpb.ResponseBatch, bool) {
+	var executable []rpcpb.Request
+	for idx, req := range ctx.req.Requests {
+		if errs[idx] == nil {
+			executable = append(executable, req)
+		}
 	}
 
-	if err := d.dataStorage.Write(d.writeCtx); err != nil {
-		d.logger.Fatal("failed to exec write cmd",
-			zap.Error(err))
+	var executed rpcpb.ResponseBatch
+	if len(executable) > 0 {
+		executed = d.execWriteRequests(ctx, executable)
+	}
+	resp := rpcpb.ResponseBatch{}
+	executedIdx := 0
+	for idx := range ctx.req.Requests {
+		if errs[idx] != nil {
+			resp.Responses = append(resp.Responses, rpcpb.Response{Error: *errs[idx]})
+			continue
+		}
+		resp.Responses = append(resp.Responses, executed.Responses[executedIdx])
+		executedIdx++
+	}
+	return resp, len(executable) > 0
+}
+
+// checkStaleEpochRequest returns the error if the request proposed with a
+// stale epoch can not be executed. Only the non-transactional requests routed
+// by key can be executed, and all the keys must be in the current shard.
+func checkStaleEpochRequest(req rpcpb.Request, shard Shard) *errorpb.Error {
+	if req.IsTransaction() || len(req.Key) == 0 {
+		return &errorpb.Error{
+			Message:    errStaleEpoch.Error(),
+			StaleEpoch: &errorpb.StaleEpoch{NewShards: []Shard{shard}},
+		}
+	}
+	if err := checkKeyInShard(req.Key, shard, nil); err != nil {
+		return err
+	}
+	if req.KeysRange != nil && !keysRangeInShard(req.KeysRange, shard) {
+		if err := checkKeyInShard(req.KeysRange.From, shard, nil); err != nil {
+			return err
+		}
+		return &errorpb.Error{
+			Message: errKeyNotInShard.Error(),
+			KeyNotInShard: &errorpb.KeyNotInShard{
+				Key:     req.KeysRange.To,
+				ShardID: shard.ID,
+				Start:   shard.Start,
+				End:     shard.End,
+			},
+		}
+	}
+	return nil
+}
+
+func (d *stateMachine) execWriteRequests(ctx *applyContext, requests []rpcpb.Request) rpcpb.ResponseBatch {
+	d.lastWriteIndex = ctx.index
+	if d.isWitness() {
+		d.writeCtx.initialize(d.getShard(), ctx.index, ctx.req.Header.CommitTime)
+		return d.execWitnessWriteRequests(requests)
+	}
+	if err := d.writeRequests(ctx, requests); err != nil {
+		if err := d.handleWriteError(ctx, requests, err); err != nil {
+			return d.storageIOErrorResp(requests)
+		}
 	}
 
 	resp := rpcpb.ResponseBatch{}
 	customResponseIdx := 0
 	for idx := range requests {
 		if ce := d.logger.Check(zap.DebugLevel, "write completed"); ce != nil {
-			ce.Write(log.HexField("id", requests[idx].ID),
+			ce.Write(log.RequestIDField(requests[idx].ID),
 				log.ShardIDField(d.shardID),
 				log.ReplicaIDField(d.replica.ID),
 				log.IndexField(ctx.index))
 		}
 		ctx.metrics.writtenKeys++
-		r := rpcpb.Response{}
+		r := rpcpb.Response{AppliedIndex: ctx.index}
 		if !requests[idx].IsTransaction() {
 			r.Value = d.writeCtx.responses[customResponseIdx]
 			customResponseIdx++
@@ -498,6 +768,50 @@ func (d *stateMachine) execWriteRequest(ctx *applyContext) rpcpb.ResponseBatch {
 	return resp
 }
 
+// writeRequests writes the requests into the data storage, the write context is
+// rebuilt from the requests, so it's called again to retry the failed write.
+func (d *stateMachine) writeRequests(ctx *applyContext, requests []rpcpb.Request) error {
+	d.writeCtx.initialize(d.getShard(), ctx.index, ctx.req.Header.CommitTime)
+	for idx := range requests {
+		if ce := d.logger.Check(zap.DebugLevel, "begin to execute write"); ce != nil {
+			ce.Write(log.RequestIDField(requests[idx].ID),
+				log.ShardIDField(d.shardID),
+				log.ReplicaIDField(d.replica.ID),
+				log.IndexField(ctx.index))
+		}
+		if !requests[idx].IsTransaction() {
+			d.writeCtx.batch.Requests = append(d.writeCtx.batch.Requests, storage.Request{
+				ID:      requests[idx].ID,
+				CmdType: requests[idx].CustomType,
+				Key:     requests[idx].Key,
+				CF:      requests[idx].CF,
+				Cmd:     requests[idx].Cmd,
+			})
+			continue
+		}
+
+		start := time.Now()
+		d.execTransactionWrite(requests[idx], d.writeCtx)
+		d.applyCPU.addHandler(start)
+	}
+
+	if d.throttleWrite != nil {
+		// charged on every replica applying the entry, the followers write the
+		// data storage as much as the leader
+		n := int64(0)
+		for idx := range requests {
+			n += int64(requests[idx].Size())
+		}
+		d.throttleWrite(n)
+	}
+	start := time.Now()
+	if err := d.dataStorage.Write(d.writeCtx); err != nil {
+		return err
+	}
+	d.applyCPU.addWrite(start)
+	return nil
+}
+
 func (d *stateMachine) execTransactionWrite(req rpcpb.Request, ctx storage.WriteContext) {
 	if d.transactionalDataStorage == nil {
 		d.logger.Fatal("can not handle transaction request.",
@@ -538,26 +852,30 @@ func (d *stateMachine) execTransactionWrite(req rpcpb.Request, ctx storage.Write
 
 func (d *stateMachine) updateWriteMetrics() {
 	d.applyCtx.metrics.writtenBytes += d.writeCtx.writtenBytes
-	if d.writeCtx.diffBytes < 0 {
-		v := uint64(math.Abs(float64(d.writeCtx.diffBytes)))
-		if v >= d.applyCtx.metrics.approximateDiffHint {
-			d.applyCtx.metrics.approximateDiffHint = 0
-		} else {
-			d.applyCtx.metrics.approximateDiffHint -= v
-		}
-	} else {
-		d.applyCtx.metrics.approximateDiffHint += uint64(d.writeCtx.diffBytes)
-	}
+	d.applyCtx.metrics.approximateDiffHint += d.writeCtx.diffBytes
 }
 
 func (d *stateMachine) saveShardMetedata(index uint64, shard Shard, state metapb.ReplicaState, lease *metapb.EpochLease) error {
-	return d.dataStorage.SaveShardMetadata([]metapb.ShardMetadata{{
+	return d.dataStorage.SaveShardMetadata([]metapb.ShardMetadata{
+		d.newShardMetadata(index, shard, state, lease)})
+}
+
+func (d *stateMachine) newShardMetadata(index uint64, shard Shard, state metapb.ReplicaState, lease *metapb.EpochLease) metapb.ShardMetadata {
+	return metapb.ShardMetadata{
 		ShardID:  shard.ID,
 		LogIndex: index,
 		Metadata: metapb.ShardLocalState{
-			State: state,
-			Shard: shard,
-			Lease: lease,
+			State:         state,
+			Shard:         shard,
+			Lease:         lease,
+			ConfigChanges: d.getConfigChanges(),
+			Purges:        d.getPurgeMarkers(),
+			AppliedAdmins: d.getAppliedAdmins(),
+			Dictionaries:  d.getDictionaries(),
+			// the writes never save the metadata, the last write index is
+			// restored from the persistent log index of the data storage if
+			// any write is persisted after
+			LastWriteIndex: d.lastWriteIndex,
 		},
-	}})
+	}
 }
@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"testing"
+	"time"
 
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/pb/hlcpb"
@@ -72,6 +73,85 @@ func TestStateMachineAddLearner(t *testing.T) {
 	runSimpleStateMachineTest(t, f, h)
 }
 
+func TestStateMachineAddWitness(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		batch := newTestAdminRequestBatch(string([]byte{0x1, 0x2, 0x3}), 0,
+			rpcpb.CmdConfigChange,
+			protoc.MustMarshal(&rpcpb.ConfigChangeRequest{
+				ChangeType: metapb.ConfigChangeType_AddNode,
+				Replica: metapb.Replica{
+					ID:      100,
+					StoreID: 200,
+					Role:    metapb.ReplicaRole_Witness,
+				},
+			}))
+		batch.Header.ShardID = 1
+		cc := raftpb.ConfChange{
+			Type:    raftpb.ConfChangeAddNode,
+			NodeID:  100,
+			Context: protoc.MustMarshal(&batch),
+		}
+		entry := raftpb.Entry{
+			Index: 1,
+			Term:  1,
+			Type:  raftpb.EntryConfChange,
+			Data:  protoc.MustMarshal(&cc),
+		}
+		sm.applyCommittedEntries([]raftpb.Entry{entry})
+		shard := sm.getShard()
+		require.Equal(t, 1, len(shard.Replicas))
+		assert.Equal(t, uint64(100), shard.Replicas[0].ID)
+		assert.Equal(t, metapb.ReplicaRole_Witness, shard.Replicas[0].Role)
+		assert.Equal(t, []uint64{100}, sm.getConfState().Voters)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestStateMachineAddObserver(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		apply := func(index uint64, ct metapb.ConfigChangeType, role metapb.ReplicaRole) {
+			batch := newTestAdminRequestBatch(string([]byte{0x1, 0x2, byte(index)}), 0,
+				rpcpb.CmdConfigChange,
+				protoc.MustMarshal(&rpcpb.ConfigChangeRequest{
+					ChangeType: ct,
+					Replica: metapb.Replica{
+						ID:      100,
+						StoreID: 200,
+						Role:    role,
+					},
+				}))
+			batch.Header.ShardID = 1
+			cc := raftpb.ConfChange{
+				Type:    raftpb.ConfChangeType(ct),
+				NodeID:  100,
+				Context: protoc.MustMarshal(&batch),
+			}
+			sm.applyCommittedEntries([]raftpb.Entry{{
+				Index: index,
+				Term:  1,
+				Type:  raftpb.EntryConfChange,
+				Data:  protoc.MustMarshal(&cc),
+			}})
+		}
+
+		apply(1, metapb.ConfigChangeType_AddLearnerNode, metapb.ReplicaRole_Observer)
+		shard := sm.getShard()
+		require.Equal(t, 1, len(shard.Replicas))
+		assert.Equal(t, metapb.ReplicaRole_Observer, shard.Replicas[0].Role)
+		assert.Equal(t, []uint64{100}, sm.getConfState().Learners)
+		assert.Empty(t, sm.getConfState().Voters)
+
+		// the observer is never promoted
+		apply(2, metapb.ConfigChangeType_AddNode, metapb.ReplicaRole_Voter)
+		shard = sm.getShard()
+		require.Equal(t, 1, len(shard.Replicas))
+		assert.Equal(t, metapb.ReplicaRole_Observer, shard.Replicas[0].Role)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
 func TestStateMachinePromoteLeanerToVoter(t *testing.T) {
 	h := &testReplicaResultHandler{}
 	f := func(sm *stateMachine) {
@@ -229,6 +309,9 @@ func TestDoExecSplit(t *testing.T) {
 	go checkPanicFn()
 	assert.True(t, <-ch)
 
+	observer := &testKeyRangeObserver{DataStorage: pr.sm.dataStorage}
+	pr.sm.dataStorage = observer
+
 	// s1 -> s2+s3
 	ctx.index = 100
 	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdBatchSplit, protoc.MustMarshal(&rpcpb.BatchSplitRequest{
@@ -249,6 +332,11 @@ func TestDoExecSplit(t *testing.T) {
 	assert.Equal(t, pr.getShard().End, adminResp.Shards[1].End)
 	assert.False(t, pr.sm.canApply(raftpb.Entry{}))
 	assert.True(t, pr.sm.metadataMu.splited)
+	require.Equal(t, 1, len(observer.changes))
+	assert.Equal(t, storage.KeyRangeSplit, observer.changes[0].Type)
+	assert.Equal(t, uint64(1), observer.changes[0].Old.ID)
+	assert.Equal(t, uint64(2), observer.changes[0].Old.Epoch.Generation)
+	assert.Equal(t, adminResp.Shards, observer.changes[0].News)
 
 	_, err = pr.sm.dataStorage.GetInitialStates()
 	require.NoError(t, err)
@@ -280,6 +368,57 @@ func TestDoExecSplit(t *testing.T) {
 	assert.Equal(t, &metapb.EpochLease{ReplicaID: 300}, metadata[2].Metadata.Lease)
 }
 
+func TestDoExecSplitWithAttributes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	storeID := uint64(1000)
+	newSplitReq := func() rpcpb.RequestBatch {
+		return newTestAdminRequestBatch("", 0, rpcpb.CmdBatchSplit, protoc.MustMarshal(&rpcpb.BatchSplitRequest{
+			Requests: []rpcpb.SplitRequest{
+				{Start: []byte{1}, End: []byte{5}, NewShardID: 2, NewReplicas: []Replica{{ID: 200, StoreID: storeID}}},
+				{Start: []byte{5}, End: []byte{10}, NewShardID: 3, NewReplicas: []Replica{{ID: 300, StoreID: storeID}}},
+			},
+		}))
+	}
+	newReplica := func() *replica {
+		pr := newTestReplica(Shard{ID: 1, Epoch: Epoch{Generation: 2}, Start: []byte{1}, End: []byte{10},
+			Attributes: []byte("v1"), Replicas: []Replica{{ID: 2, StoreID: storeID}}}, Replica{ID: 2, StoreID: storeID}, s)
+		pr.sm.updateLease(&metapb.EpochLease{ReplicaID: 2})
+		return pr
+	}
+
+	// inherit
+	pr := newReplica()
+	ctx := newApplyContext()
+	ctx.index = 100
+	ctx.req = newSplitReq()
+	resp, err := pr.sm.execAdminRequest(ctx)
+	assert.NoError(t, err)
+	shards := resp.GetBatchSplitResponse().Shards
+	require.Equal(t, 2, len(shards))
+	assert.Equal(t, []byte("v1"), shards[0].Attributes)
+	assert.Equal(t, []byte("v1"), shards[1].Attributes)
+
+	// recompute
+	pr = newReplica()
+	pr.sm.splitAttributesFunc = func(parent, newShard Shard) []byte {
+		assert.Equal(t, uint64(1), parent.ID)
+		return append(append([]byte{}, parent.Attributes...), newShard.Start...)
+	}
+	ctx = newApplyContext()
+	ctx.index = 101
+	ctx.req = newSplitReq()
+	resp, err = pr.sm.execAdminRequest(ctx)
+	assert.NoError(t, err)
+	shards = resp.GetBatchSplitResponse().Shards
+	require.Equal(t, 2, len(shards))
+	assert.Equal(t, []byte("v1\x01"), shards[0].Attributes)
+	assert.Equal(t, []byte("v1\x05"), shards[1].Attributes)
+}
+
 func TestDoExecUpdateLease(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -320,6 +459,180 @@ func TestDoExecUpdateLease(t *testing.T) {
 	assert.Equal(t, &metapb.EpochLease{Epoch: 2, ReplicaID: 3}, pr.getLease())
 }
 
+func TestDoExecUpdateGate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1,
+		Epoch:    Epoch{Generation: 2},
+		Replicas: []Replica{{ID: 2}, {ID: 3}, {ID: 4}}},
+		Replica{ID: 2}, s)
+	ctx := newApplyContext()
+
+	gate := metapb.ShardGate{DisableWrite: true, Redirect: "127.0.0.1:8080"}
+	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdUpdateGate, protoc.MustMarshal(&rpcpb.UpdateGateRequest{
+		Gate: gate,
+	}))
+	resp, err := pr.sm.execAdminRequest(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp.Responses))
+	assert.Equal(t, rpcpb.CmdUpdateGate, ctx.adminResult.adminType)
+	assert.Equal(t, gate, pr.getShard().Gate)
+
+	// the admin requests are never blocked
+	assert.Nil(t, pr.sm.checkShardGate(ctx.req))
+	err2 := pr.sm.checkShardGate(rpcpb.RequestBatch{Requests: []rpcpb.Request{{Type: rpcpb.Write}}})
+	assert.NotNil(t, err2)
+	assert.Equal(t, gate.Redirect, err2.ShardWriteDisabled.Redirect)
+
+	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdUpdateGate, protoc.MustMarshal(&rpcpb.UpdateGateRequest{}))
+	_, err = pr.sm.execAdminRequest(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, metapb.ShardGate{}, pr.getShard().Gate)
+	assert.Nil(t, pr.sm.checkShardGate(rpcpb.RequestBatch{Requests: []rpcpb.Request{{Type: rpcpb.Write}}}))
+}
+
+func TestDoExecBarrier(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+	ctx := newApplyContext()
+	ctx.index = 100
+	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdBarrier, protoc.MustMarshal(&rpcpb.BarrierRequest{}))
+	resp, err := pr.sm.execAdminRequest(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, ctx.adminResult)
+	assert.Equal(t, uint64(100), resp.GetBarrierResponse().Index)
+}
+
+func TestDoExecDeleteRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1, TTL: 10, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+	pr.sm.lastWriteIndex = 10
+
+	// a write is applied after the check index
+	ctx := newApplyContext()
+	ctx.index = 11
+	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdDeleteRange, protoc.MustMarshal(&rpcpb.DeleteRangeRequest{
+		CheckIndex: 9,
+	}))
+	resp, err := pr.sm.execAdminRequest(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, rpcpb.CmdDeleteRange, ctx.adminResult.adminType)
+	assert.False(t, resp.GetDeleteRangeResponse().Deleted)
+	assert.False(t, ctx.adminResult.deleteRangeResult.deleted)
+
+	ctx = newApplyContext()
+	ctx.index = 12
+	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdDeleteRange, protoc.MustMarshal(&rpcpb.DeleteRangeRequest{
+		CheckIndex: 10,
+	}))
+	resp, err = pr.sm.execAdminRequest(ctx)
+	assert.NoError(t, err)
+	assert.True(t, resp.GetDeleteRangeResponse().Deleted)
+	assert.True(t, ctx.adminResult.deleteRangeResult.deleted)
+	assert.True(t, ctx.adminResult.deleteRangeResult.emptied)
+	assert.Equal(t, uint64(10), pr.getShard().TTL)
+
+	pr.ttlGC.onWrite(time.Now())
+	pr.stats.approximateSize = 1024
+	pr.stats.approximateKeys = 10
+	pr.handleAdminResult(applyResult{adminResult: ctx.adminResult})
+	assert.True(t, pr.ttlGC.clean)
+	assert.Equal(t, uint64(0), pr.stats.approximateSize)
+	assert.Equal(t, uint64(0), pr.stats.approximateKeys)
+
+	// part of the shard is deleted
+	ctx = newApplyContext()
+	ctx.index = 13
+	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdDeleteRange, protoc.MustMarshal(&rpcpb.DeleteRangeRequest{
+		Start:      []byte("a"),
+		CheckIndex: 12,
+	}))
+	_, err = pr.sm.execAdminRequest(ctx)
+	assert.NoError(t, err)
+	assert.True(t, ctx.adminResult.deleteRangeResult.deleted)
+	assert.False(t, ctx.adminResult.deleteRangeResult.emptied)
+}
+
+func TestDoExecAppLease(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 2}}}, Replica{ID: 2}, s)
+	kv := pr.sm.dataStorage
+	ds := &testDataStorage{}
+	_, err := ds.GetInitialStates()
+	assert.NoError(t, err)
+
+	ctx := newApplyContext()
+	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdAcquireAppLease, protoc.MustMarshal(&rpcpb.AcquireAppLeaseRequest{
+		Holder: "a",
+		TTL:    10,
+		Now:    100,
+	}))
+	resp, err := pr.sm.execAdminRequest(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, rpcpb.CmdAcquireAppLease, ctx.adminResult.adminType)
+	acquired := resp.GetAcquireAppLeaseResponse()
+	assert.True(t, acquired.Acquired)
+	assert.Equal(t, uint64(1), acquired.Lease.Token)
+	assert.Equal(t, acquired.Lease, pr.getShard().AppLease)
+
+	// the stale token is rejected, the others are executed
+	pr.sm.dataStorage = ds
+	ctx = newApplyContext()
+	ctx.req = newTestRequestBatch(3, func(r *rpcpb.Request, i int) {
+		r.CustomType = uint64(rpcpb.CmdReserved) + 1
+		r.AppLeaseToken = uint64(i)
+	})
+	responses := pr.sm.execWriteRequest(ctx).Responses
+	assert.Equal(t, 3, len(responses))
+	assert.Equal(t, []byte("OK"), responses[0].Value)
+	assert.Equal(t, []byte("OK"), responses[1].Value)
+	assert.NotNil(t, responses[2].Error.AppLeaseMismatch)
+
+	pr.sm.dataStorage = kv
+	ctx = newApplyContext()
+	ctx.req = newTestAdminRequestBatch("", 0, rpcpb.CmdReleaseAppLease, protoc.MustMarshal(&rpcpb.ReleaseAppLeaseRequest{
+		Token: 1,
+	}))
+	resp, err = pr.sm.execAdminRequest(ctx)
+	assert.NoError(t, err)
+	assert.True(t, resp.GetReleaseAppLeaseResponse().Released)
+	assert.Equal(t, metapb.AppLease{Token: 1}, pr.getShard().AppLease)
+
+	// all requests are rejected
+	ctx = newApplyContext()
+	ctx.req = newTestRequestBatch(1, func(r *rpcpb.Request, i int) {
+		r.CustomType = uint64(rpcpb.CmdReserved) + 1
+		r.AppLeaseToken = 1
+	})
+	responses = pr.sm.execWriteRequest(ctx).Responses
+	assert.Equal(t, 1, len(responses))
+	assert.NotNil(t, responses[0].Error.AppLeaseMismatch)
+}
+
+type testKeyRangeObserver struct {
+	storage.DataStorage
+	changes []storage.KeyRangeChange
+}
+
+func (o *testKeyRangeObserver) KeyRangeChanged(change storage.KeyRangeChange) {
+	o.changes = append(o.changes, change)
+}
+
 type testDataStorage struct {
 	persistentLogIndex uint64
 	feature            storage.Feature
@@ -565,6 +878,34 @@ func TestExecWriteRequest(t *testing.T) {
 	}
 }
 
+func TestExecWitnessWriteRequest(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{{ID: 2, Role: metapb.ReplicaRole_Witness}}}, Replica{ID: 2}, s)
+	ds := &testDataStorage{}
+	_, err := ds.GetInitialStates()
+	assert.NoError(t, err)
+
+	pr.sm.dataStorage = ds
+	pr.sm.transactionalDataStorage = ds
+
+	ctx := newApplyContext()
+	ctx.req = newTestRequestBatch(2, func(r *rpcpb.Request, i int) {
+		if i == 0 {
+			r.CustomType = uint64(rpcpb.CmdUpdateTxnRecord)
+		} else {
+			r.CustomType = uint64(rpcpb.CmdReserved) + 1
+		}
+	})
+	resp := pr.sm.execWriteRequest(ctx)
+	require.Equal(t, 2, len(resp.Responses))
+	for _, r := range resp.Responses {
+		assert.Nil(t, r.Value)
+	}
+	assert.Empty(t, ds.counts)
+}
+
 func newTestRequestBatch(n int, builder func(*rpcpb.Request, int)) rpcpb.RequestBatch {
 	rb := rpcpb.RequestBatch{
 		Header: rpcpb.RequestBatchHeader{ID: uuid.NewV4().Bytes()}}
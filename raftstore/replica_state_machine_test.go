@@ -19,6 +19,7 @@ import (
 	cpebble "github.com/cockroachdb/pebble"
 	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
@@ -239,6 +240,44 @@ func (t *testReplicaResultHandler) notifyPendingProposal(id []byte,
 	t.notified++
 }
 
+func TestStateMachineDropsAbortedChunks(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		set := newKVSetEntry(0, 1, []byte("k1"), make([]byte, 4000))
+		var req rpcpb.RequestBatch
+		protoc.MustUnmarshal(&req, set.Data)
+		chunks := splitProposal(req.Header, set.Data, 1024)
+		require.True(t, len(chunks) > 2)
+
+		// the rest chunks failed to propose, the abort entry drops the
+		// incomplete chunks
+		sm.applyCommittedEntries([]raftpb.Entry{
+			{Index: 1, Term: 1, Type: raftpb.EntryNormal, Data: chunks[0]},
+			{Index: 2, Term: 1, Type: raftpb.EntryNormal, Data: chunks[1]},
+		})
+		assert.True(t, sm.chunks.pending())
+		sm.applyCommittedEntries([]raftpb.Entry{{Index: 3, Term: 1, Type: raftpb.EntryNormal}})
+		assert.False(t, sm.chunks.pending())
+		assert.Equal(t, uint64(0), h.notified)
+		assert.Equal(t, uint64(3), h.appliedIndex)
+
+		// proposed again
+		var entries []raftpb.Entry
+		for i, chunk := range chunks {
+			entries = append(entries, raftpb.Entry{Index: uint64(4 + i), Term: 1, Type: raftpb.EntryNormal, Data: chunk})
+		}
+		sm.applyCommittedEntries(entries)
+		assert.False(t, sm.chunks.pending())
+		assert.Equal(t, uint64(1), h.notified)
+		assert.Equal(t, []byte{1}, h.id)
+		require.Equal(t, 1, len(h.resp.Responses))
+		assert.False(t, errorpb.HasError(h.resp.Responses[0].Error))
+		index, _ := sm.getAppliedIndexTerm()
+		assert.Equal(t, uint64(3+len(chunks)), index)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
 func TestStateMachineApplyNoopEntry(t *testing.T) {
 	h := &testReplicaResultHandler{}
 	f := func(sm *stateMachine) {
@@ -427,6 +466,91 @@ func TestStateMachineRejectsStaleEpochEntries(t *testing.T) {
 	runSimpleStateMachineTest(t, f, h)
 }
 
+func TestStateMachineAppliesStaleEpochEntriesPartially(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		sm.updateShard(Shard{ID: 100, Start: []byte("b"), End: []byte("d"),
+			Epoch: Epoch{Generation: 2}})
+		newSetRequest := func(key string) rpcpb.Request {
+			return rpcpb.Request{
+				ID:         []byte(key),
+				Type:       rpcpb.Write,
+				Key:        []byte(key),
+				CustomType: uint64(rpcpb.CmdKVSet),
+				Cmd:        protoc.MustMarshal(&rpcpb.KVSetRequest{Key: []byte(key), Value: []byte(key)}),
+				Epoch:      Epoch{Generation: 1},
+			}
+		}
+		apply := func(index uint64, requests ...rpcpb.Request) {
+			batch := rpcpb.RequestBatch{
+				Header:   rpcpb.RequestBatchHeader{ID: []byte{byte(index)}, ShardID: 100},
+				Requests: requests,
+			}
+			sm.applyCommittedEntries([]raftpb.Entry{{
+				Index: index,
+				Term:  1,
+				Type:  raftpb.EntryNormal,
+				Data:  protoc.MustMarshal(&batch),
+			}})
+		}
+
+		// the key moved out of the shard after split, and the request without key
+		routeByShard := newSetRequest("c")
+		routeByShard.Key = nil
+		apply(1, newSetRequest("c"), newSetRequest("e"), routeByShard)
+		assert.Equal(t, uint64(1), h.notified)
+		assert.False(t, errorpb.HasError(h.resp.Header.Error))
+		require.Equal(t, 3, len(h.resp.Responses))
+		assert.False(t, errorpb.HasError(h.resp.Responses[0].Error))
+		assert.NotNil(t, h.resp.Responses[1].Error.KeyNotInShard)
+		assert.Equal(t, []byte("e"), h.resp.Responses[1].Error.KeyNotInShard.Key)
+		assert.NotNil(t, h.resp.Responses[2].Error.StaleEpoch)
+
+		readContext := newReadContext()
+		readContext.reset(sm.getShard(), storage.Request{
+			Key:     []byte("c"),
+			CmdType: uint64(rpcpb.CmdKVGet),
+			Cmd:     protoc.MustMarshal(&rpcpb.KVGetRequest{Key: []byte("c")}),
+		})
+		data, err := sm.dataStorage.Read(readContext)
+		assert.NoError(t, err)
+		assert.Equal(t, protoc.MustMarshal(&rpcpb.KVGetResponse{Value: []byte("c")}), data)
+
+		// no request can be executed
+		apply(2, newSetRequest("a"), newSetRequest("e"))
+		assert.Equal(t, uint64(2), h.notified)
+		require.Equal(t, 0, len(h.resp.Responses))
+		assert.NotNil(t, h.resp.Header.Error.StaleEpoch)
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
+
+func TestCheckStaleEpochRequest(t *testing.T) {
+	shard := Shard{ID: 1, Start: []byte("b"), End: []byte("d")}
+	cases := []struct {
+		req           rpcpb.Request
+		keyNotInShard bool
+		staleEpoch    bool
+	}{
+		{req: rpcpb.Request{Key: []byte("b")}},
+		{req: rpcpb.Request{Key: []byte("d")}, keyNotInShard: true},
+		{req: rpcpb.Request{Key: []byte("b"), KeysRange: &rpcpb.Range{From: []byte("b"), To: []byte("c")}}},
+		{req: rpcpb.Request{Key: []byte("b"), KeysRange: &rpcpb.Range{From: []byte("b"), To: []byte("e")}}, keyNotInShard: true},
+		{req: rpcpb.Request{ToShard: 1}, staleEpoch: true},
+		{req: rpcpb.Request{Key: []byte("b"), CustomType: uint64(rpcpb.CmdUpdateTxnRecord)}, staleEpoch: true},
+	}
+	for i, c := range cases {
+		err := checkStaleEpochRequest(c.req, shard)
+		if !c.keyNotInShard && !c.staleEpoch {
+			assert.Nil(t, err, "index %d", i)
+			continue
+		}
+		require.NotNil(t, err, "index %d", i)
+		assert.Equal(t, c.keyNotInShard, err.KeyNotInShard != nil, "index %d", i)
+		assert.Equal(t, c.staleEpoch, err.StaleEpoch != nil, "index %d", i)
+	}
+}
+
 func TestStateMachineRejectsStaleLeaseEntries(t *testing.T) {
 	h := &testReplicaResultHandler{}
 	f := func(sm *stateMachine) {
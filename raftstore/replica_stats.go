@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 )
 
 type replicaStats struct {
@@ -29,15 +30,18 @@ type replicaStats struct {
 	deleteKeysHint       uint64
 	approximateSize      uint64
 	approximateKeys      uint64
+	// readLatency and readCount are the total latency and the count of the
+	// reads since the last heartbeat
+	readLatency time.Duration
+	readCount   uint64
 }
 
 func newReplicaStats() *replicaStats {
 	return &replicaStats{}
 }
 
-func (rs *replicaStats) heartbeatState() metapb.ShardStats {
-	now := uint64(time.Now().Unix())
-	stats := metapb.ShardStats{
+func (rs *replicaStats) heartbeatState(now time.Time) metapb.ShardStats {
+	return metapb.ShardStats{
 		WrittenBytes:    rs.writtenBytes,
 		WrittenKeys:     rs.writtenKeys,
 		ReadBytes:       rs.readBytes,
@@ -46,9 +50,76 @@ func (rs *replicaStats) heartbeatState() metapb.ShardStats {
 		ApproximateSize: rs.approximateSize,
 		Interval: &metapb.TimeInterval{
 			Start: rs.prophetHeartbeatTime,
-			End:   uint64(time.Now().Unix()),
+			End:   uint64(now.Unix()),
 		},
+		ReadLatency: rs.avgReadLatency(),
 	}
-	rs.prophetHeartbeatTime = now
-	return stats
+}
+
+// avgReadLatency returns the average read latency in microseconds since the
+// last heartbeat
+func (rs *replicaStats) avgReadLatency() uint64 {
+	if rs.readCount == 0 {
+		return 0
+	}
+	return uint64(rs.readLatency.Microseconds()) / rs.readCount
+}
+
+func (rs *replicaStats) resetReadLatency() {
+	rs.readLatency = 0
+	rs.readCount = 0
+}
+
+// heartbeatDigest is the state sent by the last shard heartbeat, it is used to
+// skip the periodic heartbeats of the shards without notable changes.
+type heartbeatDigest struct {
+	time         time.Time
+	term         uint64
+	epoch        metapb.ShardEpoch
+	lease        *metapb.EpochLease
+	downCount    int
+	pendingCount int
+	stats        metapb.ShardStats
+	// blockingChanged the time of the last change of the blocking reasons
+	blockingChanged time.Time
+}
+
+func newHeartbeatDigest(now time.Time, shard metapb.Shard, req rpcpb.ShardHeartbeatReq) heartbeatDigest {
+	return heartbeatDigest{
+		time:         now,
+		term:         req.Term,
+		epoch:        shard.Epoch,
+		lease:        req.Lease,
+		downCount:    len(req.DownReplicas),
+		pendingCount: len(req.PendingReplicas),
+		stats:        req.Stats,
+	}
+}
+
+// changed returns true if the state is changed or the stats are changed beyond
+// the thresholds since the last heartbeat
+func (d heartbeatDigest) changed(current heartbeatDigest, bytesThreshold, keysThreshold uint64) bool {
+	if d.time.IsZero() ||
+		d.term != current.term ||
+		!epochMatch(d.epoch, current.epoch) ||
+		d.downCount != current.downCount ||
+		d.pendingCount != current.pendingCount ||
+		!d.lease.Match(current.lease) ||
+		!d.blockingChanged.Equal(current.blockingChanged) {
+		return true
+	}
+
+	return exceedThreshold(d.stats.WrittenBytes, current.stats.WrittenBytes, bytesThreshold) ||
+		exceedThreshold(d.stats.ReadBytes, current.stats.ReadBytes, bytesThreshold) ||
+		exceedThreshold(d.stats.ApproximateSize, current.stats.ApproximateSize, bytesThreshold) ||
+		exceedThreshold(d.stats.WrittenKeys, current.stats.WrittenKeys, keysThreshold) ||
+		exceedThreshold(d.stats.ReadKeys, current.stats.ReadKeys, keysThreshold) ||
+		exceedThreshold(d.stats.ApproximateKeys, current.stats.ApproximateKeys, keysThreshold)
+}
+
+func exceedThreshold(last, current, threshold uint64) bool {
+	if current > last {
+		return current-last >= threshold
+	}
+	return last-current >= threshold
 }
@@ -0,0 +1,70 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeatDigestChanged(t *testing.T) {
+	now := time.Now()
+	shard := Shard{Epoch: Epoch{ConfigVer: 1, Generation: 1}}
+	req := rpcpb.ShardHeartbeatReq{
+		Term:  1,
+		Lease: &metapb.EpochLease{Epoch: 1, ReplicaID: 1},
+		Stats: metapb.ShardStats{WrittenBytes: 100, ApproximateKeys: 10},
+	}
+	last := newHeartbeatDigest(now, shard, req)
+
+	assert.True(t, heartbeatDigest{}.changed(last, 100, 10))
+	assert.False(t, last.changed(newHeartbeatDigest(now, shard, req), 100, 10))
+
+	tests := []struct {
+		fn      func(s *Shard, req *rpcpb.ShardHeartbeatReq)
+		changed bool
+	}{
+		{fn: func(s *Shard, req *rpcpb.ShardHeartbeatReq) { req.Term++ }, changed: true},
+		{fn: func(s *Shard, req *rpcpb.ShardHeartbeatReq) { s.Epoch.ConfigVer++ }, changed: true},
+		{fn: func(s *Shard, req *rpcpb.ShardHeartbeatReq) { req.Lease = &metapb.EpochLease{Epoch: 2, ReplicaID: 1} }, changed: true},
+		{fn: func(s *Shard, req *rpcpb.ShardHeartbeatReq) { req.DownReplicas = []metapb.ReplicaStats{{}} }, changed: true},
+		{fn: func(s *Shard, req *rpcpb.ShardHeartbeatReq) { req.Stats.WrittenBytes += 99 }, changed: false},
+		{fn: func(s *Shard, req *rpcpb.ShardHeartbeatReq) { req.Stats.WrittenBytes += 100 }, changed: true},
+		{fn: func(s *Shard, req *rpcpb.ShardHeartbeatReq) { req.Stats.ApproximateKeys = 1 }, changed: false},
+		{fn: func(s *Shard, req *rpcpb.ShardHeartbeatReq) { req.Stats.ApproximateKeys = 0 }, changed: true},
+	}
+	for idx, tt := range tests {
+		s := shard
+		r := req
+		tt.fn(&s, &r)
+		assert.Equal(t, tt.changed, last.changed(newHeartbeatDigest(now, s, r), 100, 10), "index %d", idx)
+	}
+}
+
+func TestReplicaStatsReadLatency(t *testing.T) {
+	rs := newReplicaStats()
+	assert.Equal(t, uint64(0), rs.heartbeatState(time.Now()).ReadLatency)
+
+	rs.readLatency += time.Millisecond
+	rs.readLatency += 3 * time.Millisecond
+	rs.readCount += 2
+	assert.Equal(t, uint64(2000), rs.heartbeatState(time.Now()).ReadLatency)
+
+	rs.resetReadLatency()
+	assert.Equal(t, uint64(0), rs.heartbeatState(time.Now()).ReadLatency)
+}
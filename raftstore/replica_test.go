@@ -22,6 +22,8 @@ import (
 	"go.etcd.io/etcd/raft/v3"
 	"go.etcd.io/etcd/raft/v3/raftpb"
 
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/matrixorigin/matrixcube/util/stop"
@@ -95,3 +97,27 @@ func newTestReplica(shard Shard, peer Replica, s *store) *replica {
 	pr.setStarted()
 	return pr
 }
+
+func TestGetRaftConfigElectionOptions(t *testing.T) {
+	cfg := &config.Config{}
+	c := getRaftConfig(1, 0, 1, nil, cfg, log.Adjust(nil))
+	assert.True(t, c.PreVote)
+	assert.True(t, c.CheckQuorum)
+
+	disabled := false
+	cfg.Raft.Groups = []config.GroupRaftConfig{{Group: 1, PreVote: &disabled, CheckQuorum: &disabled}}
+	c = getRaftConfig(1, 0, 1, nil, cfg, log.Adjust(nil))
+	assert.False(t, c.PreVote)
+	assert.False(t, c.CheckQuorum)
+	c = getRaftConfig(1, 0, 2, nil, cfg, log.Adjust(nil))
+	assert.True(t, c.PreVote)
+	assert.True(t, c.CheckQuorum)
+}
+
+func TestGetRaftConfigMaxApplyBatchSize(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Raft.MaxApplyBatchSize = 1024
+	cfg.Worker.GroupPools = []config.GroupWorkerPoolConfig{{Groups: []uint64{1}, MaxApplyBatchSize: 10}}
+	assert.Equal(t, uint64(10), getRaftConfig(1, 0, 1, nil, cfg, log.Adjust(nil)).MaxCommittedSizePerReady)
+	assert.Equal(t, uint64(1024), getRaftConfig(1, 0, 2, nil, cfg, log.Adjust(nil)).MaxCommittedSizePerReady)
+}
@@ -0,0 +1,114 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+)
+
+// ttlGCState tracks the last write of the shard applied by the replica. All the
+// replicas track the writes, so the new leader is able to continue the GC
+// after the leadership changed.
+type ttlGCState struct {
+	// lastWrite the time of the last applied write, zero until the first check
+	// or write, so a restarted replica waits for a full TTL.
+	lastWrite time.Time
+	// clean no write is applied since the last applied DeleteRange
+	clean bool
+}
+
+func (s *ttlGCState) onWrite(now time.Time) {
+	s.lastWrite = now
+	s.clean = false
+}
+
+// expired returns true if the data written before the TTL is not removed yet
+func (s *ttlGCState) expired(now time.Time, ttl time.Duration) bool {
+	if s.lastWrite.IsZero() {
+		s.lastWrite = now
+		return false
+	}
+	return !s.clean && now.Sub(s.lastWrite) >= ttl
+}
+
+// doTTLGC proposes the DeleteRange of the whole shard if no write is applied
+// within the TTL of the shard. Only the leader proposes, the proposal is
+// rejected by the epoch check if the range of the shard is changed, and it's
+// skipped by the state machine if any write is applied after the current
+// applied index, so the proposals of a stale leader never remove new data.
+func (pr *replica) doTTLGC(now time.Time) {
+	shard := pr.getShard()
+	if shard.TTL == 0 || !pr.isLeader() {
+		return
+	}
+	if _, ok := pr.sm.dataStorage.(storage.RangeDeleter); !ok {
+		return
+	}
+	if !pr.ttlGC.expired(now, time.Duration(shard.TTL)*time.Second) {
+		return
+	}
+
+	pr.logger.Info("requesting delete range of the expired shard",
+		log.IndexField(pr.appliedIndex),
+		zap.Uint64("ttl", shard.TTL),
+		zap.Time("last-write", pr.ttlGC.lastWrite))
+	pr.addAdminRequest(rpcpb.CmdDeleteRange, &rpcpb.DeleteRangeRequest{
+		Start:      shard.Start,
+		End:        shard.End,
+		CheckIndex: pr.appliedIndex,
+	})
+}
+
+// applyDeleteRange marks the replica clean once the expired data is removed,
+// no more DeleteRange is proposed until the next write. The approximate stats
+// of the emptied shard are reset, so the prophet finds the empty shard by the
+// next heartbeat rather than waiting for the next split check.
+func (pr *replica) applyDeleteRange(result deleteRangeResult) {
+	if result.deleted {
+		pr.ttlGC.clean = true
+	}
+	if result.emptied {
+		pr.stats.approximateSize = 0
+		pr.stats.approximateKeys = 0
+		pr.stats.deleteKeysHint = 0
+		pr.logger.Info("shard emptied by delete range")
+	}
+}
+
+func (s *store) handleTTLGCTask() {
+	s.forEachReplica(func(pr *replica) bool {
+		if pr.isLeader() && pr.getShard().TTL > 0 {
+			pr.addAction(action{actionType: ttlGCAction})
+		}
+		return true
+	})
+}
+
+// restoreLastWriteIndex returns the index of the last applied write restored
+// from the shard metadata persisted at md.LogIndex. The writes never save the
+// metadata, they only move the persistent log index of the data storage, so
+// the last write is at the persistent log index if it's beyond the metadata.
+func restoreLastWriteIndex(md metapb.ShardMetadata, persistentLogIndex uint64) uint64 {
+	if persistentLogIndex > md.LogIndex {
+		return persistentLogIndex
+	}
+	return md.Metadata.LastWriteIndex
+}
@@ -0,0 +1,141 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestTTLGCStateExpired(t *testing.T) {
+	now := time.Now()
+	var s ttlGCState
+	// the first check starts the TTL
+	assert.False(t, s.expired(now, time.Second))
+	assert.False(t, s.expired(now.Add(time.Millisecond), time.Second))
+	assert.True(t, s.expired(now.Add(time.Second), time.Second))
+
+	s.clean = true
+	assert.False(t, s.expired(now.Add(time.Hour), time.Second))
+
+	s.onWrite(now.Add(time.Hour))
+	assert.False(t, s.clean)
+	assert.False(t, s.expired(now.Add(time.Hour), time.Second))
+	assert.True(t, s.expired(now.Add(time.Hour+time.Second), time.Second))
+}
+
+func TestDoTTLGC(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 1, Start: []byte("a"), End: []byte("b"), TTL: 1},
+		Replica{ID: 1}, s)
+	now := time.Now()
+	pr.ttlGC.onWrite(now)
+	pr.appliedIndex = 10
+
+	pr.leaderID = 2
+	pr.doTTLGC(now.Add(time.Hour))
+	assert.Equal(t, int64(0), pr.requests.Len())
+
+	pr.leaderID = 1
+	pr.doTTLGC(now)
+	assert.Equal(t, int64(0), pr.requests.Len())
+
+	pr.doTTLGC(now.Add(time.Second))
+	require.Equal(t, int64(1), pr.requests.Len())
+	v, err := pr.requests.Peek()
+	require.NoError(t, err)
+	ctx := v.(reqCtx)
+	assert.Equal(t, uint64(rpcpb.CmdDeleteRange), ctx.req.CustomType)
+	req := &rpcpb.DeleteRangeRequest{}
+	protoc.MustUnmarshal(req, ctx.req.Cmd)
+	assert.Equal(t, []byte("a"), req.Start)
+	assert.Equal(t, []byte("b"), req.End)
+	assert.Equal(t, uint64(10), req.CheckIndex)
+}
+
+func TestRestoreLastWriteIndex(t *testing.T) {
+	md := metapb.ShardMetadata{
+		LogIndex: 97,
+		Metadata: metapb.ShardLocalState{LastWriteIndex: 90},
+	}
+	assert.Equal(t, uint64(90), restoreLastWriteIndex(md, 97))
+	// a write is persisted after the metadata
+	assert.Equal(t, uint64(98), restoreLastWriteIndex(md, 98))
+}
+
+func newDeleteRangeEntry(index uint64, checkIndex uint64) raftpb.Entry {
+	batch := newTestAdminRequestBatch("", 0, rpcpb.CmdDeleteRange,
+		protoc.MustMarshal(&rpcpb.DeleteRangeRequest{CheckIndex: checkIndex}))
+	batch.Header.ShardID = 100
+	return raftpb.Entry{
+		Index: index,
+		Term:  1,
+		Type:  raftpb.EntryNormal,
+		Data:  protoc.MustMarshal(&batch),
+	}
+}
+
+func TestDeleteRangeAgreesAfterRestart(t *testing.T) {
+	h := &testReplicaResultHandler{}
+	f := func(sm *stateMachine) {
+		apply := func(entry raftpb.Entry) {
+			sm.applyCommittedEntries([]raftpb.Entry{entry})
+		}
+		deleted := func() bool {
+			require.Equal(t, 1, len(h.resp.Responses))
+			return h.resp.GetDeleteRangeResponse().Deleted
+		}
+		// the replica is restarted, the last write index restored from the
+		// persisted metadata and the persistent log index is the same as the
+		// one kept by the replicas not restarted
+		restart := func() {
+			lastWriteIndex := sm.lastWriteIndex
+			sm.lastWriteIndex = 0
+			states, err := sm.dataStorage.GetInitialStates()
+			require.NoError(t, err)
+			require.Equal(t, 1, len(states))
+			persistentLogIndex, err := sm.dataStorage.GetPersistentLogIndex(sm.shardID)
+			require.NoError(t, err)
+			sm.lastWriteIndex = restoreLastWriteIndex(states[0], persistentLogIndex)
+			assert.Equal(t, lastWriteIndex, sm.lastWriteIndex)
+		}
+
+		apply(newKVSetEntry(1, 1, []byte("k1"), []byte("v1")))
+		// skipped, the metadata is saved at the index 2
+		apply(newDeleteRangeEntry(2, 0))
+		assert.False(t, deleted())
+		restart()
+		apply(newDeleteRangeEntry(3, 1))
+		assert.True(t, deleted())
+
+		// the write is persisted after the metadata
+		apply(newKVSetEntry(4, 4, []byte("k2"), []byte("v2")))
+		restart()
+		apply(newDeleteRangeEntry(5, 3))
+		assert.False(t, deleted())
+	}
+	runSimpleStateMachineTest(t, f, h)
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"github.com/fagongzi/util/protoc"
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+// A witness replica is a voter which persists the raft log but applies no user
+// data. It participates in the quorum, so a 2 datacenter deployment can place
+// the third voter on a cheap node. The witness never serves the reads and
+// transfers the leadership away as soon as it's elected.
+
+// isWitnessReplica returns true if the replica of the shard is a witness
+func isWitnessReplica(shard Shard, replicaID uint64) bool {
+	for _, r := range shard.Replicas {
+		if r.ID == replicaID {
+			return r.Role == metapb.ReplicaRole_Witness
+		}
+	}
+	return false
+}
+
+func (pr *replica) isWitness() bool {
+	return isWitnessReplica(pr.getShard(), pr.replicaID)
+}
+
+func (d *stateMachine) isWitness() bool {
+	return isWitnessReplica(d.getShard(), d.replica.ID)
+}
+
+// maybeTransferWitnessLeader transfers the leadership of the witness leader to
+// a voter with the user data.
+func (pr *replica) maybeTransferWitnessLeader() {
+	if !pr.isLeader() || !pr.isWitness() {
+		return
+	}
+	if pr.rn.BasicStatus().LeadTransferee != 0 {
+		return
+	}
+	for _, r := range pr.getShard().Replicas {
+		if r.ID != pr.replicaID &&
+			r.Role == metapb.ReplicaRole_Voter &&
+			pr.isTransferLeaderAllowed(r) {
+			pr.logger.Info("witness leader transfers the leadership",
+				log.ReplicaField("to", r))
+			pr.doTransferLeader(r)
+			return
+		}
+	}
+}
+
+// execWitnessWriteRequests skips the user data of the write requests, only the
+// applied index is advanced.
+func (d *stateMachine) execWitnessWriteRequests(requests []rpcpb.Request) rpcpb.ResponseBatch {
+	if err := d.dataStorage.Write(d.writeCtx); err != nil {
+		d.logger.Fatal("failed to advance witness applied index",
+			zap.Error(err))
+	}
+
+	resp := rpcpb.ResponseBatch{}
+	for range requests {
+		resp.Responses = append(resp.Responses, rpcpb.Response{})
+	}
+	return resp
+}
+
+// newWitnessSnapshot returns the snapshot sent to the witness, it carries the
+// shard metadata at the applied index instead of the on disk snapshot image.
+func (pr *replica) newWitnessSnapshot() raftpb.Snapshot {
+	index, term := pr.sm.getAppliedIndexTerm()
+	si := metapb.SnapshotInfo{
+		Witness: true,
+		Metadata: metapb.ShardMetadata{
+			ShardID:  pr.shardID,
+			LogIndex: index,
+			Metadata: metapb.ShardLocalState{
+				State:         metapb.ReplicaState_Normal,
+				Shard:         pr.getShard(),
+				Lease:         pr.sm.getLease(),
+				ConfigChanges: pr.sm.getConfigChanges(),
+				Purges:        pr.sm.getPurgeMarkers(),
+				AppliedAdmins: pr.sm.getAppliedAdmins(),
+				Dictionaries:  pr.sm.getDictionaries(),
+				// the witness never writes, the persistent log index restored
+				// from the snapshot is the index of the metadata
+				LastWriteIndex: pr.sm.lastWriteIndex,
+			},
+		},
+	}
+	return raftpb.Snapshot{
+		Metadata: raftpb.SnapshotMetadata{
+			Index:     index,
+			Term:      term,
+			ConfState: pr.sm.getConfState(),
+		},
+		Data: protoc.MustMarshal(&si),
+	}
+}
+
+// sendWitnessSnapshot sends the snapshot to the witness as a regular message,
+// the snapshot is reported as finished once the message is sent.
+func (pr *replica) sendWitnessSnapshot(m metapb.RaftMessage) {
+	m.Message.Snapshot = pr.newWitnessSnapshot()
+	pr.logger.Info("sending a witness snapshot message",
+		log.SnapshotField(m.Message.Snapshot))
+	status := raft.SnapshotFinish
+	if !pr.transport.Send(m) {
+		status = raft.SnapshotFailure
+	}
+	pr.rn.ReportSnapshot(m.Message.To, status)
+}
+
+// applyWitnessSnapshot persists the shard metadata in the witness snapshot,
+// which also advances the applied index to the snapshot index.
+func (d *stateMachine) applyWitnessSnapshot(ss raftpb.Snapshot,
+	si metapb.SnapshotInfo) (metapb.ShardMetadata, error) {
+	md := si.Metadata
+	md.LogIndex = ss.Metadata.Index
+	if err := d.dataStorage.SaveShardMetadata([]metapb.ShardMetadata{md}); err != nil {
+		return metapb.ShardMetadata{}, err
+	}
+	if err := d.dataStorage.Sync([]uint64{md.ShardID}); err != nil {
+		return metapb.ShardMetadata{}, err
+	}
+	return md, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/metric"
+)
+
+const (
+	admissionApplyLag         = "apply-lag"
+	admissionPendingProposals = "pending-proposals"
+	admissionDiskSaturated    = "disk-saturated"
+)
+
+// checkWriteAdmission returns the type and the reason to reject the writes,
+// empty if the writes are admitted. Only the writes proposed to the leader are
+// checked, the admin requests are always admitted.
+func (pr *replica) checkWriteAdmission(c batch) (string, string) {
+	if !pr.isLeader() || c.requestBatch.IsAdmin() {
+		return "", ""
+	}
+
+	cfg := pr.cfg.WriteAdmission
+	if cfg.MaxApplyLag > 0 {
+		if applyLag := pr.getApplyLag(); applyLag > cfg.MaxApplyLag {
+			return admissionApplyLag, fmt.Sprintf("apply lag %d exceeds %d",
+				applyLag, cfg.MaxApplyLag)
+		}
+	}
+	if cfg.MaxPendingProposals > 0 {
+		if n := pr.pendingProposals.size(); n >= cfg.MaxPendingProposals {
+			return admissionPendingProposals, fmt.Sprintf("%d pending proposals reach %d",
+				n, cfg.MaxPendingProposals)
+		}
+	}
+	if cfg.RejectOnDiskSaturated && pr.store.ioScheduler.enabled() {
+		if _, write := pr.store.ioScheduler.isSaturated(); write {
+			return admissionDiskSaturated, "disk write throughput saturated"
+		}
+	}
+	return "", ""
+}
+
+// admitWrite rejects the write batch with the ServerIsBusy error if the apply
+// backlog of the shard or the disk throughput exceeds the thresholds. Returns
+// false if the batch is rejected.
+func (pr *replica) admitWrite(c batch) bool {
+	tp, reason := pr.checkWriteAdmission(c)
+	if tp == "" {
+		return true
+	}
+
+	metric.IncWriteAdmissionRejected(tp)
+	if ce := pr.logger.Check(zap.DebugLevel, "write rejected by admission control"); ce != nil {
+		ce.Write(log.RequestIDField(c.getRequestID()),
+			log.ReasonField(reason))
+	}
+	c.respServerIsBusy(pr.shardID, reason)
+	return false
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/errorpb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestAdmitWrite(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	pr.leaderID = 1
+	pr.lastCommittedIndex = 10
+	pr.appliedIndex = 4
+
+	var responses []rpcpb.ResponseBatch
+	newWrite := func(tp rpcpb.CmdType) batch {
+		return newBatch(s.logger, rpcpb.RequestBatch{
+			Header:   rpcpb.RequestBatchHeader{ID: []byte("batch")},
+			Requests: []rpcpb.Request{{ID: []byte("write"), Type: tp}},
+		}, func(resp rpcpb.ResponseBatch) {
+			responses = append(responses, resp)
+		}, 0, 0)
+	}
+	write := newWrite(rpcpb.Write)
+
+	// disabled
+	assert.True(t, pr.admitWrite(write))
+
+	pr.cfg.WriteAdmission.MaxApplyLag = 6
+	assert.True(t, pr.admitWrite(write))
+	pr.cfg.WriteAdmission.MaxApplyLag = 5
+	assert.False(t, pr.admitWrite(write))
+	require.Equal(t, 1, len(responses))
+	require.Equal(t, 1, len(responses[0].Responses))
+	busy := responses[0].Responses[0].Error.ServerIsBusy
+	require.NotNil(t, busy)
+	assert.Equal(t, uint64(1), busy.ShardID)
+	assert.Equal(t, "apply lag 6 exceeds 5", busy.Reason)
+	// rejected writes are retried by the proxy
+	assert.True(t, errorpb.Retryable(responses[0].Responses[0].Error))
+
+	// the admin requests and the writes to the followers are not checked
+	assert.True(t, pr.admitWrite(newWrite(rpcpb.Admin)))
+	pr.leaderID = 2
+	assert.True(t, pr.admitWrite(write))
+	pr.leaderID = 1
+	pr.cfg.WriteAdmission.MaxApplyLag = 0
+
+	pr.cfg.WriteAdmission.MaxPendingProposals = 2
+	pr.pendingProposals.append(newWrite(rpcpb.Write))
+	assert.True(t, pr.admitWrite(write))
+	pr.pendingProposals.append(newWrite(rpcpb.Write))
+	assert.False(t, pr.admitWrite(write))
+	require.Equal(t, 2, len(responses))
+	assert.Equal(t, "2 pending proposals reach 2",
+		responses[1].Responses[0].Error.ServerIsBusy.Reason)
+	pr.cfg.WriteAdmission.MaxPendingProposals = 0
+
+	pr.cfg.WriteAdmission.RejectOnDiskSaturated = true
+	// the disk saturation is unknown without the io scheduler
+	assert.True(t, pr.admitWrite(write))
+	s.ioScheduler = newIOScheduler(s.logger, config.QoSConfig{Enable: true}, nil, nil)
+	assert.True(t, pr.admitWrite(write))
+	s.ioScheduler.mu.write.saturated = true
+	assert.False(t, pr.admitWrite(write))
+	require.Equal(t, 3, len(responses))
+	assert.Equal(t, "disk write throughput saturated",
+		responses[2].Responses[0].Error.ServerIsBusy.Reason)
+}
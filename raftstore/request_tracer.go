@@ -0,0 +1,168 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+// maxRequestTraceEvents max number of events kept for a request, a request may
+// be retried many times, the older events are dropped.
+const maxRequestTraceEvents = 16
+
+// RequestTraceStage is the stage of a request handled by the store
+type RequestTraceStage int
+
+const (
+	// RequestReceived the request is received by the store
+	RequestReceived RequestTraceStage = iota
+	// RequestProposed the request is proposed to the raft group
+	RequestProposed
+	// RequestReadIndex the read index of the request is requested
+	RequestReadIndex
+	// RequestApplied the request is applied to the state machine
+	RequestApplied
+	// RequestResponded the response of the request is returned
+	RequestResponded
+)
+
+func (s RequestTraceStage) String() string {
+	switch s {
+	case RequestReceived:
+		return "received"
+	case RequestProposed:
+		return "proposed"
+	case RequestReadIndex:
+		return "read-index"
+	case RequestApplied:
+		return "applied"
+	case RequestResponded:
+		return "responded"
+	}
+	return "unknown"
+}
+
+// RequestTraceEvent is an event in the trace of a request
+type RequestTraceEvent struct {
+	Stage   RequestTraceStage
+	Time    time.Time
+	ShardID uint64
+	// Index is the raft log index of the proposed and applied events
+	Index uint64
+}
+
+// requestTracer keeps the traces of the recent requests by the request id. The
+// request id is the correlation id of the request, which is already used in the
+// logs of the request.
+type requestTracer struct {
+	capacity int
+
+	mu struct {
+		sync.Mutex
+		traces map[string][]RequestTraceEvent
+		// ids is a ring of the traced request ids, the oldest trace is
+		// evicted when the ring is full
+		ids  []string
+		next int
+	}
+}
+
+func newRequestTracer(capacity int) *requestTracer {
+	t := &requestTracer{capacity: capacity}
+	t.mu.traces = make(map[string][]RequestTraceEvent, capacity)
+	t.mu.ids = make([]string, 0, capacity)
+	return t
+}
+
+func (t *requestTracer) enabled() bool {
+	return t != nil && t.capacity > 0
+}
+
+func (t *requestTracer) record(id []byte, stage RequestTraceStage, shardID uint64, index uint64) {
+	if !t.enabled() || len(id) == 0 {
+		return
+	}
+
+	e := RequestTraceEvent{
+		Stage:   stage,
+		Time:    time.Now(),
+		ShardID: shardID,
+		Index:   index,
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := string(id)
+	events, ok := t.mu.traces[key]
+	if !ok {
+		if len(t.mu.ids) < t.capacity {
+			t.mu.ids = append(t.mu.ids, key)
+		} else {
+			delete(t.mu.traces, t.mu.ids[t.mu.next])
+			t.mu.ids[t.mu.next] = key
+			t.mu.next = (t.mu.next + 1) % t.capacity
+		}
+	}
+	if len(events) >= maxRequestTraceEvents {
+		events = events[1:]
+	}
+	t.mu.traces[key] = append(events, e)
+}
+
+func (t *requestTracer) recordBatch(requests []rpcpb.Request, stage RequestTraceStage,
+	shardID uint64, index uint64) {
+	if !t.enabled() {
+		return
+	}
+	for idx := range requests {
+		t.record(requests[idx].ID, stage, shardID, index)
+	}
+}
+
+func (t *requestTracer) get(id []byte) []RequestTraceEvent {
+	if !t.enabled() {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	events := t.mu.traces[string(id)]
+	if len(events) == 0 {
+		return nil
+	}
+	return append([]RequestTraceEvent(nil), events...)
+}
+
+// wrap returns a callback which observes the request duration and records the
+// responded event. The request id is attached as the exemplar of the duration
+// only if the request is traced, so the trace of the exemplar can be fetched.
+func (t *requestTracer) wrap(req rpcpb.Request, cb func(rpcpb.ResponseBatch)) func(rpcpb.ResponseBatch) {
+	start := time.Now()
+	traced := t.enabled() && len(req.ID) > 0
+	t.record(req.ID, RequestReceived, req.ToShard, 0)
+	return func(resp rpcpb.ResponseBatch) {
+		var traceID string
+		if traced {
+			t.record(req.ID, RequestResponded, req.ToShard, 0)
+			traceID = hex.EncodeToString(req.ID)
+		}
+		metric.ObserveRequestDuration(start, traceID)
+		cb(resp)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTracerDisabled(t *testing.T) {
+	var tracer *requestTracer
+	tracer.record([]byte("id"), RequestReceived, 1, 0)
+	assert.Nil(t, tracer.get([]byte("id")))
+
+	called := false
+	cb := tracer.wrap(rpcpb.Request{ID: []byte("id")}, func(rpcpb.ResponseBatch) { called = true })
+	cb(rpcpb.ResponseBatch{})
+	assert.True(t, called)
+}
+
+func TestRequestTracerRecordStages(t *testing.T) {
+	tracer := newRequestTracer(8)
+	req := rpcpb.Request{ID: []byte("id1"), ToShard: 1}
+	cb := tracer.wrap(req, func(rpcpb.ResponseBatch) {})
+	tracer.recordBatch([]rpcpb.Request{req}, RequestProposed, 1, 10)
+	tracer.recordBatch([]rpcpb.Request{req}, RequestApplied, 1, 10)
+	cb(rpcpb.ResponseBatch{})
+
+	events := tracer.get(req.ID)
+	require.Equal(t, 4, len(events))
+	assert.Equal(t, RequestReceived, events[0].Stage)
+	assert.Equal(t, RequestProposed, events[1].Stage)
+	assert.Equal(t, uint64(10), events[1].Index)
+	assert.Equal(t, RequestApplied, events[2].Stage)
+	assert.Equal(t, RequestResponded, events[3].Stage)
+	assert.Equal(t, "responded", events[3].Stage.String())
+	assert.Nil(t, tracer.get([]byte("id2")))
+}
+
+func TestRequestTracerEvictOldestTrace(t *testing.T) {
+	tracer := newRequestTracer(2)
+	tracer.record([]byte("id1"), RequestReceived, 1, 0)
+	tracer.record([]byte("id2"), RequestReceived, 1, 0)
+	tracer.record([]byte("id3"), RequestReceived, 1, 0)
+	assert.Nil(t, tracer.get([]byte("id1")))
+	assert.NotNil(t, tracer.get([]byte("id2")))
+	assert.NotNil(t, tracer.get([]byte("id3")))
+
+	for i := 0; i < maxRequestTraceEvents+1; i++ {
+		tracer.record([]byte("id3"), RequestProposed, 1, uint64(i))
+	}
+	events := tracer.get([]byte("id3"))
+	assert.Equal(t, maxRequestTraceEvents, len(events))
+	assert.Equal(t, uint64(maxRequestTraceEvents), events[len(events)-1].Index)
+}
@@ -8,8 +8,17 @@ import (
 // TODO: move all response method to here
 
 func requestDone(req rpcpb.Request, cb func(rpcpb.ResponseBatch), data []byte) {
+	requestDoneWithReadState(req, cb, data, 0, 0)
+}
+
+// requestDoneWithReadState returns the read result with the apply lag and the
+// applied index of the replica at which the read is served.
+func requestDoneWithReadState(req rpcpb.Request, cb func(rpcpb.ResponseBatch), data []byte,
+	applyLag uint64, appliedIndex uint64) {
 	r := getResponse(req)
 	r.Value = data
+	r.ApplyLag = applyLag
+	r.AppliedIndex = appliedIndex
 	cb(rpcpb.ResponseBatch{Responses: []rpcpb.Response{r}})
 }
 
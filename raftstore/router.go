@@ -100,6 +100,7 @@ type routerOptions struct {
 	fields             []zap.Field
 	removeShardHandler func(id uint64)
 	createShardHandler func(shard Shard)
+	settingHandler     func(settings []metapb.ClusterSetting)
 }
 
 func (opts *routerOptions) adjust() {
@@ -110,6 +111,9 @@ func (opts *routerOptions) adjust() {
 	if opts.createShardHandler == nil {
 		opts.createShardHandler = func(shard Shard) {}
 	}
+	if opts.settingHandler == nil {
+		opts.settingHandler = func(settings []metapb.ClusterSetting) {}
+	}
 }
 
 type routerBuilder struct {
@@ -137,6 +141,11 @@ func (rb *routerBuilder) withCreatShardHandle(handle func(shard Shard)) *routerB
 	return rb
 }
 
+func (rb *routerBuilder) withSettingHandle(handle func(settings []metapb.ClusterSetting)) *routerBuilder {
+	rb.options.settingHandler = handle
+	return rb
+}
+
 func (rb *routerBuilder) build(eventC chan rpcpb.EventNotify) (Router, error) {
 	return newRouter(eventC, rb.options)
 }
@@ -416,6 +425,7 @@ func (r *defaultRouter) handleEvent(evt rpcpb.EventNotify) {
 				&evt.InitEvent.Leases[i],
 				false, false)
 		}
+		r.options.settingHandler(evt.InitEvent.Settings)
 	case event.ShardEvent:
 		r.updateShardLocked(evt.ShardEvent.Data,
 			evt.ShardEvent.LeaderReplicaID,
@@ -428,6 +438,8 @@ func (r *defaultRouter) handleEvent(evt rpcpb.EventNotify) {
 		r.mu.shardStats[evt.ShardStatsEvent.ShardID] = *evt.ShardStatsEvent
 	case event.StoreStatsEvent:
 		r.mu.storeStats[evt.StoreStatsEvent.StoreID] = *evt.StoreStatsEvent
+	case event.SettingEvent:
+		r.options.settingHandler([]metapb.ClusterSetting{*evt.SettingEvent})
 	}
 }
 
@@ -503,3 +503,32 @@ func TestAscendRange(t *testing.T) {
 		assert.Equal(t, c.expectStores, stores, "index %d", i)
 	}
 }
+
+func TestHandleSettingEvent(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	settings := newClusterSettings(nil)
+	rr, err := newRouterBuilder().
+		withSettingHandle(settings.update).
+		build(make(chan rpcpb.EventNotify))
+	assert.NoError(t, err)
+	r := rr.(*defaultRouter)
+
+	e := rpcpb.EventNotify{}
+	e.Type = event.InitEvent
+	e.InitEvent = &rpcpb.InitEventData{
+		Settings: []metapb.ClusterSetting{{Key: "k1", Value: "v1", Version: 1}},
+	}
+	r.handleEvent(e)
+	v, ok := settings.get("k1")
+	assert.True(t, ok)
+	assert.Equal(t, "v1", v)
+
+	e = rpcpb.EventNotify{}
+	e.Type = event.SettingEvent
+	e.SettingEvent = &metapb.ClusterSetting{Key: "k1", Value: "v2", Version: 2}
+	r.handleEvent(e)
+	v, ok = settings.get("k1")
+	assert.True(t, ok)
+	assert.Equal(t, "v2", v)
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+
+	"github.com/lni/goutils/syncutil"
+)
+
+// snapshotTask saves a snapshot from the checkpoint of a replica
+type snapshotTask struct {
+	// generate saves the snapshot, it's called by a snapshot worker
+	generate func()
+	// cancel releases the checkpoint of the task which is not generated as the
+	// generator is closed
+	cancel func()
+}
+
+// snapshotGenerator saves the snapshots from the checkpoints of the data
+// storage in a pool of workers, so the raft event workers are not blocked by
+// saving big snapshots. The tasks exceeding the queue are rejected, the replica
+// creates the snapshot again when it's requested by raft next time.
+type snapshotGenerator struct {
+	workers int
+	stopper *syncutil.Stopper
+	tasksC  chan snapshotTask
+
+	mu struct {
+		sync.Mutex
+		closed bool
+	}
+}
+
+func newSnapshotGenerator(workers int) *snapshotGenerator {
+	return &snapshotGenerator{
+		workers: workers,
+		stopper: syncutil.NewStopper(),
+		tasksC:  make(chan snapshotTask, workers),
+	}
+}
+
+func (g *snapshotGenerator) start() {
+	for i := 0; i < g.workers; i++ {
+		g.stopper.RunWorker(func() {
+			for {
+				select {
+				case <-g.stopper.ShouldStop():
+					return
+				case task := <-g.tasksC:
+					task.generate()
+				}
+			}
+		})
+	}
+}
+
+// add adds the task to the queue, returns false if the queue is full or the
+// generator is closed, the caller should cancel the rejected task.
+func (g *snapshotGenerator) add(task snapshotTask) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.mu.closed {
+		return false
+	}
+	select {
+	case g.tasksC <- task:
+		return true
+	default:
+		return false
+	}
+}
+
+// close waits for the snapshots being generated, and cancels the queued tasks.
+// It must be called before the data storage is closed.
+func (g *snapshotGenerator) close() {
+	g.mu.Lock()
+	g.mu.closed = true
+	g.mu.Unlock()
+	g.stopper.Stop()
+	for {
+		select {
+		case task := <-g.tasksC:
+			task.cancel()
+		default:
+			return
+		}
+	}
+}
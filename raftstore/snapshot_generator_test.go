@@ -0,0 +1,61 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotGenerator(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	g := newSnapshotGenerator(1)
+	g.start()
+
+	blockC := make(chan struct{})
+	generatedC := make(chan struct{}, 3)
+	canceled := 0
+	newTask := func(block bool) snapshotTask {
+		return snapshotTask{
+			generate: func() {
+				if block {
+					<-blockC
+				}
+				generatedC <- struct{}{}
+			},
+			cancel: func() { canceled++ },
+		}
+	}
+	assert.True(t, g.add(newTask(false)))
+	<-generatedC
+
+	// the worker is blocked by the task, the next one is queued
+	assert.True(t, g.add(newTask(true)))
+	for len(g.tasksC) > 0 {
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.True(t, g.add(newTask(false)))
+	assert.False(t, g.add(newTask(false)))
+
+	close(blockC)
+	<-generatedC
+	g.close()
+	assert.False(t, g.add(newTask(false)))
+	// the queued task is either generated or canceled
+	assert.Equal(t, 1, canceled+len(generatedC))
+}
@@ -261,8 +261,7 @@ func (s *snapshotter) parseIndex(dir string) uint64 {
 }
 
 func (s *snapshotter) isZombie(dir string) bool {
-	return snapshot.GenSnapshotDirNameRe.Match([]byte(dir)) ||
-		snapshot.RecvSnapshotDirNameRe.Match([]byte(dir))
+	return snapshot.IsTempSnapshotDir(dir)
 }
 
 func (s *snapshotter) saveSnapshot(ss raftpb.Snapshot) error {
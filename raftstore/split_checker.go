@@ -29,12 +29,29 @@ type splitCheckFunc func(shard Shard, size uint64) (currentApproximateSize uint6
 	currentApproximateKeys uint64, splitKeys [][]byte, ctx []byte, err error)
 type featureGetter func(uint64) storage.Feature
 
+// splitCheckTask is a shard to be checked, the splitKeys are picked by the load
+// based split or specified by the manual split, the size based split check is
+// not performed if they are not nil. The split keys of the manual split are not
+// checked by the split check policy.
+type splitCheckTask struct {
+	shard     Shard
+	splitKeys [][]byte
+	manual    bool
+}
+
 type splitChecker struct {
 	replicaGetter     replicaGetter
 	featureGetterFunc featureGetter
 	checkFuncFactory  func(group uint64) splitCheckFunc
 	stopper           *syncutil.Stopper
-	shardsC           chan Shard
+	shardsC           chan splitCheckTask
+
+	// codecGetter returns the split key codec of the shard group, nil means the
+	// keys of all groups can be split at any point
+	codecGetter func(group uint64) storage.SplitKeyCodec
+	// splitPolicyGetter returns the split check policy of the shard group, nil
+	// means the split keys picked by the size or the load are used
+	splitPolicyGetter func(group uint64) storage.SplitCheckPolicy
 
 	mu struct {
 		sync.Mutex
@@ -51,7 +68,7 @@ func newSplitChecker(maxWaitToCheck int,
 		replicaGetter:     replicaGetter,
 		checkFuncFactory:  checkFuncFactory,
 		featureGetterFunc: featureGetter,
-		shardsC:           make(chan Shard, maxWaitToCheck),
+		shardsC:           make(chan splitCheckTask, maxWaitToCheck),
 	}
 }
 
@@ -70,14 +87,19 @@ func (sc *splitChecker) start() {
 			case <-sc.stopper.ShouldStop():
 				close(sc.shardsC)
 				return
-			case shard := <-sc.shardsC:
-				sc.doChecker(shard)
+			case task := <-sc.shardsC:
+				sc.doCheckTask(task)
 			}
 		}
 	}()
 }
 
 func (sc *splitChecker) doChecker(shard Shard) bool {
+	return sc.doCheckTask(splitCheckTask{shard: shard})
+}
+
+func (sc *splitChecker) doCheckTask(task splitCheckTask) bool {
+	shard := task.shard
 	pr, ok := sc.replicaGetter.getReplica(shard.ID)
 	if !ok {
 		return false
@@ -95,11 +117,19 @@ func (sc *splitChecker) doChecker(shard Shard) bool {
 	}
 
 	policy := sc.featureGetterFunc(shard.Group)
-	fn := sc.checkFuncFactory(shard.Group)
-	size, keys, splitKeys, ctx, err := fn(shard, policy.ShardCapacityBytes)
-	if err != nil {
-		pr.logger.Fatal("fail to scan split key",
-			zap.Error(err))
+	var size, keys uint64
+	var splitKeys [][]byte
+	var ctx []byte
+	if task.splitKeys != nil {
+		splitKeys = task.splitKeys
+	} else {
+		var err error
+		fn := sc.checkFuncFactory(shard.Group)
+		size, keys, splitKeys, ctx, err = fn(shard, policy.ShardCapacityBytes)
+		if err != nil {
+			pr.logger.Fatal("fail to scan split key",
+				zap.Error(err))
+		}
 	}
 
 	pr.logger.Debug("split check result",
@@ -109,9 +139,41 @@ func (sc *splitChecker) doChecker(shard Shard) bool {
 		zap.Uint64("keys", keys),
 		zap.ByteStrings("split-keys", splitKeys))
 
+	var splitPolicy storage.SplitCheckPolicy
+	if sc.splitPolicyGetter != nil && !task.manual {
+		splitPolicy = sc.splitPolicyGetter(shard.Group)
+	}
+	if splitPolicy != nil && len(splitKeys) > 0 {
+		chosen, err := splitPolicy.SplitKeys(shard, size, keys, splitKeys)
+		if err == nil && len(chosen) > 0 {
+			err = checkSplitKeys(shard, chosen)
+		}
+		if err != nil {
+			pr.logger.Error("fail to choose split keys by split check policy",
+				zap.Error(err))
+			return false
+		}
+		pr.logger.Debug("split keys chosen by split check policy",
+			zap.ByteStrings("split-keys", splitKeys),
+			zap.ByteStrings("chosen-split-keys", chosen))
+		splitKeys = chosen
+	}
+
+	var codec storage.SplitKeyCodec
+	if sc.codecGetter != nil {
+		codec = sc.codecGetter(shard.Group)
+	}
+	if codec != nil && len(splitKeys) > 0 {
+		adjusted := adjustSplitKeys(codec, shard, splitKeys)
+		pr.logger.Debug("split keys adjusted by split key codec",
+			zap.ByteStrings("split-keys", splitKeys),
+			zap.ByteStrings("adjusted-split-keys", adjusted))
+		splitKeys = adjusted
+	}
+
 	if len(splitKeys) > 0 {
 		for idx, key := range splitKeys {
-			if checkKeyInShard(key, shard) != nil {
+			if checkKeyInShard(key, shard, codec) != nil {
 				pr.logger.Fatal("invalid split key",
 					log.HexField("key", key),
 					log.HexField("shard-start", shard.Start),
@@ -158,6 +220,24 @@ func (sc *splitChecker) doChecker(shard Shard) bool {
 	return true
 }
 
+// adjustSplitKeys moves the split keys to the split points defined by the codec.
+// The keys whose split point is the start of the shard or not greater than the
+// previous split key are removed.
+func adjustSplitKeys(codec storage.SplitKeyCodec, shard Shard, splitKeys [][]byte) [][]byte {
+	adjusted := make([][]byte, 0, len(splitKeys))
+	for _, key := range splitKeys {
+		key = codec.SplitPoint(key)
+		if bytes.Compare(key, shard.Start) <= 0 {
+			continue
+		}
+		if n := len(adjusted); n > 0 && bytes.Compare(key, adjusted[n-1]) <= 0 {
+			continue
+		}
+		adjusted = append(adjusted, key)
+	}
+	return adjusted
+}
+
 func (sc *splitChecker) close() {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
@@ -171,20 +251,28 @@ func (sc *splitChecker) close() {
 }
 
 func (sc *splitChecker) add(shard Shard) {
+	sc.addTask(splitCheckTask{shard: shard})
+}
+
+// addTask returns false if the task is dropped
+func (sc *splitChecker) addTask(task splitCheckTask) bool {
+	shard := task.shard
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
 
 	if !sc.mu.running {
-		return
+		return false
 	}
 
 	if shard.State == metapb.ShardState_Destroying ||
 		shard.State == metapb.ShardState_Destroyed {
-		return
+		return false
 	}
 
 	select {
-	case sc.shardsC <- shard:
+	case sc.shardsC <- task:
+		return true
 	default:
+		return false
 	}
 }
@@ -14,6 +14,8 @@
 package raftstore
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -130,3 +132,154 @@ func TestSplitCheckerDoCheck(t *testing.T) {
 	assert.Equal(t, action{actionType: splitAction, epoch: pr.getShard().Epoch, splitCheckData: splitCheckData{keys: currentKeys, size: currentSize, splitKeys: splitKeys, splitIDs: splitIDs}}, act)
 
 }
+
+type testSplitCheckPolicy func(shard metapb.Shard, size, keys uint64, candidates [][]byte) ([][]byte, error)
+
+func (f testSplitCheckPolicy) SplitKeys(shard metapb.Shard, size, keys uint64, candidates [][]byte) ([][]byte, error) {
+	return f(shard, size, keys, candidates)
+}
+
+func TestSplitCheckerDoCheckWithSplitPolicy(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var chosen [][]byte
+	var err error
+	var candidates [][]byte
+	trg := newTestReplicaGetter()
+	sc := newSplitChecker(1, trg, func(u uint64) storage.Feature {
+		return storage.Feature{
+			ShardCapacityBytes: 100,
+		}
+	}, func(group uint64) splitCheckFunc {
+		return func(shard Shard, size uint64) (uint64, uint64, [][]byte, []byte, error) {
+			return 200, 20, [][]byte{[]byte("a"), []byte("c")}, nil, nil
+		}
+	})
+	sc.splitPolicyGetter = func(group uint64) storage.SplitCheckPolicy {
+		return testSplitCheckPolicy(func(shard metapb.Shard, size, keys uint64, keysToSplit [][]byte) ([][]byte, error) {
+			assert.Equal(t, uint64(200), size)
+			assert.Equal(t, uint64(20), keys)
+			candidates = keysToSplit
+			return chosen, err
+		})
+	}
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 1, Epoch: Epoch{Generation: 1}}, Replica{ID: 1}, s)
+	trg.replicas[1] = pr
+
+	splitIDs := []rpcpb.SplitID{{NewID: 1, NewReplicaIDs: []uint64{1, 2, 3}}, {NewID: 2, NewReplicaIDs: []uint64{1, 2, 3}}}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().AskBatchSplit(gomock.Any(), uint32(2)).Return(splitIDs, nil).Times(2)
+	pr.prophetClient = client
+	getAction := func() action {
+		act, _ := pr.actions.Peek()
+		_, err := pr.actions.Get(1, make([]interface{}, 1))
+		assert.NoError(t, err)
+		return act.(action)
+	}
+
+	// split at the keys chosen by the policy
+	chosen = [][]byte{[]byte("b")}
+	assert.True(t, sc.doChecker(pr.getShard()))
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("c")}, candidates)
+	assert.Equal(t, chosen, getAction().splitCheckData.splitKeys)
+
+	// not split if no key is chosen
+	chosen = nil
+	assert.True(t, sc.doChecker(pr.getShard()))
+	assert.Empty(t, getAction().splitCheckData.splitKeys)
+
+	// not split if the policy fails
+	err = errors.New("policy failed")
+	assert.False(t, sc.doChecker(pr.getShard()))
+	assert.Equal(t, int64(0), pr.actions.Len())
+	err = nil
+
+	// not split if the chosen keys are invalid
+	chosen = [][]byte{[]byte("c"), []byte("b")}
+	assert.False(t, sc.doChecker(pr.getShard()))
+	assert.Equal(t, int64(0), pr.actions.Len())
+
+	// the manual split keys are not checked by the policy
+	candidates = nil
+	assert.True(t, sc.doCheckTask(splitCheckTask{shard: pr.getShard(), splitKeys: [][]byte{[]byte("x")}, manual: true}))
+	assert.Nil(t, candidates)
+	assert.Equal(t, [][]byte{[]byte("x")}, getAction().splitCheckData.splitKeys)
+}
+
+// testSplitKeyCodec treats the part before '#' as the logical key
+type testSplitKeyCodec struct{}
+
+func (testSplitKeyCodec) SplitPoint(key []byte) []byte {
+	if idx := bytes.IndexByte(key, '#'); idx >= 0 {
+		return key[:idx]
+	}
+	return key
+}
+
+func TestAdjustSplitKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	cases := []struct {
+		shard     Shard
+		splitKeys [][]byte
+		expect    [][]byte
+	}{
+		{
+			shard:     Shard{},
+			splitKeys: [][]byte{[]byte("a"), []byte("b#1")},
+			expect:    [][]byte{[]byte("a"), []byte("b")},
+		},
+		{
+			shard:     Shard{},
+			splitKeys: [][]byte{[]byte("a#1"), []byte("a#2"), []byte("b#1")},
+			expect:    [][]byte{[]byte("a"), []byte("b")},
+		},
+		{
+			shard:     Shard{Start: []byte("a")},
+			splitKeys: [][]byte{[]byte("a#1"), []byte("a#2")},
+			expect:    [][]byte{},
+		},
+	}
+
+	for i, c := range cases {
+		assert.Equal(t, c.expect, adjustSplitKeys(testSplitKeyCodec{}, c.shard, c.splitKeys), "index %d", i)
+	}
+}
+
+func TestSplitCheckerDoCheckWithLoadSplitKey(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	trg := newTestReplicaGetter()
+	sc := newSplitChecker(1, trg, func(u uint64) storage.Feature {
+		return storage.Feature{
+			ShardCapacityBytes: 100,
+		}
+	}, func(group uint64) splitCheckFunc {
+		return func(shard Shard, size uint64) (uint64, uint64, [][]byte, []byte, error) {
+			assert.FailNow(t, "the size based split check is not expected")
+			return 0, 0, nil, nil, nil
+		}
+	})
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1, Epoch: Epoch{Generation: 1}, Start: []byte("a"), End: []byte("z")}, Replica{ID: 1}, s)
+	trg.replicas[1] = pr
+
+	splitIDs := []rpcpb.SplitID{{NewID: 2, NewReplicaIDs: []uint64{2}}, {NewID: 3, NewReplicaIDs: []uint64{3}}}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mockclient.NewMockClient(ctrl)
+	client.EXPECT().AskBatchSplit(gomock.Any(), uint32(2)).Return(splitIDs, nil)
+	pr.prophetClient = client
+
+	assert.True(t, sc.doCheckTask(splitCheckTask{shard: pr.getShard(), splitKeys: [][]byte{[]byte("m")}}))
+	act, _ := pr.actions.Peek()
+	assert.Equal(t, action{actionType: splitAction, epoch: pr.getShard().Epoch, splitCheckData: splitCheckData{splitKeys: [][]byte{[]byte("m")}, splitIDs: splitIDs}}, act)
+}
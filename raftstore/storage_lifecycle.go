@@ -0,0 +1,94 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/storage"
+)
+
+type groupLifecycleHooks struct {
+	group uint64
+	ds    storage.DataStorage
+	hooks storage.LifecycleHooks
+}
+
+// storageLifecycle calls the lifecycle hooks of the data storages, the hooks
+// of the groups are called in the ascending order of the group ID, and
+// BeforeClose is called in the reverse order.
+type storageLifecycle struct {
+	logger *zap.Logger
+	groups []groupLifecycleHooks
+	// opened the number of the groups whose BeforeOpen is called, only these
+	// groups are closed
+	opened int
+}
+
+func newStorageLifecycle(logger *zap.Logger, cfg *config.Config) *storageLifecycle {
+	l := &storageLifecycle{logger: logger}
+	factory := cfg.Customize.CustomStorageLifecycleHooksFactory
+	if factory == nil {
+		return l
+	}
+	cfg.Storage.ForeachDataStorageFunc(func(group uint64, ds storage.DataStorage) {
+		if hooks := factory(group); hooks != nil {
+			l.groups = append(l.groups, groupLifecycleHooks{
+				group: group,
+				ds:    ds,
+				hooks: hooks,
+			})
+		}
+	})
+	sort.Slice(l.groups, func(i, j int) bool {
+		return l.groups[i].group < l.groups[j].group
+	})
+	return l
+}
+
+func (l *storageLifecycle) beforeOpen() {
+	for _, g := range l.groups {
+		l.opened++
+		if err := g.hooks.BeforeOpen(g.group, g.ds); err != nil {
+			l.logger.Fatal("fail to call before open hook of the storage",
+				zap.Uint64("group", g.group),
+				zap.Error(err))
+		}
+	}
+}
+
+func (l *storageLifecycle) afterOpen() {
+	for _, g := range l.groups[:l.opened] {
+		if err := g.hooks.AfterOpen(g.group, g.ds); err != nil {
+			l.logger.Fatal("fail to call after open hook of the storage",
+				zap.Uint64("group", g.group),
+				zap.Error(err))
+		}
+	}
+}
+
+func (l *storageLifecycle) beforeClose() {
+	for i := l.opened - 1; i >= 0; i-- {
+		g := l.groups[i]
+		if err := g.hooks.BeforeClose(g.group, g.ds); err != nil {
+			l.logger.Error("fail to call before close hook of the storage",
+				zap.Uint64("group", g.group),
+				zap.Error(err))
+		}
+	}
+	l.opened = 0
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+type testLifecycleHooks struct {
+	sync.Mutex
+	events []string
+	// replicas returns the number of the replicas when the hook is called
+	replicas func() int
+}
+
+func (h *testLifecycleHooks) record(event string, group uint64) error {
+	h.Lock()
+	defer h.Unlock()
+	if h.replicas != nil {
+		event = fmt.Sprintf("%s-%d", event, h.replicas())
+	}
+	h.events = append(h.events, fmt.Sprintf("%s-%d", event, group))
+	return nil
+}
+
+func (h *testLifecycleHooks) getEvents() []string {
+	h.Lock()
+	defer h.Unlock()
+	return append([]string(nil), h.events...)
+}
+
+func (h *testLifecycleHooks) BeforeOpen(group uint64, ds storage.DataStorage) error {
+	return h.record("before-open", group)
+}
+
+func (h *testLifecycleHooks) AfterOpen(group uint64, ds storage.DataStorage) error {
+	return h.record("after-open", group)
+}
+
+func (h *testLifecycleHooks) BeforeClose(group uint64, ds storage.DataStorage) error {
+	return h.record("before-close", group)
+}
+
+func TestStorageLifecycleOrder(t *testing.T) {
+	hooks := &testLifecycleHooks{}
+	cfg := &config.Config{}
+	cfg.Storage.ForeachDataStorageFunc = func(cb func(uint64, storage.DataStorage)) {
+		for _, g := range []uint64{2, 0, 1} {
+			cb(g, nil)
+		}
+	}
+
+	l := newStorageLifecycle(log.GetDefaultZapLogger(), cfg)
+	l.beforeOpen()
+	l.afterOpen()
+	l.beforeClose()
+
+	cfg.Customize.CustomStorageLifecycleHooksFactory = func(group uint64) storage.LifecycleHooks {
+		if group == 1 {
+			return nil
+		}
+		return hooks
+	}
+	l = newStorageLifecycle(log.GetDefaultZapLogger(), cfg)
+	// not opened
+	l.beforeClose()
+	assert.Empty(t, hooks.getEvents())
+
+	l.beforeOpen()
+	l.afterOpen()
+	l.beforeClose()
+	l.beforeClose()
+	assert.Equal(t, []string{"before-open-0", "before-open-2",
+		"after-open-0", "after-open-2",
+		"before-close-2", "before-close-0"}, hooks.getEvents())
+}
+
+func TestStorageLifecycleHooks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode.")
+	}
+
+	defer leaktest.AfterTest(t)()
+
+	var s *store
+	hooks := &testLifecycleHooks{
+		replicas: func() int {
+			n := 0
+			s.forEachReplica(func(pr *replica) bool {
+				if !pr.closed() {
+					n++
+				}
+				return true
+			})
+			return n
+		},
+	}
+	c := NewSingleTestClusterStore(t,
+		WithTestClusterUseDisk(),
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Customize.CustomStorageLifecycleHooksFactory = func(group uint64) storage.LifecycleHooks {
+				return hooks
+			}
+		}))
+	s = c.GetStore(0).(*store)
+	c.Start()
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+
+	c.RestartWithFunc(func() {
+		s = c.GetStore(0).(*store)
+	})
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.Stop()
+
+	// no replica is started before BeforeOpen, the replicas are started before
+	// AfterOpen and all of them are stopped before BeforeClose
+	events := hooks.getEvents()
+	assert.Equal(t, []string{"before-open-0-0", "after-open-1-0", "before-close-0-0",
+		"before-open-0-0", "after-open-1-0", "before-close-0-0"}, events)
+}
@@ -19,7 +19,10 @@
 package raftstore
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -34,6 +37,7 @@ import (
 	putil "github.com/matrixorigin/matrixcube/components/prophet/util"
 	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/logdb"
+	"github.com/matrixorigin/matrixcube/metric"
 	"github.com/matrixorigin/matrixcube/pb/errorpb"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
@@ -41,6 +45,7 @@ import (
 	"github.com/matrixorigin/matrixcube/storage/kv/pebble"
 	"github.com/matrixorigin/matrixcube/transport"
 	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/util/fsync"
 	"go.etcd.io/etcd/raft/v3/raftpb"
 	"go.uber.org/zap"
 )
@@ -72,6 +77,33 @@ type Store interface {
 	DataStorageByGroup(uint64) storage.DataStorage
 	// MaybeLeader returns the shard replica maybe leader
 	MaybeLeader(uint64) bool
+	// SplitShard splits the shard by the split keys, e.g. to pre-split the shard
+	// before the bulk load. The local replica must be the leader, the keys must
+	// be in ascending order and inside the shard range. The split is done
+	// asynchronously, the new shards can be found in the router once it's done.
+	SplitShard(shardID uint64, splitKeys [][]byte) error
+	// SetShardReadOnly marks the shard read-only or writable by a permanent gate
+	// blocking the writes. The writes of the read-only shard are rejected with
+	// the non-retryable ShardWriteDisabled error, the reads and the admin
+	// commands, e.g. the config changes, are still served. The local replica
+	// must be the leader, the gate is updated asynchronously and can be found in
+	// the shard metadata once it's applied.
+	SetShardReadOnly(shardID uint64, readOnly bool) error
+	// TransferLeader transfers the leadership of the shard to the target voter
+	// replica. The local replica must be the leader, the transfer is done
+	// asynchronously and it may fail, e.g. the target can't catch up the log.
+	TransferLeader(shardID uint64, targetReplicaID uint64) error
+	// Drain transfers all the leaders of the local replicas to the other stores
+	// and stops accepting the new replicas, so the store can be restarted
+	// without the write unavailability. The prophet stops scheduling the leaders
+	// and the replicas to the store. It returns once no leader is left, or the
+	// context is done. The store is draining until it's restarted.
+	Drain(ctx context.Context) error
+	// ExportShard writes the data of the local replica of the shard to the
+	// writer in the format of the options, and returns the number of the
+	// exported key-value pairs. The data storage must implement the
+	// storage.Exporter.
+	ExportShard(shardID uint64, w io.Writer, opts storage.ExportOptions) (uint64, error)
 	// MustAllocID returns an uint64 id, panic if it has an error
 	MustAllocID() uint64
 	// Prophet return current prophet instance
@@ -83,6 +115,36 @@ type Store interface {
 	CreateShardPool(...metapb.ShardPoolJobMeta) (ShardsPool, error)
 	// GetShardPool returns `ShardsPool`, nil if `CreateShardPool` not completed
 	GetShardPool() ShardsPool
+	// GetRequestTrace returns the trace of the recent request with the id, nil
+	// if the request tracer is not enabled or the trace has been evicted
+	GetRequestTrace(id []byte) []RequestTraceEvent
+	// GetRaftStatuses returns the raft status of all local replicas, ordered by
+	// shard id. The replicas which do not respond in time are skipped.
+	GetRaftStatuses() []ReplicaRaftStatus
+	// GetRaftLogStats returns the breakdown of the raft log entries of the local
+	// replica by entry type, to see what is filling the logdb.
+	GetRaftLogStats(shardID uint64) (RaftLogStats, error)
+	// GetTopApplyCPUShards returns the top n local replicas ordered by the time
+	// spent by their apply loops in the last sampling window, all replicas with
+	// applied entries in the window are returned if n <= 0.
+	GetTopApplyCPUShards(n int) []ShardApplyCPU
+	// CompactLogs compacts the raft logs of the local leader replicas whose
+	// uncompacted raft logs exceed the threshold, to recover the disk space.
+	CompactLogs(ctx context.Context, opts CompactLogsOptions) CompactLogsReport
+	// GetClusterSetting returns the value of the cluster-wide setting which is
+	// put by the prophet client, false if the setting is not found
+	GetClusterSetting(key string) (string, bool)
+	// GetConfigChangeHistory returns the membership changes applied to the local
+	// replica of the shard, the oldest first. The history is persisted with the
+	// shard metadata, so it's rebuilt by replaying the raft log after restart,
+	// and only the recent changes are kept.
+	GetConfigChangeHistory(shardID uint64) ([]metapb.ConfigChangeRecord, error)
+	// GetPurgeMarkers returns the markers of the purges applied to the local
+	// replica of the shard, the oldest first. Each marker proves the data is
+	// deleted from all the replicas at the index, the backup tooling should
+	// purge the data again after restoring a backup taken before the index.
+	// Only the recent markers are kept in the shard metadata.
+	GetPurgeMarkers(shardID uint64) ([]metapb.PurgeMarker, error)
 }
 
 type store struct {
@@ -103,6 +165,10 @@ type store struct {
 	splitChecker          *splitChecker
 	watcher               prophet.EventWatcher
 	vacuumCleaner         *vacuumCleaner
+	// snapshotGenerator saves the snapshots from the checkpoints of the data
+	// storage, nil in the deterministic mode
+	snapshotGenerator     *snapshotGenerator
+	tombstones            *tombstoneGC
 	createShardsProtector *createShardsProtector
 	keyRanges             sync.Map // group id -> *util.ShardTree
 	replicaRecords        sync.Map // replica id -> metapb.Replica
@@ -112,25 +178,60 @@ type store struct {
 	state    uint32
 	stopOnce sync.Once
 
-	aware   aware.ShardStateAware
-	stopper *syncutil.Stopper
+	aware      aware.ShardStateAware
+	adminAware aware.AdminResultAware
+	stopper    *syncutil.Stopper
 	// the worker pool used to drive all replicas
 	workerPool *workerPool
+	// groupWorkerPools the dedicated worker pools driving the replicas of the
+	// shard groups, group -> pool
+	groupWorkerPools map[uint64]*workerPool
 	// shard pool processor
 	shardPool       *dynamicShardsPool
 	groupController *replicaGroupController
 
 	storageStatsReader storageStatsReader
+	ioScheduler        *ioScheduler
+	batchTuner         *batchTuner
+	tracer             *requestTracer
+	storageLifecycle   *storageLifecycle
+	applyCPUSampler    *applyCPUSampler
+	peerLatencies      *peerLatencyStats
+	settings           *clusterSettings
+	// auditUploader uploads the audit trail to the object storage, nil if the
+	// audit sync is disabled
+	auditUploader *auditUploader
+	// diskPressure is 1 if the store is under the disk pressure
+	diskPressure uint32
+	// diskFailed is 1 if the store failed to write the data storage, see
+	// config.IOErrorConfig
+	diskFailed uint32
+	// draining is 1 if the store is draining
+	draining uint32
+	walSyncPool        *fsync.Pool
+	dataSyncPool       *fsync.Pool
+	debugServer        *http.Server
+	console            *storeConsole
+	// driver drives all replicas in deterministic mode
+	driver *deterministicDriver
 
 	mu struct {
 		sync.RWMutex
 		unavailableShards *roaring64.Bitmap
 	}
+	// crashReporter creates the crash report on panic or fatal, nil if the crash
+	// report is disabled
+	crashReporter *crashReporter
 }
 
 // NewStore returns a raft store
 func NewStore(cfg *config.Config) Store {
 	cfg.Adjust()
+	var reporter *crashReporter
+	if cfg.CrashReport.Enable {
+		reporter = newCrashReporter(cfg.CrashReport, cfg.FS, cfg.Prophet.Name)
+		cfg.Logger = reporter.wrapLogger(cfg.Logger)
+	}
 	kv := pebble.CreateLogDBStorage(cfg.DataPath, cfg.FS, cfg.Logger)
 	logger := cfg.Logger.Named("store").With(zap.String("store", cfg.Prophet.Name))
 	s := &store{
@@ -142,9 +243,27 @@ func NewStore(cfg *config.Config) Store {
 		stopper:               syncutil.NewStopper(),
 		createShardsProtector: newCreateShardsProtector(),
 		groupController:       newReplicaGroupController(),
+		crashReporter:         reporter,
+		settings:              newClusterSettings(logger.Named("settings")),
 	}
 
-	s.vacuumCleaner = newVacuumCleaner(s.vacuum)
+	if cfg.Fsync.Enable {
+		s.walSyncPool = fsync.NewGroupCommitPool(fsync.WAL, cfg.Fsync.WALWorkers,
+			cfg.Fsync.WALQueueSize, cfg.Fsync.WALGroupSize, kv.Sync)
+		s.dataSyncPool = fsync.NewPool(fsync.Data, cfg.Fsync.DataWorkers, cfg.Fsync.DataQueueSize)
+		s.logdb.(*logdb.KVLogDB).SetSyncPool(s.walSyncPool)
+		cfg.Storage.ForeachDataStorageFunc(func(_ uint64, ds storage.DataStorage) {
+			if u, ok := ds.(storage.SyncPoolUser); ok {
+				u.SetSyncPool(s.dataSyncPool)
+			}
+		})
+	}
+	s.setPersistentLogIndexListeners()
+
+	s.vacuumCleaner = newVacuumCleaner(s.vacuum).withLimits(
+		int(cfg.Replication.DestroyWorkerCount),
+		int64(cfg.Replication.DestroyBytesPerSecond))
+	s.tombstones = newTombstoneGC(cfg.Replication.TombstoneGCGracePeriod.Duration)
 	// TODO: make maxWaitToChecker configurable
 	s.splitChecker = newSplitChecker(4, &storeReplicaGetter{s},
 		func(group uint64) storage.Feature {
@@ -152,12 +271,48 @@ func NewStore(cfg *config.Config) Store {
 		}, func(group uint64) splitCheckFunc {
 			return s.cfg.Storage.DataStorageFactory(group).SplitCheck
 		})
+	s.splitChecker.codecGetter = s.cfg.Customize.CustomSplitKeyCodecFactory
+	s.splitChecker.splitPolicyGetter = s.cfg.Customize.CustomSplitCheckPolicyFactory
+	if !s.cfg.Test.Deterministic {
+		s.snapshotGenerator = newSnapshotGenerator(int(s.cfg.Snapshot.GenerateWorkers))
+	}
 	s.workerPool = newWorkerPool(s.logger, s.logdb, &storeReplicaLoader{s}, s.cfg.Worker.RaftEventWorkers)
+	s.workerPool.deterministic = s.cfg.Test.Deterministic
+	// all replicas are driven by the DeterministicDriver in deterministic mode
+	if !s.cfg.Test.Deterministic {
+		s.groupWorkerPools = make(map[uint64]*workerPool)
+		for _, pc := range s.cfg.Worker.GroupPools {
+			p := newWorkerPool(s.logger.With(zap.Uint64s("groups", pc.Groups)),
+				s.logdb, &storeReplicaLoader{s}, pc.Workers)
+			for _, g := range pc.Groups {
+				s.groupWorkerPools[g] = p
+			}
+		}
+	}
+	if reporter != nil {
+		reporter.logger = s.logger.Named("crash-report")
+		reporter.sections = s.crashReportSections
+		s.workerPool.onPanic = reporter.reportPanic
+		for _, p := range s.groupWorkerPools {
+			p.onPanic = reporter.reportPanic
+		}
+	}
+	if s.cfg.Test.Deterministic {
+		s.driver = newDeterministicDriver(s)
+	}
 	s.shardPool = newDynamicShardsPool(cfg, s.logger)
 
 	if s.cfg.Customize.CustomShardStateAwareFactory != nil {
 		s.aware = cfg.Customize.CustomShardStateAwareFactory()
 	}
+	s.adminAware = cfg.Customize.CustomAdminResultAware
+	s.storageLifecycle = newStorageLifecycle(s.logger.Named("storage-lifecycle"), cfg)
+	s.applyCPUSampler = newApplyCPUSampler()
+	s.peerLatencies = newPeerLatencyStats()
+	if cfg.AuditSync.Enable {
+		s.auditUploader = newAuditUploader(cfg.AuditSync,
+			cfg.Customize.CustomObjectStorage, s.logger.Named("audit-sync"))
+	}
 
 	if s.cfg.UseMemoryAsStorage {
 		s.storageStatsReader = newMemoryStorageStatsReader()
@@ -165,6 +320,48 @@ func NewStore(cfg *config.Config) Store {
 		s.storageStatsReader = newDiskStorageStatsReader(s.cfg.DataPath)
 	}
 
+	dataStorages := make(map[uint64]storage.DataStorage)
+	cfg.Storage.ForeachDataStorageFunc(func(group uint64, ds storage.DataStorage) {
+		dataStorages[group] = ds
+	})
+	s.ioScheduler = newIOScheduler(s.logger.Named("io-scheduler"), cfg.QoS,
+		func() (uint64, uint64, error) {
+			rates, err := util.IORates(cfg.DataPath)
+			if err != nil {
+				return 0, 0, err
+			}
+			var read, write uint64
+			for _, v := range rates {
+				read += v.ReadBytes
+				write += v.WriteBytes
+			}
+			return read, write, nil
+		},
+		func(group uint64) (uint64, uint64) {
+			ds, ok := dataStorages[group]
+			if !ok {
+				return 0, 0
+			}
+			st := ds.Stats()
+			return st.CompactionReadBytes, st.CompactionWrittenBytes
+		})
+	s.batchTuner = newBatchTuner(s.logger.Named("batch-tuner"), cfg.AutoTune)
+	cfg.Storage.ForeachDataStorageFunc(func(group uint64, _ storage.DataStorage) {
+		s.ioScheduler.addGroup(group)
+		applyBatchSize := cfg.GetMaxApplyBatchSize(group)
+		if applyBatchSize == 0 {
+			applyBatchSize = uint64(cfg.Raft.MaxSizePerMsg)
+		}
+		s.batchTuner.addGroup(group, batchTuning{
+			writeBatchSize: uint64(cfg.Raft.MaxEntryBytes),
+			applyBatchSize: applyBatchSize,
+		})
+	})
+
+	if cfg.RequestTrace.Enable {
+		s.tracer = newRequestTracer(cfg.RequestTrace.Capacity)
+	}
+
 	s.mu.unavailableShards = roaring64.New()
 	return s
 }
@@ -173,9 +370,31 @@ func (s *store) GetConfig() *config.Config {
 	return s.cfg
 }
 
+// getWorkerPool returns the worker pool driving the replicas of the group
+func (s *store) getWorkerPool(group uint64) *workerPool {
+	if p, ok := s.groupWorkerPools[group]; ok {
+		return p
+	}
+	return s.workerPool
+}
+
+// forEachGroupWorkerPool calls fn once for each dedicated worker pool
+func (s *store) forEachGroupWorkerPool(fn func(*workerPool)) {
+	visited := make(map[*workerPool]struct{})
+	for _, p := range s.groupWorkerPools {
+		if _, ok := visited[p]; !ok {
+			visited[p] = struct{}{}
+			fn(p)
+		}
+	}
+}
+
 func (s *store) Start() {
 	s.logger.Info("begin to start raftstore")
 	s.workerPool.start()
+	s.forEachGroupWorkerPool(func(p *workerPool) {
+		p.start()
+	})
 	s.logger.Info("worker pool started",
 		s.storeField())
 
@@ -187,18 +406,31 @@ func (s *store) Start() {
 	s.logger.Info("split checker started",
 		s.storeField())
 
+	if s.snapshotGenerator != nil {
+		s.snapshotGenerator.start()
+		s.logger.Info("snapshot generator started",
+			s.storeField())
+	}
+
 	s.startProphet()
 	s.logger.Info("prophet started",
 		s.storeField())
 
+	s.removeSnapshotStagingDirs()
+	s.logger.Info("snapshot staging dirs removed",
+		s.storeField())
+
 	s.createTransport()
 	s.logger.Info("raft internal transport created",
 		s.storeField())
 
+	s.storageLifecycle.beforeOpen()
 	s.startShards()
 	s.logger.Info("shards started",
 		s.storeField())
 
+	s.storageLifecycle.afterOpen()
+
 	s.startTransport()
 	s.logger.Info("raft internal transport started",
 		s.storeField(),
@@ -208,6 +440,9 @@ func (s *store) Start() {
 	s.logger.Info("shard timer based tasks started",
 		s.storeField())
 
+	s.startAuditSync()
+	s.startDiskPressureCheck()
+
 	s.startRouter()
 	s.logger.Info("router started",
 		s.storeField())
@@ -217,7 +452,10 @@ func (s *store) Start() {
 		s.storeField(),
 		log.ListenAddressField(s.cfg.ClientAddr))
 
-	s.handleStoreHeartbeatTask(time.Now())
+	s.startDebugServer()
+	s.startConsole()
+
+	s.handleStoreHeartbeatTask(s.now())
 }
 
 func (s *store) Stop() {
@@ -227,6 +465,9 @@ func (s *store) Stop() {
 		s.logger.Info("begin to stop raftstore",
 			s.storeField())
 
+		s.stopDebugServer()
+		s.stopConsole()
+
 		s.splitChecker.close()
 		s.logger.Info("split checker closed",
 			s.storeField())
@@ -259,6 +500,9 @@ func (s *store) Stop() {
 			s.storeField())
 		// stop the worker pool
 		s.workerPool.close()
+		s.forEachGroupWorkerPool(func(p *workerPool) {
+			p.close()
+		})
 		s.logger.Info("worker pool stopped",
 			s.storeField())
 		// worker pool stopped, it's now safe to check whether all replicas have been
@@ -272,6 +516,15 @@ func (s *store) Stop() {
 		s.logger.Info("shards stopped",
 			s.storeField())
 
+		// the checkpoints must be released before the data storage is closed
+		if s.snapshotGenerator != nil {
+			s.snapshotGenerator.close()
+			s.logger.Info("snapshot generator closed",
+				s.storeField())
+		}
+
+		s.storageLifecycle.beforeClose()
+
 		s.stopper.Stop()
 		s.logger.Info("stopper stopped",
 			s.storeField())
@@ -284,6 +537,12 @@ func (s *store) Stop() {
 		s.logger.Info("proxy stopped",
 			s.storeField())
 
+		// the raft logs and the data are no longer written by the store
+		s.walSyncPool.Close()
+		s.dataSyncPool.Close()
+		s.logger.Info("fsync pools closed",
+			s.storeField())
+
 		s.kvStorage.Close()
 		s.logger.Info("kvStorage closed")
 	})
@@ -317,6 +576,7 @@ func (s *store) startRouter() {
 		withRemoveShardHandle(func(id uint64) {
 			s.destroyReplica(id, true, true, "remove by event")
 		}).
+		withSettingHandle(s.settings.update).
 		build(watcher.GetNotify())
 	if err != nil {
 		s.logger.Fatal("fail to create router",
@@ -338,6 +598,10 @@ func (s *store) Meta() metapb.Store {
 	return s.meta
 }
 
+func (s *store) GetRequestTrace(id []byte) []RequestTraceEvent {
+	return s.tracer.get(id)
+}
+
 func (s *store) OnRequest(req rpcpb.Request) error {
 	return s.OnRequestWithCB(req, s.shardsProxy.OnResponse)
 }
@@ -347,6 +611,7 @@ func (s *store) OnRequestWithCB(req rpcpb.Request, cb func(resp rpcpb.ResponseBa
 		ce.Write(log.RequestIDField(req.ID),
 			s.storeField())
 	}
+	cb = s.tracer.wrap(req, cb)
 
 	var pr *replica
 	var err error
@@ -369,6 +634,18 @@ func (s *store) OnRequestWithCB(req rpcpb.Request, cb func(resp rpcpb.ResponseBa
 			return nil
 		}
 	} else {
+		if err := checkKeyGroup(s.cfg.Customize.CustomKeyRouter, req); err != nil {
+			if ce := s.logger.Check(zap.DebugLevel, "fail to handle request"); ce != nil {
+				ce.Write(log.RequestIDField(req.ID),
+					s.storeField(),
+					log.HexField("key", req.Key),
+					log.ReasonField("group not match"))
+			}
+
+			respError(*err, req, cb)
+			return nil
+		}
+
 		pr, err = s.selectShard(req.Group, req.Key)
 		if err != nil {
 			if ce := s.logger.Check(zap.DebugLevel, "fail to handle request"); ce != nil {
@@ -387,6 +664,11 @@ func (s *store) OnRequestWithCB(req rpcpb.Request, cb func(resp rpcpb.ResponseBa
 		}
 	}
 
+	if err := checkColumnFamily(pr.feature, req); err != nil {
+		respOtherError(err, req, cb)
+		return nil
+	}
+
 	if req.ReplicaSelectPolicy == rpcpb.SelectLeaseHolder {
 		if req.Lease == nil {
 			s.logger.Fatal("missing lease when use SelectLeaseHolder")
@@ -408,6 +690,10 @@ func (s *store) OnRequestWithCB(req rpcpb.Request, cb func(resp rpcpb.ResponseBa
 			respLeaseReadNotReady(req, cb)
 			return nil
 		}
+		if err := checkShardGate(req.Type, pr.getShard()); err != nil {
+			respError(*err, req, cb)
+			return nil
+		}
 
 		if err := s.cfg.Customize.CustomLeaseHolderRequestHandler(pr.getShard(),
 			*req.Lease,
@@ -443,6 +729,29 @@ func (s *store) MaybeLeader(shard uint64) bool {
 	return nil != s.getReplica(shard, true)
 }
 
+func (s *store) SplitShard(shardID uint64, splitKeys [][]byte) error {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return errShardNotFound
+	}
+	return pr.splitShard(splitKeys)
+}
+
+func (s *store) SetShardReadOnly(shardID uint64, readOnly bool) error {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return errShardNotFound
+	}
+	if !pr.isLeader() {
+		return errNotLeader
+	}
+	gate := pr.getShard().Gate
+	gate.DisableWrite = readOnly
+	gate.Permanent = readOnly
+	pr.addAdminRequest(rpcpb.CmdUpdateGate, &rpcpb.UpdateGateRequest{Gate: gate})
+	return nil
+}
+
 func (s *store) MustAllocID() uint64 {
 	for {
 		id, err := s.pd.GetClient().AllocID()
@@ -471,9 +780,14 @@ func (s *store) startProphet() {
 }
 
 func (s *store) createTransport() {
-	s.trans = transport.NewTransport(s.logger,
+	trans := transport.NewTransport(s.logger,
 		s.cfg.RaftAddr, s.Meta().ID, s.handle, s.unreachable, s.snapshotStatus,
 		s.GetReplicaSnapshotDir, s.containerResolver, s.cfg.FS)
+	trans.SetSnapshotPriority(s.getSnapshotPriority)
+	trans.EnableHandshake(s.pd.GetClusterID(), transport.BuildVersion())
+	trans.SetSnapshotRateLimit(uint64(s.cfg.Snapshot.SendBytesPerSecond))
+	s.registerClusterSettingHandlers(trans.SetSnapshotRateLimit)
+	s.trans = trans
 	if s.cfg.Customize.CustomWrapNewTransport != nil {
 		s.trans = s.cfg.Customize.CustomWrapNewTransport(s.trans)
 	}
@@ -497,6 +811,7 @@ func (s *store) startShards() {
 	var tombstones []metapb.ShardLocalState
 	shards := make(map[uint64]metapb.ShardLocalState)
 	localDestroyings := make(map[uint64]metapb.ShardMetadata)
+	lastWrites := make(map[uint64]uint64)
 	confirmShards := roaring64.New()
 	s.cfg.Storage.ForeachDataStorageFunc(func(group uint64, ds storage.DataStorage) {
 		initStates, err := ds.GetInitialStates()
@@ -537,6 +852,14 @@ func (s *store) startShards() {
 				confirmShards.Add(sls.Shard.ID)
 			}
 
+			persistentLogIndex, err := ds.GetPersistentLogIndex(sls.Shard.ID)
+			if err != nil {
+				s.logger.Fatal("fail to get persistent log index",
+					s.storeField(),
+					log.ShardIDField(sls.Shard.ID),
+					zap.Error(err))
+			}
+			lastWrites[sls.Shard.ID] = restoreLastWriteIndex(metadata, persistentLogIndex)
 			shards[sls.Shard.ID] = sls
 		}
 	})
@@ -563,9 +886,17 @@ func (s *store) startShards() {
 
 	var readyBootstrapShards []Shard
 	leases := make(map[uint64]*metapb.EpochLease)
+	configChanges := make(map[uint64][]metapb.ConfigChangeRecord)
+	purges := make(map[uint64][]metapb.PurgeMarker)
+	appliedAdmins := make(map[uint64][]metapb.AppliedAdminRecord)
+	dictionaries := make(map[uint64][]metapb.CompressionDictionary)
 	for _, sls := range shards {
 		readyBootstrapShards = append(readyBootstrapShards, sls.Shard)
 		leases[sls.Shard.ID] = sls.Lease
+		configChanges[sls.Shard.ID] = sls.ConfigChanges
+		purges[sls.Shard.ID] = sls.Purges
+		appliedAdmins[sls.Shard.ID] = sls.AppliedAdmins
+		dictionaries[sls.Shard.ID] = sls.Dictionaries
 	}
 
 	newReplicaCreator(s).
@@ -573,6 +904,11 @@ func (s *store) startShards() {
 		withStartReplica(true,
 			func(r *replica) {
 				r.sm.updateLease(leases[r.shardID])
+				r.sm.updateConfigChanges(configChanges[r.shardID])
+				r.sm.updatePurgeMarkers(purges[r.shardID])
+				r.sm.updateAppliedAdmins(appliedAdmins[r.shardID])
+				r.sm.updateDictionaries(dictionaries[r.shardID])
+				r.sm.lastWriteIndex = lastWrites[r.shardID]
 			},
 			func(r *replica) {
 				if metadata, ok := localDestroyings[r.shardID]; ok {
@@ -603,7 +939,7 @@ func (s *store) removeReplica(shard Shard) {
 }
 
 func (s *store) startShardsProxy() {
-	maxBodySize := int(s.cfg.Raft.MaxEntryBytes) * 2
+	maxBodySize := int(s.cfg.Raft.GetMaxProposalBytes()) * 2
 	rpc := newProxyRPC(s.logger.Named("proxy.rpc").With(s.storeField()),
 		s.cfg.ClientAddr,
 		maxBodySize,
@@ -615,6 +951,7 @@ func (s *store) startShardsProxy() {
 		withBackendFactory(newBackendFactory(l, s)).
 		withMaxBodySize(maxBodySize).
 		withRPC(rpc).
+		withReadCache(s.cfg.ReadCache).
 		build(s.router)
 	if err != nil {
 		s.logger.Fatal("fail to create shards proxy", zap.Error(err))
@@ -639,6 +976,25 @@ func (s *store) getReplicaRecord(id uint64) (Replica, bool) {
 	return value.(Replica), true
 }
 
+// now returns the logical time of the DeterministicDriver in deterministic mode,
+// otherwise the wall clock time.
+func (s *store) now() time.Time {
+	if s != nil && s.driver != nil {
+		return s.driver.Now()
+	}
+	return time.Now()
+}
+
+// afterFunc calls fn after the duration d, the timer is fired by the
+// DeterministicDriver in deterministic mode.
+func (s *store) afterFunc(d time.Duration, fn func()) {
+	if s != nil && s.driver != nil {
+		s.driver.afterFunc(d, fn)
+		return
+	}
+	time.AfterFunc(d, fn)
+}
+
 func (s *store) forEachReplica(consumerFunc func(*replica) bool) {
 	s.replicas.Range(func(key, value interface{}) bool {
 		return consumerFunc(value.(*replica))
@@ -705,7 +1061,7 @@ func (s *store) validateShard(req rpcpb.RequestBatch) (errorpb.Error, bool) {
 		}, true
 	}
 
-	if !pr.isLeader() {
+	if !pr.isLeader() && !isFollowerReadBatch(req) {
 		err := new(errorpb.NotLeader)
 		err.ShardID = shardID
 		err.Leader, _ = s.getReplicaRecord(pr.getLeaderReplicaID())
@@ -740,6 +1096,12 @@ func (s *store) validateShard(req rpcpb.RequestBatch) (errorpb.Error, bool) {
 		}, true
 	}
 
+	if !req.IsAdmin() && len(req.Requests) > 0 {
+		if err := checkShardGate(req.Requests[0].Type, shard); err != nil {
+			return *err, true
+		}
+	}
+
 	return errorpb.Error{}, false
 }
 
@@ -749,7 +1111,8 @@ func checkEpoch(shard Shard, req rpcpb.RequestBatch) bool {
 
 	if req.IsAdmin() {
 		switch req.GetAdminCmdType() {
-		case rpcpb.CmdBatchSplit:
+		case rpcpb.CmdBatchSplit, rpcpb.CmdSplitShard, rpcpb.CmdDeleteRange, rpcpb.CmdBarrier,
+			rpcpb.CmdComputeHash, rpcpb.CmdVerifyHash, rpcpb.CmdPurge:
 			checkVer = true
 		case rpcpb.CmdConfigChange:
 			checkConfVer = true
@@ -943,7 +1306,7 @@ func (s *store) getStoreHeartbeat(last time.Time) (rpcpb.StoreHeartbeatReq, erro
 	stats := metapb.StoreStats{}
 	stats.StoreID = s.Meta().ID
 
-	v, err := s.storageStatsReader.stats()
+	v, err := s.getStorageStats()
 	if err != nil {
 		s.logger.Error("fail to get storage capacity status",
 			s.storeField(),
@@ -954,12 +1317,6 @@ func (s *store) getStoreHeartbeat(last time.Time) (rpcpb.StoreHeartbeatReq, erro
 	stats.UsedSize = v.usedSize
 	stats.Available = v.available
 
-	if s.cfg.Capacity > 0 {
-		stats.Capacity = uint64(s.cfg.Capacity)
-		// If `Capacity` set, calculate `Available` using `Capacity`
-		stats.Available = stats.Capacity - stats.UsedSize
-	}
-
 	// cpu usages
 	usages, err := util.CPUUsages()
 	if err != nil {
@@ -1001,8 +1358,18 @@ func (s *store) getStoreHeartbeat(last time.Time) (rpcpb.StoreHeartbeatReq, erro
 		//}
 
 		stats.ShardCount++
+		if pr.quorumLoss.isLost() {
+			stats.QuorumLostShards = append(stats.QuorumLostShards, pr.shardID)
+		}
+		stats.ApplyBacklog += atomic.LoadUint64(&pr.applyBacklog)
+		if v := pr.sm.consistency.getInconsistent(); v != nil {
+			stats.InconsistentShards = append(stats.InconsistentShards, *v)
+		}
 		return true
 	})
+	stats.ApplyLatency = uint64(s.applyCPUSampler.avgEntryLatency())
+	stats.PeerLatencies = s.peerLatencies.flush()
+	metric.SetQuorumLostShardsOnStore(len(stats.QuorumLostShards))
 	// FIXME: provide this count from the new implementation
 	// stats.ReceivingSnapCount = s.snapshotManager.ReceiveSnapCount()
 	stats.SendingSnapCount = s.trans.SendingSnapshotCount()
@@ -1016,8 +1383,10 @@ func (s *store) getStoreHeartbeat(last time.Time) (rpcpb.StoreHeartbeatReq, erro
 		stats.ReadBytes += st.ReadBytes
 	})
 
-	// TODO: is busy
-	stats.IsBusy = false
+	// prophet stops placing new replicas on the busy stores
+	stats.IsBusy = s.isUnderDiskPressure()
+	// prophet moves the replicas away from the disk failed stores
+	stats.DiskFailed = s.isDiskFailed()
 	stats.Interval = &metapb.TimeInterval{
 		Start: uint64(last.Unix()),
 		End:   uint64(time.Now().Unix()),
@@ -1076,6 +1445,7 @@ func (s *store) doShardHeartbeatRsp(rsp rpcpb.ShardHeartbeatRsp) {
 		pr.addAdminRequest(rpcpb.CmdConfigChange, &rpcpb.ConfigChangeRequest{
 			ChangeType: rsp.ConfigChange.ChangeType,
 			Replica:    rsp.ConfigChange.Replica,
+			Initiator:  rsp.ConfigChange.Initiator,
 		})
 	} else if rsp.ConfigChangeV2 != nil {
 		s.logger.Info("send conf change request",
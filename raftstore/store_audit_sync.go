@@ -0,0 +1,309 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"go.etcd.io/etcd/raft/v3/raftpb"
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/metric"
+)
+
+const (
+	auditRaftLog  = "raft-log"
+	auditMetadata = "metadata"
+
+	auditUploaded = "uploaded"
+	auditFailed   = "failed"
+	auditDropped  = "dropped"
+
+	// maxAuditSegmentBytes the max bytes of the entries in a raft log segment,
+	// the compacted entries are split into multiple segments if exceeded
+	maxAuditSegmentBytes = 64 * 1024 * 1024
+)
+
+// auditObject is an object waiting for upload
+type auditObject struct {
+	tp   string
+	key  string
+	data []byte
+}
+
+// AuditShardMetadata is the metadata of a shard in the metadata snapshot
+type AuditShardMetadata struct {
+	Shard        Shard  `json:"shard"`
+	ReplicaID    uint64 `json:"replica-id"`
+	AppliedIndex uint64 `json:"applied-index"`
+	AppliedTerm  uint64 `json:"applied-term"`
+	Leader       bool   `json:"leader"`
+}
+
+// AuditMetadataSnapshot is the snapshot of the metadata of all the shards of a
+// store uploaded by the audit sync
+type AuditMetadataSnapshot struct {
+	StoreID uint64               `json:"store-id"`
+	Time    time.Time            `json:"time"`
+	Shards  []AuditShardMetadata `json:"shards"`
+}
+
+// auditUploader uploads the sealed raft log segments and the metadata
+// snapshots to the object storage in the background. The uploads are best
+// effort, the objects are dropped if the queue is full.
+type auditUploader struct {
+	cfg     config.AuditSyncConfig
+	storage config.ObjectStorage
+	logger  *zap.Logger
+	queue   chan auditObject
+}
+
+func newAuditUploader(cfg config.AuditSyncConfig, storage config.ObjectStorage,
+	logger *zap.Logger) *auditUploader {
+	return &auditUploader{
+		cfg:     cfg,
+		storage: storage,
+		logger:  log.Adjust(logger),
+		queue:   make(chan auditObject, cfg.QueueSize),
+	}
+}
+
+// add adds the object to the queue, returns false if the queue is full
+func (u *auditUploader) add(obj auditObject) bool {
+	select {
+	case u.queue <- obj:
+		return true
+	default:
+		metric.IncAuditSync(obj.tp, auditDropped)
+		u.logger.Warn("audit sync queue is full, object dropped",
+			zap.String("key", obj.key))
+		return false
+	}
+}
+
+func (u *auditUploader) upload(obj auditObject) {
+	ctx, cancel := context.WithTimeout(context.Background(), u.cfg.UploadTimeout.Duration)
+	defer cancel()
+	if err := u.storage.Put(ctx, obj.key, obj.data); err != nil {
+		metric.IncAuditSync(obj.tp, auditFailed)
+		u.logger.Error("fail to upload audit object",
+			zap.String("key", obj.key),
+			zap.Error(err))
+		return
+	}
+	metric.IncAuditSync(obj.tp, auditUploaded)
+}
+
+// removeExpired removes the objects with the prefix which are older than the
+// retention
+func (u *auditUploader) removeExpired(prefix string, now time.Time) error {
+	if u.cfg.Retention.Duration <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), u.cfg.UploadTimeout.Duration)
+	defer cancel()
+	objects, err := u.storage.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	expired := now.Add(-u.cfg.Retention.Duration)
+	removed := 0
+	for _, obj := range objects {
+		if !obj.ModTime.Before(expired) {
+			continue
+		}
+		if err := u.storage.Delete(ctx, obj.Key); err != nil {
+			return err
+		}
+		removed++
+	}
+	if removed > 0 {
+		u.logger.Info("expired audit objects removed",
+			zap.String("prefix", prefix),
+			zap.Int("removed", removed))
+	}
+	return nil
+}
+
+// auditLogSegmentKey returns the key of the raft log segment, the indexes are
+// padded so the segments of a shard are listed in order.
+func auditLogSegmentKey(prefix string, shardID, first, last uint64) string {
+	return path.Join(prefix, auditRaftLog, fmt.Sprintf("%020d", shardID),
+		fmt.Sprintf("%020d-%020d", first, last))
+}
+
+func auditMetadataKey(prefix string, storeID uint64, now time.Time) string {
+	return path.Join(prefix, auditMetadata, fmt.Sprintf("%020d", storeID),
+		fmt.Sprintf("%020d.json", now.UnixNano()))
+}
+
+// encodeAuditLogSegment encodes the entries as the sequence of the uvarint
+// length prefixed raftpb.Entry
+func encodeAuditLogSegment(entries []raftpb.Entry) []byte {
+	size := 0
+	for idx := range entries {
+		size += binary.MaxVarintLen64 + entries[idx].Size()
+	}
+	data := make([]byte, size)
+	offset := 0
+	for idx := range entries {
+		offset += binary.PutUvarint(data[offset:], uint64(entries[idx].Size()))
+		n, err := entries[idx].MarshalTo(data[offset:])
+		if err != nil {
+			panic(err)
+		}
+		offset += n
+	}
+	return data[:offset]
+}
+
+// DecodeAuditLogSegment decodes the raft log segment uploaded by the audit
+// sync, it's used by the recovery tools.
+func DecodeAuditLogSegment(data []byte) ([]raftpb.Entry, error) {
+	var entries []raftpb.Entry
+	for len(data) > 0 {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < size {
+			return nil, fmt.Errorf("corrupted audit log segment")
+		}
+		data = data[n:]
+		var e raftpb.Entry
+		if err := e.Unmarshal(data[:size]); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+		data = data[size:]
+	}
+	return entries, nil
+}
+
+// sealLogSegments uploads the entries up to the index before they are removed
+// by the log compaction. Only the leader uploads, so the segments are not
+// uploaded by every replica.
+func (pr *replica) sealLogSegments(index uint64) {
+	if pr.store == nil || pr.store.auditUploader == nil || !pr.isLeader() {
+		return
+	}
+
+	u := pr.store.auditUploader
+	first, err := pr.lr.FirstIndex()
+	if err != nil {
+		return
+	}
+	for first <= index {
+		entries, err := pr.lr.Entries(first, index+1, maxAuditSegmentBytes)
+		if err != nil {
+			pr.logger.Error("fail to read the raft log segment for audit sync",
+				zap.Uint64("first", first),
+				log.IndexField(index),
+				zap.Error(err))
+			return
+		}
+		last := entries[len(entries)-1].Index
+		u.add(auditObject{
+			tp:   auditRaftLog,
+			key:  auditLogSegmentKey(u.cfg.Prefix, pr.shardID, first, last),
+			data: encodeAuditLogSegment(entries),
+		})
+		first = last + 1
+	}
+}
+
+// getAuditMetadataSnapshot returns the metadata of all the local replicas
+func (s *store) getAuditMetadataSnapshot(now time.Time) AuditMetadataSnapshot {
+	snapshot := AuditMetadataSnapshot{StoreID: s.Meta().ID, Time: now}
+	s.forEachReplica(func(pr *replica) bool {
+		index, term := pr.sm.getAppliedIndexTerm()
+		snapshot.Shards = append(snapshot.Shards, AuditShardMetadata{
+			Shard:        pr.getShard(),
+			ReplicaID:    pr.replicaID,
+			AppliedIndex: index,
+			AppliedTerm:  term,
+			Leader:       pr.isLeader(),
+		})
+		return true
+	})
+	sort.Slice(snapshot.Shards, func(i, j int) bool {
+		return snapshot.Shards[i].Shard.ID < snapshot.Shards[j].Shard.ID
+	})
+	return snapshot
+}
+
+// handleAuditMetadataTask uploads the metadata snapshot of the store and
+// removes the expired objects
+func (s *store) handleAuditMetadataTask(now time.Time) {
+	u := s.auditUploader
+	snapshot := s.getAuditMetadataSnapshot(now)
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		s.logger.Error("fail to encode audit metadata snapshot",
+			s.storeField(),
+			zap.Error(err))
+		return
+	}
+	u.upload(auditObject{
+		tp:   auditMetadata,
+		key:  auditMetadataKey(u.cfg.Prefix, snapshot.StoreID, now),
+		data: data,
+	})
+
+	// the raft log segments are uploaded by the leaders on all stores, the
+	// retention is applied by every store as the segments are idempotent
+	for _, prefix := range []string{
+		path.Join(u.cfg.Prefix, auditMetadata, fmt.Sprintf("%020d", snapshot.StoreID)),
+		path.Join(u.cfg.Prefix, auditRaftLog),
+	} {
+		if err := u.removeExpired(prefix, now); err != nil {
+			s.logger.Error("fail to remove expired audit objects",
+				s.storeField(),
+				zap.String("prefix", prefix),
+				zap.Error(err))
+		}
+	}
+}
+
+func (s *store) startAuditSync() {
+	u := s.auditUploader
+	if u == nil {
+		return
+	}
+
+	s.stopper.RunWorker(func() {
+		ticker := time.NewTicker(u.cfg.MetadataInterval.Duration)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stopper.ShouldStop():
+				s.logger.Info("audit sync stopped",
+					s.storeField(),
+					zap.Int("dropped", len(u.queue)))
+				return
+			case obj := <-u.queue:
+				u.upload(obj)
+			case now := <-ticker.C:
+				s.handleAuditMetadataTask(now)
+			}
+		}
+	})
+}
@@ -0,0 +1,210 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+type testObjectStorage struct {
+	sync.Mutex
+	objects map[string][]byte
+	modTime map[string]time.Time
+}
+
+func newTestObjectStorage() *testObjectStorage {
+	return &testObjectStorage{
+		objects: make(map[string][]byte),
+		modTime: make(map[string]time.Time),
+	}
+}
+
+func (s *testObjectStorage) Put(ctx context.Context, key string, data []byte) error {
+	s.Lock()
+	defer s.Unlock()
+	s.objects[key] = data
+	s.modTime[key] = time.Now()
+	return nil
+}
+
+func (s *testObjectStorage) List(ctx context.Context, prefix string) ([]config.ObjectInfo, error) {
+	s.Lock()
+	defer s.Unlock()
+	var objects []config.ObjectInfo
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, config.ObjectInfo{Key: key, ModTime: s.modTime[key]})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+func (s *testObjectStorage) Delete(ctx context.Context, key string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.objects, key)
+	delete(s.modTime, key)
+	return nil
+}
+
+func (s *testObjectStorage) get(key string) ([]byte, bool) {
+	s.Lock()
+	defer s.Unlock()
+	data, ok := s.objects[key]
+	return data, ok
+}
+
+func TestAuditLogSegmentCodec(t *testing.T) {
+	entries := []raftpb.Entry{
+		{Index: 1, Term: 1},
+		{Index: 2, Term: 1, Data: []byte("hello")},
+		{Index: 3, Term: 2, Type: raftpb.EntryConfChange, Data: []byte("cc")},
+	}
+	data := encodeAuditLogSegment(entries)
+	decoded, err := DecodeAuditLogSegment(data)
+	require.NoError(t, err)
+	assert.Equal(t, entries, decoded)
+
+	decoded, err = DecodeAuditLogSegment(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, decoded)
+
+	_, err = DecodeAuditLogSegment(data[:len(data)-1])
+	assert.Error(t, err)
+}
+
+func TestAuditUploader(t *testing.T) {
+	storage := newTestObjectStorage()
+	cfg := config.AuditSyncConfig{QueueSize: 1}
+	cfg.UploadTimeout.Duration = time.Second
+	u := newAuditUploader(cfg, storage, nil)
+
+	assert.True(t, u.add(auditObject{tp: auditRaftLog, key: "p/1", data: []byte("1")}))
+	assert.False(t, u.add(auditObject{tp: auditRaftLog, key: "p/2", data: []byte("2")}))
+	u.upload(<-u.queue)
+	data, ok := storage.get("p/1")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), data)
+
+	// no retention
+	now := time.Now()
+	require.NoError(t, u.removeExpired("p", now.Add(time.Hour)))
+	_, ok = storage.get("p/1")
+	assert.True(t, ok)
+
+	u.cfg.Retention.Duration = time.Minute
+	require.NoError(t, storage.Put(context.Background(), "q/1", []byte("1")))
+	require.NoError(t, u.removeExpired("p", now))
+	_, ok = storage.get("p/1")
+	assert.True(t, ok)
+	require.NoError(t, u.removeExpired("p", now.Add(time.Hour)))
+	_, ok = storage.get("p/1")
+	assert.False(t, ok)
+	// other prefixes are not touched
+	_, ok = storage.get("q/1")
+	assert.True(t, ok)
+
+	assert.Equal(t, "p/raft-log/00000000000000000001/00000000000000000002-00000000000000000003",
+		auditLogSegmentKey("p", 1, 2, 3))
+}
+
+func TestAuditSync(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	storage := newTestObjectStorage()
+	c := NewSingleTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.AuditSync.Enable = true
+			cfg.AuditSync.Prefix = "audit"
+			cfg.AuditSync.MetadataInterval.Duration = 100 * time.Millisecond
+			cfg.Customize.CustomObjectStorage = storage
+		}))
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+	shard := c.GetShardByIndex(0, 0)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, kv.Set(fmt.Sprintf("k%d", i), "v", testWaitTimeout))
+	}
+
+	s := c.GetStore(0).(*store)
+	pr := s.getReplica(shard.ID, false)
+	require.NotNil(t, pr)
+	require.NoError(t, pr.sm.dataStorage.Sync([]uint64{shard.ID}))
+	firstIndex, err := pr.lr.FirstIndex()
+	require.NoError(t, err)
+
+	report := s.CompactLogs(context.Background(), CompactLogsOptions{Threshold: 1})
+	require.Equal(t, 1, report.Compacted, "%+v", report)
+	compactIndex := report.Shards[0].CompactIndex
+
+	key := auditLogSegmentKey("audit", shard.ID, firstIndex, compactIndex)
+	var data []byte
+	for i := 0; ; i++ {
+		var ok bool
+		if data, ok = storage.get(key); ok {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("failed to upload the raft log segment %s", key)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	entries, err := DecodeAuditLogSegment(data)
+	require.NoError(t, err)
+	require.Equal(t, int(compactIndex-firstIndex+1), len(entries))
+	for idx, e := range entries {
+		assert.Equal(t, firstIndex+uint64(idx), e.Index)
+	}
+
+	var snapshot AuditMetadataSnapshot
+	for i := 0; ; i++ {
+		objects, err := storage.List(context.Background(), "audit/metadata/")
+		require.NoError(t, err)
+		if len(objects) > 0 {
+			data, _ := storage.get(objects[len(objects)-1].Key)
+			require.NoError(t, json.Unmarshal(data, &snapshot))
+			break
+		}
+		if i == 50 {
+			t.Fatalf("failed to upload the metadata snapshot")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.Equal(t, s.Meta().ID, snapshot.StoreID)
+	require.Equal(t, 1, len(snapshot.Shards))
+	assert.Equal(t, shard.ID, snapshot.Shards[0].Shard.ID)
+	assert.True(t, snapshot.Shards[0].Leader)
+	assert.True(t, snapshot.Shards[0].AppliedIndex > compactIndex)
+}
@@ -46,7 +46,7 @@ func (s *store) ProphetBecomeFollower() {
 
 func (s *store) initMeta() {
 	s.meta.SetLabels(s.cfg.GetLabels())
-	s.meta.SetStartTime(time.Now().Unix())
+	s.meta.SetStartTime(s.now().Unix())
 	s.meta.SetDeployPath(s.cfg.DeployPath)
 	s.meta.SetVersionAndCommitID(s.cfg.Version, s.cfg.GitHash)
 	s.meta.SetAddrs(s.cfg.AdvertiseClientAddr, s.cfg.AdvertiseRaftAddr)
@@ -97,6 +97,11 @@ func (s *store) doBootstrapCluster(bootstrap bool) {
 			if s.cfg.Customize.CustomInitShardsFactory != nil {
 				shards := s.cfg.Customize.CustomInitShardsFactory()
 				for _, shard := range shards {
+					// the shard groups owned by the other prophet groups of the
+					// federation are bootstrapped by the stores of them
+					if _, ok := s.cfg.Prophet.Federation.GetOwner(shard.Group); ok {
+						continue
+					}
 					s.doCreateInitShard(&shard)
 					initShards = append(initShards, shard)
 					resources = append(resources, shard.Clone())
@@ -0,0 +1,264 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+const (
+	// CompactLogCompacted the compact log request was applied by the shard
+	CompactLogCompacted = "compacted"
+	// CompactLogSkipped the shard was not compacted, see the reason
+	CompactLogSkipped = "skipped"
+	// CompactLogFailed the compact log request of the shard failed
+	CompactLogFailed = "failed"
+
+	defaultCompactLogsTimeout = 30 * time.Second
+)
+
+// CompactLogsOptions the options of the batch log compaction
+type CompactLogsOptions struct {
+	// Threshold only the shards whose uncompacted raft log bytes are not less
+	// than the threshold are compacted
+	Threshold uint64
+	// Concurrency the max number of the shards compacting at the same time, 1
+	// if not set
+	Concurrency int
+	// Timeout the max time to wait for the compaction of a shard, 30s if not set
+	Timeout time.Duration
+	// Progress is called after each shard is handled, done is the number of
+	// the handled shards
+	Progress func(done, total int, result CompactLogResult)
+}
+
+func (opts *CompactLogsOptions) adjust() {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultCompactLogsTimeout
+	}
+}
+
+// CompactLogResult is the result of the log compaction of a local replica
+type CompactLogResult struct {
+	ShardID uint64 `json:"shard-id"`
+	// Bytes the uncompacted raft log bytes before the compaction
+	Bytes uint64 `json:"bytes"`
+	// CompactIndex the index requested to compact to, 0 if skipped
+	CompactIndex uint64 `json:"compact-index,omitempty"`
+	Status       string `json:"status"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// CompactLogsReport is the report of the batch log compaction
+type CompactLogsReport struct {
+	Total     int                `json:"total"`
+	Compacted int                `json:"compacted"`
+	Skipped   int                `json:"skipped"`
+	Failed    int                `json:"failed"`
+	Shards    []CompactLogResult `json:"shards"`
+}
+
+func (r *CompactLogsReport) add(result CompactLogResult) {
+	switch result.Status {
+	case CompactLogCompacted:
+		r.Compacted++
+	case CompactLogSkipped:
+		r.Skipped++
+	default:
+		r.Failed++
+	}
+	r.Shards = append(r.Shards, result)
+}
+
+// doCompactLogs requests the log compaction up to the applied index if the
+// replica is the leader and its uncompacted raft log is large enough. The
+// callback of the action is called once the compaction is applied or skipped.
+func (pr *replica) doCompactLogs(act action) {
+	result := CompactLogResult{
+		ShardID: pr.shardID,
+		Bytes:   pr.stats.raftLogSizeHint,
+		Status:  CompactLogSkipped,
+	}
+	if !pr.isLeader() {
+		result.Reason = "not leader"
+		act.actionCallback(result)
+		return
+	}
+	if result.Bytes < act.compactLogThreshold {
+		result.Reason = "below threshold"
+		act.actionCallback(result)
+		return
+	}
+	firstIndex := pr.getFirstIndex()
+	if pr.appliedIndex <= firstIndex {
+		result.Reason = "nothing to compact"
+		act.actionCallback(result)
+		return
+	}
+
+	result.CompactIndex = pr.appliedIndex - 1
+	pr.logger.Info("requesting log compaction by maintenance",
+		log.IndexField(result.CompactIndex),
+		zap.Uint64("bytes", result.Bytes))
+	pr.addAdminRequestWithCallback(rpcpb.CmdCompactLog, &rpcpb.CompactLogRequest{
+		CompactIndex: result.CompactIndex,
+	}, func(resp rpcpb.ResponseBatch) {
+		result.Status = CompactLogCompacted
+		if !resp.Header.IsEmpty() {
+			result.Status = CompactLogFailed
+			result.Reason = resp.Header.Error.Message
+		}
+		act.actionCallback(result)
+	})
+}
+
+// shrinkRaftLogSizeHint scales the raft log size hint by the share of the log
+// entries left after compacting to the index.
+func (pr *replica) shrinkRaftLogSizeHint(index uint64) {
+	first, err := pr.lr.FirstIndex()
+	if err != nil {
+		return
+	}
+	last, err := pr.lr.LastIndex()
+	if err != nil || index < first || last < first {
+		return
+	}
+	if index >= last {
+		pr.stats.raftLogSizeHint = 0
+		return
+	}
+	left := float64(last-index) / float64(last-first+1)
+	pr.stats.raftLogSizeHint = uint64(float64(pr.stats.raftLogSizeHint) * left)
+}
+
+// CompactLogs compacts the raft logs of the local leader replicas whose
+// uncompacted raft logs exceed the threshold.
+func (s *store) CompactLogs(ctx context.Context, opts CompactLogsOptions) CompactLogsReport {
+	opts.adjust()
+	var replicas []*replica
+	s.forEachReplica(func(pr *replica) bool {
+		replicas = append(replicas, pr)
+		return true
+	})
+	sort.Slice(replicas, func(i, j int) bool {
+		return replicas[i].shardID < replicas[j].shardID
+	})
+
+	s.logger.Info("begin to compact raft logs",
+		s.storeField(),
+		zap.Int("shards", len(replicas)),
+		zap.Uint64("threshold", opts.Threshold),
+		zap.Int("concurrency", opts.Concurrency))
+
+	report := CompactLogsReport{Total: len(replicas)}
+	results := make(chan CompactLogResult, len(replicas))
+	next, running := 0, 0
+	for report.Compacted+report.Skipped+report.Failed < len(replicas) {
+		for next < len(replicas) && running < opts.Concurrency {
+			running++
+			go func(pr *replica) {
+				results <- s.compactReplicaLogs(ctx, pr, opts)
+			}(replicas[next])
+			next++
+		}
+
+		result := <-results
+		running--
+		report.add(result)
+		if opts.Progress != nil {
+			opts.Progress(len(report.Shards), report.Total, result)
+		}
+	}
+	sort.Slice(report.Shards, func(i, j int) bool {
+		return report.Shards[i].ShardID < report.Shards[j].ShardID
+	})
+
+	s.logger.Info("compact raft logs completed",
+		s.storeField(),
+		zap.Int("compacted", report.Compacted),
+		zap.Int("skipped", report.Skipped),
+		zap.Int("failed", report.Failed))
+	return report
+}
+
+func (s *store) compactReplicaLogs(ctx context.Context, pr *replica, opts CompactLogsOptions) CompactLogResult {
+	result := CompactLogResult{ShardID: pr.shardID, Status: CompactLogFailed}
+	if err := ctx.Err(); err != nil {
+		result.Status = CompactLogSkipped
+		result.Reason = err.Error()
+		return result
+	}
+
+	c := make(chan CompactLogResult, 1)
+	pr.addAction(action{
+		actionType:          compactLogsAction,
+		compactLogThreshold: opts.Threshold,
+		actionCallback: func(arg interface{}) {
+			c <- arg.(CompactLogResult)
+		},
+	})
+
+	timer := time.NewTimer(opts.Timeout)
+	defer timer.Stop()
+	select {
+	case result = <-c:
+	case <-timer.C:
+		result.Reason = fmt.Sprintf("timeout after %s", opts.Timeout)
+	case <-ctx.Done():
+		result.Reason = ctx.Err().Error()
+	}
+	return result
+}
+
+func (s *store) consoleCompactLogs(w io.Writer, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("expect threshold and optional concurrency")
+	}
+	threshold, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid threshold %q", args[0])
+	}
+	opts := CompactLogsOptions{Threshold: threshold}
+	if len(args) == 2 {
+		if opts.Concurrency, err = strconv.Atoi(args[1]); err != nil || opts.Concurrency <= 0 {
+			return fmt.Errorf("invalid concurrency %q", args[1])
+		}
+	}
+	opts.Progress = func(done, total int, result CompactLogResult) {
+		fmt.Fprintf(w, "[%d/%d] shard: %d, bytes: %d, status: %s",
+			done, total, result.ShardID, result.Bytes, result.Status)
+		if result.Reason != "" {
+			fmt.Fprintf(w, ", reason: %s", result.Reason)
+		}
+		fmt.Fprintln(w)
+	}
+
+	report := s.CompactLogs(context.Background(), opts)
+	fmt.Fprintf(w, "total: %d, compacted: %d, skipped: %d, failed: %d\n",
+		report.Total, report.Compacted, report.Skipped, report.Failed)
+	return nil
+}
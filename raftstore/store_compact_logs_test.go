@@ -0,0 +1,166 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestDoCompactLogs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+
+	var results []CompactLogResult
+	act := action{
+		actionType:          compactLogsAction,
+		compactLogThreshold: 100,
+		actionCallback: func(arg interface{}) {
+			results = append(results, arg.(CompactLogResult))
+		},
+	}
+
+	pr.leaderID = 2
+	pr.doCompactLogs(act)
+	require.Equal(t, 1, len(results))
+	assert.Equal(t, CompactLogSkipped, results[0].Status)
+	assert.Equal(t, "not leader", results[0].Reason)
+
+	pr.leaderID = 1
+	pr.stats.raftLogSizeHint = 99
+	pr.doCompactLogs(act)
+	require.Equal(t, 2, len(results))
+	assert.Equal(t, "below threshold", results[1].Reason)
+
+	pr.stats.raftLogSizeHint = 100
+	pr.sm.setFirstIndex(10)
+	pr.appliedIndex = 10
+	pr.doCompactLogs(act)
+	require.Equal(t, 3, len(results))
+	assert.Equal(t, "nothing to compact", results[2].Reason)
+	assert.Equal(t, int64(0), pr.requests.Len())
+
+	pr.appliedIndex = 20
+	pr.doCompactLogs(act)
+	require.Equal(t, 3, len(results))
+	v, err := pr.requests.Peek()
+	require.NoError(t, err)
+	ctx := v.(reqCtx)
+	req := &rpcpb.CompactLogRequest{}
+	protoc.MustUnmarshal(req, ctx.req.Cmd)
+	assert.Equal(t, uint64(19), req.CompactIndex)
+
+	ctx.cb(rpcpb.ResponseBatch{})
+	require.Equal(t, 4, len(results))
+	assert.Equal(t, CompactLogCompacted, results[3].Status)
+	assert.Equal(t, uint64(19), results[3].CompactIndex)
+	assert.Equal(t, uint64(100), results[3].Bytes)
+
+	resp := rpcpb.ResponseBatch{}
+	resp.Header.Error.Message = "stale command"
+	ctx.cb(resp)
+	require.Equal(t, 5, len(results))
+	assert.Equal(t, CompactLogFailed, results[4].Status)
+	assert.Equal(t, "stale command", results[4].Reason)
+}
+
+func TestCompactLogsReport(t *testing.T) {
+	var report CompactLogsReport
+	report.add(CompactLogResult{Status: CompactLogCompacted})
+	report.add(CompactLogResult{Status: CompactLogSkipped})
+	report.add(CompactLogResult{Status: CompactLogFailed})
+	report.add(CompactLogResult{Status: CompactLogCompacted})
+	assert.Equal(t, 2, report.Compacted)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 4, len(report.Shards))
+}
+
+func TestCompactLogs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+	shard := c.GetShardByIndex(0, 0)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, kv.Set(fmt.Sprintf("k%d", i), "v", testWaitTimeout))
+	}
+
+	s := c.GetStore(0).(*store)
+	pr := s.getReplica(shard.ID, false)
+	require.NotNil(t, pr)
+	// sync the data storage to move the persistent log index, otherwise the
+	// compact index is adjusted to the persistent log index
+	require.NoError(t, pr.sm.dataStorage.Sync([]uint64{shard.ID}))
+	firstIndex, err := pr.lr.FirstIndex()
+	require.NoError(t, err)
+
+	report := s.CompactLogs(context.Background(), CompactLogsOptions{Threshold: 1 << 30})
+	assert.Equal(t, 1, report.Total)
+	assert.Equal(t, 1, report.Skipped)
+	require.Equal(t, 1, len(report.Shards))
+	assert.Equal(t, "below threshold", report.Shards[0].Reason)
+
+	var progress []string
+	report = s.CompactLogs(context.Background(), CompactLogsOptions{
+		Threshold:   1,
+		Concurrency: 2,
+		Progress: func(done, total int, result CompactLogResult) {
+			progress = append(progress, fmt.Sprintf("%d/%d %s", done, total, result.Status))
+		},
+	})
+	assert.Equal(t, 1, report.Compacted, "%+v", report)
+	assert.Equal(t, []string{"1/1 compacted"}, progress)
+	assert.True(t, report.Shards[0].CompactIndex > firstIndex)
+	for i := 0; ; i++ {
+		idx, err := pr.lr.FirstIndex()
+		require.NoError(t, err)
+		if idx > firstIndex {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("failed to compact the raft log")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	var buf bytes.Buffer
+	s.serveConsole(strings.NewReader("compact-logs 1073741824 4"), &buf)
+	assert.Contains(t, buf.String(), fmt.Sprintf("[1/1] shard: %d", shard.ID))
+	assert.Contains(t, buf.String(), "total: 1, compacted: 0, skipped: 1, failed: 0")
+	buf.Reset()
+	s.serveConsole(strings.NewReader("compact-logs x"), &buf)
+	assert.Contains(t, buf.String(), "error: invalid threshold")
+}
@@ -0,0 +1,413 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+	"go.uber.org/zap"
+)
+
+const (
+	consolePrompt = "> "
+	// consoleMaxRawEntries the max number of raw entries dumped by the raw command
+	consoleMaxRawEntries = 1024
+)
+
+// storeConsole is the interactive debug console of the store, it serves the
+// line based commands on a unix socket which is only accessible to the owner
+// of the store process.
+type storeConsole struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+	mu       struct {
+		sync.Mutex
+		closed bool
+		conns  map[net.Conn]struct{}
+	}
+}
+
+type consoleCommand struct {
+	usage   string
+	handler func(w io.Writer, args []string) error
+}
+
+// startConsole starts the debug console if the socket is configured
+func (s *store) startConsole() {
+	path := s.cfg.Debug.ConsoleSocket
+	if path == "" {
+		return
+	}
+
+	// remove the socket left by the previous process
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			s.logger.Error("fail to remove stale console socket",
+				s.storeField(),
+				zap.String("path", path),
+				zap.Error(err))
+			return
+		}
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		s.logger.Error("fail to start debug console",
+			s.storeField(),
+			zap.String("path", path),
+			zap.Error(err))
+		return
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		s.logger.Error("fail to secure debug console socket",
+			s.storeField(),
+			zap.String("path", path),
+			zap.Error(err))
+		l.Close()
+		return
+	}
+
+	c := &storeConsole{listener: l}
+	c.mu.conns = make(map[net.Conn]struct{})
+	s.console = c
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			if !c.track(conn) {
+				conn.Close()
+				return
+			}
+			c.wg.Add(1)
+			go func() {
+				defer c.wg.Done()
+				defer c.untrack(conn)
+				s.serveConsole(conn, conn)
+			}()
+		}
+	}()
+	s.logger.Info("debug console started",
+		s.storeField(),
+		zap.String("path", path))
+}
+
+func (s *store) stopConsole() {
+	if s.console == nil {
+		return
+	}
+
+	s.console.close()
+	s.logger.Info("debug console stopped",
+		s.storeField())
+}
+
+func (c *storeConsole) track(conn net.Conn) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.closed {
+		return false
+	}
+	c.mu.conns[conn] = struct{}{}
+	return true
+}
+
+func (c *storeConsole) untrack(conn net.Conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.mu.conns, conn)
+	conn.Close()
+}
+
+func (c *storeConsole) close() {
+	c.mu.Lock()
+	c.mu.closed = true
+	c.listener.Close()
+	for conn := range c.mu.conns {
+		conn.Close()
+	}
+	c.mu.Unlock()
+	c.wg.Wait()
+}
+
+// serveConsole executes the commands read from r line by line until the quit
+// command or EOF
+func (s *store) serveConsole(r io.Reader, w io.Writer) {
+	commands := s.consoleCommands()
+	scanner := bufio.NewScanner(r)
+	fmt.Fprint(w, consolePrompt)
+	for scanner.Scan() {
+		args := strings.Fields(scanner.Text())
+		if len(args) > 0 {
+			if args[0] == "quit" || args[0] == "exit" {
+				return
+			}
+			cmd, ok := commands[args[0]]
+			if !ok {
+				fmt.Fprintf(w, "unknown command %q, type help for the commands\n", args[0])
+			} else if err := cmd.handler(w, args[1:]); err != nil {
+				fmt.Fprintf(w, "error: %s\n", err)
+			}
+		}
+		fmt.Fprint(w, consolePrompt)
+	}
+}
+
+func (s *store) consoleCommands() map[string]consoleCommand {
+	commands := map[string]consoleCommand{
+		"replica": {
+			usage:   "replica <shard-id>: show the metadata and the internal state of the replica",
+			handler: s.consoleShowReplica,
+		},
+		"raw": {
+			usage:   "raw <group> <hex-key>: dump the raw storage entries of the key",
+			handler: s.consoleDumpRawKey,
+		},
+		"entry": {
+			usage:   "entry <shard-id> <index>: decode the applied raft log entry",
+			handler: s.consoleDecodeEntry,
+		},
+		"tick": {
+			usage:   "tick <shard-id>: step a raft tick of the replica",
+			handler: s.consoleStepTick,
+		},
+		"compact-logs": {
+			usage:   "compact-logs <threshold-bytes> [concurrency]: compact the raft logs of the local leaders above the threshold",
+			handler: s.consoleCompactLogs,
+		},
+	}
+	commands["help"] = consoleCommand{
+		usage: "help: show the commands",
+		handler: func(w io.Writer, args []string) error {
+			usages := []string{"quit: close the console"}
+			for _, cmd := range commands {
+				usages = append(usages, cmd.usage)
+			}
+			sort.Strings(usages)
+			for _, usage := range usages {
+				fmt.Fprintln(w, usage)
+			}
+			return nil
+		},
+	}
+	return commands
+}
+
+func (s *store) consoleShowReplica(w io.Writer, args []string) error {
+	pr, err := s.getConsoleReplica(args, 1)
+	if err != nil {
+		return err
+	}
+
+	c := make(chan interface{}, 1)
+	pr.addAction(action{actionType: debugInfoAction, actionCallback: func(arg interface{}) {
+		c <- arg
+	}})
+	var info ShardDebugInfo
+	select {
+	case v := <-c:
+		info = v.(ShardDebugInfo)
+	case <-time.After(debugInfoTimeout):
+		return fmt.Errorf("replica of shard %d does not respond in %s", pr.shardID, debugInfoTimeout)
+	}
+
+	v, err := json.MarshalIndent(struct {
+		Shard Shard          `json:"shard"`
+		Info  ShardDebugInfo `json:"info"`
+	}{pr.getShard(), info}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, string(v))
+	return nil
+}
+
+func (s *store) consoleDumpRawKey(w io.Writer, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("expect group and key")
+	}
+	group, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid group %q", args[0])
+	}
+	key, err := hex.DecodeString(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid hex key %q", args[1])
+	}
+	ds := s.DataStorageByGroup(group)
+	if ds == nil {
+		return fmt.Errorf("group %d not found", group)
+	}
+	wrapper, ok := ds.(storage.KVStorageWrapper)
+	if !ok {
+		return fmt.Errorf("data storage of group %d is not a kv storage", group)
+	}
+
+	// the raw entries of the key share the encoded data key as the prefix
+	prefix := keysutil.EncodeDataKey(key, nil)
+	n := 0
+	err = wrapper.GetKVStorage().Scan(prefix, prefixEnd(prefix), func(key, value []byte) (bool, error) {
+		fmt.Fprintf(w, "key: %s, value: %s (%d bytes)\n",
+			hex.EncodeToString(key), hex.EncodeToString(value), len(value))
+		n++
+		return n < consoleMaxRawEntries, nil
+	}, false)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%d entries\n", n)
+	return nil
+}
+
+func (s *store) consoleDecodeEntry(w io.Writer, args []string) error {
+	pr, err := s.getConsoleReplica(args, 2)
+	if err != nil {
+		return err
+	}
+	index, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid index %q", args[1])
+	}
+	if applied, _ := pr.sm.getAppliedIndexTerm(); index > applied {
+		return fmt.Errorf("entry %d is not applied, applied index %d", index, applied)
+	}
+
+	entries, err := pr.lr.Entries(index, index+1, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	if len(entries) != 1 {
+		return fmt.Errorf("entry %d not found", index)
+	}
+	req, cc, err := decodeLogEntry(entries[0])
+	if err != nil {
+		return err
+	}
+	if len(req.Compressed) > 0 {
+		if req, err = decompressRequestBatch(pr.sm.getDictionaries(), req.Compressed); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "index: %d, term: %d, type: %s, size: %d\n",
+		entries[0].Index, entries[0].Term, entries[0].Type, len(entries[0].Data))
+	if len(cc.Changes) > 0 {
+		fmt.Fprintf(w, "config change: %s\n", cc.String())
+	}
+	if req.Header.IsEmpty() {
+		return nil
+	}
+	fmt.Fprintf(w, "batch: %s, shard: %d, replica: %d, commit-time: %d\n",
+		hex.EncodeToString(req.Header.ID), req.Header.ShardID, req.Header.Replica.ID, req.Header.CommitTime)
+	if req.IsAdmin() {
+		fmt.Fprintf(w, "admin: %s, request: %s\n",
+			req.GetAdminCmdType().String(), hex.EncodeToString(req.Requests[0].Cmd))
+		return nil
+	}
+	for _, r := range req.Requests {
+		fmt.Fprintf(w, "request: %s, type: %s, custom-type: %d, key: %s, cmd: %d bytes\n",
+			hex.EncodeToString(r.ID), r.Type.String(), r.CustomType, hex.EncodeToString(r.Key), len(r.Cmd))
+	}
+	return nil
+}
+
+func (s *store) consoleStepTick(w io.Writer, args []string) error {
+	pr, err := s.getConsoleReplica(args, 1)
+	if err != nil {
+		return err
+	}
+	if !pr.addRaftTick() {
+		return fmt.Errorf("replica of shard %d is stopped", pr.shardID)
+	}
+	fmt.Fprintf(w, "tick added, total %d, handled %d\n",
+		pr.getTickTotalCount(), pr.getTickHandledCount())
+	return nil
+}
+
+// getConsoleReplica returns the replica of the shard in the first argument
+func (s *store) getConsoleReplica(args []string, expectArgs int) (*replica, error) {
+	if len(args) != expectArgs {
+		return nil, fmt.Errorf("expect %d arguments", expectArgs)
+	}
+	shardID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shard %q", args[0])
+	}
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return nil, fmt.Errorf("shard %d not found", shardID)
+	}
+	return pr, nil
+}
+
+// decodeLogEntry decodes the request batch and the config change of the entry
+func decodeLogEntry(entry raftpb.Entry) (rpcpb.RequestBatch, raftpb.ConfChangeV2, error) {
+	var req rpcpb.RequestBatch
+	var cc raftpb.ConfChangeV2
+	switch entry.Type {
+	case raftpb.EntryNormal:
+		if err := req.Unmarshal(entry.Data); err != nil {
+			return req, cc, err
+		}
+	case raftpb.EntryConfChange:
+		var v1 raftpb.ConfChange
+		if err := v1.Unmarshal(entry.Data); err != nil {
+			return req, cc, err
+		}
+		cc = v1.AsV2()
+		if err := req.Unmarshal(v1.Context); err != nil {
+			return req, cc, err
+		}
+	case raftpb.EntryConfChangeV2:
+		if err := cc.Unmarshal(entry.Data); err != nil {
+			return req, cc, err
+		}
+		if err := req.Unmarshal(cc.Context); err != nil {
+			return req, cc, err
+		}
+	default:
+		return req, cc, fmt.Errorf("unknown entry type %s", entry.Type)
+	}
+	return req, cc, nil
+}
+
+// prefixEnd returns the smallest key greater than all keys with the prefix
+func prefixEnd(prefix []byte) []byte {
+	end := keysutil.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
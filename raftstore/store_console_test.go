@@ -0,0 +1,124 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+)
+
+func TestPrefixEnd(t *testing.T) {
+	assert.Equal(t, []byte{1, 3}, prefixEnd([]byte{1, 2}))
+	assert.Equal(t, []byte{2}, prefixEnd([]byte{1, 0xff}))
+	assert.Nil(t, prefixEnd([]byte{0xff, 0xff}))
+}
+
+func TestDecodeLogEntry(t *testing.T) {
+	req := rpcpb.RequestBatch{
+		Header:   rpcpb.RequestBatchHeader{ID: []byte("id"), ShardID: 1},
+		Requests: []rpcpb.Request{{Key: []byte("k")}},
+	}
+	data, err := req.Marshal()
+	require.NoError(t, err)
+
+	v, cc, err := decodeLogEntry(raftpb.Entry{Type: raftpb.EntryNormal, Data: data})
+	require.NoError(t, err)
+	assert.Equal(t, req.Header.ID, v.Header.ID)
+	assert.Empty(t, cc.Changes)
+
+	v2 := raftpb.ConfChangeV2{
+		Changes: []raftpb.ConfChangeSingle{{Type: raftpb.ConfChangeAddNode, NodeID: 2}},
+		Context: data,
+	}
+	data, err = v2.Marshal()
+	require.NoError(t, err)
+	v, cc, err = decodeLogEntry(raftpb.Entry{Type: raftpb.EntryConfChangeV2, Data: data})
+	require.NoError(t, err)
+	assert.Equal(t, req.Header.ID, v.Header.ID)
+	assert.Equal(t, v2.Changes, cc.Changes)
+
+	_, _, err = decodeLogEntry(raftpb.Entry{Type: raftpb.EntryNormal, Data: []byte{0xff}})
+	assert.Error(t, err)
+}
+
+func TestConsole(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	socket := filepath.Join(t.TempDir(), "console.sock")
+	c := NewSingleTestClusterStore(t,
+		WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+			cfg.Debug.ConsoleSocket = socket
+		}))
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+	shard := c.GetShardByIndex(0, 0)
+
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	require.NoError(t, kv.Set("k1", "v1", testWaitTimeout))
+
+	fi, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	s := c.GetStore(0).(*store)
+	exec := func(lines ...string) string {
+		var buf bytes.Buffer
+		s.serveConsole(strings.NewReader(strings.Join(lines, "\n")), &buf)
+		return buf.String()
+	}
+
+	assert.Contains(t, exec("help"), "raw <group> <hex-key>")
+	assert.Contains(t, exec("unknown"), "unknown command")
+	assert.Contains(t, exec("replica"), "error: expect 1 arguments")
+	assert.Contains(t, exec("replica 100000"), "error: shard 100000 not found")
+	assert.Contains(t, exec(fmt.Sprintf("replica %d", shard.ID)), `"leader-id"`)
+	assert.Contains(t, exec(fmt.Sprintf("raw %d %s", shard.Group, hex.EncodeToString([]byte("k1")))),
+		hex.EncodeToString([]byte("v1")))
+	assert.Contains(t, exec(fmt.Sprintf("tick %d", shard.ID)), "tick added")
+	assert.Contains(t, exec(fmt.Sprintf("entry %d 1000000", shard.ID)), "is not applied")
+	applied, _ := s.getReplica(shard.ID, false).sm.getAppliedIndexTerm()
+	assert.Contains(t, exec(fmt.Sprintf("entry %d %d", shard.ID, applied)), fmt.Sprintf("index: %d", applied))
+	// the commands after quit are ignored
+	assert.NotContains(t, exec("quit", "help"), "raw <group>")
+
+	conn, err := net.Dial("unix", socket)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("help\nquit\n"))
+	require.NoError(t, err)
+	var lines []string
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	assert.Contains(t, strings.Join(lines, "\n"), "tick <shard-id>")
+}
@@ -0,0 +1,371 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"runtime/debug"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+const (
+	// crashReportCollectTimeout max time to collect the store states, the
+	// states may never be collected if the store is broken
+	crashReportCollectTimeout = 3 * time.Second
+	crashReportRedacted       = "******"
+)
+
+// crashReportSection is a file of the crash report archive, the value is
+// encoded as json unless it's a string.
+type crashReportSection struct {
+	name  string
+	value interface{}
+}
+
+// crashReporter gathers the recent logs, the store states and the goroutine
+// dump into an archive on panic or fatal, and optionally uploads it.
+type crashReporter struct {
+	cfg  config.CrashReportConfig
+	fs   vfs.FS
+	name string
+	logs *crashLogRing
+	// logger must not log at the panic or fatal level, which triggers the report
+	logger *zap.Logger
+	// sections returns the store states, it may block forever if the store is
+	// broken
+	sections func() []crashReportSection
+
+	mu struct {
+		sync.Mutex
+		reported bool
+		path     string
+	}
+}
+
+func newCrashReporter(cfg config.CrashReportConfig, fs vfs.FS, name string) *crashReporter {
+	return &crashReporter{
+		cfg:    cfg,
+		fs:     fs,
+		name:   name,
+		logs:   newCrashLogRing(cfg.RecentLogs),
+		logger: zap.NewNop(),
+	}
+}
+
+// wrapLogger returns the logger whose entries are also kept by the reporter,
+// the entries at the panic or fatal level trigger the report.
+func (r *crashReporter) wrapLogger(logger *zap.Logger) *zap.Logger {
+	return logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		encCfg := zap.NewProductionEncoderConfig()
+		encCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+		return zapcore.NewTee(c, &crashReportCore{
+			LevelEnabler: c,
+			enc:          zapcore.NewJSONEncoder(encCfg),
+			reporter:     r,
+		})
+	}))
+}
+
+// reportPanic must be called with the recovered value in the deferred function
+// of the panicking goroutine to capture its stack.
+func (r *crashReporter) reportPanic(p interface{}) {
+	r.report(fmt.Sprintf("panic: %v\n\n%s", p, debug.Stack()))
+}
+
+// report creates the crash report once, the later calls wait for the first one
+// to complete, so the process doesn't exit with a partial archive. Returns the
+// path of the archive, empty if it's failed to create.
+func (r *crashReporter) report(reason string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mu.reported {
+		return r.mu.path
+	}
+	r.mu.reported = true
+
+	path, err := r.createArchive(reason)
+	if err != nil {
+		r.logger.Error("fail to create crash report",
+			zap.Error(err))
+		return ""
+	}
+	r.mu.path = path
+	r.logger.Error("crash report created",
+		zap.String("path", path))
+
+	if r.cfg.UploadURL != "" {
+		if err := r.upload(path); err != nil {
+			r.logger.Error("fail to upload crash report",
+				zap.String("path", path),
+				zap.Error(err))
+		} else {
+			r.logger.Info("crash report uploaded",
+				zap.String("path", path))
+		}
+	}
+	return path
+}
+
+func (r *crashReporter) createArchive(reason string) (string, error) {
+	if err := r.fs.MkdirAll(r.cfg.Dir, 0755); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	path := filepath.Join(r.cfg.Dir, fmt.Sprintf("crash-%s-%s.tar.gz",
+		r.name, now.Format("20060102T150405.000")))
+	f, err := r.fs.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	sections := []crashReportSection{
+		{name: "reason.txt", value: reason},
+		{name: "logs.txt", value: r.logs.String()},
+		{name: "goroutines.txt", value: goroutineDump()},
+	}
+	sections = append(sections, r.collectSections()...)
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, section := range sections {
+		data, err := encodeCrashReportSection(section)
+		if err != nil {
+			return "", err
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    section.name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}); err != nil {
+			return "", err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return "", err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+	if err := f.Sync(); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// collectSections collects the store states with a timeout, as the report may
+// be created by a goroutine which holds the locks required by the collection.
+func (r *crashReporter) collectSections() []crashReportSection {
+	if r.sections == nil {
+		return nil
+	}
+
+	c := make(chan []crashReportSection, 1)
+	go func() {
+		c <- r.sections()
+	}()
+	select {
+	case sections := <-c:
+		return sections
+	case <-time.After(crashReportCollectTimeout):
+		return []crashReportSection{
+			{name: "errors.txt", value: "timeout to collect the store states"},
+		}
+	}
+}
+
+func (r *crashReporter) upload(path string) error {
+	f, err := r.fs.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.UploadTimeout.Duration)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.cfg.UploadURL, f)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", filepath.Base(path)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected upload response status %s", resp.Status)
+	}
+	return nil
+}
+
+func encodeCrashReportSection(section crashReportSection) ([]byte, error) {
+	if v, ok := section.value.(string); ok {
+		return []byte(v), nil
+	}
+	return json.MarshalIndent(section.value, "", "  ")
+}
+
+func goroutineDump() string {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 2); err != nil {
+		return err.Error()
+	}
+	return buf.String()
+}
+
+// crashReportSections returns the store states of the crash report
+func (s *store) crashReportSections() []crashReportSection {
+	ctx, cancel := context.WithTimeout(context.Background(), crashReportCollectTimeout/2)
+	defer cancel()
+
+	var shards []Shard
+	s.forEachReplica(func(pr *replica) bool {
+		shards = append(shards, pr.getShard())
+		return true
+	})
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i].ID < shards[j].ID
+	})
+	return []crashReportSection{
+		{name: "config.json", value: redactConfigItems(s.cfg.DescribeConfig())},
+		{name: "shards.json", value: shards},
+		{name: "raft.json", value: s.getRaftStatuses(ctx)},
+	}
+}
+
+// redactConfigItems hides the credentials, the archive is supposed to be
+// attached to the reports.
+func redactConfigItems(items []config.ConfigItem) []config.ConfigItem {
+	for idx := range items {
+		if items[idx].Value == "" {
+			continue
+		}
+		if strings.HasSuffix(items[idx].Name, "token") ||
+			strings.HasSuffix(items[idx].Name, "upload-url") {
+			items[idx].Value = crashReportRedacted
+			items[idx].Origin = ""
+		}
+	}
+	return items
+}
+
+// crashLogRing keeps the recent encoded log entries
+type crashLogRing struct {
+	sync.Mutex
+	entries []string
+	next    int
+	full    bool
+}
+
+func newCrashLogRing(capacity int) *crashLogRing {
+	return &crashLogRing{entries: make([]string, capacity)}
+}
+
+func (r *crashLogRing) add(entry string) {
+	r.Lock()
+	defer r.Unlock()
+	r.entries[r.next] = entry
+	r.next++
+	if r.next == len(r.entries) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// String returns the entries from the oldest to the newest
+func (r *crashLogRing) String() string {
+	r.Lock()
+	defer r.Unlock()
+	var entries []string
+	if r.full {
+		entries = append(entries, r.entries[r.next:]...)
+	}
+	entries = append(entries, r.entries[:r.next]...)
+	return strings.Join(entries, "\n")
+}
+
+// crashReportCore is the zap core which keeps the log entries for the crash
+// report, the entries at the panic or fatal level trigger the report before
+// the logger panics or exits.
+type crashReportCore struct {
+	zapcore.LevelEnabler
+	enc      zapcore.Encoder
+	reporter *crashReporter
+}
+
+var _ zapcore.Core = (*crashReportCore)(nil)
+
+func (c *crashReportCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &crashReportCore{
+		LevelEnabler: c.LevelEnabler,
+		enc:          c.enc.Clone(),
+		reporter:     c.reporter,
+	}
+	for idx := range fields {
+		fields[idx].AddTo(clone.enc)
+	}
+	return clone
+}
+
+func (c *crashReportCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *crashReportCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	entry := strings.TrimSuffix(buf.String(), "\n")
+	buf.Free()
+
+	c.reporter.logs.add(entry)
+	if ent.Level >= zapcore.PanicLevel {
+		c.reporter.report(ent.Level.CapitalString() + ": " + entry)
+	}
+	return nil
+}
+
+func (c *crashReportCore) Sync() error {
+	return nil
+}
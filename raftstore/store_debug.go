@@ -14,10 +14,280 @@
 package raftstore
 
 import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/metric"
 	"go.uber.org/zap"
 )
 
+const (
+	debugInfoTimeout = 5 * time.Second
+)
+
+// ShardDebugInfo is the internal state of a shard replica exposed by the debug
+// service
+type ShardDebugInfo struct {
+	ShardID          uint64 `json:"shard-id"`
+	Group            uint64 `json:"group"`
+	ReplicaID        uint64 `json:"replica-id"`
+	LeaderID         uint64 `json:"leader-id"`
+	Term             uint64 `json:"term"`
+	Commit           uint64 `json:"commit"`
+	Applied          uint64 `json:"applied"`
+	PendingProposals int    `json:"pending-proposals"`
+	PendingReads     int    `json:"pending-reads"`
+	Hibernated       bool   `json:"hibernated"`
+	// Queues the depths of the event queues of the replica
+	Queues map[string]int64 `json:"queues"`
+}
+
+// getDebugInfo must be called in the event worker, as the raft status is not
+// thread safe.
+func (pr *replica) getDebugInfo() ShardDebugInfo {
+	status := pr.rn.Status()
+	return ShardDebugInfo{
+		ShardID:          pr.shardID,
+		Group:            pr.group,
+		ReplicaID:        pr.replicaID,
+		LeaderID:         status.Lead,
+		Term:             status.Term,
+		Commit:           status.Commit,
+		Applied:          pr.appliedIndex,
+		PendingProposals: pr.pendingProposals.size(),
+		PendingReads:     len(pr.pendingReads.reads),
+		Hibernated:       pr.isHibernated(),
+		Queues: map[string]int64{
+			"requests":        pr.requests.Len(),
+			"messages":        pr.messages.Len(),
+			"ticks":           pr.ticks.Len(),
+			"actions":         pr.actions.Len(),
+			"feedbacks":       pr.feedbacks.Len(),
+			"snapshot-status": pr.snapshotStatus.Len(),
+		},
+	}
+}
+
+// getShardsDebugInfo collects the debug info of all replicas in their event
+// workers, the replicas which do not respond in time are skipped.
+func (s *store) getShardsDebugInfo(ctx context.Context) []ShardDebugInfo {
+	values := s.collectFromReplicas(ctx, debugInfoAction, "debug info")
+	infos := make([]ShardDebugInfo, 0, len(values))
+	for _, v := range values {
+		infos = append(infos, v.(ShardDebugInfo))
+	}
+	return sortShardsDebugInfo(infos)
+}
+
+// collectFromReplicas adds the action to all replicas, and collects the values
+// passed to the action callbacks until the ctx is done.
+func (s *store) collectFromReplicas(ctx context.Context, actionType actionType, name string) []interface{} {
+	var replicas []*replica
+	s.forEachReplica(func(pr *replica) bool {
+		replicas = append(replicas, pr)
+		return true
+	})
+
+	c := make(chan interface{}, len(replicas))
+	for _, pr := range replicas {
+		pr.addAction(action{actionType: actionType, actionCallback: func(arg interface{}) {
+			c <- arg
+		}})
+	}
+
+	values := make([]interface{}, 0, len(replicas))
+	for range replicas {
+		select {
+		case v := <-c:
+			values = append(values, v)
+		case <-ctx.Done():
+			s.logger.Warn("fail to collect "+name+" of all shards",
+				s.storeField(),
+				zap.Int("expect", len(replicas)),
+				zap.Int("collected", len(values)))
+			return values
+		}
+	}
+	return values
+}
+
+func sortShardsDebugInfo(infos []ShardDebugInfo) []ShardDebugInfo {
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ShardID < infos[j].ShardID
+	})
+	return infos
+}
+
+// startDebugServer starts the debug service if the address is configured
+func (s *store) startDebugServer() {
+	if s.cfg.Debug.Addr == "" {
+		return
+	}
+
+	if s.cfg.Debug.Token == "" {
+		if !isLoopbackAddr(s.cfg.Debug.Addr) {
+			s.logger.Error("debug service is not started, missing token to listen on a non loopback address",
+				s.storeField(),
+				log.ListenAddressField(s.cfg.Debug.Addr))
+			return
+		}
+		s.logger.Warn("debug service is not secured, missing token",
+			s.storeField())
+	}
+
+	s.debugServer = &http.Server{
+		Addr:    s.cfg.Debug.Addr,
+		Handler: s.debugHandler(),
+	}
+	go func() {
+		if err := s.debugServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("fail to start debug service",
+				s.storeField(),
+				zap.Error(err))
+		}
+	}()
+	s.logger.Info("debug service started",
+		s.storeField(),
+		log.ListenAddressField(s.cfg.Debug.Addr))
+}
+
+func (s *store) stopDebugServer() {
+	if s.debugServer == nil {
+		return
+	}
+
+	if err := s.debugServer.Close(); err != nil {
+		s.logger.Error("fail to stop debug service",
+			s.storeField(),
+			zap.Error(err))
+	}
+	s.logger.Info("debug service stopped",
+		s.storeField())
+}
+
+func (s *store) debugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/shards", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), debugInfoTimeout)
+		defer cancel()
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(s.getShardsDebugInfo(ctx)); err != nil {
+			s.logger.Error("fail to write shards debug info",
+				zap.Error(err))
+		}
+	})
+	mux.HandleFunc("/debug/raft", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(s.GetRaftStatuses()); err != nil {
+			s.logger.Error("fail to write raft statuses",
+				zap.Error(err))
+		}
+	})
+	mux.HandleFunc("/debug/raft-log", func(w http.ResponseWriter, r *http.Request) {
+		shardID, err := strconv.ParseUint(r.FormValue("shard"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid shard", http.StatusBadRequest)
+			return
+		}
+		stats, err := s.GetRaftLogStats(shardID)
+		if err == errShardNotFound {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(stats); err != nil {
+			s.logger.Error("fail to write raft log stats",
+				zap.Error(err))
+		}
+	})
+	mux.HandleFunc("/debug/apply-cpu", func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		if v := r.FormValue("top"); v != "" {
+			var err error
+			if n, err = strconv.Atoi(v); err != nil {
+				http.Error(w, "invalid top", http.StatusBadRequest)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(s.GetTopApplyCPUShards(n)); err != nil {
+			s.logger.Error("fail to write apply cpu",
+				zap.Error(err))
+		}
+	})
+	mux.HandleFunc("/debug/config", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(s.cfg.DescribeConfig()); err != nil {
+			s.logger.Error("fail to write config",
+				zap.Error(err))
+		}
+	})
+	mux.HandleFunc("/debug/pprof/", func(w http.ResponseWriter, r *http.Request) {
+		p := pprof.Lookup(strings.TrimPrefix(r.URL.Path, "/debug/pprof/"))
+		if p == nil {
+			http.NotFound(w, r)
+			return
+		}
+		debug, _ := strconv.Atoi(r.FormValue("debug"))
+		if debug == 0 {
+			w.Header().Set("Content-Type", "application/octet-stream")
+		} else {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		}
+		if err := p.WriteTo(w, debug); err != nil {
+			s.logger.Error("fail to write profile",
+				zap.String("profile", p.Name()),
+				zap.Error(err))
+		}
+	})
+	// the metrics in the OpenMetrics format with the exemplars of the request
+	// durations
+	mux.Handle("/metrics", metric.Handler())
+	return withDebugAuth(s.cfg.Debug.Token, mux)
+}
+
+// isLoopbackAddr returns true if the address only listens on the loopback
+// interface, the empty host listens on all the interfaces.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// withDebugAuth rejects the requests without the expected bearer token
+func withDebugAuth(token string, h http.Handler) http.Handler {
+	if token == "" {
+		return h
+	}
+
+	expect := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expect) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
 func (s *store) doLogDebugInfo() {
 	if ce := s.logger.Check(zap.DebugLevel, ""); ce == nil {
 		return
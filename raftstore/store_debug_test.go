@@ -0,0 +1,105 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/config"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugAuth(t *testing.T) {
+	h := withDebugAuth("token", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/debug/shards", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	r.Header.Set("Authorization", "Bearer bad")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	r.Header.Set("Authorization", "Bearer token")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestIsLoopbackAddr(t *testing.T) {
+	assert.True(t, isLoopbackAddr("127.0.0.1:8080"))
+	assert.True(t, isLoopbackAddr("[::1]:8080"))
+	assert.True(t, isLoopbackAddr("localhost:8080"))
+	assert.False(t, isLoopbackAddr(":8080"))
+	assert.False(t, isLoopbackAddr("0.0.0.0:8080"))
+	assert.False(t, isLoopbackAddr("192.168.0.1:8080"))
+	assert.False(t, isLoopbackAddr("127.0.0.1"))
+}
+
+func TestDebugHandler(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	s := c.GetStore(0).(*store)
+	infos := s.getShardsDebugInfo(context.Background())
+	require.Equal(t, 1, len(infos))
+	assert.Equal(t, infos[0].ReplicaID, infos[0].LeaderID)
+	assert.True(t, infos[0].Term > 0)
+	assert.True(t, infos[0].Commit >= infos[0].Applied)
+
+	h := s.debugHandler()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/shards", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var values []ShardDebugInfo
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &values))
+	assert.Equal(t, 1, len(values))
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/config", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var items []config.ConfigItem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &items))
+	assert.NotEmpty(t, items)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/goroutine?debug=1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "goroutine profile")
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "matrixcube_raftstore_request_duration_seconds")
+}
@@ -0,0 +1,147 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/storage"
+)
+
+const (
+	diskPressureReleasedView = "released-view"
+	diskPressureCompaction   = "compaction"
+)
+
+// getStorageStats returns the disk usage of the store, the available size is
+// calculated by the configured capacity if it's set
+func (s *store) getStorageStats() (storageStats, error) {
+	v, err := s.storageStatsReader.stats()
+	if err != nil {
+		return storageStats{}, err
+	}
+	if s.cfg.Capacity > 0 {
+		v.capacity = uint64(s.cfg.Capacity)
+		v.available = 0
+		if v.capacity > v.usedSize {
+			v.available = v.capacity - v.usedSize
+		}
+	}
+	return v, nil
+}
+
+func (s *store) isUnderDiskPressure() bool {
+	return atomic.LoadUint32(&s.diskPressure) == 1
+}
+
+// getResourceReleasers returns the metadata storage and the data storages which
+// are able to release the disk space
+func (s *store) getResourceReleasers() []storage.ResourceReleaser {
+	var releasers []storage.ResourceReleaser
+	if r, ok := s.kvStorage.(storage.ResourceReleaser); ok {
+		releasers = append(releasers, r)
+	}
+	s.cfg.Storage.ForeachDataStorageFunc(func(_ uint64, ds storage.DataStorage) {
+		if r, ok := ds.(storage.ResourceReleaser); ok {
+			releasers = append(releasers, r)
+		}
+	})
+	return releasers
+}
+
+// handleDiskPressureTask checks the disk usage. Under the pressure, the old
+// views are released on every check, and the storages are compacted once when
+// the pressure starts, as the compaction itself needs the disk space and only
+// helps after the pinned data is released.
+func (s *store) handleDiskPressureTask(now time.Time) {
+	v, err := s.getStorageStats()
+	if err != nil {
+		s.logger.Error("fail to get storage capacity status",
+			s.storeField(),
+			zap.Error(err))
+		return
+	}
+	if v.capacity == 0 {
+		return
+	}
+
+	cfg := s.cfg.DiskPressure
+	ratio := float64(v.capacity-v.available) / float64(v.capacity)
+	if ratio < cfg.UsedRatio {
+		if atomic.CompareAndSwapUint32(&s.diskPressure, 1, 0) {
+			s.logger.Info("disk pressure is gone",
+				s.storeField(),
+				zap.Float64("used-ratio", ratio))
+		}
+		return
+	}
+
+	started := atomic.CompareAndSwapUint32(&s.diskPressure, 0, 1)
+	if started {
+		s.logger.Warn("store is under disk pressure, stop accepting new replicas",
+			s.storeField(),
+			zap.Float64("used-ratio", ratio),
+			zap.Uint64("capacity", v.capacity),
+			zap.Uint64("available", v.available))
+	}
+
+	released := 0
+	releasers := s.getResourceReleasers()
+	for _, r := range releasers {
+		released += r.ReleaseViews(now.Add(-cfg.MaxViewAge.Duration))
+	}
+	if released > 0 {
+		metric.AddDiskPressure(diskPressureReleasedView, released)
+		s.logger.Warn("storage views released by disk pressure",
+			s.storeField(),
+			zap.Int("released", released))
+	}
+
+	if started {
+		for _, r := range releasers {
+			if err := r.CompactAll(); err != nil {
+				s.logger.Error("fail to compact storage under disk pressure",
+					s.storeField(),
+					zap.Error(err))
+				continue
+			}
+			metric.AddDiskPressure(diskPressureCompaction, 1)
+		}
+	}
+}
+
+func (s *store) startDiskPressureCheck() {
+	cfg := s.cfg.DiskPressure
+	if cfg.UsedRatio <= 0 {
+		return
+	}
+
+	s.stopper.RunWorker(func() {
+		ticker := time.NewTicker(cfg.CheckInterval.Duration)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stopper.ShouldStop():
+				return
+			case now := <-ticker.C:
+				s.handleDiskPressureTask(now)
+			}
+		}
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+type testResourceReleaser struct {
+	storage.DataStorage
+	before    []time.Time
+	compacted int
+}
+
+func (r *testResourceReleaser) ReleaseViews(before time.Time) int {
+	r.before = append(r.before, before)
+	return 1
+}
+
+func (r *testResourceReleaser) CompactAll() error {
+	r.compacted++
+	return nil
+}
+
+func TestHandleDiskPressureTask(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	reader := &customStorageStatsReader{s: s}
+	reader.setStatsWithGB(100, 50)
+	s.storageStatsReader = reader
+	s.cfg.DiskPressure.UsedRatio = 0.9
+	s.cfg.DiskPressure.MaxViewAge.Duration = time.Minute
+	releaser := &testResourceReleaser{DataStorage: s.DataStorageByGroup(0)}
+	s.cfg.Storage.ForeachDataStorageFunc = func(cb func(uint64, storage.DataStorage)) {
+		cb(0, releaser)
+	}
+
+	now := time.Now()
+	s.handleDiskPressureTask(now)
+	assert.False(t, s.isUnderDiskPressure())
+	assert.Empty(t, releaser.before)
+
+	// compacted once when the pressure starts
+	reader.setStatsWithGB(100, 5)
+	s.handleDiskPressureTask(now)
+	s.handleDiskPressureTask(now.Add(time.Second))
+	assert.True(t, s.isUnderDiskPressure())
+	require.Equal(t, 2, len(releaser.before))
+	assert.Equal(t, now.Add(-time.Minute), releaser.before[0])
+	assert.Equal(t, 1, releaser.compacted)
+
+	reader.setStatsWithGB(100, 50)
+	s.handleDiskPressureTask(now)
+	assert.False(t, s.isUnderDiskPressure())
+}
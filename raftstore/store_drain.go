@@ -0,0 +1,133 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+// drainCheckInterval the interval to transfer the remaining leaders of the
+// draining store
+var drainCheckInterval = time.Second
+
+func (s *store) TransferLeader(shardID uint64, targetReplicaID uint64) error {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return errShardNotFound
+	}
+	if !pr.isLeader() {
+		return errNotLeader
+	}
+
+	target, ok := getTransferLeaderTarget(pr.getShard(), targetReplicaID)
+	if !ok {
+		return errInvalidTransferee
+	}
+	pr.addAdminRequest(rpcpb.CmdTransferLeader, &rpcpb.TransferLeaderRequest{
+		Replica: target,
+	})
+	return nil
+}
+
+// getTransferLeaderTarget returns the replica if it's a voter of the shard
+func getTransferLeaderTarget(shard Shard, replicaID uint64) (Replica, bool) {
+	for _, r := range shard.Replicas {
+		if r.ID == replicaID {
+			return r, r.Role == metapb.ReplicaRole_Voter
+		}
+	}
+	return Replica{}, false
+}
+
+func (s *store) Drain(ctx context.Context) error {
+	if atomic.CompareAndSwapUint32(&s.draining, 0, 1) {
+		meta := s.Meta()
+		meta.SetDraining(true)
+		if err := s.pd.GetClient().PutStore(meta); err != nil {
+			atomic.StoreUint32(&s.draining, 0)
+			return err
+		}
+		s.logger.Info("store draining",
+			s.storeField())
+	}
+
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	for round := 0; ; round++ {
+		if s.transferLeadersOut(round) == 0 {
+			s.logger.Info("store drained",
+				s.storeField())
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *store) isDraining() bool {
+	return atomic.LoadUint32(&s.draining) == 1
+}
+
+// transferLeadersOut transfers the leaders of the local replicas to the voters
+// on the other stores, returns the number of the leaders to be transferred. The
+// target is changed by the round, in case that the previous target can't catch
+// up. The shards without any other voter are skipped.
+func (s *store) transferLeadersOut(round int) int {
+	leaders := 0
+	s.forEachReplica(func(pr *replica) bool {
+		if !pr.isLeader() {
+			return true
+		}
+
+		shard := pr.getShard()
+		var targets []Replica
+		for _, r := range shard.Replicas {
+			if r.StoreID != s.Meta().ID &&
+				r.Role == metapb.ReplicaRole_Voter {
+				targets = append(targets, r)
+			}
+		}
+		if len(targets) == 0 {
+			s.logger.Warn("skip transfer leader of draining store",
+				s.storeField(),
+				log.ShardIDField(shard.ID),
+				log.ReasonField("no other voter"))
+			return true
+		}
+
+		leaders++
+		target := targets[round%len(targets)]
+		pr.addAdminRequest(rpcpb.CmdTransferLeader, &rpcpb.TransferLeaderRequest{
+			Replica: target,
+		})
+		if ce := s.logger.Check(zap.DebugLevel, "transfer leader of draining store"); ce != nil {
+			ce.Write(s.storeField(),
+				log.ShardIDField(shard.ID),
+				log.ReplicaField("to", target))
+		}
+		return true
+	})
+	return leaders
+}
@@ -0,0 +1,33 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"io"
+
+	"github.com/matrixorigin/matrixcube/storage"
+)
+
+func (s *store) ExportShard(shardID uint64, w io.Writer,
+	opts storage.ExportOptions) (uint64, error) {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return 0, errShardNotFound
+	}
+	exporter, ok := pr.sm.dataStorage.(storage.Exporter)
+	if !ok {
+		return 0, errExportNotSupported
+	}
+	return exporter.ExportShard(pr.getShard(), w, opts)
+}
@@ -26,6 +26,11 @@ import (
 
 // all raft message entrypoint
 func (s *store) handle(batch metapb.RaftMessageBatch) {
+	if s.driver != nil {
+		s.driver.addMessages(batch.Messages)
+		return
+	}
+
 	now := uint64(time.Now().UnixMilli())
 	for _, msg := range batch.Messages {
 		if now > msg.SendTime && now-msg.SendTime > 500 {
@@ -152,7 +157,16 @@ func (s *store) tryToCreateReplicate(msg metapb.RaftMessage) bool {
 		return false
 	}
 
-	if msg.From.Role == metapb.ReplicaRole_Learner {
+	if s.isDraining() {
+		s.logger.Info("skip create replica",
+			s.storeField(),
+			log.ReasonField("store draining"),
+			log.ShardIDField(msg.ShardID))
+		return false
+	}
+
+	if msg.From.Role == metapb.ReplicaRole_Learner ||
+		msg.From.Role == metapb.ReplicaRole_Observer {
 		s.logger.Fatal("received a learner vote/pre-vote message",
 			s.storeField(),
 			log.ShardIDField(msg.ShardID),
@@ -183,6 +197,17 @@ func (s *store) tryToCreateReplicate(msg metapb.RaftMessage) bool {
 		return false
 	}
 
+	// the tombstone of the shard's previous replica must be deleted before the
+	// new replica created, they share the same raft log and metadata keys
+	if s.tombstones.has(msg.ShardID) {
+		s.logger.Info("skip create replica",
+			s.storeField(),
+			log.ReasonField("tombstone replica not deleted"),
+			log.ShardIDField(msg.ShardID))
+		s.removeTombstone(msg.ShardID, "replica recreated")
+		return false
+	}
+
 	newReplicaCreator(s).
 		withReason(fmt.Sprintf("raft %s message from %d/%d/%s",
 			msg.Message.Type.String(),
@@ -0,0 +1,196 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sort"
+
+	"go.etcd.io/etcd/raft/v3"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+const (
+	// raftLogEntryWrite the normal entry with the write requests
+	raftLogEntryWrite = "write"
+	// raftLogEntryAdmin the normal entry with an admin request, e.g. split
+	raftLogEntryAdmin = "admin"
+	// raftLogEntryConfChange the conf change entry
+	raftLogEntryConfChange = "conf-change"
+	// raftLogEntryNoop the empty entry proposed by the new leader
+	raftLogEntryNoop = "noop"
+
+	raftLogStatsReadBytes = 4 * 1024 * 1024
+)
+
+var raftLogEntryTypes = []string{
+	raftLogEntryWrite,
+	raftLogEntryAdmin,
+	raftLogEntryConfChange,
+	raftLogEntryNoop,
+}
+
+// RaftLogStats is the breakdown of the raft log entries of a local replica
+// which are not compacted yet
+type RaftLogStats struct {
+	ShardID    uint64 `json:"shard-id"`
+	FirstIndex uint64 `json:"first-index"`
+	LastIndex  uint64 `json:"last-index"`
+	Entries    uint64 `json:"entries"`
+	Bytes      uint64 `json:"bytes"`
+	// Types the stats of each entry type, the types without entries are skipped
+	Types []RaftLogEntryStats `json:"types"`
+}
+
+// RaftLogEntryStats is the stats of the raft log entries of a type. The
+// percentiles are the payload sizes in bytes.
+type RaftLogEntryStats struct {
+	Type    string `json:"type"`
+	Entries uint64 `json:"entries"`
+	Bytes   uint64 `json:"bytes"`
+	P50     uint64 `json:"p50"`
+	P90     uint64 `json:"p90"`
+	P99     uint64 `json:"p99"`
+	Max     uint64 `json:"max"`
+}
+
+// getRaftLogEntryType returns the type of the entry. The req is the decoded
+// request batch of the normal entry.
+func getRaftLogEntryType(entry raftpb.Entry, req rpcpb.RequestBatch) string {
+	if isConfigChangeEntry(entry) {
+		return raftLogEntryConfChange
+	}
+	if len(entry.Data) == 0 {
+		return raftLogEntryNoop
+	}
+	if req.IsAdmin() {
+		return raftLogEntryAdmin
+	}
+	return raftLogEntryWrite
+}
+
+type raftLogStatsCollector struct {
+	sizes map[string][]uint64
+}
+
+func newRaftLogStatsCollector() *raftLogStatsCollector {
+	return &raftLogStatsCollector{sizes: make(map[string][]uint64)}
+}
+
+func (c *raftLogStatsCollector) add(entry raftpb.Entry) error {
+	var req rpcpb.RequestBatch
+	if entry.Type == raftpb.EntryNormal && len(entry.Data) > 0 {
+		if err := req.FastUnmarshal(entry.Data); err != nil {
+			return err
+		}
+	}
+	tp := getRaftLogEntryType(entry, req)
+	c.sizes[tp] = append(c.sizes[tp], uint64(len(entry.Data)))
+	return nil
+}
+
+func (c *raftLogStatsCollector) fill(stats *RaftLogStats) {
+	for _, tp := range raftLogEntryTypes {
+		sizes := c.sizes[tp]
+		if len(sizes) == 0 {
+			continue
+		}
+
+		sort.Slice(sizes, func(i, j int) bool { return sizes[i] < sizes[j] })
+		es := RaftLogEntryStats{
+			Type:    tp,
+			Entries: uint64(len(sizes)),
+			P50:     percentile(sizes, 50),
+			P90:     percentile(sizes, 90),
+			P99:     percentile(sizes, 99),
+			Max:     sizes[len(sizes)-1],
+		}
+		for _, size := range sizes {
+			es.Bytes += size
+		}
+		stats.Entries += es.Entries
+		stats.Bytes += es.Bytes
+		stats.Types = append(stats.Types, es)
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted values
+func percentile(sorted []uint64, p int) uint64 {
+	rank := (len(sorted)*p + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// getRaftLogStats reads all the raft log entries in the logdb, it's safe to
+// call from any goroutine as the LogReader is protected by its own lock.
+func (pr *replica) getRaftLogStats() (RaftLogStats, error) {
+	stats := RaftLogStats{ShardID: pr.shardID}
+	c := newRaftLogStatsCollector()
+	first, err := pr.lr.FirstIndex()
+	if err != nil {
+		return stats, err
+	}
+	last, err := pr.lr.LastIndex()
+	if err != nil {
+		return stats, err
+	}
+
+	low := first
+	for low <= last {
+		entries, err := pr.lr.Entries(low, last+1, raftLogStatsReadBytes)
+		if err == raft.ErrCompacted {
+			// compacted while reading, restart from the new first index to keep
+			// the stats consistent with the index range
+			newFirst, err := pr.lr.FirstIndex()
+			if err != nil {
+				return stats, err
+			}
+			if newFirst <= first {
+				return stats, raft.ErrCompacted
+			}
+			first, low = newFirst, newFirst
+			c = newRaftLogStatsCollector()
+			continue
+		}
+		if err != nil {
+			return stats, err
+		}
+		if len(entries) == 0 {
+			break
+		}
+		for _, entry := range entries {
+			if err := c.add(entry); err != nil {
+				return stats, err
+			}
+		}
+		low = entries[len(entries)-1].Index + 1
+	}
+
+	stats.FirstIndex = first
+	stats.LastIndex = last
+	c.fill(&stats)
+	return stats, nil
+}
+
+// GetRaftLogStats returns the breakdown of the raft log of the local replica
+func (s *store) GetRaftLogStats(shardID uint64) (RaftLogStats, error) {
+	pr := s.getReplica(shardID, false)
+	if pr == nil {
+		return RaftLogStats{}, errShardNotFound
+	}
+	return pr.getRaftLogStats()
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, uint64(5), percentile(values, 50))
+	assert.Equal(t, uint64(9), percentile(values, 90))
+	assert.Equal(t, uint64(10), percentile(values, 99))
+	assert.Equal(t, uint64(1), percentile(values[:1], 50))
+}
+
+func TestGetRaftLogStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	write := protoc.MustMarshal(&rpcpb.RequestBatch{
+		Requests: []rpcpb.Request{{Type: rpcpb.Write, Cmd: make([]byte, 100)}},
+	})
+	admin := protoc.MustMarshal(&rpcpb.RequestBatch{
+		Requests: []rpcpb.Request{{Type: rpcpb.Admin}},
+	})
+	cc := protoc.MustMarshal(&raftpb.ConfChangeV2{Context: admin})
+	ents := []raftpb.Entry{
+		{Index: 3, Term: 3},
+		{Index: 4, Term: 4},
+		{Index: 5, Term: 4, Data: write},
+		{Index: 6, Term: 4, Data: admin},
+		{Index: 7, Term: 4, Type: raftpb.EntryConfChangeV2, Data: cc},
+		{Index: 8, Term: 4, Data: write},
+	}
+	lr, closer := getTestLogReader(ents, vfs.NewMemFS())
+	defer closer()
+
+	pr := &replica{shardID: 1, lr: lr}
+	stats, err := pr.getRaftLogStats()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), stats.ShardID)
+	assert.Equal(t, uint64(4), stats.FirstIndex)
+	assert.Equal(t, uint64(8), stats.LastIndex)
+	assert.Equal(t, uint64(5), stats.Entries)
+	assert.Equal(t, uint64(2*len(write)+len(admin)+len(cc)), stats.Bytes)
+	assert.Equal(t, []RaftLogEntryStats{
+		{Type: raftLogEntryWrite, Entries: 2, Bytes: uint64(2 * len(write)),
+			P50: uint64(len(write)), P90: uint64(len(write)), P99: uint64(len(write)), Max: uint64(len(write))},
+		{Type: raftLogEntryAdmin, Entries: 1, Bytes: uint64(len(admin)),
+			P50: uint64(len(admin)), P90: uint64(len(admin)), P99: uint64(len(admin)), Max: uint64(len(admin))},
+		{Type: raftLogEntryConfChange, Entries: 1, Bytes: uint64(len(cc)),
+			P50: uint64(len(cc)), P90: uint64(len(cc)), P99: uint64(len(cc)), Max: uint64(len(cc))},
+		{Type: raftLogEntryNoop, Entries: 1},
+	}, stats.Types)
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"context"
+	"sort"
+)
+
+// ReplicaRaftStatus is the raft status of a local replica
+type ReplicaRaftStatus struct {
+	ShardID   uint64 `json:"shard-id"`
+	Group     uint64 `json:"group"`
+	ReplicaID uint64 `json:"replica-id"`
+	LeaderID  uint64 `json:"leader-id"`
+	Term      uint64 `json:"term"`
+	Commit    uint64 `json:"commit"`
+	Applied   uint64 `json:"applied"`
+	// PendingConfIndex the index of the conf change proposed but not applied,
+	// 0 if there is no pending conf change. Only set on the leader.
+	PendingConfIndex uint64 `json:"pending-conf-index,omitempty"`
+	// Progress the replication progress of the replicas, only set on the leader
+	Progress []PeerProgress `json:"progress,omitempty"`
+}
+
+// PeerProgress is the replication progress of a replica tracked by the leader
+type PeerProgress struct {
+	ReplicaID    uint64 `json:"replica-id"`
+	Match        uint64 `json:"match"`
+	Next         uint64 `json:"next"`
+	State        string `json:"state"`
+	RecentActive bool   `json:"recent-active"`
+	IsLearner    bool   `json:"is-learner"`
+}
+
+// getRaftStatus must be called in the event worker, as the raft status is not
+// thread safe.
+func (pr *replica) getRaftStatus() ReplicaRaftStatus {
+	status := pr.rn.Status()
+	rs := ReplicaRaftStatus{
+		ShardID:   pr.shardID,
+		Group:     pr.group,
+		ReplicaID: pr.replicaID,
+		LeaderID:  status.Lead,
+		Term:      status.Term,
+		Commit:    status.Commit,
+		Applied:   pr.appliedIndex,
+	}
+	if len(status.Progress) == 0 {
+		return rs
+	}
+
+	if idx := pr.rn.PendingConfIndex(); idx > pr.appliedIndex {
+		rs.PendingConfIndex = idx
+	}
+	rs.Progress = make([]PeerProgress, 0, len(status.Progress))
+	for id, p := range status.Progress {
+		rs.Progress = append(rs.Progress, PeerProgress{
+			ReplicaID:    id,
+			Match:        p.Match,
+			Next:         p.Next,
+			State:        p.State.String(),
+			RecentActive: p.RecentActive,
+			IsLearner:    p.IsLearner,
+		})
+	}
+	sort.Slice(rs.Progress, func(i, j int) bool {
+		return rs.Progress[i].ReplicaID < rs.Progress[j].ReplicaID
+	})
+	return rs
+}
+
+func (s *store) GetRaftStatuses() []ReplicaRaftStatus {
+	ctx, cancel := context.WithTimeout(context.Background(), debugInfoTimeout)
+	defer cancel()
+	return s.getRaftStatuses(ctx)
+}
+
+func (s *store) getRaftStatuses(ctx context.Context) []ReplicaRaftStatus {
+	values := s.collectFromReplicas(ctx, raftStatusAction, "raft status")
+	statuses := make([]ReplicaRaftStatus, 0, len(values))
+	for _, v := range values {
+		statuses = append(statuses, v.(ReplicaRaftStatus))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ShardID < statuses[j].ShardID
+	})
+	return statuses
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRaftStatuses(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewSingleTestClusterStore(t)
+	c.Start()
+	defer c.Stop()
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	c.WaitLeadersByCount(1, testWaitTimeout)
+
+	s := c.GetStore(0)
+	statuses := s.GetRaftStatuses()
+	require.Equal(t, 1, len(statuses))
+	rs := statuses[0]
+	assert.Equal(t, rs.ReplicaID, rs.LeaderID)
+	assert.True(t, rs.Term > 0)
+	assert.True(t, rs.Commit >= rs.Applied)
+	assert.Equal(t, uint64(0), rs.PendingConfIndex)
+	require.Equal(t, 1, len(rs.Progress))
+	assert.Equal(t, rs.ReplicaID, rs.Progress[0].ReplicaID)
+	assert.Equal(t, "StateReplicate", rs.Progress[0].State)
+	assert.True(t, rs.Progress[0].Match > 0)
+
+	w := httptest.NewRecorder()
+	s.(*store).debugHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/raft", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var values []ReplicaRaftStatus
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &values))
+	require.Equal(t, 1, len(values))
+	assert.Equal(t, rs.ShardID, values[0].ShardID)
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"sync"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/components/prophet/util/typeutil"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"go.uber.org/zap"
+)
+
+const (
+	// snapshotSendRateSettingKey overrides the `snapshot.send-bytes-per-second`
+	// of the store config, the value is a byte size, e.g. `64MB`.
+	snapshotSendRateSettingKey = "snapshot.send-bytes-per-second"
+)
+
+// clusterSettings keeps the latest version of the cluster-wide settings, which
+// are received from the prophet watcher. The settings with a registered
+// handler are applied to the store once they are changed.
+type clusterSettings struct {
+	logger *zap.Logger
+
+	mu struct {
+		sync.RWMutex
+		settings map[string]metapb.ClusterSetting
+		handlers map[string]func(value string) error
+	}
+}
+
+func newClusterSettings(logger *zap.Logger) *clusterSettings {
+	cs := &clusterSettings{logger: log.Adjust(logger)}
+	cs.mu.settings = make(map[string]metapb.ClusterSetting)
+	cs.mu.handlers = make(map[string]func(value string) error)
+	return cs
+}
+
+// register registers the handler to apply the setting of the key
+func (cs *clusterSettings) register(key string, handler func(value string) error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.mu.handlers[key] = handler
+}
+
+func (cs *clusterSettings) get(key string) (string, bool) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	setting, ok := cs.mu.settings[key]
+	return setting.Value, ok
+}
+
+// update updates the settings, the outdated ones are ignored as the events of
+// the old prophet leader may be received after the init event of the new one.
+func (cs *clusterSettings) update(settings []metapb.ClusterSetting) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for _, setting := range settings {
+		if old, ok := cs.mu.settings[setting.Key]; ok &&
+			old.Version >= setting.Version {
+			continue
+		}
+		cs.mu.settings[setting.Key] = setting
+
+		handler, ok := cs.mu.handlers[setting.Key]
+		if !ok {
+			continue
+		}
+		if err := handler(setting.Value); err != nil {
+			cs.logger.Error("fail to apply cluster setting",
+				zap.String("key", setting.Key),
+				zap.String("value", setting.Value),
+				zap.Uint64("version", setting.Version),
+				zap.Error(err))
+			continue
+		}
+		cs.logger.Info("cluster setting applied",
+			zap.String("key", setting.Key),
+			zap.String("value", setting.Value),
+			zap.Uint64("version", setting.Version))
+	}
+}
+
+func (s *store) GetClusterSetting(key string) (string, bool) {
+	return s.settings.get(key)
+}
+
+func (s *store) registerClusterSettingHandlers(setSnapshotRateLimit func(uint64)) {
+	s.settings.register(snapshotSendRateSettingKey, func(value string) error {
+		var size typeutil.ByteSize
+		if err := size.UnmarshalText([]byte(value)); err != nil {
+			return err
+		}
+		setSnapshotRateLimit(uint64(size))
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterSettingsUpdate(t *testing.T) {
+	cs := newClusterSettings(nil)
+	s := &store{settings: cs}
+
+	var rate uint64
+	s.registerClusterSettingHandlers(func(v uint64) { rate = v })
+
+	cs.update([]metapb.ClusterSetting{
+		{Key: "k1", Value: "v1", Version: 2},
+		{Key: snapshotSendRateSettingKey, Value: "1KB", Version: 1},
+	})
+	v, ok := s.GetClusterSetting("k1")
+	assert.True(t, ok)
+	assert.Equal(t, "v1", v)
+	assert.Equal(t, uint64(1024), rate)
+
+	// the outdated settings are ignored
+	cs.update([]metapb.ClusterSetting{
+		{Key: "k1", Value: "v0", Version: 1},
+		{Key: snapshotSendRateSettingKey, Value: "2KB", Version: 1},
+	})
+	v, _ = s.GetClusterSetting("k1")
+	assert.Equal(t, "v1", v)
+	assert.Equal(t, uint64(1024), rate)
+
+	// the invalid setting is kept but not applied
+	cs.update([]metapb.ClusterSetting{{Key: snapshotSendRateSettingKey, Value: "abc", Version: 2}})
+	v, _ = s.GetClusterSetting(snapshotSendRateSettingKey)
+	assert.Equal(t, "abc", v)
+	assert.Equal(t, uint64(1024), rate)
+
+	cs.update([]metapb.ClusterSetting{{Key: snapshotSendRateSettingKey, Value: "4KB", Version: 3}})
+	assert.Equal(t, uint64(4096), rate)
+
+	_, ok = s.GetClusterSetting("k2")
+	assert.False(t, ok)
+}
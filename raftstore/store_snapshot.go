@@ -0,0 +1,90 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/snapshot"
+	"github.com/matrixorigin/matrixcube/transport"
+	"github.com/matrixorigin/matrixcube/util/fileutil"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+// removeSnapshotStagingDirs removes the temp dirs of the snapshots being
+// generated or received when the store was stopped. The replicas only clean
+// up their own snapshot dirs when they are started, this covers the replicas
+// which are removed or never started again.
+func (s *store) removeSnapshotStagingDirs() {
+	root := s.cfg.FS.PathJoin(s.cfg.DataPath, snapshotDirName)
+	if err := removeSnapshotStagingDirs(s.cfg.FS, root, s.logger); err != nil {
+		s.logger.Fatal("failed to remove snapshot staging dirs",
+			s.storeField(),
+			zap.String("dir", root),
+			zap.Error(err))
+	}
+}
+
+func removeSnapshotStagingDirs(fs vfs.FS, root string, logger *zap.Logger) error {
+	replicaDirs, err := fs.List(root)
+	if err != nil {
+		if vfs.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, replicaDir := range replicaDirs {
+		replicaDir = fs.PathJoin(root, replicaDir)
+		if fi, err := fs.Stat(replicaDir); err != nil {
+			return err
+		} else if !fi.IsDir() {
+			continue
+		}
+
+		names, err := fs.List(replicaDir)
+		if err != nil {
+			return err
+		}
+		removed := false
+		for _, name := range names {
+			if !snapshot.IsTempSnapshotDir(name) {
+				continue
+			}
+			dir := fs.PathJoin(replicaDir, name)
+			logger.Info("removing snapshot staging dir",
+				zap.String("dir", dir))
+			if err := fs.RemoveAll(dir); err != nil {
+				return err
+			}
+			removed = true
+		}
+		if removed {
+			if err := fileutil.SyncDir(replicaDir, fs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getSnapshotPriority returns the repair priority for the snapshots of the
+// shards below their replica target, so the fault recovery is not delayed by
+// the rebalancing.
+func (s *store) getSnapshotPriority(m metapb.RaftMessage) transport.SnapshotPriority {
+	if pr := s.getReplica(m.ShardID, false); pr != nil && pr.isUnderReplicated() {
+		return transport.SnapshotPriorityRepair
+	}
+	return transport.SnapshotPriorityNormal
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/snapshot"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+func TestRemoveSnapshotStagingDirs(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	root := "snapshot-staging-dir-safe-to-delete"
+	require.NoError(t, fs.RemoveAll(root))
+	defer func() {
+		require.NoError(t, fs.RemoveAll(root))
+	}()
+
+	// not exist
+	assert.NoError(t, removeSnapshotStagingDirs(fs, root, log.GetDefaultZapLogger()))
+
+	dirFunc := func(shardID, replicaID uint64) string {
+		return fs.PathJoin(root, "shard-1-replica-1")
+	}
+	require.NoError(t, fs.MkdirAll(dirFunc(1, 1), 0755))
+	generating := snapshot.NewSSEnv(dirFunc, 1, 1, 0, 2, snapshot.CreatingMode, fs)
+	require.NoError(t, generating.CreateTempDir())
+	receiving := snapshot.NewSSEnv(dirFunc, 1, 1, 10, 3, snapshot.ReceivingMode, fs)
+	require.NoError(t, receiving.CreateTempDir())
+	final := snapshot.NewSSEnv(dirFunc, 1, 1, 0, 4, snapshot.CreatingMode, fs)
+	require.NoError(t, final.CreateTempDir())
+	final.FinalizeIndex(5)
+	require.NoError(t, final.FinalizeSnapshot())
+
+	assert.NoError(t, removeSnapshotStagingDirs(fs, root, log.GetDefaultZapLogger()))
+	names, err := fs.List(dirFunc(1, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{fs.PathBase(final.GetFinalDir())}, names)
+}
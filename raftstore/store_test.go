@@ -14,11 +14,13 @@
 package raftstore
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
 	"github.com/fagongzi/util/protoc"
 	"github.com/juju/ratelimit"
+	"github.com/matrixorigin/matrixcube/config"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
@@ -39,6 +41,30 @@ func TestStartAndStop(t *testing.T) {
 	defer c.Stop()
 }
 
+func TestStartAndStopWithFsyncPools(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	c := NewSingleTestClusterStore(t, WithAppendTestClusterAdjustConfigFunc(func(node int, cfg *config.Config) {
+		cfg.Fsync.Enable = true
+		cfg.Fsync.WALWorkers = 1
+		cfg.Fsync.DataWorkers = 1
+	}))
+	c.Start()
+	defer c.Stop()
+
+	s := c.GetStore(0).(*store)
+	assert.NotNil(t, s.walSyncPool)
+	assert.NotNil(t, s.dataSyncPool)
+
+	c.WaitShardByCountPerNode(1, testWaitTimeout)
+	kv := c.CreateTestKVClient(0)
+	defer kv.Close()
+	assert.NoError(t, kv.Set("key", "value", testWaitTimeout))
+	v, err := kv.Get("key", testWaitTimeout)
+	assert.NoError(t, err)
+	assert.Equal(t, "value", v)
+}
+
 func TestSearchShard(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -148,6 +174,7 @@ func TestValidateShard(t *testing.T) {
 		pr    *replica
 		req   rpcpb.RequestBatch
 		epoch Epoch
+		gate  metapb.ShardGate
 		err   string
 		ok    bool
 	}{
@@ -163,6 +190,11 @@ func TestValidateShard(t *testing.T) {
 			err: errNotLeader.Error(),
 			ok:  true,
 		},
+		{
+			pr:  &replica{shardID: 1, replica: Replica{ID: 1}, leaderID: 2, startedC: make(chan struct{}), actions: task.New(32)},
+			req: rpcpb.RequestBatch{Header: rpcpb.RequestBatchHeader{ShardID: 1, Replica: Replica{ID: 1}}, Requests: []rpcpb.Request{{Type: rpcpb.Read, FollowerRead: true}}},
+			ok:  false,
+		},
 		// FIXME:
 		// the way how expected err is defined & checked below is incorrect, should
 		// define an error type, e.g. ErrMismatchedReplica, use errors.Wrapf to attach
@@ -181,6 +213,20 @@ func TestValidateShard(t *testing.T) {
 			err:   errStaleEpoch.Error(),
 			ok:    true,
 		},
+		{
+			pr:   &replica{shardID: 1, replica: Replica{ID: 1}, leaderID: 1, startedC: make(chan struct{}), actions: task.New(32)},
+			gate: metapb.ShardGate{DisableWrite: true},
+			req:  rpcpb.RequestBatch{Header: rpcpb.RequestBatchHeader{ShardID: 1, Replica: Replica{ID: 1}}, Requests: []rpcpb.Request{{Type: rpcpb.Write}}},
+			err:  errShardWriteDisabled.Error(),
+			ok:   true,
+		},
+		{
+			pr:   &replica{shardID: 1, replica: Replica{ID: 1}, leaderID: 1, startedC: make(chan struct{}), actions: task.New(32)},
+			gate: metapb.ShardGate{DisableRead: true, DisableWrite: true},
+			req:  rpcpb.RequestBatch{Header: rpcpb.RequestBatchHeader{ShardID: 1, Replica: Replica{ID: 1}}, Requests: []rpcpb.Request{{Type: rpcpb.Write}}},
+			err:  errShardDisabled.Error(),
+			ok:   true,
+		},
 	}
 
 	for idx, c := range cases {
@@ -190,7 +236,7 @@ func TestValidateShard(t *testing.T) {
 			c.pr.store = s
 			c.pr.replicaID = c.pr.replica.ID
 			c.pr.sm = &stateMachine{}
-			c.pr.sm.metadataMu.shard = Shard{ID: c.pr.shardID, Epoch: c.epoch}
+			c.pr.sm.metadataMu.shard = Shard{ID: c.pr.shardID, Epoch: c.epoch, Gate: c.gate}
 			close(c.pr.startedC)
 			s.addReplica(c.pr)
 
@@ -348,13 +394,17 @@ func TestGetStoreHeartbeat(t *testing.T) {
 	s, cancel := newTestStore(t)
 	defer cancel()
 
-	s.addReplica(&replica{shardID: 1})
-	s.addReplica(&replica{shardID: 2})
+	s.addReplica(&replica{shardID: 1, sm: &stateMachine{}})
+	pr := &replica{shardID: 2, sm: &stateMachine{}}
+	pr.sm.consistency.setInconsistent(&metapb.InconsistentShard{ShardID: 2, Index: 10, Hash: 1, ExpectHash: 2})
+	s.addReplica(pr)
 	s.trans = transport.NewTransport(nil, "", 0, nil, nil, nil, nil, nil, s.cfg.FS)
 	defer s.trans.Close()
 	req, err := s.getStoreHeartbeat(time.Now())
 	assert.NoError(t, err)
 	assert.Equal(t, uint64(2), req.Stats.ShardCount)
+	assert.Equal(t, []metapb.InconsistentShard{{ShardID: 2, Index: 10, Hash: 1, ExpectHash: 2}},
+		req.Stats.InconsistentShards)
 }
 
 func TestDoShardHeartbeatRsp(t *testing.T) {
@@ -489,3 +539,88 @@ func TestOnRequestWithLease(t *testing.T) {
 		}()
 	}
 }
+
+func TestSetShardReadOnly(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1}, Replica{ID: 1}, s)
+	s.addReplica(pr)
+
+	assert.Equal(t, errShardNotFound, s.SetShardReadOnly(2, true))
+	assert.Equal(t, errNotLeader, s.SetShardReadOnly(1, true))
+
+	pr.leaderID = 1
+	pr.sm.metadataMu.shard = Shard{ID: 1, Gate: metapb.ShardGate{DisableRead: true, Redirect: "r"}}
+	assert.NoError(t, s.SetShardReadOnly(1, true))
+	assert.NoError(t, s.SetShardReadOnly(1, false))
+	items := make([]interface{}, 2)
+	n, err := pr.requests.Get(2, items)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	var gates []metapb.ShardGate
+	for _, v := range items {
+		req := v.(reqCtx).req
+		assert.Equal(t, uint64(rpcpb.CmdUpdateGate), req.CustomType)
+		updateReq := &rpcpb.UpdateGateRequest{}
+		protoc.MustUnmarshal(updateReq, req.Cmd)
+		gates = append(gates, updateReq.Gate)
+	}
+	// the other gate settings are kept
+	assert.Equal(t, metapb.ShardGate{DisableRead: true, DisableWrite: true, Redirect: "r", Permanent: true}, gates[0])
+	assert.Equal(t, metapb.ShardGate{DisableRead: true, Redirect: "r"}, gates[1])
+}
+
+func TestTransferLeader(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1, Replicas: []Replica{
+		{ID: 1, StoreID: 1},
+		{ID: 2, StoreID: 2},
+		{ID: 3, StoreID: 3, Role: metapb.ReplicaRole_Learner},
+	}}, Replica{ID: 1}, s)
+	s.addReplica(pr)
+
+	assert.Equal(t, errShardNotFound, s.TransferLeader(2, 2))
+	assert.Equal(t, errNotLeader, s.TransferLeader(1, 2))
+
+	pr.leaderID = 1
+	assert.Equal(t, errInvalidTransferee, s.TransferLeader(1, 3))
+	assert.Equal(t, errInvalidTransferee, s.TransferLeader(1, 4))
+	assert.NoError(t, s.TransferLeader(1, 2))
+	v, err := pr.requests.Peek()
+	assert.NoError(t, err)
+	req := v.(reqCtx).req
+	assert.Equal(t, uint64(rpcpb.CmdTransferLeader), req.CustomType)
+	transferReq := &rpcpb.TransferLeaderRequest{}
+	protoc.MustUnmarshal(transferReq, req.Cmd)
+	assert.Equal(t, uint64(2), transferReq.Replica.ID)
+}
+
+func TestExportShard(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1, Start: []byte("a"), End: []byte("b"),
+		Replicas: []Replica{{ID: 1, StoreID: 1}}}, Replica{ID: 1}, s)
+	s.addReplica(pr)
+	kv := s.DataStorageByGroup(0).(storage.KVStorageWrapper).GetKVStorage()
+	assert.NoError(t, kv.Set(keysutil.EncodeDataKey([]byte("a1"), nil), []byte("v1"), false))
+	assert.NoError(t, kv.Set(keysutil.EncodeDataKey([]byte("b1"), nil), []byte("v2"), false))
+
+	var buf bytes.Buffer
+	_, err := s.ExportShard(2, &buf, storage.ExportOptions{Format: storage.ExportCSV})
+	assert.Equal(t, errShardNotFound, err)
+
+	n, err := s.ExportShard(1, &buf, storage.ExportOptions{Format: storage.ExportCSV})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+	assert.Equal(t, "key,value\nYTE=,djE=\n", buf.String())
+}
@@ -22,27 +22,65 @@ import (
 	"go.uber.org/zap"
 )
 
-func (s *store) startTimerTasks() {
-	s.stopper.RunWorker(func() {
-		last := time.Now()
+// timerTask is a periodic task of the store
+type timerTask struct {
+	interval time.Duration
+	next     time.Time
+	fn       func(now time.Time)
+}
 
-		stateCheckTicker := time.NewTicker(s.cfg.Replication.ShardStateCheckDuration.Duration)
-		defer stateCheckTicker.Stop()
+// timerTasks runs the periodic tasks serially. The tasks are driven by the wall
+// clock in a worker, or by the DeterministicDriver in deterministic mode.
+type timerTasks struct {
+	tasks []*timerTask
+}
 
-		shardLeaderheartbeatTicker := time.NewTicker(s.cfg.Replication.ShardHeartbeatDuration.Duration)
-		defer shardLeaderheartbeatTicker.Stop()
+func (t *timerTasks) add(now time.Time, interval time.Duration, fn func(now time.Time)) {
+	if interval <= 0 {
+		return
+	}
+	t.tasks = append(t.tasks, &timerTask{interval: interval, next: now.Add(interval), fn: fn})
+}
 
-		storeheartbeatTicker := time.NewTicker(s.cfg.Replication.StoreHeartbeatDuration.Duration)
-		defer storeheartbeatTicker.Stop()
+// runDue runs the tasks which are due in the order they were added, the missed
+// runs are dropped like the ticks of a time.Ticker.
+func (t *timerTasks) runDue(now time.Time) {
+	for _, task := range t.tasks {
+		if task.next.After(now) {
+			continue
+		}
+		for !task.next.After(now) {
+			task.next = task.next.Add(task.interval)
+		}
+		task.fn(now)
+	}
+}
 
-		compactLogCheckTicker := time.NewTicker(s.cfg.Replication.CompactLogCheckDuration.Duration)
-		defer compactLogCheckTicker.Stop()
+// nextDue returns the time the first task is due
+func (t *timerTasks) nextDue() time.Time {
+	var next time.Time
+	for _, task := range t.tasks {
+		if next.IsZero() || task.next.Before(next) {
+			next = task.next
+		}
+	}
+	return next
+}
 
-		refreshScheduleGroupRuleTicker := time.NewTicker(time.Second * 30)
-		defer refreshScheduleGroupRuleTicker.Stop()
+// runTimerTasks runs the timer tasks until the store is stopped, they are run
+// by the DeterministicDriver in deterministic mode.
+func (s *store) runTimerTasks(tasks *timerTasks) {
+	if len(tasks.tasks) == 0 {
+		return
+	}
+	if s.driver != nil {
+		s.driver.addTimerTasks(tasks)
+		return
+	}
 
-		debugTicker := time.NewTicker(time.Second * 10)
-		defer debugTicker.Stop()
+	s.stopper.RunWorker(func() {
+		timer := time.NewTimer(time.Until(tasks.nextDue()))
+		defer timer.Stop()
 
 		for {
 			select {
@@ -50,45 +88,70 @@ func (s *store) startTimerTasks() {
 				s.logger.Info("timer based tasks stopped",
 					s.storeField())
 				return
-			case <-compactLogCheckTicker.C:
-				s.handleCompactLogTask()
-			case <-stateCheckTicker.C:
-				s.handleShardStateCheckTask()
-			case <-shardLeaderheartbeatTicker.C:
-				s.handleShardHeartbeatTask()
-			case <-storeheartbeatTicker.C:
-				s.handleStoreHeartbeatTask(last)
-				last = time.Now()
-			case <-refreshScheduleGroupRuleTicker.C:
-				s.handleRefreshScheduleGroupRule()
-			case <-debugTicker.C:
-				s.doLogDebugInfo()
+			case now := <-timer.C:
+				tasks.runDue(now)
+				timer.Reset(time.Until(tasks.nextDue()))
 			}
 		}
 	})
+}
 
-	s.cfg.Storage.ForeachDataStorageFunc(func(group uint64, ds storage.DataStorage) {
-		s.stopper.RunWorker(func() {
-			policy := ds.Feature()
-			if policy.DisableShardSplit {
-				return
-			}
-
-			splitCheckTicker := time.NewTicker(policy.ShardSplitCheckDuration)
-			defer splitCheckTicker.Stop()
+func (s *store) startTimerTasks() {
+	now := s.now()
+	last := now
+	tasks := &timerTasks{}
+	tasks.add(now, s.cfg.Replication.CompactLogCheckDuration.Duration, func(time.Time) {
+		s.handleCompactLogTask()
+	})
+	tasks.add(now, s.cfg.Replication.ShardStateCheckDuration.Duration, func(now time.Time) {
+		s.handleShardStateCheckTask()
+		s.handleTombstoneGCTask(now)
+		s.handleTTLGCTask()
+	})
+	tasks.add(now, s.cfg.Replication.ShardHeartbeatDuration.Duration, func(time.Time) {
+		s.handleShardHeartbeatTask()
+	})
+	tasks.add(now, s.cfg.Replication.StoreHeartbeatDuration.Duration, func(now time.Time) {
+		s.handleStoreHeartbeatTask(last)
+		s.handleApplyCPUSampleTask()
+		last = now
+	})
+	tasks.add(now, time.Second*30, func(time.Time) {
+		s.handleRefreshScheduleGroupRule()
+	})
+	tasks.add(now, time.Second*10, func(time.Time) {
+		s.doLogDebugInfo()
+	})
+	tasks.add(now, s.cfg.QoS.RefreshInterval.Duration, func(now time.Time) {
+		s.ioScheduler.refresh(now)
+	})
+	tasks.add(now, s.cfg.AutoTune.Interval.Duration, func(now time.Time) {
+		s.batchTuner.refresh(now)
+	})
+	s.runTimerTasks(tasks)
 
-			for {
-				select {
-				case <-s.stopper.ShouldStop():
-					s.logger.Info("timer based tasks stopped",
-						s.storeField())
-					return
+	s.cfg.Storage.ForeachDataStorageFunc(func(group uint64, ds storage.DataStorage) {
+		dc, ok := ds.(storage.DictionaryCompressor)
+		if !ok || ds.Feature().DictionaryTrainDuration == 0 {
+			return
+		}
+		tasks := &timerTasks{}
+		tasks.add(now, ds.Feature().DictionaryTrainDuration, func(time.Time) {
+			s.handleDictionaryTrainTask(group, dc)
+		})
+		s.runTimerTasks(tasks)
+	})
 
-				case <-splitCheckTicker.C:
-					s.handleSplitCheckTask(group)
-				}
-			}
+	s.cfg.Storage.ForeachDataStorageFunc(func(group uint64, ds storage.DataStorage) {
+		policy := ds.Feature()
+		if policy.DisableShardSplit {
+			return
+		}
+		tasks := &timerTasks{}
+		tasks.add(now, policy.ShardSplitCheckDuration, func(time.Time) {
+			s.handleSplitCheckTask(group)
 		})
+		s.runTimerTasks(tasks)
 	})
 }
 
@@ -139,6 +202,10 @@ func (s *store) handleSplitCheckTask(group uint64) {
 		if pr.group == group &&
 			pr.isLeader() {
 			pr.addAction(action{actionType: checkSplitAction, actionCallback: func(arg interface{}) {
+				if task, ok := arg.(splitCheckTask); ok {
+					s.splitChecker.addTask(task)
+					return
+				}
 				s.splitChecker.add(arg.(Shard))
 			}})
 		}
@@ -0,0 +1,203 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/metric"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+)
+
+// tombstoneReplica is a replica removed from the shard, its raft log, data and
+// metadata are kept until prophet confirms that it's safe to delete them.
+type tombstoneReplica struct {
+	shard      Shard
+	replicaID  uint64
+	removeData bool
+	// size the approximate size of the shard data, 0 if unknown
+	size  uint64
+	since time.Time
+	// removing the tombstone has been handed over to the vacuum cleaner
+	removing bool
+}
+
+// tombstoneGC tracks the tombstone replicas in the store. The replicas removed
+// by config change, or found stale, are not deleted immediately, so that the
+// data is still there if the removal turns out to be wrong, e.g. prophet is
+// rolled back to an old state. The tombstones are deleted after the grace
+// period once prophet confirms.
+type tombstoneGC struct {
+	gracePeriod time.Duration
+
+	mu struct {
+		sync.Mutex
+		replicas map[uint64]*tombstoneReplica // shard id -> tombstone
+	}
+}
+
+func newTombstoneGC(gracePeriod time.Duration) *tombstoneGC {
+	gc := &tombstoneGC{gracePeriod: gracePeriod}
+	gc.mu.replicas = make(map[uint64]*tombstoneReplica)
+	return gc
+}
+
+func (gc *tombstoneGC) enabled() bool {
+	return gc.gracePeriod > 0
+}
+
+func (gc *tombstoneGC) add(t tombstoneReplica) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	gc.mu.replicas[t.shard.ID] = &t
+	metric.SetTombstonesOnStore(len(gc.mu.replicas))
+}
+
+// has returns true if the shard has a tombstone replica which is not deleted
+// yet, the shard's replica can not be recreated until the tombstone is deleted.
+func (gc *tombstoneGC) has(shardID uint64) bool {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	_, ok := gc.mu.replicas[shardID]
+	return ok
+}
+
+// expired returns the tombstones kept for more than the grace period
+func (gc *tombstoneGC) expired(now time.Time) []rpcpb.TombstoneReplica {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	var replicas []rpcpb.TombstoneReplica
+	for _, t := range gc.mu.replicas {
+		if t.removing || now.Sub(t.since) < gc.gracePeriod {
+			continue
+		}
+		replicas = append(replicas, rpcpb.TombstoneReplica{
+			ShardID:   t.shard.ID,
+			ReplicaID: t.replicaID,
+			Epoch:     t.shard.Epoch,
+		})
+	}
+	sort.Slice(replicas, func(i, j int) bool {
+		return replicas[i].ShardID < replicas[j].ShardID
+	})
+	return replicas
+}
+
+// take marks the tombstone as removing, returns false if the tombstone is not
+// found or it's already being removed.
+func (gc *tombstoneGC) take(shardID uint64) (tombstoneReplica, bool) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	t, ok := gc.mu.replicas[shardID]
+	if !ok || t.removing {
+		return tombstoneReplica{}, false
+	}
+	t.removing = true
+	return *t, true
+}
+
+func (gc *tombstoneGC) remove(shardID uint64) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	delete(gc.mu.replicas, shardID)
+	metric.SetTombstonesOnStore(len(gc.mu.replicas))
+}
+
+func (gc *tombstoneGC) size(shardID uint64) uint64 {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	if t, ok := gc.mu.replicas[shardID]; ok {
+		return t.size
+	}
+	return 0
+}
+
+// handleTombstoneGCTask asks prophet whether the expired tombstones are safe to
+// delete, and deletes the confirmed ones.
+func (s *store) handleTombstoneGCTask(now time.Time) {
+	if !s.tombstones.enabled() {
+		return
+	}
+	replicas := s.tombstones.expired(now)
+	if len(replicas) == 0 {
+		return
+	}
+
+	removable, err := s.pd.GetClient().CheckTombstoneReplicas(replicas)
+	if err != nil {
+		s.logger.Error("fail to check tombstone replicas",
+			s.storeField(),
+			zap.Error(err))
+		return
+	}
+	for _, id := range removable.ToArray() {
+		s.removeTombstone(id, "tombstone-gc")
+	}
+}
+
+// removeTombstone hands over the tombstone to the vacuum cleaner
+func (s *store) removeTombstone(shardID uint64, reason string) {
+	t, ok := s.tombstones.take(shardID)
+	if !ok {
+		return
+	}
+	s.vacuumCleaner.addTask(vacuumTask{
+		shard:      t.shard,
+		removeData: t.removeData,
+		tombstone:  true,
+		reason:     reason,
+		size:       t.size,
+	})
+}
+
+// addTombstone keeps the destroyed replica as a tombstone, the shard is removed
+// from the store, but its raft log, data and metadata are kept.
+func (s *store) addTombstone(t tombstoneReplica) {
+	s.logger.Info("replica kept as tombstone",
+		s.storeField(),
+		log.ShardIDField(t.shard.ID),
+		log.ReplicaIDField(t.replicaID),
+		log.EpochField("epoch", t.shard.Epoch))
+	s.tombstones.add(t)
+	s.removeReplica(t.shard)
+}
+
+// hasOverlappedReplica returns true if any replica in the store overlaps the
+// key range of the shard. The data range of a tombstone must not be deleted if
+// the range is owned by another replica now.
+func (s *store) hasOverlappedReplica(shard Shard) bool {
+	overlapped := false
+	s.forEachReplica(func(pr *replica) bool {
+		current := pr.getShard()
+		if current.ID != shard.ID &&
+			current.Group == shard.Group &&
+			(len(shard.End) == 0 || bytes.Compare(current.Start, shard.End) < 0) &&
+			(len(current.End) == 0 || bytes.Compare(shard.Start, current.End) < 0) {
+			overlapped = true
+		}
+		return !overlapped
+	})
+	return overlapped
+}
+
+func (s *store) tombstoneRemoved(shardID uint64) {
+	metric.AddTombstoneGCReclaimed(1, s.tombstones.size(shardID))
+	s.tombstones.remove(shardID)
+}
@@ -0,0 +1,168 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/util/stop"
+	"github.com/matrixorigin/matrixcube/util/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTombstoneGC(t *testing.T) {
+	gc := newTombstoneGC(time.Minute)
+	assert.True(t, gc.enabled())
+	assert.False(t, newTombstoneGC(0).enabled())
+
+	now := time.Now()
+	epoch := metapb.ShardEpoch{ConfigVer: 2, Generation: 1}
+	gc.add(tombstoneReplica{shard: Shard{ID: 2, Epoch: epoch}, replicaID: 20, since: now.Add(-time.Hour)})
+	gc.add(tombstoneReplica{shard: Shard{ID: 1, Epoch: epoch}, replicaID: 10, since: now.Add(-time.Hour)})
+	gc.add(tombstoneReplica{shard: Shard{ID: 3, Epoch: epoch}, replicaID: 30, since: now})
+	assert.True(t, gc.has(1))
+	assert.False(t, gc.has(4))
+	assert.Equal(t, []rpcpb.TombstoneReplica{
+		{ShardID: 1, ReplicaID: 10, Epoch: epoch},
+		{ShardID: 2, ReplicaID: 20, Epoch: epoch},
+	}, gc.expired(now))
+
+	_, ok := gc.take(1)
+	assert.True(t, ok)
+	_, ok = gc.take(1)
+	assert.False(t, ok)
+	_, ok = gc.take(4)
+	assert.False(t, ok)
+	// the tombstone being removed is not expired again, but still blocks the
+	// recreation of the replica
+	assert.Equal(t, 1, len(gc.expired(now)))
+	assert.True(t, gc.has(1))
+
+	gc.remove(1)
+	assert.False(t, gc.has(1))
+}
+
+func TestDestroyReplicaWithTombstoneGC(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	r := Replica{ID: 1}
+	s, cancel := newTestStore(t)
+	defer cancel()
+	s.tombstones = newTombstoneGC(time.Hour)
+	kv := s.DataStorageByGroup(0).(storage.KVStorageWrapper).GetKVStorage()
+	assert.NoError(t, kv.Set(keysutil.EncodeDataKey([]byte("a1"), nil), []byte("hello-a1"), false))
+	assert.NoError(t, kv.Set(keysutil.EncodeDataKey([]byte("a2"), nil), []byte("hello-a2"), false))
+
+	shard := Shard{
+		ID:       1,
+		Start:    []byte("a"),
+		End:      []byte("b"),
+		Replicas: []Replica{r},
+	}
+	scan := func() int {
+		count := 0
+		assert.NoError(t, kv.Scan(keysutil.EncodeShardStart(shard.Start, nil), keysutil.EncodeShardStart(shard.End, nil), func(key, value []byte) (bool, error) {
+			count++
+			return true, nil
+		}, false))
+		return count
+	}
+
+	pr := &replica{
+		shardID:           1,
+		replica:           r,
+		replicaID:         r.ID,
+		startedC:          make(chan struct{}),
+		closedC:           make(chan struct{}),
+		destroyedC:        make(chan struct{}),
+		unloadedC:         make(chan struct{}),
+		store:             s,
+		logger:            s.logger,
+		ticks:             task.New(32),
+		messages:          task.New(32),
+		requests:          task.New(32),
+		actions:           task.New(32),
+		feedbacks:         task.New(32),
+		pendingProposals:  newPendingProposals(),
+		incomingProposals: newProposalBatch(s.logger, 10, 1, r),
+		pendingReads:      &readIndexQueue{shardID: 1, logger: s.logger},
+		readStopper:       stop.NewStopper("TestDestroyReplicaWithTombstoneGC"),
+		stats:             newReplicaStats(),
+	}
+	pr.sm = newStateMachine(pr.logger, s.DataStorageByGroup(0), s.logdb, shard, pr.replica, nil, nil, nil)
+	s.vacuumCleaner.start()
+	defer s.vacuumCleaner.close()
+	close(pr.startedC)
+	s.addReplica(pr)
+	s.destroyReplica(pr.shardID, false, true, "testing")
+	for {
+		if pr.closed() {
+			break
+		}
+	}
+	wc := s.logdb.NewWorkerContext()
+	_, err := pr.handleEvent(wc)
+	assert.NoError(t, err)
+	pr.waitDestroyed()
+
+	// the replica is removed, but the data is kept as tombstone
+	assert.Nil(t, s.getReplica(1, false))
+	assert.True(t, s.tombstones.has(1))
+	assert.Equal(t, 2, scan())
+	smd, err := pr.sm.dataStorage.GetInitialStates()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(smd))
+	assert.Equal(t, metapb.ReplicaState_ReplicaTombstone, smd[0].Metadata.State)
+
+	s.removeTombstone(1, "testing")
+	for s.tombstones.has(1) {
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Equal(t, 0, scan())
+	smd, err = pr.sm.dataStorage.GetInitialStates()
+	require.NoError(t, err)
+	require.Empty(t, smd)
+}
+
+func TestHasOverlappedReplica(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	s, cancel := newTestStore(t)
+	defer cancel()
+
+	pr := newTestReplica(Shard{ID: 1, Start: []byte("b"), End: []byte("d")}, Replica{ID: 1}, s)
+	defer pr.readStopper.Stop()
+	s.addReplica(pr)
+
+	cases := []struct {
+		shard      Shard
+		overlapped bool
+	}{
+		{shard: Shard{ID: 1, Start: []byte("b"), End: []byte("d")}, overlapped: false},
+		{shard: Shard{ID: 2, Start: []byte("b"), End: []byte("d")}, overlapped: true},
+		{shard: Shard{ID: 2, Start: []byte("a"), End: []byte("b")}, overlapped: false},
+		{shard: Shard{ID: 2, Start: []byte("d")}, overlapped: false},
+		{shard: Shard{ID: 2, Start: []byte("c")}, overlapped: true},
+		{shard: Shard{ID: 2, End: []byte("c")}, overlapped: true},
+		{shard: Shard{ID: 2, Group: 1, Start: []byte("b"), End: []byte("d")}, overlapped: false},
+	}
+	for i, c := range cases {
+		assert.Equal(t, c.overlapped, s.hasOverlappedReplica(c.shard), "index %d", i)
+	}
+}
@@ -15,8 +15,13 @@ package raftstore
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/juju/ratelimit"
 	"github.com/lni/goutils/syncutil"
+
+	"github.com/matrixorigin/matrixcube/metric"
 )
 
 type vacuumFunc = func(vacuumTask) error
@@ -26,15 +31,28 @@ type vacuumTask struct {
 	replica      *replica
 	shardRemoved bool
 	removeData   bool
-	reason       string
+	// tombstone the task deletes a tombstone replica kept by the tombstone gc
+	tombstone bool
+	reason    string
+	// size the approximate size of the data to be deleted, 0 if unknown
+	size uint64
 }
 
 // vacuumCleaner is used to cleanup shard data belongs to shards that have been
-// destroyed.
+// destroyed. The tasks are handled by a pool of workers, the tasks of the same
+// shard are always handled by the same worker in order. The deletion of the
+// data can be throttled by the limiter to avoid IO spikes when many replicas
+// are removed at once.
 type vacuumCleaner struct {
 	stopper *syncutil.Stopper
 	notifyC chan struct{}
 	vf      vacuumFunc
+	// workerC the task queues of the workers, the tasks are handled by the
+	// dispatcher itself if there is no more than one worker
+	workerC []chan vacuumTask
+	limiter *ratelimit.Bucket
+	// pending number of the added tasks which are not finished
+	pending int64
 
 	mu struct {
 		sync.Mutex
@@ -50,7 +68,35 @@ func newVacuumCleaner(f vacuumFunc) *vacuumCleaner {
 	}
 }
 
+// withLimits sets the number of workers and the max bytes of the data deleted
+// per second, 0 means unlimited. It must be called before start.
+func (v *vacuumCleaner) withLimits(workers int, bytesPerSecond int64) *vacuumCleaner {
+	if workers > 1 {
+		v.workerC = make([]chan vacuumTask, workers)
+		for i := range v.workerC {
+			v.workerC[i] = make(chan vacuumTask, 16)
+		}
+	}
+	if bytesPerSecond > 0 {
+		v.limiter = ratelimit.NewBucketWithRate(float64(bytesPerSecond), bytesPerSecond)
+	}
+	return v
+}
+
 func (v *vacuumCleaner) start() {
+	for _, c := range v.workerC {
+		c := c
+		v.stopper.RunWorker(func() {
+			for {
+				select {
+				case <-v.stopper.ShouldStop():
+					return
+				case task := <-c:
+					v.run(task)
+				}
+			}
+		})
+	}
 	v.stopper.RunWorker(func() {
 		for {
 			select {
@@ -73,6 +119,7 @@ func (v *vacuumCleaner) addTask(t vacuumTask) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 	v.mu.pending = append(v.mu.pending, t)
+	metric.SetVacuumQueueMetric(atomic.AddInt64(&v.pending, 1))
 	select {
 	case v.notifyC <- struct{}{}:
 	default:
@@ -97,13 +144,20 @@ func (v *vacuumCleaner) vacuum() bool {
 	for {
 		if tasks := v.getTasks(); len(tasks) > 0 {
 			for _, task := range tasks {
-				if err := v.vf(task); err != nil {
-					panic(err)
+				if len(v.workerC) == 0 {
+					v.run(task)
+					select {
+					case <-v.stopper.ShouldStop():
+						return true
+					default:
+					}
+					continue
 				}
+
 				select {
 				case <-v.stopper.ShouldStop():
 					return true
-				default:
+				case v.workerC[task.shard.ID%uint64(len(v.workerC))] <- task:
 				}
 			}
 		} else {
@@ -112,3 +166,31 @@ func (v *vacuumCleaner) vacuum() bool {
 	}
 	return false
 }
+
+func (v *vacuumCleaner) run(task vacuumTask) {
+	if err := v.vf(task); err != nil {
+		panic(err)
+	}
+	metric.SetVacuumQueueMetric(atomic.AddInt64(&v.pending, -1))
+}
+
+// throttle waits until the data of the size is allowed to be deleted, returns
+// false if the vacuum cleaner is stopped while waiting. The data of unknown
+// size is not throttled.
+func (v *vacuumCleaner) throttle(size uint64) bool {
+	if v.limiter == nil || size == 0 {
+		return true
+	}
+	wait := v.limiter.Take(int64(size))
+	if wait <= 0 {
+		return true
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-v.stopper.ShouldStop():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
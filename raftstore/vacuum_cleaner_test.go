@@ -15,7 +15,9 @@ package raftstore
 
 import (
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -86,3 +88,39 @@ func TestVacuumMethodWillPanicOnError(t *testing.T) {
 		vc.vacuum()
 	}
 }
+
+func TestVacuumWorkersKeepShardOrder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	p := &testVacuumTaskProcessor{}
+	vc := newVacuumCleaner(p.vacuum).withLimits(4, 0)
+	vc.start()
+	defer vc.close()
+	for i := uint64(0); i < 40; i++ {
+		vc.addTask(vacuumTask{shard: Shard{ID: i % 8, Epoch: Epoch{Generation: i}}})
+	}
+	for p.getProcessedCount() != 40 {
+		time.Sleep(time.Millisecond)
+	}
+
+	versions := make(map[uint64]uint64)
+	for _, shard := range p.shards {
+		if v, ok := versions[shard.ID]; ok {
+			assert.True(t, shard.Epoch.Generation > v)
+		}
+		versions[shard.ID] = shard.Epoch.Generation
+	}
+	assert.Equal(t, int64(0), atomic.LoadInt64(&vc.pending))
+}
+
+func TestVacuumThrottle(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	vc := newVacuumCleaner(nil)
+	assert.True(t, vc.throttle(1<<30))
+
+	vc = newVacuumCleaner(nil).withLimits(1, 1024)
+	assert.True(t, vc.throttle(0))
+	assert.True(t, vc.throttle(1024))
+	vc.close()
+	// the bucket is drained, waiting for the tokens is interrupted by close
+	assert.False(t, vc.throttle(1<<20))
+}
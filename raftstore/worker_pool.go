@@ -15,6 +15,7 @@ package raftstore
 
 import (
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/lni/goutils/syncutil"
@@ -56,10 +57,11 @@ type replicaWorker struct {
 	requestC   chan replicaEventHandler
 	completedC chan struct{}
 	workerID   uint64
+	onPanic    func(interface{})
 }
 
 func newReplicaWorker(logger *zap.Logger, workerID uint64,
-	stopper *syncutil.Stopper, wc *logdb.WorkerContext) *replicaWorker {
+	stopper *syncutil.Stopper, wc *logdb.WorkerContext, onPanic func(interface{})) *replicaWorker {
 	w := &replicaWorker{
 		logger:     logger,
 		workerID:   workerID,
@@ -67,6 +69,7 @@ func newReplicaWorker(logger *zap.Logger, workerID uint64,
 		requestC:   make(chan replicaEventHandler, 1),
 		completedC: make(chan struct{}, 1),
 		wc:         wc,
+		onPanic:    onPanic,
 	}
 	stopper.RunWorker(func() {
 		w.workerMain()
@@ -109,6 +112,15 @@ func (w *replicaWorker) completed() {
 }
 
 func (w *replicaWorker) handleEvent(h replicaEventHandler) error {
+	if w.onPanic != nil {
+		defer func() {
+			if p := recover(); p != nil {
+				w.onPanic(p)
+				panic(p)
+			}
+		}()
+	}
+
 	for {
 		w.wc.Reset()
 		hasEvent, err := h.handleEvent(w.wc)
@@ -146,6 +158,12 @@ type workerPool struct {
 
 	ldb         logdb.LogDB
 	workerCount uint64
+	// deterministic is set when all replicas are driven by runOnce() in the
+	// caller's goroutine, no worker goroutine will be started.
+	deterministic bool
+	wc            *logdb.WorkerContext
+	// onPanic is called with the recovered value before the worker re-panics
+	onPanic func(interface{})
 }
 
 func newWorkerPool(logger *zap.Logger, ldb logdb.LogDB, loader replicaLoader, workerCount uint64) *workerPool {
@@ -165,9 +183,14 @@ func newWorkerPool(logger *zap.Logger, ldb logdb.LogDB, loader replicaLoader, wo
 }
 
 func (p *workerPool) start() {
+	if p.deterministic {
+		p.wc = p.ldb.NewWorkerContext()
+		return
+	}
+
 	for workerID := uint64(0); workerID < p.workerCount; workerID++ {
 		workerContext := p.ldb.NewWorkerContext()
-		w := newReplicaWorker(p.logger, workerID, p.workerStopper, workerContext)
+		w := newReplicaWorker(p.logger, workerID, p.workerStopper, workerContext, p.onPanic)
 		p.workers = append(p.workers, w)
 	}
 
@@ -186,9 +209,49 @@ func (p *workerPool) notify(shardID uint64) {
 
 func (p *workerPool) close() error {
 	p.poolStopper.Stop()
+	if p.wc != nil {
+		p.wc.Close()
+	}
 	return nil
 }
 
+// runOnce handles all ready replicas in shard id order in the caller's
+// goroutine, it returns a boolean value indicating whether any replica has
+// been handled. runOnce is only used in deterministic mode.
+func (p *workerPool) runOnce() bool {
+	if !p.deterministic {
+		panic("runOnce called on a non-deterministic worker pool")
+	}
+
+	var shards []uint64
+	p.ready.Range(func(key interface{}, value interface{}) bool {
+		shards = append(shards, key.(uint64))
+		p.ready.Delete(key)
+		return true
+	})
+	sort.Slice(shards, func(i, j int) bool { return shards[i] < shards[j] })
+
+	for _, shardID := range shards {
+		h, ok := p.loader.getReplica(shardID)
+		if !ok {
+			p.logger.Warn("work pool failed to locate the requested shard",
+				log.ShardIDField(shardID))
+			continue
+		}
+		for {
+			p.wc.Reset()
+			hasEvent, err := h.handleEvent(p.wc)
+			if err != nil {
+				panic(err)
+			}
+			if !hasEvent {
+				break
+			}
+		}
+	}
+	return len(shards) > 0
+}
+
 func (p *workerPool) workerPoolMain() {
 	cases := make([]reflect.SelectCase, len(p.workers)+2)
 	for {
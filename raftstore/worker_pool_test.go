@@ -83,6 +83,50 @@ func (f *testReplicaLoader) getReplica(r uint64) (replicaEventHandler, bool) {
 	return h, true
 }
 
+type orderedReplicaEventHandler struct {
+	shardID uint64
+	handled *[]uint64
+}
+
+func (h *orderedReplicaEventHandler) getShardID() uint64 {
+	return h.shardID
+}
+
+func (h *orderedReplicaEventHandler) handleEvent(*logdb.WorkerContext) (bool, error) {
+	*h.handled = append(*h.handled, h.shardID)
+	return false, nil
+}
+
+type orderedReplicaLoader struct {
+	handled []uint64
+}
+
+func (l *orderedReplicaLoader) getReplica(shardID uint64) (replicaEventHandler, bool) {
+	return &orderedReplicaEventHandler{shardID: shardID, handled: &l.handled}, true
+}
+
+func TestWorkerPoolRunOnceInDeterministicMode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	mem := mem.NewStorage()
+	defer mem.Close()
+	ldb := logdb.NewKVLogDB(mem, nil)
+	defer ldb.Close()
+	l := &orderedReplicaLoader{}
+	p := newWorkerPool(nil, ldb, l, 32)
+	p.deterministic = true
+	p.start()
+	defer p.close()
+
+	assert.False(t, p.runOnce())
+	for _, id := range []uint64{5, 3, 100, 1, 3} {
+		p.notify(id)
+	}
+	assert.True(t, p.runOnce())
+	assert.Equal(t, []uint64{1, 3, 5, 100}, l.handled)
+	assert.False(t, p.runOnce())
+	assert.Empty(t, p.workers)
+}
+
 func TestWorkerPoolCanScheduleSimpleJob(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	l := newTestReplicaLoader()
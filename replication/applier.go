@@ -0,0 +1,80 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/matrixorigin/matrixcube/client"
+)
+
+type kvApplier struct {
+	kv client.KVClient
+}
+
+// NewKVApplier returns an Applier which applies the change events to the
+// standby cluster by the KVClient. The events are applied one by one in order,
+// the changes of the primary cluster overwrite the values in the standby
+// cluster. Note that the range of a range delete event must be in a shard of
+// the standby cluster.
+func NewKVApplier(kv client.KVClient) Applier {
+	return &kvApplier{kv: kv}
+}
+
+func (a *kvApplier) Apply(ctx context.Context, events []ChangeEvent) error {
+	for _, e := range events {
+		var f *client.Future
+		switch e.Type {
+		case ChangeSet:
+			f = a.kv.Set(ctx, e.Key, e.Value)
+		case ChangeDelete:
+			f = a.kv.Delete(ctx, e.Key)
+		case ChangeRangeDelete:
+			f = a.kv.RangeDelete(ctx, e.Key, e.End)
+		default:
+			return fmt.Errorf("unknown change type %d", e.Type)
+		}
+		err := f.GetError()
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type memCheckpointStorage struct {
+	sync.Mutex
+	checkpoints map[uint64]uint64
+}
+
+// NewMemCheckpointStorage returns a CheckpointStorage in memory, used in tests
+func NewMemCheckpointStorage() CheckpointStorage {
+	return &memCheckpointStorage{checkpoints: make(map[uint64]uint64)}
+}
+
+func (s *memCheckpointStorage) Load() (map[uint64]uint64, error) {
+	s.Lock()
+	defer s.Unlock()
+	return copyCheckpoints(s.checkpoints), nil
+}
+
+func (s *memCheckpointStorage) Save(checkpoints map[uint64]uint64) error {
+	s.Lock()
+	defer s.Unlock()
+	s.checkpoints = copyCheckpoints(checkpoints)
+	return nil
+}
@@ -0,0 +1,286 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrPromoted the bridge is promoted, the standby cluster is no longer
+	// replicated from the primary cluster
+	ErrPromoted = errors.New("standby cluster promoted")
+	// ErrBridgeStarted the bridge is already started
+	ErrBridgeStarted = errors.New("replication bridge already started")
+)
+
+const (
+	defaultRetryInterval = time.Second
+)
+
+// ChangeType is the type of the change event
+type ChangeType int
+
+const (
+	// ChangeSet set the value of the key
+	ChangeSet ChangeType = iota
+	// ChangeDelete delete the key
+	ChangeDelete
+	// ChangeRangeDelete delete the keys in range [Key, End)
+	ChangeRangeDelete
+)
+
+// ChangeEvent is a change applied to a shard of the primary cluster
+type ChangeEvent struct {
+	// ShardID shard of the primary cluster
+	ShardID uint64
+	// Index raft log index of the change in the shard of the primary cluster,
+	// the events of a shard must be delivered in the order of the index
+	Index uint64
+	Type  ChangeType
+	Key   []byte
+	Value []byte
+	End   []byte
+	// CommitTime wall-clock time in nanoseconds stamped by the leader of the
+	// shard of the primary cluster, see storage.Batch.CommitTime. It's the same
+	// on all replicas, unlike the time the change is applied.
+	CommitTime int64
+}
+
+// ChangeSource is the CDC stream of the primary cluster
+type ChangeSource interface {
+	// Next returns the next batch of the change events, the source should
+	// resume the shards from the checkpoints passed to Subscribe.
+	Next(ctx context.Context) ([]ChangeEvent, error)
+	// Subscribe starts the stream from the checkpoints, the events at or before
+	// the checkpoint index of a shard may be delivered again.
+	Subscribe(ctx context.Context, checkpoints map[uint64]uint64) error
+	// Close closes the stream
+	Close() error
+}
+
+// Applier applies the change events to the standby cluster
+type Applier interface {
+	Apply(ctx context.Context, events []ChangeEvent) error
+}
+
+// CheckpointStorage persists the per-shard checkpoints, a checkpoint is the
+// max index of the change events of the shard applied to the standby cluster.
+type CheckpointStorage interface {
+	Load() (map[uint64]uint64, error)
+	Save(checkpoints map[uint64]uint64) error
+}
+
+// Bridge replicates the changes of a primary cluster to a standby cluster
+// asynchronously. The changes are applied in the order of the primary cluster,
+// the standby cluster must not accept other writes before promoted, so the
+// primary always wins.
+type Bridge struct {
+	logger        *zap.Logger
+	source        ChangeSource
+	applier       Applier
+	checkpoints   CheckpointStorage
+	retryInterval time.Duration
+
+	mu struct {
+		sync.Mutex
+		started     bool
+		promoted    bool
+		cancel      context.CancelFunc
+		doneC       chan struct{}
+		checkpoints map[uint64]uint64
+	}
+}
+
+// NewBridge returns a replication bridge
+func NewBridge(logger *zap.Logger, source ChangeSource, applier Applier,
+	checkpoints CheckpointStorage) *Bridge {
+	return &Bridge{
+		logger:        log.Adjust(logger).Named("replication-bridge"),
+		source:        source,
+		applier:       applier,
+		checkpoints:   checkpoints,
+		retryInterval: defaultRetryInterval,
+	}
+}
+
+// Start loads the checkpoints and starts to replicate the changes
+func (b *Bridge) Start() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.mu.promoted {
+		return ErrPromoted
+	}
+	if b.mu.started {
+		return ErrBridgeStarted
+	}
+
+	checkpoints, err := b.checkpoints.Load()
+	if err != nil {
+		return err
+	}
+	if checkpoints == nil {
+		checkpoints = make(map[uint64]uint64)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := b.source.Subscribe(ctx, copyCheckpoints(checkpoints)); err != nil {
+		cancel()
+		return err
+	}
+
+	b.mu.started = true
+	b.mu.cancel = cancel
+	b.mu.doneC = make(chan struct{})
+	b.mu.checkpoints = checkpoints
+	go b.run(ctx, b.mu.doneC)
+	b.logger.Info("replication bridge started",
+		zap.Int("shards", len(checkpoints)))
+	return nil
+}
+
+// Stop stops replicating the changes, the bridge can be started again
+func (b *Bridge) Stop() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stopLocked()
+}
+
+// Promote stops replicating the changes and promotes the standby cluster, the
+// bridge can not be started after promoted. The checkpoints returned is the
+// last changes of the primary cluster applied to the standby cluster, the
+// changes after the checkpoints are lost.
+func (b *Bridge) Promote() (map[uint64]uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.stopLocked(); err != nil {
+		return nil, err
+	}
+	b.mu.promoted = true
+	b.logger.Info("standby cluster promoted",
+		zap.Int("shards", len(b.mu.checkpoints)))
+	return copyCheckpoints(b.mu.checkpoints), nil
+}
+
+// Checkpoints returns the current checkpoints
+func (b *Bridge) Checkpoints() map[uint64]uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return copyCheckpoints(b.mu.checkpoints)
+}
+
+func (b *Bridge) stopLocked() error {
+	if !b.mu.started {
+		return nil
+	}
+
+	b.mu.cancel()
+	// the replication loop updates the checkpoints with the lock held
+	b.mu.Unlock()
+	<-b.mu.doneC
+	b.mu.Lock()
+	b.mu.started = false
+	b.logger.Info("replication bridge stopped")
+	return b.source.Close()
+}
+
+func (b *Bridge) run(ctx context.Context, doneC chan struct{}) {
+	defer close(doneC)
+
+	for {
+		events, err := b.source.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			b.logger.Error("fail to read change events",
+				zap.Error(err))
+			if !b.wait(ctx) {
+				return
+			}
+			continue
+		}
+
+		for {
+			err := b.apply(ctx, events)
+			if err == nil {
+				break
+			}
+			b.logger.Error("fail to apply change events",
+				zap.Int("events", len(events)),
+				zap.Error(err))
+			if !b.wait(ctx) {
+				return
+			}
+		}
+	}
+}
+
+func (b *Bridge) wait(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(b.retryInterval):
+		return true
+	}
+}
+
+// apply applies the events which are not applied yet, and saves the
+// checkpoints after the events applied.
+func (b *Bridge) apply(ctx context.Context, events []ChangeEvent) error {
+	b.mu.Lock()
+	current := b.mu.checkpoints
+	b.mu.Unlock()
+
+	pending := events[:0:0]
+	checkpoints := copyCheckpoints(current)
+	for _, e := range events {
+		if e.Index <= checkpoints[e.ShardID] {
+			continue
+		}
+		pending = append(pending, e)
+		checkpoints[e.ShardID] = e.Index
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if err := b.applier.Apply(ctx, pending); err != nil {
+		return err
+	}
+	if err := b.checkpoints.Save(checkpoints); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.mu.checkpoints = checkpoints
+	b.mu.Unlock()
+	return nil
+}
+
+func copyCheckpoints(checkpoints map[uint64]uint64) map[uint64]uint64 {
+	values := make(map[uint64]uint64, len(checkpoints))
+	for k, v := range checkpoints {
+		values[k] = v
+	}
+	return values
+}
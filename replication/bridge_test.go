@@ -0,0 +1,153 @@
+// Copyright 2021 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testChangeSource struct {
+	eventsC     chan []ChangeEvent
+	checkpoints map[uint64]uint64
+	closed      bool
+}
+
+func newTestChangeSource() *testChangeSource {
+	return &testChangeSource{eventsC: make(chan []ChangeEvent, 16)}
+}
+
+func (s *testChangeSource) Subscribe(ctx context.Context, checkpoints map[uint64]uint64) error {
+	s.checkpoints = checkpoints
+	return nil
+}
+
+func (s *testChangeSource) Next(ctx context.Context) ([]ChangeEvent, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case events := <-s.eventsC:
+		return events, nil
+	}
+}
+
+func (s *testChangeSource) Close() error {
+	s.closed = true
+	return nil
+}
+
+type testApplier struct {
+	sync.Mutex
+	failures int
+	values   map[string]string
+	applied  []ChangeEvent
+}
+
+func newTestApplier() *testApplier {
+	return &testApplier{values: make(map[string]string)}
+}
+
+func (a *testApplier) Apply(ctx context.Context, events []ChangeEvent) error {
+	a.Lock()
+	defer a.Unlock()
+	if a.failures > 0 {
+		a.failures--
+		return errors.New("apply failed")
+	}
+	for _, e := range events {
+		switch e.Type {
+		case ChangeSet:
+			a.values[string(e.Key)] = string(e.Value)
+		case ChangeDelete:
+			delete(a.values, string(e.Key))
+		}
+		a.applied = append(a.applied, e)
+	}
+	return nil
+}
+
+func (a *testApplier) appliedCount() int {
+	a.Lock()
+	defer a.Unlock()
+	return len(a.applied)
+}
+
+func waitApplied(t *testing.T, a *testApplier, n int) {
+	for i := 0; i < 100; i++ {
+		if a.appliedCount() >= n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Fail(t, "wait applied timeout")
+}
+
+func TestBridgeApplyAndCheckpoint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	source := newTestChangeSource()
+	applier := newTestApplier()
+	checkpoints := NewMemCheckpointStorage()
+	require.NoError(t, checkpoints.Save(map[uint64]uint64{1: 2}))
+
+	b := NewBridge(nil, source, applier, checkpoints)
+	b.retryInterval = time.Millisecond
+	require.NoError(t, b.Start())
+	assert.Equal(t, ErrBridgeStarted, b.Start())
+	assert.Equal(t, map[uint64]uint64{1: 2}, source.checkpoints)
+
+	applier.failures = 1
+	source.eventsC <- []ChangeEvent{
+		{ShardID: 1, Index: 2, Type: ChangeSet, Key: []byte("k1"), Value: []byte("old")},
+		{ShardID: 1, Index: 3, Type: ChangeSet, Key: []byte("k1"), Value: []byte("v1")},
+		{ShardID: 2, Index: 1, Type: ChangeSet, Key: []byte("k2"), Value: []byte("v2")},
+	}
+	waitApplied(t, applier, 2)
+	source.eventsC <- []ChangeEvent{
+		{ShardID: 2, Index: 1, Type: ChangeSet, Key: []byte("k2"), Value: []byte("old")},
+		{ShardID: 2, Index: 2, Type: ChangeDelete, Key: []byte("k2")},
+	}
+	waitApplied(t, applier, 3)
+
+	require.NoError(t, b.Stop())
+	assert.True(t, source.closed)
+	assert.Equal(t, map[string]string{"k1": "v1"}, applier.values)
+	saved, err := checkpoints.Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]uint64{1: 3, 2: 2}, saved)
+}
+
+func TestBridgePromote(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	source := newTestChangeSource()
+	applier := newTestApplier()
+	b := NewBridge(nil, source, applier, NewMemCheckpointStorage())
+	require.NoError(t, b.Start())
+
+	source.eventsC <- []ChangeEvent{{ShardID: 1, Index: 1, Type: ChangeSet, Key: []byte("k1")}}
+	waitApplied(t, applier, 1)
+
+	checkpoints, err := b.Promote()
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]uint64{1: 1}, checkpoints)
+	assert.Equal(t, ErrPromoted, b.Start())
+}
@@ -50,8 +50,9 @@ var (
 	// SnapshotDirNamePartsRe is used to find the index value from snapshot folder name.
 	SnapshotDirNamePartsRe = regexp.MustCompile(`^snapshot-([0-9A-F]+)-[0-9A-F]+$`)
 	// GenSnapshotDirNameRe is the regex of temp snapshot directory name used when
-	// generating snapshots.
-	GenSnapshotDirNameRe = regexp.MustCompile(`^snapshot-[0-9A-F]+-[0-9A-F]+\.generating$`)
+	// generating snapshots, the index is unknown before the snapshot is
+	// generated.
+	GenSnapshotDirNameRe = regexp.MustCompile(`^snapshot-[0-9A-F]+(-[0-9A-F]+)?\.generating$`)
 	// RecvSnapshotDirNameRe is the regex of temp snapshot directory name used when
 	// receiving snapshots from remote NodeHosts.
 	RecvSnapshotDirNameRe = regexp.MustCompile(`^snapshot-[0-9A-F]+-[0-9A-F]+\.receiving$`)
@@ -78,6 +79,14 @@ const (
 	ReceivingMode
 )
 
+// IsTempSnapshotDir returns true if the dir name is a temp snapshot directory
+// used when generating or receiving snapshots. Such directories are incomplete
+// snapshots if they are found on startup.
+func IsTempSnapshotDir(name string) bool {
+	return GenSnapshotDirNameRe.MatchString(name) ||
+		RecvSnapshotDirNameRe.MatchString(name)
+}
+
 // GetSnapshotDirName returns the snapshot dir name for the snapshot captured
 // at the specified index.
 func GetSnapshotDirName(index uint64, extra uint64) string {
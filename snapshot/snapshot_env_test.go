@@ -142,6 +142,21 @@ func TestTempSuffix(t *testing.T) {
 	}
 }
 
+func TestIsTempSnapshotDir(t *testing.T) {
+	f := func(cid uint64, nid uint64) string {
+		return "/data"
+	}
+	fs := vfs.GetTestFS()
+	defer reportLeakedFD(fs, t)
+	env := NewSSEnv(f, 1, 1, 1, 2, CreatingMode, fs)
+	assert.True(t, IsTempSnapshotDir(fs.PathBase(env.GetTempDir())))
+	env.FinalizeIndex(1)
+	assert.False(t, IsTempSnapshotDir(fs.PathBase(env.GetFinalDir())))
+	env = NewSSEnv(f, 1, 1, 1, 2, ReceivingMode, fs)
+	assert.True(t, IsTempSnapshotDir(fs.PathBase(env.GetTempDir())))
+	assert.False(t, IsTempSnapshotDir(fs.PathBase(env.GetFinalDir())))
+}
+
 func TestFinalSnapshotDirDoesNotContainTempSuffix(t *testing.T) {
 	f := func(cid uint64, nid uint64) string {
 		return "/data"
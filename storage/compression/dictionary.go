@@ -0,0 +1,257 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"sync"
+
+	"github.com/DataDog/zstd"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+const (
+	// formatRaw the value is stored as is, compression doesn't make it smaller
+	formatRaw byte = 0
+	// formatZstd the value is compressed by zstd, followed by the uvarint
+	// version of the dictionary, 0 means no dictionary is used
+	formatZstd byte = 1
+)
+
+var (
+	// ErrDictionaryNotFound the dictionary used to compress the value is missing
+	ErrDictionaryNotFound = errors.New("compression dictionary not found")
+	// ErrInvalidCompressedValue the value is not compressed by Dictionaries
+	ErrInvalidCompressedValue = errors.New("invalid compressed value")
+)
+
+type shardDictionaries struct {
+	current  uint64
+	versions map[uint64][]byte
+	samples  [][]byte
+	sampled  uint64
+}
+
+// Dictionaries holds the compression dictionaries of the shards, and the values
+// sampled to train the next version of the dictionaries. The dictionaries are
+// replicated by the raft log and saved in the shard metadata, the store sets
+// the dictionaries of a shard before the writes of the shard are applied, so
+// all the replicas compress the same values with the same dictionary. The
+// versions of a shard are kept to decompress the values written before.
+type Dictionaries struct {
+	level      int
+	maxSamples int
+	maxSize    int
+	rnd        *rand.Rand
+
+	mu struct {
+		sync.RWMutex
+		shards map[uint64]*shardDictionaries
+	}
+}
+
+// NewDictionaries returns Dictionaries compressing the values with the zstd
+// level. At most maxSamples values are sampled for each shard, and the trained
+// dictionaries are at most maxSize bytes, 0 disables the training.
+func NewDictionaries(level, maxSamples, maxSize int) *Dictionaries {
+	d := &Dictionaries{
+		level:      level,
+		maxSamples: maxSamples,
+		maxSize:    maxSize,
+		rnd:        rand.New(rand.NewSource(rand.Int63())),
+	}
+	d.mu.shards = make(map[uint64]*shardDictionaries)
+	return d
+}
+
+// Set sets the dictionaries of the shard, the last one compresses the values
+// written after. The sampled values of the shard are kept.
+func (d *Dictionaries) Set(shardID uint64, dicts []metapb.CompressionDictionary) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	g := d.getShardLocked(shardID)
+	g.current = 0
+	g.versions = make(map[uint64][]byte, len(dicts))
+	for _, dict := range dicts {
+		g.versions[dict.Version] = dict.Data
+		g.current = dict.Version
+	}
+}
+
+// Remove removes the dictionaries and the samples of the shard
+func (d *Dictionaries) Remove(shardID uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.mu.shards, shardID)
+}
+
+// Current returns the current dictionary of the shard
+func (d *Dictionaries) Current(shardID uint64) (metapb.CompressionDictionary, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if g, ok := d.mu.shards[shardID]; ok && g.current > 0 {
+		return metapb.CompressionDictionary{Version: g.current, Data: g.versions[g.current]}, true
+	}
+	return metapb.CompressionDictionary{}, false
+}
+
+// Sample samples the value of the shard by reservoir sampling
+func (d *Dictionaries) Sample(shardID uint64, value []byte) {
+	if d.maxSamples <= 0 || d.maxSize <= 0 || len(value) == 0 {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	g := d.getShardLocked(shardID)
+	g.sampled++
+	if len(g.samples) < d.maxSamples {
+		g.samples = append(g.samples, append([]byte(nil), value...))
+		return
+	}
+	if idx := d.rnd.Int63n(int64(g.sampled)); idx < int64(d.maxSamples) {
+		g.samples[idx] = append(g.samples[idx][:0], value...)
+	}
+}
+
+// Train trains a dictionary from the values of the shard sampled since the last
+// training, the dictionary is not used until it's replicated and Set. The
+// samples are dropped after training. False is returned if less than
+// maxSamples values are sampled or the samples are not compressible.
+func (d *Dictionaries) Train(shardID uint64) ([]byte, bool) {
+	d.mu.Lock()
+	g, ok := d.mu.shards[shardID]
+	if !ok || d.maxSamples <= 0 || len(g.samples) < d.maxSamples {
+		d.mu.Unlock()
+		return nil, false
+	}
+	samples := g.samples
+	g.samples = nil
+	g.sampled = 0
+	d.mu.Unlock()
+
+	data := TrainDictionary(samples, d.maxSize)
+	return data, len(data) > 0
+}
+
+// Compress compresses the value with the current dictionary of the shard. The
+// returned value is self-describing, it can be decompressed as long as the
+// dictionary version used is kept.
+func (d *Dictionaries) Compress(shardID uint64, value []byte) ([]byte, error) {
+	dict, _ := d.Current(shardID)
+	return CompressWithDictionary(d.level, dict, value)
+}
+
+// Decompress decompresses the value returned by Compress
+func (d *Dictionaries) Decompress(shardID uint64, value []byte) ([]byte, error) {
+	return decompress(value, func(version uint64) []byte {
+		d.mu.RLock()
+		defer d.mu.RUnlock()
+		if g, ok := d.mu.shards[shardID]; ok {
+			return g.versions[version]
+		}
+		return nil
+	})
+}
+
+// CompressWithDictionary compresses the value with the dictionary at the zstd
+// level, the value is compressed without dictionary if the version of dict is
+// 0. The returned value is in the same format as the values returned by
+// Dictionaries.Compress.
+func CompressWithDictionary(level int, dict metapb.CompressionDictionary, value []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(formatZstd)
+	var version [binary.MaxVarintLen64]byte
+	buf.Write(version[:binary.PutUvarint(version[:], dict.Version)])
+	if dict.Version == 0 {
+		compressed, err := zstd.CompressLevel(nil, value, level)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(compressed)
+	} else {
+		w := zstd.NewWriterLevelDict(&buf, level, dict.Data)
+		if _, err := w.Write(value); err != nil {
+			w.Close()
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if buf.Len() > len(value) {
+		raw := make([]byte, len(value)+1)
+		raw[0] = formatRaw
+		copy(raw[1:], value)
+		return raw, nil
+	}
+	return buf.Bytes(), nil
+}
+
+// DecompressWithDictionaries decompresses the value returned by
+// CompressWithDictionary with the dictionary of the same version in dicts.
+func DecompressWithDictionaries(dicts []metapb.CompressionDictionary, value []byte) ([]byte, error) {
+	return decompress(value, func(version uint64) []byte {
+		for _, dict := range dicts {
+			if dict.Version == version {
+				return dict.Data
+			}
+		}
+		return nil
+	})
+}
+
+func decompress(value []byte, getDictionary func(version uint64) []byte) ([]byte, error) {
+	if len(value) == 0 {
+		return nil, ErrInvalidCompressedValue
+	}
+
+	switch value[0] {
+	case formatRaw:
+		return value[1:], nil
+	case formatZstd:
+		version, n := binary.Uvarint(value[1:])
+		if n <= 0 {
+			return nil, ErrInvalidCompressedValue
+		}
+		data := value[1+n:]
+		if version == 0 {
+			return zstd.Decompress(nil, data)
+		}
+
+		dict := getDictionary(version)
+		if len(dict) == 0 {
+			return nil, ErrDictionaryNotFound
+		}
+		r := zstd.NewReaderDict(bytes.NewReader(data), dict)
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	default:
+		return nil, ErrInvalidCompressedValue
+	}
+}
+
+func (d *Dictionaries) getShardLocked(shardID uint64) *shardDictionaries {
+	g, ok := d.mu.shards[shardID]
+	if !ok {
+		g = &shardDictionaries{versions: make(map[uint64][]byte)}
+		d.mu.shards[shardID] = g
+	}
+	return g
+}
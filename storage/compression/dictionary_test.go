@@ -0,0 +1,115 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRow(i int) []byte {
+	return []byte(fmt.Sprintf(`{"id":%d,"name":"user-%d","email":"user-%d@example.com","status":"active","role":"member"}`, i, i, i))
+}
+
+func TestTrainDictionary(t *testing.T) {
+	assert.Nil(t, TrainDictionary(nil, 1024))
+	assert.Nil(t, TrainDictionary([][]byte{[]byte("abc")}, 1024))
+
+	var samples [][]byte
+	for i := 0; i < 100; i++ {
+		samples = append(samples, testRow(i))
+	}
+	dict := TrainDictionary(samples, 256)
+	assert.NotEmpty(t, dict)
+	assert.True(t, len(dict) <= 256)
+	assert.Contains(t, string(dict), `@example.com","status":"active"`)
+}
+
+func TestCompressWithDictionary(t *testing.T) {
+	d := NewDictionaries(3, 100, 1024)
+	_, ok := d.Train(1)
+	assert.False(t, ok)
+	for i := 0; i < 200; i++ {
+		d.Sample(1, testRow(i))
+	}
+
+	plain, err := d.Compress(1, testRow(1000))
+	require.NoError(t, err)
+	data, ok := d.Train(1)
+	require.True(t, ok)
+	// the trained dictionary is not used until it's set
+	_, ok = d.Current(1)
+	assert.False(t, ok)
+	d.Set(1, []metapb.CompressionDictionary{{Version: 1, Data: data}})
+	compressed, err := d.Compress(1, testRow(1000))
+	require.NoError(t, err)
+	assert.True(t, len(compressed) < len(plain))
+
+	for _, v := range [][]byte{plain, compressed} {
+		value, err := d.Decompress(1, v)
+		require.NoError(t, err)
+		assert.Equal(t, testRow(1000), value)
+	}
+
+	// the dictionary of the other shard is not used
+	_, err = d.Decompress(2, compressed)
+	assert.Equal(t, ErrDictionaryNotFound, err)
+
+	// the values compressed by the old version can still be decompressed
+	d.Set(1, []metapb.CompressionDictionary{{Version: 1, Data: data}, {Version: 2, Data: testRow(1)}})
+	dict, ok := d.Current(1)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), dict.Version)
+	value, err := d.Decompress(1, compressed)
+	require.NoError(t, err)
+	assert.Equal(t, testRow(1000), value)
+
+	// incompressible values are stored as is
+	raw, err := d.Compress(1, []byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{formatRaw, 'a'}, raw)
+	value, err = d.Decompress(1, raw)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a"), value)
+
+	_, err = d.Decompress(1, []byte{9})
+	assert.Equal(t, ErrInvalidCompressedValue, err)
+
+	d.Remove(1)
+	_, err = d.Decompress(1, compressed)
+	assert.Equal(t, ErrDictionaryNotFound, err)
+}
+
+func TestDecompressWithDictionaries(t *testing.T) {
+	var samples [][]byte
+	for i := 0; i < 100; i++ {
+		samples = append(samples, testRow(i))
+	}
+	dict := metapb.CompressionDictionary{Version: 2, Data: TrainDictionary(samples, 256)}
+	require.NotEmpty(t, dict.Data)
+
+	compressed, err := CompressWithDictionary(3, dict, testRow(1000))
+	require.NoError(t, err)
+	dicts := []metapb.CompressionDictionary{{Version: 1, Data: testRow(1)}, dict}
+	value, err := DecompressWithDictionaries(dicts, compressed)
+	require.NoError(t, err)
+	assert.Equal(t, testRow(1000), value)
+
+	_, err = DecompressWithDictionaries(dicts[:1], compressed)
+	assert.Equal(t, ErrDictionaryNotFound, err)
+}
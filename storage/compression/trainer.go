@@ -0,0 +1,136 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"sort"
+)
+
+var (
+	// dmerSize the length of the substrings counted by the trainer
+	dmerSize = 8
+	// segmentSize the length of the segments selected into the dictionary
+	segmentSize = 64
+)
+
+type segment struct {
+	data  []byte
+	score int
+}
+
+// TrainDictionary builds a raw content dictionary of at most maxSize bytes from
+// the sampled values. It's a simplified version of the COVER algorithm used by
+// zstd: the samples are split into epochs, the segment covering the most
+// frequent substrings is selected from each epoch, and the substrings already
+// covered are not counted again. Nil is returned if nothing is worth to be put
+// into the dictionary.
+func TrainDictionary(samples [][]byte, maxSize int) []byte {
+	if maxSize <= 0 || len(samples) == 0 {
+		return nil
+	}
+
+	// the count of the samples containing the substring
+	freq := make(map[string]int)
+	for _, sample := range samples {
+		seen := make(map[string]struct{})
+		for i := 0; i+dmerSize <= len(sample); i++ {
+			dmer := string(sample[i : i+dmerSize])
+			if _, ok := seen[dmer]; ok {
+				continue
+			}
+			seen[dmer] = struct{}{}
+			freq[dmer]++
+		}
+	}
+
+	epochs := maxSize / segmentSize
+	if epochs == 0 {
+		epochs = 1
+	}
+	epochSize := (len(samples) + epochs - 1) / epochs
+
+	var segments []segment
+	total := 0
+	for start := 0; start < len(samples) && total < maxSize; start += epochSize {
+		end := start + epochSize
+		if end > len(samples) {
+			end = len(samples)
+		}
+		best := bestSegment(samples[start:end], freq)
+		if best.score == 0 {
+			continue
+		}
+		for i := 0; i+dmerSize <= len(best.data); i++ {
+			delete(freq, string(best.data[i:i+dmerSize]))
+		}
+		segments = append(segments, best)
+		total += len(best.data)
+	}
+
+	// zstd prefers the content close to the end of the dictionary, so the most
+	// valuable segments are put at the end
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].score < segments[j].score
+	})
+	dict := make([]byte, 0, total)
+	for _, seg := range segments {
+		dict = append(dict, seg.data...)
+	}
+	if len(dict) > maxSize {
+		dict = dict[len(dict)-maxSize:]
+	}
+	if len(dict) == 0 {
+		return nil
+	}
+	return dict
+}
+
+// bestSegment returns the segment with the highest score in the samples, the
+// score of a segment is the sum of the frequencies of the substrings it covers,
+// the substrings found in only one sample are not counted.
+func bestSegment(samples [][]byte, freq map[string]int) segment {
+	score := func(dmer []byte) int {
+		if n := freq[string(dmer)]; n > 1 {
+			return n
+		}
+		return 0
+	}
+
+	var best segment
+	for _, sample := range samples {
+		if len(sample) < dmerSize {
+			continue
+		}
+		size := segmentSize
+		if size > len(sample) {
+			size = len(sample)
+		}
+		// sliding window over the dmers of [i, i+size)
+		current := 0
+		for j := 0; j+dmerSize <= size; j++ {
+			current += score(sample[j : j+dmerSize])
+		}
+		for i := 0; ; i++ {
+			if current > best.score {
+				best = segment{data: append([]byte(nil), sample[i:i+size]...), score: current}
+			}
+			if i+size >= len(sample) {
+				break
+			}
+			current -= score(sample[i : i+dmerSize])
+			current += score(sample[i+size-dmerSize+1 : i+size+1])
+		}
+	}
+	return best
+}
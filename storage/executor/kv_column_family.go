@@ -0,0 +1,230 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"fmt"
+
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/util"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+)
+
+var _ storage.ColumnFamilyUser = (*kvExecutor)(nil)
+
+// SetColumnFamilies sets the column families of the requests. The handlers
+// encode the keys in the data key space as usual, the keys are moved into the
+// key space of the request's column family by the kv storage and the write
+// batch passed to the handlers, so the handlers are not aware of the column
+// families.
+func (ke *kvExecutor) SetColumnFamilies(names []string) {
+	if len(names) >= keysutil.MaxColumnFamilies {
+		panic(fmt.Sprintf("too many column families %d", len(names)))
+	}
+	ke.cfs = storage.Feature{ColumnFamilies: names}
+}
+
+func (ke *kvExecutor) getColumnFamilyID(cf string) int {
+	id, ok := ke.cfs.ColumnFamilyID(cf)
+	if !ok {
+		panic(fmt.Errorf("not support column family %q", cf))
+	}
+	return id
+}
+
+func withColumnFamilyKVStorage(cf int, kv storage.KVStorage) storage.KVStorage {
+	if cf == 0 {
+		return kv
+	}
+	return &cfKVStorage{KVStorage: kv, cf: cf}
+}
+
+func withColumnFamilyWriteBatch(cf int, wb util.WriteBatch) util.WriteBatch {
+	if cf == 0 {
+		return wb
+	}
+	return &cfWriteBatch{WriteBatch: wb, cf: cf}
+}
+
+// cfWriteBatch moves the keys put into the write batch into the key space of
+// the column family
+type cfWriteBatch struct {
+	util.WriteBatch
+	cf int
+}
+
+func (wb *cfWriteBatch) Set(key, value []byte) {
+	wb.WriteBatch.Set(keysutil.EncodeColumnFamilyKey(wb.cf, key, nil), value)
+}
+
+func (wb *cfWriteBatch) SetDeferred(keyLen, valueLen int, setter func(key, value []byte)) {
+	wb.WriteBatch.SetDeferred(keyLen, valueLen, func(key, value []byte) {
+		setter(key, value)
+		keysutil.EncodeColumnFamilyKeyTo(wb.cf, key, key)
+	})
+}
+
+func (wb *cfWriteBatch) Delete(key []byte) {
+	wb.WriteBatch.Delete(keysutil.EncodeColumnFamilyKey(wb.cf, key, nil))
+}
+
+func (wb *cfWriteBatch) DeleteDeferred(keyLen int, setter func(key []byte)) {
+	wb.WriteBatch.DeleteDeferred(keyLen, func(key []byte) {
+		setter(key)
+		keysutil.EncodeColumnFamilyKeyTo(wb.cf, key, key)
+	})
+}
+
+func (wb *cfWriteBatch) DeleteRange(start, end []byte) {
+	wb.WriteBatch.DeleteRange(keysutil.EncodeColumnFamilyKey(wb.cf, start, nil),
+		keysutil.EncodeColumnFamilyKey(wb.cf, end, nil))
+}
+
+func (wb *cfWriteBatch) DeleteRangeDeferred(startLen, endLen int, setter func(start, end []byte)) {
+	wb.WriteBatch.DeleteRangeDeferred(startLen, endLen, func(start, end []byte) {
+		setter(start, end)
+		keysutil.EncodeColumnFamilyKeyTo(wb.cf, start, start)
+		keysutil.EncodeColumnFamilyKeyTo(wb.cf, end, end)
+	})
+}
+
+// cfKVStorage moves the keys of the operations into the key space of the column
+// family, and moves the keys returned back to the data key space
+type cfKVStorage struct {
+	storage.KVStorage
+	cf int
+}
+
+func (kv *cfKVStorage) encode(key []byte) []byte {
+	return keysutil.EncodeColumnFamilyKey(kv.cf, key, nil)
+}
+
+func (kv *cfKVStorage) NewWriteBatch() storage.Resetable {
+	return &cfWriteBatch{WriteBatch: kv.KVStorage.NewWriteBatch().(util.WriteBatch), cf: kv.cf}
+}
+
+func (kv *cfKVStorage) Write(wb util.WriteBatch, sync bool) error {
+	if v, ok := wb.(*cfWriteBatch); ok {
+		wb = v.WriteBatch
+	}
+	return kv.KVStorage.Write(wb, sync)
+}
+
+func (kv *cfKVStorage) Set(key []byte, value []byte, sync bool) error {
+	return kv.KVStorage.Set(kv.encode(key), value, sync)
+}
+
+func (kv *cfKVStorage) Get(key []byte) ([]byte, error) {
+	return kv.KVStorage.Get(kv.encode(key))
+}
+
+func (kv *cfKVStorage) GetWithFunc(key []byte, fn func(value []byte) error) error {
+	return kv.KVStorage.GetWithFunc(kv.encode(key), fn)
+}
+
+func (kv *cfKVStorage) Delete(key []byte, sync bool) error {
+	return kv.KVStorage.Delete(kv.encode(key), sync)
+}
+
+func (kv *cfKVStorage) RangeDelete(start, end []byte, sync bool) error {
+	return kv.KVStorage.RangeDelete(kv.encode(start), kv.encode(end), sync)
+}
+
+func (kv *cfKVStorage) Scan(start, end []byte,
+	handler func(key, value []byte) (bool, error), clone bool) error {
+	return kv.KVStorage.Scan(kv.encode(start), kv.encode(end), kv.wrapScanHandler(handler, clone), clone)
+}
+
+func (kv *cfKVStorage) ScanInView(view storage.View, start, end []byte,
+	handler func(key, value []byte) (bool, error), clone bool) error {
+	return kv.KVStorage.ScanInView(view, kv.encode(start), kv.encode(end), kv.wrapScanHandler(handler, clone), clone)
+}
+
+func (kv *cfKVStorage) PrefixScan(prefix []byte,
+	handler func(key, value []byte) (bool, error), clone bool) error {
+	return kv.KVStorage.PrefixScan(kv.encode(prefix), kv.wrapScanHandler(handler, clone), clone)
+}
+
+func (kv *cfKVStorage) ScanInViewWithOptions(view storage.View, start, end []byte,
+	handler func(key, value []byte) (storage.NextIterOptions, error)) error {
+	return kv.KVStorage.ScanInViewWithOptions(view, kv.encode(start), kv.encode(end), kv.wrapIterHandler(handler))
+}
+
+func (kv *cfKVStorage) ReverseScanInViewWithOptions(view storage.View, start, end []byte,
+	handler func(key, value []byte) (storage.NextIterOptions, error)) error {
+	return kv.KVStorage.ReverseScanInViewWithOptions(view, kv.encode(start), kv.encode(end), kv.wrapIterHandler(handler))
+}
+
+func (kv *cfKVStorage) Seek(lowerBound []byte) ([]byte, []byte, error) {
+	return kv.decodePair(kv.KVStorage.SeekAndLT(kv.encode(lowerBound), kv.end()))
+}
+
+func (kv *cfKVStorage) SeekAndLT(lowerBound, upperBound []byte) ([]byte, []byte, error) {
+	return kv.decodePair(kv.KVStorage.SeekAndLT(kv.encode(lowerBound), kv.encode(upperBound)))
+}
+
+func (kv *cfKVStorage) SeekLT(upperBound []byte) ([]byte, []byte, error) {
+	return kv.decodePair(kv.KVStorage.SeekLTAndGE(kv.encode(upperBound), kv.start()))
+}
+
+func (kv *cfKVStorage) SeekLTAndGE(upperBound, lowerBound []byte) ([]byte, []byte, error) {
+	return kv.decodePair(kv.KVStorage.SeekLTAndGE(kv.encode(upperBound), kv.encode(lowerBound)))
+}
+
+// start returns the start of the key space of the column family
+func (kv *cfKVStorage) start() []byte {
+	start, _ := keysutil.EncodeColumnFamilyShardRange(kv.cf, nil, nil)
+	return start
+}
+
+// end returns the end of the key space of the column family
+func (kv *cfKVStorage) end() []byte {
+	_, end := keysutil.EncodeColumnFamilyShardRange(kv.cf, nil, nil)
+	return end
+}
+
+// wrapScanHandler moves the keys passed to the handler back to the data key
+// space, the keys are reused between the calls unless the clone is required.
+func (kv *cfKVStorage) wrapScanHandler(handler func(key, value []byte) (bool, error), clone bool) func(key, value []byte) (bool, error) {
+	var buf []byte
+	return func(key, value []byte) (bool, error) {
+		if clone {
+			buf = nil
+		}
+		buf = keysutil.DecodeColumnFamilyKeyTo(kv.cf, key, buf)
+		return handler(buf, value)
+	}
+}
+
+func (kv *cfKVStorage) wrapIterHandler(handler func(key, value []byte) (storage.NextIterOptions, error)) func(key, value []byte) (storage.NextIterOptions, error) {
+	var buf []byte
+	return func(key, value []byte) (storage.NextIterOptions, error) {
+		buf = keysutil.DecodeColumnFamilyKeyTo(kv.cf, key, buf)
+		opts, err := handler(buf, value)
+		if len(opts.SeekGE) > 0 {
+			opts.SeekGE = kv.encode(opts.SeekGE)
+		}
+		if len(opts.SeekLT) > 0 {
+			opts.SeekLT = kv.encode(opts.SeekLT)
+		}
+		return opts, err
+	}
+}
+
+func (kv *cfKVStorage) decodePair(key, value []byte, err error) ([]byte, []byte, error) {
+	if err != nil || len(key) == 0 {
+		return key, value, err
+	}
+	return keysutil.DecodeColumnFamilyKey(kv.cf, key, nil), value, nil
+}
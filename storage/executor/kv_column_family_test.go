@@ -0,0 +1,82 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/kv/mem"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKVExecutorWithColumnFamilies(t *testing.T) {
+	kvStore := mem.NewStorage()
+	defer kvStore.Close()
+
+	ke := NewKVExecutor(kvStore).(*kvExecutor)
+	ke.SetColumnFamilies([]string{"index", "lock"})
+
+	write := func(cf string, cmdType rpcpb.InternalCmd, cmd []byte) {
+		ctx := storage.NewSimpleWriteContext(1, kvStore, storage.Batch{
+			Index:    1,
+			Requests: []storage.Request{{CmdType: uint64(cmdType), CF: cf, Cmd: cmd}},
+		})
+		require.NoError(t, ke.UpdateWriteBatch(ctx))
+		require.NoError(t, ke.ApplyWriteBatch(ctx.WriteBatch()))
+	}
+	read := func(cf string, cmdType rpcpb.InternalCmd, cmd []byte) []byte {
+		v, err := ke.Read(storage.NewSimpleReadContext(1, storage.Request{CmdType: uint64(cmdType), CF: cf, Cmd: cmd}))
+		require.NoError(t, err)
+		return v
+	}
+	get := func(cf, key string) string {
+		return string(getTestGetResponseValue(read(cf, rpcpb.CmdKVGet, newTestGetRequest(key))))
+	}
+	scan := func(cf string) [][]byte {
+		var resp rpcpb.KVScanResponse
+		protoc.MustUnmarshal(&resp, read(cf, rpcpb.CmdKVScan, protoc.MustMarshal(&rpcpb.KVScanRequest{})))
+		return resp.Keys
+	}
+
+	write(storage.DefaultColumnFamily, rpcpb.CmdKVSet, newTestSetRequest("k1", "data"))
+	write("index", rpcpb.CmdKVBatchSet, newTestBatchSetRequest("k1", "index", "k2", "index"))
+	write("lock", rpcpb.CmdKVSet, newTestSetRequest("k1", "lock"))
+
+	assert.Equal(t, "data", get(storage.DefaultColumnFamily, "k1"))
+	assert.Equal(t, "index", get("index", "k1"))
+	assert.Equal(t, "lock", get("lock", "k1"))
+	assert.Equal(t, "", get("lock", "k2"))
+	assert.Equal(t, [][]byte{[]byte("k1")}, scan(storage.DefaultColumnFamily))
+	assert.Equal(t, [][]byte{[]byte("k1"), []byte("k2")}, scan("index"))
+
+	// the column families are stored in separate key spaces
+	v, err := kvStore.Get(keysutil.EncodeColumnFamilyKey(2, keysutil.EncodeDataKey([]byte("k1"), nil), nil))
+	require.NoError(t, err)
+	assert.Equal(t, "lock", string(v))
+
+	// the deletes only change the key space of the column family
+	write("index", rpcpb.CmdKVRangeDelete, newTestRangeDeleteRequest("k1", "k3"))
+	write("lock", rpcpb.CmdKVDelete, newTestDeleteRequest("k1"))
+	assert.Empty(t, scan("index"))
+	assert.Equal(t, "", get("lock", "k1"))
+	assert.Equal(t, "data", get(storage.DefaultColumnFamily, "k1"))
+
+	assert.Panics(t, func() { get("unknown", "k1") })
+	assert.Panics(t, func() { ke.SetColumnFamilies(make([]string, keysutil.MaxColumnFamilies)) })
+}
@@ -34,7 +34,15 @@ var (
 	rangeDeleteResponse     = protoc.MustMarshal(&rpcpb.KVRangeDeleteResponse{})
 	batchMixedWriteResponse = protoc.MustMarshal(&rpcpb.KVMixedWriteResponse{})
 
-	emptyGetResponse = protoc.MustMarshal(&rpcpb.KVGetRequest{})
+	emptyGetResponse    = protoc.MustMarshal(&rpcpb.KVGetRequest{})
+	emptyGetDelResponse = protoc.MustMarshal(&rpcpb.KVGetDelResponse{})
+	deleteIfResponse    = protoc.MustMarshal(&rpcpb.KVDeleteIfResponse{Deleted: true})
+	notDeleteIfResponse = protoc.MustMarshal(&rpcpb.KVDeleteIfResponse{})
+
+	// maxScanResponseBytes is the maximum bytes of the keys and values in a scan
+	// response, the client continues the scan from the last returned key if the
+	// response is not completed.
+	maxScanResponseBytes = uint64(4 * 1024 * 1024)
 )
 
 func handleSet(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
@@ -105,6 +113,76 @@ func doHandleDelete(shard metapb.Shard, req rpcpb.KVDeleteRequest, wb util.Write
 	}, nil
 }
 
+// handleGetDel gets the value of the key and deletes the key in one proposal.
+// The value includes the writes of the previous requests in the same batch.
+func handleGetDel(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+	defer buffer.ResetWrite()
+
+	var req rpcpb.KVGetDelRequest
+	if err := req.FastUnmarshal(cmd); err != nil {
+		panic(err)
+	}
+
+	var resp rpcpb.KVGetDelResponse
+	found := false
+	err := kvStore.GetWithFunc(keysutil.EncodeDataKey(req.Key, buffer), func(value []byte) error {
+		found = true
+		resp.Value = append([]byte(nil), value...)
+		return nil
+	})
+	if err != nil {
+		return KVWriteCommandResult{}, err
+	}
+	if !found {
+		return KVWriteCommandResult{Response: emptyGetDelResponse}, nil
+	}
+
+	kLen := keysutil.DataKeyLen(req.Key)
+	wb.DeleteDeferred(kLen, func(key []byte) {
+		keysutil.EncodeDataKeyTo(req.Key, key)
+	})
+	return KVWriteCommandResult{
+		DiffBytes:    -int64(kLen + len(resp.Value)),
+		WrittenBytes: uint64(kLen),
+		Response:     protoc.MustMarshal(&resp),
+	}, nil
+}
+
+// handleDeleteIf deletes the key only if its current value equals the expected
+// value. The current value includes the writes of the previous requests in the
+// same batch.
+func handleDeleteIf(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
+	defer buffer.ResetWrite()
+
+	var req rpcpb.KVDeleteIfRequest
+	if err := req.FastUnmarshal(cmd); err != nil {
+		panic(err)
+	}
+
+	found, matched := false, false
+	err := kvStore.GetWithFunc(keysutil.EncodeDataKey(req.Key, buffer), func(value []byte) error {
+		found = true
+		matched = bytes.Equal(value, req.Value)
+		return nil
+	})
+	if err != nil {
+		return KVWriteCommandResult{}, err
+	}
+	if !found || !matched {
+		return KVWriteCommandResult{Response: notDeleteIfResponse}, nil
+	}
+
+	kLen := keysutil.DataKeyLen(req.Key)
+	wb.DeleteDeferred(kLen, func(key []byte) {
+		keysutil.EncodeDataKeyTo(req.Key, key)
+	})
+	return KVWriteCommandResult{
+		DiffBytes:    -int64(kLen + len(req.Value)),
+		WrittenBytes: uint64(kLen),
+		Response:     deleteIfResponse,
+	}, nil
+}
+
 func handleBatchDelete(shard metapb.Shard, cmd []byte, wb util.WriteBatch, buffer *buf.ByteBuf, kvStore storage.KVStorage) (KVWriteCommandResult, error) {
 	var req rpcpb.KVBatchDeleteRequest
 	if err := req.FastUnmarshal(cmd); err != nil {
@@ -216,8 +294,8 @@ func handleScan(shard metapb.Shard, cmd []byte, buffer *buf.ByteBuf, kvStore sto
 	if req.Limit == 0 {
 		req.Limit = math.MaxUint64
 	}
-	if req.LimitBytes == 0 {
-		req.LimitBytes = math.MaxUint64
+	if req.LimitBytes == 0 || req.LimitBytes > maxScanResponseBytes {
+		req.LimitBytes = maxScanResponseBytes
 	}
 
 	var resp rpcpb.KVScanResponse
@@ -313,7 +391,7 @@ func handleBatchMixedWrite(shard metapb.Shard, cmd []byte, wb util.WriteBatch, b
 			return mixedResult, err
 		}
 		mixedResult.DiffBytes += result.DiffBytes
-		mixedResult.WrittenBytes += mixedResult.WrittenBytes
+		mixedResult.WrittenBytes += result.WrittenBytes
 	}
 
 	mixedResult.Response = batchMixedWriteResponse
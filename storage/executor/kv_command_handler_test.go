@@ -114,6 +114,88 @@ func TestHandleDelete(t *testing.T) {
 	assert.Equal(t, "", string(v))
 }
 
+func TestHandleGetDel(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+
+	kvStore := mem.NewStorage()
+	defer kvStore.Close()
+
+	buffer := buf.NewByteBuf(32)
+	defer buffer.Release()
+
+	assert.NoError(t, kvStore.Set(keysutil.EncodeDataKey([]byte("k1"), nil), []byte("v1"), false))
+
+	wb := kvStore.NewWriteBatch().(util.WriteBatch)
+	result, err := handleGetDel(metapb.Shard{}, protoc.MustMarshal(&rpcpb.KVGetDelRequest{Key: []byte("k1")}), wb, buffer, kvStore)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-5), result.DiffBytes)
+	assert.Equal(t, uint64(3), result.WrittenBytes)
+	var resp rpcpb.KVGetDelResponse
+	protoc.MustUnmarshal(&resp, result.Response)
+	assert.Equal(t, []byte("v1"), resp.Value)
+
+	assert.NoError(t, kvStore.Write(wb, false))
+	v, err := kvStore.Get(keysutil.EncodeDataKey([]byte("k1"), buffer))
+	assert.NoError(t, err)
+	assert.Equal(t, "", string(v))
+
+	// key not exists
+	wb.Reset()
+	result, err = handleGetDel(metapb.Shard{}, protoc.MustMarshal(&rpcpb.KVGetDelRequest{Key: []byte("k1")}), wb, buffer, kvStore)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.DiffBytes)
+	resp = rpcpb.KVGetDelResponse{}
+	protoc.MustUnmarshal(&resp, result.Response)
+	assert.Empty(t, resp.Value)
+}
+
+func TestHandleDeleteIf(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+
+	kvStore := mem.NewStorage()
+	defer kvStore.Close()
+
+	buffer := buf.NewByteBuf(32)
+	defer buffer.Release()
+
+	assert.NoError(t, kvStore.Set(keysutil.EncodeDataKey([]byte("k1"), nil), []byte("v1"), false))
+
+	tests := []struct {
+		key     string
+		value   string
+		deleted bool
+	}{
+		{key: "k1", value: "v2", deleted: false},
+		{key: "k1", value: "", deleted: false},
+		{key: "k2", value: "", deleted: false},
+		{key: "k1", value: "v1", deleted: true},
+		{key: "k1", value: "v1", deleted: false},
+	}
+	for idx, tt := range tests {
+		wb := kvStore.NewWriteBatch().(util.WriteBatch)
+		result, err := handleDeleteIf(metapb.Shard{}, protoc.MustMarshal(&rpcpb.KVDeleteIfRequest{Key: []byte(tt.key), Value: []byte(tt.value)}), wb, buffer, kvStore)
+		assert.NoError(t, err, "index %d", idx)
+		var resp rpcpb.KVDeleteIfResponse
+		protoc.MustUnmarshal(&resp, result.Response)
+		assert.Equal(t, tt.deleted, resp.Deleted, "index %d", idx)
+		if tt.deleted {
+			assert.Equal(t, int64(-5), result.DiffBytes, "index %d", idx)
+			assert.Equal(t, uint64(3), result.WrittenBytes, "index %d", idx)
+		} else {
+			assert.Equal(t, int64(0), result.DiffBytes, "index %d", idx)
+			assert.Equal(t, uint64(0), result.WrittenBytes, "index %d", idx)
+		}
+		assert.NoError(t, kvStore.Write(wb, false))
+		wb.Close()
+	}
+
+	v, err := kvStore.Get(keysutil.EncodeDataKey([]byte("k1"), buffer))
+	assert.NoError(t, err)
+	assert.Equal(t, "", string(v))
+}
+
 func TestHandleBatchDelete(t *testing.T) {
 	fs := vfs.GetTestFS()
 	defer vfs.ReportLeakedFD(fs, t)
@@ -206,6 +288,7 @@ func TestHandleScan(t *testing.T) {
 		onlyCount       bool
 		limit           uint64
 		limitBytes      uint64
+		maxBytes        uint64
 		expectCount     uint64
 		expectCompleted bool
 		expectKeys      [][]byte
@@ -284,9 +367,38 @@ func TestHandleScan(t *testing.T) {
 			expectCount:     4,
 			expectKeys:      [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")},
 		},
+		{
+			shard:           metapb.Shard{},
+			start:           nil,
+			end:             nil,
+			withValue:       true,
+			maxBytes:        4,
+			expectCompleted: false,
+			expectCount:     2,
+			expectKeys:      [][]byte{[]byte("a"), []byte("b")},
+			expectValues:    [][]byte{[]byte("a"), []byte("b")},
+		},
+		{
+			shard:           metapb.Shard{},
+			start:           nil,
+			end:             nil,
+			limitBytes:      10,
+			maxBytes:        2,
+			expectCompleted: false,
+			expectCount:     2,
+			expectKeys:      [][]byte{[]byte("a"), []byte("b")},
+		},
 	}
 
+	defaultMaxBytes := maxScanResponseBytes
+	defer func() {
+		maxScanResponseBytes = defaultMaxBytes
+	}()
 	for _, c := range cases {
+		maxScanResponseBytes = defaultMaxBytes
+		if c.maxBytes > 0 {
+			maxScanResponseBytes = c.maxBytes
+		}
 		req := &rpcpb.KVScanRequest{}
 		req.Start = c.start
 		req.End = c.end
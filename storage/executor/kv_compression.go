@@ -0,0 +1,197 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/compression"
+	"github.com/matrixorigin/matrixcube/util"
+)
+
+// NewKVExecutorWithCompression returns a kv executor compressing the values of
+// the shard group with the dictionaries of the shards. The values written by
+// the handlers are compressed before they are put into the write batch, and the
+// values read by the handlers are decompressed, so the handlers are not aware
+// of the compression. The written values are sampled to train the next version
+// of the dictionaries, the store proposes the trained dictionaries to the raft
+// group of the shard, and sets the replicated dictionaries of the shard by the
+// storage.DictionaryCompressor implemented by the executor. The store also
+// compresses the write batches proposed to the raft log of the shard with the
+// current replicated dictionary. The kv storage must not contain values written
+// by an executor without compression.
+func NewKVExecutorWithCompression(kv storage.KVStorage, dicts *compression.Dictionaries) RegisterExecutor {
+	ke := NewKVExecutor(kv).(*kvExecutor)
+	ke.dicts = dicts
+	return ke
+}
+
+var _ storage.DictionaryCompressor = (*kvExecutor)(nil)
+
+func (ke *kvExecutor) SetDictionaries(shardID uint64, dicts []metapb.CompressionDictionary) {
+	if ke.dicts != nil {
+		ke.dicts.Set(shardID, dicts)
+	}
+}
+
+func (ke *kvExecutor) TrainDictionary(shardID uint64) ([]byte, bool) {
+	if ke.dicts == nil {
+		return nil, false
+	}
+	return ke.dicts.Train(shardID)
+}
+
+func (ke *kvExecutor) RemoveDictionaries(shardID uint64) {
+	if ke.dicts != nil {
+		ke.dicts.Remove(shardID)
+	}
+}
+
+func (ke *kvExecutor) getKVStorage(shardID uint64, kv storage.KVStorage) storage.KVStorage {
+	if ke.dicts == nil {
+		return kv
+	}
+	return &compressedKVStorage{KVStorage: kv, shardID: shardID, dicts: ke.dicts}
+}
+
+func (ke *kvExecutor) getWriteBatch(shardID uint64, wb util.WriteBatch) util.WriteBatch {
+	if ke.dicts == nil {
+		return wb
+	}
+	return &compressedWriteBatch{WriteBatch: wb, shardID: shardID, dicts: ke.dicts}
+}
+
+// compressedWriteBatch compresses the values put into the write batch
+type compressedWriteBatch struct {
+	util.WriteBatch
+	shardID uint64
+	dicts   *compression.Dictionaries
+}
+
+func (wb *compressedWriteBatch) Set(key, value []byte) {
+	wb.dicts.Sample(wb.shardID, value)
+	compressed, err := wb.dicts.Compress(wb.shardID, value)
+	if err != nil {
+		panic(err)
+	}
+	wb.WriteBatch.Set(key, compressed)
+}
+
+func (wb *compressedWriteBatch) SetDeferred(keyLen, valueLen int, setter func(key, value []byte)) {
+	key, value := make([]byte, keyLen), make([]byte, valueLen)
+	setter(key, value)
+	wb.Set(key, value)
+}
+
+// compressedKVStorage decompresses the values read from the storage
+type compressedKVStorage struct {
+	storage.KVStorage
+	shardID uint64
+	dicts   *compression.Dictionaries
+}
+
+func (kv *compressedKVStorage) Set(key []byte, value []byte, sync bool) error {
+	compressed, err := kv.dicts.Compress(kv.shardID, value)
+	if err != nil {
+		return err
+	}
+	return kv.KVStorage.Set(key, compressed, sync)
+}
+
+func (kv *compressedKVStorage) Get(key []byte) ([]byte, error) {
+	value, err := kv.KVStorage.Get(key)
+	if err != nil || len(value) == 0 {
+		return value, err
+	}
+	return kv.dicts.Decompress(kv.shardID, value)
+}
+
+func (kv *compressedKVStorage) GetWithFunc(key []byte, fn func(value []byte) error) error {
+	return kv.KVStorage.GetWithFunc(key, func(value []byte) error {
+		value, err := kv.dicts.Decompress(kv.shardID, value)
+		if err != nil {
+			return err
+		}
+		return fn(value)
+	})
+}
+
+func (kv *compressedKVStorage) Scan(start, end []byte,
+	handler func(key, value []byte) (bool, error), clone bool) error {
+	return kv.KVStorage.Scan(start, end, kv.wrapScanHandler(handler), clone)
+}
+
+func (kv *compressedKVStorage) ScanInView(view storage.View, start, end []byte,
+	handler func(key, value []byte) (bool, error), clone bool) error {
+	return kv.KVStorage.ScanInView(view, start, end, kv.wrapScanHandler(handler), clone)
+}
+
+func (kv *compressedKVStorage) PrefixScan(prefix []byte,
+	handler func(key, value []byte) (bool, error), clone bool) error {
+	return kv.KVStorage.PrefixScan(prefix, kv.wrapScanHandler(handler), clone)
+}
+
+func (kv *compressedKVStorage) ScanInViewWithOptions(view storage.View, start, end []byte,
+	handler func(key, value []byte) (storage.NextIterOptions, error)) error {
+	return kv.KVStorage.ScanInViewWithOptions(view, start, end, kv.wrapIterHandler(handler))
+}
+
+func (kv *compressedKVStorage) ReverseScanInViewWithOptions(view storage.View, start, end []byte,
+	handler func(key, value []byte) (storage.NextIterOptions, error)) error {
+	return kv.KVStorage.ReverseScanInViewWithOptions(view, start, end, kv.wrapIterHandler(handler))
+}
+
+func (kv *compressedKVStorage) Seek(lowerBound []byte) ([]byte, []byte, error) {
+	return kv.decompressPair(kv.KVStorage.Seek(lowerBound))
+}
+
+func (kv *compressedKVStorage) SeekAndLT(lowerBound, upperBound []byte) ([]byte, []byte, error) {
+	return kv.decompressPair(kv.KVStorage.SeekAndLT(lowerBound, upperBound))
+}
+
+func (kv *compressedKVStorage) SeekLT(upperBound []byte) ([]byte, []byte, error) {
+	return kv.decompressPair(kv.KVStorage.SeekLT(upperBound))
+}
+
+func (kv *compressedKVStorage) SeekLTAndGE(upperBound, lowerBound []byte) ([]byte, []byte, error) {
+	return kv.decompressPair(kv.KVStorage.SeekLTAndGE(upperBound, lowerBound))
+}
+
+func (kv *compressedKVStorage) wrapScanHandler(handler func(key, value []byte) (bool, error)) func(key, value []byte) (bool, error) {
+	return func(key, value []byte) (bool, error) {
+		value, err := kv.dicts.Decompress(kv.shardID, value)
+		if err != nil {
+			return false, err
+		}
+		return handler(key, value)
+	}
+}
+
+func (kv *compressedKVStorage) wrapIterHandler(handler func(key, value []byte) (storage.NextIterOptions, error)) func(key, value []byte) (storage.NextIterOptions, error) {
+	return func(key, value []byte) (storage.NextIterOptions, error) {
+		value, err := kv.dicts.Decompress(kv.shardID, value)
+		if err != nil {
+			return storage.NextIterOptions{}, err
+		}
+		return handler(key, value)
+	}
+}
+
+func (kv *compressedKVStorage) decompressPair(key, value []byte, err error) ([]byte, []byte, error) {
+	if err != nil || len(key) == 0 {
+		return key, value, err
+	}
+	value, err = kv.dicts.Decompress(kv.shardID, value)
+	return key, value, err
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
+	"github.com/matrixorigin/matrixcube/storage/compression"
+	"github.com/matrixorigin/matrixcube/storage/kv/mem"
+	"github.com/matrixorigin/matrixcube/util"
+	"github.com/matrixorigin/matrixcube/util/buf"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKVExecutorWithCompression(t *testing.T) {
+	kvStore := mem.NewStorage()
+	defer kvStore.Close()
+	buffer := buf.NewByteBuf(32)
+	defer buffer.Release()
+
+	dicts := compression.NewDictionaries(3, 10, 1024)
+	ke := NewKVExecutorWithCompression(kvStore, dicts).(*kvExecutor)
+	shard := metapb.Shard{ID: 1, Group: 1}
+	value := func(i int) string {
+		return fmt.Sprintf(`{"id":%d,"name":"user-%d","email":"user-%d@example.com","status":"active"}`, i, i, i)
+	}
+
+	write := func(i int) {
+		wb := kvStore.NewWriteBatch().(util.WriteBatch)
+		_, err := handleSet(shard, newTestSetRequest(fmt.Sprintf("k%d", i), value(i)), ke.getWriteBatch(shard.ID, wb), buffer, ke.getKVStorage(shard.ID, ke.kv))
+		require.NoError(t, err)
+		require.NoError(t, kvStore.Write(wb, false))
+	}
+	for i := 0; i < 10; i++ {
+		write(i)
+	}
+	data, ok := ke.TrainDictionary(shard.ID)
+	require.True(t, ok)
+	ke.SetDictionaries(shard.ID, []metapb.CompressionDictionary{{Version: 1, Data: data}})
+	for i := 10; i < 20; i++ {
+		write(i)
+	}
+
+	// the values are compressed in the storage
+	v, err := kvStore.Get(keysutil.EncodeDataKey([]byte("k15"), buffer))
+	require.NoError(t, err)
+	assert.True(t, len(v) < len(value(15)))
+
+	kv := ke.getKVStorage(shard.ID, ke.kv)
+	for _, i := range []int{0, 15} {
+		readed, err := handleGet(shard, newTestGetRequest(fmt.Sprintf("k%d", i)), buffer, kv)
+		require.NoError(t, err)
+		assert.Equal(t, value(i), string(getTestGetResponseValue(readed.Response)))
+	}
+
+	readed, err := handleBatchGet(shard, newTestBatchGetRequest("k1", "k100", "k11"), buffer, kv)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte(value(1)), {}, []byte(value(11))}, getTestBatchGetResponseValue(readed.Response))
+
+	readed, err = handleScan(shard, protoc.MustMarshal(&rpcpb.KVScanRequest{Start: []byte("k1"), End: []byte("k11"), WithValue: true}), buffer, kv)
+	require.NoError(t, err)
+	var resp rpcpb.KVScanResponse
+	protoc.MustUnmarshal(&resp, readed.Response)
+	assert.Equal(t, [][]byte{[]byte(value(1)), []byte(value(10))}, resp.Values)
+
+	// the dictionaries of the removed shard are dropped
+	ke.RemoveDictionaries(shard.ID)
+	_, err = handleGet(shard, newTestGetRequest("k15"), buffer, kv)
+	assert.Equal(t, compression.ErrDictionaryNotFound, err)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/compression"
 	"github.com/matrixorigin/matrixcube/util"
 	"github.com/matrixorigin/matrixcube/util/buf"
 )
@@ -60,6 +61,10 @@ type KVReadCommandHandler func(shard metapb.Shard, cmd []byte, buffer *buf.ByteB
 // kvExecutor is a kv executor.
 type kvExecutor struct {
 	kv storage.KVStorage
+	// dicts compresses the values of the shards if not nil
+	dicts *compression.Dictionaries
+	// cfs the column families of the requests
+	cfs storage.Feature
 
 	writeHandlers map[uint64]KVWriteCommandHandler
 	readHandlers  map[uint64]KVReadCommandHandler
@@ -81,6 +86,8 @@ func NewKVExecutor(kv storage.KVStorage) RegisterExecutor {
 	ke.writeHandlers[uint64(rpcpb.CmdKVBatchDelete)] = handleBatchDelete
 	ke.writeHandlers[uint64(rpcpb.CmdKVRangeDelete)] = handleRangeDelete
 	ke.writeHandlers[uint64(rpcpb.CmdKVBatchMixedWrite)] = handleBatchMixedWrite
+	ke.writeHandlers[uint64(rpcpb.CmdKVGetDel)] = handleGetDel
+	ke.writeHandlers[uint64(rpcpb.CmdKVDeleteIf)] = handleDeleteIf
 
 	ke.readHandlers[uint64(rpcpb.CmdKVGet)] = handleGet
 	ke.readHandlers[uint64(rpcpb.CmdKVBatchGet)] = handleBatchGet
@@ -110,6 +117,16 @@ func (ke *kvExecutor) UpdateWriteBatch(ctx storage.WriteContext) error {
 	batch := ctx.Batch()
 	requests := batch.Requests
 	buffer := ctx.(storage.InternalContext).ByteBuf()
+	shardID := ctx.Shard().ID
+	kv := ke.kv
+	// the requests of the batch read the writes of the previous requests
+	if len(requests) > 1 {
+		pending := newPendingWrites()
+		wb = &pendingWriteBatch{WriteBatch: wb, pending: pending}
+		kv = &pendingKVStorage{KVStorage: kv, pending: pending}
+	}
+	wb = ke.getWriteBatch(shardID, wb)
+	kv = ke.getKVStorage(shardID, kv)
 
 	for idx := range requests {
 		handlerFunc, ok := ke.writeHandlers[requests[idx].CmdType]
@@ -117,7 +134,9 @@ func (ke *kvExecutor) UpdateWriteBatch(ctx storage.WriteContext) error {
 			panic(fmt.Errorf("not support write cmd %d", requests[idx].CmdType))
 		}
 
-		result, err := handlerFunc(ctx.Shard(), requests[idx].Cmd, wb, buffer, ke.kv)
+		cf := ke.getColumnFamilyID(requests[idx].CF)
+		result, err := handlerFunc(ctx.Shard(), requests[idx].Cmd,
+			withColumnFamilyWriteBatch(cf, wb), buffer, withColumnFamilyKVStorage(cf, kv))
 		if err != nil {
 			return err
 		}
@@ -145,7 +164,8 @@ func (ke *kvExecutor) Read(ctx storage.ReadContext) ([]byte, error) {
 		panic(fmt.Errorf("not support read cmd %d", request.CmdType))
 	}
 
-	result, err := handlerFunc(ctx.Shard(), request.Cmd, buffer, ke.kv)
+	kv := withColumnFamilyKVStorage(ke.getColumnFamilyID(request.CF), ke.getKVStorage(ctx.Shard().ID, ke.kv))
+	result, err := handlerFunc(ctx.Shard(), request.Cmd, buffer, kv)
 	if err != nil {
 		return nil, err
 	}
@@ -16,6 +16,7 @@ package executor
 import (
 	"testing"
 
+	"github.com/fagongzi/util/protoc"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
@@ -24,6 +25,7 @@ import (
 	"github.com/matrixorigin/matrixcube/util/buf"
 	"github.com/matrixorigin/matrixcube/vfs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRegisterWriteHandler(t *testing.T) {
@@ -65,3 +67,76 @@ func TestRegisterReadHandler(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, handled)
 }
+
+func TestDeleteIfReadsPendingWrites(t *testing.T) {
+	kvStore := mem.NewStorage()
+	defer kvStore.Close()
+
+	exec := NewKVExecutor(kvStore)
+	write := func(requests ...storage.Request) [][]byte {
+		ctx := storage.NewSimpleWriteContext(1, kvStore, storage.Batch{Index: 1, Requests: requests})
+		require.NoError(t, exec.UpdateWriteBatch(ctx))
+		require.NoError(t, exec.ApplyWriteBatch(ctx.WriteBatch()))
+		return ctx.Responses()
+	}
+	set := func(k, v string) storage.Request {
+		return storage.Request{CmdType: uint64(rpcpb.CmdKVSet), Cmd: newTestSetRequest(k, v)}
+	}
+	deleteIf := func(k, v string) storage.Request {
+		return storage.Request{CmdType: uint64(rpcpb.CmdKVDeleteIf),
+			Cmd: protoc.MustMarshal(&rpcpb.KVDeleteIfRequest{Key: []byte(k), Value: []byte(v)})}
+	}
+	deleted := func(resp []byte) bool {
+		var v rpcpb.KVDeleteIfResponse
+		protoc.MustUnmarshal(&v, resp)
+		return v.Deleted
+	}
+	get := func(k string) string {
+		v, err := exec.Read(storage.NewSimpleReadContext(1, storage.Request{CmdType: uint64(rpcpb.CmdKVGet), Cmd: newTestGetRequest(k)}))
+		require.NoError(t, err)
+		return string(getTestGetResponseValue(v))
+	}
+
+	// the value is changed by the previous request
+	write(set("k1", "v1"))
+	resps := write(set("k1", "v2"), deleteIf("k1", "v1"))
+	assert.False(t, deleted(resps[1]))
+	assert.Equal(t, "v2", get("k1"))
+
+	// the key is created by the previous request
+	resps = write(set("k2", "v1"), deleteIf("k2", "v1"))
+	assert.True(t, deleted(resps[1]))
+	assert.Equal(t, "", get("k2"))
+
+	// the key is deleted by the previous request
+	resps = write(set("k3", "v1"), storage.Request{CmdType: uint64(rpcpb.CmdKVRangeDelete),
+		Cmd: newTestRangeDeleteRequest("k1", "k4")}, deleteIf("k3", "v1"))
+	assert.False(t, deleted(resps[2]))
+	assert.Equal(t, "", get("k1"))
+}
+
+func TestGetDelReadsPendingWrites(t *testing.T) {
+	kvStore := mem.NewStorage()
+	defer kvStore.Close()
+
+	exec := NewKVExecutor(kvStore)
+	ctx := storage.NewSimpleWriteContext(1, kvStore, storage.Batch{Index: 1, Requests: []storage.Request{
+		{CmdType: uint64(rpcpb.CmdKVSet), Cmd: newTestSetRequest("k1", "v1")},
+		{CmdType: uint64(rpcpb.CmdKVGetDel), Cmd: protoc.MustMarshal(&rpcpb.KVGetDelRequest{Key: []byte("k1")})},
+		{CmdType: uint64(rpcpb.CmdKVGetDel), Cmd: protoc.MustMarshal(&rpcpb.KVGetDelRequest{Key: []byte("k1")})},
+	}})
+	require.NoError(t, exec.UpdateWriteBatch(ctx))
+	require.NoError(t, exec.ApplyWriteBatch(ctx.WriteBatch()))
+
+	var resp rpcpb.KVGetDelResponse
+	protoc.MustUnmarshal(&resp, ctx.Responses()[1])
+	assert.Equal(t, []byte("v1"), resp.Value)
+	// deleted by the previous GetDel
+	resp = rpcpb.KVGetDelResponse{}
+	protoc.MustUnmarshal(&resp, ctx.Responses()[2])
+	assert.Empty(t, resp.Value)
+
+	v, err := exec.Read(storage.NewSimpleReadContext(1, storage.Request{CmdType: uint64(rpcpb.CmdKVGet), Cmd: newTestGetRequest("k1")}))
+	require.NoError(t, err)
+	assert.Empty(t, getTestGetResponseValue(v))
+}
@@ -0,0 +1,149 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"bytes"
+
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/util"
+)
+
+// pendingWrites records the writes put into the write batch by the requests of
+// a batch. The writes are not visible in the storage until the write batch is
+// applied, the handlers reading the keys written by the previous requests of
+// the same batch, e.g. GetDel and DeleteIf, read them from the pendingWrites.
+type pendingWrites struct {
+	seq    uint64
+	keys   map[string]pendingWrite
+	ranges []pendingRange
+}
+
+type pendingWrite struct {
+	seq     uint64
+	value   []byte
+	deleted bool
+}
+
+type pendingRange struct {
+	seq        uint64
+	start, end []byte
+}
+
+func newPendingWrites() *pendingWrites {
+	return &pendingWrites{keys: make(map[string]pendingWrite)}
+}
+
+func (p *pendingWrites) set(key, value []byte) {
+	p.seq++
+	p.keys[string(key)] = pendingWrite{seq: p.seq, value: append([]byte{}, value...)}
+}
+
+func (p *pendingWrites) delete(key []byte) {
+	p.seq++
+	p.keys[string(key)] = pendingWrite{seq: p.seq, deleted: true}
+}
+
+func (p *pendingWrites) deleteRange(start, end []byte) {
+	p.seq++
+	p.ranges = append(p.ranges, pendingRange{
+		seq:   p.seq,
+		start: append([]byte(nil), start...),
+		end:   append([]byte(nil), end...),
+	})
+}
+
+// get returns the value of the key written by the batch, ok is false if the
+// key is not written by the batch and has to be read from the storage. A nil
+// value with ok means the key is deleted by the batch.
+func (p *pendingWrites) get(key []byte) (value []byte, ok bool) {
+	w, ok := p.keys[string(key)]
+	for _, r := range p.ranges {
+		if r.seq > w.seq &&
+			bytes.Compare(key, r.start) >= 0 &&
+			bytes.Compare(key, r.end) < 0 {
+			return nil, true
+		}
+	}
+	if !ok || w.deleted {
+		return nil, ok
+	}
+	return w.value, true
+}
+
+// pendingWriteBatch records the writes into the pendingWrites
+type pendingWriteBatch struct {
+	util.WriteBatch
+	pending *pendingWrites
+}
+
+func (wb *pendingWriteBatch) Set(key, value []byte) {
+	wb.WriteBatch.Set(key, value)
+	wb.pending.set(key, value)
+}
+
+func (wb *pendingWriteBatch) SetDeferred(keyLen, valueLen int, setter func(key, value []byte)) {
+	wb.WriteBatch.SetDeferred(keyLen, valueLen, func(key, value []byte) {
+		setter(key, value)
+		wb.pending.set(key, value)
+	})
+}
+
+func (wb *pendingWriteBatch) Delete(key []byte) {
+	wb.WriteBatch.Delete(key)
+	wb.pending.delete(key)
+}
+
+func (wb *pendingWriteBatch) DeleteDeferred(keyLen int, setter func(key []byte)) {
+	wb.WriteBatch.DeleteDeferred(keyLen, func(key []byte) {
+		setter(key)
+		wb.pending.delete(key)
+	})
+}
+
+func (wb *pendingWriteBatch) DeleteRange(start, end []byte) {
+	wb.WriteBatch.DeleteRange(start, end)
+	wb.pending.deleteRange(start, end)
+}
+
+func (wb *pendingWriteBatch) DeleteRangeDeferred(startLen, endLen int, setter func(start, end []byte)) {
+	wb.WriteBatch.DeleteRangeDeferred(startLen, endLen, func(start, end []byte) {
+		setter(start, end)
+		wb.pending.deleteRange(start, end)
+	})
+}
+
+// pendingKVStorage reads the keys written by the batch from the pendingWrites.
+// Only the point reads see the pending writes, the scans read the storage.
+type pendingKVStorage struct {
+	storage.KVStorage
+	pending *pendingWrites
+}
+
+func (kv *pendingKVStorage) Get(key []byte) ([]byte, error) {
+	if value, ok := kv.pending.get(key); ok {
+		return value, nil
+	}
+	return kv.KVStorage.Get(key)
+}
+
+func (kv *pendingKVStorage) GetWithFunc(key []byte, fn func(value []byte) error) error {
+	if value, ok := kv.pending.get(key); ok {
+		if value == nil {
+			return nil
+		}
+		return fn(value)
+	}
+	return kv.KVStorage.GetWithFunc(key, fn)
+}
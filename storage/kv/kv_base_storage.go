@@ -17,6 +17,7 @@ import (
 	"encoding/binary"
 	"io"
 	"math"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/pebble"
@@ -37,19 +38,48 @@ var (
 type BaseStorage struct {
 	kv storage.KVStorage
 	fs vfs.FS
+	// serializer the serializer of the shard metadata in the snapshots, it's
+	// set by the data storage
+	serializer storage.MetadataSerializer
 }
 
+var _ storage.MetadataSerializerUser = (*BaseStorage)(nil)
+
 func NewBaseStorage(kv storage.KVStorage, fs vfs.FS) storage.KVBaseStorage {
 	return &BaseStorage{
-		kv: kv,
-		fs: fs,
+		kv:         kv,
+		fs:         fs,
+		serializer: storage.DefaultMetadataSerializer(),
 	}
 }
 
+// SetMetadataSerializer implements storage.MetadataSerializerUser
+func (s *BaseStorage) SetMetadataSerializer(serializer storage.MetadataSerializer) {
+	s.serializer = serializer
+}
+
 func (s *BaseStorage) GetView() storage.View {
 	return s.kv.GetView()
 }
 
+// ReleaseViews implements storage.ResourceReleaser if the underlying kv storage
+// supports it
+func (s *BaseStorage) ReleaseViews(before time.Time) int {
+	if r, ok := s.kv.(storage.ResourceReleaser); ok {
+		return r.ReleaseViews(before)
+	}
+	return 0
+}
+
+// CompactAll implements storage.ResourceReleaser if the underlying kv storage
+// supports it
+func (s *BaseStorage) CompactAll() error {
+	if r, ok := s.kv.(storage.ResourceReleaser); ok {
+		return r.CompactAll()
+	}
+	return nil
+}
+
 func (s *BaseStorage) Close() error {
 	return s.kv.Close()
 }
@@ -184,31 +214,57 @@ func (s *BaseStorage) getShardMetadata(ss *pebble.Snapshot,
 
 // CreateSnapshot create a snapshot file under the giving path
 func (s *BaseStorage) CreateSnapshot(shardID uint64, path string) error {
-	if err := s.fs.MkdirAll(path, 0755); err != nil {
+	cp, err := s.CreateSnapshotCheckpoint(shardID)
+	if err != nil {
+		return err
+	}
+	defer cp.Close()
+	return cp.Save(path)
+}
+
+// CreateSnapshotCheckpoint implements storage.SnapshotCheckpointer, the
+// checkpoint holds a view of the kv storage until it's closed.
+func (s *BaseStorage) CreateSnapshotCheckpoint(shardID uint64) (storage.SnapshotCheckpoint, error) {
+	return &snapshotCheckpoint{
+		base:    s,
+		shardID: shardID,
+		view:    s.kv.GetView(),
+	}, nil
+}
+
+type snapshotCheckpoint struct {
+	base    *BaseStorage
+	shardID uint64
+	view    storage.View
+}
+
+func (c *snapshotCheckpoint) Save(path string) error {
+	fs := c.base.fs
+	if err := fs.MkdirAll(path, 0755); err != nil {
 		return err
 	}
-	file := s.fs.PathJoin(path, "db.data")
-	f, err := s.fs.Create(file)
+	file := fs.PathJoin(path, "db.data")
+	f, err := fs.Create(file)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
-	view := s.kv.GetView()
-	defer view.Close()
 
-	snap := view.Raw().(*pebble.Snapshot)
-	appliedIndexKey, appliedIndexValue, err := s.getAppliedIndex(snap, shardID)
+	snap := c.view.Raw().(*pebble.Snapshot)
+	appliedIndexKey, appliedIndexValue, err := c.base.getAppliedIndex(snap, c.shardID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get applied index in CreateSnapshot")
 	}
-	metadataKey, metadataValue, err := s.getShardMetadata(snap, shardID)
+	metadataKey, metadataValue, err := c.base.getShardMetadata(snap, c.shardID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get shard in CreateSnapshot")
 	}
 
-	var sls metapb.ShardMetadata
+	sls, err := c.base.serializer.Unmarshal(metadataValue)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read shard in CreateSnapshot")
+	}
 	var logIndex metapb.LogIndex
-	protoc.MustUnmarshal(&sls, metadataValue)
 	protoc.MustUnmarshal(&logIndex, appliedIndexValue)
 	shard := sls.Metadata.Shard
 
@@ -231,12 +287,28 @@ func (s *BaseStorage) CreateSnapshot(shardID uint64, path string) error {
 		return err
 	}
 
-	ios := &pebble.IterOptions{
-		LowerBound: keysutil.EncodeShardStart(shard.Start, nil),
-		UpperBound: keysutil.EncodeShardEnd(shard.End, nil),
+	// the base storage is not aware of the column families defined by the data
+	// storage, the key spaces of all possible column families are included
+	for cf := 0; cf < keysutil.MaxColumnFamilies; cf++ {
+		if err := writeSnapshotRange(f, snap, cf, shard); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+func (c *snapshotCheckpoint) Close() error {
+	return c.view.Close()
+}
 
-	iter := snap.NewIter(ios)
+// writeSnapshotRange writes the data of the shard in the key space of the
+// column family
+func writeSnapshotRange(f vfs.File, snap *pebble.Snapshot, cf int, shard metapb.Shard) error {
+	min, max := keysutil.EncodeColumnFamilyShardRange(cf, shard.Start, shard.End)
+	iter := snap.NewIter(&pebble.IterOptions{
+		LowerBound: min,
+		UpperBound: max,
+	})
 	defer iter.Close()
 	iter.First()
 	for iter.Valid() {
@@ -246,16 +318,25 @@ func (s *BaseStorage) CreateSnapshot(shardID uint64, path string) error {
 		if err := writeBytes(f, iter.Key()); err != nil {
 			return err
 		}
-		if err = writeBytes(f, iter.Value()); err != nil {
+		if err := writeBytes(f, iter.Value()); err != nil {
 			return err
 		}
 		iter.Next()
 	}
-
 	return nil
 }
 
-// ApplySnapshot apply a snapshort file from giving path
+// snapshotApplyBatchSize the max bytes of the shard data written in a batch
+// when applying a snapshot, to bound the memory used by big snapshots.
+var snapshotApplyBatchSize = 4 * 1024 * 1024
+
+// ApplySnapshot apply a snapshort file from giving path. The shard data is
+// written in multiple batches, a SnapshotApplyingState pointing to the path is
+// saved before any shard data is changed, and removed in the last batch
+// together with the update of the applied index and the shard metadata. If the
+// process crashes in the middle, the shard keeps the old applied index and the
+// SnapshotApplyingState, GetSnapshotApplyingStates returns it so that the
+// snapshot can be applied again from the path.
 func (s *BaseStorage) ApplySnapshot(shardID uint64, path string) error {
 	f, err := s.fs.Open(s.fs.PathJoin(path, "db.data"))
 	if err != nil {
@@ -295,10 +376,32 @@ func (s *BaseStorage) ApplySnapshot(shardID uint64, path string) error {
 	if err != nil {
 		return err
 	}
+	// the shard metadata is persisted in the local format, the snapshot in a
+	// format newer than the local one is refused before any data is changed
+	md, err := s.serializer.Unmarshal(metadataValue)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read shard in ApplySnapshot")
+	}
+	metadataValue = s.serializer.Marshal(md)
+
+	var logIndex metapb.LogIndex
+	protoc.MustUnmarshal(&logIndex, appliedIndexValue)
+	applyingKey := keysutil.EncodeShardMetadataKey(keys.GetSnapshotApplyingKey(shardID, nil), nil)
+	batch.Set(applyingKey, protoc.MustMarshal(&metapb.SnapshotApplyingState{
+		Index: logIndex.Index,
+		Dir:   path,
+	}))
 	batch.DeleteRange(start, end)
-	batch.Set(appliedIndexKey, appliedIndexValue)
-	batch.Set(metadataKey, metadataValue)
+	for cf := 1; cf < keysutil.MaxColumnFamilies; cf++ {
+		batch.DeleteRange(keysutil.EncodeColumnFamilyKey(cf, start, nil),
+			keysutil.EncodeColumnFamilyKey(cf, end, nil))
+	}
+	if err := s.kv.Write(batch, true); err != nil {
+		return err
+	}
+	batch.Reset()
 
+	size := 0
 	for {
 		key, err := readBytes(f)
 		if err != nil {
@@ -315,7 +418,19 @@ func (s *BaseStorage) ApplySnapshot(shardID uint64, path string) error {
 			panic("key specified without value")
 		}
 		batch.Set(key, value)
+		size += len(key) + len(value)
+		if size >= snapshotApplyBatchSize {
+			if err := s.kv.Write(batch, false); err != nil {
+				return err
+			}
+			batch.Reset()
+			size = 0
+		}
 	}
+
+	batch.Set(appliedIndexKey, appliedIndexValue)
+	batch.Set(metadataKey, metadataValue)
+	batch.Delete(applyingKey)
 	if err := s.kv.Write(batch, true); err != nil {
 		return err
 	}
@@ -323,6 +438,30 @@ func (s *BaseStorage) ApplySnapshot(shardID uint64, path string) error {
 	return s.kv.Sync()
 }
 
+// GetSnapshotApplyingStates returns the snapshots not fully applied, keyed by
+// the shard id.
+func (s *BaseStorage) GetSnapshotApplyingStates() (map[uint64]metapb.SnapshotApplyingState, error) {
+	min := keysutil.EncodeShardMetadataKey(keys.GetSnapshotApplyingKey(0, nil), nil)
+	max := keysutil.EncodeShardMetadataKey(keys.GetSnapshotApplyingKey(math.MaxUint64, nil), nil)
+	states := make(map[uint64]metapb.SnapshotApplyingState)
+	if err := s.kv.Scan(min, max, func(key, value []byte) (bool, error) {
+		key = key[1:]
+		if keys.IsSnapshotApplyingKey(key) {
+			shardID, err := keys.GetShardIDFromSnapshotApplyingKey(key)
+			if err != nil {
+				panic(err)
+			}
+			var state metapb.SnapshotApplyingState
+			protoc.MustUnmarshal(&state, value)
+			states[shardID] = state
+		}
+		return true, nil
+	}, false); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
 func writeBytes(f vfs.File, data []byte) error {
 	size := make([]byte, 4)
 	binary.BigEndian.PutUint32(size, uint32(len(data)))
@@ -140,7 +140,7 @@ func TestGetShardMetadata(t *testing.T) {
 	key, val, err := base.(*BaseStorage).getShardMetadata(view.Raw().(*pebble.Snapshot), 100)
 	assert.NoError(t, err)
 	assert.Equal(t, keys.GetMetadataKey(uint64(100), uint64(120), nil), key[1:])
-	assert.Equal(t, protoc.MustMarshal(&sm2), val)
+	assert.Equal(t, storage.DefaultMetadataSerializer().Marshal(sm2), val)
 }
 
 func TestCreateAndApplySnapshot(t *testing.T) {
@@ -161,6 +161,9 @@ func TestCreateAndApplySnapshot(t *testing.T) {
 		assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("bb"), nil), []byte("v"), false))
 		assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("mmm"), nil), []byte("vv"), false))
 		assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("yy"), nil), []byte("vvv"), false))
+		assert.NoError(t, base.Set(testColumnFamilyKey(1, "bb"), []byte("cf1"), false))
+		assert.NoError(t, base.Set(testColumnFamilyKey(keysutil.MaxColumnFamilies-1, "bb"), []byte("cf7"), false))
+		assert.NoError(t, base.Set(testColumnFamilyKey(1, "yy"), []byte("cf1"), false))
 		shard := metapb.Shard{
 			ID:    shardID,
 			Start: []byte("aa"),
@@ -174,7 +177,7 @@ func TestCreateAndApplySnapshot(t *testing.T) {
 			LogIndex: 110,
 			Metadata: sls,
 		}
-		metadata = protoc.MustMarshal(&sm)
+		metadata = storage.DefaultMetadataSerializer().Marshal(sm)
 		assert.NoError(t, ds.SaveShardMetadata([]metapb.ShardMetadata{sm}))
 		err := base.CreateSnapshot(sm.ShardID, dir)
 		assert.NoError(t, err)
@@ -187,8 +190,23 @@ func TestCreateAndApplySnapshot(t *testing.T) {
 		defer ds.Close()
 		assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("cc"), nil), []byte("vv"), false))
 		assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("yy"), nil), []byte("zzz"), false))
+		assert.NoError(t, base.Set(testColumnFamilyKey(1, "cc"), []byte("cf1"), false))
 		assert.NoError(t, base.ApplySnapshot(shardID, dir))
-		v, err := base.Get(keysutil.EncodeDataKey([]byte("cc"), nil))
+		// the key spaces of the column families are replaced too
+		v, err := base.Get(testColumnFamilyKey(1, "cc"))
+		assert.NoError(t, err)
+		assert.Empty(t, v)
+		v, err = base.Get(testColumnFamilyKey(1, "bb"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("cf1"), v)
+		v, err = base.Get(testColumnFamilyKey(keysutil.MaxColumnFamilies-1, "bb"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("cf7"), v)
+		v, err = base.Get(testColumnFamilyKey(1, "yy"))
+		assert.NoError(t, err)
+		assert.Empty(t, v)
+
+		v, err = base.Get(keysutil.EncodeDataKey([]byte("cc"), nil))
 		assert.NoError(t, err)
 		assert.Empty(t, v)
 		v, err = base.Get(keysutil.EncodeDataKey([]byte("yy"), nil))
@@ -216,6 +234,180 @@ func TestCreateAndApplySnapshot(t *testing.T) {
 	}()
 }
 
+func TestApplySnapshotRefusesNewerMetadataFormat(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	dir := "snapshot-dir-safe-to-delete"
+	shardID := uint64(100)
+	require.NoError(t, fs.RemoveAll(dir))
+	defer func() {
+		require.NoError(t, fs.RemoveAll(dir))
+	}()
+	sm := metapb.ShardMetadata{
+		ShardID:  shardID,
+		LogIndex: 110,
+		Metadata: metapb.ShardLocalState{
+			Shard: metapb.Shard{ID: shardID, Start: []byte("aa"), End: []byte("xx")},
+		},
+	}
+	func() {
+		kv := mem.NewStorage()
+		base := NewBaseStorage(kv, fs)
+		ds := NewKVDataStorage(base, executor.NewKVExecutor(kv),
+			WithMetadataSerializer(storage.NewMetadataSerializer(storage.CurrentMetadataFormat+1, nil)))
+		defer ds.Close()
+		assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("bb"), nil), []byte("v"), false))
+		assert.NoError(t, ds.SaveShardMetadata([]metapb.ShardMetadata{sm}))
+		assert.NoError(t, base.CreateSnapshot(shardID, dir))
+	}()
+
+	kv := mem.NewStorage()
+	base := NewBaseStorage(kv, fs)
+	ds := NewKVDataStorage(base, executor.NewKVExecutor(kv))
+	defer ds.Close()
+	assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("cc"), nil), []byte("vv"), false))
+	assert.ErrorIs(t, base.ApplySnapshot(shardID, dir), storage.ErrUnsupportedMetadataFormat)
+	// refused before any data is changed
+	v, err := base.Get(keysutil.EncodeDataKey([]byte("cc"), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("vv"), v)
+	states, err := base.GetSnapshotApplyingStates()
+	assert.NoError(t, err)
+	assert.Empty(t, states)
+}
+
+func TestSnapshotCheckpoint(t *testing.T) {
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	dir := "snapshot-dir-safe-to-delete"
+	shardID := uint64(100)
+	require.NoError(t, fs.RemoveAll(dir))
+	defer func() {
+		require.NoError(t, fs.RemoveAll(dir))
+	}()
+	newShardMetadata := func(index uint64) metapb.ShardMetadata {
+		return metapb.ShardMetadata{
+			ShardID:  shardID,
+			LogIndex: index,
+			Metadata: metapb.ShardLocalState{
+				Shard: metapb.Shard{ID: shardID, Start: []byte("aa"), End: []byte("xx")},
+			},
+		}
+	}
+
+	func() {
+		kv := mem.NewStorage()
+		base := NewBaseStorage(kv, fs)
+		ds := NewKVDataStorage(base, executor.NewKVExecutor(kv))
+		defer ds.Close()
+		assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("bb"), nil), []byte("v"), false))
+		assert.NoError(t, ds.SaveShardMetadata([]metapb.ShardMetadata{newShardMetadata(110)}))
+		cp, err := ds.(storage.SnapshotCheckpointer).CreateSnapshotCheckpoint(shardID)
+		require.NoError(t, err)
+		defer cp.Close()
+
+		// the changes after the checkpoint is taken are not included
+		assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("cc"), nil), []byte("v"), false))
+		assert.NoError(t, ds.SaveShardMetadata([]metapb.ShardMetadata{newShardMetadata(120)}))
+		assert.NoError(t, cp.Save(dir))
+	}()
+
+	func() {
+		kv := mem.NewStorage()
+		base := NewBaseStorage(kv, fs)
+		ds := NewKVDataStorage(base, executor.NewKVExecutor(kv))
+		defer ds.Close()
+		assert.NoError(t, base.ApplySnapshot(shardID, dir))
+		v, err := base.Get(keysutil.EncodeDataKey([]byte("bb"), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("v"), v)
+		v, err = base.Get(keysutil.EncodeDataKey([]byte("cc"), nil))
+		assert.NoError(t, err)
+		assert.Empty(t, v)
+		states, err := ds.GetInitialStates()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(states))
+		assert.Equal(t, uint64(110), states[0].LogIndex)
+	}()
+}
+
+func testColumnFamilyKey(cf int, key string) []byte {
+	return keysutil.EncodeColumnFamilyKey(cf, keysutil.EncodeDataKey([]byte(key), nil), nil)
+}
+
+func TestResumeInterruptedSnapshotApplying(t *testing.T) {
+	defer func(v int) {
+		snapshotApplyBatchSize = v
+	}(snapshotApplyBatchSize)
+	snapshotApplyBatchSize = 1
+
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	dir := "snapshot-dir-safe-to-delete"
+	shardID := uint64(100)
+	require.NoError(t, fs.RemoveAll(dir))
+	defer func() {
+		require.NoError(t, fs.RemoveAll(dir))
+	}()
+	newShardMetadata := func(index uint64) metapb.ShardMetadata {
+		return metapb.ShardMetadata{
+			ShardID:  shardID,
+			LogIndex: index,
+			Metadata: metapb.ShardLocalState{
+				Shard: metapb.Shard{ID: shardID, Start: []byte("aa"), End: []byte("xx")},
+			},
+		}
+	}
+
+	func() {
+		kv := mem.NewStorage()
+		base := NewBaseStorage(kv, fs)
+		ds := NewKVDataStorage(base, executor.NewKVExecutor(kv))
+		defer ds.Close()
+		assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("bb"), nil), []byte("v"), false))
+		assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("mmm"), nil), []byte("vv"), false))
+		assert.NoError(t, ds.SaveShardMetadata([]metapb.ShardMetadata{newShardMetadata(110)}))
+		assert.NoError(t, base.CreateSnapshot(shardID, dir))
+	}()
+
+	kv := mem.NewStorage()
+	base := NewBaseStorage(kv, fs)
+	ds := NewKVDataStorage(base, executor.NewKVExecutor(kv))
+	defer ds.Close()
+	assert.NoError(t, base.Set(keysutil.EncodeDataKey([]byte("cc"), nil), []byte("vv"), false))
+	assert.NoError(t, ds.SaveShardMetadata([]metapb.ShardMetadata{newShardMetadata(100)}))
+
+	// fully applied snapshot leaves no applying state
+	assert.NoError(t, base.ApplySnapshot(shardID, dir))
+	states, err := base.GetSnapshotApplyingStates()
+	assert.NoError(t, err)
+	assert.Empty(t, states)
+
+	// crashed after the shard data is partially replaced
+	assert.NoError(t, ds.SaveShardMetadata([]metapb.ShardMetadata{newShardMetadata(100)}))
+	assert.NoError(t, base.Set(keysutil.EncodeShardMetadataKey(keys.GetSnapshotApplyingKey(shardID, nil), nil),
+		protoc.MustMarshal(&metapb.SnapshotApplyingState{Index: 110, Dir: dir}), false))
+	assert.NoError(t, base.RangeDelete(keysutil.EncodeDataKey([]byte("mmm"), nil),
+		keysutil.EncodeDataKey([]byte("xx"), nil), false))
+	states, err = base.GetSnapshotApplyingStates()
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint64]metapb.SnapshotApplyingState{shardID: {Index: 110, Dir: dir}}, states)
+
+	initStates, err := ds.GetInitialStates()
+	assert.NoError(t, err)
+	require.Equal(t, 1, len(initStates))
+	assert.Equal(t, uint64(110), initStates[0].LogIndex)
+	index, err := ds.GetPersistentLogIndex(shardID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(110), index)
+	v, err := base.Get(keysutil.EncodeDataKey([]byte("mmm"), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("vv"), v)
+	states, err = base.GetSnapshotApplyingStates()
+	assert.NoError(t, err)
+	assert.Empty(t, states)
+}
+
 func TestScanInViewWithOptions(t *testing.T) {
 	fs := vfs.GetTestFS()
 	defer vfs.ReportLeakedFD(fs, t)
@@ -29,6 +29,7 @@ import (
 	"github.com/matrixorigin/matrixcube/storage/stats"
 	"github.com/matrixorigin/matrixcube/util"
 	"github.com/matrixorigin/matrixcube/util/buf"
+	"github.com/matrixorigin/matrixcube/util/fsync"
 	keysutil "github.com/matrixorigin/matrixcube/util/keys"
 	"go.uber.org/zap"
 )
@@ -41,9 +42,11 @@ var (
 type Option func(*options)
 
 type options struct {
-	sampleSync uint64
-	logger     *zap.Logger
-	feature    storage.Feature
+	sampleSync         uint64
+	logger             *zap.Logger
+	feature            storage.Feature
+	syncPool           *fsync.Pool
+	metadataSerializer storage.MetadataSerializer
 }
 
 // WithSampleSync set sync sample interval. `Cube` will call the `GetPersistentLogIndex` method of `DataStorage` to obtain
@@ -70,6 +73,22 @@ func WithFeature(feature storage.Feature) Option {
 	}
 }
 
+// WithSyncPool set the pool running the fsync of the storage, nil means the
+// fsync runs on the calling goroutine
+func WithSyncPool(pool *fsync.Pool) Option {
+	return func(opts *options) {
+		opts.syncPool = pool
+	}
+}
+
+// WithMetadataSerializer set the serializer of the persisted shard metadata,
+// default is storage.DefaultMetadataSerializer
+func WithMetadataSerializer(serializer storage.MetadataSerializer) Option {
+	return func(opts *options) {
+		opts.metadataSerializer = serializer
+	}
+}
+
 func newOptions() *options {
 	return &options{}
 }
@@ -99,6 +118,10 @@ func (opts *options) adjust() {
 		opts.feature.ForceCompactBytes = opts.feature.ShardCapacityBytes * 3 / 4
 	}
 
+	if opts.metadataSerializer == nil {
+		opts.metadataSerializer = storage.DefaultMetadataSerializer()
+	}
+
 	opts.logger = log.Adjust(opts.logger).Named("kv-data-storage")
 }
 
@@ -107,6 +130,9 @@ type kvDataStorage struct {
 	base       storage.KVBaseStorage
 	executor   storage.Executor
 	writeCount uint64
+	// persistentLogIndexListener is notified when the persistent applied
+	// indexes are advanced
+	persistentLogIndexListener func(indexes map[uint64]uint64)
 
 	mu struct {
 		sync.RWMutex
@@ -118,6 +144,12 @@ type kvDataStorage struct {
 
 var _ storage.DataStorage = (*kvDataStorage)(nil)
 var _ storage.KVStorageWrapper = (*kvDataStorage)(nil)
+var _ storage.SyncPoolUser = (*kvDataStorage)(nil)
+var _ storage.PersistentLogIndexNotifier = (*kvDataStorage)(nil)
+var _ storage.RangeDeleter = (*kvDataStorage)(nil)
+var _ storage.SnapshotCheckpointer = (*kvDataStorage)(nil)
+var _ storage.ResourceReleaser = (*kvDataStorage)(nil)
+var _ storage.DictionaryCompressor = (*kvDataStorage)(nil)
 
 // NewKVDataStorage returns data storage based on a kv base storage.
 func NewKVDataStorage(base storage.KVBaseStorage,
@@ -131,6 +163,15 @@ func NewKVDataStorage(base storage.KVBaseStorage,
 		opt(s.opts)
 	}
 	s.opts.adjust()
+	if n := len(s.opts.feature.ColumnFamilies); n >= keysutil.MaxColumnFamilies {
+		panic(fmt.Sprintf("too many column families %d", n))
+	}
+	if v, ok := executor.(storage.ColumnFamilyUser); ok {
+		v.SetColumnFamilies(s.opts.feature.ColumnFamilies)
+	}
+	if v, ok := base.(storage.MetadataSerializerUser); ok {
+		v.SetMetadataSerializer(s.opts.metadataSerializer)
+	}
 
 	s.mu.lastAppliedIndexes = make(map[uint64]uint64)
 	s.mu.persistentAppliedIndexes = make(map[uint64]uint64)
@@ -153,24 +194,57 @@ func (kv *kvDataStorage) Write(ctx storage.WriteContext) error {
 
 	// append data key
 	for idx := range batch.Requests {
-		batch.Requests[idx].Key = keysutil.EncodeDataKey(batch.Requests[idx].Key, ctx.(storage.InternalContext).ByteBuf())
+		batch.Requests[idx].Key = kv.encodeRequestKey(batch.Requests[idx], ctx.(storage.InternalContext).ByteBuf())
 	}
 	if err := kv.executor.UpdateWriteBatch(ctx); err != nil {
-		return err
+		return storage.NewNotWrittenError(err)
 	}
 	r := ctx.WriteBatch()
 	defer r.Reset()
 
+	// the write batch is applied atomically with the applied index, nothing is
+	// written if it fails
 	kv.setAppliedIndexToWriteBatch(ctx, batch.Index)
-	kv.updateAppliedIndex(ctx.Shard().ID, batch.Index)
 	if err := kv.executor.ApplyWriteBatch(r); err != nil {
-		return err
+		return storage.NewNotWrittenError(err)
 	}
+	kv.updateAppliedIndex(ctx.Shard().ID, batch.Index)
 	return kv.trySync()
 }
 
+// SetDictionaries implements storage.DictionaryCompressor, the dictionaries
+// are set to the executor compressing the values.
+func (kv *kvDataStorage) SetDictionaries(shardID uint64, dicts []metapb.CompressionDictionary) {
+	if v, ok := kv.executor.(storage.DictionaryCompressor); ok {
+		v.SetDictionaries(shardID, dicts)
+	}
+}
+
+func (kv *kvDataStorage) TrainDictionary(shardID uint64) ([]byte, bool) {
+	if v, ok := kv.executor.(storage.DictionaryCompressor); ok {
+		return v.TrainDictionary(shardID)
+	}
+	return nil, false
+}
+
+func (kv *kvDataStorage) RemoveDictionaries(shardID uint64) {
+	if v, ok := kv.executor.(storage.DictionaryCompressor); ok {
+		v.RemoveDictionaries(shardID)
+	}
+}
+
 func (kv *kvDataStorage) Read(ctx storage.ReadContext) ([]byte, error) {
-	return kv.executor.Read(readContext{base: ctx})
+	return kv.executor.Read(readContext{kv: kv, base: ctx})
+}
+
+// encodeRequestKey encodes the key of the request in the key space of the
+// request's column family
+func (kv *kvDataStorage) encodeRequestKey(req storage.Request, buffer *buf.ByteBuf) []byte {
+	cf, ok := kv.opts.feature.ColumnFamilyID(req.CF)
+	if !ok || cf == 0 {
+		return keysutil.EncodeDataKey(req.Key, buffer)
+	}
+	return keysutil.EncodeColumnFamilyKey(cf, keysutil.EncodeDataKey(req.Key, nil), buffer)
 }
 
 func (kv *kvDataStorage) SaveShardMetadata(metadatas []metapb.ShardMetadata) error {
@@ -185,7 +259,7 @@ func (kv *kvDataStorage) SaveShardMetadata(metadatas []metapb.ShardMetadata) err
 			panic(fmt.Errorf("BUG: shard ID mismatch, %+v", m))
 		}
 		key := keysutil.EncodeShardMetadataKey(keys.GetMetadataKey(m.ShardID, m.LogIndex, nil), nil)
-		wb.Set(key, protoc.MustMarshal(&m))
+		wb.Set(key, kv.opts.metadataSerializer.Marshal(m))
 
 		logIndex := metapb.LogIndex{Index: m.LogIndex}
 		key = keysutil.EncodeShardMetadataKey(keys.GetAppliedIndexKey(m.ShardID, nil), nil)
@@ -207,6 +281,10 @@ func (kv *kvDataStorage) SaveShardMetadata(metadatas []metapb.ShardMetadata) err
 }
 
 func (kv *kvDataStorage) GetInitialStates() ([]metapb.ShardMetadata, error) {
+	if err := kv.resumeSnapshotApplying(); err != nil {
+		return nil, err
+	}
+
 	// TODO: this assumes that all shards have applied index records saved.
 	// double check to make sure this is actually true.
 	min := keysutil.EncodeShardMetadataKey(keys.GetAppliedIndexKey(0, nil), nil)
@@ -265,8 +343,10 @@ func (kv *kvDataStorage) GetInitialStates() ([]metapb.ShardMetadata, error) {
 			panic("failed to get shard metadata")
 		}
 
-		sm := metapb.ShardMetadata{}
-		protoc.MustUnmarshal(&sm, v)
+		sm, err := kv.opts.metadataSerializer.Unmarshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the metadata of shard %d: %w", shard, err)
+		}
 		if sm.LogIndex != logIndex {
 			panic(fmt.Sprintf("LogIndex not match, expect %d, but %d", logIndex, sm.LogIndex))
 		}
@@ -276,6 +356,27 @@ func (kv *kvDataStorage) GetInitialStates() ([]metapb.ShardMetadata, error) {
 	return values, nil
 }
 
+// resumeSnapshotApplying applies the snapshots interrupted by a crash again,
+// the shard data is partially replaced and inconsistent with the shard
+// metadata before that.
+func (kv *kvDataStorage) resumeSnapshotApplying() error {
+	states, err := kv.base.GetSnapshotApplyingStates()
+	if err != nil {
+		return err
+	}
+	for shardID, state := range states {
+		kv.opts.logger.Warn("found an interrupted snapshot applying, apply it again",
+			log.ShardIDField(shardID),
+			log.IndexField(state.Index),
+			zap.String("dir", state.Dir))
+		if err := kv.base.ApplySnapshot(shardID, state.Dir); err != nil {
+			return fmt.Errorf("failed to apply the interrupted snapshot %s of shard %d: %w",
+				state.Dir, shardID, err)
+		}
+	}
+	return nil
+}
+
 // TODO: handle shardID not found error, maybe define ShardNotFound?
 
 func (kv *kvDataStorage) GetPersistentLogIndex(shardID uint64) (uint64, error) {
@@ -287,8 +388,16 @@ func (kv *kvDataStorage) GetPersistentLogIndex(shardID uint64) (uint64, error) {
 	return kv.mu.persistentAppliedIndexes[shardID], nil
 }
 
+func (kv *kvDataStorage) SetSyncPool(pool *fsync.Pool) {
+	kv.opts.syncPool = pool
+}
+
+func (kv *kvDataStorage) SetPersistentLogIndexListener(listener func(indexes map[uint64]uint64)) {
+	kv.persistentLogIndexListener = listener
+}
+
 func (kv *kvDataStorage) Sync(_ []uint64) error {
-	if err := kv.base.Sync(); err != nil {
+	if err := kv.opts.syncPool.Do(kv.base.Sync); err != nil {
 		return err
 	}
 
@@ -300,14 +409,15 @@ func (kv *kvDataStorage) RemoveShard(shard metapb.Shard, removeData bool) error
 	// This is not an atomic operation, but it is idempotent, and the metadata is
 	// deleted afterwards, so the cleanup will not be lost.
 	if removeData {
-		min := keysutil.EncodeShardStart(shard.Start, nil)
-		max := keysutil.EncodeShardEnd(shard.End, nil)
-		kv.opts.logger.Debug("remove shard data",
-			log.ShardField("shard", shard),
-			log.HexField("from", min),
-			log.HexField("to", max))
-		if err := kv.base.RangeDelete(min, max, false); err != nil {
-			return err
+		for cf := 0; cf <= len(kv.opts.feature.ColumnFamilies); cf++ {
+			min, max := keysutil.EncodeColumnFamilyShardRange(cf, shard.Start, shard.End)
+			kv.opts.logger.Debug("remove shard data",
+				log.ShardField("shard", shard),
+				log.HexField("from", min),
+				log.HexField("to", max))
+			if err := kv.base.RangeDelete(min, max, false); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -320,9 +430,42 @@ func (kv *kvDataStorage) RemoveShard(shard metapb.Shard, removeData bool) error
 	return kv.base.RangeDelete(min, max, false)
 }
 
+// DeleteRange deletes the data in [start, end) of all the column families, the
+// range deletion is written to the same pebble instance as the metadata, so it
+// is persisted no later than the metadata saved afterwards.
+func (kv *kvDataStorage) DeleteRange(shard metapb.Shard, start, end []byte) error {
+	for cf := 0; cf <= len(kv.opts.feature.ColumnFamilies); cf++ {
+		min, max := keysutil.EncodeColumnFamilyShardRange(cf, start, end)
+		kv.opts.logger.Debug("delete shard range",
+			log.ShardField("shard", shard),
+			log.HexField("from", min),
+			log.HexField("to", max))
+		if err := kv.base.RangeDelete(min, max, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReleaseViews implements storage.ResourceReleaser
+func (kv *kvDataStorage) ReleaseViews(before time.Time) int {
+	if r, ok := kv.base.(storage.ResourceReleaser); ok {
+		return r.ReleaseViews(before)
+	}
+	return 0
+}
+
+// CompactAll implements storage.ResourceReleaser
+func (kv *kvDataStorage) CompactAll() error {
+	if r, ok := kv.base.(storage.ResourceReleaser); ok {
+		return r.CompactAll()
+	}
+	return nil
+}
+
 // SplitCheck find keys from [start, end), so that the sum of bytes of the
 // value of [start, key) <=size, returns the current bytes in [start,end),
-// and the founded keys.
+// and the founded keys. Only the default column family is checked.
 func (kv *kvDataStorage) SplitCheck(shard metapb.Shard,
 	size uint64) (uint64, uint64, [][]byte, []byte, error) {
 	total := uint64(0)
@@ -398,7 +541,7 @@ func (kv *kvDataStorage) trySync() error {
 	if n%kv.opts.sampleSync != 0 {
 		return nil
 	}
-	if err := kv.base.Sync(); err != nil {
+	if err := kv.opts.syncPool.Do(kv.base.Sync); err != nil {
 		return err
 	}
 
@@ -415,9 +558,14 @@ func (kv *kvDataStorage) CreateSnapshot(shardID uint64, path string) error {
 	return kv.base.CreateSnapshot(shardID, path)
 }
 
+// CreateSnapshotCheckpoint implements storage.SnapshotCheckpointer
+func (kv *kvDataStorage) CreateSnapshotCheckpoint(shardID uint64) (storage.SnapshotCheckpoint, error) {
+	return kv.base.CreateSnapshotCheckpoint(shardID)
+}
+
 func (kv *kvDataStorage) ApplySnapshot(shardID uint64, path string) error {
-	// FIXME: kv.base.ApplySnapshot is not atomic
-	// kvDataStorage.ApplySnapshot suffers from the same issue
+	// kv.base.ApplySnapshot is not atomic, the snapshot interrupted by a crash
+	// is applied again by resumeSnapshotApplying on restart
 	if err := kv.base.ApplySnapshot(shardID, path); err != nil {
 		return err
 	}
@@ -440,14 +588,27 @@ func (kv *kvDataStorage) Stats() stats.Stats {
 }
 
 func (kv *kvDataStorage) updatePersistentAppliedIndexes() {
+	var advanced map[uint64]uint64
 	kv.mu.Lock()
 	for k, v := range kv.mu.lastAppliedIndexes {
+		if kv.persistentLogIndexListener != nil &&
+			kv.mu.persistentAppliedIndexes[k] < v {
+			if advanced == nil {
+				advanced = make(map[uint64]uint64)
+			}
+			advanced[k] = v
+		}
 		kv.mu.persistentAppliedIndexes[k] = v
 	}
 	kv.mu.Unlock()
+
+	if len(advanced) > 0 {
+		kv.persistentLogIndexListener(advanced)
+	}
 }
 
 type readContext struct {
+	kv   *kvDataStorage
 	base storage.ReadContext
 }
 
@@ -456,6 +617,6 @@ func (c readContext) Shard() metapb.Shard   { return c.base.Shard() }
 func (c readContext) SetReadBytes(v uint64) { c.base.SetReadBytes(v) }
 func (c readContext) Request() storage.Request {
 	req := c.base.Request()
-	req.Key = keysutil.EncodeDataKey(req.Key, c.base.(storage.InternalContext).ByteBuf())
+	req.Key = c.kv.encodeRequestKey(req, c.base.(storage.InternalContext).ByteBuf())
 	return req
 }
@@ -0,0 +1,55 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"encoding/binary"
+	"hash/crc64"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/storage"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+)
+
+var (
+	_ storage.Checksummer = (*kvDataStorage)(nil)
+
+	checksumTable = crc64.MakeTable(crc64.ECMA)
+)
+
+// Checksum implements storage.Checksummer, the lengths of the keys and the
+// values are hashed too, so the boundaries of the key-value pairs are covered.
+func (kv *kvDataStorage) Checksum(shard metapb.Shard) (uint64, error) {
+	view := kv.base.GetView()
+	defer view.Close()
+
+	h := crc64.New(checksumTable)
+	var size [binary.MaxVarintLen64]byte
+	write := func(v []byte) {
+		n := binary.PutUvarint(size[:], uint64(len(v)))
+		h.Write(size[:n])
+		h.Write(v)
+	}
+	for cf := 0; cf <= len(kv.opts.feature.ColumnFamilies); cf++ {
+		min, max := keysutil.EncodeColumnFamilyShardRange(cf, shard.Start, shard.End)
+		if err := kv.base.ScanInView(view, min, max, func(key, value []byte) (bool, error) {
+			write(key)
+			write(value)
+			return true, nil
+		}, false); err != nil {
+			return 0, err
+		}
+	}
+	return h.Sum64(), nil
+}
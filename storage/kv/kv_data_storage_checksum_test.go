@@ -0,0 +1,69 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/executor"
+	"github.com/matrixorigin/matrixcube/storage/kv/mem"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/vfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksum(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+
+	shard := metapb.Shard{ID: 1, Start: []byte("b"), End: []byte("e")}
+	checksum := func(pairs ...string) uint64 {
+		base := NewBaseStorage(mem.NewStorage(), fs)
+		ds := NewKVDataStorage(base, executor.NewKVExecutor(base),
+			WithFeature(storage.Feature{ColumnFamilies: []string{"lock"}}))
+		defer ds.Close()
+
+		var requests []storage.Request
+		for i := 0; i < len(pairs); i += 3 {
+			req := executor.NewWriteRequest([]byte(pairs[i+1]), []byte(pairs[i+2]))
+			req.CF = pairs[i]
+			requests = append(requests, req)
+		}
+		if len(requests) > 0 {
+			require.NoError(t, ds.Write(storage.NewSimpleWriteContext(shard.ID, base, storage.Batch{
+				Index:    1,
+				Requests: requests,
+			})))
+		}
+		v, err := ds.(storage.Checksummer).Checksum(shard)
+		require.NoError(t, err)
+		return v
+	}
+
+	cf := storage.DefaultColumnFamily
+	v := checksum(cf, "b", "1", cf, "c", "2", "lock", "c", "3")
+	assert.Equal(t, v, checksum(cf, "b", "1", cf, "c", "2", "lock", "c", "3"))
+	// the keys out of the shard range are not covered
+	assert.Equal(t, v, checksum(cf, "a", "0", cf, "b", "1", cf, "c", "2", "lock", "c", "3", cf, "e", "4"))
+	assert.NotEqual(t, v, checksum(cf, "b", "1", cf, "c", "4", "lock", "c", "3"))
+	assert.NotEqual(t, v, checksum(cf, "b", "1", cf, "c", "2"))
+	assert.NotEqual(t, v, checksum(cf, "b", "1", cf, "c", "2", "lock", "c", "3", "lock", "d", ""))
+	// the boundaries of the key-value pairs are covered
+	assert.NotEqual(t, checksum(cf, "b", "12"), checksum(cf, "b1", "2"))
+	assert.NotEqual(t, checksum(), checksum(cf, "b", ""))
+}
@@ -0,0 +1,195 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/storage"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+)
+
+var _ storage.Exporter = (*kvDataStorage)(nil)
+
+// ExportShard implements storage.Exporter, the data is read from a point in
+// time view of the base storage.
+func (kv *kvDataStorage) ExportShard(shard metapb.Shard, w io.Writer,
+	opts storage.ExportOptions) (uint64, error) {
+	view := kv.base.GetView()
+	defer view.Close()
+
+	switch opts.Format {
+	case storage.ExportSST:
+		return kv.exportSST(view, shard, w)
+	case storage.ExportCSV, storage.ExportJSONL:
+		cf, ok := kv.opts.feature.ColumnFamilyID(opts.CF)
+		if !ok {
+			return 0, storage.ErrColumnFamilyNotFound
+		}
+		schema := opts.Schema
+		if schema == nil {
+			schema = base64Schema{}
+		}
+		var ew exportWriter
+		if opts.Format == storage.ExportCSV {
+			ew = newCSVExportWriter(w)
+		} else {
+			ew = newJSONLExportWriter(w)
+		}
+		return kv.exportPortable(view, shard, cf, schema, ew)
+	}
+	return 0, fmt.Errorf("unknown export format %d", opts.Format)
+}
+
+// exportSST writes the encoded keys of all the column families, the key spaces
+// of the column families are in ascending order.
+func (kv *kvDataStorage) exportSST(view storage.View, shard metapb.Shard,
+	w io.Writer) (uint64, error) {
+	sw := sstable.NewWriter(nopSyncCloser{w}, sstable.WriterOptions{})
+	count := uint64(0)
+	for cf := 0; cf <= len(kv.opts.feature.ColumnFamilies); cf++ {
+		min, max := keysutil.EncodeColumnFamilyShardRange(cf, shard.Start, shard.End)
+		if err := kv.base.ScanInView(view, min, max, func(key, value []byte) (bool, error) {
+			count++
+			return true, sw.Set(key, value)
+		}, false); err != nil {
+			sw.Close()
+			return 0, err
+		}
+	}
+	if err := sw.Close(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (kv *kvDataStorage) exportPortable(view storage.View, shard metapb.Shard,
+	cf int, schema storage.ExportSchema, ew exportWriter) (uint64, error) {
+	columns := schema.Columns()
+	if err := ew.begin(columns); err != nil {
+		return 0, err
+	}
+
+	count := uint64(0)
+	min, max := keysutil.EncodeColumnFamilyShardRange(cf, shard.Start, shard.End)
+	if err := kv.base.ScanInView(view, min, max, func(key, value []byte) (bool, error) {
+		values, err := schema.Decode(keysutil.DecodeDataKey(key), value)
+		if err != nil {
+			return false, err
+		}
+		if values == nil {
+			return true, nil
+		}
+		if len(values) != len(columns) {
+			return false, fmt.Errorf("%d values decoded, %d columns expected",
+				len(values), len(columns))
+		}
+		count++
+		return true, ew.write(columns, values)
+	}, false); err != nil {
+		return 0, err
+	}
+	return count, ew.flush()
+}
+
+// base64Schema exports the keys and the values as the base64 strings
+type base64Schema struct{}
+
+func (base64Schema) Columns() []string {
+	return []string{"key", "value"}
+}
+
+func (base64Schema) Decode(key, value []byte) ([]string, error) {
+	return []string{
+		base64.StdEncoding.EncodeToString(key),
+		base64.StdEncoding.EncodeToString(value),
+	}, nil
+}
+
+type exportWriter interface {
+	begin(columns []string) error
+	write(columns, values []string) error
+	flush() error
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func newCSVExportWriter(w io.Writer) exportWriter {
+	return &csvExportWriter{w: csv.NewWriter(w)}
+}
+
+func (cw *csvExportWriter) begin(columns []string) error {
+	return cw.w.Write(columns)
+}
+
+func (cw *csvExportWriter) write(columns, values []string) error {
+	return cw.w.Write(values)
+}
+
+func (cw *csvExportWriter) flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// jsonlExportWriter writes the columns in the order of the schema, which is
+// not kept by the map encoding
+type jsonlExportWriter struct {
+	w *bufio.Writer
+}
+
+func newJSONLExportWriter(w io.Writer) exportWriter {
+	return &jsonlExportWriter{w: bufio.NewWriter(w)}
+}
+
+func (jw *jsonlExportWriter) begin(columns []string) error {
+	return nil
+}
+
+func (jw *jsonlExportWriter) write(columns, values []string) error {
+	jw.w.WriteByte('{')
+	for i := range columns {
+		if i > 0 {
+			jw.w.WriteByte(',')
+		}
+		name, _ := json.Marshal(columns[i])
+		value, _ := json.Marshal(values[i])
+		jw.w.Write(name)
+		jw.w.WriteByte(':')
+		jw.w.Write(value)
+	}
+	jw.w.WriteByte('}')
+	return jw.w.WriteByte('\n')
+}
+
+func (jw *jsonlExportWriter) flush() error {
+	return jw.w.Flush()
+}
+
+// nopSyncCloser adapts the writer to the sstable writer, the writer is closed
+// by the caller
+type nopSyncCloser struct {
+	io.Writer
+}
+
+func (nopSyncCloser) Sync() error  { return nil }
+func (nopSyncCloser) Close() error { return nil }
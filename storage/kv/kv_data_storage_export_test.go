@@ -0,0 +1,135 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kv
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/storage/executor"
+	"github.com/matrixorigin/matrixcube/storage/kv/mem"
+	keysutil "github.com/matrixorigin/matrixcube/util/keys"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/vfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testExportSchema struct{}
+
+func (testExportSchema) Columns() []string {
+	return []string{"id", "name"}
+}
+
+func (testExportSchema) Decode(key, value []byte) ([]string, error) {
+	if string(value) == "skip" {
+		return nil, nil
+	}
+	return []string{string(key), string(value)}, nil
+}
+
+func TestExportShard(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	kv := mem.NewStorage()
+	base := NewBaseStorage(kv, fs)
+	ds := NewKVDataStorage(base, executor.NewKVExecutor(base),
+		WithFeature(storage.Feature{ColumnFamilies: []string{"lock"}}))
+	defer ds.Close()
+
+	shard := metapb.Shard{ID: 1, Start: []byte("b"), End: []byte("e")}
+	var requests []storage.Request
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		for _, cf := range []string{storage.DefaultColumnFamily, "lock"} {
+			value := key
+			if key == "d" {
+				value = "skip"
+			}
+			req := executor.NewWriteRequest([]byte(key), []byte(value))
+			req.CF = cf
+			requests = append(requests, req)
+		}
+	}
+	require.NoError(t, ds.Write(storage.NewSimpleWriteContext(shard.ID, base, storage.Batch{
+		Index:    1,
+		Requests: requests,
+	})))
+
+	exporter := ds.(storage.Exporter)
+	export := func(opts storage.ExportOptions) (string, uint64) {
+		var buf bytes.Buffer
+		n, err := exporter.ExportShard(shard, &buf, opts)
+		require.NoError(t, err)
+		return buf.String(), n
+	}
+
+	data, n := export(storage.ExportOptions{Format: storage.ExportSST})
+	assert.Equal(t, uint64(6), n)
+	file := filepath.Join(t.TempDir(), "export.sst")
+	require.NoError(t, os.WriteFile(file, []byte(data), 0644))
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	r, err := sstable.NewReader(f, sstable.ReaderOptions{})
+	require.NoError(t, err)
+	iter, err := r.NewIter(nil, nil)
+	require.NoError(t, err)
+	var keys [][]byte
+	for k, v := iter.First(); k != nil; k, v = iter.Next() {
+		keys = append(keys, append([]byte(nil), k.UserKey...))
+		if string(k.UserKey) == string(testExportKey(1, "c")) {
+			assert.Equal(t, "c", string(v))
+		}
+	}
+	require.NoError(t, iter.Close())
+	require.NoError(t, r.Close())
+	assert.Equal(t, [][]byte{
+		testExportKey(0, "b"),
+		testExportKey(0, "c"),
+		testExportKey(0, "d"),
+		testExportKey(1, "b"),
+		testExportKey(1, "c"),
+		testExportKey(1, "d"),
+	}, keys)
+
+	data, n = export(storage.ExportOptions{Format: storage.ExportCSV})
+	assert.Equal(t, uint64(3), n)
+	assert.Equal(t, "key,value\nYg==,Yg==\nYw==,Yw==\nZA==,c2tpcA==\n", data)
+
+	data, n = export(storage.ExportOptions{Format: storage.ExportCSV,
+		Schema: testExportSchema{}, CF: "lock"})
+	assert.Equal(t, uint64(2), n)
+	assert.Equal(t, "id,name\nb,b\nc,c\n", data)
+
+	data, n = export(storage.ExportOptions{Format: storage.ExportJSONL,
+		Schema: testExportSchema{}})
+	assert.Equal(t, uint64(2), n)
+	assert.Equal(t, `{"id":"b","name":"b"}`+"\n"+`{"id":"c","name":"c"}`+"\n", data)
+
+	_, err = exporter.ExportShard(shard, &bytes.Buffer{},
+		storage.ExportOptions{Format: storage.ExportCSV, CF: "unknown"})
+	assert.Equal(t, storage.ErrColumnFamilyNotFound, err)
+}
+
+func testExportKey(cf int, key string) []byte {
+	if cf == 0 {
+		return keysutil.EncodeDataKey([]byte(key), nil)
+	}
+	return testColumnFamilyKey(cf, key)
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	cpebble "github.com/cockroachdb/pebble"
 	"github.com/fagongzi/util/format"
@@ -24,10 +25,13 @@ import (
 	pvfs "github.com/lni/vfs"
 	"github.com/matrixorigin/matrixcube/keys"
 	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/pb/rpcpb"
 	"github.com/matrixorigin/matrixcube/storage"
 	"github.com/matrixorigin/matrixcube/storage/executor"
+	"github.com/matrixorigin/matrixcube/storage/kv/mem"
 	"github.com/matrixorigin/matrixcube/storage/kv/pebble"
 	"github.com/matrixorigin/matrixcube/util/buf"
+	"github.com/matrixorigin/matrixcube/util/fsync"
 	keysutil "github.com/matrixorigin/matrixcube/util/keys"
 	"github.com/matrixorigin/matrixcube/util/leaktest"
 	"github.com/matrixorigin/matrixcube/vfs"
@@ -172,6 +176,46 @@ func TestGetInitialStatesLoadsPersistentLogIndexValues(t *testing.T) {
 	}
 }
 
+func TestGetInitialStatesWithMetadataFormats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	kv := mem.NewStorage()
+	base := NewBaseStorage(kv, fs)
+	inputs := newTestShardMetadata(3)
+
+	legacy := NewKVDataStorage(base, nil,
+		WithMetadataSerializer(storage.NewMetadataSerializer(storage.MetadataFormatLegacy, nil)))
+	defer legacy.Close()
+	assert.NoError(t, legacy.SaveShardMetadata(inputs[:1]))
+
+	// the legacy metadata is migrated by all the migrations to the format 2
+	var migrated []uint32
+	migrate := func(format uint32) storage.MetadataMigration {
+		return func(md *metapb.ShardMetadata) error {
+			migrated = append(migrated, format)
+			md.Metadata.Shard.Unique = fmt.Sprintf("%s-%d", md.Metadata.Shard.Unique, format)
+			return nil
+		}
+	}
+	v2 := NewKVDataStorage(base, nil,
+		WithMetadataSerializer(storage.NewMetadataSerializer(2, map[uint32]storage.MetadataMigration{
+			storage.MetadataFormatLegacy: migrate(storage.MetadataFormatLegacy),
+			storage.MetadataFormatV1:     migrate(storage.MetadataFormatV1),
+		})))
+	values, err := v2.GetInitialStates()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(values))
+	assert.Equal(t, []uint32{0, 1}, migrated)
+	assert.Equal(t, "-0-1", values[0].Metadata.Shard.Unique)
+
+	// the store supporting the older format refuses the newer one
+	assert.NoError(t, v2.SaveShardMetadata(inputs[1:]))
+	v1 := NewKVDataStorage(base, nil)
+	_, err = v1.GetInitialStates()
+	assert.ErrorIs(t, err, storage.ErrUnsupportedMetadataFormat)
+}
+
 func TestGetPersistentLogIndexWillPanicWhenPersistentIndexesAreNotLoaded(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	fs := vfs.GetTestFS()
@@ -257,6 +301,48 @@ func TestGetPersistentLogIndex(t *testing.T) {
 	}
 }
 
+func TestSyncRunsOnSyncPool(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	kv := getTestPebbleStorage(t, fs)
+	base := NewBaseStorage(kv, fs)
+	s := NewKVDataStorage(base, nil)
+	defer func() {
+		require.NoError(t, fs.RemoveAll(testDir))
+	}()
+	defer s.Close()
+
+	pool := fsync.NewPool(fsync.Data, 1, 1)
+	defer pool.Close()
+	s.(storage.SyncPoolUser).SetSyncPool(pool)
+
+	// the only worker of the pool is busy
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		assert.NoError(t, pool.Do(func() error {
+			close(started)
+			<-release
+			return nil
+		}))
+	}()
+	<-started
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Sync(nil)
+	}()
+	select {
+	case <-done:
+		assert.Fail(t, "sync must wait for the sync pool")
+	case <-time.After(time.Millisecond * 50):
+	}
+	close(release)
+	assert.NoError(t, <-done)
+	assert.Equal(t, uint64(1), kv.Stats().SyncCount)
+}
+
 func TestKVDataStorageRestartWithNotSyncedDataLost(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	for _, sample := range []uint64{10, 11} {
@@ -523,3 +609,94 @@ func newTestShardMetadata(n uint64) []metapb.ShardMetadata {
 	}
 	return values
 }
+
+func TestColumnFamilies(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	kv := mem.NewStorage()
+	base := NewBaseStorage(kv, fs)
+	ds := NewKVDataStorage(base, executor.NewKVExecutor(base),
+		WithFeature(storage.Feature{ColumnFamilies: []string{"lock"}}))
+	defer ds.Close()
+
+	shard := metapb.Shard{ID: 1, Start: []byte("a"), End: []byte("z")}
+	write := func(index uint64, cf, key, value string) {
+		req := executor.NewWriteRequest([]byte(key), []byte(value))
+		req.CF = cf
+		ctx := storage.NewSimpleWriteContext(shard.ID, base, storage.Batch{
+			Index:    index,
+			Requests: []storage.Request{req},
+		})
+		require.NoError(t, ds.Write(ctx))
+	}
+	read := func(cf, key string) string {
+		req := executor.NewReadRequest([]byte(key))
+		req.CF = cf
+		v, err := ds.Read(storage.NewSimpleReadContext(shard.ID, req))
+		require.NoError(t, err)
+		var resp rpcpb.KVGetResponse
+		protoc.MustUnmarshal(&resp, v)
+		return string(resp.Value)
+	}
+
+	write(1, storage.DefaultColumnFamily, "k1", "data")
+	write(2, "lock", "k1", "lock")
+	assert.Equal(t, "data", read(storage.DefaultColumnFamily, "k1"))
+	assert.Equal(t, "lock", read("lock", "k1"))
+	v, err := base.Get(keysutil.EncodeColumnFamilyKey(1, keysutil.EncodeDataKey([]byte("k1"), nil), nil))
+	require.NoError(t, err)
+	assert.Equal(t, "lock", string(v))
+
+	require.NoError(t, ds.RemoveShard(shard, true))
+	assert.Equal(t, "", read(storage.DefaultColumnFamily, "k1"))
+	assert.Equal(t, "", read("lock", "k1"))
+
+	assert.Panics(t, func() {
+		NewKVDataStorage(base, nil,
+			WithFeature(storage.Feature{ColumnFamilies: make([]string, keysutil.MaxColumnFamilies)}))
+	})
+}
+
+func TestDeleteRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	kv := mem.NewStorage()
+	base := NewBaseStorage(kv, fs)
+	ds := NewKVDataStorage(base, executor.NewKVExecutor(base),
+		WithFeature(storage.Feature{ColumnFamilies: []string{"lock"}}))
+	defer ds.Close()
+
+	shard := metapb.Shard{ID: 1, Start: []byte("a"), End: []byte("z")}
+	var requests []storage.Request
+	for _, key := range []string{"b", "c", "d"} {
+		for _, cf := range []string{storage.DefaultColumnFamily, "lock"} {
+			req := executor.NewWriteRequest([]byte(key), []byte(key))
+			req.CF = cf
+			requests = append(requests, req)
+		}
+	}
+	require.NoError(t, ds.Write(storage.NewSimpleWriteContext(shard.ID, base, storage.Batch{
+		Index:    1,
+		Requests: requests,
+	})))
+	read := func(cf, key string) string {
+		req := executor.NewReadRequest([]byte(key))
+		req.CF = cf
+		v, err := ds.Read(storage.NewSimpleReadContext(shard.ID, req))
+		require.NoError(t, err)
+		var resp rpcpb.KVGetResponse
+		protoc.MustUnmarshal(&resp, v)
+		return string(resp.Value)
+	}
+
+	require.NoError(t, ds.(storage.RangeDeleter).DeleteRange(shard, []byte("b"), []byte("d")))
+	for _, cf := range []string{storage.DefaultColumnFamily, "lock"} {
+		assert.Equal(t, "", read(cf, "b"))
+		assert.Equal(t, "", read(cf, "c"))
+		assert.Equal(t, "d", read(cf, "d"))
+	}
+	// idempotent
+	require.NoError(t, ds.(storage.RangeDeleter).DeleteRange(shard, []byte("b"), []byte("d")))
+}
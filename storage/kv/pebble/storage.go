@@ -15,7 +15,10 @@ package pebble
 
 import (
 	"bytes"
+	"sort"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/matrixorigin/matrixcube/components/log"
@@ -28,22 +31,81 @@ import (
 	"go.uber.org/zap"
 )
 
+// view is a pebble snapshot tracked by the storage, so it can be force released
+// under the disk pressure. The scans hold the read lock, the views are only
+// released when they are not in use.
 type view struct {
-	ss *pebble.Snapshot
+	sync.RWMutex
+	s       *Storage
+	ss      *pebble.Snapshot
+	created time.Time
+	// raw the snapshot is exposed to the holder by Raw, such views are never
+	// force released as their use can not be tracked
+	raw      bool
+	released bool
+	closed   bool
 }
 
 func (v *view) Close() error {
+	v.Lock()
+	defer v.Unlock()
+	if v.closed {
+		return nil
+	}
+	v.closed = true
+	v.s.removeView(v)
+	if v.released {
+		return nil
+	}
 	return v.ss.Close()
 }
 
 func (v *view) Raw() interface{} {
+	v.Lock()
+	defer v.Unlock()
+	v.raw = true
 	return v.ss
 }
 
+// acquire returns the snapshot of the view, the view is not released until the
+// returned func is called
+func (v *view) acquire() (*pebble.Snapshot, func(), error) {
+	v.RLock()
+	if v.released {
+		v.RUnlock()
+		return nil, nil, storage.ErrViewReleased
+	}
+	return v.ss, v.RUnlock, nil
+}
+
+func acquireView(v storage.View) (*pebble.Snapshot, func(), error) {
+	return v.(*view).acquire()
+}
+
+// tryRelease releases the snapshot if the view is not in use
+func (v *view) tryRelease() bool {
+	if !v.TryLock() {
+		return false
+	}
+	defer v.Unlock()
+	if v.raw || v.released || v.closed {
+		return false
+	}
+	v.released = true
+	if err := v.ss.Close(); err != nil {
+		return false
+	}
+	return true
+}
+
 // Storage returns a kv storage based on badger
 type Storage struct {
 	db    *pebble.DB
 	stats stats.Stats
+	views struct {
+		sync.Mutex
+		m map[*view]struct{}
+	}
 }
 
 var _ storage.KVStorage = (*Storage)(nil)
@@ -78,13 +140,65 @@ func NewStorage(dir string, logger *zap.Logger, opts *pebble.Options) (*Storage,
 		return nil, err
 	}
 
-	return &Storage{
+	s := &Storage{
 		db: db,
-	}, nil
+	}
+	s.views.m = make(map[*view]struct{})
+	return s, nil
 }
 
 func (s *Storage) GetView() storage.View {
-	return &view{ss: s.db.NewSnapshot()}
+	v := &view{s: s, ss: s.db.NewSnapshot(), created: time.Now()}
+	s.views.Lock()
+	s.views.m[v] = struct{}{}
+	s.views.Unlock()
+	return v
+}
+
+func (s *Storage) removeView(v *view) {
+	s.views.Lock()
+	delete(s.views.m, v)
+	s.views.Unlock()
+}
+
+// ReleaseViews implements storage.ResourceReleaser, the oldest views are
+// released first
+func (s *Storage) ReleaseViews(before time.Time) int {
+	s.views.Lock()
+	var views []*view
+	for v := range s.views.m {
+		if v.created.Before(before) {
+			views = append(views, v)
+		}
+	}
+	s.views.Unlock()
+
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].created.Before(views[j].created)
+	})
+	released := 0
+	for _, v := range views {
+		if v.tryRelease() {
+			released++
+		}
+	}
+	return released
+}
+
+// CompactAll implements storage.ResourceReleaser
+func (s *Storage) CompactAll() error {
+	iter := s.db.NewIter(&pebble.IterOptions{})
+	defer iter.Close()
+	if !iter.First() {
+		return iter.Error()
+	}
+	first := keysutil.Clone(iter.Key())
+	if !iter.Last() {
+		return iter.Error()
+	}
+	last := keysutil.Clone(iter.Key())
+	// the end key of pebble Compact is exclusive
+	return s.db.Compact(first, append(last, 0), true)
 }
 
 // Close close the storage
@@ -240,7 +354,11 @@ func (s *Storage) ScanInView(view storage.View,
 	if len(end) > 0 {
 		ios.UpperBound = end
 	}
-	ss := view.Raw().(*pebble.Snapshot)
+	ss, done, err := acquireView(view)
+	if err != nil {
+		return err
+	}
+	defer done()
 	iter := ss.NewIter(ios)
 
 	defer iter.Close()
@@ -279,7 +397,11 @@ func (s *Storage) ScanInViewWithOptions(view storage.View, start, end []byte, ha
 	if len(end) > 0 {
 		ios.UpperBound = end
 	}
-	ss := view.Raw().(*pebble.Snapshot)
+	ss, done, err := acquireView(view)
+	if err != nil {
+		return err
+	}
+	defer done()
 	iter := ss.NewIter(ios)
 	defer iter.Close()
 
@@ -319,7 +441,11 @@ func (s *Storage) ReverseScanInViewWithOptions(view storage.View, start, end []b
 	if len(end) > 0 {
 		ios.UpperBound = end
 	}
-	ss := view.Raw().(*pebble.Snapshot)
+	ss, done, err := acquireView(view)
+	if err != nil {
+		return err
+	}
+	defer done()
 	iter := ss.NewIter(ios)
 	defer iter.Close()
 
@@ -451,12 +577,16 @@ func (s *Storage) Sync() error {
 }
 
 func (s *Storage) Stats() stats.Stats {
+	total := s.db.Metrics().Total()
 	return stats.Stats{
 		WrittenKeys:  atomic.LoadUint64(&s.stats.WrittenKeys),
 		WrittenBytes: atomic.LoadUint64(&s.stats.WrittenBytes),
 		ReadKeys:     atomic.LoadUint64(&s.stats.ReadKeys),
 		ReadBytes:    atomic.LoadUint64(&s.stats.ReadBytes),
 		SyncCount:    atomic.LoadUint64(&s.stats.SyncCount),
+
+		CompactionReadBytes:    total.BytesRead,
+		CompactionWrittenBytes: total.BytesCompacted + total.BytesFlushed,
 	}
 }
 
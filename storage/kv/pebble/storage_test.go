@@ -0,0 +1,82 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble
+
+import (
+	"testing"
+	"time"
+
+	cpebble "github.com/cockroachdb/pebble"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/storage"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+func TestReleaseViews(t *testing.T) {
+	// the in memory fs leaves nothing on the disk
+	fs := vfs.NewMemFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	s, err := NewStorage("test-data", nil, &cpebble.Options{FS: vfs.NewPebbleFS(fs)})
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Set([]byte("a"), []byte("1"), false))
+	scan := func(v storage.View) error {
+		return s.ScanInView(v, nil, nil, func(key, value []byte) (bool, error) {
+			return true, nil
+		}, false)
+	}
+
+	old := s.GetView()
+	raw := s.GetView()
+	raw.Raw()
+	now := time.Now()
+	time.Sleep(time.Millisecond)
+	recent := s.GetView()
+
+	// the views in use are not released
+	done := make(chan struct{})
+	inUse := make(chan struct{})
+	go func() {
+		_ = s.ScanInView(old, nil, nil, func(key, value []byte) (bool, error) {
+			close(inUse)
+			<-done
+			return true, nil
+		}, false)
+	}()
+	<-inUse
+	assert.Equal(t, 0, s.ReleaseViews(now))
+	close(done)
+
+	for i := 0; ; i++ {
+		if n := s.ReleaseViews(now); n > 0 {
+			assert.Equal(t, 1, n)
+			break
+		}
+		require.True(t, i < 100)
+		time.Sleep(time.Millisecond * 10)
+	}
+	assert.Equal(t, storage.ErrViewReleased, scan(old))
+	assert.NoError(t, scan(raw))
+	assert.NoError(t, scan(recent))
+	assert.NoError(t, old.Close())
+	assert.NoError(t, raw.Close())
+	assert.NoError(t, recent.Close())
+	assert.Equal(t, 0, len(s.views.m))
+
+	require.NoError(t, s.Delete([]byte("a"), false))
+	assert.NoError(t, s.CompactAll())
+}
@@ -0,0 +1,158 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/fagongzi/util/protoc"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+// The shard metadata is persisted in a versioned format, so the fields added by
+// the future formats are migrated explicitly on read, and the store reading a
+// format newer than it supports refuses to start instead of misparsing it.
+//
+// The versioned format is the header byte, the format as an uvarint and the
+// protobuf encoded ShardMetadata. The header byte is never the first byte of a
+// protobuf encoded ShardMetadata as its wire type is invalid, so the metadata
+// persisted without the header is read as MetadataFormatLegacy, and the old
+// stores fail to parse the versioned format instead of misparsing it.
+
+const (
+	metadataFormatHeader byte = 0xff
+
+	// MetadataFormatLegacy the protobuf encoded ShardMetadata without the header,
+	// it's persisted before the versioned format is introduced. It's written by
+	// the serializer of the legacy format, so the stores can be rolled back to
+	// the versions without the versioned format.
+	MetadataFormatLegacy uint32 = 0
+	// MetadataFormatV1 the first versioned format
+	MetadataFormatV1 uint32 = 1
+	// CurrentMetadataFormat the format written by the default serializer
+	CurrentMetadataFormat = MetadataFormatV1
+)
+
+var (
+	// ErrUnsupportedMetadataFormat is returned when the persisted shard metadata
+	// is written in a format newer than the serializer supports, the store must
+	// be upgraded to read it.
+	ErrUnsupportedMetadataFormat = errors.New("unsupported shard metadata format")
+)
+
+// MetadataMigration migrates the shard metadata read from a format to the next
+// format, e.g. it sets the default value of the field added by the next format.
+type MetadataMigration func(md *metapb.ShardMetadata) error
+
+// MetadataSerializer serializes the ShardMetadata persisted by the DataStorage.
+type MetadataSerializer interface {
+	// Format returns the format written by Marshal, it's the newest format
+	// supported by Unmarshal.
+	Format() uint32
+	// Marshal encodes the shard metadata in the format.
+	Marshal(md metapb.ShardMetadata) []byte
+	// Unmarshal decodes the shard metadata written in the format or the older
+	// formats, the metadata of the older formats are migrated to the format.
+	// ErrUnsupportedMetadataFormat is returned if the data is written in a newer
+	// format.
+	Unmarshal(data []byte) (metapb.ShardMetadata, error)
+}
+
+// MetadataSerializerUser is implemented by the storage which persists the shard
+// metadata with the given serializer. The DataStorage sets its serializer to
+// the base storage which creates and applies the snapshots.
+type MetadataSerializerUser interface {
+	SetMetadataSerializer(s MetadataSerializer)
+}
+
+type versionedMetadataSerializer struct {
+	format     uint32
+	migrations map[uint32]MetadataMigration
+}
+
+// NewMetadataSerializer returns the serializer writing the shard metadata in the
+// given format. The migrations are keyed by the format they migrate from, the
+// metadata read from an older format is migrated by all the migrations from its
+// format to the given format in order.
+func NewMetadataSerializer(format uint32,
+	migrations map[uint32]MetadataMigration) MetadataSerializer {
+	return &versionedMetadataSerializer{
+		format:     format,
+		migrations: migrations,
+	}
+}
+
+// DefaultMetadataSerializer returns the serializer of the CurrentMetadataFormat
+func DefaultMetadataSerializer() MetadataSerializer {
+	return NewMetadataSerializer(CurrentMetadataFormat, nil)
+}
+
+func (s *versionedMetadataSerializer) Format() uint32 {
+	return s.format
+}
+
+func (s *versionedMetadataSerializer) Marshal(md metapb.ShardMetadata) []byte {
+	if s.format == MetadataFormatLegacy {
+		return protoc.MustMarshal(&md)
+	}
+
+	data := make([]byte, 1+binary.MaxVarintLen32+md.Size())
+	data[0] = metadataFormatHeader
+	n := 1 + binary.PutUvarint(data[1:], uint64(s.format))
+	size, err := md.MarshalTo(data[n:])
+	if err != nil {
+		panic(err)
+	}
+	return data[:n+size]
+}
+
+func (s *versionedMetadataSerializer) Unmarshal(data []byte) (metapb.ShardMetadata, error) {
+	format, payload, err := GetMetadataFormat(data)
+	if err != nil {
+		return metapb.ShardMetadata{}, err
+	}
+	if format > s.format {
+		return metapb.ShardMetadata{}, fmt.Errorf("%w: format %d, supported %d",
+			ErrUnsupportedMetadataFormat, format, s.format)
+	}
+
+	var md metapb.ShardMetadata
+	if err := md.Unmarshal(payload); err != nil {
+		return metapb.ShardMetadata{}, err
+	}
+	for ; format < s.format; format++ {
+		if migrate, ok := s.migrations[format]; ok {
+			if err := migrate(&md); err != nil {
+				return metapb.ShardMetadata{}, err
+			}
+		}
+	}
+	return md, nil
+}
+
+// GetMetadataFormat returns the format and the payload of the persisted shard
+// metadata.
+func GetMetadataFormat(data []byte) (uint32, []byte, error) {
+	if len(data) == 0 || data[0] != metadataFormatHeader {
+		return MetadataFormatLegacy, data, nil
+	}
+
+	format, n := binary.Uvarint(data[1:])
+	if n <= 0 || format > uint64(^uint32(0)) {
+		return 0, nil, fmt.Errorf("%w: invalid format header", ErrUnsupportedMetadataFormat)
+	}
+	return uint32(format), data[1+n:], nil
+}
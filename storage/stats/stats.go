@@ -25,6 +25,11 @@ type Stats struct {
 	ReadBytes    uint64
 	// SyncCount number of `Sync` method called
 	SyncCount uint64
+	// CompactionReadBytes bytes read by the background flushes and compactions
+	CompactionReadBytes uint64
+	// CompactionWrittenBytes bytes written by the background flushes and
+	// compactions
+	CompactionWrittenBytes uint64
 }
 
 // Copy returns another instance for rough statistics.
@@ -36,5 +41,8 @@ func (s *Stats) Copy() Stats {
 		ReadKeys:     atomic.LoadUint64(&s.ReadKeys),
 		ReadBytes:    atomic.LoadUint64(&s.ReadBytes),
 		SyncCount:    atomic.LoadUint64(&s.SyncCount),
+
+		CompactionReadBytes:    atomic.LoadUint64(&s.CompactionReadBytes),
+		CompactionWrittenBytes: atomic.LoadUint64(&s.CompactionWrittenBytes),
 	}
 }
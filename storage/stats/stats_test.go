@@ -27,6 +27,9 @@ func TestCopy(t *testing.T) {
 		ReadKeys:     3,
 		ReadBytes:    4,
 		SyncCount:    5,
+
+		CompactionReadBytes:    6,
+		CompactionWrittenBytes: 7,
 	}
 	actual := stats.Copy()
 
@@ -36,4 +39,6 @@ func TestCopy(t *testing.T) {
 	assert.Equal(t, stats.ReadKeys, actual.ReadKeys)
 	assert.Equal(t, stats.ReadBytes, actual.ReadBytes)
 	assert.Equal(t, stats.SyncCount, actual.SyncCount)
+	assert.Equal(t, stats.CompactionReadBytes, actual.CompactionReadBytes)
+	assert.Equal(t, stats.CompactionWrittenBytes, actual.CompactionWrittenBytes)
 }
@@ -15,6 +15,7 @@ package storage
 
 import (
 	"errors"
+	"io"
 	"time"
 
 	"github.com/matrixorigin/matrixcube/pb/hlcpb"
@@ -22,6 +23,7 @@ import (
 	"github.com/matrixorigin/matrixcube/pb/txnpb"
 	"github.com/matrixorigin/matrixcube/storage/stats"
 	"github.com/matrixorigin/matrixcube/util/buf"
+	"github.com/matrixorigin/matrixcube/util/fsync"
 )
 
 var (
@@ -32,8 +34,43 @@ var (
 	// ErrShardNotFound is returned by the data storage to indicate that the
 	// requested shard is not found.
 	ErrShardNotFound = errors.New("shard not found")
+	// ErrColumnFamilyNotFound is returned when the column family of the request
+	// is not defined by the data storage.
+	ErrColumnFamilyNotFound = errors.New("column family not found")
 )
 
+// NotWrittenError is returned by DataStorage.Write if none of the requests of
+// the WriteContext is written, e.g. the atomic write batch is rejected by the
+// underlying storage. Only such failed writes can be retried, executing a part
+// of the requests twice is not idempotent, e.g. GetDel and DeleteIf.
+type NotWrittenError struct {
+	Err error
+}
+
+// NewNotWrittenError returns a NotWrittenError wrapping the error
+func NewNotWrittenError(err error) error {
+	return NotWrittenError{Err: err}
+}
+
+// Error implements error interface
+func (err NotWrittenError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (err NotWrittenError) Unwrap() error {
+	return err.Err
+}
+
+// IsNotWrittenError returns true if the write failed with nothing written
+func IsNotWrittenError(err error) bool {
+	var e NotWrittenError
+	return errors.As(err, &e)
+}
+
+// DefaultColumnFamily the column family of the requests without a column family
+const DefaultColumnFamily = ""
+
 // Closeable is an instance that can be closed.
 type Closeable interface {
 	// Close closes the instance.
@@ -170,6 +207,11 @@ type Feature struct {
 	// value that changes after each Write call. Whenever this value exceeds the size set by the
 	// current field, a real check is made to see if a split is needed, involving real IO operations.
 	ShardSplitCheckBytes uint64
+	// ShardSplitQPS the Shard is split at the traffic midpoint if the reads and
+	// the writes per second served by its leader exceed the value during a
+	// ShardSplitCheckDuration, regardless of its size. The split ctx passed to
+	// Split is nil for the load based split. 0 disables the load based split.
+	ShardSplitQPS uint64
 	// DisableShardSplit disable shard split
 	DisableShardSplit bool
 	// ForceCompactCount force compaction when the number of Raft logs reaches the specified number
@@ -182,6 +224,248 @@ type Feature struct {
 	// SupportTransaction whether to support Transaction, if support transaction, the current DataStorage
 	// need to implement TransactionalDataStorage, used to handle transaction-related consensus commands.
 	SupportTransaction bool
+	// ColumnFamilies the names of the column families besides the default one.
+	// Each column family is a separate key space of the shards, so the data, the
+	// index and the lock records of a shard can be iterated and compacted
+	// independently. The id of a column family is its position in the list, so
+	// the order must not be changed once the data is written. At most
+	// keys.MaxColumnFamilies-1 column families are allowed.
+	ColumnFamilies []string
+	// DictionaryTrainDuration the leaders of the shards train the compression
+	// dictionaries from the sampled values every DictionaryTrainDuration, only
+	// for the DataStorage implementing DictionaryCompressor. 0 disables the
+	// training.
+	DictionaryTrainDuration time.Duration
+}
+
+// ColumnFamilyID returns the id of the column family in the column families of
+// the feature, the default column family has id 0.
+func (f Feature) ColumnFamilyID(cf string) (int, bool) {
+	if cf == DefaultColumnFamily {
+		return 0, true
+	}
+	for i, name := range f.ColumnFamilies {
+		if name == cf {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// SplitKeyCodec defines the valid split points of the keys in a shard group,
+// e.g. a multi-part encoded key must not be split in the middle, otherwise the
+// parts of a logical key are managed by two shards.
+type SplitKeyCodec interface {
+	// SplitPoint returns the start of the logical key which the key belongs to,
+	// the returned value must be <= key. A key is a valid split point only if
+	// SplitPoint returns the key itself.
+	SplitPoint(key []byte) []byte
+}
+
+// SplitCheckPolicy chooses the split keys of the shards in a shard group, so
+// the shards are split at the boundaries of the application's own encoding,
+// e.g. never in the middle of the row group of a table.
+type SplitCheckPolicy interface {
+	// SplitKeys returns the keys to split the shard at. The candidates are the
+	// keys picked by the size based split check of the DataStorage, or the
+	// traffic midpoint picked by the load based split. The size and keys are the
+	// approximate bytes and number of keys of the shard, both are 0 for the load
+	// based split. The returned keys must be inside the shard and in ascending
+	// order, the shard is not split if no key is returned.
+	SplitKeys(shard metapb.Shard, size, keys uint64, candidates [][]byte) ([][]byte, error)
+}
+
+// KeyRangeChangeType the type of the key range ownership change
+type KeyRangeChangeType int
+
+const (
+	// KeyRangeSplit the key range of the old shard is split into the new shards
+	KeyRangeSplit KeyRangeChangeType = iota
+	// KeyRangeRemoved the key range of the old shard is no longer owned by the
+	// replica in the current store
+	KeyRangeRemoved
+)
+
+// KeyRangeChange the change of the key range owned by a shard
+type KeyRangeChange struct {
+	Type KeyRangeChangeType
+	// Old the shard before the change
+	Old metapb.Shard
+	// News the shards owning the key range of the old shard after the change,
+	// empty if the key range is removed
+	News []metapb.Shard
+	// RemoveData whether the data of the removed key range is removed
+	RemoveData bool
+}
+
+// KeyRangeObserver is an optional interface of the DataStorage, which is
+// notified when the key range owned by a shard is changed, so the storage
+// engine can adjust its internal partitioning or indexes. The split is notified
+// after the `Split` call succeeds, the removal is notified after the
+// `RemoveShard` call succeeds, and the old shard of a split is removed once all
+// its replicas have applied the split. The same change may be notified again
+// when the raft logs are replayed after restart.
+type KeyRangeObserver interface {
+	// KeyRangeChanged is called in the apply or the destroy goroutine of the
+	// shard, it must not block.
+	KeyRangeChanged(KeyRangeChange)
+}
+
+// LifecycleHooks is notified of the lifecycle of the DataStorage of a shard
+// group in a store, so the embedders can attach the resources tied to the
+// lifetime of the storage engine, e.g. caches or index builders. The hooks of
+// the groups are called in the ascending order of the group ID, and in the
+// reverse order on close.
+type LifecycleHooks interface {
+	// BeforeOpen is called when the store starts, before the storage is used by
+	// the store and before any replica of the group is started. The store fails
+	// to start if an error is returned.
+	BeforeOpen(group uint64, ds DataStorage) error
+	// AfterOpen is called after the replicas of the group in the store are
+	// started, and before the store serves any request. The store fails to
+	// start if an error is returned.
+	AfterOpen(group uint64, ds DataStorage) error
+	// BeforeClose is called when the store stops, after all the replicas of the
+	// group are stopped, and before the storage is closed by the embedder.
+	BeforeClose(group uint64, ds DataStorage) error
+}
+
+// SyncPoolUser is implemented by the DataStorage which runs its fsync work on
+// the given pool, so the data storage flushes don't delay the raft log
+// appends syncing on the other pool. The store sets the pool before the
+// storage is used by the store.
+type ColumnFamilyUser interface {
+	// SetColumnFamilies sets the column families besides the default one, the
+	// id of a column family is its position in the names plus 1.
+	SetColumnFamilies(names []string)
+}
+
+type SyncPoolUser interface {
+	SetSyncPool(pool *fsync.Pool)
+}
+
+// PersistentLogIndexNotifier is implemented by the DataStorage which pushes the
+// advances of the persistent log indexes, so the replicas don't need to query
+// GetPersistentLogIndex on every log compaction. The store sets the listener
+// before the storage is used by the store.
+type PersistentLogIndexNotifier interface {
+	// SetPersistentLogIndexListener sets the listener called with the shards
+	// whose persistent log indexes are advanced by a flush, all the shards of a
+	// flush are notified in one call. The listener must not block, and the
+	// indexes of the concurrent flushes may be notified out of order.
+	SetPersistentLogIndexListener(listener func(indexes map[uint64]uint64))
+}
+
+// RangeDeleter is implemented by the DataStorage which deletes the data of a
+// key range of a shard, it's required by the shard TTL GC. DeleteRange is
+// called in the apply goroutine of the shard, the deletion must be persisted no
+// later than the shard metadata saved afterwards, and it must be idempotent as
+// it may be applied again after restart.
+type RangeDeleter interface {
+	// DeleteRange deletes the data in [start, end) of the shard, including the
+	// data of all the column families
+	DeleteRange(shard metapb.Shard, start, end []byte) error
+}
+
+// DictionaryCompressor is implemented by the DataStorage which compresses the
+// values with the dictionaries of the shards. The dictionaries are replicated
+// by the raft log and saved in the shard metadata, so the same values are
+// compressed with the same dictionary on all the replicas, and a replica
+// rebuilt from a snapshot gets the dictionaries with the shard metadata. The
+// store sets the dictionaries of a shard before the writes of the shard are
+// applied, and the leader of the shard proposes the trained dictionaries every
+// Feature.DictionaryTrainDuration.
+type DictionaryCompressor interface {
+	// SetDictionaries sets the dictionaries of the shard, the oldest first. The
+	// last one compresses the values written after, the old ones decompress the
+	// values written before. It's called in the apply goroutine of the shard.
+	SetDictionaries(shardID uint64, dicts []metapb.CompressionDictionary)
+	// TrainDictionary trains a dictionary from the values of the shard sampled
+	// since the last training, false if not enough values are sampled. The
+	// dictionary is not used until it's replicated and set by SetDictionaries.
+	TrainDictionary(shardID uint64) ([]byte, bool)
+	// RemoveDictionaries removes the dictionaries of the shard removed from the
+	// store.
+	RemoveDictionaries(shardID uint64)
+}
+
+// SnapshotCheckpoint is a point-in-time view of the data and the metadata of a
+// shard, the changes made to the shard after the checkpoint is taken are not
+// visible to it.
+type SnapshotCheckpoint interface {
+	// Save saves the snapshot of the checkpoint into the directory specified by
+	// the path parameter, the saved snapshot is applied by ApplySnapshot. It's
+	// called in a different goroutine from the one taking the checkpoint.
+	Save(path string) error
+	// Close releases the resources held by the checkpoint.
+	Close() error
+}
+
+// SnapshotCheckpointer is implemented by the DataStorage which takes a cheap
+// checkpoint of a shard. The checkpoint is taken in the goroutine applying the
+// raft logs of the shard, and the snapshot is saved from the checkpoint in the
+// background, so a lagging replica can be rebuilt from a snapshot without
+// blocking the apply loop of the shard.
+type SnapshotCheckpointer interface {
+	// CreateSnapshotCheckpoint takes a checkpoint of the specified shard, the
+	// returned checkpoint must be closed by the caller.
+	CreateSnapshotCheckpoint(shardID uint64) (SnapshotCheckpoint, error)
+}
+
+// ExportFormat the format of the exported shard data
+type ExportFormat int
+
+const (
+	// ExportSST exports the engine-native SST file with the encoded keys of all
+	// the column families, which can be ingested into the engine directly
+	ExportSST ExportFormat = iota
+	// ExportCSV exports a CSV file, the first record is the column names
+	ExportCSV
+	// ExportJSONL exports a JSON object per line, keyed by the column names
+	ExportJSONL
+)
+
+// ExportSchema decodes the key-value pairs encoded by the embedder into the
+// columns of the portable export formats
+type ExportSchema interface {
+	// Columns returns the column names
+	Columns() []string
+	// Decode decodes the key-value pair into the column values, the pair is
+	// skipped if nil values are returned
+	Decode(key, value []byte) ([]string, error)
+}
+
+// ExportOptions the options of the shard export
+type ExportOptions struct {
+	// Format the format of the exported data
+	Format ExportFormat
+	// Schema decodes the key-value pairs of the CSV and JSONL formats. The keys
+	// and the values are exported as the base64 strings of the "key" and the
+	// "value" columns if it's nil.
+	Schema ExportSchema
+	// CF the column family exported in the CSV and JSONL formats, empty for the
+	// default column family
+	CF string
+}
+
+// Exporter is implemented by the DataStorage which exports the data of a shard
+// from a point in time view, e.g. to re-ingest the data into another cluster or
+// to import it into an analytics system.
+type Exporter interface {
+	// ExportShard writes the data of the shard to the writer in the format of
+	// the options, returns the number of the exported key-value pairs
+	ExportShard(shard metapb.Shard, w io.Writer, opts ExportOptions) (uint64, error)
+}
+
+// Checksummer is implemented by the DataStorage which computes the checksum of
+// the data of a shard, it's used to find the replicas whose data diverges
+// silently. Checksum is called in the apply goroutine of the shard, so the
+// checksum covers all the data applied before the call, and the same data must
+// always have the same checksum on all the stores.
+type Checksummer interface {
+	// Checksum returns the checksum of the data of the shard, including the
+	// data of all the column families
+	Checksum(shard metapb.Shard) (uint64, error)
 }
 
 // TransactionalDataStorage is a `DataStorage` that supports transaction operations.  Where all write data
@@ -300,14 +584,29 @@ type Batch struct {
 	Index uint64
 	// Requests is the requests included in the batch.
 	Requests []Request
+	// CommitTime is the wall-clock time in nanoseconds stamped by the leader
+	// when the batch was proposed, only for write batches. It's the same on all
+	// replicas, so it can be used as the timestamp of the changes. It never goes
+	// backwards in the order of the batch index: the leader stamps the last
+	// commit time plus 1ns if its clock is behind. It's not the time the batch
+	// is committed, and it may be ahead of the wall clock of the replicas by the
+	// clock offset between the stores. Zero if the batch was proposed by a
+	// version without the stamp.
+	CommitTime int64
 }
 
 // Request is the custom request type.
 type Request struct {
+	// ID is the unique id of the request, a request resubmitted by the client
+	// keeps the same ID, so it can be used as an idempotency token.
+	ID []byte
 	// CmdType is the request type.
 	CmdType uint64
 	// Key is the key of the request.
 	Key []byte
+	// CF is the column family of the request, empty for the default column
+	// family.
+	CF string
 	// Cmd is the content of the request.
 	Cmd []byte
 }
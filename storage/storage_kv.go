@@ -14,9 +14,17 @@
 package storage
 
 import (
+	"errors"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
 	"github.com/matrixorigin/matrixcube/util"
 )
 
+// ErrViewReleased is returned when a View force released under the disk
+// pressure is used again. The holder should close the view and retry later.
+var ErrViewReleased = errors.New("view released")
+
 // View is a point in time view of the KVStore.
 type View interface {
 	Close() error
@@ -98,6 +106,19 @@ type KVStorage interface {
 	KVStore
 }
 
+// ResourceReleaser is implemented by the KVStorage and the DataStorage which
+// are able to give back the disk space on demand. It's used by the store to
+// avoid the disk full when the disk usage is over the threshold.
+type ResourceReleaser interface {
+	// ReleaseViews force releases the views created before the time, which
+	// pin the deleted and the overwritten data on the disk. The views in use
+	// are skipped. Returns the number of the released views.
+	ReleaseViews(before time.Time) int
+	// CompactAll compacts the whole key space urgently to reclaim the disk
+	// space of the deleted and the overwritten data.
+	CompactAll() error
+}
+
 // KVMetadataStore is a KV based data store for storing MatrixCube metadata.
 type KVMetadataStore interface {
 	// not allowed to close the store
@@ -109,4 +130,10 @@ type KVMetadataStore interface {
 type KVBaseStorage interface {
 	BaseStorage
 	KVStore
+	SnapshotCheckpointer
+	// GetSnapshotApplyingStates returns the snapshots not fully applied because
+	// of a crash, keyed by the shard id. The data of such shards is partially
+	// replaced by the snapshot, the snapshot must be applied again before the
+	// shards are used.
+	GetSnapshotApplyingStates() (map[uint64]metapb.SnapshotApplyingState, error)
 }
@@ -68,6 +68,14 @@ type tracked struct {
 	next  uint64
 }
 
+// received is the snapshot received recently, it's kept until the gc to reply
+// the resume queries of the sender
+type received struct {
+	from  uint64
+	count uint64
+	tick  uint64
+}
+
 type ssLock struct {
 	mu sync.Mutex
 }
@@ -92,8 +100,9 @@ type Chunk struct {
 
 	mu struct {
 		sync.Mutex
-		tracked map[string]*tracked
-		locks   map[string]*ssLock
+		tracked  map[string]*tracked
+		received map[string]received
+		locks    map[string]*ssLock
 	}
 }
 
@@ -110,6 +119,7 @@ func NewChunk(logger *zap.Logger,
 		fs:        fs,
 	}
 	c.mu.tracked = make(map[string]*tracked)
+	c.mu.received = make(map[string]received)
 	c.mu.locks = make(map[string]*ssLock)
 
 	return c
@@ -124,6 +134,27 @@ func (c *Chunk) Add(chunk metapb.SnapshotChunk) bool {
 	return c.addLocked(chunk)
 }
 
+// Progress returns the next chunk ID expected of the snapshot, 0 if the
+// snapshot is not being received, and the chunk count if the snapshot is
+// received.
+func (c *Chunk) Progress(query metapb.SnapshotResume) uint64 {
+	key := chunkKey(metapb.SnapshotChunk{ShardID: query.ShardID,
+		ReplicaID: query.ReplicaID, Index: query.Index})
+	lock := c.getSnapshotLock(key)
+	lock.lock()
+	defer lock.unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if td, ok := c.mu.tracked[key]; ok && td.first.From == query.From {
+		return td.next
+	}
+	if r, ok := c.mu.received[key]; ok && r.from == query.From {
+		return r.count
+	}
+	return 0
+}
+
 // Tick moves the internal logical clock forward.
 func (c *Chunk) Tick() {
 	ct := atomic.AddUint64(&c.tick, 1)
@@ -147,8 +178,15 @@ func (c *Chunk) Close() {
 }
 
 func (c *Chunk) gc() {
-	tracked := c.getTracked()
 	tick := c.getTick()
+	c.mu.Lock()
+	for key, r := range c.mu.received {
+		if tick-r.tick >= c.timeout {
+			delete(c.mu.received, key)
+		}
+	}
+	c.mu.Unlock()
+	tracked := c.getTracked()
 	for key, td := range tracked {
 		func() {
 			l := c.getSnapshotLock(key)
@@ -256,8 +294,40 @@ func (c *Chunk) record(chunk metapb.SnapshotChunk) *tracked {
 	return td
 }
 
+// isDuplicated returns true if the chunk is saved before, the chunks are sent
+// again when the interrupted send is resumed.
+func (c *Chunk) isDuplicated(chunk metapb.SnapshotChunk) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	td, ok := c.mu.tracked[chunkKey(chunk)]
+	return ok && chunk.ChunkID != 0 &&
+		chunk.ChunkID < td.next && chunk.From == td.first.From
+}
+
+func (c *Chunk) markReceived(key string, td *tracked) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.received[key] = received{
+		from:  td.first.From,
+		count: td.first.ChunkCount,
+		tick:  c.getTick(),
+	}
+}
+
 func (c *Chunk) addLocked(chunk metapb.SnapshotChunk) bool {
 	key := chunkKey(chunk)
+	if !verifyChunk(chunk) {
+		c.logger.Error("snapshot chunk checksum mismatch",
+			zap.String("key", key),
+			zap.Uint64("chunk", chunk.ChunkID))
+		return false
+	}
+	if c.isDuplicated(chunk) {
+		c.logger.Debug("duplicated snapshot chunk ignored",
+			zap.String("key", key),
+			zap.Uint64("chunk", chunk.ChunkID))
+		return true
+	}
 	td := c.record(chunk)
 	if td == nil {
 		c.logger.Warn("ignored a snapshot chunk",
@@ -294,6 +364,7 @@ func (c *Chunk) addLocked(chunk metapb.SnapshotChunk) bool {
 			}
 			return false
 		}
+		c.markReceived(key, td)
 		snapshotMessage := c.toMessage(td.first)
 		c.logger.Info("received a snapshot",
 			zap.String("key", key))
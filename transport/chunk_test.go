@@ -465,3 +465,67 @@ func TestToMessageFromChunk(t *testing.T) {
 	assert.Equal(t, chunk.From, msg.Message.From)
 	assert.Equal(t, chunk.ReplicaID, msg.Message.To)
 }
+
+func TestChunkWithMismatchedChecksumIsRejected(t *testing.T) {
+	fn := func(t *testing.T, chunks *Chunk, handler *testMessageHandler) {
+		inputs := getTestChunks()
+		for idx := range inputs {
+			inputs[idx].Checksum = chunkChecksum(inputs[idx].Data)
+		}
+		require.True(t, chunks.addLocked(inputs[0]))
+		corrupted := inputs[1]
+		corrupted.Data = append([]byte{}, corrupted.Data...)
+		corrupted.Data[0]++
+		assert.False(t, chunks.addLocked(corrupted))
+		assert.Equal(t, uint64(1), chunks.mu.tracked[chunkKey(inputs[0])].next)
+		for _, c := range inputs[1:] {
+			require.True(t, chunks.addLocked(c))
+		}
+		assert.Equal(t, uint64(1), handler.getSnapshotCount(100, 2))
+	}
+	fs := vfs.GetTestFS()
+	runChunkTest(t, fn, fs)
+}
+
+func TestDuplicatedChunkIsIgnored(t *testing.T) {
+	fn := func(t *testing.T, chunks *Chunk, handler *testMessageHandler) {
+		inputs := getTestChunks()
+		for _, c := range inputs[:5] {
+			require.True(t, chunks.addLocked(c))
+		}
+		// the chunks sent again by the resumed send
+		for _, c := range inputs[2:] {
+			require.True(t, chunks.addLocked(c))
+		}
+		assert.Equal(t, uint64(1), handler.getSnapshotCount(100, 2))
+		checkTestSnapshotFile(t, chunks, inputs[0], 10240)
+	}
+	fs := vfs.GetTestFS()
+	runChunkTest(t, fn, fs)
+}
+
+func TestChunkProgress(t *testing.T) {
+	fn := func(t *testing.T, chunks *Chunk, handler *testMessageHandler) {
+		inputs := getTestChunks()
+		query := newSnapshotResume(inputs[0])
+		assert.Equal(t, uint64(0), chunks.Progress(query))
+		for _, c := range inputs[:3] {
+			require.True(t, chunks.addLocked(c))
+		}
+		assert.Equal(t, uint64(3), chunks.Progress(query))
+		other := query
+		other.From++
+		assert.Equal(t, uint64(0), chunks.Progress(other))
+
+		for _, c := range inputs[3:] {
+			require.True(t, chunks.addLocked(c))
+		}
+		assert.Equal(t, uint64(10), chunks.Progress(query))
+		for i := uint64(0); i < chunks.timeout+chunks.gcTick; i++ {
+			chunks.Tick()
+		}
+		assert.Equal(t, uint64(0), chunks.Progress(query))
+	}
+	fs := vfs.GetTestFS()
+	runChunkTest(t, fn, fs)
+}
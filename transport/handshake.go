@@ -0,0 +1,283 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"runtime/debug"
+	"sync"
+	"syscall"
+
+	"github.com/cockroachdb/errors"
+	"github.com/fagongzi/util/protoc"
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+const (
+	// ProtocolVersion is the version of the transport protocol, the connections
+	// between the stores with different protocol versions are rejected.
+	ProtocolVersion uint32 = 1
+	// CapabilityHandshake the store exchanges the handshake, the stores without
+	// it are running the versions before the handshake was introduced.
+	CapabilityHandshake = "handshake"
+
+	modulePath = "github.com/matrixorigin/matrixcube"
+)
+
+var (
+	// Capabilities is the capabilities announced to the peers in the handshake.
+	// A feature which requires the support of the receivers should add its
+	// capability here, and check the receiver by Transport.HasCapability before
+	// it's used.
+	Capabilities = []string{CapabilityHandshake}
+
+	// ErrHandshakeRejected is the error returned when the handshake of the
+	// connection is rejected by either side, e.g. the cluster ID mismatch.
+	ErrHandshakeRejected = errors.New("transport handshake rejected")
+	// errHandshakeUnsupported the peer closes the connection on the handshake,
+	// it's running a version without the handshake.
+	errHandshakeUnsupported = errors.New("transport handshake unsupported")
+)
+
+// BuildVersion returns the version of the matrixcube module in the binary,
+// "unknown" if the build info is unavailable.
+func BuildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
+
+// handshaker exchanges the handshake on the connections, and keeps the
+// handshake info of the peers.
+type handshaker struct {
+	logger *zap.Logger
+	local  metapb.TransportHandshake
+	// targetStore returns the store ID of the address, 0 if unknown
+	targetStore func(addr string) uint64
+
+	mu struct {
+		sync.RWMutex
+		peers map[uint64]metapb.TransportHandshake
+	}
+}
+
+func newHandshaker(logger *zap.Logger, clusterID, storeID uint64,
+	version string, targetStore func(addr string) uint64) *handshaker {
+	h := &handshaker{
+		logger: logger,
+		local: metapb.TransportHandshake{
+			ClusterID:       clusterID,
+			StoreID:         storeID,
+			ProtocolVersion: ProtocolVersion,
+			Version:         version,
+			Capabilities:    Capabilities,
+		},
+		targetStore: targetStore,
+	}
+	h.mu.peers = make(map[uint64]metapb.TransportHandshake)
+	return h
+}
+
+// check returns the reason why the remote is rejected, empty if the remote is
+// accepted.
+func (h *handshaker) check(remote metapb.TransportHandshake, expectedStoreID uint64) string {
+	if remote.ProtocolVersion != h.local.ProtocolVersion {
+		return fmt.Sprintf("protocol version %d mismatch, store %d uses %d",
+			remote.ProtocolVersion, h.local.StoreID, h.local.ProtocolVersion)
+	}
+	if remote.ClusterID != h.local.ClusterID {
+		return fmt.Sprintf("cluster ID %d mismatch, store %d belongs to cluster %d",
+			remote.ClusterID, h.local.StoreID, h.local.ClusterID)
+	}
+	if expectedStoreID != 0 && remote.StoreID != expectedStoreID {
+		return fmt.Sprintf("store ID %d mismatch, expect store %d",
+			remote.StoreID, expectedStoreID)
+	}
+	return ""
+}
+
+// dial sends the handshake on the new connection to the address, and checks
+// the reply of the acceptor.
+func (h *handshaker) dial(conn net.Conn, addr string, encrypted bool) error {
+	req := h.local
+	req.TargetStoreID = h.targetStore(addr)
+	header := make([]byte, requestHeaderSize)
+	if err := writeMessage(conn, requestHeader{method: handshakeType},
+		protoc.MustMarshal(&req), header, encrypted); err != nil {
+		return err
+	}
+
+	magicNum := make([]byte, len(magicNumber))
+	if err := readMagicNumber(conn, magicNum); err != nil {
+		if isConnClosed(err) {
+			return errHandshakeUnsupported
+		}
+		return err
+	}
+	rheader, buf, err := readMessage(h.logger, conn, header, nil, encrypted)
+	if err != nil {
+		return err
+	}
+	if rheader.method != handshakeType {
+		return ErrBadMessage
+	}
+	resp := metapb.TransportHandshake{}
+	if err := resp.Unmarshal(buf); err != nil {
+		return err
+	}
+	if resp.Rejected != "" {
+		return errors.Wrapf(ErrHandshakeRejected, "rejected by %s: %s",
+			addr, resp.Rejected)
+	}
+	if reason := h.check(resp, req.TargetStoreID); reason != "" {
+		return errors.Wrapf(ErrHandshakeRejected, "reject %s: %s", addr, reason)
+	}
+	h.addPeer(resp)
+	return nil
+}
+
+// accept checks the handshake received by the acceptor, and replies with the
+// local info or the reason of the rejection.
+func (h *handshaker) accept(conn net.Conn, buf []byte, header []byte,
+	encrypted bool) error {
+	remote := metapb.TransportHandshake{}
+	if err := remote.Unmarshal(buf); err != nil {
+		return err
+	}
+
+	resp := h.local
+	reason := h.check(remote, 0)
+	if reason == "" && remote.TargetStoreID != 0 &&
+		remote.TargetStoreID != h.local.StoreID {
+		reason = fmt.Sprintf("target store ID %d mismatch, this is store %d",
+			remote.TargetStoreID, h.local.StoreID)
+	}
+	resp.Rejected = reason
+	if err := writeMessage(conn, requestHeader{method: handshakeType},
+		protoc.MustMarshal(&resp), header, encrypted); err != nil {
+		return err
+	}
+	if reason != "" {
+		return errors.Wrapf(ErrHandshakeRejected, "reject store %d: %s",
+			remote.StoreID, reason)
+	}
+	h.addPeer(remote)
+	return nil
+}
+
+// addLegacyPeer records the store at the address without the handshake
+func (h *handshaker) addLegacyPeer(addr string) {
+	h.logger.Warn("peer does not support transport handshake",
+		zap.String("addr", addr))
+	if storeID := h.targetStore(addr); storeID != 0 {
+		h.mu.Lock()
+		h.mu.peers[storeID] = metapb.TransportHandshake{StoreID: storeID}
+		h.mu.Unlock()
+	}
+}
+
+func (h *handshaker) addPeer(peer metapb.TransportHandshake) {
+	h.mu.Lock()
+	old, ok := h.mu.peers[peer.StoreID]
+	h.mu.peers[peer.StoreID] = peer
+	h.mu.Unlock()
+
+	if !ok || old.Version != peer.Version {
+		fields := []zap.Field{zap.Uint64("store", peer.StoreID),
+			zap.String("version", peer.Version),
+			zap.Strings("capabilities", peer.Capabilities)}
+		if peer.Version != h.local.Version {
+			h.logger.Warn("transport handshake with a store of different version",
+				append(fields, zap.String("local-version", h.local.Version))...)
+		} else {
+			h.logger.Info("transport handshake completed", fields...)
+		}
+	}
+}
+
+func (h *handshaker) getPeer(storeID uint64) (metapb.TransportHandshake, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	peer, ok := h.mu.peers[storeID]
+	return peer, ok
+}
+
+func isConnClosed(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// EnableHandshake enables the handshake on the connections of the transport.
+// The connections from or to the stores of the other clusters, the stores at
+// the unexpected addresses or the stores using the other protocol versions
+// are rejected. It must be called before Start.
+func (t *Transport) EnableHandshake(clusterID uint64, version string) {
+	tcp, ok := t.trans.(*TCP)
+	if !ok {
+		t.logger.Warn("transport handshake is not supported",
+			zap.String("transport", t.trans.Name()))
+		return
+	}
+	t.handshake = newHandshaker(t.logger, clusterID, t.storeID, version,
+		func(addr string) uint64 {
+			if v, ok := t.addrsRevert.Load(addr); ok {
+				return v.(uint64)
+			}
+			return 0
+		})
+	tcp.handshake = t.handshake
+}
+
+// GetPeer returns the handshake info of the store, false if the handshake is
+// not enabled or the store is never connected. The info of the stores without
+// the handshake support only has the store ID.
+func (t *Transport) GetPeer(storeID uint64) (metapb.TransportHandshake, bool) {
+	if t.handshake == nil {
+		return metapb.TransportHandshake{}, false
+	}
+	return t.handshake.getPeer(storeID)
+}
+
+// HasCapability returns true if the store announced the capability in the
+// handshake.
+func (t *Transport) HasCapability(storeID uint64, capability string) bool {
+	peer, ok := t.GetPeer(storeID)
+	if !ok {
+		return false
+	}
+	for _, c := range peer.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+var (
+	testHandshakeAddr = "localhost:36011"
+)
+
+type testHandshakePeer struct {
+	tcp      *TCP
+	received uint64
+}
+
+func newTestHandshakePeer(t *testing.T, addr string, h *handshaker) *testHandshakePeer {
+	p := &testHandshakePeer{}
+	p.tcp = NewTCPTransport(log.GetDefaultZapLogger(), addr,
+		func(batch metapb.RaftMessageBatch) {
+			atomic.AddUint64(&p.received, uint64(len(batch.Messages)))
+		},
+		func(metapb.SnapshotChunk) bool { return true }).(*TCP)
+	p.tcp.handshake = h
+	return p
+}
+
+func newTestHandshaker(clusterID, storeID, targetStoreID uint64) *handshaker {
+	return newHandshaker(log.GetDefaultZapLogger(), clusterID, storeID, "v1",
+		func(addr string) uint64 { return targetStoreID })
+}
+
+func TestHandshake(t *testing.T) {
+	server := newTestHandshakePeer(t, testHandshakeAddr, newTestHandshaker(1, 2, 0))
+	require.NoError(t, server.tcp.Start())
+	defer server.tcp.Close()
+
+	client := newTestHandshakePeer(t, "", newTestHandshaker(1, 1, 2))
+	conn, err := client.tcp.GetConnection(context.Background(), testHandshakeAddr)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SendMessageBatch(metapb.RaftMessageBatch{
+		Messages: []metapb.RaftMessage{{ShardID: 1}},
+	}))
+	assert.Eventually(t, func() bool {
+		return atomic.LoadUint64(&server.received) == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	trans := &Transport{handshake: client.tcp.handshake}
+	peer, ok := trans.GetPeer(2)
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), peer.ClusterID)
+	assert.Equal(t, "v1", peer.Version)
+	assert.True(t, trans.HasCapability(2, CapabilityHandshake))
+	assert.False(t, trans.HasCapability(2, "unknown"))
+	assert.False(t, trans.HasCapability(3, CapabilityHandshake))
+
+	peer, ok = server.tcp.handshake.getPeer(1)
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), peer.TargetStoreID)
+}
+
+func TestHandshakeRejected(t *testing.T) {
+	server := newTestHandshakePeer(t, testHandshakeAddr, newTestHandshaker(1, 2, 0))
+	require.NoError(t, server.tcp.Start())
+	defer server.tcp.Close()
+
+	protocolMismatch := newTestHandshaker(1, 1, 2)
+	protocolMismatch.local.ProtocolVersion++
+	cases := []*handshaker{
+		// cluster ID mismatch
+		newTestHandshaker(2, 1, 2),
+		// the address is not the expected store
+		newTestHandshaker(1, 1, 3),
+		protocolMismatch,
+	}
+	for idx, h := range cases {
+		client := newTestHandshakePeer(t, "", h)
+		_, err := client.tcp.GetConnection(context.Background(), testHandshakeAddr)
+		assert.True(t, errors.Is(err, ErrHandshakeRejected), "index %d, error %v", idx, err)
+		_, ok := h.getPeer(2)
+		assert.False(t, ok, "index %d", idx)
+	}
+}
+
+func TestHandshakeWithLegacyPeer(t *testing.T) {
+	server := newTestHandshakePeer(t, testHandshakeAddr, nil)
+	require.NoError(t, server.tcp.Start())
+	defer server.tcp.Close()
+
+	// the client falls back to the connection without the handshake
+	client := newTestHandshakePeer(t, "", newTestHandshaker(1, 1, 2))
+	conn, err := client.tcp.GetConnection(context.Background(), testHandshakeAddr)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SendMessageBatch(metapb.RaftMessageBatch{
+		Messages: []metapb.RaftMessage{{ShardID: 1}},
+	}))
+	assert.Eventually(t, func() bool {
+		return atomic.LoadUint64(&server.received) == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	trans := &Transport{handshake: client.tcp.handshake}
+	peer, ok := trans.GetPeer(2)
+	require.True(t, ok)
+	assert.Equal(t, metapb.TransportHandshake{StoreID: 2}, peer)
+	assert.False(t, trans.HasCapability(2, CapabilityHandshake))
+
+	// the legacy client is accepted without the handshake
+	legacy := newTestHandshakePeer(t, "", nil)
+	conn2, err := legacy.tcp.GetConnection(context.Background(), testHandshakeAddr)
+	require.NoError(t, err)
+	defer conn2.Close()
+	require.NoError(t, conn2.SendMessageBatch(metapb.RaftMessageBatch{
+		Messages: []metapb.RaftMessage{{ShardID: 1}},
+	}))
+	assert.Eventually(t, func() bool {
+		return atomic.LoadUint64(&server.received) == 2
+	}, time.Second*5, time.Millisecond*10)
+}
+
+func TestHandshakeAcceptsLegacyClient(t *testing.T) {
+	server := newTestHandshakePeer(t, testHandshakeAddr, newTestHandshaker(1, 2, 0))
+	require.NoError(t, server.tcp.Start())
+	defer server.tcp.Close()
+
+	legacy := newTestHandshakePeer(t, "", nil)
+	conn, err := legacy.tcp.GetConnection(context.Background(), testHandshakeAddr)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SendMessageBatch(metapb.RaftMessageBatch{
+		Messages: []metapb.RaftMessage{{ShardID: 1}},
+	}))
+	assert.Eventually(t, func() bool {
+		return atomic.LoadUint64(&server.received) == 1
+	}, time.Second*5, time.Millisecond*10)
+}
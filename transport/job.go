@@ -31,6 +31,7 @@ package transport
 
 import (
 	"context"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/fagongzi/util/protoc"
@@ -45,6 +46,9 @@ var (
 	// ErrStopped is the error returned to indicate that the connection has
 	// already been stopped.
 	ErrStopped = errors.New("connection stopped")
+	// ErrPreempted is the error returned to indicate that the snapshot send is
+	// preempted by a send with the higher priority.
+	ErrPreempted = errors.New("snapshot send preempted")
 )
 
 type job struct {
@@ -57,10 +61,14 @@ type job struct {
 	ch                chan metapb.SnapshotChunk
 	completed         chan struct{}
 	stopc             chan struct{}
+	preempted         chan struct{}
 	failed            chan struct{}
 	shardID           uint64
 	replicaID         uint64
 	snapshotChunkSize uint64
+	// limiter is nil if the bandwidth is not limited
+	limiter *snapshotRateLimiter
+	addr    string
 }
 
 func newJob(logger *zap.Logger,
@@ -99,6 +107,7 @@ func (j *job) connect(addr string) error {
 		return err
 	}
 	j.conn = conn
+	j.addr = addr
 	return nil
 }
 
@@ -125,6 +134,8 @@ func (j *job) sendSnapshot() error {
 		select {
 		case <-j.stopc:
 			return ErrStopped
+		case <-j.preempted:
+			return ErrPreempted
 		case chunk := <-j.ch:
 			if len(chunks) == 0 && chunk.ChunkID != 0 {
 				panic("chunk alignment error")
@@ -137,12 +148,79 @@ func (j *job) sendSnapshot() error {
 	}
 }
 
+// sendChunks sends the chunks, the send is confirmed with the receiver if the
+// connection is resumable, and the interrupted send is resumed from the next
+// chunk expected by the receiver.
 func (j *job) sendChunks(chunks []metapb.SnapshotChunk) error {
 	chunkData := make([]byte, j.snapshotChunkSize)
+	if _, ok := j.conn.(ResumableSnapshotConnection); !ok {
+		return j.sendChunksFrom(chunks, chunkData)
+	}
+
+	count := uint64(len(chunks))
+	next := uint64(0)
+	for retry := 0; ; retry++ {
+		err := j.sendChunksFrom(chunks[next:], chunkData)
+		if err == nil {
+			if next, err = j.resume(chunks[0]); err == nil {
+				if next == count {
+					return nil
+				}
+				err = errors.Newf("snapshot incomplete, %d/%d chunks received",
+					next, count)
+			}
+		}
+		if err == ErrStopped || err == ErrPreempted ||
+			retry >= maxSnapshotResumeRetries {
+			return err
+		}
+
+		j.logger.Warn("failed to send snapshot chunks, resuming",
+			zap.Uint64("shard", j.shardID),
+			zap.Uint64("replica", j.replicaID),
+			zap.Int("retry", retry+1),
+			zap.Error(err))
+		if next, err = j.reconnect(chunks[0]); err != nil {
+			return err
+		}
+		if next > count {
+			next = 0
+		}
+	}
+}
+
+// reconnect connects to the receiver again after the interval, and returns the
+// next chunk expected by the receiver.
+func (j *job) reconnect(first metapb.SnapshotChunk) (uint64, error) {
+	timer := time.NewTimer(snapshotResumeInterval)
+	defer timer.Stop()
+	select {
+	case <-j.stopc:
+		return 0, ErrStopped
+	case <-j.preempted:
+		return 0, ErrPreempted
+	case <-timer.C:
+	}
+
+	j.close()
+	j.conn = nil
+	if err := j.connect(j.addr); err != nil {
+		return 0, err
+	}
+	return j.resume(first)
+}
+
+func (j *job) resume(first metapb.SnapshotChunk) (uint64, error) {
+	return j.conn.(ResumableSnapshotConnection).Resume(newSnapshotResume(first))
+}
+
+func (j *job) sendChunksFrom(chunks []metapb.SnapshotChunk, chunkData []byte) error {
 	for _, chunk := range chunks {
 		select {
 		case <-j.stopc:
 			return ErrStopped
+		case <-j.preempted:
+			return ErrPreempted
 		default:
 		}
 		env := j.getEnv(chunk)
@@ -153,6 +231,12 @@ func (j *job) sendChunks(chunks []metapb.SnapshotChunk) error {
 				zap.Error(err))
 		}
 		chunk.Data = data
+		chunk.Checksum = chunkChecksum(data)
+		if j.limiter != nil {
+			if err := j.limiter.wait(len(data), j.stopc, j.preempted); err != nil {
+				return err
+			}
+		}
 		if err := j.conn.SendChunk(chunk); err != nil {
 			return err
 		}
@@ -48,21 +48,69 @@ var (
 	maxConnectionCount       uint64 = 64
 )
 
-// SendSnapshot asynchronously sends raft snapshot message to its target.
+// SendSnapshot asynchronously sends raft snapshot message to its target. The
+// message is queued by its priority if too many snapshots are being sent.
 func (t *Transport) SendSnapshot(m metapb.RaftMessage) bool {
-	if !t.sendSnapshot(m) {
-		t.logger.Error("failed to send snapshot",
-			log.RaftMessageField("message", m))
-		t.sendSnapshotNotification(m.ShardID, m.To.ID, m.Message.Snapshot, true)
-		return false
+	if m.Message.Type != raftpb.MsgSnap {
+		panic("not a snapshot message")
 	}
-	return true
+	task := newSnapshotTask(m, t.getSnapshotPriority(m))
+	start, dropped := t.snapshots.add(task)
+	if dropped != nil {
+		t.logger.Warn("snapshot dropped, too many pending snapshots",
+			log.RaftMessageField("message", dropped.m))
+		t.sendSnapshotNotification(dropped.m.ShardID, dropped.m.To.ID,
+			dropped.m.Message.Snapshot, true)
+		if dropped == task {
+			return false
+		}
+	}
+	if !start {
+		t.logger.Info("snapshot queued",
+			log.RaftMessageField("message", m),
+			zap.Int("priority", int(task.priority)))
+		return true
+	}
+	return t.startSnapshotTask(task)
 }
 
-func (t *Transport) sendSnapshot(m metapb.RaftMessage) bool {
-	if m.Message.Type != raftpb.MsgSnap {
-		panic("not a snapshot message")
+// SetSnapshotPriority sets the func to get the priority of the snapshots, all
+// snapshots have the SnapshotPriorityNormal if not set.
+func (t *Transport) SetSnapshotPriority(f SnapshotPriorityFunc) {
+	if f == nil {
+		panic("nil snapshot priority func")
+	}
+	t.snapshotPriority.Store(f)
+}
+
+func (t *Transport) getSnapshotPriority(m metapb.RaftMessage) SnapshotPriority {
+	if f := t.snapshotPriority.Load(); f != nil {
+		return f.(SnapshotPriorityFunc)(m)
+	}
+	return SnapshotPriorityNormal
+}
+
+func (t *Transport) startSnapshotTask(task *snapshotTask) bool {
+	if t.sendSnapshot(task) {
+		return true
 	}
+	t.logger.Error("failed to send snapshot",
+		log.RaftMessageField("message", task.m))
+	t.sendSnapshotNotification(task.m.ShardID, task.m.To.ID,
+		task.m.Message.Snapshot, true)
+	t.snapshotTaskDone(task)
+	return false
+}
+
+// snapshotTaskDone starts the next pending task after the task is finished
+func (t *Transport) snapshotTaskDone(task *snapshotTask) {
+	if next := t.snapshots.done(task); next != nil {
+		t.startSnapshotTask(next)
+	}
+}
+
+func (t *Transport) sendSnapshot(task *snapshotTask) bool {
+	m := task.m
 	env := t.getEnv(m)
 	chunks, err := splitSnapshotMessage(m,
 		env.GetFinalDir(), defaultSnapshotChunkSize, t.fs)
@@ -87,12 +135,14 @@ func (t *Transport) sendSnapshot(m metapb.RaftMessage) bool {
 	if job == nil {
 		return false
 	}
+	job.preempted = task.preempted
 	shutdown := func() {
 		atomic.AddUint64(&t.jobs, ^uint64(0))
 	}
 	t.stopper.RunWorker(func() {
 		t.processSnapshot(job, m.Message.Snapshot, targetInfo.addr)
 		shutdown()
+		t.snapshotTaskDone(task)
 	})
 	job.addSnapshot(chunks)
 	return true
@@ -116,8 +166,10 @@ func (t *Transport) createJob(shardID uint64, toReplicaID uint64,
 			zap.Uint64("job-count", r))
 		return nil
 	}
-	return newJob(t.logger, t.ctx, shardID, toReplicaID,
+	j := newJob(t.logger, t.ctx, shardID, toReplicaID,
 		sz, t.trans, t.dir, t.stopper.ShouldStop(), defaultSnapshotChunkSize, t.fs)
+	j.limiter = t.snapshotLimiter
+	return j
 }
 
 func (t *Transport) processSnapshot(c *job, ss raftpb.Snapshot, addr string) {
@@ -141,6 +193,14 @@ func (t *Transport) processSnapshot(c *job, ss raftpb.Snapshot, addr string) {
 				zap.String("addr", addr))
 		}
 		err := c.process()
+		if err == ErrPreempted {
+			// not a connection failure, the snapshot will be sent again later
+			t.logger.Info("snapshot send preempted",
+				log.ShardIDField(shardID),
+				log.ReplicaIDField(replicaID))
+			t.sendSnapshotNotification(shardID, replicaID, ss, true)
+			return nil
+		}
 		if err != nil {
 			t.logger.Error("failed to process snapshot chunk",
 				zap.Error(err))
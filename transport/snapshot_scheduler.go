@@ -0,0 +1,178 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+var (
+	// maxPendingSnapshotCount the max number of snapshots waiting for a free
+	// connection
+	maxPendingSnapshotCount = 256
+)
+
+// SnapshotPriority is the priority of sending a snapshot. When there are too
+// many snapshots to send, the snapshots with the higher priority are sent
+// first, and the running sends with the lower priority are preempted.
+type SnapshotPriority int
+
+const (
+	// SnapshotPriorityNormal the snapshots sent for the routine moves, e.g.
+	// rebalancing
+	SnapshotPriorityNormal SnapshotPriority = iota
+	// SnapshotPriorityRepair the snapshots sent to the shards below their
+	// replica target
+	SnapshotPriorityRepair
+)
+
+// SnapshotPriorityFunc returns the priority of the snapshot message
+type SnapshotPriorityFunc func(metapb.RaftMessage) SnapshotPriority
+
+type snapshotTask struct {
+	m        metapb.RaftMessage
+	priority SnapshotPriority
+	seq      uint64
+	// preempted is closed to stop the running send for a higher priority one
+	preempted   chan struct{}
+	isPreempted bool
+}
+
+func newSnapshotTask(m metapb.RaftMessage, priority SnapshotPriority) *snapshotTask {
+	return &snapshotTask{
+		m:         m,
+		priority:  priority,
+		preempted: make(chan struct{}),
+	}
+}
+
+// snapshotScheduler limits the number of the concurrent snapshot sends. The
+// sends over the limit are queued by priority, a queued send with the higher
+// priority preempts a running one with the lower priority.
+type snapshotScheduler struct {
+	sync.Mutex
+	maxRunning int
+	maxPending int
+	seq        uint64
+	running    map[*snapshotTask]struct{}
+	// pending is ordered by priority desc and seq asc
+	pending []*snapshotTask
+}
+
+func newSnapshotScheduler(maxRunning, maxPending int) *snapshotScheduler {
+	return &snapshotScheduler{
+		maxRunning: maxRunning,
+		maxPending: maxPending,
+		running:    make(map[*snapshotTask]struct{}),
+	}
+}
+
+// add adds the task, returns true if the task can be started now. The tasks
+// dropped from the full pending queue are returned, the task itself is
+// returned if it's dropped.
+func (s *snapshotScheduler) add(task *snapshotTask) (bool, *snapshotTask) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.seq++
+	task.seq = s.seq
+	if len(s.running) < s.maxRunning {
+		s.startLocked(task)
+		return true, nil
+	}
+
+	var dropped *snapshotTask
+	if len(s.pending) >= s.maxPending {
+		last := s.pending[len(s.pending)-1]
+		if last.priority >= task.priority {
+			return false, task
+		}
+		s.pending = s.pending[:len(s.pending)-1]
+		dropped = last
+	}
+	s.insertLocked(task)
+	s.preemptLocked(task.priority)
+	return false, dropped
+}
+
+// done marks the task as finished and returns the next task to start
+func (s *snapshotScheduler) done(task *snapshotTask) *snapshotTask {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.running[task]; !ok {
+		return nil
+	}
+	delete(s.running, task)
+	if len(s.pending) == 0 || len(s.running) >= s.maxRunning {
+		return nil
+	}
+	next := s.pending[0]
+	s.pending = s.pending[1:]
+	s.startLocked(next)
+	return next
+}
+
+func (s *snapshotScheduler) startLocked(task *snapshotTask) {
+	s.running[task] = struct{}{}
+}
+
+func (s *snapshotScheduler) insertLocked(task *snapshotTask) {
+	i := sort.Search(len(s.pending), func(i int) bool {
+		p := s.pending[i]
+		return p.priority < task.priority ||
+			(p.priority == task.priority && p.seq > task.seq)
+	})
+	s.pending = append(s.pending, nil)
+	copy(s.pending[i+1:], s.pending[i:])
+	s.pending[i] = task
+}
+
+// preemptLocked preempts a running task with the lower priority if the pending
+// tasks with the priority can not get the connections freed by the tasks
+// preempted before.
+func (s *snapshotScheduler) preemptLocked(priority SnapshotPriority) {
+	waiting := 0
+	for _, p := range s.pending {
+		if p.priority < priority {
+			break
+		}
+		waiting++
+	}
+	var victim *snapshotTask
+	for t := range s.running {
+		if t.isPreempted {
+			waiting--
+			continue
+		}
+		if t.priority < priority &&
+			(victim == nil || t.priority < victim.priority ||
+				(t.priority == victim.priority && t.seq > victim.seq)) {
+			victim = t
+		}
+	}
+	if waiting > 0 && victim != nil {
+		victim.isPreempted = true
+		close(victim.preempted)
+	}
+}
+
+func (s *snapshotScheduler) stats() (running int, pending int) {
+	s.Lock()
+	defer s.Unlock()
+	return len(s.running), len(s.pending)
+}
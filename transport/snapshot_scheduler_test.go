@@ -0,0 +1,121 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+func newTestSnapshotTask(shardID uint64, priority SnapshotPriority) *snapshotTask {
+	return newSnapshotTask(metapb.RaftMessage{ShardID: shardID}, priority)
+}
+
+func isPreempted(task *snapshotTask) bool {
+	select {
+	case <-task.preempted:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestSnapshotSchedulerOrder(t *testing.T) {
+	s := newSnapshotScheduler(1, 10)
+	t1 := newTestSnapshotTask(1, SnapshotPriorityRepair)
+	t2 := newTestSnapshotTask(2, SnapshotPriorityNormal)
+	t3 := newTestSnapshotTask(3, SnapshotPriorityRepair)
+	t4 := newTestSnapshotTask(4, SnapshotPriorityRepair)
+
+	start, dropped := s.add(t1)
+	assert.True(t, start)
+	assert.Nil(t, dropped)
+	for _, task := range []*snapshotTask{t2, t3, t4} {
+		start, dropped = s.add(task)
+		assert.False(t, start)
+		assert.Nil(t, dropped)
+	}
+	// no lower priority task to preempt
+	assert.False(t, isPreempted(t1))
+
+	assert.Equal(t, t3, s.done(t1))
+	assert.Equal(t, t4, s.done(t3))
+	assert.Equal(t, t2, s.done(t4))
+	assert.Nil(t, s.done(t2))
+	// done twice
+	assert.Nil(t, s.done(t2))
+	running, pending := s.stats()
+	assert.Equal(t, 0, running)
+	assert.Equal(t, 0, pending)
+}
+
+func TestSnapshotSchedulerPreempt(t *testing.T) {
+	s := newSnapshotScheduler(2, 10)
+	t1 := newTestSnapshotTask(1, SnapshotPriorityNormal)
+	t2 := newTestSnapshotTask(2, SnapshotPriorityNormal)
+	s.add(t1)
+	s.add(t2)
+
+	t3 := newTestSnapshotTask(3, SnapshotPriorityNormal)
+	s.add(t3)
+	assert.False(t, isPreempted(t1))
+	assert.False(t, isPreempted(t2))
+
+	// the latest started one is preempted
+	t4 := newTestSnapshotTask(4, SnapshotPriorityRepair)
+	start, _ := s.add(t4)
+	assert.False(t, start)
+	assert.False(t, isPreempted(t1))
+	assert.True(t, isPreempted(t2))
+
+	t5 := newTestSnapshotTask(5, SnapshotPriorityRepair)
+	s.add(t5)
+	assert.True(t, isPreempted(t1))
+
+	// no more task to preempt
+	t6 := newTestSnapshotTask(6, SnapshotPriorityRepair)
+	s.add(t6)
+
+	assert.Equal(t, t4, s.done(t2))
+	assert.Equal(t, t5, s.done(t1))
+	assert.Equal(t, t6, s.done(t4))
+	assert.Equal(t, t3, s.done(t5))
+}
+
+func TestSnapshotSchedulerDrop(t *testing.T) {
+	s := newSnapshotScheduler(1, 2)
+	t1 := newTestSnapshotTask(1, SnapshotPriorityRepair)
+	s.add(t1)
+	t2 := newTestSnapshotTask(2, SnapshotPriorityNormal)
+	s.add(t2)
+	t3 := newTestSnapshotTask(3, SnapshotPriorityRepair)
+	s.add(t3)
+
+	t4 := newTestSnapshotTask(4, SnapshotPriorityNormal)
+	start, dropped := s.add(t4)
+	assert.False(t, start)
+	assert.Equal(t, t4, dropped)
+
+	t5 := newTestSnapshotTask(5, SnapshotPriorityRepair)
+	start, dropped = s.add(t5)
+	assert.False(t, start)
+	assert.Equal(t, t2, dropped)
+
+	assert.Equal(t, t3, s.done(t1))
+	assert.Equal(t, t5, s.done(t3))
+	assert.Nil(t, s.done(t5))
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"hash/crc32"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/ratelimit"
+
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+)
+
+var (
+	// maxSnapshotResumeRetries the max number of the times an interrupted
+	// snapshot send is resumed
+	maxSnapshotResumeRetries = 3
+	// snapshotResumeInterval the time to wait before resuming a snapshot send
+	snapshotResumeInterval = time.Second
+
+	crc32Table = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// ResumableSnapshotConnection is a SnapshotConnection which can query the
+// receiving progress of the snapshot, the snapshot sends over it are resumed
+// from the progress of the receiver after the failures, and are confirmed
+// after all chunks are sent.
+type ResumableSnapshotConnection interface {
+	SnapshotConnection
+	// Resume returns the next chunk ID expected by the receiver, 0 if the
+	// snapshot is unknown to the receiver and the chunk count if the snapshot
+	// is received.
+	Resume(query metapb.SnapshotResume) (uint64, error)
+}
+
+func chunkChecksum(data []byte) uint32 {
+	return crc32.Checksum(data, crc32Table)
+}
+
+// verifyChunk returns false if the chunk data mismatches the checksum, the
+// chunks without the checksum are sent by the old versions and are not
+// verified.
+func verifyChunk(chunk metapb.SnapshotChunk) bool {
+	return chunk.Checksum == 0 || chunk.Checksum == chunkChecksum(chunk.Data)
+}
+
+func newSnapshotResume(chunk metapb.SnapshotChunk) metapb.SnapshotResume {
+	return metapb.SnapshotResume{
+		ShardID:   chunk.ShardID,
+		ReplicaID: chunk.ReplicaID,
+		From:      chunk.From,
+		Index:     chunk.Index,
+	}
+}
+
+// snapshotRateLimiter limits the bandwidth used by all snapshot sends of the
+// store.
+type snapshotRateLimiter struct {
+	// bucket stores the *ratelimit.Bucket, nil if unlimited
+	bucket atomic.Value
+}
+
+func (l *snapshotRateLimiter) setRate(bytesPerSecond uint64) {
+	var bucket *ratelimit.Bucket
+	if bytesPerSecond > 0 {
+		bucket = ratelimit.NewBucketWithRate(float64(bytesPerSecond),
+			int64(bytesPerSecond))
+	}
+	l.bucket.Store(bucket)
+}
+
+// wait waits until the n bytes can be sent, ErrStopped or ErrPreempted is
+// returned if the send is stopped or preempted during the wait.
+func (l *snapshotRateLimiter) wait(n int,
+	stopc <-chan struct{}, preempted <-chan struct{}) error {
+	v := l.bucket.Load()
+	if v == nil || v.(*ratelimit.Bucket) == nil {
+		return nil
+	}
+	d := v.(*ratelimit.Bucket).Take(int64(n))
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-stopc:
+		return ErrStopped
+	case <-preempted:
+		return ErrPreempted
+	case <-timer.C:
+		return nil
+	}
+}
+
+// SetSnapshotRateLimit sets the max bytes per second of the snapshots sent by
+// the store, the bandwidth is shared by all snapshot sends. 0 means unlimited.
+func (t *Transport) SetSnapshotRateLimit(bytesPerSecond uint64) {
+	t.snapshotLimiter.setRate(bytesPerSecond)
+}
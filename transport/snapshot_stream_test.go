@@ -0,0 +1,199 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/fagongzi/util/protoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft/v3/raftpb"
+	"go.uber.org/zap"
+
+	"github.com/matrixorigin/matrixcube/components/log"
+	"github.com/matrixorigin/matrixcube/pb/metapb"
+	"github.com/matrixorigin/matrixcube/snapshot"
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+	"github.com/matrixorigin/matrixcube/vfs"
+)
+
+const (
+	testFaultDrop = iota + 1
+	testFaultCorrupt
+	testFaultDropAlways
+)
+
+// testResumableConnection delivers the chunks to the receiver directly, the
+// faults injected by the chunk ID are triggered once unless it's always.
+type testResumableConnection struct {
+	chunks  *Chunk
+	faults  map[uint64]int
+	sent    int
+	resumes int
+}
+
+func (c *testResumableConnection) Close() {}
+
+func (c *testResumableConnection) SendChunk(chunk metapb.SnapshotChunk) error {
+	c.sent++
+	fault := c.faults[chunk.ChunkID]
+	if fault != testFaultDropAlways {
+		delete(c.faults, chunk.ChunkID)
+	}
+	switch fault {
+	case testFaultDrop, testFaultDropAlways:
+		return nil
+	case testFaultCorrupt:
+		chunk.Data = append([]byte{}, chunk.Data...)
+		chunk.Data[0]++
+	}
+	if !c.chunks.Add(chunk) {
+		return errors.New("chunk rejected")
+	}
+	return nil
+}
+
+func (c *testResumableConnection) Resume(query metapb.SnapshotResume) (uint64, error) {
+	c.resumes++
+	return c.chunks.Progress(query), nil
+}
+
+type testResumableTransport struct {
+	NOOPTransport
+	conn     *testResumableConnection
+	connects int
+}
+
+func (t *testResumableTransport) GetSnapshotConnection(ctx context.Context,
+	target string) (SnapshotConnection, error) {
+	t.connects++
+	return t.conn, nil
+}
+
+func runSnapshotResumeTest(t *testing.T, faults map[uint64]int,
+	fn func(*testing.T, *job, *testResumableTransport, *testMessageHandler)) {
+	defer leaktest.AfterTest(t)()
+	fs := vfs.GetTestFS()
+	defer vfs.ReportLeakedFD(fs, t)
+	require.NoError(t, fs.RemoveAll(testSnapshotDir))
+	defer func() {
+		require.NoError(t, fs.RemoveAll(testSnapshotDir))
+	}()
+	defer func(v time.Duration) {
+		snapshotResumeInterval = v
+	}(snapshotResumeInterval)
+	snapshotResumeInterval = time.Millisecond
+
+	extra := uint64(12345)
+	index := uint64(100)
+	m := metapb.RaftMessage{
+		ShardID: 1,
+		From:    metapb.Replica{ID: 1},
+		To:      metapb.Replica{ID: 2},
+		Message: raftpb.Message{
+			Type: raftpb.MsgSnap,
+			Snapshot: raftpb.Snapshot{
+				Data:     protoc.MustMarshal(&metapb.SnapshotInfo{Extra: extra}),
+				Metadata: raftpb.SnapshotMetadata{Index: index, Term: 1},
+			},
+		},
+	}
+	require.NoError(t, fs.MkdirAll(getTestSnapshotDir(1, 2), 0755))
+	env := snapshot.NewSSEnv(getTestSnapshotDir, 1, 1, index, extra,
+		snapshot.CreatingMode, fs)
+	env.FinalizeIndex(index)
+	require.NoError(t, generateTestSnapshotDirWithFiles(10, 1024, env.GetFinalDir(), fs))
+	chunkSize := uint64(1000)
+	chunks, err := splitSnapshotMessage(m, env.GetFinalDir(), chunkSize, fs)
+	require.NoError(t, err)
+	require.Equal(t, 20, len(chunks))
+
+	logger := log.GetDefaultZapLoggerWithLevel(zap.DebugLevel)
+	handler := newTestMessageHandler()
+	receiver := NewChunk(logger, handler.HandleMessageBatch, getTestSnapshotDir, fs)
+	defer receiver.Close()
+	trans := &testResumableTransport{
+		conn: &testResumableConnection{chunks: receiver, faults: faults},
+	}
+	j := newJob(logger, context.Background(), 1, 2, len(chunks), trans,
+		getTestSnapshotDir, make(chan struct{}), chunkSize, fs)
+	require.NoError(t, j.connect("test"))
+	j.addSnapshot(chunks)
+	fn(t, j, trans, handler)
+}
+
+func TestSnapshotSendCanBeResumed(t *testing.T) {
+	faults := map[uint64]int{5: testFaultDrop, 12: testFaultCorrupt}
+	fn := func(t *testing.T, j *job, trans *testResumableTransport,
+		handler *testMessageHandler) {
+		require.NoError(t, j.process())
+		assert.Equal(t, uint64(1), handler.getSnapshotCount(1, 2))
+		// chunk 6 is rejected as chunk 5 is lost, the send is resumed from
+		// chunk 5. Then the corrupted chunk 12 is rejected, the send is resumed
+		// from chunk 12, and confirmed after the last chunk.
+		assert.Equal(t, 3, trans.connects)
+		assert.Equal(t, 7+8+8, trans.conn.sent)
+		assert.Equal(t, 3, trans.conn.resumes)
+	}
+	runSnapshotResumeTest(t, faults, fn)
+}
+
+func TestSnapshotSendResumeRetriesAreLimited(t *testing.T) {
+	defer func(v int) {
+		maxSnapshotResumeRetries = v
+	}(maxSnapshotResumeRetries)
+	maxSnapshotResumeRetries = 2
+
+	faults := map[uint64]int{0: testFaultDropAlways}
+	fn := func(t *testing.T, j *job, trans *testResumableTransport,
+		handler *testMessageHandler) {
+		assert.Error(t, j.process())
+		assert.Equal(t, uint64(0), handler.getSnapshotCount(1, 2))
+		assert.Equal(t, 3, trans.connects)
+	}
+	runSnapshotResumeTest(t, faults, fn)
+}
+
+func TestSnapshotRateLimiter(t *testing.T) {
+	stopc := make(chan struct{})
+	preempted := make(chan struct{})
+
+	l := &snapshotRateLimiter{}
+	assert.NoError(t, l.wait(1<<30, stopc, preempted))
+
+	l.setRate(1000)
+	start := time.Now()
+	assert.NoError(t, l.wait(1000, stopc, preempted))
+	assert.NoError(t, l.wait(100, stopc, preempted))
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	close(preempted)
+	assert.Equal(t, ErrPreempted, l.wait(1000, stopc, preempted))
+
+	l.setRate(0)
+	assert.NoError(t, l.wait(1<<30, stopc, preempted))
+}
+
+func TestVerifyChunk(t *testing.T) {
+	chunk := metapb.SnapshotChunk{Data: []byte("data")}
+	assert.True(t, verifyChunk(chunk))
+	chunk.Checksum = chunkChecksum(chunk.Data)
+	assert.True(t, verifyChunk(chunk))
+	chunk.Data = []byte("date")
+	assert.False(t, verifyChunk(chunk))
+}
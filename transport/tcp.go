@@ -75,6 +75,8 @@ const (
 	requestHeaderSize        = 18
 	raftType          uint16 = 100
 	snapshotType      uint16 = 200
+	handshakeType     uint16 = 300
+	resumeType        uint16 = 400
 )
 
 type requestHeader struct {
@@ -117,7 +119,8 @@ func (h *requestHeader) decode(buf []byte) bool {
 	}
 	binary.BigEndian.PutUint32(buf[10:], incoming)
 	method := binary.BigEndian.Uint16(buf)
-	if method != raftType && method != snapshotType {
+	if method != raftType && method != snapshotType &&
+		method != handshakeType && method != resumeType {
 		return false
 	}
 	h.method = method
@@ -354,7 +357,7 @@ type TCPSnapshotConnection struct {
 	encrypted bool
 }
 
-var _ SnapshotConnection = (*TCPSnapshotConnection)(nil)
+var _ ResumableSnapshotConnection = (*TCPSnapshotConnection)(nil)
 
 // NewTCPSnapshotConnection creates and returns a new snapshot connection.
 func NewTCPSnapshotConnection(logger *zap.Logger,
@@ -388,6 +391,31 @@ func (c *TCPSnapshotConnection) SendChunk(chunk metapb.SnapshotChunk) error {
 	return writeMessage(c.conn, header, buf, c.header, c.encrypted)
 }
 
+// Resume queries the receiving progress of the snapshot, and returns the next
+// chunk ID expected by the remote node.
+func (c *TCPSnapshotConnection) Resume(query metapb.SnapshotResume) (uint64, error) {
+	if err := writeMessage(c.conn, requestHeader{method: resumeType},
+		protoc.MustMarshal(&query), c.header, c.encrypted); err != nil {
+		return 0, err
+	}
+	magicNum := make([]byte, len(magicNumber))
+	if err := readMagicNumber(c.conn, magicNum); err != nil {
+		return 0, err
+	}
+	rheader, buf, err := readMessage(c.logger, c.conn, c.header, nil, c.encrypted)
+	if err != nil {
+		return 0, err
+	}
+	if rheader.method != resumeType {
+		return 0, ErrBadMessage
+	}
+	resp := metapb.SnapshotResume{}
+	if err := resp.Unmarshal(buf); err != nil {
+		return 0, err
+	}
+	return resp.Next, nil
+}
+
 // TCP is a TCP based transport module for exchanging raft messages and
 // snapshots between NodeHost instances.
 type TCP struct {
@@ -397,8 +425,13 @@ type TCP struct {
 	connStopper    *syncutil.Stopper
 	requestHandler MessageHandler
 	chunkHandler   SnapshotChunkHandler
+	// resumeHandler returns the next chunk ID expected of the snapshot, the
+	// resume queries are rejected if it's nil
+	resumeHandler func(metapb.SnapshotResume) uint64
 	//nhConfig       config.NodeHostConfig
 	encrypted bool
+	// handshake is nil if the handshake is not enabled
+	handshake *handshaker
 }
 
 var _ TransImpl = (*TCP)(nil)
@@ -499,7 +532,7 @@ func (t *TCP) serveConn(conn net.Conn) {
 	magicNum := make([]byte, len(magicNumber))
 	header := make([]byte, requestHeaderSize)
 	tbuf := make([]byte, payloadBufferSize)
-	for {
+	for first := true; ; first = false {
 		err := readMagicNumber(conn, magicNum)
 		if err != nil {
 			if errors.Is(err, errPoisonReceived) {
@@ -523,12 +556,37 @@ func (t *TCP) serveConn(conn net.Conn) {
 		if err != nil {
 			return
 		}
-		if rheader.method == raftType {
+		if rheader.method == handshakeType {
+			// the handshake can only be the first message of the connection
+			if t.handshake == nil || !first {
+				return
+			}
+			if err := t.handshake.accept(conn, buf, header, t.encrypted); err != nil {
+				t.logger.Error("failed to accept transport handshake",
+					zap.Error(err))
+				return
+			}
+		} else if rheader.method == raftType {
 			batch := metapb.RaftMessageBatch{}
 			if err := batch.Unmarshal(buf); err != nil {
 				return
 			}
 			t.requestHandler(batch)
+		} else if rheader.method == resumeType {
+			if t.resumeHandler == nil {
+				return
+			}
+			query := metapb.SnapshotResume{}
+			if err := query.Unmarshal(buf); err != nil {
+				return
+			}
+			query.Next = t.resumeHandler(query)
+			if err := writeMessage(conn, requestHeader{method: resumeType},
+				protoc.MustMarshal(&query), header, t.encrypted); err != nil {
+				t.logger.Error("failed to reply snapshot resume query",
+					zap.Error(err))
+				return
+			}
 		} else {
 			chunk := metapb.SnapshotChunk{}
 			if err := chunk.Unmarshal(buf); err != nil {
@@ -553,9 +611,32 @@ func setTCPConn(conn *net.TCPConn) error {
 	return conn.SetKeepAlivePeriod(keepAlivePeriod)
 }
 
+func (t *TCP) getConnection(ctx context.Context,
+	target string) (net.Conn, error) {
+	conn, err := t.dial(ctx, target)
+	if err != nil || t.handshake == nil {
+		return conn, err
+	}
+
+	err = t.handshake.dial(conn, target, t.encrypted)
+	if err == nil {
+		return conn, nil
+	}
+	if err := conn.Close(); err != nil {
+		t.logger.Error("failed to close the connection",
+			zap.Error(err))
+	}
+	if !errors.Is(err, errHandshakeUnsupported) {
+		return nil, err
+	}
+	// the peer is running a version without the handshake
+	t.handshake.addLegacyPeer(target)
+	return t.dial(ctx, target)
+}
+
 // FIXME:
 // context.Context is ignored
-func (t *TCP) getConnection(ctx context.Context,
+func (t *TCP) dial(ctx context.Context,
 	target string) (net.Conn, error) {
 	timeout := time.Duration(dialTimeoutSecond) * time.Second
 	conn, err := net.DialTimeout("tcp", target, timeout)
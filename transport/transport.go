@@ -159,6 +159,12 @@ type Transport struct {
 	addrs          sync.Map // storeID -> targetInfo
 	addrsRevert    sync.Map // addr -> storeID
 	fs             vfs.FS
+	// snapshotPriority stores the SnapshotPriorityFunc
+	snapshotPriority atomic.Value
+	snapshots        *snapshotScheduler
+	snapshotLimiter  *snapshotRateLimiter
+	// handshake is nil if the handshake is not enabled
+	handshake *handshaker
 }
 
 func NewTransport(logger *zap.Logger, addr string,
@@ -176,9 +182,13 @@ func NewTransport(logger *zap.Logger, addr string,
 		resolver:       resolver,
 		stopper:        syncutil.NewStopper(),
 		fs:             fs,
+		snapshots: newSnapshotScheduler(int(maxConnectionCount),
+			maxPendingSnapshotCount),
 	}
 	t.chunks = NewChunk(t.logger, t.handler, t.dir, fs)
 	t.trans = NewTCPTransport(logger, addr, handler, t.chunks.Add)
+	t.trans.(*TCP).resumeHandler = t.chunks.Progress
+	t.snapshotLimiter = &snapshotRateLimiter{}
 	t.mu.queues = make(map[string]chan metapb.RaftMessage)
 	t.mu.breakers = make(map[string]*circuit.Breaker)
 	t.ctx, t.cancel = context.WithCancel(context.Background())
@@ -222,12 +232,15 @@ func (t *Transport) SetFilter(f func(metapb.RaftMessage) bool) {
 	t.filter.Store(f)
 }
 
+// SendingSnapshotCount returns the number of the snapshots being sent or
+// waiting to be sent
 func (t *Transport) SendingSnapshotCount() uint64 {
-	return 0
+	running, pending := t.snapshots.stats()
+	return uint64(running + pending)
 }
 
 func (t *Transport) Send(m metapb.RaftMessage) bool {
-	if m.Message.Type == raftpb.MsgSnap {
+	if m.Message.Type == raftpb.MsgSnap && !isWitnessSnapshot(m.Message.Snapshot) {
 		panic("sending snapshot message as regular message")
 	}
 
@@ -393,6 +406,19 @@ func (t *Transport) processMessages(addr string,
 	}
 }
 
+// isWitnessSnapshot returns true if the snapshot is sent to a witness, such
+// snapshot carries the shard metadata only and is sent as a regular message.
+func isWitnessSnapshot(ss raftpb.Snapshot) bool {
+	if len(ss.Data) == 0 {
+		return false
+	}
+	var si metapb.SnapshotInfo
+	if err := si.Unmarshal(ss.Data); err != nil {
+		return false
+	}
+	return si.Witness
+}
+
 func lazyFree(reqs []metapb.RaftMessage,
 	mb metapb.RaftMessageBatch) ([]metapb.RaftMessage, metapb.RaftMessageBatch) {
 	for i := 0; i < len(reqs); i++ {
@@ -203,3 +203,16 @@ func TestSetNilFilter(t *testing.T) {
 	}()
 	assert.True(t, hasPanic)
 }
+
+func TestIsWitnessSnapshot(t *testing.T) {
+	witness := metapb.SnapshotInfo{Witness: true}
+	data, err := witness.Marshal()
+	require.NoError(t, err)
+	assert.True(t, isWitnessSnapshot(raftpb.Snapshot{Data: data}))
+
+	normal := metapb.SnapshotInfo{Extra: 1}
+	data, err = normal.Marshal()
+	require.NoError(t, err)
+	assert.False(t, isWitnessSnapshot(raftpb.Snapshot{Data: data}))
+	assert.False(t, isWitnessSnapshot(raftpb.Snapshot{}))
+}
@@ -0,0 +1,264 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/matrixorigin/matrixcube/metric"
+)
+
+const (
+	// WAL is the name of the pool syncing the raft log appends
+	WAL = "wal"
+	// Data is the name of the pool syncing the data storage flushes
+	Data = "data"
+)
+
+type request struct {
+	fn func() error
+	// write is true if the fn only writes, the write is made durable by the
+	// group sync of the pool
+	write   bool
+	enqueue time.Time
+	done    chan error
+}
+
+var requestPool = sync.Pool{
+	New: func() interface{} {
+		return &request{done: make(chan error, 1)}
+	},
+}
+
+// Pool runs the fsync work on a bounded number of goroutines with a bounded
+// queue. The different kinds of fsync work, e.g. the raft log appends and the
+// data storage flushes, run on their own pools, so a burst of one kind doesn't
+// delay the other. A nil Pool runs the fsync work on the calling goroutine.
+type Pool struct {
+	name  string
+	queue chan *request
+	wg    sync.WaitGroup
+	// groupSync is run once after the writes committed together, nil if the
+	// pool does not group commit
+	groupSync    func() error
+	maxGroupSize int
+
+	mu struct {
+		sync.RWMutex
+		closed bool
+	}
+}
+
+// NewPool creates a Pool with the number of workers, Do blocks when there are
+// queueSize pending requests.
+func NewPool(name string, workers, queueSize int) *Pool {
+	return newPool(name, workers, queueSize, 0, nil)
+}
+
+// NewGroupCommitPool returns a pool which groups the writes submitted by Commit,
+// the writes waiting in the queue are run together, and made durable by a
+// single call of the sync. At most maxGroupSize writes are grouped.
+func NewGroupCommitPool(name string, workers, queueSize, maxGroupSize int,
+	sync func() error) *Pool {
+	if maxGroupSize <= 0 {
+		maxGroupSize = 1
+	}
+	return newPool(name, workers, queueSize, maxGroupSize, sync)
+}
+
+func newPool(name string, workers, queueSize, maxGroupSize int,
+	sync func() error) *Pool {
+	if workers <= 0 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	p := &Pool{
+		name:         name,
+		queue:        make(chan *request, queueSize),
+		groupSync:    sync,
+		maxGroupSize: maxGroupSize,
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.run()
+	}
+	return p
+}
+
+// Name returns the name of the pool
+func (p *Pool) Name() string {
+	return p.name
+}
+
+// Do runs the fn on the pool and waits for its result. The fn runs on the
+// calling goroutine if the pool is nil or closed.
+func (p *Pool) Do(fn func() error) error {
+	return p.DoAsync(fn)()
+}
+
+// DoAsync runs the fn on the pool, the returned func waits for the result and
+// must be called exactly once.
+func (p *Pool) DoAsync(fn func() error) func() error {
+	return p.submit(fn, false)
+}
+
+// GroupCommit returns true if the writes submitted by Commit are grouped
+func (p *Pool) GroupCommit() bool {
+	return p != nil && p.groupSync != nil
+}
+
+// Commit runs the write on the pool, and returns after the write is made
+// durable by the group sync. It must only be called if GroupCommit returns
+// true.
+func (p *Pool) Commit(write func() error) error {
+	return p.CommitAsync(write)()
+}
+
+// CommitAsync is the asynchronous version of Commit, the returned func waits
+// for the result and must be called exactly once.
+func (p *Pool) CommitAsync(write func() error) func() error {
+	if !p.GroupCommit() {
+		panic("pool does not group commit")
+	}
+	return p.submit(write, true)
+}
+
+func (p *Pool) submit(fn func() error, write bool) func() error {
+	if p == nil {
+		err := fn()
+		return func() error { return err }
+	}
+
+	p.mu.RLock()
+	if p.mu.closed {
+		p.mu.RUnlock()
+		err := fn()
+		if err == nil && write {
+			err = p.groupSync()
+		}
+		return func() error { return err }
+	}
+	req := requestPool.Get().(*request)
+	req.fn = fn
+	req.write = write
+	req.enqueue = time.Now()
+	p.queue <- req
+	metric.SetFsyncQueueMetric(p.name, int64(len(p.queue)))
+	p.mu.RUnlock()
+
+	return func() error {
+		err := <-req.done
+		req.fn = nil
+		requestPool.Put(req)
+		return err
+	}
+}
+
+// QueueLen returns the number of the pending requests
+func (p *Pool) QueueLen() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.queue)
+}
+
+// Close stops the workers after the pending requests are done
+func (p *Pool) Close() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	if p.mu.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.mu.closed = true
+	close(p.queue)
+	p.mu.Unlock()
+	p.wg.Wait()
+	metric.SetFsyncQueueMetric(p.name, 0)
+}
+
+func (p *Pool) run() {
+	defer p.wg.Done()
+	group := make([]*request, 0, p.maxGroupSize)
+	for req := range p.queue {
+		if !req.write {
+			p.runRequest(req)
+			continue
+		}
+
+		// group the writes waiting in the queue, the request which is not a
+		// write ends the group and runs after it
+		var next *request
+		group = append(group[:0], req)
+	grouping:
+		for len(group) < p.maxGroupSize {
+			select {
+			case v, ok := <-p.queue:
+				if !ok {
+					break grouping
+				}
+				if !v.write {
+					next = v
+					break grouping
+				}
+				group = append(group, v)
+			default:
+				break grouping
+			}
+		}
+		p.commitGroup(group)
+		if next != nil {
+			p.runRequest(next)
+		}
+	}
+}
+
+func (p *Pool) runRequest(req *request) {
+	metric.ObserveFsyncWaitDuration(p.name, req.enqueue)
+	start := time.Now()
+	err := req.fn()
+	metric.ObserveFsyncDuration(p.name, start)
+	req.done <- err
+}
+
+func (p *Pool) commitGroup(group []*request) {
+	errs := make([]error, len(group))
+	written := false
+	for i, req := range group {
+		metric.ObserveFsyncWaitDuration(p.name, req.enqueue)
+		errs[i] = req.fn()
+		written = written || errs[i] == nil
+	}
+
+	var err error
+	if written {
+		start := time.Now()
+		err = p.groupSync()
+		metric.ObserveFsyncDuration(p.name, start)
+		metric.ObserveFsyncGroupSize(p.name, len(group))
+	}
+	for i, req := range group {
+		if errs[i] != nil {
+			req.done <- errs[i]
+		} else {
+			req.done <- err
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2022 MatrixOrigin.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fsync
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matrixorigin/matrixcube/util/leaktest"
+)
+
+func TestPoolDo(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	p := NewPool(WAL, 2, 4)
+	assert.Equal(t, WAL, p.Name())
+	assert.NoError(t, p.Do(func() error { return nil }))
+	err := errors.New("sync failed")
+	assert.Equal(t, err, p.Do(func() error { return err }))
+	p.Close()
+	p.Close()
+
+	// runs on the calling goroutine after closed
+	n := 0
+	assert.NoError(t, p.Do(func() error { n++; return nil }))
+	assert.Equal(t, 1, n)
+
+	var nilPool *Pool
+	assert.NoError(t, nilPool.Do(func() error { n++; return nil }))
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 0, nilPool.QueueLen())
+	nilPool.Close()
+}
+
+func TestPoolBoundsConcurrency(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	p := NewPool(Data, 2, 0)
+	defer p.Close()
+
+	var running, max int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, p.Do(func() error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					v := atomic.LoadInt32(&max)
+					if n <= v || atomic.CompareAndSwapInt32(&max, v, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond * 5)
+				atomic.AddInt32(&running, -1)
+				return nil
+			}))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&max))
+}
+
+func TestPoolsAreIsolated(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	wal := NewPool(WAL, 1, 1)
+	defer wal.Close()
+	data := NewPool(Data, 1, 1)
+	defer data.Close()
+
+	// the data pool is blocked by a slow flush
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		assert.NoError(t, data.Do(func() error {
+			close(started)
+			<-release
+			return nil
+		}))
+	}()
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, wal.Do(func() error { return nil }))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "wal sync blocked by the data sync")
+	}
+	close(release)
+}
+
+func TestPoolGroupCommit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	syncs := 0
+	p := NewGroupCommitPool(WAL, 1, 16, 4, func() error {
+		syncs++
+		return nil
+	})
+	plain := NewPool(Data, 1, 0)
+	defer plain.Close()
+	assert.True(t, p.GroupCommit())
+	assert.False(t, plain.GroupCommit())
+
+	// block the worker until all requests are queued
+	blocked := make(chan struct{})
+	waitBlocked := p.DoAsync(func() error { <-blocked; return nil })
+
+	var order []int
+	err := errors.New("write failed")
+	var waits []func() error
+	for i := 0; i < 6; i++ {
+		i := i
+		waits = append(waits, p.CommitAsync(func() error {
+			order = append(order, i)
+			if i == 1 {
+				return err
+			}
+			return nil
+		}))
+	}
+	// the request which is not a write ends the group
+	waits = append(waits, p.DoAsync(func() error { order = append(order, 6); return nil }))
+	waits = append(waits, p.CommitAsync(func() error { order = append(order, 7); return nil }))
+	close(blocked)
+	assert.NoError(t, waitBlocked())
+	for i, wait := range waits {
+		if i == 1 {
+			assert.Equal(t, err, wait())
+		} else {
+			assert.NoError(t, wait())
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, order)
+	// [0, 4), [4, 6) and [7, 8) are grouped
+	assert.Equal(t, 3, syncs)
+
+	// the writes are synced on the calling goroutine after closed
+	p.Close()
+	assert.NoError(t, p.Commit(func() error { return nil }))
+	assert.Equal(t, 4, syncs)
+	assert.Panics(t, func() { _ = plain.Commit(func() error { return nil }) })
+}
@@ -126,3 +126,82 @@ func doAppendPrefix(key []byte, prefix byte, buffer *buf.ByteBuf) []byte {
 	}
 	return buffer.WrittenDataAfterMark().Data()
 }
+
+// MaxColumnFamilies the max number of the column families of a shard, including
+// the default column family
+const MaxColumnFamilies = 8
+
+// EncodeColumnFamilyKey moves the encoded data key or shard range bound into
+// the key space of the column family. The key spaces of the column families are
+// disjoint and ordered by the column family id, the default column family 0 is
+// the data key space. The keys outside the data key space are returned as is.
+func EncodeColumnFamilyKey(cf int, key []byte, buffer *buf.ByteBuf) []byte {
+	checkColumnFamily(cf)
+	if !inKeySpace(key, dataPrefix) {
+		return key
+	}
+	return doAppendPrefix(key[prefixLen:], key[0]+byte(cf), buffer)
+}
+
+// EncodeColumnFamilyKeyTo is similar to EncodeColumnFamilyKey, but encodes the
+// key to the dst, the dst can be the key itself.
+func EncodeColumnFamilyKeyTo(cf int, key, dst []byte) []byte {
+	checkColumnFamily(cf)
+	if cap(dst) < len(key) {
+		dst = make([]byte, len(key))
+	}
+	dst = dst[:len(key)]
+	copy(dst, key)
+	if inKeySpace(key, dataPrefix) {
+		dst[0] += byte(cf)
+	}
+	return dst
+}
+
+// DecodeColumnFamilyKey moves the key in the key space of the column family back
+// to the data key space. The keys outside the key space of the column family
+// are returned as is.
+func DecodeColumnFamilyKey(cf int, key []byte, buffer *buf.ByteBuf) []byte {
+	checkColumnFamily(cf)
+	if !inKeySpace(key, dataPrefix+byte(cf)) {
+		return key
+	}
+	return doAppendPrefix(key[prefixLen:], key[0]-byte(cf), buffer)
+}
+
+// DecodeColumnFamilyKeyTo is similar to DecodeColumnFamilyKey, but decodes the
+// key to the dst, the dst can be the key itself.
+func DecodeColumnFamilyKeyTo(cf int, key, dst []byte) []byte {
+	checkColumnFamily(cf)
+	if cap(dst) < len(key) {
+		dst = make([]byte, len(key))
+	}
+	dst = dst[:len(key)]
+	copy(dst, key)
+	if inKeySpace(key, dataPrefix+byte(cf)) {
+		dst[0] -= byte(cf)
+	}
+	return dst
+}
+
+// EncodeColumnFamilyShardRange returns the range of the shard in the key space
+// of the column family
+func EncodeColumnFamilyShardRange(cf int, start, end []byte) ([]byte, []byte) {
+	min, max := EncodeShardStart(start, nil), EncodeShardEnd(end, nil)
+	if cf == 0 {
+		return min, max
+	}
+	return EncodeColumnFamilyKey(cf, min, nil), EncodeColumnFamilyKey(cf, max, nil)
+}
+
+// inKeySpace returns true if the key is in the key space of the prefix, or it's
+// the end of the key space
+func inKeySpace(key []byte, prefix byte) bool {
+	return len(key) > 0 && (key[0] == prefix || (key[0] == prefix+1 && len(key) == prefixLen))
+}
+
+func checkColumnFamily(cf int) {
+	if cf <= 0 || cf >= MaxColumnFamilies {
+		panic("invalid column family")
+	}
+}
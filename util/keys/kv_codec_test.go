@@ -40,3 +40,35 @@ func TestNextKey(t *testing.T) {
 	assert.Equal(t, []byte("a\x00"), NextKey([]byte("a"), buffer))
 	assert.Equal(t, []byte("\x00"), NextKey(nil, buffer))
 }
+
+func TestEncodeColumnFamilyKey(t *testing.T) {
+	key := EncodeDataKey([]byte{1}, nil)
+	cfKey := EncodeColumnFamilyKey(2, key, nil)
+	assert.Equal(t, []byte{dataPrefix + 2, 1}, cfKey)
+	assert.Equal(t, key, DecodeColumnFamilyKey(2, cfKey, buf.NewByteBuf(12)))
+	assert.Equal(t, cfKey, EncodeColumnFamilyKeyTo(2, key, nil))
+	assert.Equal(t, cfKey, EncodeColumnFamilyKeyTo(2, key, key))
+	assert.Equal(t, cfKey, key)
+	assert.Equal(t, []byte{dataPrefix, 1}, DecodeColumnFamilyKeyTo(2, cfKey, nil))
+
+	// the keys outside the data key space are not changed
+	meta := EncodeShardMetadataKey([]byte{1}, nil)
+	assert.Equal(t, meta, EncodeColumnFamilyKey(2, meta, nil))
+	assert.Equal(t, []byte{dataPrefix + 1, 1}, DecodeColumnFamilyKey(2, []byte{dataPrefix + 1, 1}, nil))
+	assert.Panics(t, func() { EncodeColumnFamilyKey(0, key, nil) })
+	assert.Panics(t, func() { EncodeColumnFamilyKey(MaxColumnFamilies, key, nil) })
+}
+
+func TestEncodeColumnFamilyShardRange(t *testing.T) {
+	min, max := EncodeColumnFamilyShardRange(0, nil, nil)
+	assert.Equal(t, minStartKey, min)
+	assert.Equal(t, maxEndKey, max)
+
+	min, max = EncodeColumnFamilyShardRange(1, nil, nil)
+	assert.Equal(t, []byte{dataPrefix + 1}, min)
+	assert.Equal(t, []byte{dataPrefix + 2}, max)
+
+	min, max = EncodeColumnFamilyShardRange(MaxColumnFamilies-1, []byte{1}, []byte{2})
+	assert.Equal(t, []byte{dataPrefix + MaxColumnFamilies - 1, 1}, min)
+	assert.Equal(t, []byte{dataPrefix + MaxColumnFamilies - 1, 2}, max)
+}